@@ -0,0 +1,166 @@
+#!/usr/bin/env bash
+
+# Copyright 2023 Antrea Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# The script creates a ClusterSet with 2 Kind clusters and runs the multi-cluster
+# tests of the e2e test suite. The "leader" cluster is both the leader and a
+# member of the ClusterSet, and the "member" cluster is a member of the
+# ClusterSet. The Multi-cluster Gateway is enabled in both clusters.
+# The antrea/antrea-ubuntu:latest and antrea/antrea-mc-controller:latest images
+# must have been built before running the script.
+
+set -eo pipefail
+
+function echoerr {
+    >&2 echo "$@"
+}
+
+_usage="Usage: $0 [--run <regexp>] [--skip-cleanup] [--help|-h]
+        --run                         Run only tests matching the regexp (default is 'TestMulticluster').
+        --skip-cleanup                Do not delete the Kind clusters after running the tests.
+        --help, -h                    Print this message and exit.
+"
+
+function print_usage {
+    echoerr -n "$_usage"
+}
+
+THIS_DIR="$( cd "$( dirname "${BASH_SOURCE[0]}" )" >/dev/null 2>&1 && pwd )"
+ROOT_DIR=$THIS_DIR/../..
+TESTBED_CMD=$THIS_DIR/kind-setup.sh
+YML_CMD=$ROOT_DIR/hack/generate-manifest.sh
+MC_YML_CMD=$ROOT_DIR/multicluster/hack/generate-manifest.sh
+CLUSTERSET_INIT_DIR=$ROOT_DIR/multicluster/config/samples/clusterset_init
+
+run="TestMulticluster"
+skip_cleanup=false
+
+while [[ $# -gt 0 ]]
+do
+key="$1"
+
+case $key in
+    --run)
+    run="$2"
+    shift 2
+    ;;
+    --skip-cleanup)
+    skip_cleanup=true
+    shift
+    ;;
+    -h|--help)
+    print_usage
+    exit 0
+    ;;
+    *)    # unknown option
+    echoerr "Unknown option $1"
+    exit 1
+    ;;
+esac
+done
+
+CLUSTER_NAMES=("leader" "member")
+CLUSTER_IDS=("test-cluster-leader" "test-cluster-member")
+# The Pod CIDRs and Service CIDRs of the member clusters must not overlap.
+POD_CIDRS=("10.244.0.0/20" "10.244.16.0/20")
+SERVICE_CIDRS=("10.96.10.0/24" "10.96.20.0/24")
+KUBECONFIG_DIR=$(mktemp -d)
+
+IMAGES_LIST=("projects.registry.vmware.com/antrea/busybox" \
+             "projects.registry.vmware.com/antrea/nginx:1.21.6-alpine")
+for image in "${IMAGES_LIST[@]}"; do
+    for i in `seq 3`; do
+        docker pull $image && break
+        sleep 1
+    done
+done
+IMAGES_LIST+=("antrea/antrea-ubuntu:latest" "antrea/antrea-mc-controller:latest")
+printf -v IMAGES "%s " "${IMAGES_LIST[@]}"
+
+function cleanup {
+    if $skip_cleanup; then
+        echo "Kubeconfig files of the Kind clusters are saved in $KUBECONFIG_DIR"
+        return
+    fi
+    for name in "${CLUSTER_NAMES[@]}"; do
+        $TESTBED_CMD destroy $name
+    done
+    rm -rf $KUBECONFIG_DIR
+}
+trap cleanup EXIT
+
+# When running this script as part of a Github Action, we do *not* want to use
+# the pre-installed version of kustomize, as it is a snap and cannot access
+# /tmp. See:
+#  * https://github.com/actions/virtual-environments/issues/1514
+#  * https://forum.snapcraft.io/t/interfaces-allow-access-tmp-directory/5129
+unset KUSTOMIZE
+
+for i in "${!CLUSTER_NAMES[@]}"; do
+    name=${CLUSTER_NAMES[$i]}
+    echo "======== Creating Kind cluster $name ========"
+    timeout 600 $TESTBED_CMD create $name --num-workers 1 --pod-cidr ${POD_CIDRS[$i]} --service-cidr ${SERVICE_CIDRS[$i]} --images "$IMAGES"
+    kind get kubeconfig --name $name > $KUBECONFIG_DIR/$name
+
+    echo "======== Deploying Antrea in Kind cluster $name ========"
+    # The manifest is also saved on the control-plane Node, where the e2e framework expects it.
+    $YML_CMD --feature-gates Multicluster=true --extra-helm-values multicluster.enableGateway=true | docker exec -i $name-control-plane dd of=/root/antrea.yml
+    docker exec -i $name-control-plane kubectl apply -f /root/antrea.yml
+    kubectl --kubeconfig $KUBECONFIG_DIR/$name rollout status deployment/antrea-controller -n kube-system --timeout=5m
+    kubectl --kubeconfig $KUBECONFIG_DIR/$name rollout status daemonset/antrea-agent -n kube-system --timeout=5m
+
+    echo "======== Deploying Multi-cluster Controllers in Kind cluster $name ========"
+    if [[ "$name" == "leader" ]]; then
+        $MC_YML_CMD --global | kubectl --kubeconfig $KUBECONFIG_DIR/$name apply -f -
+        kubectl --kubeconfig $KUBECONFIG_DIR/$name create ns antrea-multicluster
+        $MC_YML_CMD --leader antrea-multicluster | kubectl --kubeconfig $KUBECONFIG_DIR/$name apply -f -
+        kubectl --kubeconfig $KUBECONFIG_DIR/$name rollout status deployment/antrea-mc-controller -n antrea-multicluster --timeout=5m
+    fi
+    $MC_YML_CMD --member | kubectl --kubeconfig $KUBECONFIG_DIR/$name apply -f -
+    kubectl --kubeconfig $KUBECONFIG_DIR/$name rollout status deployment/antrea-mc-controller -n kube-system --timeout=5m
+
+    # Only one Node of each cluster is the Multi-cluster Gateway.
+    kubectl --kubeconfig $KUBECONFIG_DIR/$name annotate node $name-worker multicluster.antrea.io/gateway=true
+done
+
+echo "======== Initializing ClusterSet ========"
+LEADER_KUBECONFIG=$KUBECONFIG_DIR/leader
+kubectl --kubeconfig $LEADER_KUBECONFIG apply -f $CLUSTERSET_INIT_DIR/leader-clusterset-template.yml
+kubectl --kubeconfig $LEADER_KUBECONFIG apply -f $CLUSTERSET_INIT_DIR/leader-access-token-template.yml
+# Wait for the token of the ServiceAccount to be populated.
+for i in `seq 30`; do
+    kubectl --kubeconfig $LEADER_KUBECONFIG get secret default-member-token -n antrea-multicluster -o jsonpath='{.data.token}' | grep -q . && break
+    sleep 1
+done
+kubectl --kubeconfig $LEADER_KUBECONFIG get secret default-member-token -n antrea-multicluster -o yaml | \
+    grep -w -e '^apiVersion' -e '^data' -e '^metadata' -e '^ *name:' -e '^kind' -e '  ca.crt' -e '  token:' -e '^type' -e '  namespace' | \
+    sed -e 's/kubernetes.io\/service-account-token/Opaque/g' -e 's/antrea-multicluster/kube-system/g' > $KUBECONFIG_DIR/default-member-token.yml
+LEADER_APISERVER_IP=$(docker inspect -f '{{range .NetworkSettings.Networks}}{{.IPAddress}}{{end}}' leader-control-plane)
+
+for i in "${!CLUSTER_NAMES[@]}"; do
+    name=${CLUSTER_NAMES[$i]}
+    echo "======== Joining ClusterSet with Kind cluster $name as ${CLUSTER_IDS[$i]} ========"
+    kubectl --kubeconfig $KUBECONFIG_DIR/$name apply -f $KUBECONFIG_DIR/default-member-token.yml
+    sed -e "s/test-cluster-member/${CLUSTER_IDS[$i]}/g" -e "s/<LEADER_APISERVER_IP>/$LEADER_APISERVER_IP/g" $CLUSTERSET_INIT_DIR/member-clusterset-template.yml | \
+        kubectl --kubeconfig $KUBECONFIG_DIR/$name apply -f -
+done
+
+echo "======== Running multi-cluster e2e tests ========"
+# The e2e framework uses the current context of the default Kubeconfig file, which must select the
+# leader cluster, while the member cluster is provided as the peer cluster.
+kubectl config use-context kind-leader
+rc=0
+go test -v -timeout=30m -run=$run antrea.io/antrea/test/e2e -provider=kind -mc.peerKubeconfig=$KUBECONFIG_DIR/member --logs-export-dir=$ANTREA_LOG_DIR || rc=$?
+exit $rc
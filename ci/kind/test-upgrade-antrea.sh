@@ -23,6 +23,7 @@ function echoerr {
 FROM_TAG=
 FROM_VERSION_N_MINUS=
 CONTROLLER_ONLY=false
+DOWNGRADE=false
 
 _usage="Usage: $0 [--from-tag <TAG>] [--from-version-n-minus <COUNT>]
 Perform some basic tests to make sure that Antrea can be upgraded from the provided version to the
@@ -36,6 +37,8 @@ provided.
                                         script is run from a release branch, it will only consider
                                         releases which predate that release branch.
         --controller-only               Update antrea-controller only when upgrading.
+        --downgrade                     After upgrading, downgrade back to the original version,
+                                        while continuously checking datapath connectivity.
         --help, -h                      Print this message and exit
 "
 
@@ -67,6 +70,10 @@ case $key in
     CONTROLLER_ONLY=true
     shift
     ;;
+    --downgrade)
+    DOWNGRADE=true
+    shift
+    ;;
     -h|--help)
     print_usage
     exit 0
@@ -186,7 +193,11 @@ popd
 rm -rf $TMP_DIR
 
 rc=0
-go test -v -run=TestUpgrade antrea.io/antrea/test/e2e -provider=kind -upgrade.toYML=antrea-new.yml --upgrade.controllerOnly=$CONTROLLER_ONLY --logs-export-dir=$ANTREA_LOG_DIR || rc=$?
+if $DOWNGRADE; then
+    go test -v -timeout=30m -run=TestUpgradeDowngradeDatapathContinuity antrea.io/antrea/test/e2e -provider=kind -upgrade.toYML=antrea-new.yml -upgrade.fromYML=antrea.yml --upgrade.controllerOnly=$CONTROLLER_ONLY --logs-export-dir=$ANTREA_LOG_DIR || rc=$?
+else
+    go test -v -run=TestUpgrade antrea.io/antrea/test/e2e -provider=kind -upgrade.toYML=antrea-new.yml --upgrade.controllerOnly=$CONTROLLER_ONLY --logs-export-dir=$ANTREA_LOG_DIR || rc=$?
+fi
 
 $THIS_DIR/kind-setup.sh destroy kind
 
@@ -16,53 +16,79 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
+	"path/filepath"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/server/dynamiccertificates"
 	"k8s.io/apiserver/pkg/server/options"
 	"k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/certificate"
+	"k8s.io/component-base/metrics/legacyregistry"
 	"k8s.io/klog/v2"
 
 	mcinformers "antrea.io/antrea/multicluster/pkg/client/informers/externalversions"
 	"antrea.io/antrea/pkg/agent"
+	"antrea.io/antrea/pkg/agent/afxdp"
 	"antrea.io/antrea/pkg/agent/apiserver"
 	"antrea.io/antrea/pkg/agent/cniserver"
 	"antrea.io/antrea/pkg/agent/cniserver/ipam"
 	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/configwatcher"
 	"antrea.io/antrea/pkg/agent/controller/egress"
+	"antrea.io/antrea/pkg/agent/controller/egressdefaultdeny"
+	"antrea.io/antrea/pkg/agent/controller/gatewayproxy"
 	"antrea.io/antrea/pkg/agent/controller/ipseccertificate"
+	"antrea.io/antrea/pkg/agent/controller/neighborspoofguard"
 	"antrea.io/antrea/pkg/agent/controller/networkpolicy"
 	"antrea.io/antrea/pkg/agent/controller/noderoute"
 	"antrea.io/antrea/pkg/agent/controller/serviceexternalip"
 	"antrea.io/antrea/pkg/agent/controller/traceflow"
 	"antrea.io/antrea/pkg/agent/controller/trafficcontrol"
+	"antrea.io/antrea/pkg/agent/controller/trafficmirror"
+	"antrea.io/antrea/pkg/agent/cpuaccounting"
 	"antrea.io/antrea/pkg/agent/externalnode"
 	"antrea.io/antrea/pkg/agent/flowexporter"
+	"antrea.io/antrea/pkg/agent/flowexporter/connections"
 	"antrea.io/antrea/pkg/agent/flowexporter/exporter"
+	"antrea.io/antrea/pkg/agent/flowgc"
+	"antrea.io/antrea/pkg/agent/flowscheduler"
 	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/kubeproxycheck"
 	"antrea.io/antrea/pkg/agent/memberlist"
 	"antrea.io/antrea/pkg/agent/metrics"
+	"antrea.io/antrea/pkg/agent/metrics/otlp"
+	"antrea.io/antrea/pkg/agent/monitortool"
 	"antrea.io/antrea/pkg/agent/multicast"
 	mcroute "antrea.io/antrea/pkg/agent/multicluster"
 	npl "antrea.io/antrea/pkg/agent/nodeportlocal"
 	"antrea.io/antrea/pkg/agent/openflow"
+	"antrea.io/antrea/pkg/agent/ovsoffload"
 	"antrea.io/antrea/pkg/agent/proxy"
 	proxytypes "antrea.io/antrea/pkg/agent/proxy/types"
 	"antrea.io/antrea/pkg/agent/querier"
 	"antrea.io/antrea/pkg/agent/route"
+	"antrea.io/antrea/pkg/agent/secondarynetwork"
 	"antrea.io/antrea/pkg/agent/secondarynetwork/cnipodcache"
-	"antrea.io/antrea/pkg/agent/secondarynetwork/podwatch"
 	"antrea.io/antrea/pkg/agent/servicecidr"
 	"antrea.io/antrea/pkg/agent/stats"
 	support "antrea.io/antrea/pkg/agent/supportbundlecollection"
 	agenttypes "antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/agent/watchdog"
+	antreaapis "antrea.io/antrea/pkg/apis"
 	"antrea.io/antrea/pkg/apis/controlplane"
 	crdinformers "antrea.io/antrea/pkg/client/informers/externalversions"
 	crdv1alpha1informers "antrea.io/antrea/pkg/client/informers/externalversions/crd/v1alpha1"
@@ -71,11 +97,15 @@ import (
 	"antrea.io/antrea/pkg/log"
 	"antrea.io/antrea/pkg/monitor"
 	ofconfig "antrea.io/antrea/pkg/ovs/openflow"
+	"antrea.io/antrea/pkg/ovs/openflow/connrelay"
 	"antrea.io/antrea/pkg/ovs/ovsconfig"
 	"antrea.io/antrea/pkg/ovs/ovsctl"
+	antreaquerier "antrea.io/antrea/pkg/querier"
 	"antrea.io/antrea/pkg/signals"
 	"antrea.io/antrea/pkg/util/channel"
+	"antrea.io/antrea/pkg/util/env"
 	"antrea.io/antrea/pkg/util/k8s"
+	"antrea.io/antrea/pkg/util/runtime"
 	"antrea.io/antrea/pkg/version"
 )
 
@@ -93,10 +123,18 @@ var excludeNodePortDevices = []string{"antrea-egress0", "antrea-ingress0", "kube
 
 var ipv4Localhost = net.ParseIP("127.0.0.1")
 
+// ovsConnRelaySockDir is the directory of the unix socket through which the OpenFlow connection to a remote OVS
+// instance is relayed.
+const ovsConnRelaySockDir = "/var/run/antrea"
+
 // run starts Antrea agent with the given options and waits for termination signal.
 func run(o *Options) error {
 	klog.Infof("Starting Antrea agent (version %s)", version.GetFullVersion())
 
+	if err := setLogVerbosity(o.config.LogVerbosity); err != nil {
+		return fmt.Errorf("error setting log verbosity: %v", err)
+	}
+
 	// Create K8s Clientset, CRD Clientset, Multicluster CRD Clientset and SharedInformerFactory for the given config.
 	k8sClient, _, crdClient, _, mcClient, err := k8s.CreateClients(o.config.ClientConnection, o.config.KubeAPIServerOverride)
 	if err != nil {
@@ -110,6 +148,7 @@ func run(o *Options) error {
 	egressInformer := crdInformerFactory.Crd().V1alpha2().Egresses()
 	externalIPPoolInformer := crdInformerFactory.Crd().V1alpha2().ExternalIPPools()
 	trafficControlInformer := crdInformerFactory.Crd().V1alpha2().TrafficControls()
+	trafficMirrorInformer := crdInformerFactory.Crd().V1alpha2().TrafficMirrors()
 	nodeInformer := informerFactory.Core().V1().Nodes()
 	serviceInformer := informerFactory.Core().V1().Services()
 	endpointsInformer := informerFactory.Core().V1().Endpoints()
@@ -121,6 +160,7 @@ func run(o *Options) error {
 	// Register Antrea Agent metrics if EnablePrometheusMetrics is set
 	if *o.config.EnablePrometheusMetrics {
 		metrics.InitializePrometheusMetrics()
+		metrics.InitializeCPUAccountingMetrics()
 	}
 
 	// Create ovsdb and openflow clients.
@@ -136,6 +176,7 @@ func run(o *Options) error {
 	enableBridgingMode := enableAntreaIPAM && o.config.EnableBridgingMode
 	enableNodePortLocal := features.DefaultFeatureGate.Enabled(features.NodePortLocal) && o.config.NodePortLocal.Enable
 	l7NetworkPolicyEnabled := features.DefaultFeatureGate.Enabled(features.L7NetworkPolicy)
+	nodeNetworkPolicyEnabled := features.DefaultFeatureGate.Enabled(features.NodeNetworkPolicy)
 	enableMulticlusterGW := features.DefaultFeatureGate.Enabled(features.Multicluster) && o.config.Multicluster.EnableGateway
 	enableMulticlusterNP := features.DefaultFeatureGate.Enabled(features.Multicluster) && o.config.Multicluster.EnableStretchedNetworkPolicy
 
@@ -144,7 +185,28 @@ func run(o *Options) error {
 	ovsDatapathType := ovsconfig.OVSDatapathType(o.config.OVSDatapathType)
 	ovsBridgeClient := ovsconfig.NewOVSBridge(o.config.OVSBridge, ovsDatapathType, ovsdbConnection)
 	ovsCtlClient := ovsctl.NewClient(o.config.OVSBridge)
+	var physicalBridgeClient ovsconfig.OVSBridgeClient
+	if o.config.OVSPhysicalBridge != "" {
+		physicalBridgeClient = ovsconfig.NewOVSBridge(o.config.OVSPhysicalBridge, ovsDatapathType, ovsdbConnection)
+	}
 	ovsBridgeMgmtAddr := ofconfig.GetMgmtAddress(o.config.OVSRunDir, o.config.OVSBridge)
+	var ovsConnRelay *connrelay.ConnRelay
+	if connConfig := o.config.OVSOpenFlowConnection; connConfig.Address != "" {
+		var tlsConfig *tls.Config
+		if network, _, _ := connrelay.ParseAddress(connConfig.Address); network == connrelay.NetworkSSL {
+			tlsConfig, err = connrelay.NewTLSConfig(connConfig.CACertFile, connConfig.CertFile, connConfig.KeyFile)
+			if err != nil {
+				return fmt.Errorf("error creating TLS config for OVS connection: %v", err)
+			}
+		}
+		// The OpenFlow client only supports connecting to the OVS bridge via unix socket, so the connection to the
+		// remote OVS instance is relayed through a local unix socket.
+		ovsConnRelay, err = connrelay.NewConnRelay(filepath.Join(ovsConnRelaySockDir, o.config.OVSBridge+".mgmt"), connConfig.Address, tlsConfig)
+		if err != nil {
+			return fmt.Errorf("error creating OVS connection relay: %v", err)
+		}
+		ovsBridgeMgmtAddr = ovsConnRelay.SockPath()
+	}
 	multicastEnabled := features.DefaultFeatureGate.Enabled(features.Multicast) && o.config.Multicast.Enable
 	ofClient := openflow.NewClient(o.config.OVSBridge, ovsBridgeMgmtAddr,
 		features.DefaultFeatureGate.Enabled(features.AntreaProxy),
@@ -155,8 +217,10 @@ func run(o *Options) error {
 		o.config.AntreaProxy.ProxyAll,
 		connectUplinkToBridge,
 		multicastEnabled,
-		features.DefaultFeatureGate.Enabled(features.TrafficControl),
+		// TrafficMirror reuses the pipeline of TrafficControl.
+		features.DefaultFeatureGate.Enabled(features.TrafficControl) || features.DefaultFeatureGate.Enabled(features.TrafficMirror),
 		enableMulticlusterGW,
+		features.DefaultFeatureGate.Enabled(features.PodBandwidthMeter),
 	)
 
 	var serviceCIDRNet *net.IPNet
@@ -176,6 +240,7 @@ func run(o *Options) error {
 		encryptionMode = config.TrafficEncryptionModeIPSec
 	}
 	_, ipsecAuthenticationMode := config.GetIPsecAuthenticationModeFromStr(o.config.IPsec.AuthenticationMode)
+	_, firewallBackend := config.GetFirewallBackendFromStr(o.config.FirewallBackend)
 
 	networkConfig := &config.NetworkConfig{
 		TunnelType:            ovsconfig.TunnelType(o.config.TunnelType),
@@ -189,6 +254,12 @@ func run(o *Options) error {
 			AuthenticationMode: ipsecAuthenticationMode,
 		},
 		EnableMulticlusterGW: enableMulticlusterGW,
+		FirewallBackend:      firewallBackend,
+		DisableTTLDecrement:  !*o.config.TTL.Decrement,
+		SendICMPTimeExceeded: o.config.TTL.SendTimeExceeded,
+
+		EnableRPFilterExceptions:    o.config.RPFilterExceptions.Enable,
+		RPFilterExceptionInterfaces: o.config.RPFilterExceptions.ExtraInterfaces,
 	}
 
 	wireguardConfig := &config.WireGuardConfig{
@@ -200,9 +271,11 @@ func run(o *Options) error {
 		exceptCIDRs = append(exceptCIDRs, *exceptCIDR)
 	}
 	egressConfig := &config.EgressConfig{
-		ExceptCIDRs: exceptCIDRs,
+		ExceptCIDRs:              exceptCIDRs,
+		EnableDefaultDeny:        o.config.Egress.DefaultDenyExternal.Enable,
+		DefaultDenyAllNamespaces: o.config.Egress.DefaultDenyExternal.AllNamespaces,
 	}
-	routeClient, err := route.NewClient(networkConfig, o.config.NoSNAT, o.config.AntreaProxy.ProxyAll, connectUplinkToBridge, multicastEnabled, serviceCIDRProvider)
+	routeClient, err := route.NewClient(networkConfig, o.config.NoSNAT, o.config.AntreaProxy.ProxyAll, o.config.AntreaProxy.HostClusterIPAccess, connectUplinkToBridge, multicastEnabled, serviceCIDRProvider)
 	if err != nil {
 		return fmt.Errorf("error creating route client: %v", err)
 	}
@@ -217,6 +290,12 @@ func run(o *Options) error {
 	// cause the stopCh channel to be closed; if another signal is received before the program
 	// exits, we will force exit.
 	stopCh := signals.RegisterSignalHandlers()
+	// The relay must be ready before the OpenFlow client connects to the OVS bridge during initialization.
+	if ovsConnRelay != nil {
+		if err := ovsConnRelay.Start(stopCh); err != nil {
+			return fmt.Errorf("error starting OVS connection relay: %v", err)
+		}
+	}
 	// Generate a context for functions which require one (instead of stopCh).
 	// We cancel the context when the function returns, which in the normal case will be when
 	// stopCh is closed.
@@ -229,7 +308,7 @@ func run(o *Options) error {
 	// Get all available NodePort addresses.
 	var nodePortAddressesIPv4, nodePortAddressesIPv6 []net.IP
 	if o.config.AntreaProxy.ProxyAll {
-		nodePortAddressesIPv4, nodePortAddressesIPv6, err = getAvailableNodePortAddresses(o.config.AntreaProxy.NodePortAddresses, append(excludeNodePortDevices, o.config.HostGateway))
+		nodePortAddressesIPv4, nodePortAddressesIPv6, err = getAvailableNodePortAddresses(o.config.AntreaProxy.NodePortAddresses, o.config.AntreaProxy.NodePortInterfaces, append(excludeNodePortDevices, o.config.HostGateway))
 		if err != nil {
 			return fmt.Errorf("getting available NodePort IP addresses failed: %v", err)
 		}
@@ -239,6 +318,9 @@ func run(o *Options) error {
 		ServiceCIDRv6:         serviceCIDRNetv6,
 		NodePortAddressesIPv4: nodePortAddressesIPv4,
 		NodePortAddressesIPv6: nodePortAddressesIPv6,
+		// It's only set when AntreaProxy is enabled.
+		RejectServicesWithoutEndpoints: o.config.AntreaProxy.RejectServicesWithoutEndpoints != nil && *o.config.AntreaProxy.RejectServicesWithoutEndpoints,
+		EnableLoadBalancerModeDSR:      features.DefaultFeatureGate.Enabled(features.LoadBalancerModeDSR),
 	}
 
 	// Initialize agent and node network.
@@ -246,6 +328,7 @@ func run(o *Options) error {
 		k8sClient,
 		crdClient,
 		ovsBridgeClient,
+		physicalBridgeClient,
 		ovsCtlClient,
 		ofClient,
 		routeClient,
@@ -269,6 +352,10 @@ func run(o *Options) error {
 	}
 	nodeConfig := agentInitializer.GetNodeConfig()
 
+	if o.nodeType == config.K8sNode && networkConfig.SendICMPTimeExceeded {
+		ofClient.RegisterPacketInHandler(uint8(openflow.PacketInCategoryTTLExpired), openflow.NewTTLExpiredPacketInHandler(ofClient, nodeConfig))
+	}
+
 	var ipsecCertController *ipseccertificate.Controller
 
 	if networkConfig.TrafficEncryptionMode == config.TrafficEncryptionModeIPSec &&
@@ -307,12 +394,17 @@ func run(o *Options) error {
 		externalEntityUpdateChannel = channel.NewSubscribableChannel("ExternalEntityUpdate", 100)
 	}
 
+	// The FlowExporter needs the labels of the local Pods when its export filter has a Pod selector.
+	enableFlowExportPodSelector := features.DefaultFeatureGate.Enabled(features.FlowExporter) && o.config.FlowExporter.Enable &&
+		o.config.FlowExporter.ExportFilter.PodSelector != ""
 	// Initialize localPodInformer for NPLAgent, AntreaIPAMController,
-	// StretchedNetworkPolicyController, and secondary network controller.
+	// StretchedNetworkPolicyController, secondary network controller, FlowExporter, and AntreaProxy NUMA affinity.
+	enableNUMALocalEndpoints := features.DefaultFeatureGate.Enabled(features.AntreaProxy) && o.config.AntreaProxy.PreferNUMALocalEndpoints
 	var localPodInformer cache.SharedIndexInformer
-	if enableNodePortLocal || enableBridgingMode || enableMulticlusterNP ||
+	if enableNodePortLocal || enableBridgingMode || enableMulticlusterNP || enableFlowExportPodSelector || enableNUMALocalEndpoints ||
 		features.DefaultFeatureGate.Enabled(features.SecondaryNetwork) ||
-		features.DefaultFeatureGate.Enabled(features.TrafficControl) {
+		features.DefaultFeatureGate.Enabled(features.TrafficControl) ||
+		features.DefaultFeatureGate.Enabled(features.TrafficMirror) {
 		listOptions := func(options *metav1.ListOptions) {
 			options.FieldSelector = fields.OneTermEqualSelector("spec.nodeName", nodeConfig.Name).String()
 		}
@@ -331,6 +423,7 @@ func run(o *Options) error {
 	var mcInformerFactory mcinformers.SharedInformerFactory
 	var mcInformerFactoryWithNamespaceOption mcinformers.SharedInformerFactory
 
+	groupIDAllocator := openflow.NewGroupAllocator()
 	if enableMulticlusterGW {
 		if !networkConfig.IPv4Enabled {
 			return fmt.Errorf("Antrea Mutli-cluster doesn't not support IPv6 only cluster")
@@ -347,6 +440,7 @@ func run(o *Options) error {
 			gwInformer,
 			ciImportInformer,
 			ofClient,
+			groupIDAllocator,
 			nodeConfig,
 			networkConfig,
 			routeClient,
@@ -377,21 +471,37 @@ func run(o *Options) error {
 	v4Enabled := networkConfig.IPv4Enabled
 	v6Enabled := networkConfig.IPv6Enabled
 
+	// reconcileScheduler shares the OVS programming bandwidth among features. A nil scheduler means
+	// reconcile operations are not throttled.
+	var reconcileScheduler *flowscheduler.Scheduler
+	if o.config.ReconcileScheduler.Enable {
+		if *o.config.EnablePrometheusMetrics {
+			metrics.InitializeReconcileSchedulerMetrics()
+		}
+		reconcileScheduler = flowscheduler.NewScheduler(o.reconcileSchedulerWeights, o.reconcileSchedulerStarvationTimeout)
+	}
+
 	var groupCounters []proxytypes.GroupCounter
 	groupIDUpdates := make(chan string, 100)
-	groupIDAllocator := openflow.NewGroupAllocator()
 	var v4GroupCounter, v6GroupCounter proxytypes.GroupCounter
 	if v4Enabled {
-		v4GroupCounter = proxytypes.NewGroupCounter(groupIDAllocator, groupIDUpdates)
+		v4GroupCounter = proxytypes.NewGroupCounter(groupIDAllocator, groupIDUpdates, false)
 		groupCounters = append(groupCounters, v4GroupCounter)
 	}
 	if v6Enabled {
-		v6GroupCounter = proxytypes.NewGroupCounter(groupIDAllocator, groupIDUpdates)
+		v6GroupCounter = proxytypes.NewGroupCounter(groupIDAllocator, groupIDUpdates, true)
 		groupCounters = append(groupCounters, v6GroupCounter)
 	}
 
 	var proxier proxy.Proxier
 	if features.DefaultFeatureGate.Enabled(features.AntreaProxy) {
+		var numaAffinity *proxy.NUMAAffinity
+		if enableNUMALocalEndpoints {
+			numaAffinity, err = proxy.NewNUMAAffinity(nodeConfig.NodeTransportInterfaceName, ifaceStore, corelisters.NewPodLister(localPodInformer.GetIndexer()))
+			if err != nil {
+				klog.ErrorS(err, "Failed to determine the NUMA node of the transport interface, NUMA-local Endpoints will not be preferred", "interface", nodeConfig.NodeTransportInterfaceName)
+			}
+		}
 		proxier, err = proxy.NewProxier(nodeConfig.Name,
 			k8sClient,
 			ofClient,
@@ -401,15 +511,54 @@ func run(o *Options) error {
 			nodePortAddressesIPv4,
 			nodePortAddressesIPv6,
 			o.config.AntreaProxy,
+			o.endpointDrainingTimeout,
 			v4GroupCounter,
 			v6GroupCounter,
 			enableMulticlusterGW,
-			informerFactory)
+			informerFactory,
+			reconcileScheduler,
+			numaAffinity)
 		if err != nil {
 			return fmt.Errorf("error when creating proxier: %v", err)
 		}
 	}
 
+	var skipServicesController *proxy.SkipServicesController
+	if proxier != nil && o.config.AntreaProxy.SkipServicesConfigMap != "" {
+		skipServicesController = proxy.NewSkipServicesController(
+			k8sClient,
+			proxier,
+			o.config.AntreaProxy.SkipServices,
+			env.GetAntreaNamespace(),
+			o.config.AntreaProxy.SkipServicesConfigMap)
+	}
+
+	// The NodePort addresses are resolved periodically to pick up the changes of the Node's addresses.
+	var nodePortAddressesSyncer *nodePortAddressesSyncer
+	if proxier != nil && o.config.AntreaProxy.ProxyAll {
+		nodePortAddressesSyncer = newNodePortAddressesSyncer(
+			o.config.AntreaProxy.NodePortAddresses,
+			o.config.AntreaProxy.NodePortInterfaces,
+			append(excludeNodePortDevices, o.config.HostGateway),
+			nodePortAddressesIPv4,
+			nodePortAddressesIPv6,
+			func(nodePortAddressesIPv4, nodePortAddressesIPv6 []net.IP) error {
+				if err := ofClient.UpdateNodePortAddresses(nodePortAddressesIPv4, nodePortAddressesIPv6); err != nil {
+					return err
+				}
+				return proxier.UpdateNodePortAddresses(nodePortAddressesIPv4, nodePortAddressesIPv6)
+			})
+	}
+
+	// kube-proxy running with a configuration conflicting with proxyAll leads to Service traffic being blackholed
+	// silently, so the Node is checked periodically and the result is reported in the AntreaAgentInfo.
+	var kubeProxyChecker *kubeproxycheck.Checker
+	var kubeProxyQuerier kubeproxycheck.Querier
+	if proxier != nil && o.config.AntreaProxy.ProxyAll && !runtime.IsWindowsPlatform() {
+		kubeProxyChecker = kubeproxycheck.NewChecker(v4Enabled, v6Enabled, o.config.AntreaProxy.InstallKubeProxyCompatibilityRules)
+		kubeProxyQuerier = kubeProxyChecker
+	}
+
 	// We set flow poll interval as the time interval for rule deletion in the async
 	// rule cache, which is implemented as part of the idAllocator. This is to preserve
 	// the rule info for populating NetworkPolicy fields in the Flow Exporter even
@@ -443,10 +592,13 @@ func run(o *Options) error {
 		groupIDUpdates,
 		antreaPolicyEnabled,
 		l7NetworkPolicyEnabled,
+		nodeNetworkPolicyEnabled,
 		antreaProxyEnabled,
 		statusManagerEnabled,
 		multicastEnabled,
+		ofClient.IsNetworkPolicyRateLimitSupported(),
 		loggingEnabled,
+		features.DefaultFeatureGate.Enabled(features.ControlplaneGRPC),
 		asyncRuleDeleteInterval,
 		o.dnsServerOverride,
 		o.nodeType,
@@ -459,6 +611,23 @@ func run(o *Options) error {
 	if err != nil {
 		return fmt.Errorf("error creating new NetworkPolicy controller: %v", err)
 	}
+	networkPolicyController.SetReconcileScheduler(reconcileScheduler)
+	networkPolicyController.SetAuditLoggingConfig(toAuditLoggingConfig(o.config.AuditLogging))
+	if o.config.AuditLogging.EventSeverityThreshold != "" || l7NetworkPolicyEnabled {
+		eventBroadcaster := record.NewBroadcaster()
+		eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: k8sClient.CoreV1().Events("")})
+		defer eventBroadcaster.Shutdown()
+		recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "antrea-agent", Host: nodeConfig.Name})
+		if o.config.AuditLogging.EventSeverityThreshold != "" {
+			networkPolicyController.SetDenialEventRecorder(recorder, o.config.AuditLogging.EventSeverityThreshold)
+		}
+		if l7NetworkPolicyEnabled {
+			networkPolicyController.SetL7EventRecorder(recorder)
+		}
+	}
+	if l7NetworkPolicyEnabled && *o.config.EnablePrometheusMetrics {
+		metrics.InitializeL7EngineMetrics()
+	}
 
 	var egressController *egress.EgressController
 
@@ -493,6 +662,16 @@ func run(o *Options) error {
 		if err != nil {
 			return fmt.Errorf("error creating new Egress controller: %v", err)
 		}
+		egressController.SetReconcileScheduler(reconcileScheduler)
+	}
+	// The Namespace annotation is not watched when Egress default deny applies to all Namespaces.
+	var egressDefaultDenyController *egressdefaultdeny.Controller
+	if o.enableEgress && o.config.Egress.DefaultDenyExternal.Enable && !o.config.Egress.DefaultDenyExternal.AllNamespaces {
+		egressDefaultDenyController = egressdefaultdeny.NewEgressDefaultDenyController(ofClient, ifaceStore, namespaceInformer, podUpdateChannel)
+	}
+	var neighborSpoofGuardController *neighborspoofguard.Controller
+	if features.DefaultFeatureGate.Enabled(features.NeighborSpoofGuard) {
+		neighborSpoofGuardController = neighborspoofguard.NewNeighborSpoofGuardController(ofClient, ifaceStore, namespaceInformer, podUpdateChannel)
 	}
 	if features.DefaultFeatureGate.Enabled(features.ServiceExternalIP) {
 		externalIPController, err = serviceexternalip.NewServiceExternalIPController(
@@ -581,7 +760,7 @@ func run(o *Options) error {
 		return err
 	}
 	// ConnectUplinkToOVSBridge must be run immediately after FlowRestoreComplete
-	if connectUplinkToBridge {
+	if connectUplinkToBridge || physicalBridgeClient != nil {
 		// Restore network config before shutdown. ovsdbConnection must be alive when restore.
 		defer agentInitializer.RestoreOVSBridge()
 		if err := agentInitializer.ConnectUplinkToOVSBridge(); err != nil {
@@ -595,14 +774,25 @@ func run(o *Options) error {
 
 	var flowExporter *exporter.FlowExporter
 	if features.DefaultFeatureGate.Enabled(features.FlowExporter) && o.config.FlowExporter.Enable {
+		var podLister corelisters.PodLister
+		if enableFlowExportPodSelector {
+			podLister = corelisters.NewPodLister(localPodInformer.GetIndexer())
+		}
+		exportFilter, err := flowexporter.NewExportFilter(o.config.FlowExporter.ExportFilter, namespaceInformer.Lister(), podLister)
+		if err != nil {
+			return fmt.Errorf("error when creating flow export filter: %w", err)
+		}
 		flowExporterOptions := &flowexporter.FlowExporterOptions{
 			FlowCollectorAddr:      o.flowCollectorAddr,
 			FlowCollectorProto:     o.flowCollectorProto,
+			RecordFormat:           o.config.FlowExporter.RecordFormat,
 			ActiveFlowTimeout:      o.activeFlowTimeout,
 			IdleFlowTimeout:        o.idleFlowTimeout,
 			StaleConnectionTimeout: o.staleConnectionTimeout,
 			PollInterval:           o.pollInterval,
-			ConnectUplinkToBridge:  connectUplinkToBridge}
+			ConnectUplinkToBridge:  connectUplinkToBridge,
+			ExportFilter:           exportFilter,
+			EstimateTCPRTT:         o.config.FlowExporter.EstimateTCPRTT}
 		flowExporter, err = exporter.NewFlowExporter(
 			ifaceStore,
 			proxier,
@@ -624,9 +814,119 @@ func run(o *Options) error {
 		}
 		networkPolicyController.SetDenyConnStore(flowExporter.GetDenyConnStore())
 	}
+	// The connection querier of the agent API reuses the connection store of the FlowExporter when it is enabled, so
+	// that the connections share the same K8s metadata.
+	var connectionQuerier *connections.ConntrackConnectionStore
+	if flowExporter != nil {
+		connectionQuerier = flowExporter.GetConntrackConnStore()
+	} else {
+		connTrackDumper := connections.InitializeConnTrackDumper(nodeConfig, serviceCIDRNet, serviceCIDRNetv6, ovsDatapathType, features.DefaultFeatureGate.Enabled(features.AntreaProxy))
+		connectionQuerier = connections.NewConntrackConnectionStore(
+			connTrackDumper,
+			v4Enabled,
+			v6Enabled,
+			networkPolicyController,
+			ifaceStore,
+			proxier,
+			&flowexporter.FlowExporterOptions{ConnectUplinkToBridge: connectUplinkToBridge})
+	}
+
+	if o.config.Watchdog.Enable {
+		if *o.config.EnablePrometheusMetrics {
+			metrics.InitializeWatchdogMetrics()
+		}
+		eventBroadcaster := record.NewBroadcaster()
+		eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: k8sClient.CoreV1().Events("")})
+		defer eventBroadcaster.Shutdown()
+		recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "antrea-agent", Host: nodeConfig.Name})
+		getFlowCount := func() int {
+			var count int
+			for _, status := range ofClient.GetFlowTableStatus() {
+				count += int(status.FlowCount)
+			}
+			return count
+		}
+		agentWatchdog := watchdog.NewWatchdog(nodeConfig.Name, o.watchdogConfig, getFlowCount, recorder)
+		agentWatchdog.AddQueue("networkpolicy", networkPolicyController.GetRuleQueueLength)
+		if flowExporter != nil {
+			agentWatchdog.AddDegradationHandler(flowExporter.GetConntrackConnStore().SetDegraded)
+		}
+		go agentWatchdog.Run(stopCh)
+	}
+
+	if o.config.FlowGC.Enable {
+		if *o.config.EnablePrometheusMetrics {
+			metrics.InitializeFlowGCMetrics()
+		}
+		flowGC := flowgc.NewGarbageCollector(o.flowGCConfig, ofClient)
+		go flowGC.Run(stopCh)
+	}
+
+	// The OpenFlow capacity is only reported in metrics, hence there is no need to monitor it when metrics are
+	// disabled.
+	if *o.config.EnablePrometheusMetrics {
+		go ofClient.MonitorCapacity(o.ovsSoftLimits, stopCh)
+	}
+
+	// The per-feature CPU estimates are only reported in metrics. The work of the features is tagged with pprof labels
+	// regardless, so that CPU profiles can be filtered by feature.
+	if *o.config.EnablePrometheusMetrics {
+		go cpuaccounting.NewEstimator(cpuaccounting.DefaultEstimateInterval).Run(stopCh)
+	}
+
+	// Monitor the hardware offload status of the OVS datapath flows, so that flows falling back to software can be
+	// noticed.
+	var ovsOffloadQuerier antreaquerier.AgentOVSOffloadQuerier
+	if ovsBridgeClient.IsHardwareOffloadEnabled() {
+		if *o.config.EnablePrometheusMetrics {
+			metrics.InitializeOVSOffloadMetrics()
+		}
+		eventBroadcaster := record.NewBroadcaster()
+		eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: k8sClient.CoreV1().Events("")})
+		defer eventBroadcaster.Shutdown()
+		recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "antrea-agent", Host: nodeConfig.Name})
+		ovsOffloadMonitor := ovsoffload.NewMonitor(nodeConfig.Name, ovsCtlClient, ifaceStore, recorder, ovsoffload.DefaultSampleInterval, *o.config.EnablePrometheusMetrics)
+		ovsOffloadQuerier = ovsOffloadMonitor
+		go ovsOffloadMonitor.Run(stopCh)
+	}
+
+	// Report the statistics of the AF_XDP interfaces, whose packets are processed by the OVS userspace datapath.
+	if ovsDatapathType == ovsconfig.OVSDatapathNetdev && *o.config.EnablePrometheusMetrics {
+		metrics.InitializeAFXDPMetrics()
+		go afxdp.NewMonitor(ovsBridgeClient, ifaceStore, afxdp.DefaultSampleInterval).Run(stopCh)
+	}
 
 	log.StartLogFileNumberMonitor(stopCh)
 
+	// Push the metrics to an OpenTelemetry collector, for users who don't want to scrape the Prometheus endpoint.
+	if o.config.OTelMetricsExporter.Enable && o.nodeType == config.K8sNode {
+		otelExporter, err := otlp.NewExporter(nodeConfig.Name, legacyregistry.DefaultGatherer, &otlp.Config{
+			Endpoint:        o.config.OTelMetricsExporter.Endpoint,
+			Interval:        o.otelMetricsExportInterval,
+			Headers:         o.config.OTelMetricsExporter.Headers,
+			BearerTokenFile: o.config.OTelMetricsExporter.BearerTokenFile,
+			CAFile:          o.config.OTelMetricsExporter.CAFile,
+		})
+		if err != nil {
+			return fmt.Errorf("error creating OpenTelemetry metrics exporter: %v", err)
+		}
+		go otelExporter.Run(stopCh)
+	}
+
+	// Watch the configuration file to apply the changes of the options which can be updated at runtime.
+	if o.configFile != "" {
+		eventBroadcaster := record.NewBroadcaster()
+		eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: k8sClient.CoreV1().Events("")})
+		defer eventBroadcaster.Shutdown()
+		recorder := eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "antrea-agent", Host: nodeConfig.Name})
+		configWatcher, err := configwatcher.NewWatcher(o.configFile, nodeConfig.Name, loadConfig, recorder)
+		if err != nil {
+			return fmt.Errorf("error creating configuration file watcher: %v", err)
+		}
+		addReloadableOptions(configWatcher, o, ofClient, networkPolicyController, flowExporter, nodePortAddressesSyncer)
+		go configWatcher.Run(stopCh)
+	}
+
 	if o.nodeType == config.K8sNode {
 		go routeClient.Run(stopCh)
 		go podUpdateChannel.Run(stopCh)
@@ -670,22 +970,25 @@ func run(o *Options) error {
 		go ipamController.Run(stopCh)
 	}
 
+	if enableBridgingMode {
+		gatewayProxyController := gatewayproxy.NewGatewayProxyController(ofClient, crdInformerFactory.Crd().V1alpha2().IPPools())
+		go gatewayProxyController.Run(stopCh)
+	}
+
 	if features.DefaultFeatureGate.Enabled(features.SecondaryNetwork) {
-		// Create the NetworkAttachmentDefinition client, which handles access to secondary network object definition from the API Server.
-		netAttachDefClient, err := k8s.CreateNetworkAttachDefClient(o.config.ClientConnection, o.config.KubeAPIServerOverride)
-		if err != nil {
-			return fmt.Errorf("NetworkAttachmentDefinition client creation failed. %v", err)
-		}
-		// Create podController to handle secondary network configuration for Pods with k8s.v1.cni.cncf.io/networks Annotation defined.
-		podWatchController := podwatch.NewPodController(
+		if err := secondarynetwork.Initialize(
+			o.config.ClientConnection,
+			o.config.KubeAPIServerOverride,
 			k8sClient,
-			netAttachDefClient,
 			localPodInformer,
 			nodeConfig.Name,
 			cniPodInfoStore,
-			// safe to call given that cniServer.Initialize has been called already.
-			cniServer.GetPodConfigurator())
-		go podWatchController.Run(stopCh)
+			cniServer,
+			&o.config.SecondaryNetwork,
+			ovsdbConnection,
+			stopCh); err != nil {
+			return fmt.Errorf("failed to initialize secondary network: %v", err)
+		}
 	}
 
 	if features.DefaultFeatureGate.Enabled(features.TrafficControl) {
@@ -700,6 +1003,36 @@ func run(o *Options) error {
 		go tcController.Run(stopCh)
 	}
 
+	if features.DefaultFeatureGate.Enabled(features.TrafficMirror) {
+		tmController := trafficmirror.NewTrafficMirrorController(ofClient,
+			ifaceStore,
+			ovsBridgeClient,
+			ovsCtlClient,
+			trafficMirrorInformer,
+			localPodInformer,
+			namespaceInformer,
+			podUpdateChannel)
+		go tmController.Run(stopCh)
+	}
+
+	// Probe the other Nodes periodically, to report the latency and the packet loss of the tunnel and of the underlay
+	// network.
+	var nodeLatencyQuerier monitortool.Querier
+	if features.DefaultFeatureGate.Enabled(features.NodeLatencyMonitor) && o.nodeType == config.K8sNode {
+		if *o.config.EnablePrometheusMetrics {
+			metrics.InitializeNodeLatencyMetrics()
+		}
+		nodeLatencyMonitor := monitortool.NewNodeLatencyMonitor(nodeConfig.Name,
+			nodeInformer,
+			v4Enabled,
+			v6Enabled,
+			networkConfig.TrafficEncapMode == config.TrafficEncapModeEncap,
+			o.nodeLatencyMonitorPingInterval,
+			*o.config.EnablePrometheusMetrics)
+		nodeLatencyQuerier = nodeLatencyMonitor
+		go nodeLatencyMonitor.Run(stopCh)
+	}
+
 	//  Start the localPodInformer
 	if localPodInformer != nil {
 		go localPodInformer.Run(stopCh)
@@ -722,12 +1055,24 @@ func run(o *Options) error {
 	}
 
 	if features.DefaultFeatureGate.Enabled(features.AntreaProxy) {
+		if skipServicesController != nil {
+			go skipServicesController.Run(stopCh)
+			// Wait for the Services listed in the ConfigMap to be known before AntreaProxy starts installing flows,
+			// otherwise the flows of these Services would be installed and uninstalled right after.
+			cache.WaitForNamedCacheSync("AntreaProxy", stopCh, skipServicesController.HasSynced)
+		}
+		if kubeProxyChecker != nil {
+			go kubeProxyChecker.Run(stopCh)
+		}
 		go proxier.GetProxyProvider().Run(stopCh)
+		if nodePortAddressesSyncer != nil {
+			go nodePortAddressesSyncer.Run(stopCh)
+		}
 
-		// If AntreaProxy is configured to proxy all Service traffic, we need to wait for it to sync at least once
-		// before moving forward. Components that rely on Service availability should run after it, otherwise accessing
-		// Service would fail.
-		if o.config.AntreaProxy.ProxyAll {
+		// If AntreaProxy is configured to proxy all Service traffic, or the ClusterIP traffic from the host, we need to
+		// wait for it to sync at least once before moving forward. Components that rely on Service availability should
+		// run after it, otherwise accessing Service would fail.
+		if o.config.AntreaProxy.ProxyAll || o.config.AntreaProxy.HostClusterIPAccess {
 			klog.InfoS("Waiting for AntreaProxy to be ready")
 			if err := wait.PollUntil(time.Second, func() (bool, error) {
 				klog.V(2).InfoS("Checking if AntreaProxy is ready")
@@ -745,6 +1090,23 @@ func run(o *Options) error {
 	if o.enableEgress {
 		go egressController.Run(stopCh)
 	}
+	if egressDefaultDenyController != nil {
+		go egressDefaultDenyController.Run(stopCh)
+	}
+	if neighborSpoofGuardController != nil {
+		go neighborSpoofGuardController.Run(stopCh)
+	}
+
+	// Stale flows from the previous round are deleted once the flows for the initial Nodes, Services and
+	// NetworkPolicies have been installed, so that the existing flows keep forwarding traffic until then.
+	flowsSynced := []func() bool{networkPolicyController.InitialFlowsInstalled}
+	if o.nodeType == config.K8sNode {
+		flowsSynced = append(flowsSynced, nodeRouteController.HasSynced)
+	}
+	if features.DefaultFeatureGate.Enabled(features.AntreaProxy) {
+		flowsSynced = append(flowsSynced, proxier.GetProxyProvider().SyncedOnce)
+	}
+	go agentInitializer.DeleteStaleFlows(flowsSynced...)
 
 	var mcastController *multicast.Controller
 	if multicastEnabled {
@@ -770,6 +1132,11 @@ func run(o *Options) error {
 			validator,
 			networkConfig.TrafficEncapMode.SupportsEncap(),
 			informerFactory)
+		mcastController.SetReconcileScheduler(reconcileScheduler)
+		if *o.config.EnablePrometheusMetrics {
+			metrics.InitializeMulticastMetrics()
+			mcastController.EnablePodTrafficMetrics()
+		}
 		if err := mcastController.Initialize(); err != nil {
 			return err
 		}
@@ -809,6 +1176,8 @@ func run(o *Options) error {
 		o.config.NodePortLocal.PortRange,
 		memberlistCluster,
 		nodeInformer.Lister(),
+		nodeLatencyQuerier,
+		kubeProxyQuerier,
 	)
 
 	if features.DefaultFeatureGate.Enabled(features.SupportBundleCollection) {
@@ -834,14 +1203,24 @@ func run(o *Options) error {
 	secureServing.MinTLSVersion = o.config.TLSMinVersion
 	authentication := options.NewDelegatingAuthenticationOptions()
 	authorization := options.NewDelegatingAuthorizationOptions().WithAlwaysAllowPaths("/healthz", "/livez", "/readyz")
+	var servingCertManager certificate.Manager
+	if features.DefaultFeatureGate.Enabled(features.AgentServingCertificate) {
+		servingCertManager, err = apiserver.NewServingCertificateManager(k8sClient, nodeConfig.Name, nodeInformer.Lister())
+		if err != nil {
+			return fmt.Errorf("error when creating serving certificate manager for agent API server: %v", err)
+		}
+	}
 	apiServer, err := apiserver.New(
 		agentQuerier,
 		networkPolicyController,
 		mcastController,
 		externalIPController,
+		connectionQuerier,
+		ovsOffloadQuerier,
 		secureServing,
 		authentication,
 		authorization,
+		servingCertManager,
 		*o.config.EnablePrometheusMetrics,
 		o.config.ClientConnection.Kubeconfig,
 		v4Enabled,
@@ -850,10 +1229,30 @@ func run(o *Options) error {
 		return fmt.Errorf("error when creating agent API server: %v", err)
 	}
 
-	// The certificate is static and will not be rotated; it will be re-generated if the Agent restarts.
-	agentAPICertData := apiServer.GetCertData()
-	if agentAPICertData == nil {
-		return fmt.Errorf("error when getting generated cert for agent API server")
+	var getAgentAPICertData func() []byte
+	if servingCertManager != nil {
+		// The serving certificate is signed by antrea-controller and rotated, so the CA certificate
+		// published by antrea-controller is provided for validating it instead.
+		agentAPICAProvider, err := dynamiccertificates.NewDynamicCAFromConfigMapController(
+			"antrea-agent-serving-ca",
+			env.GetAntreaNamespace(),
+			antreaapis.AntreaAgentServingCAName,
+			"ca.crt",
+			k8sClient)
+		if err != nil {
+			return fmt.Errorf("error when creating CA provider for agent API server: %v", err)
+		}
+		go agentAPICAProvider.Run(ctx, 1)
+		getAgentAPICertData = agentAPICAProvider.CurrentCABundleContent
+	} else {
+		// The certificate is static and will not be rotated; it will be re-generated if the Agent restarts.
+		agentAPICertData := apiServer.GetCertData()
+		if agentAPICertData == nil {
+			return fmt.Errorf("error when getting generated cert for agent API server")
+		}
+		getAgentAPICertData = func() []byte {
+			return agentAPICertData
+		}
 	}
 
 	go apiServer.Run(stopCh)
@@ -862,10 +1261,11 @@ func run(o *Options) error {
 	// the agentQuerier. This is to avoid a circular dependency between apiServer and
 	// agentQuerier. The apiServer already depends on the agentQuerier to implement some API
 	// handlers. The certificate data is only available after initializing the apiServer.
-	agentMonitor := monitor.NewAgentMonitor(crdClient, agentQuerier, agentAPICertData)
+	agentMonitor := monitor.NewAgentMonitor(crdClient, agentQuerier, getAgentAPICertData)
 	go agentMonitor.Run(stopCh)
 
 	// Start PacketIn
+	ofClient.SetPacketInRate(o.config.PacketInRate)
 	go ofClient.StartPacketInHandler(stopCh)
 
 	// Start the goroutine to periodically export IPFIX flow records.
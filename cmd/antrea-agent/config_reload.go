@@ -0,0 +1,116 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v2"
+
+	"antrea.io/antrea/pkg/agent/configwatcher"
+	"antrea.io/antrea/pkg/agent/controller/networkpolicy"
+	"antrea.io/antrea/pkg/agent/flowexporter/exporter"
+	"antrea.io/antrea/pkg/agent/openflow"
+	agentconfig "antrea.io/antrea/pkg/config/agent"
+	"antrea.io/antrea/pkg/log"
+)
+
+// loadConfig parses, defaults and validates the content of the configuration file, in the same way as it's done when
+// antrea-agent starts, except that the feature gates are not updated.
+func loadConfig(data []byte) (*agentconfig.AgentConfig, error) {
+	o := newOptions()
+	if err := yaml.UnmarshalStrict(data, o.config); err != nil {
+		return nil, err
+	}
+	o.setDefaults()
+	if err := o.validate(nil); err != nil {
+		return nil, err
+	}
+	return o.config, nil
+}
+
+func setLogVerbosity(logVerbosity *int) error {
+	if logVerbosity == nil {
+		return nil
+	}
+	return log.SetLogLevel(strconv.Itoa(*logVerbosity))
+}
+
+func toAuditLoggingConfig(c agentconfig.AuditLoggingConfig) networkpolicy.AuditLoggingConfig {
+	return networkpolicy.AuditLoggingConfig{
+		MaxSize:    c.MaxSize,
+		MaxBackups: *c.MaxBackups,
+		MaxAge:     *c.MaxAge,
+		Compress:   *c.Compress,
+	}
+}
+
+// addReloadableOptions registers the configuration options which can be updated at runtime to the watcher. The
+// options of the features which are not running, e.g. the FlowExporter, are not registered, and their changes require
+// restarting antrea-agent.
+func addReloadableOptions(w *configwatcher.Watcher,
+	o *Options,
+	ofClient openflow.Client,
+	networkPolicyController *networkpolicy.Controller,
+	flowExporter *exporter.FlowExporter,
+	nodePortAddressesSyncer *nodePortAddressesSyncer) {
+	w.AddOption(configwatcher.Option{
+		Paths: []string{"logVerbosity"},
+		Apply: func(c *agentconfig.AgentConfig) error {
+			if c.LogVerbosity == nil {
+				// Keep the current log verbosity, which can also be changed with antctl.
+				return nil
+			}
+			return setLogVerbosity(c.LogVerbosity)
+		},
+	})
+	w.AddOption(configwatcher.Option{
+		Paths: []string{"packetInRate"},
+		Apply: func(c *agentconfig.AgentConfig) error {
+			ofClient.SetPacketInRate(c.PacketInRate)
+			return nil
+		},
+	})
+	w.AddOption(configwatcher.Option{
+		Paths: []string{"auditLogging.maxSize", "auditLogging.maxBackups", "auditLogging.maxAge", "auditLogging.compress"},
+		Apply: func(c *agentconfig.AgentConfig) error {
+			networkPolicyController.SetAuditLoggingConfig(toAuditLoggingConfig(c.AuditLogging))
+			return nil
+		},
+	})
+	if flowExporter != nil {
+		w.AddOption(configwatcher.Option{
+			Paths: []string{"flowExporter.flowPollInterval", "flowExporter.activeFlowExportTimeout", "flowExporter.idleFlowExportTimeout"},
+			Apply: func(c *agentconfig.AgentConfig) error {
+				// The intervals have been defaulted and validated when loading the configuration, parse them again to
+				// get the timeouts adjusted against the poll interval.
+				parsed := &Options{config: c}
+				if err := parsed.validateFlowExporterConfig(); err != nil {
+					return err
+				}
+				flowExporter.UpdateIntervals(parsed.pollInterval, parsed.activeFlowTimeout, parsed.idleFlowTimeout)
+				return nil
+			},
+		})
+	}
+	if nodePortAddressesSyncer != nil {
+		w.AddOption(configwatcher.Option{
+			Paths: []string{"antreaProxy.nodePortAddresses", "antreaProxy.nodePortInterfaces"},
+			Apply: func(c *agentconfig.AgentConfig) error {
+				return nodePortAddressesSyncer.setOptions(c.AntreaProxy.NodePortAddresses, c.AntreaProxy.NodePortInterfaces)
+			},
+		})
+	}
+}
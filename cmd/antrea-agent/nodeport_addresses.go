@@ -0,0 +1,114 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	agentconfig "antrea.io/antrea/pkg/config/agent"
+)
+
+// nodePortAddressesSyncInterval is the interval at which the NodePort addresses are resolved again, to pick up the
+// changes of the Node's addresses, e.g. an address added to an interface selected by nodePortInterfaces.
+const nodePortAddressesSyncInterval = 30 * time.Second
+
+// nodePortAddressesSyncer resolves the NodePort addresses from the nodePortAddresses and nodePortInterfaces options,
+// and updates the NodePort addresses of the OpenFlow client and the proxier when the resolved addresses change.
+type nodePortAddressesSyncer struct {
+	mutex              sync.Mutex
+	nodePortAddresses  []string
+	nodePortInterfaces []agentconfig.NodePortInterface
+	excludeDevices     []string
+	addressesIPv4      []net.IP
+	addressesIPv6      []net.IP
+	update             func(nodePortAddressesIPv4, nodePortAddressesIPv6 []net.IP) error
+}
+
+func newNodePortAddressesSyncer(
+	nodePortAddresses []string,
+	nodePortInterfaces []agentconfig.NodePortInterface,
+	excludeDevices []string,
+	addressesIPv4, addressesIPv6 []net.IP,
+	update func(nodePortAddressesIPv4, nodePortAddressesIPv6 []net.IP) error,
+) *nodePortAddressesSyncer {
+	return &nodePortAddressesSyncer{
+		nodePortAddresses:  nodePortAddresses,
+		nodePortInterfaces: nodePortInterfaces,
+		excludeDevices:     excludeDevices,
+		addressesIPv4:      addressesIPv4,
+		addressesIPv6:      addressesIPv6,
+		update:             update,
+	}
+}
+
+// setOptions replaces the options the NodePort addresses are selected by, and syncs the NodePort addresses.
+func (s *nodePortAddressesSyncer) setOptions(nodePortAddresses []string, nodePortInterfaces []agentconfig.NodePortInterface) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.nodePortAddresses = nodePortAddresses
+	s.nodePortInterfaces = nodePortInterfaces
+	return s.syncLocked()
+}
+
+func (s *nodePortAddressesSyncer) sync() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.syncLocked()
+}
+
+func (s *nodePortAddressesSyncer) syncLocked() error {
+	addressesIPv4, addressesIPv6, err := getAvailableNodePortAddresses(s.nodePortAddresses, s.nodePortInterfaces, s.excludeDevices)
+	if err != nil {
+		return fmt.Errorf("getting available NodePort IP addresses failed: %w", err)
+	}
+	if ipsEqual(addressesIPv4, s.addressesIPv4) && ipsEqual(addressesIPv6, s.addressesIPv6) {
+		return nil
+	}
+	klog.InfoS("NodePort addresses changed", "old", append(s.addressesIPv4, s.addressesIPv6...), "new", append(addressesIPv4, addressesIPv6...))
+	if err := s.update(addressesIPv4, addressesIPv6); err != nil {
+		return err
+	}
+	s.addressesIPv4, s.addressesIPv6 = addressesIPv4, addressesIPv6
+	return nil
+}
+
+// Run resolves the NodePort addresses periodically until stopCh is closed.
+func (s *nodePortAddressesSyncer) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Starting NodePort addresses syncer")
+	defer klog.InfoS("Shutting down NodePort addresses syncer")
+	wait.Until(func() {
+		if err := s.sync(); err != nil {
+			klog.ErrorS(err, "Failed to sync NodePort addresses")
+		}
+	}, nodePortAddressesSyncInterval, stopCh)
+}
+
+func ipsEqual(a, b []net.IP) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -18,21 +18,35 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
+	"golang.org/x/exp/slices"
 	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	cliflag "k8s.io/component-base/cli/flag"
 	"k8s.io/component-base/featuregate"
 	"k8s.io/klog/v2"
 
 	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/flowexporter"
+	"antrea.io/antrea/pkg/agent/flowgc"
+	"antrea.io/antrea/pkg/agent/flowscheduler"
+	"antrea.io/antrea/pkg/agent/metrics/otlp"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/agent/watchdog"
 	"antrea.io/antrea/pkg/apis"
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
 	"antrea.io/antrea/pkg/cni"
 	agentconfig "antrea.io/antrea/pkg/config/agent"
 	"antrea.io/antrea/pkg/features"
+	"antrea.io/antrea/pkg/legacyflow"
+	"antrea.io/antrea/pkg/ovs/openflow/connrelay"
 	"antrea.io/antrea/pkg/ovs/ovsconfig"
 	"antrea.io/antrea/pkg/util/env"
 	"antrea.io/antrea/pkg/util/flowexport"
@@ -48,6 +62,7 @@ const (
 	defaultFlowCollectorAddress    = "flow-aggregator/flow-aggregator:4739:tls"
 	defaultFlowCollectorTransport  = "tls"
 	defaultFlowCollectorPort       = "4739"
+	defaultFlowRecordFormat        = "IPFIX"
 	defaultFlowPollInterval        = "5s"
 	defaultActiveFlowExportTimeout = "5s"
 	defaultIdleFlowExportTimeout   = "15s"
@@ -55,6 +70,12 @@ const (
 	defaultStaleConnectionTimeout  = 5 * time.Minute
 	defaultNodeType                = config.K8sNode
 	defaultMaxEgressIPsPerNode     = 255
+	defaultPacketInRate            = 100
+	defaultAuditLogMaxSize         = 500
+	defaultAuditLogMaxBackups      = 3
+	defaultAuditLogMaxAge          = 28
+
+	defaultOTelMetricsExportInterval = 60 * time.Second
 )
 
 var defaultIGMPQueryVersions = []int{1, 2, 3}
@@ -88,6 +109,22 @@ type Options struct {
 	// enableEgress represents whether Egress should run or not, calculated from its feature gate configuration and
 	// whether the traffic mode supports it.
 	enableEgress bool
+	// reconcileSchedulerWeights and reconcileSchedulerStarvationTimeout are parsed from the reconcileScheduler
+	// configuration.
+	reconcileSchedulerWeights           map[flowscheduler.Feature]int
+	reconcileSchedulerStarvationTimeout time.Duration
+	// watchdogConfig is parsed from the watchdog configuration.
+	watchdogConfig watchdog.Config
+	// flowGCConfig is parsed from the flowGC configuration.
+	flowGCConfig flowgc.Config
+	// ovsSoftLimits is parsed from the ovsSoftLimits configuration.
+	ovsSoftLimits types.OVSSoftLimits
+	// nodeLatencyMonitorPingInterval is parsed from the nodeLatencyMonitor configuration.
+	nodeLatencyMonitorPingInterval time.Duration
+	// endpointDrainingTimeout is parsed from the antreaProxy configuration.
+	endpointDrainingTimeout time.Duration
+	// otelMetricsExportInterval is parsed from the otelMetricsExporter configuration.
+	otelMetricsExportInterval time.Duration
 }
 
 func newOptions() *Options {
@@ -127,7 +164,7 @@ func (o *Options) validate(args []string) error {
 		return fmt.Errorf("no positional arguments are supported")
 	}
 
-	if o.config.OVSDatapathType != string(ovsconfig.OVSDatapathSystem) {
+	if o.config.OVSDatapathType != string(ovsconfig.OVSDatapathSystem) && o.config.OVSDatapathType != string(ovsconfig.OVSDatapathNetdev) {
 		return fmt.Errorf("OVS datapath type %s is not supported", o.config.OVSDatapathType)
 	}
 
@@ -135,6 +172,14 @@ func (o *Options) validate(args []string) error {
 		return err
 	}
 
+	if err := o.validateOVSOpenFlowConnectionConfig(); err != nil {
+		return fmt.Errorf("failed to validate ovsOpenFlowConnection config: %v", err)
+	}
+
+	if err := o.validateLoggingConfig(); err != nil {
+		return err
+	}
+
 	if config.ExternalNode.String() == o.config.NodeType && !features.DefaultFeatureGate.Enabled(features.ExternalNode) {
 		return fmt.Errorf("nodeType %s requires feature gate ExternalNode to be enabled", o.config.NodeType)
 	}
@@ -183,6 +228,28 @@ func (o *Options) setDefaults() {
 	if o.config.Multicluster.EnableGateway {
 		o.setMulticlusterDefaultOptions()
 	}
+	if o.config.PacketInRate == 0 {
+		o.config.PacketInRate = defaultPacketInRate
+	}
+	if o.config.TTL.Decrement == nil {
+		o.config.TTL.Decrement = new(bool)
+		*o.config.TTL.Decrement = true
+	}
+	if o.config.AuditLogging.MaxSize == 0 {
+		o.config.AuditLogging.MaxSize = defaultAuditLogMaxSize
+	}
+	if o.config.AuditLogging.MaxBackups == nil {
+		o.config.AuditLogging.MaxBackups = new(int)
+		*o.config.AuditLogging.MaxBackups = defaultAuditLogMaxBackups
+	}
+	if o.config.AuditLogging.MaxAge == nil {
+		o.config.AuditLogging.MaxAge = new(int)
+		*o.config.AuditLogging.MaxAge = defaultAuditLogMaxAge
+	}
+	if o.config.AuditLogging.Compress == nil {
+		o.config.AuditLogging.Compress = new(bool)
+		*o.config.AuditLogging.Compress = true
+	}
 }
 
 func (o *Options) validateTLSOptions() error {
@@ -202,6 +269,21 @@ func (o *Options) validateTLSOptions() error {
 	return nil
 }
 
+func (o *Options) validateOVSOpenFlowConnectionConfig() error {
+	connConfig := o.config.OVSOpenFlowConnection
+	if connConfig.Address == "" {
+		return nil
+	}
+	network, _, err := connrelay.ParseAddress(connConfig.Address)
+	if err != nil {
+		return err
+	}
+	if network == connrelay.NetworkSSL && (connConfig.CACertFile == "" || connConfig.CertFile == "" || connConfig.KeyFile == "") {
+		return fmt.Errorf("caCertFile, certFile and keyFile must be set for ssl connection")
+	}
+	return nil
+}
+
 func (o *Options) validateAntreaProxyConfig() error {
 	if !features.DefaultFeatureGate.Enabled(features.AntreaProxy) {
 		// Validate service CIDR configuration if AntreaProxy is not enabled.
@@ -216,6 +298,29 @@ func (o *Options) validateAntreaProxyConfig() error {
 		if len(o.config.AntreaProxy.SkipServices) > 0 {
 			klog.InfoS("skipServices will be ignored because AntreaProxy is disabled", "skipServices", o.config.AntreaProxy.SkipServices)
 		}
+		if o.config.AntreaProxy.SkipServicesConfigMap != "" {
+			klog.InfoS("skipServicesConfigMap will be ignored because AntreaProxy is disabled", "skipServicesConfigMap", o.config.AntreaProxy.SkipServicesConfigMap)
+		}
+		if len(o.config.AntreaProxy.SkipServiceProtocols) > 0 {
+			klog.InfoS("skipServiceProtocols will be ignored because AntreaProxy is disabled", "skipServiceProtocols", o.config.AntreaProxy.SkipServiceProtocols)
+		}
+		// The traffic from the host would be routed to the Antrea gateway without being load-balanced.
+		if o.config.AntreaProxy.HostClusterIPAccess {
+			return fmt.Errorf("hostClusterIPAccess requires AntreaProxy to be enabled")
+		}
+	}
+	if o.config.AntreaProxy.HostClusterIPAccess && o.config.AntreaProxy.ProxyAll {
+		klog.InfoS("hostClusterIPAccess is implied by proxyAll")
+	}
+	for _, protocol := range o.config.AntreaProxy.SkipServiceProtocols {
+		switch corev1.Protocol(protocol) {
+		case corev1.ProtocolTCP, corev1.ProtocolUDP, corev1.ProtocolSCTP:
+		default:
+			return fmt.Errorf("skipServiceProtocols %s is invalid, supported values are %s, %s and %s", protocol, corev1.ProtocolTCP, corev1.ProtocolUDP, corev1.ProtocolSCTP)
+		}
+	}
+	if len(o.config.AntreaProxy.SkipServiceProtocols) > 0 && o.config.AntreaProxy.ProxyAll {
+		klog.InfoS("kube-proxy must still be running to handle the Service ports with the skipped protocols", "skipServiceProtocols", o.config.AntreaProxy.SkipServiceProtocols)
 	}
 
 	if o.config.AntreaProxy.ProxyAll {
@@ -224,6 +329,29 @@ func (o *Options) validateAntreaProxyConfig() error {
 				return fmt.Errorf("invalid NodePort IP address `%s`: %w", nodePortAddress, err)
 			}
 		}
+		for _, nodePortInterface := range o.config.AntreaProxy.NodePortInterfaces {
+			if nodePortInterface.Name == "" {
+				return fmt.Errorf("the name of NodePort interface must not be empty")
+			}
+			if _, err := regexp.Compile(nodePortInterface.Name); err != nil {
+				return fmt.Errorf("invalid NodePort interface name `%s`: %w", nodePortInterface.Name, err)
+			}
+			switch corev1.IPFamily(nodePortInterface.IPFamily) {
+			case "", corev1.IPv4Protocol, corev1.IPv6Protocol:
+			default:
+				return fmt.Errorf("ipFamily %s of NodePort interface %s is invalid, supported values are %s and %s", nodePortInterface.IPFamily, nodePortInterface.Name, corev1.IPv4Protocol, corev1.IPv6Protocol)
+			}
+		}
+	}
+	if o.config.AntreaProxy.EndpointDrainingTimeout != "" {
+		timeout, err := time.ParseDuration(o.config.AntreaProxy.EndpointDrainingTimeout)
+		if err != nil {
+			return fmt.Errorf("endpointDrainingTimeout is invalid: %v", err)
+		}
+		if timeout < 0 {
+			return fmt.Errorf("endpointDrainingTimeout must not be negative")
+		}
+		o.endpointDrainingTimeout = timeout
 	}
 	return nil
 }
@@ -236,6 +364,14 @@ func (o *Options) validateFlowExporterConfig() error {
 		}
 		o.flowCollectorAddr = net.JoinHostPort(host, port)
 		o.flowCollectorProto = proto
+		if recordFormat := o.config.FlowExporter.RecordFormat; recordFormat != defaultFlowRecordFormat {
+			if !legacyflow.IsValidFormat(recordFormat) {
+				return fmt.Errorf("record format %s is not supported", recordFormat)
+			}
+			if proto != "udp" {
+				return fmt.Errorf("record format %s is only supported with the udp transport protocol", recordFormat)
+			}
+		}
 
 		// Parse the given flowPollInterval config
 		if o.config.FlowExporter.FlowPollInterval != "" {
@@ -267,6 +403,17 @@ func (o *Options) validateFlowExporterConfig() error {
 				klog.Warningf("IdleFlowExportTimeout must be greater than or equal to FlowPollInterval")
 			}
 		}
+		exportFilter := o.config.FlowExporter.ExportFilter
+		if exportFilter.PodSelector != "" {
+			if _, err := labels.Parse(exportFilter.PodSelector); err != nil {
+				return fmt.Errorf("invalid exportFilter.podSelector %q: %w", exportFilter.PodSelector, err)
+			}
+		}
+		for _, protocol := range exportFilter.Protocols {
+			if _, err := flowexporter.ParseProtocol(protocol); err != nil {
+				return fmt.Errorf("invalid exportFilter.protocols: %w", err)
+			}
+		}
 		if (o.activeFlowTimeout > defaultStaleConnectionTimeout) || (o.idleFlowTimeout > defaultStaleConnectionTimeout) {
 			if o.activeFlowTimeout > o.idleFlowTimeout {
 				o.staleConnectionTimeout = 2 * o.activeFlowTimeout
@@ -307,6 +454,28 @@ func (o *Options) validateMulticastConfig() error {
 	return nil
 }
 
+func (o *Options) validateSecondaryNetworkConfig() error {
+	if !features.DefaultFeatureGate.Enabled(features.SecondaryNetwork) {
+		return nil
+	}
+	bridges := o.config.SecondaryNetwork.OVSBridges
+	if len(bridges) > 1 {
+		return fmt.Errorf("only one OVS bridge can be specified for secondary networks")
+	}
+	for _, bridge := range bridges {
+		if bridge.BridgeName == "" {
+			return fmt.Errorf("bridgeName must be specified for the secondary network OVS bridge")
+		}
+		if bridge.BridgeName == o.config.OVSBridge {
+			return fmt.Errorf("secondary network OVS bridge %s conflicts with the Antrea OVS bridge", bridge.BridgeName)
+		}
+		if len(bridge.PhysicalInterfaces) > 1 {
+			return fmt.Errorf("only one physical interface can be connected to the secondary network OVS bridge %s", bridge.BridgeName)
+		}
+	}
+	return nil
+}
+
 func (o *Options) validateAntreaIPAMConfig() error {
 	if !o.config.EnableBridgingMode {
 		return nil
@@ -326,6 +495,24 @@ func (o *Options) validateAntreaIPAMConfig() error {
 	return nil
 }
 
+func (o *Options) validateOVSPhysicalBridgeConfig() error {
+	if o.config.OVSPhysicalBridge == "" {
+		return nil
+	}
+	if o.config.OVSPhysicalBridge == o.config.OVSBridge {
+		return fmt.Errorf("ovsPhysicalBridge must be different from ovsBridge %s", o.config.OVSBridge)
+	}
+	// In bridging mode, the uplink interface is connected to ovsBridge.
+	if o.config.EnableBridgingMode {
+		return fmt.Errorf("ovsPhysicalBridge cannot be used together with bridging mode")
+	}
+	// The flows of the physical bridge are installed with ovs-ofctl, which requires a local OVS instance.
+	if o.config.OVSOpenFlowConnection.Address != "" {
+		return fmt.Errorf("ovsPhysicalBridge cannot be used together with a remote OVS instance")
+	}
+	return nil
+}
+
 func (o *Options) validateMulticlusterConfig(encapMode config.TrafficEncapModeType, encryptionMode config.TrafficEncryptionModeType) error {
 	if !o.config.Multicluster.EnableGateway && !o.config.Multicluster.EnableStretchedNetworkPolicy {
 		return nil
@@ -366,6 +553,9 @@ func (o *Options) setK8sNodeDefaultOptions() {
 	if o.config.TunnelType == "" {
 		o.config.TunnelType = defaultTunnelType
 	}
+	if o.config.FirewallBackend == "" {
+		o.config.FirewallBackend = config.FirewallBackendAuto.String()
+	}
 	if o.config.HostProcPathPrefix == "" {
 		o.config.HostProcPathPrefix = defaultHostProcPathPrefix
 	}
@@ -374,6 +564,10 @@ func (o *Options) setK8sNodeDefaultOptions() {
 			o.config.AntreaProxy.ProxyLoadBalancerIPs = new(bool)
 			*o.config.AntreaProxy.ProxyLoadBalancerIPs = true
 		}
+		if o.config.AntreaProxy.RejectServicesWithoutEndpoints == nil {
+			o.config.AntreaProxy.RejectServicesWithoutEndpoints = new(bool)
+			*o.config.AntreaProxy.RejectServicesWithoutEndpoints = true
+		}
 	} else {
 		if o.config.ServiceCIDR == "" {
 			o.config.ServiceCIDR = defaultServiceCIDR
@@ -423,6 +617,9 @@ func (o *Options) setK8sNodeDefaultOptions() {
 				o.config.FlowExporter.IdleFlowExportTimeout = o.config.IdleFlowExportTimeout
 			}
 		}
+		if o.config.FlowExporter.RecordFormat == "" {
+			o.config.FlowExporter.RecordFormat = defaultFlowRecordFormat
+		}
 	}
 
 	if features.DefaultFeatureGate.Enabled(features.NodePortLocal) {
@@ -482,10 +679,204 @@ func (o *Options) validateEgressConfig(encapMode config.TrafficEncapModeType) er
 	if o.config.Egress.MaxEgressIPsPerNode > defaultMaxEgressIPsPerNode {
 		return fmt.Errorf("maxEgressIPsPerNode cannot be greater than %d", defaultMaxEgressIPsPerNode)
 	}
+	if o.config.Egress.DefaultDenyExternal.AllNamespaces && !o.config.Egress.DefaultDenyExternal.Enable {
+		return fmt.Errorf("defaultDenyExternal.allNamespaces cannot be set when defaultDenyExternal is not enabled")
+	}
 	o.enableEgress = true
 	return nil
 }
 
+// validateLoadBalancerModeDSRConfig validates the requirements of the LoadBalancerModeDSR feature, which forwards the
+// connections to remote Endpoints through the tunnel.
+func (o *Options) validateLoadBalancerModeDSRConfig(encapMode config.TrafficEncapModeType) error {
+	if !features.DefaultFeatureGate.Enabled(features.LoadBalancerModeDSR) {
+		return nil
+	}
+	if !features.DefaultFeatureGate.Enabled(features.AntreaProxy) {
+		return fmt.Errorf("%s requires AntreaProxy to be enabled", features.LoadBalancerModeDSR)
+	}
+	if encapMode != config.TrafficEncapModeEncap {
+		return fmt.Errorf("%s is only applicable to the %s mode", features.LoadBalancerModeDSR, config.TrafficEncapModeEncap)
+	}
+	return nil
+}
+
+func (o *Options) validateReconcileSchedulerConfig() error {
+	if !o.config.ReconcileScheduler.Enable {
+		return nil
+	}
+	knownFeatures := sets.New[string]()
+	for _, f := range flowscheduler.Features {
+		knownFeatures.Insert(string(f))
+	}
+	o.reconcileSchedulerWeights = make(map[flowscheduler.Feature]int, len(o.config.ReconcileScheduler.FeatureWeights))
+	for feature, weight := range o.config.ReconcileScheduler.FeatureWeights {
+		if !knownFeatures.Has(feature) {
+			return fmt.Errorf("unknown feature %s in featureWeights, supported features: %v", feature, sets.List(knownFeatures))
+		}
+		if weight <= 0 {
+			return fmt.Errorf("weight of feature %s must be positive", feature)
+		}
+		o.reconcileSchedulerWeights[flowscheduler.Feature(feature)] = weight
+	}
+	if o.config.ReconcileScheduler.StarvationTimeout != "" {
+		timeout, err := time.ParseDuration(o.config.ReconcileScheduler.StarvationTimeout)
+		if err != nil {
+			return fmt.Errorf("starvationTimeout is invalid: %v", err)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("starvationTimeout must be positive")
+		}
+		o.reconcileSchedulerStarvationTimeout = timeout
+	}
+	return nil
+}
+
+func (o *Options) validateWatchdogConfig() error {
+	if !o.config.Watchdog.Enable {
+		return nil
+	}
+	if o.config.Watchdog.CheckInterval != "" {
+		interval, err := time.ParseDuration(o.config.Watchdog.CheckInterval)
+		if err != nil {
+			return fmt.Errorf("checkInterval is invalid: %v", err)
+		}
+		if interval <= 0 {
+			return fmt.Errorf("checkInterval must be positive")
+		}
+		o.watchdogConfig.CheckInterval = interval
+	}
+	if o.config.Watchdog.MemoryThreshold != "" {
+		threshold, err := resource.ParseQuantity(o.config.Watchdog.MemoryThreshold)
+		if err != nil {
+			return fmt.Errorf("memoryThreshold is invalid: %v", err)
+		}
+		if threshold.Sign() <= 0 {
+			return fmt.Errorf("memoryThreshold must be positive")
+		}
+		o.watchdogConfig.MemoryThreshold = uint64(threshold.Value())
+	}
+	if o.config.Watchdog.FlowCountThreshold < 0 {
+		return fmt.Errorf("flowCountThreshold must not be negative")
+	}
+	o.watchdogConfig.FlowCountThreshold = o.config.Watchdog.FlowCountThreshold
+	if o.config.Watchdog.QueueDepthThreshold < 0 {
+		return fmt.Errorf("queueDepthThreshold must not be negative")
+	}
+	o.watchdogConfig.QueueDepthThreshold = o.config.Watchdog.QueueDepthThreshold
+	if o.watchdogConfig.MemoryThreshold == 0 && o.watchdogConfig.FlowCountThreshold == 0 && o.watchdogConfig.QueueDepthThreshold == 0 {
+		return fmt.Errorf("at least one of memoryThreshold, flowCountThreshold and queueDepthThreshold must be set")
+	}
+	return nil
+}
+
+func (o *Options) validateFlowGCConfig() error {
+	if !o.config.FlowGC.Enable {
+		return nil
+	}
+	if o.config.FlowGC.Interval != "" {
+		interval, err := time.ParseDuration(o.config.FlowGC.Interval)
+		if err != nil {
+			return fmt.Errorf("interval is invalid: %v", err)
+		}
+		if interval <= 0 {
+			return fmt.Errorf("interval must be positive")
+		}
+		o.flowGCConfig.Interval = interval
+	}
+	o.flowGCConfig.DryRun = o.config.FlowGC.DryRun
+	return nil
+}
+
+func (o *Options) validateOVSSoftLimitsConfig() error {
+	limits := o.config.OVSSoftLimits
+	if limits.FlowsPerTable < 0 {
+		return fmt.Errorf("flowsPerTable must not be negative")
+	}
+	if limits.Groups < 0 {
+		return fmt.Errorf("groups must not be negative")
+	}
+	if limits.Meters < 0 {
+		return fmt.Errorf("meters must not be negative")
+	}
+	o.ovsSoftLimits = types.OVSSoftLimits{
+		FlowsPerTable: limits.FlowsPerTable,
+		Groups:        limits.Groups,
+		Meters:        limits.Meters,
+	}
+	return nil
+}
+
+func (o *Options) validateNodeLatencyMonitorConfig() error {
+	if !features.DefaultFeatureGate.Enabled(features.NodeLatencyMonitor) || o.config.NodeLatencyMonitor.PingInterval == "" {
+		return nil
+	}
+	interval, err := time.ParseDuration(o.config.NodeLatencyMonitor.PingInterval)
+	if err != nil {
+		return fmt.Errorf("pingInterval is invalid: %v", err)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("pingInterval must be positive")
+	}
+	o.nodeLatencyMonitorPingInterval = interval
+	return nil
+}
+
+func (o *Options) validateOTelMetricsExporterConfig() error {
+	exporterConfig := o.config.OTelMetricsExporter
+	if !exporterConfig.Enable {
+		return nil
+	}
+	if o.config.EnablePrometheusMetrics == nil || !*o.config.EnablePrometheusMetrics {
+		return fmt.Errorf("enablePrometheusMetrics must be true")
+	}
+	if _, err := otlp.ParseEndpoint(exporterConfig.Endpoint); err != nil {
+		return err
+	}
+	o.otelMetricsExportInterval = defaultOTelMetricsExportInterval
+	if exporterConfig.ExportInterval != "" {
+		interval, err := time.ParseDuration(exporterConfig.ExportInterval)
+		if err != nil {
+			return fmt.Errorf("exportInterval is invalid: %v", err)
+		}
+		if interval < time.Second {
+			return fmt.Errorf("exportInterval must be at least 1s")
+		}
+		o.otelMetricsExportInterval = interval
+	}
+	return nil
+}
+
+// validateLoggingConfig validates the logging and packet-in options, which are applicable to all Node types.
+func (o *Options) validateLoggingConfig() error {
+	if o.config.LogVerbosity != nil && *o.config.LogVerbosity < 0 {
+		return fmt.Errorf("logVerbosity must not be negative")
+	}
+	if o.config.PacketInRate < 0 {
+		return fmt.Errorf("packetInRate must be positive")
+	}
+	if o.config.AuditLogging.MaxSize < 0 {
+		return fmt.Errorf("auditLogging.maxSize must be positive")
+	}
+	if o.config.AuditLogging.MaxBackups != nil && *o.config.AuditLogging.MaxBackups < 0 {
+		return fmt.Errorf("auditLogging.maxBackups must not be negative")
+	}
+	if o.config.AuditLogging.MaxAge != nil && *o.config.AuditLogging.MaxAge < 0 {
+		return fmt.Errorf("auditLogging.maxAge must not be negative")
+	}
+	if threshold := o.config.AuditLogging.EventSeverityThreshold; threshold != "" && !slices.Contains(crdv1alpha1.AuditLogSeverities, threshold) {
+		return fmt.Errorf("auditLogging.eventSeverityThreshold %q is invalid, must be one of %s", threshold, strings.Join(crdv1alpha1.AuditLogSeverities, ", "))
+	}
+	return nil
+}
+
+func (o *Options) validateTTLConfig() error {
+	if o.config.TTL.SendTimeExceeded && o.config.TTL.Decrement != nil && !*o.config.TTL.Decrement {
+		return fmt.Errorf("ttl.sendTimeExceeded requires ttl.decrement to be true")
+	}
+	return nil
+}
+
 func (o *Options) validateK8sNodeOptions() error {
 	if o.config.TunnelType != ovsconfig.VXLANTunnel && o.config.TunnelType != ovsconfig.GeneveTunnel &&
 		o.config.TunnelType != ovsconfig.GRETunnel && o.config.TunnelType != ovsconfig.STTTunnel {
@@ -506,6 +897,12 @@ func (o *Options) validateK8sNodeOptions() error {
 	if ipsecAuthMode == config.IPsecAuthenticationModeCert && !features.DefaultFeatureGate.Enabled(features.IPsecCertAuth) {
 		return fmt.Errorf("IPsec AuthenticationMode %s requires feature gate %s to be enabled", o.config.TrafficEncapMode, features.IPsecCertAuth)
 	}
+	if ok, _ := config.GetFirewallBackendFromStr(o.config.FirewallBackend); !ok {
+		return fmt.Errorf("FirewallBackend %s is unknown", o.config.FirewallBackend)
+	}
+	if err := o.validateTTLConfig(); err != nil {
+		return err
+	}
 
 	// Check if the enabled features are supported on the OS.
 	if err := o.checkUnsupportedFeatures(); err != nil {
@@ -554,6 +951,27 @@ func (o *Options) validateK8sNodeOptions() error {
 	if err := o.validateMulticlusterConfig(encapMode, encryptionMode); err != nil {
 		return err
 	}
+	if err := o.validateLoadBalancerModeDSRConfig(encapMode); err != nil {
+		return err
+	}
+	if err := o.validateReconcileSchedulerConfig(); err != nil {
+		return fmt.Errorf("failed to validate reconcileScheduler config: %v", err)
+	}
+	if err := o.validateWatchdogConfig(); err != nil {
+		return fmt.Errorf("failed to validate watchdog config: %v", err)
+	}
+	if err := o.validateFlowGCConfig(); err != nil {
+		return fmt.Errorf("failed to validate flowGC config: %v", err)
+	}
+	if err := o.validateOVSSoftLimitsConfig(); err != nil {
+		return fmt.Errorf("failed to validate ovsSoftLimits config: %v", err)
+	}
+	if err := o.validateNodeLatencyMonitorConfig(); err != nil {
+		return fmt.Errorf("failed to validate nodeLatencyMonitor config: %v", err)
+	}
+	if err := o.validateOTelMetricsExporterConfig(); err != nil {
+		return fmt.Errorf("failed to validate otelMetricsExporter config: %v", err)
+	}
 
 	if features.DefaultFeatureGate.Enabled(features.NodePortLocal) {
 		startPort, endPort, err := parsePortRange(o.config.NodePortLocal.PortRange)
@@ -568,6 +986,12 @@ func (o *Options) validateK8sNodeOptions() error {
 	if err := o.validateAntreaIPAMConfig(); err != nil {
 		return fmt.Errorf("failed to validate AntreaIPAM config: %v", err)
 	}
+	if err := o.validateOVSPhysicalBridgeConfig(); err != nil {
+		return fmt.Errorf("failed to validate ovsPhysicalBridge config: %v", err)
+	}
+	if err := o.validateSecondaryNetworkConfig(); err != nil {
+		return fmt.Errorf("failed to validate secondaryNetwork config: %v", err)
+	}
 
 	if o.config.DNSServerOverride != "" {
 		hostPort := ip.AppendPortIfMissing(o.config.DNSServerOverride, "53")
@@ -581,11 +1005,17 @@ func (o *Options) validateK8sNodeOptions() error {
 	return nil
 }
 
-// resetVMDefaultFeatures sets the feature's default enablement status as false if it is not supported on a VM or a BM.
+// resetVMDefaultFeatures sets the feature's default enablement status as false if it is not supported on a VM or a BM,
+// or if it is supported but must be enabled explicitly, and it is not set in the configuration.
 func (o *Options) resetVMDefaultFeatures() error {
 	disabledFeatureMap := make(map[string]bool)
 	for f, s := range features.DefaultAntreaFeatureGates {
-		if s.Default && !features.SupportedOnExternalNode(f) {
+		if !s.Default {
+			continue
+		}
+		if !features.SupportedOnExternalNode(f) {
+			disabledFeatureMap[string(f)] = false
+		} else if _, configured := o.config.FeatureGates[string(f)]; !configured && !features.EnabledByDefaultOnExternalNode(f) {
 			disabledFeatureMap[string(f)] = false
 		}
 	}
@@ -608,12 +1038,25 @@ func (o *Options) validateExternalNodeOptions() error {
 	if o.config.EnableIPSecTunnel {
 		unsupported = append(unsupported, "EnableIPSecTunnel")
 	}
+	if features.DefaultFeatureGate.Enabled(features.AntreaProxy) {
+		if o.config.AntreaProxy.ProxyAll {
+			unsupported = append(unsupported, "AntreaProxy.ProxyAll")
+		}
+		if o.config.AntreaProxy.HostClusterIPAccess {
+			unsupported = append(unsupported, "AntreaProxy.HostClusterIPAccess")
+		}
+	}
 	if unsupported != nil {
 		return fmt.Errorf("unsupported features on Virtual Machine: {%s}", strings.Join(unsupported, ", "))
 	}
 	if err := o.validatePolicyBypassRulesConfig(); err != nil {
 		return fmt.Errorf("policyBypassRules configuration is invalid: %w", err)
 	}
+	if features.DefaultFeatureGate.Enabled(features.AntreaProxy) {
+		if err := o.validateAntreaProxyConfig(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -655,6 +1098,10 @@ func (o *Options) setExternalNodeDefaultOptions() {
 	if o.config.ExternalNode.ExternalNodeNamespace == "" {
 		o.config.ExternalNode.ExternalNodeNamespace = "default"
 	}
+	if o.config.AntreaProxy.ProxyLoadBalancerIPs == nil {
+		o.config.AntreaProxy.ProxyLoadBalancerIPs = new(bool)
+		*o.config.AntreaProxy.ProxyLoadBalancerIPs = true
+	}
 }
 
 func (o *Options) setMulticlusterDefaultOptions() {
@@ -17,12 +17,18 @@ package main
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	featuregatetesting "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/pointer"
 
 	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/flowgc"
+	"antrea.io/antrea/pkg/agent/flowscheduler"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/agent/watchdog"
 	agentconfig "antrea.io/antrea/pkg/config/agent"
 	"antrea.io/antrea/pkg/features"
 )
@@ -120,6 +126,30 @@ func TestOptionsValidateEgressConfig(t *testing.T) {
 			expectedErr:          "Egress Except CIDR 1.1.1.300/32 is invalid",
 			expectedEnableEgress: false,
 		},
+		{
+			name:             "default deny for all Namespaces",
+			featureGateValue: true,
+			trafficEncapMode: config.TrafficEncapModeEncap,
+			egressConfig: agentconfig.EgressConfig{
+				DefaultDenyExternal: agentconfig.EgressDefaultDenyConfig{
+					Enable:        true,
+					AllNamespaces: true,
+				},
+			},
+			expectedEnableEgress: true,
+		},
+		{
+			name:             "allNamespaces without enabling default deny",
+			featureGateValue: true,
+			trafficEncapMode: config.TrafficEncapModeEncap,
+			egressConfig: agentconfig.EgressConfig{
+				DefaultDenyExternal: agentconfig.EgressDefaultDenyConfig{
+					AllNamespaces: true,
+				},
+			},
+			expectedErr:          "defaultDenyExternal.allNamespaces cannot be set when defaultDenyExternal is not enabled",
+			expectedEnableEgress: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -172,3 +202,986 @@ func TestOptionsValidateMulticastConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestOptionsValidateSecondaryNetworkConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		bridges     []agentconfig.OVSBridgeConfig
+		expectedErr error
+	}{
+		{
+			name: "no bridge",
+		},
+		{
+			name:    "valid bridge",
+			bridges: []agentconfig.OVSBridgeConfig{{BridgeName: "br-secondary", PhysicalInterfaces: []string{"eth1"}}},
+		},
+		{
+			name:        "multiple bridges",
+			bridges:     []agentconfig.OVSBridgeConfig{{BridgeName: "br1"}, {BridgeName: "br2"}},
+			expectedErr: fmt.Errorf("only one OVS bridge can be specified for secondary networks"),
+		},
+		{
+			name:        "empty bridge name",
+			bridges:     []agentconfig.OVSBridgeConfig{{PhysicalInterfaces: []string{"eth1"}}},
+			expectedErr: fmt.Errorf("bridgeName must be specified for the secondary network OVS bridge"),
+		},
+		{
+			name:        "Antrea bridge",
+			bridges:     []agentconfig.OVSBridgeConfig{{BridgeName: "br-int"}},
+			expectedErr: fmt.Errorf("secondary network OVS bridge br-int conflicts with the Antrea OVS bridge"),
+		},
+		{
+			name:        "multiple physical interfaces",
+			bridges:     []agentconfig.OVSBridgeConfig{{BridgeName: "br-secondary", PhysicalInterfaces: []string{"eth1", "eth2"}}},
+			expectedErr: fmt.Errorf("only one physical interface can be connected to the secondary network OVS bridge br-secondary"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.SecondaryNetwork, true)()
+			o := &Options{config: &agentconfig.AgentConfig{
+				OVSBridge: "br-int",
+				SecondaryNetwork: agentconfig.SecondaryNetworkConfig{
+					OVSBridges: tt.bridges,
+				},
+			}}
+			assert.Equal(t, tt.expectedErr, o.validateSecondaryNetworkConfig())
+		})
+	}
+}
+
+func TestOptionsValidateReconcileSchedulerConfig(t *testing.T) {
+	tests := []struct {
+		name                      string
+		reconcileSchedulerConfig  agentconfig.ReconcileSchedulerConfig
+		expectedErr               string
+		expectedWeights           map[flowscheduler.Feature]int
+		expectedStarvationTimeout time.Duration
+	}{
+		{
+			name: "disabled",
+			reconcileSchedulerConfig: agentconfig.ReconcileSchedulerConfig{
+				FeatureWeights: map[string]int{"foo": 1},
+			},
+		},
+		{
+			name: "valid",
+			reconcileSchedulerConfig: agentconfig.ReconcileSchedulerConfig{
+				Enable:            true,
+				FeatureWeights:    map[string]int{"proxy": 4, "networkpolicy": 2},
+				StarvationTimeout: "500ms",
+			},
+			expectedWeights:           map[flowscheduler.Feature]int{flowscheduler.FeatureProxy: 4, flowscheduler.FeatureNetworkPolicy: 2},
+			expectedStarvationTimeout: 500 * time.Millisecond,
+		},
+		{
+			name: "unknown feature",
+			reconcileSchedulerConfig: agentconfig.ReconcileSchedulerConfig{
+				Enable:         true,
+				FeatureWeights: map[string]int{"foo": 1},
+			},
+			expectedErr: "unknown feature foo in featureWeights",
+		},
+		{
+			name: "non-positive weight",
+			reconcileSchedulerConfig: agentconfig.ReconcileSchedulerConfig{
+				Enable:         true,
+				FeatureWeights: map[string]int{"egress": 0},
+			},
+			expectedErr: "weight of feature egress must be positive",
+		},
+		{
+			name: "invalid starvationTimeout",
+			reconcileSchedulerConfig: agentconfig.ReconcileSchedulerConfig{
+				Enable:            true,
+				StarvationTimeout: "1x",
+			},
+			expectedErr: "starvationTimeout is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{config: &agentconfig.AgentConfig{
+				ReconcileScheduler: tt.reconcileSchedulerConfig,
+			}}
+			err := o.validateReconcileSchedulerConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				if tt.expectedWeights != nil {
+					assert.Equal(t, tt.expectedWeights, o.reconcileSchedulerWeights)
+				}
+				assert.Equal(t, tt.expectedStarvationTimeout, o.reconcileSchedulerStarvationTimeout)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateEndpointDrainingTimeout(t *testing.T) {
+	tests := []struct {
+		name            string
+		timeout         string
+		expectedErr     string
+		expectedTimeout time.Duration
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:            "valid",
+			timeout:         "30s",
+			expectedTimeout: 30 * time.Second,
+		},
+		{
+			name:        "invalid",
+			timeout:     "30",
+			expectedErr: "endpointDrainingTimeout is invalid",
+		},
+		{
+			name:        "negative",
+			timeout:     "-1s",
+			expectedErr: "endpointDrainingTimeout must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{config: &agentconfig.AgentConfig{
+				AntreaProxy: agentconfig.AntreaProxyConfig{EndpointDrainingTimeout: tt.timeout},
+			}}
+			err := o.validateAntreaProxyConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedTimeout, o.endpointDrainingTimeout)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateSkipServiceProtocols(t *testing.T) {
+	tests := []struct {
+		name        string
+		protocols   []string
+		expectedErr string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:      "valid",
+			protocols: []string{"UDP", "SCTP"},
+		},
+		{
+			name:        "invalid",
+			protocols:   []string{"udp"},
+			expectedErr: "skipServiceProtocols udp is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{config: &agentconfig.AgentConfig{
+				AntreaProxy: agentconfig.AntreaProxyConfig{SkipServiceProtocols: tt.protocols},
+			}}
+			err := o.validateAntreaProxyConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateHostClusterIPAccess(t *testing.T) {
+	tests := []struct {
+		name               string
+		antreaProxyEnabled bool
+		proxyAll           bool
+		expectedErr        string
+	}{
+		{
+			name:               "AntreaProxy enabled",
+			antreaProxyEnabled: true,
+		},
+		{
+			name:               "with proxyAll",
+			antreaProxyEnabled: true,
+			proxyAll:           true,
+		},
+		{
+			name:               "AntreaProxy disabled",
+			antreaProxyEnabled: false,
+			expectedErr:        "hostClusterIPAccess requires AntreaProxy to be enabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.AntreaProxy, tt.antreaProxyEnabled)()
+			o := &Options{config: &agentconfig.AgentConfig{
+				ServiceCIDR: "10.96.0.0/12",
+				AntreaProxy: agentconfig.AntreaProxyConfig{HostClusterIPAccess: true, ProxyAll: tt.proxyAll},
+			}}
+			err := o.validateAntreaProxyConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateNodePortInterfaces(t *testing.T) {
+	tests := []struct {
+		name               string
+		nodePortInterfaces []agentconfig.NodePortInterface
+		expectedErr        string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:               "valid",
+			nodePortInterfaces: []agentconfig.NodePortInterface{{Name: "eth0", IPFamily: "IPv4"}, {Name: "eth[0-9]+"}},
+		},
+		{
+			name:               "empty name",
+			nodePortInterfaces: []agentconfig.NodePortInterface{{IPFamily: "IPv6"}},
+			expectedErr:        "the name of NodePort interface must not be empty",
+		},
+		{
+			name:               "invalid name",
+			nodePortInterfaces: []agentconfig.NodePortInterface{{Name: "eth["}},
+			expectedErr:        "invalid NodePort interface name `eth[`",
+		},
+		{
+			name:               "invalid ipFamily",
+			nodePortInterfaces: []agentconfig.NodePortInterface{{Name: "eth0", IPFamily: "ipv4"}},
+			expectedErr:        "ipFamily ipv4 of NodePort interface eth0 is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{config: &agentconfig.AgentConfig{
+				AntreaProxy: agentconfig.AntreaProxyConfig{ProxyAll: true, NodePortInterfaces: tt.nodePortInterfaces},
+			}}
+			err := o.validateAntreaProxyConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateWatchdogConfig(t *testing.T) {
+	tests := []struct {
+		name                   string
+		watchdogConfig         agentconfig.WatchdogConfig
+		expectedErr            string
+		expectedWatchdogConfig watchdog.Config
+	}{
+		{
+			name: "disabled",
+			watchdogConfig: agentconfig.WatchdogConfig{
+				MemoryThreshold: "foo",
+			},
+		},
+		{
+			name: "valid",
+			watchdogConfig: agentconfig.WatchdogConfig{
+				Enable:              true,
+				CheckInterval:       "30s",
+				MemoryThreshold:     "512Mi",
+				FlowCountThreshold:  100000,
+				QueueDepthThreshold: 1000,
+			},
+			expectedWatchdogConfig: watchdog.Config{
+				CheckInterval:       30 * time.Second,
+				MemoryThreshold:     512 * 1024 * 1024,
+				FlowCountThreshold:  100000,
+				QueueDepthThreshold: 1000,
+			},
+		},
+		{
+			name: "invalid checkInterval",
+			watchdogConfig: agentconfig.WatchdogConfig{
+				Enable:             true,
+				CheckInterval:      "1x",
+				FlowCountThreshold: 100000,
+			},
+			expectedErr: "checkInterval is invalid",
+		},
+		{
+			name: "invalid memoryThreshold",
+			watchdogConfig: agentconfig.WatchdogConfig{
+				Enable:          true,
+				MemoryThreshold: "1Gx",
+			},
+			expectedErr: "memoryThreshold is invalid",
+		},
+		{
+			name: "negative flowCountThreshold",
+			watchdogConfig: agentconfig.WatchdogConfig{
+				Enable:             true,
+				FlowCountThreshold: -1,
+			},
+			expectedErr: "flowCountThreshold must not be negative",
+		},
+		{
+			name: "no threshold",
+			watchdogConfig: agentconfig.WatchdogConfig{
+				Enable: true,
+			},
+			expectedErr: "at least one of memoryThreshold, flowCountThreshold and queueDepthThreshold must be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{config: &agentconfig.AgentConfig{
+				Watchdog: tt.watchdogConfig,
+			}}
+			err := o.validateWatchdogConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedWatchdogConfig, o.watchdogConfig)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateFlowGCConfig(t *testing.T) {
+	tests := []struct {
+		name                 string
+		flowGCConfig         agentconfig.FlowGCConfig
+		expectedErr          string
+		expectedFlowGCConfig flowgc.Config
+	}{
+		{
+			name: "disabled",
+			flowGCConfig: agentconfig.FlowGCConfig{
+				Interval: "foo",
+			},
+		},
+		{
+			name: "valid",
+			flowGCConfig: agentconfig.FlowGCConfig{
+				Enable:   true,
+				Interval: "5m",
+				DryRun:   true,
+			},
+			expectedFlowGCConfig: flowgc.Config{
+				Interval: 5 * time.Minute,
+				DryRun:   true,
+			},
+		},
+		{
+			name: "default interval",
+			flowGCConfig: agentconfig.FlowGCConfig{
+				Enable: true,
+			},
+		},
+		{
+			name: "invalid interval",
+			flowGCConfig: agentconfig.FlowGCConfig{
+				Enable:   true,
+				Interval: "1x",
+			},
+			expectedErr: "interval is invalid",
+		},
+		{
+			name: "negative interval",
+			flowGCConfig: agentconfig.FlowGCConfig{
+				Enable:   true,
+				Interval: "-1m",
+			},
+			expectedErr: "interval must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{config: &agentconfig.AgentConfig{
+				FlowGC: tt.flowGCConfig,
+			}}
+			err := o.validateFlowGCConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedFlowGCConfig, o.flowGCConfig)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateOVSSoftLimitsConfig(t *testing.T) {
+	tests := []struct {
+		name                  string
+		ovsSoftLimitsConfig   agentconfig.OVSSoftLimitsConfig
+		expectedErr           string
+		expectedOVSSoftLimits types.OVSSoftLimits
+	}{
+		{
+			name: "no limits",
+		},
+		{
+			name: "valid",
+			ovsSoftLimitsConfig: agentconfig.OVSSoftLimitsConfig{
+				FlowsPerTable: 100000,
+				Groups:        1000,
+			},
+			expectedOVSSoftLimits: types.OVSSoftLimits{
+				FlowsPerTable: 100000,
+				Groups:        1000,
+			},
+		},
+		{
+			name: "negative flowsPerTable",
+			ovsSoftLimitsConfig: agentconfig.OVSSoftLimitsConfig{
+				FlowsPerTable: -1,
+			},
+			expectedErr: "flowsPerTable must not be negative",
+		},
+		{
+			name: "negative meters",
+			ovsSoftLimitsConfig: agentconfig.OVSSoftLimitsConfig{
+				Meters: -1,
+			},
+			expectedErr: "meters must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{config: &agentconfig.AgentConfig{
+				OVSSoftLimits: tt.ovsSoftLimitsConfig,
+			}}
+			err := o.validateOVSSoftLimitsConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedOVSSoftLimits, o.ovsSoftLimits)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateNodeLatencyMonitorConfig(t *testing.T) {
+	tests := []struct {
+		name                 string
+		featureGateValue     bool
+		pingInterval         string
+		expectedErr          string
+		expectedPingInterval time.Duration
+	}{
+		{
+			name:         "feature disabled",
+			pingInterval: "foo",
+		},
+		{
+			name:             "default",
+			featureGateValue: true,
+		},
+		{
+			name:                 "valid",
+			featureGateValue:     true,
+			pingInterval:         "10s",
+			expectedPingInterval: 10 * time.Second,
+		},
+		{
+			name:             "invalid pingInterval",
+			featureGateValue: true,
+			pingInterval:     "1x",
+			expectedErr:      "pingInterval is invalid",
+		},
+		{
+			name:             "non-positive pingInterval",
+			featureGateValue: true,
+			pingInterval:     "0s",
+			expectedErr:      "pingInterval must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.NodeLatencyMonitor, tt.featureGateValue)()
+			o := &Options{config: &agentconfig.AgentConfig{
+				NodeLatencyMonitor: agentconfig.NodeLatencyMonitorConfig{PingInterval: tt.pingInterval},
+			}}
+			err := o.validateNodeLatencyMonitorConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedPingInterval, o.nodeLatencyMonitorPingInterval)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateOTelMetricsExporterConfig(t *testing.T) {
+	tests := []struct {
+		name                    string
+		enablePrometheusMetrics bool
+		exporterConfig          agentconfig.OTelMetricsExporterConfig
+		expectedErr             string
+		expectedInterval        time.Duration
+	}{
+		{
+			name:           "disabled",
+			exporterConfig: agentconfig.OTelMetricsExporterConfig{Endpoint: "foo"},
+		},
+		{
+			name:                    "default interval",
+			enablePrometheusMetrics: true,
+			exporterConfig:          agentconfig.OTelMetricsExporterConfig{Enable: true, Endpoint: "http://otel-collector:4318"},
+			expectedInterval:        defaultOTelMetricsExportInterval,
+		},
+		{
+			name:                    "custom interval",
+			enablePrometheusMetrics: true,
+			exporterConfig:          agentconfig.OTelMetricsExporterConfig{Enable: true, Endpoint: "https://otel-collector:4318", ExportInterval: "30s"},
+			expectedInterval:        30 * time.Second,
+		},
+		{
+			name:           "Prometheus metrics disabled",
+			exporterConfig: agentconfig.OTelMetricsExporterConfig{Enable: true, Endpoint: "http://otel-collector:4318"},
+			expectedErr:    "enablePrometheusMetrics must be true",
+		},
+		{
+			name:                    "invalid endpoint",
+			enablePrometheusMetrics: true,
+			exporterConfig:          agentconfig.OTelMetricsExporterConfig{Enable: true, Endpoint: "otel-collector:4318"},
+			expectedErr:             "scheme must be http or https",
+		},
+		{
+			name:                    "invalid interval",
+			enablePrometheusMetrics: true,
+			exporterConfig:          agentconfig.OTelMetricsExporterConfig{Enable: true, Endpoint: "http://otel-collector:4318", ExportInterval: "1x"},
+			expectedErr:             "exportInterval is invalid",
+		},
+		{
+			name:                    "too small interval",
+			enablePrometheusMetrics: true,
+			exporterConfig:          agentconfig.OTelMetricsExporterConfig{Enable: true, Endpoint: "http://otel-collector:4318", ExportInterval: "100ms"},
+			expectedErr:             "exportInterval must be at least 1s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{config: &agentconfig.AgentConfig{
+				EnablePrometheusMetrics: &tt.enablePrometheusMetrics,
+				OTelMetricsExporter:     tt.exporterConfig,
+			}}
+			err := o.validateOTelMetricsExporterConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedInterval, o.otelMetricsExportInterval)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateLoadBalancerModeDSRConfig(t *testing.T) {
+	tests := []struct {
+		name               string
+		featureGateValue   bool
+		antreaProxyEnabled bool
+		encapMode          config.TrafficEncapModeType
+		expectedErr        string
+	}{
+		{
+			name:      "feature disabled",
+			encapMode: config.TrafficEncapModeNoEncap,
+		},
+		{
+			name:               "encap mode",
+			featureGateValue:   true,
+			antreaProxyEnabled: true,
+			encapMode:          config.TrafficEncapModeEncap,
+		},
+		{
+			name:               "noEncap mode",
+			featureGateValue:   true,
+			antreaProxyEnabled: true,
+			encapMode:          config.TrafficEncapModeNoEncap,
+			expectedErr:        "LoadBalancerModeDSR is only applicable to the encap mode",
+		},
+		{
+			name:             "AntreaProxy disabled",
+			featureGateValue: true,
+			encapMode:        config.TrafficEncapModeEncap,
+			expectedErr:      "LoadBalancerModeDSR requires AntreaProxy to be enabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.AntreaProxy, tt.antreaProxyEnabled)()
+			defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.LoadBalancerModeDSR, tt.featureGateValue)()
+			o := &Options{config: &agentconfig.AgentConfig{}}
+			err := o.validateLoadBalancerModeDSRConfig(tt.encapMode)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateOVSOpenFlowConnectionConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		connConfig  agentconfig.OVSOpenFlowConnectionConfig
+		expectedErr string
+	}{
+		{
+			name: "local bridge",
+		},
+		{
+			name: "tcp",
+			connConfig: agentconfig.OVSOpenFlowConnectionConfig{
+				Address: "tcp:10.0.0.1:6653",
+			},
+		},
+		{
+			name: "ssl",
+			connConfig: agentconfig.OVSOpenFlowConnectionConfig{
+				Address:    "ssl:10.0.0.1:6653",
+				CACertFile: "/etc/antrea/ovs/ca.crt",
+				CertFile:   "/etc/antrea/ovs/tls.crt",
+				KeyFile:    "/etc/antrea/ovs/tls.key",
+			},
+		},
+		{
+			name: "ssl without certificates",
+			connConfig: agentconfig.OVSOpenFlowConnectionConfig{
+				Address: "ssl:10.0.0.1:6653",
+			},
+			expectedErr: "caCertFile, certFile and keyFile must be set for ssl connection",
+		},
+		{
+			name: "unsupported connection type",
+			connConfig: agentconfig.OVSOpenFlowConnectionConfig{
+				Address: "unix:/var/run/openvswitch/br-int.mgmt",
+			},
+			expectedErr: "unsupported connection type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{config: &agentconfig.AgentConfig{
+				OVSOpenFlowConnection: tt.connConfig,
+			}}
+			err := o.validateOVSOpenFlowConnectionConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateOVSPhysicalBridgeConfig(t *testing.T) {
+	tests := []struct {
+		name               string
+		physicalBridge     string
+		enableBridgingMode bool
+		ovsAddress         string
+		expectedErr        string
+	}{
+		{
+			name: "no physical bridge",
+		},
+		{
+			name:           "valid physical bridge",
+			physicalBridge: "br-phy",
+		},
+		{
+			name:           "same bridge as ovsBridge",
+			physicalBridge: "br-int",
+			expectedErr:    "ovsPhysicalBridge must be different from ovsBridge br-int",
+		},
+		{
+			name:               "bridging mode",
+			physicalBridge:     "br-phy",
+			enableBridgingMode: true,
+			expectedErr:        "ovsPhysicalBridge cannot be used together with bridging mode",
+		},
+		{
+			name:           "remote OVS instance",
+			physicalBridge: "br-phy",
+			ovsAddress:     "tcp:10.0.0.1:6653",
+			expectedErr:    "ovsPhysicalBridge cannot be used together with a remote OVS instance",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{config: &agentconfig.AgentConfig{
+				OVSBridge:          "br-int",
+				OVSPhysicalBridge:  tt.physicalBridge,
+				EnableBridgingMode: tt.enableBridgingMode,
+				OVSOpenFlowConnection: agentconfig.OVSOpenFlowConnectionConfig{
+					Address: tt.ovsAddress,
+				},
+			}}
+			err := o.validateOVSPhysicalBridgeConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateLoggingConfig(t *testing.T) {
+	negative := -1
+	tests := []struct {
+		name        string
+		config      *agentconfig.AgentConfig
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			config: &agentconfig.AgentConfig{
+				LogVerbosity: new(int),
+				PacketInRate: 500,
+				AuditLogging: agentconfig.AuditLoggingConfig{MaxSize: 100, EventSeverityThreshold: "High"},
+			},
+		},
+		{
+			name:        "negative logVerbosity",
+			config:      &agentconfig.AgentConfig{LogVerbosity: &negative},
+			expectedErr: "logVerbosity must not be negative",
+		},
+		{
+			name:        "negative packetInRate",
+			config:      &agentconfig.AgentConfig{PacketInRate: -1},
+			expectedErr: "packetInRate must be positive",
+		},
+		{
+			name:        "negative auditLogging.maxSize",
+			config:      &agentconfig.AgentConfig{AuditLogging: agentconfig.AuditLoggingConfig{MaxSize: -1}},
+			expectedErr: "auditLogging.maxSize must be positive",
+		},
+		{
+			name:        "negative auditLogging.maxBackups",
+			config:      &agentconfig.AgentConfig{AuditLogging: agentconfig.AuditLoggingConfig{MaxBackups: &negative}},
+			expectedErr: "auditLogging.maxBackups must not be negative",
+		},
+		{
+			name:        "negative auditLogging.maxAge",
+			config:      &agentconfig.AgentConfig{AuditLogging: agentconfig.AuditLoggingConfig{MaxAge: &negative}},
+			expectedErr: "auditLogging.maxAge must not be negative",
+		},
+		{
+			name:        "invalid auditLogging.eventSeverityThreshold",
+			config:      &agentconfig.AgentConfig{AuditLogging: agentconfig.AuditLoggingConfig{EventSeverityThreshold: "high"}},
+			expectedErr: `auditLogging.eventSeverityThreshold "high" is invalid, must be one of Low, Medium, High, Critical`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{config: tt.config}
+			err := o.validateLoggingConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateFlowExporterRecordFormat(t *testing.T) {
+	tests := []struct {
+		name              string
+		flowCollectorAddr string
+		recordFormat      string
+		expectedErr       string
+	}{
+		{
+			name:              "IPFIX",
+			flowCollectorAddr: "flow-aggregator/flow-aggregator:4739:tls",
+			recordFormat:      "IPFIX",
+		},
+		{
+			name:              "NetFlowV9",
+			flowCollectorAddr: "10.0.0.1:2055:udp",
+			recordFormat:      "NetFlowV9",
+		},
+		{
+			name:              "sFlow",
+			flowCollectorAddr: "10.0.0.1:6343:udp",
+			recordFormat:      "sFlow",
+		},
+		{
+			name:              "sFlow over tcp",
+			flowCollectorAddr: "10.0.0.1:6343:tcp",
+			recordFormat:      "sFlow",
+			expectedErr:       "record format sFlow is only supported with the udp transport protocol",
+		},
+		{
+			name:              "invalid format",
+			flowCollectorAddr: "10.0.0.1:2055:udp",
+			recordFormat:      "NetFlowV5",
+			expectedErr:       "record format NetFlowV5 is not supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.FlowExporter, true)()
+			o := &Options{config: &agentconfig.AgentConfig{
+				FlowExporter: agentconfig.FlowExporterConfig{
+					FlowCollectorAddr: tt.flowCollectorAddr,
+					RecordFormat:      tt.recordFormat,
+				},
+			}}
+			err := o.validateFlowExporterConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateFlowExporterExportFilter(t *testing.T) {
+	tests := []struct {
+		name         string
+		exportFilter agentconfig.FlowExportFilterConfig
+		expectedErr  string
+	}{
+		{
+			name: "valid filter",
+			exportFilter: agentconfig.FlowExportFilterConfig{
+				IncludeNamespaces: []string{"ns1"},
+				PodSelector:       "app=web,tier!=db",
+				Protocols:         []string{"tcp", "UDP"},
+			},
+		},
+		{
+			name:         "invalid Pod selector",
+			exportFilter: agentconfig.FlowExportFilterConfig{PodSelector: "app in ("},
+			expectedErr:  "invalid exportFilter.podSelector",
+		},
+		{
+			name:         "invalid protocol",
+			exportFilter: agentconfig.FlowExportFilterConfig{Protocols: []string{"GRE"}},
+			expectedErr:  "unsupported protocol GRE",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.FlowExporter, true)()
+			o := &Options{config: &agentconfig.AgentConfig{
+				FlowExporter: agentconfig.FlowExporterConfig{
+					FlowCollectorAddr: "flow-aggregator/flow-aggregator:4739:tls",
+					RecordFormat:      "IPFIX",
+					ExportFilter:      tt.exportFilter,
+				},
+			}}
+			err := o.validateFlowExporterConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsValidateTTLConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		ttlConfig   agentconfig.TTLConfig
+		expectedErr string
+	}{
+		{
+			name:      "default",
+			ttlConfig: agentconfig.TTLConfig{Decrement: pointer.Bool(true)},
+		},
+		{
+			name:      "decrement disabled",
+			ttlConfig: agentconfig.TTLConfig{Decrement: pointer.Bool(false)},
+		},
+		{
+			name:      "sendTimeExceeded enabled",
+			ttlConfig: agentconfig.TTLConfig{Decrement: pointer.Bool(true), SendTimeExceeded: true},
+		},
+		{
+			name:        "sendTimeExceeded enabled without decrement",
+			ttlConfig:   agentconfig.TTLConfig{Decrement: pointer.Bool(false), SendTimeExceeded: true},
+			expectedErr: "ttl.sendTimeExceeded requires ttl.decrement to be true",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &Options{config: &agentconfig.AgentConfig{
+				TTL: tt.ttlConfig,
+			}}
+			err := o.validateTTLConfig()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestOptionsExternalNodeAntreaProxy(t *testing.T) {
+	tests := []struct {
+		name                string
+		featureGates        map[string]bool
+		antreaProxyConfig   agentconfig.AntreaProxyConfig
+		expectedProxyEnable bool
+		expectedErr         string
+	}{
+		{
+			name:                "disabled by default",
+			expectedProxyEnable: false,
+		},
+		{
+			name:                "enabled explicitly",
+			featureGates:        map[string]bool{"ExternalNode": true, "AntreaProxy": true},
+			expectedProxyEnable: true,
+		},
+		{
+			name:                "proxyAll is not supported",
+			featureGates:        map[string]bool{"ExternalNode": true, "AntreaProxy": true},
+			antreaProxyConfig:   agentconfig.AntreaProxyConfig{ProxyAll: true},
+			expectedProxyEnable: true,
+			expectedErr:         "unsupported features on Virtual Machine: {AntreaProxy.ProxyAll}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.AntreaProxy, true)()
+			defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.ExternalNode, true)()
+			o := &Options{config: &agentconfig.AgentConfig{
+				FeatureGates:     tt.featureGates,
+				TrafficEncapMode: config.TrafficEncapModeNoEncap.String(),
+				AntreaProxy:      tt.antreaProxyConfig,
+			}}
+			require.NoError(t, o.resetVMDefaultFeatures())
+			assert.Equal(t, tt.expectedProxyEnable, features.DefaultFeatureGate.Enabled(features.AntreaProxy))
+			err := o.validateExternalNodeOptions()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
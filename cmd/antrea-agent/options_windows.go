@@ -60,6 +60,15 @@ func (o *Options) checkUnsupportedFeatures() error {
 	if o.config.EnableBridgingMode {
 		unsupported = append(unsupported, "EnableBridgingMode")
 	}
+	if o.config.OVSPhysicalBridge != "" {
+		unsupported = append(unsupported, "OVSPhysicalBridge")
+	}
+	if o.config.AntreaProxy.HostClusterIPAccess {
+		unsupported = append(unsupported, "HostClusterIPAccess")
+	}
+	if o.config.AntreaProxy.InstallKubeProxyCompatibilityRules {
+		unsupported = append(unsupported, "InstallKubeProxyCompatibilityRules")
+	}
 	if unsupported != nil {
 		return fmt.Errorf("unsupported features on Windows: {%s}", strings.Join(unsupported, ", "))
 	}
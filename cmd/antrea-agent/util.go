@@ -17,22 +17,31 @@ package main
 import (
 	"fmt"
 	"net"
+	"regexp"
 	"strconv"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
 	"antrea.io/antrea/pkg/agent/util"
+	agentconfig "antrea.io/antrea/pkg/config/agent"
 )
 
-var getAllNodeAddresses = util.GetAllNodeAddresses
+var (
+	getAllNodeAddresses         = util.GetAllNodeAddresses
+	getNodeAddressesByInterface = util.GetNodeAddressesByInterface
+)
 
-func getAvailableNodePortAddresses(nodePortAddressesFromConfig []string, excludeDevices []string) ([]net.IP, []net.IP, error) {
+func getAvailableNodePortAddresses(nodePortAddressesFromConfig []string, nodePortInterfacesFromConfig []agentconfig.NodePortInterface, excludeDevices []string) ([]net.IP, []net.IP, error) {
 	// Get all IP addresses of Node
 	nodeAddressesIPv4, nodeAddressesIPv6, err := getAllNodeAddresses(excludeDevices)
 	if err != nil {
 		return nil, nil, err
 	}
-	// If option `NodePortAddresses` is not set, then all Node IP addresses will be used as NodePort IP address.
-	if len(nodePortAddressesFromConfig) == 0 {
+	// If neither option `NodePortAddresses` nor option `NodePortInterfaces` is set, then all Node IP addresses will be
+	// used as NodePort IP address.
+	if len(nodePortAddressesFromConfig) == 0 && len(nodePortInterfacesFromConfig) == 0 {
 		return nodeAddressesIPv4, nodeAddressesIPv6, nil
 	}
 
@@ -43,15 +52,54 @@ func getAvailableNodePortAddresses(nodePortAddressesFromConfig []string, exclude
 	}
 
 	var nodePortAddressesIPv4, nodePortAddressesIPv6 []net.IP
+	selected := sets.New[string]()
+	addAddress := func(ip net.IP) {
+		if selected.Has(ip.String()) {
+			return
+		}
+		selected.Insert(ip.String())
+		if ip.To4() != nil {
+			nodePortAddressesIPv4 = append(nodePortAddressesIPv4, ip)
+		} else {
+			nodePortAddressesIPv6 = append(nodePortAddressesIPv6, ip)
+		}
+	}
 	for _, nodePortIPNet := range nodePortIPNets {
 		for i := range nodeAddressesIPv4 {
 			if nodePortIPNet.Contains(nodeAddressesIPv4[i]) {
-				nodePortAddressesIPv4 = append(nodePortAddressesIPv4, nodeAddressesIPv4[i])
+				addAddress(nodeAddressesIPv4[i])
 			}
 		}
 		for i := range nodeAddressesIPv6 {
 			if nodePortIPNet.Contains(nodeAddressesIPv6[i]) {
-				nodePortAddressesIPv6 = append(nodePortAddressesIPv6, nodeAddressesIPv6[i])
+				addAddress(nodeAddressesIPv6[i])
+			}
+		}
+	}
+
+	if len(nodePortInterfacesFromConfig) == 0 {
+		return nodePortAddressesIPv4, nodePortAddressesIPv6, nil
+	}
+	addressesByInterface, err := getNodeAddressesByInterface(excludeDevices)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Iterate the interfaces in a stable order so that the resolved addresses don't change between calls.
+	interfaceNames := sets.List(sets.KeySet(addressesByInterface))
+	for _, nodePortInterface := range nodePortInterfacesFromConfig {
+		// The name has been validated when loading the configuration.
+		nameRegexp := regexp.MustCompile("^(?:" + nodePortInterface.Name + ")$")
+		for _, interfaceName := range interfaceNames {
+			if !nameRegexp.MatchString(interfaceName) {
+				continue
+			}
+			for _, ip := range addressesByInterface[interfaceName] {
+				isIPv4 := ip.To4() != nil
+				if (nodePortInterface.IPFamily == string(corev1.IPv4Protocol) && !isIPv4) ||
+					(nodePortInterface.IPFamily == string(corev1.IPv6Protocol) && isIPv4) {
+					continue
+				}
+				addAddress(ip)
 			}
 		}
 	}
@@ -22,14 +22,16 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"antrea.io/antrea/pkg/agent/util"
+	agentconfig "antrea.io/antrea/pkg/config/agent"
 )
 
 func TestGetAvailableNodePortAddresses(t *testing.T) {
 	testCases := []struct {
-		name                        string
-		nodePortAddressesFromConfig []string
-		expectedIPv4                []net.IP
-		expectedIPv6                []net.IP
+		name                         string
+		nodePortAddressesFromConfig  []string
+		nodePortInterfacesFromConfig []agentconfig.NodePortInterface
+		expectedIPv4                 []net.IP
+		expectedIPv6                 []net.IP
 	}{
 		{
 			name:                        "empty nodePortAddresses",
@@ -43,19 +45,61 @@ func TestGetAvailableNodePortAddresses(t *testing.T) {
 			expectedIPv4:                []net.IP{net.ParseIP("192.168.225.234")},
 			expectedIPv6:                nil,
 		},
+		{
+			name: "nodePortInterfaces per IP family",
+			nodePortInterfacesFromConfig: []agentconfig.NodePortInterface{
+				{Name: "eth0", IPFamily: "IPv4"},
+				{Name: "eth1", IPFamily: "IPv6"},
+			},
+			expectedIPv4: []net.IP{net.ParseIP("192.168.225.234")},
+			expectedIPv6: []net.IP{net.ParseIP("2409:4071:4d11:f5d2:75ab:a5b6:ff05:b31e")},
+		},
+		{
+			name: "nodePortInterfaces with regular expression",
+			nodePortInterfacesFromConfig: []agentconfig.NodePortInterface{
+				{Name: "eth[0-9]+"},
+			},
+			expectedIPv4: []net.IP{net.ParseIP("192.168.225.234"), net.ParseIP("10.104.73.43")},
+			expectedIPv6: []net.IP{net.ParseIP("2409:4071:4d11:f5d2:71:e53f:7d28:668e"), net.ParseIP("2409:4071:4d11:f5d2:75ab:a5b6:ff05:b31e")},
+		},
+		{
+			name: "nodePortInterfaces matching whole name",
+			nodePortInterfacesFromConfig: []agentconfig.NodePortInterface{
+				{Name: "eth"},
+			},
+			expectedIPv4: nil,
+			expectedIPv6: nil,
+		},
+		{
+			name:                        "nodePortAddresses and nodePortInterfaces",
+			nodePortAddressesFromConfig: []string{"192.168.225.0/24", "127.0.0.0/8"},
+			nodePortInterfacesFromConfig: []agentconfig.NodePortInterface{
+				{Name: "eth0"},
+			},
+			expectedIPv4: []net.IP{net.ParseIP("192.168.225.234"), net.ParseIP("127.0.0.1")},
+			expectedIPv6: []net.IP{net.ParseIP("2409:4071:4d11:f5d2:71:e53f:7d28:668e")},
+		},
 	}
 	getAllNodeAddresses = func(excludeDevices []string) ([]net.IP, []net.IP, error) {
 		ipv4 := []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("192.168.225.234"), net.ParseIP("10.104.73.43")}
 		ipv6 := []net.IP{net.ParseIP("::1"), net.ParseIP("2409:4071:4d11:f5d2:71:e53f:7d28:668e"), net.ParseIP("2409:4071:4d11:f5d2:75ab:a5b6:ff05:b31e")}
 		return ipv4, ipv6, nil
 	}
+	getNodeAddressesByInterface = func(excludeDevices []string) (map[string][]net.IP, error) {
+		return map[string][]net.IP{
+			"lo":   {net.ParseIP("127.0.0.1"), net.ParseIP("::1")},
+			"eth0": {net.ParseIP("192.168.225.234"), net.ParseIP("2409:4071:4d11:f5d2:71:e53f:7d28:668e")},
+			"eth1": {net.ParseIP("10.104.73.43"), net.ParseIP("2409:4071:4d11:f5d2:75ab:a5b6:ff05:b31e")},
+		}, nil
+	}
 	defer func() {
 		getAllNodeAddresses = util.GetAllNodeAddresses
+		getNodeAddressesByInterface = util.GetNodeAddressesByInterface
 	}()
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			gotIPv4, gotIPv6, err := getAvailableNodePortAddresses(tc.nodePortAddressesFromConfig, []string{"antrea-egress0", "antrea-ingress0", "kube-ipvs0", "antrea-gw0"})
+			gotIPv4, gotIPv6, err := getAvailableNodePortAddresses(tc.nodePortAddressesFromConfig, tc.nodePortInterfacesFromConfig, []string{"antrea-egress0", "antrea-ingress0", "kube-ipvs0", "antrea-gw0"})
 			require.NoError(t, err)
 			assert.Equal(t, tc.expectedIPv4, gotIPv4)
 			assert.Equal(t, tc.expectedIPv6, gotIPv6)
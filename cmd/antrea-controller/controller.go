@@ -22,6 +22,7 @@ import (
 	"path"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apiextensionclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -32,8 +33,10 @@ import (
 	csrinformers "k8s.io/client-go/informers/certificates/v1"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	clientset "k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	csrlisters "k8s.io/client-go/listers/certificates/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	aggregatorclientset "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset"
 	netutils "k8s.io/utils/net"
@@ -42,14 +45,17 @@ import (
 	antreaapis "antrea.io/antrea/pkg/apis"
 	"antrea.io/antrea/pkg/apiserver"
 	"antrea.io/antrea/pkg/apiserver/certificate"
+	"antrea.io/antrea/pkg/apiserver/controlplanestream"
 	"antrea.io/antrea/pkg/apiserver/openapi"
 	"antrea.io/antrea/pkg/apiserver/storage"
+	crdscheme "antrea.io/antrea/pkg/client/clientset/versioned/scheme"
 	crdinformers "antrea.io/antrea/pkg/client/informers/externalversions"
 	crdv1a2informers "antrea.io/antrea/pkg/client/informers/externalversions/crd/v1alpha2"
 	"antrea.io/antrea/pkg/clusteridentity"
 	"antrea.io/antrea/pkg/controller/certificatesigningrequest"
 	"antrea.io/antrea/pkg/controller/egress"
 	egressstore "antrea.io/antrea/pkg/controller/egress/store"
+	"antrea.io/antrea/pkg/controller/externaliplease"
 	"antrea.io/antrea/pkg/controller/externalippool"
 	"antrea.io/antrea/pkg/controller/externalnode"
 	"antrea.io/antrea/pkg/controller/grouping"
@@ -104,6 +110,7 @@ var allowedPaths = []string{
 	"/mutate/anp",
 	"/mutate/namespace",
 	"/validate/tier",
+	"/validate/tierentitlement",
 	"/validate/acnp",
 	"/validate/annp",
 	"/validate/anp",
@@ -138,6 +145,7 @@ func run(o *Options) error {
 	eeInformer := crdInformerFactory.Crd().V1alpha2().ExternalEntities()
 	annpInformer := crdInformerFactory.Crd().V1alpha1().NetworkPolicies()
 	tierInformer := crdInformerFactory.Crd().V1alpha1().Tiers()
+	tierEntitlementInformer := crdInformerFactory.Crd().V1alpha1().TierEntitlements()
 	tfInformer := crdInformerFactory.Crd().V1alpha1().Traceflows()
 	cgInformer := crdInformerFactory.Crd().V1alpha3().ClusterGroups()
 	grpInformer := crdInformerFactory.Crd().V1alpha3().Groups()
@@ -170,6 +178,10 @@ func run(o *Options) error {
 	groupEntityIndex := grouping.NewGroupEntityIndex()
 	groupEntityController := grouping.NewGroupEntityController(groupEntityIndex, podInformer, namespaceInformer, eeInformer)
 	labelIdentityIndex := labelidentity.NewLabelIdentityIndex()
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: client.CoreV1().Events("")})
+	defer eventBroadcaster.Shutdown()
+	eventRecorder := eventBroadcaster.NewRecorder(crdscheme.Scheme, corev1.EventSource{Component: "antrea-controller"})
 	networkPolicyController := networkpolicy.NewNetworkPolicyController(client,
 		crdClient,
 		groupEntityIndex,
@@ -181,13 +193,19 @@ func run(o *Options) error {
 		acnpInformer,
 		annpInformer,
 		tierInformer,
+		tierEntitlementInformer,
 		cgInformer,
 		grpInformer,
 		addressGroupStore,
 		appliedToGroupStore,
 		networkPolicyStore,
 		groupStore,
+		eventRecorder,
 		enableMulticlusterNP)
+	networkPolicyController.SetFlowBudgetConfig(networkpolicy.FlowBudgetConfig{
+		MaxFlowsPerNode: o.config.NetworkPolicyFlowBudget.MaxFlowsPerNode,
+		Reject:          o.config.NetworkPolicyFlowBudget.Action == flowBudgetActionReject,
+	})
 
 	var externalNodeController *externalnode.ExternalNodeController
 	if features.DefaultFeatureGate.Enabled(features.ExternalNode) {
@@ -216,14 +234,19 @@ func run(o *Options) error {
 	var egressController *egress.EgressController
 	var externalIPPoolController *externalippool.ExternalIPPoolController
 	var externalIPController *serviceexternalip.ServiceExternalIPController
-	if features.DefaultFeatureGate.Enabled(features.Egress) || features.DefaultFeatureGate.Enabled(features.ServiceExternalIP) {
+	var externalIPLeaseController *externaliplease.Controller
+	if features.DefaultFeatureGate.Enabled(features.Egress) || features.DefaultFeatureGate.Enabled(features.ServiceExternalIP) ||
+		features.DefaultFeatureGate.Enabled(features.ExternalIPLease) {
 		externalIPPoolController = externalippool.NewExternalIPPoolController(
 			crdClient, externalIPPoolInformer,
 		)
 	}
+	if features.DefaultFeatureGate.Enabled(features.ExternalIPLease) {
+		externalIPLeaseController = externaliplease.NewController(client, env.GetAntreaNamespace(), externalIPPoolController)
+	}
 
 	var csrApprovingController *certificatesigningrequest.CSRApprovingController
-	var csrSigningController *certificatesigningrequest.IPsecCSRSigningController
+	var csrSigningController *certificatesigningrequest.CSRSigningController
 	var csrInformer cache.SharedIndexInformer
 	var csrLister csrlisters.CertificateSigningRequestLister
 	if features.DefaultFeatureGate.Enabled(features.IPsecCertAuth) {
@@ -238,6 +261,23 @@ func run(o *Options) error {
 		csrSigningController = certificatesigningrequest.NewIPsecCSRSigningController(client, csrInformer, csrLister, *o.config.IPsecCSRSignerConfig.SelfSignedCA)
 	}
 
+	// The CertificateSigningRequests of antrea-agent serving certificates are watched with a separate
+	// informer, as a field selector cannot match multiple signer names.
+	var agentServingCSRApprovingController *certificatesigningrequest.CSRApprovingController
+	var agentServingCSRSigningController *certificatesigningrequest.CSRSigningController
+	var agentServingCSRInformer cache.SharedIndexInformer
+	if features.DefaultFeatureGate.Enabled(features.AgentServingCertificate) {
+		agentServingCSRInformer = csrinformers.NewFilteredCertificateSigningRequestInformer(client, 0, nil, func(listOptions *metav1.ListOptions) {
+			listOptions.FieldSelector = fields.OneTermEqualSelector("spec.signerName", antreaapis.AntreaAgentServingCSRSignerName).String()
+		})
+		agentServingCSRLister := csrlisters.NewCertificateSigningRequestLister(agentServingCSRInformer.GetIndexer())
+
+		if *o.config.AgentServingCSRSignerConfig.AutoApprove {
+			agentServingCSRApprovingController = certificatesigningrequest.NewCSRApprovingController(client, agentServingCSRInformer, agentServingCSRLister)
+		}
+		agentServingCSRSigningController = certificatesigningrequest.NewAgentServingCSRSigningController(client, agentServingCSRInformer, agentServingCSRLister, *o.config.AgentServingCSRSignerConfig.SelfSignedCA)
+	}
+
 	if features.DefaultFeatureGate.Enabled(features.Egress) {
 		egressController = egress.NewEgressController(crdClient, groupEntityIndex, egressInformer, externalIPPoolController, egressGroupStore)
 	}
@@ -291,6 +331,7 @@ func run(o *Options) error {
 		egressController,
 		statsAggregator,
 		bundleCollectionController,
+		externalIPLeaseController,
 		*o.config.EnablePrometheusMetrics,
 		cipherSuites,
 		cipher.TLSVersionMap[o.config.TLSMinVersion])
@@ -379,10 +420,15 @@ func run(o *Options) error {
 		}
 	}
 
-	if features.DefaultFeatureGate.Enabled(features.Egress) || features.DefaultFeatureGate.Enabled(features.ServiceExternalIP) {
+	if features.DefaultFeatureGate.Enabled(features.Egress) || features.DefaultFeatureGate.Enabled(features.ServiceExternalIP) ||
+		features.DefaultFeatureGate.Enabled(features.ExternalIPLease) {
 		go externalIPPoolController.Run(stopCh)
 	}
 
+	if features.DefaultFeatureGate.Enabled(features.ExternalIPLease) {
+		go externalIPLeaseController.Run(stopCh)
+	}
+
 	if features.DefaultFeatureGate.Enabled(features.Egress) {
 		go egressController.Run(stopCh)
 	}
@@ -411,6 +457,14 @@ func run(o *Options) error {
 		go csrSigningController.Run(stopCh)
 	}
 
+	if features.DefaultFeatureGate.Enabled(features.AgentServingCertificate) {
+		go agentServingCSRInformer.Run(stopCh)
+		if *o.config.AgentServingCSRSignerConfig.AutoApprove {
+			go agentServingCSRApprovingController.Run(stopCh)
+		}
+		go agentServingCSRSigningController.Run(stopCh)
+	}
+
 	<-stopCh
 	klog.Info("Stopping Antrea controller")
 	return nil
@@ -476,6 +530,7 @@ func createAPIServerConfig(kubeconfig string,
 	egressController *egress.EgressController,
 	statsAggregator *stats.Aggregator,
 	bundleCollectionStore *supportbundlecollection.Controller,
+	externalIPLeaseController *externaliplease.Controller,
 	enableMetrics bool,
 	cipherSuites []uint16,
 	tlsMinVersion uint16) (*apiserver.Config, error) {
@@ -522,6 +577,8 @@ func createAPIServerConfig(kubeconfig string,
 	serverConfig.OpenAPIConfig.Info.Title = "Antrea"
 	serverConfig.EnableMetrics = enableMetrics
 	serverConfig.MinRequestTimeout = int(serverMinWatchTimeout.Seconds())
+	// The streams of the ControlplaneStream gRPC service last as long as watches.
+	serverConfig.LongRunningFunc = controlplanestream.WithLongRunningRequests(serverConfig.LongRunningFunc)
 	serverConfig.SecureServing.CipherSuites = cipherSuites
 	serverConfig.SecureServing.MinTLSVersion = tlsMinVersion
 
@@ -542,5 +599,6 @@ func createAPIServerConfig(kubeconfig string,
 		endpointQuerier,
 		npController,
 		egressController,
-		bundleCollectionStore), nil
+		bundleCollectionStore,
+		externalIPLeaseController), nil
 }
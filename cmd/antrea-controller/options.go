@@ -37,6 +37,9 @@ const (
 	ipamIPv6MaskLo      = 64
 	ipamIPv6MaskHi      = 126
 	ipamIPv6MaskDefault = 64
+
+	flowBudgetActionReject = "Reject"
+	flowBudgetActionWarn   = "Warn"
 )
 
 type Options struct {
@@ -89,6 +92,22 @@ func (o *Options) validate(args []string) error {
 		klog.InfoS("Multicluster feature gate is disabled. Multicluster.EnableStretchedNetworkPolicy is ignored")
 	}
 
+	if err := o.validateNetworkPolicyFlowBudgetOptions(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *Options) validateNetworkPolicyFlowBudgetOptions() error {
+	if o.config.NetworkPolicyFlowBudget.MaxFlowsPerNode < 0 {
+		return fmt.Errorf("networkPolicyFlowBudget.maxFlowsPerNode must not be negative")
+	}
+	switch o.config.NetworkPolicyFlowBudget.Action {
+	case flowBudgetActionReject, flowBudgetActionWarn:
+	default:
+		return fmt.Errorf("networkPolicyFlowBudget.action %q is invalid, must be %q or %q", o.config.NetworkPolicyFlowBudget.Action, flowBudgetActionReject, flowBudgetActionWarn)
+	}
 	return nil
 }
 
@@ -190,6 +209,15 @@ func (o *Options) setDefaults() {
 	if o.config.IPsecCSRSignerConfig.AutoApprove == nil {
 		o.config.IPsecCSRSignerConfig.AutoApprove = ptrBool(true)
 	}
+	if o.config.AgentServingCSRSignerConfig.SelfSignedCA == nil {
+		o.config.AgentServingCSRSignerConfig.SelfSignedCA = ptrBool(true)
+	}
+	if o.config.AgentServingCSRSignerConfig.AutoApprove == nil {
+		o.config.AgentServingCSRSignerConfig.AutoApprove = ptrBool(true)
+	}
+	if o.config.NetworkPolicyFlowBudget.Action == "" {
+		o.config.NetworkPolicyFlowBudget.Action = flowBudgetActionReject
+	}
 }
 
 func ptrBool(value bool) *bool {
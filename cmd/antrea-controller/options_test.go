@@ -33,6 +33,9 @@ func TestNewOptions(t *testing.T) {
 	assert.Equal(t, ipamIPv6MaskDefault, op.config.NodeIPAM.NodeCIDRMaskSizeIPv6)
 	assert.Equal(t, true, *op.config.IPsecCSRSignerConfig.SelfSignedCA)
 	assert.Equal(t, true, *op.config.IPsecCSRSignerConfig.AutoApprove)
+	assert.Equal(t, true, *op.config.AgentServingCSRSignerConfig.SelfSignedCA)
+	assert.Equal(t, true, *op.config.AgentServingCSRSignerConfig.AutoApprove)
+	assert.Equal(t, flowBudgetActionReject, op.config.NetworkPolicyFlowBudget.Action)
 }
 
 func TestValidateNodeIPAMControllerOptions(t *testing.T) {
@@ -174,3 +177,37 @@ func TestValidateNodeIPAMControllerOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateNetworkPolicyFlowBudgetOptions(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      controllerconfig.NetworkPolicyFlowBudgetConfig
+		expectedErr string
+	}{
+		{
+			name:   "valid config",
+			config: controllerconfig.NetworkPolicyFlowBudgetConfig{MaxFlowsPerNode: 10000, Action: flowBudgetActionWarn},
+		},
+		{
+			name:        "negative maxFlowsPerNode",
+			config:      controllerconfig.NetworkPolicyFlowBudgetConfig{MaxFlowsPerNode: -1, Action: flowBudgetActionReject},
+			expectedErr: "networkPolicyFlowBudget.maxFlowsPerNode must not be negative",
+		},
+		{
+			name:        "invalid action",
+			config:      controllerconfig.NetworkPolicyFlowBudgetConfig{MaxFlowsPerNode: 10000, Action: "Drop"},
+			expectedErr: `networkPolicyFlowBudget.action "Drop" is invalid, must be "Reject" or "Warn"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			o := &Options{config: &controllerconfig.ControllerConfig{NetworkPolicyFlowBudget: tc.config}}
+			err := o.validateNetworkPolicyFlowBudgetOptions()
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
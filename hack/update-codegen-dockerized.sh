@@ -58,8 +58,9 @@ MOCKGEN_TARGETS=(
   "pkg/agent/util/ipset Interface testing"
   "pkg/agent/util/iptables Interface testing mock_iptables_linux.go" # Must specify linux.go suffix, otherwise compilation would fail on windows platform as source file has linux build tag.
   "pkg/agent/util/netlink Interface testing mock_netlink_linux.go"
+  "pkg/agent/util/nftables Interface testing mock_nftables_linux.go"
   "pkg/antctl AntctlClient ."
-  "pkg/controller/networkpolicy EndpointQuerier testing"
+  "pkg/controller/networkpolicy EndpointQuerier,PolicyBundler testing"
   "pkg/controller/querier ControllerQuerier testing"
   "pkg/flowaggregator/exporter Interface testing"
   "pkg/ipfix IPFIXExportingProcess,IPFIXRegistry,IPFIXCollectingProcess,IPFIXAggregationProcess testing"
@@ -67,7 +68,7 @@ MOCKGEN_TARGETS=(
   "pkg/ovs/ovsconfig OVSBridgeClient testing"
   "pkg/ovs/ovsctl OVSCtlClient testing"
   "pkg/ovs/ovsctl OVSOfctlRunner,OVSAppctlRunner ."
-  "pkg/querier AgentNetworkPolicyInfoQuerier,AgentMulticastInfoQuerier,EgressQuerier testing"
+  "pkg/querier AgentNetworkPolicyInfoQuerier,AgentMulticastInfoQuerier,EgressQuerier,AgentConnectionQuerier,AgentOVSOffloadQuerier testing"
   "pkg/flowaggregator/querier FlowAggregatorQuerier testing"
   "pkg/flowaggregator/s3uploader S3UploaderAPI testing"
   "third_party/proxy Provider testing"
@@ -84,6 +85,8 @@ fi
 function generate_antrea_client_code {
   # Generate protobuf code for CNI gRPC service with protoc.
   protoc --go_out=plugins=grpc:. pkg/apis/cni/v1beta1/cni.proto
+  # Generate protobuf code for controlplane streaming gRPC service with protoc.
+  protoc --go_out=plugins=grpc:. pkg/apis/controlplanestream/v1alpha1/controlplanestream.proto
 
   # Generate clientset and apis code with K8s codegen tools.
   $GOPATH/bin/client-gen \
@@ -57,6 +57,10 @@ type MultiClusterConfig struct {
 	// ClusterSet and allow Antrea-native policies to select peers from other clusters
 	// in a ClusterSet.
 	EnableStretchedNetworkPolicy bool `json:"enableStretchedNetworkPolicy,omitempty"`
+	// Enable active-active Gateways, which creates a Gateway for every ready Node with
+	// the Gateway annotation, instead of a single active Gateway. Cross-cluster
+	// connections are hashed across all active Gateways by Antrea Agents.
+	EnableActiveActiveGateways bool `json:"enableActiveActiveGateways,omitempty"`
 }
 
 func init() {
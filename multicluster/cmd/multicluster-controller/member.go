@@ -109,7 +109,8 @@ func runMember(o *Options) error {
 		mgr.GetScheme(),
 		env.GetPodNamespace(),
 		opts.ServiceCIDR,
-		opts.GatewayIPPrecedence)
+		opts.GatewayIPPrecedence,
+		opts.EnableActiveActiveGateways)
 	if err = nodeReconciler.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("error creating Node controller: %v", err)
 	}
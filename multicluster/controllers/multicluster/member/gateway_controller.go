@@ -18,6 +18,7 @@ package member
 
 import (
 	"context"
+	"sort"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -48,7 +49,8 @@ type (
 )
 
 // NewGatewayReconciler creates a GatewayReconciler which will watch Gateway events
-// and create a ClusterInfo kind of ResourceExport in the leader cluster.
+// and create a ClusterInfo kind of ResourceExport in the leader cluster. The
+// ClusterInfo includes all active Gateways of the member cluster.
 func NewGatewayReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
@@ -94,52 +96,54 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		},
 	}
 
-	createOrUpdate := func(gateway *mcsv1alpha1.Gateway) error {
+	createOrUpdate := func(gateways []mcsv1alpha1.Gateway) error {
 		existingResExport := &mcsv1alpha1.ResourceExport{}
 		err := commonArea.Get(ctx, resExportNamespacedName, existingResExport)
 		if err != nil && !apierrors.IsNotFound(err) {
 			return err
 		}
 		if apierrors.IsNotFound(err) || !existingResExport.DeletionTimestamp.IsZero() {
-			if err = r.createResourceExport(ctx, req, commonArea, gateway); err != nil {
+			if err = r.createResourceExport(ctx, req, commonArea, gateways); err != nil {
 				return err
 			}
 			return nil
 		}
 		// updateResourceExport will update latest Gateway information with the existing ResourceExport's resourceVersion.
 		// It will return an error and retry when there is a version conflict.
-		if err = r.updateResourceExport(ctx, req, commonArea, existingResExport, gateway); err != nil {
+		if err = r.updateResourceExport(ctx, req, commonArea, existingResExport, gateways); err != nil {
 			return err
 		}
 		return nil
 	}
 
-	gw := &mcsv1alpha1.Gateway{}
-	if err := r.Client.Get(ctx, req.NamespacedName, gw); err != nil {
-		if !apierrors.IsNotFound(err) {
-			return ctrl.Result{}, err
-		}
+	// There can be multiple active Gateways when active-active Gateways are enabled, so
+	// the ClusterInfo is always generated from all Gateways in the Namespace.
+	gwList := &mcsv1alpha1.GatewayList{}
+	if err := r.Client.List(ctx, gwList, &client.ListOptions{Namespace: r.namespace}); err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(gwList.Items) == 0 {
 		if err := commonArea.Delete(ctx, resExport, &client.DeleteOptions{}); err != nil {
 			return ctrl.Result{}, client.IgnoreNotFound(err)
 		}
 		return ctrl.Result{}, nil
 	}
 
-	if err := createOrUpdate(gw); err != nil {
+	if err := createOrUpdate(gwList.Items); err != nil {
 		return ctrl.Result{}, err
 	}
 	return ctrl.Result{}, nil
 }
 
 func (r *GatewayReconciler) updateResourceExport(ctx context.Context, req ctrl.Request,
-	commonArea commonarea.RemoteCommonArea, existingResExport *mcsv1alpha1.ResourceExport, gw *mcsv1alpha1.Gateway) error {
+	commonArea commonarea.RemoteCommonArea, existingResExport *mcsv1alpha1.ResourceExport, gateways []mcsv1alpha1.Gateway) error {
 	resExportSpec := mcsv1alpha1.ResourceExportSpec{
 		Kind:      constants.ClusterInfoKind,
 		ClusterID: r.localClusterID,
 		Name:      r.localClusterID,
 		Namespace: r.namespace,
 	}
-	resExportSpec.ClusterInfo = r.getClusterInfo(gw)
+	resExportSpec.ClusterInfo = r.getClusterInfo(gateways)
 	klog.V(2).InfoS("Updating ClusterInfo kind of ResourceExport", "clusterinfo", klog.KObj(existingResExport),
 		"gateway", req.NamespacedName)
 	existingResExport.Spec = resExportSpec
@@ -150,14 +154,14 @@ func (r *GatewayReconciler) updateResourceExport(ctx context.Context, req ctrl.R
 }
 
 func (r *GatewayReconciler) createResourceExport(ctx context.Context, req ctrl.Request,
-	commonArea commonarea.RemoteCommonArea, gateway *mcsv1alpha1.Gateway) error {
+	commonArea commonarea.RemoteCommonArea, gateways []mcsv1alpha1.Gateway) error {
 	resExportSpec := mcsv1alpha1.ResourceExportSpec{
 		Kind:      constants.ClusterInfoKind,
 		ClusterID: r.localClusterID,
 		Name:      r.localClusterID,
 		Namespace: r.namespace,
 	}
-	resExportSpec.ClusterInfo = r.getClusterInfo(gateway)
+	resExportSpec.ClusterInfo = r.getClusterInfo(gateways)
 	resExport := &mcsv1alpha1.ResourceExport{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: r.leaderNamespace,
@@ -185,16 +189,20 @@ func (r *GatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-func (r *GatewayReconciler) getClusterInfo(gateway *mcsv1alpha1.Gateway) *mcsv1alpha1.ClusterInfo {
+// getClusterInfo generates the ClusterInfo from the Gateways sorted by name. The
+// first Gateway is used as the tunnel peer by the other member clusters.
+func (r *GatewayReconciler) getClusterInfo(gateways []mcsv1alpha1.Gateway) *mcsv1alpha1.ClusterInfo {
+	sort.Slice(gateways, func(i, j int) bool {
+		return gateways[i].Name < gateways[j].Name
+	})
+	gateway := gateways[0]
 	clusterInfo := &mcsv1alpha1.ClusterInfo{
 		ClusterID:   r.localClusterID,
 		ServiceCIDR: gateway.ServiceCIDR,
 		PodCIDRs:    r.podCIDRs,
-		GatewayInfos: []mcsv1alpha1.GatewayInfo{
-			{
-				GatewayIP: gateway.GatewayIP,
-			},
-		},
+	}
+	for _, gw := range gateways {
+		clusterInfo.GatewayInfos = append(clusterInfo.GatewayInfos, mcsv1alpha1.GatewayInfo{GatewayIP: gw.GatewayIP})
 	}
 	if gateway.WireGuard != nil && gateway.WireGuard.PublicKey != "" {
 		clusterInfo.WireGuard = &mcsv1alpha1.WireGuardInfo{
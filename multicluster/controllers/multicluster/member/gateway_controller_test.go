@@ -51,6 +51,15 @@ var (
 		InternalIP: "172.11.10.1",
 	}
 
+	gwNode2 = mcsv1alpha1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "node-2",
+			Namespace: "default",
+		},
+		GatewayIP:  "10.10.10.11",
+		InternalIP: "172.11.10.2",
+	}
+
 	existingResExport = &mcsv1alpha1.ResourceExport{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "cluster-a-clusterinfo",
@@ -130,6 +139,42 @@ func TestGatewayReconciler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "update a ResourceExport successfully with multiple active Gateways",
+			namespacedName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "node-2",
+			},
+			gateway: []mcsv1alpha1.Gateway{
+				gwNode2,
+				gwNode1,
+			},
+			resExport: existingResExport,
+			expectedInfo: []mcsv1alpha1.GatewayInfo{
+				{
+					GatewayIP: "10.10.10.10",
+				},
+				{
+					GatewayIP: "10.10.10.11",
+				},
+			},
+		},
+		{
+			name: "update a ResourceExport successfully by deleting one of the active Gateways",
+			namespacedName: types.NamespacedName{
+				Namespace: "default",
+				Name:      "node-1",
+			},
+			gateway: []mcsv1alpha1.Gateway{
+				gwNode2,
+			},
+			resExport: existingResExport,
+			expectedInfo: []mcsv1alpha1.GatewayInfo{
+				{
+					GatewayIP: "10.10.10.11",
+				},
+			},
+		},
 		{
 			name: "delete a ResourceExport successfully by deleting an existing Gateway",
 			namespacedName: types.NamespacedName{
@@ -213,5 +258,5 @@ func TestGetClusterInfo(t *testing.T) {
 		},
 	}
 
-	assert.Equal(t, expectedClusterInfo, r.getClusterInfo(gw))
+	assert.Equal(t, expectedClusterInfo, r.getClusterInfo([]mcsv1alpha1.Gateway{*gw}))
 }
@@ -50,6 +50,9 @@ type (
 		activeGateway     string
 		serviceCIDR       string
 		initialized       bool
+		// activeActive indicates whether to create a Gateway for every ready
+		// Gateway candidate instead of a single active Gateway.
+		activeActive bool
 	}
 )
 
@@ -57,13 +60,15 @@ type (
 // It's responsible for creating a Gateway for the first ready Node with
 // annotation `multicluster.antrea.io/gateway:true` if there is no existing Gateway.
 // It guarantees there is always only one Gateway CR when there are multiple Nodes
-// with annotation `multicluster.antrea.io/gateway:true`.
+// with annotation `multicluster.antrea.io/gateway:true`, unless activeActive is true,
+// in which case a Gateway is created for every ready Node with the annotation.
 func NewNodeReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
 	namespace string,
 	serviceCIDR string,
-	precedence mcsv1alpha1.Precedence) *NodeReconciler {
+	precedence mcsv1alpha1.Precedence,
+	activeActive bool) *NodeReconciler {
 	if string(precedence) == "" {
 		precedence = mcsv1alpha1.PrecedenceInternal
 	}
@@ -74,6 +79,7 @@ func NewNodeReconciler(
 		serviceCIDR:       serviceCIDR,
 		precedence:        precedence,
 		gatewayCandidates: make(map[string]bool),
+		activeActive:      activeActive,
 	}
 	return reconciler
 }
@@ -131,6 +137,13 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		isValidGateway = err == nil
 	}
 
+	if r.activeActive {
+		if err := r.reconcileActiveActiveGateway(ctx, gw, isValidGateway && isReadyNode(node)); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
 	if isActiveGateway {
 		if !isValidGateway || !isReadyNode(node) {
 			if err := r.recreateActiveGateway(ctx, gw); err != nil {
@@ -165,8 +178,23 @@ func (r *NodeReconciler) initialize() error {
 	if err := r.Client.List(ctx, gwList, &client.ListOptions{}); err != nil {
 		return err
 	}
-	// Gateway webhook guarantees that there is at most one Gateway in the member cluster.
-	if len(gwList.Items) > 0 {
+	if r.activeActive {
+		// Remove the Gateways of deleted Nodes. The Gateways of existing Nodes will be
+		// reconciled with the Node events.
+		for i := range gwList.Items {
+			gw := &gwList.Items[i]
+			if err := r.Client.Get(ctx, types.NamespacedName{Name: gw.Name}, &corev1.Node{}); err != nil {
+				if !apierrors.IsNotFound(err) {
+					return err
+				}
+				if err := r.Client.Delete(ctx, gw, &client.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+					return err
+				}
+			}
+		}
+	} else if len(gwList.Items) > 0 {
+		// NodeReconciler guarantees that there is at most one Gateway in the member cluster
+		// when active-active Gateways are not enabled.
 		existingGWName := gwList.Items[0].Name
 		node := &corev1.Node{}
 		if err := r.Client.Get(ctx, types.NamespacedName{Name: existingGWName}, node); err != nil {
@@ -194,6 +222,26 @@ func (r *NodeReconciler) initialize() error {
 	return nil
 }
 
+// reconcileActiveActiveGateway creates or updates the Gateway of a Node when it is a
+// ready Gateway candidate with valid IPs, and deletes the Gateway otherwise. The Gateway
+// of a Node which becomes not ready is removed right away, so that Antrea Agents can
+// stop forwarding cross-cluster traffic to it.
+func (r *NodeReconciler) reconcileActiveActiveGateway(ctx context.Context, gateway *mcsv1alpha1.Gateway, isActive bool) error {
+	if !isActive {
+		err := r.Client.Delete(ctx, gateway, &client.DeleteOptions{})
+		return client.IgnoreNotFound(err)
+	}
+	existingGW := &mcsv1alpha1.Gateway{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: gateway.Name, Namespace: r.namespace}, existingGW); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		klog.InfoS("Creating an active Gateway", "node", gateway.Name)
+		return r.Client.Create(ctx, gateway, &client.CreateOptions{})
+	}
+	return r.updateActiveGateway(ctx, gateway)
+}
+
 func (r *NodeReconciler) updateActiveGateway(ctx context.Context, newGateway *mcsv1alpha1.Gateway) error {
 	existingGW := &mcsv1alpha1.Gateway{}
 	// TODO: cache might be stale. Need to revisit here and other reconcilers to
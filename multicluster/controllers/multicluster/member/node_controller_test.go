@@ -231,7 +231,7 @@ func TestNodeReconciler(t *testing.T) {
 				obj = append(obj, tt.existingGW)
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(common.TestScheme).WithObjects(obj...).Build()
-			r := NewNodeReconciler(fakeClient, common.TestScheme, "default", "10.100.0.0/16", tt.precedence)
+			r := NewNodeReconciler(fakeClient, common.TestScheme, "default", "10.100.0.0/16", tt.precedence, false)
 			r.activeGateway = tt.activeGateway
 			if _, err := r.Reconcile(common.TestCtx, tt.req); err != nil {
 				t.Errorf("Node Reconciler should handle Node events successfully but got error = %v", err)
@@ -260,6 +260,82 @@ func TestNodeReconciler(t *testing.T) {
 	}
 }
 
+func TestNodeReconcilerWithActiveActiveGateways(t *testing.T) {
+	initializeCommonData()
+	gateway2 := updatedGateway2.DeepCopy()
+	gateway2.GatewayIP = "10.10.10.11"
+	gateway4 := gwNode1.DeepCopy()
+	gateway4.Name = "node-4"
+
+	tests := []struct {
+		name        string
+		nodes       []*corev1.Node
+		existingGWs []*mcsv1alpha1.Gateway
+		req         reconcile.Request
+		expectedGWs []*mcsv1alpha1.Gateway
+	}{
+		{
+			name:        "create a Gateway when another Gateway exists",
+			nodes:       []*corev1.Node{node1, node2},
+			existingGWs: []*mcsv1alpha1.Gateway{&gwNode1},
+			req:         reconcile.Request{NamespacedName: types.NamespacedName{Name: node2.Name}},
+			expectedGWs: []*mcsv1alpha1.Gateway{&gwNode1, updatedGateway2},
+		},
+		{
+			name:        "update a Gateway",
+			nodes:       []*corev1.Node{node1, node2},
+			existingGWs: []*mcsv1alpha1.Gateway{&gwNode1, gateway2},
+			req:         reconcile.Request{NamespacedName: types.NamespacedName{Name: node2.Name}},
+			expectedGWs: []*mcsv1alpha1.Gateway{&gwNode1, updatedGateway2},
+		},
+		{
+			name:        "delete the Gateway of a not ready Node",
+			nodes:       []*corev1.Node{node1, node3},
+			existingGWs: []*mcsv1alpha1.Gateway{&gwNode1, gateway3},
+			req:         reconcile.Request{NamespacedName: types.NamespacedName{Name: node3.Name}},
+			expectedGWs: []*mcsv1alpha1.Gateway{&gwNode1},
+		},
+		{
+			name:        "delete the Gateway of a Node without valid IP",
+			nodes:       []*corev1.Node{node1, node4},
+			existingGWs: []*mcsv1alpha1.Gateway{&gwNode1, gateway4},
+			req:         reconcile.Request{NamespacedName: types.NamespacedName{Name: node4.Name}},
+			expectedGWs: []*mcsv1alpha1.Gateway{&gwNode1},
+		},
+		{
+			name:        "delete the Gateway of a deleted Node",
+			nodes:       []*corev1.Node{node2},
+			existingGWs: []*mcsv1alpha1.Gateway{&gwNode1, updatedGateway2},
+			req:         reconcile.Request{NamespacedName: types.NamespacedName{Name: node1.Name}},
+			expectedGWs: []*mcsv1alpha1.Gateway{updatedGateway2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var obj []client.Object
+			for _, n := range tt.nodes {
+				obj = append(obj, n)
+			}
+			for _, gw := range tt.existingGWs {
+				obj = append(obj, gw.DeepCopy())
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(common.TestScheme).WithObjects(obj...).Build()
+			r := NewNodeReconciler(fakeClient, common.TestScheme, "default", "10.100.0.0/16", mcsv1alpha1.PrecedencePublic, true)
+			_, err := r.Reconcile(common.TestCtx, tt.req)
+			assert.NoError(t, err)
+
+			gwList := &mcsv1alpha1.GatewayList{}
+			assert.NoError(t, fakeClient.List(common.TestCtx, gwList, &client.ListOptions{}))
+			assert.Equal(t, len(tt.expectedGWs), len(gwList.Items))
+			for i, gw := range gwList.Items {
+				assert.Equal(t, tt.expectedGWs[i].Name, gw.Name)
+				assert.Equal(t, tt.expectedGWs[i].GatewayIP, gw.GatewayIP)
+				assert.Equal(t, tt.expectedGWs[i].InternalIP, gw.InternalIP)
+			}
+		})
+	}
+}
+
 func TestInitialize(t *testing.T) {
 	initializeCommonData()
 	node5 := node1.DeepCopy()
@@ -307,7 +383,7 @@ func TestInitialize(t *testing.T) {
 				obj = append(obj, tt.existingGW)
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(common.TestScheme).WithObjects(obj...).Build()
-			r := NewNodeReconciler(fakeClient, common.TestScheme, "default", "10.100.0.0/16", mcsv1alpha1.PrecedencePublic)
+			r := NewNodeReconciler(fakeClient, common.TestScheme, "default", "10.100.0.0/16", mcsv1alpha1.PrecedencePublic, false)
 			if err := r.initialize(); err != nil {
 				t.Errorf("Expected initialize() successfully but got err: %v", err)
 			} else {
@@ -0,0 +1,126 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package afxdp supports running the OVS userspace (netdev) datapath, with which the network devices attached to the
+// OVS bridge are accessed by OVS through AF_XDP sockets instead of the OVS kernel module.
+package afxdp
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/metrics"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+)
+
+const (
+	CategoryPod    = "pod"
+	CategoryUplink = "uplink"
+	CategoryOther  = "other"
+
+	DefaultSampleInterval = 30 * time.Second
+)
+
+// categories lists the interface categories in the order in which they are reported.
+var categories = []string{CategoryPod, CategoryUplink, CategoryOther}
+
+// statistics lists the OVS Interface statistics which are reported. The per-queue statistics of the AF_XDP sockets
+// are not reported, to keep the cardinality of the metrics bounded.
+var statistics = []string{"rx_packets", "rx_bytes", "rx_dropped", "rx_errors", "tx_packets", "tx_bytes", "tx_dropped", "tx_errors"}
+
+// CheckOVSSupport checks whether OVS is built with AF_XDP support, i.e. whether it supports the "afxdp" Interface
+// type.
+func CheckOVSSupport(ovsBridgeClient ovsconfig.OVSBridgeClient) error {
+	ifTypes, err := ovsBridgeClient.GetOVSInterfaceTypes()
+	if err != nil {
+		return fmt.Errorf("failed to get the Interface types supported by OVS: %w", err)
+	}
+	if !sets.New[string](ifTypes...).Has(ovsconfig.AFXDPInterfaceType) {
+		return fmt.Errorf("OVS is not built with AF_XDP support, supported Interface types: %v", ifTypes)
+	}
+	return nil
+}
+
+// Monitor samples the statistics of the OVS AF_XDP Interfaces periodically, and reports their sums as metrics for
+// each category of interface.
+type Monitor struct {
+	ovsBridgeClient ovsconfig.OVSBridgeClient
+	ifaceStore      interfacestore.InterfaceStore
+	sampleInterval  time.Duration
+}
+
+func NewMonitor(ovsBridgeClient ovsconfig.OVSBridgeClient, ifaceStore interfacestore.InterfaceStore, sampleInterval time.Duration) *Monitor {
+	if sampleInterval <= 0 {
+		sampleInterval = DefaultSampleInterval
+	}
+	return &Monitor{
+		ovsBridgeClient: ovsBridgeClient,
+		ifaceStore:      ifaceStore,
+		sampleInterval:  sampleInterval,
+	}
+}
+
+// Run samples the statistics of the AF_XDP Interfaces periodically until stopCh is closed.
+func (m *Monitor) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Starting AF_XDP interface monitor", "sampleInterval", m.sampleInterval)
+	wait.Until(m.sample, m.sampleInterval, stopCh)
+}
+
+// categorize returns the category of an interface according to its type in the InterfaceStore.
+func (m *Monitor) categorize(name string) string {
+	iface, ok := m.ifaceStore.GetInterfaceByName(name)
+	if !ok {
+		return CategoryOther
+	}
+	switch iface.Type {
+	case interfacestore.ContainerInterface:
+		return CategoryPod
+	case interfacestore.UplinkInterface:
+		return CategoryUplink
+	}
+	return CategoryOther
+}
+
+func (m *Monitor) sample() {
+	ifStats, err := m.ovsBridgeClient.GetInterfaceStatistics(ovsconfig.AFXDPInterfaceType)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get the statistics of the AF_XDP interfaces")
+		return
+	}
+
+	counts := make(map[string]int, len(categories))
+	sums := make(map[string]map[string]int64, len(categories))
+	for _, category := range categories {
+		sums[category] = make(map[string]int64, len(statistics))
+	}
+	for name, stats := range ifStats {
+		category := m.categorize(name)
+		counts[category]++
+		for _, statistic := range statistics {
+			sums[category][statistic] += stats[statistic]
+		}
+	}
+
+	for _, category := range categories {
+		metrics.OVSAFXDPInterfaceCount.WithLabelValues(category).Set(float64(counts[category]))
+		for _, statistic := range statistics {
+			metrics.OVSAFXDPInterfaceStatistics.WithLabelValues(category, statistic).Set(float64(sums[category][statistic]))
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package afxdp
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/component-base/metrics/testutil"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/metrics"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+	ovsconfigtest "antrea.io/antrea/pkg/ovs/ovsconfig/testing"
+)
+
+func TestCheckOVSSupport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ovsBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(ctrl)
+
+	ovsBridgeClient.EXPECT().GetOVSInterfaceTypes().Return([]string{"afxdp", "internal", "tap"}, nil)
+	assert.NoError(t, CheckOVSSupport(ovsBridgeClient))
+	ovsBridgeClient.EXPECT().GetOVSInterfaceTypes().Return([]string{"internal", "tap"}, nil)
+	assert.ErrorContains(t, CheckOVSSupport(ovsBridgeClient), "not built with AF_XDP support")
+	ovsBridgeClient.EXPECT().GetOVSInterfaceTypes().Return(nil, ovsconfig.NewTransactionError(fmt.Errorf("connection refused"), true))
+	assert.Error(t, CheckOVSSupport(ovsBridgeClient))
+}
+
+func TestSample(t *testing.T) {
+	metrics.InitializeAFXDPMetrics()
+	ctrl := gomock.NewController(t)
+	ovsBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(ctrl)
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(interfacestore.NewContainerInterface("pod1-abc", "container1", "pod1", "ns1", nil, nil, 0))
+	ifaceStore.AddInterface(interfacestore.NewContainerInterface("pod2-abc", "container2", "pod2", "ns1", nil, nil, 0))
+	ifaceStore.AddInterface(interfacestore.NewUplinkInterface("eth0~"))
+	m := NewMonitor(ovsBridgeClient, ifaceStore, 0)
+
+	ovsBridgeClient.EXPECT().GetInterfaceStatistics(ovsconfig.AFXDPInterfaceType).Return(map[string]map[string]int64{
+		"pod1-abc": {"rx_packets": 10, "rx_bytes": 1000, "tx_packets": 5, "queue_0_rx_dropped": 1},
+		"pod2-abc": {"rx_packets": 20, "rx_bytes": 2000, "rx_dropped": 2},
+		"eth0~":    {"rx_packets": 100, "tx_packets": 200, "tx_dropped": 3},
+		"unknown":  {"rx_packets": 1},
+	}, nil)
+	m.sample()
+
+	for category, expected := range map[string]struct {
+		count int
+		stats map[string]int64
+	}{
+		CategoryPod:    {2, map[string]int64{"rx_packets": 30, "rx_bytes": 3000, "rx_dropped": 2, "tx_packets": 5}},
+		CategoryUplink: {1, map[string]int64{"rx_packets": 100, "tx_packets": 200, "tx_dropped": 3}},
+		CategoryOther:  {1, map[string]int64{"rx_packets": 1}},
+	} {
+		count, err := testutil.GetGaugeMetricValue(metrics.OVSAFXDPInterfaceCount.WithLabelValues(category))
+		require.NoError(t, err)
+		assert.Equal(t, float64(expected.count), count, "category %s", category)
+		for _, statistic := range statistics {
+			value, err := testutil.GetGaugeMetricValue(metrics.OVSAFXDPInterfaceStatistics.WithLabelValues(category, statistic))
+			require.NoError(t, err)
+			assert.Equal(t, float64(expected.stats[statistic]), value, "category %s, statistic %s", category, statistic)
+		}
+	}
+
+	// The statistics are not updated when they cannot be retrieved.
+	ovsBridgeClient.EXPECT().GetInterfaceStatistics(ovsconfig.AFXDPInterfaceType).Return(nil, ovsconfig.NewTransactionError(fmt.Errorf("connection refused"), true))
+	m.sample()
+	count, _ := testutil.GetGaugeMetricValue(metrics.OVSAFXDPInterfaceCount.WithLabelValues(CategoryPod))
+	assert.Equal(t, float64(2), count)
+}
@@ -0,0 +1,35 @@
+//go:build linux
+// +build linux
+
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package afxdp
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// CheckKernelSupport checks whether the kernel supports AF_XDP sockets, by creating one. AF_XDP is available since
+// Linux 4.18, but it can be disabled at build time with CONFIG_XDP_SOCKETS.
+func CheckKernelSupport() error {
+	fd, err := unix.Socket(unix.AF_XDP, unix.SOCK_RAW, 0)
+	if err != nil {
+		return fmt.Errorf("failed to create AF_XDP socket, the kernel may not support it: %w", err)
+	}
+	unix.Close(fd)
+	return nil
+}
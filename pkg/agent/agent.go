@@ -36,6 +36,7 @@ import (
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 
+	"antrea.io/antrea/pkg/agent/afxdp"
 	"antrea.io/antrea/pkg/agent/cniserver"
 	"antrea.io/antrea/pkg/agent/config"
 	"antrea.io/antrea/pkg/agent/controller/noderoute"
@@ -76,6 +77,10 @@ var (
 	// getIPNetDeviceByV4CIDR is meant to be overridden for testing.
 	getIPNetDeviceByCIDRs = util.GetIPNetDeviceByCIDRs
 
+	// flowSyncCheckInterval and maxFlowSyncWait are meant to be overridden for testing.
+	flowSyncCheckInterval = time.Second
+	maxFlowSyncWait       = 2 * time.Minute
+
 	// getTransportIPNetDeviceByName is meant to be overridden for testing.
 	getTransportIPNetDeviceByName = GetTransportIPNetDeviceByName
 
@@ -95,6 +100,7 @@ type Initializer struct {
 	client                clientset.Interface
 	crdClient             versioned.Interface
 	ovsBridgeClient       ovsconfig.OVSBridgeClient
+	physicalBridgeClient  ovsconfig.OVSBridgeClient // nil if there is no OVS physical bridge
 	ovsCtlClient          ovsctl.OVSCtlClient
 	ofClient              openflow.Client
 	routeClient           route.Interface
@@ -117,12 +123,14 @@ type Initializer struct {
 	stopCh                <-chan struct{}
 	nodeType              config.NodeType
 	externalNodeNamespace string
+	roundInfo             types.RoundInfo
 }
 
 func NewInitializer(
 	k8sClient clientset.Interface,
 	crdClient versioned.Interface,
 	ovsBridgeClient ovsconfig.OVSBridgeClient,
+	physicalBridgeClient ovsconfig.OVSBridgeClient,
 	ovsCtlClient ovsctl.OVSCtlClient,
 	ofClient openflow.Client,
 	routeClient route.Interface,
@@ -143,6 +151,7 @@ func NewInitializer(
 ) *Initializer {
 	return &Initializer{
 		ovsBridgeClient:       ovsBridgeClient,
+		physicalBridgeClient:  physicalBridgeClient,
 		ovsCtlClient:          ovsCtlClient,
 		client:                k8sClient,
 		crdClient:             crdClient,
@@ -211,6 +220,16 @@ func (i *Initializer) setupOVSBridge() error {
 }
 
 func (i *Initializer) validateSupportedDPFeatures() error {
+	// With the netdev datapath, the uplink and Pod interfaces are attached to the bridge as AF_XDP ports, which
+	// requires both the kernel and OVS to support AF_XDP.
+	if i.ovsBridgeClient.GetOVSDatapathType() == ovsconfig.OVSDatapathNetdev {
+		if err := afxdp.CheckKernelSupport(); err != nil {
+			return err
+		}
+		if err := afxdp.CheckOVSSupport(i.ovsBridgeClient); err != nil {
+			return err
+		}
+	}
 	gotFeatures, err := ovsctl.NewClient(i.ovsBridge).GetDPFeatures()
 	if err != nil {
 		return err
@@ -317,6 +336,8 @@ func (i *Initializer) initInterfaceStore() error {
 				intf = cniserver.ParseOVSPortInterfaceConfig(port, ovsPort)
 			case interfacestore.AntreaTrafficControl:
 				intf = trafficcontrol.ParseTrafficControlInterfaceConfig(port, ovsPort)
+			case interfacestore.AntreaPatch:
+				intf = interfacestore.NewPatchInterface(port.Name, ovsPort)
 			default:
 				klog.InfoS("Unknown Antrea interface type", "type", interfaceType)
 			}
@@ -378,6 +399,8 @@ func (i *Initializer) restorePortConfigs() error {
 		switch intf.Type {
 		case interfacestore.IPSecTunnelInterface:
 			fallthrough
+		case interfacestore.NodeTunnelInterface:
+			fallthrough
 		case interfacestore.TrafficControlInterface:
 			if intf.OFPort < 0 {
 				klog.InfoS("Skipped setting no-flood for port due to invalid ofPort", "port", intf.InterfaceName, "ofport", intf.OFPort)
@@ -528,9 +551,15 @@ func persistRoundNum(num uint64, bridgeClient ovsconfig.OVSBridgeClient, interva
 //     is deleted.
 //  3. all required flows are installed, using the round number obtained from step 1.
 //  4. after convergence, all existing flows for which the round number matches the previous round
-//     number (i.e. the round number which was persisted in OVSDB, if any) are deleted.
+//     number (i.e. the round number which was persisted in OVSDB, if any) are deleted. See
+//     DeleteStaleFlows.
 //  5. the new round number obtained from step 1 is persisted to OVSDB.
 //
+// Existing flows are never removed during step 3: a flow installed with the new round number replaces the
+// flow of the previous round with the same match and priority in place, so that the datapath keeps
+// forwarding traffic with the existing flows during the restart, and only the flows which are no longer
+// needed are left with the previous round number.
+//
 // The rationale for not persisting the new round number until after all previous flows have been
 // deleted is to avoid a situation in which some stale flows are never deleted because of successive
 // agent restarts (with the agent crashing before step 4 can be completed). With the sequence
@@ -543,10 +572,10 @@ func persistRoundNum(num uint64, bridgeClient ovsconfig.OVSBridgeClient, interva
 // https://github.com/wenyingd/ofnet/blob/14a78b27ef8762e45a0cfc858c4d07a4572a99d5/ofctrl/fgraphSwitch.go#L57-L62
 // All previous groups have been deleted by the time the call to i.ofClient.Initialize returns.
 func (i *Initializer) initOpenFlowPipeline() error {
-	roundInfo := getRoundInfo(i.ovsBridgeClient)
+	i.roundInfo = getRoundInfo(i.ovsBridgeClient)
 
 	// Set up all basic flows.
-	ofConnCh, err := i.ofClient.Initialize(roundInfo, i.nodeConfig, i.networkConfig, i.egressConfig, i.serviceConfig, i.l7NetworkPolicyConfig)
+	ofConnCh, err := i.ofClient.Initialize(i.roundInfo, i.nodeConfig, i.networkConfig, i.egressConfig, i.serviceConfig, i.l7NetworkPolicyConfig)
 	if err != nil {
 		klog.Errorf("Failed to initialize openflow client: %v", err)
 		return err
@@ -558,27 +587,6 @@ func (i *Initializer) initOpenFlowPipeline() error {
 		}
 	}
 
-	go func() {
-		// Delete stale flows from previous round. We need to wait long enough to ensure
-		// that all the flow which are still required have received an updated cookie (with
-		// the new round number), otherwise we would disrupt the dataplane. Unfortunately,
-		// the time required for convergence may be large and there is no simple way to
-		// determine when is a right time to perform the cleanup task.
-		// TODO: introduce a deterministic mechanism through which the different entities
-		//  responsible for installing flows can notify the agent that this deletion
-		//  operation can take place. A waitGroup can be created here and notified when
-		//  full sync in agent networkpolicy controller is complete. This would signal NP
-		//  flows have been synced once. Other mechanisms are still needed for node flows
-		//  fullSync check.
-		time.Sleep(10 * time.Second)
-		klog.Info("Deleting stale flows from previous round if any")
-		if err := i.ofClient.DeleteStaleFlows(); err != nil {
-			klog.Errorf("Error when deleting stale flows from previous round: %v", err)
-			return
-		}
-		persistRoundNum(roundInfo.RoundNum, i.ovsBridgeClient, 1*time.Second, maxRetryForRoundNumSave)
-	}()
-
 	go func() {
 		for {
 			if _, ok := <-ofConnCh; !ok {
@@ -615,6 +623,31 @@ func (i *Initializer) initOpenFlowPipeline() error {
 	return nil
 }
 
+// DeleteStaleFlows deletes the flows from the previous round once all the given functions report that the flows for
+// the initial state of their component have been installed, and persists the new round number. We need to wait for
+// all the flows which are still required to receive an updated cookie (with the new round number), otherwise we would
+// disrupt the dataplane. If the components have not synced after maxFlowSyncWait, the stale flows are deleted anyway,
+// as some components may never sync if they cannot reach the K8s or Antrea API.
+func (i *Initializer) DeleteStaleFlows(flowsSynced ...func() bool) {
+	klog.InfoS("Waiting for initial flows to be installed before deleting stale flows from previous round")
+	if err := wait.PollImmediate(flowSyncCheckInterval, maxFlowSyncWait, func() (bool, error) {
+		for _, synced := range flowsSynced {
+			if !synced() {
+				return false, nil
+			}
+		}
+		return true, nil
+	}); err != nil {
+		klog.InfoS("Initial flows were not installed in time, deleting stale flows from previous round anyway", "timeout", maxFlowSyncWait)
+	}
+	klog.InfoS("Deleting stale flows from previous round if any")
+	if err := i.ofClient.DeleteStaleFlows(); err != nil {
+		klog.ErrorS(err, "Error when deleting stale flows from previous round")
+		return
+	}
+	persistRoundNum(i.roundInfo.RoundNum, i.ovsBridgeClient, 1*time.Second, maxRetryForRoundNumSave)
+}
+
 func (i *Initializer) FlowRestoreComplete() error {
 	// Issue #1600: A rare case has been found that the "flow-restore-wait" config was still true even though the delete
 	// call below was considered success. At the moment we don't know if it's a race condition caused by "ovs-vsctl set
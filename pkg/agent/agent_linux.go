@@ -31,6 +31,7 @@ import (
 	"antrea.io/antrea/pkg/agent/interfacestore"
 	"antrea.io/antrea/pkg/agent/util"
 	"antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
 	utilip "antrea.io/antrea/pkg/util/ip"
 )
 
@@ -39,33 +40,20 @@ func (i *Initializer) prepareHostNetwork() error {
 	return nil
 }
 
-// prepareOVSBridgeForK8sNode returns immediately on Linux if connectUplinkToBridge is false.
+// prepareOVSBridgeForK8sNode returns immediately on Linux if connectUplinkToBridge is false and there is no OVS
+// physical bridge.
 func (i *Initializer) prepareOVSBridgeForK8sNode() error {
+	if i.physicalBridgeClient != nil {
+		return i.preparePhysicalBridge()
+	}
 	if !i.connectUplinkToBridge {
 		return nil
 	}
 	klog.Infof("Preparing OVS bridge for AntreaFlexibleIPAM")
-	// Get uplink network configuration.
-	// TODO(gran): support IPv6
-	_, _, adapter, err := i.getNodeInterfaceFromIP(&utilip.DualStackIPs{IPv4: i.nodeConfig.NodeIPv4Addr.IP})
-	if err != nil {
+	if err := i.initUplinkNetConfig(); err != nil {
 		return err
 	}
 	uplinkNetConfig := i.nodeConfig.UplinkNetConfig
-	uplinkNetConfig.Name = adapter.Name
-	uplinkNetConfig.MAC = adapter.HardwareAddr
-	uplinkNetConfig.IPs = []*net.IPNet{i.nodeConfig.NodeIPv4Addr}
-	uplinkNetConfig.Index = adapter.Index
-	// Gateway and DNSServers are not configured at adapter in Linux
-	// Limitation: dynamic DNS servers will be lost after DHCP lease expired
-	uplinkNetConfig.Gateway = ""
-	uplinkNetConfig.DNSServers = ""
-	// Save routes which are configured on the uplink interface.
-	// The routes on the host will be lost when moving the network configuration of the uplink interface
-	// to the OVS bridge local interface. The saved routes will be restored on host after that.
-	if err = i.saveHostRoutes(); err != nil {
-		return err
-	}
 
 	// Set datapathID of OVS bridge.
 	// If no datapathID configured explicitly, the reconfiguration operation will change OVS bridge datapathID
@@ -74,7 +62,7 @@ func (i *Initializer) prepareOVSBridgeForK8sNode() error {
 	// implementer-defined. Antrea uses "0x0000" for the upper 16-bits.
 	datapathID := strings.Replace(uplinkNetConfig.MAC.String(), ":", "", -1)
 	datapathID = "0000" + datapathID
-	if err = i.ovsBridgeClient.SetDatapathID(datapathID); err != nil {
+	if err := i.ovsBridgeClient.SetDatapathID(datapathID); err != nil {
 		return fmt.Errorf("failed to set datapath_id %s: err=%w", datapathID, err)
 	}
 
@@ -94,7 +82,7 @@ func (i *Initializer) prepareOVSBridgeForK8sNode() error {
 		} else {
 			uplinkNetConfig.Index = adapter.Index
 		}
-		klog.InfoS("Found uplink", "Name", adapter.Name, "Index", uplinkNetConfig.Index, "OFPort", uplinkNetConfig.OFPort)
+		klog.InfoS("Found uplink", "Name", uplinkNetConfig.Name, "Index", uplinkNetConfig.Index, "OFPort", uplinkNetConfig.OFPort)
 	} else {
 		freePort, err := i.ovsBridgeClient.AllocateOFPort(config.UplinkOFPort)
 		if err != nil {
@@ -112,6 +100,30 @@ func (i *Initializer) prepareOVSBridgeForK8sNode() error {
 	return nil
 }
 
+// initUplinkNetConfig initializes UplinkNetConfig with the configuration of the interface which has the Node IP, and
+// saves the routes configured on the interface.
+func (i *Initializer) initUplinkNetConfig() error {
+	// Get uplink network configuration.
+	// TODO(gran): support IPv6
+	_, _, adapter, err := i.getNodeInterfaceFromIP(&utilip.DualStackIPs{IPv4: i.nodeConfig.NodeIPv4Addr.IP})
+	if err != nil {
+		return err
+	}
+	uplinkNetConfig := i.nodeConfig.UplinkNetConfig
+	uplinkNetConfig.Name = adapter.Name
+	uplinkNetConfig.MAC = adapter.HardwareAddr
+	uplinkNetConfig.IPs = []*net.IPNet{i.nodeConfig.NodeIPv4Addr}
+	uplinkNetConfig.Index = adapter.Index
+	// Gateway and DNSServers are not configured at adapter in Linux
+	// Limitation: dynamic DNS servers will be lost after DHCP lease expired
+	uplinkNetConfig.Gateway = ""
+	uplinkNetConfig.DNSServers = ""
+	// Save routes which are configured on the uplink interface.
+	// The routes on the host will be lost when moving the network configuration of the uplink interface
+	// to the OVS bridge local interface. The saved routes will be restored on host after that.
+	return i.saveHostRoutes()
+}
+
 // getTunnelLocalIP returns local_ip of tunnel port.
 // On linux platform, local_ip option is not needed.
 func (i *Initializer) getTunnelPortLocalIP() net.IP {
@@ -173,14 +185,15 @@ func (i *Initializer) restoreHostRoutesToInterface(ifaceName string) error {
 }
 
 func (i *Initializer) ConnectUplinkToOVSBridge() error {
+	if i.physicalBridgeClient != nil {
+		return i.connectUplinkToPhysicalBridge()
+	}
 	// Return immediately on Linux if connectUplinkToBridge is false.
 	if !i.connectUplinkToBridge {
 		return nil
 	}
 	klog.Infof("Bridging uplink to OVS bridge")
-	var err error
 	uplinkNetConfig := i.nodeConfig.UplinkNetConfig
-	uplinkName := uplinkNetConfig.Name
 	bridgedUplinkName := util.GenerateUplinkInterfaceName(uplinkNetConfig.Name)
 
 	// If uplink is already exists, return.
@@ -188,35 +201,51 @@ func (i *Initializer) ConnectUplinkToOVSBridge() error {
 		klog.InfoS("Uplink already exists, skip the configuration", "uplink", bridgedUplinkName, "port", uplinkOFPort)
 		return nil
 	}
+	uplinkPortUUID, uplinkOFPort, err := i.bridgeUplink(i.ovsBridgeClient, int32(uplinkNetConfig.OFPort), int32(i.nodeConfig.HostInterfaceOFPort))
+	if err != nil {
+		return err
+	}
+	// Add newly created uplinkInterface to interface cache.
+	uplinkInterface := interfacestore.NewUplinkInterface(bridgedUplinkName)
+	uplinkInterface.OVSPortConfig = &interfacestore.OVSPortConfig{uplinkPortUUID, uplinkOFPort} //nolint: govet
+	i.ifaceStore.AddInterface(uplinkInterface)
+	return nil
+}
+
+// bridgeUplink connects the uplink interface to the provided OVS bridge: the uplink interface is renamed and added to
+// the bridge as the uplink port, and an internal port is created with the original name and MAC of the uplink
+// interface, to which the IP addresses and routes of the uplink interface are moved. It returns the UUID and the
+// OpenFlow port number of the uplink port.
+func (i *Initializer) bridgeUplink(bridgeClient ovsconfig.OVSBridgeClient, uplinkOFPortRequest, hostOFPortRequest int32) (string, int32, error) {
+	uplinkNetConfig := i.nodeConfig.UplinkNetConfig
+	uplinkName := uplinkNetConfig.Name
+	bridgedUplinkName := util.GenerateUplinkInterfaceName(uplinkNetConfig.Name)
+
 	uplinkIPs, err := util.GetAllIPNetsByName(uplinkName)
 	if err != nil {
-		return fmt.Errorf("failed to get uplink IPs: err=%w", err)
+		return "", 0, fmt.Errorf("failed to get uplink IPs: err=%w", err)
 	}
 	if err := util.RenameInterface(uplinkName, bridgedUplinkName); err != nil {
-		return fmt.Errorf("failed to change uplink interface name: err=%w", err)
+		return "", 0, fmt.Errorf("failed to change uplink interface name: err=%w", err)
 	}
 
 	// Create uplink port.
-	uplinkPortUUID, err := i.ovsBridgeClient.CreateUplinkPort(bridgedUplinkName, int32(uplinkNetConfig.OFPort), map[string]interface{}{interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaUplink})
+	uplinkPortUUID, err := bridgeClient.CreateUplinkPort(bridgedUplinkName, uplinkOFPortRequest, map[string]interface{}{interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaUplink})
 	if err != nil {
-		return fmt.Errorf("failed to add uplink port %s: err=%w", bridgedUplinkName, err)
+		return "", 0, fmt.Errorf("failed to add uplink port %s: err=%w", bridgedUplinkName, err)
 	}
-	// Add newly created uplinkInterface to interface cache.
-	uplinkInterface := interfacestore.NewUplinkInterface(bridgedUplinkName)
-	uplinkOFPort, err := i.ovsBridgeClient.GetOFPort(bridgedUplinkName, false)
+	uplinkOFPort, err := bridgeClient.GetOFPort(bridgedUplinkName, false)
 	if err != nil {
-		return fmt.Errorf("failed to get uplink ofport %s: err=%w", bridgedUplinkName, err)
+		return "", 0, fmt.Errorf("failed to get uplink ofport %s: err=%w", bridgedUplinkName, err)
 	}
 	klog.InfoS("Allocated OpenFlow port for uplink interface", "port", bridgedUplinkName, "ofPort", uplinkOFPort)
-	uplinkInterface.OVSPortConfig = &interfacestore.OVSPortConfig{uplinkPortUUID, uplinkOFPort} //nolint: govet
-	i.ifaceStore.AddInterface(uplinkInterface)
 
 	// Create local port.
 	externalIDs := map[string]interface{}{
 		interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaHost,
 	}
-	if _, err = i.ovsBridgeClient.CreateInternalPort(uplinkName, int32(i.nodeConfig.HostInterfaceOFPort), uplinkNetConfig.MAC.String(), externalIDs); err != nil {
-		return fmt.Errorf("cannot create host interface port %s: err=%w", uplinkName, err)
+	if _, err = bridgeClient.CreateInternalPort(uplinkName, hostOFPortRequest, uplinkNetConfig.MAC.String(), externalIDs); err != nil {
+		return "", 0, fmt.Errorf("cannot create host interface port %s: err=%w", uplinkName, err)
 	}
 
 	// Move network configuration of uplink interface to OVS bridge local interface.
@@ -233,29 +262,29 @@ func (i *Initializer) ConnectUplinkToOVSBridge() error {
 	})
 	localLink, err := netlink.LinkByName(uplinkName)
 	if err != nil {
-		return err
+		return "", 0, err
 	}
 	if _, _, err = util.SetLinkUp(uplinkName); err != nil {
-		return err
+		return "", 0, err
 	}
 	if err = util.ConfigureLinkAddresses(localLink.Attrs().Index, uplinkIPs); err != nil {
-		return err
+		return "", 0, err
 	}
 	if err = util.ConfigureLinkAddresses(uplinkNetConfig.Index, nil); err != nil {
-		return err
+		return "", 0, err
 	}
 	// Restore the host routes which are lost when moving the network configuration of the uplink interface to OVS bridge interface.
 	if err = i.restoreHostRoutes(); err != nil {
-		return err
+		return "", 0, err
 	}
 
-	return nil
+	return uplinkPortUUID, uplinkOFPort, nil
 }
 
-// RestoreOVSBridge returns immediately on Linux if connectUplinkToBridge is false.
+// RestoreOVSBridge returns immediately on Linux if connectUplinkToBridge is false and there is no OVS physical bridge.
 // OVS is managed by Antrea in Linux, network config must be restored to uplink before Antrea Agent shutdown.
 func (i *Initializer) RestoreOVSBridge() {
-	if !i.connectUplinkToBridge {
+	if !i.connectUplinkToBridge && i.physicalBridgeClient == nil {
 		return
 	}
 	klog.Infof("Restoring bridge config to uplink...")
@@ -267,6 +296,9 @@ func (i *Initializer) RestoreOVSBridge() {
 		bridgedUplinkName = util.GenerateUplinkInterfaceName(uplinkName)
 	}
 	brName := i.ovsBridge
+	if i.physicalBridgeClient != nil {
+		brName = i.physicalBridgeClient.GetBridgeName()
+	}
 
 	if uplinkName != "" {
 		uplinkIPs, err := util.GetAllIPNetsByName(uplinkName)
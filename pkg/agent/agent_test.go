@@ -20,7 +20,9 @@ import (
 	"net"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	mock "github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -34,6 +36,7 @@ import (
 	"antrea.io/antrea/pkg/agent/cniserver"
 	"antrea.io/antrea/pkg/agent/config"
 	"antrea.io/antrea/pkg/agent/interfacestore"
+	openflowtest "antrea.io/antrea/pkg/agent/openflow/testing"
 	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/ovs/ovsconfig"
 	ovsconfigtest "antrea.io/antrea/pkg/ovs/ovsconfig/testing"
@@ -149,6 +152,55 @@ func TestPersistRoundNum(t *testing.T) {
 	persistRoundNum(roundNum, mockOVSBridgeClient, 0, maxRetries)
 }
 
+func TestDeleteStaleFlows(t *testing.T) {
+	defer func(interval, timeout time.Duration) {
+		flowSyncCheckInterval, maxFlowSyncWait = interval, timeout
+	}(flowSyncCheckInterval, maxFlowSyncWait)
+	flowSyncCheckInterval = 10 * time.Millisecond
+	maxFlowSyncWait = 500 * time.Millisecond
+	const roundNum uint64 = 5555
+	newExternalIDs := map[string]interface{}{roundNumKey: fmt.Sprint(roundNum)}
+
+	t.Run("wait for flows to be synced", func(t *testing.T) {
+		controller := mock.NewController(t)
+		mockOVSBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(controller)
+		mockOFClient := openflowtest.NewMockClient(controller)
+		initializer := &Initializer{ovsBridgeClient: mockOVSBridgeClient, ofClient: mockOFClient, roundInfo: types.RoundInfo{RoundNum: roundNum}}
+
+		var synced atomic.Bool
+		time.AfterFunc(50*time.Millisecond, func() { synced.Store(true) })
+		mockOFClient.EXPECT().DeleteStaleFlows().Do(func() {
+			assert.True(t, synced.Load(), "Stale flows should not be deleted before flows are synced")
+		}).Return(nil)
+		mockOVSBridgeClient.EXPECT().GetExternalIDs().Return(map[string]string{}, nil)
+		mockOVSBridgeClient.EXPECT().SetExternalIDs(mock.Eq(newExternalIDs)).Return(nil)
+		initializer.DeleteStaleFlows(func() bool { return true }, synced.Load)
+	})
+
+	t.Run("flows not synced in time", func(t *testing.T) {
+		controller := mock.NewController(t)
+		mockOVSBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(controller)
+		mockOFClient := openflowtest.NewMockClient(controller)
+		initializer := &Initializer{ovsBridgeClient: mockOVSBridgeClient, ofClient: mockOFClient, roundInfo: types.RoundInfo{RoundNum: roundNum}}
+
+		mockOFClient.EXPECT().DeleteStaleFlows().Return(nil)
+		mockOVSBridgeClient.EXPECT().GetExternalIDs().Return(map[string]string{}, nil)
+		mockOVSBridgeClient.EXPECT().SetExternalIDs(mock.Eq(newExternalIDs)).Return(nil)
+		initializer.DeleteStaleFlows(func() bool { return false })
+	})
+
+	t.Run("failed to delete stale flows", func(t *testing.T) {
+		controller := mock.NewController(t)
+		mockOVSBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(controller)
+		mockOFClient := openflowtest.NewMockClient(controller)
+		initializer := &Initializer{ovsBridgeClient: mockOVSBridgeClient, ofClient: mockOFClient, roundInfo: types.RoundInfo{RoundNum: roundNum}}
+
+		// The round number must not be persisted, otherwise the stale flows would never be deleted.
+		mockOFClient.EXPECT().DeleteStaleFlows().Return(fmt.Errorf("connection lost"))
+		initializer.DeleteStaleFlows()
+	})
+}
+
 func TestGetRoundInfo(t *testing.T) {
 	controller := mock.NewController(t)
 	mockOVSBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(controller)
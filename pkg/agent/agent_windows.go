@@ -30,6 +30,7 @@ import (
 	"antrea.io/antrea/pkg/agent/interfacestore"
 	"antrea.io/antrea/pkg/agent/util"
 	"antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	"antrea.io/antrea/pkg/features"
 	"antrea.io/antrea/pkg/ovs/ovsctl"
 	utilip "antrea.io/antrea/pkg/util/ip"
 )
@@ -185,7 +186,44 @@ func (i *Initializer) prepareVMNetworkAndOVSExtension() error {
 // prepareOVSBridgeForK8sNode adds local port and uplink port to OVS bridge after OVS extension is enabled on HNSNetwork.
 // This function deletes OVS bridge and HNS network created by Antrea on failure.
 func (i *Initializer) prepareOVSBridgeForK8sNode() error {
-	return i.prepareOVSBridgeOnHNSNetwork()
+	if err := i.prepareOVSBridgeOnHNSNetwork(); err != nil {
+		return err
+	}
+	if features.DefaultFeatureGate.Enabled(features.Egress) {
+		// The Egress SNAT packets are output to the uplink directly, which requires the MAC address of the uplink
+		// default gateway. Failing to resolve it only affects Egress, so it is not a fatal error.
+		if err := i.saveUplinkGatewayMAC(); err != nil {
+			klog.ErrorS(err, "Failed to resolve the MAC address of the uplink default gateway, Egress will not work on this Node")
+		}
+	}
+	return nil
+}
+
+// saveUplinkGatewayMAC resolves the MAC address of the default gateway from the neighbor cache of the interface that
+// holds the Node transport IP, and saves it in the uplink configuration.
+func (i *Initializer) saveUplinkGatewayMAC() error {
+	_, _, iface, err := i.getNodeInterfaceFromIP(&utilip.DualStackIPs{IPv4: i.nodeConfig.NodeTransportIPv4Addr.IP})
+	if err != nil {
+		return err
+	}
+	gateway, err := util.GetDefaultGatewayByInterfaceIndex(iface.Index)
+	if err != nil {
+		return err
+	}
+	gatewayIP := net.ParseIP(gateway)
+	if gatewayIP == nil {
+		return fmt.Errorf("no default gateway found on interface %s", iface.Name)
+	}
+	neighbors, err := util.GetNetNeighbor(&util.Neighbor{LinkIndex: iface.Index, IPAddress: gatewayIP})
+	if err != nil {
+		return err
+	}
+	if len(neighbors) == 0 {
+		return fmt.Errorf("no neighbor cache entry found for default gateway %s", gatewayIP)
+	}
+	i.nodeConfig.UplinkNetConfig.GatewayMAC = neighbors[0].LinkLayerAddress
+	klog.InfoS("Resolved MAC address of the uplink default gateway", "gateway", gatewayIP, "mac", neighbors[0].LinkLayerAddress)
+	return nil
 }
 
 // prepareOVSBridgeOnHNSNetwork adds local port and uplink to OVS bridge after the OVS Extension is enabled on HNSNetwork.
@@ -30,17 +30,24 @@ import (
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/apiserver/pkg/server/healthz"
 	genericoptions "k8s.io/apiserver/pkg/server/options"
+	"k8s.io/client-go/util/certificate"
 
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/addressgroup"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/agentinfo"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/appliedtogroup"
+	"antrea.io/antrea/pkg/agent/apiserver/handlers/connections"
+	"antrea.io/antrea/pkg/agent/apiserver/handlers/deletedrules"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/featuregates"
+	"antrea.io/antrea/pkg/agent/apiserver/handlers/fqdncache"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/memberlist"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/multicast"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/networkpolicy"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/ovsflows"
+	"antrea.io/antrea/pkg/agent/apiserver/handlers/ovsoffload"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/ovstracing"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/podinterface"
+	"antrea.io/antrea/pkg/agent/apiserver/handlers/policypause"
+	"antrea.io/antrea/pkg/agent/apiserver/handlers/policysimulation"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/serviceexternalip"
 	agentquerier "antrea.io/antrea/pkg/agent/querier"
 	systeminstall "antrea.io/antrea/pkg/apis/system/install"
@@ -67,10 +74,15 @@ func init() {
 }
 
 type agentAPIServer struct {
-	GenericAPIServer *genericapiserver.GenericAPIServer
+	GenericAPIServer   *genericapiserver.GenericAPIServer
+	servingCertManager certificate.Manager
 }
 
 func (s *agentAPIServer) Run(stopCh <-chan struct{}) error {
+	if s.servingCertManager != nil {
+		s.servingCertManager.Start()
+		defer s.servingCertManager.Stop()
+	}
 	return s.GenericAPIServer.PrepareRun().Run(stopCh)
 }
 
@@ -83,19 +95,28 @@ func (s *agentAPIServer) GetCertData() []byte {
 	return cert
 }
 
-func installHandlers(aq agentquerier.AgentQuerier, npq querier.AgentNetworkPolicyInfoQuerier, mq querier.AgentMulticastInfoQuerier, seipq querier.ServiceExternalIPStatusQuerier, s *genericapiserver.GenericAPIServer) {
+func installHandlers(aq agentquerier.AgentQuerier, npq querier.AgentNetworkPolicyInfoQuerier, mq querier.AgentMulticastInfoQuerier, seipq querier.ServiceExternalIPStatusQuerier, cq querier.AgentConnectionQuerier, oq querier.AgentOVSOffloadQuerier, s *genericapiserver.GenericAPIServer) {
 	s.Handler.NonGoRestfulMux.HandleFunc("/loglevel", loglevel.HandleFunc())
 	s.Handler.NonGoRestfulMux.HandleFunc("/podmulticaststats", multicast.HandleFunc(mq))
 	s.Handler.NonGoRestfulMux.HandleFunc("/featuregates", featuregates.HandleFunc())
 	s.Handler.NonGoRestfulMux.HandleFunc("/agentinfo", agentinfo.HandleFunc(aq))
 	s.Handler.NonGoRestfulMux.HandleFunc("/podinterfaces", podinterface.HandleFunc(aq))
 	s.Handler.NonGoRestfulMux.HandleFunc("/networkpolicies", networkpolicy.HandleFunc(aq))
+	s.Handler.NonGoRestfulMux.HandleFunc("/networkpolicies/paused", policypause.HandleFunc(npq))
+	s.Handler.NonGoRestfulMux.HandleFunc("/networkpolicies/pause", policypause.HandlePauseFunc(npq))
+	s.Handler.NonGoRestfulMux.HandleFunc("/networkpolicies/resume", policypause.HandleResumeFunc(npq))
+	s.Handler.NonGoRestfulMux.HandleFunc("/networkpolicies/deletedrules/expire", deletedrules.HandleExpireFunc(npq))
+	s.Handler.NonGoRestfulMux.HandleFunc("/policysimulation", policysimulation.HandleFunc(npq))
 	s.Handler.NonGoRestfulMux.HandleFunc("/appliedtogroups", appliedtogroup.HandleFunc(npq))
 	s.Handler.NonGoRestfulMux.HandleFunc("/addressgroups", addressgroup.HandleFunc(npq))
 	s.Handler.NonGoRestfulMux.HandleFunc("/ovsflows", ovsflows.HandleFunc(aq))
 	s.Handler.NonGoRestfulMux.HandleFunc("/ovstracing", ovstracing.HandleFunc(aq))
 	s.Handler.NonGoRestfulMux.HandleFunc("/serviceexternalip", serviceexternalip.HandleFunc(seipq))
 	s.Handler.NonGoRestfulMux.HandleFunc("/memberlist", memberlist.HandleFunc(aq))
+	s.Handler.NonGoRestfulMux.HandleFunc("/fqdncache", fqdncache.HandleFunc(npq))
+	s.Handler.NonGoRestfulMux.HandleFunc("/fqdncache/flush", fqdncache.HandleFlushFunc(npq))
+	s.Handler.NonGoRestfulMux.HandleFunc("/connections", connections.HandleFunc(cq))
+	s.Handler.NonGoRestfulMux.HandleFunc("/ovsoffload", ovsoffload.HandleFunc(oq))
 }
 
 func installAPIGroup(s *genericapiserver.GenericAPIServer, aq agentquerier.AgentQuerier, npq querier.AgentNetworkPolicyInfoQuerier, v4Enabled, v6Enabled bool) error {
@@ -108,20 +129,24 @@ func installAPIGroup(s *genericapiserver.GenericAPIServer, aq agentquerier.Agent
 	return s.InstallAPIGroup(&systemGroup)
 }
 
-// New creates an APIServer for running in antrea agent.
+// New creates an APIServer for running in antrea agent. If servingCertManager is not nil, the API
+// server serves the certificate it manages once issued, instead of a self-signed certificate.
 func New(aq agentquerier.AgentQuerier,
 	npq querier.AgentNetworkPolicyInfoQuerier,
 	mq querier.AgentMulticastInfoQuerier,
 	seipq querier.ServiceExternalIPStatusQuerier,
+	cq querier.AgentConnectionQuerier,
+	oq querier.AgentOVSOffloadQuerier,
 	secureServing *genericoptions.SecureServingOptionsWithLoopback,
 	authentication *genericoptions.DelegatingAuthenticationOptions,
 	authorization *genericoptions.DelegatingAuthorizationOptions,
+	servingCertManager certificate.Manager,
 	enableMetrics bool,
 	kubeconfig string,
 	v4Enabled,
 	v6Enabled bool,
 ) (*agentAPIServer, error) {
-	cfg, err := newConfig(aq, npq, secureServing, authentication, authorization, enableMetrics, kubeconfig)
+	cfg, err := newConfig(aq, npq, secureServing, authentication, authorization, servingCertManager, enableMetrics, kubeconfig)
 	if err != nil {
 		return nil, err
 	}
@@ -132,8 +157,8 @@ func New(aq agentquerier.AgentQuerier,
 	if err := installAPIGroup(s, aq, npq, v4Enabled, v6Enabled); err != nil {
 		return nil, err
 	}
-	installHandlers(aq, npq, mq, seipq, s)
-	return &agentAPIServer{GenericAPIServer: s}, nil
+	installHandlers(aq, npq, mq, seipq, cq, oq, s)
+	return &agentAPIServer{GenericAPIServer: s, servingCertManager: servingCertManager}, nil
 }
 
 func newConfig(aq agentquerier.AgentQuerier,
@@ -141,6 +166,7 @@ func newConfig(aq agentquerier.AgentQuerier,
 	secureServing *genericoptions.SecureServingOptionsWithLoopback,
 	authentication *genericoptions.DelegatingAuthenticationOptions,
 	authorization *genericoptions.DelegatingAuthorizationOptions,
+	servingCertManager certificate.Manager,
 	enableMetrics bool,
 	kubeconfig string,
 ) (*genericapiserver.CompletedConfig, error) {
@@ -161,6 +187,12 @@ func newConfig(aq agentquerier.AgentQuerier,
 	if err := secureServing.ApplyTo(&serverConfig.SecureServing, &serverConfig.LoopbackClientConfig); err != nil {
 		return nil, err
 	}
+	if servingCertManager != nil {
+		serverConfig.SecureServing.Cert = &servingCertificateProvider{
+			manager:  servingCertManager,
+			fallback: serverConfig.SecureServing.Cert,
+		}
+	}
 	if err := authentication.ApplyTo(&serverConfig.Authentication, serverConfig.SecureServing, nil); err != nil {
 		return nil, err
 	}
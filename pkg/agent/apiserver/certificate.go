@@ -0,0 +1,135 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apiserver/pkg/server/dynamiccertificates"
+	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/client-go/util/certificate"
+	"k8s.io/client-go/util/keyutil"
+	"k8s.io/klog/v2"
+
+	antreaapis "antrea.io/antrea/pkg/apis"
+)
+
+const servingCertificatePairName = "antrea-agent-serving"
+
+// ServingCertificateDir is the directory where the serving certificates issued by antrea-controller
+// are stored, so that they can be reused after antrea-agent restarts.
+var ServingCertificateDir = "/var/run/antrea/apiserver/certs"
+
+// NewServingCertificateManager returns a certificate.Manager which requests the serving certificate
+// of the API server with a CertificateSigningRequest for the antrea-agent serving signer, and
+// rotates it before it expires. The subjectAltNames of the certificate are the Node name and the
+// addresses of the Node in addition to the loopback ones, and a new certificate is requested when
+// the addresses of the Node change.
+func NewServingCertificateManager(client clientset.Interface, nodeName string, nodeLister corelisters.NodeLister) (certificate.Manager, error) {
+	store, err := certificate.NewFileStore(servingCertificatePairName, ServingCertificateDir, ServingCertificateDir, "", "")
+	if err != nil {
+		return nil, err
+	}
+	getTemplate := func() *x509.CertificateRequest {
+		node, err := nodeLister.Get(nodeName)
+		if err != nil {
+			klog.ErrorS(err, "Failed to get Node for serving certificate", "node", nodeName)
+			return nil
+		}
+		return newServingCertificateRequestTemplate(node)
+	}
+	return certificate.NewManager(&certificate.Config{
+		ClientsetFn: func(_ *tls.Certificate) (clientset.Interface, error) {
+			return client, nil
+		},
+		GetTemplate: getTemplate,
+		SignerName:  antreaapis.AntreaAgentServingCSRSignerName,
+		Usages: []certificatesv1.KeyUsage{
+			certificatesv1.UsageDigitalSignature,
+			certificatesv1.UsageServerAuth,
+		},
+		CertificateStore: store,
+		Name:             servingCertificatePairName,
+		Logf:             klog.Infof,
+	})
+}
+
+func newServingCertificateRequestTemplate(node *corev1.Node) *x509.CertificateRequest {
+	ips := []net.IP{net.ParseIP("127.0.0.1"), net.IPv6loopback}
+	for _, addr := range node.Status.Addresses {
+		if addr.Type != corev1.NodeInternalIP && addr.Type != corev1.NodeExternalIP {
+			continue
+		}
+		if ip := net.ParseIP(addr.Address); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   node.Name,
+			Organization: []string{antreaapis.AntreaOrganizationName},
+		},
+		DNSNames:    []string{node.Name, "localhost"},
+		IPAddresses: ips,
+	}
+}
+
+// servingCertificateProvider provides the certificate managed by a certificate.Manager to the API
+// server. Until the first certificate is issued, it provides the certificate of the fallback
+// provider, so that the API server, including its health checks, is available in the meantime.
+type servingCertificateProvider struct {
+	manager  certificate.Manager
+	fallback dynamiccertificates.CertKeyContentProvider
+}
+
+var _ dynamiccertificates.CertKeyContentProvider = (*servingCertificateProvider)(nil)
+
+func (p *servingCertificateProvider) Name() string {
+	return servingCertificatePairName
+}
+
+// AddListener implements dynamiccertificates.Notifier. It is a no-op as the serving certificate
+// controller of the API server resyncs the certificate every minute, which is sufficient given that
+// certificates are rotated long before they expire.
+func (p *servingCertificateProvider) AddListener(listener dynamiccertificates.Listener) {}
+
+func (p *servingCertificateProvider) CurrentCertKeyContent() ([]byte, []byte) {
+	cert := p.manager.Current()
+	if cert == nil {
+		return p.fallback.CurrentCertKeyContent()
+	}
+	certBuffer := bytes.Buffer{}
+	for _, der := range cert.Certificate {
+		if err := pem.Encode(&certBuffer, &pem.Block{Type: certutil.CertificateBlockType, Bytes: der}); err != nil {
+			klog.ErrorS(err, "Failed to encode serving certificate")
+			return p.fallback.CurrentCertKeyContent()
+		}
+	}
+	keyPEM, err := keyutil.MarshalPrivateKeyToPEM(cert.PrivateKey)
+	if err != nil {
+		klog.ErrorS(err, "Failed to encode serving certificate key")
+		return p.fallback.CurrentCertKeyContent()
+	}
+	return certBuffer.Bytes(), keyPEM
+}
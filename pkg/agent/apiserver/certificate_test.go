@@ -0,0 +1,87 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/server/dynamiccertificates"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+type fakeCertificateManager struct {
+	cert *tls.Certificate
+}
+
+func (m *fakeCertificateManager) Start() {}
+
+func (m *fakeCertificateManager) Stop() {}
+
+func (m *fakeCertificateManager) Current() *tls.Certificate {
+	return m.cert
+}
+
+func (m *fakeCertificateManager) ServerHealthy() bool {
+	return true
+}
+
+func TestNewServingCertificateRequestTemplate(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node1",
+		},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "192.168.1.10"},
+				{Type: corev1.NodeExternalIP, Address: "10.10.0.10"},
+				{Type: corev1.NodeHostName, Address: "node1.example.com"},
+			},
+		},
+	}
+	template := newServingCertificateRequestTemplate(node)
+	assert.Equal(t, "node1", template.Subject.CommonName)
+	assert.Equal(t, []string{"antrea.io"}, template.Subject.Organization)
+	assert.Equal(t, []string{"node1", "localhost"}, template.DNSNames)
+	assert.Equal(t, []net.IP{net.ParseIP("127.0.0.1"), net.IPv6loopback, net.ParseIP("192.168.1.10"), net.ParseIP("10.10.0.10")}, template.IPAddresses)
+}
+
+func TestServingCertificateProvider(t *testing.T) {
+	fallbackCert, fallbackKey, err := certutil.GenerateSelfSignedCertKey("localhost", nil, nil)
+	require.NoError(t, err)
+	fallback, err := dynamiccertificates.NewStaticCertKeyContent("self-signed", fallbackCert, fallbackKey)
+	require.NoError(t, err)
+	signedCert, signedKey, err := certutil.GenerateSelfSignedCertKey("node1", nil, []string{"node1"})
+	require.NoError(t, err)
+	tlsCert, err := tls.X509KeyPair(signedCert, signedKey)
+	require.NoError(t, err)
+
+	manager := &fakeCertificateManager{}
+	provider := &servingCertificateProvider{manager: manager, fallback: fallback}
+	cert, key := provider.CurrentCertKeyContent()
+	assert.Equal(t, fallbackCert, cert)
+	assert.Equal(t, fallbackKey, key)
+
+	manager.cert = &tlsCert
+	cert, key = provider.CurrentCertKeyContent()
+	assert.Equal(t, signedCert, cert)
+	_, err = tls.X509KeyPair(cert, key)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,241 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/flowexporter"
+	"antrea.io/antrea/pkg/antctl/transform/common"
+	"antrea.io/antrea/pkg/querier"
+)
+
+const (
+	// Flags of the conntrack status, see include/uapi/linux/netfilter/nf_conntrack_common.h.
+	conntrackStatusSeenReply = uint32(1 << 1)
+	conntrackStatusAssured   = uint32(1 << 2)
+	conntrackStatusDying     = uint32(1 << 9)
+)
+
+var protocolNumbers = map[string]uint8{
+	"icmp":   1,
+	"tcp":    6,
+	"udp":    17,
+	"icmpv6": 58,
+	"sctp":   132,
+}
+
+// Response describes the response struct of connections command.
+type Response struct {
+	Protocol        string `json:"protocol,omitempty"`
+	SourceIP        string `json:"sourceIP,omitempty"`
+	SourcePort      uint16 `json:"sourcePort,omitempty"`
+	SourcePod       string `json:"sourcePod,omitempty"`
+	DestinationIP   string `json:"destinationIP,omitempty"`
+	DestinationPort uint16 `json:"destinationPort,omitempty"`
+	DestinationPod  string `json:"destinationPod,omitempty"`
+	// The original destination of the connection before DNAT, e.g. the ClusterIP of a Service. It is the same as the
+	// destination if the connection is not translated.
+	OriginalDestinationIP   string `json:"originalDestinationIP,omitempty"`
+	OriginalDestinationPort uint16 `json:"originalDestinationPort,omitempty"`
+	Service                 string `json:"service,omitempty"`
+	State                   string `json:"state,omitempty"`
+	// The remaining time before the connection expires from the conntrack table, in seconds.
+	Timeout              uint32    `json:"timeout"`
+	StartTime            time.Time `json:"startTime,omitempty"`
+	IngressNetworkPolicy string    `json:"ingressNetworkPolicy,omitempty"`
+	EgressNetworkPolicy  string    `json:"egressNetworkPolicy,omitempty"`
+}
+
+// HandleFunc creates a http.HandlerFunc which uses an AgentConnectionQuerier to dump the
+// Antrea connections tracked by conntrack on the Node. The HandlerFunc accepts the following
+// optional parameters in URL to filter the connections: `namespace`, `pod`, `service`,
+// `src-ip`, `src-port`, `dst-ip`, `dst-port` and `protocol`.
+func HandleFunc(cq querier.AgentConnectionQuerier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if cq == nil {
+			http.Error(w, "connection querier is not available", http.StatusServiceUnavailable)
+			return
+		}
+		filter, err := newFilter(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		conns, err := cq.GetConnections(filter)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp := make([]Response, 0, len(conns))
+		for _, conn := range conns {
+			resp = append(resp, newResponse(conn))
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			klog.ErrorS(err, "Error when encoding connections to json")
+		}
+	}
+}
+
+func newFilter(r *http.Request) (*querier.ConnectionFilter, error) {
+	query := r.URL.Query()
+	filter := &querier.ConnectionFilter{
+		Namespace:   query.Get("namespace"),
+		PodName:     query.Get("pod"),
+		ServiceName: query.Get("service"),
+	}
+	if (filter.PodName != "" || filter.ServiceName != "") && filter.Namespace == "" {
+		return nil, fmt.Errorf("namespace must be provided with pod or service")
+	}
+	if filter.PodName != "" && filter.ServiceName != "" {
+		return nil, fmt.Errorf("pod and service cannot be provided at the same time")
+	}
+	var err error
+	if filter.SourceIP, err = parseIP(query.Get("src-ip")); err != nil {
+		return nil, err
+	}
+	if filter.DestinationIP, err = parseIP(query.Get("dst-ip")); err != nil {
+		return nil, err
+	}
+	if filter.SourcePort, err = parsePort(query.Get("src-port")); err != nil {
+		return nil, err
+	}
+	if filter.DestinationPort, err = parsePort(query.Get("dst-port")); err != nil {
+		return nil, err
+	}
+	if protocol := query.Get("protocol"); protocol != "" {
+		protocolNumber, ok := protocolNumbers[strings.ToLower(protocol)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported protocol %s", protocol)
+		}
+		filter.Protocol = protocolNumber
+	}
+	return filter, nil
+}
+
+func parseIP(str string) (net.IP, error) {
+	if str == "" {
+		return nil, nil
+	}
+	ip := net.ParseIP(str)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %s", str)
+	}
+	return ip, nil
+}
+
+func parsePort(str string) (uint16, error) {
+	if str == "" {
+		return 0, nil
+	}
+	port, err := strconv.ParseUint(str, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port %s", str)
+	}
+	return uint16(port), nil
+}
+
+func newResponse(conn *flowexporter.Connection) Response {
+	return Response{
+		Protocol:                protocolName(conn.FlowKey.Protocol),
+		SourceIP:                conn.FlowKey.SourceAddress.String(),
+		SourcePort:              conn.FlowKey.SourcePort,
+		SourcePod:               namespacedName(conn.SourcePodNamespace, conn.SourcePodName),
+		DestinationIP:           conn.FlowKey.DestinationAddress.String(),
+		DestinationPort:         conn.FlowKey.DestinationPort,
+		DestinationPod:          namespacedName(conn.DestinationPodNamespace, conn.DestinationPodName),
+		OriginalDestinationIP:   conn.DestinationServiceAddress.String(),
+		OriginalDestinationPort: conn.DestinationServicePort,
+		Service:                 conn.DestinationServicePortName,
+		State:                   connectionState(conn),
+		Timeout:                 conn.Timeout,
+		StartTime:               conn.StartTime,
+		IngressNetworkPolicy:    namespacedName(conn.IngressNetworkPolicyNamespace, conn.IngressNetworkPolicyName),
+		EgressNetworkPolicy:     namespacedName(conn.EgressNetworkPolicyNamespace, conn.EgressNetworkPolicyName),
+	}
+}
+
+func protocolName(protocol uint8) string {
+	for name, number := range protocolNumbers {
+		if number == protocol {
+			return strings.ToUpper(name)
+		}
+	}
+	return strconv.Itoa(int(protocol))
+}
+
+// namespacedName returns <Namespace>/<name> for namespaced resources, and <name> for cluster-scoped resources, e.g.
+// Antrea ClusterNetworkPolicies.
+func namespacedName(namespace, name string) string {
+	if name == "" || namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+// connectionState returns the TCP state of TCP connections, and the conntrack status of other connections.
+func connectionState(conn *flowexporter.Connection) string {
+	if conn.TCPState != "" {
+		return conn.TCPState
+	}
+	switch {
+	case conn.StatusFlag&conntrackStatusDying != 0:
+		return "DYING"
+	case conn.StatusFlag&conntrackStatusAssured != 0:
+		return "ASSURED"
+	case conn.StatusFlag&conntrackStatusSeenReply != 0:
+		return "REPLIED"
+	default:
+		return "UNREPLIED"
+	}
+}
+
+func endpointString(ip string, port uint16) string {
+	if port == 0 {
+		return ip
+	}
+	return net.JoinHostPort(ip, strconv.Itoa(int(port)))
+}
+
+var _ common.TableOutput = new(Response)
+
+func (r Response) GetTableHeader() []string {
+	return []string{"PROTOCOL", "SOURCE", "DESTINATION", "ORIGINAL-DESTINATION", "SERVICE", "STATE", "TIMEOUT"}
+}
+
+func (r Response) GetTableRow(maxColumnLength int) []string {
+	return []string{
+		r.Protocol,
+		endpointString(r.SourceIP, r.SourcePort),
+		endpointString(r.DestinationIP, r.DestinationPort),
+		endpointString(r.OriginalDestinationIP, r.OriginalDestinationPort),
+		r.Service,
+		r.State,
+		strconv.Itoa(int(r.Timeout)),
+	}
+}
+
+func (r Response) SortRows() bool {
+	return true
+}
@@ -0,0 +1,192 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"antrea.io/antrea/pkg/agent/flowexporter"
+	"antrea.io/antrea/pkg/querier"
+	queriertest "antrea.io/antrea/pkg/querier/testing"
+)
+
+func TestConnectionsQuery(t *testing.T) {
+	startTime := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	serviceConn := &flowexporter.Connection{
+		FlowKey: flowexporter.Tuple{
+			SourceAddress:      net.ParseIP("10.10.0.1"),
+			DestinationAddress: net.ParseIP("10.10.1.2"),
+			Protocol:           6,
+			SourcePort:         50000,
+			DestinationPort:    8080,
+		},
+		DestinationServiceAddress:     net.ParseIP("10.96.0.10"),
+		DestinationServicePort:        80,
+		DestinationServicePortName:    "ns1/svc1:http",
+		SourcePodNamespace:            "ns1",
+		SourcePodName:                 "pod1",
+		TCPState:                      "ESTABLISHED",
+		Timeout:                       86399,
+		StartTime:                     startTime,
+		EgressNetworkPolicyName:       "acnp1",
+		IngressNetworkPolicyNamespace: "ns1",
+		IngressNetworkPolicyName:      "np1",
+	}
+	udpConn := &flowexporter.Connection{
+		FlowKey: flowexporter.Tuple{
+			SourceAddress:      net.ParseIP("10.10.0.1"),
+			DestinationAddress: net.ParseIP("10.10.0.2"),
+			Protocol:           17,
+			SourcePort:         50001,
+			DestinationPort:    53,
+		},
+		DestinationServiceAddress: net.ParseIP("10.10.0.2"),
+		DestinationServicePort:    53,
+		DestinationPodNamespace:   "ns2",
+		DestinationPodName:        "pod2",
+		StatusFlag:                conntrackStatusSeenReply,
+		Timeout:                   30,
+		StartTime:                 startTime,
+	}
+	tests := []struct {
+		name             string
+		query            string
+		expectedCalls    func(cq *queriertest.MockAgentConnectionQuerierMockRecorder)
+		expectedStatus   int
+		expectedResponse []Response
+	}{
+		{
+			name: "get all connections",
+			expectedCalls: func(cq *queriertest.MockAgentConnectionQuerierMockRecorder) {
+				cq.GetConnections(&querier.ConnectionFilter{}).Return([]*flowexporter.Connection{serviceConn, udpConn}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: []Response{
+				{
+					Protocol:                "TCP",
+					SourceIP:                "10.10.0.1",
+					SourcePort:              50000,
+					SourcePod:               "ns1/pod1",
+					DestinationIP:           "10.10.1.2",
+					DestinationPort:         8080,
+					OriginalDestinationIP:   "10.96.0.10",
+					OriginalDestinationPort: 80,
+					Service:                 "ns1/svc1:http",
+					State:                   "ESTABLISHED",
+					Timeout:                 86399,
+					StartTime:               startTime,
+					IngressNetworkPolicy:    "ns1/np1",
+					EgressNetworkPolicy:     "acnp1",
+				},
+				{
+					Protocol:                "UDP",
+					SourceIP:                "10.10.0.1",
+					SourcePort:              50001,
+					DestinationIP:           "10.10.0.2",
+					DestinationPort:         53,
+					DestinationPod:          "ns2/pod2",
+					OriginalDestinationIP:   "10.10.0.2",
+					OriginalDestinationPort: 53,
+					State:                   "REPLIED",
+					Timeout:                 30,
+					StartTime:               startTime,
+				},
+			},
+		},
+		{
+			name:  "filter by Pod",
+			query: "?namespace=ns1&pod=pod1",
+			expectedCalls: func(cq *queriertest.MockAgentConnectionQuerierMockRecorder) {
+				cq.GetConnections(&querier.ConnectionFilter{Namespace: "ns1", PodName: "pod1"}).Return(nil, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedResponse: []Response{},
+		},
+		{
+			name:  "filter by 5-tuple",
+			query: "?src-ip=10.10.0.1&src-port=50000&dst-ip=10.96.0.10&dst-port=80&protocol=TCP",
+			expectedCalls: func(cq *queriertest.MockAgentConnectionQuerierMockRecorder) {
+				cq.GetConnections(&querier.ConnectionFilter{
+					SourceIP:        net.ParseIP("10.10.0.1"),
+					SourcePort:      50000,
+					DestinationIP:   net.ParseIP("10.96.0.10"),
+					DestinationPort: 80,
+					Protocol:        6,
+				}).Return(nil, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedResponse: []Response{},
+		},
+		{
+			name:           "Service without Namespace",
+			query:          "?service=svc1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid IP",
+			query:          "?dst-ip=10.96.0",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid port",
+			query:          "?dst-port=65536",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unsupported protocol",
+			query:          "?protocol=gre",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "failed to dump connections",
+			expectedCalls: func(cq *queriertest.MockAgentConnectionQuerierMockRecorder) {
+				cq.GetConnections(&querier.ConnectionFilter{}).Return(nil, fmt.Errorf("error when getting netlink socket"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cq := queriertest.NewMockAgentConnectionQuerier(ctrl)
+			if tt.expectedCalls != nil {
+				tt.expectedCalls(cq.EXPECT())
+			}
+			handler := HandleFunc(cq)
+
+			req, err := http.NewRequest(http.MethodGet, tt.query, nil)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var received []Response
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &received))
+				assert.Equal(t, tt.expectedResponse, received)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deletedrules
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/antctl/transform/common"
+	"antrea.io/antrea/pkg/querier"
+)
+
+// Response describes the response struct of the expire-deletedrules command.
+type Response struct {
+	ExpiredRules int `json:"expiredRules"`
+}
+
+// HandleExpireFunc creates a http.HandlerFunc which uses an AgentNetworkPolicyInfoQuerier
+// to expire the rules of deleted NetworkPolicies pending async deletion immediately.
+func HandleExpireFunc(npq querier.AgentNetworkPolicyInfoQuerier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp := Response{ExpiredRules: npq.ExpireDeletedRules()}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			klog.ErrorS(err, "Error when encoding expired rules to json")
+		}
+	}
+}
+
+var _ common.TableOutput = new(Response)
+
+func (r Response) GetTableHeader() []string {
+	return []string{"EXPIRED-RULES"}
+}
+
+func (r Response) GetTableRow(maxColumnLength int) []string {
+	return []string{strconv.Itoa(r.ExpiredRules)}
+}
+
+func (r Response) SortRows() bool {
+	return true
+}
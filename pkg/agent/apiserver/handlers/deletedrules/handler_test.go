@@ -0,0 +1,44 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deletedrules
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	queriertest "antrea.io/antrea/pkg/querier/testing"
+)
+
+func TestExpireDeletedRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	npq := queriertest.NewMockAgentNetworkPolicyInfoQuerier(ctrl)
+	npq.EXPECT().ExpireDeletedRules().Return(3)
+	handler := HandleExpireFunc(npq)
+
+	req, err := http.NewRequest(http.MethodGet, "", nil)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var received Response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &received))
+	assert.Equal(t, Response{ExpiredRules: 3}, received)
+}
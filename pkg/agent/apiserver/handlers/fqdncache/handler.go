@@ -0,0 +1,107 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fqdncache
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/antctl/transform/common"
+	"antrea.io/antrea/pkg/features"
+	"antrea.io/antrea/pkg/querier"
+)
+
+// Response describes the response struct of fqdncache command.
+type Response struct {
+	FQDN           string    `json:"fqdn,omitempty"`
+	IPAddresses    []string  `json:"ipAddresses,omitempty"`
+	ExpirationTime time.Time `json:"expirationTime,omitempty"`
+	Rules          []string  `json:"rules,omitempty"`
+}
+
+// HandleFunc creates a http.HandlerFunc which uses an AgentNetworkPolicyInfoQuerier
+// to query the DNS cache of the FQDNs selected by FQDN policy rules. The HandlerFunc
+// accepts an optional `domain` parameter in URL, which can be an exact name or a
+// wildcard pattern, e.g. "*.example.com".
+func HandleFunc(npq querier.AgentNetworkPolicyInfoQuerier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !features.DefaultFeatureGate.Enabled(features.AntreaPolicy) {
+			http.Error(w, "AntreaPolicy is not enabled", http.StatusServiceUnavailable)
+			return
+		}
+		domain := r.URL.Query().Get("domain")
+		entries := npq.GetFQDNCache(&querier.FQDNCacheFilter{DomainName: domain})
+		resp := make([]Response, 0, len(entries))
+		for _, entry := range entries {
+			ips := make([]string, 0, len(entry.IPAddresses))
+			for _, ip := range entry.IPAddresses {
+				ips = append(ips, ip.String())
+			}
+			resp = append(resp, Response{
+				FQDN:           entry.FQDN,
+				IPAddresses:    ips,
+				ExpirationTime: entry.ExpirationTime,
+				Rules:          entry.RuleIDs,
+			})
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			klog.ErrorS(err, "Error when encoding FQDN cache to json")
+		}
+	}
+}
+
+// HandleFlushFunc creates a http.HandlerFunc which uses an AgentNetworkPolicyInfoQuerier
+// to flush the DNS cache of a FQDN, provided with the `domain` parameter in URL. The
+// cached IP addresses of the FQDN are expired and the FQDN is resolved again immediately.
+func HandleFlushFunc(npq querier.AgentNetworkPolicyInfoQuerier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !features.DefaultFeatureGate.Enabled(features.AntreaPolicy) {
+			http.Error(w, "AntreaPolicy is not enabled", http.StatusServiceUnavailable)
+			return
+		}
+		domain := r.URL.Query().Get("domain")
+		if domain == "" {
+			http.Error(w, "domain must be provided", http.StatusBadRequest)
+			return
+		}
+		if err := npq.FlushFQDNCache(domain); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+	}
+}
+
+var _ common.TableOutput = new(Response)
+
+func (r Response) GetTableHeader() []string {
+	return []string{"FQDN", "IP-ADDRESSES", "EXPIRATION-TIME", "RULES"}
+}
+
+func (r Response) GetTableRow(maxColumnLength int) []string {
+	return []string{
+		r.FQDN,
+		common.GenerateTableElementWithSummary(r.IPAddresses, maxColumnLength),
+		r.ExpirationTime.Format(time.RFC3339),
+		common.GenerateTableElementWithSummary(r.Rules, maxColumnLength),
+	}
+}
+
+func (r Response) SortRows() bool {
+	return true
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fqdncache
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/features"
+	"antrea.io/antrea/pkg/querier"
+	queriertest "antrea.io/antrea/pkg/querier/testing"
+)
+
+func TestFQDNCacheQuery(t *testing.T) {
+	expirationTime := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name                string
+		query               string
+		antreaPolicyEnabled bool
+		expectedCalls       func(npq *queriertest.MockAgentNetworkPolicyInfoQuerierMockRecorder)
+		expectedStatus      int
+		expectedResponse    []Response
+	}{
+		{
+			name:                "get all entries",
+			antreaPolicyEnabled: true,
+			expectedCalls: func(npq *queriertest.MockAgentNetworkPolicyInfoQuerierMockRecorder) {
+				npq.GetFQDNCache(&querier.FQDNCacheFilter{}).Return([]types.DNSCacheEntry{
+					{
+						FQDN:           "www.example.com",
+						IPAddresses:    []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("fec0::1")},
+						ExpirationTime: expirationTime,
+						RuleIDs:        []string{"rule1"},
+					},
+				})
+			},
+			expectedStatus: http.StatusOK,
+			expectedResponse: []Response{
+				{
+					FQDN:           "www.example.com",
+					IPAddresses:    []string{"10.0.0.1", "fec0::1"},
+					ExpirationTime: expirationTime,
+					Rules:          []string{"rule1"},
+				},
+			},
+		},
+		{
+			name:                "filter by domain",
+			query:               "?domain=*.example.com",
+			antreaPolicyEnabled: true,
+			expectedCalls: func(npq *queriertest.MockAgentNetworkPolicyInfoQuerierMockRecorder) {
+				npq.GetFQDNCache(&querier.FQDNCacheFilter{DomainName: "*.example.com"}).Return(nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedResponse: []Response{},
+		},
+		{
+			name:           "AntreaPolicy disabled",
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.AntreaPolicy, tt.antreaPolicyEnabled)()
+			ctrl := gomock.NewController(t)
+			npq := queriertest.NewMockAgentNetworkPolicyInfoQuerier(ctrl)
+			if tt.expectedCalls != nil {
+				tt.expectedCalls(npq.EXPECT())
+			}
+			handler := HandleFunc(npq)
+
+			req, err := http.NewRequest(http.MethodGet, tt.query, nil)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var received []Response
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &received))
+				assert.Equal(t, tt.expectedResponse, received)
+			}
+		})
+	}
+}
+
+func TestFQDNCacheFlush(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedCalls  func(npq *queriertest.MockAgentNetworkPolicyInfoQuerierMockRecorder)
+		expectedStatus int
+	}{
+		{
+			name:  "flush domain",
+			query: "?domain=www.example.com",
+			expectedCalls: func(npq *queriertest.MockAgentNetworkPolicyInfoQuerierMockRecorder) {
+				npq.FlushFQDNCache("www.example.com").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "domain not cached",
+			query: "?domain=www.example.com",
+			expectedCalls: func(npq *queriertest.MockAgentNetworkPolicyInfoQuerierMockRecorder) {
+				npq.FlushFQDNCache("www.example.com").Return(fmt.Errorf("FQDN www.example.com is not in the DNS cache"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "domain not provided",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			npq := queriertest.NewMockAgentNetworkPolicyInfoQuerier(ctrl)
+			if tt.expectedCalls != nil {
+				tt.expectedCalls(npq.EXPECT())
+			}
+			handler := HandleFlushFunc(npq)
+
+			req, err := http.NewRequest(http.MethodGet, tt.query, nil)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+		})
+	}
+}
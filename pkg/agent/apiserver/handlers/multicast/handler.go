@@ -26,18 +26,22 @@ import (
 )
 
 type Response struct {
-	PodName      string `json:"name,omitempty" antctl:"name,Name of the Pod"`
-	PodNamespace string `json:"podNamespace,omitempty"`
-	Inbound      string `json:"inbound,omitempty"`
-	Outbound     string `json:"outbound,omitempty"`
+	PodName       string `json:"name,omitempty" antctl:"name,Name of the Pod"`
+	PodNamespace  string `json:"podNamespace,omitempty"`
+	Inbound       string `json:"inbound,omitempty"`
+	Outbound      string `json:"outbound,omitempty"`
+	InboundBytes  string `json:"inboundBytes,omitempty"`
+	OutboundBytes string `json:"outboundBytes,omitempty"`
 }
 
 func generateResponse(podName string, podNamespace string, trafficStats *multicast.PodTrafficStats) Response {
 	return Response{
-		PodName:      podName,
-		PodNamespace: podNamespace,
-		Inbound:      strconv.FormatUint(trafficStats.Inbound, 10),
-		Outbound:     strconv.FormatUint(trafficStats.Outbound, 10),
+		PodName:       podName,
+		PodNamespace:  podNamespace,
+		Inbound:       strconv.FormatUint(trafficStats.Inbound, 10),
+		Outbound:      strconv.FormatUint(trafficStats.Outbound, 10),
+		InboundBytes:  strconv.FormatUint(trafficStats.InboundBytes, 10),
+		OutboundBytes: strconv.FormatUint(trafficStats.OutboundBytes, 10),
 	}
 }
 
@@ -82,11 +86,11 @@ func HandleFunc(mq querier.AgentMulticastInfoQuerier) http.HandlerFunc {
 var _ common.TableOutput = new(Response)
 
 func (r Response) GetTableHeader() []string {
-	return []string{"NAMESPACE", "NAME", "INBOUND", "OUTBOUND"}
+	return []string{"NAMESPACE", "NAME", "INBOUND", "OUTBOUND", "INBOUND-BYTES", "OUTBOUND-BYTES"}
 }
 
 func (r Response) GetTableRow(_ int) []string {
-	return []string{r.PodNamespace, r.PodName, r.Inbound, r.Outbound}
+	return []string{r.PodNamespace, r.PodName, r.Inbound, r.Outbound, r.InboundBytes, r.OutboundBytes}
 }
 
 func (r Response) SortRows() bool {
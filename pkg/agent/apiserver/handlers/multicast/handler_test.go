@@ -50,13 +50,15 @@ func TestPodMulticastStatsQuery(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedContent: []Response{
 				{
-					PodName:      "pod1",
-					PodNamespace: "namespaceA",
-					Inbound:      "22",
-					Outbound:     "33",
+					PodName:       "pod1",
+					PodNamespace:  "namespaceA",
+					Inbound:       "22",
+					Outbound:      "33",
+					InboundBytes:  "220",
+					OutboundBytes: "330",
 				},
 			},
-			getPodStatsResult: &multicast.PodTrafficStats{Inbound: 22, Outbound: 33},
+			getPodStatsResult: &multicast.PodTrafficStats{Inbound: 22, Outbound: 33, InboundBytes: 220, OutboundBytes: 330},
 		},
 		"Miss PodMulticastStats query, namespace and name provided": {
 			name:              "pod1",
@@ -74,15 +76,17 @@ func TestPodMulticastStatsQuery(t *testing.T) {
 			namespace:      "namespaceA",
 			expectedStatus: http.StatusOK,
 			gettAllPodsStatsResult: map[*interfacestore.InterfaceConfig]*multicast.PodTrafficStats{
-				{ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "test1"}}:      {Inbound: 22, Outbound: 33},
-				{ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod2", PodNamespace: "namespaceA"}}: {Inbound: 44, Outbound: 69},
+				{ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "test1"}}:      {Inbound: 22, Outbound: 33, InboundBytes: 220, OutboundBytes: 330},
+				{ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod2", PodNamespace: "namespaceA"}}: {Inbound: 44, Outbound: 69, InboundBytes: 440, OutboundBytes: 690},
 			},
 			expectedContent: []Response{
 				{
-					PodName:      "pod2",
-					PodNamespace: "namespaceA",
-					Inbound:      "44",
-					Outbound:     "69",
+					PodName:       "pod2",
+					PodNamespace:  "namespaceA",
+					Inbound:       "44",
+					Outbound:      "69",
+					InboundBytes:  "440",
+					OutboundBytes: "690",
 				},
 			},
 		},
@@ -91,21 +95,25 @@ func TestPodMulticastStatsQuery(t *testing.T) {
 			namespace:      "",
 			expectedStatus: http.StatusOK,
 			gettAllPodsStatsResult: map[*interfacestore.InterfaceConfig]*multicast.PodTrafficStats{
-				{ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "test1"}}: {Inbound: 22, Outbound: 33},
-				{ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod2", PodNamespace: "test2"}}: {Inbound: 44, Outbound: 66},
+				{ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "test1"}}: {Inbound: 22, Outbound: 33, InboundBytes: 220, OutboundBytes: 330},
+				{ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod2", PodNamespace: "test2"}}: {Inbound: 44, Outbound: 66, InboundBytes: 440, OutboundBytes: 660},
 			},
 			expectedContent: []Response{
 				{
-					PodName:      "pod1",
-					PodNamespace: "test1",
-					Inbound:      "22",
-					Outbound:     "33",
+					PodName:       "pod1",
+					PodNamespace:  "test1",
+					Inbound:       "22",
+					Outbound:      "33",
+					InboundBytes:  "220",
+					OutboundBytes: "330",
 				},
 				{
-					PodName:      "pod2",
-					PodNamespace: "test2",
-					Inbound:      "44",
-					Outbound:     "66",
+					PodName:       "pod2",
+					PodNamespace:  "test2",
+					Inbound:       "44",
+					Outbound:      "66",
+					InboundBytes:  "440",
+					OutboundBytes: "660",
 				},
 			},
 		},
@@ -0,0 +1,79 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovsoffload
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"antrea.io/antrea/pkg/antctl/transform/common"
+	"antrea.io/antrea/pkg/querier"
+)
+
+// Response describes the response struct of ovsoffload command.
+type Response struct {
+	Category          string `json:"category"`
+	OffloadedFlows    int    `json:"offloadedFlows"`
+	NonOffloadedFlows int    `json:"nonOffloadedFlows"`
+	// The percentage of the flows of the category which are offloaded to hardware, or "N/A" if the category has no
+	// flow.
+	Coverage string `json:"coverage"`
+}
+
+func getCoverage(status querier.OVSOffloadStatus) string {
+	total := status.OffloadedFlows + status.NonOffloadedFlows
+	if total == 0 {
+		return "N/A"
+	}
+	return strconv.FormatFloat(float64(status.OffloadedFlows)*100/float64(total), 'f', 1, 64) + "%"
+}
+
+// HandleFunc creates a http.HandlerFunc which uses an AgentOVSOffloadQuerier to get the number of offloaded and
+// non-offloaded OVS datapath flows of each flow category.
+func HandleFunc(oq querier.AgentOVSOffloadQuerier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if oq == nil {
+			http.Error(w, "OVS hardware offload is not enabled", http.StatusServiceUnavailable)
+			return
+		}
+		response := []Response{}
+		for _, status := range oq.GetOVSOffloadStatus() {
+			response = append(response, Response{
+				Category:          status.Category,
+				OffloadedFlows:    status.OffloadedFlows,
+				NonOffloadedFlows: status.NonOffloadedFlows,
+				Coverage:          getCoverage(status),
+			})
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			http.Error(w, "Failed to encode response: "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+var _ common.TableOutput = (*Response)(nil)
+
+func (r Response) GetTableHeader() []string {
+	return []string{"CATEGORY", "OFFLOADED", "NON-OFFLOADED", "COVERAGE"}
+}
+
+func (r Response) GetTableRow(_ int) []string {
+	return []string{r.Category, strconv.Itoa(r.OffloadedFlows), strconv.Itoa(r.NonOffloadedFlows), r.Coverage}
+}
+
+func (r Response) SortRows() bool {
+	return false
+}
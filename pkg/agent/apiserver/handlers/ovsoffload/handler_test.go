@@ -0,0 +1,58 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovsoffload
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"antrea.io/antrea/pkg/querier"
+	queriertest "antrea.io/antrea/pkg/querier/testing"
+)
+
+func TestOVSOffloadQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	oq := queriertest.NewMockAgentOVSOffloadQuerier(ctrl)
+	oq.EXPECT().GetOVSOffloadStatus().Return([]querier.OVSOffloadStatus{
+		{Category: "pod", OffloadedFlows: 3, NonOffloadedFlows: 1},
+		{Category: "tunnel"},
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "", nil)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	HandleFunc(oq).ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var received []Response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &received))
+	assert.Equal(t, []Response{
+		{Category: "pod", OffloadedFlows: 3, NonOffloadedFlows: 1, Coverage: "75.0%"},
+		{Category: "tunnel", Coverage: "N/A"},
+	}, received)
+}
+
+func TestOVSOffloadQueryNotEnabled(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "", nil)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	HandleFunc(nil).ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
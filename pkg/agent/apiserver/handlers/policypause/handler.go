@@ -0,0 +1,95 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policypause
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/antctl/transform/common"
+	"antrea.io/antrea/pkg/querier"
+)
+
+// Response describes the response struct of the pause-networkpolicy, resume-networkpolicy and
+// get pausednetworkpolicy commands.
+type Response struct {
+	Name string `json:"name,omitempty"`
+}
+
+// HandleFunc creates a http.HandlerFunc which uses an AgentNetworkPolicyInfoQuerier
+// to query the NetworkPolicies whose realization is paused on the Node.
+func HandleFunc(npq querier.AgentNetworkPolicyInfoQuerier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		names := npq.GetPausedNetworkPolicies()
+		resp := make([]Response, 0, len(names))
+		for _, name := range names {
+			resp = append(resp, Response{Name: name})
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			klog.ErrorS(err, "Error when encoding paused NetworkPolicies to json")
+		}
+	}
+}
+
+// HandlePauseFunc creates a http.HandlerFunc which uses an AgentNetworkPolicyInfoQuerier
+// to pause the realization of a NetworkPolicy, provided with the `name` parameter in URL.
+// The current flows of the NetworkPolicy are kept until its realization is resumed.
+func HandlePauseFunc(npq querier.AgentNetworkPolicyInfoQuerier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			http.Error(w, "name must be provided", http.StatusBadRequest)
+			return
+		}
+		if err := npq.PauseNetworkPolicy(name); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+	}
+}
+
+// HandleResumeFunc creates a http.HandlerFunc which uses an AgentNetworkPolicyInfoQuerier
+// to resume the realization of a NetworkPolicy paused previously, provided with the `name`
+// parameter in URL.
+func HandleResumeFunc(npq querier.AgentNetworkPolicyInfoQuerier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		if name == "" {
+			http.Error(w, "name must be provided", http.StatusBadRequest)
+			return
+		}
+		if err := npq.ResumeNetworkPolicy(name); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+	}
+}
+
+var _ common.TableOutput = new(Response)
+
+func (r Response) GetTableHeader() []string {
+	return []string{"NAME"}
+}
+
+func (r Response) GetTableRow(maxColumnLength int) []string {
+	return []string{r.Name}
+}
+
+func (r Response) SortRows() bool {
+	return true
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policypause
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	queriertest "antrea.io/antrea/pkg/querier/testing"
+)
+
+func TestPausedNetworkPoliciesQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	npq := queriertest.NewMockAgentNetworkPolicyInfoQuerier(ctrl)
+	npq.EXPECT().GetPausedNetworkPolicies().Return([]string{"uid1", "uid2"})
+	handler := HandleFunc(npq)
+
+	req, err := http.NewRequest(http.MethodGet, "", nil)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var received []Response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &received))
+	assert.Equal(t, []Response{{Name: "uid1"}, {Name: "uid2"}}, received)
+}
+
+func TestPauseAndResumeNetworkPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		resume         bool
+		query          string
+		expectedCalls  func(npq *queriertest.MockAgentNetworkPolicyInfoQuerierMockRecorder)
+		expectedStatus int
+	}{
+		{
+			name:  "pause policy",
+			query: "?name=uid1",
+			expectedCalls: func(npq *queriertest.MockAgentNetworkPolicyInfoQuerierMockRecorder) {
+				npq.PauseNetworkPolicy("uid1").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "pause unknown policy",
+			query: "?name=uid1",
+			expectedCalls: func(npq *queriertest.MockAgentNetworkPolicyInfoQuerierMockRecorder) {
+				npq.PauseNetworkPolicy("uid1").Return(fmt.Errorf("NetworkPolicy uid1 not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:   "resume policy",
+			resume: true,
+			query:  "?name=uid1",
+			expectedCalls: func(npq *queriertest.MockAgentNetworkPolicyInfoQuerierMockRecorder) {
+				npq.ResumeNetworkPolicy("uid1").Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "resume policy not paused",
+			resume: true,
+			query:  "?name=uid1",
+			expectedCalls: func(npq *queriertest.MockAgentNetworkPolicyInfoQuerierMockRecorder) {
+				npq.ResumeNetworkPolicy("uid1").Return(fmt.Errorf("NetworkPolicy uid1 is not paused"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "name not provided",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			npq := queriertest.NewMockAgentNetworkPolicyInfoQuerier(ctrl)
+			if tt.expectedCalls != nil {
+				tt.expectedCalls(npq.EXPECT())
+			}
+			handler := HandlePauseFunc(npq)
+			if tt.resume {
+				handler = HandleResumeFunc(npq)
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tt.query, nil)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policysimulation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/querier"
+	utilip "antrea.io/antrea/pkg/util/ip"
+)
+
+var protocolNumbers = map[string]uint8{
+	"icmp":   utilip.ICMPProtocol,
+	"igmp":   utilip.IGMPProtocol,
+	"tcp":    utilip.TCPProtocol,
+	"udp":    utilip.UDPProtocol,
+	"icmpv6": utilip.ICMPv6Protocol,
+	"sctp":   utilip.SCTPProtocol,
+}
+
+// Response describes the response struct of the simulate-policy command.
+type Response struct {
+	Stages  []types.PolicySimulationStage `json:"stages"`
+	Verdict string                        `json:"verdict"`
+}
+
+// HandleFunc returns the function which can handle the /policysimulation API request. The flow
+// to evaluate is described by the following parameters in URL: `src-ip` and `dst-ip`, which are
+// required, `protocol`, which is a protocol name or number and defaults to TCP, `src-port` and
+// `dst-port`.
+func HandleFunc(npq querier.AgentNetworkPolicyInfoQuerier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flow, err := newSimulatedFlow(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result := npq.SimulateFlow(flow)
+		resp := Response{Stages: result.Stages, Verdict: result.Verdict}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			klog.ErrorS(err, "Error when encoding policy simulation result to json")
+		}
+	}
+}
+
+func newSimulatedFlow(r *http.Request) (*querier.SimulatedFlow, error) {
+	query := r.URL.Query()
+	flow := &querier.SimulatedFlow{Protocol: utilip.TCPProtocol}
+	var err error
+	if flow.SourceIP, err = parseIP("src-ip", query.Get("src-ip")); err != nil {
+		return nil, err
+	}
+	if flow.DestinationIP, err = parseIP("dst-ip", query.Get("dst-ip")); err != nil {
+		return nil, err
+	}
+	if (flow.SourceIP.To4() == nil) != (flow.DestinationIP.To4() == nil) {
+		return nil, fmt.Errorf("src-ip and dst-ip must be of the same IP family")
+	}
+	if flow.SourcePort, err = parsePort(query.Get("src-port")); err != nil {
+		return nil, err
+	}
+	if flow.DestinationPort, err = parsePort(query.Get("dst-port")); err != nil {
+		return nil, err
+	}
+	if protocol := query.Get("protocol"); protocol != "" {
+		protocolNumber, ok := protocolNumbers[strings.ToLower(protocol)]
+		if !ok {
+			number, err := strconv.ParseUint(protocol, 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("unsupported protocol %s", protocol)
+			}
+			protocolNumber = uint8(number)
+		}
+		flow.Protocol = protocolNumber
+	}
+	return flow, nil
+}
+
+func parseIP(name, str string) (net.IP, error) {
+	if str == "" {
+		return nil, fmt.Errorf("%s must be provided", name)
+	}
+	ip := net.ParseIP(str)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %s", str)
+	}
+	return ip, nil
+}
+
+func parsePort(str string) (uint16, error) {
+	if str == "" {
+		return 0, nil
+	}
+	port, err := strconv.ParseUint(str, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port %s", str)
+	}
+	return uint16(port), nil
+}
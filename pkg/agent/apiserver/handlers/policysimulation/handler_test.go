@@ -0,0 +1,128 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policysimulation
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/querier"
+	queriertest "antrea.io/antrea/pkg/querier/testing"
+)
+
+func TestPolicySimulationQuery(t *testing.T) {
+	result := &types.PolicySimulationResult{
+		Stages: []types.PolicySimulationStage{
+			{
+				Stage:        "AntreaPolicyEgressRule",
+				Policy:       "AntreaClusterNetworkPolicy:acnp1",
+				Rule:         "rule1",
+				Action:       "Drop",
+				MatchedRules: []string{"AntreaClusterNetworkPolicy:acnp1 (rule rule1)"},
+			},
+		},
+		Verdict: "Drop",
+	}
+	tests := []struct {
+		name           string
+		query          string
+		expectedFlow   *querier.SimulatedFlow
+		expectedStatus int
+	}{
+		{
+			name:  "TCP flow",
+			query: "?src-ip=10.0.0.1&dst-ip=10.0.0.2&src-port=30000&dst-port=80",
+			expectedFlow: &querier.SimulatedFlow{
+				SourceIP:        net.ParseIP("10.0.0.1"),
+				DestinationIP:   net.ParseIP("10.0.0.2"),
+				Protocol:        6,
+				SourcePort:      30000,
+				DestinationPort: 80,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "UDP flow with protocol number",
+			query: "?src-ip=fd00::1&dst-ip=fd00::2&protocol=17&dst-port=53",
+			expectedFlow: &querier.SimulatedFlow{
+				SourceIP:        net.ParseIP("fd00::1"),
+				DestinationIP:   net.ParseIP("fd00::2"),
+				Protocol:        17,
+				DestinationPort: 53,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "ICMP flow",
+			query: "?src-ip=10.0.0.1&dst-ip=10.0.0.2&protocol=ICMP",
+			expectedFlow: &querier.SimulatedFlow{
+				SourceIP:      net.ParseIP("10.0.0.1"),
+				DestinationIP: net.ParseIP("10.0.0.2"),
+				Protocol:      1,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing destination IP",
+			query:          "?src-ip=10.0.0.1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "mixed IP families",
+			query:          "?src-ip=10.0.0.1&dst-ip=fd00::2",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid port",
+			query:          "?src-ip=10.0.0.1&dst-ip=10.0.0.2&dst-port=70000",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unsupported protocol",
+			query:          "?src-ip=10.0.0.1&dst-ip=10.0.0.2&protocol=foo",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			npq := queriertest.NewMockAgentNetworkPolicyInfoQuerier(ctrl)
+			if tt.expectedFlow != nil {
+				npq.EXPECT().SimulateFlow(tt.expectedFlow).Return(result)
+			}
+			handler := HandleFunc(npq)
+
+			req, err := http.NewRequest(http.MethodGet, tt.query, nil)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			require.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var received Response
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &received))
+			assert.Equal(t, Response{Stages: result.Stages, Verdict: result.Verdict}, received)
+		})
+	}
+}
@@ -18,9 +18,13 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
+	"strings"
 	"sync"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"k8s.io/apiserver/pkg/server/dynamiccertificates"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -28,22 +32,30 @@ import (
 	"k8s.io/component-base/config"
 	"k8s.io/klog/v2"
 
+	cpstreamv1alpha1 "antrea.io/antrea/pkg/apis/controlplanestream/v1alpha1"
 	cert "antrea.io/antrea/pkg/apiserver/certificate"
 	"antrea.io/antrea/pkg/client/clientset/versioned"
+	"antrea.io/antrea/pkg/features"
 )
 
 // AntreaClientProvider provides a method to get Antrea client.
 type AntreaClientProvider interface {
 	GetAntreaClient() (versioned.Interface, error)
+	// GetControlplaneStreamClient returns a client of the ControlplaneStream gRPC service of
+	// antrea-controller. It is only available when the ControlplaneGRPC feature is enabled.
+	GetControlplaneStreamClient() (cpstreamv1alpha1.ControlplaneStreamClient, error)
 }
 
 // antreaClientProvider provides an AntreaClientProvider that can dynamically react to ConfigMap changes.
 type antreaClientProvider struct {
 	config config.ClientConnectionConfiguration
-	// mutex protects client.
+	// mutex protects client and streamConn.
 	mutex sync.RWMutex
 	// client is the Antrea client that will be returned. It will be updated when caBundle is updated.
 	client versioned.Interface
+	// streamConn is the gRPC connection to antrea-controller used by the ControlplaneStream
+	// clients. Like client, it will be updated when caBundle is updated.
+	streamConn *grpc.ClientConn
 	// caContentProvider provides the very latest content of the ca bundle.
 	caContentProvider *dynamiccertificates.ConfigMapCAController
 }
@@ -98,6 +110,16 @@ func (p *antreaClientProvider) GetAntreaClient() (versioned.Interface, error) {
 	return p.client, nil
 }
 
+// GetControlplaneStreamClient implements AntreaClientProvider.
+func (p *antreaClientProvider) GetControlplaneStreamClient() (cpstreamv1alpha1.ControlplaneStreamClient, error) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	if p.streamConn == nil {
+		return nil, fmt.Errorf("Antrea controlplane stream connection is not ready")
+	}
+	return cpstreamv1alpha1.NewControlplaneStreamClient(p.streamConn), nil
+}
+
 func (p *antreaClientProvider) updateAntreaClient() error {
 	var kubeConfig *rest.Config
 	var err error
@@ -126,15 +148,75 @@ func (p *antreaClientProvider) updateAntreaClient() error {
 	if err != nil {
 		return err
 	}
+	var streamConn *grpc.ClientConn
+	if features.DefaultFeatureGate.Enabled(features.ControlplaneGRPC) {
+		streamConn, err = newControlplaneStreamConn(kubeConfig)
+		if err != nil {
+			return err
+		}
+	}
 
 	klog.Info("Updating Antrea client with the new CA bundle")
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 	p.client = client
+	if p.streamConn != nil {
+		// Unlike HTTP watches, the streams of the previous connection are interrupted, and
+		// restarted with the new connection.
+		p.streamConn.Close()
+	}
+	p.streamConn = streamConn
 
 	return nil
 }
 
+// newControlplaneStreamConn creates a gRPC connection to the apiserver of antrea-controller, which
+// serves the ControlplaneStream gRPC service on the same port as the controlplane API, with the
+// same TLS configuration and credentials as the Antrea client.
+func newControlplaneStreamConn(kubeConfig *rest.Config) (*grpc.ClientConn, error) {
+	tlsConfig, err := rest.TLSConfigFor(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		return nil, fmt.Errorf("TLS is required to connect to the Antrea controlplane stream")
+	}
+	u, err := url.Parse(kubeConfig.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host %q: %w", kubeConfig.Host, err)
+	}
+	return grpc.Dial(u.Host,
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithPerRPCCredentials(&tokenCredentials{token: kubeConfig.BearerToken, tokenFile: kubeConfig.BearerTokenFile}))
+}
+
+// tokenCredentials implements credentials.PerRPCCredentials. It authenticates gRPC requests with
+// the bearer token of the client config. The token file takes precedence over the token, and is
+// read for every request so that the rotated token is used.
+type tokenCredentials struct {
+	token     string
+	tokenFile string
+}
+
+func (c *tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token := c.token
+	if c.tokenFile != "" {
+		data, err := os.ReadFile(c.tokenFile)
+		if err != nil {
+			return nil, err
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token == "" {
+		return nil, nil
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+func (c *tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
 // inClusterConfig returns a config object which uses the service account
 // kubernetes gives to pods. It's intended for clients that expect to be
 // running inside a pod running on kubernetes. It will return error
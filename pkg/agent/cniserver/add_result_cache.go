@@ -0,0 +1,125 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cniserver
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	cnipb "antrea.io/antrea/pkg/apis/cni/v1beta1"
+)
+
+// addResultCacheTTL is how long the result of a successful CNI ADD request is kept. kubelet retries CNI ADD shortly
+// after timing out waiting for the result, so there is no need to keep it longer.
+const addResultCacheTTL = 10 * time.Minute
+
+type addResultKey struct {
+	containerID string
+	ifname      string
+}
+
+// addRequest holds the arguments of a CNI ADD request which must be identical for a request to be considered as a
+// retry of a previous one.
+type addRequest struct {
+	netns                string
+	args                 string
+	path                 string
+	networkConfiguration []byte
+}
+
+func newAddRequest(cniArgs *cnipb.CniCmdArgs) addRequest {
+	return addRequest{
+		netns:                cniArgs.Netns,
+		args:                 cniArgs.Args,
+		path:                 cniArgs.Path,
+		networkConfiguration: append([]byte(nil), cniArgs.NetworkConfiguration...),
+	}
+}
+
+func (r *addRequest) equal(other *addRequest) bool {
+	return r.netns == other.netns && r.args == other.args && r.path == other.path &&
+		bytes.Equal(r.networkConfiguration, other.networkConfiguration)
+}
+
+type addResultEntry struct {
+	request    addRequest
+	response   *cnipb.CniCmdResponse
+	expireTime time.Time
+}
+
+// addResultCache caches the responses of the successful CNI ADD requests per container and interface, so that the
+// requests retried by kubelet after a timeout can be answered without allocating IPs and configuring the interfaces
+// again. Entries are removed by CNI DEL requests, and expire after addResultCacheTTL otherwise.
+type addResultCache struct {
+	mutex   sync.Mutex
+	entries map[addResultKey]*addResultEntry
+	clock   clock.Clock
+}
+
+func newAddResultCache(clock clock.Clock) *addResultCache {
+	return &addResultCache{
+		entries: map[addResultKey]*addResultEntry{},
+		clock:   clock,
+	}
+}
+
+// get returns the cached response of the CNI ADD request, or nil if there is no valid entry for the request. An entry
+// cached for a different request of the same container and interface is stale and is removed.
+func (c *addResultCache) get(cniArgs *cnipb.CniCmdArgs) *cnipb.CniCmdResponse {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	key := addResultKey{containerID: cniArgs.ContainerId, ifname: cniArgs.Ifname}
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	request := newAddRequest(cniArgs)
+	if !entry.request.equal(&request) || c.clock.Now().After(entry.expireTime) {
+		delete(c.entries, key)
+		return nil
+	}
+	return entry.response
+}
+
+// add caches the response of a successful CNI ADD request, and removes the expired entries.
+func (c *addResultCache) add(cniArgs *cnipb.CniCmdArgs, response *cnipb.CniCmdResponse) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.clock.Now()
+	for key, entry := range c.entries {
+		if now.After(entry.expireTime) {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[addResultKey{containerID: cniArgs.ContainerId, ifname: cniArgs.Ifname}] = &addResultEntry{
+		request:    newAddRequest(cniArgs),
+		response:   response,
+		expireTime: now.Add(addResultCacheTTL),
+	}
+}
+
+// delete removes the cached responses of all the interfaces of the container.
+func (c *addResultCache) delete(containerID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for key := range c.entries {
+		if key.containerID == containerID {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cniserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	cnipb "antrea.io/antrea/pkg/apis/cni/v1beta1"
+)
+
+func TestAddResultCache(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	cache := newAddResultCache(fakeClock)
+	newArgs := func(containerID, ifname, netns string) *cnipb.CniCmdArgs {
+		return &cnipb.CniCmdArgs{
+			ContainerId:          containerID,
+			Netns:                netns,
+			Ifname:               ifname,
+			Args:                 "K8S_POD_NAMESPACE=ns1;K8S_POD_NAME=pod1",
+			NetworkConfiguration: []byte(`{"cniVersion":"0.4.0","name":"antrea","type":"antrea"}`),
+		}
+	}
+	response1 := &cnipb.CniCmdResponse{CniResult: []byte("result1")}
+	response2 := &cnipb.CniCmdResponse{CniResult: []byte("result2")}
+
+	assert.Nil(t, cache.get(newArgs("c1", "eth0", "ns1")))
+	cache.add(newArgs("c1", "eth0", "ns1"), response1)
+	cache.add(newArgs("c1", "eth1", "ns1"), response2)
+	assert.Equal(t, response1, cache.get(newArgs("c1", "eth0", "ns1")))
+	assert.Equal(t, response2, cache.get(newArgs("c1", "eth1", "ns1")))
+	assert.Nil(t, cache.get(newArgs("c2", "eth0", "ns1")))
+
+	// A different request for the same container and interface makes the entry stale.
+	assert.Nil(t, cache.get(newArgs("c1", "eth0", "ns2")))
+	assert.Nil(t, cache.get(newArgs("c1", "eth0", "ns1")))
+
+	// The entries of all the interfaces of the container are deleted.
+	cache.add(newArgs("c1", "eth0", "ns1"), response1)
+	cache.delete("c1")
+	assert.Nil(t, cache.get(newArgs("c1", "eth0", "ns1")))
+	assert.Nil(t, cache.get(newArgs("c1", "eth1", "ns1")))
+
+	// Expired entries are not returned, and are removed when adding new entries.
+	cache.add(newArgs("c1", "eth0", "ns1"), response1)
+	fakeClock.Step(addResultCacheTTL + time.Second)
+	assert.Nil(t, cache.get(newArgs("c1", "eth0", "ns1")))
+	cache.add(newArgs("c2", "eth0", "ns1"), response2)
+	fakeClock.Step(addResultCacheTTL + time.Second)
+	cache.add(newArgs("c3", "eth0", "ns1"), response2)
+	assert.Len(t, cache.entries, 1)
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cniserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/containernetworking/cni/pkg/invoke"
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/cniserver/types"
+	agenttypes "antrea.io/antrea/pkg/agent/types"
+)
+
+const (
+	bandwidthPluginType = "bandwidth"
+	// defaultCNIPath is where the CNI plugins shipped with Antrea, including the bandwidth plugin, are installed.
+	defaultCNIPath = "/opt/cni/bin"
+)
+
+var (
+	// Declared as variables for testing.
+	execBandwidthPluginWithoutResultFunc = invoke.ExecPluginWithoutResult
+	findInPathFunc                       = invoke.FindInPath
+)
+
+// bandwidthPluginConfig is the network configuration passed to the bandwidth plugin when it's delegated to enforce the
+// bandwidth limits of a Pod.
+type bandwidthPluginConfig struct {
+	CNIVersion    string                 `json:"cniVersion"`
+	Name          string                 `json:"name"`
+	Type          string                 `json:"type"`
+	RuntimeConfig bandwidthRuntimeConfig `json:"runtimeConfig"`
+	PrevResult    cnitypes.Result        `json:"prevResult,omitempty"`
+}
+
+type bandwidthRuntimeConfig struct {
+	Bandwidth *types.BandwidthEntry `json:"bandwidth,omitempty"`
+}
+
+// configurePodBandwidth enforces the bandwidth limits requested by the container runtime for a Pod. The limits are
+// enforced with OVS meters if the OVS datapath supports them, so that they apply consistently to the kernel and
+// userspace datapaths. Otherwise, the bandwidth plugin is delegated to enforce them with TC qdiscs.
+func (s *CNIServer) configurePodBandwidth(cniConfig *CNIConfig, result *current.Result, netNS string) error {
+	bandwidth := cniConfig.RuntimeConfig.Bandwidth
+	if s.podConfigurator.ofClient.IsPodBandwidthMeterSupported() {
+		return s.podConfigurator.configureBandwidthMeters(cniConfig.ContainerId, &agenttypes.PodBandwidth{
+			IngressRate:  bandwidth.IngressRate,
+			IngressBurst: bandwidth.IngressBurst,
+			EgressRate:   bandwidth.EgressRate,
+			EgressBurst:  bandwidth.EgressBurst,
+		})
+	}
+	klog.V(2).InfoS("OVS meters are not supported, delegating Pod bandwidth limits to the bandwidth plugin", "container", cniConfig.ContainerId)
+	prevResult, err := result.GetAsVersion(cniConfig.CNIVersion)
+	if err != nil {
+		return err
+	}
+	return delegateBandwidthPlugin("ADD", cniConfig, prevResult, netNS)
+}
+
+// removePodBandwidth removes the TC qdiscs configured by the bandwidth plugin for a Pod. The OVS meters are removed
+// with the Pod interface, so there is nothing to do when they are supported.
+func (s *CNIServer) removePodBandwidth(cniConfig *CNIConfig) error {
+	if s.podConfigurator.ofClient.IsPodBandwidthMeterSupported() {
+		return nil
+	}
+	return delegateBandwidthPlugin("DEL", cniConfig, nil, s.hostNetNsPath(cniConfig.Netns))
+}
+
+func delegateBandwidthPlugin(command string, cniConfig *CNIConfig, prevResult cnitypes.Result, netNS string) error {
+	networkConfig, err := json.Marshal(&bandwidthPluginConfig{
+		CNIVersion:    cniConfig.CNIVersion,
+		Name:          cniConfig.Name,
+		Type:          bandwidthPluginType,
+		RuntimeConfig: bandwidthRuntimeConfig{Bandwidth: cniConfig.RuntimeConfig.Bandwidth},
+		PrevResult:    prevResult,
+	})
+	if err != nil {
+		return err
+	}
+	// defaultCNIPath is always searched first, as kubelet can be configured to search for CNI plugins in other paths,
+	// while the bandwidth plugin is installed there by the Antrea Agent Pod.
+	paths := append([]string{defaultCNIPath}, filepath.SplitList(cniConfig.Path)...)
+	pluginPath, err := findInPathFunc(bandwidthPluginType, paths)
+	if err != nil {
+		return err
+	}
+	args := &invoke.Args{
+		Command:     command,
+		ContainerID: cniConfig.ContainerId,
+		NetNS:       netNS,
+		IfName:      cniConfig.Ifname,
+		Path:        cniConfig.Path,
+	}
+	exec := &invoke.DefaultExec{RawExec: &invoke.RawExec{Stderr: os.Stderr}}
+	if err := execBandwidthPluginWithoutResultFunc(context.TODO(), pluginPath, networkConfig, args, exec); err != nil {
+		return fmt.Errorf("bandwidth plugin failed for CNI %s: %w", command, err)
+	}
+	return nil
+}
@@ -20,6 +20,9 @@ package cniserver
 import (
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	cnitypes "github.com/containernetworking/cni/pkg/types"
@@ -63,6 +66,7 @@ var (
 	nsGetNS                        = ns.GetNS
 	nsWithNetNSPath                = ns.WithNetNSPath
 	nsIsNSorErr                    = ns.IsNSorErr
+	sysctlDir                      = "/proc/sys"
 )
 
 type ifConfigurator struct {
@@ -197,6 +201,23 @@ func (ic *ifConfigurator) configureContainerSriovLinkOnBridge(
 	return ic.moveVFtoContainerNS(vfNetdevice, containerID, containerNetNS, containerIfaceName, mtu, result)
 }
 
+// configureSecondaryContainerLink creates a veth pair for a secondary network interface of a container. The host veth
+// name is generated with the container interface name, so that it doesn't conflict with the host veth of the primary
+// interface or of the other secondary interfaces of the container.
+func (ic *ifConfigurator) configureSecondaryContainerLink(
+	podName string,
+	podNamespace string,
+	containerID string,
+	containerNetNS string,
+	containerIfaceName string,
+	mtu int,
+	result *current.Result,
+) error {
+	hostIfaceName := util.GenerateContainerSecondaryInterfaceName(podName, podNamespace, containerID, containerIfaceName)
+	klog.V(2).InfoS("Create veth pair for secondary interface", "container", containerID, "interface", containerIfaceName)
+	return ic.configureContainerVeth(hostIfaceName, containerID, containerNetNS, containerIfaceName, mtu, result)
+}
+
 // configureContainerSriovLink moves the VF to the container namespace for Pod link SR-IOV interface;
 // intended for multiple interfaces other than the primary interface.
 func (ic *ifConfigurator) configureContainerSriovLink(
@@ -250,7 +271,17 @@ func (ic *ifConfigurator) configureContainerLinkVeth(
 	result *current.Result,
 ) error {
 	hostIfaceName := util.GenerateContainerInterfaceName(podName, podNamespace, containerID)
+	return ic.configureContainerVeth(hostIfaceName, containerID, containerNetNS, containerIfaceName, mtu, result)
+}
 
+func (ic *ifConfigurator) configureContainerVeth(
+	hostIfaceName string,
+	containerID string,
+	containerNetNS string,
+	containerIfaceName string,
+	mtu int,
+	result *current.Result,
+) error {
 	hostIface := &current.Interface{Name: hostIfaceName}
 	containerIface := &current.Interface{Name: containerIfaceName, Sandbox: containerNetNS}
 	result.Interfaces = []*current.Interface{hostIface, containerIface}
@@ -264,8 +295,9 @@ func (ic *ifConfigurator) configureContainerLinkVeth(
 		}
 		containerIface.Mac = podMAC.String()
 		hostIface.Mac = hostVeth.HardwareAddr.String()
-		// Disable TX checksum offloading when it's configured explicitly.
-		if ic.disableTXChecksumOffload {
+		// Disable TX checksum offloading when it's configured explicitly, or when the netdev datapath is used, as the
+		// checksums of the packets received from the AF_XDP sockets are not computed by OVS.
+		if ic.disableTXChecksumOffload || ic.ovsDatapathType == ovsconfig.OVSDatapathNetdev {
 			if err := ethtoolTXHWCsumOff(containerVeth.Name); err != nil {
 				return fmt.Errorf("error when disabling TX checksum offload on container veth: %v", err)
 			}
@@ -373,7 +405,9 @@ func (ic *ifConfigurator) configureContainerLink(
 	}
 }
 
-func (ic *ifConfigurator) changeContainerMTU(containerNetNS string, containerIFDev string, mtuDeduction int) error {
+// changeContainerMTU sets the MTU of the container interface and of its veth peer to mtu minus
+// mtuDeduction. If mtu is 0, the current MTU of each interface is reduced by mtuDeduction.
+func (ic *ifConfigurator) changeContainerMTU(containerNetNS string, containerIFDev string, mtu int, mtuDeduction int) error {
 	var peerIdx int
 	if err := nsWithNetNSPath(containerNetNS, func(hostNS ns.NetNS) error {
 		link, err := ic.netlink.LinkByName(containerIFDev)
@@ -384,7 +418,7 @@ func (ic *ifConfigurator) changeContainerMTU(containerNetNS string, containerIFD
 		if err != nil {
 			return fmt.Errorf("failed to get peer index for dev %s in container netns %s: %w", containerIFDev, containerNetNS, err)
 		}
-		err = ic.netlink.LinkSetMTU(link, link.Attrs().MTU-mtuDeduction)
+		err = ic.netlink.LinkSetMTU(link, newMTU(link, mtu, mtuDeduction))
 		if err != nil {
 			return fmt.Errorf("failed to set MTU for interface %s in container netns %s: %v", containerIFDev, containerNetNS, err)
 		}
@@ -403,13 +437,34 @@ func (ic *ifConfigurator) changeContainerMTU(containerNetNS string, containerIFD
 	if err != nil {
 		return fmt.Errorf("failed to find host interface %s: %v", hostInterfaceName, err)
 	}
-	err = ic.netlink.LinkSetMTU(link, link.Attrs().MTU-mtuDeduction)
+	err = ic.netlink.LinkSetMTU(link, newMTU(link, mtu, mtuDeduction))
 	if err != nil {
 		return fmt.Errorf("failed to set MTU for host interface %s: %v", hostInterfaceName, err)
 	}
 	return nil
 }
 
+func newMTU(link netlink.Link, mtu int, mtuDeduction int) int {
+	if mtu == 0 {
+		mtu = link.Attrs().MTU
+	}
+	return mtu - mtuDeduction
+}
+
+// configureContainerSysctls writes the provided net.* sysctls in the container network namespace.
+func (ic *ifConfigurator) configureContainerSysctls(containerNetNS string, sysctls map[string]string) error {
+	return nsWithNetNSPath(containerNetNS, func(hostNS ns.NetNS) error {
+		for key, value := range sysctls {
+			sysctlPath := filepath.Join(sysctlDir, strings.ReplaceAll(key, ".", "/"))
+			// #nosec G306: provided permissions match /proc/sys file permissions
+			if err := os.WriteFile(sysctlPath, []byte(value), 0644); err != nil {
+				return fmt.Errorf("failed to set sysctl %s to %s in container netns %s: %v", key, value, containerNetNS, err)
+			}
+		}
+		return nil
+	})
+}
+
 func (ic *ifConfigurator) removeContainerLink(containerID, hostInterfaceName string) error {
 	klog.V(2).Infof("Deleting veth devices for container %s", containerID)
 	// Don't return an error if the device is already removed as CniDel can be called multiple times.
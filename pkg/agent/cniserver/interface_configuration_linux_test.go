@@ -660,6 +660,68 @@ func TestValidateVFRepInterface(t *testing.T) {
 	}
 }
 
+func TestConfigureAndReleaseSriovVF(t *testing.T) {
+	controller := gomock.NewController(t)
+	fakeSriovNet := cniservertest.NewMockSriovNet(controller)
+	fakeNetlink := netlinktest.NewMockInterface(controller)
+	testIfConfigurator := newTestIfConfigurator(false, fakeNetlink, fakeSriovNet)
+
+	pciAddress := "0000:03:02.1"
+	pfName := "ens1f0"
+	vfID := 1
+	vfName := "ens1f0v1"
+	vfMAC, _ := net.ParseMAC("aa:bb:cc:dd:ee:01")
+	podVFMAC, _ := net.ParseMAC("aa:bb:cc:dd:ee:02")
+	pfLink := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: pfName}}
+	vfLink := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: vfName, HardwareAddr: vfMAC}}
+	// The VF keeps the container interface name when it is returned to the host network namespace.
+	returnedVFLink := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth1", HardwareAddr: podVFMAC}}
+	vfConfig := &SriovVFConfig{MAC: podVFMAC, VLAN: 100, Trust: true}
+
+	fakeSriovNet.EXPECT().GetPfName(pciAddress).Return(pfName, nil)
+	fakeSriovNet.EXPECT().GetVfid(pciAddress, pfName).Return(vfID, nil)
+	fakeSriovNet.EXPECT().GetVFLinkNames(pciAddress).Return(vfName, nil)
+	fakeNetlink.EXPECT().LinkByName(pfName).Return(pfLink, nil)
+	fakeNetlink.EXPECT().LinkByName(vfName).Return(vfLink, nil)
+	fakeNetlink.EXPECT().LinkSetVfHardwareAddr(pfLink, vfID, podVFMAC).Return(nil)
+	fakeNetlink.EXPECT().LinkSetHardwareAddr(vfLink, podVFMAC).Return(nil)
+	fakeNetlink.EXPECT().LinkSetVfVlan(pfLink, vfID, 100).Return(nil)
+	fakeNetlink.EXPECT().LinkSetVfTrust(pfLink, vfID, true).Return(nil)
+	originalMAC, err := testIfConfigurator.configureSriovVF(pciAddress, vfConfig)
+	require.NoError(t, err)
+	assert.Equal(t, vfMAC, originalMAC)
+
+	fakeSriovNet.EXPECT().GetPfName(pciAddress).Return(pfName, nil)
+	fakeSriovNet.EXPECT().GetVfid(pciAddress, pfName).Return(vfID, nil)
+	fakeSriovNet.EXPECT().GetVFLinkNames(pciAddress).Return("eth1", nil)
+	fakeNetlink.EXPECT().LinkByName(pfName).Return(pfLink, nil)
+	fakeNetlink.EXPECT().LinkByName("eth1").Return(returnedVFLink, nil)
+	fakeNetlink.EXPECT().LinkSetDown(returnedVFLink).Return(nil)
+	fakeNetlink.EXPECT().LinkSetName(returnedVFLink, vfName).Return(nil)
+	fakeNetlink.EXPECT().LinkSetVfHardwareAddr(pfLink, vfID, vfMAC).Return(nil)
+	fakeNetlink.EXPECT().LinkSetHardwareAddr(returnedVFLink, vfMAC).Return(nil)
+	fakeNetlink.EXPECT().LinkSetVfVlan(pfLink, vfID, 0).Return(nil)
+	fakeNetlink.EXPECT().LinkSetVfTrust(pfLink, vfID, false).Return(nil)
+	err = testIfConfigurator.releaseSriovVF(pciAddress, &current.Interface{Name: vfName, Mac: vfMAC.String()}, vfConfig)
+	require.NoError(t, err)
+}
+
+func TestReleaseSriovVFNotInHostNamespace(t *testing.T) {
+	controller := gomock.NewController(t)
+	fakeSriovNet := cniservertest.NewMockSriovNet(controller)
+	fakeNetlink := netlinktest.NewMockInterface(controller)
+	testIfConfigurator := newTestIfConfigurator(false, fakeNetlink, fakeSriovNet)
+
+	pciAddress := "0000:03:02.1"
+	pfLink := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "ens1f0"}}
+	fakeSriovNet.EXPECT().GetPfName(pciAddress).Return("ens1f0", nil)
+	fakeSriovNet.EXPECT().GetVfid(pciAddress, "ens1f0").Return(1, nil)
+	fakeNetlink.EXPECT().LinkByName("ens1f0").Return(pfLink, nil)
+	fakeSriovNet.EXPECT().GetVFLinkNames(pciAddress).Return("", fmt.Errorf("no such file or directory"))
+	err := testIfConfigurator.releaseSriovVF(pciAddress, &current.Interface{Name: "ens1f0v1"}, nil)
+	assert.ErrorContains(t, err, "VF interface not found")
+}
+
 func TestGetInterceptedInterfaces(t *testing.T) {
 	sandbox := "containerSandbox"
 	containerNS := "containerNS"
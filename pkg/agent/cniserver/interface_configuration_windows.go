@@ -191,10 +191,16 @@ func (ic *ifConfigurator) configureContainerLink(
 
 // changeContainerMTU is only used for Antrea Multi-cluster with networkPolicyOnly
 // mode, and this mode doesn't support Windows platform yet.
-func (ic *ifConfigurator) changeContainerMTU(containerNetNS string, containerIFDev string, mtuDeduction int) error {
+func (ic *ifConfigurator) changeContainerMTU(containerNetNS string, containerIFDev string, mtu int, mtuDeduction int) error {
 	return errors.New("changeContainerMTU is unsupported on Windows")
 }
 
+// configureContainerSysctls is only used in networkPolicyOnly mode, and sysctls don't exist on
+// Windows.
+func (ic *ifConfigurator) configureContainerSysctls(containerNetNS string, sysctls map[string]string) error {
+	return errors.New("configureContainerSysctls is unsupported on Windows")
+}
+
 // createContainerLink creates HNSEndpoint using the IP configuration in the IPAM result.
 func (ic *ifConfigurator) createContainerLink(endpointName string, result *current.Result, containerID, podName, podNamespace string) (hostLink *hcsshim.HNSEndpoint, err error) {
 	containerIP, err := findContainerIPConfig(result.IPs)
@@ -438,6 +444,18 @@ func (ic *ifConfigurator) validateVFRepInterface(sriovVFDeviceID string) (string
 	return "", fmt.Errorf("OVS hardware offload is not supported in windows")
 }
 
+func (ic *ifConfigurator) configureSecondaryContainerLink(podName string, podNamespace string, containerID string, containerNetNS string, containerIfaceName string, mtu int, result *current.Result) error {
+	return fmt.Errorf("secondary network is not supported in windows")
+}
+
+func (ic *ifConfigurator) configureSriovVF(pciAddress string, vfConfig *SriovVFConfig) (net.HardwareAddr, error) {
+	return nil, fmt.Errorf("SR-IOV is not supported in windows")
+}
+
+func (ic *ifConfigurator) releaseSriovVF(pciAddress string, hostIface *current.Interface, vfConfig *SriovVFConfig) error {
+	return fmt.Errorf("SR-IOV is not supported in windows")
+}
+
 // validateContainerPeerInterface checks HNSEndpoint configuration.
 func (ic *ifConfigurator) validateContainerPeerInterface(interfaces []*current.Interface, containerVeth *vethPair) (*vethPair, error) {
 	// Iterate all the passed interfaces and look up the host interface by
@@ -15,6 +15,8 @@
 package cniserver
 
 import (
+	"net"
+
 	cnitypes "github.com/containernetworking/cni/pkg/types"
 	current "github.com/containernetworking/cni/pkg/types/100"
 )
@@ -31,7 +33,11 @@ type podInterfaceConfigurator interface {
 	getInterceptedInterfaces(sandbox string, containerNetNS string, containerIFDev string) (*current.Interface, *current.Interface, error)
 	checkContainerInterface(containerNetns, containerID string, containerIface *current.Interface, containerIPs []*current.IPConfig, containerRoutes []*cnitypes.Route, sriovVFDeviceID string) (interface{}, error)
 	addPostInterfaceCreateHook(containerID, endpointName string, containerAccess *containerAccessArbitrator, hook postInterfaceCreateHook) error
-	changeContainerMTU(containerNetNS string, containerIFDev string, mtuDeduction int) error
+	changeContainerMTU(containerNetNS string, containerIFDev string, mtu int, mtuDeduction int) error
+	configureContainerSysctls(containerNetNS string, sysctls map[string]string) error
+	configureSecondaryContainerLink(podName string, podNamespace string, containerID string, containerNetNS string, containerIfaceName string, mtu int, result *current.Result) error
+	configureSriovVF(pciAddress string, vfConfig *SriovVFConfig) (net.HardwareAddr, error)
+	releaseSriovVF(pciAddress string, hostIface *current.Interface, vfConfig *SriovVFConfig) error
 }
 
 type SriovNet interface {
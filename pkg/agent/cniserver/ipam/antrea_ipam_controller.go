@@ -138,8 +138,11 @@ func (c *AntreaIPAMController) getIPPoolsByPod(namespace, name string) ([]string
 		return nil, nil, nil, err
 	}
 
+	var poolNames []string
 	annotations, exists := pod.Annotations[annotation.AntreaIPAMAnnotationKey]
-	if !exists {
+	if exists {
+		poolNames = strings.Split(annotations, annotation.AntreaIPAMAnnotationDelimiter)
+	} else {
 		// Find IPPool by Namespace
 		ns, err := c.namespaceLister.Get(namespace)
 		if err != nil {
@@ -149,6 +152,15 @@ func (c *AntreaIPAMController) getIPPoolsByPod(namespace, name string) ([]string
 		if !exists {
 			return nil, nil, nil, nil
 		}
+		// The Namespace can be annotated with multiple IPPools, select the ones matching the
+		// Pod labels.
+		poolNames, err = annotation.SelectIPPoolsForPod(strings.Split(annotations, annotation.AntreaIPAMAnnotationDelimiter), pod.Labels, c.ipPoolLister)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if len(poolNames) == 0 {
+			return nil, nil, nil, fmt.Errorf("no IPPool of Namespace %s selects the Pod", namespace)
+		}
 	}
 
 	// Collect specified IPs if exist
@@ -190,7 +202,7 @@ ownerReferenceLoop:
 		}
 	}
 
-	return strings.Split(annotations, annotation.AntreaIPAMAnnotationDelimiter), ips, reservedOwner, ipErr
+	return poolNames, ips, reservedOwner, ipErr
 }
 
 // Look up IPPools from the Pod annotation.
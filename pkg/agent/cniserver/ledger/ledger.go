@@ -0,0 +1,162 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ledger provides a small on-disk store of the containers configured by
+// the CNI server. An entry is recorded before the networking of a container is
+// configured, and removed once it has been fully cleaned up by CNI DEL. Unlike
+// the interface store, which is initialized from OVSDB, the ledger survives the
+// loss of the OVS configuration, so that the host interfaces, OVS ports and IPAM
+// leases of a container can still be released after an Agent restart.
+package ledger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const fileSuffix = ".json"
+
+// Entry records what is needed to clean up the networking of a container.
+type Entry struct {
+	ContainerID   string `json:"containerID"`
+	PodName       string `json:"podName"`
+	PodNamespace  string `json:"podNamespace"`
+	InterfaceName string `json:"interfaceName"`
+	IPAMType      string `json:"ipamType"`
+	// The following fields are copied from the CNI ADD request, and are used to
+	// release the IPAM lease of the container.
+	Netns                string `json:"netns,omitempty"`
+	Ifname               string `json:"ifname,omitempty"`
+	Args                 string `json:"args,omitempty"`
+	Path                 string `json:"path,omitempty"`
+	NetworkConfiguration []byte `json:"networkConfiguration,omitempty"`
+}
+
+// Ledger stores one file per container in a directory.
+type Ledger struct {
+	dir   string
+	mutex sync.Mutex
+}
+
+// New creates a Ledger which stores its entries in dir. The directory is
+// created if it doesn't exist.
+func New(dir string) (*Ledger, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create ledger directory %s: %w", dir, err)
+	}
+	return &Ledger{dir: dir}, nil
+}
+
+func (l *Ledger) entryPath(containerID string) (string, error) {
+	if containerID == "" || strings.ContainsAny(containerID, `/\`) || strings.HasPrefix(containerID, ".") {
+		return "", fmt.Errorf("invalid container ID %q", containerID)
+	}
+	return filepath.Join(l.dir, containerID+fileSuffix), nil
+}
+
+// Add records the entry, replacing any existing entry for the same container.
+// The file is written atomically, so that a crash never leaves a partial entry.
+func (l *Ledger) Add(entry *Entry) error {
+	path, err := l.entryPath(entry.ContainerID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write ledger entry for container %s: %w", entry.ContainerID, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write ledger entry for container %s: %w", entry.ContainerID, err)
+	}
+	return nil
+}
+
+// Get returns the entry of the container, or nil if there is none.
+func (l *Ledger) Get(containerID string) (*Entry, error) {
+	path, err := l.entryPath(containerID)
+	if err != nil {
+		return nil, err
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return readEntry(path)
+}
+
+// Delete removes the entry of the container. It doesn't return an error if
+// there is no entry, as CNI DEL can be called multiple times.
+func (l *Ledger) Delete(containerID string) error {
+	path, err := l.entryPath(containerID)
+	if err != nil {
+		return err
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete ledger entry for container %s: %w", containerID, err)
+	}
+	return nil
+}
+
+// List returns all the entries. The files which cannot be parsed are ignored
+// and removed, as they cannot be used for any cleanup.
+func (l *Ledger) List() ([]*Entry, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	files, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ledger directory %s: %w", l.dir, err)
+	}
+	var entries []*Entry
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), fileSuffix) {
+			continue
+		}
+		path := filepath.Join(l.dir, file.Name())
+		entry, err := readEntry(path)
+		if err != nil {
+			os.Remove(path)
+			continue
+		}
+		if entry != nil {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func readEntry(path string) (*Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	entry := &Entry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, fmt.Errorf("failed to parse ledger entry %s: %w", path, err)
+	}
+	return entry, nil
+}
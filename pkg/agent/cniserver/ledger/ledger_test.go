@@ -0,0 +1,81 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ledger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedger(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "ledger")
+	l, err := New(dir)
+	require.NoError(t, err)
+
+	entry1 := &Entry{
+		ContainerID:          "c1",
+		PodName:              "pod1",
+		PodNamespace:         "ns1",
+		InterfaceName:        "pod1-abc",
+		IPAMType:             "host-local",
+		Netns:                "/var/run/netns/c1",
+		Ifname:               "eth0",
+		NetworkConfiguration: []byte(`{"cniVersion":"0.4.0"}`),
+	}
+	entry2 := &Entry{ContainerID: "c2", PodName: "pod2", PodNamespace: "ns1", InterfaceName: "pod2-abc"}
+	require.NoError(t, l.Add(entry1))
+	require.NoError(t, l.Add(entry2))
+
+	got, err := l.Get("c1")
+	require.NoError(t, err)
+	assert.Equal(t, entry1, got)
+
+	// A ledger created on the same directory, e.g. after a restart, has the same entries.
+	l, err = New(dir)
+	require.NoError(t, err)
+	entries, err := l.List()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*Entry{entry1, entry2}, entries)
+
+	require.NoError(t, l.Delete("c1"))
+	// Deleting a missing entry is not an error.
+	require.NoError(t, l.Delete("c1"))
+	got, err = l.Get("c1")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// Corrupted entries are ignored and removed.
+	corruptedPath := filepath.Join(dir, "c3"+fileSuffix)
+	require.NoError(t, os.WriteFile(corruptedPath, []byte("{"), 0600))
+	entries, err = l.List()
+	require.NoError(t, err)
+	assert.Equal(t, []*Entry{entry2}, entries)
+	assert.NoFileExists(t, corruptedPath)
+}
+
+func TestInvalidContainerID(t *testing.T) {
+	l, err := New(t.TempDir())
+	require.NoError(t, err)
+	for _, containerID := range []string{"", "../c1", `a\b`, "."} {
+		assert.Error(t, l.Add(&Entry{ContainerID: containerID}), containerID)
+		_, err := l.Get(containerID)
+		assert.Error(t, err, containerID)
+		assert.Error(t, l.Delete(containerID), containerID)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 
 	current "github.com/containernetworking/cni/pkg/types/100"
@@ -51,6 +52,11 @@ const (
 	ovsExternalIDContainerID  = "container-id"
 	ovsExternalIDPodName      = "pod-name"
 	ovsExternalIDPodNamespace = "pod-namespace"
+	// The bandwidth limits of the Pod enforced with OVS meters.
+	ovsExternalIDIngressRate  = "ingress-rate"
+	ovsExternalIDIngressBurst = "ingress-burst"
+	ovsExternalIDEgressRate   = "egress-rate"
+	ovsExternalIDEgressBurst  = "egress-burst"
 )
 
 const (
@@ -146,9 +152,38 @@ func BuildOVSPortExternalIDs(containerConfig *interfacestore.InterfaceConfig) ma
 	externalIDs[ovsExternalIDPodName] = containerConfig.PodName
 	externalIDs[ovsExternalIDPodNamespace] = containerConfig.PodNamespace
 	externalIDs[interfacestore.AntreaInterfaceTypeKey] = interfacestore.AntreaContainer
+	if bandwidth := containerConfig.Bandwidth; bandwidth != nil {
+		externalIDs[ovsExternalIDIngressRate] = strconv.FormatUint(bandwidth.IngressRate, 10)
+		externalIDs[ovsExternalIDIngressBurst] = strconv.FormatUint(bandwidth.IngressBurst, 10)
+		externalIDs[ovsExternalIDEgressRate] = strconv.FormatUint(bandwidth.EgressRate, 10)
+		externalIDs[ovsExternalIDEgressBurst] = strconv.FormatUint(bandwidth.EgressBurst, 10)
+	}
 	return externalIDs
 }
 
+// parseOVSPortBandwidth parses the bandwidth limits saved in the OVS port external_ids. nil is returned if there is
+// none or if they are invalid.
+func parseOVSPortBandwidth(portData *ovsconfig.OVSPortData) *agenttypes.PodBandwidth {
+	if _, found := portData.ExternalIDs[ovsExternalIDIngressRate]; !found {
+		return nil
+	}
+	values := make([]uint64, 4)
+	for i, key := range []string{ovsExternalIDIngressRate, ovsExternalIDIngressBurst, ovsExternalIDEgressRate, ovsExternalIDEgressBurst} {
+		value, err := strconv.ParseUint(portData.ExternalIDs[key], 10, 64)
+		if err != nil {
+			klog.ErrorS(err, "Failed to parse bandwidth limit from OVS external config", "port", portData.Name, "key", key)
+			return nil
+		}
+		values[i] = value
+	}
+	return &agenttypes.PodBandwidth{
+		IngressRate:  values[0],
+		IngressBurst: values[1],
+		EgressRate:   values[2],
+		EgressBurst:  values[3],
+	}
+}
+
 func getContainerIPsString(ips []net.IP) string {
 	var containerIPs []string
 	for _, ip := range ips {
@@ -195,6 +230,7 @@ func ParseOVSPortInterfaceConfig(portData *ovsconfig.OVSPortData, portConfig *in
 		containerIPs,
 		portData.VLANID)
 	interfaceConfig.OVSPortConfig = portConfig
+	interfaceConfig.Bandwidth = parseOVSPortBandwidth(portData)
 	return interfaceConfig
 }
 
@@ -319,6 +355,28 @@ func (pc *podConfigurator) removeInterfaces(containerID string) error {
 	return nil
 }
 
+// removeOrphanedInterfaces removes the OVS port and the host interface of a container which is not in the interface
+// store, e.g. because the OVS configuration was lost while the Agent was restarted. The OVS port is looked up by name,
+// as its UUID is unknown. No OpenFlow entries need to be uninstalled, as they are only installed for the interfaces in
+// the interface store.
+func (pc *podConfigurator) removeOrphanedInterfaces(containerID, hostIfaceName string) error {
+	ports, err := pc.ovsBridgeClient.GetPortList()
+	if err != nil {
+		return fmt.Errorf("failed to list OVS ports: %v", err)
+	}
+	for _, port := range ports {
+		if port.Name != hostIfaceName {
+			continue
+		}
+		klog.V(2).InfoS("Deleting orphaned OVS port", "port", hostIfaceName, "container", containerID)
+		if err := pc.ovsBridgeClient.DeletePort(port.UUID); err != nil {
+			return fmt.Errorf("failed to delete OVS port for container %s: %v", containerID, err)
+		}
+		break
+	}
+	return pc.ifConfigurator.removeContainerLink(containerID, hostIfaceName)
+}
+
 func (pc *podConfigurator) checkInterfaces(
 	containerID, containerNetNS string,
 	containerIface *current.Interface,
@@ -456,6 +514,13 @@ func (pc *podConfigurator) reconcile(pods []corev1.Pod, containerAccess *contain
 			); err != nil {
 				klog.Errorf("Error when re-installing flows for Pod %s", namespacedName)
 			}
+			if containerConfig.Bandwidth != nil {
+				if !pc.ofClient.IsPodBandwidthMeterSupported() {
+					klog.InfoS("OVS meters are not supported, cannot restore the bandwidth limits of Pod", "Pod", namespacedName)
+				} else if err := pc.ofClient.InstallPodBandwidthMeters(containerConfig.InterfaceName, uint32(containerConfig.OFPort), containerConfig.Bandwidth); err != nil {
+					klog.ErrorS(err, "Error when re-installing bandwidth meters for Pod", "Pod", namespacedName)
+				}
+			}
 		} else {
 			// clean-up and delete interface
 			klog.V(4).Infof("Deleting interface %s", containerConfig.InterfaceName)
@@ -511,9 +576,32 @@ func (pc *podConfigurator) connectInterfaceToOVSCommon(ovsPortName string, conta
 	return nil
 }
 
+// configureBandwidthMeters enforces the bandwidth limits of a Pod with OVS meters, and saves them in the external_ids
+// of the OVS port, so that the meters can be restored after the Agent restarts.
+func (pc *podConfigurator) configureBandwidthMeters(containerID string, bandwidth *agenttypes.PodBandwidth) error {
+	containerConfig, found := pc.ifaceStore.GetContainerInterface(containerID)
+	if !found {
+		return fmt.Errorf("failed to find the interface of container %s", containerID)
+	}
+	if err := pc.ofClient.InstallPodBandwidthMeters(containerConfig.InterfaceName, uint32(containerConfig.OFPort), bandwidth); err != nil {
+		return fmt.Errorf("failed to add bandwidth meters for container %s: %v", containerID, err)
+	}
+	containerConfig.Bandwidth = bandwidth
+	if err := pc.ovsBridgeClient.SetPortExternalIDs(containerConfig.InterfaceName, BuildOVSPortExternalIDs(containerConfig)); err != nil {
+		return fmt.Errorf("failed to save bandwidth limits of container %s to OVS port: %v", containerID, err)
+	}
+	return nil
+}
+
 // disconnectInterfaceFromOVS disconnects an existing interface from ovs br-int.
 func (pc *podConfigurator) disconnectInterfaceFromOVS(containerConfig *interfacestore.InterfaceConfig) error {
 	containerID := containerConfig.ContainerID
+	if containerConfig.Bandwidth != nil {
+		klog.V(2).Infof("Deleting bandwidth meters for container %s", containerID)
+		if err := pc.ofClient.UninstallPodBandwidthMeters(containerConfig.InterfaceName); err != nil {
+			return fmt.Errorf("failed to delete bandwidth meters for container %s: %v", containerID, err)
+		}
+	}
 	klog.V(2).Infof("Deleting Openflow entries for container %s", containerID)
 	if err := pc.ofClient.UninstallPodFlows(containerConfig.InterfaceName); err != nil {
 		return fmt.Errorf("failed to delete Openflow entries for container %s: %v", containerID, err)
@@ -15,6 +15,7 @@
 package cniserver
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"testing"
@@ -54,6 +55,8 @@ type fakeInterfaceConfigurator struct {
 	getInterceptedInterfacesError       error
 	checkContainerInterfaceError        error
 	containerVFLink                     interface{}
+	configureSriovVFError               error
+	releaseSriovVFError                 error
 }
 
 func (c *fakeInterfaceConfigurator) configureContainerLink(podName string, podNamespace string, containerID string, containerNetNS string, containerIfaceName string, mtu int, brSriovVFDeviceID string, podSriovVFDeviceID string, result *current.Result, containerAccess *containerAccessArbitrator) error {
@@ -120,10 +123,35 @@ func (c *fakeInterfaceConfigurator) checkContainerInterface(containerNetns, cont
 	return c.containerVethPair, nil
 }
 
-func (c *fakeInterfaceConfigurator) changeContainerMTU(containerNetNS string, containerIFDev string, mtuDeduction int) error {
+func (c *fakeInterfaceConfigurator) changeContainerMTU(containerNetNS string, containerIFDev string, mtu int, mtuDeduction int) error {
 	return nil
 }
 
+func (c *fakeInterfaceConfigurator) configureContainerSysctls(containerNetNS string, sysctls map[string]string) error {
+	return nil
+}
+
+func (c *fakeInterfaceConfigurator) configureSecondaryContainerLink(podName string, podNamespace string, containerID string, containerNetNS string, containerIfaceName string, mtu int, result *current.Result) error {
+	if c.configureContainerLinkError != nil {
+		return c.configureContainerLinkError
+	}
+	hostIface := &current.Interface{Name: c.hostIfaceName, Mac: hostIfaceMAC}
+	containerIface := &current.Interface{Name: containerIfaceName, Sandbox: containerNetNS, Mac: containerMAC}
+	result.Interfaces = []*current.Interface{hostIface, containerIface}
+	return nil
+}
+
+func (c *fakeInterfaceConfigurator) configureSriovVF(pciAddress string, vfConfig *SriovVFConfig) (net.HardwareAddr, error) {
+	if c.configureSriovVFError != nil {
+		return nil, c.configureSriovVFError
+	}
+	return net.ParseMAC(hostIfaceMAC)
+}
+
+func (c *fakeInterfaceConfigurator) releaseSriovVF(pciAddress string, hostIface *current.Interface, vfConfig *SriovVFConfig) error {
+	return c.releaseSriovVFError
+}
+
 func newTestInterfaceConfigurator() *fakeInterfaceConfigurator {
 	return &fakeInterfaceConfigurator{
 		containerMAC: "01:02:03:04:05:06",
@@ -460,6 +488,7 @@ func TestConfigureSriovSecondaryInterface(t *testing.T) {
 	for _, tc := range []struct {
 		name               string
 		podSriovVFDeviceID string
+		configureVFErr     error
 		configureLinkErr   error
 		advertiseErr       error
 		expectedErr        error
@@ -467,6 +496,11 @@ func TestConfigureSriovSecondaryInterface(t *testing.T) {
 		{
 			name:        "sriov-vf-not-set",
 			expectedErr: fmt.Errorf("error getting the Pod SR-IOV VF device ID"),
+		}, {
+			name:               "configure-vf-failure",
+			podSriovVFDeviceID: "vf0",
+			configureVFErr:     errors.New("unable to set VF VLAN"),
+			expectedErr:        fmt.Errorf("failed to configure SR-IOV VF vf0: %w", errors.New("unable to set VF VLAN")),
 		}, {
 			name:               "configure-link-failure",
 			podSriovVFDeviceID: "vf1",
@@ -484,15 +518,34 @@ func TestConfigureSriovSecondaryInterface(t *testing.T) {
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ifaceConfigurator := newTestInterfaceConfigurator()
+			ifaceConfigurator.configureSriovVFError = tc.configureVFErr
 			ifaceConfigurator.configureContainerLinkError = tc.configureLinkErr
 			ifaceConfigurator.advertiseContainerAddrError = tc.advertiseErr
 			podConfigurator := createPodConfigurator(controller, ifaceConfigurator)
-			err := podConfigurator.ConfigureSriovSecondaryInterface(podName, testPodNamespace, containerID, containerNS, containerIfaceName, mtu, tc.podSriovVFDeviceID, &current.Result{})
+			result := &current.Result{}
+			err := podConfigurator.ConfigureSriovSecondaryInterface(podName, testPodNamespace, containerID, containerNS, containerIfaceName, mtu, tc.podSriovVFDeviceID, &SriovVFConfig{VLAN: 100}, result)
 			assert.Equal(t, tc.expectedErr, err)
+			if tc.expectedErr == nil {
+				// The original MAC address of the VF should be recorded in the host interface.
+				assert.Equal(t, hostIfaceMAC, result.Interfaces[0].Mac)
+			}
 		})
 	}
 }
 
+func TestReleaseSriovSecondaryInterface(t *testing.T) {
+	controller := gomock.NewController(t)
+	hostIface := &current.Interface{Name: "ens1f0v1", Mac: hostIfaceMAC}
+
+	ifaceConfigurator := newTestInterfaceConfigurator()
+	podConfigurator := createPodConfigurator(controller, ifaceConfigurator)
+	assert.NoError(t, podConfigurator.ReleaseSriovSecondaryInterface("vf1", hostIface, nil))
+
+	ifaceConfigurator.releaseSriovVFError = errors.New("VF interface not found")
+	err := podConfigurator.ReleaseSriovSecondaryInterface("vf1", hostIface, nil)
+	assert.EqualError(t, err, "failed to release SR-IOV VF vf1: VF interface not found")
+}
+
 func createPodConfigurator(controller *gomock.Controller, testIfaceConfigurator *fakeInterfaceConfigurator) *podConfigurator {
 	gwMAC, _ := net.ParseMAC("00:00:11:11:11:11")
 	mockOVSBridgeClient = ovsconfigtest.NewMockOVSBridgeClient(controller)
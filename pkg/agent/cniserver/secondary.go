@@ -0,0 +1,117 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cniserver
+
+import (
+	"fmt"
+
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/util"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+)
+
+// SecondaryInterfaceConfigurator configures the secondary network interfaces of Pods. SR-IOV interfaces are configured
+// by the embedded podConfigurator, while VLAN interfaces are connected to the secondary OVS bridge.
+type SecondaryInterfaceConfigurator struct {
+	*podConfigurator
+	// secondaryBridgeClient is nil if no OVS bridge is configured for secondary networks.
+	secondaryBridgeClient ovsconfig.OVSBridgeClient
+}
+
+// NewSecondaryInterfaceConfigurator returns a SecondaryInterfaceConfigurator, using pc to configure the container
+// links and connecting the VLAN interfaces to the OVS bridge managed by ovsBridgeClient.
+func NewSecondaryInterfaceConfigurator(pc *podConfigurator, ovsBridgeClient ovsconfig.OVSBridgeClient) *SecondaryInterfaceConfigurator {
+	return &SecondaryInterfaceConfigurator{
+		podConfigurator:       pc,
+		secondaryBridgeClient: ovsBridgeClient,
+	}
+}
+
+// ConfigureVLANSecondaryInterface creates a veth pair for a secondary network interface of a Pod, and connects the
+// host veth to the secondary OVS bridge as an access port of vlanID. The port is not tagged if vlanID is 0.
+func (c *SecondaryInterfaceConfigurator) ConfigureVLANSecondaryInterface(
+	podName string,
+	podNamespace string,
+	containerID string,
+	containerNetNS string,
+	containerIFDev string,
+	mtu int,
+	vlanID uint16,
+	result *current.Result,
+) error {
+	if c.secondaryBridgeClient == nil {
+		return fmt.Errorf("no OVS bridge is configured for secondary networks")
+	}
+	if err := c.ifConfigurator.configureSecondaryContainerLink(podName, podNamespace, containerID, containerNetNS, containerIFDev, mtu, result); err != nil {
+		return err
+	}
+	hostIface := result.Interfaces[0]
+	containerIface := result.Interfaces[1]
+
+	success := false
+	defer func() {
+		if !success {
+			if err := c.ifConfigurator.removeContainerLink(containerID, hostIface.Name); err != nil {
+				klog.ErrorS(err, "Failed to roll back veth link for secondary interface", "container", containerID, "interface", hostIface.Name)
+			}
+		}
+	}()
+
+	externalIDs := map[string]interface{}{
+		ovsExternalIDMAC:          containerIface.Mac,
+		ovsExternalIDContainerID:  containerID,
+		ovsExternalIDPodName:      podName,
+		ovsExternalIDPodNamespace: podNamespace,
+	}
+	if _, err := c.secondaryBridgeClient.CreateAccessPort(hostIface.Name, hostIface.Name, externalIDs, vlanID); err != nil {
+		return fmt.Errorf("failed to create OVS port for secondary interface %s of container %s: %v", containerIFDev, containerID, err)
+	}
+
+	if err := c.ifConfigurator.advertiseContainerAddr(containerNetNS, containerIface.Name, result); err != nil {
+		klog.ErrorS(err, "Failed to advertise IP address for secondary interface", "container", containerID, "interface", containerIFDev)
+	}
+	success = true
+	klog.InfoS("Configured VLAN secondary interface", "Pod", klog.KRef(podNamespace, podName), "container", containerID, "interface", containerIFDev, "hostInterface", hostIface.Name, "vlan", vlanID)
+	return nil
+}
+
+// DeleteVLANSecondaryInterface deletes the OVS port and the veth pair of a VLAN secondary network interface of a Pod.
+// It doesn't return an error if the port or the veth pair is already deleted.
+func (c *SecondaryInterfaceConfigurator) DeleteVLANSecondaryInterface(podName, podNamespace, containerID, containerIFDev string) error {
+	if c.secondaryBridgeClient == nil {
+		return fmt.Errorf("no OVS bridge is configured for secondary networks")
+	}
+	hostIfaceName := util.GenerateContainerSecondaryInterfaceName(podName, podNamespace, containerID, containerIFDev)
+	ports, err := c.secondaryBridgeClient.GetPortList()
+	if err != nil {
+		return fmt.Errorf("failed to list OVS ports of the secondary bridge: %v", err)
+	}
+	for _, port := range ports {
+		if port.Name != hostIfaceName {
+			continue
+		}
+		if err := c.secondaryBridgeClient.DeletePort(port.UUID); err != nil {
+			return fmt.Errorf("failed to delete OVS port %s: %v", hostIfaceName, err)
+		}
+		break
+	}
+	if err := c.ifConfigurator.removeContainerLink(containerID, hostIfaceName); err != nil {
+		return err
+	}
+	klog.InfoS("Deleted VLAN secondary interface", "Pod", klog.KRef(podNamespace, podName), "container", containerID, "interface", containerIFDev, "hostInterface", hostIfaceName)
+	return nil
+}
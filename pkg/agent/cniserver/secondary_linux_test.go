@@ -0,0 +1,121 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cniserver
+
+import (
+	"fmt"
+	"testing"
+
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"antrea.io/antrea/pkg/agent/util"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+	ovsconfigtest "antrea.io/antrea/pkg/ovs/ovsconfig/testing"
+)
+
+func TestConfigureVLANSecondaryInterface(t *testing.T) {
+	controller := gomock.NewController(t)
+	containerID := generateUUID(t)
+	containerNS := "containerNS"
+	secondaryIfaceName := "secondary-veth"
+
+	for _, tc := range []struct {
+		name             string
+		noBridge         bool
+		configureLinkErr error
+		createPortErr    error
+		expectedErr      error
+	}{
+		{
+			name:        "no-bridge",
+			noBridge:    true,
+			expectedErr: fmt.Errorf("no OVS bridge is configured for secondary networks"),
+		}, {
+			name:             "configure-link-failure",
+			configureLinkErr: fmt.Errorf("unable to create veth"),
+			expectedErr:      fmt.Errorf("unable to create veth"),
+		}, {
+			name:          "create-port-failure",
+			createPortErr: ovsconfig.NewTransactionError(fmt.Errorf("transaction failed"), false),
+			expectedErr:   fmt.Errorf("failed to create OVS port for secondary interface eth1 of container %s: transaction failed", containerID),
+		}, {
+			name: "success",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ifaceConfigurator := newTestInterfaceConfigurator()
+			ifaceConfigurator.hostIfaceName = secondaryIfaceName
+			ifaceConfigurator.configureContainerLinkError = tc.configureLinkErr
+			mockSecondaryBridge := ovsconfigtest.NewMockOVSBridgeClient(controller)
+			var secondaryBridge ovsconfig.OVSBridgeClient = mockSecondaryBridge
+			if tc.noBridge {
+				secondaryBridge = nil
+			}
+			configurator := NewSecondaryInterfaceConfigurator(createPodConfigurator(controller, ifaceConfigurator), secondaryBridge)
+			if !tc.noBridge && tc.configureLinkErr == nil {
+				mockSecondaryBridge.EXPECT().CreateAccessPort(secondaryIfaceName, secondaryIfaceName, gomock.Any(), uint16(100)).Return("port-uuid", tc.createPortErr)
+			}
+			result := &current.Result{}
+			err := configurator.ConfigureVLANSecondaryInterface(podName, testPodNamespace, containerID, containerNS, "eth1", mtu, 100, result)
+			assert.Equal(t, tc.expectedErr, err)
+			if tc.createPortErr != nil {
+				// The veth pair should be removed if the OVS port cannot be created.
+				assert.Empty(t, ifaceConfigurator.hostIfaceName)
+			} else if tc.expectedErr == nil {
+				assert.Equal(t, secondaryIfaceName, ifaceConfigurator.hostIfaceName)
+			}
+		})
+	}
+}
+
+func TestDeleteVLANSecondaryInterface(t *testing.T) {
+	controller := gomock.NewController(t)
+	containerID := generateUUID(t)
+	hostIfaceName := util.GenerateContainerSecondaryInterfaceName(podName, testPodNamespace, containerID, "eth1")
+
+	for _, tc := range []struct {
+		name        string
+		ports       []ovsconfig.OVSPortData
+		expectedErr error
+	}{
+		{
+			name: "port-exists",
+			ports: []ovsconfig.OVSPortData{
+				{UUID: "uuid1", Name: "other-port"},
+				{UUID: "uuid2", Name: hostIfaceName},
+			},
+		}, {
+			name:  "port-not-found",
+			ports: []ovsconfig.OVSPortData{{UUID: "uuid1", Name: "other-port"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ifaceConfigurator := newTestInterfaceConfigurator()
+			ifaceConfigurator.hostIfaceName = hostIfaceName
+			mockSecondaryBridge := ovsconfigtest.NewMockOVSBridgeClient(controller)
+			configurator := NewSecondaryInterfaceConfigurator(createPodConfigurator(controller, ifaceConfigurator), mockSecondaryBridge)
+			mockSecondaryBridge.EXPECT().GetPortList().Return(tc.ports, nil)
+			for _, port := range tc.ports {
+				if port.Name == hostIfaceName {
+					mockSecondaryBridge.EXPECT().DeletePort(port.UUID).Return(nil)
+				}
+			}
+			assert.NoError(t, configurator.DeleteVLANSecondaryInterface(podName, testPodNamespace, containerID, "eth1"))
+			assert.Empty(t, ifaceConfigurator.hostIfaceName)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -29,11 +30,15 @@ import (
 	"github.com/containernetworking/cni/pkg/version"
 	"github.com/containernetworking/plugins/pkg/ip"
 	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 
 	"antrea.io/antrea/pkg/agent/cniserver/ipam"
+	"antrea.io/antrea/pkg/agent/cniserver/ledger"
 	"antrea.io/antrea/pkg/agent/cniserver/types"
 	"antrea.io/antrea/pkg/agent/config"
 	"antrea.io/antrea/pkg/agent/interfacestore"
@@ -45,6 +50,8 @@ import (
 	"antrea.io/antrea/pkg/cni"
 	"antrea.io/antrea/pkg/ovs/ovsconfig"
 	"antrea.io/antrea/pkg/util/channel"
+	"antrea.io/antrea/pkg/util/k8s"
+	"antrea.io/antrea/pkg/util/runtime"
 )
 
 const (
@@ -56,8 +63,16 @@ const (
 	// https://github.com/kubernetes/kubernetes/blob/v1.19.3/staging/src/k8s.io/kubelet/config/v1beta1/types.go#L451
 	// networkReadyTimeout is set to a shorter time so it returns a clear message to the runtime.
 	networkReadyTimeout = 30 * time.Second
+
+	// minIPv4MTU and minIPv6MTU are the minimum MTUs required by IPv4 (RFC 791) and IPv6 (RFC 8200).
+	minIPv4MTU = 68
+	minIPv6MTU = 1280
 )
 
+// ledgerDir is the directory of the ledger recording the containers configured by the CNI server. It is a variable to
+// be overridden in tests.
+var ledgerDir = "/var/run/antrea/cni/ledger"
+
 // containerAccessArbitrator is used to ensure that concurrent goroutines cannot perfom operations
 // on the same containerID. Other parts of the code make this assumption (in particular the
 // InstallPodFlows / UninstallPodFlows methods of the OpenFlow client, which are invoked
@@ -120,6 +135,11 @@ type CNIServer struct {
 	networkConfig              *config.NetworkConfig
 	// networkReadyCh notifies that the network is ready so new Pods can be created. Therefore, CmdAdd waits for it.
 	networkReadyCh <-chan struct{}
+	// ledger records the containers configured by the CNI server, so that they can be cleaned up even if the
+	// interface store was lost. It's nil if the ledger could not be created.
+	ledger *ledger.Ledger
+	// addResultCache caches the responses of the successful CNI ADD requests, to answer the retried requests.
+	addResultCache *addResultCache
 }
 
 var supportedCNIVersionSet map[string]bool
@@ -192,7 +212,9 @@ func (s *CNIServer) loadNetworkConfig(request *cnipb.CniCmdRequest) (*CNIConfig,
 	if err := cnitypes.LoadArgs(request.CniArgs.Args, cniConfig.K8sArgs); err != nil {
 		return nil, err
 	}
-	if cniConfig.MTU == 0 {
+	// In chaining mode, the MTU is only set when it is overridden in the network configuration, as the interface is
+	// created by the primary CNI plugin.
+	if cniConfig.MTU == 0 && !s.isChaining {
 		cniConfig.MTU = s.networkConfig.InterfaceMTU
 	}
 	cniConfig.CniCmdArgs = request.CniArgs
@@ -479,6 +501,14 @@ func (s *CNIServer) CmdAdd(ctx context.Context, request *cnipb.CniCmdRequest) (*
 		return resp, err
 	}
 
+	// kubelet retries CNI ADD after timing out waiting for the result, while the previous request may have completed.
+	// Return the result of the previous request if it's identical and the container interface still exists.
+	if resp := s.getCachedAddResult(cniConfig, infraContainer); resp != nil {
+		klog.InfoS("Returned cached result for retried CmdAdd", "container", cniConfig.ContainerId)
+		success = true
+		return resp, nil
+	}
+
 	var ipamResult *ipam.IPAMResult
 	var err error
 	// Only allocate IP when handling CNI request from infra container.
@@ -488,6 +518,9 @@ func (s *CNIServer) CmdAdd(ctx context.Context, request *cnipb.CniCmdRequest) (*
 			return nil, fmt.Errorf("allocated IP address not found")
 		}
 	} else {
+		// Record the container before allocating any resource for it, so that they can be released by CmdDel or the
+		// startup sweep even if the Agent restarts in the middle of the operation.
+		s.recordContainer(cniConfig)
 		// Request IP Address from IPAM driver.
 		ipamResult, err = ipam.ExecIPAMAdd(cniConfig.CniCmdArgs, cniConfig.K8sArgs, cniConfig.IPAM.Type, infraContainer)
 		if err != nil {
@@ -521,6 +554,12 @@ func (s *CNIServer) CmdAdd(ctx context.Context, request *cnipb.CniCmdRequest) (*
 		klog.Errorf("Failed to configure interfaces for container %s: %v", cniConfig.ContainerId, err)
 		return s.configInterfaceFailureResponse(err), nil
 	}
+	if cniConfig.RuntimeConfig.Bandwidth != nil && isInfraContainer {
+		if err = s.configurePodBandwidth(cniConfig, &result.Result, netNS); err != nil {
+			klog.Errorf("Failed to configure bandwidth limits for container %s: %v", cniConfig.ContainerId, err)
+			return s.configInterfaceFailureResponse(err), nil
+		}
+	}
 	cniVersion := cniConfig.CNIVersion
 	cniResult, _ := result.Result.GetAsVersion(cniVersion)
 
@@ -536,7 +575,24 @@ func (s *CNIServer) CmdAdd(ctx context.Context, request *cnipb.CniCmdRequest) (*
 		s.podConfigurator.podInfoStore.AddCNIConfigInfo(cniInfo)
 	}
 
-	return resultToResponse(cniResult), nil
+	response = resultToResponse(cniResult)
+	s.addResultCache.add(cniConfig.CniCmdArgs, response)
+	return response, nil
+}
+
+// getCachedAddResult returns the cached response of an identical CNI ADD request, or nil if there is none or if the
+// state of the container has changed since, i.e. its interface has been removed from the interface store.
+func (s *CNIServer) getCachedAddResult(cniConfig *CNIConfig, infraContainer string) *cnipb.CniCmdResponse {
+	resp := s.addResultCache.get(cniConfig.CniCmdArgs)
+	if resp == nil {
+		return nil
+	}
+	if _, ok := s.podConfigurator.ifaceStore.GetContainerInterface(infraContainer); !ok {
+		klog.InfoS("Discarded stale cached result for CmdAdd as the container interface is not found", "container", cniConfig.ContainerId)
+		s.addResultCache.delete(cniConfig.ContainerId)
+		return nil
+	}
+	return resp
 }
 
 func (s *CNIServer) CmdDel(_ context.Context, request *cnipb.CniCmdRequest) (
@@ -551,6 +607,8 @@ func (s *CNIServer) CmdDel(_ context.Context, request *cnipb.CniCmdRequest) (
 	infraContainer := cniConfig.getInfraContainer()
 	s.containerAccess.lockContainer(infraContainer)
 	defer s.containerAccess.unlockContainer(infraContainer)
+	// The result of the previous CNI ADD request is no longer valid, even if the CNI DEL request fails.
+	s.addResultCache.delete(cniConfig.ContainerId)
 
 	if cniConfig.secondaryNetworkIPAM {
 		klog.InfoS("Antrea IPAM del", "CNI", cniConfig.Type, "network", cniConfig.Name)
@@ -566,11 +624,18 @@ func (s *CNIServer) CmdDel(_ context.Context, request *cnipb.CniCmdRequest) (
 		return s.ipamFailureResponse(err), nil
 	}
 	klog.Infof("Deleted IP addresses for container %v", cniConfig.ContainerId)
+	if cniConfig.RuntimeConfig.Bandwidth != nil {
+		if err := s.removePodBandwidth(cniConfig); err != nil {
+			klog.Errorf("Failed to remove bandwidth limits for container %s: %v", cniConfig.ContainerId, err)
+			return s.configInterfaceFailureResponse(err), nil
+		}
+	}
 	// Remove host interface and OVS configuration
-	if err := s.podConfigurator.removeInterfaces(cniConfig.ContainerId); err != nil {
+	if err := s.removeContainerInterfaces(cniConfig.ContainerId); err != nil {
 		klog.Errorf("Failed to remove interfaces for container %s: %v", cniConfig.ContainerId, err)
 		return s.configInterfaceFailureResponse(err), nil
 	}
+	s.forgetContainer(cniConfig.ContainerId)
 	klog.Infof("CmdDel for container %v succeeded", cniConfig.ContainerId)
 	if s.secondaryNetworkEnabled {
 		podName := string(cniConfig.K8S_POD_NAME)
@@ -648,6 +713,7 @@ func New(
 		enableSecondaryNetworkIPAM: enableSecondaryNetworkIPAM,
 		networkConfig:              networkConfig,
 		networkReadyCh:             networkReadyCh,
+		addResultCache:             newAddResultCache(clock.RealClock{}),
 	}
 }
 
@@ -666,6 +732,11 @@ func (s *CNIServer) Initialize(
 		s.secondaryNetworkEnabled = false
 	}
 
+	if s.ledger, err = ledger.New(ledgerDir); err != nil {
+		// The ledger is only used to clean up orphaned resources, so the CNI server can work without it.
+		klog.ErrorS(err, "Failed to create CNI ledger, orphaned container resources may not be cleaned up after restart")
+	}
+
 	s.podConfigurator, err = newPodConfigurator(
 		ovsBridgeClient, ofClient, s.routeClient, ifaceStore, s.nodeConfig.GatewayConfig.MAC,
 		ovsBridgeClient.GetOVSDatapathType(), ovsBridgeClient.IsHardwareOffloadEnabled(), podUpdateNotifier,
@@ -710,6 +781,10 @@ func (s *CNIServer) interceptAdd(cniConfig *CNIConfig) (*cnipb.CniCmdResponse, e
 		klog.Infof("Failed to parse prev result for container %s", cniConfig.ContainerId)
 		return response, nil
 	}
+	if response := s.validateChainingConfig(cniConfig, prevResult); response != nil {
+		klog.InfoS("Invalid network configuration in chaining mode", "container", cniConfig.ContainerId)
+		return response, nil
+	}
 	podName := string(cniConfig.K8S_POD_NAME)
 	podNamespace := string(cniConfig.K8S_POD_NAMESPACE)
 	if err := s.podConfigurator.connectInterceptedInterface(
@@ -725,15 +800,28 @@ func (s *CNIServer) interceptAdd(cniConfig *CNIConfig) (*cnipb.CniCmdResponse, e
 
 	// Packets for multi-cluster traffic will always be encapsulated and sent through
 	// tunnels. So here we need to reduce interface MTU for different tunnel types.
-	if s.networkConfig.MTUDeduction != 0 {
+	// The MTU of the interface is also changed if it is overridden in the network
+	// configuration.
+	if cniConfig.MTU != 0 || s.networkConfig.MTUDeduction != 0 {
 		if err := s.podConfigurator.ifConfigurator.changeContainerMTU(
 			s.hostNetNsPath(cniConfig.Netns),
 			cniConfig.Ifname,
+			cniConfig.MTU,
 			s.networkConfig.MTUDeduction,
 		); err != nil {
 			return &cnipb.CniCmdResponse{CniResult: []byte("")}, fmt.Errorf("failed to change container %s's MTU: %w", cniConfig.ContainerId, err)
 		}
 	}
+	if len(cniConfig.Sysctl) > 0 {
+		if err := s.podConfigurator.ifConfigurator.configureContainerSysctls(s.hostNetNsPath(cniConfig.Netns), cniConfig.Sysctl); err != nil {
+			return &cnipb.CniCmdResponse{CniResult: []byte("")}, fmt.Errorf("failed to configure sysctls for container %s: %w", cniConfig.ContainerId, err)
+		}
+	}
+	if cniConfig.RuntimeConfig.Bandwidth != nil {
+		if err := s.configurePodBandwidth(cniConfig, prevResult, s.hostNetNsPath(cniConfig.Netns)); err != nil {
+			return &cnipb.CniCmdResponse{CniResult: []byte("")}, fmt.Errorf("failed to configure bandwidth limits for container %s: %w", cniConfig.ContainerId, err)
+		}
+	}
 
 	// we return prevResult, which should be exactly what we received from
 	// the runtime, potentially converted to the current CNI version used by
@@ -741,8 +829,67 @@ func (s *CNIServer) interceptAdd(cniConfig *CNIConfig) (*cnipb.CniCmdResponse, e
 	return resultToResponse(prevResult), nil
 }
 
+// validateChainingConfig validates the settings of the network configuration which are honored in
+// chaining mode on top of the interface created by the primary CNI plugin: the MTU override, the
+// sysctl settings of the tuning plugin and the bandwidth limits of the bandwidth plugin. Conflicts
+// are reported as CNI errors before any change is made to the interface.
+func (s *CNIServer) validateChainingConfig(cniConfig *CNIConfig, prevResult *current.Result) *cnipb.CniCmdResponse {
+	podMTU := cniConfig.MTU - s.networkConfig.MTUDeduction
+	if cniConfig.MTU != 0 {
+		if s.networkConfig.InterfaceMTU != 0 && cniConfig.MTU > s.networkConfig.InterfaceMTU {
+			return s.invalidNetworkConfigResponse(fmt.Sprintf("mtu %d is larger than the Node network MTU %d", cniConfig.MTU, s.networkConfig.InterfaceMTU))
+		}
+		minMTU := minIPv4MTU
+		for _, ipc := range prevResult.IPs {
+			if ipc.Address.IP.To4() == nil {
+				minMTU = minIPv6MTU
+				break
+			}
+		}
+		if podMTU < minMTU {
+			return s.invalidNetworkConfigResponse(fmt.Sprintf("mtu %d is too small, the Pod MTU would be %d which is lower than %d", cniConfig.MTU, podMTU, minMTU))
+		}
+	}
+	if len(cniConfig.Sysctl) > 0 && runtime.IsWindowsPlatform() {
+		return s.unsupportedFieldResponse("sysctl", cniConfig.Sysctl)
+	}
+	for key, value := range cniConfig.Sysctl {
+		if !isValidContainerSysctl(key, cniConfig.Ifname) {
+			return s.unsupportedFieldResponse("sysctl/"+key, value)
+		}
+		if cniConfig.MTU != 0 && key == fmt.Sprintf("net.ipv6.conf.%s.mtu", cniConfig.Ifname) && value != strconv.Itoa(podMTU) {
+			return s.invalidNetworkConfigResponse(fmt.Sprintf("sysctl %s=%s conflicts with mtu %d", key, value, cniConfig.MTU))
+		}
+	}
+	if bandwidth := cniConfig.RuntimeConfig.Bandwidth; bandwidth != nil {
+		if (bandwidth.IngressBurst != 0 && bandwidth.IngressRate == 0) || (bandwidth.EgressBurst != 0 && bandwidth.EgressRate == 0) {
+			return s.invalidNetworkConfigResponse("bandwidth burst cannot be set without rate")
+		}
+	}
+	return nil
+}
+
+// isValidContainerSysctl returns whether a sysctl can be set in the container network namespace.
+// Only net.* sysctls are namespaced, and the per-interface ones must refer to the container
+// interface, which is the only one Antrea is allowed to change.
+func isValidContainerSysctl(key, ifname string) bool {
+	if !strings.HasPrefix(key, "net.") || strings.Contains(key, "/") || strings.Contains(key, "..") {
+		return false
+	}
+	parts := strings.Split(key, ".")
+	if len(parts) >= 4 && (parts[1] == "ipv4" || parts[1] == "ipv6") && (parts[2] == "conf" || parts[2] == "neigh") {
+		return parts[3] == ifname
+	}
+	return true
+}
+
 func (s *CNIServer) interceptDel(cniConfig *CNIConfig) (*cnipb.CniCmdResponse, error) {
 	klog.Infof("CNI Chaining: delete for container %s", cniConfig.ContainerId)
+	if cniConfig.RuntimeConfig.Bandwidth != nil {
+		if err := s.removePodBandwidth(cniConfig); err != nil {
+			return &cnipb.CniCmdResponse{CniResult: []byte("")}, fmt.Errorf("failed to remove bandwidth limits for container %s: %w", cniConfig.ContainerId, err)
+		}
+	}
 	return &cnipb.CniCmdResponse{CniResult: []byte("")}, s.podConfigurator.disconnectInterceptedInterface(
 		string(cniConfig.K8S_POD_NAME),
 		string(cniConfig.K8S_POD_NAMESPACE),
@@ -770,7 +917,114 @@ func (s *CNIServer) reconcile() error {
 		return fmt.Errorf("failed to list Pods running on Node %s: %v", s.nodeConfig.Name, err)
 	}
 
-	return s.podConfigurator.reconcile(pods.Items, s.containerAccess)
+	if err := s.podConfigurator.reconcile(pods.Items, s.containerAccess); err != nil {
+		return err
+	}
+	s.sweepLedger(pods.Items)
+	return nil
+}
+
+// recordContainer adds the container to the ledger. Failures are only logged, as the ledger is not required to
+// configure the container.
+func (s *CNIServer) recordContainer(cniConfig *CNIConfig) {
+	if s.ledger == nil {
+		return
+	}
+	podName := string(cniConfig.K8S_POD_NAME)
+	podNamespace := string(cniConfig.K8S_POD_NAMESPACE)
+	entry := &ledger.Entry{
+		ContainerID:          cniConfig.ContainerId,
+		PodName:              podName,
+		PodNamespace:         podNamespace,
+		InterfaceName:        util.GenerateContainerInterfaceName(podName, podNamespace, cniConfig.ContainerId),
+		IPAMType:             cniConfig.IPAM.Type,
+		Netns:                cniConfig.Netns,
+		Ifname:               cniConfig.Ifname,
+		Args:                 cniConfig.Args,
+		Path:                 cniConfig.Path,
+		NetworkConfiguration: cniConfig.NetworkConfiguration,
+	}
+	if err := s.ledger.Add(entry); err != nil {
+		klog.ErrorS(err, "Failed to record container in CNI ledger", "container", cniConfig.ContainerId)
+	}
+}
+
+// forgetContainer removes the container from the ledger after it has been fully cleaned up.
+func (s *CNIServer) forgetContainer(containerID string) {
+	if s.ledger == nil {
+		return
+	}
+	if err := s.ledger.Delete(containerID); err != nil {
+		klog.ErrorS(err, "Failed to delete container from CNI ledger", "container", containerID)
+	}
+}
+
+// removeContainerInterfaces removes the host interface and OVS configuration of the container. If the container is not
+// in the interface store, the interfaces recorded in the ledger are removed instead, if any.
+func (s *CNIServer) removeContainerInterfaces(containerID string) error {
+	if _, found := s.podConfigurator.ifaceStore.GetContainerInterface(containerID); found || s.ledger == nil {
+		return s.podConfigurator.removeInterfaces(containerID)
+	}
+	entry, err := s.ledger.Get(containerID)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get container from CNI ledger", "container", containerID)
+		return nil
+	}
+	if entry == nil {
+		klog.V(2).Infof("Did not find the port for container %s in local cache", containerID)
+		return nil
+	}
+	klog.InfoS("Container not found in interface store, removing interfaces recorded in CNI ledger", "container", containerID, "interface", entry.InterfaceName)
+	return s.podConfigurator.removeOrphanedInterfaces(containerID, entry.InterfaceName)
+}
+
+// sweepLedger releases the resources of the containers recorded in the ledger, whose Pods no longer exist and which
+// are not in the interface store anymore. Such containers can be left behind when CmdDel was never called successfully
+// for them, e.g. because the Pod was deleted while the Agent was down and the interface store was lost. The entries of
+// the containers which cannot be cleaned up are kept, so that the cleanup is retried after the next restart.
+func (s *CNIServer) sweepLedger(pods []corev1.Pod) {
+	if s.ledger == nil {
+		return
+	}
+	entries, err := s.ledger.List()
+	if err != nil {
+		klog.ErrorS(err, "Failed to list containers in CNI ledger")
+		return
+	}
+	desiredPods := sets.New[string]()
+	for _, pod := range pods {
+		desiredPods.Insert(k8s.NamespacedName(pod.Namespace, pod.Name))
+	}
+	for _, entry := range entries {
+		if desiredPods.Has(k8s.NamespacedName(entry.PodNamespace, entry.PodName)) {
+			continue
+		}
+		if _, found := s.podConfigurator.ifaceStore.GetContainerInterface(entry.ContainerID); found {
+			continue
+		}
+		klog.InfoS("Cleaning up orphaned container recorded in CNI ledger", "container", entry.ContainerID, "pod", klog.KRef(entry.PodNamespace, entry.PodName))
+		if err := s.podConfigurator.removeOrphanedInterfaces(entry.ContainerID, entry.InterfaceName); err != nil {
+			klog.ErrorS(err, "Failed to remove interfaces of orphaned container", "container", entry.ContainerID)
+			continue
+		}
+		cniArgs := &cnipb.CniCmdArgs{
+			ContainerId:          entry.ContainerID,
+			Netns:                entry.Netns,
+			Ifname:               entry.Ifname,
+			Args:                 entry.Args,
+			Path:                 entry.Path,
+			NetworkConfiguration: entry.NetworkConfiguration,
+		}
+		k8sArgs := &types.K8sArgs{}
+		if err := cnitypes.LoadArgs(entry.Args, k8sArgs); err != nil {
+			klog.ErrorS(err, "Failed to parse CNI args of orphaned container", "container", entry.ContainerID)
+		}
+		if err := ipam.ExecIPAMDelete(cniArgs, k8sArgs, entry.IPAMType, entry.ContainerID); err != nil {
+			klog.ErrorS(err, "Failed to release IP addresses of orphaned container", "container", entry.ContainerID)
+			continue
+		}
+		s.forgetContainer(entry.ContainerID)
+	}
 }
 
 func init() {
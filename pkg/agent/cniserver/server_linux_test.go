@@ -34,6 +34,7 @@ import (
 
 	"antrea.io/antrea/pkg/agent/cniserver/ipam"
 	ipamtest "antrea.io/antrea/pkg/agent/cniserver/ipam/testing"
+	"antrea.io/antrea/pkg/agent/cniserver/ledger"
 	cniservertest "antrea.io/antrea/pkg/agent/cniserver/testing"
 	types "antrea.io/antrea/pkg/agent/cniserver/types"
 	"antrea.io/antrea/pkg/agent/config"
@@ -115,6 +116,98 @@ func TestValidatePrevResult(t *testing.T) {
 	})
 }
 
+func TestValidateChainingConfig(t *testing.T) {
+	ipv4Result := ipamtest.GenerateIPAMResult(ips, routes, dns)
+	ipv6Result := ipamtest.GenerateIPAMResult([]string{"fd00:10:1:2::100/64,fd00:10:1:2::1,6"}, nil, dns)
+	for _, tc := range []struct {
+		name         string
+		mtu          int
+		mtuDeduction int
+		sysctl       map[string]string
+		bandwidth    *types.BandwidthEntry
+		prevResult   *current.Result
+		errorCode    cnipb.ErrorCode
+	}{
+		{
+			name:   "valid config",
+			mtu:    1400,
+			sysctl: map[string]string{"net.core.somaxconn": "1024", "net.ipv4.conf.eth0.arp_notify": "1", "net.ipv6.conf.eth0.mtu": "1350"},
+			bandwidth: &types.BandwidthEntry{
+				IngressRate:  1000000,
+				IngressBurst: 100000,
+			},
+			mtuDeduction: 50,
+		},
+		{
+			name:      "MTU larger than Node network MTU",
+			mtu:       9000,
+			errorCode: cnipb.ErrorCode_INVALID_NETWORK_CONFIG,
+		},
+		{
+			name:         "MTU too small after deduction",
+			mtu:          100,
+			mtuDeduction: 50,
+			errorCode:    cnipb.ErrorCode_INVALID_NETWORK_CONFIG,
+		},
+		{
+			name:       "MTU too small for IPv6",
+			mtu:        1000,
+			prevResult: ipv6Result,
+			errorCode:  cnipb.ErrorCode_INVALID_NETWORK_CONFIG,
+		},
+		{
+			name:      "non-net sysctl",
+			sysctl:    map[string]string{"kernel.shmmax": "1024"},
+			errorCode: cnipb.ErrorCode_UNSUPPORTED_FIELD,
+		},
+		{
+			name:      "sysctl for other interface",
+			sysctl:    map[string]string{"net.ipv4.conf.eth1.arp_notify": "1"},
+			errorCode: cnipb.ErrorCode_UNSUPPORTED_FIELD,
+		},
+		{
+			name:      "sysctl path traversal",
+			sysctl:    map[string]string{"net/../../kernel/shmmax": "1024"},
+			errorCode: cnipb.ErrorCode_UNSUPPORTED_FIELD,
+		},
+		{
+			name:      "sysctl conflicting with MTU",
+			mtu:       1400,
+			sysctl:    map[string]string{"net.ipv6.conf.eth0.mtu": "1300"},
+			errorCode: cnipb.ErrorCode_INVALID_NETWORK_CONFIG,
+		},
+		{
+			name:      "bandwidth burst without rate",
+			bandwidth: &types.BandwidthEntry{EgressBurst: 100000},
+			errorCode: cnipb.ErrorCode_INVALID_NETWORK_CONFIG,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cniServer := newCNIServer(t)
+			cniServer.isChaining = true
+			cniServer.networkConfig.MTUDeduction = tc.mtuDeduction
+			networkCfg := generateNetworkConfiguration("", supportedCNIVersion, "", testIpamType)
+			networkCfg.MTU = tc.mtu
+			networkCfg.Sysctl = tc.sysctl
+			networkCfg.RuntimeConfig.Bandwidth = tc.bandwidth
+			cniConfig := &CNIConfig{
+				NetworkConfig: networkCfg,
+				CniCmdArgs:    &cnipb.CniCmdArgs{Ifname: ifname},
+			}
+			prevResult := tc.prevResult
+			if prevResult == nil {
+				prevResult = ipv4Result
+			}
+			response := cniServer.validateChainingConfig(cniConfig, prevResult)
+			if tc.errorCode != 0 {
+				checkErrorResponse(t, response, tc.errorCode, "")
+			} else {
+				assert.Nil(t, response)
+			}
+		})
+	}
+}
+
 func TestRemoveInterface(t *testing.T) {
 	controller := gomock.NewController(t)
 	mockOVSBridgeClient = ovsconfigtest.NewMockOVSBridgeClient(controller)
@@ -382,6 +475,11 @@ func TestCmdAdd(t *testing.T) {
 				assert.NoError(t, err)
 				successResponse := resultToResponse(versionedResult)
 				assert.Equal(t, successResponse, resp)
+				// The retried request should get the cached result, without allocating IPs or configuring the
+				// interfaces again.
+				resp, err = cniserver.CmdAdd(ctx, requestMsg)
+				assert.NoError(t, err)
+				assert.Equal(t, successResponse, resp)
 			}
 			if tc.secondaryNetworkEnabled {
 				cniConfigInfo := cniserver.podConfigurator.podInfoStore.GetCNIConfigInfoByContainerID(tc.podName, testPodNamespace, containerID)
@@ -730,3 +828,66 @@ func TestReconcile(t *testing.T) {
 	_, exists := ifaceStore.GetInterfaceByName("iface3")
 	assert.False(t, exists)
 }
+
+func TestCmdDelWithLedger(t *testing.T) {
+	controller := gomock.NewController(t)
+	ipamMock := ipamtest.NewMockIPAMDriver(controller)
+	ipamType := "test-delete-ledger"
+	cniServer := newMockCNIServer(t, controller, ipamMock, ipamType, false, false, false)
+	var err error
+	cniServer.ledger, err = ledger.New(t.TempDir())
+	require.NoError(t, err)
+	requestMsg, hostInterfaceName := createCNIRequestAndInterfaceName(t, "pod1", "", ipamResult, ipamType, true)
+	containerID := requestMsg.CniArgs.ContainerId
+	// The container is recorded in the ledger but not in the interface store, e.g. after OVSDB was reset.
+	require.NoError(t, cniServer.ledger.Add(&ledger.Entry{ContainerID: containerID, PodName: "pod1", PodNamespace: testPodNamespace, InterfaceName: hostInterfaceName}))
+	testIfaceConfigurator := newTestInterfaceConfigurator()
+	testIfaceConfigurator.hostIfaceName = hostInterfaceName
+	cniServer.podConfigurator.ifConfigurator = testIfaceConfigurator
+
+	ovsPortID := generateUUID(t)
+	ipamMock.EXPECT().Del(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).Times(1)
+	mockOVSBridgeClient.EXPECT().GetPortList().Return([]ovsconfig.OVSPortData{
+		{UUID: generateUUID(t), Name: "antrea-gw0"},
+		{UUID: ovsPortID, Name: hostInterfaceName},
+	}, nil).Times(1)
+	mockOVSBridgeClient.EXPECT().DeletePort(ovsPortID).Return(nil).Times(1)
+	resp, err := cniServer.CmdDel(context.TODO(), requestMsg)
+	require.NoError(t, err)
+	assert.Equal(t, emptyResponse, resp)
+	entry, err := cniServer.ledger.Get(containerID)
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+}
+
+func TestSweepLedger(t *testing.T) {
+	controller := gomock.NewController(t)
+	ipamMock := ipamtest.NewMockIPAMDriver(controller)
+	ipamType := "test-sweep-ledger"
+	cniServer := newMockCNIServer(t, controller, ipamMock, ipamType, false, false, false)
+	cniServer.podConfigurator.ifConfigurator = newTestInterfaceConfigurator()
+	var err error
+	cniServer.ledger, err = ledger.New(t.TempDir())
+	require.NoError(t, err)
+
+	runningEntry := &ledger.Entry{ContainerID: "c1", PodName: "p1", PodNamespace: testPodNamespace, InterfaceName: "p1-iface", IPAMType: ipamType}
+	configuredEntry := &ledger.Entry{ContainerID: "c2", PodName: "p2", PodNamespace: testPodNamespace, InterfaceName: "p2-iface", IPAMType: ipamType}
+	orphanedEntry := &ledger.Entry{ContainerID: "c3", PodName: "p3", PodNamespace: testPodNamespace, InterfaceName: "p3-iface", IPAMType: ipamType,
+		Args: fmt.Sprintf("K8S_POD_NAMESPACE=%s;K8S_POD_NAME=p3", testPodNamespace)}
+	for _, entry := range []*ledger.Entry{runningEntry, configuredEntry, orphanedEntry} {
+		require.NoError(t, cniServer.ledger.Add(entry))
+	}
+	// The Pod of c2 is gone, but its interface is still in the interface store, so it's cleaned up by CmdDel.
+	ifaceStore.AddInterface(interfacestore.NewContainerInterface("p2-iface", "c2", "p2", testPodNamespace, containerVethMac, nil, 0))
+	pods := []v1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: testPodNamespace}}}
+
+	ovsPortID := generateUUID(t)
+	mockOVSBridgeClient.EXPECT().GetPortList().Return([]ovsconfig.OVSPortData{{UUID: ovsPortID, Name: "p3-iface"}}, nil).Times(1)
+	mockOVSBridgeClient.EXPECT().DeletePort(ovsPortID).Return(nil).Times(1)
+	ipamMock.EXPECT().Del(gomock.Any(), &types.K8sArgs{K8S_POD_NAME: "p3", K8S_POD_NAMESPACE: cnitypes.UnmarshallableString(testPodNamespace)}, gomock.Any()).Return(true, nil).Times(1)
+	cniServer.sweepLedger(pods)
+
+	entries, err := cniServer.ledger.List()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*ledger.Entry{runningEntry, configuredEntry}, entries)
+}
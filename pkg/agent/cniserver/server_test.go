@@ -28,6 +28,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/utils/clock"
 
 	"antrea.io/antrea/pkg/agent/cniserver/ipam"
 	ipamtest "antrea.io/antrea/pkg/agent/cniserver/ipam/testing"
@@ -37,6 +38,7 @@ import (
 	"antrea.io/antrea/pkg/agent/interfacestore"
 	openflowtest "antrea.io/antrea/pkg/agent/openflow/testing"
 	routetest "antrea.io/antrea/pkg/agent/route/testing"
+	agenttypes "antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/agent/util"
 	cnipb "antrea.io/antrea/pkg/apis/cni/v1beta1"
 	"antrea.io/antrea/pkg/cni"
@@ -589,6 +591,15 @@ func TestBuildOVSPortExternalIDs(t *testing.T) {
 		}
 		assert.True(t, existed, fmt.Sprintf("IP %s should exist in the restored InterfaceConfig", ip1.String()))
 	}
+	assert.Nil(t, ifaceConfig.Bandwidth)
+
+	// The bandwidth limits enforced with OVS meters are restored as well.
+	containerConfig.Bandwidth = &agenttypes.PodBandwidth{IngressRate: 10000000, IngressBurst: 2147483647, EgressRate: 20000000, EgressBurst: 2147483647}
+	for k, v := range BuildOVSPortExternalIDs(containerConfig) {
+		portExternalIDs[k] = v.(string)
+	}
+	ifaceConfig = ParseOVSPortInterfaceConfig(mockPort, portConfig)
+	assert.Equal(t, containerConfig.Bandwidth, ifaceConfig.Bandwidth)
 }
 
 func translateRawPrevResult(prevResult *current.Result, cniVersion string) (map[string]interface{}, error) {
@@ -621,6 +632,7 @@ func newCNIServer(t *testing.T) *CNIServer {
 		serverVersion:   cni.AntreaCNIVersion,
 		containerAccess: newContainerAccessArbitrator(),
 		networkReadyCh:  networkReadyCh,
+		addResultCache:  newAddResultCache(clock.RealClock{}),
 	}
 	close(networkReadyCh)
 	cniServer.supportedCNIVersions = buildVersionSet()
@@ -39,6 +39,18 @@ const (
 	connectionTimeout       = 10 * time.Second
 )
 
+// SriovVFConfig is the configuration applied to a SR-IOV VF through its PF before the VF is moved to the container
+// network namespace.
+type SriovVFConfig struct {
+	// MAC is the MAC address assigned to the VF. The VF keeps its MAC address if it is nil.
+	MAC net.HardwareAddr
+	// VLAN is the VLAN ID of the VF. The VF traffic is not tagged if it is 0.
+	VLAN int
+	// Trust enables the trusted mode of the VF, which allows the VF to change its MAC address and to enable the
+	// promiscuous mode.
+	Trust bool
+}
+
 type KubeletPodResources struct {
 	resources []*podresourcesv1alpha1.PodResources
 }
@@ -98,18 +110,27 @@ func (pc *podConfigurator) ConfigureSriovSecondaryInterface(
 	containerIFDev string,
 	mtu int,
 	podSriovVFDeviceID string,
+	vfConfig *SriovVFConfig,
 	result *current.Result,
 ) error {
 	if podSriovVFDeviceID == "" {
 		return fmt.Errorf("error getting the Pod SR-IOV VF device ID")
 	}
 
-	err := pc.ifConfigurator.configureContainerLink(podName, podNameSpace, containerID, containerNetNS, containerIFDev, mtu, "", podSriovVFDeviceID, result, nil)
+	vfMAC, err := pc.ifConfigurator.configureSriovVF(podSriovVFDeviceID, vfConfig)
+	if err != nil {
+		return fmt.Errorf("failed to configure SR-IOV VF %s: %w", podSriovVFDeviceID, err)
+	}
+	err = pc.ifConfigurator.configureContainerLink(podName, podNameSpace, containerID, containerNetNS, containerIFDev, mtu, "", podSriovVFDeviceID, result, nil)
 	if err != nil {
 		return err
 	}
 	hostIface := result.Interfaces[0]
 	containerIface := result.Interfaces[1]
+	// Record the original MAC address of the VF, which is restored when the VF is released.
+	if vfMAC != nil {
+		hostIface.Mac = vfMAC.String()
+	}
 
 	if err = pc.ifConfigurator.advertiseContainerAddr(containerNetNS, containerIface.Name, result); err != nil {
 		return fmt.Errorf("failed to advertise IP address for container %s: %v", containerID, err)
@@ -118,3 +139,14 @@ func (pc *podConfigurator) ConfigureSriovSecondaryInterface(
 	klog.Infof("Configured interfaces for container %s; hostIface: %+v, containerIface: %+v", containerID, hostIface, containerIface)
 	return nil
 }
+
+// ReleaseSriovSecondaryInterface restores the original name and configuration of a SR-IOV VF after it is returned to the
+// host network namespace, which happens when the Pod's network namespace is deleted. hostIface is the host interface
+// returned by ConfigureSriovSecondaryInterface, which holds the original name and MAC address of the VF.
+func (pc *podConfigurator) ReleaseSriovSecondaryInterface(podSriovVFDeviceID string, hostIface *current.Interface, vfConfig *SriovVFConfig) error {
+	if err := pc.ifConfigurator.releaseSriovVF(podSriovVFDeviceID, hostIface, vfConfig); err != nil {
+		return fmt.Errorf("failed to release SR-IOV VF %s: %w", podSriovVFDeviceID, err)
+	}
+	klog.InfoS("Released SR-IOV VF", "device", podSriovVFDeviceID, "interface", hostIface.Name)
+	return nil
+}
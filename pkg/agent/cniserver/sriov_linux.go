@@ -18,8 +18,14 @@
 package cniserver
 
 import (
+	"fmt"
+	"net"
+
 	"github.com/Mellanox/sriovnet"
+	current "github.com/containernetworking/cni/pkg/types/100"
 	sriovcniutils "github.com/k8snetworkplumbingwg/sriov-cni/pkg/utils"
+	"github.com/vishvananda/netlink"
+	"k8s.io/klog/v2"
 )
 
 // getVFInfo takes in a VF's PCI device ID and returns its PF and VF ID.
@@ -44,6 +50,104 @@ func (ic *ifConfigurator) getVFLinkName(pciAddress string) (string, error) {
 	return ic.sriovnet.GetVFLinkNames(pciAddress)
 }
 
+// configureSriovVF applies vfConfig to the VF through its PF, and returns the original MAC address of the VF.
+func (ic *ifConfigurator) configureSriovVF(pciAddress string, vfConfig *SriovVFConfig) (net.HardwareAddr, error) {
+	pfLink, vfID, vfLink, err := ic.getVFLinks(pciAddress)
+	if err != nil {
+		return nil, err
+	}
+	vfMAC := vfLink.Attrs().HardwareAddr
+	if vfConfig == nil {
+		return vfMAC, nil
+	}
+	if vfConfig.MAC != nil {
+		if err := ic.netlink.LinkSetVfHardwareAddr(pfLink, vfID, vfConfig.MAC); err != nil {
+			return nil, fmt.Errorf("failed to set MAC address of VF %d: %v", vfID, err)
+		}
+		// Some drivers don't apply the administrative MAC address to the VF netdevice.
+		if err := ic.netlink.LinkSetHardwareAddr(vfLink, vfConfig.MAC); err != nil {
+			return nil, fmt.Errorf("failed to set MAC address of VF netdevice %s: %v", vfLink.Attrs().Name, err)
+		}
+	}
+	if vfConfig.VLAN != 0 {
+		if err := ic.netlink.LinkSetVfVlan(pfLink, vfID, vfConfig.VLAN); err != nil {
+			return nil, fmt.Errorf("failed to set VLAN of VF %d: %v", vfID, err)
+		}
+	}
+	if vfConfig.Trust {
+		if err := ic.netlink.LinkSetVfTrust(pfLink, vfID, true); err != nil {
+			return nil, fmt.Errorf("failed to enable trusted mode of VF %d: %v", vfID, err)
+		}
+	}
+	klog.V(2).InfoS("Configured SR-IOV VF", "device", pciAddress, "pf", pfLink.Attrs().Name, "vf", vfID)
+	return vfMAC, nil
+}
+
+// releaseSriovVF renames the VF netdevice back to its original name and resets the configuration applied by
+// configureSriovVF. It fails if the VF is not in the host network namespace yet.
+func (ic *ifConfigurator) releaseSriovVF(pciAddress string, hostIface *current.Interface, vfConfig *SriovVFConfig) error {
+	pfLink, vfID, vfLink, err := ic.getVFLinks(pciAddress)
+	if err != nil {
+		return err
+	}
+	if vfLink.Attrs().Name != hostIface.Name {
+		if err := ic.netlink.LinkSetDown(vfLink); err != nil {
+			return fmt.Errorf("failed to set VF netdevice %s down: %v", vfLink.Attrs().Name, err)
+		}
+		if err := ic.netlink.LinkSetName(vfLink, hostIface.Name); err != nil {
+			return fmt.Errorf("failed to rename VF netdevice %s to %s: %v", vfLink.Attrs().Name, hostIface.Name, err)
+		}
+	}
+	if vfConfig == nil {
+		return nil
+	}
+	if vfConfig.MAC != nil && hostIface.Mac != "" {
+		mac, err := net.ParseMAC(hostIface.Mac)
+		if err != nil {
+			return fmt.Errorf("invalid MAC address %s of VF %d: %v", hostIface.Mac, vfID, err)
+		}
+		if err := ic.netlink.LinkSetVfHardwareAddr(pfLink, vfID, mac); err != nil {
+			return fmt.Errorf("failed to restore MAC address of VF %d: %v", vfID, err)
+		}
+		if err := ic.netlink.LinkSetHardwareAddr(vfLink, mac); err != nil {
+			return fmt.Errorf("failed to restore MAC address of VF netdevice %s: %v", hostIface.Name, err)
+		}
+	}
+	if vfConfig.VLAN != 0 {
+		if err := ic.netlink.LinkSetVfVlan(pfLink, vfID, 0); err != nil {
+			return fmt.Errorf("failed to reset VLAN of VF %d: %v", vfID, err)
+		}
+	}
+	if vfConfig.Trust {
+		if err := ic.netlink.LinkSetVfTrust(pfLink, vfID, false); err != nil {
+			return fmt.Errorf("failed to disable trusted mode of VF %d: %v", vfID, err)
+		}
+	}
+	return nil
+}
+
+// getVFLinks returns the PF link, the VF ID and the VF link of a VF given its PCI address. The VF must be in the host
+// network namespace.
+func (ic *ifConfigurator) getVFLinks(pciAddress string) (netlink.Link, int, netlink.Link, error) {
+	pfName, vfID, err := ic.getVFInfo(pciAddress)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("failed to get VF information: %v", err)
+	}
+	pfLink, err := ic.netlink.LinkByName(pfName)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("error getting PF link %s: %v", pfName, err)
+	}
+	vfIFName, err := ic.getVFLinkName(pciAddress)
+	if err != nil || vfIFName == "" {
+		return nil, 0, nil, fmt.Errorf("VF interface not found for pciAddress %s: %v", pciAddress, err)
+	}
+	vfLink, err := ic.netlink.LinkByName(vfIFName)
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("error getting VF link %s: %v", vfIFName, err)
+	}
+	return pfLink, vfID, vfLink, nil
+}
+
 type sriovNet struct{}
 
 func (n *sriovNet) GetNetDevicesFromPci(pciAddress string) ([]string, error) {
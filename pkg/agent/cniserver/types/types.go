@@ -32,8 +32,18 @@ type RuntimeDNS struct {
 	Search      []string `json:"searches,omitempty"`
 }
 
+// BandwidthEntry is passed by the container runtime when the "bandwidth" capability is enabled for the plugin. The
+// rates are in bits per second and the bursts are in bits.
+type BandwidthEntry struct {
+	IngressRate  uint64 `json:"ingressRate,omitempty"`
+	IngressBurst uint64 `json:"ingressBurst,omitempty"`
+	EgressRate   uint64 `json:"egressRate,omitempty"`
+	EgressBurst  uint64 `json:"egressBurst,omitempty"`
+}
+
 type RuntimeConfig struct {
-	DNS RuntimeDNS `json:"dns"`
+	DNS       RuntimeDNS      `json:"dns"`
+	Bandwidth *BandwidthEntry `json:"bandwidth,omitempty"`
 }
 
 type Range struct {
@@ -73,6 +83,9 @@ type NetworkConfig struct {
 	MTU        int          `json:"mtu,omitempty"`
 	DNS        cnitypes.DNS `json:"dns,omitempty"`
 	IPAM       *IPAMConfig  `json:"ipam,omitempty"`
+	// Sysctl settings to apply in the container network namespace, with the same format as the tuning plugin.
+	// Only honored in chaining mode.
+	Sysctl map[string]string `json:"sysctl,omitempty"`
 	// Options to be passed in by the runtime.
 	RuntimeConfig RuntimeConfig          `json:"runtimeConfig,omitempty"`
 	RawPrevResult map[string]interface{} `json:"prevResult,omitempty"`
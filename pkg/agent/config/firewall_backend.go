@@ -0,0 +1,62 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import "strings"
+
+// FirewallBackend is the backend used by the route client to program the host firewall rules, e.g. the
+// masquerade and NodePort rules.
+type FirewallBackend int
+
+const (
+	// FirewallBackendAuto lets antrea-agent select the backend automatically.
+	FirewallBackendAuto FirewallBackend = iota
+	FirewallBackendIPTables
+	FirewallBackendNFTables
+	FirewallBackendInvalid = -1
+)
+
+var supportedFirewallBackendStrs = [...]string{
+	"auto",
+	"iptables",
+	"nftables",
+}
+
+func GetFirewallBackends() []FirewallBackend {
+	return []FirewallBackend{
+		FirewallBackendAuto,
+		FirewallBackendIPTables,
+		FirewallBackendNFTables,
+	}
+}
+
+// String returns value in string.
+func (b FirewallBackend) String() string {
+	if b < 0 || int(b) >= len(supportedFirewallBackendStrs) {
+		return "invalid"
+	}
+	return supportedFirewallBackendStrs[b]
+}
+
+// GetFirewallBackendFromStr returns true and FirewallBackend corresponding to input string.
+// Otherwise, false and undefined value is returned
+func GetFirewallBackendFromStr(str string) (bool, FirewallBackend) {
+	for idx, bs := range supportedFirewallBackendStrs {
+		if strings.EqualFold(bs, str) {
+			return true, FirewallBackend(idx)
+		}
+	}
+	return false, FirewallBackendInvalid
+}
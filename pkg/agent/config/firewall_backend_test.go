@@ -0,0 +1,67 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFirewallBackends(t *testing.T) {
+	backends := GetFirewallBackends()
+	expBackends := []FirewallBackend{0, 1, 2}
+	assert.Equal(t, expBackends, backends, "TestGetFirewallBackends received unexpected backends")
+}
+
+func TestGetFirewallBackendFromStr(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		expBool    bool
+		expBackend FirewallBackend
+	}{
+		{"Auto", "auto", true, FirewallBackendAuto},
+		{"iptables", "iptables", true, FirewallBackendIPTables},
+		{"nftables", "nftables", true, FirewallBackendNFTables},
+		{"Capital case", "NFTables", true, FirewallBackendNFTables},
+		{"Invalid string", "ebtables", false, FirewallBackendInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, backend := GetFirewallBackendFromStr(tt.input)
+			assert.Equal(t, tt.expBool, ok, "GetFirewallBackendFromStr did not return correct boolean")
+			assert.Equal(t, tt.expBackend, backend, "GetFirewallBackendFromStr did not return correct backend")
+		})
+	}
+}
+
+func TestFirewallBackend_String(t *testing.T) {
+	tests := []struct {
+		name string
+		b    FirewallBackend
+		want string
+	}{
+		{"Auto", FirewallBackendAuto, "auto"},
+		{"iptables", FirewallBackendIPTables, "iptables"},
+		{"nftables", FirewallBackendNFTables, "nftables"},
+		{"Invalid", FirewallBackendInvalid, "invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.b.String(), "FirewallBackend.String did not return correct string representation")
+		})
+	}
+}
@@ -45,6 +45,14 @@ const (
 	IPSecESPOverhead = 38
 )
 
+const (
+	// PhysicalBridgePatchPortName is the name of the patch port on the OVS bridge for Pod traffic, which is connected
+	// to the OVS physical bridge.
+	PhysicalBridgePatchPortName = "antrea-phy-patch"
+	// IntegrationBridgePatchPortName is the name of the peer patch port on the OVS physical bridge.
+	IntegrationBridgePatchPortName = "antrea-int-patch"
+)
+
 const (
 	L7NetworkPolicyTargetPortName = "antrea-l7-tap0"
 	L7NetworkPolicyReturnPortName = "antrea-l7-tap1"
@@ -115,6 +123,9 @@ type AdapterNetConfig struct {
 	Routes     []interface{}
 	// OFPort is the OpenFlow port number of the uplink interface allocated by OVS.
 	OFPort uint32
+	// GatewayMAC is the MAC address of the default gateway of the uplink. It is only resolved on Windows, where it is
+	// used to output the Egress SNAT packets to the uplink directly from OVS.
+	GatewayMAC net.HardwareAddr
 }
 
 type WireGuardConfig struct {
@@ -130,6 +141,12 @@ type WireGuardConfig struct {
 
 type EgressConfig struct {
 	ExceptCIDRs []net.IPNet
+	// EnableDefaultDeny indicates whether the traffic from local Pods to the external network is dropped, unless it is
+	// SNAT'd by an Egress or allowed by an Antrea-native policy.
+	EnableDefaultDeny bool
+	// DefaultDenyAllNamespaces indicates whether default deny applies to the Pods of all Namespaces. Otherwise, it only
+	// applies to the Pods of the Namespaces annotated with "egress.antrea.io/default-deny-external".
+	DefaultDenyAllNamespaces bool
 }
 
 // Local Node configurations retrieved from K8s API or host networking state.
@@ -168,6 +185,12 @@ type NodeConfig struct {
 	// one which the IP/MAC of the uplink is moved to. If the host interface is the OVS bridge interface (br-int), the
 	// value is config.BridgeOFPort.
 	HostInterfaceOFPort uint32
+	// The name of the OVS bridge which the uplink interface is connected to, when it's separated from the OVS bridge
+	// for Pod traffic. It's empty if there is no such bridge.
+	OVSPhysicalBridge string
+	// PhysicalBridgePatchOFPort is the OpenFlow port number of the patch port connecting the OVS bridge to the OVS
+	// physical bridge. Without the OVS physical bridge, the value is 0.
+	PhysicalBridgePatchOFPort uint32
 	// The config of the gateway interface on the OVS bridge.
 	GatewayConfig *GatewayConfig
 	// The config of the OVS bridge uplink interface. Only for Windows Node.
@@ -209,6 +232,18 @@ type NetworkConfig struct {
 	// encap header.
 	InterfaceMTU         int
 	EnableMulticlusterGW bool
+	// FirewallBackend is the backend used to program the host firewall rules.
+	FirewallBackend FirewallBackend
+	// DisableTTLDecrement disables decrementing the TTL of the Pod traffic routed by OVS.
+	DisableTTLDecrement bool
+	// SendICMPTimeExceeded enables replying to the routed Pod packets whose TTL expires with ICMP Time Exceeded
+	// messages.
+	SendICMPTimeExceeded bool
+	// EnableRPFilterExceptions enables managing the rp_filter exceptions of the Antrea-managed interfaces and virtual
+	// IPs.
+	EnableRPFilterExceptions bool
+	// RPFilterExceptionInterfaces are the additional interfaces to set rp_filter to loose mode on.
+	RPFilterExceptionInterfaces []string
 }
 
 // IsIPv4Enabled returns true if the cluster network supports IPv4. Legal cases are:
@@ -290,6 +325,11 @@ type ServiceConfig struct {
 	ServiceCIDRv6         *net.IPNet // K8s Service ClusterIP CIDR in IPv6
 	NodePortAddressesIPv4 []net.IP
 	NodePortAddressesIPv6 []net.IP
+	// RejectServicesWithoutEndpoints indicates whether the packets sent to Services without Endpoints are rejected with
+	// TCP RST or ICMP port unreachable packets. Otherwise, they are dropped.
+	RejectServicesWithoutEndpoints bool
+	// EnableLoadBalancerModeDSR indicates whether the external addresses of Services can be load balanced in DSR mode.
+	EnableLoadBalancerModeDSR bool
 }
 
 // L7NetworkPolicyConfig includes target and return ofPorts for L7 NetworkPolicy.
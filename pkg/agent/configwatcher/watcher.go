@@ -0,0 +1,255 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configwatcher watches the antrea-agent configuration file and applies the changes of the options which
+// support being updated at runtime, without restarting antrea-agent. The changes of the other options are rejected
+// and reported with Events, as they only take effect after antrea-agent is restarted.
+package configwatcher
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	agentconfig "antrea.io/antrea/pkg/config/agent"
+)
+
+const (
+	ReasonConfigChangeApplied  = "ConfigChangeApplied"
+	ReasonConfigChangeRejected = "ConfigChangeRejected"
+)
+
+// Option is a set of antrea-agent configuration options which can be updated at runtime together.
+type Option struct {
+	// Paths are the paths of the options in the configuration file, with the names of the nested options joined by
+	// ".", e.g. "flowExporter.flowPollInterval".
+	Paths []string
+	// Apply applies the options of the new configuration. It's called when any of the options has changed.
+	Apply func(config *agentconfig.AgentConfig) error
+}
+
+// LoadFunc parses, defaults and validates the content of the configuration file.
+type LoadFunc func(data []byte) (*agentconfig.AgentConfig, error)
+
+// Watcher watches the antrea-agent configuration file and applies the changes of the registered Options.
+type Watcher struct {
+	configFile string
+	configData []byte
+	// config is the effective configuration, i.e. the configuration antrea-agent was started with, with the changes
+	// applied at runtime. It's a copy loaded from the file, as the configuration used by antrea-agent must not be
+	// modified.
+	config   *agentconfig.AgentConfig
+	load     LoadFunc
+	options  []Option
+	recorder record.EventRecorder
+	nodeRef  *corev1.ObjectReference
+}
+
+// NewWatcher creates a Watcher for the configuration file antrea-agent was started with. Events are recorded on the
+// Node with the provided name.
+func NewWatcher(configFile, nodeName string, load LoadFunc, recorder record.EventRecorder) (*Watcher, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read antrea-agent configuration file: %w", err)
+	}
+	config, err := load(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load antrea-agent configuration: %w", err)
+	}
+	return &Watcher{
+		configFile: configFile,
+		configData: data,
+		config:     config,
+		load:       load,
+		recorder:   recorder,
+		nodeRef: &corev1.ObjectReference{
+			Kind: "Node",
+			Name: nodeName,
+			UID:  types.UID(nodeName),
+		},
+	}, nil
+}
+
+// AddOption registers an Option which can be updated at runtime. It must be called before Run.
+func (w *Watcher) AddOption(option Option) {
+	w.options = append(w.options, option)
+}
+
+func (w *Watcher) Run(stopCh <-chan struct{}) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		klog.ErrorS(err, "Error when creating file watcher for antrea-agent configuration file")
+		return
+	}
+	defer watcher.Close()
+	// The configuration file is a symlink updated atomically by kubelet when the ConfigMap changes, and the file
+	// watched directly would be lost after the update. Watching the directory can prevent us from this situation.
+	if err := watcher.Add(filepath.Dir(w.configFile)); err != nil {
+		klog.ErrorS(err, "Error when starting file watch on antrea-agent configuration dir")
+		return
+	}
+	klog.InfoS("Watching for antrea-agent configuration file", "file", w.configFile)
+	for {
+		select {
+		case <-stopCh:
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				klog.InfoS("Stopped watching antrea-agent configuration file as the event channel is closed")
+				return
+			}
+			klog.V(2).InfoS("Event happened", "event", event.String())
+			w.reload()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				klog.InfoS("Stopped watching antrea-agent configuration file as the error channel is closed")
+				return
+			}
+			klog.ErrorS(err, "Error when watching antrea-agent configuration file")
+		}
+	}
+}
+
+func (w *Watcher) reload() {
+	data, err := os.ReadFile(w.configFile)
+	if err != nil {
+		klog.ErrorS(err, "Cannot read antrea-agent configuration file")
+		return
+	}
+	if bytes.Equal(data, w.configData) {
+		klog.V(2).InfoS("antrea-agent configuration didn't change")
+		return
+	}
+	w.configData = data
+	newConfig, err := w.load(data)
+	if err != nil {
+		klog.ErrorS(err, "Invalid antrea-agent configuration, ignoring the changes")
+		w.recorder.Eventf(w.nodeRef, corev1.EventTypeWarning, ReasonConfigChangeRejected, "Invalid antrea-agent configuration, ignoring the changes: %v", err)
+		return
+	}
+
+	changed := map[string]bool{}
+	diffConfig(reflect.ValueOf(w.config).Elem(), reflect.ValueOf(newConfig).Elem(), "", changed)
+	if len(changed) == 0 {
+		return
+	}
+
+	var applied []string
+	failed := map[string]error{}
+	for _, option := range w.options {
+		var changedPaths []string
+		for _, path := range option.Paths {
+			if changed[path] {
+				changedPaths = append(changedPaths, path)
+			}
+		}
+		if len(changedPaths) == 0 {
+			continue
+		}
+		for _, path := range changedPaths {
+			delete(changed, path)
+		}
+		if err := option.Apply(newConfig); err != nil {
+			klog.ErrorS(err, "Failed to apply antrea-agent configuration change", "options", changedPaths)
+			for _, path := range changedPaths {
+				failed[path] = err
+			}
+			continue
+		}
+		// Only record the applied changes in the effective configuration, so that the changes which are not applied
+		// are still considered as changed after the next update of the configuration file.
+		for _, path := range changedPaths {
+			setField(reflect.ValueOf(w.config).Elem(), reflect.ValueOf(newConfig).Elem(), strings.Split(path, "."))
+		}
+		applied = append(applied, changedPaths...)
+	}
+
+	if len(applied) > 0 {
+		sort.Strings(applied)
+		klog.InfoS("Applied antrea-agent configuration changes", "options", applied)
+		w.recorder.Eventf(w.nodeRef, corev1.EventTypeNormal, ReasonConfigChangeApplied, "Applied the changes of antrea-agent configuration options: %s", strings.Join(applied, ", "))
+	}
+	for _, path := range sortedKeys(failed) {
+		w.recorder.Eventf(w.nodeRef, corev1.EventTypeWarning, ReasonConfigChangeRejected, "Failed to apply the change of antrea-agent configuration option %s: %v", path, failed[path])
+	}
+	if len(changed) > 0 {
+		restartRequired := sortedKeys(changed)
+		klog.InfoS("antrea-agent configuration changes require restarting antrea-agent to take effect", "options", restartRequired)
+		w.recorder.Eventf(w.nodeRef, corev1.EventTypeWarning, ReasonConfigChangeRejected, "The changes of antrea-agent configuration options require restarting antrea-agent to take effect: %s", strings.Join(restartRequired, ", "))
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fieldName returns the name of the struct field in the configuration file.
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"yaml", "json"} {
+		if name, _, _ := strings.Cut(field.Tag.Get(key), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return strings.ToLower(field.Name[:1]) + field.Name[1:]
+}
+
+// diffConfig compares the fields of two configuration structs recursively, and stores the paths of the fields which
+// have different values in changed.
+func diffConfig(oldValue, newValue reflect.Value, prefix string, changed map[string]bool) {
+	for i := 0; i < oldValue.NumField(); i++ {
+		field := oldValue.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		path := prefix + fieldName(field)
+		if field.Type.Kind() == reflect.Struct {
+			diffConfig(oldValue.Field(i), newValue.Field(i), path+".", changed)
+			continue
+		}
+		if !reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			changed[path] = true
+		}
+	}
+}
+
+// setField sets the field identified by path in dst to its value in src.
+func setField(dst, src reflect.Value, path []string) {
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		if !field.IsExported() || fieldName(field) != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			dst.Field(i).Set(src.Field(i))
+		} else {
+			setField(dst.Field(i), src.Field(i), path[1:])
+		}
+		return
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configwatcher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+	"k8s.io/client-go/tools/record"
+
+	agentconfig "antrea.io/antrea/pkg/config/agent"
+)
+
+func loadConfig(data []byte) (*agentconfig.AgentConfig, error) {
+	config := &agentconfig.AgentConfig{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, err
+	}
+	if config.PacketInRate < 0 {
+		return nil, fmt.Errorf("packetInRate must not be negative")
+	}
+	return config, nil
+}
+
+func writeConfig(t *testing.T, configFile, data string) {
+	require.NoError(t, os.WriteFile(configFile, []byte(data), 0644))
+}
+
+func receiveEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestReload(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "antrea-agent.conf")
+	writeConfig(t, configFile, `
+packetInRate: 100
+flowExporter:
+  flowPollInterval: 5s
+  activeFlowExportTimeout: 5s
+ovsBridge: br-int
+`)
+	recorder := record.NewFakeRecorder(10)
+	w, err := NewWatcher(configFile, "node1", loadConfig, recorder)
+	require.NoError(t, err)
+
+	var packetInRate int
+	var flowExporterConfig agentconfig.FlowExporterConfig
+	var flowExporterErr error
+	w.AddOption(Option{
+		Paths: []string{"packetInRate"},
+		Apply: func(config *agentconfig.AgentConfig) error {
+			packetInRate = config.PacketInRate
+			return nil
+		},
+	})
+	w.AddOption(Option{
+		Paths: []string{"flowExporter.flowPollInterval", "flowExporter.activeFlowExportTimeout"},
+		Apply: func(config *agentconfig.AgentConfig) error {
+			if flowExporterErr != nil {
+				return flowExporterErr
+			}
+			flowExporterConfig = config.FlowExporter
+			return nil
+		},
+	})
+
+	// The content of the file is unchanged.
+	w.reload()
+	assert.Empty(t, receiveEvents(recorder))
+
+	writeConfig(t, configFile, `
+packetInRate: 200
+flowExporter:
+  flowPollInterval: 10s
+  activeFlowExportTimeout: 5s
+ovsBridge: br-int
+`)
+	w.reload()
+	assert.Equal(t, 200, packetInRate)
+	assert.Equal(t, "10s", flowExporterConfig.FlowPollInterval)
+	assert.Equal(t, []string{
+		"Normal ConfigChangeApplied Applied the changes of antrea-agent configuration options: flowExporter.flowPollInterval, packetInRate",
+	}, receiveEvents(recorder))
+
+	writeConfig(t, configFile, `
+packetInRate: 200
+flowExporter:
+  flowPollInterval: 10s
+  activeFlowExportTimeout: 10s
+ovsBridge: br-ext
+`)
+	flowExporterErr = fmt.Errorf("some error")
+	w.reload()
+	assert.Equal(t, []string{
+		"Warning ConfigChangeRejected Failed to apply the change of antrea-agent configuration option flowExporter.activeFlowExportTimeout: some error",
+		"Warning ConfigChangeRejected The changes of antrea-agent configuration options require restarting antrea-agent to take effect: ovsBridge",
+	}, receiveEvents(recorder))
+	assert.Equal(t, "5s", w.config.FlowExporter.ActiveFlowExportTimeout)
+	assert.Equal(t, "br-int", w.config.OVSBridge)
+
+	// The change which failed to be applied is retried after the file is updated.
+	writeConfig(t, configFile, `
+packetInRate: 200
+flowExporter:
+  flowPollInterval: 10s
+  activeFlowExportTimeout: 10s
+ovsBridge: br-int
+`)
+	flowExporterErr = nil
+	w.reload()
+	assert.Equal(t, "10s", flowExporterConfig.ActiveFlowExportTimeout)
+	assert.Equal(t, []string{
+		"Normal ConfigChangeApplied Applied the changes of antrea-agent configuration options: flowExporter.activeFlowExportTimeout",
+	}, receiveEvents(recorder))
+
+	writeConfig(t, configFile, `
+packetInRate: -1
+`)
+	w.reload()
+	assert.Equal(t, 200, packetInRate)
+	assert.Equal(t, []string{
+		"Warning ConfigChangeRejected Invalid antrea-agent configuration, ignoring the changes: packetInRate must not be negative",
+	}, receiveEvents(recorder))
+}
@@ -37,6 +37,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"antrea.io/antrea/pkg/agent"
+	"antrea.io/antrea/pkg/agent/flowscheduler"
 	"antrea.io/antrea/pkg/agent/interfacestore"
 	"antrea.io/antrea/pkg/agent/ipassigner"
 	"antrea.io/antrea/pkg/agent/memberlist"
@@ -89,6 +90,12 @@ type egressState struct {
 	ofPorts sets.Set[int32]
 	// The actual Pods of the Egress. Used to identify stale Pods when updating or deleting an Egress.
 	pods sets.Set[string]
+	// The actual destination CIDRs of the Egress, for which we have installed SNAT rules. Empty if the Egress applies
+	// to all destinations.
+	destinationCIDRs []net.IPNet
+	// The binding scope of the Egress, computed from destinationCIDRs. Used to check if the destination CIDRs change
+	// since last process.
+	scope string
 }
 
 // egressIPState keeps the actual state of an Egress IP. It's maintained separately from egressState because
@@ -105,8 +112,8 @@ type egressIPState struct {
 	ruleInstalled bool
 }
 
-// egressBinding keeps the Egresses applying to a Pod.
-// There is one effective Egress for a Pod at any given time.
+// egressBinding keeps the Egresses applying to a Pod for a given scope, i.e. a given set of destination CIDRs.
+// There is one effective Egress for a Pod and a scope at any given time.
 type egressBinding struct {
 	effectiveEgress     string
 	alternativeEgresses sets.Set[string]
@@ -132,7 +139,9 @@ type EgressController struct {
 	egressGroups      map[string]sets.Set[string]
 	egressGroupsMutex sync.RWMutex
 
-	egressBindings      map[string]*egressBinding
+	// egressBindings is keyed by Pod and then by scope. The scope of the Egresses applying to all destinations is
+	// the empty string.
+	egressBindings      map[string]map[string]*egressBinding
 	egressBindingsMutex sync.RWMutex
 
 	egressStates map[string]*egressState
@@ -147,6 +156,9 @@ type EgressController struct {
 	ipAssigner ipassigner.IPAssigner
 
 	egressIPScheduler *egressIPScheduler
+	// reconcileScheduler shares the OVS programming bandwidth with other features.
+	// It's nil if the scheduler is disabled.
+	reconcileScheduler *flowscheduler.Scheduler
 }
 
 func NewEgressController(
@@ -177,7 +189,7 @@ func NewEgressController(
 		egressGroups:         map[string]sets.Set[string]{},
 		egressStates:         map[string]*egressState{},
 		egressIPStates:       map[string]*egressIPState{},
-		egressBindings:       map[string]*egressBinding{},
+		egressBindings:       map[string]map[string]*egressBinding{},
 		localIPDetector:      ipassigner.NewLocalIPDetector(),
 		idAllocator:          newIDAllocator(minEgressMark, maxEgressMark),
 		cluster:              cluster,
@@ -232,17 +244,15 @@ func (c *EgressController) onEgressIPSchedule(egress string) {
 }
 
 // processPodUpdate will be called when CNIServer publishes a Pod update event.
-// It triggers reconciling the effective Egress of the Pod.
+// It triggers reconciling the effective Egresses of the Pod.
 func (c *EgressController) processPodUpdate(e interface{}) {
 	c.egressBindingsMutex.Lock()
 	defer c.egressBindingsMutex.Unlock()
 	podEvent := e.(types.PodUpdate)
 	pod := k8s.NamespacedName(podEvent.PodNamespace, podEvent.PodName)
-	binding, exists := c.egressBindings[pod]
-	if !exists {
-		return
+	for _, binding := range c.egressBindings[pod] {
+		c.queue.Add(binding.effectiveEgress)
 	}
-	c.queue.Add(binding.effectiveEgress)
 }
 
 // addEgress processes Egress ADD events.
@@ -304,6 +314,11 @@ func (c *EgressController) onLocalIPUpdate(ip string, added bool) {
 
 // Run will create defaultWorkers workers (go routines) which will process the Egress events from the
 // workqueue.
+// SetReconcileScheduler sets the scheduler used to share the OVS programming bandwidth with other features.
+func (c *EgressController) SetReconcileScheduler(scheduler *flowscheduler.Scheduler) {
+	c.reconcileScheduler = scheduler
+}
+
 func (c *EgressController) Run(stopCh <-chan struct{}) {
 	defer c.queue.ShutDown()
 
@@ -371,7 +386,9 @@ func (c *EgressController) processNextWorkItem() bool {
 		c.queue.Forget(obj)
 		klog.Errorf("Expected string in work queue but got %#v", obj)
 		return true
-	} else if err := c.syncEgress(key); err == nil {
+	} else if err := c.reconcileScheduler.Do(flowscheduler.FeatureEgress, func() error {
+		return c.syncEgress(key)
+	}); err == nil {
 		// If no error occurs we Forget this item so it does not get queued again until
 		// another change happens.
 		c.queue.Forget(key)
@@ -515,15 +532,21 @@ func (c *EgressController) newEgressState(egressName string, egressIP string) *e
 	return state
 }
 
-// bindPodEgress binds the Pod with the Egress and returns whether this Egress is the effective one for the Pod.
-func (c *EgressController) bindPodEgress(pod, egress string) bool {
+// bindPodEgress binds the Pod with the Egress in the given scope and returns whether this Egress is the effective one
+// for the Pod in the scope.
+func (c *EgressController) bindPodEgress(pod, scope, egress string) bool {
 	c.egressBindingsMutex.Lock()
 	defer c.egressBindingsMutex.Unlock()
 
-	binding, exists := c.egressBindings[pod]
+	bindings, exists := c.egressBindings[pod]
+	if !exists {
+		bindings = map[string]*egressBinding{}
+		c.egressBindings[pod] = bindings
+	}
+	binding, exists := bindings[scope]
 	if !exists {
 		// Promote itself as the effective Egress if there was not one.
-		c.egressBindings[pod] = &egressBinding{
+		bindings[scope] = &egressBinding{
 			effectiveEgress:     egress,
 			alternativeEgresses: sets.New[string](),
 		}
@@ -538,21 +561,25 @@ func (c *EgressController) bindPodEgress(pod, egress string) bool {
 	return false
 }
 
-// unbindPodEgress unbinds the Pod with the Egress.
-// If the unbound Egress was the effective one for the Pod and there are any alternative ones, it will return the new
-// effective Egress and true. Otherwise it return empty string and false.
-func (c *EgressController) unbindPodEgress(pod, egress string) (string, bool) {
+// unbindPodEgress unbinds the Pod with the Egress in the given scope.
+// If the unbound Egress was the effective one for the Pod in the scope and there are any alternative ones, it will
+// return the new effective Egress and true. Otherwise it return empty string and false.
+func (c *EgressController) unbindPodEgress(pod, scope, egress string) (string, bool) {
 	c.egressBindingsMutex.Lock()
 	defer c.egressBindingsMutex.Unlock()
 
 	// The binding must exist.
-	binding := c.egressBindings[pod]
+	bindings := c.egressBindings[pod]
+	binding := bindings[scope]
 	if binding.effectiveEgress == egress {
 		var popped bool
 		binding.effectiveEgress, popped = binding.alternativeEgresses.PopAny()
 		if !popped {
 			// Remove the Pod's binding if there is no alternative.
-			delete(c.egressBindings, pod)
+			delete(bindings, scope)
+			if len(bindings) == 0 {
+				delete(c.egressBindings, pod)
+			}
 			return "", false
 		}
 		return binding.effectiveEgress, true
@@ -657,6 +684,17 @@ func (c *EgressController) syncEgress(egressName string) error {
 		eState = c.newEgressState(egressName, desiredEgressIP)
 	}
 
+	// If the destination CIDRs change, uninstall all of the Egress's Pod flows first, then install them with the new
+	// destination CIDRs. The Pods are unbound from the previous scope and will be bound to the new one.
+	destinationCIDRs, scope := getEgressDestinationCIDRs(egress)
+	if eState.scope != scope {
+		if err := c.uninstallPodFlows(egressName, eState, eState.ofPorts, eState.pods); err != nil {
+			return err
+		}
+		eState.destinationCIDRs = destinationCIDRs
+		eState.scope = scope
+	}
+
 	if desiredNode == c.nodeName {
 		// Ensure the Egress IP is assigned to the system. Force advertising the IP if it was previously assigned to
 		// another Node in the Egress API. This could force refreshing other peers' neighbor cache when the Egress IP is
@@ -713,7 +751,7 @@ func (c *EgressController) syncEgress(egressName string) error {
 		stalePods.Delete(pod)
 
 		// If the Egress is not the effective one for the Pod, do nothing.
-		if !c.bindPodEgress(pod, egressName) {
+		if !c.bindPodEgress(pod, eState.scope, egressName) {
 			continue
 		}
 
@@ -731,7 +769,7 @@ func (c *EgressController) syncEgress(egressName string) error {
 			staleOFPorts.Delete(ofPort)
 			continue
 		}
-		if err := c.ofClient.InstallPodSNATFlows(uint32(ofPort), egressIP, mark); err != nil {
+		if err := c.installPodFlows(eState, uint32(ofPort), egressIP, mark); err != nil {
 			return err
 		}
 		eState.ofPorts.Insert(ofPort)
@@ -762,9 +800,22 @@ func (c *EgressController) uninstallEgress(egressName string, eState *egressStat
 	return nil
 }
 
+func (c *EgressController) installPodFlows(egressState *egressState, ofPort uint32, egressIP net.IP, mark uint32) error {
+	if len(egressState.destinationCIDRs) == 0 {
+		return c.ofClient.InstallPodSNATFlows(ofPort, egressIP, mark)
+	}
+	return c.ofClient.InstallPodDestinationSNATFlows(ofPort, egressState.destinationCIDRs, egressIP, mark)
+}
+
 func (c *EgressController) uninstallPodFlows(egressName string, egressState *egressState, ofPorts sets.Set[int32], pods sets.Set[string]) error {
 	for ofPort := range ofPorts {
-		if err := c.ofClient.UninstallPodSNATFlows(uint32(ofPort)); err != nil {
+		var err error
+		if len(egressState.destinationCIDRs) == 0 {
+			err = c.ofClient.UninstallPodSNATFlows(uint32(ofPort))
+		} else {
+			err = c.ofClient.UninstallPodDestinationSNATFlows(uint32(ofPort), egressState.destinationCIDRs)
+		}
+		if err != nil {
 			return err
 		}
 		egressState.ofPorts.Delete(ofPort)
@@ -777,7 +828,7 @@ func (c *EgressController) uninstallPodFlows(egressName string, egressState *egr
 	newEffectiveEgresses := sets.New[string]()
 	for pod := range pods {
 		delete(egressState.pods, pod)
-		newEffectiveEgress, exists := c.unbindPodEgress(pod, egressName)
+		newEffectiveEgress, exists := c.unbindPodEgress(pod, egressState.scope, egressName)
 		if exists {
 			newEffectiveEgresses.Insert(newEffectiveEgress)
 		}
@@ -945,7 +996,8 @@ func (c *EgressController) GetEgressIPByMark(mark uint32) (string, error) {
 	return "", fmt.Errorf("no EgressIP associated with mark %v", mark)
 }
 
-// GetEgress returns effective Egress and Egress IP applied on a Pod.
+// GetEgress returns effective Egress and Egress IP applied on a Pod. Only the Egresses applying to all destinations are
+// considered.
 func (c *EgressController) GetEgress(ns, podName string) (string, string, error) {
 	if c == nil {
 		return "", "", fmt.Errorf("Egress is not enabled")
@@ -954,7 +1006,7 @@ func (c *EgressController) GetEgress(ns, podName string) (string, string, error)
 	egress, exists := func() (string, bool) {
 		c.egressBindingsMutex.RLock()
 		defer c.egressBindingsMutex.RUnlock()
-		binding, exists := c.egressBindings[pod]
+		binding, exists := c.egressBindings[pod][""]
 		if !exists {
 			return "", false
 		}
@@ -970,6 +1022,28 @@ func (c *EgressController) GetEgress(ns, podName string) (string, string, error)
 	return egress, state.egressIP, nil
 }
 
+// getEgressDestinationCIDRs returns the parsed destination CIDRs of the Egress, and the scope in which the Egress is
+// bound to Pods. The scope is the sorted list of the normalized CIDRs, so that Egresses with the same destination CIDRs
+// compete for the same Pods, and it's empty if the Egress applies to all destinations. Invalid CIDRs, which should have
+// been rejected by the validating webhook, are ignored.
+func getEgressDestinationCIDRs(egress *crdv1a2.Egress) ([]net.IPNet, string) {
+	var cidrs []net.IPNet
+	cidrStrings := sets.New[string]()
+	for _, cidr := range egress.Spec.DestinationCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			klog.ErrorS(err, "Ignored invalid destination CIDR of Egress", "egress", klog.KObj(egress), "cidr", cidr)
+			continue
+		}
+		if cidrStrings.Has(ipNet.String()) {
+			continue
+		}
+		cidrStrings.Insert(ipNet.String())
+		cidrs = append(cidrs, *ipNet)
+	}
+	return cidrs, strings.Join(sets.List(cidrStrings), ",")
+}
+
 // An Egress is schedulable if its Egress IP is allocated from ExternalIPPool.
 func isEgressSchedulable(egress *crdv1a2.Egress) bool {
 	return egress.Spec.EgressIP != "" && egress.Spec.ExternalIPPool != ""
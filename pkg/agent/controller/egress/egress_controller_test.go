@@ -44,6 +44,7 @@ import (
 	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/agent/util"
 	cpv1b2 "antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	cpstreamv1alpha1 "antrea.io/antrea/pkg/apis/controlplanestream/v1alpha1"
 	crdv1a2 "antrea.io/antrea/pkg/apis/crd/v1alpha2"
 	"antrea.io/antrea/pkg/client/clientset/versioned"
 	fakeversioned "antrea.io/antrea/pkg/client/clientset/versioned/fake"
@@ -89,6 +90,10 @@ func (g *antreaClientGetter) GetAntreaClient() (versioned.Interface, error) {
 	return g.clientset, nil
 }
 
+func (g *antreaClientGetter) GetControlplaneStreamClient() (cpstreamv1alpha1.ControlplaneStreamClient, error) {
+	return nil, fmt.Errorf("controlplane stream is not supported")
+}
+
 type fakeSingleNodeCluster struct {
 	node string
 }
@@ -110,6 +115,10 @@ func (c *fakeSingleNodeCluster) SelectNodeForIP(ip, externalIPPool string, filte
 	return c.node, nil
 }
 
+func (c *fakeSingleNodeCluster) GetMaxIPsPerNode(externalIPPool string) int {
+	return 0
+}
+
 func (c *fakeSingleNodeCluster) AliveNodes() sets.Set[string] {
 	return sets.New[string](c.node)
 }
@@ -919,6 +928,75 @@ func TestSyncOverlappingEgress(t *testing.T) {
 	assert.Len(t, c.egressIPStates, 0)
 }
 
+func TestSyncEgressWithDestinationCIDRs(t *testing.T) {
+	egress1 := &crdv1a2.Egress{
+		ObjectMeta: metav1.ObjectMeta{Name: "egressA", UID: "uidA"},
+		Spec:       crdv1a2.EgressSpec{EgressIP: fakeLocalEgressIP1},
+	}
+	egressGroup1 := &cpv1b2.EgressGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "egressA", UID: "uidA"},
+		GroupMembers: []cpv1b2.GroupMember{
+			{Pod: &cpv1b2.PodReference{Name: "pod1", Namespace: "ns1"}},
+		},
+	}
+	// egress2 applies to the same Pod as egress1, but only for some destinations.
+	egress2 := &crdv1a2.Egress{
+		ObjectMeta: metav1.ObjectMeta{Name: "egressB", UID: "uidB"},
+		Spec:       crdv1a2.EgressSpec{EgressIP: fakeRemoteEgressIP1, DestinationCIDRs: []string{"10.10.0.0/16", "10.20.0.1/16"}},
+	}
+	egressGroup2 := &cpv1b2.EgressGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "egressB", UID: "uidB"},
+		GroupMembers: []cpv1b2.GroupMember{
+			{Pod: &cpv1b2.PodReference{Name: "pod1", Namespace: "ns1"}},
+		},
+	}
+	destinationCIDRs := []net.IPNet{
+		{IP: net.ParseIP("10.10.0.0").To4(), Mask: net.CIDRMask(16, 32)},
+		{IP: net.ParseIP("10.20.0.0").To4(), Mask: net.CIDRMask(16, 32)},
+	}
+	c := newFakeController(t, []runtime.Object{egress1, egress2})
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	c.crdInformerFactory.Start(stopCh)
+	c.informerFactory.Start(stopCh)
+	c.crdInformerFactory.WaitForCacheSync(stopCh)
+	c.informerFactory.WaitForCacheSync(stopCh)
+	c.addEgressGroup(egressGroup1)
+	c.addEgressGroup(egressGroup2)
+	checkQueueItemExistence(t, c.queue, egress1.Name, egress2.Name)
+
+	c.mockOFClient.EXPECT().InstallSNATMarkFlows(net.ParseIP(fakeLocalEgressIP1), uint32(1))
+	c.mockOFClient.EXPECT().InstallPodSNATFlows(uint32(1), net.ParseIP(fakeLocalEgressIP1), uint32(1))
+	c.mockRouteClient.EXPECT().AddSNATRule(net.ParseIP(fakeLocalEgressIP1), uint32(1))
+	c.mockIPAssigner.EXPECT().UnassignIP(fakeLocalEgressIP1)
+	assert.NoError(t, c.syncEgress(egress1.Name))
+
+	// egress2 doesn't compete with egress1 as it applies to different destinations, so both are effective for pod1.
+	c.mockOFClient.EXPECT().InstallPodDestinationSNATFlows(uint32(1), destinationCIDRs, net.ParseIP(fakeRemoteEgressIP1), uint32(0))
+	c.mockIPAssigner.EXPECT().UnassignIP(fakeRemoteEgressIP1)
+	assert.NoError(t, c.syncEgress(egress2.Name))
+
+	egress, egressIP, err := c.GetEgress("ns1", "pod1")
+	require.NoError(t, err)
+	assert.Equal(t, egress1.Name, egress)
+	assert.Equal(t, fakeLocalEgressIP1, egressIP)
+
+	// After deleting egress2, only its destination SNAT flows are removed and no other Egress is triggered for resync.
+	c.mockOFClient.EXPECT().UninstallPodDestinationSNATFlows(uint32(1), destinationCIDRs)
+	c.mockIPAssigner.EXPECT().UnassignIP(fakeRemoteEgressIP1)
+	c.crdClient.CrdV1alpha2().Egresses().Delete(context.TODO(), egress2.Name, metav1.DeleteOptions{})
+	assert.NoError(t, wait.Poll(time.Millisecond*100, time.Second, func() (bool, error) {
+		_, err := c.egressLister.Get(egress2.Name)
+		return err != nil, nil
+	}))
+	checkQueueItemExistence(t, c.queue, egress2.Name)
+	assert.NoError(t, c.syncEgress(egress2.Name))
+	require.Equal(t, 0, c.queue.Len())
+	assert.Equal(t, map[string]map[string]*egressBinding{
+		"ns1/pod1": {"": {effectiveEgress: egress1.Name, alternativeEgresses: sets.New[string]()}},
+	}, c.egressBindings)
+}
+
 func addPodInterface(ifaceStore interfacestore.InterfaceStore, podNamespace, podName string, ofPort int32) {
 	containerName := k8s.NamespacedName(podNamespace, podName)
 	ifaceStore.AddInterface(&interfacestore.InterfaceConfig{
@@ -329,6 +329,9 @@ func (s *egressIPScheduler) schedule() {
 	var egressesToUpdate []string
 	newResults := map[string]*scheduleResult{}
 	nodeToIPs := map[string]sets.Set[string]{}
+	// poolToNodeIPs tracks the Egress IPs assigned to each Node per ExternalIPPool, to enforce the maximum number of
+	// IPs per Node of the pools.
+	poolToNodeIPs := map[string]map[string]sets.Set[string]{}
 	egresses, _ := s.egressLister.List(labels.Everything())
 	// Sort Egresses by creation timestamp to make the result deterministic and prioritize objected created earlier
 	// when the total capacity is insufficient.
@@ -349,7 +352,20 @@ func (s *egressIPScheduler) schedule() {
 			}
 			return numIPs <= s.getMaxEgressIPsByNode(node)
 		}
-		node, err := s.cluster.SelectNodeForIP(egress.Spec.EgressIP, egress.Spec.ExternalIPPool, maxEgressIPsFilter)
+		filters := []func(string) bool{maxEgressIPsFilter}
+		if maxIPsPerNode := s.cluster.GetMaxIPsPerNode(egress.Spec.ExternalIPPool); maxIPsPerNode > 0 {
+			poolMaxIPsFilter := func(node string) bool {
+				// Count the Egress IPs of the same pool that are already assigned to this Node.
+				ipsOnNode, _ := poolToNodeIPs[egress.Spec.ExternalIPPool][node]
+				numIPs := ipsOnNode.Len()
+				if !ipsOnNode.Has(egress.Spec.EgressIP) {
+					numIPs += 1
+				}
+				return numIPs <= maxIPsPerNode
+			}
+			filters = append(filters, poolMaxIPsFilter)
+		}
+		node, err := s.cluster.SelectNodeForIP(egress.Spec.EgressIP, egress.Spec.ExternalIPPool, filters...)
 		if err != nil {
 			if err == memberlist.ErrNoNodeAvailable {
 				klog.InfoS("No Node is eligible for Egress", "egress", klog.KObj(egress))
@@ -370,6 +386,18 @@ func (s *egressIPScheduler) schedule() {
 			nodeToIPs[node] = ips
 		}
 		ips.Insert(egress.Spec.EgressIP)
+
+		nodeToPoolIPs, exists := poolToNodeIPs[egress.Spec.ExternalIPPool]
+		if !exists {
+			nodeToPoolIPs = map[string]sets.Set[string]{}
+			poolToNodeIPs[egress.Spec.ExternalIPPool] = nodeToPoolIPs
+		}
+		poolIPs, exists := nodeToPoolIPs[node]
+		if !exists {
+			poolIPs = sets.New[string]()
+			nodeToPoolIPs[node] = poolIPs
+		}
+		poolIPs.Insert(egress.Spec.EgressIP)
 	}
 
 	func() {
@@ -43,6 +43,8 @@ type fakeMemberlistCluster struct {
 	nodes         []string
 	hashMap       *consistenthash.Map
 	eventHandlers []memberlist.ClusterNodeEventHandler
+	// poolToMaxIPsPerNode is the maximum number of IPs per Node of each pool.
+	poolToMaxIPsPerNode map[string]int
 }
 
 func newFakeMemberlistCluster(nodes []string) *fakeMemberlistCluster {
@@ -67,6 +69,10 @@ func (f *fakeMemberlistCluster) AddClusterEventHandler(h memberlist.ClusterNodeE
 	f.eventHandlers = append(f.eventHandlers, h)
 }
 
+func (f *fakeMemberlistCluster) GetMaxIPsPerNode(externalIPPool string) int {
+	return f.poolToMaxIPsPerNode[externalIPPool]
+}
+
 func (f *fakeMemberlistCluster) AliveNodes() sets.Set[string] {
 	return sets.New[string](f.nodes...)
 }
@@ -103,6 +109,7 @@ func TestSchedule(t *testing.T) {
 		nodes               []string
 		maxEgressIPsPerNode int
 		nodeToMaxEgressIPs  map[string]int
+		poolToMaxIPsPerNode map[string]int
 		expectedResults     map[string]*scheduleResult
 	}{
 		{
@@ -164,6 +171,27 @@ func TestSchedule(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:                "pool specific limit",
+			nodes:               []string{"node1", "node2", "node3"},
+			maxEgressIPsPerNode: 3,
+			poolToMaxIPsPerNode: map[string]int{"pool1": 1},
+			// egressC was moved to node2 due to the pool's maximum number of IPs per Node.
+			expectedResults: map[string]*scheduleResult{
+				"egressA": {
+					node: "node1",
+					ip:   "1.1.1.1",
+				},
+				"egressB": {
+					node: "node3",
+					ip:   "1.1.1.11",
+				},
+				"egressC": {
+					node: "node2",
+					ip:   "1.1.1.21",
+				},
+			},
+		},
 		{
 			name:                "insufficient cluster capacity",
 			nodes:               []string{"node1", "node3"},
@@ -184,6 +212,7 @@ func TestSchedule(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeCluster := newFakeMemberlistCluster(tt.nodes)
+			fakeCluster.poolToMaxIPsPerNode = tt.poolToMaxIPsPerNode
 			crdClient := fakeversioned.NewSimpleClientset(egresses...)
 			crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
 			egressInformer := crdInformerFactory.Crd().V1alpha2().Egresses()
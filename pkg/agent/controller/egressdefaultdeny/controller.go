@@ -0,0 +1,219 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package egressdefaultdeny
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/openflow"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/util/channel"
+)
+
+const (
+	controllerName = "EgressDefaultDenyController"
+	// How long to wait before retrying the processing of a Namespace change.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Disable resyncing.
+	resyncPeriod time.Duration = 0
+)
+
+// Controller watches the Namespaces annotated with "egress.antrea.io/default-deny-external" and installs the flows
+// which drop the traffic from their local Pods to the external network, unless it is SNAT'd by an Egress or allowed
+// by an Antrea-native policy. It is only used when Egress default deny is enabled and doesn't apply to all Namespaces.
+type Controller struct {
+	ofClient   openflow.Client
+	ifaceStore interfacestore.InterfaceStore
+
+	namespaceInformer     cache.SharedIndexInformer
+	namespaceLister       corelisters.NamespaceLister
+	namespaceListerSynced cache.InformerSynced
+
+	queue workqueue.RateLimitingInterface
+
+	// namespaceOFPorts stores the ofPorts of the local Pods for which the default deny flows have been installed,
+	// keyed by Namespace. It is only accessed by the worker, so no lock is needed.
+	namespaceOFPorts map[string]sets.Set[int32]
+}
+
+func NewEgressDefaultDenyController(ofClient openflow.Client,
+	ifaceStore interfacestore.InterfaceStore,
+	namespaceInformer coreinformers.NamespaceInformer,
+	podUpdateSubscriber channel.Subscriber) *Controller {
+	c := &Controller{
+		ofClient:              ofClient,
+		ifaceStore:            ifaceStore,
+		namespaceInformer:     namespaceInformer.Informer(),
+		namespaceLister:       namespaceInformer.Lister(),
+		namespaceListerSynced: namespaceInformer.Informer().HasSynced,
+		queue:                 workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "egressDefaultDeny"),
+		namespaceOFPorts:      map[string]sets.Set[int32]{},
+	}
+	c.namespaceInformer.AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addNamespace,
+			UpdateFunc: c.updateNamespace,
+			DeleteFunc: c.deleteNamespace,
+		},
+		resyncPeriod,
+	)
+	// Subscribe Pod update events from CNIServer to enforce default deny as soon as the Pods are created.
+	podUpdateSubscriber.Subscribe(c.processPodUpdate)
+	return c
+}
+
+func isDefaultDenyEnabled(namespace *corev1.Namespace) bool {
+	return namespace.Annotations[types.NamespaceEgressDefaultDenyAnnotationKey] == "true"
+}
+
+func (c *Controller) addNamespace(obj interface{}) {
+	namespace := obj.(*corev1.Namespace)
+	if isDefaultDenyEnabled(namespace) {
+		klog.V(2).InfoS("Processing Namespace ADD event", "Namespace", namespace.Name)
+		c.queue.Add(namespace.Name)
+	}
+}
+
+func (c *Controller) updateNamespace(oldObj interface{}, obj interface{}) {
+	oldNamespace := oldObj.(*corev1.Namespace)
+	namespace := obj.(*corev1.Namespace)
+	if isDefaultDenyEnabled(oldNamespace) != isDefaultDenyEnabled(namespace) {
+		klog.V(2).InfoS("Processing Namespace UPDATE event", "Namespace", namespace.Name)
+		c.queue.Add(namespace.Name)
+	}
+}
+
+func (c *Controller) deleteNamespace(obj interface{}) {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Received unexpected object: %v", obj)
+			return
+		}
+		namespace, ok = deletedState.Obj.(*corev1.Namespace)
+		if !ok {
+			klog.Errorf("DeletedFinalStateUnknown contains non-Namespace object: %v", deletedState.Obj)
+			return
+		}
+	}
+	if isDefaultDenyEnabled(namespace) {
+		klog.V(2).InfoS("Processing Namespace DELETE event", "Namespace", namespace.Name)
+		c.queue.Add(namespace.Name)
+	}
+}
+
+// processPodUpdate will be called when CNIServer publishes a Pod update event. It triggers reconciling the default
+// deny flows of the Pod's Namespace.
+func (c *Controller) processPodUpdate(e interface{}) {
+	podEvent := e.(types.PodUpdate)
+	c.queue.Add(podEvent.PodNamespace)
+}
+
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting", "controllerName", controllerName)
+	defer klog.InfoS("Shutting down", "controllerName", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.namespaceListerSynced) {
+		return
+	}
+
+	// A single worker is used, as namespaceOFPorts is not protected by any lock.
+	go wait.Until(c.worker, time.Second, stopCh)
+
+	<-stopCh
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	obj, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(obj)
+
+	if key, ok := obj.(string); !ok {
+		c.queue.Forget(obj)
+		klog.Errorf("Expected string in work queue but got %#v", obj)
+		return true
+	} else if err := c.syncNamespace(key); err == nil {
+		c.queue.Forget(key)
+	} else {
+		c.queue.AddRateLimited(key)
+		klog.ErrorS(err, "Syncing Egress default deny for Namespace failed, requeue", "Namespace", key)
+	}
+	return true
+}
+
+func (c *Controller) syncNamespace(namespaceName string) error {
+	startTime := time.Now()
+	defer func() {
+		klog.V(4).InfoS("Finished syncing Egress default deny for Namespace", "Namespace", namespaceName, "durationTime", time.Since(startTime))
+	}()
+
+	desiredOFPorts := sets.New[int32]()
+	namespace, err := c.namespaceLister.Get(namespaceName)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	if namespace != nil && isDefaultDenyEnabled(namespace) {
+		for _, iface := range c.ifaceStore.GetInterfacesByType(interfacestore.ContainerInterface) {
+			if iface.PodNamespace == namespaceName && iface.OVSPortConfig != nil {
+				desiredOFPorts.Insert(iface.OFPort)
+			}
+		}
+	}
+
+	installedOFPorts, exists := c.namespaceOFPorts[namespaceName]
+	if !exists {
+		installedOFPorts = sets.New[int32]()
+		c.namespaceOFPorts[namespaceName] = installedOFPorts
+	}
+	for ofPort := range desiredOFPorts.Difference(installedOFPorts) {
+		if err := c.ofClient.InstallPodEgressDefaultDenyFlows(uint32(ofPort)); err != nil {
+			return fmt.Errorf("failed to install Egress default deny flows for ofPort %d: %w", ofPort, err)
+		}
+		installedOFPorts.Insert(ofPort)
+	}
+	for ofPort := range installedOFPorts.Difference(desiredOFPorts) {
+		if err := c.ofClient.UninstallPodEgressDefaultDenyFlows(uint32(ofPort)); err != nil {
+			return fmt.Errorf("failed to uninstall Egress default deny flows for ofPort %d: %w", ofPort, err)
+		}
+		installedOFPorts.Delete(ofPort)
+	}
+	if installedOFPorts.Len() == 0 {
+		delete(c.namespaceOFPorts, namespaceName)
+	}
+	return nil
+}
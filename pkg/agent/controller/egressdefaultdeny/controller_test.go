@@ -0,0 +1,127 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package egressdefaultdeny
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	openflowtest "antrea.io/antrea/pkg/agent/openflow/testing"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/util/channel"
+)
+
+type fakeController struct {
+	*Controller
+	mockOFClient    *openflowtest.MockClient
+	client          *fake.Clientset
+	informerFactory informers.SharedInformerFactory
+}
+
+func newFakeController(t *testing.T, namespaces ...*corev1.Namespace) *fakeController {
+	ctrl := gomock.NewController(t)
+	mockOFClient := openflowtest.NewMockClient(ctrl)
+	client := fake.NewSimpleClientset()
+	for _, ns := range namespaces {
+		client.CoreV1().Namespaces().Create(context.TODO(), ns, metav1.CreateOptions{})
+	}
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(newPodInterface("pod1", "ns1", 10))
+	ifaceStore.AddInterface(newPodInterface("pod2", "ns1", 11))
+	ifaceStore.AddInterface(newPodInterface("pod3", "ns2", 12))
+	podUpdateChannel := channel.NewSubscribableChannel("PodUpdate", 100)
+	c := NewEgressDefaultDenyController(mockOFClient, ifaceStore, informerFactory.Core().V1().Namespaces(), podUpdateChannel)
+	return &fakeController{
+		Controller:      c,
+		mockOFClient:    mockOFClient,
+		client:          client,
+		informerFactory: informerFactory,
+	}
+}
+
+func newNamespace(name string, defaultDeny bool) *corev1.Namespace {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if defaultDeny {
+		ns.Annotations = map[string]string{types.NamespaceEgressDefaultDenyAnnotationKey: "true"}
+	}
+	return ns
+}
+
+func newPodInterface(podName, podNamespace string, ofPort int32) *interfacestore.InterfaceConfig {
+	iface := interfacestore.NewContainerInterface(podName, podName, podName, podNamespace, nil, nil, 0)
+	iface.OVSPortConfig = &interfacestore.OVSPortConfig{OFPort: ofPort}
+	return iface
+}
+
+func TestSyncNamespace(t *testing.T) {
+	c := newFakeController(t, newNamespace("ns1", true), newNamespace("ns2", false))
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	c.informerFactory.Start(stopCh)
+	c.informerFactory.WaitForCacheSync(stopCh)
+
+	c.mockOFClient.EXPECT().InstallPodEgressDefaultDenyFlows(uint32(10))
+	c.mockOFClient.EXPECT().InstallPodEgressDefaultDenyFlows(uint32(11))
+	require.NoError(t, c.syncNamespace("ns1"))
+	require.NoError(t, c.syncNamespace("ns2"))
+	assert.Equal(t, map[string]sets.Set[int32]{"ns1": sets.New[int32](10, 11)}, c.namespaceOFPorts)
+
+	// A Pod of the Namespace is deleted.
+	c.ifaceStore.DeleteInterface(newPodInterface("pod2", "ns1", 11))
+	c.mockOFClient.EXPECT().UninstallPodEgressDefaultDenyFlows(uint32(11))
+	require.NoError(t, c.syncNamespace("ns1"))
+	assert.Equal(t, map[string]sets.Set[int32]{"ns1": sets.New[int32](10)}, c.namespaceOFPorts)
+
+	// The annotation is removed from the Namespace.
+	_, err := c.client.CoreV1().Namespaces().Update(context.TODO(), newNamespace("ns1", false), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		ns, _ := c.namespaceLister.Get("ns1")
+		return ns != nil && !isDefaultDenyEnabled(ns)
+	}, time.Second, 10*time.Millisecond)
+	c.mockOFClient.EXPECT().UninstallPodEgressDefaultDenyFlows(uint32(10))
+	require.NoError(t, c.syncNamespace("ns1"))
+	assert.Empty(t, c.namespaceOFPorts)
+}
+
+func TestNamespaceEvents(t *testing.T) {
+	c := newFakeController(t)
+	c.addNamespace(newNamespace("ns1", false))
+	assert.Equal(t, 0, c.queue.Len())
+	c.addNamespace(newNamespace("ns1", true))
+	assert.Equal(t, 1, c.queue.Len())
+	c.updateNamespace(newNamespace("ns2", false), newNamespace("ns2", false))
+	assert.Equal(t, 1, c.queue.Len())
+	c.updateNamespace(newNamespace("ns2", true), newNamespace("ns2", false))
+	assert.Equal(t, 2, c.queue.Len())
+	c.deleteNamespace(newNamespace("ns3", false))
+	assert.Equal(t, 2, c.queue.Len())
+	c.deleteNamespace(newNamespace("ns3", true))
+	assert.Equal(t, 3, c.queue.Len())
+	c.processPodUpdate(types.PodUpdate{PodNamespace: "ns4", PodName: "pod1", IsAdd: true})
+	assert.Equal(t, 4, c.queue.Len())
+}
@@ -0,0 +1,206 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gatewayproxy
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/openflow"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	crdinformers "antrea.io/antrea/pkg/client/informers/externalversions/crd/v1alpha2"
+	crdlisters "antrea.io/antrea/pkg/client/listers/crd/v1alpha2"
+)
+
+const (
+	controllerName = "GatewayProxyController"
+	// How long to wait before retrying the processing of an IPPool change.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Disable resyncing.
+	resyncPeriod time.Duration = 0
+	// The flows of all IPPools are installed together, as the flows routing the traffic between subnets depend on
+	// the subnets of all IPPools with GatewayProxy enabled. A single key is used to trigger the sync.
+	syncKey = "key"
+)
+
+// Controller watches IPPools and installs the flows which proxy the ARP requests sent by local Pods for the gateways
+// of the IPPools with GatewayProxy enabled, and which route the traffic between local Pods of these IPPools in
+// different VLANs in OVS. It is only used in bridging mode.
+type Controller struct {
+	ofClient openflow.Client
+
+	ipPoolInformer     cache.SharedIndexInformer
+	ipPoolLister       crdlisters.IPPoolLister
+	ipPoolListerSynced cache.InformerSynced
+
+	queue workqueue.RateLimitingInterface
+}
+
+func NewGatewayProxyController(ofClient openflow.Client, ipPoolInformer crdinformers.IPPoolInformer) *Controller {
+	c := &Controller{
+		ofClient:           ofClient,
+		ipPoolInformer:     ipPoolInformer.Informer(),
+		ipPoolLister:       ipPoolInformer.Lister(),
+		ipPoolListerSynced: ipPoolInformer.Informer().HasSynced,
+		queue:              workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "gatewayProxy"),
+	}
+	c.ipPoolInformer.AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addIPPool,
+			UpdateFunc: c.updateIPPool,
+			DeleteFunc: c.deleteIPPool,
+		},
+		resyncPeriod,
+	)
+	return c
+}
+
+func (c *Controller) addIPPool(obj interface{}) {
+	ipPool := obj.(*v1alpha2.IPPool)
+	if ipPool.Spec.GatewayProxy {
+		klog.V(2).InfoS("Processing IPPool ADD event", "IPPool", ipPool.Name)
+		c.queue.Add(syncKey)
+	}
+}
+
+func (c *Controller) updateIPPool(oldObj interface{}, obj interface{}) {
+	oldIPPool := oldObj.(*v1alpha2.IPPool)
+	ipPool := obj.(*v1alpha2.IPPool)
+	// IPRanges can only be added, so the flows need to be synced if GatewayProxy is enabled and IPRanges are added,
+	// or GatewayProxy is toggled.
+	if oldIPPool.Spec.GatewayProxy != ipPool.Spec.GatewayProxy ||
+		(ipPool.Spec.GatewayProxy && len(oldIPPool.Spec.IPRanges) != len(ipPool.Spec.IPRanges)) {
+		klog.V(2).InfoS("Processing IPPool UPDATE event", "IPPool", ipPool.Name)
+		c.queue.Add(syncKey)
+	}
+}
+
+func (c *Controller) deleteIPPool(obj interface{}) {
+	ipPool, ok := obj.(*v1alpha2.IPPool)
+	if !ok {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Received unexpected object: %v", obj)
+			return
+		}
+		ipPool, ok = deletedState.Obj.(*v1alpha2.IPPool)
+		if !ok {
+			klog.Errorf("DeletedFinalStateUnknown contains non-IPPool object: %v", deletedState.Obj)
+			return
+		}
+	}
+	if ipPool.Spec.GatewayProxy {
+		klog.V(2).InfoS("Processing IPPool DELETE event", "IPPool", ipPool.Name)
+		c.queue.Add(syncKey)
+	}
+}
+
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting", "controllerName", controllerName)
+	defer klog.InfoS("Shutting down", "controllerName", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.ipPoolListerSynced) {
+		return
+	}
+
+	go wait.Until(c.worker, time.Second, stopCh)
+
+	<-stopCh
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	obj, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(obj)
+
+	if err := c.syncGatewayProxy(); err == nil {
+		c.queue.Forget(obj)
+	} else {
+		c.queue.AddRateLimited(obj)
+		klog.ErrorS(err, "Syncing gateway proxy failed, requeue")
+	}
+	return true
+}
+
+// getGatewayProxySubnets returns the subnets of all the IPPools with GatewayProxy enabled. The subnets are sorted, so
+// that the flows are generated deterministically.
+func (c *Controller) getGatewayProxySubnets() ([]types.GatewayProxySubnet, error) {
+	ipPools, err := c.ipPoolLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	subnetMap := map[string]types.GatewayProxySubnet{}
+	for _, ipPool := range ipPools {
+		if !ipPool.Spec.GatewayProxy || ipPool.Spec.IPVersion != v1alpha2.IPv4 {
+			continue
+		}
+		for _, ipRange := range ipPool.Spec.IPRanges {
+			gateway := net.ParseIP(ipRange.Gateway).To4()
+			if gateway == nil {
+				klog.InfoS("Ignored IPRange with invalid gateway", "IPPool", ipPool.Name, "gateway", ipRange.Gateway)
+				continue
+			}
+			subnet := &net.IPNet{IP: gateway.Mask(net.CIDRMask(int(ipRange.PrefixLength), 32)), Mask: net.CIDRMask(int(ipRange.PrefixLength), 32)}
+			// Multiple IPRanges can share the same subnet.
+			key := fmt.Sprintf("%s/%d", subnet.String(), ipRange.VLAN)
+			subnetMap[key] = types.GatewayProxySubnet{Gateway: gateway, Subnet: subnet, VLANID: ipRange.VLAN}
+		}
+	}
+	keys := make([]string, 0, len(subnetMap))
+	for key := range subnetMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	subnets := make([]types.GatewayProxySubnet, 0, len(keys))
+	for _, key := range keys {
+		subnets = append(subnets, subnetMap[key])
+	}
+	return subnets, nil
+}
+
+func (c *Controller) syncGatewayProxy() error {
+	startTime := time.Now()
+	defer func() {
+		klog.V(4).InfoS("Finished syncing gateway proxy", "durationTime", time.Since(startTime))
+	}()
+
+	subnets, err := c.getGatewayProxySubnets()
+	if err != nil {
+		return err
+	}
+	if err := c.ofClient.InstallGatewayProxyFlows(subnets); err != nil {
+		return fmt.Errorf("failed to install gateway proxy flows: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gatewayproxy
+
+import (
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+
+	openflowtest "antrea.io/antrea/pkg/agent/openflow/testing"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	fakeversioned "antrea.io/antrea/pkg/client/clientset/versioned/fake"
+	crdinformers "antrea.io/antrea/pkg/client/informers/externalversions"
+)
+
+func newIPPool(name string, ipVersion v1alpha2.IPVersion, gatewayProxy bool, ipRanges ...v1alpha2.SubnetIPRange) *v1alpha2.IPPool {
+	return &v1alpha2.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha2.IPPoolSpec{
+			IPVersion:    ipVersion,
+			IPRanges:     ipRanges,
+			GatewayProxy: gatewayProxy,
+		},
+	}
+}
+
+func newIPRange(cidr, gateway string, prefixLength int32, vlan uint16) v1alpha2.SubnetIPRange {
+	return v1alpha2.SubnetIPRange{
+		IPRange:    v1alpha2.IPRange{CIDR: cidr},
+		SubnetInfo: v1alpha2.SubnetInfo{Gateway: gateway, PrefixLength: prefixLength, VLAN: vlan},
+	}
+}
+
+func TestSyncGatewayProxy(t *testing.T) {
+	_, subnet100, _ := net.ParseCIDR("10.10.100.0/24")
+	_, subnet200, _ := net.ParseCIDR("10.10.200.0/24")
+	tests := []struct {
+		name            string
+		ipPools         []runtime.Object
+		expectedSubnets []types.GatewayProxySubnet
+	}{
+		{
+			name: "no IPPool with GatewayProxy",
+			ipPools: []runtime.Object{
+				newIPPool("pool1", v1alpha2.IPv4, false, newIPRange("10.10.100.0/26", "10.10.100.1", 24, 100)),
+			},
+			expectedSubnets: []types.GatewayProxySubnet{},
+		},
+		{
+			name: "IPPools with GatewayProxy",
+			ipPools: []runtime.Object{
+				newIPPool("pool1", v1alpha2.IPv4, true,
+					newIPRange("10.10.100.0/26", "10.10.100.1", 24, 100),
+					newIPRange("10.10.100.64/26", "10.10.100.1", 24, 100)),
+				newIPPool("pool2", v1alpha2.IPv4, true, newIPRange("10.10.200.0/26", "10.10.200.1", 24, 200)),
+				newIPPool("pool3", v1alpha2.IPv4, false, newIPRange("10.10.30.0/26", "10.10.30.1", 24, 300)),
+				newIPPool("pool4", v1alpha2.IPv6, true, newIPRange("10:2400::0/96", "10:2400::01", 64, 400)),
+			},
+			expectedSubnets: []types.GatewayProxySubnet{
+				{Gateway: net.ParseIP("10.10.100.1").To4(), Subnet: subnet100, VLANID: 100},
+				{Gateway: net.ParseIP("10.10.200.1").To4(), Subnet: subnet200, VLANID: 200},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ofClient := openflowtest.NewMockClient(ctrl)
+			crdClient := fakeversioned.NewSimpleClientset(tt.ipPools...)
+			crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+			c := NewGatewayProxyController(ofClient, crdInformerFactory.Crd().V1alpha2().IPPools())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			crdInformerFactory.Start(stopCh)
+			crdInformerFactory.WaitForCacheSync(stopCh)
+
+			ofClient.EXPECT().InstallGatewayProxyFlows(tt.expectedSubnets)
+			require.NoError(t, c.syncGatewayProxy())
+		})
+	}
+}
+
+func TestIPPoolEvents(t *testing.T) {
+	pool := newIPPool("pool1", v1alpha2.IPv4, false, newIPRange("10.10.100.0/26", "10.10.100.1", 24, 100))
+	poolWithGatewayProxy := newIPPool("pool1", v1alpha2.IPv4, true, newIPRange("10.10.100.0/26", "10.10.100.1", 24, 100))
+	poolWithNewRange := newIPPool("pool1", v1alpha2.IPv4, true,
+		newIPRange("10.10.100.0/26", "10.10.100.1", 24, 100),
+		newIPRange("10.10.200.0/26", "10.10.200.1", 24, 200))
+
+	c := &Controller{queue: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())}
+	c.addIPPool(pool)
+	assert.Equal(t, 0, c.queue.Len())
+	c.updateIPPool(pool, pool)
+	assert.Equal(t, 0, c.queue.Len())
+	c.updateIPPool(pool, poolWithGatewayProxy)
+	assert.Equal(t, 1, c.queue.Len())
+
+	c = &Controller{queue: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())}
+	c.updateIPPool(poolWithGatewayProxy, poolWithNewRange)
+	assert.Equal(t, 1, c.queue.Len())
+
+	c = &Controller{queue: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())}
+	c.deleteIPPool(pool)
+	assert.Equal(t, 0, c.queue.Len())
+	c.deleteIPPool(poolWithGatewayProxy)
+	assert.Equal(t, 1, c.queue.Len())
+}
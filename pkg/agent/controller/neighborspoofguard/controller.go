@@ -0,0 +1,228 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package neighborspoofguard
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/openflow"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/util/channel"
+)
+
+const (
+	controllerName = "NeighborSpoofGuardController"
+	// How long to wait before retrying the processing of a Namespace change.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Disable resyncing.
+	resyncPeriod time.Duration = 0
+)
+
+// Controller installs the flows which drop the ARP and NDP packets from the local Pods which don't carry the Pods' IP
+// and MAC addresses, preventing a compromised Pod from poisoning the neighbor caches of the other Pods and of the
+// Node. The Namespaces annotated with "spoofguard.antrea.io/neighbor: false" are opted out.
+type Controller struct {
+	ofClient   openflow.Client
+	ifaceStore interfacestore.InterfaceStore
+
+	namespaceInformer     cache.SharedIndexInformer
+	namespaceLister       corelisters.NamespaceLister
+	namespaceListerSynced cache.InformerSynced
+
+	queue workqueue.RateLimitingInterface
+
+	// namespacePodPorts stores the local Pods for which the spoof guard flows have been installed, keyed by Namespace
+	// and ofPort. The value identifies the addresses the flows were installed with, so that the flows are updated if
+	// the ofPort is reused by another Pod. It is only accessed by the worker, so no lock is needed.
+	namespacePodPorts map[string]map[int32]string
+}
+
+func NewNeighborSpoofGuardController(ofClient openflow.Client,
+	ifaceStore interfacestore.InterfaceStore,
+	namespaceInformer coreinformers.NamespaceInformer,
+	podUpdateSubscriber channel.Subscriber) *Controller {
+	c := &Controller{
+		ofClient:              ofClient,
+		ifaceStore:            ifaceStore,
+		namespaceInformer:     namespaceInformer.Informer(),
+		namespaceLister:       namespaceInformer.Lister(),
+		namespaceListerSynced: namespaceInformer.Informer().HasSynced,
+		queue:                 workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "neighborSpoofGuard"),
+		namespacePodPorts:     map[string]map[int32]string{},
+	}
+	c.namespaceInformer.AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addNamespace,
+			UpdateFunc: c.updateNamespace,
+			DeleteFunc: c.deleteNamespace,
+		},
+		resyncPeriod,
+	)
+	// Subscribe Pod update events from CNIServer to protect the Pods as soon as they are created.
+	podUpdateSubscriber.Subscribe(c.processPodUpdate)
+	return c
+}
+
+func isSpoofGuardEnabled(namespace *corev1.Namespace) bool {
+	return namespace.Annotations[types.NamespaceNeighborSpoofGuardAnnotationKey] != "false"
+}
+
+// podAddresses returns the string identifying the addresses which the spoof guard flows of a Pod are installed with.
+func podAddresses(iface *interfacestore.InterfaceConfig) string {
+	return fmt.Sprintf("%s/%v", iface.MAC, iface.IPs)
+}
+
+func (c *Controller) addNamespace(obj interface{}) {
+	namespace := obj.(*corev1.Namespace)
+	klog.V(2).InfoS("Processing Namespace ADD event", "Namespace", namespace.Name)
+	c.queue.Add(namespace.Name)
+}
+
+func (c *Controller) updateNamespace(oldObj interface{}, obj interface{}) {
+	oldNamespace := oldObj.(*corev1.Namespace)
+	namespace := obj.(*corev1.Namespace)
+	if isSpoofGuardEnabled(oldNamespace) != isSpoofGuardEnabled(namespace) {
+		klog.V(2).InfoS("Processing Namespace UPDATE event", "Namespace", namespace.Name)
+		c.queue.Add(namespace.Name)
+	}
+}
+
+func (c *Controller) deleteNamespace(obj interface{}) {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Received unexpected object: %v", obj)
+			return
+		}
+		namespace, ok = deletedState.Obj.(*corev1.Namespace)
+		if !ok {
+			klog.Errorf("DeletedFinalStateUnknown contains non-Namespace object: %v", deletedState.Obj)
+			return
+		}
+	}
+	klog.V(2).InfoS("Processing Namespace DELETE event", "Namespace", namespace.Name)
+	c.queue.Add(namespace.Name)
+}
+
+// processPodUpdate will be called when CNIServer publishes a Pod update event. It triggers reconciling the spoof
+// guard flows of the Pod's Namespace.
+func (c *Controller) processPodUpdate(e interface{}) {
+	podEvent := e.(types.PodUpdate)
+	c.queue.Add(podEvent.PodNamespace)
+}
+
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting", "controllerName", controllerName)
+	defer klog.InfoS("Shutting down", "controllerName", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.namespaceListerSynced) {
+		return
+	}
+
+	// A single worker is used, as namespacePodPorts is not protected by any lock.
+	go wait.Until(c.worker, time.Second, stopCh)
+
+	<-stopCh
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	obj, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(obj)
+
+	if key, ok := obj.(string); !ok {
+		c.queue.Forget(obj)
+		klog.Errorf("Expected string in work queue but got %#v", obj)
+		return true
+	} else if err := c.syncNamespace(key); err == nil {
+		c.queue.Forget(key)
+	} else {
+		c.queue.AddRateLimited(key)
+		klog.ErrorS(err, "Syncing neighbor spoof guard for Namespace failed, requeue", "Namespace", key)
+	}
+	return true
+}
+
+func (c *Controller) syncNamespace(namespaceName string) error {
+	startTime := time.Now()
+	defer func() {
+		klog.V(4).InfoS("Finished syncing neighbor spoof guard for Namespace", "Namespace", namespaceName, "durationTime", time.Since(startTime))
+	}()
+
+	desiredPorts := map[int32]*interfacestore.InterfaceConfig{}
+	namespace, err := c.namespaceLister.Get(namespaceName)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	// The Pods are protected unless their Namespace opts out explicitly.
+	if namespace == nil || isSpoofGuardEnabled(namespace) {
+		for _, iface := range c.ifaceStore.GetInterfacesByType(interfacestore.ContainerInterface) {
+			if iface.PodNamespace == namespaceName && iface.OVSPortConfig != nil {
+				desiredPorts[iface.OFPort] = iface
+			}
+		}
+	}
+
+	installedPorts, exists := c.namespacePodPorts[namespaceName]
+	if !exists {
+		installedPorts = map[int32]string{}
+		c.namespacePodPorts[namespaceName] = installedPorts
+	}
+	for ofPort, iface := range desiredPorts {
+		addresses := podAddresses(iface)
+		if installedPorts[ofPort] == addresses {
+			continue
+		}
+		if err := c.ofClient.InstallPodNeighborSpoofGuardFlows(uint32(ofPort), iface.IPs, iface.MAC); err != nil {
+			return fmt.Errorf("failed to install neighbor spoof guard flows for ofPort %d: %w", ofPort, err)
+		}
+		installedPorts[ofPort] = addresses
+	}
+	for ofPort := range installedPorts {
+		if _, exists := desiredPorts[ofPort]; exists {
+			continue
+		}
+		if err := c.ofClient.UninstallPodNeighborSpoofGuardFlows(uint32(ofPort)); err != nil {
+			return fmt.Errorf("failed to uninstall neighbor spoof guard flows for ofPort %d: %w", ofPort, err)
+		}
+		delete(installedPorts, ofPort)
+	}
+	if len(installedPorts) == 0 {
+		delete(c.namespacePodPorts, namespaceName)
+	}
+	return nil
+}
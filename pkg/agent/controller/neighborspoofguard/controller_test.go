@@ -0,0 +1,139 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package neighborspoofguard
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	openflowtest "antrea.io/antrea/pkg/agent/openflow/testing"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/util/channel"
+)
+
+var (
+	pod1 = newPodInterface("pod1", "ns1", "10.10.0.10", "00:00:10:10:00:10", 10)
+	pod2 = newPodInterface("pod2", "ns1", "10.10.0.11", "00:00:10:10:00:11", 11)
+	pod3 = newPodInterface("pod3", "ns2", "10.10.0.12", "00:00:10:10:00:12", 12)
+)
+
+type fakeController struct {
+	*Controller
+	mockOFClient    *openflowtest.MockClient
+	client          *fake.Clientset
+	informerFactory informers.SharedInformerFactory
+}
+
+func newFakeController(t *testing.T, namespaces ...*corev1.Namespace) *fakeController {
+	ctrl := gomock.NewController(t)
+	mockOFClient := openflowtest.NewMockClient(ctrl)
+	client := fake.NewSimpleClientset()
+	for _, ns := range namespaces {
+		client.CoreV1().Namespaces().Create(context.TODO(), ns, metav1.CreateOptions{})
+	}
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(pod1)
+	ifaceStore.AddInterface(pod2)
+	ifaceStore.AddInterface(pod3)
+	podUpdateChannel := channel.NewSubscribableChannel("PodUpdate", 100)
+	c := NewNeighborSpoofGuardController(mockOFClient, ifaceStore, informerFactory.Core().V1().Namespaces(), podUpdateChannel)
+	return &fakeController{
+		Controller:      c,
+		mockOFClient:    mockOFClient,
+		client:          client,
+		informerFactory: informerFactory,
+	}
+}
+
+func newNamespace(name string, optOut bool) *corev1.Namespace {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if optOut {
+		ns.Annotations = map[string]string{types.NamespaceNeighborSpoofGuardAnnotationKey: "false"}
+	}
+	return ns
+}
+
+func newPodInterface(podName, podNamespace, ip, mac string, ofPort int32) *interfacestore.InterfaceConfig {
+	podMAC, _ := net.ParseMAC(mac)
+	iface := interfacestore.NewContainerInterface(podName, podName, podName, podNamespace, podMAC, []net.IP{net.ParseIP(ip)}, 0)
+	iface.OVSPortConfig = &interfacestore.OVSPortConfig{OFPort: ofPort}
+	return iface
+}
+
+func TestSyncNamespace(t *testing.T) {
+	c := newFakeController(t, newNamespace("ns1", false), newNamespace("ns2", true))
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	c.informerFactory.Start(stopCh)
+	c.informerFactory.WaitForCacheSync(stopCh)
+
+	c.mockOFClient.EXPECT().InstallPodNeighborSpoofGuardFlows(uint32(10), pod1.IPs, pod1.MAC)
+	c.mockOFClient.EXPECT().InstallPodNeighborSpoofGuardFlows(uint32(11), pod2.IPs, pod2.MAC)
+	require.NoError(t, c.syncNamespace("ns1"))
+	require.NoError(t, c.syncNamespace("ns2"))
+	assert.Equal(t, map[string]map[int32]string{"ns1": {10: podAddresses(pod1), 11: podAddresses(pod2)}}, c.namespacePodPorts)
+
+	// Syncing again doesn't reinstall the flows.
+	require.NoError(t, c.syncNamespace("ns1"))
+
+	// A Pod of the Namespace is deleted, and its ofPort is reused by a new Pod.
+	c.ifaceStore.DeleteInterface(pod2)
+	c.mockOFClient.EXPECT().UninstallPodNeighborSpoofGuardFlows(uint32(11))
+	require.NoError(t, c.syncNamespace("ns1"))
+	assert.Equal(t, map[string]map[int32]string{"ns1": {10: podAddresses(pod1)}}, c.namespacePodPorts)
+	pod4 := newPodInterface("pod4", "ns1", "10.10.0.13", "00:00:10:10:00:13", 10)
+	c.ifaceStore.DeleteInterface(pod1)
+	c.ifaceStore.AddInterface(pod4)
+	c.mockOFClient.EXPECT().InstallPodNeighborSpoofGuardFlows(uint32(10), pod4.IPs, pod4.MAC)
+	require.NoError(t, c.syncNamespace("ns1"))
+	assert.Equal(t, map[string]map[int32]string{"ns1": {10: podAddresses(pod4)}}, c.namespacePodPorts)
+
+	// The Namespace opts out.
+	_, err := c.client.CoreV1().Namespaces().Update(context.TODO(), newNamespace("ns1", true), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		ns, _ := c.namespaceLister.Get("ns1")
+		return ns != nil && !isSpoofGuardEnabled(ns)
+	}, time.Second, 10*time.Millisecond)
+	c.mockOFClient.EXPECT().UninstallPodNeighborSpoofGuardFlows(uint32(10))
+	require.NoError(t, c.syncNamespace("ns1"))
+	assert.Empty(t, c.namespacePodPorts)
+}
+
+func TestNamespaceEvents(t *testing.T) {
+	c := newFakeController(t)
+	c.addNamespace(newNamespace("ns1", false))
+	assert.Equal(t, 1, c.queue.Len())
+	c.updateNamespace(newNamespace("ns2", false), newNamespace("ns2", false))
+	assert.Equal(t, 1, c.queue.Len())
+	c.updateNamespace(newNamespace("ns2", false), newNamespace("ns2", true))
+	assert.Equal(t, 2, c.queue.Len())
+	c.deleteNamespace(newNamespace("ns3", true))
+	assert.Equal(t, 3, c.queue.Len())
+	c.processPodUpdate(types.PodUpdate{PodNamespace: "ns4", PodName: "pod1", IsAdd: true})
+	assert.Equal(t, 4, c.queue.Len())
+}
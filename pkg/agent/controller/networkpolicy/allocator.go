@@ -27,6 +27,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 
+	"antrea.io/antrea/pkg/agent/metrics"
 	"antrea.io/antrea/pkg/agent/types"
 )
 
@@ -36,6 +37,10 @@ const (
 
 var (
 	minAsyncDeleteInterval = time.Second * 5
+	// asyncDeletePressureThreshold is the number of rules pending async deletion above which
+	// the delete interval of the rules forgotten afterwards is shortened proportionally, to
+	// bound the memory retained by the async rule cache.
+	asyncDeletePressureThreshold = 10000
 )
 
 // idAllocator provides interfaces to allocate and release uint32 IDs. It's thread-safe.
@@ -62,6 +67,10 @@ type idAllocator struct {
 	deleteQueue workqueue.DelayingInterface
 	// deleteInterval is the delay interval for deleting the rule in the asyncRuleCache.
 	deleteInterval time.Duration
+	// pendingDeletes maintains the IDs of the rules pending async deletion, and the time
+	// after which they can be deleted from the asyncRuleCache.
+	pendingDeletes map[uint32]time.Time
+	clock          clock.Clock
 }
 
 // asyncRuleCacheKeyFunc knows how to get key of a *rule.
@@ -77,6 +86,8 @@ func newIDAllocator(asyncRuleDeleteInterval time.Duration, allocatedIDs ...uint3
 		availableSet:   make(map[uint32]struct{}),
 		asyncRuleCache: cache.NewStore(asyncRuleCacheKeyFunc),
 		deleteQueue:    workqueue.NewNamedDelayingQueue(deleteQueueName),
+		pendingDeletes: make(map[uint32]time.Time),
+		clock:          clock.RealClock{},
 	}
 
 	// Set the deleteInterval.
@@ -110,6 +121,7 @@ func newIDAllocatorWithCustomClock(clock clock.WithTicker, asyncRuleDeleteInterv
 	allocator := newIDAllocator(asyncRuleDeleteInterval, allocatedIDs...)
 	// override regular delaying workqueue with one using a custom clock
 	allocator.deleteQueue = workqueue.NewDelayingQueueWithCustomClock(clock, deleteQueueName)
+	allocator.clock = clock
 	return allocator
 }
 
@@ -145,7 +157,51 @@ func (a *idAllocator) allocateForRule(rule *types.PolicyRule) error {
 
 // forgetRule adds the rule to the async delete queue with a given delay.
 func (a *idAllocator) forgetRule(ruleID uint32) {
-	a.deleteQueue.AddAfter(ruleID, a.deleteInterval)
+	a.Lock()
+	interval := a.getDeleteInterval()
+	a.pendingDeletes[ruleID] = a.clock.Now().Add(interval)
+	metrics.NetworkPolicyAsyncDeletePendingRuleCount.Set(float64(len(a.pendingDeletes)))
+	a.Unlock()
+	a.deleteQueue.AddAfter(ruleID, interval)
+}
+
+// getDeleteInterval returns the delay interval for deleting a rule forgotten now. When the
+// number of rules pending async deletion exceeds asyncDeletePressureThreshold, the interval is
+// shortened proportionally, but never below minAsyncDeleteInterval. It must be called with the
+// lock held.
+func (a *idAllocator) getDeleteInterval() time.Duration {
+	pending := len(a.pendingDeletes)
+	if pending <= asyncDeletePressureThreshold {
+		return a.deleteInterval
+	}
+	interval := time.Duration(int64(a.deleteInterval) * int64(asyncDeletePressureThreshold) / int64(pending))
+	if interval < minAsyncDeleteInterval {
+		return minAsyncDeleteInterval
+	}
+	return interval
+}
+
+// expireRules deletes all the rules pending async deletion from the asyncRuleCache and releases
+// their IDs immediately, regardless of their delete interval. It returns the number of expired
+// rules.
+func (a *idAllocator) expireRules() int {
+	a.Lock()
+	ruleIDs := make([]uint32, 0, len(a.pendingDeletes))
+	for ruleID := range a.pendingDeletes {
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	a.pendingDeletes = make(map[uint32]time.Time)
+	metrics.NetworkPolicyAsyncDeletePendingRuleCount.Set(0)
+	a.Unlock()
+
+	// The items left in deleteQueue are ignored by the worker as the rules are no longer
+	// pending deletion.
+	for _, ruleID := range ruleIDs {
+		a.deleteRule(ruleID)
+	}
+	metrics.NetworkPolicyAsyncDeleteExpiredRuleCount.Add(float64(len(ruleIDs)))
+	klog.InfoS("Expired rules pending async deletion", "count", len(ruleIDs))
+	return len(ruleIDs)
 }
 
 func (a *idAllocator) getRuleFromAsyncCache(ruleID uint32) (*types.PolicyRule, bool, error) {
@@ -176,26 +232,48 @@ func (a *idAllocator) processDeleteQueueItem() bool {
 	}
 	defer a.deleteQueue.Done(key)
 
-	rule, exists, err := a.getRuleFromAsyncCache(key.(uint32))
-	if !exists {
-		klog.Warningf("Rule with id %v is not present in the async rule cache", key.(uint32))
+	ruleID := key.(uint32)
+	a.Lock()
+	deleteTime, pending := a.pendingDeletes[ruleID]
+	if !pending {
+		// The rule has been expired by expireRules.
+		a.Unlock()
 		return true
 	}
-	if err != nil {
-		klog.Errorf("Unexpected error when trying to get rule with id %d: %v", key.(uint32), err)
+	// The rule may have been expired and its ID reused by another rule forgotten later, in
+	// which case the item was queued for the previous rule and the deletion must be delayed.
+	if remaining := deleteTime.Sub(a.clock.Now()); remaining > 0 {
+		a.Unlock()
+		a.deleteQueue.AddAfter(ruleID, remaining)
 		return true
 	}
+	delete(a.pendingDeletes, ruleID)
+	metrics.NetworkPolicyAsyncDeletePendingRuleCount.Set(float64(len(a.pendingDeletes)))
+	a.Unlock()
+
+	a.deleteRule(ruleID)
+	return true
+}
+
+// deleteRule deletes the rule from the asyncRuleCache and releases its ID.
+func (a *idAllocator) deleteRule(ruleID uint32) {
+	rule, exists, err := a.getRuleFromAsyncCache(ruleID)
+	if !exists {
+		klog.Warningf("Rule with id %v is not present in the async rule cache", ruleID)
+		return
+	}
+	if err != nil {
+		klog.Errorf("Unexpected error when trying to get rule with id %d: %v", ruleID, err)
+		return
+	}
 	if err := a.asyncRuleCache.Delete(rule); err != nil {
 		klog.Errorf("Unexpected error when trying to delete rule: %v", err)
-		return true
+		return
 	}
 
-	if err := a.release(key.(uint32)); err != nil {
-		klog.Errorf("Unexpected error when releasing id %d: %v", key.(uint32), err)
-		return true
+	if err := a.release(ruleID); err != nil {
+		klog.Errorf("Unexpected error when releasing id %d: %v", ruleID, err)
 	}
-
-	return true
 }
 
 // release releases an uint32 ID if it has been allocated before, otherwise error is returned.
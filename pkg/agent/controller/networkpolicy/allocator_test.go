@@ -255,6 +255,95 @@ func TestIdAllocatorWorker(t *testing.T) {
 	}
 }
 
+func TestIdAllocatorExpireRules(t *testing.T) {
+	newRule := func() *types.PolicyRule {
+		return &types.PolicyRule{
+			Direction: v1beta2.DirectionIn,
+			From:      []types.Address{},
+			To:        ofPortsToOFAddresses(sets.New[int32](1)),
+		}
+	}
+	startTime := time.Now()
+	fakeClock := clock.NewFakeClock(startTime)
+	minAsyncDeleteInterval = testMinAsyncDeleteInterval
+	a := newIDAllocatorWithCustomClock(fakeClock, 200*time.Millisecond)
+	rule1, rule2 := newRule(), newRule()
+	require.NoError(t, a.allocateForRule(rule1))
+	require.NoError(t, a.allocateForRule(rule2))
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go a.runWorker(stopCh)
+
+	a.forgetRule(rule1.FlowID)
+	assert.Equal(t, 1, a.expireRules())
+	_, exists, err := a.getRuleFromAsyncCache(rule1.FlowID)
+	require.NoError(t, err)
+	assert.False(t, exists, "Expired rule should not be present in asyncRuleCache")
+	_, exists, err = a.getRuleFromAsyncCache(rule2.FlowID)
+	require.NoError(t, err)
+	assert.True(t, exists, "Rule which is not forgotten should be present in asyncRuleCache")
+	assert.Equal(t, []uint32{rule1.FlowID}, a.availableSlice)
+	assert.Equal(t, 0, a.expireRules())
+
+	// The ID of the expired rule is reused by a new rule, which must not be deleted when the
+	// item queued for the expired rule is processed.
+	fakeClock.SetTime(startTime.Add(100 * time.Millisecond))
+	rule3 := newRule()
+	require.NoError(t, a.allocateForRule(rule3))
+	require.Equal(t, rule1.FlowID, rule3.FlowID)
+	a.forgetRule(rule3.FlowID)
+	ruleHasBeenDeleted := func() (bool, error) {
+		_, exists, err := a.getRuleFromAsyncCache(rule3.FlowID)
+		return !exists, err
+	}
+
+	fakeClock.SetTime(startTime.Add(210 * time.Millisecond))
+	err = wait.PollImmediate(10*time.Millisecond, 100*time.Millisecond, ruleHasBeenDeleted)
+	require.Error(t, err, "Rule was unexpectedly deleted")
+
+	fakeClock.SetTime(startTime.Add(310 * time.Millisecond))
+	err = wait.PollImmediate(10*time.Millisecond, 1*time.Second, ruleHasBeenDeleted)
+	require.NoError(t, err, "Rule was not deleted")
+}
+
+func TestIdAllocatorGetDeleteInterval(t *testing.T) {
+	defer func(threshold int) {
+		asyncDeletePressureThreshold = threshold
+	}(asyncDeletePressureThreshold)
+	asyncDeletePressureThreshold = 2
+	minAsyncDeleteInterval = testMinAsyncDeleteInterval
+	tests := []struct {
+		name             string
+		pendingDeletes   int
+		expectedInterval time.Duration
+	}{
+		{
+			name:             "below threshold",
+			pendingDeletes:   2,
+			expectedInterval: time.Second,
+		},
+		{
+			name:             "above threshold",
+			pendingDeletes:   4,
+			expectedInterval: 500 * time.Millisecond,
+		},
+		{
+			name:             "minimum interval",
+			pendingDeletes:   40,
+			expectedInterval: testMinAsyncDeleteInterval,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newIDAllocator(time.Second)
+			for i := 0; i < tt.pendingDeletes; i++ {
+				a.pendingDeletes[uint32(i+1)] = time.Now()
+			}
+			assert.Equal(t, tt.expectedInterval, a.getDeleteInterval())
+		})
+	}
+}
+
 func TestVlanIDAllocator(t *testing.T) {
 	vlanIDAllocator := newL7VlanIDAllocator()
 	ruleID1 := "rule1"
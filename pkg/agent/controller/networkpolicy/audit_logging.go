@@ -25,13 +25,18 @@ import (
 	"time"
 
 	"antrea.io/ofnet/ofctrl"
+	"golang.org/x/exp/slices"
+	"golang.org/x/time/rate"
 	"gopkg.in/natefinch/lumberjack.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
 
 	"antrea.io/antrea/pkg/agent/interfacestore"
 	"antrea.io/antrea/pkg/agent/openflow"
 	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
 	"antrea.io/antrea/pkg/util/ip"
 	"antrea.io/antrea/pkg/util/logdir"
@@ -41,8 +46,33 @@ const (
 	logfileSubdir   string = "networkpolicy"
 	logfileName     string = "np.log"
 	nullPlaceholder        = "<nil>"
+
+	// denialEventReason is the reason of the Events emitted for the connections denied by high-severity policies.
+	denialEventReason = "NetworkPolicyDenied"
+	// denialEventInterval is the minimum interval between two Events emitted for the same policy and Pod. The
+	// connections denied in between are aggregated into the next Event.
+	denialEventInterval = time.Minute
 )
 
+// AuditLoggingConfig includes the rotation settings of the audit log file.
+type AuditLoggingConfig struct {
+	// MaxSize is the maximum size in megabytes of the log file before it gets rotated.
+	MaxSize int
+	// MaxBackups is the maximum number of old log files to retain.
+	MaxBackups int
+	// MaxAge is the maximum number of days to retain old log files.
+	MaxAge int
+	// Compress determines whether the rotated log files are compressed.
+	Compress bool
+}
+
+var defaultAuditLoggingConfig = AuditLoggingConfig{
+	MaxSize:    500,  // allow max 500 megabytes for one log file
+	MaxBackups: 3,    // allow max 3 old log file backups
+	MaxAge:     28,   // allow max 28 days maintenance of old log files
+	Compress:   true, // compress the old log files for backup
+}
+
 // AntreaPolicyLogger is used for Antrea policy audit logging.
 // Includes a lumberjack logger and a map used for log deduplication.
 type AntreaPolicyLogger struct {
@@ -50,6 +80,56 @@ type AntreaPolicyLogger struct {
 	clock            clock.Clock // enable the use of a "virtual" clock for unit tests
 	anpLogger        *log.Logger
 	logDeduplication logRecordDedupMap
+	// logFile and logOutput are used to update the log file rotation settings at runtime.
+	logFile        string
+	logOutput      *lumberjack.Logger
+	logOutputMutex sync.Mutex
+	// rotationConfig is the current rotation settings, which also apply to the dedicated log files of policies.
+	rotationConfig AuditLoggingConfig
+	// policyLoggers are the loggers of the dedicated log files set with the audit-log-file annotation, keyed by file
+	// name. Protected by logOutputMutex.
+	policyLoggers map[string]*policyLogger
+	// rateLimiters are the rate limiters of the policies with the audit-log-rate-limit annotation, keyed by policy
+	// reference.
+	rateLimiters      map[string]*policyRateLimiter
+	rateLimitersMutex sync.Mutex
+	// eventRecorder is used to emit Events on the Pods for the connections denied by the policies whose severity is
+	// at least eventSeverityThreshold. No Event is emitted if it is nil.
+	eventRecorder          record.EventRecorder
+	eventSeverityThreshold int
+	// denialEvents are the aggregation records of the denial Events, keyed by policy reference and Pod.
+	denialEvents      map[string]*denialEventRecord
+	denialEventsMutex sync.Mutex
+}
+
+// policyLogger is a logger writing to the dedicated log file of one or more policies.
+type policyLogger struct {
+	logger *log.Logger
+	output *lumberjack.Logger
+}
+
+// policyRateLimiter limits the log entries of a policy, and counts the log entries dropped since the last one written.
+type policyRateLimiter struct {
+	limiter *rate.Limiter
+	dropped int64
+}
+
+// denialEventRecord records the last denial Event emitted for a policy and Pod, and counts the denied packets not
+// reported since then.
+type denialEventRecord struct {
+	lastTime   time.Time
+	suppressed int64
+}
+
+// policyLogSettings includes the audit logging settings of a policy, which are set with annotations.
+type policyLogSettings struct {
+	// logFile is the name of the dedicated log file of the policy, empty if the log entries of the policy are written
+	// to the default log file.
+	logFile string
+	// rateLimit is the maximum number of log entries written per second for the policy, 0 if there is no limit.
+	rateLimit int
+	// severity is the severity of the policy, empty if it is not set.
+	severity string
 }
 
 // logInfo will be set by retrieving info from packetin and register.
@@ -68,13 +148,20 @@ type logInfo struct {
 	destPort     string // destination port of the traffic logged
 	pktLength    string // packet length of packetin
 	protocolStr  string // protocol of the traffic logged
+
+	// The fields below are not logged.
+	npUID       string            // UID of the Network Policy, used to retrieve its audit logging settings
+	logSettings policyLogSettings // audit logging settings of the Network Policy
 }
 
 // logDedupRecord will be used as 1 sec buffer for log deduplication.
 type logDedupRecord struct {
-	count         int64            // record count of duplicate log
-	initTime      time.Time        // initial time upon receiving packet log
-	bufferTimerCh <-chan time.Time // 1 sec buffer for each log
+	count         int64             // record count of duplicate log
+	initTime      time.Time         // initial time upon receiving packet log
+	bufferTimerCh <-chan time.Time  // 1 sec buffer for each log
+	npRef         string            // Network Policy name reference of the log
+	logSettings   policyLogSettings // audit logging settings of the Network Policy
+	ob            *logInfo          // info of the first packet of the log, used to emit denial Events
 }
 
 // logRecordDedupMap includes a map of log buffers and a r/w mutex for accessing the map.
@@ -103,27 +190,149 @@ func (l *AntreaPolicyLogger) terminateLogKey(logMsg string) {
 	defer l.logDeduplication.logMutex.Unlock()
 	logRecord := l.logDeduplication.logMap[logMsg]
 	if logRecord.count == 1 {
-		l.anpLogger.Printf(logMsg)
+		l.writeLog(logRecord.npRef, logRecord.logSettings, logMsg)
 	} else {
-		l.anpLogger.Printf("%s [%d packets in %s]", logMsg, logRecord.count, time.Since(logRecord.initTime))
+		l.writeLog(logRecord.npRef, logRecord.logSettings, fmt.Sprintf("%s [%d packets in %s]", logMsg, logRecord.count, time.Since(logRecord.initTime)))
 	}
+	l.recordDenialEvent(logRecord)
 	delete(l.logDeduplication.logMap, logMsg)
 }
 
 // updateLogKey initiates record or increases the count in logDeduplication corresponding to given logMsg.
-func (l *AntreaPolicyLogger) updateLogKey(logMsg string, bufferLength time.Duration) bool {
+func (l *AntreaPolicyLogger) updateLogKey(ob *logInfo, logMsg string, bufferLength time.Duration) bool {
 	l.logDeduplication.logMutex.Lock()
 	defer l.logDeduplication.logMutex.Unlock()
 	_, exists := l.logDeduplication.logMap[logMsg]
 	if exists {
 		l.logDeduplication.logMap[logMsg].count++
 	} else {
-		record := logDedupRecord{1, l.clock.Now(), l.clock.After(bufferLength)}
+		record := logDedupRecord{1, l.clock.Now(), l.clock.After(bufferLength), ob.npRef, ob.logSettings, ob}
 		l.logDeduplication.logMap[logMsg] = &record
 	}
 	return exists
 }
 
+// writeLog writes a log entry of a policy to the log file of the policy, if it's allowed by the rate limit of the
+// policy. The number of log entries dropped by the rate limit is appended to the next log entry written.
+func (l *AntreaPolicyLogger) writeLog(npRef string, settings policyLogSettings, logMsg string) {
+	allowed, dropped := l.allowLog(npRef, settings.rateLimit)
+	if !allowed {
+		return
+	}
+	logger := l.getLogger(settings.logFile)
+	if dropped > 0 {
+		logger.Printf("%s [%d log entries dropped by rate limit]", logMsg, dropped)
+	} else {
+		logger.Print(logMsg)
+	}
+}
+
+// allowLog returns whether a log entry of the policy can be written according to the provided rate limit, and the
+// number of log entries of the policy dropped since the last one written.
+func (l *AntreaPolicyLogger) allowLog(npRef string, rateLimit int) (bool, int64) {
+	l.rateLimitersMutex.Lock()
+	defer l.rateLimitersMutex.Unlock()
+	if rateLimit <= 0 {
+		delete(l.rateLimiters, npRef)
+		return true, 0
+	}
+	rateLimiter, exists := l.rateLimiters[npRef]
+	// Re-create the rate limiter if the rate limit of the policy has been updated.
+	if !exists || rateLimiter.limiter.Burst() != rateLimit {
+		rateLimiter = &policyRateLimiter{limiter: rate.NewLimiter(rate.Limit(rateLimit), rateLimit)}
+		l.rateLimiters[npRef] = rateLimiter
+	}
+	if !rateLimiter.limiter.AllowN(l.clock.Now(), 1) {
+		rateLimiter.dropped++
+		return false, 0
+	}
+	dropped := rateLimiter.dropped
+	rateLimiter.dropped = 0
+	return true, dropped
+}
+
+// getLogger returns the logger of the provided log file, which is created in the same directory as the default log
+// file. It returns the default logger if logFile is empty or is the default log file.
+func (l *AntreaPolicyLogger) getLogger(logFile string) *log.Logger {
+	if logFile == "" || logFile == logfileName {
+		return l.anpLogger
+	}
+	l.logOutputMutex.Lock()
+	defer l.logOutputMutex.Unlock()
+	if pLogger, exists := l.policyLoggers[logFile]; exists {
+		return pLogger.logger
+	}
+	logOutput := newLogOutput(filepath.Join(filepath.Dir(l.logFile), logFile), l.rotationConfig)
+	pLogger := &policyLogger{
+		logger: log.New(logOutput, "", log.Ldate|log.Lmicroseconds),
+		output: logOutput,
+	}
+	l.policyLoggers[logFile] = pLogger
+	klog.InfoS("Created dedicated audit log file for Antrea-native policies", "logFile", logOutput.Filename)
+	return pLogger.logger
+}
+
+// auditLogSeverityLevel returns the level of the provided severity, which is its position in
+// crdv1alpha1.AuditLogSeverities starting from 1, or 0 if it is not a valid severity.
+func auditLogSeverityLevel(severity string) int {
+	return slices.Index(crdv1alpha1.AuditLogSeverities, severity) + 1
+}
+
+// setDenialEventRecorder enables emitting Events for the connections denied by the policies whose severity is at least
+// severityThreshold.
+func (l *AntreaPolicyLogger) setDenialEventRecorder(recorder record.EventRecorder, severityThreshold string) {
+	l.denialEventsMutex.Lock()
+	defer l.denialEventsMutex.Unlock()
+	l.eventRecorder = recorder
+	l.eventSeverityThreshold = auditLogSeverityLevel(severityThreshold)
+	l.denialEvents = map[string]*denialEventRecord{}
+}
+
+// recordDenialEvent emits a Warning Event on the Pod to which the policy of a deduplicated log record is applied, if
+// the connection was denied and the severity of the policy reaches the configured threshold. At most one Event is
+// emitted per policy and Pod every denialEventInterval, the packets denied in between are counted in the next Event.
+func (l *AntreaPolicyLogger) recordDenialEvent(logRecord *logDedupRecord) {
+	ob := logRecord.ob
+	if l.eventRecorder == nil || ob == nil {
+		return
+	}
+	if ob.disposition != openflow.DispositionToString[openflow.DispositionDrop] && ob.disposition != openflow.DispositionToString[openflow.DispositionRej] {
+		return
+	}
+	severity := auditLogSeverityLevel(logRecord.logSettings.severity)
+	if severity == 0 || severity < l.eventSeverityThreshold {
+		return
+	}
+	namespace, name, found := strings.Cut(ob.appliedToRef, "/")
+	if !found {
+		return
+	}
+	key := logRecord.npRef + " " + ob.appliedToRef
+
+	l.denialEventsMutex.Lock()
+	defer l.denialEventsMutex.Unlock()
+	now := l.clock.Now()
+	count := logRecord.count
+	if eventRecord, exists := l.denialEvents[key]; exists {
+		if now.Sub(eventRecord.lastTime) < denialEventInterval {
+			eventRecord.suppressed += count
+			return
+		}
+		count += eventRecord.suppressed
+	}
+	// Remove the stale records to keep the map bounded, their suppressed packets can no longer be reported.
+	for k, eventRecord := range l.denialEvents {
+		if now.Sub(eventRecord.lastTime) >= denialEventInterval {
+			delete(l.denialEvents, k)
+		}
+	}
+	l.denialEvents[key] = &denialEventRecord{lastTime: now}
+	pod := &corev1.ObjectReference{Kind: "Pod", APIVersion: "v1", Namespace: namespace, Name: name}
+	l.eventRecorder.Eventf(pod, corev1.EventTypeWarning, denialEventReason,
+		"%s connection %s:%s -> %s:%s denied (%s) by rule %s of %s with severity %s [%d packets]",
+		ob.protocolStr, ob.srcIP, ob.srcPort, ob.destIP, ob.destPort, ob.disposition, ob.ruleName, logRecord.npRef, logRecord.logSettings.severity, count)
+}
+
 func buildLogMsg(ob *logInfo) string {
 	return strings.Join([]string{
 		ob.tableName,
@@ -148,10 +357,10 @@ func (l *AntreaPolicyLogger) LogDedupPacket(ob *logInfo) {
 	// Deduplicate non-Allow packet log.
 	logMsg := buildLogMsg(ob)
 	if ob.disposition == openflow.DispositionToString[openflow.DispositionAllow] {
-		l.anpLogger.Printf(logMsg)
+		l.writeLog(ob.npRef, ob.logSettings, logMsg)
 	} else {
 		// Increase count if duplicated within 1 sec, create buffer otherwise.
-		exists := l.updateLogKey(logMsg, l.bufferLength)
+		exists := l.updateLogKey(ob, logMsg, l.bufferLength)
 		if !exists {
 			// Go routine for logging when buffer timer stops.
 			go l.logAfterTimer(logMsg)
@@ -172,24 +381,55 @@ func newAntreaPolicyLogger() (*AntreaPolicyLogger, error) {
 	}
 
 	// Use lumberjack log file rotation.
-	logOutput := &lumberjack.Logger{
-		Filename:   logFile,
-		MaxSize:    500,  // allow max 500 megabytes for one log file
-		MaxBackups: 3,    // allow max 3 old log file backups
-		MaxAge:     28,   // allow max 28 days maintenance of old log files
-		Compress:   true, // compress the old log files for backup
-	}
+	logOutput := newLogOutput(logFile, defaultAuditLoggingConfig)
 
 	antreaPolicyLogger := &AntreaPolicyLogger{
 		bufferLength:     time.Second,
 		clock:            clock.RealClock{},
 		anpLogger:        log.New(logOutput, "", log.Ldate|log.Lmicroseconds),
 		logDeduplication: logRecordDedupMap{logMap: make(map[string]*logDedupRecord)},
+		logFile:          logFile,
+		logOutput:        logOutput,
+		rotationConfig:   defaultAuditLoggingConfig,
+		policyLoggers:    map[string]*policyLogger{},
+		rateLimiters:     map[string]*policyRateLimiter{},
 	}
 	klog.InfoS("Initialized Antrea-native Policy Logger for audit logging", "logFile", logFile)
 	return antreaPolicyLogger, nil
 }
 
+func newLogOutput(logFile string, config AuditLoggingConfig) *lumberjack.Logger {
+	return &lumberjack.Logger{
+		Filename:   logFile,
+		MaxSize:    config.MaxSize,
+		MaxBackups: config.MaxBackups,
+		MaxAge:     config.MaxAge,
+		Compress:   config.Compress,
+	}
+}
+
+// updateRotationConfig replaces the lumberjack logger with a new one using the provided rotation settings. The
+// settings of a lumberjack logger cannot be changed safely while it is in use, so the old logger is closed after the
+// new one has been set as the output.
+func (l *AntreaPolicyLogger) updateRotationConfig(config AuditLoggingConfig) {
+	l.logOutputMutex.Lock()
+	defer l.logOutputMutex.Unlock()
+	oldLogOutput := l.logOutput
+	l.logOutput = newLogOutput(l.logFile, config)
+	l.anpLogger.SetOutput(l.logOutput)
+	if oldLogOutput != nil {
+		oldLogOutput.Close()
+	}
+	for _, pLogger := range l.policyLoggers {
+		oldLogOutput := pLogger.output
+		pLogger.output = newLogOutput(oldLogOutput.Filename, config)
+		pLogger.logger.SetOutput(pLogger.output)
+		oldLogOutput.Close()
+	}
+	l.rotationConfig = config
+	klog.InfoS("Updated audit log file rotation settings", "logFile", l.logFile, "maxSize", config.MaxSize, "maxBackups", config.MaxBackups, "maxAge", config.MaxAge, "compress", config.Compress)
+}
+
 // getNetworkPolicyInfo fills in tableName, npName, ofPriority, disposition of logInfo ob.
 func getNetworkPolicyInfo(pktIn *ofctrl.PacketIn, packet *binding.Packet, c *Controller, ob *logInfo) error {
 	matchers := pktIn.GetMatches()
@@ -271,6 +511,7 @@ func getNetworkPolicyInfo(pktIn *ofctrl.PacketIn, packet *binding.Packet, c *Con
 		return fmt.Errorf("networkpolicy not found for conjunction id: %v", conjID)
 	}
 	ob.npRef = npRef.ToString()
+	ob.npUID = string(npRef.UID)
 	ob.ofPriority = ofPriority
 	ob.ruleName = ruleName
 	ob.logLabel = logLabel
@@ -295,6 +536,42 @@ func getPacketInfo(packet *binding.Packet, ob *logInfo) {
 	}
 }
 
+// getPolicyLogSettings returns the audit logging settings of the Network Policy with the provided UID, which are set
+// with annotations on Antrea-native policies. The annotations are validated by the Antrea Controller, invalid values
+// are ignored.
+func (c *Controller) getPolicyLogSettings(npUID string) policyLogSettings {
+	var settings policyLogSettings
+	if npUID == "" {
+		return settings
+	}
+	policy := c.ruleCache.getNetworkPolicy(npUID)
+	if policy == nil {
+		return settings
+	}
+	if logFile, exists := policy.Annotations[crdv1alpha1.AuditLogFileAnnotationKey]; exists {
+		if logFile != "" && filepath.Base(logFile) == logFile && logFile != "." && logFile != ".." {
+			settings.logFile = logFile
+		} else {
+			klog.V(2).InfoS("Ignored invalid audit log file annotation", "policy", policy.SourceRef, "logFile", logFile)
+		}
+	}
+	if rateLimit, exists := policy.Annotations[crdv1alpha1.AuditLogRateLimitAnnotationKey]; exists {
+		if limit, err := strconv.Atoi(rateLimit); err == nil && limit > 0 {
+			settings.rateLimit = limit
+		} else {
+			klog.V(2).InfoS("Ignored invalid audit log rate limit annotation", "policy", policy.SourceRef, "rateLimit", rateLimit)
+		}
+	}
+	if severity, exists := policy.Annotations[crdv1alpha1.AuditLogSeverityAnnotationKey]; exists {
+		if auditLogSeverityLevel(severity) > 0 {
+			settings.severity = severity
+		} else {
+			klog.V(2).InfoS("Ignored invalid audit log severity annotation", "policy", policy.SourceRef, "severity", severity)
+		}
+	}
+	return settings
+}
+
 func fillLogInfoPlaceholders(logItems []*string) {
 	for i, v := range logItems {
 		if *v == "" {
@@ -319,6 +596,7 @@ func (c *Controller) logPacket(pktIn *ofctrl.PacketIn) error {
 		return fmt.Errorf("received error while retrieving NetworkPolicy info: %v", err)
 	}
 	getPacketInfo(packet, ob)
+	ob.logSettings = c.getPolicyLogSettings(ob.npUID)
 
 	// Log the ob info to corresponding file w/ deduplication.
 	c.antreaPolicyLogger.LogDedupPacket(ob)
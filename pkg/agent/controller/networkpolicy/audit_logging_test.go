@@ -20,6 +20,8 @@ import (
 	"io"
 	"log"
 	"net"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -31,6 +33,8 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 	clocktesting "k8s.io/utils/clock/testing"
 
@@ -39,6 +43,7 @@ import (
 	openflowtesting "antrea.io/antrea/pkg/agent/openflow/testing"
 	"antrea.io/antrea/pkg/agent/util"
 	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
 	"antrea.io/antrea/pkg/util/ip"
 )
@@ -87,6 +92,7 @@ func newTestAntreaPolicyLogger(bufferLength time.Duration, clock clock.Clock) (*
 		clock:            clock,
 		anpLogger:        log.New(mockAnpLogger, "", log.Ldate),
 		logDeduplication: logRecordDedupMap{logMap: make(map[string]*logDedupRecord)},
+		rateLimiters:     map[string]*policyRateLimiter{},
 	}
 	return antreaLogger, mockAnpLogger
 }
@@ -207,6 +213,220 @@ func TestRedirectPacketLog(t *testing.T) {
 	assert.Contains(t, actual, expected)
 }
 
+func TestUpdateRotationConfig(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), logfileName)
+	logOutput := newLogOutput(logFile, defaultAuditLoggingConfig)
+	antreaLogger := &AntreaPolicyLogger{
+		bufferLength:     testBufferLength,
+		clock:            clock.RealClock{},
+		anpLogger:        log.New(logOutput, "", log.Ldate),
+		logDeduplication: logRecordDedupMap{logMap: make(map[string]*logDedupRecord)},
+		logFile:          logFile,
+		logOutput:        logOutput,
+	}
+	ob, expected := newLogInfo(actionAllow)
+	antreaLogger.LogDedupPacket(ob)
+
+	config := AuditLoggingConfig{MaxSize: 100, MaxBackups: 5, MaxAge: 7, Compress: false}
+	antreaLogger.updateRotationConfig(config)
+	assert.Equal(t, newLogOutput(logFile, config), antreaLogger.logOutput)
+	antreaLogger.LogDedupPacket(ob)
+	antreaLogger.logOutput.Close()
+
+	content, err := os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(content), expected))
+}
+
+func TestPolicyLogFile(t *testing.T) {
+	logDir := t.TempDir()
+	logFile := filepath.Join(logDir, logfileName)
+	logOutput := newLogOutput(logFile, defaultAuditLoggingConfig)
+	antreaLogger := &AntreaPolicyLogger{
+		bufferLength:     testBufferLength,
+		clock:            clock.RealClock{},
+		anpLogger:        log.New(logOutput, "", log.Ldate),
+		logDeduplication: logRecordDedupMap{logMap: make(map[string]*logDedupRecord)},
+		logFile:          logFile,
+		logOutput:        logOutput,
+		rotationConfig:   defaultAuditLoggingConfig,
+		policyLoggers:    map[string]*policyLogger{},
+		rateLimiters:     map[string]*policyRateLimiter{},
+	}
+	ob, expected := newLogInfo(actionAllow)
+	ob.logSettings = policyLogSettings{logFile: "test.log"}
+	antreaLogger.LogDedupPacket(ob)
+	// The log entries of the policies without a dedicated log file are still written to the default log file.
+	defaultOb, defaultExpected := newLogInfo(actionAllow)
+	antreaLogger.LogDedupPacket(defaultOb)
+
+	config := AuditLoggingConfig{MaxSize: 100, MaxBackups: 5, MaxAge: 7, Compress: false}
+	antreaLogger.updateRotationConfig(config)
+	require.Contains(t, antreaLogger.policyLoggers, "test.log")
+	policyLogOutput := antreaLogger.policyLoggers["test.log"].output
+	assert.Equal(t, newLogOutput(filepath.Join(logDir, "test.log"), config), policyLogOutput)
+	antreaLogger.LogDedupPacket(ob)
+	antreaLogger.logOutput.Close()
+	policyLogOutput.Close()
+
+	content, err := os.ReadFile(filepath.Join(logDir, "test.log"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(content), expected))
+	content, err = os.ReadFile(logFile)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(content), defaultExpected))
+}
+
+func TestPolicyLogRateLimit(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	antreaLogger, mockAnpLogger := newTestAntreaPolicyLogger(testBufferLength, clock)
+	ob, expected := newLogInfo(actionAllow)
+	ob.logSettings = policyLogSettings{rateLimit: 2}
+
+	for i := 0; i < 5; i++ {
+		antreaLogger.LogDedupPacket(ob)
+	}
+	// Only the first 2 log entries are written, the other ones are dropped.
+	for i := 0; i < 2; i++ {
+		assert.Contains(t, <-mockAnpLogger.logged, expected)
+	}
+	assert.Empty(t, mockAnpLogger.logged)
+
+	// The log entries of other policies are not affected by the rate limit.
+	otherOb, otherExpected := newLogInfo(actionAllow)
+	otherOb.npRef = testK8sNPRef.ToString()
+	otherExpected = strings.Replace(otherExpected, testANNPRef.ToString(), testK8sNPRef.ToString(), 1)
+	antreaLogger.LogDedupPacket(otherOb)
+	assert.Contains(t, <-mockAnpLogger.logged, otherExpected)
+
+	clock.Step(time.Second)
+	antreaLogger.LogDedupPacket(ob)
+	assert.Contains(t, <-mockAnpLogger.logged, fmt.Sprintf("%s [3 log entries dropped by rate limit]", expected))
+	antreaLogger.LogDedupPacket(ob)
+	actual := <-mockAnpLogger.logged
+	assert.Contains(t, actual, expected)
+	assert.NotContains(t, actual, "dropped by rate limit")
+}
+
+func TestDenialEvent(t *testing.T) {
+	clock := clocktesting.NewFakeClock(time.Now())
+	antreaLogger, mockAnpLogger := newTestAntreaPolicyLogger(testBufferLength, clock)
+	recorder := record.NewFakeRecorder(10)
+	antreaLogger.setDenialEventRecorder(recorder, "High")
+	// logDenial logs the provided number of duplicate packets as a single deduplicated log entry.
+	logDenial := func(ob *logInfo, count int) {
+		logMsg := buildLogMsg(ob)
+		for i := 0; i < count; i++ {
+			antreaLogger.updateLogKey(ob, logMsg, testBufferLength)
+		}
+		antreaLogger.terminateLogKey(logMsg)
+		<-mockAnpLogger.logged
+	}
+	ob, _ := newLogInfo(actionDrop)
+	ob.appliedToRef = "default/pod1"
+	ob.logSettings = policyLogSettings{severity: "Critical"}
+	expected := fmt.Sprintf("Warning %s TCP connection 0.0.0.0:35402 -> 1.1.1.1:80 denied (Drop) by rule test-rule of %s with severity Critical", denialEventReason, testANNPRef.ToString())
+
+	logDenial(ob, 1)
+	assert.Equal(t, expected+" [1 packets]", <-recorder.Events)
+	// The denials within the interval are aggregated into the next Event.
+	logDenial(ob, 3)
+	logDenial(ob, 1)
+	assert.Empty(t, recorder.Events)
+	clock.Step(denialEventInterval)
+	logDenial(ob, 2)
+	assert.Equal(t, expected+" [6 packets]", <-recorder.Events)
+
+	// The denials of policies below the threshold, or not applied to a Pod, don't generate Events.
+	lowOb, _ := newLogInfo(actionDrop)
+	lowOb.appliedToRef = "default/pod2"
+	lowOb.logSettings = policyLogSettings{severity: "Medium"}
+	logDenial(lowOb, 1)
+	noPodOb, _ := newLogInfo(actionDrop)
+	noPodOb.appliedToRef = nullPlaceholder
+	noPodOb.logSettings = policyLogSettings{severity: "High"}
+	logDenial(noPodOb, 1)
+	noSeverityOb, _ := newLogInfo(actionDrop)
+	noSeverityOb.appliedToRef = "default/pod3"
+	logDenial(noSeverityOb, 1)
+	assert.Empty(t, recorder.Events)
+
+	// Events are aggregated per Pod.
+	otherPodOb, _ := newLogInfo(actionDrop)
+	otherPodOb.appliedToRef = "default/pod4"
+	otherPodOb.logSettings = policyLogSettings{severity: "High"}
+	logDenial(otherPodOb, 1)
+	assert.Contains(t, <-recorder.Events, "with severity High [1 packets]")
+}
+
+func TestGetPolicyLogSettings(t *testing.T) {
+	c := &Controller{
+		ruleCache: &ruleCache{
+			policyMap: map[string]*v1beta2.NetworkPolicy{
+				"uid1": {
+					ObjectMeta: metav1.ObjectMeta{
+						UID: "uid1",
+						Annotations: map[string]string{
+							crdv1alpha1.AuditLogFileAnnotationKey:      "test.log",
+							crdv1alpha1.AuditLogRateLimitAnnotationKey: "10",
+							crdv1alpha1.AuditLogSeverityAnnotationKey:  "High",
+						},
+					},
+				},
+				"uid2": {
+					ObjectMeta: metav1.ObjectMeta{
+						UID: "uid2",
+						Annotations: map[string]string{
+							crdv1alpha1.AuditLogFileAnnotationKey:      "../test.log",
+							crdv1alpha1.AuditLogRateLimitAnnotationKey: "-1",
+							crdv1alpha1.AuditLogSeverityAnnotationKey:  "high",
+						},
+					},
+				},
+				"uid3": {
+					ObjectMeta: metav1.ObjectMeta{UID: "uid3"},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		npUID    string
+		expected policyLogSettings
+	}{
+		{
+			name:     "valid annotations",
+			npUID:    "uid1",
+			expected: policyLogSettings{logFile: "test.log", rateLimit: 10, severity: "High"},
+		},
+		{
+			name:     "invalid annotations",
+			npUID:    "uid2",
+			expected: policyLogSettings{},
+		},
+		{
+			name:     "no annotations",
+			npUID:    "uid3",
+			expected: policyLogSettings{},
+		},
+		{
+			name:     "unknown policy",
+			npUID:    "uid4",
+			expected: policyLogSettings{},
+		},
+		{
+			name:     "K8s default deny",
+			npUID:    "",
+			expected: policyLogSettings{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, c.getPolicyLogSettings(tt.npUID))
+		})
+	}
+}
+
 func TestGetNetworkPolicyInfo(t *testing.T) {
 	prepareMockOFTablesWithCache()
 	generateMatch := func(regID int, data []byte) openflow15.MatchField {
@@ -100,6 +100,10 @@ type rule struct {
 	EnableLogging bool
 	// LogLabel is a string associated to the NetworkPolicy rule. Used for logging.
 	LogLabel string
+	// NetworkPolicy features which must be supported to enforce this rule.
+	RequiredFeatures []string
+	// RateLimit of this rule. Only set when Action is RateLimit.
+	RateLimit *v1beta.RateLimit
 }
 
 func (r *rule) Less(r2 *rule) bool {
@@ -675,21 +679,23 @@ func toRule(r *v1beta.NetworkPolicyRule, policy *v1beta.NetworkPolicy, maxPriori
 		appliedToGroups = r.AppliedToGroups
 	}
 	rule := &rule{
-		Direction:       r.Direction,
-		From:            r.From,
-		To:              r.To,
-		Services:        r.Services,
-		L7Protocols:     r.L7Protocols,
-		Action:          r.Action,
-		Priority:        r.Priority,
-		PolicyPriority:  policy.Priority,
-		TierPriority:    policy.TierPriority,
-		AppliedToGroups: appliedToGroups,
-		Name:            r.Name,
-		PolicyUID:       policy.UID,
-		SourceRef:       policy.SourceRef,
-		EnableLogging:   r.EnableLogging,
-		LogLabel:        r.LogLabel,
+		Direction:        r.Direction,
+		From:             r.From,
+		To:               r.To,
+		Services:         r.Services,
+		L7Protocols:      r.L7Protocols,
+		Action:           r.Action,
+		Priority:         r.Priority,
+		PolicyPriority:   policy.Priority,
+		TierPriority:     policy.TierPriority,
+		AppliedToGroups:  appliedToGroups,
+		Name:             r.Name,
+		PolicyUID:        policy.UID,
+		SourceRef:        policy.SourceRef,
+		EnableLogging:    r.EnableLogging,
+		LogLabel:         r.LogLabel,
+		RequiredFeatures: r.RequiredFeatures,
+		RateLimit:        r.RateLimit,
 	}
 	rule.ID = hashRule(rule)
 	rule.PolicyName = policy.Name
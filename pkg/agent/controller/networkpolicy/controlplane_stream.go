@@ -0,0 +1,75 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+	"net/http"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+
+	cpstreamv1alpha1 "antrea.io/antrea/pkg/apis/controlplanestream/v1alpha1"
+	"antrea.io/antrea/pkg/client/clientset/versioned/scheme"
+)
+
+// streamDecoder implements watch.Decoder. It decodes the events received in batches from a stream
+// of the ControlplaneStream gRPC service.
+type streamDecoder struct {
+	stream cpstreamv1alpha1.ControlplaneStream_WatchClient
+	cancel context.CancelFunc
+	// pending holds the events of the last received batch which have not been decoded yet.
+	pending []*cpstreamv1alpha1.WatchEvent
+}
+
+func (d *streamDecoder) Decode() (watch.EventType, runtime.Object, error) {
+	for len(d.pending) == 0 {
+		batch, err := d.stream.Recv()
+		if err != nil {
+			return "", nil, err
+		}
+		d.pending = batch.Events
+	}
+	event := d.pending[0]
+	d.pending = d.pending[1:]
+	obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(event.Object, nil, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	return watch.EventType(event.Type), obj, nil
+}
+
+func (d *streamDecoder) Close() {
+	d.cancel()
+}
+
+// newStreamWatch watches the given controlplane resource with the ControlplaneStream gRPC service.
+// The returned watch.Interface delivers the events one by one, like the HTTP watch API.
+func (c *Controller) newStreamWatch(resource string, options metav1.ListOptions) (watch.Interface, error) {
+	client, err := c.antreaClientProvider.GetControlplaneStreamClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.Watch(ctx, &cpstreamv1alpha1.WatchRequest{Resource: resource, FieldSelector: options.FieldSelector})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	decoder := &streamDecoder{stream: stream, cancel: cancel}
+	return watch.NewStreamWatcher(decoder, apierrors.NewClientErrorReporter(http.StatusInternalServerError, http.MethodPost, "ClientWatchDecoding")), nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	cpstreamv1alpha1 "antrea.io/antrea/pkg/apis/controlplanestream/v1alpha1"
+	"antrea.io/antrea/pkg/client/clientset/versioned/scheme"
+)
+
+type fakeStreamClientGetter struct {
+	antreaClientGetter
+	streamClient *fakeStreamClient
+}
+
+func (g *fakeStreamClientGetter) GetControlplaneStreamClient() (cpstreamv1alpha1.ControlplaneStreamClient, error) {
+	return g.streamClient, nil
+}
+
+type fakeStreamClient struct {
+	batches chan *cpstreamv1alpha1.WatchEventBatch
+	request *cpstreamv1alpha1.WatchRequest
+	ctx     context.Context
+}
+
+func (c *fakeStreamClient) Watch(ctx context.Context, in *cpstreamv1alpha1.WatchRequest, opts ...grpc.CallOption) (cpstreamv1alpha1.ControlplaneStream_WatchClient, error) {
+	c.request = in
+	c.ctx = ctx
+	return &fakeWatchClient{ctx: ctx, batches: c.batches}, nil
+}
+
+type fakeWatchClient struct {
+	grpc.ClientStream
+	ctx     context.Context
+	batches chan *cpstreamv1alpha1.WatchEventBatch
+}
+
+func (c *fakeWatchClient) Recv() (*cpstreamv1alpha1.WatchEventBatch, error) {
+	select {
+	case <-c.ctx.Done():
+		return nil, c.ctx.Err()
+	case batch, ok := <-c.batches:
+		if !ok {
+			return nil, io.EOF
+		}
+		return batch, nil
+	}
+}
+
+func newTestWatchEvent(t *testing.T, eventType watch.EventType, obj runtime.Object) *cpstreamv1alpha1.WatchEvent {
+	info, _ := runtime.SerializerInfoForMediaType(scheme.Codecs.SupportedMediaTypes(), runtime.ContentTypeProtobuf)
+	encoder := scheme.Codecs.EncoderForVersion(info.Serializer, v1beta2.SchemeGroupVersion)
+	var buf bytes.Buffer
+	require.NoError(t, encoder.Encode(obj, &buf))
+	return &cpstreamv1alpha1.WatchEvent{Type: string(eventType), Object: buf.Bytes()}
+}
+
+func TestStreamWatch(t *testing.T) {
+	streamClient := &fakeStreamClient{batches: make(chan *cpstreamv1alpha1.WatchEventBatch, 2)}
+	c := &Controller{antreaClientProvider: &fakeStreamClientGetter{streamClient: streamClient}}
+	policy := func(name string) *v1beta2.NetworkPolicy {
+		return &v1beta2.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	streamClient.batches <- &cpstreamv1alpha1.WatchEventBatch{Events: []*cpstreamv1alpha1.WatchEvent{
+		newTestWatchEvent(t, watch.Added, policy("np1")),
+		newTestWatchEvent(t, watch.Added, policy("np2")),
+		newTestWatchEvent(t, watch.Bookmark, &v1beta2.NetworkPolicy{}),
+	}}
+	streamClient.batches <- &cpstreamv1alpha1.WatchEventBatch{Events: []*cpstreamv1alpha1.WatchEvent{
+		newTestWatchEvent(t, watch.Deleted, policy("np1")),
+	}}
+
+	w, err := c.newStreamWatch("networkpolicies", metav1.ListOptions{FieldSelector: "nodeName=node1"})
+	require.NoError(t, err)
+	assert.Equal(t, "networkpolicies", streamClient.request.Resource)
+	assert.Equal(t, "nodeName=node1", streamClient.request.FieldSelector)
+
+	expectedEvents := []struct {
+		eventType watch.EventType
+		name      string
+	}{
+		{watch.Added, "np1"},
+		{watch.Added, "np2"},
+		{watch.Bookmark, ""},
+		{watch.Deleted, "np1"},
+	}
+	for _, expected := range expectedEvents {
+		select {
+		case event := <-w.ResultChan():
+			assert.Equal(t, expected.eventType, event.Type)
+			policy, ok := event.Object.(*v1beta2.NetworkPolicy)
+			require.True(t, ok, "Unexpected object type %T", event.Object)
+			assert.Equal(t, expected.name, policy.Name)
+		case <-time.After(time.Second):
+			t.Fatalf("Failed to receive event %v in time", expected)
+		}
+	}
+
+	// The end of the stream closes the result channel.
+	close(streamClient.batches)
+	select {
+	case _, ok := <-w.ResultChan():
+		assert.False(t, ok, "Result channel should be closed")
+	case <-time.After(time.Second):
+		t.Fatal("Result channel was not closed in time")
+	}
+	w.Stop()
+	assert.Error(t, streamClient.ctx.Err(), "Stream context should be canceled")
+}
@@ -34,6 +34,7 @@ import (
 
 	"antrea.io/antrea/pkg/agent/openflow"
 	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/agent/util"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
 	utilsets "antrea.io/antrea/pkg/util/sets"
 	dnsutil "antrea.io/antrea/third_party/dns"
@@ -45,6 +46,9 @@ const (
 
 	ruleRealizationTimeout = 2 * time.Second
 	dnsRequestTimeout      = 10 * time.Second
+	// nodeLocalDNSSyncInterval is the interval to detect the IPs NodeLocal DNSCache listens on, as NodeLocal DNSCache
+	// can be deployed or removed after the Agent starts.
+	nodeLocalDNSSyncInterval = time.Minute
 )
 
 // fqdnSelectorItem is a selector that selects FQDNs,
@@ -138,7 +142,7 @@ type fqdnController struct {
 	// The mapping between FQDN rule IDs and the Pod's ofPort IDs that the rule selects.
 	fqdnRuleToSelectedPods map[string]sets.Set[int32]
 
-	// Mutex for fqdnToSelectorItem, selectorItemToFQDN and selectorItemToRuleIDs.
+	// Mutex for fqdnToSelectorItem, selectorItemToFQDN, selectorItemToRuleIDs and fqdnRuleMatches.
 	fqdnSelectorMutex sync.Mutex
 	// fqdnToSelectorItem stores known FQDNSelectorItems that selects the FQDN, for each
 	// FQDN tracked by this controller.
@@ -148,9 +152,17 @@ type fqdnController struct {
 	selectorItemToFQDN map[fqdnSelectorItem]sets.Set[string]
 	// selectorItemToRuleIDs maps fqdnToSelectorItem to the rules that contains the selector.
 	selectorItemToRuleIDs map[fqdnSelectorItem]sets.Set[string]
-	ipv4Enabled           bool
-	ipv6Enabled           bool
-	gwPort                uint32
+	// fqdnRuleMatches stores the number of intercepted DNS responses matched by each FQDN
+	// of the FQDN rules, keyed by rule IDs and then by the FQDNs as specified in the rules.
+	fqdnRuleMatches map[string]map[string]int64
+	ipv4Enabled     bool
+	ipv6Enabled     bool
+	gwPort          uint32
+
+	// getNodeLocalDNSIPs returns the IPs NodeLocal DNSCache listens on. It's overridden in tests.
+	getNodeLocalDNSIPs func() ([]net.IP, error)
+	// nodeLocalDNSIPs stores the IPs of NodeLocal DNSCache for which the DNS response interception flows are installed.
+	nodeLocalDNSIPs sets.Set[string]
 }
 
 func newFQDNController(client openflow.Client, allocator *idAllocator, dnsServerOverride string, dirtyRuleHandler func(string), v4Enabled, v6Enabled bool, gwPort uint32) (*fqdnController, error) {
@@ -165,9 +177,12 @@ func newFQDNController(client openflow.Client, allocator *idAllocator, dnsServer
 		fqdnToSelectorItem:     map[string]map[fqdnSelectorItem]struct{}{},
 		selectorItemToFQDN:     map[fqdnSelectorItem]sets.Set[string]{},
 		selectorItemToRuleIDs:  map[fqdnSelectorItem]sets.Set[string]{},
+		fqdnRuleMatches:        map[string]map[string]int64{},
 		ipv4Enabled:            v4Enabled,
 		ipv6Enabled:            v6Enabled,
 		gwPort:                 gwPort,
+		getNodeLocalDNSIPs:     util.GetNodeLocalDNSIPs,
+		nodeLocalDNSIPs:        sets.New[string](),
 	}
 	if controller.ofClient != nil {
 		if err := controller.ofClient.NewDNSPacketInConjunction(dnsInterceptRuleID); err != nil {
@@ -268,6 +283,13 @@ func (f *fqdnController) addFQDNRule(ruleID string, fqdns []string, podOFAddrs s
 func (f *fqdnController) addFQDNSelector(ruleID string, fqdns []string) {
 	f.fqdnSelectorMutex.Lock()
 	defer f.fqdnSelectorMutex.Unlock()
+	if _, exists := f.fqdnRuleMatches[ruleID]; !exists {
+		matches := make(map[string]int64, len(fqdns))
+		for _, fqdn := range fqdns {
+			matches[fqdn] = 0
+		}
+		f.fqdnRuleMatches[ruleID] = matches
+	}
 	for _, fqdn := range fqdns {
 		fqdnSelectorItem := fqdnToSelectorItem(fqdn)
 		ruleIDs, exists := f.selectorItemToRuleIDs[fqdnSelectorItem]
@@ -335,6 +357,7 @@ func (f *fqdnController) deleteFQDNRule(ruleID string, fqdns []string) error {
 func (f *fqdnController) deleteFQDNSelector(ruleID string, fqdns []string) {
 	f.fqdnSelectorMutex.Lock()
 	defer f.fqdnSelectorMutex.Unlock()
+	delete(f.fqdnRuleMatches, ruleID)
 	for _, fqdn := range fqdns {
 		fqdnSelectorItem := fqdnToSelectorItem(fqdn)
 		ruleIDs, exists := f.selectorItemToRuleIDs[fqdnSelectorItem]
@@ -458,10 +481,99 @@ func (f *fqdnController) onDNSResponse(
 			responseIPs:    responseIPs,
 		}
 		f.dnsQueryQueue.AddAfter(fqdn, recordTTL.Sub(time.Now()))
+		// Only the DNS responses intercepted for the Pods are counted, the responses of the
+		// queries initiated by the fqdnController itself are not.
+		if waitCh != nil {
+			f.countFQDNRuleMatches(fqdn)
+		}
 	}
 	f.syncDirtyRules(fqdn, waitCh, addressUpdate)
 }
 
+// countFQDNRuleMatches increments the match counts of the FQDNs of the rules selecting the
+// provided FQDN. It must be called with fqdnSelectorMutex held.
+func (f *fqdnController) countFQDNRuleMatches(fqdn string) {
+	for selectorItem := range f.fqdnToSelectorItem[fqdn] {
+		for ruleID := range f.selectorItemToRuleIDs[selectorItem] {
+			matches := f.fqdnRuleMatches[ruleID]
+			for ruleFQDN := range matches {
+				if fqdnToSelectorItem(ruleFQDN) == selectorItem {
+					matches[ruleFQDN]++
+				}
+			}
+		}
+	}
+}
+
+// getFQDNRuleMatches returns a copy of the match counts of the FQDNs of the FQDN rules, keyed
+// by rule IDs. The counts are cumulative since the rules were added.
+func (f *fqdnController) getFQDNRuleMatches() map[string]map[string]int64 {
+	f.fqdnSelectorMutex.Lock()
+	defer f.fqdnSelectorMutex.Unlock()
+	ruleMatches := make(map[string]map[string]int64, len(f.fqdnRuleMatches))
+	for ruleID, matches := range f.fqdnRuleMatches {
+		matchesCopy := make(map[string]int64, len(matches))
+		for fqdn, count := range matches {
+			matchesCopy[fqdn] = count
+		}
+		ruleMatches[ruleID] = matchesCopy
+	}
+	return ruleMatches
+}
+
+// getDNSCacheEntries returns the cached name resolution results of the FQDNs matching the
+// provided domain, which can be an exact name or a wildcard pattern. All cached FQDNs are
+// returned if domain is empty.
+func (f *fqdnController) getDNSCacheEntries(domain string) []types.DNSCacheEntry {
+	var filter *fqdnSelectorItem
+	if domain != "" {
+		selectorItem := fqdnToSelectorItem(domain)
+		filter = &selectorItem
+	}
+	f.fqdnSelectorMutex.Lock()
+	defer f.fqdnSelectorMutex.Unlock()
+	entries := make([]types.DNSCacheEntry, 0, len(f.dnsEntryCache))
+	for fqdn, meta := range f.dnsEntryCache {
+		if filter != nil && !filter.matches(fqdn) {
+			continue
+		}
+		ruleIDs := sets.New[string]()
+		for selectorItem := range f.fqdnToSelectorItem[fqdn] {
+			ruleIDs = ruleIDs.Union(f.selectorItemToRuleIDs[selectorItem])
+		}
+		ips := make([]net.IP, 0, len(meta.responseIPs))
+		for _, ip := range meta.responseIPs {
+			ips = append(ips, ip)
+		}
+		entries = append(entries, types.DNSCacheEntry{
+			FQDN:           fqdn,
+			IPAddresses:    ips,
+			ExpirationTime: meta.expirationTime,
+			RuleIDs:        sets.List(ruleIDs),
+		})
+	}
+	return entries
+}
+
+// flushDNSCacheEntry expires the cached name resolution results of a FQDN and triggers a DNS
+// query for it immediately. The cached IP addresses are kept until the DNS response is received,
+// so that the realized rules are not disrupted, after which the ones not seen in the response are
+// removed.
+func (f *fqdnController) flushDNSCacheEntry(fqdn string) error {
+	f.fqdnSelectorMutex.Lock()
+	defer f.fqdnSelectorMutex.Unlock()
+	meta, exists := f.dnsEntryCache[fqdn]
+	if !exists {
+		return fmt.Errorf("FQDN %s is not in the DNS cache", fqdn)
+	}
+	// Use a time in the past as the IP addresses are only considered expired after their
+	// expirationTime.
+	meta.expirationTime = time.Now().Add(-time.Second)
+	f.dnsEntryCache[fqdn] = meta
+	f.dnsQueryQueue.Add(fqdn)
+	return nil
+}
+
 // onDNSResponseMsg handles a DNS response message intercepted.
 func (f *fqdnController) onDNSResponseMsg(dnsMsg *dns.Msg, lookupTime time.Time, waitCh chan error) {
 	fqdn, responseIPs, lowestTTL, err := f.parseDNSResponse(dnsMsg)
@@ -579,6 +691,35 @@ func (f *fqdnController) runRuleSyncTracker(stopCh <-chan struct{}) {
 	f.ruleSyncTracker.Run(stopCh)
 }
 
+// syncNodeLocalDNSIPs detects the IPs NodeLocal DNSCache listens on, and makes sure the DNS responses sent from these
+// IPs are intercepted. When NodeLocal DNSCache is deployed, the DNS responses to Pods are sent by the Node itself,
+// typically from a link-local IP (e.g. 169.254.20.10), and would not be intercepted otherwise.
+func (f *fqdnController) syncNodeLocalDNSIPs() {
+	ips, err := f.getNodeLocalDNSIPs()
+	if err != nil {
+		klog.ErrorS(err, "Failed to get the IPs of NodeLocal DNSCache")
+		return
+	}
+	var dnsIPs []net.IP
+	dnsIPSet := sets.New[string]()
+	for _, ip := range ips {
+		if (ip.To4() != nil && !f.ipv4Enabled) || (ip.To4() == nil && !f.ipv6Enabled) {
+			continue
+		}
+		dnsIPs = append(dnsIPs, ip)
+		dnsIPSet.Insert(ip.String())
+	}
+	if dnsIPSet.Equal(f.nodeLocalDNSIPs) {
+		return
+	}
+	if err := f.ofClient.InstallNodeLocalDNSInterceptFlows(dnsIPs); err != nil {
+		klog.ErrorS(err, "Failed to install flows to intercept DNS responses from NodeLocal DNSCache", "dnsIPs", dnsIPs)
+		return
+	}
+	klog.InfoS("Updated DNS response interception for NodeLocal DNSCache", "dnsIPs", sets.List(dnsIPSet))
+	f.nodeLocalDNSIPs = dnsIPSet
+}
+
 // parseDNSResponse returns the FQDN, IP query result and lowest applicable TTL of a DNS response.
 func (f *fqdnController) parseDNSResponse(msg *dns.Msg) (string, map[string]net.IP, uint32, error) {
 	if len(msg.Question) == 0 {
@@ -27,6 +27,7 @@ import (
 
 	"antrea.io/antrea/pkg/agent/config"
 	openflowtest "antrea.io/antrea/pkg/agent/openflow/testing"
+	"antrea.io/antrea/pkg/agent/types"
 )
 
 func newMockFQDNController(t *testing.T, controller *gomock.Controller, dnsServer *string) (*fqdnController, *openflowtest.MockClient) {
@@ -304,6 +305,34 @@ func TestLookupIPFallback(t *testing.T) {
 	require.NoError(t, err, "Error when resolving name")
 }
 
+func TestSyncNodeLocalDNSIPs(t *testing.T) {
+	controller := gomock.NewController(t)
+	f, c := newMockFQDNController(t, controller, nil)
+	var nodeLocalDNSIPs []net.IP
+	f.getNodeLocalDNSIPs = func() ([]net.IP, error) {
+		return nodeLocalDNSIPs, nil
+	}
+
+	// No flows should be installed when NodeLocal DNSCache is not deployed.
+	f.syncNodeLocalDNSIPs()
+	assert.Empty(t, f.nodeLocalDNSIPs)
+
+	// IPv6 is not enabled, the IPv6 address should be ignored.
+	nodeLocalDNSIPs = []net.IP{net.ParseIP("169.254.20.10"), net.ParseIP("10.96.0.10"), net.ParseIP("fd00::20:10")}
+	c.EXPECT().InstallNodeLocalDNSInterceptFlows([]net.IP{nodeLocalDNSIPs[0], nodeLocalDNSIPs[1]}).Return(nil).Times(1)
+	f.syncNodeLocalDNSIPs()
+	assert.Equal(t, sets.New[string]("169.254.20.10", "10.96.0.10"), f.nodeLocalDNSIPs)
+
+	// Flows should not be updated if the IPs don't change.
+	f.syncNodeLocalDNSIPs()
+
+	// Flows should be removed when NodeLocal DNSCache is removed.
+	nodeLocalDNSIPs = nil
+	c.EXPECT().InstallNodeLocalDNSInterceptFlows(nil).Return(nil).Times(1)
+	f.syncNodeLocalDNSIPs()
+	assert.Empty(t, f.nodeLocalDNSIPs)
+}
+
 func TestString(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -384,3 +413,139 @@ func TestGetIPsForFQDNSelectors(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDNSCacheEntries(t *testing.T) {
+	expirationTime := time.Now().Add(time.Minute)
+	selectorItem1 := fqdnSelectorItem{
+		matchName: "test.antrea.io",
+	}
+	selectorItem2 := fqdnSelectorItem{
+		matchRegex: "^.*antrea[.]io$",
+	}
+	tests := []struct {
+		name            string
+		domain          string
+		expectedEntries []types.DNSCacheEntry
+	}{
+		{
+			name: "all entries",
+			expectedEntries: []types.DNSCacheEntry{
+				{
+					FQDN:           "test.antrea.io",
+					IPAddresses:    []net.IP{net.ParseIP("127.0.0.1")},
+					ExpirationTime: expirationTime,
+					RuleIDs:        []string{"rule1", "rule2"},
+				},
+				{
+					FQDN:           "www.antrea.io",
+					IPAddresses:    []net.IP{net.ParseIP("127.0.0.2")},
+					ExpirationTime: expirationTime,
+					RuleIDs:        []string{"rule2"},
+				},
+			},
+		},
+		{
+			name:   "exact name",
+			domain: "www.antrea.io",
+			expectedEntries: []types.DNSCacheEntry{
+				{
+					FQDN:           "www.antrea.io",
+					IPAddresses:    []net.IP{net.ParseIP("127.0.0.2")},
+					ExpirationTime: expirationTime,
+					RuleIDs:        []string{"rule2"},
+				},
+			},
+		},
+		{
+			name:   "wildcard pattern",
+			domain: "test.*",
+			expectedEntries: []types.DNSCacheEntry{
+				{
+					FQDN:           "test.antrea.io",
+					IPAddresses:    []net.IP{net.ParseIP("127.0.0.1")},
+					ExpirationTime: expirationTime,
+					RuleIDs:        []string{"rule1", "rule2"},
+				},
+			},
+		},
+		{
+			name:            "no match",
+			domain:          "www.example.com",
+			expectedEntries: []types.DNSCacheEntry{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			f, _ := newMockFQDNController(t, controller, nil)
+			f.dnsEntryCache = map[string]dnsMeta{
+				"test.antrea.io": {
+					expirationTime: expirationTime,
+					responseIPs:    map[string]net.IP{"127.0.0.1": net.ParseIP("127.0.0.1")},
+				},
+				"www.antrea.io": {
+					expirationTime: expirationTime,
+					responseIPs:    map[string]net.IP{"127.0.0.2": net.ParseIP("127.0.0.2")},
+				},
+			}
+			f.fqdnToSelectorItem = map[string]map[fqdnSelectorItem]struct{}{
+				"test.antrea.io": {selectorItem1: struct{}{}, selectorItem2: struct{}{}},
+				"www.antrea.io":  {selectorItem2: struct{}{}},
+			}
+			f.selectorItemToRuleIDs = map[fqdnSelectorItem]sets.Set[string]{
+				selectorItem1: sets.New[string]("rule1"),
+				selectorItem2: sets.New[string]("rule2"),
+			}
+			assert.ElementsMatch(t, tc.expectedEntries, f.getDNSCacheEntries(tc.domain))
+		})
+	}
+}
+
+func TestFlushDNSCacheEntry(t *testing.T) {
+	controller := gomock.NewController(t)
+	f, _ := newMockFQDNController(t, controller, nil)
+	f.dnsEntryCache = map[string]dnsMeta{
+		"test.antrea.io": {
+			expirationTime: time.Now().Add(time.Minute),
+			responseIPs:    map[string]net.IP{"127.0.0.1": net.ParseIP("127.0.0.1")},
+		},
+	}
+
+	assert.EqualError(t, f.flushDNSCacheEntry("www.antrea.io"), "FQDN www.antrea.io is not in the DNS cache")
+	assert.Equal(t, 0, f.dnsQueryQueue.Len())
+
+	require.NoError(t, f.flushDNSCacheEntry("test.antrea.io"))
+	meta := f.dnsEntryCache["test.antrea.io"]
+	assert.True(t, meta.expirationTime.Before(time.Now()))
+	assert.Equal(t, map[string]net.IP{"127.0.0.1": net.ParseIP("127.0.0.1")}, meta.responseIPs, "cached IPs should be kept until the FQDN is resolved again")
+	assert.Equal(t, 1, f.dnsQueryQueue.Len())
+	item, _ := f.dnsQueryQueue.Get()
+	assert.Equal(t, "test.antrea.io", item)
+}
+
+func TestGetFQDNRuleMatches(t *testing.T) {
+	controller := gomock.NewController(t)
+	f, _ := newMockFQDNController(t, controller, nil)
+	f.addFQDNSelector("mockRule1", []string{"*antrea.io", "www.example.com"})
+	f.addFQDNSelector("mockRule2", []string{"test.antrea.io"})
+	responseIPs := map[string]net.IP{"127.0.0.1": net.ParseIP("127.0.0.1")}
+
+	// DNS responses intercepted for the Pods.
+	f.onDNSResponse("test.antrea.io", responseIPs, 60, time.Now(), make(chan error, 1))
+	f.onDNSResponse("test.antrea.io", responseIPs, 60, time.Now(), make(chan error, 1))
+	f.onDNSResponse("www.example.com", responseIPs, 60, time.Now(), make(chan error, 1))
+	// DNS responses of the queries initiated by the fqdnController are not counted.
+	f.onDNSResponse("test.antrea.io", responseIPs, 60, time.Now(), nil)
+	// DNS responses not matching any rule are not counted.
+	f.onDNSResponse("www.antrea.com", responseIPs, 60, time.Now(), make(chan error, 1))
+
+	expectedMatches := map[string]map[string]int64{
+		"mockRule1": {"*antrea.io": 2, "www.example.com": 1},
+		"mockRule2": {"test.antrea.io": 2},
+	}
+	assert.Equal(t, expectedMatches, f.getFQDNRuleMatches())
+
+	f.deleteFQDNSelector("mockRule2", []string{"test.antrea.io"})
+	delete(expectedMatches, "mockRule2")
+	assert.Equal(t, expectedMatches, f.getFQDNRuleMatches())
+}
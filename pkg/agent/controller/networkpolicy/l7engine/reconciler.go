@@ -30,7 +30,9 @@ import (
 	"k8s.io/klog/v2"
 
 	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/metrics"
 	v1beta "antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
 )
 
 const (
@@ -53,6 +55,30 @@ type scCmdRet struct {
 	Return  string `json:"return"`
 }
 
+// rulesetStats is an entry of the message returned by the Suricata command "ruleset-stats", there is one entry per
+// detect engine. The ID of the detect engine of a tenant is the tenant ID.
+type rulesetStats struct {
+	ID          uint32 `json:"id"`
+	RulesLoaded int    `json:"rules_loaded"`
+	RulesFailed int    `json:"rules_failed"`
+}
+
+// FallbackError is returned by AddRule when the Suricata rules of an L7 rule failed to load and the fallback rules of
+// its fail mode were loaded instead: the traffic of the rule is rejected with crdv1alpha1.L7FailModeClosed, and passes
+// without Layer 7 filtering with crdv1alpha1.L7FailModeOpen. The L7 rule should be retried.
+type FallbackError struct {
+	FailMode string
+	Err      error
+}
+
+func (e *FallbackError) Error() string {
+	return fmt.Sprintf("%v, fell back to fail mode %s", e.Err, e.FailMode)
+}
+
+func (e *FallbackError) Unwrap() error {
+	return e.Err
+}
+
 var (
 	// Declared as a variable for testing.
 	defaultFS = afero.NewOsFs()
@@ -89,6 +115,10 @@ type Reconciler struct {
 	suricataTenantCache        *threadSafeInt32Set
 	suricataTenantHandlerCache *threadSafeInt32Set
 
+	// fallbackTenants stores the fail mode of the tenants whose fallback rules are loaded, keyed by tenant ID.
+	fallbackTenants      map[uint32]string
+	fallbackTenantsMutex sync.Mutex
+
 	once sync.Once
 }
 
@@ -102,6 +132,7 @@ func NewReconciler() *Reconciler {
 		suricataTenantHandlerCache: &threadSafeInt32Set{
 			cached: sets.New[int32](),
 		},
+		fallbackTenants: map[uint32]string{},
 	}
 }
 
@@ -141,6 +172,15 @@ func generateTenantRulesData(policyName string, protoKeywords map[string]sets.Se
 	return rulesData
 }
 
+// generateFailOpenRulesData generates the fallback rules of fail mode "open", which let all the traffic pass. The
+// fallback rules of fail mode "closed" are the default reject rule only, as generated by generateTenantRulesData without
+// any protocol.
+func generateFailOpenRulesData(policyName string) *bytes.Buffer {
+	rulesData := bytes.NewBuffer(nil)
+	rulesData.WriteString(fmt.Sprintf("pass ip any any -> any any (msg: \"Allow by %s in fail mode open\"; sid: 1;)\n", policyName))
+	return rulesData
+}
+
 func generateTenantRulesPath(vlanID uint32) string {
 	return fmt.Sprintf("%s/antrea-l7-networkpolicy-%d.rules", tenantRulesDir, vlanID)
 }
@@ -195,7 +235,9 @@ func convertProtocolHTTP(http *v1beta.HTTPProtocol) string {
 	return strings.Join(keywords, " ")
 }
 
-func (r *Reconciler) AddRule(ruleID, policyName string, vlanID uint32, l7Protocols []v1beta.L7Protocol, enableLogging bool) error {
+// AddRule loads the Suricata rules of an L7 rule to the tenant of the provided VLAN ID. If the rules fail to load, the
+// fallback rules of failMode are loaded instead and a *FallbackError is returned.
+func (r *Reconciler) AddRule(ruleID, policyName string, vlanID uint32, l7Protocols []v1beta.L7Protocol, enableLogging bool, failMode string) error {
 	start := time.Now()
 	defer func() {
 		klog.V(5).Infof("AddRule took %v", time.Since(start))
@@ -218,19 +260,89 @@ func (r *Reconciler) AddRule(ruleID, policyName string, vlanID uint32, l7Protoco
 	}
 
 	klog.InfoS("Reconciling L7 rule", "RuleID", ruleID, "PolicyName", policyName)
-	// Write the Suricata rules to file.
-	rulesPath := generateTenantRulesPath(vlanID)
 	rulesData := generateTenantRulesData(policyName, protoKeywords, enableLogging)
-	if err := writeConfigFile(rulesPath, rulesData); err != nil {
-		return fmt.Errorf("failed to write Suricata rules data to file %s for L7 rule %s of %s", rulesPath, ruleID, policyName)
+	err := r.loadSuricataTenantRules(vlanID, rulesData)
+	if err == nil {
+		r.setFallbackTenant(vlanID, "")
+		return nil
+	}
+	err = fmt.Errorf("failed to load Suricata rules for L7 rule %s of %s: %w", ruleID, policyName, err)
+	metrics.L7EngineRuleLoadFailureCount.WithLabelValues(failMode).Inc()
+
+	// Load the fallback rules so that the traffic of the rule is handled as requested by the fail mode, instead of
+	// being filtered by the partially loaded or the previous rules.
+	var fallbackRulesData *bytes.Buffer
+	if failMode == crdv1alpha1.L7FailModeOpen {
+		fallbackRulesData = generateFailOpenRulesData(policyName)
+	} else {
+		fallbackRulesData = generateTenantRulesData(policyName, nil, enableLogging)
+	}
+	if fallbackErr := r.loadSuricataTenantRules(vlanID, fallbackRulesData); fallbackErr != nil {
+		return fmt.Errorf("%w, failed to load fallback rules of fail mode %s: %v", err, failMode, fallbackErr)
 	}
+	klog.ErrorS(err, "Loaded fallback rules for L7 rule", "RuleID", ruleID, "PolicyName", policyName, "FailMode", failMode)
+	r.setFallbackTenant(vlanID, failMode)
+	return &FallbackError{FailMode: failMode, Err: err}
+}
 
-	// Add a Suricata tenant.
+// loadSuricataTenantRules writes the provided rules to the rules file of the tenant, adds or reloads the tenant, and
+// verifies that all the rules have been loaded.
+func (r *Reconciler) loadSuricataTenantRules(vlanID uint32, rulesData *bytes.Buffer) error {
+	// Each rule is written on its own line.
+	expectedRules := strings.Count(rulesData.String(), "\n")
+	rulesPath := generateTenantRulesPath(vlanID)
+	if err := writeConfigFile(rulesPath, rulesData); err != nil {
+		return fmt.Errorf("failed to write Suricata rules data to file %s: %w", rulesPath, err)
+	}
 	if err := r.addBindingSuricataTenant(vlanID, rulesPath); err != nil {
-		return fmt.Errorf("failed to add Suricata tenant for L7 rule %s of %s: %w", ruleID, policyName, err)
+		return fmt.Errorf("failed to add Suricata tenant %d: %w", vlanID, err)
 	}
+	// Suricata skips the rules which it fails to load without failing the command, check the stats of the tenant.
+	return r.verifySuricataTenantRules(vlanID, expectedRules)
+}
 
-	return nil
+func (r *Reconciler) verifySuricataTenantRules(tenantID uint32, expectedRules int) error {
+	resp, err := r.getSuricataRulesetStats()
+	if err != nil {
+		return err
+	}
+	if resp.Return != scCmdOK {
+		return fmt.Errorf("failed to get Suricata ruleset stats: %v", resp.Message)
+	}
+	var allStats []rulesetStats
+	if err := json.Unmarshal([]byte(resp.Message), &allStats); err != nil {
+		return fmt.Errorf("failed to parse Suricata ruleset stats: %w", err)
+	}
+	for _, stats := range allStats {
+		if stats.ID != tenantID {
+			continue
+		}
+		if stats.RulesFailed != 0 || stats.RulesLoaded != expectedRules {
+			return fmt.Errorf("%d rules loaded and %d rules failed for Suricata tenant %d, expected %d rules loaded", stats.RulesLoaded, stats.RulesFailed, tenantID, expectedRules)
+		}
+		klog.V(4).InfoS("Verified Suricata tenant rules successfully", "TenantID", tenantID, "RulesLoaded", stats.RulesLoaded)
+		return nil
+	}
+	return fmt.Errorf("ruleset stats of Suricata tenant %d not found", tenantID)
+}
+
+// setFallbackTenant records the fail mode of the fallback rules loaded for the tenant, an empty fail mode means that the
+// rules of the tenant are loaded successfully.
+func (r *Reconciler) setFallbackTenant(tenantID uint32, failMode string) {
+	r.fallbackTenantsMutex.Lock()
+	defer r.fallbackTenantsMutex.Unlock()
+	prevFailMode, exists := r.fallbackTenants[tenantID]
+	if exists && prevFailMode == failMode {
+		return
+	}
+	if exists {
+		metrics.L7EngineFallbackRuleCount.WithLabelValues(prevFailMode).Dec()
+		delete(r.fallbackTenants, tenantID)
+	}
+	if failMode != "" {
+		metrics.L7EngineFallbackRuleCount.WithLabelValues(failMode).Inc()
+		r.fallbackTenants[tenantID] = failMode
+	}
 }
 
 func (r *Reconciler) DeleteRule(ruleID string, vlanID uint32) error {
@@ -244,6 +356,8 @@ func (r *Reconciler) DeleteRule(ruleID string, vlanID uint32) error {
 		return fmt.Errorf("failed to delete Suricata tenant %d for L7 rule %s: %w", vlanID, ruleID, err)
 	}
 
+	r.setFallbackTenant(vlanID, "")
+
 	// Delete the Suricata rules file.
 	rulesPath := generateTenantRulesPath(vlanID)
 	if err := defaultFS.Remove(rulesPath); err != nil {
@@ -361,6 +475,10 @@ func (r *Reconciler) deleteBindingSuricataTenant(vlanID uint32) error {
 	return nil
 }
 
+func (r *Reconciler) getSuricataRulesetStats() (*scCmdRet, error) {
+	return r.suricataScFn("ruleset-stats")
+}
+
 func (r *Reconciler) reloadSuricataTenant(tenantID uint32, tenantConfigPath string) (*scCmdRet, error) {
 	scCmd := fmt.Sprintf("reload-tenant %d %s", tenantID, tenantConfigPath)
 	return r.suricataScFn(scCmd)
@@ -488,9 +606,19 @@ func suricataSc(scCmd string) (*scCmdRet, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to run Suricata command '%s': %w", scCmd, err)
 	}
-	var ret scCmdRet
+	var ret struct {
+		Message json.RawMessage `json:"message"`
+		Return  string          `json:"return"`
+	}
 	if err = json.Unmarshal(retBytes, &ret); err != nil {
 		return nil, err
 	}
-	return &ret, nil
+	// The message is a string for most commands, but it's a JSON object or array for some commands, e.g.
+	// "ruleset-stats", in which case it is returned as is to be parsed by the caller.
+	message := string(ret.Message)
+	var messageStr string
+	if err = json.Unmarshal(ret.Message, &messageStr); err == nil {
+		message = messageStr
+	}
+	return &scCmdRet{Message: message, Return: ret.Return}, nil
 }
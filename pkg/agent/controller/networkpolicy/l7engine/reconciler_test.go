@@ -15,32 +15,71 @@
 package l7engine
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	v1beta "antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
 )
 
 type fakeSuricata struct {
 	calledScCommands      sets.Set[string]
 	startSuricataFnCalled bool
+	// The rules containing failedKeyword fail to load.
+	failedKeyword string
+	// The stats of the loaded tenants, keyed by tenant ID.
+	tenantStats map[uint32]*rulesetStats
 }
 
 func newFakeSuricata() *fakeSuricata {
 	return &fakeSuricata{
 		calledScCommands:      sets.New[string](),
 		startSuricataFnCalled: false,
+		tenantStats:           map[uint32]*rulesetStats{},
 	}
 }
 
 func (f *fakeSuricata) suricataScFunc(scCmd string) (*scCmdRet, error) {
 	f.calledScCommands.Insert(scCmd)
+	var tenantID uint32
+	if _, err := fmt.Sscanf(scCmd, "register-tenant %d", &tenantID); err == nil && !strings.HasPrefix(scCmd, "register-tenant-handler") {
+		f.loadTenant(tenantID)
+	} else if _, err := fmt.Sscanf(scCmd, "reload-tenant %d", &tenantID); err == nil {
+		f.loadTenant(tenantID)
+	} else if _, err := fmt.Sscanf(scCmd, "unregister-tenant %d", &tenantID); err == nil {
+		delete(f.tenantStats, tenantID)
+	} else if scCmd == "ruleset-stats" {
+		allStats := []rulesetStats{{ID: 0}}
+		for _, stats := range f.tenantStats {
+			allStats = append(allStats, *stats)
+		}
+		message, _ := json.Marshal(allStats)
+		return &scCmdRet{Return: scCmdOK, Message: string(message)}, nil
+	}
 	return &scCmdRet{Return: scCmdOK}, nil
 }
 
+func (f *fakeSuricata) loadTenant(tenantID uint32) {
+	data, _ := afero.ReadFile(defaultFS, generateTenantRulesPath(tenantID))
+	stats := &rulesetStats{ID: tenantID}
+	for _, rule := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if f.failedKeyword != "" && strings.Contains(rule, f.failedKeyword) {
+			stats.RulesFailed++
+		} else {
+			stats.RulesLoaded++
+		}
+	}
+	f.tenantStats[tenantID] = stats
+}
+
 func (f *fakeSuricata) startSuricataFn() {
 	f.startSuricataFnCalled = true
 	defaultFS.Create(suricataCommandSocket)
@@ -201,7 +240,7 @@ func TestRuleLifecycle(t *testing.T) {
 			fe.startSuricataFn = fs.startSuricataFn
 
 			// Test add a L7 NetworkPolicy.
-			assert.NoError(t, fe.AddRule(ruleID, policyName, vlanID, tc.l7Protocols, false))
+			assert.NoError(t, fe.AddRule(ruleID, policyName, vlanID, tc.l7Protocols, false, crdv1alpha1.L7FailModeClosed))
 
 			rulesPath := generateTenantRulesPath(vlanID)
 			ok, err := afero.FileContainsBytes(defaultFS, rulesPath, []byte(tc.expectedRules))
@@ -213,12 +252,12 @@ func TestRuleLifecycle(t *testing.T) {
 			assert.NoError(t, err)
 			assert.True(t, ok)
 
-			expectedScCommands := sets.New[string]("register-tenant 1 /etc/suricata/antrea-tenant-1.yaml", "register-tenant-handler 1 vlan 1")
+			expectedScCommands := sets.New[string]("register-tenant 1 /etc/suricata/antrea-tenant-1.yaml", "register-tenant-handler 1 vlan 1", "ruleset-stats")
 			assert.True(t, fs.startSuricataFnCalled)
 			assert.Equal(t, expectedScCommands, fs.calledScCommands)
 
 			// Update the added L7 NetworkPolicy.
-			assert.NoError(t, fe.AddRule(ruleID, policyName, vlanID, tc.updatedL7Protocols, false))
+			assert.NoError(t, fe.AddRule(ruleID, policyName, vlanID, tc.updatedL7Protocols, false, crdv1alpha1.L7FailModeClosed))
 			expectedScCommands.Insert("reload-tenant 1 /etc/suricata/antrea-tenant-1.yaml")
 			assert.Equal(t, expectedScCommands, fs.calledScCommands)
 
@@ -237,3 +276,78 @@ func TestRuleLifecycle(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleLoadFailure(t *testing.T) {
+	ruleID := "123456"
+	vlanID := uint32(1)
+	policyName := "AntreaNetworkPolicy:test-l7"
+	l7Protocols := []v1beta.L7Protocol{
+		{
+			HTTP: &v1beta.HTTPProtocol{
+				Method: "GET",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name                  string
+		failMode              string
+		expectedFallbackRules string
+	}{
+		{
+			name:                  "fail mode closed",
+			failMode:              crdv1alpha1.L7FailModeClosed,
+			expectedFallbackRules: `reject ip any any -> any any (msg: "Reject by AntreaNetworkPolicy:test-l7"; flow: to_server, established; sid: 1;)` + "\n",
+		},
+		{
+			name:                  "fail mode open",
+			failMode:              crdv1alpha1.L7FailModeOpen,
+			expectedFallbackRules: `pass ip any any -> any any (msg: "Allow by AntreaNetworkPolicy:test-l7 in fail mode open"; sid: 1;)` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defaultFS = afero.NewMemMapFs()
+			defer func() {
+				defaultFS = afero.NewOsFs()
+			}()
+
+			_, err := defaultFS.Create(defaultSuricataConfigPath)
+			assert.NoError(t, err)
+
+			fe := NewReconciler()
+			fs := newFakeSuricata()
+			fe.suricataScFn = fs.suricataScFunc
+			fe.startSuricataFn = fs.startSuricataFn
+
+			// The HTTP rule fails to load, the fallback rules should be loaded instead.
+			fs.failedKeyword = "http.method"
+			err = fe.AddRule(ruleID, policyName, vlanID, l7Protocols, false, tc.failMode)
+			var fallbackErr *FallbackError
+			require.ErrorAs(t, err, &fallbackErr)
+			assert.Equal(t, tc.failMode, fallbackErr.FailMode)
+			assert.ErrorContains(t, err, "1 rules loaded and 1 rules failed for Suricata tenant 1, expected 2 rules loaded")
+			rulesData, err := afero.ReadFile(defaultFS, generateTenantRulesPath(vlanID))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFallbackRules, string(rulesData))
+			assert.Equal(t, map[uint32]string{vlanID: tc.failMode}, fe.fallbackTenants)
+
+			// The HTTP rule is loaded successfully when retrying.
+			fs.failedKeyword = ""
+			assert.NoError(t, fe.AddRule(ruleID, policyName, vlanID, l7Protocols, false, tc.failMode))
+			ok, err := afero.FileContainsBytes(defaultFS, generateTenantRulesPath(vlanID), []byte(`http.method; content:"GET";`))
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Empty(t, fe.fallbackTenants)
+
+			// The fallback rules fail to load too.
+			fs.failedKeyword = " any any -> any any"
+			err = fe.AddRule(ruleID, policyName, vlanID, l7Protocols, false, tc.failMode)
+			assert.ErrorContains(t, err, "failed to load fallback rules of fail mode "+tc.failMode)
+			fallbackErr = nil
+			assert.False(t, errors.As(err, &fallbackErr))
+			assert.Empty(t, fe.fallbackTenants)
+		})
+	}
+}
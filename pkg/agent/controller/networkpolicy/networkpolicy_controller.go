@@ -16,30 +16,41 @@ package networkpolicy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"reflect"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"antrea.io/ofnet/ofctrl"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
 	"antrea.io/antrea/pkg/agent"
 	"antrea.io/antrea/pkg/agent/config"
 	"antrea.io/antrea/pkg/agent/controller/networkpolicy/l7engine"
+	"antrea.io/antrea/pkg/agent/cpuaccounting"
 	"antrea.io/antrea/pkg/agent/flowexporter/connections"
+	"antrea.io/antrea/pkg/agent/flowscheduler"
 	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/metrics"
 	"antrea.io/antrea/pkg/agent/openflow"
 	proxytypes "antrea.io/antrea/pkg/agent/proxy/types"
 	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
 	"antrea.io/antrea/pkg/querier"
 	"antrea.io/antrea/pkg/util/channel"
 )
@@ -56,10 +67,12 @@ const (
 	// It is a special OVS rule which intercepts DNS query responses from DNS
 	// services to the workloads that have FQDN policy rules applied.
 	dnsInterceptRuleID = uint32(1)
+	// The reason of the Events emitted when the Layer 7 rules of a policy fail to load.
+	l7RuleLoadFailedReason = "L7RuleLoadFailed"
 )
 
 type L7RuleReconciler interface {
-	AddRule(ruleID, policyName string, vlanID uint32, l7Protocols []v1beta2.L7Protocol, enableLogging bool) error
+	AddRule(ruleID, policyName string, vlanID uint32, l7Protocols []v1beta2.L7Protocol, enableLogging bool, failMode string) error
 	DeleteRule(ruleID string, vlanID uint32) error
 }
 
@@ -82,6 +95,9 @@ type Controller struct {
 	// ClusterNetworkPolicy are enabled.
 	antreaPolicyEnabled    bool
 	l7NetworkPolicyEnabled bool
+	// nodeNetworkPolicyEnabled indicates whether Antrea ClusterNetworkPolicy
+	// can be applied to the host network traffic of Nodes.
+	nodeNetworkPolicyEnabled bool
 	// antreaProxyEnabled indicates whether Antrea proxy is enabled.
 	antreaProxyEnabled bool
 	// statusManagerEnabled indicates whether a statusManager is configured.
@@ -90,6 +106,9 @@ type Controller struct {
 	multicastEnabled bool
 	// loggingEnabled indicates where Antrea policy audit logging is enabled.
 	loggingEnabled bool
+	// controlplaneGRPCEnabled indicates whether the resources are watched with the ControlplaneStream
+	// gRPC service instead of the HTTP watch API.
+	controlplaneGRPCEnabled bool
 	// nodeType indicates type of the Node where Antrea Agent is running on.
 	nodeType config.NodeType
 	// antreaClientProvider provides interfaces to get antreaClient, which can be
@@ -112,6 +131,12 @@ type Controller struct {
 	l7RuleReconciler L7RuleReconciler
 	// l7VlanIDAllocator allocates a VLAN ID for every L7 rule.
 	l7VlanIDAllocator *l7VlanIDAllocator
+	// l7EventRecorder emits Events on the policies whose L7 rules fail to load, if set.
+	l7EventRecorder record.EventRecorder
+	// nodeReconciler provides interfaces to reconcile the desired state of
+	// NetworkPolicy rules which are applied to the Node itself with the actual
+	// state of the host firewall.
+	nodeReconciler nodeReconciler
 	// ofClient registers packetin for Antrea Policy logging.
 	ofClient           openflow.Client
 	antreaPolicyLogger *AntreaPolicyLogger
@@ -123,12 +148,28 @@ type Controller struct {
 	appliedToGroupWatcher *watcher
 	addressGroupWatcher   *watcher
 	fullSyncGroup         sync.WaitGroup
+	// initialFlowsInstalled indicates whether the flows of the rules received in the full sync have been installed.
+	initialFlowsInstalled atomic.Bool
 	ifaceStore            interfacestore.InterfaceStore
 	// denyConnStore is for storing deny connections for flow exporter.
 	denyConnStore *connections.DenyConnectionStore
 	gwPort        uint32
 	tunPort       uint32
 	nodeConfig    *config.NodeConfig
+	// reconcileScheduler shares the OVS programming bandwidth with other features.
+	// It's nil if the scheduler is disabled.
+	reconcileScheduler *flowscheduler.Scheduler
+	// supportedFeatures is the set of NetworkPolicy features which can be required
+	// by rules and are supported by this agent.
+	supportedFeatures sets.Set[string]
+	// unsupportedRules maps the IDs of the rules which are not enforced because
+	// they require unsupported features to these features.
+	unsupportedRules     map[string][]string
+	unsupportedRulesLock sync.RWMutex
+	// pausedPolicies maps the UIDs of the NetworkPolicies whose realization is
+	// paused to the IDs of their rules at the time they were paused.
+	pausedPolicies     map[string]sets.Set[string]
+	pausedPoliciesLock sync.RWMutex
 
 	logPacketAction           packetInAction
 	rejectRequestAction       packetInAction
@@ -146,10 +187,13 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 	groupIDUpdates <-chan string,
 	antreaPolicyEnabled bool,
 	l7NetworkPolicyEnabled bool,
+	nodeNetworkPolicyEnabled bool,
 	antreaProxyEnabled bool,
 	statusManagerEnabled bool,
 	multicastEnabled bool,
+	rateLimitSupported bool,
 	loggingEnabled bool,
+	controlplaneGRPCEnabled bool,
 	asyncRuleDeleteInterval time.Duration,
 	dnsServerOverride string,
 	nodeType config.NodeType,
@@ -159,19 +203,24 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 	nodeConfig *config.NodeConfig) (*Controller, error) {
 	idAllocator := newIDAllocator(asyncRuleDeleteInterval, dnsInterceptRuleID)
 	c := &Controller{
-		antreaClientProvider:   antreaClientGetter,
-		queue:                  workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "networkpolicyrule"),
-		ofClient:               ofClient,
-		nodeType:               nodeType,
-		antreaPolicyEnabled:    antreaPolicyEnabled,
-		l7NetworkPolicyEnabled: l7NetworkPolicyEnabled,
-		antreaProxyEnabled:     antreaProxyEnabled,
-		statusManagerEnabled:   statusManagerEnabled,
-		multicastEnabled:       multicastEnabled,
-		loggingEnabled:         loggingEnabled,
-		gwPort:                 gwPort,
-		tunPort:                tunPort,
-		nodeConfig:             nodeConfig,
+		antreaClientProvider:     antreaClientGetter,
+		queue:                    workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "networkpolicyrule"),
+		ofClient:                 ofClient,
+		nodeType:                 nodeType,
+		antreaPolicyEnabled:      antreaPolicyEnabled,
+		l7NetworkPolicyEnabled:   l7NetworkPolicyEnabled,
+		nodeNetworkPolicyEnabled: nodeNetworkPolicyEnabled,
+		antreaProxyEnabled:       antreaProxyEnabled,
+		statusManagerEnabled:     statusManagerEnabled,
+		multicastEnabled:         multicastEnabled,
+		loggingEnabled:           loggingEnabled,
+		controlplaneGRPCEnabled:  controlplaneGRPCEnabled,
+		gwPort:                   gwPort,
+		tunPort:                  tunPort,
+		nodeConfig:               nodeConfig,
+		supportedFeatures:        getSupportedFeatures(l7NetworkPolicyEnabled, multicastEnabled, antreaPolicyEnabled && rateLimitSupported),
+		unsupportedRules:         map[string][]string{},
+		pausedPolicies:           map[string]sets.Set[string]{},
 	}
 
 	if l7NetworkPolicyEnabled {
@@ -179,6 +228,13 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 		c.l7VlanIDAllocator = newL7VlanIDAllocator()
 	}
 
+	if nodeNetworkPolicyEnabled {
+		var err error
+		if c.nodeReconciler, err = newNodeReconciler(v4Enabled, v6Enabled); err != nil {
+			return nil, err
+		}
+	}
+
 	if antreaPolicyEnabled {
 		var err error
 		if c.fqdnController, err = newFQDNController(ofClient, idAllocator, dnsServerOverride, c.enqueueRule, v4Enabled, v6Enabled, gwPort); err != nil {
@@ -222,6 +278,9 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 	c.networkPolicyWatcher = &watcher{
 		objectType: "NetworkPolicy",
 		watchFunc: func() (watch.Interface, error) {
+			if c.controlplaneGRPCEnabled {
+				return c.newStreamWatch("networkpolicies", options)
+			}
 			antreaClient, err := c.antreaClientProvider.GetAntreaClient()
 			if err != nil {
 				return nil, err
@@ -306,6 +365,9 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 	c.appliedToGroupWatcher = &watcher{
 		objectType: "AppliedToGroup",
 		watchFunc: func() (watch.Interface, error) {
+			if c.controlplaneGRPCEnabled {
+				return c.newStreamWatch("appliedtogroups", options)
+			}
 			antreaClient, err := c.antreaClientProvider.GetAntreaClient()
 			if err != nil {
 				return nil, err
@@ -355,6 +417,9 @@ func NewNetworkPolicyController(antreaClientGetter agent.AntreaClientProvider,
 	c.addressGroupWatcher = &watcher{
 		objectType: "AddressGroup",
 		watchFunc: func() (watch.Interface, error) {
+			if c.controlplaneGRPCEnabled {
+				return c.newStreamWatch("addressgroups", options)
+			}
 			antreaClient, err := c.antreaClientProvider.GetAntreaClient()
 			if err != nil {
 				return nil, err
@@ -419,6 +484,93 @@ func (c *Controller) GetAppliedToGroupNum() int {
 	return c.ruleCache.GetAppliedToGroupNum()
 }
 
+// GetSupportedFeatures returns the NetworkPolicy features supported by the agent, sorted by name.
+func (c *Controller) GetSupportedFeatures() []string {
+	return sets.List(c.supportedFeatures)
+}
+
+// GetUnsupportedFeatures returns the NetworkPolicy features required by the rules received by
+// the agent but not supported by it, sorted by name.
+func (c *Controller) GetUnsupportedFeatures() []string {
+	c.unsupportedRulesLock.RLock()
+	defer c.unsupportedRulesLock.RUnlock()
+	features := sets.New[string]()
+	for _, ruleFeatures := range c.unsupportedRules {
+		features.Insert(ruleFeatures...)
+	}
+	return sets.List(features)
+}
+
+// getSupportedFeatures returns the NetworkPolicy features which can be required by rules
+// and are supported by the agent with the given configuration.
+func getSupportedFeatures(l7NetworkPolicyEnabled, multicastEnabled, rateLimitSupported bool) sets.Set[string] {
+	features := sets.New[string]()
+	if l7NetworkPolicyEnabled {
+		features.Insert(v1beta2.NetworkPolicyFeatureL7Protocols)
+	}
+	if multicastEnabled {
+		features.Insert(v1beta2.NetworkPolicyFeatureIGMP)
+	}
+	if rateLimitSupported {
+		features.Insert(v1beta2.NetworkPolicyFeatureRateLimit)
+	}
+	return features
+}
+
+// getUnsupportedFeatures returns the features required by the rule but not supported by the agent.
+func (c *Controller) getUnsupportedFeatures(rule *CompletedRule) []string {
+	var features []string
+	for _, feature := range rule.RequiredFeatures {
+		if !c.supportedFeatures.Has(feature) {
+			features = append(features, feature)
+		}
+	}
+	return features
+}
+
+// setRuleUnsupported records that the rule is not enforced because it requires the given
+// unsupported features. It returns false if the rule has been recorded before.
+func (c *Controller) setRuleUnsupported(ruleID string, features []string) bool {
+	c.unsupportedRulesLock.Lock()
+	defer c.unsupportedRulesLock.Unlock()
+	if _, exists := c.unsupportedRules[ruleID]; exists {
+		return false
+	}
+	c.unsupportedRules[ruleID] = features
+	for _, feature := range features {
+		metrics.UnsupportedNetworkPolicyRuleCount.WithLabelValues(feature).Inc()
+	}
+	return true
+}
+
+// deleteRuleUnsupported deletes the record of the rule if it was not enforced because of
+// unsupported features.
+func (c *Controller) deleteRuleUnsupported(ruleID string) {
+	c.unsupportedRulesLock.Lock()
+	defer c.unsupportedRulesLock.Unlock()
+	features, exists := c.unsupportedRules[ruleID]
+	if !exists {
+		return
+	}
+	delete(c.unsupportedRules, ruleID)
+	for _, feature := range features {
+		metrics.UnsupportedNetworkPolicyRuleCount.WithLabelValues(feature).Dec()
+	}
+}
+
+// handleUnsupportedRule records the rule which is not enforced because it requires the given
+// unsupported features, and reports it to the statusManager. Instead of enforcing the rule
+// partially, e.g. ignoring its L7Protocols, which could allow or drop unexpected traffic, the
+// rule is not enforced at all and its policy is reported as failed to be realized.
+func (c *Controller) handleUnsupportedRule(rule *CompletedRule, features []string) {
+	if c.setRuleUnsupported(rule.ID, features) {
+		klog.InfoS("Rule requires NetworkPolicy features not supported by the agent, not enforcing it", "ruleID", rule.ID, "policy", rule.SourceRef.ToString(), "features", features)
+	}
+	if c.statusManagerEnabled && rule.SourceRef.Type != v1beta2.K8sNetworkPolicy {
+		c.statusManager.SetRuleUnsupported(rule.ID, rule.PolicyUID, features)
+	}
+}
+
 // GetNetworkPolicies returns the requested NetworkPolicies.
 // This func will return all NetworkPolicies that can match all provided attributes in NetworkPolicyQueryFilter.
 // These not provided attributes in NetworkPolicyQueryFilter means match all.
@@ -459,6 +611,181 @@ func (c *Controller) GetRuleByFlowID(ruleFlowID uint32) *types.PolicyRule {
 	return rule
 }
 
+// GetFQDNCache returns the DNS cache of the FQDNs selected by FQDN policy rules, which match the
+// provided filter.
+func (c *Controller) GetFQDNCache(fqdnFilter *querier.FQDNCacheFilter) []types.DNSCacheEntry {
+	if c.fqdnController == nil {
+		return nil
+	}
+	var domain string
+	if fqdnFilter != nil {
+		domain = fqdnFilter.DomainName
+	}
+	return c.fqdnController.getDNSCacheEntries(domain)
+}
+
+// GetFQDNRuleMatches returns the match counts of the FQDNs of the FQDN policy rules, along
+// with the references of the policies and the names of the rules.
+func (c *Controller) GetFQDNRuleMatches() []types.FQDNRuleMatches {
+	if c.fqdnController == nil {
+		return nil
+	}
+	ruleMatches := c.fqdnController.getFQDNRuleMatches()
+	result := make([]types.FQDNRuleMatches, 0, len(ruleMatches))
+	for ruleID, matches := range ruleMatches {
+		obj, exists, _ := c.ruleCache.rules.GetByKey(ruleID)
+		if !exists {
+			// The rule has been removed from the cache and will be removed from the
+			// fqdnController soon.
+			continue
+		}
+		r := obj.(*rule)
+		result = append(result, types.FQDNRuleMatches{
+			PolicyRef: r.SourceRef,
+			RuleName:  r.Name,
+			Matches:   matches,
+		})
+	}
+	return result
+}
+
+// GetRuleSummaries returns the summaries of the rules in the rule cache, sorted by rule ID.
+func (c *Controller) GetRuleSummaries() []types.NetworkPolicyRuleSummary {
+	ruleIDs := c.ruleCache.rules.ListKeys()
+	sort.Strings(ruleIDs)
+	summaries := make([]types.NetworkPolicyRuleSummary, 0, len(ruleIDs))
+	for _, ruleID := range ruleIDs {
+		obj, exists, _ := c.ruleCache.rules.GetByKey(ruleID)
+		if !exists {
+			continue
+		}
+		r := obj.(*rule)
+		summary := types.NetworkPolicyRuleSummary{
+			ID:              r.ID,
+			Policy:          r.SourceRef.ToString(),
+			Name:            r.Name,
+			Direction:       string(r.Direction),
+			Priority:        r.Priority,
+			AppliedToGroups: r.AppliedToGroups,
+		}
+		summary.AddressGroups = append(summary.AddressGroups, r.From.AddressGroups...)
+		summary.AddressGroups = append(summary.AddressGroups, r.To.AddressGroups...)
+		completedRule, effective, realizable := c.ruleCache.GetCompletedRule(ruleID)
+		summary.Effective = effective
+		summary.Realizable = realizable
+		if completedRule != nil {
+			summary.NumTargetMembers = len(completedRule.TargetMembers)
+			summary.NumAddresses = len(completedRule.FromAddresses) + len(completedRule.ToAddresses)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// FlushFQDNCache expires the DNS cache of a FQDN to force its re-resolution.
+func (c *Controller) FlushFQDNCache(fqdn string) error {
+	if c.fqdnController == nil {
+		return fmt.Errorf("AntreaPolicy is not enabled")
+	}
+	return c.fqdnController.flushDNSCacheEntry(fqdn)
+}
+
+// PauseNetworkPolicy pauses the realization of the NetworkPolicy with the provided name,
+// which is the UID of the original policy. The flows of its rules are kept as they are,
+// and updates to the policy or to the groups it references are not applied until
+// ResumeNetworkPolicy is called. The paused state is not persisted across agent restarts.
+func (c *Controller) PauseNetworkPolicy(name string) error {
+	if c.ruleCache.getNetworkPolicy(name) == nil {
+		return fmt.Errorf("NetworkPolicy %s not found", name)
+	}
+	c.pausedPoliciesLock.Lock()
+	defer c.pausedPoliciesLock.Unlock()
+	if _, exists := c.pausedPolicies[name]; exists {
+		return nil
+	}
+	// Record the rules at the time the policy is paused so that they are kept even if
+	// they are removed from the policy later.
+	ruleIDs := sets.New[string]()
+	objs, _ := c.ruleCache.rules.ByIndex(policyIndex, name)
+	for _, obj := range objs {
+		ruleIDs.Insert(obj.(*rule).ID)
+	}
+	c.pausedPolicies[name] = ruleIDs
+	klog.InfoS("Paused realization of NetworkPolicy", "policy", name, "rules", ruleIDs.Len())
+	return nil
+}
+
+// ResumeNetworkPolicy resumes the realization of a NetworkPolicy paused by PauseNetworkPolicy.
+// All the rules of the policy, including the ones removed while it was paused, are reconciled
+// again.
+func (c *Controller) ResumeNetworkPolicy(name string) error {
+	c.pausedPoliciesLock.Lock()
+	ruleIDs, exists := c.pausedPolicies[name]
+	delete(c.pausedPolicies, name)
+	c.pausedPoliciesLock.Unlock()
+	if !exists {
+		return fmt.Errorf("NetworkPolicy %s is not paused", name)
+	}
+	objs, _ := c.ruleCache.rules.ByIndex(policyIndex, name)
+	for _, obj := range objs {
+		ruleIDs.Insert(obj.(*rule).ID)
+	}
+	for ruleID := range ruleIDs {
+		c.enqueueRule(ruleID)
+	}
+	klog.InfoS("Resumed realization of NetworkPolicy", "policy", name, "rules", ruleIDs.Len())
+	return nil
+}
+
+// GetPausedNetworkPolicies returns the names of the NetworkPolicies whose realization is paused,
+// sorted by name.
+func (c *Controller) GetPausedNetworkPolicies() []string {
+	c.pausedPoliciesLock.RLock()
+	defer c.pausedPoliciesLock.RUnlock()
+	names := make([]string, 0, len(c.pausedPolicies))
+	for name := range c.pausedPolicies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExpireDeletedRules deletes the rules of deleted NetworkPolicies, which are kept for a while to
+// map the flows of existing connections to them, immediately. It returns the number of deleted
+// rules.
+func (c *Controller) ExpireDeletedRules() int {
+	return c.reconciler.ExpireDeletedRules()
+}
+
+// SimulateFlow evaluates the flow against the realizable rules in the rule cache and returns the
+// action applied to it at each stage of the NetworkPolicy pipeline. No packet is sent and the OVS
+// flows are not consulted, so the result reflects the desired state of the NetworkPolicies on the
+// Node.
+func (c *Controller) SimulateFlow(flow *querier.SimulatedFlow) *types.PolicySimulationResult {
+	return simulateFlow(c.ruleCache.getRealizableRules(), flow)
+}
+
+// isRulePaused returns whether the rule with the provided ID belongs to a NetworkPolicy whose
+// realization is paused. completedRule can be nil if the rule has been removed from the cache.
+func (c *Controller) isRulePaused(ruleID string, completedRule *CompletedRule) bool {
+	c.pausedPoliciesLock.RLock()
+	defer c.pausedPoliciesLock.RUnlock()
+	if len(c.pausedPolicies) == 0 {
+		return false
+	}
+	if completedRule != nil {
+		if _, exists := c.pausedPolicies[string(completedRule.PolicyUID)]; exists {
+			return true
+		}
+	}
+	for _, ruleIDs := range c.pausedPolicies {
+		if ruleIDs.Has(ruleID) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Controller) GetControllerConnectionStatus() bool {
 	// When the watchers are connected, controller connection status is true. Otherwise, it is false.
 	return c.addressGroupWatcher.isConnected() && c.appliedToGroupWatcher.isConnected() && c.networkPolicyWatcher.isConnected()
@@ -468,6 +795,44 @@ func (c *Controller) SetDenyConnStore(denyConnStore *connections.DenyConnectionS
 	c.denyConnStore = denyConnStore
 }
 
+// SetReconcileScheduler sets the scheduler used to share the OVS programming bandwidth with other features.
+func (c *Controller) SetReconcileScheduler(scheduler *flowscheduler.Scheduler) {
+	c.reconcileScheduler = scheduler
+}
+
+// SetAuditLoggingConfig updates the rotation settings of the audit log file. It's a no-op if audit logging for
+// Antrea-native policies is not enabled.
+func (c *Controller) SetAuditLoggingConfig(config AuditLoggingConfig) {
+	if c.antreaPolicyLogger == nil {
+		return
+	}
+	c.antreaPolicyLogger.updateRotationConfig(config)
+}
+
+// SetDenialEventRecorder enables emitting Kubernetes Events on the Pods for the connections denied by Antrea-native
+// policies whose severity annotation is at least severityThreshold. It's a no-op if audit logging for Antrea-native
+// policies is not enabled.
+func (c *Controller) SetDenialEventRecorder(recorder record.EventRecorder, severityThreshold string) {
+	if c.antreaPolicyLogger == nil {
+		return
+	}
+	c.antreaPolicyLogger.setDenialEventRecorder(recorder, severityThreshold)
+}
+
+// SetL7EventRecorder enables emitting Kubernetes Events on the Antrea-native policies whose Layer 7 rules fail to load.
+// It's a no-op if the L7NetworkPolicy feature is not enabled.
+func (c *Controller) SetL7EventRecorder(recorder record.EventRecorder) {
+	if !c.l7NetworkPolicyEnabled {
+		return
+	}
+	c.l7EventRecorder = recorder
+}
+
+// GetRuleQueueLength returns the number of rules waiting to be reconciled.
+func (c *Controller) GetRuleQueueLength() int {
+	return c.queue.Len()
+}
+
 // Run begins watching and processing Antrea AddressGroups, AppliedToGroups
 // and NetworkPolicies, and spawns workers that reconciles NetworkPolicy rules.
 // Run will not return until stopCh is closed.
@@ -500,12 +865,14 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 			go wait.Until(c.fqdnController.worker, time.Second, stopCh)
 		}
 		go c.fqdnController.runRuleSyncTracker(stopCh)
+		go wait.Until(c.fqdnController.syncNodeLocalDNSIPs, nodeLocalDNSSyncInterval, stopCh)
 	}
 	klog.Infof("Waiting for all watchers to complete full sync")
 	c.fullSyncGroup.Wait()
 	klog.Infof("All watchers have completed full sync, installing flows for init events")
 	// Batch install all rules in queue after fullSync is finished.
 	c.processAllItemsInQueue()
+	c.initialFlowsInstalled.Store(true)
 
 	klog.Infof("Starting NetworkPolicy workers now")
 	defer c.queue.ShutDown()
@@ -523,6 +890,12 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 	<-stopCh
 }
 
+// InitialFlowsInstalled returns true once the flows of the NetworkPolicies received in the initial full sync with the
+// antrea-controller have been installed.
+func (c *Controller) InitialFlowsInstalled() bool {
+	return c.initialFlowsInstalled.Load()
+}
+
 func (c *Controller) matchIGMPType(r *rule, igmpType uint8, groupAddress string) bool {
 	for _, s := range r.Services {
 		if (s.IGMPType == nil || uint8(*s.IGMPType) == igmpType) && (s.GroupAddress == "" || s.GroupAddress == groupAddress) {
@@ -590,7 +963,12 @@ func (c *Controller) processNextWorkItem() bool {
 	}
 	defer c.queue.Done(key)
 
-	err := c.syncRule(key.(string))
+	err := c.reconcileScheduler.Do(flowscheduler.FeatureNetworkPolicy, func() (err error) {
+		cpuaccounting.Run(cpuaccounting.FeatureNetworkPolicy, func() {
+			err = c.syncRule(key.(string))
+		})
+		return err
+	})
 	c.handleErr(err, key)
 
 	return true
@@ -622,16 +1000,28 @@ func (c *Controller) syncRule(key string) error {
 		klog.V(4).InfoS("Finished syncing rule", "ruleID", key, "duration", time.Since(startTime))
 	}()
 	rule, effective, realizable := c.ruleCache.GetCompletedRule(key)
+	if c.isRulePaused(key, rule) {
+		klog.V(2).InfoS("Realization of the rule's NetworkPolicy is paused, skipping", "ruleID", key)
+		return nil
+	}
 	if !effective {
 		klog.V(2).InfoS("Rule was not effective, removing it", "ruleID", key)
 		if err := c.reconciler.Forget(key); err != nil {
 			return err
 		}
+		if c.nodeNetworkPolicyEnabled {
+			// We don't know whether this is a rule applied to the Node, but
+			// harmless to delete it.
+			if err := c.nodeReconciler.Forget(key); err != nil {
+				return err
+			}
+		}
 		if c.statusManagerEnabled {
 			// We don't know whether this is a rule owned by Antrea Policy, but
 			// harmless to delete it.
 			c.statusManager.DeleteRuleRealization(key)
 		}
+		c.deleteRuleUnsupported(key)
 		if c.l7NetworkPolicyEnabled {
 			if vlanID := c.l7VlanIDAllocator.query(key); vlanID != 0 {
 				if err := c.l7RuleReconciler.DeleteRule(key, vlanID); err != nil {
@@ -648,14 +1038,35 @@ func (c *Controller) syncRule(key string) error {
 		klog.V(2).InfoS("Rule is not realizable, skipping", "ruleID", key)
 		return nil
 	}
+	// The rule ID is computed from all fields of the rule including RequiredFeatures,
+	// so the rule can't have been enforced before if it requires unsupported features.
+	if unsupportedFeatures := c.getUnsupportedFeatures(rule); len(unsupportedFeatures) > 0 {
+		c.handleUnsupportedRule(rule, unsupportedFeatures)
+		return nil
+	}
+
+	if c.nodeNetworkPolicyEnabled && isNodeNetworkPolicyRule(rule) {
+		if err := c.nodeReconciler.Reconcile(rule); err != nil {
+			return err
+		}
+		if c.statusManagerEnabled {
+			c.statusManager.SetRuleRealization(key, rule.PolicyUID)
+		}
+		return nil
+	}
 
+	var l7Err error
 	if c.l7NetworkPolicyEnabled && len(rule.L7Protocols) != 0 {
 		// Allocate VLAN ID for the L7 rule.
 		vlanID := c.l7VlanIDAllocator.allocate(key)
 		rule.L7RuleVlanID = &vlanID
 
-		if err := c.l7RuleReconciler.AddRule(key, rule.SourceRef.ToString(), vlanID, rule.L7Protocols, rule.EnableLogging); err != nil {
-			return err
+		if fallback, err := c.addL7Rule(rule, vlanID); err != nil {
+			if !fallback {
+				return err
+			}
+			// The fallback rules of the fail mode are enforced, the rule is realized and retried later.
+			l7Err = err
 		}
 	}
 
@@ -669,6 +1080,9 @@ func (c *Controller) syncRule(key string) error {
 	if err != nil {
 		return err
 	}
+	if l7Err != nil {
+		return l7Err
+	}
 	if c.statusManagerEnabled && rule.SourceRef.Type != v1beta2.K8sNetworkPolicy {
 		c.statusManager.SetRuleRealization(key, rule.PolicyUID)
 	}
@@ -684,23 +1098,36 @@ func (c *Controller) syncRules(keys []string) error {
 		klog.V(4).Infof("Finished syncing all rules before bookmark event (%v)", time.Since(startTime))
 	}()
 
-	var allRules []*CompletedRule
+	var allRules, allNodeRules []*CompletedRule
+	var l7Err error
 	for _, key := range keys {
 		rule, effective, realizable := c.ruleCache.GetCompletedRule(key)
+		if c.isRulePaused(key, rule) {
+			klog.V(2).InfoS("Realization of the rule's NetworkPolicy is paused, skipping", "ruleID", key)
+			continue
+		}
 		// It's normal that a rule is not effective on this Node but abnormal that it is not realizable after watchers
 		// complete full sync.
 		if !effective {
 			klog.Infof("Rule %s is not effective on this Node", key)
 		} else if !realizable {
 			klog.Errorf("Rule %s is effective but not realizable", key)
+		} else if unsupportedFeatures := c.getUnsupportedFeatures(rule); len(unsupportedFeatures) > 0 {
+			c.handleUnsupportedRule(rule, unsupportedFeatures)
+		} else if c.nodeNetworkPolicyEnabled && isNodeNetworkPolicyRule(rule) {
+			allNodeRules = append(allNodeRules, rule)
 		} else {
 			if c.l7NetworkPolicyEnabled && len(rule.L7Protocols) != 0 {
 				// Allocate VLAN ID for the L7 rule.
 				vlanID := c.l7VlanIDAllocator.allocate(key)
 				rule.L7RuleVlanID = &vlanID
 
-				if err := c.l7RuleReconciler.AddRule(key, rule.SourceRef.ToString(), vlanID, rule.L7Protocols, rule.EnableLogging); err != nil {
-					return err
+				if fallback, err := c.addL7Rule(rule, vlanID); err != nil {
+					if !fallback {
+						return err
+					}
+					// The fallback rules of the fail mode are enforced, the rules are realized and retried later.
+					l7Err = err
 				}
 			}
 			allRules = append(allRules, rule)
@@ -709,6 +1136,15 @@ func (c *Controller) syncRules(keys []string) error {
 	if err := c.reconciler.BatchReconcile(allRules); err != nil {
 		return err
 	}
+	if c.nodeNetworkPolicyEnabled {
+		if err := c.nodeReconciler.BatchReconcile(allNodeRules); err != nil {
+			return err
+		}
+		allRules = append(allRules, allNodeRules...)
+	}
+	if l7Err != nil {
+		return l7Err
+	}
 	if c.statusManagerEnabled {
 		for _, rule := range allRules {
 			if rule.SourceRef.Type != v1beta2.K8sNetworkPolicy {
@@ -719,6 +1155,68 @@ func (c *Controller) syncRules(keys []string) error {
 	return nil
 }
 
+// addL7Rule loads the Layer 7 rules of the rule to the L7 engine with the fail mode of its policy. If the rules fail
+// to load, a Warning Event is emitted on the policy, and fallback is true if the fallback rules of the fail mode were
+// loaded instead, in which case the rule can be realized but must be retried.
+func (c *Controller) addL7Rule(rule *CompletedRule, vlanID uint32) (fallback bool, err error) {
+	failMode := c.getL7FailMode(rule.PolicyUID)
+	err = c.l7RuleReconciler.AddRule(rule.ID, rule.SourceRef.ToString(), vlanID, rule.L7Protocols, rule.EnableLogging, failMode)
+	if err == nil {
+		return false, nil
+	}
+	var fallbackErr *l7engine.FallbackError
+	fallback = errors.As(err, &fallbackErr)
+	c.recordL7RuleLoadFailure(rule, failMode, fallback, err)
+	return fallback, err
+}
+
+// getL7FailMode returns the L7 fail mode of the Network Policy with the provided UID, which is set with an annotation
+// on Antrea-native policies. It defaults to crdv1alpha1.L7FailModeClosed, invalid values are ignored.
+func (c *Controller) getL7FailMode(npUID k8stypes.UID) string {
+	policy := c.ruleCache.getNetworkPolicy(string(npUID))
+	if policy == nil {
+		return crdv1alpha1.L7FailModeClosed
+	}
+	failMode, exists := policy.Annotations[crdv1alpha1.L7FailModeAnnotationKey]
+	if !exists {
+		return crdv1alpha1.L7FailModeClosed
+	}
+	if failMode != crdv1alpha1.L7FailModeClosed && failMode != crdv1alpha1.L7FailModeOpen {
+		klog.V(2).InfoS("Ignored invalid L7 fail mode annotation", "policy", policy.SourceRef, "failMode", failMode)
+		return crdv1alpha1.L7FailModeClosed
+	}
+	return failMode
+}
+
+// recordL7RuleLoadFailure emits a Warning Event on the Antrea-native policy of a rule whose Layer 7 rules failed to
+// load, if the Event recorder is set.
+func (c *Controller) recordL7RuleLoadFailure(rule *CompletedRule, failMode string, fallback bool, err error) {
+	if c.l7EventRecorder == nil {
+		return
+	}
+	ref := &corev1.ObjectReference{
+		APIVersion: crdv1alpha1.SchemeGroupVersion.String(),
+		Namespace:  rule.SourceRef.Namespace,
+		Name:       rule.SourceRef.Name,
+		UID:        k8stypes.UID(rule.SourceRef.UID),
+	}
+	switch rule.SourceRef.Type {
+	case v1beta2.AntreaClusterNetworkPolicy:
+		ref.Kind = "ClusterNetworkPolicy"
+	case v1beta2.AntreaNetworkPolicy:
+		ref.Kind = "NetworkPolicy"
+	default:
+		return
+	}
+	if fallback {
+		c.l7EventRecorder.Eventf(ref, corev1.EventTypeWarning, l7RuleLoadFailedReason,
+			"Failed to load Layer 7 rules of rule %q, enforcing fail mode %s: %v", rule.Name, failMode, err)
+	} else {
+		c.l7EventRecorder.Eventf(ref, corev1.EventTypeWarning, l7RuleLoadFailedReason,
+			"Failed to load Layer 7 rules of rule %q and fallback rules of fail mode %s: %v", rule.Name, failMode, err)
+	}
+}
+
 func (c *Controller) handleErr(err error, key interface{}) {
 	if err == nil {
 		c.queue.Forget(key)
@@ -38,6 +38,7 @@ import (
 	proxytypes "antrea.io/antrea/pkg/agent/proxy/types"
 	agenttypes "antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	cpstreamv1alpha1 "antrea.io/antrea/pkg/apis/controlplanestream/v1alpha1"
 	"antrea.io/antrea/pkg/apis/crd/v1alpha1"
 	"antrea.io/antrea/pkg/client/clientset/versioned"
 	"antrea.io/antrea/pkg/client/clientset/versioned/fake"
@@ -64,13 +65,17 @@ func (g *antreaClientGetter) GetAntreaClient() (versioned.Interface, error) {
 	return g.clientset, nil
 }
 
+func (g *antreaClientGetter) GetControlplaneStreamClient() (cpstreamv1alpha1.ControlplaneStreamClient, error) {
+	return nil, fmt.Errorf("controlplane stream is not supported")
+}
+
 func newTestController() (*Controller, *fake.Clientset, *mockReconciler) {
 	clientset := &fake.Clientset{}
 	podUpdateChannel := channel.NewSubscribableChannel("PodUpdate", 100)
 	ch2 := make(chan string, 100)
 	groupIDAllocator := openflow.NewGroupAllocator()
-	groupCounters := []proxytypes.GroupCounter{proxytypes.NewGroupCounter(groupIDAllocator, ch2)}
-	controller, _ := NewNetworkPolicyController(&antreaClientGetter{clientset}, nil, nil, "node1", podUpdateChannel, nil, groupCounters, ch2, true, true, true, true, false, true, testAsyncDeleteInterval, "8.8.8.8:53", config.K8sNode, true, false, config.HostGatewayOFPort, config.DefaultTunOFPort, &config.NodeConfig{})
+	groupCounters := []proxytypes.GroupCounter{proxytypes.NewGroupCounter(groupIDAllocator, ch2, false)}
+	controller, _ := NewNetworkPolicyController(&antreaClientGetter{clientset}, nil, nil, "node1", podUpdateChannel, nil, groupCounters, ch2, true, true, false, true, true, false, false, true, false, testAsyncDeleteInterval, "8.8.8.8:53", config.K8sNode, true, false, config.HostGatewayOFPort, config.DefaultTunOFPort, &config.NodeConfig{})
 	reconciler := newMockReconciler()
 	controller.reconciler = reconciler
 	controller.antreaPolicyLogger = nil
@@ -134,6 +139,10 @@ func (r *mockReconciler) GetRuleByFlowID(_ uint32) (*agenttypes.PolicyRule, bool
 	return nil, false, nil
 }
 
+func (r *mockReconciler) ExpireDeletedRules() int {
+	return 0
+}
+
 func (r *mockReconciler) getLastRealized(ruleID string) (*CompletedRule, bool) {
 	r.Lock()
 	defer r.Unlock()
@@ -423,6 +432,117 @@ func TestDeleteRule(t *testing.T) {
 	}
 }
 
+func TestUnsupportedRule(t *testing.T) {
+	prepareMockTables()
+	controller, clientset, reconciler := newTestController()
+	addressGroupWatcher := watch.NewFake()
+	appliedToGroupWatcher := watch.NewFake()
+	networkPolicyWatcher := watch.NewFake()
+	clientset.AddWatchReactor("addressgroups", k8stesting.DefaultWatchReactor(addressGroupWatcher, nil))
+	clientset.AddWatchReactor("appliedtogroups", k8stesting.DefaultWatchReactor(appliedToGroupWatcher, nil))
+	clientset.AddWatchReactor("networkpolicies", k8stesting.DefaultWatchReactor(networkPolicyWatcher, nil))
+
+	protocolIGMP := v1beta2.ProtocolIGMP
+	services := []v1beta2.Service{{Protocol: &protocolIGMP}}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go controller.Run(stopCh)
+
+	// The test controller supports L7 NetworkPolicy but not multicast.
+	assert.Equal(t, []string{v1beta2.NetworkPolicyFeatureL7Protocols}, controller.GetSupportedFeatures())
+
+	addressGroupWatcher.Add(newAddressGroup("addressGroup1", []v1beta2.GroupMember{*newAddressGroupMember("1.1.1.1")}))
+	addressGroupWatcher.Action(watch.Bookmark, nil)
+	appliedToGroupWatcher.Add(newAppliedToGroup("appliedToGroup1", []v1beta2.GroupMember{*newAppliedToGroupMemberPod("pod1", "ns1")}))
+	appliedToGroupWatcher.Action(watch.Bookmark, nil)
+	policy := newNetworkPolicy("policy1", "uid1", []string{"addressGroup1"}, []string{}, []string{"appliedToGroup1"}, services)
+	policy.Rules[0].RequiredFeatures = []string{v1beta2.NetworkPolicyFeatureIGMP}
+	networkPolicyWatcher.Add(policy)
+	networkPolicyWatcher.Action(watch.Bookmark, nil)
+	// The rule requiring IGMP must not be enforced.
+	select {
+	case ruleID := <-reconciler.updated:
+		t.Fatalf("Expected no update, got %v", ruleID)
+	case <-time.After(time.Millisecond * 100):
+	}
+	assert.Equal(t, []string{v1beta2.NetworkPolicyFeatureIGMP}, controller.GetUnsupportedFeatures())
+
+	networkPolicyWatcher.Delete(newNetworkPolicy("policy1", "uid1", []string{}, []string{}, []string{}, nil))
+	select {
+	case <-reconciler.deleted:
+	case <-time.After(time.Millisecond * 100):
+		t.Fatal("Expected one deletion, got none")
+	}
+	assert.Eventually(t, func() bool {
+		return len(controller.GetUnsupportedFeatures()) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPauseAndResumeNetworkPolicy(t *testing.T) {
+	prepareMockTables()
+	controller, clientset, reconciler := newTestController()
+	addressGroupWatcher := watch.NewFake()
+	appliedToGroupWatcher := watch.NewFake()
+	networkPolicyWatcher := watch.NewFake()
+	clientset.AddWatchReactor("addressgroups", k8stesting.DefaultWatchReactor(addressGroupWatcher, nil))
+	clientset.AddWatchReactor("appliedtogroups", k8stesting.DefaultWatchReactor(appliedToGroupWatcher, nil))
+	clientset.AddWatchReactor("networkpolicies", k8stesting.DefaultWatchReactor(networkPolicyWatcher, nil))
+
+	protocolTCP := v1beta2.ProtocolTCP
+	port := intstr.FromInt(80)
+	services := []v1beta2.Service{{Protocol: &protocolTCP, Port: &port}}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go controller.Run(stopCh)
+
+	expectNoEvent := func() {
+		select {
+		case ruleID := <-reconciler.updated:
+			t.Fatalf("Expected no update, got %v", ruleID)
+		case ruleID := <-reconciler.deleted:
+			t.Fatalf("Expected no deletion, got %v", ruleID)
+		case <-time.After(time.Millisecond * 100):
+		}
+	}
+
+	assert.EqualError(t, controller.PauseNetworkPolicy("uid1"), "NetworkPolicy uid1 not found")
+
+	addressGroupWatcher.Add(newAddressGroup("addressGroup1", []v1beta2.GroupMember{*newAddressGroupMember("1.1.1.1")}))
+	addressGroupWatcher.Action(watch.Bookmark, nil)
+	appliedToGroupWatcher.Add(newAppliedToGroup("appliedToGroup1", []v1beta2.GroupMember{*newAppliedToGroupMemberPod("pod1", "ns1")}))
+	appliedToGroupWatcher.Action(watch.Bookmark, nil)
+	networkPolicyWatcher.Add(newNetworkPolicy("policy1", "uid1", []string{"addressGroup1"}, []string{}, []string{"appliedToGroup1"}, services))
+	networkPolicyWatcher.Action(watch.Bookmark, nil)
+	var ruleID string
+	select {
+	case ruleID = <-reconciler.updated:
+	case <-time.After(time.Second):
+		t.Fatal("Expected one update, got none")
+	}
+
+	require.NoError(t, controller.PauseNetworkPolicy("uid1"))
+	assert.Equal(t, []string{"uid1"}, controller.GetPausedNetworkPolicies())
+
+	// Neither the update of the AddressGroup nor the deletion of the policy should be realized.
+	addressGroupWatcher.Modify(newAddressGroup("addressGroup1", []v1beta2.GroupMember{*newAddressGroupMember("1.1.1.1"), *newAddressGroupMember("2.2.2.2")}))
+	expectNoEvent()
+	actualRule, _ := reconciler.getLastRealized(ruleID)
+	assert.Equal(t, v1beta2.NewGroupMemberSet(newAddressGroupMember("1.1.1.1")), actualRule.FromAddresses)
+	networkPolicyWatcher.Delete(newNetworkPolicy("policy1", "uid1", []string{}, []string{}, []string{}, nil))
+	expectNoEvent()
+
+	// The rule removed while the policy was paused is removed once the policy is resumed.
+	require.NoError(t, controller.ResumeNetworkPolicy("uid1"))
+	assert.Empty(t, controller.GetPausedNetworkPolicies())
+	select {
+	case deletedRuleID := <-reconciler.deleted:
+		assert.Equal(t, ruleID, deletedRuleID)
+	case <-time.After(time.Second):
+		t.Fatal("Expected one deletion, got none")
+	}
+	assert.EqualError(t, controller.ResumeNetworkPolicy("uid1"), "NetworkPolicy uid1 is not paused")
+}
+
 func TestAddNetworkPolicyWithMultipleRules(t *testing.T) {
 	prepareMockTables()
 	controller, clientset, reconciler := newTestController()
@@ -694,3 +814,30 @@ func TestValidate(t *testing.T) {
 		t.Fatalf("groupAddress %s expect %v, but got %v", groupAddress2, v1alpha1.RuleActionDrop, item.RuleAction)
 	}
 }
+
+func TestGetRuleSummaries(t *testing.T) {
+	controller, _, _ := newTestController()
+	policy := newNetworkPolicy("policy1", "uid1", []string{"addressGroup1"}, nil, []string{"appliedToGroup1"}, nil)
+	controller.ruleCache.AddNetworkPolicy(policy)
+	ruleIDs := controller.ruleCache.rules.ListKeys()
+	require.Len(t, ruleIDs, 1)
+	expectedSummary := agenttypes.NetworkPolicyRuleSummary{
+		ID:              ruleIDs[0],
+		Policy:          "K8sNetworkPolicy:" + testNamespace + "/policy1",
+		Direction:       "In",
+		AppliedToGroups: []string{"appliedToGroup1"},
+		AddressGroups:   []string{"addressGroup1"},
+	}
+	// The groups of the rule haven't been received.
+	assert.Equal(t, []agenttypes.NetworkPolicyRuleSummary{expectedSummary}, controller.GetRuleSummaries())
+
+	require.NoError(t, controller.ruleCache.AddAppliedToGroup(newAppliedToGroup("appliedToGroup1", []v1beta2.GroupMember{*newAppliedToGroupMemberPod("pod1", "ns1")})))
+	expectedSummary.Effective = true
+	assert.Equal(t, []agenttypes.NetworkPolicyRuleSummary{expectedSummary}, controller.GetRuleSummaries())
+
+	require.NoError(t, controller.ruleCache.AddAddressGroup(newAddressGroup("addressGroup1", []v1beta2.GroupMember{*newAddressGroupMember("1.1.1.1"), *newAddressGroupMember("2.2.2.2")})))
+	expectedSummary.Realizable = true
+	expectedSummary.NumTargetMembers = 1
+	expectedSummary.NumAddresses = 2
+	assert.Equal(t, []agenttypes.NetworkPolicyRuleSummary{expectedSummary}, controller.GetRuleSummaries())
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+// nodeReconciler is responsible for enforcing the rules which are applied to
+// the Node itself, i.e. the rules of policies with nodeSelector in appliedTo.
+// These rules are enforced on the host network traffic of the Node instead of
+// the traffic of Pods, thus they are not realized with Openflow entries.
+type nodeReconciler interface {
+	// Reconcile reconciles the desired state of the provided CompletedRule
+	// with the actual state of the host firewall.
+	Reconcile(rule *CompletedRule) error
+
+	// BatchReconcile reconciles the desired state of the provided CompletedRules
+	// with the actual state of the host firewall in batch.
+	BatchReconcile(rules []*CompletedRule) error
+
+	// Forget cleanups the actual state of the host firewall of the specified ruleID.
+	Forget(ruleID string) error
+}
+
+// isNodeNetworkPolicyRule returns true if the rule is applied to Nodes.
+// A policy applied to Nodes cannot be applied to other entities at the same
+// time, so it's enough to check any of the target members.
+func isNodeNetworkPolicyRule(rule *CompletedRule) bool {
+	for _, member := range rule.TargetMembers {
+		return member.Node != nil
+	}
+	return false
+}
@@ -0,0 +1,345 @@
+//go:build linux
+// +build linux
+
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/util/iptables"
+	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	"antrea.io/antrea/pkg/util/ip"
+)
+
+const (
+	// nodeIngressRulesChain is the chain in the filter table which holds the ingress rules applied to the Node.
+	nodeIngressRulesChain = "ANTREA-POL-INGRESS-RULES"
+	// nodeEgressRulesChain is the chain in the filter table which holds the egress rules applied to the Node.
+	nodeEgressRulesChain = "ANTREA-POL-EGRESS-RULES"
+)
+
+// iptablesNodeReconciler implements nodeReconciler with iptables. All the rules applied to the Node
+// are kept in memory, and the whole chains are re-generated with iptables-restore whenever a rule
+// changes, as the order of the iptables rules must follow the priorities of the policy rules.
+type iptablesNodeReconciler struct {
+	ipt         iptables.Interface
+	ipv4Enabled bool
+	ipv6Enabled bool
+
+	mutex sync.Mutex
+	// rules stores the CompletedRules which are applied to the Node, keyed by rule ID.
+	rules map[string]*CompletedRule
+	// initialized is true once the chains and the jump rules have been created.
+	initialized bool
+}
+
+func newNodeReconciler(ipv4Enabled, ipv6Enabled bool) (nodeReconciler, error) {
+	ipt, err := iptables.New(ipv4Enabled, ipv6Enabled)
+	if err != nil {
+		return nil, fmt.Errorf("error creating iptables client: %v", err)
+	}
+	return newIPTablesNodeReconciler(ipt, ipv4Enabled, ipv6Enabled), nil
+}
+
+func newIPTablesNodeReconciler(ipt iptables.Interface, ipv4Enabled, ipv6Enabled bool) *iptablesNodeReconciler {
+	return &iptablesNodeReconciler{
+		ipt:         ipt,
+		ipv4Enabled: ipv4Enabled,
+		ipv6Enabled: ipv6Enabled,
+		rules:       map[string]*CompletedRule{},
+	}
+}
+
+func (r *iptablesNodeReconciler) Reconcile(rule *CompletedRule) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.rules[rule.ID] = rule
+	return r.syncLocked()
+}
+
+func (r *iptablesNodeReconciler) BatchReconcile(rules []*CompletedRule) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, rule := range rules {
+		r.rules[rule.ID] = rule
+	}
+	return r.syncLocked()
+}
+
+func (r *iptablesNodeReconciler) Forget(ruleID string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, exists := r.rules[ruleID]; !exists {
+		return nil
+	}
+	delete(r.rules, ruleID)
+	return r.syncLocked()
+}
+
+// initializeLocked creates the Antrea managed chains and links them to the built-in chains. We cannot
+// use iptables-restore for the jump rules because there are non Antrea managed rules in built-in chains.
+func (r *iptablesNodeReconciler) initializeLocked() error {
+	jumpRules := []struct {
+		srcChain string
+		dstChain string
+		comment  string
+	}{
+		{iptables.InputChain, nodeIngressRulesChain, "Antrea: jump to Antrea Node ingress policy rules"},
+		{iptables.OutputChain, nodeEgressRulesChain, "Antrea: jump to Antrea Node egress policy rules"},
+	}
+	for _, rule := range jumpRules {
+		if err := r.ipt.EnsureChain(iptables.ProtocolDual, iptables.FilterTable, rule.dstChain); err != nil {
+			return err
+		}
+		ruleSpec := []string{"-j", rule.dstChain, "-m", "comment", "--comment", rule.comment}
+		if err := r.ipt.AppendRule(iptables.ProtocolDual, iptables.FilterTable, rule.srcChain, ruleSpec); err != nil {
+			return err
+		}
+	}
+	r.initialized = true
+	return nil
+}
+
+func (r *iptablesNodeReconciler) syncLocked() error {
+	if !r.initialized {
+		if err := r.initializeLocked(); err != nil {
+			return err
+		}
+	}
+	rules := make([]*CompletedRule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+	// Sort the rules so that the ones with higher precedence come first.
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Less(rules[j].rule) != rules[j].Less(rules[i].rule) {
+			return rules[j].Less(rules[i].rule)
+		}
+		return rules[i].ID < rules[j].ID
+	})
+	if r.ipv4Enabled {
+		// Setting --noflush to keep the previous contents (i.e. non antrea managed chains) of the table.
+		if err := r.ipt.Restore(buildNodeIPTablesData(rules, false).String(), false, false); err != nil {
+			return err
+		}
+	}
+	if r.ipv6Enabled {
+		if err := r.ipt.Restore(buildNodeIPTablesData(rules, true).String(), false, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildNodeIPTablesData generates the iptables-restore input for the provided rules, which must be
+// sorted by precedence already.
+func buildNodeIPTablesData(rules []*CompletedRule, isIPv6 bool) *bytes.Buffer {
+	iptablesData := bytes.NewBuffer(nil)
+	writeLine(iptablesData, "*filter")
+	writeLine(iptablesData, iptables.MakeChainLine(nodeIngressRulesChain))
+	writeLine(iptablesData, iptables.MakeChainLine(nodeEgressRulesChain))
+	for _, chain := range []struct {
+		name      string
+		ifaceFlag string
+	}{
+		{nodeIngressRulesChain, "-i"},
+		{nodeEgressRulesChain, "-o"},
+	} {
+		writeLine(iptablesData, []string{
+			"-A", chain.name,
+			"-m", "comment", "--comment", `"Antrea: skip loopback traffic"`,
+			chain.ifaceFlag, "lo",
+			"-j", iptables.ReturnTarget,
+		}...)
+		writeLine(iptablesData, []string{
+			"-A", chain.name,
+			"-m", "comment", "--comment", `"Antrea: skip established connections"`,
+			"-m", "conntrack", "--ctstate", "ESTABLISHED,RELATED",
+			"-j", iptables.ReturnTarget,
+		}...)
+	}
+	for _, rule := range rules {
+		for _, ruleSpec := range buildNodeRuleSpecs(rule, isIPv6) {
+			writeLine(iptablesData, ruleSpec...)
+		}
+	}
+	writeLine(iptablesData, "COMMIT")
+	return iptablesData
+}
+
+// buildNodeRuleSpecs generates the iptables rules of a CompletedRule for the given address family.
+// A rule is generated for each combination of peer address and service.
+func buildNodeRuleSpecs(rule *CompletedRule, isIPv6 bool) [][]string {
+	chain, addrFlag := nodeIngressRulesChain, "-s"
+	peer, members := rule.From, rule.FromAddresses
+	if rule.Direction == v1beta2.DirectionOut {
+		chain, addrFlag = nodeEgressRulesChain, "-d"
+		peer, members = rule.To, rule.ToAddresses
+	}
+	target := nodeRuleTarget(rule.Action)
+	comment := fmt.Sprintf(`"Antrea: %s rule %s"`, rule.SourceRef.ToString(), rule.Name)
+
+	// nil means the rule is not restricted by addresses.
+	var addresses []string
+	if len(peer.AddressGroups) > 0 || len(peer.IPBlocks) > 0 {
+		addresses = getNodeRuleAddresses(peer.IPBlocks, members, isIPv6)
+		if len(addresses) == 0 {
+			// None of the peers is in this address family.
+			return nil
+		}
+	} else {
+		addresses = []string{""}
+	}
+	serviceMatches := getNodeRuleServiceMatches(rule.Services, isIPv6)
+	var ruleSpecs [][]string
+	for _, address := range addresses {
+		for _, serviceMatch := range serviceMatches {
+			ruleSpec := []string{"-A", chain, "-m", "comment", "--comment", comment}
+			if address != "" {
+				ruleSpec = append(ruleSpec, addrFlag, address)
+			}
+			ruleSpec = append(ruleSpec, serviceMatch...)
+			ruleSpec = append(ruleSpec, "-j", target)
+			ruleSpecs = append(ruleSpecs, ruleSpec)
+		}
+	}
+	return ruleSpecs
+}
+
+func nodeRuleTarget(action *crdv1alpha1.RuleAction) string {
+	if action == nil {
+		return iptables.AcceptTarget
+	}
+	switch *action {
+	case crdv1alpha1.RuleActionDrop:
+		return iptables.DROPTarget
+	case crdv1alpha1.RuleActionReject:
+		return iptables.RejectTarget
+	case crdv1alpha1.RuleActionPass:
+		// Skip the remaining Antrea rules and let the traffic be handled by other host firewall rules.
+		return iptables.ReturnTarget
+	default:
+		return iptables.AcceptTarget
+	}
+}
+
+// getNodeRuleAddresses returns the CIDRs of the provided IPBlocks and GroupMembers in the given address
+// family.
+func getNodeRuleAddresses(ipBlocks []v1beta2.IPBlock, members v1beta2.GroupMemberSet, isIPv6 bool) []string {
+	var addresses []string
+	matchFamily := func(ipAddr net.IP) bool {
+		return (ipAddr.To4() == nil) == isIPv6
+	}
+	for _, b := range ipBlocks {
+		blockCIDR := ip.IPNetToNetIPNet(&b.CIDR)
+		if !matchFamily(blockCIDR.IP) {
+			continue
+		}
+		exceptIPNets := make([]*net.IPNet, 0, len(b.Except))
+		for i := range b.Except {
+			exceptIPNets = append(exceptIPNets, ip.IPNetToNetIPNet(&b.Except[i]))
+		}
+		diffCIDRs, err := ip.DiffFromCIDRs(blockCIDR, exceptIPNets)
+		if err != nil {
+			klog.ErrorS(err, "Error when computing effective CIDRs by removing except IPNets from IPBlock")
+			continue
+		}
+		for _, d := range diffCIDRs {
+			addresses = append(addresses, d.String())
+		}
+	}
+	for _, member := range members {
+		for _, ipAddr := range member.IPs {
+			memberIP := net.IP(ipAddr)
+			if matchFamily(memberIP) {
+				addresses = append(addresses, memberIP.String())
+			}
+		}
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// getNodeRuleServiceMatches returns the iptables match arguments of the provided Services. Services
+// which cannot be enforced on the Node, e.g. named ports and IGMP, are ignored.
+func getNodeRuleServiceMatches(services []v1beta2.Service, isIPv6 bool) [][]string {
+	if len(services) == 0 {
+		return [][]string{nil}
+	}
+	var matches [][]string
+	for _, svc := range services {
+		protocol := v1beta2.ProtocolTCP
+		if svc.Protocol != nil {
+			protocol = *svc.Protocol
+		}
+		var match []string
+		switch protocol {
+		case v1beta2.ProtocolTCP, v1beta2.ProtocolUDP, v1beta2.ProtocolSCTP:
+			proto := strings.ToLower(string(protocol))
+			match = []string{"-p", proto}
+			if svc.Port != nil {
+				if svc.Port.IntValue() == 0 {
+					klog.V(2).InfoS("Named port cannot be resolved for Node, skipping it", "port", svc.Port.String())
+					continue
+				}
+				match = append(match, "--dport", portRange(int32(svc.Port.IntValue()), svc.EndPort))
+			}
+			if svc.SrcPort != nil {
+				match = append(match, "--sport", portRange(*svc.SrcPort, svc.SrcEndPort))
+			}
+		case v1beta2.ProtocolICMP:
+			proto, typeFlag := "icmp", "--icmp-type"
+			if isIPv6 {
+				proto, typeFlag = "ipv6-icmp", "--icmpv6-type"
+			}
+			match = []string{"-p", proto}
+			if svc.ICMPType != nil {
+				icmpType := strconv.Itoa(int(*svc.ICMPType))
+				if svc.ICMPCode != nil {
+					icmpType = fmt.Sprintf("%s/%d", icmpType, *svc.ICMPCode)
+				}
+				match = append(match, typeFlag, icmpType)
+			}
+		default:
+			klog.V(2).InfoS("Protocol is not supported for Node, skipping it", "protocol", protocol)
+			continue
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+func portRange(port int32, endPort *int32) string {
+	if endPort == nil || *endPort == port {
+		return strconv.Itoa(int(port))
+	}
+	return fmt.Sprintf("%d:%d", port, *endPort)
+}
+
+func writeLine(buf *bytes.Buffer, words ...string) {
+	buf.WriteString(strings.Join(words, " "))
+	buf.WriteByte('\n')
+}
@@ -0,0 +1,194 @@
+//go:build linux
+// +build linux
+
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"antrea.io/antrea/pkg/agent/util/iptables"
+	iptablestest "antrea.io/antrea/pkg/agent/util/iptables/testing"
+	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+)
+
+var (
+	nodeTargetMembers = v1beta2.NewGroupMemberSet(&v1beta2.GroupMember{Node: &v1beta2.NodeReference{Name: "node1"}})
+	acnpSourceRef     = &v1beta2.NetworkPolicyReference{Type: v1beta2.AntreaClusterNetworkPolicy, Name: "acnp1"}
+)
+
+func newNodeRule(id string, direction v1beta2.Direction, priority int32, action crdv1alpha1.RuleAction, peer v1beta2.NetworkPolicyPeer, addresses v1beta2.GroupMemberSet, services []v1beta2.Service) *CompletedRule {
+	policyPriority := float64(1)
+	tierPriority := int32(250)
+	r := &rule{
+		ID:             id,
+		Direction:      direction,
+		Services:       services,
+		Name:           id,
+		Action:         &action,
+		Priority:       priority,
+		PolicyPriority: &policyPriority,
+		TierPriority:   &tierPriority,
+		SourceRef:      acnpSourceRef,
+	}
+	completedRule := &CompletedRule{rule: r, TargetMembers: nodeTargetMembers}
+	if direction == v1beta2.DirectionIn {
+		r.From = peer
+		completedRule.FromAddresses = addresses
+	} else {
+		r.To = peer
+		completedRule.ToAddresses = addresses
+	}
+	return completedRule
+}
+
+func TestIsNodeNetworkPolicyRule(t *testing.T) {
+	nodeRule := newNodeRule("rule1", v1beta2.DirectionIn, 0, crdv1alpha1.RuleActionAllow, v1beta2.NetworkPolicyPeer{}, nil, nil)
+	assert.True(t, isNodeNetworkPolicyRule(nodeRule))
+	podRule := &CompletedRule{rule: &rule{ID: "rule2"}, TargetMembers: appliedToGroup1}
+	assert.False(t, isNodeNetworkPolicyRule(podRule))
+	assert.False(t, isNodeNetworkPolicyRule(&CompletedRule{rule: &rule{ID: "rule3"}}))
+}
+
+func TestBuildNodeRuleSpecs(t *testing.T) {
+	port80 := intstr.FromInt(80)
+	portHTTP := intstr.FromString("http")
+	endPort := int32(90)
+	protocolTCP := v1beta2.ProtocolTCP
+	protocolICMP := v1beta2.ProtocolICMP
+	icmpType := int32(8)
+	icmpCode := int32(0)
+	ingressComment := `"Antrea: AntreaClusterNetworkPolicy:acnp1 rule ingress-rule"`
+	egressComment := `"Antrea: AntreaClusterNetworkPolicy:acnp1 rule egress-rule"`
+
+	tests := []struct {
+		name              string
+		rule              *CompletedRule
+		isIPv6            bool
+		expectedRuleSpecs [][]string
+	}{
+		{
+			name: "ingress any",
+			rule: newNodeRule("ingress-rule", v1beta2.DirectionIn, 0, crdv1alpha1.RuleActionDrop, v1beta2.NetworkPolicyPeer{}, nil, nil),
+			expectedRuleSpecs: [][]string{
+				{"-A", nodeIngressRulesChain, "-m", "comment", "--comment", ingressComment, "-j", iptables.DROPTarget},
+			},
+		},
+		{
+			name: "ingress from address group with port range",
+			rule: newNodeRule("ingress-rule", v1beta2.DirectionIn, 0, crdv1alpha1.RuleActionAllow,
+				v1beta2.NetworkPolicyPeer{AddressGroups: []string{"ag1"}},
+				v1beta2.NewGroupMemberSet(newAddressGroupMember("1.1.1.2"), newAddressGroupMember("1.1.1.1", "2002:1a23:fb44::1")),
+				[]v1beta2.Service{{Protocol: &protocolTCP, Port: &port80, EndPort: &endPort}}),
+			expectedRuleSpecs: [][]string{
+				{"-A", nodeIngressRulesChain, "-m", "comment", "--comment", ingressComment, "-s", "1.1.1.1", "-p", "tcp", "--dport", "80:90", "-j", iptables.AcceptTarget},
+				{"-A", nodeIngressRulesChain, "-m", "comment", "--comment", ingressComment, "-s", "1.1.1.2", "-p", "tcp", "--dport", "80:90", "-j", iptables.AcceptTarget},
+			},
+		},
+		{
+			name: "ingress from address group in other family",
+			rule: newNodeRule("ingress-rule", v1beta2.DirectionIn, 0, crdv1alpha1.RuleActionAllow,
+				v1beta2.NetworkPolicyPeer{AddressGroups: []string{"ag1"}},
+				v1beta2.NewGroupMemberSet(newAddressGroupMember("1.1.1.1")),
+				nil),
+			isIPv6:            true,
+			expectedRuleSpecs: nil,
+		},
+		{
+			name: "egress to IPBlock with except and ICMP",
+			rule: newNodeRule("egress-rule", v1beta2.DirectionOut, 0, crdv1alpha1.RuleActionReject,
+				v1beta2.NetworkPolicyPeer{IPBlocks: []v1beta2.IPBlock{
+					{
+						CIDR:   v1beta2.IPNet{IP: v1beta2.IPAddress(newCIDR("10.0.0.0/24").IP), PrefixLength: 24},
+						Except: []v1beta2.IPNet{{IP: v1beta2.IPAddress(newCIDR("10.0.0.0/25").IP), PrefixLength: 25}},
+					},
+				}},
+				nil,
+				[]v1beta2.Service{{Protocol: &protocolICMP, ICMPType: &icmpType, ICMPCode: &icmpCode}}),
+			expectedRuleSpecs: [][]string{
+				{"-A", nodeEgressRulesChain, "-m", "comment", "--comment", egressComment, "-d", "10.0.0.128/25", "-p", "icmp", "--icmp-type", "8/0", "-j", iptables.RejectTarget},
+			},
+		},
+		{
+			name: "egress IPv6 with named port skipped",
+			rule: newNodeRule("egress-rule", v1beta2.DirectionOut, 0, crdv1alpha1.RuleActionPass,
+				v1beta2.NetworkPolicyPeer{AddressGroups: []string{"ag1"}},
+				v1beta2.NewGroupMemberSet(newAddressGroupMember("1.1.1.1", "2002:1a23:fb44::1")),
+				[]v1beta2.Service{{Protocol: &protocolTCP, Port: &portHTTP}, {Protocol: &protocolICMP}}),
+			isIPv6: true,
+			expectedRuleSpecs: [][]string{
+				{"-A", nodeEgressRulesChain, "-m", "comment", "--comment", egressComment, "-d", "2002:1a23:fb44::1", "-p", "ipv6-icmp", "-j", iptables.ReturnTarget},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedRuleSpecs, buildNodeRuleSpecs(tt.rule, tt.isIPv6))
+		})
+	}
+}
+
+func TestIPTablesNodeReconciler(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockIPTables := iptablestest.NewMockInterface(controller)
+	r := newIPTablesNodeReconciler(mockIPTables, true, false)
+
+	rule1 := newNodeRule("rule1", v1beta2.DirectionIn, 1, crdv1alpha1.RuleActionDrop, v1beta2.NetworkPolicyPeer{}, nil, nil)
+	rule2 := newNodeRule("rule2", v1beta2.DirectionIn, 0, crdv1alpha1.RuleActionAllow,
+		v1beta2.NetworkPolicyPeer{AddressGroups: []string{"ag1"}},
+		v1beta2.NewGroupMemberSet(newAddressGroupMember("1.1.1.1")),
+		nil)
+
+	expectedHeader := []string{
+		"*filter",
+		":ANTREA-POL-INGRESS-RULES - [0:0]",
+		":ANTREA-POL-EGRESS-RULES - [0:0]",
+		`-A ANTREA-POL-INGRESS-RULES -m comment --comment "Antrea: skip loopback traffic" -i lo -j RETURN`,
+		`-A ANTREA-POL-INGRESS-RULES -m comment --comment "Antrea: skip established connections" -m conntrack --ctstate ESTABLISHED,RELATED -j RETURN`,
+		`-A ANTREA-POL-EGRESS-RULES -m comment --comment "Antrea: skip loopback traffic" -o lo -j RETURN`,
+		`-A ANTREA-POL-EGRESS-RULES -m comment --comment "Antrea: skip established connections" -m conntrack --ctstate ESTABLISHED,RELATED -j RETURN`,
+	}
+	rule1Line := `-A ANTREA-POL-INGRESS-RULES -m comment --comment "Antrea: AntreaClusterNetworkPolicy:acnp1 rule rule1" -j DROP`
+	rule2Line := `-A ANTREA-POL-INGRESS-RULES -m comment --comment "Antrea: AntreaClusterNetworkPolicy:acnp1 rule rule2" -s 1.1.1.1 -j ACCEPT`
+	makeData := func(lines ...string) string {
+		lines = append(append(append([]string{}, expectedHeader...), lines...), "COMMIT")
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	mockIPTables.EXPECT().EnsureChain(iptables.ProtocolDual, iptables.FilterTable, nodeIngressRulesChain)
+	mockIPTables.EXPECT().AppendRule(iptables.ProtocolDual, iptables.FilterTable, iptables.InputChain, gomock.Any())
+	mockIPTables.EXPECT().EnsureChain(iptables.ProtocolDual, iptables.FilterTable, nodeEgressRulesChain)
+	mockIPTables.EXPECT().AppendRule(iptables.ProtocolDual, iptables.FilterTable, iptables.OutputChain, gomock.Any())
+	mockIPTables.EXPECT().Restore(makeData(rule1Line), false, false)
+	require.NoError(t, r.Reconcile(rule1))
+
+	// rule2 has a higher precedence than rule1 as its priority is smaller.
+	mockIPTables.EXPECT().Restore(makeData(rule2Line, rule1Line), false, false)
+	require.NoError(t, r.BatchReconcile([]*CompletedRule{rule2}))
+
+	mockIPTables.EXPECT().Restore(makeData(rule2Line), false, false)
+	require.NoError(t, r.Forget("rule1"))
+
+	// Forgetting an unknown rule should be a no-op.
+	require.NoError(t, r.Forget("rule1"))
+}
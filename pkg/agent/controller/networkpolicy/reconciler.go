@@ -27,6 +27,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/metrics"
 	"antrea.io/antrea/pkg/agent/openflow"
 	proxytypes "antrea.io/antrea/pkg/agent/proxy/types"
 	"antrea.io/antrea/pkg/agent/types"
@@ -38,6 +39,9 @@ import (
 
 var (
 	baselineTierPriority int32 = 253
+	// addressUpdateBatchSize is the maximum number of addresses added to or deleted from an Openflow rule in a
+	// single call to the Openflow client. It's a variable to allow overriding it in tests.
+	addressUpdateBatchSize = 1000
 )
 
 type ruleType int
@@ -68,6 +72,10 @@ type Reconciler interface {
 	// GetRuleByFlowID returns the rule from the async rule cache in idAllocator cache.
 	GetRuleByFlowID(ruleID uint32) (*types.PolicyRule, bool, error)
 
+	// ExpireDeletedRules deletes the rules pending async deletion from the cache in
+	// idAllocator immediately, and returns the number of deleted rules.
+	ExpireDeletedRules() int
+
 	// RunIDAllocatorWorker runs the worker that deletes the rules from the cache
 	// in idAllocator.
 	RunIDAllocatorWorker(stopCh <-chan struct{})
@@ -163,7 +171,7 @@ type lastRealized struct {
 	podIPs sets.Set[string]
 	// fqdnIPaddresses tracks the last realized set of IP addresses resolved for
 	// the fqdn selector of this policy rule. It must be empty for policy rule
-	// that is not egress and does not have toFQDN field.
+	// that does not have fqdn peers.
 	fqdnIPAddresses sets.Set[string]
 	// serviceGroupIDs tracks the last realized set of groupIDs resolved for the
 	// toServices of this policy rule or services of TargetMember of this policy rule.
@@ -539,6 +547,7 @@ func (r *reconciler) computeOFRulesForAdd(rule *CompletedRule, ofPriority *uint1
 			PolicyRef:     rule.SourceRef,
 			EnableLogging: rule.EnableLogging,
 			LogLabel:      rule.LogLabel,
+			RateLimit:     rule.RateLimit,
 		}
 		return ofRuleByServicesMap, lastRealized
 	} else if isIGMP {
@@ -548,11 +557,23 @@ func (r *reconciler) computeOFRulesForAdd(rule *CompletedRule, ofPriority *uint1
 	if rule.Direction == v1beta2.DirectionIn {
 		isRuleAppliedToService := isRuleAppliedToService(rule.TargetMembers)
 		// Addresses got from source GroupMembers' IPs.
-		from1 := groupMembersToOFAddresses(rule.FromAddresses)
+		from1 := groupMembersToOFAddresses(rule.FromAddresses, isRuleAppliedToService)
 		// Get addresses that in From IPBlock but not in Except IPBlocks.
 		from2 := ipBlocksToOFAddresses(rule.From.IPBlocks, r.ipv4Enabled, r.ipv6Enabled, isRuleAppliedToService)
 		from3 := labelIDToOFAddresses(rule.From.LabelIdentities)
 		from := append(from1, append(from2, from3...)...)
+		if r.fqdnController != nil && len(rule.From.FQDNs) > 0 {
+			// No DNS response needs to be intercepted for the Pods selected by an ingress FQDN
+			// rule, the addresses of the FQDNs are resolved by the fqdnController or learned
+			// from the DNS responses intercepted for the other Pods on this Node.
+			if err := r.fqdnController.addFQDNRule(rule.ID, rule.From.FQDNs, nil); err != nil {
+				klog.ErrorS(err, "Error when adding FQDN rule", "ruleID", rule.ID)
+			}
+			addressSet := r.getFQDNAddressSet(rule.From.FQDNs)
+			from = append(from, ipsToOFAddresses(addressSet)...)
+			// If the rule installation fails, this will be reset.
+			lastRealized.fqdnIPAddresses = addressSet
+		}
 		membersByServicesMap, servicesMap := groupMembersByServices(rule.Services, rule.TargetMembers)
 		for svcKey, members := range membersByServicesMap {
 			var toAddresses []types.Address
@@ -582,6 +603,7 @@ func (r *reconciler) computeOFRulesForAdd(rule *CompletedRule, ofPriority *uint1
 				PolicyRef:     rule.SourceRef,
 				EnableLogging: rule.EnableLogging,
 				LogLabel:      rule.LogLabel,
+				RateLimit:     rule.RateLimit,
 			}
 		}
 	} else {
@@ -602,7 +624,7 @@ func (r *reconciler) computeOFRulesForAdd(rule *CompletedRule, ofPriority *uint1
 			ofRuleByServicesMap[svcKey] = &types.PolicyRule{
 				Direction:     v1beta2.DirectionOut,
 				From:          from,
-				To:            groupMembersToOFAddresses(members),
+				To:            groupMembersToOFAddresses(members, false),
 				Service:       filterUnresolvablePort(servicesMap[svcKey]),
 				L7Protocols:   rule.L7Protocols,
 				L7RuleVlanID:  rule.L7RuleVlanID,
@@ -613,6 +635,7 @@ func (r *reconciler) computeOFRulesForAdd(rule *CompletedRule, ofPriority *uint1
 				PolicyRef:     rule.SourceRef,
 				EnableLogging: rule.EnableLogging,
 				LogLabel:      rule.LogLabel,
+				RateLimit:     rule.RateLimit,
 			}
 		}
 
@@ -638,6 +661,7 @@ func (r *reconciler) computeOFRulesForAdd(rule *CompletedRule, ofPriority *uint1
 					PolicyRef:     rule.SourceRef,
 					EnableLogging: rule.EnableLogging,
 					LogLabel:      rule.LogLabel,
+					RateLimit:     rule.RateLimit,
 				}
 				ofRuleByServicesMap[svcKey] = ofRule
 			}
@@ -738,6 +762,7 @@ func (r *reconciler) update(lastRealized *lastRealized, newRule *CompletedRule,
 				PolicyRef:     newRule.SourceRef,
 				EnableLogging: newRule.EnableLogging,
 				LogLabel:      newRule.LogLabel,
+				RateLimit:     newRule.RateLimit,
 			}
 			err := r.idAllocator.allocateForRule(ofRule)
 			if err != nil {
@@ -764,10 +789,28 @@ func (r *reconciler) update(lastRealized *lastRealized, newRule *CompletedRule,
 	// only happen to Group members.
 	if newRule.Direction == v1beta2.DirectionIn {
 		isRuleAppliedToService := isRuleAppliedToService(newRule.TargetMembers)
-		from1 := groupMembersToOFAddresses(newRule.FromAddresses)
+		from1 := groupMembersToOFAddresses(newRule.FromAddresses, isRuleAppliedToService)
 		from2 := ipBlocksToOFAddresses(newRule.From.IPBlocks, r.ipv4Enabled, r.ipv6Enabled, isRuleAppliedToService)
-		addedFrom := ipsToOFAddresses(newRule.FromAddresses.IPDifference(lastRealized.FromAddresses))
-		deletedFrom := ipsToOFAddresses(lastRealized.FromAddresses.IPDifference(newRule.FromAddresses))
+		fromIPsToOFAddresses := ipsToOFAddresses
+		if isRuleAppliedToService {
+			fromIPsToOFAddresses = ctIPsToOFAddresses
+		}
+		addedFrom := fromIPsToOFAddresses(newRule.FromAddresses.IPDifference(lastRealized.FromAddresses))
+		deletedFrom := fromIPsToOFAddresses(lastRealized.FromAddresses.IPDifference(newRule.FromAddresses))
+		var newFQDNAddressSet sets.Set[string]
+		if r.fqdnController != nil && len(newRule.From.FQDNs) > 0 {
+			if err := r.fqdnController.addFQDNRule(newRule.ID, newRule.From.FQDNs, nil); err != nil {
+				return fmt.Errorf("error when adding FQDN rule %s: %w", newRule.ID, err)
+			}
+			originalFQDNAddressSet := sets.New[string]()
+			if lastRealized.fqdnIPAddresses != nil {
+				originalFQDNAddressSet = lastRealized.fqdnIPAddresses
+			}
+			newFQDNAddressSet = r.getFQDNAddressSet(newRule.From.FQDNs)
+			from2 = append(from2, ipsToOFAddresses(newFQDNAddressSet)...)
+			addedFrom = append(addedFrom, ipsToOFAddresses(newFQDNAddressSet.Difference(originalFQDNAddressSet))...)
+			deletedFrom = append(deletedFrom, ipsToOFAddresses(originalFQDNAddressSet.Difference(newFQDNAddressSet))...)
+		}
 
 		membersByServicesMap, servicesMap := groupMembersByServices(newRule.Services, newRule.TargetMembers)
 		for svcKey, members := range membersByServicesMap {
@@ -796,6 +839,7 @@ func (r *reconciler) update(lastRealized *lastRealized, newRule *CompletedRule,
 					PolicyRef:     newRule.SourceRef,
 					EnableLogging: newRule.EnableLogging,
 					LogLabel:      newRule.LogLabel,
+					RateLimit:     newRule.RateLimit,
 				}
 				err := r.idAllocator.allocateForRule(ofRule)
 				if err != nil {
@@ -831,6 +875,10 @@ func (r *reconciler) update(lastRealized *lastRealized, newRule *CompletedRule,
 			lastRealized.podOFPorts[svcKey] = newOFPorts
 			lastRealized.serviceGroupIDs = newGroupIDSet
 		}
+		if r.fqdnController != nil && len(newRule.From.FQDNs) > 0 {
+			// Update the FQDN address set if rule installation succeeds.
+			lastRealized.fqdnIPAddresses = newFQDNAddressSet
+		}
 	} else {
 		if r.fqdnController != nil && len(newRule.To.FQDNs) > 0 {
 			if err := r.fqdnController.addFQDNRule(newRule.ID, newRule.To.FQDNs, r.getOFPorts(newRule.TargetMembers)); err != nil {
@@ -857,7 +905,7 @@ func (r *reconciler) update(lastRealized *lastRealized, newRule *CompletedRule,
 				ofRule := &types.PolicyRule{
 					Direction:     v1beta2.DirectionOut,
 					From:          from,
-					To:            groupMembersToOFAddresses(members),
+					To:            groupMembersToOFAddresses(members, false),
 					Service:       filterUnresolvablePort(servicesMap[svcKey]),
 					L7Protocols:   newRule.L7Protocols,
 					L7RuleVlanID:  newRule.L7RuleVlanID,
@@ -868,6 +916,7 @@ func (r *reconciler) update(lastRealized *lastRealized, newRule *CompletedRule,
 					PolicyRef:     newRule.SourceRef,
 					EnableLogging: newRule.EnableLogging,
 					LogLabel:      newRule.LogLabel,
+					RateLimit:     newRule.RateLimit,
 				}
 				// If the PolicyRule for the original services doesn't exist and IPBlocks is present, it means the
 				// reconciler hasn't installed flows for IPBlocks, then it must be added to the new PolicyRule.
@@ -951,32 +1000,55 @@ func (r *reconciler) installOFRule(ofRule *types.PolicyRule) error {
 	return nil
 }
 
+// updateOFRule applies the address deltas of a policy rule to its existing Openflow rule. Only the conjunctive match
+// flows of the added and deleted addresses are changed, and the addresses are sent in batches of at most
+// addressUpdateBatchSize, so that a large update, e.g. when a Deployment selected by an address group is scaled
+// rapidly, doesn't result in a single huge OpenFlow bundle.
 func (r *reconciler) updateOFRule(ofID uint32, addedFrom []types.Address, addedTo []types.Address, deletedFrom []types.Address, deletedTo []types.Address, priority *uint16, enableLogging, isMCNPRule bool) error {
 	klog.V(2).InfoS("Updating ofRule", "id", ofID, "addedFrom", len(addedFrom), "addedTo", len(addedTo), "deletedFrom", len(deletedFrom), "deletedTo", len(deletedTo))
+	if added := len(addedFrom) + len(addedTo); added > 0 {
+		metrics.NetworkPolicyAddressUpdateSize.WithLabelValues("add").Observe(float64(added))
+	}
+	if deleted := len(deletedFrom) + len(deletedTo); deleted > 0 {
+		metrics.NetworkPolicyAddressUpdateSize.WithLabelValues("delete").Observe(float64(deleted))
+	}
 	// TODO: This might be unnecessarily complex and hard for error handling, consider revising the Openflow interfaces.
-	if len(addedFrom) > 0 {
-		if err := r.ofClient.AddPolicyRuleAddress(ofID, types.SrcAddress, addedFrom, priority, enableLogging, isMCNPRule); err != nil {
+	for _, batch := range batchAddresses(addedFrom, addressUpdateBatchSize) {
+		if err := r.ofClient.AddPolicyRuleAddress(ofID, types.SrcAddress, batch, priority, enableLogging, isMCNPRule); err != nil {
 			return fmt.Errorf("error adding policy rule source addresses for ofRule %v: %v", ofID, err)
 		}
 	}
-	if len(addedTo) > 0 {
-		if err := r.ofClient.AddPolicyRuleAddress(ofID, types.DstAddress, addedTo, priority, enableLogging, isMCNPRule); err != nil {
+	for _, batch := range batchAddresses(addedTo, addressUpdateBatchSize) {
+		if err := r.ofClient.AddPolicyRuleAddress(ofID, types.DstAddress, batch, priority, enableLogging, isMCNPRule); err != nil {
 			return fmt.Errorf("error adding policy rule destination addresses for ofRule %v: %v", ofID, err)
 		}
 	}
-	if len(deletedFrom) > 0 {
-		if err := r.ofClient.DeletePolicyRuleAddress(ofID, types.SrcAddress, deletedFrom, priority); err != nil {
+	for _, batch := range batchAddresses(deletedFrom, addressUpdateBatchSize) {
+		if err := r.ofClient.DeletePolicyRuleAddress(ofID, types.SrcAddress, batch, priority); err != nil {
 			return fmt.Errorf("error deleting policy rule source addresses for ofRule %v: %v", ofID, err)
 		}
 	}
-	if len(deletedTo) > 0 {
-		if err := r.ofClient.DeletePolicyRuleAddress(ofID, types.DstAddress, deletedTo, priority); err != nil {
+	for _, batch := range batchAddresses(deletedTo, addressUpdateBatchSize) {
+		if err := r.ofClient.DeletePolicyRuleAddress(ofID, types.DstAddress, batch, priority); err != nil {
 			return fmt.Errorf("error deleting policy rule destination addresses for ofRule %v: %v", ofID, err)
 		}
 	}
 	return nil
 }
 
+// batchAddresses splits the addresses into batches of at most batchSize addresses.
+func batchAddresses(addresses []types.Address, batchSize int) [][]types.Address {
+	var batches [][]types.Address
+	for len(addresses) > batchSize {
+		batches = append(batches, addresses[:batchSize])
+		addresses = addresses[batchSize:]
+	}
+	if len(addresses) > 0 {
+		batches = append(batches, addresses)
+	}
+	return batches
+}
+
 func (r *reconciler) uninstallOFRule(ofID uint32, table uint8) error {
 	klog.V(2).InfoS("Uninstalling ofRule", "id", ofID)
 	stalePriorities, err := r.ofClient.UninstallPolicyRuleFlows(ofID)
@@ -1026,7 +1098,7 @@ func (r *reconciler) Forget(ruleID string) error {
 		delete(lastRealized.podOFPorts, svcKey)
 	}
 	if r.fqdnController != nil {
-		r.fqdnController.deleteFQDNRule(ruleID, lastRealized.To.FQDNs)
+		r.fqdnController.deleteFQDNRule(ruleID, getRuleFQDNs(lastRealized.CompletedRule))
 	}
 	r.lastRealizeds.Delete(ruleID)
 	return nil
@@ -1045,6 +1117,10 @@ func (r *reconciler) GetRuleByFlowID(ruleFlowID uint32) (*types.PolicyRule, bool
 	return r.idAllocator.getRuleFromAsyncCache(ruleFlowID)
 }
 
+func (r *reconciler) ExpireDeletedRules() int {
+	return r.idAllocator.expireRules()
+}
+
 func (r *reconciler) getOFPorts(members v1beta2.GroupMemberSet) sets.Set[int32] {
 	ofPorts := sets.New[int32]()
 	for _, m := range members {
@@ -1182,12 +1258,19 @@ func svcGroupIDsToOFAddresses(groupIDs sets.Set[int64]) []types.Address {
 	return addresses
 }
 
-func groupMembersToOFAddresses(groupMemberSet v1beta2.GroupMemberSet) []types.Address {
+// groupMembersToOFAddresses converts the IPs of the GroupMembers to OpenFlow addresses. If ctMatch is true,
+// the addresses match the source IP of the original direction of the connection, which is required when the
+// rule is applied to Services as the packets may have been SNAT'd before reaching the policy tables.
+func groupMembersToOFAddresses(groupMemberSet v1beta2.GroupMemberSet, ctMatch bool) []types.Address {
 	// Must not return nil as it means not restricted by addresses in Openflow implementation.
 	addresses := make([]types.Address, 0, len(groupMemberSet))
 	for _, member := range groupMemberSet {
 		for _, ip := range member.IPs {
-			addresses = append(addresses, openflow.NewIPAddress(net.IP(ip)))
+			if ctMatch {
+				addresses = append(addresses, openflow.NewCTIPAddress(net.IP(ip)))
+			} else {
+				addresses = append(addresses, openflow.NewIPAddress(net.IP(ip)))
+			}
 		}
 	}
 	return addresses
@@ -1245,6 +1328,24 @@ func isIPNetSupportedByAF(ipnet *net.IPNet, ipv4Enabled, ipv6Enabled bool) bool
 	return false
 }
 
+// getFQDNAddressSet returns the IP addresses currently resolved for the provided FQDNs.
+func (r *reconciler) getFQDNAddressSet(fqdns []string) sets.Set[string] {
+	addressSet := sets.New[string]()
+	for _, ipAddr := range r.fqdnController.getIPsForFQDNSelectors(fqdns) {
+		addressSet.Insert(ipAddr.String())
+	}
+	return addressSet
+}
+
+// getRuleFQDNs returns the FQDNs of the peers of the rule, which are in From for ingress rules
+// and in To for egress rules.
+func getRuleFQDNs(rule *CompletedRule) []string {
+	if rule.Direction == v1beta2.DirectionIn {
+		return rule.From.FQDNs
+	}
+	return rule.To.FQDNs
+}
+
 func ipsToOFAddresses(ips sets.Set[string]) []types.Address {
 	// Must not return nil as it means not restricted by addresses in Openflow implementation.
 	from := make([]types.Address, 0, len(ips))
@@ -1254,6 +1355,15 @@ func ipsToOFAddresses(ips sets.Set[string]) []types.Address {
 	return from
 }
 
+func ctIPsToOFAddresses(ips sets.Set[string]) []types.Address {
+	// Must not return nil as it means not restricted by addresses in Openflow implementation.
+	from := make([]types.Address, 0, len(ips))
+	for ipAddr := range ips {
+		from = append(from, openflow.NewCTIPAddress(net.ParseIP(ipAddr)))
+	}
+	return from
+}
+
 func filterUnresolvablePort(in []v1beta2.Service) []v1beta2.Service {
 	// Empty or nil slice means allowing all ports in Kubernetes.
 	// nil must be returned to meet ofClient's expectation for this behavior.
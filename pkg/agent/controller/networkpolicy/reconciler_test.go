@@ -20,9 +20,11 @@ import (
 	"net"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -105,7 +107,7 @@ func newTestReconciler(t *testing.T, controller *gomock.Controller, ifaceStore i
 	f, _ := newMockFQDNController(t, controller, nil)
 	ch := make(chan string, 100)
 	groupIDAllocator := openflow.NewGroupAllocator()
-	groupCounters := []proxytypes.GroupCounter{proxytypes.NewGroupCounter(groupIDAllocator, ch)}
+	groupCounters := []proxytypes.GroupCounter{proxytypes.NewGroupCounter(groupIDAllocator, ch, false)}
 	r := newReconciler(ofClient, ifaceStore, newIDAllocator(testAsyncDeleteInterval), f, groupCounters, v4Enabled, v6Enabled, true, false)
 	return r
 }
@@ -635,6 +637,10 @@ func TestReconcilerReconcileServiceRelatedRule(t *testing.T) {
 		Port:     "80",
 		Protocol: v1.ProtocolTCP,
 	}
+	// The group IDs are derived from the Services, so any GroupCounter allocates the same IDs for them.
+	groupCounter := proxytypes.NewGroupCounter(openflow.NewGroupAllocator(), make(chan string, 10), false)
+	svc1GroupID := groupCounter.AllocateIfNotExist(svc1PortName, true)
+	svc2GroupID := groupCounter.AllocateIfNotExist(svc2PortName, true)
 
 	tests := []struct {
 		name            string
@@ -691,7 +697,7 @@ func TestReconcilerReconcileServiceRelatedRule(t *testing.T) {
 					Direction: v1beta2.DirectionIn,
 					From:      []types.Address{openflow.NewCTIPNetAddress(*ipNet)},
 					To: []types.Address{
-						openflow.NewServiceGroupIDAddress(1),
+						openflow.NewServiceGroupIDAddress(svc1GroupID),
 					},
 					Service:   nil,
 					PolicyRef: &np1,
@@ -722,8 +728,36 @@ func TestReconcilerReconcileServiceRelatedRule(t *testing.T) {
 					Direction: v1beta2.DirectionIn,
 					From:      []types.Address{openflow.NewCTIPNetAddress(*ipNet)},
 					To: []types.Address{
-						openflow.NewServiceGroupIDAddress(1),
-						openflow.NewServiceGroupIDAddress(2),
+						openflow.NewServiceGroupIDAddress(svc1GroupID),
+						openflow.NewServiceGroupIDAddress(svc2GroupID),
+					},
+					Service:   nil,
+					PolicyRef: &np1,
+				},
+			},
+			false,
+		},
+		{
+			"applied-to-services-from-pods",
+			&CompletedRule{
+				rule: &rule{
+					ID:        "ingress-rule",
+					Direction: v1beta2.DirectionIn,
+					Services:  nil,
+					SourceRef: &np1,
+				},
+				FromAddresses: v1beta2.NewGroupMemberSet(newAddressGroupMember("2.2.2.2")),
+				TargetMembers: appliedToGroupWithServices,
+			},
+			[]proxy.ServicePortName{
+				svc1PortName,
+			},
+			[]*types.PolicyRule{
+				{
+					Direction: v1beta2.DirectionIn,
+					From:      ctIPsToOFAddresses(sets.New[string]("2.2.2.2")),
+					To: []types.Address{
+						openflow.NewServiceGroupIDAddress(svc1GroupID),
 					},
 					Service:   nil,
 					PolicyRef: &np1,
@@ -775,7 +809,7 @@ func TestReconcilerReconcileServiceRelatedRule(t *testing.T) {
 					Direction: v1beta2.DirectionOut,
 					From:      ipsToOFAddresses(sets.New[string]("1.1.1.1")),
 					To: []types.Address{
-						openflow.NewServiceGroupIDAddress(1),
+						openflow.NewServiceGroupIDAddress(svc1GroupID),
 					},
 					Service:   nil,
 					PolicyRef: &np1,
@@ -802,8 +836,8 @@ func TestReconcilerReconcileServiceRelatedRule(t *testing.T) {
 					Direction: v1beta2.DirectionOut,
 					From:      ipsToOFAddresses(sets.New[string]("1.1.1.1")),
 					To: []types.Address{
-						openflow.NewServiceGroupIDAddress(1),
-						openflow.NewServiceGroupIDAddress(2),
+						openflow.NewServiceGroupIDAddress(svc1GroupID),
+						openflow.NewServiceGroupIDAddress(svc2GroupID),
 					},
 					Service:   nil,
 					PolicyRef: &np1,
@@ -840,6 +874,50 @@ func TestReconcilerReconcileServiceRelatedRule(t *testing.T) {
 // The first reconciling is supposed to fail without any openflow IDs persisted.
 // The second reconciling is supposed to succeed with proper PolicyRules installed and all openflow IDs persisted.
 // The third reconciling is supposed to do nothing.
+func TestReconcilerReconcileIngressFQDNRule(t *testing.T) {
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(&interfacestore.InterfaceConfig{
+		InterfaceName:            util.GenerateContainerInterfaceName("pod1", "ns1", "container1"),
+		IPs:                      []net.IP{net.ParseIP("2.2.2.2")},
+		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "ns1", ContainerID: "container1"},
+		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 1},
+	})
+	controller := gomock.NewController(t)
+	mockOFClient := openflowtest.NewMockClient(controller)
+	r := newTestReconciler(t, controller, ifaceStore, mockOFClient, true, false)
+	r.fqdnController.dnsEntryCache["test.antrea.io"] = dnsMeta{
+		expirationTime: time.Now().Add(time.Hour),
+		responseIPs:    map[string]net.IP{"1.1.1.1": net.ParseIP("1.1.1.1")},
+	}
+	ingressRule := &CompletedRule{
+		rule: &rule{
+			ID:        "ingress-rule",
+			Direction: v1beta2.DirectionIn,
+			From:      v1beta2.NetworkPolicyPeer{FQDNs: []string{"*antrea.io"}},
+			SourceRef: &np1,
+		},
+		TargetMembers: appliedToGroup1,
+	}
+
+	mockOFClient.EXPECT().InstallPolicyRuleFlows(newPolicyRulesMatcher(&types.PolicyRule{
+		Direction: v1beta2.DirectionIn,
+		From:      ipsToOFAddresses(sets.New[string]("1.1.1.1")),
+		To:        ofPortsToOFAddresses(sets.New[int32](1)),
+		PolicyRef: &np1,
+	}))
+	require.NoError(t, r.Reconcile(ingressRule))
+	value, exists := r.lastRealizeds.Load(ingressRule.ID)
+	require.True(t, exists)
+	assert.Equal(t, sets.New[string]("1.1.1.1"), value.(*lastRealized).fqdnIPAddresses)
+
+	// The addresses of the FQDN are updated by a DNS response, only the new address is added.
+	r.fqdnController.dnsEntryCache["test.antrea.io"].responseIPs["1.1.1.2"] = net.ParseIP("1.1.1.2")
+	mockOFClient.EXPECT().AddPolicyRuleAddress(gomock.Any(), types.SrcAddress, gomock.InAnyOrder(ipsToOFAddresses(sets.New[string]("1.1.1.2"))), gomock.Nil(), false, false)
+	require.NoError(t, r.Reconcile(ingressRule))
+	value, _ = r.lastRealizeds.Load(ingressRule.ID)
+	assert.Equal(t, sets.New[string]("1.1.1.1", "1.1.1.2"), value.(*lastRealized).fqdnIPAddresses)
+}
+
 func TestReconcileWithTransientError(t *testing.T) {
 	ifaceStore := interfacestore.NewInterfaceStore()
 	ifaceStore.AddInterface(
@@ -1323,6 +1401,47 @@ func TestReconcilerUpdate(t *testing.T) {
 	}
 }
 
+func TestReconcilerUpdateInBatches(t *testing.T) {
+	defer func(batchSize int) { addressUpdateBatchSize = batchSize }(addressUpdateBatchSize)
+	addressUpdateBatchSize = 2
+
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(
+		&interfacestore.InterfaceConfig{
+			InterfaceName:            util.GenerateContainerInterfaceName("pod1", "ns1", "container1"),
+			IPs:                      []net.IP{net.ParseIP("2.2.2.2")},
+			ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: "pod1", PodNamespace: "ns1", ContainerID: "container1"},
+			OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: 1}})
+	originalRule := &CompletedRule{
+		rule:          &rule{ID: "ingress-rule", Direction: v1beta2.DirectionIn, SourceRef: &np1},
+		FromAddresses: addressGroup1,
+		TargetMembers: appliedToGroup1,
+	}
+	updatedRule := &CompletedRule{
+		rule:          &rule{ID: "ingress-rule", Direction: v1beta2.DirectionIn, SourceRef: &np1},
+		FromAddresses: v1beta2.NewGroupMemberSet(newAddressGroupMember("1.1.1.2", "1.1.1.3", "1.1.1.4", "1.1.1.5", "1.1.1.6")),
+		TargetMembers: appliedToGroup1,
+	}
+
+	controller := gomock.NewController(t)
+	mockOFClient := openflowtest.NewMockClient(controller)
+	mockOFClient.EXPECT().InstallPolicyRuleFlows(gomock.Any())
+	// Only the deltas are sent to the Openflow client, in batches of at most addressUpdateBatchSize addresses.
+	mockOFClient.EXPECT().AddPolicyRuleAddress(gomock.Any(), types.SrcAddress, gomock.Len(2), nil, false, false).Times(2)
+	mockOFClient.EXPECT().AddPolicyRuleAddress(gomock.Any(), types.SrcAddress, gomock.Len(1), nil, false, false)
+	mockOFClient.EXPECT().DeletePolicyRuleAddress(gomock.Any(), types.SrcAddress, ipsToOFAddresses(sets.New[string]("1.1.1.1")), nil)
+	r := newTestReconciler(t, controller, ifaceStore, mockOFClient, true, false)
+	require.NoError(t, r.Reconcile(originalRule))
+	require.NoError(t, r.Reconcile(updatedRule))
+}
+
+func TestBatchAddresses(t *testing.T) {
+	addresses := ipsToOFAddresses(sets.New[string]("1.1.1.1", "1.1.1.2", "1.1.1.3"))
+	assert.Nil(t, batchAddresses(nil, 2))
+	assert.Equal(t, [][]types.Address{addresses[:2], addresses[2:]}, batchAddresses(addresses, 2))
+	assert.Equal(t, [][]types.Address{addresses}, batchAddresses(addresses, 3))
+}
+
 func TestGroupMembersByServices(t *testing.T) {
 	numberedServices := []v1beta2.Service{serviceTCP80, serviceTCP443}
 	numberedServicesKey := normalizeServices(numberedServices)
@@ -0,0 +1,292 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	agenttypes "antrea.io/antrea/pkg/agent/types"
+	v1beta "antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	"antrea.io/antrea/pkg/querier"
+	utilip "antrea.io/antrea/pkg/util/ip"
+)
+
+// simulationActionNoMatch is the action of a simulation stage in which no rule matches the flow.
+const simulationActionNoMatch = "NoMatch"
+
+// simulationStages are the names of the stages of the NetworkPolicy pipeline for each direction,
+// in the order they are traversed: Antrea-native policies except the baseline Tier, K8s
+// NetworkPolicies, then K8s NetworkPolicy isolation and Antrea-native policies of the baseline Tier.
+var simulationStages = map[v1beta.Direction][3]string{
+	v1beta.DirectionOut: {"AntreaPolicyEgressRule", "EgressRule", "EgressDefaultRule"},
+	v1beta.DirectionIn:  {"AntreaPolicyIngressRule", "IngressRule", "IngressDefaultRule"},
+}
+
+// getRealizableRules returns the rules in the cache whose groups have all been received, sorted
+// by rule ID.
+func (c *ruleCache) getRealizableRules() []*CompletedRule {
+	ruleIDs := c.rules.ListKeys()
+	sort.Strings(ruleIDs)
+	rules := make([]*CompletedRule, 0, len(ruleIDs))
+	for _, ruleID := range ruleIDs {
+		if r, _, realizable := c.GetCompletedRule(ruleID); realizable {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// simulateFlow evaluates the flow against the provided rules, egress rules first as they are
+// enforced on the Node of the source, then ingress rules. Only the rules applied to the endpoints
+// on this Node are evaluated. FQDN, ToServices and label identity peers are not supported and never
+// match the flow, and ICMP rules only match the protocol of the flow.
+func simulateFlow(rules []*CompletedRule, flow *querier.SimulatedFlow) *agenttypes.PolicySimulationResult {
+	result := &agenttypes.PolicySimulationResult{Verdict: string(crdv1alpha1.RuleActionAllow)}
+	for _, direction := range []v1beta.Direction{v1beta.DirectionOut, v1beta.DirectionIn} {
+		stages, verdict := simulateFlowInDirection(rules, flow, direction)
+		result.Stages = append(result.Stages, stages...)
+		if verdict != crdv1alpha1.RuleActionAllow {
+			result.Verdict = string(verdict)
+			break
+		}
+	}
+	return result
+}
+
+func simulateFlowInDirection(rules []*CompletedRule, flow *querier.SimulatedFlow, direction v1beta.Direction) ([]agenttypes.PolicySimulationStage, crdv1alpha1.RuleAction) {
+	stageNames := simulationStages[direction]
+	var antreaRules, k8sRules, baselineRules []*CompletedRule
+	// isolatingRule is a K8s NetworkPolicy rule applied to the endpoint, which isolates it in
+	// this direction.
+	var isolatingRule *CompletedRule
+	for _, r := range rules {
+		if r.Direction != direction || !r.appliesToFlow(flow) {
+			continue
+		}
+		if !r.isAntreaNetworkPolicyRule() && isolatingRule == nil {
+			isolatingRule = r
+		}
+		if !r.matchesFlow(flow) {
+			continue
+		}
+		if !r.isAntreaNetworkPolicyRule() {
+			k8sRules = append(k8sRules, r)
+		} else if r.TierPriority != nil && *r.TierPriority == baselineTierPriority {
+			baselineRules = append(baselineRules, r)
+		} else {
+			antreaRules = append(antreaRules, r)
+		}
+	}
+
+	var stages []agenttypes.PolicySimulationStage
+	stage, action := newSimulationStage(stageNames[0], antreaRules)
+	stages = append(stages, stage)
+	switch action {
+	case crdv1alpha1.RuleActionAllow, crdv1alpha1.RuleActionDrop, crdv1alpha1.RuleActionReject:
+		// The K8s NetworkPolicies and the baseline Tier are skipped.
+		return stages, action
+	}
+
+	stage, action = newSimulationStage(stageNames[1], k8sRules)
+	stages = append(stages, stage)
+	if action == crdv1alpha1.RuleActionAllow {
+		return stages, action
+	}
+	// The baseline Tier cannot counteract the isolation of K8s NetworkPolicies.
+	if isolatingRule != nil {
+		stages = append(stages, agenttypes.PolicySimulationStage{
+			Stage:  stageNames[2],
+			Policy: isolatingRule.SourceRef.ToString(),
+			Action: string(crdv1alpha1.RuleActionDrop),
+		})
+		return stages, crdv1alpha1.RuleActionDrop
+	}
+	stage, action = newSimulationStage(stageNames[2], baselineRules)
+	stages = append(stages, stage)
+	if action == crdv1alpha1.RuleActionDrop || action == crdv1alpha1.RuleActionReject {
+		return stages, action
+	}
+	return stages, crdv1alpha1.RuleActionAllow
+}
+
+// newSimulationStage returns the result of a stage given the rules of the stage matching the flow,
+// and the action applied to the flow. The rule with the highest precedence decides the action.
+func newSimulationStage(name string, matchedRules []*CompletedRule) (agenttypes.PolicySimulationStage, crdv1alpha1.RuleAction) {
+	stage := agenttypes.PolicySimulationStage{
+		Stage:  name,
+		Action: simulationActionNoMatch,
+	}
+	if len(matchedRules) == 0 {
+		return stage, simulationActionNoMatch
+	}
+	// Rule.Less returns true if the first rule has lower precedence.
+	sort.SliceStable(matchedRules, func(i, j int) bool {
+		return matchedRules[j].rule.Less(matchedRules[i].rule)
+	})
+	for _, r := range matchedRules {
+		stage.MatchedRules = append(stage.MatchedRules, r.simulationString())
+	}
+	decidingRule := matchedRules[0]
+	action := crdv1alpha1.RuleActionAllow
+	if decidingRule.Action != nil {
+		action = *decidingRule.Action
+	}
+	stage.Policy = decidingRule.SourceRef.ToString()
+	stage.Rule = decidingRule.Name
+	stage.Action = string(action)
+	return stage, action
+}
+
+func (r *CompletedRule) simulationString() string {
+	if r.Name == "" {
+		return fmt.Sprintf("%s (rule %s)", r.SourceRef.ToString(), r.ID)
+	}
+	return fmt.Sprintf("%s (rule %s)", r.SourceRef.ToString(), r.Name)
+}
+
+// appliesToFlow returns whether the rule is applied to the endpoint of the flow in the rule's
+// direction, i.e. the destination for ingress rules and the source for egress rules.
+func (r *CompletedRule) appliesToFlow(flow *querier.SimulatedFlow) bool {
+	ip := flow.SourceIP
+	if r.Direction == v1beta.DirectionIn {
+		ip = flow.DestinationIP
+	}
+	return getMemberByIP(r.TargetMembers, ip) != nil
+}
+
+// matchesFlow returns whether the peer and the services of the rule match the flow.
+func (r *CompletedRule) matchesFlow(flow *querier.SimulatedFlow) bool {
+	peer, addresses, peerIP := r.To, r.ToAddresses, flow.DestinationIP
+	destinationMembers := r.ToAddresses
+	if r.Direction == v1beta.DirectionIn {
+		peer, addresses, peerIP = r.From, r.FromAddresses, flow.SourceIP
+		destinationMembers = r.TargetMembers
+	}
+	if !peerMatchesIP(&peer, addresses, peerIP) {
+		return false
+	}
+	if len(r.Services) == 0 {
+		return true
+	}
+	destinationMember := getMemberByIP(destinationMembers, flow.DestinationIP)
+	for i := range r.Services {
+		if serviceMatchesFlow(&r.Services[i], destinationMember, flow) {
+			return true
+		}
+	}
+	return false
+}
+
+func peerMatchesIP(peer *v1beta.NetworkPolicyPeer, addresses v1beta.GroupMemberSet, ip net.IP) bool {
+	// An empty peer matches all sources or destinations.
+	if len(peer.AddressGroups) == 0 && len(peer.IPBlocks) == 0 && len(peer.FQDNs) == 0 &&
+		len(peer.ToServices) == 0 && len(peer.LabelIdentities) == 0 {
+		return true
+	}
+	if getMemberByIP(addresses, ip) != nil {
+		return true
+	}
+	for _, ipBlock := range peer.IPBlocks {
+		if !utilip.IPNetToNetIPNet(&ipBlock.CIDR).Contains(ip) {
+			continue
+		}
+		excepted := false
+		for i := range ipBlock.Except {
+			if utilip.IPNetToNetIPNet(&ipBlock.Except[i]).Contains(ip) {
+				excepted = true
+				break
+			}
+		}
+		if !excepted {
+			return true
+		}
+	}
+	return false
+}
+
+func getMemberByIP(members v1beta.GroupMemberSet, ip net.IP) *v1beta.GroupMember {
+	for _, member := range members {
+		for _, memberIP := range member.IPs {
+			if net.IP(memberIP).Equal(ip) {
+				return member
+			}
+		}
+	}
+	return nil
+}
+
+var simulationProtocolNumbers = map[v1beta.Protocol]uint8{
+	v1beta.ProtocolTCP:  utilip.TCPProtocol,
+	v1beta.ProtocolUDP:  utilip.UDPProtocol,
+	v1beta.ProtocolSCTP: utilip.SCTPProtocol,
+	v1beta.ProtocolIGMP: utilip.IGMPProtocol,
+}
+
+// serviceMatchesFlow returns whether the service matches the flow. destinationMember is used to
+// resolve named ports, it can be nil if the destination is not a known member.
+func serviceMatchesFlow(service *v1beta.Service, destinationMember *v1beta.GroupMember, flow *querier.SimulatedFlow) bool {
+	protocol := v1beta.ProtocolTCP
+	if service.Protocol != nil {
+		protocol = *service.Protocol
+	}
+	if protocol == v1beta.ProtocolICMP {
+		return flow.Protocol == utilip.ICMPProtocol || flow.Protocol == utilip.ICMPv6Protocol
+	}
+	if simulationProtocolNumbers[protocol] != flow.Protocol {
+		return false
+	}
+	if protocol == v1beta.ProtocolIGMP {
+		return true
+	}
+	if service.SrcPort != nil {
+		srcEndPort := *service.SrcPort
+		if service.SrcEndPort != nil {
+			srcEndPort = *service.SrcEndPort
+		}
+		if int32(flow.SourcePort) < *service.SrcPort || int32(flow.SourcePort) > srcEndPort {
+			return false
+		}
+	}
+	if service.Port == nil {
+		return true
+	}
+	var port int32
+	if service.Port.Type == intstr.Int {
+		port = service.Port.IntVal
+	} else {
+		if destinationMember == nil {
+			return false
+		}
+		for _, namedPort := range destinationMember.Ports {
+			if namedPort.Name == service.Port.StrVal && namedPort.Protocol == protocol {
+				port = namedPort.Port
+				break
+			}
+		}
+		if port == 0 {
+			return false
+		}
+	}
+	endPort := port
+	if service.EndPort != nil {
+		endPort = *service.EndPort
+	}
+	return int32(flow.DestinationPort) >= port && int32(flow.DestinationPort) <= endPort
+}
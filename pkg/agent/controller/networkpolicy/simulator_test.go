@@ -0,0 +1,229 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	agenttypes "antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	"antrea.io/antrea/pkg/querier"
+)
+
+func TestSimulateFlow(t *testing.T) {
+	actionAllow := crdv1alpha1.RuleActionAllow
+	actionDrop := crdv1alpha1.RuleActionDrop
+	actionPass := crdv1alpha1.RuleActionPass
+	tierApplication := int32(250)
+	tierBaseline := baselineTierPriority
+	policyPriority := float64(1)
+	protocolTCP := v1beta2.ProtocolTCP
+	protocolUDP := v1beta2.ProtocolUDP
+	port53 := intstr.FromInt(53)
+	port5432 := intstr.FromInt(5432)
+	portHTTP := intstr.FromString("http")
+
+	client := newAddressGroupPodMember("client", "ns1", "10.0.0.1")
+	server := newAddressGroupPodMember("server", "ns1", "10.0.0.2")
+	server.Ports = []v1beta2.NamedPort{{Name: "http", Port: 80, Protocol: v1beta2.ProtocolTCP}}
+	acnp := &v1beta2.NetworkPolicyReference{Type: v1beta2.AntreaClusterNetworkPolicy, Name: "acnp1"}
+	baselineACNP := &v1beta2.NetworkPolicyReference{Type: v1beta2.AntreaClusterNetworkPolicy, Name: "baseline1"}
+	annp := &v1beta2.NetworkPolicyReference{Type: v1beta2.AntreaNetworkPolicy, Namespace: "ns1", Name: "annp1"}
+	k8sNP := &v1beta2.NetworkPolicyReference{Type: v1beta2.K8sNetworkPolicy, Namespace: "ns1", Name: "np1"}
+	newIPBlock := func(cidr string) v1beta2.IPBlock {
+		_, ipNet, _ := net.ParseCIDR(cidr)
+		prefixLength, _ := ipNet.Mask.Size()
+		return v1beta2.IPBlock{CIDR: v1beta2.IPNet{IP: v1beta2.IPAddress(ipNet.IP), PrefixLength: int32(prefixLength)}}
+	}
+
+	rules := []*CompletedRule{
+		{
+			rule: &rule{
+				ID:             "drop-to-db",
+				Name:           "drop-to-db",
+				Direction:      v1beta2.DirectionOut,
+				To:             v1beta2.NetworkPolicyPeer{IPBlocks: []v1beta2.IPBlock{newIPBlock("10.0.1.0/24")}},
+				Services:       []v1beta2.Service{{Protocol: &protocolTCP, Port: &port5432}},
+				Action:         &actionDrop,
+				TierPriority:   &tierApplication,
+				PolicyPriority: &policyPriority,
+				SourceRef:      acnp,
+			},
+			TargetMembers: v1beta2.NewGroupMemberSet(client),
+		},
+		{
+			rule: &rule{
+				ID:             "allow-http",
+				Name:           "allow-http",
+				Direction:      v1beta2.DirectionIn,
+				From:           v1beta2.NetworkPolicyPeer{AddressGroups: []string{"clients"}},
+				Services:       []v1beta2.Service{{Protocol: &protocolTCP, Port: &portHTTP}},
+				Action:         &actionAllow,
+				Priority:       0,
+				TierPriority:   &tierApplication,
+				PolicyPriority: &policyPriority,
+				SourceRef:      annp,
+			},
+			FromAddresses: v1beta2.NewGroupMemberSet(client),
+			TargetMembers: v1beta2.NewGroupMemberSet(server),
+		},
+		{
+			rule: &rule{
+				ID:             "pass-all",
+				Name:           "pass-all",
+				Direction:      v1beta2.DirectionIn,
+				Action:         &actionPass,
+				Priority:       1,
+				TierPriority:   &tierApplication,
+				PolicyPriority: &policyPriority,
+				SourceRef:      annp,
+			},
+			TargetMembers: v1beta2.NewGroupMemberSet(server),
+		},
+		{
+			rule: &rule{
+				ID:        "k8s-ingress",
+				Direction: v1beta2.DirectionIn,
+				From:      v1beta2.NetworkPolicyPeer{IPBlocks: []v1beta2.IPBlock{newIPBlock("10.0.2.0/24")}},
+				SourceRef: k8sNP,
+			},
+			TargetMembers: v1beta2.NewGroupMemberSet(server),
+		},
+		{
+			rule: &rule{
+				ID:             "drop-dns",
+				Name:           "drop-dns",
+				Direction:      v1beta2.DirectionOut,
+				To:             v1beta2.NetworkPolicyPeer{IPBlocks: []v1beta2.IPBlock{newIPBlock("8.8.8.0/24")}},
+				Services:       []v1beta2.Service{{Protocol: &protocolUDP, Port: &port53}},
+				Action:         &actionDrop,
+				TierPriority:   &tierBaseline,
+				PolicyPriority: &policyPriority,
+				SourceRef:      baselineACNP,
+			},
+			TargetMembers: v1beta2.NewGroupMemberSet(client),
+		},
+	}
+	noMatchEgressStages := []agenttypes.PolicySimulationStage{
+		{Stage: "AntreaPolicyEgressRule", Action: "NoMatch"},
+		{Stage: "EgressRule", Action: "NoMatch"},
+		{Stage: "EgressDefaultRule", Action: "NoMatch"},
+	}
+
+	tests := []struct {
+		name           string
+		flow           *querier.SimulatedFlow
+		expectedResult *agenttypes.PolicySimulationResult
+	}{
+		{
+			name: "allowed by Antrea-native policy with named port",
+			flow: &querier.SimulatedFlow{SourceIP: net.ParseIP("10.0.0.1"), DestinationIP: net.ParseIP("10.0.0.2"), Protocol: 6, SourcePort: 30000, DestinationPort: 80},
+			expectedResult: &agenttypes.PolicySimulationResult{
+				Stages: append(noMatchEgressStages, agenttypes.PolicySimulationStage{
+					Stage:        "AntreaPolicyIngressRule",
+					Policy:       "AntreaNetworkPolicy:ns1/annp1",
+					Rule:         "allow-http",
+					Action:       "Allow",
+					MatchedRules: []string{"AntreaNetworkPolicy:ns1/annp1 (rule allow-http)", "AntreaNetworkPolicy:ns1/annp1 (rule pass-all)"},
+				}),
+				Verdict: "Allow",
+			},
+		},
+		{
+			name: "dropped by K8s NetworkPolicy isolation after pass",
+			flow: &querier.SimulatedFlow{SourceIP: net.ParseIP("10.0.0.1"), DestinationIP: net.ParseIP("10.0.0.2"), Protocol: 6, SourcePort: 30000, DestinationPort: 8080},
+			expectedResult: &agenttypes.PolicySimulationResult{
+				Stages: append(noMatchEgressStages,
+					agenttypes.PolicySimulationStage{
+						Stage:        "AntreaPolicyIngressRule",
+						Policy:       "AntreaNetworkPolicy:ns1/annp1",
+						Rule:         "pass-all",
+						Action:       "Pass",
+						MatchedRules: []string{"AntreaNetworkPolicy:ns1/annp1 (rule pass-all)"},
+					},
+					agenttypes.PolicySimulationStage{Stage: "IngressRule", Action: "NoMatch"},
+					agenttypes.PolicySimulationStage{Stage: "IngressDefaultRule", Policy: "K8sNetworkPolicy:ns1/np1", Action: "Drop"},
+				),
+				Verdict: "Drop",
+			},
+		},
+		{
+			name: "allowed by K8s NetworkPolicy after pass",
+			flow: &querier.SimulatedFlow{SourceIP: net.ParseIP("10.0.2.3"), DestinationIP: net.ParseIP("10.0.0.2"), Protocol: 6, SourcePort: 30000, DestinationPort: 8080},
+			expectedResult: &agenttypes.PolicySimulationResult{
+				Stages: append(noMatchEgressStages,
+					agenttypes.PolicySimulationStage{
+						Stage:        "AntreaPolicyIngressRule",
+						Policy:       "AntreaNetworkPolicy:ns1/annp1",
+						Rule:         "pass-all",
+						Action:       "Pass",
+						MatchedRules: []string{"AntreaNetworkPolicy:ns1/annp1 (rule pass-all)"},
+					},
+					agenttypes.PolicySimulationStage{
+						Stage:        "IngressRule",
+						Policy:       "K8sNetworkPolicy:ns1/np1",
+						Action:       "Allow",
+						MatchedRules: []string{"K8sNetworkPolicy:ns1/np1 (rule k8s-ingress)"},
+					},
+				),
+				Verdict: "Allow",
+			},
+		},
+		{
+			name: "dropped by Antrea-native egress rule",
+			flow: &querier.SimulatedFlow{SourceIP: net.ParseIP("10.0.0.1"), DestinationIP: net.ParseIP("10.0.1.5"), Protocol: 6, SourcePort: 30000, DestinationPort: 5432},
+			expectedResult: &agenttypes.PolicySimulationResult{
+				Stages: []agenttypes.PolicySimulationStage{
+					{
+						Stage:        "AntreaPolicyEgressRule",
+						Policy:       "AntreaClusterNetworkPolicy:acnp1",
+						Rule:         "drop-to-db",
+						Action:       "Drop",
+						MatchedRules: []string{"AntreaClusterNetworkPolicy:acnp1 (rule drop-to-db)"},
+					},
+				},
+				Verdict: "Drop",
+			},
+		},
+		{
+			name: "dropped by baseline egress rule",
+			flow: &querier.SimulatedFlow{SourceIP: net.ParseIP("10.0.0.1"), DestinationIP: net.ParseIP("8.8.8.8"), Protocol: 17, SourcePort: 30000, DestinationPort: 53},
+			expectedResult: &agenttypes.PolicySimulationResult{
+				Stages: []agenttypes.PolicySimulationStage{
+					{Stage: "AntreaPolicyEgressRule", Action: "NoMatch"},
+					{Stage: "EgressRule", Action: "NoMatch"},
+					{
+						Stage:        "EgressDefaultRule",
+						Policy:       "AntreaClusterNetworkPolicy:baseline1",
+						Rule:         "drop-dns",
+						Action:       "Drop",
+						MatchedRules: []string{"AntreaClusterNetworkPolicy:baseline1 (rule drop-dns)"},
+					},
+				},
+				Verdict: "Drop",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedResult, simulateFlow(rules, tt.flow))
+		})
+	}
+}
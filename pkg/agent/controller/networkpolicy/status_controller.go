@@ -17,6 +17,7 @@ package networkpolicy
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,10 +40,15 @@ const (
 // antrea-controller once it is realized. A policy is considered realized when all of its desired rules have been
 // realized and all of its undesired rules have been removed.
 // For each new policy, SetRuleRealization is supposed to be called for each of its desired rules while
-// DeleteRuleRealization is supposed to be called for the removed rules.
+// DeleteRuleRealization is supposed to be called for the removed rules. SetRuleUnsupported is supposed to be called
+// instead of SetRuleRealization for the desired rules which are not enforced because they require NetworkPolicy
+// features not supported by the agent, in which case the policy is reported as failed to be realized.
 type StatusManager interface {
 	// SetRuleRealization updates the actual status for the given NetworkPolicy rule.
 	SetRuleRealization(ruleID string, policyID types.UID)
+	// SetRuleUnsupported updates the actual status for the given NetworkPolicy rule, which is not enforced because
+	// it requires the given unsupported features.
+	SetRuleUnsupported(ruleID string, policyID types.UID, features []string)
 	// DeleteRuleRealization deletes the actual status for the given NetworkPolicy rule.
 	DeleteRuleRealization(ruleID string)
 	// Resync triggers syncing status with the antrea-controller for the given NetworkPolicy.
@@ -71,6 +77,8 @@ type StatusController struct {
 type realizedRule struct {
 	ruleID   string
 	policyID types.UID
+	// unsupportedFeatures is set if the rule is not enforced because it requires features not supported by the agent.
+	unsupportedFeatures []string
 }
 
 func realizedRuleKeyFunc(obj interface{}) (string, error) {
@@ -95,16 +103,25 @@ func newStatusController(antreaClientProvider agent.AntreaClientProvider, nodeNa
 }
 
 func (c *StatusController) SetRuleRealization(ruleID string, policyID types.UID) {
-	_, exists, _ := c.realizedRules.GetByKey(ruleID)
+	obj, exists, _ := c.realizedRules.GetByKey(ruleID)
 	// This rule has been realized before. The current call must be triggered by group member updates, which doesn't
 	// affect the policy's realization status.
-	if exists {
+	if exists && len(obj.(*realizedRule).unsupportedFeatures) == 0 {
 		return
 	}
 	c.realizedRules.Add(&realizedRule{ruleID: ruleID, policyID: policyID})
 	c.queue.Add(policyID)
 }
 
+func (c *StatusController) SetRuleUnsupported(ruleID string, policyID types.UID, features []string) {
+	obj, exists, _ := c.realizedRules.GetByKey(ruleID)
+	if exists && sets.New[string](obj.(*realizedRule).unsupportedFeatures...).Equal(sets.New[string](features...)) {
+		return
+	}
+	c.realizedRules.Add(&realizedRule{ruleID: ruleID, policyID: policyID, unsupportedFeatures: features})
+	c.queue.Add(policyID)
+}
+
 func (c *StatusController) DeleteRuleRealization(ruleID string) {
 	obj, exists, _ := c.realizedRules.GetByKey(ruleID)
 	// This rule hasn't been realized before, so it doesn't affect the policy's realization status.
@@ -178,12 +195,14 @@ func (c *StatusController) syncHandler(uid types.UID) error {
 	for _, r := range desiredRules {
 		desiredRuleSet.Insert(r.ID)
 	}
+	unsupportedFeatures := sets.New[string]()
 	for _, r := range actualRules {
 		ruleID := r.(*realizedRule).ruleID
 		if !desiredRuleSet.Has(ruleID) {
 			return nil
 		}
 		desiredRuleSet.Delete(ruleID)
+		unsupportedFeatures.Insert(r.(*realizedRule).unsupportedFeatures...)
 	}
 	if len(desiredRuleSet) > 0 {
 		return nil
@@ -191,17 +210,22 @@ func (c *StatusController) syncHandler(uid types.UID) error {
 
 	// At this point, all desired rules have been realized and all undesired rules have been removed, report it to the antrea-controller.
 	klog.V(2).Infof("Syncing NetworkPolicyStatus for %s, generation: %v", uid, policy.Generation)
+	nodeStatus := v1beta2.NetworkPolicyNodeStatus{
+		NodeName:           c.nodeName,
+		Generation:         policy.Generation,
+		RealizationFailure: false,
+	}
+	// If some rules are not enforced because they require unsupported features, report the policy as failed to be
+	// realized, so that the skew between the antrea-controller and the agent isn't silently ignored.
+	if len(unsupportedFeatures) > 0 {
+		nodeStatus.RealizationFailure = true
+		nodeStatus.Message = fmt.Sprintf("Rules requiring unsupported features %s are not enforced", strings.Join(sets.List(unsupportedFeatures), ","))
+	}
 	status := &v1beta2.NetworkPolicyStatus{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: policy.Name,
 		},
-		Nodes: []v1beta2.NetworkPolicyNodeStatus{
-			{
-				NodeName:           c.nodeName,
-				Generation:         policy.Generation,
-				RealizationFailure: false,
-			},
-		},
+		Nodes: []v1beta2.NetworkPolicyNodeStatus{nodeStatus},
 	}
 	return c.statusControlInterface.UpdateNetworkPolicyStatus(status.Name, status)
 }
@@ -16,6 +16,7 @@ package networkpolicy
 
 import (
 	"fmt"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
@@ -178,6 +179,38 @@ func TestSyncStatusUpForUpdatedPolicy(t *testing.T) {
 	assert.NoError(t, matchGeneration(policy.Generation), "The generation should be updated to %v but was not updated", policy.Generation)
 }
 
+func TestSyncStatusForUnsupportedRule(t *testing.T) {
+	statusController, ruleCache, statusControl := newTestStatusController()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go statusController.Run(stopCh)
+
+	ruleCache.AddAppliedToGroup(newAppliedToGroup("appliedToGroup1", []v1beta2.GroupMember{*newAppliedToGroupMemberPod("pod1", "ns1")}))
+	policy := newNetworkPolicyWithMultipleRules("policy1", "uid1", []string{"addressGroup1"}, []string{}, []string{"appliedToGroup1"}, nil)
+	policy.Generation = 1
+	ruleCache.AddNetworkPolicy(policy)
+	rules := ruleCache.getEffectiveRulesByNetworkPolicy(string(policy.UID))
+	statusController.SetRuleRealization(rules[0].ID, policy.UID)
+	statusController.SetRuleUnsupported(rules[1].ID, policy.UID, []string{v1beta2.NetworkPolicyFeatureL7Protocols})
+
+	expectedStatus := &v1beta2.NetworkPolicyStatus{
+		ObjectMeta: v1.ObjectMeta{
+			Name: policy.Name,
+		},
+		Nodes: []v1beta2.NetworkPolicyNodeStatus{
+			{
+				NodeName:           testNode1,
+				Generation:         1,
+				RealizationFailure: true,
+				Message:            "Rules requiring unsupported features L7Protocols are not enforced",
+			},
+		},
+	}
+	assert.NoError(t, wait.PollImmediate(100*time.Millisecond, 1*time.Second, func() (done bool, err error) {
+		return reflect.DeepEqual(expectedStatus, statusControl.getNetworkPolicyStatus()), nil
+	}), "The policy should be reported as failed to be realized")
+}
+
 // BenchmarkSyncHandler benchmarks syncHandler when the policy has 100 rules. Its current result is:
 // 47754 ns/op           15320 B/op         23 allocs/op
 func BenchmarkSyncHandler(b *testing.B) {
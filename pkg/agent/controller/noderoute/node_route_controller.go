@@ -17,11 +17,15 @@ package noderoute
 import (
 	"fmt"
 	"net"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/containernetworking/plugins/pkg/ip"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
@@ -77,9 +81,13 @@ type Controller struct {
 	// installedNodes records routes and flows installation states of Nodes.
 	// The key is the host name of the Node, the value is the nodeRouteInfo of the Node.
 	// A node will be in the map after its flows and routes are installed successfully.
-	installedNodes  cache.Indexer
-	wireGuardClient wireguard.Interface
-	proxyAll        bool
+	installedNodes cache.Indexer
+	// pendingInitialNodes are the Nodes which existed when the controller started and haven't been processed
+	// successfully yet. It's nil until the Node informer has synced.
+	pendingInitialNodes      sets.Set[string]
+	pendingInitialNodesMutex sync.Mutex
+	wireGuardClient          wireguard.Interface
+	proxyAll                 bool
 	// ipsecCertificateManager is useful for determining whether the ipsec certificate has been configured
 	// or not when IPsec is enabled with "cert" mode. The NodeRouteController must wait for the certificate
 	// to be configured before installing routes/flows to peer Nodes to prevent unencrypted traffic across Nodes.
@@ -127,7 +135,7 @@ func NewNodeRouteController(
 				controller.enqueueNode(cur)
 			},
 			UpdateFunc: func(old, cur interface{}) {
-				controller.enqueueNode(cur)
+				controller.updateNode(old, cur)
 			},
 			DeleteFunc: func(old interface{}) {
 				controller.enqueueNode(old)
@@ -158,6 +166,8 @@ type nodeRouteInfo struct {
 	gatewayIPs         *utilip.DualStackIPs
 	nodeMAC            net.HardwareAddr
 	wireGuardPublicKey string
+	tunnelType         ovsconfig.TunnelType
+	tunnelPort         int32
 }
 
 // enqueueNode adds an object to the controller work queue
@@ -183,6 +193,25 @@ func (c *Controller) enqueueNode(obj interface{}) {
 	}
 }
 
+// updateNode enqueues the updated Node. When the tunnel overrides of this Node are updated, all the
+// peer Nodes are enqueued as well, as the overrides apply to the tunnels to all of them.
+func (c *Controller) updateNode(old, cur interface{}) {
+	c.enqueueNode(cur)
+	oldNode, oldOK := old.(*corev1.Node)
+	curNode, curOK := cur.(*corev1.Node)
+	if !oldOK || !curOK || curNode.Name != c.nodeConfig.Name {
+		return
+	}
+	if oldNode.Annotations[types.NodeTunnelTypeAnnotationKey] == curNode.Annotations[types.NodeTunnelTypeAnnotationKey] &&
+		oldNode.Annotations[types.NodeTunnelPortAnnotationKey] == curNode.Annotations[types.NodeTunnelPortAnnotationKey] {
+		return
+	}
+	klog.InfoS("Tunnel overrides of this Node changed, resyncing all peer Nodes")
+	for _, nodeName := range c.installedNodes.ListKeys() {
+		c.queue.Add(nodeName)
+	}
+}
+
 // removeStaleGatewayRoutes removes all the gateway routes which no longer correspond to a Node in
 // the cluster. If the antrea agent restarts and Nodes have left the cluster, this function will
 // take care of removing routes which are no longer valid.
@@ -226,33 +255,31 @@ func (c *Controller) removeStaleTunnelPorts() error {
 	desiredInterfaces := make(map[string]bool)
 	// knownInterfaces is the list of interfaces currently in the local cache.
 	knownInterfaces := c.interfaceStore.GetInterfaceKeysByType(interfacestore.IPSecTunnelInterface)
+	knownInterfaces = append(knownInterfaces, c.interfaceStore.GetInterfaceKeysByType(interfacestore.NodeTunnelInterface)...)
 
-	if c.networkConfig.TrafficEncryptionMode == config.TrafficEncryptionModeIPSec {
-		for _, node := range nodes {
-			interfaceConfig, found := c.interfaceStore.GetNodeTunnelInterface(node.Name)
-			if !found {
-				// Tunnel port not created for this Node, nothing to do.
-				continue
-			}
+	for _, node := range nodes {
+		interfaceConfig, found := c.interfaceStore.GetNodeTunnelInterface(node.Name)
+		if !found {
+			// Tunnel port not created for this Node, nothing to do.
+			continue
+		}
+		tunnelType, tunnelPort, overridden := c.getPeerTunnelConfig(node)
+		if c.networkConfig.TrafficEncryptionMode != config.TrafficEncryptionModeIPSec && !overridden {
+			// No tunnel port should be dedicated to this Node.
+			continue
+		}
 
-			peerNodeIPs, err := k8s.GetNodeAddrs(node)
-			if err != nil {
-				klog.Errorf("Failed to retrieve IP address of Node %s: %v", node.Name, err)
-				continue
-			}
-			var remoteName, psk string
-			// remote_name and psk are mutually exclusive.
-			switch c.networkConfig.IPsecConfig.AuthenticationMode {
-			case config.IPsecAuthenticationModeCert:
-				remoteName = node.Name
-			case config.IPsecAuthenticationModePSK:
-				psk = c.networkConfig.IPsecConfig.PSK
-			}
-			ifaceID := util.GenerateNodeTunnelInterfaceKey(node.Name)
-			ifaceName := util.GenerateNodeTunnelInterfaceName(node.Name)
-			if c.compareInterfaceConfig(interfaceConfig, peerNodeIPs.IPv4, psk, remoteName, ifaceName) || c.compareInterfaceConfig(interfaceConfig, peerNodeIPs.IPv6, psk, remoteName, ifaceName) {
-				desiredInterfaces[ifaceID] = true
-			}
+		peerNodeIPs, err := k8s.GetNodeAddrs(node)
+		if err != nil {
+			klog.Errorf("Failed to retrieve IP address of Node %s: %v", node.Name, err)
+			continue
+		}
+		psk, remoteName := c.getIPsecAuthConfig(node.Name)
+		ifaceID := util.GenerateNodeTunnelInterfaceKey(node.Name)
+		ifaceName := util.GenerateNodeTunnelInterfaceName(node.Name)
+		if c.compareInterfaceConfig(interfaceConfig, peerNodeIPs.IPv4, psk, remoteName, ifaceName, tunnelType, tunnelPort) ||
+			c.compareInterfaceConfig(interfaceConfig, peerNodeIPs.IPv6, psk, remoteName, ifaceName, tunnelType, tunnelPort) {
+			desiredInterfaces[ifaceID] = true
 		}
 	}
 
@@ -284,12 +311,94 @@ func (c *Controller) removeStaleTunnelPorts() error {
 }
 
 func (c *Controller) compareInterfaceConfig(interfaceConfig *interfacestore.InterfaceConfig,
-	peerNodeIP net.IP, psk, remoteName, interfaceName string) bool {
+	peerNodeIP net.IP, psk, remoteName, interfaceName string, tunnelType ovsconfig.TunnelType, tunnelPort int32) bool {
 	return interfaceConfig.InterfaceName == interfaceName &&
 		interfaceConfig.PSK == psk &&
 		interfaceConfig.RemoteName == remoteName &&
 		interfaceConfig.RemoteIP.Equal(peerNodeIP) &&
-		interfaceConfig.TunnelInterfaceConfig.Type == c.networkConfig.TunnelType
+		interfaceConfig.TunnelInterfaceConfig.Type == tunnelType &&
+		interfaceConfig.TunnelInterfaceConfig.DestinationPort == tunnelPort
+}
+
+// getIPsecAuthConfig returns the PSK and the remote name to set for the tunnel port to the Node,
+// which are both empty when IPsec is not enabled.
+func (c *Controller) getIPsecAuthConfig(nodeName string) (psk, remoteName string) {
+	if c.networkConfig.TrafficEncryptionMode != config.TrafficEncryptionModeIPSec {
+		return "", ""
+	}
+	// remote_name and psk are mutually exclusive.
+	switch c.networkConfig.IPsecConfig.AuthenticationMode {
+	case config.IPsecAuthenticationModeCert:
+		remoteName = nodeName
+	case config.IPsecAuthenticationModePSK:
+		psk = c.networkConfig.IPsecConfig.PSK
+	}
+	return psk, remoteName
+}
+
+// getTunnelOverrides returns the tunnel type and the tunnel destination port specified with the
+// annotations of the Node. An empty tunnel type or a zero port means that it is not overridden.
+// Invalid values are ignored.
+func getTunnelOverrides(node *corev1.Node) (ovsconfig.TunnelType, int32) {
+	var tunnelType ovsconfig.TunnelType
+	var tunnelPort int32
+	if value, ok := node.Annotations[types.NodeTunnelTypeAnnotationKey]; ok {
+		switch t := ovsconfig.TunnelType(strings.ToLower(value)); t {
+		case ovsconfig.GeneveTunnel, ovsconfig.VXLANTunnel:
+			tunnelType = t
+		default:
+			klog.ErrorS(nil, "Ignoring invalid tunnel type in Node annotation", "node", node.Name, "tunnelType", value)
+		}
+	}
+	if value, ok := node.Annotations[types.NodeTunnelPortAnnotationKey]; ok {
+		port, err := strconv.ParseUint(value, 10, 16)
+		if err != nil || port == 0 {
+			klog.ErrorS(err, "Ignoring invalid tunnel port in Node annotation", "node", node.Name, "tunnelPort", value)
+		} else {
+			tunnelPort = int32(port)
+		}
+	}
+	return tunnelType, tunnelPort
+}
+
+// mergeTunnelOverride returns the override which applies to the tunnel between this Node and a peer
+// Node. Both Nodes must use the same tunnel configuration for each other, so when both Nodes specify
+// different values, the one of the Node whose name comes first alphabetically is used.
+func mergeTunnelOverride[T comparable](local, peer T, localFirst bool) T {
+	var unset T
+	if local == unset || (peer != unset && !localFirst) {
+		return peer
+	}
+	return local
+}
+
+// getPeerTunnelConfig returns the tunnel type and destination port to use for the tunnel to the peer
+// Node, and whether they differ from the ones of the default tunnel port, in which case a tunnel port
+// must be dedicated to the peer Node. They can be overridden with the annotations of both this Node
+// and the peer Node. A zero destination port means that the default port of the tunnel type is used.
+func (c *Controller) getPeerTunnelConfig(peerNode *corev1.Node) (ovsconfig.TunnelType, int32, bool) {
+	defaultType := c.networkConfig.TunnelType
+	// The tunnels are not used with WireGuard.
+	if !c.networkConfig.TrafficEncapMode.SupportsEncap() || c.networkConfig.TrafficEncryptionMode == config.TrafficEncryptionModeWireGuard {
+		return defaultType, 0, false
+	}
+	var localType ovsconfig.TunnelType
+	var localPort int32
+	if localNode, err := c.nodeLister.Get(c.nodeConfig.Name); err == nil {
+		localType, localPort = getTunnelOverrides(localNode)
+	}
+	peerType, peerPort := getTunnelOverrides(peerNode)
+	localFirst := c.nodeConfig.Name < peerNode.Name
+	tunnelType := mergeTunnelOverride(localType, peerType, localFirst)
+	tunnelPort := mergeTunnelOverride(localPort, peerPort, localFirst)
+	if tunnelType == "" || tunnelType == defaultType {
+		if tunnelPort == 0 || tunnelPort == c.networkConfig.TunnelPort {
+			return defaultType, 0, false
+		}
+		return defaultType, tunnelPort, true
+	}
+	// When only the tunnel type is overridden, the default port of this tunnel type is used.
+	return tunnelType, tunnelPort, true
 }
 
 func (c *Controller) reconcile() error {
@@ -336,6 +445,7 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 	// underlying network. Therefore it needs not know the routes to
 	// peer Pod CIDRs.
 	if c.networkConfig.TrafficEncapMode.IsNetworkPolicyOnly() {
+		c.setPendingInitialNodes(sets.New[string]())
 		<-stopCh
 		return
 	}
@@ -358,12 +468,44 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 		klog.ErrorS(err, "Error during reconciliation", "controller", controllerName)
 	}
 
+	initialNodes := sets.New[string]()
+	if nodes, err := c.nodeLister.List(labels.Everything()); err == nil {
+		for _, node := range nodes {
+			if node.Name != c.nodeConfig.Name {
+				initialNodes.Insert(node.Name)
+			}
+		}
+	}
+	c.setPendingInitialNodes(initialNodes)
+
 	for i := 0; i < defaultWorkers; i++ {
 		go wait.Until(c.worker, time.Second, stopCh)
 	}
 	<-stopCh
 }
 
+func (c *Controller) setPendingInitialNodes(nodes sets.Set[string]) {
+	c.pendingInitialNodesMutex.Lock()
+	defer c.pendingInitialNodesMutex.Unlock()
+	c.pendingInitialNodes = nodes
+}
+
+func (c *Controller) removePendingInitialNode(nodeName string) {
+	c.pendingInitialNodesMutex.Lock()
+	defer c.pendingInitialNodesMutex.Unlock()
+	if c.pendingInitialNodes != nil {
+		c.pendingInitialNodes.Delete(nodeName)
+	}
+}
+
+// HasSynced returns true once the routes and flows of all the Nodes which existed when the controller started have
+// been installed.
+func (c *Controller) HasSynced() bool {
+	c.pendingInitialNodesMutex.Lock()
+	defer c.pendingInitialNodesMutex.Unlock()
+	return c.pendingInitialNodes != nil && c.pendingInitialNodes.Len() == 0
+}
+
 // worker is a long-running function that will continually call the processNextWorkItem function in
 // order to read and process a message on the workqueue.
 func (c *Controller) worker() {
@@ -400,6 +542,7 @@ func (c *Controller) processNextWorkItem() bool {
 		// If no error occurs we Forget this item so it does not get queued again until
 		// another change happens.
 		c.queue.Forget(key)
+		c.removePendingInitialNode(key)
 	} else {
 		// Put the item back on the workqueue to handle any transient errors.
 		c.queue.AddRateLimited(key)
@@ -456,18 +599,8 @@ func (c *Controller) deleteNodeRoute(nodeName string) error {
 	}
 	c.installedNodes.Delete(obj)
 
-	if c.networkConfig.TrafficEncryptionMode == config.TrafficEncryptionModeIPSec {
-		interfaceConfig, ok := c.interfaceStore.GetNodeTunnelInterface(nodeName)
-		if !ok {
-			// Tunnel port not created for this Node.
-			return nil
-		}
-		if err := c.ovsBridgeClient.DeletePort(interfaceConfig.PortUUID); err != nil {
-			klog.Errorf("Failed to delete OVS tunnel port %s for Node %s: %v",
-				interfaceConfig.InterfaceName, nodeName, err)
-			return fmt.Errorf("failed to delete OVS tunnel port for Node %s", nodeName)
-		}
-		c.interfaceStore.DeleteInterface(interfaceConfig)
+	if err := c.deleteNodeTunnelPort(nodeName); err != nil {
+		return err
 	}
 
 	if c.networkConfig.TrafficEncryptionMode == config.TrafficEncryptionModeWireGuard {
@@ -478,6 +611,22 @@ func (c *Controller) deleteNodeRoute(nodeName string) error {
 	return nil
 }
 
+// deleteNodeTunnelPort deletes the tunnel port dedicated to the Node if it exists.
+func (c *Controller) deleteNodeTunnelPort(nodeName string) error {
+	interfaceConfig, ok := c.interfaceStore.GetNodeTunnelInterface(nodeName)
+	if !ok {
+		// Tunnel port not created for this Node.
+		return nil
+	}
+	if err := c.ovsBridgeClient.DeletePort(interfaceConfig.PortUUID); err != nil {
+		klog.Errorf("Failed to delete OVS tunnel port %s for Node %s: %v",
+			interfaceConfig.InterfaceName, nodeName, err)
+		return fmt.Errorf("failed to delete OVS tunnel port for Node %s", nodeName)
+	}
+	c.interfaceStore.DeleteInterface(interfaceConfig)
+	return nil
+}
+
 func (c *Controller) addNodeRoute(nodeName string, node *corev1.Node) error {
 	// It is only for Windows Noencap mode to get Node MAC.
 	peerNodeMAC, err := getNodeMAC(node)
@@ -490,13 +639,16 @@ func (c *Controller) addNodeRoute(nodeName string, node *corev1.Node) error {
 		return err
 	}
 	peerWireGuardPublicKey := node.Annotations[types.NodeWireGuardPublicAnnotationKey]
+	tunnelType, tunnelPort, tunnelOverridden := c.getPeerTunnelConfig(node)
 
 	nrInfo, installed, _ := c.installedNodes.GetByKey(nodeName)
-	// Route is already added for this Node and Node MAC, transport IP
-	// and WireGuard public key are not changed.
+	// Route is already added for this Node and Node MAC, transport IP,
+	// WireGuard public key and tunnel configuration are not changed.
 	if installed && nrInfo.(*nodeRouteInfo).nodeMAC.String() == peerNodeMAC.String() &&
 		peerNodeIPs.Equal(*nrInfo.(*nodeRouteInfo).nodeIPs) &&
-		nrInfo.(*nodeRouteInfo).wireGuardPublicKey == peerWireGuardPublicKey {
+		nrInfo.(*nodeRouteInfo).wireGuardPublicKey == peerWireGuardPublicKey &&
+		nrInfo.(*nodeRouteInfo).tunnelType == tunnelType &&
+		nrInfo.(*nodeRouteInfo).tunnelPort == tunnelPort {
 		return nil
 	}
 
@@ -552,20 +704,21 @@ func (c *Controller) addNodeRoute(nodeName string, node *corev1.Node) error {
 			"peerNodeIP", peerNodeIP)
 	}
 
-	var ipsecTunOFPort uint32
-	if c.networkConfig.TrafficEncryptionMode == config.TrafficEncryptionModeIPSec {
+	var tunOFPort uint32
+	if c.networkConfig.TrafficEncryptionMode == config.TrafficEncryptionModeIPSec || tunnelOverridden {
 		// Create a separate tunnel port for the Node, as OVS IPsec monitor needs to
 		// read PSK and remote IP from the Node's tunnel interface to create IPsec
-		// security policies.
+		// security policies, and as the tunnel type or destination port to the Node
+		// may differ from the ones of the default tunnel port.
 		peerNodeIP := peerNodeIPs.IPv4
 		if peerNodeIP == nil {
 			peerNodeIP = peerNodeIPs.IPv6
 		}
-		port, err := c.createIPSecTunnelPort(nodeName, peerNodeIP)
+		port, err := c.createNodeTunnelPort(nodeName, peerNodeIP, tunnelType, tunnelPort)
 		if err != nil {
 			return err
 		}
-		ipsecTunOFPort = uint32(port)
+		tunOFPort = uint32(port)
 	}
 
 	if c.networkConfig.TrafficEncryptionMode == config.TrafficEncryptionModeWireGuard && peerWireGuardPublicKey != "" {
@@ -582,10 +735,17 @@ func (c *Controller) addNodeRoute(nodeName string, node *corev1.Node) error {
 		nodeName,
 		peerConfigs,
 		peerNodeIPs,
-		ipsecTunOFPort,
+		tunOFPort,
 		peerNodeMAC); err != nil {
 		return fmt.Errorf("failed to install flows to Node %s: %v", nodeName, err)
 	}
+	if tunOFPort == 0 {
+		// The tunnel overrides may have been removed, in which case the tunnel port
+		// which was dedicated to the Node is not needed anymore.
+		if err := c.deleteNodeTunnelPort(nodeName); err != nil {
+			return err
+		}
+	}
 
 	peerGatewayIPs := new(utilip.DualStackIPs)
 	for peerPodCIDR, peerGatewayIP := range peerConfigs {
@@ -609,6 +769,8 @@ func (c *Controller) addNodeRoute(nodeName string, node *corev1.Node) error {
 		gatewayIPs:         peerGatewayIPs,
 		nodeMAC:            peerNodeMAC,
 		wireGuardPublicKey: peerWireGuardPublicKey,
+		tunnelType:         tunnelType,
+		tunnelPort:         tunnelPort,
 	})
 
 	return err
@@ -627,28 +789,23 @@ func getPodCIDRsOnNode(node *corev1.Node) []string {
 	return []string{node.Spec.PodCIDR}
 }
 
-// createIPSecTunnelPort creates an IPsec tunnel port for the remote Node if the
-// tunnel does not exist, and returns the ofport number.
-func (c *Controller) createIPSecTunnelPort(nodeName string, nodeIP net.IP) (int32, error) {
+// createNodeTunnelPort creates a tunnel port dedicated to the remote Node if the
+// tunnel does not exist, and returns the ofport number. The tunnel port is an
+// IPsec tunnel port when IPsec is enabled.
+func (c *Controller) createNodeTunnelPort(nodeName string, nodeIP net.IP, tunnelType ovsconfig.TunnelType, tunnelPort int32) (int32, error) {
 	portName := util.GenerateNodeTunnelInterfaceName(nodeName)
 	interfaceConfig, exists := c.interfaceStore.GetNodeTunnelInterface(nodeName)
 
-	var remoteName, psk string
-	// remote_name and psk are mutually exclusive.
-	switch c.networkConfig.IPsecConfig.AuthenticationMode {
-	case config.IPsecAuthenticationModeCert:
-		remoteName = nodeName
-	case config.IPsecAuthenticationModePSK:
-		psk = c.networkConfig.IPsecConfig.PSK
-	}
-	// check if Node IP, PSK, remote name, or tunnel type changes. This can
+	psk, remoteName := c.getIPsecAuthConfig(nodeName)
+	isIPsec := c.networkConfig.TrafficEncryptionMode == config.TrafficEncryptionModeIPSec
+	// check if Node IP, PSK, remote name, tunnel type or port changes. This can
 	// happen if removeStaleTunnelPorts fails to remove a "stale"
 	// tunnel port for which the configuration has changed, return error to requeue the Node.
 	if exists {
-		if !c.compareInterfaceConfig(interfaceConfig, nodeIP, psk, remoteName, portName) {
-			klog.InfoS("IPsec tunnel interface config doesn't match the cached one, deleting the stale IPsec tunnel port", "node", nodeName, "interface", interfaceConfig.InterfaceName)
+		if !c.compareInterfaceConfig(interfaceConfig, nodeIP, psk, remoteName, portName, tunnelType, tunnelPort) {
+			klog.InfoS("Tunnel interface config doesn't match the cached one, deleting the stale tunnel port", "node", nodeName, "interface", interfaceConfig.InterfaceName)
 			if err := c.ovsBridgeClient.DeletePort(interfaceConfig.PortUUID); err != nil {
-				return 0, fmt.Errorf("fail to delete the stale IPsec tunnel port %s: %v", interfaceConfig.InterfaceName, err)
+				return 0, fmt.Errorf("fail to delete the stale tunnel port %s: %v", interfaceConfig.InterfaceName, err)
 			}
 			c.interfaceStore.DeleteInterface(interfaceConfig)
 			exists = false
@@ -656,36 +813,56 @@ func (c *Controller) createIPSecTunnelPort(nodeName string, nodeIP net.IP) (int3
 	}
 
 	if !exists {
+		antreaInterfaceType := interfacestore.AntreaTunnel
+		if isIPsec {
+			antreaInterfaceType = interfacestore.AntreaIPsecTunnel
+		}
 		ovsExternalIDs := map[string]interface{}{
 			ovsExternalIDNodeName:                 nodeName,
-			interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaIPsecTunnel,
+			interfacestore.AntreaInterfaceTypeKey: antreaInterfaceType,
+		}
+		var extraOptions map[string]interface{}
+		if tunnelPort != 0 {
+			extraOptions = map[string]interface{}{"dst_port": strconv.Itoa(int(tunnelPort))}
 		}
 		portUUID, err := c.ovsBridgeClient.CreateTunnelPortExt(
 			portName,
-			c.networkConfig.TunnelType,
+			tunnelType,
 			0, // ofPortRequest - let OVS allocate OFPort number.
 			false,
 			"",
 			nodeIP.String(),
 			remoteName,
 			psk,
-			nil,
+			extraOptions,
 			ovsExternalIDs)
 		if err != nil {
-			return 0, fmt.Errorf("failed to create IPsec tunnel port for Node %s", nodeName)
+			return 0, fmt.Errorf("failed to create tunnel port for Node %s", nodeName)
 		}
-		klog.Infof("Created IPsec tunnel port %s for Node %s", portName, nodeName)
+		klog.InfoS("Created tunnel port for Node", "port", portName, "node", nodeName, "type", tunnelType, "destinationPort", tunnelPort, "ipsec", isIPsec)
 
 		ovsPortConfig := &interfacestore.OVSPortConfig{PortUUID: portUUID}
-		interfaceConfig = interfacestore.NewIPSecTunnelInterface(
-			portName,
-			c.networkConfig.TunnelType,
-			nodeName,
-			nodeIP,
-			psk,
-			remoteName,
-			ovsPortConfig,
-		)
+		if isIPsec {
+			interfaceConfig = interfacestore.NewIPSecTunnelInterface(
+				portName,
+				tunnelType,
+				nodeName,
+				nodeIP,
+				psk,
+				remoteName,
+				ovsPortConfig,
+			)
+			interfaceConfig.DestinationPort = tunnelPort
+		} else {
+			interfaceConfig = interfacestore.NewNodeTunnelInterface(
+				portName,
+				tunnelType,
+				nodeName,
+				nodeIP,
+				tunnelPort,
+				ovsPortConfig,
+			)
+		}
 		c.interfaceStore.AddInterface(interfaceConfig)
 	}
 	// GetOFPort will wait for up to 1 second for OVSDB to report the OFPort number.
@@ -693,7 +870,7 @@ func (c *Controller) createIPSecTunnelPort(nodeName string, nodeIP net.IP) (int3
 	if err != nil {
 		// Could be a temporary OVSDB connection failure or timeout.
 		// Let NodeRouteController retry at errors.
-		return 0, fmt.Errorf("failed to get of_port of IPsec tunnel port for Node %s", nodeName)
+		return 0, fmt.Errorf("failed to get of_port of tunnel port for Node %s", nodeName)
 	}
 
 	// Set the port with no-flood to reject ARP flood packets.
@@ -734,6 +911,18 @@ func ParseTunnelInterfaceConfig(
 			remoteName,
 			portConfig,
 		)
+		interfaceConfig.DestinationPort = tunnelPort
+	} else if nodeName != "" && remoteIP != nil {
+		// The tunnel port dedicated to a Node when the tunnel type or destination port
+		// to the Node is overridden.
+		interfaceConfig = interfacestore.NewNodeTunnelInterface(
+			portData.Name,
+			ovsconfig.TunnelType(portData.IFType),
+			nodeName,
+			remoteIP,
+			tunnelPort,
+			portConfig,
+		)
 	} else {
 		interfaceConfig = interfacestore.NewTunnelInterface(
 			portData.Name,
@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 
@@ -32,6 +33,7 @@ import (
 	"antrea.io/antrea/pkg/agent/interfacestore"
 	oftest "antrea.io/antrea/pkg/agent/openflow/testing"
 	routetest "antrea.io/antrea/pkg/agent/route/testing"
+	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/agent/util"
 	"antrea.io/antrea/pkg/ovs/ovsconfig"
 	ovsconfigtest "antrea.io/antrea/pkg/ovs/ovsconfig/testing"
@@ -174,6 +176,22 @@ func TestControllerWithDuplicatePodCIDR(t *testing.T) {
 	}
 }
 
+func TestHasSynced(t *testing.T) {
+	c := newController(t, &config.NetworkConfig{})
+	defer c.queue.ShutDown()
+
+	assert.False(t, c.HasSynced(), "Controller should not be synced before the Node informer has synced")
+	c.setPendingInitialNodes(sets.New[string]("node1", "node2"))
+	assert.False(t, c.HasSynced())
+	c.removePendingInitialNode("node1")
+	assert.False(t, c.HasSynced())
+	c.removePendingInitialNode("node2")
+	assert.True(t, c.HasSynced())
+	// Nodes added after the controller started don't affect it.
+	c.removePendingInitialNode("node3")
+	assert.True(t, c.HasSynced())
+}
+
 func TestIPInPodSubnets(t *testing.T) {
 	c := newController(t, &config.NetworkConfig{})
 	defer c.queue.ShutDown()
@@ -393,7 +411,7 @@ func TestCreateIPSecTunnelPortPSK(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := c.createIPSecTunnelPort(tt.nodeName, tt.peerNodeIP)
+			got, err := c.createNodeTunnelPort(tt.nodeName, tt.peerNodeIP, c.networkConfig.TunnelType, 0)
 			hasErr := err != nil
 			assert.Equal(t, tt.wantErr, hasErr)
 			assert.Equal(t, tt.want, got)
@@ -438,10 +456,155 @@ func TestCreateIPSecTunnelPortCert(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := c.createIPSecTunnelPort(tt.nodeName, tt.peerNodeIP)
+			got, err := c.createNodeTunnelPort(tt.nodeName, tt.peerNodeIP, c.networkConfig.TunnelType, 0)
 			hasErr := err != nil
 			assert.Equal(t, tt.wantErr, hasErr)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
+
+func TestGetPeerTunnelConfig(t *testing.T) {
+	newNode := func(name, tunnelType, tunnelPort string) *corev1.Node {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{}}}
+		if tunnelType != "" {
+			node.Annotations[types.NodeTunnelTypeAnnotationKey] = tunnelType
+		}
+		if tunnelPort != "" {
+			node.Annotations[types.NodeTunnelPortAnnotationKey] = tunnelPort
+		}
+		return node
+	}
+	tests := []struct {
+		name               string
+		encryptionMode     config.TrafficEncryptionModeType
+		localNode          *corev1.Node
+		peerNode           *corev1.Node
+		expectedType       ovsconfig.TunnelType
+		expectedPort       int32
+		expectedOverridden bool
+	}{
+		{
+			name:         "no overrides",
+			localNode:    newNode("node1", "", ""),
+			peerNode:     newNode("node2", "", ""),
+			expectedType: ovsconfig.GeneveTunnel,
+		},
+		{
+			name:               "peer overrides port",
+			localNode:          newNode("node1", "", ""),
+			peerNode:           newNode("node2", "", "10000"),
+			expectedType:       ovsconfig.GeneveTunnel,
+			expectedPort:       10000,
+			expectedOverridden: true,
+		},
+		{
+			name:               "local Node overrides type",
+			localNode:          newNode("node1", "vxlan", ""),
+			peerNode:           newNode("node2", "", ""),
+			expectedType:       ovsconfig.VXLANTunnel,
+			expectedOverridden: true,
+		},
+		{
+			name:               "conflicting overrides",
+			localNode:          newNode("node2", "vxlan", "10000"),
+			peerNode:           newNode("node1", "", "20000"),
+			expectedType:       ovsconfig.VXLANTunnel,
+			expectedPort:       20000,
+			expectedOverridden: true,
+		},
+		{
+			name:         "same as default",
+			localNode:    newNode("node1", "", ""),
+			peerNode:     newNode("node2", "geneve", "6081"),
+			expectedType: ovsconfig.GeneveTunnel,
+		},
+		{
+			name:         "invalid overrides",
+			localNode:    newNode("node1", "", ""),
+			peerNode:     newNode("node2", "gre", "foo"),
+			expectedType: ovsconfig.GeneveTunnel,
+		},
+		{
+			name:           "WireGuard",
+			encryptionMode: config.TrafficEncryptionModeWireGuard,
+			localNode:      newNode("node1", "", ""),
+			peerNode:       newNode("node2", "vxlan", ""),
+			expectedType:   ovsconfig.GeneveTunnel,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newController(t, &config.NetworkConfig{
+				TrafficEncapMode:      config.TrafficEncapModeEncap,
+				TrafficEncryptionMode: tt.encryptionMode,
+				TunnelType:            ovsconfig.GeneveTunnel,
+				TunnelPort:            6081,
+			})
+			defer c.queue.ShutDown()
+			c.nodeConfig.Name = tt.localNode.Name
+			c.informerFactory.Core().V1().Nodes().Informer().GetIndexer().Add(tt.localNode)
+
+			tunnelType, tunnelPort, overridden := c.getPeerTunnelConfig(tt.peerNode)
+			assert.Equal(t, tt.expectedType, tunnelType)
+			assert.Equal(t, tt.expectedPort, tunnelPort)
+			assert.Equal(t, tt.expectedOverridden, overridden)
+		})
+	}
+}
+
+func TestAddNodeRouteWithTunnelOverrides(t *testing.T) {
+	c := newController(t, &config.NetworkConfig{
+		TrafficEncapMode: config.TrafficEncapModeEncap,
+		TunnelType:       ovsconfig.GeneveTunnel,
+	})
+	defer c.queue.ShutDown()
+
+	node1 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node1",
+			Annotations: map[string]string{
+				types.NodeTunnelTypeAnnotationKey: "vxlan",
+				types.NodeTunnelPortAnnotationKey: "10000",
+			},
+		},
+		Spec: corev1.NodeSpec{
+			PodCIDR:  podCIDR.String(),
+			PodCIDRs: []string{podCIDR.String()},
+		},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{
+					Type:    corev1.NodeInternalIP,
+					Address: nodeIP1.String(),
+				},
+			},
+		},
+	}
+	portName := util.GenerateNodeTunnelInterfaceName("node1")
+	c.ovsClient.EXPECT().CreateTunnelPortExt(
+		portName, ovsconfig.VXLANTunnel, int32(0),
+		false, "", nodeIP1.String(), "", "", map[string]interface{}{"dst_port": "10000"},
+		map[string]interface{}{ovsExternalIDNodeName: "node1",
+			interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaTunnel,
+		}).Return("uuid1", nil)
+	c.ovsClient.EXPECT().GetOFPort(portName, false).Return(int32(10), nil)
+	c.ovsCtlClient.EXPECT().SetPortNoFlood(10)
+	c.ofClient.EXPECT().InstallNodeFlows("node1", gomock.Any(), &dsIPs1, uint32(10), nil)
+	c.routeClient.EXPECT().AddRoutes(podCIDR, "node1", nodeIP1, podCIDRGateway)
+	assert.NoError(t, c.addNodeRoute("node1", node1))
+	interfaceConfig, ok := c.interfaceStore.GetNodeTunnelInterface("node1")
+	assert.True(t, ok)
+	assert.Equal(t, interfacestore.NodeTunnelInterface, interfaceConfig.Type)
+	assert.Equal(t, int32(10000), interfaceConfig.DestinationPort)
+
+	// The overrides are removed, the dedicated tunnel port should be deleted.
+	updatedNode1 := node1.DeepCopy()
+	updatedNode1.Annotations = nil
+	c.ofClient.EXPECT().InstallNodeFlows("node1", gomock.Any(), &dsIPs1, uint32(0), nil)
+	c.ovsClient.EXPECT().DeletePort("uuid1")
+	c.routeClient.EXPECT().AddRoutes(podCIDR, "node1", nodeIP1, podCIDRGateway)
+	assert.NoError(t, c.addNodeRoute("node1", updatedNode1))
+	_, ok = c.interfaceStore.GetNodeTunnelInterface("node1")
+	assert.False(t, ok)
+}
@@ -76,6 +76,10 @@ var _ memberlist.Interface = (*fakeMemberlistCluster)(nil)
 func (f *fakeMemberlistCluster) AddClusterEventHandler(h memberlist.ClusterNodeEventHandler) {
 }
 
+func (f *fakeMemberlistCluster) GetMaxIPsPerNode(externalIPPool string) int {
+	return 0
+}
+
 func (f *fakeMemberlistCluster) AliveNodes() sets.Set[string] {
 	return sets.New[string](f.nodes...)
 }
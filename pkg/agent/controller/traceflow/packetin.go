@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 
+	"antrea.io/antrea/pkg/agent/interfacestore"
 	"antrea.io/antrea/pkg/agent/openflow"
 	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
@@ -69,6 +70,13 @@ func (c *Controller) HandlePacketIn(pktIn *ofctrl.PacketIn) error {
 	if err != nil {
 		return fmt.Errorf("Traceflow update error: %w", err)
 	}
+	// For reverse-path Traceflow, inject the reply packet once the forward
+	// packet has been delivered to a local Pod.
+	if nodeResult.Direction == crdv1alpha1.TraceflowDirectionForward {
+		if err := c.injectReplyPacket(oldTf, pktIn, nodeResult); err != nil {
+			return fmt.Errorf("failed to inject Traceflow reply packet: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -329,9 +337,107 @@ func (c *Controller) parsePacketIn(pktIn *ofctrl.PacketIn) (*crdv1alpha1.Tracefl
 	}
 
 	nodeResult := crdv1alpha1.NodeResult{Node: c.nodeConfig.Name, Timestamp: time.Now().Unix(), Observations: obs}
+	// The packets of both IP families carry the same data plane tag in
+	// dual-stack Traceflow, so the results are told apart by IP family.
+	if tfState.dualStack {
+		if etherData.Ethertype == protocol.IPv6_MSG {
+			nodeResult.IPFamily = crdv1alpha1.IPFamilyIPv6
+		} else {
+			nodeResult.IPFamily = crdv1alpha1.IPFamilyIPv4
+		}
+	}
+	// The forward and reply packets of reverse-path Traceflow carry
+	// different data plane tags, which are told apart by their states.
+	if tfState.reverse {
+		nodeResult.Direction = crdv1alpha1.TraceflowDirectionReverse
+	} else if tfState.reverseTag != 0 {
+		nodeResult.Direction = crdv1alpha1.TraceflowDirectionForward
+	}
 	return tf, &nodeResult, capturedPacket, nil
 }
 
+// injectReplyPacket injects the reply packet of a reverse-path Traceflow from
+// the destination Pod, if the forward packet has been delivered to a Pod on the
+// local Node. The Node then becomes the sender of the reply packet.
+func (c *Controller) injectReplyPacket(tf *crdv1alpha1.Traceflow, pktIn *ofctrl.PacketIn, nodeResult *crdv1alpha1.NodeResult) error {
+	if len(nodeResult.Observations) == 0 {
+		return nil
+	}
+	lastOb := nodeResult.Observations[len(nodeResult.Observations)-1]
+	if lastOb.Component != crdv1alpha1.ComponentForwarding || lastOb.Action != crdv1alpha1.ActionDelivered {
+		return nil
+	}
+	var outputPort uint32
+	if match := getMatchRegField(pktIn.GetMatches(), openflow.TargetOFPortField); match != nil {
+		var err error
+		outputPort, err = getRegValue(match, nil)
+		if err != nil {
+			return err
+		}
+	}
+	dstIntf, ok := c.interfaceStore.GetInterfaceByOFPort(outputPort)
+	if !ok || dstIntf.Type != interfacestore.ContainerInterface {
+		// The forward packet was delivered to the gateway.
+		return nil
+	}
+	packet, err := binding.ParsePacketIn(pktIn)
+	if err != nil {
+		return fmt.Errorf("failed to parse the forward packet: %w", err)
+	}
+	reply := prepareReplyPacket(packet, dstIntf)
+	// The reply packet is delivered locally if the source Pod runs on the
+	// local Node, otherwise it is sent to the gateway.
+	if srcIntf, ok := c.interfaceStore.GetInterfaceByIP(packet.SourceIP.String()); ok && srcIntf.Type == interfacestore.ContainerInterface {
+		reply.DestinationMAC = srcIntf.MAC
+	}
+
+	reverseTag := tf.Status.ReverseDataplaneTag
+	c.runningTraceflowsMutex.Lock()
+	tfState, exists := c.runningTraceflows[reverseTag]
+	if exists {
+		tfState.isSender = true
+	}
+	c.runningTraceflowsMutex.Unlock()
+	if !exists {
+		return fmt.Errorf("Traceflow for reply dataplane tag %d not found in cache", reverseTag)
+	}
+	klog.V(2).InfoS("Injecting reply packet for Traceflow", "tf", klog.KObj(tf), "packet", *reply)
+	return c.ofClient.SendTraceflowPacket(reverseTag, reply, outputPort, -1)
+}
+
+// prepareReplyPacket prepares the reply packet of the forward packet delivered
+// to the provided Pod interface, by swapping the addresses and ports of the
+// forward packet.
+func prepareReplyPacket(packet *binding.Packet, dstIntf *interfacestore.InterfaceConfig) *binding.Packet {
+	reply := &binding.Packet{
+		IsIPv6:          packet.IsIPv6,
+		SourceMAC:       dstIntf.MAC,
+		SourceIP:        packet.DestinationIP,
+		DestinationIP:   packet.SourceIP,
+		IPProto:         packet.IPProto,
+		TTL:             defaultTTL,
+		SourcePort:      packet.DestinationPort,
+		DestinationPort: packet.SourcePort,
+	}
+	switch packet.IPProto {
+	case protocol.Type_ICMP:
+		reply.ICMPType = icmpEchoReplyType
+		reply.ICMPEchoID = packet.ICMPEchoID
+		reply.ICMPEchoSeq = packet.ICMPEchoSeq
+	case protocol.Type_IPv6ICMP:
+		reply.ICMPType = icmpv6EchoReplyType
+		reply.ICMPEchoID = packet.ICMPEchoID
+		reply.ICMPEchoSeq = packet.ICMPEchoSeq
+	case protocol.Type_TCP:
+		// Reply to a SYN with a SYN-ACK, and to other segments with an ACK.
+		reply.TCPFlags = tcpFlagACK
+		if packet.TCPFlags&tcpFlagSYN != 0 {
+			reply.TCPFlags |= tcpFlagSYN
+		}
+	}
+	return reply
+}
+
 func getMatchPktMarkField(matchers *ofctrl.Matchers) *ofctrl.MatchField {
 	return matchers.GetMatchByName("NXM_NX_PKT_MARK")
 }
@@ -30,8 +30,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/interfacestore"
 	"antrea.io/antrea/pkg/agent/openflow"
 	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
 )
 
 var (
@@ -247,6 +249,15 @@ func TestParsePacketIn(t *testing.T) {
 		},
 	}
 	matchTunDst := openflow15.NewTunnelIpv4DstField(net.ParseIP(egressIP), nil)
+	podOutPortReg := make([]byte, 8)
+	binary.BigEndian.PutUint32(podOutPortReg[4:8], 3) // outputPort in 32bit reg1
+	matchPodOutPort := &openflow15.MatchField{
+		Class: openflow15.OXM_CLASS_PACKET_REGS,
+		Field: openflow15.NXM_NX_REG0,
+		Value: &openflow15.ByteArrayField{
+			Data: podOutPortReg,
+		},
+	}
 
 	pktBytes := getTestPacketBytes()
 
@@ -452,6 +463,66 @@ func TestParsePacketIn(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "IPv4 packet at destination Node for dual-stack Traceflow",
+			networkConfig: &config.NetworkConfig{
+				TrafficEncapMode: 0,
+			},
+			nodeConfig: &config.NodeConfig{
+				TunnelOFPort: 1,
+				GatewayConfig: &config.GatewayConfig{
+					OFPort: 2,
+				},
+			},
+			tfState: &traceflowState{
+				name:      "traceflow-dual-stack",
+				tag:       1,
+				dualStack: true,
+			},
+			pktIn: &ofctrl.PacketIn{
+				PacketIn: &openflow15.PacketIn{
+					TableId: openflow.L2ForwardingOutTable.GetID(),
+					Match: openflow15.Match{
+						Fields: []openflow15.MatchField{*matchPodOutPort},
+					},
+					Data: util.NewBuffer(pktBytes),
+				},
+			},
+			expectedTf: &crdv1alpha1.Traceflow{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "traceflow-dual-stack",
+				},
+				Spec: crdv1alpha1.TraceflowSpec{
+					Source: crdv1alpha1.Source{
+						Namespace: pod1.Namespace,
+						Pod:       pod1.Name,
+					},
+					Destination: crdv1alpha1.Destination{
+						Namespace: pod2.Namespace,
+						Pod:       pod2.Name,
+					},
+					DualStack: true,
+				},
+				Status: crdv1alpha1.TraceflowStatus{
+					Phase:        crdv1alpha1.Running,
+					DataplaneTag: 1,
+				},
+			},
+			expectedNodeResult: &crdv1alpha1.NodeResult{
+				IPFamily: crdv1alpha1.IPFamilyIPv4,
+				Observations: []crdv1alpha1.Observation{
+					{
+						Component: crdv1alpha1.ComponentForwarding,
+						Action:    crdv1alpha1.ActionReceived,
+					},
+					{
+						Component:     crdv1alpha1.ComponentForwarding,
+						ComponentInfo: openflow.L2ForwardingOutTable.GetName(),
+						Action:        crdv1alpha1.ActionDelivered,
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -466,6 +537,7 @@ func TestParsePacketIn(t *testing.T) {
 			tf, nodeResult, _, err := tfc.parsePacketIn(tt.pktIn)
 			require.NoError(t, err)
 			assert.Equal(t, tt.expectedNodeResult.Observations, nodeResult.Observations)
+			assert.Equal(t, tt.expectedNodeResult.IPFamily, nodeResult.IPFamily)
 			assert.Equal(t, tt.expectedTf, tf)
 		})
 	}
@@ -501,3 +573,100 @@ func TestParsePacketInLiveDuplicates(t *testing.T) {
 	_, _, _, err := tfc.parsePacketIn(pktIn)
 	assert.ErrorIs(t, err, skipTraceflowUpdateErr)
 }
+
+func TestPrepareReplyPacket(t *testing.T) {
+	dstIntf := &interfacestore.InterfaceConfig{MAC: pod2MAC}
+	tcs := []struct {
+		name          string
+		packet        *binding.Packet
+		expectedReply *binding.Packet
+	}{
+		{
+			name: "ICMP echo request",
+			packet: &binding.Packet{
+				SourceIP:      net.ParseIP(pod1IPv4),
+				DestinationIP: net.ParseIP(pod2IPv4),
+				IPProto:       protocol.Type_ICMP,
+				TTL:           63,
+				ICMPType:      icmpEchoRequestType,
+				ICMPEchoID:    1,
+				ICMPEchoSeq:   2,
+			},
+			expectedReply: &binding.Packet{
+				SourceMAC:     pod2MAC,
+				SourceIP:      net.ParseIP(pod2IPv4),
+				DestinationIP: net.ParseIP(pod1IPv4),
+				IPProto:       protocol.Type_ICMP,
+				TTL:           defaultTTL,
+				ICMPType:      icmpEchoReplyType,
+				ICMPEchoID:    1,
+				ICMPEchoSeq:   2,
+			},
+		},
+		{
+			name: "ICMPv6 echo request",
+			packet: &binding.Packet{
+				IsIPv6:        true,
+				SourceIP:      net.ParseIP("fd00:10:244::a"),
+				DestinationIP: net.ParseIP("fd00:10:244::b"),
+				IPProto:       protocol.Type_IPv6ICMP,
+				ICMPEchoID:    1,
+			},
+			expectedReply: &binding.Packet{
+				IsIPv6:        true,
+				SourceMAC:     pod2MAC,
+				SourceIP:      net.ParseIP("fd00:10:244::b"),
+				DestinationIP: net.ParseIP("fd00:10:244::a"),
+				IPProto:       protocol.Type_IPv6ICMP,
+				TTL:           defaultTTL,
+				ICMPType:      icmpv6EchoReplyType,
+				ICMPEchoID:    1,
+			},
+		},
+		{
+			name: "TCP SYN",
+			packet: &binding.Packet{
+				SourceIP:        net.ParseIP(pod1IPv4),
+				DestinationIP:   net.ParseIP(pod2IPv4),
+				IPProto:         protocol.Type_TCP,
+				SourcePort:      10000,
+				DestinationPort: 80,
+				TCPFlags:        tcpFlagSYN,
+			},
+			expectedReply: &binding.Packet{
+				SourceMAC:       pod2MAC,
+				SourceIP:        net.ParseIP(pod2IPv4),
+				DestinationIP:   net.ParseIP(pod1IPv4),
+				IPProto:         protocol.Type_TCP,
+				TTL:             defaultTTL,
+				SourcePort:      80,
+				DestinationPort: 10000,
+				TCPFlags:        tcpFlagSYN | tcpFlagACK,
+			},
+		},
+		{
+			name: "UDP",
+			packet: &binding.Packet{
+				SourceIP:        net.ParseIP(pod1IPv4),
+				DestinationIP:   net.ParseIP(pod2IPv4),
+				IPProto:         protocol.Type_UDP,
+				SourcePort:      10000,
+				DestinationPort: 53,
+			},
+			expectedReply: &binding.Packet{
+				SourceMAC:       pod2MAC,
+				SourceIP:        net.ParseIP(pod2IPv4),
+				DestinationIP:   net.ParseIP(pod1IPv4),
+				IPProto:         protocol.Type_UDP,
+				TTL:             defaultTTL,
+				SourcePort:      53,
+				DestinationPort: 10000,
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedReply, prepareReplyPacket(tc.packet, dstIntf))
+		})
+	}
+}
@@ -67,6 +67,13 @@ const (
 	icmpEchoRequestType   uint8 = 8
 	icmpv6EchoRequestType uint8 = 128
 	icmpEchoRequestCode   uint8 = 0
+	// ICMP Echo Reply type.
+	icmpEchoReplyType   uint8 = 0
+	icmpv6EchoReplyType uint8 = 129
+
+	// TCP flags set in the reply packet of reverse-path Traceflow.
+	tcpFlagSYN uint8 = 0x02
+	tcpFlagACK uint8 = 0x10
 
 	defaultTTL uint8 = 64
 )
@@ -79,6 +86,14 @@ type traceflowState struct {
 	// Live-traffic Traceflow with only destination Pod specified.
 	receiverOnly bool
 	isSender     bool
+	// Traceflow tracing a packet of each IP family.
+	dualStack bool
+	// Data plane tag of the reply packet in reverse-path Traceflow. It is
+	// only set in the state of the forward packet.
+	reverseTag uint8
+	// State of the reply packet in reverse-path Traceflow. The Node
+	// injecting the reply packet becomes its sender.
+	reverse bool
 	// Agent received the first Traceflow packet from OVS.
 	receivedPacket bool
 }
@@ -327,21 +342,42 @@ func (c *Controller) startTraceflow(tf *crdv1alpha1.Traceflow) error {
 	podInterfaces := c.interfaceStore.GetContainerInterfacesByPod(pod, ns)
 	isSender := len(podInterfaces) > 0 && !receiverOnly
 
-	var packet, matchPacket *binding.Packet
+	// For dual-stack Traceflow, a packet of each IP family is injected.
+	// Otherwise, the IP family of the packet is determined by the IP header
+	// in the spec.
+	dualStack := tf.Spec.DualStack && !liveTraffic
+	var packets []*binding.Packet
+	var matchPacket *binding.Packet
 	var ofPort uint32
 	if len(podInterfaces) > 0 {
-		packet, err = c.preparePacket(tf, podInterfaces[0], receiverOnly)
-		if err != nil {
-			return err
+		ipv6Families := []bool{tf.Spec.Packet.IPv6Header != nil}
+		if dualStack {
+			ipv6Families = []bool{false, true}
+		}
+		for _, isIPv6 := range ipv6Families {
+			var packet *binding.Packet
+			packet, err = c.preparePacket(tf, podInterfaces[0], receiverOnly, isIPv6)
+			if err != nil {
+				return err
+			}
+			klog.V(2).Infof("Traceflow packet %v", *packet)
+			packets = append(packets, packet)
 		}
 		ofPort = uint32(podInterfaces[0].OFPort)
 		// On the sender or receiver (the receiverOnly case) Node, trace
 		// the first packet of the first connection that matches the
 		// Traceflow spec.
 		if liveTraffic {
-			matchPacket = packet
+			matchPacket = packets[0]
 		}
-		klog.V(2).Infof("Traceflow packet %v", *packet)
+	}
+
+	// For reverse-path Traceflow, the reply packet is traced with a
+	// separate data plane tag, so that its observations can be told apart
+	// from the ones of the forward packet.
+	var reverseTag uint8
+	if tf.Spec.ReversePath && !liveTraffic {
+		reverseTag = tf.Status.ReverseDataplaneTag
 	}
 
 	// Store Traceflow to cache.
@@ -349,8 +385,11 @@ func (c *Controller) startTraceflow(tf *crdv1alpha1.Traceflow) error {
 	tfState := traceflowState{
 		name: tf.Name, tag: tf.Status.DataplaneTag,
 		liveTraffic: liveTraffic, droppedOnly: tf.Spec.DroppedOnly && liveTraffic,
-		receiverOnly: receiverOnly, isSender: isSender}
+		receiverOnly: receiverOnly, isSender: isSender, dualStack: dualStack, reverseTag: reverseTag}
 	c.runningTraceflows[tfState.tag] = &tfState
+	if reverseTag != 0 {
+		c.runningTraceflows[reverseTag] = &traceflowState{name: tf.Name, tag: reverseTag, dualStack: dualStack, reverse: true}
+	}
 	c.runningTraceflowsMutex.Unlock()
 
 	// Install flow entries for traceflow.
@@ -359,30 +398,44 @@ func (c *Controller) startTraceflow(tf *crdv1alpha1.Traceflow) error {
 	if timeout == 0 {
 		timeout = crdv1alpha1.DefaultTraceflowTimeout
 	}
-	err = c.ofClient.InstallTraceflowFlows(tfState.tag, liveTraffic, tfState.droppedOnly, receiverOnly, matchPacket, ofPort, timeout)
+	err = c.ofClient.InstallTraceflowFlows(tfState.tag, liveTraffic, tfState.droppedOnly, receiverOnly, false, matchPacket, ofPort, timeout)
 	if err != nil {
 		return err
 	}
+	if reverseTag != 0 {
+		if err = c.ofClient.InstallTraceflowFlows(reverseTag, false, false, false, true, nil, 0, timeout); err != nil {
+			return err
+		}
+	}
 
 	// Skip packet injection if the source Pod is not found on the local Node.
 	if !liveTraffic && isSender {
-		if packet.DestinationMAC == nil {
-			// If the destination is Service/IP or the packet will
-			// be sent to remote Node, wait a small period for other
-			// Nodes.
-			time.Sleep(time.Duration(injectPacketDelay) * time.Millisecond)
-		} else {
-			// Issue #2116
-			// Wait a small period after flows installed to avoid unexpected behavior.
-			time.Sleep(time.Duration(injectLocalPacketDelay) * time.Millisecond)
+		injectDelay := injectLocalPacketDelay
+		for _, packet := range packets {
+			if packet.DestinationMAC == nil {
+				// If the destination is Service/IP or the packet will
+				// be sent to remote Node, wait a small period for other
+				// Nodes.
+				injectDelay = injectPacketDelay
+			}
+		}
+		// Issue #2116
+		// Wait a small period after flows installed to avoid unexpected behavior.
+		time.Sleep(time.Duration(injectDelay) * time.Millisecond)
+		for _, packet := range packets {
+			klog.V(2).Infof("Injecting packet for Traceflow %s", tf.Name)
+			if err = c.ofClient.SendTraceflowPacket(tfState.tag, packet, ofPort, -1); err != nil {
+				return err
+			}
 		}
-		klog.V(2).Infof("Injecting packet for Traceflow %s", tf.Name)
-		err = c.ofClient.SendTraceflowPacket(tfState.tag, packet, ofPort, -1)
 	}
 	return err
 }
 
 func (c *Controller) validateTraceflow(tf *crdv1alpha1.Traceflow) error {
+	if tf.Spec.DualStack && !(c.networkConfig.IPv4Enabled && c.networkConfig.IPv6Enabled) {
+		return errors.New("dual-stack Traceflow requires both IPv4 and IPv6 to be enabled")
+	}
 	if tf.Spec.Destination.Service != "" && !features.DefaultFeatureGate.Enabled(features.AntreaProxy) {
 		return errors.New("using Service destination requires AntreaProxy feature enabled")
 	}
@@ -400,11 +453,13 @@ func (c *Controller) validateTraceflow(tf *crdv1alpha1.Traceflow) error {
 	return nil
 }
 
-func (c *Controller) preparePacket(tf *crdv1alpha1.Traceflow, intf *interfacestore.InterfaceConfig, receiverOnly bool) (*binding.Packet, error) {
+// preparePacket prepares the packet of the provided IP family to inject or to
+// match for the Traceflow.
+func (c *Controller) preparePacket(tf *crdv1alpha1.Traceflow, intf *interfacestore.InterfaceConfig, receiverOnly bool, isIPv6 bool) (*binding.Packet, error) {
 	liveTraffic := tf.Spec.LiveTraffic
 	isICMP := false
 	packet := new(binding.Packet)
-	packet.IsIPv6 = tf.Spec.Packet.IPv6Header != nil
+	packet.IsIPv6 = isIPv6
 	if !liveTraffic {
 		if packet.IsIPv6 {
 			packet.SourceIP = intf.GetIPv6Addr()
@@ -487,29 +542,43 @@ func (c *Controller) preparePacket(tf *crdv1alpha1.Traceflow, intf *interfacesto
 		if dstSvc.Spec.ClusterIP == "" {
 			return nil, errors.New("destination Service does not have a ClusterIP")
 		}
-		packet.DestinationIP = net.ParseIP(dstSvc.Spec.ClusterIP)
+		// A dual-stack Service has a ClusterIP of each IP family in
+		// ClusterIPs, the first one being the same as ClusterIP.
+		clusterIPStrs := dstSvc.Spec.ClusterIPs
+		if len(clusterIPStrs) == 0 {
+			clusterIPStrs = []string{dstSvc.Spec.ClusterIP}
+		}
+		clusterIPs := make([]net.IP, 0, len(clusterIPStrs))
+		for _, ip := range clusterIPStrs {
+			clusterIPs = append(clusterIPs, net.ParseIP(ip))
+		}
 		if !packet.IsIPv6 {
-			packet.DestinationIP = packet.DestinationIP.To4()
+			packet.DestinationIP = util.GetIPv4Addr(clusterIPs).To4()
 			if packet.DestinationIP == nil {
 				return nil, errors.New("destination Service does not have an IPv4 ClusterIP")
 			}
-		} else if packet.DestinationIP.To4() != nil {
-			return nil, errors.New("destination Service does not have an IPv6 ClusterIP")
+		} else {
+			packet.DestinationIP, _ = util.GetIPWithFamily(clusterIPs, util.FamilyIPv6)
+			if packet.DestinationIP == nil {
+				return nil, errors.New("destination Service does not have an IPv6 ClusterIP")
+			}
 		}
 	} else if !liveTraffic {
 		return nil, errors.New("destination is not specified")
 	}
 
-	if tf.Spec.Packet.IPv6Header != nil {
+	if packet.IsIPv6 {
 		// IP Protocol 0 (IPv6 Hop-by-Hop Option) is not supported by
 		// Traceflow. If NextHeader is not provided, protocol ICMPv6
-		// will be used as the default.
-		if tf.Spec.Packet.IPv6Header.NextHeader != nil {
-			packet.IPProto = uint8(*tf.Spec.Packet.IPv6Header.NextHeader)
-		}
-		if !liveTraffic {
-			packet.TTL = uint8(tf.Spec.Packet.IPv6Header.HopLimit)
-			packet.IPFlags = 0
+		// will be used as the default. The IPv6 header may be omitted
+		// in dual-stack Traceflow, in which case the defaults are used.
+		if ipv6Header := tf.Spec.Packet.IPv6Header; ipv6Header != nil {
+			if ipv6Header.NextHeader != nil {
+				packet.IPProto = uint8(*ipv6Header.NextHeader)
+			}
+			if !liveTraffic {
+				packet.TTL = uint8(ipv6Header.HopLimit)
+			}
 		}
 	} else {
 		packet.IPProto = uint8(tf.Spec.Packet.IPHeader.Protocol)
@@ -580,26 +649,27 @@ func (c *Controller) errorTraceflowCRD(tf *crdv1alpha1.Traceflow, reason string)
 	return c.traceflowClient.CrdV1alpha1().Traceflows().Patch(context.TODO(), tf.Name, types.MergePatchType, payloads, metav1.PatchOptions{}, "status")
 }
 
-// Delete Traceflow from cache.
-func (c *Controller) deleteTraceflowState(tfName string) *traceflowState {
+// Delete Traceflow from cache. A reverse-path Traceflow has a state for each
+// of its data plane tags.
+func (c *Controller) deleteTraceflowState(tfName string) []*traceflowState {
 	c.runningTraceflowsMutex.Lock()
 	defer c.runningTraceflowsMutex.Unlock()
 	// Controller could have deallocated the tag and cleared the DataplaneTag
 	// field in the Traceflow Status, so try looking up the tag from the
 	// cache by Traceflow name.
+	var tfStates []*traceflowState
 	for tag, tfState := range c.runningTraceflows {
 		if tfName == tfState.name {
 			delete(c.runningTraceflows, tag)
-			return tfState
+			tfStates = append(tfStates, tfState)
 		}
 	}
-	return nil
+	return tfStates
 }
 
 // Delete Traceflow state and OVS flows.
 func (c *Controller) cleanupTraceflow(tfName string) {
-	tfState := c.deleteTraceflowState(tfName)
-	if tfState != nil {
+	for _, tfState := range c.deleteTraceflowState(tfName) {
 		err := c.ofClient.UninstallTraceflowFlows(tfState.tag)
 		if err != nil {
 			klog.Errorf("Failed to uninstall Traceflow %s flows: %v", tfName, err)
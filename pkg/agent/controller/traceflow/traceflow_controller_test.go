@@ -27,6 +27,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/util/workqueue"
@@ -482,7 +483,7 @@ func TestPreparePacket(t *testing.T) {
 				podInterfaces[0] = tt.intf
 			}
 
-			pkt, err := tfc.preparePacket(tt.tf, podInterfaces[0], tt.receiverOnly)
+			pkt, err := tfc.preparePacket(tt.tf, podInterfaces[0], tt.receiverOnly, tt.tf.Spec.Packet.IPv6Header != nil)
 			if tt.expectedErr == "" {
 				require.NoError(t, err)
 				assert.Equal(t, tt.expectedPacket, pkt)
@@ -494,6 +495,63 @@ func TestPreparePacket(t *testing.T) {
 	}
 }
 
+func TestPreparePacketDualStackService(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.AntreaProxy, true)()
+	svc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"},
+		Spec: v1.ServiceSpec{
+			ClusterIP:  "10.96.0.10",
+			ClusterIPs: []string{"10.96.0.10", "fd00:10:96::a"},
+		},
+	}
+	tf := &crdv1alpha1.Traceflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "tf1", UID: "uid1"},
+		Spec: crdv1alpha1.TraceflowSpec{
+			Source: crdv1alpha1.Source{
+				Namespace: pod1.Namespace,
+				Pod:       pod1.Name,
+			},
+			Destination: crdv1alpha1.Destination{
+				Namespace: svc.Namespace,
+				Service:   svc.Name,
+			},
+			DualStack: true,
+		},
+	}
+	tfc := newFakeTraceflowController(t, []runtime.Object{tf}, nil, nil, nil, nil)
+	informerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	serviceInformer := informerFactory.Core().V1().Services()
+	serviceInformer.Informer().GetIndexer().Add(svc)
+	tfc.serviceLister = serviceInformer.Lister()
+	intf := &interfacestore.InterfaceConfig{
+		IPs: []net.IP{net.ParseIP(pod1IPv4), net.ParseIP("fd00:10:244::a")},
+		MAC: pod1MAC,
+	}
+
+	pkt, err := tfc.preparePacket(tf, intf, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, &binding.Packet{
+		SourceIP:      net.ParseIP(pod1IPv4),
+		SourceMAC:     pod1MAC,
+		DestinationIP: net.ParseIP("10.96.0.10").To4(),
+		IPProto:       protocol.Type_ICMP,
+		TTL:           64,
+		ICMPType:      icmpEchoRequestType,
+	}, pkt)
+
+	pkt, err = tfc.preparePacket(tf, intf, false, true)
+	require.NoError(t, err)
+	assert.Equal(t, &binding.Packet{
+		IsIPv6:        true,
+		SourceIP:      net.ParseIP("fd00:10:244::a"),
+		SourceMAC:     pod1MAC,
+		DestinationIP: net.ParseIP("fd00:10:96::a"),
+		IPProto:       protocol.Type_IPv6ICMP,
+		TTL:           64,
+		ICMPType:      icmpv6EchoRequestType,
+	}, pkt)
+}
+
 func TestErrTraceflowCRD(t *testing.T) {
 	tf := &crdv1alpha1.Traceflow{
 		ObjectMeta: metav1.ObjectMeta{
@@ -569,7 +627,7 @@ func TestStartTraceflow(t *testing.T) {
 				ICMPType:       8,
 			},
 			expectedCalls: func(mockOFClient *openflowtest.MockClient) {
-				mockOFClient.EXPECT().InstallTraceflowFlows(uint8(1), false, false, false, nil, ofPortPod1, crdv1alpha1.DefaultTraceflowTimeout)
+				mockOFClient.EXPECT().InstallTraceflowFlows(uint8(1), false, false, false, false, nil, ofPortPod1, crdv1alpha1.DefaultTraceflowTimeout)
 				mockOFClient.EXPECT().SendTraceflowPacket(uint8(1), &binding.Packet{
 					SourceIP:       net.ParseIP(pod1IPv4),
 					SourceMAC:      pod1MAC,
@@ -609,7 +667,7 @@ func TestStartTraceflow(t *testing.T) {
 				ICMPType:      8,
 			},
 			expectedCalls: func(mockOFClient *openflowtest.MockClient) {
-				mockOFClient.EXPECT().InstallTraceflowFlows(uint8(1), false, false, false, nil, ofPortPod1, crdv1alpha1.DefaultTraceflowTimeout)
+				mockOFClient.EXPECT().InstallTraceflowFlows(uint8(1), false, false, false, false, nil, ofPortPod1, crdv1alpha1.DefaultTraceflowTimeout)
 				mockOFClient.EXPECT().SendTraceflowPacket(uint8(1), &binding.Packet{
 					SourceIP:      net.ParseIP(pod1IPv4),
 					SourceMAC:     pod1MAC,
@@ -620,6 +678,42 @@ func TestStartTraceflow(t *testing.T) {
 				}, ofPortPod1, int32(-1))
 			},
 		},
+		{
+			name: "Pod-to-Pod reverse-path traceflow",
+			tf: &crdv1alpha1.Traceflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "tf5", UID: "uid5"},
+				Spec: crdv1alpha1.TraceflowSpec{
+					Source: crdv1alpha1.Source{
+						Namespace: pod1.Namespace,
+						Pod:       pod1.Name,
+					},
+					Destination: crdv1alpha1.Destination{
+						Namespace: pod2.Namespace,
+						Pod:       pod2.Name,
+					},
+					ReversePath: true,
+				},
+				Status: crdv1alpha1.TraceflowStatus{
+					Phase:               crdv1alpha1.Running,
+					DataplaneTag:        1,
+					ReverseDataplaneTag: 2,
+				},
+			},
+			ofPort: ofPortPod1,
+			expectedCalls: func(mockOFClient *openflowtest.MockClient) {
+				mockOFClient.EXPECT().InstallTraceflowFlows(uint8(1), false, false, false, false, nil, ofPortPod1, crdv1alpha1.DefaultTraceflowTimeout)
+				mockOFClient.EXPECT().InstallTraceflowFlows(uint8(2), false, false, false, true, nil, uint32(0), crdv1alpha1.DefaultTraceflowTimeout)
+				mockOFClient.EXPECT().SendTraceflowPacket(uint8(1), &binding.Packet{
+					SourceIP:       net.ParseIP(pod1IPv4),
+					SourceMAC:      pod1MAC,
+					DestinationIP:  net.ParseIP(pod2IPv4),
+					DestinationMAC: pod2MAC,
+					IPProto:        1,
+					TTL:            64,
+					ICMPType:       8,
+				}, ofPortPod1, int32(-1))
+			},
+		},
 		{
 			name: "empty source and destination Pod",
 			tf: &crdv1alpha1.Traceflow{
@@ -681,7 +775,7 @@ func TestStartTraceflow(t *testing.T) {
 			},
 			ofPort: ofPortPod2,
 			expectedCalls: func(mockOFClient *openflowtest.MockClient) {
-				mockOFClient.EXPECT().InstallTraceflowFlows(uint8(1), true, false, true, &binding.Packet{DestinationMAC: pod2MAC}, ofPortPod2, crdv1alpha1.DefaultTraceflowTimeout)
+				mockOFClient.EXPECT().InstallTraceflowFlows(uint8(1), true, false, true, false, &binding.Packet{DestinationMAC: pod2MAC}, ofPortPod2, crdv1alpha1.DefaultTraceflowTimeout)
 			},
 		},
 	}
@@ -837,7 +931,7 @@ func TestProcessTraceflowItem(t *testing.T) {
 	tfc.crdInformerFactory.Start(stopCh)
 	tfc.crdInformerFactory.WaitForCacheSync(stopCh)
 
-	tfc.mockOFClient.EXPECT().InstallTraceflowFlows(tc.tf.Status.DataplaneTag, tc.tf.Spec.LiveTraffic, tc.tf.Spec.DroppedOnly, tc.receiverOnly, nil, tc.ofPort, crdv1alpha1.DefaultTraceflowTimeout)
+	tfc.mockOFClient.EXPECT().InstallTraceflowFlows(tc.tf.Status.DataplaneTag, tc.tf.Spec.LiveTraffic, tc.tf.Spec.DroppedOnly, tc.receiverOnly, false, nil, tc.ofPort, crdv1alpha1.DefaultTraceflowTimeout)
 	tfc.mockOFClient.EXPECT().SendTraceflowPacket(tc.tf.Status.DataplaneTag, tc.packet, tc.ofPort, int32(-1))
 	tfc.enqueueTraceflow(tc.tf)
 	got := tfc.processTraceflowItem()
@@ -849,6 +943,7 @@ func TestValidateTraceflow(t *testing.T) {
 		name               string
 		tf                 *crdv1alpha1.Traceflow
 		antreaProxyEnabled bool
+		networkConfig      *config.NetworkConfig
 		expectedErr        string
 	}{
 		{
@@ -885,12 +980,26 @@ func TestValidateTraceflow(t *testing.T) {
 			},
 			expectedErr: "using ClusterIP destination requires AntreaProxy feature enabled",
 		},
+		{
+			name: "dual-stack Traceflow in IPv4 cluster",
+			tf: &crdv1alpha1.Traceflow{
+				Spec: crdv1alpha1.TraceflowSpec{
+					Destination: crdv1alpha1.Destination{
+						Pod: "pod-2",
+					},
+					DualStack: true,
+				},
+			},
+			antreaProxyEnabled: true,
+			networkConfig:      &config.NetworkConfig{IPv4Enabled: true},
+			expectedErr:        "dual-stack Traceflow requires both IPv4 and IPv6 to be enabled",
+		},
 	}
 
 	for _, tt := range tcs {
 		t.Run(tt.name, func(t *testing.T) {
 			defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.AntreaProxy, tt.antreaProxyEnabled)()
-			tfc := newFakeTraceflowController(t, []runtime.Object{tt.tf}, nil, nil, nil, nil)
+			tfc := newFakeTraceflowController(t, []runtime.Object{tt.tf}, tt.networkConfig, nil, nil, nil)
 			err := tfc.validateTraceflow(tt.tf)
 			assert.ErrorContains(t, err, tt.expectedErr)
 		})
@@ -0,0 +1,897 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trafficmirror
+
+import (
+	"crypto/sha1" // #nosec G505: not used for security purposes
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/openflow"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	crdinformers "antrea.io/antrea/pkg/client/informers/externalversions/crd/v1alpha2"
+	crdlisters "antrea.io/antrea/pkg/client/listers/crd/v1alpha2"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+	"antrea.io/antrea/pkg/ovs/ovsctl"
+	"antrea.io/antrea/pkg/util/channel"
+	"antrea.io/antrea/pkg/util/k8s"
+	utilsets "antrea.io/antrea/pkg/util/sets"
+)
+
+const (
+	controllerName = "TrafficMirrorController"
+	// How long to wait before retrying the processing of a TrafficMirror change.
+	minRetryDelay = 5 * time.Second
+	maxRetryDelay = 300 * time.Second
+	// Default number of workers processing a TrafficMirror change.
+	defaultWorkers = 4
+	// Disable resyncing.
+	resyncPeriod time.Duration = 0
+
+	// The prefix of the names of the ERSPAN ports created for TrafficMirrors. It is different from the one used by
+	// TrafficControl, so that the ports created by the two features never collide.
+	portNamePrefixERSPAN = "mirror"
+)
+
+var (
+	// The ports created for TrafficMirrors are restored as TrafficControl interfaces to the interface store after
+	// restarting Antrea Agent.
+	trafficMirrorPortExternalIDs = map[string]interface{}{
+		interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaTrafficControl,
+	}
+
+	// The OpenFlow protocols to match for each protocol of TrafficMirror filters, for IPv4 and IPv6 respectively.
+	filterProtocols = map[string][2]binding.Protocol{
+		"":     {binding.ProtocolIP, binding.ProtocolIPv6},
+		"TCP":  {binding.ProtocolTCP, binding.ProtocolTCPv6},
+		"UDP":  {binding.ProtocolUDP, binding.ProtocolUDPv6},
+		"SCTP": {binding.ProtocolSCTP, binding.ProtocolSCTPv6},
+		"ICMP": {binding.ProtocolICMP, binding.ProtocolICMPv6},
+	}
+)
+
+// trafficMirrorState keeps the actual state of a TrafficMirror that has been realized.
+type trafficMirrorState struct {
+	// The actual name of the target port used by a TrafficMirror.
+	targetPortName string
+	// The actual openflow port of the target port used by a TrafficMirror.
+	targetOFPort uint32
+	// The actual direction of a TrafficMirror.
+	direction v1alpha2.Direction
+	// The actual filters of a TrafficMirror.
+	filters []types.TrafficMirrorFilter
+	// The actual openflow ports for which we have installed flows for a TrafficMirror. Note that, flows are only
+	// installed for the Pods whose effective TrafficMirror is the current TrafficMirror, and the ports are these Pods'.
+	ofPorts sets.Set[int32]
+	// The actual Pods applied with the TrafficMirror. Note that, a TrafficMirror can be either effective TrafficMirror
+	// or alternative TrafficMirror for these Pods.
+	pods sets.Set[string]
+}
+
+// podToTMBinding keeps the TrafficMirrors applied to a Pod. There is only one effective TrafficMirror for a Pod at any
+// given time.
+type podToTMBinding struct {
+	effectiveTM    string
+	alternativeTMs sets.Set[string]
+}
+
+// portToTMBinding keeps the TrafficMirrors using an OVS port.
+type portToTMBinding struct {
+	interfaceConfig *interfacestore.InterfaceConfig
+	trafficMirrors  sets.Set[string]
+}
+
+// Controller watches TrafficMirrors, and the Pods and Namespaces they select, and installs the flows which mirror the
+// matching traffic of the local Pods to the target of the TrafficMirrors. The target ports are created on the OVS
+// bridge on demand, and are deleted when they are no longer used by any TrafficMirror.
+type Controller struct {
+	ofClient openflow.Client
+
+	portToTMBindings   map[string]*portToTMBinding
+	ovsBridgeClient    ovsconfig.OVSBridgeClient
+	ovsCtlClient       ovsctl.OVSCtlClient
+	ovsPortUpdateMutex sync.Mutex
+
+	interfaceStore interfacestore.InterfaceStore
+
+	podInformer     cache.SharedIndexInformer
+	podLister       corelisters.PodLister
+	podListerSynced cache.InformerSynced
+
+	namespaceInformer     cache.SharedIndexInformer
+	namespaceLister       corelisters.NamespaceLister
+	namespaceListerSynced cache.InformerSynced
+
+	podToTMBindings      map[string]*podToTMBinding
+	podToTMBindingsMutex sync.RWMutex
+
+	tmStates      map[string]*trafficMirrorState
+	tmStatesMutex sync.RWMutex
+
+	trafficMirrorInformer     cache.SharedIndexInformer
+	trafficMirrorLister       crdlisters.TrafficMirrorLister
+	trafficMirrorListerSynced cache.InformerSynced
+	queue                     workqueue.RateLimitingInterface
+}
+
+func NewTrafficMirrorController(ofClient openflow.Client,
+	interfaceStore interfacestore.InterfaceStore,
+	ovsBridgeClient ovsconfig.OVSBridgeClient,
+	ovsCtlClient ovsctl.OVSCtlClient,
+	tmInformer crdinformers.TrafficMirrorInformer,
+	podInformer cache.SharedIndexInformer,
+	namespaceInformer coreinformers.NamespaceInformer,
+	podUpdateSubscriber channel.Subscriber) *Controller {
+	c := &Controller{
+		ofClient:                  ofClient,
+		ovsBridgeClient:           ovsBridgeClient,
+		ovsCtlClient:              ovsCtlClient,
+		interfaceStore:            interfaceStore,
+		trafficMirrorInformer:     tmInformer.Informer(),
+		trafficMirrorLister:       tmInformer.Lister(),
+		trafficMirrorListerSynced: tmInformer.Informer().HasSynced,
+		podInformer:               podInformer,
+		podLister:                 corelisters.NewPodLister(podInformer.GetIndexer()),
+		podListerSynced:           podInformer.HasSynced,
+		namespaceInformer:         namespaceInformer.Informer(),
+		namespaceLister:           namespaceInformer.Lister(),
+		namespaceListerSynced:     namespaceInformer.Informer().HasSynced,
+		podToTMBindings:           map[string]*podToTMBinding{},
+		portToTMBindings:          map[string]*portToTMBinding{},
+		tmStates:                  map[string]*trafficMirrorState{},
+		queue:                     workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(minRetryDelay, maxRetryDelay), "trafficMirror"),
+	}
+	c.trafficMirrorInformer.AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addTM,
+			UpdateFunc: c.updateTM,
+			DeleteFunc: c.deleteTM,
+		},
+		resyncPeriod,
+	)
+	c.podInformer.AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addPod,
+			UpdateFunc: c.updatePod,
+			DeleteFunc: c.deletePod,
+		},
+		resyncPeriod,
+	)
+	c.namespaceInformer.AddEventHandlerWithResyncPeriod(
+		cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addNamespace,
+			UpdateFunc: c.updateNamespace,
+			DeleteFunc: nil,
+		},
+		resyncPeriod,
+	)
+	podUpdateSubscriber.Subscribe(c.processPodUpdate)
+	return c
+}
+
+// processPodUpdate will be called when CNIServer publishes a Pod update event, and the event of TrafficMirror which is
+// the effective one of the Pod is triggered.
+func (c *Controller) processPodUpdate(e interface{}) {
+	c.podToTMBindingsMutex.RLock()
+	defer c.podToTMBindingsMutex.RUnlock()
+	podEvent := e.(types.PodUpdate)
+	pod := k8s.NamespacedName(podEvent.PodNamespace, podEvent.PodName)
+	podBinding, exists := c.podToTMBindings[pod]
+	if !exists {
+		return
+	}
+	c.queue.Add(podBinding.effectiveTM)
+}
+
+func (c *Controller) matchedPod(pod *v1.Pod, to *v1alpha2.AppliedTo) bool {
+	if to.NamespaceSelector == nil && to.PodSelector == nil {
+		return false
+	}
+	if to.NamespaceSelector != nil {
+		namespace, _ := c.namespaceLister.Get(pod.Namespace)
+		if namespace == nil {
+			return false
+		}
+		nsSelector, _ := metav1.LabelSelectorAsSelector(to.NamespaceSelector)
+		if !nsSelector.Matches(labels.Set(namespace.Labels)) {
+			return false
+		}
+	}
+	if to.PodSelector != nil {
+		podSelector, _ := metav1.LabelSelectorAsSelector(to.PodSelector)
+		if !podSelector.Matches(labels.Set(pod.Labels)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Controller) filterAffectedTMsByPod(pod *v1.Pod) sets.Set[string] {
+	affectedTMs := sets.New[string]()
+	allTMs, _ := c.trafficMirrorLister.List(labels.Everything())
+	for _, tm := range allTMs {
+		if c.matchedPod(pod, &tm.Spec.AppliedTo) {
+			affectedTMs.Insert(tm.GetName())
+		}
+	}
+	return affectedTMs
+}
+
+func (c *Controller) addPod(obj interface{}) {
+	pod := obj.(*v1.Pod)
+	if pod.Spec.HostNetwork {
+		return
+	}
+	affectedTMs := c.filterAffectedTMsByPod(pod)
+	if len(affectedTMs) == 0 {
+		return
+	}
+	klog.V(2).InfoS("Processing Pod ADD event", "Pod", klog.KObj(pod))
+	for affectedTM := range affectedTMs {
+		c.queue.Add(affectedTM)
+	}
+}
+
+func (c *Controller) updatePod(oldObj interface{}, obj interface{}) {
+	oldPod := oldObj.(*v1.Pod)
+	pod := obj.(*v1.Pod)
+	if pod.Spec.HostNetwork {
+		return
+	}
+	if reflect.DeepEqual(pod.GetLabels(), oldPod.GetLabels()) {
+		return
+	}
+	oldAffectedTMs := c.filterAffectedTMsByPod(oldPod)
+	nowAffectedTMs := c.filterAffectedTMsByPod(pod)
+	affectedTMs := utilsets.SymmetricDifferenceString(oldAffectedTMs, nowAffectedTMs)
+	if len(affectedTMs) == 0 {
+		return
+	}
+	klog.V(2).InfoS("Processing Pod UPDATE event", "Pod", klog.KObj(pod))
+	for affectedTM := range affectedTMs {
+		c.queue.Add(affectedTM)
+	}
+}
+
+func (c *Controller) deletePod(obj interface{}) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Received unexpected object: %v", obj)
+			return
+		}
+		pod, ok = deletedState.Obj.(*v1.Pod)
+		if !ok {
+			klog.Errorf("DeletedFinalStateUnknown contains non-Pod object: %v", deletedState.Obj)
+			return
+		}
+	}
+	if pod.Spec.HostNetwork {
+		return
+	}
+	affectedTMs := c.filterAffectedTMsByPod(pod)
+	if len(affectedTMs) == 0 {
+		return
+	}
+	klog.V(2).InfoS("Processing Pod DELETE event", "Pod", klog.KObj(pod))
+	for affectedTM := range affectedTMs {
+		c.queue.Add(affectedTM)
+	}
+}
+
+func matchedNamespace(namespace *v1.Namespace, to *v1alpha2.AppliedTo) bool {
+	if to.NamespaceSelector != nil {
+		nsSelector, _ := metav1.LabelSelectorAsSelector(to.NamespaceSelector)
+		if !nsSelector.Matches(labels.Set(namespace.Labels)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Controller) filterAffectedTMsByNS(namespace *v1.Namespace) sets.Set[string] {
+	affectedTMs := sets.New[string]()
+	allTMs, _ := c.trafficMirrorLister.List(labels.Everything())
+	for _, tm := range allTMs {
+		if matchedNamespace(namespace, &tm.Spec.AppliedTo) {
+			affectedTMs.Insert(tm.GetName())
+		}
+	}
+	return affectedTMs
+}
+
+func (c *Controller) addNamespace(obj interface{}) {
+	ns := obj.(*v1.Namespace)
+	affectedTMs := c.filterAffectedTMsByNS(ns)
+	if len(affectedTMs) == 0 {
+		return
+	}
+	klog.V(2).InfoS("Processing Namespace ADD event", "Namespace", klog.KObj(ns))
+	for tm := range affectedTMs {
+		c.queue.Add(tm)
+	}
+}
+
+func (c *Controller) updateNamespace(oldObj, obj interface{}) {
+	oldNS := oldObj.(*v1.Namespace)
+	ns := obj.(*v1.Namespace)
+	if reflect.DeepEqual(oldNS.GetLabels(), ns.GetLabels()) {
+		return
+	}
+	oldAffectedTMs := c.filterAffectedTMsByNS(oldNS)
+	nowAffectedTMs := c.filterAffectedTMsByNS(ns)
+	affectedTMs := utilsets.SymmetricDifferenceString(oldAffectedTMs, nowAffectedTMs)
+	if len(affectedTMs) == 0 {
+		return
+	}
+	klog.V(2).InfoS("Processing Namespace UPDATE event", "Namespace", klog.KObj(ns))
+	for tm := range affectedTMs {
+		c.queue.Add(tm)
+	}
+}
+
+func (c *Controller) addTM(obj interface{}) {
+	tm := obj.(*v1alpha2.TrafficMirror)
+	klog.V(2).InfoS("Processing TrafficMirror ADD event", "TrafficMirror", klog.KObj(tm))
+	c.queue.Add(tm.Name)
+}
+
+func (c *Controller) updateTM(oldObj interface{}, obj interface{}) {
+	oldTM := oldObj.(*v1alpha2.TrafficMirror)
+	tm := obj.(*v1alpha2.TrafficMirror)
+	if tm.GetGeneration() != oldTM.GetGeneration() {
+		klog.V(2).InfoS("Processing TrafficMirror UPDATE event", "TrafficMirror", klog.KObj(tm))
+		c.queue.Add(tm.Name)
+	}
+}
+
+func (c *Controller) deleteTM(obj interface{}) {
+	tm, ok := obj.(*v1alpha2.TrafficMirror)
+	if !ok {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Received unexpected object: %v", obj)
+			return
+		}
+		tm, ok = deletedState.Obj.(*v1alpha2.TrafficMirror)
+		if !ok {
+			klog.Errorf("DeletedFinalStateUnknown contains non-TrafficMirror object: %v", deletedState.Obj)
+			return
+		}
+	}
+	klog.V(2).InfoS("Processing TrafficMirror DELETE event", "TrafficMirror", klog.KObj(tm))
+	c.queue.Add(tm.Name)
+}
+
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting", "controllerName", controllerName)
+	defer klog.InfoS("Shutting down", "controllerName", controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, c.trafficMirrorListerSynced, c.podListerSynced, c.namespaceListerSynced) {
+		return
+	}
+
+	for i := 0; i < defaultWorkers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *Controller) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *Controller) processNextWorkItem() bool {
+	obj, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(obj)
+
+	if key, ok := obj.(string); !ok {
+		// As the item in the work queue is actually invalid, we call Forget here else we'd
+		// go into a loop of attempting to process a work item that is invalid.
+		// This should not happen.
+		c.queue.Forget(obj)
+		klog.Errorf("Expected string in work queue but got %#v", obj)
+		return true
+	} else if err := c.syncTrafficMirror(key); err == nil {
+		// If no error occurs we Forget this item, so it does not get queued again until
+		// another change happens.
+		c.queue.Forget(key)
+	} else {
+		// Put the item back on the work queue to handle any transient errors.
+		c.queue.AddRateLimited(key)
+		klog.ErrorS(err, "Syncing TrafficMirror failed, requeue", "TrafficMirror", key)
+	}
+	return true
+}
+
+func (c *Controller) newTrafficMirrorState(tmName string) *trafficMirrorState {
+	c.tmStatesMutex.Lock()
+	defer c.tmStatesMutex.Unlock()
+	state := &trafficMirrorState{
+		pods:    sets.New[string](),
+		ofPorts: sets.New[int32](),
+	}
+	c.tmStates[tmName] = state
+	return state
+}
+
+func (c *Controller) getTrafficMirrorState(tmName string) (*trafficMirrorState, bool) {
+	c.tmStatesMutex.RLock()
+	defer c.tmStatesMutex.RUnlock()
+	state, exists := c.tmStates[tmName]
+	return state, exists
+}
+
+func (c *Controller) deleteTrafficMirrorState(tmName string) {
+	c.tmStatesMutex.Lock()
+	defer c.tmStatesMutex.Unlock()
+	delete(c.tmStates, tmName)
+}
+
+func (c *Controller) filterPods(appliedTo *v1alpha2.AppliedTo) ([]*v1.Pod, error) {
+	// If both selectors are nil, no Pod should be selected.
+	if appliedTo.PodSelector == nil && appliedTo.NamespaceSelector == nil {
+		return nil, nil
+	}
+	var podSelector, nsSelector labels.Selector
+	var err error
+	var selectedPods []*v1.Pod
+
+	if appliedTo.PodSelector != nil {
+		podSelector, err = metav1.LabelSelectorAsSelector(appliedTo.PodSelector)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// If Pod selector is nil, then Namespace selector will not be nil, select all Pods from the selected Namespaces.
+		podSelector = labels.Everything()
+	}
+
+	if appliedTo.NamespaceSelector != nil {
+		var namespaces []*v1.Namespace
+		nsSelector, err = metav1.LabelSelectorAsSelector(appliedTo.NamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+		namespaces, err = c.namespaceLister.List(nsSelector)
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range namespaces {
+			pods, err := c.podLister.Pods(ns.Name).List(podSelector)
+			if err != nil {
+				return nil, err
+			}
+			selectedPods = append(selectedPods, pods...)
+		}
+	} else {
+		selectedPods, err = c.podLister.List(podSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var nonHostNetworkPods []*v1.Pod
+	// TrafficMirror does not support host network Pods.
+	for _, pod := range selectedPods {
+		if !pod.Spec.HostNetwork {
+			nonHostNetworkPods = append(nonHostNetworkPods, pod)
+		}
+	}
+	return nonHostNetworkPods, nil
+}
+
+// parseFilters converts the filters of a TrafficMirror to the filters used to install the flows. A filter which
+// doesn't specify any CIDR is converted to an IPv4 filter and an IPv6 filter.
+func parseFilters(filters []v1alpha2.TrafficMirrorFilter) ([]types.TrafficMirrorFilter, error) {
+	var parsedFilters []types.TrafficMirrorFilter
+	for i := range filters {
+		filter := &filters[i]
+		var protocol string
+		if filter.Protocol != nil {
+			protocol = strings.ToUpper(*filter.Protocol)
+		}
+		protocols, ok := filterProtocols[protocol]
+		if !ok {
+			return nil, fmt.Errorf("unsupported protocol %s in filter %d", *filter.Protocol, i)
+		}
+		if (filter.SourcePort != nil || filter.DestinationPort != nil) && (protocol == "" || protocol == "ICMP") {
+			return nil, fmt.Errorf("ports can only be set for TCP, UDP or SCTP in filter %d", i)
+		}
+		parsedFilter := types.TrafficMirrorFilter{}
+		var ipFamilies []bool
+		for _, cidr := range []struct {
+			value string
+			ipNet **net.IPNet
+		}{
+			{filter.SourceCIDR, &parsedFilter.SourceIPNet},
+			{filter.DestinationCIDR, &parsedFilter.DestinationIPNet},
+		} {
+			if cidr.value == "" {
+				continue
+			}
+			_, ipNet, err := net.ParseCIDR(cidr.value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %s in filter %d: %w", cidr.value, i, err)
+			}
+			*cidr.ipNet = ipNet
+			ipFamilies = append(ipFamilies, ipNet.IP.To4() == nil)
+		}
+		if len(ipFamilies) == 2 && ipFamilies[0] != ipFamilies[1] {
+			return nil, fmt.Errorf("source CIDR and destination CIDR must be of the same IP family in filter %d", i)
+		}
+		if filter.SourcePort != nil {
+			parsedFilter.SourcePort = uint16(*filter.SourcePort)
+		}
+		if filter.DestinationPort != nil {
+			parsedFilter.DestinationPort = uint16(*filter.DestinationPort)
+		}
+		if len(ipFamilies) == 0 {
+			ipv4Filter, ipv6Filter := parsedFilter, parsedFilter
+			ipv4Filter.Protocol, ipv6Filter.Protocol = protocols[0], protocols[1]
+			parsedFilters = append(parsedFilters, ipv4Filter, ipv6Filter)
+			continue
+		}
+		if ipFamilies[0] {
+			parsedFilter.Protocol = protocols[1]
+		} else {
+			parsedFilter.Protocol = protocols[0]
+		}
+		parsedFilters = append(parsedFilters, parsedFilter)
+	}
+	return parsedFilters, nil
+}
+
+// genERSPANPortName generates a port name for the given ERSPAN tunnel. Like TrafficControl, the uniqueness of ERSPAN
+// ports is based on the remote IP and the session ID only in OVS.
+func genERSPANPortName(tunnel *v1alpha2.ERSPANTunnel) string {
+	hash := sha1.New() // #nosec G401: not used for security purposes
+	hash.Write(net.ParseIP(tunnel.RemoteIP))
+
+	var sessionID, index, dir, hardwareID int32
+	if tunnel.SessionID != nil {
+		sessionID = *tunnel.SessionID
+	}
+	if tunnel.Index != nil {
+		index = *tunnel.Index
+	}
+	if tunnel.Dir != nil {
+		dir = *tunnel.Dir
+	}
+	if tunnel.HardwareID != nil {
+		hardwareID = *tunnel.HardwareID
+	}
+	binary.Write(hash, binary.BigEndian, sessionID)
+	binary.Write(hash, binary.BigEndian, tunnel.Version)
+	binary.Write(hash, binary.BigEndian, index)
+	binary.Write(hash, binary.BigEndian, dir)
+	binary.Write(hash, binary.BigEndian, hardwareID)
+	return fmt.Sprintf("%s-%s", portNamePrefixERSPAN, hex.EncodeToString(hash.Sum(nil))[:6])
+}
+
+func (c *Controller) createERSPANPort(portName string, tunnelConfig *v1alpha2.ERSPANTunnel) (string, error) {
+	extraOptions := make(map[string]interface{})
+	extraOptions["erspan_ver"] = strconv.Itoa(int(tunnelConfig.Version))
+	if tunnelConfig.SessionID != nil {
+		extraOptions["key"] = strconv.Itoa(int(*tunnelConfig.SessionID))
+	}
+	if tunnelConfig.Version == 1 {
+		if tunnelConfig.Index != nil {
+			extraOptions["erspan_idx"] = strconv.FormatInt(int64(*tunnelConfig.Index), 16)
+		}
+	} else if tunnelConfig.Version == 2 {
+		if tunnelConfig.Dir != nil {
+			extraOptions["erspan_dir"] = strconv.Itoa(int(*tunnelConfig.Dir))
+		}
+		if tunnelConfig.HardwareID != nil {
+			extraOptions["erspan_hwid"] = strconv.Itoa(int(*tunnelConfig.HardwareID))
+		}
+	}
+	return c.ovsBridgeClient.CreateTunnelPortExt(portName,
+		ovsconfig.ERSPANTunnel,
+		0,
+		false,
+		"",
+		tunnelConfig.RemoteIP,
+		"",
+		"",
+		extraOptions,
+		trafficMirrorPortExternalIDs)
+}
+
+func getPortName(target *v1alpha2.TrafficMirrorTarget) string {
+	switch {
+	case target.Device != nil:
+		return target.Device.Name
+	case target.ERSPAN != nil:
+		return genERSPANPortName(target.ERSPAN)
+	}
+	return ""
+}
+
+// getOrCreateTargetPort ensures that there is an OVS port for the given TrafficMirrorTarget and binds the port to the
+// TrafficMirror. The OVS port will be created if the port doesn't exist. It returns the ofPort of the OVS port on
+// success, an error if there is.
+func (c *Controller) getOrCreateTargetPort(target *v1alpha2.TrafficMirrorTarget, portName, tmName string) (uint32, error) {
+	c.ovsPortUpdateMutex.Lock()
+	defer c.ovsPortUpdateMutex.Unlock()
+
+	if portBinding, exists := c.portToTMBindings[portName]; exists {
+		portBinding.trafficMirrors.Insert(tmName)
+		return uint32(portBinding.interfaceConfig.OFPort), nil
+	}
+
+	// If there is no binding information of the port in portToTMBindings, query the interface store. This is used to
+	// rebuild portToTMBindings after restarting Antrea Agent.
+	if itf, ok := c.interfaceStore.GetInterfaceByName(portName); ok {
+		c.portToTMBindings[portName] = &portToTMBinding{
+			interfaceConfig: itf,
+			trafficMirrors:  sets.New[string](tmName),
+		}
+		return uint32(itf.OFPort), nil
+	}
+
+	var portUUID string
+	var err error
+	switch {
+	case target.Device != nil:
+		portUUID, err = c.ovsBridgeClient.CreatePort(portName, portName, trafficMirrorPortExternalIDs)
+	case target.ERSPAN != nil:
+		portUUID, err = c.createERSPANPort(portName, target.ERSPAN)
+	default:
+		err = fmt.Errorf("no target is specified")
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	ofPort, err := c.ovsBridgeClient.GetOFPort(portName, false)
+	if err != nil {
+		return 0, err
+	}
+	// Set the port with no-flood to reject ARP flood packets.
+	if err = c.ovsCtlClient.SetPortNoFlood(int(ofPort)); err != nil {
+		return 0, fmt.Errorf("failed to set port %s with no-flood config: %w", portName, err)
+	}
+
+	itf := interfacestore.NewTrafficControlInterface(portName, &interfacestore.OVSPortConfig{PortUUID: portUUID, OFPort: ofPort})
+	c.interfaceStore.AddInterface(itf)
+	c.portToTMBindings[portName] = &portToTMBinding{
+		interfaceConfig: itf,
+		trafficMirrors:  sets.New[string](tmName),
+	}
+	return uint32(ofPort), nil
+}
+
+// releaseTargetPort releases the port from the TrafficMirror and deletes the port if it is no longer used by any
+// TrafficMirror.
+func (c *Controller) releaseTargetPort(portName, tmName string) error {
+	c.ovsPortUpdateMutex.Lock()
+	defer c.ovsPortUpdateMutex.Unlock()
+	portBinding, exists := c.portToTMBindings[portName]
+	if !exists {
+		klog.InfoS("Port used by TrafficMirror has been deleted", "port", portName, "TrafficMirror", tmName)
+		return nil
+	}
+
+	portBinding.trafficMirrors.Delete(tmName)
+	if len(portBinding.trafficMirrors) == 0 {
+		if err := c.ovsBridgeClient.DeletePort(portBinding.interfaceConfig.PortUUID); err != nil {
+			return err
+		}
+		c.interfaceStore.DeleteInterface(portBinding.interfaceConfig)
+		delete(c.portToTMBindings, portName)
+	}
+	return nil
+}
+
+func (c *Controller) syncTrafficMirror(tmName string) error {
+	startTime := time.Now()
+	defer func() {
+		klog.V(2).InfoS("Finished syncing TrafficMirror", "TrafficMirror", tmName, "durationTime", time.Since(startTime))
+	}()
+
+	tm, err := c.trafficMirrorLister.Get(tmName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			tmState, exists := c.getTrafficMirrorState(tmName)
+			if !exists {
+				return nil
+			}
+			if err = c.uninstallTrafficMirror(tmName, tmState); err != nil {
+				return err
+			}
+			c.deleteTrafficMirrorState(tmName)
+			return nil
+		}
+		return err
+	}
+
+	filters, err := parseFilters(tm.Spec.Filters)
+	if err != nil {
+		return fmt.Errorf("invalid filters of TrafficMirror %s: %w", tmName, err)
+	}
+
+	tmState, exists := c.getTrafficMirrorState(tmName)
+	if !exists {
+		tmState = c.newTrafficMirrorState(tmName)
+	}
+
+	// If the name of the target port is different from the cached name in the TrafficMirror state, it could be caused
+	// by the target update of the TrafficMirror or the creation of the TrafficMirror.
+	targetPortName := getPortName(&tm.Spec.Target)
+	if targetPortName != tmState.targetPortName {
+		if tmState.targetPortName != "" {
+			if err = c.releaseTargetPort(tmState.targetPortName, tmName); err != nil {
+				return err
+			}
+		}
+		tmState.targetPortName = targetPortName
+	}
+	targetOFPort, err := c.getOrCreateTargetPort(&tm.Spec.Target, targetPortName, tmName)
+	if err != nil {
+		return err
+	}
+
+	needUpdateFlows := tmState.targetOFPort != targetOFPort || tmState.direction != tm.Spec.Direction || !reflect.DeepEqual(tmState.filters, filters)
+
+	var pods []*v1.Pod
+	if pods, err = c.filterPods(&tm.Spec.AppliedTo); err != nil {
+		return err
+	}
+
+	stalePods := tmState.pods.Union(nil)
+	newPods := sets.New[string]()
+	newOfPorts := sets.New[int32]()
+	for _, pod := range pods {
+		podNN := k8s.NamespacedName(pod.Namespace, pod.Name)
+		newPods.Insert(podNN)
+		stalePods.Delete(podNN)
+
+		// If the TrafficMirror is not the effective TrafficMirror for the Pod, do nothing.
+		if !c.bindPodToTrafficMirror(podNN, tmName) {
+			continue
+		}
+		podInterfaces := c.interfaceStore.GetContainerInterfacesByPod(pod.Name, pod.Namespace)
+		if len(podInterfaces) == 0 {
+			klog.InfoS("Interfaces of Pod not found", "Pod", klog.KObj(pod))
+			continue
+		}
+		newOfPorts.Insert(podInterfaces[0].OFPort)
+	}
+
+	if needUpdateFlows || !newOfPorts.Equal(tmState.ofPorts) {
+		var ofPorts []uint32
+		for _, port := range sets.List(newOfPorts) {
+			ofPorts = append(ofPorts, uint32(port))
+		}
+		if err = c.ofClient.InstallTrafficMirrorFlows(tm.Name, ofPorts, targetOFPort, tm.Spec.Direction, filters); err != nil {
+			return err
+		}
+	}
+	tmState.pods = newPods
+	tmState.ofPorts = newOfPorts
+	tmState.targetOFPort = targetOFPort
+	tmState.direction = tm.Spec.Direction
+	tmState.filters = filters
+
+	if len(stalePods) != 0 {
+		c.podsResync(stalePods, tmName)
+	}
+	return nil
+}
+
+func (c *Controller) uninstallTrafficMirror(tmName string, tmState *trafficMirrorState) error {
+	if err := c.ofClient.UninstallTrafficMirrorFlows(tmName); err != nil {
+		return err
+	}
+	if tmState.targetPortName != "" {
+		if err := c.releaseTargetPort(tmState.targetPortName, tmName); err != nil {
+			return err
+		}
+	}
+	if len(tmState.pods) != 0 {
+		c.podsResync(tmState.pods, tmName)
+	}
+	return nil
+}
+
+func (c *Controller) podsResync(pods sets.Set[string], tmName string) {
+	// Resync the Pods that have new effective TrafficMirror.
+	newEffectiveTMs := sets.New[string]()
+	for pod := range pods {
+		if newEffectiveTM := c.unbindPodFromTrafficMirror(pod, tmName); newEffectiveTM != "" {
+			newEffectiveTMs.Insert(newEffectiveTM)
+		}
+	}
+	for tm := range newEffectiveTMs {
+		c.queue.Add(tm)
+	}
+}
+
+// bindPodToTrafficMirror binds the Pod with the TrafficMirror and returns whether this TrafficMirror is the effective
+// one for the Pod.
+func (c *Controller) bindPodToTrafficMirror(pod, tm string) bool {
+	c.podToTMBindingsMutex.Lock()
+	defer c.podToTMBindingsMutex.Unlock()
+
+	podBinding, exists := c.podToTMBindings[pod]
+	if !exists {
+		c.podToTMBindings[pod] = &podToTMBinding{
+			effectiveTM:    tm,
+			alternativeTMs: sets.New[string](),
+		}
+		return true
+	}
+	if podBinding.effectiveTM == tm {
+		return true
+	}
+	podBinding.alternativeTMs.Insert(tm)
+	return false
+}
+
+// unbindPodFromTrafficMirror unbinds the Pod with the TrafficMirror. If the unbound TrafficMirror was the effective
+// one for the Pod and there are alternative ones, it will return the new effective TrafficMirror, otherwise return
+// empty string.
+func (c *Controller) unbindPodFromTrafficMirror(pod, tmName string) string {
+	c.podToTMBindingsMutex.Lock()
+	defer c.podToTMBindingsMutex.Unlock()
+
+	podBinding, exists := c.podToTMBindings[pod]
+	if !exists {
+		return ""
+	}
+	if podBinding.effectiveTM == tmName {
+		var popped bool
+		podBinding.effectiveTM, popped = podBinding.alternativeTMs.PopAny()
+		if !popped {
+			delete(c.podToTMBindings, pod)
+			return ""
+		}
+		return podBinding.effectiveTM
+	}
+	podBinding.alternativeTMs.Delete(tmName)
+	return ""
+}
@@ -0,0 +1,426 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trafficmirror
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	openflowtest "antrea.io/antrea/pkg/agent/openflow/testing"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/agent/util"
+	"antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	fakeversioned "antrea.io/antrea/pkg/client/clientset/versioned/fake"
+	crdinformers "antrea.io/antrea/pkg/client/informers/externalversions"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+	ovsconfigtest "antrea.io/antrea/pkg/ovs/ovsconfig/testing"
+	ovsctltest "antrea.io/antrea/pkg/ovs/ovsctl/testing"
+	"antrea.io/antrea/pkg/util/channel"
+	"antrea.io/antrea/pkg/util/k8s"
+)
+
+type fakeController struct {
+	*Controller
+	mockOFClient        *openflowtest.MockClient
+	mockOVSCtlClient    *ovsctltest.MockOVSCtlClient
+	mockOVSBridgeClient *ovsconfigtest.MockOVSBridgeClient
+	crdClient           *fakeversioned.Clientset
+	crdInformerFactory  crdinformers.SharedInformerFactory
+	client              *fake.Clientset
+	informerFactory     informers.SharedInformerFactory
+	localPodInformer    cache.SharedIndexInformer
+}
+
+func (c *fakeController) startInformers(stopCh chan struct{}) {
+	c.informerFactory.Start(stopCh)
+	c.informerFactory.WaitForCacheSync(stopCh)
+	go c.localPodInformer.Run(stopCh)
+	cache.WaitForCacheSync(stopCh, c.localPodInformer.HasSynced)
+	c.crdInformerFactory.Start(stopCh)
+	c.crdInformerFactory.WaitForCacheSync(stopCh)
+}
+
+var (
+	labels1 = map[string]string{"app1": "foo1"}
+	labels2 = map[string]string{"app2": "foo2"}
+
+	ns1 = newNamespace("ns1", labels1)
+	ns2 = newNamespace("ns2", labels2)
+
+	pod1 = newPod("ns1", "pod1", labels1)
+	pod2 = newPod("ns1", "pod2", labels2)
+	pod3 = newPod("ns2", "pod3", labels1)
+
+	pod1NN = k8s.NamespacedName("ns1", "pod1")
+	pod3NN = k8s.NamespacedName("ns2", "pod3")
+
+	pod1OFPort        = uint32(1)
+	pod2OFPort        = uint32(2)
+	pod3OFPort        = uint32(3)
+	targetPort1OFPort = uint32(5)
+
+	targetPort1Name  = "ids0"
+	targetPort1      = &v1alpha2.NetworkDevice{Name: targetPort1Name}
+	targetInterface1 = newTrafficControlInterface(targetPort1Name, int32(targetPort1OFPort))
+
+	podInterfaces = []*interfacestore.InterfaceConfig{
+		newPodInterface("ns1", "pod1", int32(pod1OFPort)),
+		newPodInterface("ns1", "pod2", int32(pod2OFPort)),
+		newPodInterface("ns2", "pod3", int32(pod3OFPort)),
+	}
+
+	tm1Name = "test-tm1"
+	tm2Name = "test-tm2"
+
+	externalIDs = map[string]interface{}{interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaTrafficControl}
+)
+
+func newFakeController(t *testing.T, objects []runtime.Object, initObjects []runtime.Object, interfaces []*interfacestore.InterfaceConfig) *fakeController {
+	controller := gomock.NewController(t)
+	mockOFClient := openflowtest.NewMockClient(controller)
+	mockOVSBridgeClient := ovsconfigtest.NewMockOVSBridgeClient(controller)
+	mockOVSCtlClient := ovsctltest.NewMockOVSCtlClient(controller)
+
+	client := fake.NewSimpleClientset(objects...)
+	crdClient := fakeversioned.NewSimpleClientset(initObjects...)
+
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	tmInformer := crdInformerFactory.Crd().V1alpha2().TrafficMirrors()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	nsInformer := informerFactory.Core().V1().Namespaces()
+	localPodInformer := coreinformers.NewPodInformer(client, metav1.NamespaceAll, 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+	ifaceStore := interfacestore.NewInterfaceStore()
+	for _, itf := range interfaces {
+		ifaceStore.AddInterface(itf)
+	}
+
+	podUpdateChannel := channel.NewSubscribableChannel("PodUpdate", 100)
+	tmController := NewTrafficMirrorController(mockOFClient, ifaceStore, mockOVSBridgeClient, mockOVSCtlClient, tmInformer, localPodInformer, nsInformer, podUpdateChannel)
+
+	return &fakeController{
+		Controller:          tmController,
+		mockOFClient:        mockOFClient,
+		mockOVSBridgeClient: mockOVSBridgeClient,
+		mockOVSCtlClient:    mockOVSCtlClient,
+		crdClient:           crdClient,
+		crdInformerFactory:  crdInformerFactory,
+		client:              client,
+		informerFactory:     informerFactory,
+		localPodInformer:    localPodInformer,
+	}
+}
+
+func newPod(ns, name string, labels map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: v1.PodSpec{
+			NodeName: "fakeNode",
+		},
+	}
+}
+
+func newPodInterface(podNamespace, podName string, ofPort int32) *interfacestore.InterfaceConfig {
+	containerName := k8s.NamespacedName(podNamespace, podName)
+	return &interfacestore.InterfaceConfig{
+		InterfaceName:            util.GenerateContainerInterfaceName(podName, podNamespace, containerName),
+		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{PodName: podName, PodNamespace: podNamespace, ContainerID: containerName},
+		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: ofPort},
+	}
+}
+
+func newTrafficControlInterface(interfaceName string, ofPort int32) *interfacestore.InterfaceConfig {
+	return &interfacestore.InterfaceConfig{
+		Type:                     interfacestore.TrafficControlInterface,
+		InterfaceName:            interfaceName,
+		ContainerInterfaceConfig: &interfacestore.ContainerInterfaceConfig{},
+		OVSPortConfig:            &interfacestore.OVSPortConfig{OFPort: ofPort, PortUUID: interfaceName},
+		TunnelInterfaceConfig:    &interfacestore.TunnelInterfaceConfig{},
+	}
+}
+
+func newNamespace(ns string, labels map[string]string) *v1.Namespace {
+	return &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   ns,
+			Labels: labels,
+		},
+	}
+}
+
+func generateTrafficMirror(name string, podSelector map[string]string, direction v1alpha2.Direction, target v1alpha2.TrafficMirrorTarget, filters ...v1alpha2.TrafficMirrorFilter) *v1alpha2.TrafficMirror {
+	return &v1alpha2.TrafficMirror{
+		ObjectMeta: metav1.ObjectMeta{Name: name, UID: "test-uid"},
+		Spec: v1alpha2.TrafficMirrorSpec{
+			AppliedTo: v1alpha2.AppliedTo{PodSelector: &metav1.LabelSelector{MatchLabels: podSelector}},
+			Direction: direction,
+			Filters:   filters,
+			Target:    target,
+		},
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func waitEvents(t *testing.T, expectedEvents int, c *fakeController) {
+	require.NoError(t, wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (done bool, err error) {
+		return c.queue.Len() == expectedEvents, nil
+	}))
+}
+
+func TestTrafficMirrorAdd(t *testing.T) {
+	remoteIP := "1.1.1.1"
+	erspanTunnel := &v1alpha2.ERSPANTunnel{Version: 1, RemoteIP: remoteIP, SessionID: int32Ptr(10), Index: int32Ptr(20)}
+	httpFilter := v1alpha2.TrafficMirrorFilter{Protocol: strPtr("TCP"), DestinationCIDR: "10.10.0.0/16", DestinationPort: int32Ptr(80)}
+	_, httpDstIPNet, _ := net.ParseCIDR("10.10.0.0/16")
+
+	testcases := []struct {
+		name            string
+		tm              *v1alpha2.TrafficMirror
+		extraInterfaces []*interfacestore.InterfaceConfig
+		expectedCalls   func(mockOFClient *openflowtest.MockClient,
+			mockOVSBridgeClient *ovsconfigtest.MockOVSBridgeClient,
+			mockOVSCtlClient *ovsctltest.MockOVSCtlClient)
+	}{
+		{
+			name: "Add TrafficMirror with non-existing target port (NetworkDevice)",
+			tm:   generateTrafficMirror(tm1Name, labels1, v1alpha2.DirectionBoth, v1alpha2.TrafficMirrorTarget{Device: targetPort1}),
+			expectedCalls: func(mockOFClient *openflowtest.MockClient,
+				mockOVSBridgeClient *ovsconfigtest.MockOVSBridgeClient,
+				mockOVSCtlClient *ovsctltest.MockOVSCtlClient) {
+				mockOVSBridgeClient.EXPECT().CreatePort(targetPort1Name, targetPort1Name, externalIDs).Return("uuid", nil)
+				mockOVSBridgeClient.EXPECT().GetOFPort(targetPort1Name, false).Return(int32(targetPort1OFPort), nil)
+				mockOVSCtlClient.EXPECT().SetPortNoFlood(int(targetPort1OFPort))
+				mockOFClient.EXPECT().InstallTrafficMirrorFlows(tm1Name, gomock.InAnyOrder([]uint32{pod1OFPort, pod3OFPort}), targetPort1OFPort, v1alpha2.DirectionBoth, nil)
+			},
+		},
+		{
+			name: "Add TrafficMirror with non-existing target port (ERSPAN)",
+			tm:   generateTrafficMirror(tm1Name, labels1, v1alpha2.DirectionEgress, v1alpha2.TrafficMirrorTarget{ERSPAN: erspanTunnel}),
+			expectedCalls: func(mockOFClient *openflowtest.MockClient,
+				mockOVSBridgeClient *ovsconfigtest.MockOVSBridgeClient,
+				mockOVSCtlClient *ovsctltest.MockOVSCtlClient) {
+				extraOptions := map[string]interface{}{"erspan_ver": "1", "key": "10", "erspan_idx": strconv.FormatInt(20, 16)}
+				mockOVSBridgeClient.EXPECT().CreateTunnelPortExt(genERSPANPortName(erspanTunnel), ovsconfig.TunnelType(ovsconfig.ERSPANTunnel), int32(0), false, "", remoteIP, "", "", extraOptions, externalIDs)
+				mockOVSBridgeClient.EXPECT().GetOFPort(genERSPANPortName(erspanTunnel), false).Return(int32(10), nil)
+				mockOVSCtlClient.EXPECT().SetPortNoFlood(10)
+				mockOFClient.EXPECT().InstallTrafficMirrorFlows(tm1Name, gomock.InAnyOrder([]uint32{pod1OFPort, pod3OFPort}), uint32(10), v1alpha2.DirectionEgress, nil)
+			},
+		},
+		{
+			name:            "Add TrafficMirror with existing target port and filters",
+			tm:              generateTrafficMirror(tm1Name, labels2, v1alpha2.DirectionIngress, v1alpha2.TrafficMirrorTarget{Device: targetPort1}, httpFilter),
+			extraInterfaces: []*interfacestore.InterfaceConfig{targetInterface1},
+			expectedCalls: func(mockOFClient *openflowtest.MockClient,
+				mockOVSBridgeClient *ovsconfigtest.MockOVSBridgeClient,
+				mockOVSCtlClient *ovsctltest.MockOVSCtlClient) {
+				expectedFilters := []types.TrafficMirrorFilter{{Protocol: binding.ProtocolTCP, DestinationIPNet: httpDstIPNet, DestinationPort: 80}}
+				mockOFClient.EXPECT().InstallTrafficMirrorFlows(tm1Name, []uint32{pod2OFPort}, targetPort1OFPort, v1alpha2.DirectionIngress, expectedFilters)
+			},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeController(t, []runtime.Object{ns1, ns2, pod1, pod2, pod3}, []runtime.Object{tt.tm}, append(podInterfaces, tt.extraInterfaces...))
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+			c.startInformers(stopCh)
+
+			tt.expectedCalls(c.mockOFClient, c.mockOVSBridgeClient, c.mockOVSCtlClient)
+			assert.NoError(t, c.syncTrafficMirror(tt.tm.Name))
+		})
+	}
+}
+
+func TestTrafficMirrorDelete(t *testing.T) {
+	tm1 := generateTrafficMirror(tm1Name, labels1, v1alpha2.DirectionIngress, v1alpha2.TrafficMirrorTarget{Device: targetPort1})
+	tm2 := generateTrafficMirror(tm2Name, labels1, v1alpha2.DirectionEgress, v1alpha2.TrafficMirrorTarget{Device: targetPort1})
+	c := newFakeController(t, []runtime.Object{ns1, ns2, pod1, pod3}, []runtime.Object{tm1, tm2}, append(podInterfaces, targetInterface1))
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	c.startInformers(stopCh)
+
+	// Fake the status after TrafficMirror tm1 and tm2 are added. TrafficMirror tm1 is the effective TrafficMirror of
+	// the Pods.
+	c.portToTMBindings = map[string]*portToTMBinding{
+		targetPort1Name: {targetInterface1, sets.New[string](tm1Name, tm2Name)},
+	}
+	c.tmStates = map[string]*trafficMirrorState{
+		tm1Name: {
+			targetPortName: targetPort1Name,
+			targetOFPort:   targetPort1OFPort,
+			direction:      v1alpha2.DirectionIngress,
+			ofPorts:        sets.New[int32](int32(pod1OFPort), int32(pod3OFPort)),
+			pods:           sets.New[string](pod1NN, pod3NN),
+		},
+		tm2Name: {
+			targetPortName: targetPort1Name,
+			targetOFPort:   targetPort1OFPort,
+			direction:      v1alpha2.DirectionEgress,
+			ofPorts:        sets.New[int32](),
+			pods:           sets.New[string](pod1NN, pod3NN),
+		},
+	}
+	c.podToTMBindings = map[string]*podToTMBinding{
+		pod1NN: {effectiveTM: tm1Name, alternativeTMs: sets.New[string](tm2Name)},
+		pod3NN: {effectiveTM: tm1Name, alternativeTMs: sets.New[string](tm2Name)},
+	}
+
+	// Ignore the TrafficMirror ADD events.
+	waitEvents(t, 2, c)
+	for i := 0; i < 2; i++ {
+		item, _ := c.queue.Get()
+		c.queue.Done(item)
+	}
+
+	require.NoError(t, c.crdClient.CrdV1alpha2().TrafficMirrors().Delete(context.TODO(), tm1Name, metav1.DeleteOptions{}))
+	waitEvents(t, 1, c)
+	item, _ := c.queue.Get()
+	c.queue.Done(item)
+
+	// The target port is still used by tm2, so it should not be deleted. tm2 should become the effective TrafficMirror
+	// of the Pods and be resynced.
+	c.mockOFClient.EXPECT().UninstallTrafficMirrorFlows(tm1Name)
+	require.NoError(t, c.syncTrafficMirror(tm1Name))
+	_, exists := c.tmStates[tm1Name]
+	assert.False(t, exists)
+	assert.Equal(t, sets.New[string](tm2Name), c.portToTMBindings[targetPort1Name].trafficMirrors)
+	assert.Equal(t, &podToTMBinding{effectiveTM: tm2Name, alternativeTMs: sets.New[string]()}, c.podToTMBindings[pod1NN])
+	waitEvents(t, 1, c)
+
+	c.mockOFClient.EXPECT().InstallTrafficMirrorFlows(tm2Name, gomock.InAnyOrder([]uint32{pod1OFPort, pod3OFPort}), targetPort1OFPort, v1alpha2.DirectionEgress, nil)
+	item, _ = c.queue.Get()
+	require.NoError(t, c.syncTrafficMirror(item.(string)))
+	c.queue.Done(item)
+
+	// Deleting the last TrafficMirror using the target port should delete the port.
+	require.NoError(t, c.crdClient.CrdV1alpha2().TrafficMirrors().Delete(context.TODO(), tm2Name, metav1.DeleteOptions{}))
+	waitEvents(t, 1, c)
+	item, _ = c.queue.Get()
+	c.queue.Done(item)
+	c.mockOFClient.EXPECT().UninstallTrafficMirrorFlows(tm2Name)
+	c.mockOVSBridgeClient.EXPECT().DeletePort(targetPort1Name)
+	require.NoError(t, c.syncTrafficMirror(tm2Name))
+	assert.Empty(t, c.portToTMBindings)
+	assert.Empty(t, c.podToTMBindings)
+	_, exists = c.interfaceStore.GetInterfaceByName(targetPort1Name)
+	assert.False(t, exists)
+}
+
+func TestParseFilters(t *testing.T) {
+	_, ipv4Net, _ := net.ParseCIDR("10.10.0.0/16")
+	_, ipv6Net, _ := net.ParseCIDR("fec0:10:10::/64")
+	testcases := []struct {
+		name            string
+		filters         []v1alpha2.TrafficMirrorFilter
+		expectedFilters []types.TrafficMirrorFilter
+		expectedErr     string
+	}{
+		{
+			name: "no filter",
+		},
+		{
+			name:    "protocol only",
+			filters: []v1alpha2.TrafficMirrorFilter{{Protocol: strPtr("udp"), DestinationPort: int32Ptr(53)}},
+			expectedFilters: []types.TrafficMirrorFilter{
+				{Protocol: binding.ProtocolUDP, DestinationPort: 53},
+				{Protocol: binding.ProtocolUDPv6, DestinationPort: 53},
+			},
+		},
+		{
+			name: "CIDRs",
+			filters: []v1alpha2.TrafficMirrorFilter{
+				{SourceCIDR: "10.10.1.1/16"},
+				{Protocol: strPtr("TCP"), DestinationCIDR: "fec0:10:10::/64", SourcePort: int32Ptr(8080)},
+			},
+			expectedFilters: []types.TrafficMirrorFilter{
+				{Protocol: binding.ProtocolIP, SourceIPNet: ipv4Net},
+				{Protocol: binding.ProtocolTCPv6, DestinationIPNet: ipv6Net, SourcePort: 8080},
+			},
+		},
+		{
+			name:        "unsupported protocol",
+			filters:     []v1alpha2.TrafficMirrorFilter{{Protocol: strPtr("GRE")}},
+			expectedErr: "unsupported protocol GRE",
+		},
+		{
+			name:        "port without protocol",
+			filters:     []v1alpha2.TrafficMirrorFilter{{DestinationPort: int32Ptr(80)}},
+			expectedErr: "ports can only be set for TCP, UDP or SCTP",
+		},
+		{
+			name:        "invalid CIDR",
+			filters:     []v1alpha2.TrafficMirrorFilter{{SourceCIDR: "10.10.0.0"}},
+			expectedErr: "invalid CIDR 10.10.0.0",
+		},
+		{
+			name:        "mixed IP families",
+			filters:     []v1alpha2.TrafficMirrorFilter{{SourceCIDR: "10.10.0.0/16", DestinationCIDR: "fec0:10:10::/64"}},
+			expectedErr: "must be of the same IP family",
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			filters, err := parseFilters(tt.filters)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedFilters, filters)
+		})
+	}
+}
+
+func TestGenERSPANPortName(t *testing.T) {
+	tunnel := &v1alpha2.ERSPANTunnel{RemoteIP: "1.1.1.1", Version: 2}
+	name := genERSPANPortName(tunnel)
+	assert.True(t, strings.HasPrefix(name, portNamePrefixERSPAN+"-"))
+	// The name must be a valid interface name.
+	assert.LessOrEqual(t, len(name), 15)
+	assert.Equal(t, name, genERSPANPortName(&v1alpha2.ERSPANTunnel{RemoteIP: "1.1.1.1", Version: 2, SessionID: int32Ptr(0)}))
+	assert.NotEqual(t, name, genERSPANPortName(&v1alpha2.ERSPANTunnel{RemoteIP: "1.1.1.2", Version: 2}))
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cpuaccounting attributes the CPU usage of the Antrea Agent to its
+// features. The goroutines processing work for a feature run the work with Run,
+// which tags them with pprof labels, so that CPU profiles collected from the
+// Agent can be filtered by feature, and accounts the time spent in the work to
+// the feature. The Estimator periodically reads the CPU time consumed by the Go
+// code of the Agent with runtime/metrics, and apportions it to the features
+// according to the time they were busy during the period.
+package cpuaccounting
+
+import (
+	"context"
+	runtimemetrics "runtime/metrics"
+	"runtime/pprof"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/metrics"
+)
+
+// Feature identifies a consumer of the Agent CPU.
+type Feature string
+
+const (
+	FeatureProxy         Feature = "proxy"
+	FeatureNetworkPolicy Feature = "networkpolicy"
+	FeatureFlowExporter  Feature = "flowexporter"
+	FeaturePacketIn      Feature = "packetin"
+	// FeatureOther is the Feature which the CPU time not attributed to any
+	// other Feature is accounted to.
+	FeatureOther Feature = "other"
+)
+
+// Features lists all the Features whose work is accounted with Run.
+var Features = []Feature{FeatureProxy, FeatureNetworkPolicy, FeatureFlowExporter, FeaturePacketIn}
+
+// LabelKey is the key of the pprof label set by Run.
+const LabelKey = "antrea_feature"
+
+const (
+	DefaultEstimateInterval = 30 * time.Second
+
+	// userCPUMetric is the CPU time spent running user Go code, which
+	// excludes the time spent by the Go runtime, e.g. for garbage collection.
+	userCPUMetric = "/cpu/classes/user:cpu-seconds"
+)
+
+// busyNanoseconds stores the accumulated time spent in Run by each Feature.
+var busyNanoseconds = func() map[Feature]*atomic.Int64 {
+	m := make(map[Feature]*atomic.Int64, len(Features))
+	for _, feature := range Features {
+		m[feature] = new(atomic.Int64)
+	}
+	return m
+}()
+
+// Run runs fn with the pprof label of the given Feature, and accounts the time
+// spent in fn to the Feature. The goroutines started by fn inherit the label,
+// but the time spent by them after fn returns is not accounted.
+func Run(feature Feature, fn func()) {
+	pprof.Do(context.Background(), pprof.Labels(LabelKey, string(feature)), func(context.Context) {
+		start := time.Now()
+		fn()
+		if busy, ok := busyNanoseconds[feature]; ok {
+			busy.Add(int64(time.Since(start)))
+		}
+	})
+}
+
+// Estimator periodically updates the busy time and estimated CPU time metrics
+// of each Feature.
+type Estimator struct {
+	interval time.Duration
+	// readCPUSeconds is parameterized for testing.
+	readCPUSeconds func() (float64, bool)
+
+	lastCPUSeconds float64
+	lastBusy       map[Feature]time.Duration
+}
+
+// NewEstimator creates an Estimator. A non-positive interval means
+// DefaultEstimateInterval.
+func NewEstimator(interval time.Duration) *Estimator {
+	if interval <= 0 {
+		interval = DefaultEstimateInterval
+	}
+	return &Estimator{
+		interval:       interval,
+		readCPUSeconds: readUserCPUSeconds,
+		lastBusy:       make(map[Feature]time.Duration, len(Features)),
+	}
+}
+
+func readUserCPUSeconds() (float64, bool) {
+	samples := []runtimemetrics.Sample{{Name: userCPUMetric}}
+	runtimemetrics.Read(samples)
+	// The metric is not supported by Go runtimes older than 1.20.
+	if samples[0].Value.Kind() != runtimemetrics.KindFloat64 {
+		return 0, false
+	}
+	return samples[0].Value.Float64(), true
+}
+
+func (e *Estimator) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Starting per-feature CPU estimator", "interval", e.interval)
+	// Initialize the baseline so that the CPU time consumed before the
+	// Estimator starts is not attributed to "other".
+	e.lastCPUSeconds, _ = e.readCPUSeconds()
+	for _, feature := range Features {
+		e.lastBusy[feature] = time.Duration(busyNanoseconds[feature].Load())
+	}
+	wait.Until(e.estimate, e.interval, stopCh)
+}
+
+// estimate apportions the CPU time consumed since the last estimate to the
+// Features. As the busy time of a Feature includes the time spent waiting, e.g.
+// for OVS or the Kubernetes API, the CPU time attributed to a Feature is its
+// share of the consumed CPU time, capped by its busy time. The remaining CPU
+// time is attributed to FeatureOther.
+func (e *Estimator) estimate() {
+	busyDeltas := make(map[Feature]float64, len(Features))
+	var totalBusy float64
+	for _, feature := range Features {
+		busy := time.Duration(busyNanoseconds[feature].Load())
+		delta := (busy - e.lastBusy[feature]).Seconds()
+		e.lastBusy[feature] = busy
+		busyDeltas[feature] = delta
+		totalBusy += delta
+		metrics.FeatureBusySeconds.WithLabelValues(string(feature)).Add(delta)
+	}
+
+	cpuSeconds, ok := e.readCPUSeconds()
+	if !ok {
+		return
+	}
+	cpuDelta := cpuSeconds - e.lastCPUSeconds
+	e.lastCPUSeconds = cpuSeconds
+	if cpuDelta <= 0 {
+		return
+	}
+	ratio := 1.0
+	if totalBusy > cpuDelta {
+		ratio = cpuDelta / totalBusy
+	}
+	attributed := 0.0
+	for _, feature := range Features {
+		estimate := busyDeltas[feature] * ratio
+		attributed += estimate
+		metrics.FeatureCPUSecondsEstimate.WithLabelValues(string(feature)).Add(estimate)
+	}
+	if other := cpuDelta - attributed; other > 0 {
+		metrics.FeatureCPUSecondsEstimate.WithLabelValues(string(FeatureOther)).Add(other)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cpuaccounting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/component-base/metrics/testutil"
+
+	"antrea.io/antrea/pkg/agent/metrics"
+)
+
+func resetBusyTime() {
+	for _, feature := range Features {
+		busyNanoseconds[feature].Store(0)
+	}
+	metrics.FeatureBusySeconds.Reset()
+	metrics.FeatureCPUSecondsEstimate.Reset()
+}
+
+func TestRun(t *testing.T) {
+	metrics.InitializeCPUAccountingMetrics()
+	defer resetBusyTime()
+
+	Run(FeatureProxy, func() {
+		time.Sleep(10 * time.Millisecond)
+	})
+	assert.GreaterOrEqual(t, time.Duration(busyNanoseconds[FeatureProxy].Load()), 10*time.Millisecond)
+	assert.Zero(t, busyNanoseconds[FeatureNetworkPolicy].Load())
+}
+
+func TestEstimate(t *testing.T) {
+	metrics.InitializeCPUAccountingMetrics()
+	tests := []struct {
+		name              string
+		busy              map[Feature]time.Duration
+		cpuSeconds        float64
+		expectedEstimates map[Feature]float64
+	}{
+		{
+			name:       "busy time lower than CPU time",
+			busy:       map[Feature]time.Duration{FeatureProxy: 2 * time.Second, FeatureNetworkPolicy: time.Second},
+			cpuSeconds: 5,
+			expectedEstimates: map[Feature]float64{
+				FeatureProxy:         2,
+				FeatureNetworkPolicy: 1,
+				FeatureFlowExporter:  0,
+				FeaturePacketIn:      0,
+				FeatureOther:         2,
+			},
+		},
+		{
+			name:       "busy time higher than CPU time",
+			busy:       map[Feature]time.Duration{FeatureProxy: 6 * time.Second, FeaturePacketIn: 2 * time.Second},
+			cpuSeconds: 4,
+			expectedEstimates: map[Feature]float64{
+				FeatureProxy:         3,
+				FeatureNetworkPolicy: 0,
+				FeatureFlowExporter:  0,
+				FeaturePacketIn:      1,
+				FeatureOther:         0,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer resetBusyTime()
+			e := NewEstimator(0)
+			assert.Equal(t, DefaultEstimateInterval, e.interval)
+			cpuSeconds := 10.0
+			e.readCPUSeconds = func() (float64, bool) { return cpuSeconds, true }
+			e.lastCPUSeconds = cpuSeconds
+
+			for feature, busy := range tt.busy {
+				busyNanoseconds[feature].Add(int64(busy))
+			}
+			cpuSeconds += tt.cpuSeconds
+			e.estimate()
+
+			for feature, expected := range tt.expectedEstimates {
+				value, err := testutil.GetCounterMetricValue(metrics.FeatureCPUSecondsEstimate.WithLabelValues(string(feature)))
+				assert.NoError(t, err)
+				assert.InDelta(t, expected, value, 1e-9, "feature %s", feature)
+			}
+			for feature, busy := range tt.busy {
+				value, err := testutil.GetCounterMetricValue(metrics.FeatureBusySeconds.WithLabelValues(string(feature)))
+				assert.NoError(t, err)
+				assert.InDelta(t, busy.Seconds(), value, 1e-9)
+			}
+		})
+	}
+}
@@ -38,6 +38,7 @@ type connectionStore struct {
 	antreaProxier          proxy.Proxier
 	expirePriorityQueue    *priorityqueue.ExpirePriorityQueue
 	staleConnectionTimeout time.Duration
+	exportFilter           *flowexporter.ExportFilter
 	mutex                  sync.Mutex
 }
 
@@ -51,6 +52,7 @@ func NewConnectionStore(
 		antreaProxier:          proxier,
 		expirePriorityQueue:    priorityqueue.NewExpirePriorityQueue(o.ActiveFlowTimeout, o.IdleFlowTimeout),
 		staleConnectionTimeout: o.StaleConnectionTimeout,
+		exportFilter:           o.ExportFilter,
 	}
 }
 
@@ -142,6 +144,15 @@ func lookupServiceProtocol(protoID uint8) (corev1.Protocol, error) {
 	return serviceProto, nil
 }
 
+// SetFlowExportTimeouts updates the active and idle flow export timeouts. The new timeouts apply to the connections
+// added or exported afterwards.
+func (cs *connectionStore) SetFlowExportTimeouts(activeFlowTimeout, idleFlowTimeout time.Duration) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	cs.expirePriorityQueue.ActiveFlowTimeout = activeFlowTimeout
+	cs.expirePriorityQueue.IdleFlowTimeout = idleFlowTimeout
+}
+
 func (cs *connectionStore) AcquireConnStoreLock() {
 	cs.mutex.Lock()
 }
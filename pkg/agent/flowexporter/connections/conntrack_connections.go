@@ -17,12 +17,16 @@ package connections
 import (
 	"encoding/binary"
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/vmware/go-ipfix/pkg/registry"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 
+	"antrea.io/antrea/pkg/agent/cpuaccounting"
 	"antrea.io/antrea/pkg/agent/flowexporter"
 	"antrea.io/antrea/pkg/agent/flowexporter/priorityqueue"
 	"antrea.io/antrea/pkg/agent/interfacestore"
@@ -38,13 +42,23 @@ var serviceProtocolMap = map[uint8]corev1.Protocol{
 	132: corev1.ProtocolSCTP,
 }
 
+// degradedPollIntervalFactor is the factor by which the poll interval is multiplied when the Agent is degraded.
+const degradedPollIntervalFactor = 4
+
+var _ querier.AgentConnectionQuerier = new(ConntrackConnectionStore)
+
 type ConntrackConnectionStore struct {
 	connDumper            ConnTrackDumper
 	v4Enabled             bool
 	v6Enabled             bool
 	networkPolicyQuerier  querier.AgentNetworkPolicyInfoQuerier
-	pollInterval          time.Duration
 	connectUplinkToBridge bool
+	// pollInterval stores the conntrack poll interval, which can be updated at runtime.
+	pollInterval atomic.Int64
+	// degraded is set when the Agent is degraded, in which case conntrack is polled less often.
+	degraded atomic.Bool
+	// tcpHandshakeTracker estimates the RTT of TCP connections. It is nil if TCP RTT estimation is disabled.
+	tcpHandshakeTracker *tcpHandshakeTracker
 	connectionStore
 }
 
@@ -57,22 +71,30 @@ func NewConntrackConnectionStore(
 	proxier proxy.Proxier,
 	o *flowexporter.FlowExporterOptions,
 ) *ConntrackConnectionStore {
-	return &ConntrackConnectionStore{
+	cs := &ConntrackConnectionStore{
 		connDumper:            connTrackDumper,
 		v4Enabled:             v4Enabled,
 		v6Enabled:             v6Enabled,
 		networkPolicyQuerier:  npQuerier,
-		pollInterval:          o.PollInterval,
 		connectionStore:       NewConnectionStore(ifaceStore, proxier, o),
 		connectUplinkToBridge: o.ConnectUplinkToBridge,
 	}
+	cs.pollInterval.Store(int64(o.PollInterval))
+	if o.EstimateTCPRTT {
+		cs.tcpHandshakeTracker = newTCPHandshakeTracker(cs.getZones(), clock.RealClock{})
+	}
+	return cs
 }
 
 // Run enables the periodical polling of conntrack connections at a given flowPollInterval.
 func (cs *ConntrackConnectionStore) Run(stopCh <-chan struct{}) {
 	klog.Infof("Starting conntrack polling")
+	if cs.tcpHandshakeTracker != nil {
+		go cs.tcpHandshakeTracker.Run(stopCh)
+	}
 
-	pollTicker := time.NewTicker(cs.pollInterval)
+	currentPollInterval := cs.getPollInterval()
+	pollTicker := time.NewTicker(currentPollInterval)
 	defer pollTicker.Stop()
 
 	for {
@@ -80,16 +102,43 @@ func (cs *ConntrackConnectionStore) Run(stopCh <-chan struct{}) {
 		case <-stopCh:
 			break
 		case <-pollTicker.C:
-			_, err := cs.Poll()
+			var err error
+			cpuaccounting.Run(cpuaccounting.FeatureFlowExporter, func() {
+				_, err = cs.Poll()
+			})
 			if err != nil {
 				// Not failing here as errors can be transient and could be resolved in future poll cycles.
 				// TODO: Come up with a backoff/retry mechanism by increasing poll interval and adding retry timeout
 				klog.Errorf("Error during conntrack poll cycle: %v", err)
 			}
+			if pollInterval := cs.getPollInterval(); pollInterval != currentPollInterval {
+				klog.InfoS("Changing conntrack poll interval", "pollInterval", pollInterval)
+				pollTicker.Reset(pollInterval)
+				currentPollInterval = pollInterval
+			}
 		}
 	}
 }
 
+// SetDegraded reduces the conntrack polling frequency when the Agent is degraded, and restores it when the Agent
+// recovers. The new poll interval takes effect after the next poll.
+func (cs *ConntrackConnectionStore) SetDegraded(degraded bool) {
+	cs.degraded.Store(degraded)
+}
+
+// SetPollInterval updates the conntrack poll interval. The new poll interval takes effect after the next poll.
+func (cs *ConntrackConnectionStore) SetPollInterval(pollInterval time.Duration) {
+	cs.pollInterval.Store(int64(pollInterval))
+}
+
+func (cs *ConntrackConnectionStore) getPollInterval() time.Duration {
+	pollInterval := time.Duration(cs.pollInterval.Load())
+	if cs.degraded.Load() {
+		return pollInterval * degradedPollIntervalFactor
+	}
+	return pollInterval
+}
+
 // Poll calls into conntrackDumper interface to dump conntrack flows. It returns the number of connections for each
 // address family, as a slice. In dual-stack clusters, the slice will contain 2 values (number of IPv4 connections first,
 // then number of IPv6 connections).
@@ -97,25 +146,10 @@ func (cs *ConntrackConnectionStore) Run(stopCh <-chan struct{}) {
 func (cs *ConntrackConnectionStore) Poll() ([]int, error) {
 	klog.V(2).Infof("Polling conntrack")
 
-	var zones []uint16
 	var connsLens []int
-	if cs.v4Enabled {
-		if cs.connectUplinkToBridge {
-			zones = append(zones, uint16(openflow.IPCtZoneTypeRegMark.GetValue()<<12))
-		} else {
-			zones = append(zones, openflow.CtZone)
-		}
-	}
-	if cs.v6Enabled {
-		if cs.connectUplinkToBridge {
-			zones = append(zones, uint16(openflow.IPv6CtZoneTypeRegMark.GetValue()<<12))
-		} else {
-			zones = append(zones, openflow.CtZoneV6)
-		}
-	}
 	var totalConns int
 	var filteredConnsList []*flowexporter.Connection
-	for _, zone := range zones {
+	for _, zone := range cs.getZones() {
 		filteredConnsListPerZone, totalConnsPerZone, err := cs.connDumper.DumpFlows(zone)
 		if err != nil {
 			return []int{}, err
@@ -177,6 +211,106 @@ func (cs *ConntrackConnectionStore) Poll() ([]int, error) {
 	return connsLens, nil
 }
 
+// getZones returns the conntrack zones of the Antrea connections for each enabled address family.
+func (cs *ConntrackConnectionStore) getZones() []uint16 {
+	var zones []uint16
+	if cs.v4Enabled {
+		if cs.connectUplinkToBridge {
+			zones = append(zones, uint16(openflow.IPCtZoneTypeRegMark.GetValue()<<12))
+		} else {
+			zones = append(zones, openflow.CtZone)
+		}
+	}
+	if cs.v6Enabled {
+		if cs.connectUplinkToBridge {
+			zones = append(zones, uint16(openflow.IPv6CtZoneTypeRegMark.GetValue()<<12))
+		} else {
+			zones = append(zones, openflow.CtZoneV6)
+		}
+	}
+	return zones
+}
+
+// GetConnections dumps the Antrea connections from the conntrack table and returns the ones matching the filter. The
+// K8s metadata of the connections is taken from the connection store if the connections are already tracked by it,
+// and resolved otherwise, while their state, timeout and statistics always reflect the current conntrack table.
+func (cs *ConntrackConnectionStore) GetConnections(filter *querier.ConnectionFilter) ([]*flowexporter.Connection, error) {
+	if cs.connDumper == nil {
+		return nil, fmt.Errorf("dumping conntrack connections is not supported with this OVS datapath type")
+	}
+	var dumpedConns []*flowexporter.Connection
+	for _, zone := range cs.getZones() {
+		conns, _, err := cs.connDumper.DumpFlows(zone)
+		if err != nil {
+			return nil, err
+		}
+		dumpedConns = append(dumpedConns, conns...)
+	}
+
+	cs.AcquireConnStoreLock()
+	defer cs.ReleaseConnStoreLock()
+	conns := make([]*flowexporter.Connection, 0, len(dumpedConns))
+	for _, dumpedConn := range dumpedConns {
+		conn := dumpedConn
+		if existingConn, exists := cs.connections[flowexporter.NewConnectionKey(dumpedConn)]; exists {
+			// Copy the connection in the store as it must not be modified without the lock.
+			connCopy := *existingConn
+			connCopy.Timeout = dumpedConn.Timeout
+			connCopy.StatusFlag = dumpedConn.StatusFlag
+			connCopy.TCPState = dumpedConn.TCPState
+			connCopy.StopTime = dumpedConn.StopTime
+			connCopy.OriginalPackets = dumpedConn.OriginalPackets
+			connCopy.OriginalBytes = dumpedConn.OriginalBytes
+			connCopy.ReversePackets = dumpedConn.ReversePackets
+			connCopy.ReverseBytes = dumpedConn.ReverseBytes
+			conn = &connCopy
+		} else {
+			cs.fillK8sMetadata(conn)
+		}
+		if connectionMatchesFilter(conn, filter) {
+			conns = append(conns, conn)
+		}
+	}
+	return conns, nil
+}
+
+// connectionMatchesFilter returns whether the connection matches all the non-empty attributes of the filter.
+func connectionMatchesFilter(conn *flowexporter.Connection, filter *querier.ConnectionFilter) bool {
+	if filter == nil {
+		return true
+	}
+	if filter.PodName != "" {
+		fromPod := conn.SourcePodNamespace == filter.Namespace && conn.SourcePodName == filter.PodName
+		toPod := conn.DestinationPodNamespace == filter.Namespace && conn.DestinationPodName == filter.PodName
+		if !fromPod && !toPod {
+			return false
+		}
+	}
+	if filter.ServiceName != "" {
+		// DestinationServicePortName is in the format <Namespace>/<name>[:<port name>].
+		svcName := strings.SplitN(conn.DestinationServicePortName, ":", 2)[0]
+		if svcName != filter.Namespace+"/"+filter.ServiceName {
+			return false
+		}
+	}
+	if filter.SourceIP != nil && !filter.SourceIP.Equal(conn.FlowKey.SourceAddress) {
+		return false
+	}
+	if filter.SourcePort != 0 && filter.SourcePort != conn.FlowKey.SourcePort {
+		return false
+	}
+	if filter.DestinationIP != nil && !filter.DestinationIP.Equal(conn.FlowKey.DestinationAddress) && !filter.DestinationIP.Equal(conn.DestinationServiceAddress) {
+		return false
+	}
+	if filter.DestinationPort != 0 && filter.DestinationPort != conn.FlowKey.DestinationPort && filter.DestinationPort != conn.DestinationServicePort {
+		return false
+	}
+	if filter.Protocol != 0 && filter.Protocol != conn.FlowKey.Protocol {
+		return false
+	}
+	return true
+}
+
 func (cs *ConntrackConnectionStore) addNetworkPolicyMetadata(conn *flowexporter.Connection) {
 	// Retrieve NetworkPolicy Name and Namespace by using the ingress and egress
 	// IDs stored in the connection label.
@@ -237,8 +371,9 @@ func (cs *ConntrackConnectionStore) AddOrUpdateConn(conn *flowexporter.Connectio
 		existingConn.ReverseBytes = conn.ReverseBytes
 		existingConn.ReversePackets = conn.ReversePackets
 		existingConn.TCPState = conn.TCPState
+		cs.updateTCPStats(existingConn, connKey)
 		existingConn.IsActive = flowexporter.CheckConntrackConnActive(existingConn)
-		if existingConn.IsActive {
+		if existingConn.IsActive && !existingConn.ExcludedFromExport {
 			existingItem, exists := cs.expirePriorityQueue.KeyToItem[connKey]
 			if !exists {
 				// If the connKey:pqItem pair does not exist in the map, it shows the
@@ -252,19 +387,8 @@ func (cs *ConntrackConnectionStore) AddOrUpdateConn(conn *flowexporter.Connectio
 		}
 		klog.V(4).InfoS("Antrea flow updated", "connection", existingConn)
 	} else {
-		cs.fillPodInfo(conn)
-		if conn.Mark&openflow.ServiceCTMark.GetRange().ToNXRange().ToUint32Mask() == openflow.ServiceCTMark.GetValue() {
-			clusterIP := conn.DestinationServiceAddress.String()
-			svcPort := conn.DestinationServicePort
-			protocol, err := lookupServiceProtocol(conn.FlowKey.Protocol)
-			if err != nil {
-				klog.InfoS("Could not retrieve Service protocol", "error", err)
-			} else {
-				serviceStr := fmt.Sprintf("%s:%d/%s", clusterIP, svcPort, protocol)
-				cs.fillServiceInfo(conn, serviceStr)
-			}
-		}
-		cs.addNetworkPolicyMetadata(conn)
+		cs.fillK8sMetadata(conn)
+		cs.updateTCPStats(conn, connKey)
 		if conn.StartTime.IsZero() {
 			conn.StartTime = time.Now()
 			conn.StopTime = time.Now()
@@ -272,13 +396,57 @@ func (cs *ConntrackConnectionStore) AddOrUpdateConn(conn *flowexporter.Connectio
 		conn.LastExportTime = conn.StartTime
 		metrics.TotalAntreaConnectionsInConnTrackTable.Inc()
 		conn.IsActive = true
-		// Add new antrea connection to connection store and PQ.
+		// Add new antrea connection to connection store and PQ. The connections which are not selected by the export
+		// filter are only added to the connection store, so that they are not resolved again at every poll.
 		cs.connections[connKey] = conn
+		if !cs.exportFilter.Matches(conn) {
+			conn.ExcludedFromExport = true
+			klog.V(4).InfoS("New Antrea flow added, excluded from export", "connection", conn)
+			return
+		}
 		cs.expirePriorityQueue.WriteItemToQueue(connKey, conn)
 		klog.V(4).InfoS("New Antrea flow added", "connection", conn)
 	}
 }
 
+// updateTCPStats accumulates the TCP states seen for a connection and the number of retransmitted SYN packets, and
+// fills the smoothed RTT once the handshake of the connection has been measured.
+func (cs *ConntrackConnectionStore) updateTCPStats(conn *flowexporter.Connection, connKey flowexporter.ConnectionKey) {
+	if conn.FlowKey.Protocol != 6 {
+		return
+	}
+	conn.TCPStateFlags |= flowexporter.TCPStateToFlags(conn.TCPState)
+	if retransmissions := flowexporter.GetTCPSynRetransmissions(conn.TCPState, conn.OriginalPackets, conn.ReversePackets); retransmissions > conn.TCPSynRetransmissions {
+		conn.TCPSynRetransmissions = retransmissions
+	}
+	if cs.tcpHandshakeTracker == nil || conn.TCPSmoothedRTT != 0 {
+		return
+	}
+	if stats, exists := cs.tcpHandshakeTracker.popHandshakeStats(connKey); exists {
+		conn.TCPSmoothedRTT = stats.smoothedRTT
+		if stats.retransmissions > conn.TCPSynRetransmissions {
+			conn.TCPSynRetransmissions = stats.retransmissions
+		}
+	}
+}
+
+// fillK8sMetadata resolves the Pods, the Service and the NetworkPolicies of a new connection.
+func (cs *ConntrackConnectionStore) fillK8sMetadata(conn *flowexporter.Connection) {
+	cs.fillPodInfo(conn)
+	if conn.Mark&openflow.ServiceCTMark.GetRange().ToNXRange().ToUint32Mask() == openflow.ServiceCTMark.GetValue() {
+		clusterIP := conn.DestinationServiceAddress.String()
+		svcPort := conn.DestinationServicePort
+		protocol, err := lookupServiceProtocol(conn.FlowKey.Protocol)
+		if err != nil {
+			klog.InfoS("Could not retrieve Service protocol", "error", err)
+		} else {
+			serviceStr := fmt.Sprintf("%s:%d/%s", clusterIP, svcPort, protocol)
+			cs.fillServiceInfo(conn, serviceStr)
+		}
+	}
+	cs.addNetworkPolicyMetadata(conn)
+}
+
 func (cs *ConntrackConnectionStore) GetExpiredConns(expiredConns []flowexporter.Connection, currTime time.Time, maxSize int) ([]flowexporter.Connection, time.Duration) {
 	cs.AcquireConnStoreLock()
 	defer cs.ReleaseConnStoreLock()
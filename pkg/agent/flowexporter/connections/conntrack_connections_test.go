@@ -40,6 +40,7 @@ import (
 	agenttypes "antrea.io/antrea/pkg/agent/types"
 	cpv1beta "antrea.io/antrea/pkg/apis/controlplane/v1beta2"
 	secv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	"antrea.io/antrea/pkg/querier"
 	queriertest "antrea.io/antrea/pkg/querier/testing"
 	k8sproxy "antrea.io/antrea/third_party/proxy"
 )
@@ -332,6 +333,131 @@ func TestConnectionStore_MetricSettingInPoll(t *testing.T) {
 	checkMaxConnectionsMetric(t, MaxConnections)
 }
 
+func TestConntrackConnectionStore_SetDegraded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIfaceStore := interfacestoretest.NewMockInterfaceStore(ctrl)
+	conntrackConnStore := NewConntrackConnectionStore(nil, true, false, nil, mockIfaceStore, nil, &flowexporter.FlowExporterOptions{PollInterval: 5 * time.Second})
+	assert.Equal(t, 5*time.Second, conntrackConnStore.getPollInterval())
+	conntrackConnStore.SetDegraded(true)
+	assert.Equal(t, 20*time.Second, conntrackConnStore.getPollInterval())
+	conntrackConnStore.SetDegraded(false)
+	assert.Equal(t, 5*time.Second, conntrackConnStore.getPollInterval())
+}
+
+func TestConntrackConnectionStore_SetPollIntervalAndTimeouts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockIfaceStore := interfacestoretest.NewMockInterfaceStore(ctrl)
+	conntrackConnStore := NewConntrackConnectionStore(nil, true, false, nil, mockIfaceStore, nil, &flowexporter.FlowExporterOptions{
+		PollInterval:      5 * time.Second,
+		ActiveFlowTimeout: 5 * time.Second,
+		IdleFlowTimeout:   15 * time.Second,
+	})
+	conntrackConnStore.SetPollInterval(10 * time.Second)
+	assert.Equal(t, 10*time.Second, conntrackConnStore.getPollInterval())
+	conntrackConnStore.SetDegraded(true)
+	assert.Equal(t, 40*time.Second, conntrackConnStore.getPollInterval())
+
+	conntrackConnStore.SetFlowExportTimeouts(30*time.Second, 60*time.Second)
+	assert.Equal(t, 30*time.Second, conntrackConnStore.GetPriorityQueue().ActiveFlowTimeout)
+	assert.Equal(t, 60*time.Second, conntrackConnStore.GetPriorityQueue().IdleFlowTimeout)
+}
+
+func TestConntrackConnectionStore_GetConnections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	metrics.InitializeConnectionMetrics()
+	refTime := time.Now()
+
+	mockIfaceStore := interfacestoretest.NewMockInterfaceStore(ctrl)
+	mockProxier := proxytest.NewMockProxier(ctrl)
+	mockConnDumper := connectionstest.NewMockConnTrackDumper(ctrl)
+	npQuerier := queriertest.NewMockAgentNetworkPolicyInfoQuerier(ctrl)
+	conntrackConnStore := NewConntrackConnectionStore(mockConnDumper, true, false, npQuerier, mockIfaceStore, mockProxier, testFlowExporterOptions)
+
+	// The connection already in the store, whose K8s metadata should be reused.
+	storedConn := &flowexporter.Connection{
+		StartTime:                  refTime.Add(-time.Minute),
+		StopTime:                   refTime.Add(-time.Second),
+		FlowKey:                    tuple2,
+		Timeout:                    86400,
+		TCPState:                   "ESTABLISHED",
+		OriginalPackets:            10,
+		SourcePodNamespace:         "ns2",
+		SourcePodName:              "pod2",
+		DestinationServicePortName: servicePortName.String(),
+		IsPresent:                  true,
+	}
+	addConnToStore(conntrackConnStore, storedConn)
+	dumpedStoredConn := &flowexporter.Connection{
+		StartTime:       refTime.Add(-time.Minute),
+		StopTime:        refTime,
+		FlowKey:         tuple2,
+		Timeout:         120,
+		TCPState:        "FIN_WAIT",
+		OriginalPackets: 20,
+	}
+	// The connection not in the store, whose K8s metadata should be resolved.
+	newConn := flowexporter.Connection{
+		StartTime: refTime,
+		StopTime:  refTime,
+		FlowKey:   tuple1,
+		Timeout:   30,
+		Labels:    []byte{0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x0, 0x0},
+		Mark:      openflow.ServiceCTMark.GetValue(),
+	}
+	testAddNewConn(mockIfaceStore, mockProxier, npQuerier, newConn)
+	mockConnDumper.EXPECT().DumpFlows(uint16(openflow.CtZone)).Return([]*flowexporter.Connection{dumpedStoredConn, &newConn}, 2, nil)
+
+	conns, err := conntrackConnStore.GetConnections(nil)
+	require.NoError(t, err)
+	require.Len(t, conns, 2)
+	assert.Equal(t, "pod2", conns[0].SourcePodName, "K8s metadata should be taken from the connection store")
+	assert.Equal(t, uint32(120), conns[0].Timeout)
+	assert.Equal(t, "FIN_WAIT", conns[0].TCPState)
+	assert.Equal(t, uint64(20), conns[0].OriginalPackets)
+	assert.Equal(t, "ESTABLISHED", storedConn.TCPState, "Connection in the store should not be modified")
+	assert.Equal(t, "ns1", conns[1].DestinationPodNamespace)
+	assert.Equal(t, "pod1", conns[1].DestinationPodName)
+	assert.Equal(t, servicePortName.String(), conns[1].DestinationServicePortName)
+	assert.Equal(t, np1.Name, conns[1].IngressNetworkPolicyName)
+	_, exists := conntrackConnStore.GetConnByKey(flowexporter.NewConnectionKey(&newConn))
+	assert.False(t, exists, "New connection should not be added to the connection store")
+}
+
+func TestConnectionMatchesFilter(t *testing.T) {
+	conn := &flowexporter.Connection{
+		FlowKey:                    flowexporter.Tuple{SourceAddress: net.ParseIP("10.10.0.1"), DestinationAddress: net.ParseIP("10.10.1.2"), Protocol: 6, SourcePort: 50000, DestinationPort: 8080},
+		DestinationServiceAddress:  net.ParseIP("10.96.0.10"),
+		DestinationServicePort:     80,
+		SourcePodNamespace:         "ns1",
+		SourcePodName:              "pod1",
+		DestinationServicePortName: "ns2/svc1:http",
+	}
+	tests := []struct {
+		name     string
+		filter   *querier.ConnectionFilter
+		expected bool
+	}{
+		{name: "nil filter", expected: true},
+		{name: "empty filter", filter: &querier.ConnectionFilter{}, expected: true},
+		{name: "source Pod", filter: &querier.ConnectionFilter{Namespace: "ns1", PodName: "pod1"}, expected: true},
+		{name: "Pod in another Namespace", filter: &querier.ConnectionFilter{Namespace: "ns2", PodName: "pod1"}, expected: false},
+		{name: "Service", filter: &querier.ConnectionFilter{Namespace: "ns2", ServiceName: "svc1"}, expected: true},
+		{name: "Service with same prefix", filter: &querier.ConnectionFilter{Namespace: "ns2", ServiceName: "svc"}, expected: false},
+		{name: "source", filter: &querier.ConnectionFilter{SourceIP: net.ParseIP("10.10.0.1"), SourcePort: 50000}, expected: true},
+		{name: "other source port", filter: &querier.ConnectionFilter{SourceIP: net.ParseIP("10.10.0.1"), SourcePort: 50001}, expected: false},
+		{name: "original destination", filter: &querier.ConnectionFilter{DestinationIP: net.ParseIP("10.96.0.10"), DestinationPort: 80}, expected: true},
+		{name: "translated destination", filter: &querier.ConnectionFilter{DestinationIP: net.ParseIP("10.10.1.2"), DestinationPort: 8080}, expected: true},
+		{name: "other destination", filter: &querier.ConnectionFilter{DestinationIP: net.ParseIP("10.96.0.11")}, expected: false},
+		{name: "protocol", filter: &querier.ConnectionFilter{Protocol: 6}, expected: true},
+		{name: "other protocol", filter: &querier.ConnectionFilter{Protocol: 17}, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, connectionMatchesFilter(conn, tt.filter))
+		})
+	}
+}
+
 func checkAntreaConnectionMetrics(t *testing.T, numConns int) {
 	expectedAntreaConnectionCount := `
 	# HELP antrea_agent_conntrack_antrea_connection_count [ALPHA] Number of connections in the Antrea ZoneID of the conntrack table. This metric gets updated at an interval specified by flowPollInterval, a configuration parameter for the Agent.
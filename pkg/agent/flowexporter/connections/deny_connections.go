@@ -99,6 +99,10 @@ func (ds *DenyConnectionStore) AddOrUpdateConn(conn *flowexporter.Connection, ti
 		protocolStr := ip.IPProtocolNumberToString(conn.FlowKey.Protocol, "UnknownProtocol")
 		serviceStr := fmt.Sprintf("%s:%d/%s", conn.DestinationServiceAddress, conn.DestinationServicePort, protocolStr)
 		ds.fillServiceInfo(conn, serviceStr)
+		if !ds.exportFilter.Matches(conn) {
+			klog.V(4).InfoS("Deny connection excluded from export", "connection", conn)
+			return
+		}
 		metrics.TotalDenyConnections.Inc()
 		conn.IsActive = true
 		ds.connections[connKey] = conn
@@ -0,0 +1,139 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"antrea.io/antrea/pkg/agent/flowexporter"
+)
+
+const (
+	// handshakeStatsTimeout is the time after which the stats of a handshake are forgotten if they have not been
+	// consumed by the connection store, e.g. because the connection is not an Antrea connection, or because the
+	// handshake never completed.
+	handshakeStatsTimeout = 2 * time.Minute
+	// smoothedRTTTimeout is the time after which the smoothed RTT to a destination is forgotten if no new sample is
+	// collected for it.
+	smoothedRTTTimeout = 10 * time.Minute
+	// rttSmoothingFactor is the weight of a new RTT sample in the smoothed RTT, as recommended by RFC 6298.
+	rttSmoothingFactor = 0.125
+	// handshakeGCInterval is the interval at which the stale handshake stats are garbage collected.
+	handshakeGCInterval = time.Minute
+)
+
+type handshakeStats struct {
+	smoothedRTT     time.Duration
+	retransmissions uint32
+	completionTime  time.Time
+}
+
+type smoothedRTT struct {
+	rtt        time.Duration
+	updateTime time.Time
+}
+
+// tcpHandshakeTracker estimates the RTT of TCP connections from the duration of their handshakes, i.e. the time
+// between the SYN packet and the ACK packet completing the handshake, as observed by conntrack. As the handshake of a
+// connection only provides a single sample, the samples are smoothed per destination IP. The stats of the completed
+// handshakes are kept until they are consumed by the connection store.
+type tcpHandshakeTracker struct {
+	zones []uint16
+	clock clock.Clock
+	mutex sync.Mutex
+	// pendingHandshakes maps the connections whose handshake is in progress to the time their SYN was seen.
+	pendingHandshakes map[flowexporter.ConnectionKey]time.Time
+	// completedHandshakes stores the stats of the completed handshakes.
+	completedHandshakes map[flowexporter.ConnectionKey]*handshakeStats
+	// smoothedRTTs maps destination IPs to their smoothed RTT.
+	smoothedRTTs map[string]*smoothedRTT
+}
+
+func newTCPHandshakeTracker(zones []uint16, clock clock.Clock) *tcpHandshakeTracker {
+	return &tcpHandshakeTracker{
+		zones:               zones,
+		clock:               clock,
+		pendingHandshakes:   map[flowexporter.ConnectionKey]time.Time{},
+		completedHandshakes: map[flowexporter.ConnectionKey]*handshakeStats{},
+		smoothedRTTs:        map[string]*smoothedRTT{},
+	}
+}
+
+func (t *tcpHandshakeTracker) onHandshakeStarted(key flowexporter.ConnectionKey) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.pendingHandshakes[key] = t.clock.Now()
+}
+
+func (t *tcpHandshakeTracker) onHandshakeCompleted(key flowexporter.ConnectionKey, destinationIP string, retransmissions uint32) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	startTime, exists := t.pendingHandshakes[key]
+	if !exists {
+		return
+	}
+	delete(t.pendingHandshakes, key)
+	now := t.clock.Now()
+	sample := now.Sub(startTime)
+	srtt, exists := t.smoothedRTTs[destinationIP]
+	if !exists {
+		srtt = &smoothedRTT{rtt: sample}
+		t.smoothedRTTs[destinationIP] = srtt
+	} else {
+		srtt.rtt = time.Duration((1-rttSmoothingFactor)*float64(srtt.rtt) + rttSmoothingFactor*float64(sample))
+	}
+	srtt.updateTime = now
+	t.completedHandshakes[key] = &handshakeStats{
+		smoothedRTT:     srtt.rtt,
+		retransmissions: retransmissions,
+		completionTime:  now,
+	}
+}
+
+// popHandshakeStats returns the stats of the completed handshake of a connection, and forgets them.
+func (t *tcpHandshakeTracker) popHandshakeStats(key flowexporter.ConnectionKey) (*handshakeStats, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	stats, exists := t.completedHandshakes[key]
+	if exists {
+		delete(t.completedHandshakes, key)
+	}
+	return stats, exists
+}
+
+// gc forgets the stale handshake stats and smoothed RTTs.
+func (t *tcpHandshakeTracker) gc() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	now := t.clock.Now()
+	for key, startTime := range t.pendingHandshakes {
+		if now.Sub(startTime) > handshakeStatsTimeout {
+			delete(t.pendingHandshakes, key)
+		}
+	}
+	for key, stats := range t.completedHandshakes {
+		if now.Sub(stats.completionTime) > handshakeStatsTimeout {
+			delete(t.completedHandshakes, key)
+		}
+	}
+	for ip, srtt := range t.smoothedRTTs {
+		if now.Sub(srtt.updateTime) > smoothedRTTTimeout {
+			delete(t.smoothedRTTs, ip)
+		}
+	}
+}
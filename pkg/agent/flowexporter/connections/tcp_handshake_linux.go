@@ -0,0 +1,101 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+// +build linux
+
+package connections
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ti-mo/conntrack"
+	"github.com/ti-mo/netfilter"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/flowexporter"
+)
+
+const (
+	tcpStateSynSent     uint8 = 1
+	tcpStateEstablished uint8 = 3
+	tcpProtocolNumber   uint8 = 6
+)
+
+// Run listens to conntrack events to measure the duration of the TCP handshakes in the Antrea zones, until stopCh is
+// closed. It relies on conntrack events, which are only generated by the OVS kernel datapath.
+func (t *tcpHandshakeTracker) Run(stopCh <-chan struct{}) {
+	if err := t.listen(stopCh); err != nil {
+		klog.ErrorS(err, "Failed to listen to conntrack events, TCP RTT will not be estimated")
+	}
+}
+
+func (t *tcpHandshakeTracker) listen(stopCh <-chan struct{}) error {
+	conn, err := conntrack.Dial(nil)
+	if err != nil {
+		return fmt.Errorf("error when getting netlink socket: %v", err)
+	}
+	defer conn.Close()
+	evChan := make(chan conntrack.Event, 1024)
+	errChan, err := conn.Listen(evChan, 1, []netfilter.NetlinkGroup{netfilter.GroupCTNew, netfilter.GroupCTUpdate})
+	if err != nil {
+		return fmt.Errorf("error when listening to conntrack events: %v", err)
+	}
+	gcTicker := time.NewTicker(handshakeGCInterval)
+	defer gcTicker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		case err := <-errChan:
+			return err
+		case <-gcTicker.C:
+			t.gc()
+		case ev := <-evChan:
+			t.handleEvent(&ev)
+		}
+	}
+}
+
+func (t *tcpHandshakeTracker) handleEvent(ev *conntrack.Event) {
+	flow := ev.Flow
+	if flow == nil || flow.TupleOrig.Proto.Protocol != tcpProtocolNumber || flow.ProtoInfo.TCP == nil || !t.isAntreaZone(flow.Zone) {
+		return
+	}
+	conn := NetlinkFlowToAntreaConnection(flow)
+	key := flowexporter.NewConnectionKey(conn)
+	switch {
+	case ev.Type == conntrack.EventNew && flow.ProtoInfo.TCP.State == tcpStateSynSent:
+		t.onHandshakeStarted(key)
+	case ev.Type == conntrack.EventUpdate && flow.ProtoInfo.TCP.State == tcpStateEstablished:
+		// The ACK completing the handshake has already been accounted for in the original direction, so it is
+		// excluded when counting the retransmitted SYN and SYN-ACK packets.
+		var originalPackets uint64
+		if flow.CountersOrig.Packets > 0 {
+			originalPackets = flow.CountersOrig.Packets - 1
+		}
+		retransmissions := flowexporter.GetTCPSynRetransmissions("SYN_RECV", originalPackets, flow.CountersReply.Packets)
+		t.onHandshakeCompleted(key, conn.FlowKey.DestinationAddress.String(), retransmissions)
+	}
+}
+
+func (t *tcpHandshakeTracker) isAntreaZone(zone uint16) bool {
+	for _, z := range t.zones {
+		if z == zone {
+			return true
+		}
+	}
+	return false
+}
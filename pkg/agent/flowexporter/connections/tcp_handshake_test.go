@@ -0,0 +1,99 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connections
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	"antrea.io/antrea/pkg/agent/flowexporter"
+)
+
+func TestTCPHandshakeTracker(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	tracker := newTCPHandshakeTracker(nil, fakeClock)
+	key1 := flowexporter.ConnectionKey{"10.10.0.1", "50000", "10.10.1.2", "80", "6"}
+	key2 := flowexporter.ConnectionKey{"10.10.0.1", "50001", "10.10.1.2", "80", "6"}
+	key3 := flowexporter.ConnectionKey{"10.10.0.1", "50002", "10.10.1.2", "80", "6"}
+
+	// A handshake which has not been started is ignored.
+	tracker.onHandshakeCompleted(key1, "10.10.1.2", 0)
+	_, exists := tracker.popHandshakeStats(key1)
+	assert.False(t, exists)
+
+	tracker.onHandshakeStarted(key1)
+	fakeClock.Step(800 * time.Microsecond)
+	tracker.onHandshakeCompleted(key1, "10.10.1.2", 1)
+	stats, exists := tracker.popHandshakeStats(key1)
+	require.True(t, exists)
+	assert.Equal(t, 800*time.Microsecond, stats.smoothedRTT)
+	assert.Equal(t, uint32(1), stats.retransmissions)
+	_, exists = tracker.popHandshakeStats(key1)
+	assert.False(t, exists, "Handshake stats should only be returned once")
+
+	// The second sample to the same destination is smoothed.
+	tracker.onHandshakeStarted(key2)
+	fakeClock.Step(1600 * time.Microsecond)
+	tracker.onHandshakeCompleted(key2, "10.10.1.2", 0)
+	stats, exists = tracker.popHandshakeStats(key2)
+	require.True(t, exists)
+	assert.Equal(t, 900*time.Microsecond, stats.smoothedRTT)
+	assert.Equal(t, uint32(0), stats.retransmissions)
+
+	// Stale entries are garbage collected.
+	tracker.onHandshakeStarted(key3)
+	fakeClock.Step(handshakeStatsTimeout + time.Second)
+	tracker.gc()
+	assert.Empty(t, tracker.pendingHandshakes)
+	assert.Len(t, tracker.smoothedRTTs, 1)
+	fakeClock.Step(smoothedRTTTimeout)
+	tracker.gc()
+	assert.Empty(t, tracker.smoothedRTTs)
+}
+
+func TestConntrackConnectionStore_UpdateTCPStats(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	cs := &ConntrackConnectionStore{tcpHandshakeTracker: newTCPHandshakeTracker(nil, fakeClock)}
+	conn := &flowexporter.Connection{
+		FlowKey:         flowexporter.Tuple{SourceAddress: net.ParseIP("10.10.0.1"), DestinationAddress: net.ParseIP("10.10.1.2"), Protocol: 6, SourcePort: 50000, DestinationPort: 80},
+		OriginalPackets: 3,
+		TCPState:        "SYN_SENT",
+	}
+	connKey := flowexporter.NewConnectionKey(conn)
+	cs.updateTCPStats(conn, connKey)
+	assert.Equal(t, flowexporter.TCPStateFlagSyn, conn.TCPStateFlags)
+	assert.Equal(t, uint32(2), conn.TCPSynRetransmissions)
+	assert.Zero(t, conn.TCPSmoothedRTT)
+
+	cs.tcpHandshakeTracker.onHandshakeStarted(connKey)
+	fakeClock.Step(time.Millisecond)
+	cs.tcpHandshakeTracker.onHandshakeCompleted(connKey, "10.10.1.2", 1)
+	conn.TCPState = "ESTABLISHED"
+	conn.OriginalPackets = 10
+	conn.ReversePackets = 8
+	cs.updateTCPStats(conn, connKey)
+	assert.Equal(t, flowexporter.TCPStateFlagSyn|flowexporter.TCPStateFlagEstablished, conn.TCPStateFlags)
+	assert.Equal(t, uint32(2), conn.TCPSynRetransmissions, "The maximum number of retransmissions should be kept")
+	assert.Equal(t, time.Millisecond, conn.TCPSmoothedRTT)
+
+	conn.TCPState = "CLOSE"
+	cs.updateTCPStats(conn, connKey)
+	assert.Equal(t, flowexporter.TCPStateFlagSyn|flowexporter.TCPStateFlagEstablished|flowexporter.TCPStateFlagRst, conn.TCPStateFlags)
+}
@@ -38,6 +38,7 @@ import (
 	"antrea.io/antrea/pkg/agent/openflow"
 	"antrea.io/antrea/pkg/agent/proxy"
 	"antrea.io/antrea/pkg/ipfix"
+	"antrea.io/antrea/pkg/legacyflow"
 	"antrea.io/antrea/pkg/ovs/ovsconfig"
 	"antrea.io/antrea/pkg/querier"
 	"antrea.io/antrea/pkg/util/env"
@@ -101,6 +102,9 @@ var (
 		"flowType",
 		"egressName",
 		"egressIP",
+		"tcpStateFlags",
+		"tcpSynRetransmissionCount",
+		"tcpSmoothedRttMicroseconds",
 	}
 	AntreaInfoElementsIPv4 = append(antreaInfoElementsCommon, []string{"destinationClusterIPv4"}...)
 	AntreaInfoElementsIPv6 = append(antreaInfoElementsCommon, []string{"destinationClusterIPv6"}...)
@@ -111,6 +115,8 @@ type FlowExporter struct {
 	conntrackConnStore     *connections.ConntrackConnectionStore
 	denyConnStore          *connections.DenyConnectionStore
 	process                ipfix.IPFIXExportingProcess
+	legacyExporter         *legacyflow.Exporter // used instead of process for the NetFlowV9 and sFlow formats.
+	recordFormat           string
 	elementsListv4         []ipfixentities.InfoElementWithValue
 	elementsListv6         []ipfixentities.InfoElementWithValue
 	ipfixSet               ipfixentities.Set
@@ -174,6 +180,7 @@ func NewFlowExporter(ifaceStore interfacestore.InterfaceStore, proxier proxy.Pro
 
 	return &FlowExporter{
 		collectorAddr:          o.FlowCollectorAddr,
+		recordFormat:           o.RecordFormat,
 		conntrackConnStore:     conntrackConnStore,
 		denyConnStore:          denyConnStore,
 		registry:               registry,
@@ -196,6 +203,19 @@ func (exp *FlowExporter) GetDenyConnStore() *connections.DenyConnectionStore {
 	return exp.denyConnStore
 }
 
+func (exp *FlowExporter) GetConntrackConnStore() *connections.ConntrackConnectionStore {
+	return exp.conntrackConnStore
+}
+
+// UpdateIntervals updates the conntrack poll interval and the flow export timeouts at runtime. The new values take
+// effect after the next poll and for the connections exported afterwards respectively.
+func (exp *FlowExporter) UpdateIntervals(pollInterval, activeFlowTimeout, idleFlowTimeout time.Duration) {
+	exp.conntrackConnStore.SetPollInterval(pollInterval)
+	exp.conntrackConnStore.SetFlowExportTimeouts(activeFlowTimeout, idleFlowTimeout)
+	exp.denyConnStore.SetFlowExportTimeouts(activeFlowTimeout, idleFlowTimeout)
+	klog.InfoS("Updated flow exporter intervals", "pollInterval", pollInterval, "activeFlowExportTimeout", activeFlowTimeout, "idleFlowExportTimeout", idleFlowTimeout)
+}
+
 func (exp *FlowExporter) Run(stopCh <-chan struct{}) {
 	// Start the goroutine to periodically delete stale deny connections.
 	go exp.denyConnStore.RunPeriodicDeletion(stopCh)
@@ -208,13 +228,11 @@ func (exp *FlowExporter) Run(stopCh <-chan struct{}) {
 	for {
 		select {
 		case <-stopCh:
-			if exp.process != nil {
-				exp.process.CloseConnToCollector()
-			}
+			exp.closeConnToCollector()
 			expireTimer.Stop()
 			return
 		case <-expireTimer.C:
-			if exp.process == nil {
+			if !exp.isConnectedToCollector() {
 				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 				err := exp.initFlowExporter(ctx)
 				cancel()
@@ -223,10 +241,7 @@ func (exp *FlowExporter) Run(stopCh <-chan struct{}) {
 					// There could be other errors while initializing flow exporter
 					// other than connecting to IPFIX collector, therefore closing
 					// the connection and resetting the process.
-					if exp.process != nil {
-						exp.process.CloseConnToCollector()
-						exp.process = nil
-					}
+					exp.closeConnToCollector()
 					// Initializing flow exporter fails, will retry in next cycle.
 					expireTimer.Reset(defaultTimeout)
 					continue
@@ -240,8 +255,7 @@ func (exp *FlowExporter) Run(stopCh <-chan struct{}) {
 				// If there is an error when sending flow records because of intermittent
 				// connectivity, we reset the connection to IPFIX collector and retry
 				// in the next export cycle to reinitialize the connection and send flow records.
-				exp.process.CloseConnToCollector()
-				exp.process = nil
+				exp.closeConnToCollector()
 				expireTimer.Reset(defaultTimeout)
 				continue
 			}
@@ -250,6 +264,21 @@ func (exp *FlowExporter) Run(stopCh <-chan struct{}) {
 	}
 }
 
+func (exp *FlowExporter) isConnectedToCollector() bool {
+	return exp.process != nil || exp.legacyExporter != nil
+}
+
+func (exp *FlowExporter) closeConnToCollector() {
+	if exp.process != nil {
+		exp.process.CloseConnToCollector()
+		exp.process = nil
+	}
+	if exp.legacyExporter != nil {
+		exp.legacyExporter.Close()
+		exp.legacyExporter = nil
+	}
+}
+
 func (exp *FlowExporter) sendFlowRecords() (time.Duration, error) {
 	currTime := time.Now()
 	var expireTime1, expireTime2 time.Duration
@@ -298,6 +327,16 @@ func (exp *FlowExporter) initFlowExporter(ctx context.Context) error {
 	if err := exp.resolveCollectorAddress(ctx); err != nil {
 		return err
 	}
+	if exp.recordFormat == string(legacyflow.FormatNetFlowV9) || exp.recordFormat == string(legacyflow.FormatSFlow) {
+		legacyExporter, err := legacyflow.NewExporter(legacyflow.Format(exp.recordFormat), exp.exporterInput.CollectorAddress, exp.exporterInput.ObservationDomainID)
+		if err != nil {
+			return fmt.Errorf("error when starting %s exporter: %v", exp.recordFormat, err)
+		}
+		exp.legacyExporter = legacyExporter
+		klog.V(2).InfoS("Initialized flow exporter", "recordFormat", exp.recordFormat)
+		metrics.ReconnectionsToFlowCollector.Inc()
+		return nil
+	}
 	var err error
 	if exp.exporterInput.TLSClientConfig != nil {
 		tlsConfig := exp.exporterInput.TLSClientConfig
@@ -437,13 +476,7 @@ func (exp *FlowExporter) addConnToSet(conn *flowexporter.Connection) error {
 		case "flowEndSeconds":
 			ie.SetUnsigned32Value(uint32(conn.StopTime.Unix()))
 		case "flowEndReason":
-			if flowexporter.IsConnectionDying(conn) {
-				ie.SetUnsigned8Value(ipfixregistry.EndOfFlowReason)
-			} else if conn.IsActive {
-				ie.SetUnsigned8Value(ipfixregistry.ActiveTimeoutReason)
-			} else {
-				ie.SetUnsigned8Value(ipfixregistry.IdleTimeoutReason)
-			}
+			ie.SetUnsigned8Value(getFlowEndReason(conn))
 		case "sourceIPv4Address":
 			ie.SetIPAddressValue(conn.FlowKey.SourceAddress)
 		case "destinationIPv4Address":
@@ -564,6 +597,12 @@ func (exp *FlowExporter) addConnToSet(conn *flowexporter.Connection) error {
 			ie.SetStringValue(conn.EgressName)
 		case "egressIP":
 			ie.SetStringValue(conn.EgressIP)
+		case "tcpStateFlags":
+			ie.SetUnsigned8Value(conn.TCPStateFlags)
+		case "tcpSynRetransmissionCount":
+			ie.SetUnsigned32Value(conn.TCPSynRetransmissions)
+		case "tcpSmoothedRttMicroseconds":
+			ie.SetUnsigned32Value(uint32(conn.TCPSmoothedRTT.Microseconds()))
 		}
 	}
 	err := exp.ipfixSet.AddRecord(eL, templateID)
@@ -573,6 +612,52 @@ func (exp *FlowExporter) addConnToSet(conn *flowexporter.Connection) error {
 	return nil
 }
 
+func getFlowEndReason(conn *flowexporter.Connection) uint8 {
+	if flowexporter.IsConnectionDying(conn) {
+		return ipfixregistry.EndOfFlowReason
+	} else if conn.IsActive {
+		return ipfixregistry.ActiveTimeoutReason
+	}
+	return ipfixregistry.IdleTimeoutReason
+}
+
+// getDeltaCount returns the difference between the current and the previous counter, 0 if the counter has been reset.
+func getDeltaCount(current, previous uint64) uint64 {
+	if current < previous {
+		return 0
+	}
+	return current - previous
+}
+
+func isDeniedRuleAction(ruleAction uint8) bool {
+	return ruleAction == ipfixregistry.NetworkPolicyRuleActionDrop || ruleAction == ipfixregistry.NetworkPolicyRuleActionReject
+}
+
+// toLegacyRecord converts the connection to a NetFlow v9 or sFlow record, with the fields which can be represented
+// in these formats.
+func toLegacyRecord(conn *flowexporter.Connection) legacyflow.Record {
+	record := legacyflow.Record{
+		StartTime:          conn.StartTime,
+		EndTime:            conn.StopTime,
+		EndReason:          getFlowEndReason(conn),
+		SourceAddress:      conn.FlowKey.SourceAddress,
+		DestinationAddress: conn.FlowKey.DestinationAddress,
+		SourcePort:         conn.FlowKey.SourcePort,
+		DestinationPort:    conn.FlowKey.DestinationPort,
+		Protocol:           conn.FlowKey.Protocol,
+		Packets:            getDeltaCount(conn.OriginalPackets, conn.PrevPackets),
+		Octets:             getDeltaCount(conn.OriginalBytes, conn.PrevBytes),
+		ReversePackets:     getDeltaCount(conn.ReversePackets, conn.PrevReversePackets),
+		ReverseOctets:      getDeltaCount(conn.ReverseBytes, conn.PrevReverseBytes),
+		Dropped:            isDeniedRuleAction(conn.IngressNetworkPolicyRuleAction) || isDeniedRuleAction(conn.EgressNetworkPolicyRuleAction),
+	}
+	if conn.DestinationServicePortName != "" {
+		record.ServiceAddress = conn.DestinationServiceAddress
+		record.ServicePort = conn.DestinationServicePort
+	}
+	return record
+}
+
 func (exp *FlowExporter) sendDataSet() (int, error) {
 	sentBytes, err := exp.process.SendSet(exp.ipfixSet)
 	if err != nil {
@@ -630,12 +715,20 @@ func (exp *FlowExporter) exportConn(conn *flowexporter.Connection) error {
 			return nil
 		}
 	}
-	// TODO: more records per data set will be supported when go-ipfix supports size check when adding records
-	if err := exp.addConnToSet(conn); err != nil {
-		return err
-	}
-	if _, err := exp.sendDataSet(); err != nil {
-		return err
+	if exp.legacyExporter != nil {
+		sentBytes, err := exp.legacyExporter.Export(toLegacyRecord(conn))
+		if err != nil {
+			return err
+		}
+		klog.V(4).InfoS("Record sent successfully", "recordFormat", exp.recordFormat, "bytes sent", sentBytes)
+	} else {
+		// TODO: more records per data set will be supported when go-ipfix supports size check when adding records
+		if err := exp.addConnToSet(conn); err != nil {
+			return err
+		}
+		if _, err := exp.sendDataSet(); err != nil {
+			return err
+		}
 	}
 	exp.numDataSetsSent = exp.numDataSetsSent + 1
 	klog.V(4).InfoS("Record for connection sent successfully", "flowKey", conn.FlowKey, "connection", conn)
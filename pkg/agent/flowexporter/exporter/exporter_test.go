@@ -39,7 +39,9 @@ import (
 	"antrea.io/antrea/pkg/agent/flowexporter/connections"
 	connectionstest "antrea.io/antrea/pkg/agent/flowexporter/connections/testing"
 	"antrea.io/antrea/pkg/agent/metrics"
+	"antrea.io/antrea/pkg/ipfix"
 	ipfixtest "antrea.io/antrea/pkg/ipfix/testing"
+	"antrea.io/antrea/pkg/legacyflow"
 	queriertest "antrea.io/antrea/pkg/querier/testing"
 )
 
@@ -51,7 +53,7 @@ const (
 )
 
 func init() {
-	ipfixregistry.LoadRegistry()
+	ipfix.NewIPFIXRegistry().LoadRegistry()
 }
 
 func TestFlowExporter_sendTemplateSet(t *testing.T) {
@@ -396,6 +398,86 @@ func TestFlowExporter_initFlowExporter(t *testing.T) {
 	}
 }
 
+func TestFlowExporter_exportConnLegacyFormat(t *testing.T) {
+	metrics.InitializeConnectionMetrics()
+	collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err, "Error when creating a local UDP server")
+	defer collector.Close()
+
+	for _, recordFormat := range []string{"NetFlowV9", "sFlow"} {
+		t.Run(recordFormat, func(t *testing.T) {
+			exp := &FlowExporter{
+				collectorAddr: collector.LocalAddr().String(),
+				recordFormat:  recordFormat,
+				exporterInput: exporter.ExporterInput{
+					CollectorProtocol: "udp",
+				},
+				isNetworkPolicyOnly: true,
+			}
+			require.NoError(t, exp.initFlowExporter(context.Background()))
+			defer exp.closeConnToCollector()
+			assert.Nil(t, exp.process)
+			require.NotNil(t, exp.legacyExporter)
+			checkTotalReconnectionsMetric(t)
+			metrics.ReconnectionsToFlowCollector.Dec()
+
+			conn := getConnection(false, true, 300, 6, "ESTABLISHED")
+			require.NoError(t, exp.exportConn(conn))
+			assert.Equal(t, uint64(1), exp.numDataSetsSent)
+			buf := make([]byte, 65535)
+			require.NoError(t, collector.SetReadDeadline(time.Now().Add(5*time.Second)))
+			n, _, err := collector.ReadFrom(buf)
+			require.NoError(t, err)
+			assert.NotZero(t, n)
+		})
+	}
+}
+
+func TestToLegacyRecord(t *testing.T) {
+	conn := &flowexporter.Connection{
+		StartTime: time.Unix(1690000000, 0),
+		StopTime:  time.Unix(1690000010, 0),
+		IsActive:  true,
+		FlowKey: flowexporter.Tuple{
+			SourceAddress:      net.ParseIP("10.10.0.1"),
+			DestinationAddress: net.ParseIP("10.10.1.2"),
+			Protocol:           6,
+			SourcePort:         32768,
+			DestinationPort:    8080,
+		},
+		OriginalPackets:                100,
+		OriginalBytes:                  10000,
+		PrevPackets:                    40,
+		PrevBytes:                      4000,
+		ReversePackets:                 10,
+		ReverseBytes:                   1000,
+		PrevReversePackets:             20,
+		PrevReverseBytes:               2000,
+		DestinationServicePortName:     "default/svc:http",
+		DestinationServiceAddress:      net.ParseIP("10.96.0.10"),
+		DestinationServicePort:         80,
+		IngressNetworkPolicyRuleAction: ipfixregistry.NetworkPolicyRuleActionDrop,
+	}
+	assert.Equal(t, legacyflow.Record{
+		StartTime:          conn.StartTime,
+		EndTime:            conn.StopTime,
+		EndReason:          ipfixregistry.ActiveTimeoutReason,
+		SourceAddress:      conn.FlowKey.SourceAddress,
+		DestinationAddress: conn.FlowKey.DestinationAddress,
+		SourcePort:         32768,
+		DestinationPort:    8080,
+		Protocol:           6,
+		Packets:            60,
+		Octets:             6000,
+		// The reverse counters have been reset.
+		ReversePackets: 0,
+		ReverseOctets:  0,
+		ServiceAddress: conn.DestinationServiceAddress,
+		ServicePort:    80,
+		Dropped:        true,
+	}, toLegacyRecord(conn))
+}
+
 func checkTotalReconnectionsMetric(t *testing.T) {
 	expected := `
 	# HELP antrea_agent_flow_collector_reconnection_count [ALPHA] Number of re-connections between Flow Exporter and flow collector. This metric gets updated whenever the connection is re-established between the Flow Exporter and the flow collector (e.g. the Flow Aggregator).
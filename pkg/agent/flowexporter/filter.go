@@ -0,0 +1,138 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flowexporter
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/types"
+	agentconfig "antrea.io/antrea/pkg/config/agent"
+	"antrea.io/antrea/pkg/util/ip"
+)
+
+// ExportFilter decides whether a connection is exported, based on its protocol and on the Pods it comes from or goes
+// to. It is evaluated once, when a connection is added to a connection store.
+type ExportFilter struct {
+	includeNamespaces sets.Set[string]
+	excludeNamespaces sets.Set[string]
+	// podSelector is nil if Pods are not filtered by labels.
+	podSelector labels.Selector
+	// protocols is empty if connections are not filtered by protocol.
+	protocols       sets.Set[uint8]
+	namespaceLister corelisters.NamespaceLister
+	// podLister is only needed when podSelector is set.
+	podLister corelisters.PodLister
+}
+
+var filterableProtocols = []uint8{ip.ICMPProtocol, ip.IGMPProtocol, ip.TCPProtocol, ip.UDPProtocol, ip.ICMPv6Protocol, ip.SCTPProtocol}
+
+// ParseProtocol returns the protocol number of a protocol name supported by the export filter. The name is case
+// insensitive.
+func ParseProtocol(name string) (uint8, error) {
+	for _, protocol := range filterableProtocols {
+		if strings.EqualFold(name, ip.IPProtocolNumberToString(protocol, "")) {
+			return protocol, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported protocol %s", name)
+}
+
+// NewExportFilter creates an ExportFilter from the configuration. namespaceLister is used to look up the annotation
+// of the Namespaces, and podLister to look up the labels of the Pods when a Pod selector is configured.
+func NewExportFilter(config agentconfig.FlowExportFilterConfig, namespaceLister corelisters.NamespaceLister, podLister corelisters.PodLister) (*ExportFilter, error) {
+	f := &ExportFilter{
+		includeNamespaces: sets.New[string](config.IncludeNamespaces...),
+		excludeNamespaces: sets.New[string](config.ExcludeNamespaces...),
+		protocols:         sets.New[uint8](),
+		namespaceLister:   namespaceLister,
+		podLister:         podLister,
+	}
+	if config.PodSelector != "" {
+		selector, err := labels.Parse(config.PodSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Pod selector %q: %w", config.PodSelector, err)
+		}
+		f.podSelector = selector
+	}
+	for _, name := range config.Protocols {
+		protocol, err := ParseProtocol(name)
+		if err != nil {
+			return nil, err
+		}
+		f.protocols.Insert(protocol)
+	}
+	return f, nil
+}
+
+// Matches returns whether the connection should be exported. The K8s metadata of the connection must have been
+// resolved. A connection is exported if its protocol is selected, and if either its source or destination local Pod
+// is selected. A connection without any local Pod is exported unless Pods are filtered by the configuration. A nil
+// ExportFilter matches all connections.
+func (f *ExportFilter) Matches(conn *Connection) bool {
+	if f == nil {
+		return true
+	}
+	if f.protocols.Len() > 0 && !f.protocols.Has(conn.FlowKey.Protocol) {
+		return false
+	}
+	srcIsPod, dstIsPod := conn.SourcePodName != "", conn.DestinationPodName != ""
+	if !srcIsPod && !dstIsPod {
+		return f.includeNamespaces.Len() == 0 && f.excludeNamespaces.Len() == 0 && f.podSelector == nil
+	}
+	return (srcIsPod && f.podMatches(conn.SourcePodNamespace, conn.SourcePodName)) ||
+		(dstIsPod && f.podMatches(conn.DestinationPodNamespace, conn.DestinationPodName))
+}
+
+// podMatches returns whether the connections of a local Pod are exported.
+func (f *ExportFilter) podMatches(namespace, name string) bool {
+	if !f.namespaceMatches(namespace) {
+		return false
+	}
+	if f.podSelector == nil {
+		return true
+	}
+	pod, err := f.podLister.Pods(namespace).Get(name)
+	if err != nil {
+		klog.V(4).InfoS("Failed to get Pod for the flow export filter", "pod", klog.KRef(namespace, name), "err", err)
+		return false
+	}
+	return f.podSelector.Matches(labels.Set(pod.Labels))
+}
+
+// namespaceMatches returns whether the connections of the Pods in a Namespace can be exported. The
+// flowexporter.antrea.io/export annotation of the Namespace takes precedence over the included and excluded
+// Namespaces of the configuration.
+func (f *ExportFilter) namespaceMatches(namespace string) bool {
+	if f.namespaceLister != nil {
+		if ns, err := f.namespaceLister.Get(namespace); err == nil {
+			switch ns.Annotations[types.NamespaceFlowExportAnnotationKey] {
+			case "true":
+				return true
+			case "false":
+				return false
+			}
+		}
+	}
+	if f.excludeNamespaces.Has(namespace) {
+		return false
+	}
+	return f.includeNamespaces.Len() == 0 || f.includeNamespaces.Has(namespace)
+}
@@ -0,0 +1,156 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flowexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"antrea.io/antrea/pkg/agent/types"
+	agentconfig "antrea.io/antrea/pkg/config/agent"
+)
+
+func TestExportFilter(t *testing.T) {
+	namespaces := []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "ns1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "ns2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "ns3", Annotations: map[string]string{types.NamespaceFlowExportAnnotationKey: "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "ns4", Annotations: map[string]string{types.NamespaceFlowExportAnnotationKey: "false"}}},
+	}
+	pods := []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "web", Labels: map[string]string{"app": "web"}}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "db", Labels: map[string]string{"app": "db"}}},
+	}
+	informerFactory := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	namespaceInformer := informerFactory.Core().V1().Namespaces()
+	podInformer := informerFactory.Core().V1().Pods()
+	for _, ns := range namespaces {
+		require.NoError(t, namespaceInformer.Informer().GetIndexer().Add(ns))
+	}
+	for _, pod := range pods {
+		require.NoError(t, podInformer.Informer().GetIndexer().Add(pod))
+	}
+
+	newConn := func(srcNamespace, srcName, dstNamespace, dstName string, protocol uint8) *Connection {
+		return &Connection{
+			FlowKey:                 Tuple{Protocol: protocol},
+			SourcePodNamespace:      srcNamespace,
+			SourcePodName:           srcName,
+			DestinationPodNamespace: dstNamespace,
+			DestinationPodName:      dstName,
+		}
+	}
+	tests := []struct {
+		name     string
+		config   agentconfig.FlowExportFilterConfig
+		conn     *Connection
+		expected bool
+	}{
+		{
+			name:     "empty filter",
+			conn:     newConn("", "", "", "", 6),
+			expected: true,
+		},
+		{
+			name:     "Namespace annotation",
+			conn:     newConn("ns4", "pod", "", "", 6),
+			expected: false,
+		},
+		{
+			name:     "protocol not included",
+			config:   agentconfig.FlowExportFilterConfig{Protocols: []string{"UDP"}},
+			conn:     newConn("ns1", "web", "", "", 6),
+			expected: false,
+		},
+		{
+			name:     "protocol included",
+			config:   agentconfig.FlowExportFilterConfig{Protocols: []string{"tcp", "UDP"}},
+			conn:     newConn("ns1", "web", "", "", 6),
+			expected: true,
+		},
+		{
+			name:     "source Namespace included",
+			config:   agentconfig.FlowExportFilterConfig{IncludeNamespaces: []string{"ns1"}},
+			conn:     newConn("ns1", "web", "ns2", "pod", 6),
+			expected: true,
+		},
+		{
+			name:     "destination Namespace included",
+			config:   agentconfig.FlowExportFilterConfig{IncludeNamespaces: []string{"ns1"}},
+			conn:     newConn("ns2", "pod", "ns1", "web", 6),
+			expected: true,
+		},
+		{
+			name:     "Namespace not included",
+			config:   agentconfig.FlowExportFilterConfig{IncludeNamespaces: []string{"ns1"}},
+			conn:     newConn("ns2", "pod", "", "", 6),
+			expected: false,
+		},
+		{
+			name:     "Namespace excluded",
+			config:   agentconfig.FlowExportFilterConfig{ExcludeNamespaces: []string{"ns1"}},
+			conn:     newConn("ns1", "web", "", "", 6),
+			expected: false,
+		},
+		{
+			name:     "Namespace annotation overrides configuration",
+			config:   agentconfig.FlowExportFilterConfig{IncludeNamespaces: []string{"ns1"}, ExcludeNamespaces: []string{"ns3"}},
+			conn:     newConn("ns3", "pod", "", "", 6),
+			expected: true,
+		},
+		{
+			name:     "Pod selected",
+			config:   agentconfig.FlowExportFilterConfig{PodSelector: "app=web"},
+			conn:     newConn("ns1", "web", "", "", 6),
+			expected: true,
+		},
+		{
+			name:     "Pod not selected",
+			config:   agentconfig.FlowExportFilterConfig{PodSelector: "app=web"},
+			conn:     newConn("ns1", "db", "", "", 6),
+			expected: false,
+		},
+		{
+			name:     "unknown Pod",
+			config:   agentconfig.FlowExportFilterConfig{PodSelector: "app=web"},
+			conn:     newConn("ns2", "pod", "", "", 6),
+			expected: false,
+		},
+		{
+			name:     "no local Pod with Pod filtering",
+			config:   agentconfig.FlowExportFilterConfig{IncludeNamespaces: []string{"ns1"}},
+			conn:     newConn("", "", "", "", 6),
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewExportFilter(tt.config, namespaceInformer.Lister(), podInformer.Lister())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, filter.Matches(tt.conn))
+		})
+	}
+
+	var nilFilter *ExportFilter
+	assert.True(t, nilFilter.Matches(newConn("ns4", "pod", "", "", 6)))
+	_, err := NewExportFilter(agentconfig.FlowExportFilterConfig{Protocols: []string{"GRE"}}, nil, nil)
+	assert.EqualError(t, err, "unsupported protocol GRE")
+}
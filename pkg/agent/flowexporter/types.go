@@ -46,7 +46,10 @@ type Connection struct {
 	// IsPresent flag helps in cleaning up connections when they are not in conntrack table anymore.
 	IsPresent bool
 	// ReadyToDelete marks whether we can safely delete the connection from the connection map.
-	ReadyToDelete      bool
+	ReadyToDelete bool
+	// ExcludedFromExport is set when the connection is not selected by the export filter. Such a connection is
+	// still tracked by the conntrack connection store, but it is never queued for export.
+	ExcludedFromExport bool
 	Zone               uint16
 	Mark               uint32
 	StatusFlag         uint32
@@ -78,9 +81,16 @@ type Connection struct {
 	PrevReversePackets, PrevReverseBytes uint64
 	TCPState                             string
 	PrevTCPState                         string
-	FlowType                             uint8
-	EgressName                           string
-	EgressIP                             string
+	// TCPStateFlags records the TCP states observed for the connection, as a combination of TCPStateFlag* values.
+	TCPStateFlags uint8
+	// TCPSynRetransmissions is the number of retransmitted SYN and SYN-ACK packets observed during the handshake.
+	TCPSynRetransmissions uint32
+	// TCPSmoothedRTT is the smoothed RTT to the destination of the connection, estimated from the durations of the
+	// TCP handshakes. It is 0 if the handshake of the connection was not observed.
+	TCPSmoothedRTT time.Duration
+	FlowType       uint8
+	EgressName     string
+	EgressIP       string
 }
 
 type ItemToExpire struct {
@@ -94,9 +104,14 @@ type ItemToExpire struct {
 type FlowExporterOptions struct {
 	FlowCollectorAddr      string
 	FlowCollectorProto     string
+	RecordFormat           string
 	ActiveFlowTimeout      time.Duration
 	IdleFlowTimeout        time.Duration
 	StaleConnectionTimeout time.Duration
 	PollInterval           time.Duration
 	ConnectUplinkToBridge  bool
+	// ExportFilter selects the exported connections. If nil, all connections are exported.
+	ExportFilter *ExportFilter
+	// EstimateTCPRTT enables the estimation of the RTT of TCP connections from their handshakes.
+	EstimateTCPRTT bool
 }
@@ -26,6 +26,17 @@ const (
 	connectionDyingFlag = uint32(1 << 9)
 )
 
+const (
+	// TCPStateFlagSyn is set when the connection has been observed during its handshake.
+	TCPStateFlagSyn uint8 = 1 << iota
+	// TCPStateFlagEstablished is set when the connection has been observed established.
+	TCPStateFlagEstablished
+	// TCPStateFlagFin is set when the connection has been observed closing with FIN packets.
+	TCPStateFlagFin
+	// TCPStateFlagRst is set when the connection has been observed closed with a RST packet.
+	TCPStateFlagRst
+)
+
 // NewConnectionKey creates 5-tuple of flow as connection key
 func NewConnectionKey(conn *Connection) ConnectionKey {
 	return ConnectionKey{conn.FlowKey.SourceAddress.String(),
@@ -53,6 +64,46 @@ func IsConnectionDying(conn *Connection) bool {
 	return false
 }
 
+// TCPStateToFlags returns the TCPStateFlag* value corresponding to a conntrack TCP state.
+func TCPStateToFlags(state string) uint8 {
+	switch state {
+	case "SYN_SENT", "SYN_RECV", "SYN_SENT2":
+		return TCPStateFlagSyn
+	case "ESTABLISHED":
+		return TCPStateFlagEstablished
+	case "FIN_WAIT", "CLOSE_WAIT", "LAST_ACK", "TIME_WAIT":
+		return TCPStateFlagFin
+	case "CLOSE":
+		// Conntrack moves a connection to the CLOSE state when it is reset.
+		return TCPStateFlagRst
+	default:
+		return 0
+	}
+}
+
+// GetTCPSynRetransmissions returns the number of retransmitted SYN and SYN-ACK packets of a TCP connection whose
+// handshake is in progress. In the SYN_SENT and SYN_RECV states, the packets counted by conntrack in the original
+// direction are SYN packets, and the ones counted in the reverse direction are SYN-ACK packets.
+func GetTCPSynRetransmissions(state string, originalPackets, reversePackets uint64) uint32 {
+	var synPackets, synAckPackets uint64
+	switch state {
+	case "SYN_SENT":
+		synPackets = originalPackets
+	case "SYN_RECV":
+		synPackets, synAckPackets = originalPackets, reversePackets
+	default:
+		return 0
+	}
+	var retransmissions uint64
+	if synPackets > 1 {
+		retransmissions += synPackets - 1
+	}
+	if synAckPackets > 1 {
+		retransmissions += synAckPackets - 1
+	}
+	return uint32(retransmissions)
+}
+
 // checkConntrackConnActive returns true if there are changes in connection's stats or
 // TCP state, indicating that the connection is active.
 func CheckConntrackConnActive(conn *Connection) bool {
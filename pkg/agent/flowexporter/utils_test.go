@@ -44,6 +44,40 @@ func TestIsConnectionDying(t *testing.T) {
 	}
 }
 
+func TestTCPStateToFlags(t *testing.T) {
+	for _, tc := range []struct {
+		tcpState      string
+		expectedFlags uint8
+	}{
+		{"SYN_SENT", TCPStateFlagSyn},
+		{"SYN_RECV", TCPStateFlagSyn},
+		{"ESTABLISHED", TCPStateFlagEstablished},
+		{"FIN_WAIT", TCPStateFlagFin},
+		{"TIME_WAIT", TCPStateFlagFin},
+		{"CLOSE", TCPStateFlagRst},
+		{"", 0},
+	} {
+		assert.Equal(t, tc.expectedFlags, TCPStateToFlags(tc.tcpState), "Unexpected flags for state %s", tc.tcpState)
+	}
+}
+
+func TestGetTCPSynRetransmissions(t *testing.T) {
+	for _, tc := range []struct {
+		tcpState                        string
+		originalPackets, reversePackets uint64
+		expectedRetransmissions         uint32
+	}{
+		{"SYN_SENT", 1, 0, 0},
+		{"SYN_SENT", 3, 0, 2},
+		{"SYN_RECV", 1, 1, 0},
+		{"SYN_RECV", 2, 3, 3},
+		{"SYN_RECV", 0, 0, 0},
+		{"ESTABLISHED", 10, 10, 0},
+	} {
+		assert.Equal(t, tc.expectedRetransmissions, GetTCPSynRetransmissions(tc.tcpState, tc.originalPackets, tc.reversePackets))
+	}
+}
+
 func TestConntrackConnActive(t *testing.T) {
 	for _, tc := range []struct {
 		originalPackets, prevPackets, reversePackets, prevReversePackets uint64
@@ -0,0 +1,100 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flowgc provides a garbage collector which periodically removes the
+// OVS flows and groups installed by the Antrea Agent that are not expected by
+// any of its features anymore. Such orphaned entries can be left behind when
+// the Agent crashes or fails in the middle of an operation, and would
+// otherwise persist until the Agent is restarted and OVS flows are replayed.
+package flowgc
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/metrics"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
+)
+
+const (
+	DefaultInterval = 10 * time.Minute
+
+	entryTypeFlow  = "flow"
+	entryTypeGroup = "group"
+)
+
+// Config contains the parameters of the GarbageCollector.
+type Config struct {
+	Interval time.Duration
+	// DryRun means that the orphaned entries are only logged and reported in
+	// metrics, but not deleted.
+	DryRun bool
+}
+
+// OrphanDeleter finds the orphaned OVS flows and groups, and deletes them
+// unless dryRun is true. It is implemented by the Antrea OpenFlow client.
+type OrphanDeleter interface {
+	DeleteOrphanedFlowsAndGroups(dryRun bool) ([]string, []binding.GroupIDType, error)
+}
+
+type GarbageCollector struct {
+	config  Config
+	deleter OrphanDeleter
+}
+
+func NewGarbageCollector(config Config, deleter OrphanDeleter) *GarbageCollector {
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	return &GarbageCollector{
+		config:  config,
+		deleter: deleter,
+	}
+}
+
+// Run collects the orphaned entries periodically until stopCh is closed. The
+// first collection happens after one interval, to leave time to the Agent to
+// install the flows of all the existing resources after it starts.
+func (gc *GarbageCollector) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Starting OVS flow garbage collector", "interval", gc.config.Interval, "dryRun", gc.config.DryRun)
+	select {
+	case <-time.After(gc.config.Interval):
+	case <-stopCh:
+		return
+	}
+	wait.Until(gc.collect, gc.config.Interval, stopCh)
+}
+
+func (gc *GarbageCollector) collect() {
+	flows, groups, err := gc.deleter.DeleteOrphanedFlowsAndGroups(gc.config.DryRun)
+	if err != nil {
+		klog.ErrorS(err, "Failed to collect orphaned OVS flows and groups")
+		return
+	}
+	metrics.OVSOrphanedEntryCount.WithLabelValues(entryTypeFlow).Set(float64(len(flows)))
+	metrics.OVSOrphanedEntryCount.WithLabelValues(entryTypeGroup).Set(float64(len(groups)))
+	if len(flows) == 0 && len(groups) == 0 {
+		klog.V(2).InfoS("No orphaned OVS flows or groups found")
+		return
+	}
+	if gc.config.DryRun {
+		klog.InfoS("Found orphaned OVS flows and groups, not deleting them in dry-run mode", "flows", flows, "groups", groups)
+		return
+	}
+	klog.InfoS("Deleted orphaned OVS flows and groups", "flows", flows, "groups", groups)
+	metrics.OVSOrphanedEntryDeletedCount.WithLabelValues(entryTypeFlow).Add(float64(len(flows)))
+	metrics.OVSOrphanedEntryDeletedCount.WithLabelValues(entryTypeGroup).Add(float64(len(groups)))
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flowgc
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/component-base/metrics/testutil"
+
+	"antrea.io/antrea/pkg/agent/metrics"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
+)
+
+type fakeDeleter struct {
+	flows      []string
+	groups     []binding.GroupIDType
+	err        error
+	dryRunArgs []bool
+}
+
+func (d *fakeDeleter) DeleteOrphanedFlowsAndGroups(dryRun bool) ([]string, []binding.GroupIDType, error) {
+	d.dryRunArgs = append(d.dryRunArgs, dryRun)
+	return d.flows, d.groups, d.err
+}
+
+func TestNewGarbageCollector(t *testing.T) {
+	gc := NewGarbageCollector(Config{}, &fakeDeleter{})
+	assert.Equal(t, DefaultInterval, gc.config.Interval)
+}
+
+func TestCollect(t *testing.T) {
+	metrics.InitializeFlowGCMetrics()
+	tests := []struct {
+		name                 string
+		dryRun               bool
+		flows                []string
+		groups               []binding.GroupIDType
+		err                  error
+		expectedFlowCount    float64
+		expectedGroupCount   float64
+		expectedDeletedFlows float64
+	}{
+		{
+			name:               "dry run",
+			dryRun:             true,
+			flows:              []string{"table=0,priority=200,in_port=10"},
+			groups:             []binding.GroupIDType{1},
+			expectedFlowCount:  1,
+			expectedGroupCount: 1,
+		},
+		{
+			name:                 "delete",
+			flows:                []string{"table=0,priority=200,in_port=10", "table=0,priority=200,in_port=11"},
+			groups:               []binding.GroupIDType{1},
+			expectedFlowCount:    2,
+			expectedGroupCount:   1,
+			expectedDeletedFlows: 2,
+		},
+		{
+			name: "error",
+			err:  fmt.Errorf("connection closed"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics.OVSOrphanedEntryCount.Reset()
+			metrics.OVSOrphanedEntryDeletedCount.Reset()
+			deleter := &fakeDeleter{flows: tt.flows, groups: tt.groups, err: tt.err}
+			gc := NewGarbageCollector(Config{DryRun: tt.dryRun}, deleter)
+			gc.collect()
+			assert.Equal(t, []bool{tt.dryRun}, deleter.dryRunArgs)
+			flowCount, _ := testutil.GetGaugeMetricValue(metrics.OVSOrphanedEntryCount.WithLabelValues(entryTypeFlow))
+			assert.Equal(t, tt.expectedFlowCount, flowCount)
+			groupCount, _ := testutil.GetGaugeMetricValue(metrics.OVSOrphanedEntryCount.WithLabelValues(entryTypeGroup))
+			assert.Equal(t, tt.expectedGroupCount, groupCount)
+			deletedFlows, _ := testutil.GetCounterMetricValue(metrics.OVSOrphanedEntryDeletedCount.WithLabelValues(entryTypeFlow))
+			assert.Equal(t, tt.expectedDeletedFlows, deletedFlows)
+		})
+	}
+}
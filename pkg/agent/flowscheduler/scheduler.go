@@ -0,0 +1,203 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package flowscheduler provides a scheduler which shares the OVS programming
+// bandwidth of the Antrea Agent among features. Each feature is assigned a
+// weight, and reconcile operations of the features are executed one at a time
+// following a weighted round-robin order. An operation which has waited longer
+// than the starvation timeout is executed before all other pending operations,
+// so that a burst of operations from one feature cannot delay the operations of
+// another feature indefinitely.
+package flowscheduler
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	"antrea.io/antrea/pkg/agent/metrics"
+)
+
+// Feature identifies a consumer of the scheduler.
+type Feature string
+
+const (
+	FeatureNetworkPolicy Feature = "networkpolicy"
+	FeatureProxy         Feature = "proxy"
+	FeatureEgress        Feature = "egress"
+	FeatureMulticast     Feature = "multicast"
+)
+
+// Features lists all the Features known by the scheduler, in the order they are
+// visited during a round.
+var Features = []Feature{FeatureNetworkPolicy, FeatureProxy, FeatureEgress, FeatureMulticast}
+
+const (
+	DefaultWeight            = 1
+	DefaultStarvationTimeout = 2 * time.Second
+)
+
+type request struct {
+	enqueued time.Time
+	ready    chan struct{}
+}
+
+// Scheduler serializes reconcile operations of different features. A nil
+// *Scheduler is valid and runs all operations immediately, which is the
+// behavior when scheduling is disabled.
+type Scheduler struct {
+	clock             clock.Clock
+	starvationTimeout time.Duration
+	weights           map[Feature]int
+
+	mutex sync.Mutex
+	// queues stores the pending requests of each feature in FIFO order.
+	queues map[Feature][]*request
+	// credits stores the number of operations each feature can still run in
+	// the current round.
+	credits map[Feature]int
+	// busy is true when an operation is running.
+	busy bool
+}
+
+// NewScheduler creates a Scheduler. Features which are not present in weights
+// or have a non-positive weight use DefaultWeight. A non-positive
+// starvationTimeout means DefaultStarvationTimeout.
+func NewScheduler(weights map[Feature]int, starvationTimeout time.Duration) *Scheduler {
+	return newSchedulerWithClock(weights, starvationTimeout, clock.RealClock{})
+}
+
+func newSchedulerWithClock(weights map[Feature]int, starvationTimeout time.Duration, clock clock.Clock) *Scheduler {
+	if starvationTimeout <= 0 {
+		starvationTimeout = DefaultStarvationTimeout
+	}
+	s := &Scheduler{
+		clock:             clock,
+		starvationTimeout: starvationTimeout,
+		weights:           make(map[Feature]int, len(Features)),
+		queues:            make(map[Feature][]*request, len(Features)),
+		credits:           make(map[Feature]int, len(Features)),
+	}
+	for _, f := range Features {
+		w := weights[f]
+		if w <= 0 {
+			w = DefaultWeight
+		}
+		s.weights[f] = w
+		s.credits[f] = w
+	}
+	return s
+}
+
+// Acquire blocks until it is the feature's turn to program OVS, and returns a
+// function which must be called once the operation is done. Only one operation
+// holds the Scheduler at any time.
+func (s *Scheduler) Acquire(feature Feature) (release func()) {
+	if s == nil {
+		return func() {}
+	}
+	if _, ok := s.weights[feature]; !ok {
+		klog.InfoS("Unknown feature, running reconcile operation without scheduling", "feature", feature)
+		return func() {}
+	}
+	req := &request{enqueued: s.clock.Now(), ready: make(chan struct{})}
+	s.mutex.Lock()
+	s.queues[feature] = append(s.queues[feature], req)
+	metrics.ReconcileSchedulerQueueLength.WithLabelValues(string(feature)).Inc()
+	s.dispatchLocked()
+	s.mutex.Unlock()
+
+	<-req.ready
+	var once sync.Once
+	return func() {
+		once.Do(s.release)
+	}
+}
+
+// Do runs fn once it is the feature's turn and returns its error.
+func (s *Scheduler) Do(feature Feature, fn func() error) error {
+	release := s.Acquire(feature)
+	defer release()
+	return fn()
+}
+
+func (s *Scheduler) release() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.busy = false
+	s.dispatchLocked()
+}
+
+// dispatchLocked picks the next request to run if no operation is running.
+func (s *Scheduler) dispatchLocked() {
+	if s.busy {
+		return
+	}
+	feature, ok := s.nextLocked()
+	if !ok {
+		return
+	}
+	req := s.queues[feature][0]
+	s.queues[feature] = s.queues[feature][1:]
+	s.busy = true
+
+	waitTime := s.clock.Since(req.enqueued)
+	metrics.ReconcileSchedulerQueueLength.WithLabelValues(string(feature)).Dec()
+	metrics.ReconcileSchedulerWaitDuration.WithLabelValues(string(feature)).Observe(float64(waitTime.Milliseconds()))
+	close(req.ready)
+}
+
+// nextLocked returns the feature whose head request should run next. Starved
+// requests take precedence (oldest first); otherwise features are served in
+// weighted round-robin order.
+func (s *Scheduler) nextLocked() (Feature, bool) {
+	now := s.clock.Now()
+	var starved Feature
+	var oldest time.Time
+	pending := false
+	for _, f := range Features {
+		q := s.queues[f]
+		if len(q) == 0 {
+			continue
+		}
+		pending = true
+		if now.Sub(q[0].enqueued) >= s.starvationTimeout && (starved == "" || q[0].enqueued.Before(oldest)) {
+			starved = f
+			oldest = q[0].enqueued
+		}
+	}
+	if !pending {
+		return "", false
+	}
+	if starved != "" {
+		klog.V(2).InfoS("Running starved reconcile operation", "feature", starved, "waitTime", now.Sub(oldest))
+		metrics.ReconcileSchedulerStarvationCount.WithLabelValues(string(starved)).Inc()
+		return starved, true
+	}
+	for {
+		for _, f := range Features {
+			if len(s.queues[f]) > 0 && s.credits[f] > 0 {
+				s.credits[f]--
+				return f, true
+			}
+		}
+		// All features with pending requests have used their credits, start a
+		// new round.
+		for _, f := range Features {
+			s.credits[f] = s.weights[f]
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flowscheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+// enqueue adds a pending request for feature without blocking, and returns the
+// request so that the test can check when it is granted.
+func enqueue(s *Scheduler, feature Feature) *request {
+	req := &request{enqueued: s.clock.Now(), ready: make(chan struct{})}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.queues[feature] = append(s.queues[feature], req)
+	return req
+}
+
+// grantOrder holds the Scheduler busy, enqueues the given requests, then
+// releases the Scheduler repeatedly and records the order in which features
+// are granted.
+func grantOrder(s *Scheduler, features []Feature, advance time.Duration) []Feature {
+	s.mutex.Lock()
+	s.busy = true
+	s.mutex.Unlock()
+	reqs := make(map[*request]Feature)
+	for _, f := range features {
+		reqs[enqueue(s, f)] = f
+	}
+	s.clock.(*clocktesting.FakeClock).Step(advance)
+	var order []Feature
+	for range features {
+		s.release()
+		for req, f := range reqs {
+			select {
+			case <-req.ready:
+				order = append(order, f)
+				delete(reqs, req)
+			default:
+			}
+		}
+	}
+	return order
+}
+
+func TestWeightedRoundRobin(t *testing.T) {
+	s := newSchedulerWithClock(map[Feature]int{FeatureNetworkPolicy: 2}, time.Minute, clocktesting.NewFakeClock(time.Now()))
+	features := []Feature{
+		FeatureNetworkPolicy, FeatureNetworkPolicy, FeatureNetworkPolicy, FeatureNetworkPolicy, FeatureNetworkPolicy,
+		FeatureProxy, FeatureProxy,
+	}
+	order := grantOrder(s, features, 0)
+	assert.Equal(t, []Feature{
+		FeatureNetworkPolicy, FeatureNetworkPolicy, FeatureProxy,
+		FeatureNetworkPolicy, FeatureNetworkPolicy, FeatureProxy,
+		FeatureNetworkPolicy,
+	}, order)
+}
+
+func TestStarvationProtection(t *testing.T) {
+	fakeClock := clocktesting.NewFakeClock(time.Now())
+	s := newSchedulerWithClock(map[Feature]int{FeatureNetworkPolicy: 100}, time.Second, fakeClock)
+	s.mutex.Lock()
+	s.busy = true
+	s.mutex.Unlock()
+	for i := 0; i < 3; i++ {
+		enqueue(s, FeatureNetworkPolicy)
+	}
+	fakeClock.Step(2 * time.Second)
+	proxyReq := enqueue(s, FeatureProxy)
+	// The policy requests have exceeded the starvation timeout and are the oldest.
+	s.release()
+	select {
+	case <-proxyReq.ready:
+		t.Fatal("Proxy request should not be granted before the starved policy requests")
+	default:
+	}
+	// Make the proxy request starve as well; it's still younger than the
+	// remaining policy requests, which are served first.
+	fakeClock.Step(2 * time.Second)
+	s.release()
+	s.release()
+	s.release()
+	select {
+	case <-proxyReq.ready:
+	default:
+		t.Fatal("Proxy request should be granted after the starved policy requests")
+	}
+}
+
+func TestDo(t *testing.T) {
+	var nilScheduler *Scheduler
+	called := false
+	require.NoError(t, nilScheduler.Do(FeatureProxy, func() error {
+		called = true
+		return nil
+	}))
+	assert.True(t, called)
+
+	s := NewScheduler(nil, 0)
+	assert.Equal(t, DefaultStarvationTimeout, s.starvationTimeout)
+	expectedErr := fmt.Errorf("error")
+	assert.Equal(t, expectedErr, s.Do(FeatureEgress, func() error { return expectedErr }))
+	// The Scheduler must be released after the operation.
+	assert.NoError(t, s.Do(FeatureMulticast, func() error { return nil }))
+	assert.False(t, s.busy)
+}
@@ -63,7 +63,8 @@ const (
 // phase or retrieved from existing OVS ports.
 // An IPsec tunnel interface is added into the cache when IPsec encyption is enabled, and
 // NodeRouteController watches a new remote Node from K8s API, and is removed when the remote
-// Node is deleted.
+// Node is deleted. A dedicated tunnel interface is added for a remote Node in the same way, when
+// the tunnel type or destination port to the Node is overridden with Node annotations.
 // Todo: add periodic task to sync local cache with container veth pair
 
 type interfaceCache struct {
@@ -86,8 +87,8 @@ func getInterfaceKey(obj interface{}) (string, error) {
 	var key string
 	if interfaceConfig.Type == ContainerInterface {
 		key = util.GenerateContainerInterfaceKey(interfaceConfig.ContainerID)
-	} else if interfaceConfig.Type == IPSecTunnelInterface {
-		// IPsec tunnel interface for a Node.
+	} else if interfaceConfig.Type == IPSecTunnelInterface || interfaceConfig.Type == NodeTunnelInterface {
+		// IPsec or dedicated tunnel interface for a Node.
 		key = util.GenerateNodeTunnelInterfaceKey(interfaceConfig.NodeName)
 	} else {
 		// Use the interface name as the key by default.
@@ -18,6 +18,7 @@ import (
 	"net"
 	"strconv"
 
+	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/agent/util"
 	"antrea.io/antrea/pkg/ovs/ovsconfig"
 )
@@ -37,6 +38,11 @@ const (
 	ExternalEntityInterface
 	// IPSecTunnelInterface is used to mark current interface is for IPSec tunnel port
 	IPSecTunnelInterface
+	// PatchInterface is used to mark current interface is for the patch port connecting to the physical bridge
+	PatchInterface
+	// NodeTunnelInterface is used to mark current interface is for the tunnel port dedicated to a remote Node, when
+	// the tunnel type or destination port to the Node is overridden
+	NodeTunnelInterface
 
 	AntreaInterfaceTypeKey = "antrea-type"
 	AntreaGateway          = "gateway"
@@ -46,6 +52,7 @@ const (
 	AntreaHost             = "host"
 	AntreaTrafficControl   = "traffic-control"
 	AntreaIPsecTunnel      = "ipsec-tunnel"
+	AntreaPatch            = "patch"
 	AntreaUnset            = ""
 )
 
@@ -64,6 +71,8 @@ type ContainerInterfaceConfig struct {
 	ContainerID  string
 	PodName      string
 	PodNamespace string
+	// Bandwidth limits of the Pod enforced with OVS meters. It's nil if no limit is enforced with OVS meters.
+	Bandwidth *types.PodBandwidth
 }
 
 type TunnelInterfaceConfig struct {
@@ -169,12 +178,25 @@ func NewIPSecTunnelInterface(interfaceName string, tunnelType ovsconfig.TunnelTy
 	return &InterfaceConfig{InterfaceName: interfaceName, Type: IPSecTunnelInterface, TunnelInterfaceConfig: tunnelConfig, OVSPortConfig: ovsPortConfig}
 }
 
+// NewNodeTunnelInterface creates InterfaceConfig for the tunnel dedicated to
+// the Node, when the tunnel type or destination port to the Node is overridden.
+func NewNodeTunnelInterface(interfaceName string, tunnelType ovsconfig.TunnelType, nodeName string, nodeIP net.IP, destinationPort int32, ovsPortConfig *OVSPortConfig) *InterfaceConfig {
+	tunnelConfig := &TunnelInterfaceConfig{Type: tunnelType, NodeName: nodeName, RemoteIP: nodeIP, DestinationPort: destinationPort}
+	return &InterfaceConfig{InterfaceName: interfaceName, Type: NodeTunnelInterface, TunnelInterfaceConfig: tunnelConfig, OVSPortConfig: ovsPortConfig}
+}
+
 // NewUplinkInterface creates InterfaceConfig for the uplink interface.
 func NewUplinkInterface(uplinkName string) *InterfaceConfig {
 	uplinkConfig := &InterfaceConfig{InterfaceName: uplinkName, Type: UplinkInterface}
 	return uplinkConfig
 }
 
+// NewPatchInterface creates InterfaceConfig for the patch port connecting to the physical bridge.
+func NewPatchInterface(interfaceName string, ovsPortConfig *OVSPortConfig) *InterfaceConfig {
+	patchConfig := &InterfaceConfig{InterfaceName: interfaceName, Type: PatchInterface, OVSPortConfig: ovsPortConfig}
+	return patchConfig
+}
+
 func NewTrafficControlInterface(interfaceName string, ovsPortConfig *OVSPortConfig) *InterfaceConfig {
 	trafficControlConfig := &InterfaceConfig{InterfaceName: interfaceName, Type: TrafficControlInterface, OVSPortConfig: ovsPortConfig}
 	return trafficControlConfig
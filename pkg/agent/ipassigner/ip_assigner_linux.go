@@ -129,6 +129,18 @@ func ensureDummyDevice(deviceName string) (netlink.Link, error) {
 	return dummy, nil
 }
 
+// newAddr returns the address of the provided IP to be added to the dummy device. Duplicate Address Detection is
+// disabled for IPv6 addresses: otherwise the addresses stay tentative and cannot be used as source addresses for a
+// while after being added, and would be marked as "dadfailed" and never become usable if the previous owner Node still
+// responds for them, which can happen during failover.
+func newAddr(ip net.IP) *netlink.Addr {
+	addr := &netlink.Addr{IPNet: util.NewIPNet(ip)}
+	if utilnet.IsIPv6(ip) {
+		addr.Flags = unix.IFA_F_NODAD
+	}
+	return addr
+}
+
 // loadIPAddresses gets the IP addresses on the dummy device and caches them in memory.
 func (a *ipAssigner) loadIPAddresses() (sets.Set[string], error) {
 	addresses, err := netlink.AddrList(a.dummyDevice, netlink.FAMILY_ALL)
@@ -137,6 +149,10 @@ func (a *ipAssigner) loadIPAddresses() (sets.Set[string], error) {
 	}
 	newAssignIPs := sets.New[string]()
 	for _, address := range addresses {
+		// Skip the IPv6 link-local address generated by the kernel, which is not assigned by antrea-agent.
+		if address.IP.IsLinkLocalUnicast() {
+			continue
+		}
 		newAssignIPs.Insert(address.IP.String())
 	}
 	return newAssignIPs, nil
@@ -160,8 +176,7 @@ func (a *ipAssigner) AssignIP(ip string, forceAdvertise bool) error {
 	}
 
 	if a.dummyDevice != nil {
-		addr := util.NewIPNet(parsedIP)
-		if err := netlink.AddrAdd(a.dummyDevice, &netlink.Addr{IPNet: addr}); err != nil {
+		if err := netlink.AddrAdd(a.dummyDevice, newAddr(parsedIP)); err != nil {
 			if !errors.Is(err, unix.EEXIST) {
 				return fmt.Errorf("failed to add IP %v to interface %s: %v", ip, a.dummyDevice.Attrs().Name, err)
 			} else {
@@ -263,8 +278,7 @@ func (a *ipAssigner) InitIPs(ips sets.Set[string]) error {
 			return fmt.Errorf("error when loading IP addresses from the system: %v", err)
 		}
 		for ip := range ips.Difference(assigned) {
-			addr := util.NewIPNet(net.ParseIP(ip))
-			if err := netlink.AddrAdd(a.dummyDevice, &netlink.Addr{IPNet: addr}); err != nil {
+			if err := netlink.AddrAdd(a.dummyDevice, newAddr(net.ParseIP(ip))); err != nil {
 				if !errors.Is(err, unix.EEXIST) {
 					return fmt.Errorf("failed to add IP %v to interface %s: %v", ip, a.dummyDevice.Attrs().Name, err)
 				}
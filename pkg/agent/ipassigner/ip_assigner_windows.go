@@ -14,8 +14,148 @@
 
 package ipassigner
 
-import "errors"
+import (
+	"fmt"
+	"net"
+	"sync"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/util"
+)
+
+// ipAssigner assigns IPs to the Node transport interface.
+// There is no dummy device on Windows, so the IPs are configured directly on the host interface of the transport
+// adapter with a full-length prefix, using the same PowerShell NetTCPIP cmdlets that are used to configure the uplink.
+// Windows advertises the IPs by itself when they are added to an interface, so no ARP/NDP responder is needed.
+type ipAssigner struct {
+	// externalInterface is the interface that the IPs will be assigned to.
+	externalInterface *net.Interface
+	// nodeIPs are the IPs of the transport interface, which must never be removed by the IP assigner.
+	nodeIPs sets.Set[string]
+	// assignIPs caches the IPs that are assigned to the transport interface by this ipAssigner.
+	assignedIPs sets.Set[string]
+	mutex       sync.RWMutex
+}
+
+// NewIPAssigner returns an *ipAssigner. dummyDeviceName is ignored on Windows.
 func NewIPAssigner(nodeTransportInterface string, dummyDeviceName string) (IPAssigner, error) {
-	return nil, errors.New("IPAssigner is not implemented on Windows")
+	ipv4, ipv6, externalInterface, err := util.GetIPNetDeviceByName(nodeTransportInterface)
+	if err != nil {
+		return nil, fmt.Errorf("get IPNetDevice from name %s error: %+v", nodeTransportInterface, err)
+	}
+	a := &ipAssigner{
+		externalInterface: externalInterface,
+		nodeIPs:           sets.New[string](),
+		assignedIPs:       sets.New[string](),
+	}
+	if ipv4 != nil {
+		a.nodeIPs.Insert(ipv4.IP.String())
+	}
+	if ipv6 != nil {
+		a.nodeIPs.Insert(ipv6.IP.String())
+	}
+	return a, nil
+}
+
+// loadIPAddresses gets the IP addresses with a full-length prefix on the transport interface, excluding the Node IPs.
+func (a *ipAssigner) loadIPAddresses() (sets.Set[string], error) {
+	addresses, err := a.externalInterface.Addrs()
+	if err != nil {
+		return nil, err
+	}
+	newAssignIPs := sets.New[string]()
+	for _, address := range addresses {
+		ipNet, ok := address.(*net.IPNet)
+		if !ok || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		ones, bits := ipNet.Mask.Size()
+		if ones != bits || a.nodeIPs.Has(ipNet.IP.String()) {
+			continue
+		}
+		newAssignIPs.Insert(ipNet.IP.String())
+	}
+	return newAssignIPs, nil
+}
+
+// AssignIP ensures the provided IP is assigned to the transport interface.
+func (a *ipAssigner) AssignIP(ip string, forceAdvertise bool) error {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return fmt.Errorf("invalid IP %s", ip)
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.assignedIPs.Has(ip) {
+		klog.V(2).InfoS("The IP is already assigned", "ip", ip)
+		return nil
+	}
+
+	if err := util.ConfigureInterfaceAddress(a.externalInterface.Name, util.NewIPNet(parsedIP)); err != nil {
+		return fmt.Errorf("failed to add IP %v to interface %s: %v", ip, a.externalInterface.Name, err)
+	}
+	klog.InfoS("Assigned IP to interface", "ip", parsedIP, "interface", a.externalInterface.Name)
+	a.assignedIPs.Insert(ip)
+	return nil
+}
+
+// UnassignIP ensures the provided IP is not assigned to the transport interface.
+func (a *ipAssigner) UnassignIP(ip string) error {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return fmt.Errorf("invalid IP %s", ip)
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if !a.assignedIPs.Has(ip) {
+		klog.V(2).InfoS("The IP is not assigned", "ip", ip)
+		return nil
+	}
+
+	if err := util.RemoveInterfaceAddress(a.externalInterface.Name, parsedIP); err != nil {
+		return fmt.Errorf("failed to delete IP %v from interface %s: %v", ip, a.externalInterface.Name, err)
+	}
+	klog.InfoS("Deleted IP from interface", "ip", ip, "interface", a.externalInterface.Name)
+	a.assignedIPs.Delete(ip)
+	return nil
+}
+
+// AssignedIPs return the IPs that are assigned to the transport interface by this ipAssigner.
+func (a *ipAssigner) AssignedIPs() sets.Set[string] {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	// Return a copy.
+	return a.assignedIPs.Union(nil)
+}
+
+// InitIPs loads the IPs from the transport interface and replaces the IPs that are assigned to it with the given ones.
+// It can be used to recover the IP assigner to the desired state after Agent restarts.
+func (a *ipAssigner) InitIPs(ips sets.Set[string]) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	assigned, err := a.loadIPAddresses()
+	if err != nil {
+		return fmt.Errorf("error when loading IP addresses from the system: %v", err)
+	}
+	for ip := range ips.Difference(assigned) {
+		if err := util.ConfigureInterfaceAddress(a.externalInterface.Name, util.NewIPNet(net.ParseIP(ip))); err != nil {
+			return fmt.Errorf("failed to add IP %v to interface %s: %v", ip, a.externalInterface.Name, err)
+		}
+	}
+	for ip := range assigned.Difference(ips) {
+		if err := util.RemoveInterfaceAddress(a.externalInterface.Name, net.ParseIP(ip)); err != nil {
+			return fmt.Errorf("failed to delete IP %v from interface %s: %v", ip, a.externalInterface.Name, err)
+		}
+	}
+	a.assignedIPs = ips.Union(nil)
+	return nil
+}
+
+// Run is a no-op on Windows as there is no ARP or NDP responder to start.
+func (a *ipAssigner) Run(ch <-chan struct{}) {
+	<-ch
 }
@@ -14,25 +14,91 @@
 
 package ipassigner
 
-// Not implemented yet. The feature gate verification will protect this from being run.
-type localIPDetector struct{}
+import (
+	"net"
+	"sync"
+	"time"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// syncInterval is the interval at which the IP addresses on the Node are listed. There is no IP address update
+// subscription API equivalent to netlink available on Windows, so the detector relies on periodic listing.
+const syncInterval = 5 * time.Second
+
+var listInterfaceAddrs = net.InterfaceAddrs
+
+type localIPDetector struct {
+	mutex         sync.RWMutex
+	localIPs      sets.Set[string]
+	cacheSynced   bool
+	eventHandlers []LocalIPEventHandler
+}
+
+func NewLocalIPDetector() *localIPDetector {
+	return &localIPDetector{localIPs: sets.New[string]()}
+}
+
+// IsLocalIP checks if the provided IP is configured on the Node.
 func (d *localIPDetector) IsLocalIP(ip string) bool {
-	return false
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.localIPs.Has(ip)
 }
 
-func (d *localIPDetector) Run(stopCh <-chan struct{}) {
-	return
+func (d *localIPDetector) HasSynced() bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.cacheSynced
 }
 
 func (d *localIPDetector) AddEventHandler(handler LocalIPEventHandler) {
-	return
+	d.eventHandlers = append(d.eventHandlers, handler)
 }
 
-func (d *localIPDetector) HasSynced() bool {
-	return false
+func (d *localIPDetector) Run(stopCh <-chan struct{}) {
+	klog.Infof("Starting localIPDetector")
+
+	go wait.Until(d.syncIPAddresses, syncInterval, stopCh)
+
+	<-stopCh
 }
 
-func NewLocalIPDetector() *localIPDetector {
-	return &localIPDetector{}
+func (d *localIPDetector) notify(ip string, added bool) {
+	for _, handler := range d.eventHandlers {
+		handler(ip, added)
+	}
+}
+
+func (d *localIPDetector) syncIPAddresses() {
+	addresses, err := listInterfaceAddrs()
+	if err != nil {
+		klog.ErrorS(err, "Failed to list IP addresses on the Node")
+		return
+	}
+	ips := sets.New[string]()
+	for _, addr := range addresses {
+		if ipNet, ok := addr.(*net.IPNet); ok {
+			ips.Insert(ipNet.IP.String())
+		}
+	}
+
+	addedAddresses, deletedAddresses := func() (sets.Set[string], sets.Set[string]) {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+
+		added := ips.Difference(d.localIPs)
+		deleted := d.localIPs.Difference(ips)
+		d.localIPs = ips
+		d.cacheSynced = true
+		return added, deleted
+	}()
+	for addr := range addedAddresses {
+		d.notify(addr, true)
+	}
+	for addr := range deletedAddresses {
+		d.notify(addr, false)
+	}
 }
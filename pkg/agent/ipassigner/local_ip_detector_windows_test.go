@@ -0,0 +1,50 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipassigner
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalIPDetectorSyncIPAddresses(t *testing.T) {
+	addrs := []net.Addr{
+		&net.IPNet{IP: net.ParseIP("10.10.0.1"), Mask: net.CIDRMask(24, 32)},
+		&net.IPNet{IP: net.ParseIP("192.168.77.100"), Mask: net.CIDRMask(32, 32)},
+	}
+	defer func(orig func() ([]net.Addr, error)) { listInterfaceAddrs = orig }(listInterfaceAddrs)
+	listInterfaceAddrs = func() ([]net.Addr, error) { return addrs, nil }
+
+	events := map[string]bool{}
+	d := NewLocalIPDetector()
+	d.AddEventHandler(func(ip string, added bool) {
+		events[ip] = added
+	})
+	assert.False(t, d.HasSynced())
+
+	d.syncIPAddresses()
+	assert.True(t, d.HasSynced())
+	assert.True(t, d.IsLocalIP("10.10.0.1"))
+	assert.True(t, d.IsLocalIP("192.168.77.100"))
+	assert.Equal(t, map[string]bool{"10.10.0.1": true, "192.168.77.100": true}, events)
+
+	events = map[string]bool{}
+	addrs = addrs[:1]
+	d.syncIPAddresses()
+	assert.False(t, d.IsLocalIP("192.168.77.100"))
+	assert.Equal(t, map[string]bool{"192.168.77.100": false}, events)
+}
@@ -0,0 +1,168 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kubeproxycheck detects a kube-proxy instance running on the Node with a configuration which conflicts with
+// AntreaProxy when proxyAll is enabled. Such misconfigurations don't cause any error in either component, but lead to
+// Service traffic being blackholed silently, so they are reported in the AntreaAgentInfo of the Node.
+package kubeproxycheck
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// kube-proxy may be started or reconfigured after antrea-agent, so the Node is checked periodically.
+	DefaultCheckInterval = 60 * time.Second
+
+	ReasonIPVSStrictARPDisabled = "IPVSStrictARPDisabled"
+)
+
+// Mode is the proxy mode of the kube-proxy instance running on the Node.
+type Mode string
+
+const (
+	// ModeNone means that no kube-proxy instance was detected on the Node.
+	ModeNone     Mode = "none"
+	ModeIPTables Mode = "iptables"
+	ModeIPVS     Mode = "ipvs"
+)
+
+// Result is the result of a check of the Node.
+type Result struct {
+	Mode Mode
+	// Reason is a brief reason of the conflict between kube-proxy and AntreaProxy. It is empty when no conflict was
+	// detected.
+	Reason string
+	// Message is a human readable message describing the detected kube-proxy instance and the conflict if any.
+	Message string
+}
+
+// Compatible returns whether no conflict was detected between kube-proxy and AntreaProxy.
+func (r *Result) Compatible() bool {
+	return r.Reason == ""
+}
+
+// Querier provides the result of the check of the Node.
+type Querier interface {
+	GetResult() *Result
+}
+
+// detector inspects the host network configuration of the Node.
+type detector interface {
+	// getMode returns the proxy mode of the kube-proxy instance running on the Node.
+	getMode() (Mode, error)
+	// strictARPEnabled returns whether the Node only replies to the ARP requests for the IPs configured on the
+	// receiving interface, which kube-proxy configures when strictARP is set in IPVS mode.
+	strictARPEnabled() (bool, error)
+	// enableStrictARP configures the Node like kube-proxy does when strictARP is set in IPVS mode.
+	enableStrictARP() error
+}
+
+// Checker checks periodically whether kube-proxy is running on the Node with a configuration conflicting with
+// AntreaProxy. When installCompatibilityRules is true, it configures the Node to resolve the conflicts it can resolve
+// instead of only reporting them.
+var _ Querier = new(Checker)
+
+type Checker struct {
+	detector                  detector
+	installCompatibilityRules bool
+	checkInterval             time.Duration
+
+	mutex  sync.RWMutex
+	result *Result
+}
+
+func NewChecker(ipv4Enabled, ipv6Enabled bool, installCompatibilityRules bool) *Checker {
+	return &Checker{
+		detector:                  newDetector(ipv4Enabled, ipv6Enabled),
+		installCompatibilityRules: installCompatibilityRules,
+		checkInterval:             DefaultCheckInterval,
+	}
+}
+
+// Run checks the Node periodically until stopCh is closed.
+func (c *Checker) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Starting kube-proxy compatibility checker", "installCompatibilityRules", c.installCompatibilityRules)
+	wait.Until(c.check, c.checkInterval, stopCh)
+}
+
+// GetResult returns the result of the last successful check, or nil if the Node has not been checked yet.
+func (c *Checker) GetResult() *Result {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.result
+}
+
+func (c *Checker) check() {
+	result, err := c.checkNode()
+	if err != nil {
+		klog.ErrorS(err, "Failed to check the compatibility of kube-proxy with AntreaProxy")
+		return
+	}
+	c.mutex.Lock()
+	prevResult := c.result
+	c.result = result
+	c.mutex.Unlock()
+	// Only log the result when it changes, to avoid flooding the logs.
+	if prevResult != nil && *prevResult == *result {
+		return
+	}
+	if result.Compatible() {
+		klog.InfoS("No conflict detected between kube-proxy and AntreaProxy", "mode", result.Mode)
+	} else {
+		klog.ErrorS(nil, "Detected a kube-proxy configuration conflicting with AntreaProxy", "mode", result.Mode, "reason", result.Reason, "message", result.Message)
+	}
+}
+
+func (c *Checker) checkNode() (*Result, error) {
+	mode, err := c.detector.getMode()
+	if err != nil {
+		return nil, fmt.Errorf("error detecting the mode of kube-proxy: %w", err)
+	}
+	result := &Result{Mode: mode}
+	if mode == ModeNone {
+		return result, nil
+	}
+	result.Message = fmt.Sprintf("kube-proxy is running in %s mode", mode)
+	if mode != ModeIPVS {
+		return result, nil
+	}
+	// In IPVS mode, kube-proxy binds the Service IPs to the kube-ipvs0 interface. Unless strictARP is set, the Node
+	// then replies to the ARP requests for the LoadBalancer IPs received on any interface, and attracts the traffic
+	// which is meant to be handled by another Node or by an external load balancer.
+	enabled, err := c.detector.strictARPEnabled()
+	if err != nil {
+		return nil, fmt.Errorf("error checking the ARP settings of the Node: %w", err)
+	}
+	if enabled {
+		return result, nil
+	}
+	if c.installCompatibilityRules {
+		if err := c.detector.enableStrictARP(); err != nil {
+			return nil, fmt.Errorf("error enabling strict ARP on the Node: %w", err)
+		}
+		klog.InfoS("Enabled strict ARP on the Node for kube-proxy in IPVS mode")
+		return result, nil
+	}
+	result.Reason = ReasonIPVSStrictARPDisabled
+	result.Message = "kube-proxy is running in IPVS mode without strictARP, so the Node replies to the ARP requests for " +
+		"the LoadBalancer IPs and may blackhole their traffic; set strictARP to true in the kube-proxy configuration, " +
+		"remove kube-proxy, or set antreaProxy.installKubeProxyCompatibilityRules to true in the antrea-agent configuration"
+	return result, nil
+}
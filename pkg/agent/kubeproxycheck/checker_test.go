@@ -0,0 +1,117 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeproxycheck
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDetector struct {
+	mode              Mode
+	modeErr           error
+	strictARP         bool
+	strictARPEnabledN int
+}
+
+func (d *fakeDetector) getMode() (Mode, error) {
+	return d.mode, d.modeErr
+}
+
+func (d *fakeDetector) strictARPEnabled() (bool, error) {
+	return d.strictARP, nil
+}
+
+func (d *fakeDetector) enableStrictARP() error {
+	d.strictARP = true
+	d.strictARPEnabledN++
+	return nil
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name                      string
+		detector                  *fakeDetector
+		installCompatibilityRules bool
+		expectedResult            *Result
+		expectedStrictARPEnabledN int
+	}{
+		{
+			name:           "kube-proxy not running",
+			detector:       &fakeDetector{mode: ModeNone},
+			expectedResult: &Result{Mode: ModeNone},
+		},
+		{
+			name:           "iptables mode",
+			detector:       &fakeDetector{mode: ModeIPTables},
+			expectedResult: &Result{Mode: ModeIPTables, Message: "kube-proxy is running in iptables mode"},
+		},
+		{
+			name:           "IPVS mode with strict ARP",
+			detector:       &fakeDetector{mode: ModeIPVS, strictARP: true},
+			expectedResult: &Result{Mode: ModeIPVS, Message: "kube-proxy is running in ipvs mode"},
+		},
+		{
+			name:                      "IPVS mode without strict ARP, with compatibility rules",
+			detector:                  &fakeDetector{mode: ModeIPVS},
+			installCompatibilityRules: true,
+			expectedResult:            &Result{Mode: ModeIPVS, Message: "kube-proxy is running in ipvs mode"},
+			expectedStrictARPEnabledN: 1,
+		},
+		{
+			name:     "IPVS mode without strict ARP",
+			detector: &fakeDetector{mode: ModeIPVS},
+			expectedResult: &Result{
+				Mode:   ModeIPVS,
+				Reason: ReasonIPVSStrictARPDisabled,
+				Message: "kube-proxy is running in IPVS mode without strictARP, so the Node replies to the ARP requests for " +
+					"the LoadBalancer IPs and may blackhole their traffic; set strictARP to true in the kube-proxy configuration, " +
+					"remove kube-proxy, or set antreaProxy.installKubeProxyCompatibilityRules to true in the antrea-agent configuration",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Checker{
+				detector:                  tt.detector,
+				installCompatibilityRules: tt.installCompatibilityRules,
+				checkInterval:             DefaultCheckInterval,
+			}
+			assert.Nil(t, c.GetResult())
+			c.check()
+			result := c.GetResult()
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expectedResult, result)
+			assert.Equal(t, tt.expectedResult.Reason == "", result.Compatible())
+			assert.Equal(t, tt.expectedStrictARPEnabledN, tt.detector.strictARPEnabledN)
+		})
+	}
+}
+
+func TestCheckError(t *testing.T) {
+	detector := &fakeDetector{mode: ModeNone}
+	c := &Checker{detector: detector, checkInterval: DefaultCheckInterval}
+	c.check()
+	require.NotNil(t, c.GetResult())
+
+	// The result of the last successful check is kept when the check fails.
+	detector.mode = ModeIPVS
+	detector.modeErr = fmt.Errorf("netlink error")
+	c.check()
+	assert.Equal(t, &Result{Mode: ModeNone}, c.GetResult())
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeproxycheck
+
+import (
+	"github.com/vishvananda/netlink"
+
+	"antrea.io/antrea/pkg/agent/util/iptables"
+	"antrea.io/antrea/pkg/agent/util/sysctl"
+)
+
+const (
+	// kubeIPVSInterface is the dummy interface to which kube-proxy binds the Service IPs in IPVS mode.
+	kubeIPVSInterface = "kube-ipvs0"
+	// kubeServicesChain is the chain of the nat table in which kube-proxy installs the Service rules in iptables mode.
+	kubeServicesChain = "KUBE-SERVICES"
+
+	// The values which kube-proxy sets when strictARP is set in IPVS mode.
+	arpIgnoreSysctl   = "ipv4/conf/all/arp_ignore"
+	arpIgnoreStrict   = 1
+	arpAnnounceSysctl = "ipv4/conf/all/arp_announce"
+	arpAnnounceStrict = 2
+)
+
+type hostDetector struct {
+	ipv4Enabled bool
+	ipv6Enabled bool
+}
+
+func newDetector(ipv4Enabled, ipv6Enabled bool) detector {
+	return &hostDetector{ipv4Enabled: ipv4Enabled, ipv6Enabled: ipv6Enabled}
+}
+
+func (d *hostDetector) getMode() (Mode, error) {
+	_, err := netlink.LinkByName(kubeIPVSInterface)
+	if err == nil {
+		return ModeIPVS, nil
+	}
+	if _, ok := err.(netlink.LinkNotFoundError); !ok {
+		return "", err
+	}
+	ipt, err := iptables.New(d.ipv4Enabled, d.ipv6Enabled)
+	if err != nil {
+		return "", err
+	}
+	exists, err := ipt.ChainExists(iptables.ProtocolDual, iptables.NATTable, kubeServicesChain)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return ModeIPTables, nil
+	}
+	return ModeNone, nil
+}
+
+func (d *hostDetector) strictARPEnabled() (bool, error) {
+	arpIgnore, err := sysctl.GetSysctlNet(arpIgnoreSysctl)
+	if err != nil {
+		return false, err
+	}
+	arpAnnounce, err := sysctl.GetSysctlNet(arpAnnounceSysctl)
+	if err != nil {
+		return false, err
+	}
+	return arpIgnore == arpIgnoreStrict && arpAnnounce == arpAnnounceStrict, nil
+}
+
+func (d *hostDetector) enableStrictARP() error {
+	if err := sysctl.EnsureSysctlNetValue(arpIgnoreSysctl, arpIgnoreStrict); err != nil {
+		return err
+	}
+	return sysctl.EnsureSysctlNetValue(arpAnnounceSysctl, arpAnnounceStrict)
+}
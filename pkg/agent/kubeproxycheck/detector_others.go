@@ -0,0 +1,37 @@
+//go:build !linux
+// +build !linux
+
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeproxycheck
+
+type unsupportedDetector struct{}
+
+// newDetector returns a detector which never detects kube-proxy, as the check is only supported on Linux.
+func newDetector(ipv4Enabled, ipv6Enabled bool) detector {
+	return &unsupportedDetector{}
+}
+
+func (d *unsupportedDetector) getMode() (Mode, error) {
+	return ModeNone, nil
+}
+
+func (d *unsupportedDetector) strictARPEnabled() (bool, error) {
+	return true, nil
+}
+
+func (d *unsupportedDetector) enableStrictARP() error {
+	return nil
+}
@@ -85,6 +85,10 @@ type ClusterNodeEventHandler func(objName string)
 type Interface interface {
 	ShouldSelectIP(ip string, pool string, filters ...func(node string) bool) (bool, error)
 	SelectNodeForIP(ip, externalIPPool string, filters ...func(string) bool) (string, error)
+	// GetMaxIPsPerNode returns the maximum number of IPs of the ExternalIPPool that can be assigned to a Node, 0
+	// means no limit. As the limit depends on the IPs assigned to each Node, it's up to callers to enforce it by
+	// passing appropriate filters to SelectNodeForIP and ShouldSelectIP.
+	GetMaxIPsPerNode(externalIPPool string) int
 	AliveNodes() sets.Set[string]
 	AddClusterEventHandler(handler ClusterNodeEventHandler)
 }
@@ -105,7 +109,10 @@ type Cluster struct {
 	mList Memberlist
 	// consistentHash hold the consistentHashMap, when a Node join cluster, use method Add() to add a key to the hash.
 	// when a Node leave the cluster, the consistentHashMap should be update.
-	consistentHashMap     map[string]*consistenthash.Map
+	consistentHashMap map[string]*consistenthash.Map
+	// poolPlacements holds the placement state of the ExternalIPPools that have a placement policy. It's protected by
+	// consistentHashRWMutex as well.
+	poolPlacements        map[string]*poolPlacement
 	consistentHashRWMutex sync.RWMutex
 	// nodeEventsCh, the Node join/leave events will be notified via it.
 	nodeEventsCh chan memberlist.NodeEvent
@@ -145,6 +152,7 @@ func NewCluster(
 		bindPort:                        clusterBindPort,
 		nodeName:                        nodeName,
 		consistentHashMap:               make(map[string]*consistenthash.Map),
+		poolPlacements:                  make(map[string]*poolPlacement),
 		mList:                           ml,
 		nodeEventsCh:                    nodeEventCh,
 		nodeInformer:                    nodeInformer,
@@ -168,6 +176,9 @@ func NewCluster(
 		conf.LogOutput = io.Discard
 		klog.V(1).InfoS("New memberlist cluster", "config", conf)
 
+		if err := allowClusterBindPort(c.bindPort); err != nil {
+			return nil, err
+		}
 		mList, err := memberlist.Create(conf)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create memberlist cluster: %v", err)
@@ -189,7 +200,8 @@ func NewCluster(
 			UpdateFunc: func(oldObj, newObj interface{}) {
 				oldExternalIPPool := oldObj.(*v1alpha2.ExternalIPPool)
 				curExternalIPPool := newObj.(*v1alpha2.ExternalIPPool)
-				if !reflect.DeepEqual(oldExternalIPPool.Spec.NodeSelector, curExternalIPPool.Spec.NodeSelector) {
+				if !reflect.DeepEqual(oldExternalIPPool.Spec.NodeSelector, curExternalIPPool.Spec.NodeSelector) ||
+					!reflect.DeepEqual(oldExternalIPPool.Spec.Placement, curExternalIPPool.Spec.Placement) {
 					c.enqueueExternalIPPool(newObj)
 				}
 			},
@@ -246,11 +258,17 @@ func (c *Cluster) handleUpdateNode(oldObj, newObj interface{}) {
 		return
 	}
 	oldMatches, newMatches := c.filterEIPsFromNodeLabels(oldNode), c.filterEIPsFromNodeLabels(node)
-	if oldMatches.Equal(newMatches) {
+	affectedEIPs := sets.New[string]()
+	if !oldMatches.Equal(newMatches) {
+		affectedEIPs = oldMatches.Union(newMatches)
+	}
+	// The ExternalIPPools that still select the Node must be resynced if the Node's failure domain or preference
+	// changed.
+	affectedEIPs.Insert(sets.List(c.filterEIPsWithPlacementChange(oldNode, node, oldMatches.Intersection(newMatches)))...)
+	if affectedEIPs.Len() == 0 {
 		klog.V(2).InfoS("Processed Node UPDATE event, Node cluster status not changed", "nodeName", node.Name)
 		return
 	}
-	affectedEIPs := oldMatches.Union(newMatches)
 	c.enqueueExternalIPPools(affectedEIPs)
 	klog.V(2).InfoS("Processed Node UPDATE event", "nodeName", node.Name, "affectedExternalIPPoolNum", affectedEIPs.Len())
 }
@@ -303,6 +321,31 @@ func (c *Cluster) filterEIPsFromNodeLabels(node *corev1.Node) sets.Set[string] {
 	return pools
 }
 
+// filterEIPsWithPlacementChange returns the ExternalIPPools among the provided ones whose placement of IPs may be
+// affected by the label update of the Node.
+func (c *Cluster) filterEIPsWithPlacementChange(oldNode, node *corev1.Node, eipNames sets.Set[string]) sets.Set[string] {
+	pools := sets.New[string]()
+	oldLabels, newLabels := labels.Set(oldNode.GetLabels()), labels.Set(node.GetLabels())
+	for eipName := range eipNames {
+		eip, err := c.externalIPPoolLister.Get(eipName)
+		if err != nil || eip.Spec.Placement == nil {
+			continue
+		}
+		placement := eip.Spec.Placement
+		if placement.SpreadTopologyKey != "" && oldLabels[placement.SpreadTopologyKey] != newLabels[placement.SpreadTopologyKey] {
+			pools.Insert(eipName)
+			continue
+		}
+		if placement.PreferredNodeSelector != nil {
+			preferredSelector, err := metav1.LabelSelectorAsSelector(placement.PreferredNodeSelector)
+			if err == nil && preferredSelector.Matches(oldLabels) != preferredSelector.Matches(newLabels) {
+				pools.Insert(eipName)
+			}
+		}
+	}
+	return pools
+}
+
 // Run will join all the other K8s Nodes in a memberlist cluster
 // and will create defaultWorkers workers (go routines) which will process the ExternalIPPool or Node events
 // from the work queue.
@@ -428,6 +471,7 @@ func (c *Cluster) syncConsistentHash(eipName string) error {
 			c.consistentHashRWMutex.Lock()
 			defer c.consistentHashRWMutex.Unlock()
 			delete(c.consistentHashMap, eipName)
+			delete(c.poolPlacements, eipName)
 			return nil
 		}
 		return err
@@ -445,18 +489,29 @@ func (c *Cluster) syncConsistentHash(eipName string) error {
 		}
 		aliveNodes := c.AliveNodes()
 		// Node alive and Node labels match ExternalIPPool nodeSelector.
-		var aliveAndMatchedNodes []string
+		var aliveAndMatchedNodes []*corev1.Node
+		var aliveAndMatchedNodeNames []string
 		for _, node := range nodes {
 			nodeName := node.Name
 			if aliveNodes.Has(nodeName) {
-				aliveAndMatchedNodes = append(aliveAndMatchedNodes, nodeName)
+				aliveAndMatchedNodes = append(aliveAndMatchedNodes, node)
+				aliveAndMatchedNodeNames = append(aliveAndMatchedNodeNames, nodeName)
 			}
 		}
+		placement, err := newPoolPlacement(eip.Spec.Placement, aliveAndMatchedNodes)
+		if err != nil {
+			return err
+		}
 		consistentHashMap := NewNodeConsistentHashMap()
-		consistentHashMap.Add(aliveAndMatchedNodes...)
+		consistentHashMap.Add(aliveAndMatchedNodeNames...)
 		c.consistentHashRWMutex.Lock()
 		defer c.consistentHashRWMutex.Unlock()
 		c.consistentHashMap[eip.Name] = consistentHashMap
+		if placement != nil {
+			c.poolPlacements[eip.Name] = placement
+		} else {
+			delete(c.poolPlacements, eip.Name)
+		}
 		c.notify(eip.Name)
 		return nil
 	}
@@ -517,7 +572,7 @@ func (c *Cluster) ShouldSelectIP(ip, externalIPPool string, filters ...func(stri
 	if !ok {
 		return false, fmt.Errorf("local Node consistentHashMap has not synced, ExternalIPPool %s", externalIPPool)
 	}
-	node := consistentHash.GetWithFilters(ip, filters...)
+	node := c.selectNode(ip, externalIPPool, consistentHash, filters)
 	return node == c.nodeName, nil
 }
 
@@ -532,13 +587,33 @@ func (c *Cluster) SelectNodeForIP(ip, externalIPPool string, filters ...func(str
 	if !ok {
 		return "", fmt.Errorf("local Node consistentHashMap has not synced, ExternalIPPool %s", externalIPPool)
 	}
-	node := consistentHash.GetWithFilters(ip, filters...)
+	node := c.selectNode(ip, externalIPPool, consistentHash, filters)
 	if node == "" {
 		return "", ErrNoNodeAvailable
 	}
 	return node, nil
 }
 
+// selectNode returns the Node selected for the IP, applying the placement policy of the ExternalIPPool if there is
+// one. The caller must hold consistentHashRWMutex.
+func (c *Cluster) selectNode(ip, externalIPPool string, consistentHash *consistenthash.Map, filters []func(string) bool) string {
+	if placement, ok := c.poolPlacements[externalIPPool]; ok {
+		return placement.selectNode(ip, consistentHash, filters)
+	}
+	return consistentHash.GetWithFilters(ip, filters...)
+}
+
+// GetMaxIPsPerNode returns the maximum number of IPs of the ExternalIPPool that can be assigned to a Node, 0 means
+// no limit.
+func (c *Cluster) GetMaxIPsPerNode(externalIPPool string) int {
+	c.consistentHashRWMutex.RLock()
+	defer c.consistentHashRWMutex.RUnlock()
+	if placement, ok := c.poolPlacements[externalIPPool]; ok {
+		return placement.maxIPsPerNode
+	}
+	return 0
+}
+
 func (c *Cluster) notify(objName string) {
 	for _, handler := range c.clusterNodeEventHandlers {
 		handler(objName)
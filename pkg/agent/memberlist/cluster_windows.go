@@ -0,0 +1,42 @@
+//go:build windows
+// +build windows
+
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memberlist
+
+import (
+	"fmt"
+
+	"antrea.io/antrea/pkg/agent/util/winfirewall"
+)
+
+const (
+	tcpFirewallRuleName = "Antrea: accept memberlist TCP packets"
+	udpFirewallRuleName = "Antrea: accept memberlist UDP packets"
+)
+
+// allowClusterBindPort adds Windows firewall rules to accept the memberlist traffic from other Nodes, which is
+// blocked by the Windows host firewall by default.
+func allowClusterBindPort(port int) error {
+	fwClient := winfirewall.NewClient()
+	if err := fwClient.AddRuleAllowTCPPort(tcpFirewallRuleName, winfirewall.FWRuleIn, uint16(port)); err != nil {
+		return fmt.Errorf("failed to add firewall rule for TCP port %d: %v", port, err)
+	}
+	if err := fwClient.AddRuleAllowUDPPort(udpFirewallRuleName, winfirewall.FWRuleIn, uint16(port)); err != nil {
+		return fmt.Errorf("failed to add firewall rule for UDP port %d: %v", port, err)
+	}
+	return nil
+}
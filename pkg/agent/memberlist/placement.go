@@ -0,0 +1,107 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memberlist
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"antrea.io/antrea/pkg/agent/consistenthash"
+	"antrea.io/antrea/pkg/apis/crd/v1alpha2"
+)
+
+// poolPlacement holds the state derived from the placement policy of an ExternalIPPool and the Nodes eligible for
+// its IPs. It's immutable once created and is rebuilt whenever the ExternalIPPool or the eligible Nodes change.
+type poolPlacement struct {
+	// preferredNodes is the set of eligible Nodes matching the preferred Node selector. IPs are assigned to them in
+	// priority.
+	preferredNodes sets.Set[string]
+	// domainHash is the consistent hash of failure domains. It's nil if IPs are not spread across failure domains.
+	domainHash *consistenthash.Map
+	// nodeDomains maps each eligible Node to its failure domain.
+	nodeDomains   map[string]string
+	maxIPsPerNode int
+}
+
+// newPoolPlacement returns the poolPlacement of the provided eligible Nodes, or nil if the placement policy doesn't
+// affect Node selection.
+func newPoolPlacement(placement *v1alpha2.ExternalIPPoolPlacement, nodes []*corev1.Node) (*poolPlacement, error) {
+	if placement == nil {
+		return nil, nil
+	}
+	p := &poolPlacement{
+		maxIPsPerNode: int(placement.MaxIPsPerNode),
+	}
+	if placement.PreferredNodeSelector != nil {
+		preferredSelector, err := metav1.LabelSelectorAsSelector(placement.PreferredNodeSelector)
+		if err != nil {
+			return nil, fmt.Errorf("labelSelectorAsSelector error: %v", err)
+		}
+		p.preferredNodes = sets.New[string]()
+		for _, node := range nodes {
+			if preferredSelector.Matches(labels.Set(node.Labels)) {
+				p.preferredNodes.Insert(node.Name)
+			}
+		}
+	}
+	if placement.SpreadTopologyKey != "" {
+		p.domainHash = NewNodeConsistentHashMap()
+		p.nodeDomains = make(map[string]string, len(nodes))
+		for _, node := range nodes {
+			// Nodes without the label are all in the failure domain with an empty name.
+			domain := node.Labels[placement.SpreadTopologyKey]
+			p.nodeDomains[node.Name] = domain
+			p.domainHash.Add(domain)
+		}
+	}
+	if p.maxIPsPerNode == 0 && p.preferredNodes == nil && p.domainHash == nil {
+		return nil, nil
+	}
+	return p, nil
+}
+
+// selectNode returns the Node selected for the IP among the Nodes of nodeHash that pass the filters. Preferred Nodes
+// are tried first, then all the Nodes. It returns an empty string if no Node is available.
+func (p *poolPlacement) selectNode(ip string, nodeHash *consistenthash.Map, filters []func(string) bool) string {
+	if p.preferredNodes.Len() > 0 {
+		preferredFilters := append(filters[:len(filters):len(filters)], p.preferredNodes.Has)
+		if node := p.selectNodeAcrossDomains(ip, nodeHash, preferredFilters); node != "" {
+			return node
+		}
+	}
+	return p.selectNodeAcrossDomains(ip, nodeHash, filters)
+}
+
+// selectNodeAcrossDomains selects the failure domain of the IP first, using the first domain in the hash ring that
+// has an available Node, then selects a Node of that domain. As each domain gets the same share of the ring
+// regardless of its number of Nodes, IPs are spread evenly across failure domains.
+func (p *poolPlacement) selectNodeAcrossDomains(ip string, nodeHash *consistenthash.Map, filters []func(string) bool) string {
+	if p.domainHash == nil {
+		return nodeHash.GetWithFilters(ip, filters...)
+	}
+	var node string
+	p.domainHash.GetWithFilters(ip, func(domain string) bool {
+		domainFilters := append(filters[:len(filters):len(filters)], func(n string) bool {
+			return p.nodeDomains[n] == domain
+		})
+		node = nodeHash.GetWithFilters(ip, domainFilters...)
+		return node != ""
+	})
+	return node
+}
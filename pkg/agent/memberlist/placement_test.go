@@ -0,0 +1,104 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memberlist
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	crdv1a2 "antrea.io/antrea/pkg/apis/crd/v1alpha2"
+)
+
+func newNodeWithLabels(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func TestPoolPlacement_SelectNode(t *testing.T) {
+	nodes := []*corev1.Node{
+		newNodeWithLabels("node-a1", map[string]string{"zone": "a", "tier": "edge"}),
+		newNodeWithLabels("node-a2", map[string]string{"zone": "a"}),
+		newNodeWithLabels("node-a3", map[string]string{"zone": "a"}),
+		newNodeWithLabels("node-b1", map[string]string{"zone": "b", "tier": "edge"}),
+	}
+	nodeToZone := map[string]string{"node-a1": "a", "node-a2": "a", "node-a3": "a", "node-b1": "b"}
+	ips := make([]string, 0, 200)
+	for i := 0; i < 200; i++ {
+		ips = append(ips, fmt.Sprintf("10.10.%d.%d", i/100, i%100))
+	}
+
+	t.Run("no placement", func(t *testing.T) {
+		p, err := newPoolPlacement(nil, nodes)
+		require.NoError(t, err)
+		assert.Nil(t, p)
+	})
+
+	t.Run("spread across failure domains", func(t *testing.T) {
+		p, err := newPoolPlacement(&crdv1a2.ExternalIPPoolPlacement{SpreadTopologyKey: "zone"}, nodes)
+		require.NoError(t, err)
+		nodeHash := NewNodeConsistentHashMap()
+		nodeHash.Add("node-a1", "node-a2", "node-a3", "node-b1")
+		zoneToIPs := map[string]int{}
+		for _, ip := range ips {
+			node := p.selectNode(ip, nodeHash, nil)
+			require.NotEmpty(t, node)
+			zoneToIPs[nodeToZone[node]]++
+		}
+		// Zone b has a single Node but should get a fair share of the IPs.
+		assert.InDelta(t, len(ips)/2, zoneToIPs["b"], float64(len(ips))/5)
+
+		// IPs should fail over to the other failure domain when all Nodes of a domain are filtered out.
+		for _, ip := range ips {
+			node := p.selectNode(ip, nodeHash, []func(string) bool{func(n string) bool { return nodeToZone[n] != "b" }})
+			assert.Equal(t, "a", nodeToZone[node])
+		}
+	})
+
+	t.Run("preferred Nodes", func(t *testing.T) {
+		p, err := newPoolPlacement(&crdv1a2.ExternalIPPoolPlacement{
+			PreferredNodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "edge"}},
+		}, nodes)
+		require.NoError(t, err)
+		nodeHash := NewNodeConsistentHashMap()
+		nodeHash.Add("node-a1", "node-a2", "node-a3", "node-b1")
+		for _, ip := range ips {
+			node := p.selectNode(ip, nodeHash, nil)
+			assert.Contains(t, []string{"node-a1", "node-b1"}, node)
+		}
+		// IPs should fall back to the other Nodes when no preferred Node is available.
+		notEdge := func(n string) bool { return n != "node-a1" && n != "node-b1" }
+		for _, ip := range ips {
+			node := p.selectNode(ip, nodeHash, []func(string) bool{notEdge})
+			assert.Contains(t, []string{"node-a2", "node-a3"}, node)
+		}
+	})
+
+	t.Run("max IPs per Node", func(t *testing.T) {
+		p, err := newPoolPlacement(&crdv1a2.ExternalIPPoolPlacement{MaxIPsPerNode: 2}, nodes)
+		require.NoError(t, err)
+		assert.Equal(t, 2, p.maxIPsPerNode)
+	})
+
+	t.Run("invalid preferred Node selector", func(t *testing.T) {
+		_, err := newPoolPlacement(&crdv1a2.ExternalIPPoolPlacement{
+			PreferredNodeSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Foo"}}},
+		}, nodes)
+		assert.Error(t, err)
+	})
+}
@@ -75,6 +75,20 @@ func (mr *MockInterfaceMockRecorder) AliveNodes() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AliveNodes", reflect.TypeOf((*MockInterface)(nil).AliveNodes))
 }
 
+// GetMaxIPsPerNode mocks base method
+func (m *MockInterface) GetMaxIPsPerNode(arg0 string) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxIPsPerNode", arg0)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetMaxIPsPerNode indicates an expected call of GetMaxIPsPerNode
+func (mr *MockInterfaceMockRecorder) GetMaxIPsPerNode(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxIPsPerNode", reflect.TypeOf((*MockInterface)(nil).GetMaxIPsPerNode), arg0)
+}
+
 // SelectNodeForIP mocks base method
 func (m *MockInterface) SelectNodeForIP(arg0, arg1 string, arg2 ...func(string) bool) (string, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,373 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	metricsv1 "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	"antrea.io/antrea/pkg/version"
+)
+
+const (
+	defaultPath   = "/v1/metrics"
+	exportTimeout = 10 * time.Second
+	scopeName     = "antrea.io/antrea/pkg/agent/metrics"
+	serviceName   = "antrea-agent"
+
+	// Label names used by Prometheus exemplars to carry the trace context.
+	traceIDLabel = "trace_id"
+	spanIDLabel  = "span_id"
+)
+
+// Config is the configuration of an Exporter.
+type Config struct {
+	// Endpoint is the URL of the OTLP/HTTP endpoint, validated with ParseEndpoint.
+	Endpoint        string
+	Interval        time.Duration
+	Headers         map[string]string
+	BearerTokenFile string
+	CAFile          string
+}
+
+// Exporter periodically gathers the metrics from a Prometheus Gatherer, converts them to the OpenTelemetry data model,
+// and pushes them to an OpenTelemetry collector using OTLP over HTTP. Counters are exported as cumulative monotonic
+// Sums, gauges and untyped metrics as Gauges, and histograms and summaries as their OpenTelemetry counterparts.
+// Exemplars attached to counters and histogram buckets are exported as well.
+type Exporter struct {
+	url             string
+	interval        time.Duration
+	headers         map[string]string
+	bearerTokenFile string
+	client          *http.Client
+	gatherer        prometheus.Gatherer
+	resource        *resourcev1.Resource
+	clock           clock.Clock
+	startTime       time.Time
+}
+
+// ParseEndpoint validates the provided endpoint and returns the URL the metrics are pushed to. The default path
+// "/v1/metrics" is used if the endpoint doesn't include one.
+func ParseEndpoint(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid endpoint %q: scheme must be http or https", endpoint)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("invalid endpoint %q: host must be set", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = defaultPath
+	}
+	return u.String(), nil
+}
+
+// NewExporter creates an Exporter which pushes the metrics gathered from the provided Gatherer. The resource of the
+// exported metrics identifies the Antrea Agent running on the provided Node.
+func NewExporter(nodeName string, gatherer prometheus.Gatherer, config *Config) (*Exporter, error) {
+	u, err := ParseEndpoint(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.CAFile != "" {
+		caBytes, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA file %s: %w", config.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBytes) {
+			return nil, fmt.Errorf("no valid certificate found in CA file %s", config.CAFile)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	return newExporter(u, nodeName, gatherer, config, &http.Client{Transport: transport, Timeout: exportTimeout}, clock.RealClock{}), nil
+}
+
+func newExporter(url, nodeName string, gatherer prometheus.Gatherer, config *Config, client *http.Client, clock clock.Clock) *Exporter {
+	return &Exporter{
+		url:             url,
+		interval:        config.Interval,
+		headers:         config.Headers,
+		bearerTokenFile: config.BearerTokenFile,
+		client:          client,
+		gatherer:        gatherer,
+		resource: &resourcev1.Resource{
+			Attributes: []*commonv1.KeyValue{
+				stringKeyValue("service.name", serviceName),
+				stringKeyValue("service.version", version.GetFullVersion()),
+				stringKeyValue("k8s.node.name", nodeName),
+			},
+		},
+		clock:     clock,
+		startTime: clock.Now(),
+	}
+}
+
+// Run exports the metrics every interval until stopCh is closed.
+func (e *Exporter) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Starting OpenTelemetry metrics exporter", "url", e.url, "interval", e.interval)
+	ctx, cancel := wait.ContextForChannel(stopCh)
+	defer cancel()
+	wait.Until(func() {
+		if err := e.export(ctx); err != nil {
+			klog.ErrorS(err, "Failed to export metrics to OpenTelemetry collector", "url", e.url)
+		}
+	}, e.interval, stopCh)
+}
+
+func (e *Exporter) export(ctx context.Context) error {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		// Gather may return partial results along with an error, export what is available.
+		klog.ErrorS(err, "Error when gathering metrics")
+	}
+	data := e.buildMetricsData(families)
+	// MetricsData has the same wire format as ExportMetricsServiceRequest, which is the message expected by the
+	// collector.
+	body, err := proto.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("error marshaling metrics: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	if e.bearerTokenFile != "" {
+		token, err := os.ReadFile(e.bearerTokenFile)
+		if err != nil {
+			return fmt.Errorf("error reading bearer token file %s: %w", e.bearerTokenFile, err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so that the connection can be reused.
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+func (e *Exporter) buildMetricsData(families []*dto.MetricFamily) *metricsv1.MetricsData {
+	startTime := uint64(e.startTime.UnixNano())
+	now := uint64(e.clock.Now().UnixNano())
+	metrics := make([]*metricsv1.Metric, 0, len(families))
+	for _, family := range families {
+		if metric := convertMetricFamily(family, startTime, now); metric != nil {
+			metrics = append(metrics, metric)
+		}
+	}
+	return &metricsv1.MetricsData{
+		ResourceMetrics: []*metricsv1.ResourceMetrics{
+			{
+				Resource: e.resource,
+				ScopeMetrics: []*metricsv1.ScopeMetrics{
+					{
+						Scope:   &commonv1.InstrumentationScope{Name: scopeName, Version: version.GetFullVersion()},
+						Metrics: metrics,
+					},
+				},
+			},
+		},
+	}
+}
+
+func convertMetricFamily(family *dto.MetricFamily, startTime, now uint64) *metricsv1.Metric {
+	metric := &metricsv1.Metric{
+		Name:        family.GetName(),
+		Description: family.GetHelp(),
+	}
+	switch family.GetType() {
+	case dto.MetricType_COUNTER:
+		dataPoints := make([]*metricsv1.NumberDataPoint, 0, len(family.Metric))
+		for _, m := range family.Metric {
+			dataPoint := &metricsv1.NumberDataPoint{
+				Attributes:        labelsToAttributes(m.Label),
+				StartTimeUnixNano: startTime,
+				TimeUnixNano:      timestamp(m, now),
+				Value:             &metricsv1.NumberDataPoint_AsDouble{AsDouble: m.GetCounter().GetValue()},
+			}
+			if exemplar := m.GetCounter().GetExemplar(); exemplar != nil {
+				dataPoint.Exemplars = []*metricsv1.Exemplar{convertExemplar(exemplar)}
+			}
+			dataPoints = append(dataPoints, dataPoint)
+		}
+		metric.Data = &metricsv1.Metric_Sum{Sum: &metricsv1.Sum{
+			DataPoints:             dataPoints,
+			AggregationTemporality: metricsv1.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			IsMonotonic:            true,
+		}}
+	case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+		dataPoints := make([]*metricsv1.NumberDataPoint, 0, len(family.Metric))
+		for _, m := range family.Metric {
+			value := m.GetGauge().GetValue()
+			if family.GetType() == dto.MetricType_UNTYPED {
+				value = m.GetUntyped().GetValue()
+			}
+			dataPoints = append(dataPoints, &metricsv1.NumberDataPoint{
+				Attributes:   labelsToAttributes(m.Label),
+				TimeUnixNano: timestamp(m, now),
+				Value:        &metricsv1.NumberDataPoint_AsDouble{AsDouble: value},
+			})
+		}
+		metric.Data = &metricsv1.Metric_Gauge{Gauge: &metricsv1.Gauge{DataPoints: dataPoints}}
+	case dto.MetricType_HISTOGRAM:
+		dataPoints := make([]*metricsv1.HistogramDataPoint, 0, len(family.Metric))
+		for _, m := range family.Metric {
+			dataPoints = append(dataPoints, convertHistogram(m, startTime, now))
+		}
+		metric.Data = &metricsv1.Metric_Histogram{Histogram: &metricsv1.Histogram{
+			DataPoints:             dataPoints,
+			AggregationTemporality: metricsv1.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		}}
+	case dto.MetricType_SUMMARY:
+		dataPoints := make([]*metricsv1.SummaryDataPoint, 0, len(family.Metric))
+		for _, m := range family.Metric {
+			summary := m.GetSummary()
+			dataPoint := &metricsv1.SummaryDataPoint{
+				Attributes:        labelsToAttributes(m.Label),
+				StartTimeUnixNano: startTime,
+				TimeUnixNano:      timestamp(m, now),
+				Count:             summary.GetSampleCount(),
+				Sum:               summary.GetSampleSum(),
+			}
+			for _, q := range summary.Quantile {
+				dataPoint.QuantileValues = append(dataPoint.QuantileValues, &metricsv1.SummaryDataPoint_ValueAtQuantile{
+					Quantile: q.GetQuantile(),
+					Value:    q.GetValue(),
+				})
+			}
+			dataPoints = append(dataPoints, dataPoint)
+		}
+		metric.Data = &metricsv1.Metric_Summary{Summary: &metricsv1.Summary{DataPoints: dataPoints}}
+	default:
+		klog.V(4).InfoS("Skipping metric with unsupported type", "name", family.GetName(), "type", family.GetType())
+		return nil
+	}
+	return metric
+}
+
+// convertHistogram converts a Prometheus histogram, whose bucket counts are cumulative, to an OpenTelemetry histogram
+// data point, whose bucket counts are not. The +Inf bucket is implicit in OpenTelemetry.
+func convertHistogram(m *dto.Metric, startTime, now uint64) *metricsv1.HistogramDataPoint {
+	histogram := m.GetHistogram()
+	sum := histogram.GetSampleSum()
+	dataPoint := &metricsv1.HistogramDataPoint{
+		Attributes:        labelsToAttributes(m.Label),
+		StartTimeUnixNano: startTime,
+		TimeUnixNano:      timestamp(m, now),
+		Count:             histogram.GetSampleCount(),
+		Sum:               &sum,
+	}
+	var cumulativeCount uint64
+	for _, bucket := range histogram.Bucket {
+		if exemplar := bucket.GetExemplar(); exemplar != nil {
+			dataPoint.Exemplars = append(dataPoint.Exemplars, convertExemplar(exemplar))
+		}
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			continue
+		}
+		dataPoint.ExplicitBounds = append(dataPoint.ExplicitBounds, bucket.GetUpperBound())
+		dataPoint.BucketCounts = append(dataPoint.BucketCounts, bucket.GetCumulativeCount()-cumulativeCount)
+		cumulativeCount = bucket.GetCumulativeCount()
+	}
+	dataPoint.BucketCounts = append(dataPoint.BucketCounts, histogram.GetSampleCount()-cumulativeCount)
+	return dataPoint
+}
+
+// convertExemplar converts a Prometheus exemplar. The trace context is extracted from the "trace_id" and "span_id"
+// labels when they are valid hex-encoded IDs, the other labels are exported as filtered attributes.
+func convertExemplar(exemplar *dto.Exemplar) *metricsv1.Exemplar {
+	result := &metricsv1.Exemplar{
+		Value: &metricsv1.Exemplar_AsDouble{AsDouble: exemplar.GetValue()},
+	}
+	if exemplar.Timestamp != nil {
+		result.TimeUnixNano = uint64(exemplar.Timestamp.AsTime().UnixNano())
+	}
+	for _, label := range exemplar.Label {
+		switch label.GetName() {
+		case traceIDLabel:
+			if id, err := hex.DecodeString(label.GetValue()); err == nil && len(id) == 16 {
+				result.TraceId = id
+				continue
+			}
+		case spanIDLabel:
+			if id, err := hex.DecodeString(label.GetValue()); err == nil && len(id) == 8 {
+				result.SpanId = id
+				continue
+			}
+		}
+		result.FilteredAttributes = append(result.FilteredAttributes, stringKeyValue(label.GetName(), label.GetValue()))
+	}
+	return result
+}
+
+func timestamp(m *dto.Metric, now uint64) uint64 {
+	if m.TimestampMs != nil {
+		return uint64(m.GetTimestampMs()) * uint64(time.Millisecond)
+	}
+	return now
+}
+
+func labelsToAttributes(labels []*dto.LabelPair) []*commonv1.KeyValue {
+	if len(labels) == 0 {
+		return nil
+	}
+	attributes := make([]*commonv1.KeyValue, 0, len(labels))
+	for _, label := range labels {
+		attributes = append(attributes, stringKeyValue(label.GetName(), label.GetValue()))
+	}
+	return attributes
+}
+
+func stringKeyValue(key, value string) *commonv1.KeyValue {
+	return &commonv1.KeyValue{
+		Key:   key,
+		Value: &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: value}},
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	metricsv1 "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/proto"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint    string
+		expectedURL string
+		expectedErr string
+	}{
+		{endpoint: "http://otel-collector:4318", expectedURL: "http://otel-collector:4318/v1/metrics"},
+		{endpoint: "https://otel-collector:4318/", expectedURL: "https://otel-collector:4318/v1/metrics"},
+		{endpoint: "https://otel.example.com/custom/metrics", expectedURL: "https://otel.example.com/custom/metrics"},
+		{endpoint: "otel-collector:4318", expectedErr: "scheme must be http or https"},
+		{endpoint: "grpc://otel-collector:4317", expectedErr: "scheme must be http or https"},
+		{endpoint: "http:///v1/metrics", expectedErr: "host must be set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			u, err := ParseEndpoint(tt.endpoint)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedURL, u)
+			}
+		})
+	}
+}
+
+func getMetric(t *testing.T, data *metricsv1.MetricsData, name string) *metricsv1.Metric {
+	require.Len(t, data.ResourceMetrics, 1)
+	require.Len(t, data.ResourceMetrics[0].ScopeMetrics, 1)
+	for _, metric := range data.ResourceMetrics[0].ScopeMetrics[0].Metrics {
+		if metric.Name == name {
+			return metric
+		}
+	}
+	t.Fatalf("Metric %s not found", name)
+	return nil
+}
+
+func stringAttributes(attributes []*commonv1.KeyValue) map[string]string {
+	result := make(map[string]string)
+	for _, kv := range attributes {
+		result[kv.Key] = kv.Value.GetStringValue()
+	}
+	return result
+}
+
+func TestExport(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_counter", Help: "Test counter"}, []string{"direction"})
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_gauge", Help: "Test gauge"})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_histogram", Help: "Test histogram", Buckets: []float64{1, 5}})
+	summary := prometheus.NewSummary(prometheus.SummaryOpts{Name: "test_summary", Help: "Test summary", Objectives: map[float64]float64{0.5: 0.05}})
+	registry.MustRegister(counter, gauge, histogram, summary)
+
+	counter.WithLabelValues("ingress").Add(3)
+	gauge.Set(10)
+	histogram.Observe(0.5)
+	histogram.Observe(2)
+	histogram.(prometheus.ExemplarObserver).ObserveWithExemplar(7, prometheus.Labels{
+		"trace_id": "0102030405060708090a0b0c0d0e0f10",
+		"span_id":  "0102030405060708",
+		"user":     "foo",
+	})
+	summary.Observe(4)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0600))
+
+	var receivedRequest *http.Request
+	var receivedData *metricsv1.MetricsData
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedRequest = r
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		receivedData = &metricsv1.MetricsData{}
+		require.NoError(t, proto.Unmarshal(body, receivedData))
+	}))
+	defer server.Close()
+
+	startTime := time.Unix(1000, 0)
+	fakeClock := clocktesting.NewFakeClock(startTime)
+	url, err := ParseEndpoint(server.URL)
+	require.NoError(t, err)
+	exporter := newExporter(url, "node1", registry, &Config{
+		Headers:         map[string]string{"X-Api-Key": "key"},
+		BearerTokenFile: tokenFile,
+	}, server.Client(), fakeClock)
+	fakeClock.Step(time.Minute)
+	require.NoError(t, exporter.export(context.TODO()))
+
+	require.NotNil(t, receivedRequest)
+	assert.Equal(t, "/v1/metrics", receivedRequest.URL.Path)
+	assert.Equal(t, "application/x-protobuf", receivedRequest.Header.Get("Content-Type"))
+	assert.Equal(t, "key", receivedRequest.Header.Get("X-Api-Key"))
+	assert.Equal(t, "Bearer secret", receivedRequest.Header.Get("Authorization"))
+
+	require.Len(t, receivedData.ResourceMetrics, 1)
+	resourceAttributes := stringAttributes(receivedData.ResourceMetrics[0].Resource.Attributes)
+	assert.Equal(t, "antrea-agent", resourceAttributes["service.name"])
+	assert.Equal(t, "node1", resourceAttributes["k8s.node.name"])
+
+	start := uint64(startTime.UnixNano())
+	now := uint64(startTime.Add(time.Minute).UnixNano())
+
+	sum := getMetric(t, receivedData, "test_counter").GetSum()
+	require.NotNil(t, sum)
+	assert.True(t, sum.IsMonotonic)
+	assert.Equal(t, metricsv1.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, sum.AggregationTemporality)
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, 3.0, sum.DataPoints[0].GetAsDouble())
+	assert.Equal(t, map[string]string{"direction": "ingress"}, stringAttributes(sum.DataPoints[0].Attributes))
+	assert.Equal(t, start, sum.DataPoints[0].StartTimeUnixNano)
+	assert.Equal(t, now, sum.DataPoints[0].TimeUnixNano)
+
+	gaugeMetric := getMetric(t, receivedData, "test_gauge")
+	assert.Equal(t, "Test gauge", gaugeMetric.Description)
+	require.Len(t, gaugeMetric.GetGauge().GetDataPoints(), 1)
+	assert.Equal(t, 10.0, gaugeMetric.GetGauge().DataPoints[0].GetAsDouble())
+
+	histogramPoints := getMetric(t, receivedData, "test_histogram").GetHistogram().GetDataPoints()
+	require.Len(t, histogramPoints, 1)
+	assert.Equal(t, uint64(3), histogramPoints[0].Count)
+	assert.Equal(t, 9.5, histogramPoints[0].GetSum())
+	assert.Equal(t, []float64{1, 5}, histogramPoints[0].ExplicitBounds)
+	assert.Equal(t, []uint64{1, 1, 1}, histogramPoints[0].BucketCounts)
+	require.Len(t, histogramPoints[0].Exemplars, 1)
+	exemplar := histogramPoints[0].Exemplars[0]
+	assert.Equal(t, 7.0, exemplar.GetAsDouble())
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}, exemplar.TraceId)
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8}, exemplar.SpanId)
+	assert.Equal(t, map[string]string{"user": "foo"}, stringAttributes(exemplar.FilteredAttributes))
+
+	summaryPoints := getMetric(t, receivedData, "test_summary").GetSummary().GetDataPoints()
+	require.Len(t, summaryPoints, 1)
+	assert.Equal(t, uint64(1), summaryPoints[0].Count)
+	assert.Equal(t, 4.0, summaryPoints[0].Sum)
+	require.Len(t, summaryPoints[0].QuantileValues, 1)
+	assert.Equal(t, 0.5, summaryPoints[0].QuantileValues[0].Quantile)
+}
+
+func TestExportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	url, err := ParseEndpoint(server.URL)
+	require.NoError(t, err)
+	exporter := newExporter(url, "node1", prometheus.NewRegistry(), &Config{}, server.Client(), clocktesting.NewFakeClock(time.Now()))
+	assert.ErrorContains(t, exporter.export(context.TODO()), "401 Unauthorized")
+}
@@ -46,6 +46,49 @@ var (
 		},
 	)
 
+	UnsupportedNetworkPolicyRuleCount = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "unsupported_networkpolicy_rule_count",
+			Help:           "Number of NetworkPolicy rules received from the Antrea Controller which are not enforced because they require NetworkPolicy features not supported by the Antrea Agent, partitioned by the unsupported feature.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"feature"},
+	)
+
+	NetworkPolicyAsyncDeletePendingRuleCount = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "networkpolicy_async_delete_pending_rule_count",
+			Help:           "Number of deleted NetworkPolicy rules which are kept by the Antrea Agent until their asynchronous deletion, to map the flows of existing connections to them.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	NetworkPolicyAsyncDeleteExpiredRuleCount = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "networkpolicy_async_delete_expired_rule_count",
+			Help:           "Number of deleted NetworkPolicy rules which were forcibly expired before their asynchronous deletion.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	NetworkPolicyAddressUpdateSize = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "networkpolicy_address_update_size",
+			Help:           "Number of addresses added to or deleted from a NetworkPolicy rule per incremental update, partitioned by operation type (add and delete).",
+			Buckets:        metrics.ExponentialBuckets(1, 4, 8),
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"operation"},
+	)
+
 	PodCount = metrics.NewGauge(
 		&metrics.GaugeOpts{
 			Namespace:      metricNamespaceAntrea,
@@ -83,6 +126,30 @@ var (
 		StabilityLevel: metrics.STABLE,
 	}, []string{"table_id", "table_name"})
 
+	OVSGroupCount = metrics.NewGaugeVec(&metrics.GaugeOpts{
+		Namespace:      metricNamespaceAntrea,
+		Subsystem:      metricSubsystemAgent,
+		Name:           "ovs_group_count",
+		Help:           "Number of OVS groups installed by the Agent, partitioned by the feature which installed them.",
+		StabilityLevel: metrics.ALPHA,
+	}, []string{"feature"})
+
+	OVSMeterCount = metrics.NewGauge(&metrics.GaugeOpts{
+		Namespace:      metricNamespaceAntrea,
+		Subsystem:      metricSubsystemAgent,
+		Name:           "ovs_meter_count",
+		Help:           "Number of OVS meters installed by the Agent.",
+		StabilityLevel: metrics.ALPHA,
+	})
+
+	OVSSoftLimitExceeded = metrics.NewGaugeVec(&metrics.GaugeOpts{
+		Namespace:      metricNamespaceAntrea,
+		Subsystem:      metricSubsystemAgent,
+		Name:           "ovs_soft_limit_exceeded",
+		Help:           "Whether the number of OVS entries installed by the Agent exceeds the configured soft limit, partitioned by resource (flows_per_table, groups, meters). 1 means exceeded, 0 means normal.",
+		StabilityLevel: metrics.ALPHA,
+	}, []string{"resource"})
+
 	OVSFlowOpsCount = metrics.NewCounterVec(
 		&metrics.CounterOpts{
 			Namespace:      metricNamespaceAntrea,
@@ -156,6 +223,39 @@ var (
 		},
 	)
 
+	ReconcileSchedulerWaitDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "reconcile_scheduler_wait_duration_milliseconds",
+			Help:           "The time reconcile operations wait in the scheduler queue before being executed, partitioned by feature.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"feature"},
+	)
+
+	ReconcileSchedulerQueueLength = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "reconcile_scheduler_queue_length",
+			Help:           "Number of reconcile operations waiting in the scheduler queue, partitioned by feature.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"feature"},
+	)
+
+	ReconcileSchedulerStarvationCount = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "reconcile_scheduler_starvation_count",
+			Help:           "Number of reconcile operations executed ahead of their turn because they exceeded the starvation timeout, partitioned by feature.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"feature"},
+	)
+
 	MaxConnectionsInConnTrackTable = metrics.NewGauge(
 		&metrics.GaugeOpts{
 			Namespace:      metricNamespaceAntrea,
@@ -165,6 +265,170 @@ var (
 			StabilityLevel: metrics.ALPHA,
 		},
 	)
+
+	WatchdogDegraded = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "watchdog_degraded",
+			Help:           "Whether the Agent is in the degraded state because a resource monitored by the watchdog exceeded its threshold. 1 means degraded, 0 means normal.",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
+
+	WatchdogThresholdExceededCount = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "watchdog_threshold_exceeded_count",
+			Help:           "Number of times the Agent entered the degraded state, partitioned by the resource which exceeded its threshold (memory, flow_count, queue_depth).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"resource"},
+	)
+
+	OVSOrphanedEntryCount = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "ovs_orphaned_entry_count",
+			Help:           "Number of OVS entries installed by the Agent which were not expected by it anymore during the last garbage collection, partitioned by entry type (flow, group).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"type"},
+	)
+
+	OVSOrphanedEntryDeletedCount = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "ovs_orphaned_entry_deleted_count",
+			Help:           "Number of orphaned OVS entries deleted by the garbage collector of the Agent, partitioned by entry type (flow, group).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"type"},
+	)
+
+	FeatureBusySeconds = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "feature_busy_seconds",
+			Help:           "Time spent by the goroutines of the Agent processing work for a feature, partitioned by feature (proxy, networkpolicy, flowexporter, packetin).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"feature"},
+	)
+
+	FeatureCPUSecondsEstimate = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "feature_cpu_seconds_estimate",
+			Help:           "Estimated CPU time consumed by the Agent for a feature, partitioned by feature (proxy, networkpolicy, flowexporter, packetin, other).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"feature"},
+	)
+
+	OVSDatapathFlowCount = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "ovs_datapath_flow_count",
+			Help:           "Number of OVS datapath flows when OVS hardware offload is enabled, partitioned by flow category (the type of the input port: pod, gateway, tunnel, uplink, other) and by whether the flows are offloaded to hardware.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"category", "offloaded"},
+	)
+
+	OVSAFXDPInterfaceCount = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "ovs_afxdp_interface_count",
+			Help:           "Number of OVS AF_XDP interfaces when the netdev datapath is used, partitioned by interface category (pod, uplink, other).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"category"},
+	)
+
+	OVSAFXDPInterfaceStatistics = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "ovs_afxdp_interface_statistics",
+			Help:           "Sum of the statistics of the OVS AF_XDP interfaces when the netdev datapath is used, partitioned by interface category (pod, uplink, other) and statistic (e.g. rx_packets, tx_dropped).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"category", "statistic"},
+	)
+
+	NodeLatencyRTT = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "node_latency_rtt_seconds",
+			Help:           "Round-trip time of the last answered probe sent to a peer Node IP by the Node latency monitor, partitioned by peer Node, target IP and network (tunnel or underlay).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"peer_node", "target_ip", "network"},
+	)
+
+	NodeLatencyPacketLossRatio = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "node_latency_packet_loss_ratio",
+			Help:           "Ratio of the recent probes sent to a peer Node IP by the Node latency monitor which were not answered, partitioned by peer Node, target IP and network (tunnel or underlay).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"peer_node", "target_ip", "network"},
+	)
+
+	PodMulticastPacketCount = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "pod_multicast_packet_count",
+			Help:           "Number of multicast packets sent or received by each local Pod, partitioned by Pod Namespace, Pod name and direction (ingress or egress).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"pod_namespace", "pod_name", "direction"},
+	)
+
+	PodMulticastByteCount = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "pod_multicast_byte_count",
+			Help:           "Number of multicast bytes sent or received by each local Pod, partitioned by Pod Namespace, Pod name and direction (ingress or egress).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"pod_namespace", "pod_name", "direction"},
+	)
+
+	L7EngineRuleLoadFailureCount = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "l7_engine_rule_load_failure_count",
+			Help:           "Number of times the L7 engine failed to load the Layer 7 rules of a NetworkPolicy rule, partitioned by fail mode (open or closed).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"fail_mode"},
+	)
+
+	L7EngineFallbackRuleCount = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemAgent,
+			Name:           "l7_engine_fallback_rule_count",
+			Help:           "Number of NetworkPolicy rules whose Layer 7 rules failed to load and are enforced by the fallback rules of their fail mode, partitioned by fail mode (open or closed).",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"fail_mode"},
+	)
 )
 
 func InitializePrometheusMetrics() {
@@ -176,6 +440,103 @@ func InitializePrometheusMetrics() {
 	InitializeConnectionMetrics()
 }
 
+// InitializeReconcileSchedulerMetrics registers the metrics of the reconcile
+// scheduler. It is only called when the scheduler is enabled.
+func InitializeReconcileSchedulerMetrics() {
+	if err := legacyregistry.Register(ReconcileSchedulerWaitDuration); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_reconcile_scheduler_wait_duration_milliseconds")
+	}
+	if err := legacyregistry.Register(ReconcileSchedulerQueueLength); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_reconcile_scheduler_queue_length")
+	}
+	if err := legacyregistry.Register(ReconcileSchedulerStarvationCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_reconcile_scheduler_starvation_count")
+	}
+}
+
+// InitializeWatchdogMetrics registers the metrics of the watchdog. It is only
+// called when the watchdog is enabled.
+func InitializeWatchdogMetrics() {
+	if err := legacyregistry.Register(WatchdogDegraded); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_watchdog_degraded")
+	}
+	if err := legacyregistry.Register(WatchdogThresholdExceededCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_watchdog_threshold_exceeded_count")
+	}
+}
+
+// InitializeOVSOffloadMetrics registers the metrics of the OVS hardware offload
+// monitor. It is only called when OVS hardware offload is enabled.
+func InitializeFlowGCMetrics() {
+	if err := legacyregistry.Register(OVSOrphanedEntryCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_ovs_orphaned_entry_count")
+	}
+	if err := legacyregistry.Register(OVSOrphanedEntryDeletedCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_ovs_orphaned_entry_deleted_count")
+	}
+}
+
+// InitializeCPUAccountingMetrics registers the metrics of the per-feature CPU
+// accounting.
+func InitializeCPUAccountingMetrics() {
+	if err := legacyregistry.Register(FeatureBusySeconds); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_feature_busy_seconds")
+	}
+	if err := legacyregistry.Register(FeatureCPUSecondsEstimate); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_feature_cpu_seconds_estimate")
+	}
+}
+
+func InitializeOVSOffloadMetrics() {
+	if err := legacyregistry.Register(OVSDatapathFlowCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_ovs_datapath_flow_count")
+	}
+}
+
+// InitializeAFXDPMetrics registers the metrics of the AF_XDP interface monitor.
+// It is only called when the OVS netdev datapath is used.
+func InitializeAFXDPMetrics() {
+	if err := legacyregistry.Register(OVSAFXDPInterfaceCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_ovs_afxdp_interface_count")
+	}
+	if err := legacyregistry.Register(OVSAFXDPInterfaceStatistics); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_ovs_afxdp_interface_statistics")
+	}
+}
+
+// InitializeNodeLatencyMetrics registers the metrics of the Node latency
+// monitor. It is only called when the NodeLatencyMonitor feature is enabled.
+func InitializeNodeLatencyMetrics() {
+	if err := legacyregistry.Register(NodeLatencyRTT); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_node_latency_rtt_seconds")
+	}
+	if err := legacyregistry.Register(NodeLatencyPacketLossRatio); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_node_latency_packet_loss_ratio")
+	}
+}
+
+// InitializeMulticastMetrics registers the metrics of the multicast traffic of
+// the local Pods. It is only called when the Multicast feature is enabled.
+func InitializeMulticastMetrics() {
+	if err := legacyregistry.Register(PodMulticastPacketCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_pod_multicast_packet_count")
+	}
+	if err := legacyregistry.Register(PodMulticastByteCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_pod_multicast_byte_count")
+	}
+}
+
+// InitializeL7EngineMetrics registers the metrics of the L7 engine. It is only
+// called when the L7NetworkPolicy feature is enabled.
+func InitializeL7EngineMetrics() {
+	if err := legacyregistry.Register(L7EngineRuleLoadFailureCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_l7_engine_rule_load_failure_count")
+	}
+	if err := legacyregistry.Register(L7EngineFallbackRuleCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_l7_engine_fallback_rule_count")
+	}
+}
+
 func InitializePodMetrics() {
 	if err := legacyregistry.Register(PodCount); err != nil {
 		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_local_pod_count")
@@ -194,6 +555,22 @@ func InitializeNetworkPolicyMetrics() {
 	if err := legacyregistry.Register(NetworkPolicyCount); err != nil {
 		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_networkpolicy_count")
 	}
+
+	if err := legacyregistry.Register(UnsupportedNetworkPolicyRuleCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_unsupported_networkpolicy_rule_count")
+	}
+
+	if err := legacyregistry.Register(NetworkPolicyAsyncDeletePendingRuleCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_networkpolicy_async_delete_pending_rule_count")
+	}
+
+	if err := legacyregistry.Register(NetworkPolicyAsyncDeleteExpiredRuleCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_networkpolicy_async_delete_expired_rule_count")
+	}
+
+	if err := legacyregistry.Register(NetworkPolicyAddressUpdateSize); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_networkpolicy_address_update_size")
+	}
 }
 
 func InitializeOVSMetrics() {
@@ -203,6 +580,15 @@ func InitializeOVSMetrics() {
 	if err := legacyregistry.Register(OVSFlowCount); err != nil {
 		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_ovs_flow_count")
 	}
+	if err := legacyregistry.Register(OVSGroupCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_ovs_group_count")
+	}
+	if err := legacyregistry.Register(OVSMeterCount); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_ovs_meter_count")
+	}
+	if err := legacyregistry.Register(OVSSoftLimitExceeded); err != nil {
+		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_ovs_soft_limit_exceeded")
+	}
 
 	if err := legacyregistry.Register(OVSFlowOpsCount); err != nil {
 		klog.ErrorS(err, "Failed to register metrics with Prometheus", "metrics", "antrea_agent_ovs_flow_ops_count")
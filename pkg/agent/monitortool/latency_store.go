@@ -0,0 +1,188 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitortool
+
+import (
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	crdv1beta1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
+)
+
+// lossWindowSize is the number of the most recent probes of a target from which its packet loss ratio is computed.
+const lossWindowSize = 10
+
+// probeTarget is an IP of a peer Node which is probed.
+type probeTarget struct {
+	nodeName string
+	ip       net.IP
+	network  crdv1beta1.NodeLatencyNetwork
+}
+
+type targetStats struct {
+	nodeName     string
+	network      crdv1beta1.NodeLatencyNetwork
+	lastSendTime time.Time
+	lastRecvTime time.Time
+	lastRTT      time.Duration
+	// lastSeq is the sequence number of the last probe sent to the target, and pending tells whether it's still
+	// waiting for a reply.
+	lastSeq uint16
+	pending bool
+	// results stores whether each of the recent probes was answered, with at most lossWindowSize items.
+	results []bool
+}
+
+func (s *targetStats) addResult(answered bool) {
+	if len(s.results) == lossWindowSize {
+		s.results = s.results[1:]
+	}
+	s.results = append(s.results, answered)
+}
+
+// lossRatio returns the ratio of the recent probes which were not answered. The last probe is not counted as long
+// as it's pending, as its reply may still arrive.
+func (s *targetStats) lossRatio() float64 {
+	if len(s.results) == 0 {
+		return 0
+	}
+	lost := 0
+	for _, answered := range s.results {
+		if !answered {
+			lost++
+		}
+	}
+	return float64(lost) / float64(len(s.results))
+}
+
+// latencyStore stores the statistics of the probed IPs of the peer Nodes. It's accessed by the goroutine sending
+// the probes, the goroutines receiving the replies and the querier.
+type latencyStore struct {
+	mutex sync.RWMutex
+	// targets maps a probed IP (as a string) to its statistics.
+	targets map[string]*targetStats
+}
+
+func newLatencyStore() *latencyStore {
+	return &latencyStore{
+		targets: map[string]*targetStats{},
+	}
+}
+
+// syncTargets replaces the probed IPs with the provided ones, which are keyed by the IP as a string. The statistics
+// of the IPs which are still probed are kept. It returns the removed IPs, so that their metrics can be deleted.
+func (s *latencyStore) syncTargets(targets map[string]probeTarget) []probeTarget {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	var removed []probeTarget
+	for ipStr, stats := range s.targets {
+		target, exists := targets[ipStr]
+		if exists && target.nodeName == stats.nodeName && target.network == stats.network {
+			continue
+		}
+		removed = append(removed, probeTarget{nodeName: stats.nodeName, ip: net.ParseIP(ipStr), network: stats.network})
+		delete(s.targets, ipStr)
+	}
+	for ipStr, target := range targets {
+		if _, exists := s.targets[ipStr]; !exists {
+			s.targets[ipStr] = &targetStats{nodeName: target.nodeName, network: target.network}
+		}
+	}
+	return removed
+}
+
+// recordSend records that a probe with the given sequence number is sent to the IP. If the previous probe is still
+// pending, it's considered lost. It returns the Node and the network of the IP, and its updated packet loss ratio.
+func (s *latencyStore) recordSend(ip net.IP, seq uint16, sendTime time.Time) (probeTarget, float64, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats, exists := s.targets[ip.String()]
+	if !exists {
+		return probeTarget{}, 0, false
+	}
+	if stats.pending {
+		stats.addResult(false)
+	}
+	stats.lastSeq = seq
+	stats.pending = true
+	stats.lastSendTime = sendTime
+	return probeTarget{nodeName: stats.nodeName, ip: ip, network: stats.network}, stats.lossRatio(), true
+}
+
+// recordReply records that a reply with the given sequence number is received from the IP. Replies which don't
+// match the pending probe of the IP, e.g. late replies of previous probes, are ignored. It returns the Node and the
+// network of the IP, and the measured round-trip time.
+func (s *latencyStore) recordReply(ip net.IP, seq uint16, recvTime time.Time) (probeTarget, time.Duration, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats, exists := s.targets[ip.String()]
+	if !exists || !stats.pending || stats.lastSeq != seq {
+		return probeTarget{}, 0, false
+	}
+	stats.addResult(true)
+	stats.pending = false
+	stats.lastRecvTime = recvTime
+	stats.lastRTT = recvTime.Sub(stats.lastSendTime)
+	return probeTarget{nodeName: stats.nodeName, ip: ip, network: stats.network}, stats.lastRTT, true
+}
+
+// listTargets returns the probed IPs.
+func (s *latencyStore) listTargets() []probeTarget {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	targets := make([]probeTarget, 0, len(s.targets))
+	for ipStr, stats := range s.targets {
+		targets = append(targets, probeTarget{nodeName: stats.nodeName, ip: net.ParseIP(ipStr), network: stats.network})
+	}
+	return targets
+}
+
+// getNodeLatencyStats returns the statistics of the probed IPs, grouped by peer Node. Both the Nodes and their IPs
+// are sorted, so that the result only changes when the statistics change.
+func (s *latencyStore) getNodeLatencyStats() []crdv1beta1.PeerNodeLatencyStats {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	nodeStats := map[string][]crdv1beta1.TargetIPLatencyStats{}
+	for ipStr, stats := range s.targets {
+		targetIPStats := crdv1beta1.TargetIPLatencyStats{
+			TargetIP:          ipStr,
+			Network:           stats.network,
+			PacketLossPercent: int32(stats.lossRatio()*100 + 0.5),
+		}
+		if !stats.lastSendTime.IsZero() {
+			targetIPStats.LastSendTime = metav1.NewTime(stats.lastSendTime)
+		}
+		if !stats.lastRecvTime.IsZero() {
+			targetIPStats.LastRecvTime = metav1.NewTime(stats.lastRecvTime)
+			targetIPStats.LastMeasuredRTTNanoseconds = stats.lastRTT.Nanoseconds()
+		}
+		nodeStats[stats.nodeName] = append(nodeStats[stats.nodeName], targetIPStats)
+	}
+	result := make([]crdv1beta1.PeerNodeLatencyStats, 0, len(nodeStats))
+	for nodeName, targetIPStats := range nodeStats {
+		sort.Slice(targetIPStats, func(i, j int) bool {
+			return targetIPStats[i].TargetIP < targetIPStats[j].TargetIP
+		})
+		result = append(result, crdv1beta1.PeerNodeLatencyStats{NodeName: nodeName, TargetIPLatencyStats: targetIPStats})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NodeName < result[j].NodeName
+	})
+	return result
+}
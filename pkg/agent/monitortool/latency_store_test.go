@@ -0,0 +1,139 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitortool
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	crdv1beta1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
+)
+
+var (
+	node2TransportIP = net.ParseIP("192.168.0.2")
+	node2GatewayIP   = net.ParseIP("10.10.1.1")
+	node3TransportIP = net.ParseIP("192.168.0.3")
+)
+
+func TestLatencyStoreSyncTargets(t *testing.T) {
+	s := newLatencyStore()
+	removed := s.syncTargets(map[string]probeTarget{
+		node2TransportIP.String(): {nodeName: "node2", ip: node2TransportIP, network: crdv1beta1.NodeLatencyNetworkUnderlay},
+		node2GatewayIP.String():   {nodeName: "node2", ip: node2GatewayIP, network: crdv1beta1.NodeLatencyNetworkTunnel},
+	})
+	assert.Empty(t, removed)
+	assert.ElementsMatch(t, []probeTarget{
+		{nodeName: "node2", ip: node2TransportIP, network: crdv1beta1.NodeLatencyNetworkUnderlay},
+		{nodeName: "node2", ip: node2GatewayIP, network: crdv1beta1.NodeLatencyNetworkTunnel},
+	}, s.listTargets())
+
+	sendTime := time.Now()
+	_, _, ok := s.recordSend(node2TransportIP, 1, sendTime)
+	assert.True(t, ok)
+
+	// The transport IP is moved to another Node, so its statistics are reset.
+	removed = s.syncTargets(map[string]probeTarget{
+		node2TransportIP.String(): {nodeName: "node3", ip: node2TransportIP, network: crdv1beta1.NodeLatencyNetworkUnderlay},
+		node2GatewayIP.String():   {nodeName: "node2", ip: node2GatewayIP, network: crdv1beta1.NodeLatencyNetworkTunnel},
+	})
+	assert.Equal(t, []probeTarget{{nodeName: "node2", ip: node2TransportIP, network: crdv1beta1.NodeLatencyNetworkUnderlay}}, removed)
+	assert.True(t, s.targets[node2TransportIP.String()].lastSendTime.IsZero())
+
+	removed = s.syncTargets(map[string]probeTarget{})
+	assert.Len(t, removed, 2)
+	assert.Empty(t, s.listTargets())
+}
+
+func TestLatencyStoreRecordProbes(t *testing.T) {
+	s := newLatencyStore()
+	s.syncTargets(map[string]probeTarget{
+		node2TransportIP.String(): {nodeName: "node2", ip: node2TransportIP, network: crdv1beta1.NodeLatencyNetworkUnderlay},
+		node3TransportIP.String(): {nodeName: "node3", ip: node3TransportIP, network: crdv1beta1.NodeLatencyNetworkUnderlay},
+	})
+	startTime := time.Now()
+
+	// Probes sent to unknown IPs are ignored.
+	_, _, ok := s.recordSend(node2GatewayIP, 1, startTime)
+	assert.False(t, ok)
+
+	// The first probe of node2 is answered, the first probe of node3 is not.
+	target, lossRatio, ok := s.recordSend(node2TransportIP, 1, startTime)
+	assert.True(t, ok)
+	assert.Equal(t, "node2", target.nodeName)
+	assert.Equal(t, float64(0), lossRatio)
+	_, _, ok = s.recordSend(node3TransportIP, 1, startTime)
+	assert.True(t, ok)
+	target, rtt, ok := s.recordReply(node2TransportIP, 1, startTime.Add(2*time.Millisecond))
+	assert.True(t, ok)
+	assert.Equal(t, crdv1beta1.NodeLatencyNetworkUnderlay, target.network)
+	assert.Equal(t, 2*time.Millisecond, rtt)
+	// Duplicate replies are ignored.
+	_, _, ok = s.recordReply(node2TransportIP, 1, startTime.Add(3*time.Millisecond))
+	assert.False(t, ok)
+
+	// The second round of probes: the unanswered probe of node3 is counted as lost, and its late reply is ignored.
+	secondTime := startTime.Add(time.Minute)
+	_, lossRatio, _ = s.recordSend(node2TransportIP, 2, secondTime)
+	assert.Equal(t, float64(0), lossRatio)
+	_, lossRatio, _ = s.recordSend(node3TransportIP, 2, secondTime)
+	assert.Equal(t, float64(1), lossRatio)
+	_, _, ok = s.recordReply(node3TransportIP, 1, secondTime.Add(time.Millisecond))
+	assert.False(t, ok)
+	_, rtt, ok = s.recordReply(node3TransportIP, 2, secondTime.Add(5*time.Millisecond))
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Millisecond, rtt)
+
+	assert.Equal(t, []crdv1beta1.PeerNodeLatencyStats{
+		{
+			NodeName: "node2",
+			TargetIPLatencyStats: []crdv1beta1.TargetIPLatencyStats{{
+				TargetIP:                   node2TransportIP.String(),
+				Network:                    crdv1beta1.NodeLatencyNetworkUnderlay,
+				LastSendTime:               metav1.NewTime(secondTime),
+				LastRecvTime:               metav1.NewTime(startTime.Add(2 * time.Millisecond)),
+				LastMeasuredRTTNanoseconds: (2 * time.Millisecond).Nanoseconds(),
+			}},
+		},
+		{
+			NodeName: "node3",
+			TargetIPLatencyStats: []crdv1beta1.TargetIPLatencyStats{{
+				TargetIP:                   node3TransportIP.String(),
+				Network:                    crdv1beta1.NodeLatencyNetworkUnderlay,
+				LastSendTime:               metav1.NewTime(secondTime),
+				LastRecvTime:               metav1.NewTime(secondTime.Add(5 * time.Millisecond)),
+				LastMeasuredRTTNanoseconds: (5 * time.Millisecond).Nanoseconds(),
+				PacketLossPercent:          50,
+			}},
+		},
+	}, s.getNodeLatencyStats())
+}
+
+func TestTargetStatsLossRatioWindow(t *testing.T) {
+	stats := &targetStats{}
+	for i := 0; i < lossWindowSize; i++ {
+		stats.addResult(false)
+	}
+	assert.Equal(t, float64(1), stats.lossRatio())
+	// Older results are dropped from the window.
+	for i := 0; i < lossWindowSize/2; i++ {
+		stats.addResult(true)
+	}
+	assert.Len(t, stats.results, lossWindowSize)
+	assert.Equal(t, 0.5, stats.lossRatio())
+}
@@ -0,0 +1,305 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package monitortool probes the other Nodes of the cluster periodically with ICMP echo requests, to measure the
+// latency and the packet loss of the underlay network, by probing the transport IPs of the Nodes, and of the tunnel,
+// by probing the gateway IPs of the Nodes. This helps correlating application issues with network degradation.
+package monitortool
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/containernetworking/plugins/pkg/ip"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/metrics"
+	crdv1beta1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
+	"antrea.io/antrea/pkg/util/k8s"
+)
+
+const (
+	controllerName = "NodeLatencyMonitor"
+
+	DefaultPingInterval = 60 * time.Second
+
+	protocolICMP     = 1
+	protocolICMPIPv6 = 58
+)
+
+// probePayload is the payload of the ICMP echo requests, which helps identifying them in packet captures.
+var probePayload = []byte("antrea-node-latency-monitor")
+
+// Querier provides the latency statistics of the peer Nodes.
+type Querier interface {
+	GetNodeLatencyStats() []crdv1beta1.PeerNodeLatencyStats
+}
+
+// packetConn is the subset of *icmp.PacketConn used by NodeLatencyMonitor, which can be faked in tests.
+type packetConn interface {
+	ReadFrom(b []byte) (int, net.Addr, error)
+	WriteTo(b []byte, dst net.Addr) (int, error)
+	Close() error
+}
+
+func listenICMP(network, address string) (packetConn, error) {
+	return icmp.ListenPacket(network, address)
+}
+
+// NodeLatencyMonitor sends an ICMP echo request to each probed IP of the peer Nodes every pingInterval. The
+// round-trip time of a probe is measured when its reply is received, while a probe is considered lost if no reply
+// is received before the next probe is sent.
+type NodeLatencyMonitor struct {
+	nodeName         string
+	nodeLister       corelisters.NodeLister
+	nodeListerSynced cache.InformerSynced
+	isIPv4Enabled    bool
+	isIPv6Enabled    bool
+	// probeTunnel tells whether the gateway IPs of the peer Nodes are probed, which is only meaningful when the
+	// traffic between Pod CIDRs is encapsulated.
+	probeTunnel   bool
+	pingInterval  time.Duration
+	enableMetrics bool
+
+	// icmpID identifies the echo requests sent by this monitor, as all echo replies received by the host are
+	// delivered to the raw sockets.
+	icmpID int
+	// seq is the sequence number of the current round of probes. It's only accessed by the goroutine sending the
+	// probes, so no lock is needed.
+	seq        uint16
+	store      *latencyStore
+	listenFunc func(network, address string) (packetConn, error)
+	ipv4Conn   packetConn
+	ipv6Conn   packetConn
+}
+
+// NewNodeLatencyMonitor creates a NodeLatencyMonitor. If pingInterval is 0, DefaultPingInterval is used.
+func NewNodeLatencyMonitor(nodeName string,
+	nodeInformer coreinformers.NodeInformer,
+	isIPv4Enabled bool,
+	isIPv6Enabled bool,
+	probeTunnel bool,
+	pingInterval time.Duration,
+	enableMetrics bool) *NodeLatencyMonitor {
+	if pingInterval == 0 {
+		pingInterval = DefaultPingInterval
+	}
+	return &NodeLatencyMonitor{
+		nodeName:         nodeName,
+		nodeLister:       nodeInformer.Lister(),
+		nodeListerSynced: nodeInformer.Informer().HasSynced,
+		isIPv4Enabled:    isIPv4Enabled,
+		isIPv6Enabled:    isIPv6Enabled,
+		probeTunnel:      probeTunnel,
+		pingInterval:     pingInterval,
+		enableMetrics:    enableMetrics,
+		icmpID:           rand.Intn(0xffff) + 1,
+		store:            newLatencyStore(),
+		listenFunc:       listenICMP,
+	}
+}
+
+// GetNodeLatencyStats returns the latency statistics of the peer Nodes.
+func (m *NodeLatencyMonitor) GetNodeLatencyStats() []crdv1beta1.PeerNodeLatencyStats {
+	return m.store.getNodeLatencyStats()
+}
+
+func (m *NodeLatencyMonitor) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Starting " + controllerName)
+	defer klog.InfoS("Shutting down " + controllerName)
+
+	if !cache.WaitForNamedCacheSync(controllerName, stopCh, m.nodeListerSynced) {
+		return
+	}
+
+	if m.isIPv4Enabled {
+		conn, err := m.listenFunc("ip4:icmp", "0.0.0.0")
+		if err != nil {
+			klog.ErrorS(err, "Failed to listen for ICMP packets, the IPv4 addresses of the peer Nodes will not be probed")
+		} else {
+			m.ipv4Conn = conn
+			defer conn.Close()
+			go m.receiveReplies(conn, protocolICMP)
+		}
+	}
+	if m.isIPv6Enabled {
+		conn, err := m.listenFunc("ip6:ipv6-icmp", "::")
+		if err != nil {
+			klog.ErrorS(err, "Failed to listen for ICMPv6 packets, the IPv6 addresses of the peer Nodes will not be probed")
+		} else {
+			m.ipv6Conn = conn
+			defer conn.Close()
+			go m.receiveReplies(conn, protocolICMPIPv6)
+		}
+	}
+
+	wait.Until(m.pingAll, m.pingInterval, stopCh)
+}
+
+// getTargets returns the IPs of a peer Node to probe.
+func (m *NodeLatencyMonitor) getTargets(node *corev1.Node) []probeTarget {
+	var targets []probeTarget
+	addTarget := func(targetIP net.IP, network crdv1beta1.NodeLatencyNetwork) {
+		if targetIP == nil {
+			return
+		}
+		if (targetIP.To4() != nil && m.isIPv4Enabled) || (targetIP.To4() == nil && m.isIPv6Enabled) {
+			targets = append(targets, probeTarget{nodeName: node.Name, ip: targetIP, network: network})
+		}
+	}
+	transportAddrs, err := k8s.GetNodeTransportAddrs(node)
+	if err != nil {
+		klog.ErrorS(err, "Failed to get the transport IPs of the peer Node", "node", node.Name)
+	} else {
+		addTarget(transportAddrs.IPv4, crdv1beta1.NodeLatencyNetworkUnderlay)
+		addTarget(transportAddrs.IPv6, crdv1beta1.NodeLatencyNetworkUnderlay)
+	}
+	if m.probeTunnel {
+		podCIDRs := node.Spec.PodCIDRs
+		if len(podCIDRs) == 0 && node.Spec.PodCIDR != "" {
+			podCIDRs = []string{node.Spec.PodCIDR}
+		}
+		for _, podCIDR := range podCIDRs {
+			_, podIPNet, err := net.ParseCIDR(podCIDR)
+			if err != nil {
+				klog.ErrorS(err, "Failed to parse the Pod CIDR of the peer Node", "node", node.Name, "podCIDR", podCIDR)
+				continue
+			}
+			addTarget(ip.NextIP(podIPNet.IP), crdv1beta1.NodeLatencyNetworkTunnel)
+		}
+	}
+	return targets
+}
+
+// syncTargets updates the probed IPs according to the current peer Nodes.
+func (m *NodeLatencyMonitor) syncTargets() error {
+	nodes, err := m.nodeLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	targets := map[string]probeTarget{}
+	for _, node := range nodes {
+		if node.Name == m.nodeName {
+			continue
+		}
+		for _, target := range m.getTargets(node) {
+			targets[target.ip.String()] = target
+		}
+	}
+	removed := m.store.syncTargets(targets)
+	if m.enableMetrics {
+		for _, target := range removed {
+			labelValues := metricLabelValues(target)
+			metrics.NodeLatencyRTT.DeleteLabelValues(labelValues...)
+			metrics.NodeLatencyPacketLossRatio.DeleteLabelValues(labelValues...)
+		}
+	}
+	return nil
+}
+
+// pingAll sends a round of probes to all the probed IPs.
+func (m *NodeLatencyMonitor) pingAll() {
+	if err := m.syncTargets(); err != nil {
+		klog.ErrorS(err, "Failed to list the peer Nodes")
+		return
+	}
+	m.seq++
+	for _, target := range m.store.listTargets() {
+		conn, msgType := m.ipv4Conn, icmp.Type(ipv4.ICMPTypeEcho)
+		if target.ip.To4() == nil {
+			conn, msgType = m.ipv6Conn, ipv6.ICMPTypeEchoRequest
+		}
+		if conn == nil {
+			continue
+		}
+		msg := icmp.Message{
+			Type: msgType,
+			Code: 0,
+			Body: &icmp.Echo{ID: m.icmpID, Seq: int(m.seq), Data: probePayload},
+		}
+		// The checksum of ICMPv6 messages is computed by the kernel.
+		b, err := msg.Marshal(nil)
+		if err != nil {
+			klog.ErrorS(err, "Failed to build the ICMP echo request", "ip", target.ip)
+			continue
+		}
+		// The probe is recorded even if it cannot be sent, so that it's counted as lost.
+		recordedTarget, lossRatio, ok := m.store.recordSend(target.ip, m.seq, time.Now())
+		if !ok {
+			continue
+		}
+		if m.enableMetrics {
+			metrics.NodeLatencyPacketLossRatio.WithLabelValues(metricLabelValues(recordedTarget)...).Set(lossRatio)
+		}
+		if _, err := conn.WriteTo(b, &net.IPAddr{IP: target.ip}); err != nil {
+			klog.V(2).InfoS("Failed to send the ICMP echo request", "node", target.nodeName, "ip", target.ip, "err", err)
+		}
+	}
+}
+
+// receiveReplies reads the ICMP packets from conn until it's closed, and records the echo replies to the probes.
+func (m *NodeLatencyMonitor) receiveReplies(conn packetConn, protocol int) {
+	buf := make([]byte, 1500)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			klog.ErrorS(err, "Failed to read ICMP packet")
+			continue
+		}
+		recvTime := time.Now()
+		msg, err := icmp.ParseMessage(protocol, buf[:n])
+		if err != nil {
+			klog.V(4).InfoS("Failed to parse ICMP packet", "err", err)
+			continue
+		}
+		if msg.Type != ipv4.ICMPTypeEchoReply && msg.Type != ipv6.ICMPTypeEchoReply {
+			continue
+		}
+		echo, ok := msg.Body.(*icmp.Echo)
+		if !ok || echo.ID != m.icmpID {
+			continue
+		}
+		ipAddr, ok := addr.(*net.IPAddr)
+		if !ok {
+			continue
+		}
+		target, rtt, ok := m.store.recordReply(ipAddr.IP, uint16(echo.Seq), recvTime)
+		if !ok {
+			continue
+		}
+		klog.V(4).InfoS("Received ICMP echo reply", "node", target.nodeName, "ip", target.ip, "rtt", rtt)
+		if m.enableMetrics {
+			metrics.NodeLatencyRTT.WithLabelValues(metricLabelValues(target)...).Set(rtt.Seconds())
+		}
+	}
+}
+
+func metricLabelValues(target probeTarget) []string {
+	return []string{target.nodeName, target.ip.String(), strings.ToLower(string(target.network))}
+}
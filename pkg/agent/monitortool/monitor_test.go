@@ -0,0 +1,208 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitortool
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	crdv1beta1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
+)
+
+type fakePacket struct {
+	data []byte
+	addr net.Addr
+}
+
+// fakePacketConn records the written packets, and returns the queued packets when read, then net.ErrClosed.
+type fakePacketConn struct {
+	written []fakePacket
+	toRead  []fakePacket
+}
+
+func (c *fakePacketConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	if len(c.toRead) == 0 {
+		return 0, nil, net.ErrClosed
+	}
+	p := c.toRead[0]
+	c.toRead = c.toRead[1:]
+	return copy(b, p.data), p.addr, nil
+}
+
+func (c *fakePacketConn) WriteTo(b []byte, dst net.Addr) (int, error) {
+	c.written = append(c.written, fakePacket{data: append([]byte(nil), b...), addr: dst})
+	return len(b), nil
+}
+
+func (c *fakePacketConn) Close() error {
+	return nil
+}
+
+func newNode(name string, nodeIPs []string, podCIDRs []string) *corev1.Node {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.NodeSpec{PodCIDRs: podCIDRs},
+	}
+	for _, nodeIP := range nodeIPs {
+		node.Status.Addresses = append(node.Status.Addresses, corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: nodeIP})
+	}
+	return node
+}
+
+func newFakeMonitor(t *testing.T, isIPv6Enabled, probeTunnel bool, nodes ...*corev1.Node) *NodeLatencyMonitor {
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	nodeInformer := informerFactory.Core().V1().Nodes()
+	for _, node := range nodes {
+		require.NoError(t, nodeInformer.Informer().GetStore().Add(node))
+	}
+	m := NewNodeLatencyMonitor("node1", nodeInformer, true, isIPv6Enabled, probeTunnel, 0, false)
+	m.icmpID = 1234
+	return m
+}
+
+func parseEcho(t *testing.T, protocol int, data []byte) (icmp.Type, *icmp.Echo) {
+	msg, err := icmp.ParseMessage(protocol, data)
+	require.NoError(t, err)
+	echo, ok := msg.Body.(*icmp.Echo)
+	require.True(t, ok)
+	return msg.Type, echo
+}
+
+func newEchoReply(t *testing.T, msgType icmp.Type, id, seq int) []byte {
+	msg := icmp.Message{Type: msgType, Body: &icmp.Echo{ID: id, Seq: seq, Data: probePayload}}
+	b, err := msg.Marshal(nil)
+	require.NoError(t, err)
+	return b
+}
+
+func TestGetTargets(t *testing.T) {
+	node := newNode("node2", []string{"192.168.0.2", "fd00::2"}, []string{"10.10.1.0/24", "fd10:10:1::/64"})
+	tests := []struct {
+		name            string
+		isIPv6Enabled   bool
+		probeTunnel     bool
+		expectedTargets []probeTarget
+	}{
+		{
+			name: "IPv4 underlay",
+			expectedTargets: []probeTarget{
+				{nodeName: "node2", ip: net.ParseIP("192.168.0.2"), network: crdv1beta1.NodeLatencyNetworkUnderlay},
+			},
+		},
+		{
+			name:        "IPv4 underlay and tunnel",
+			probeTunnel: true,
+			expectedTargets: []probeTarget{
+				{nodeName: "node2", ip: net.ParseIP("192.168.0.2"), network: crdv1beta1.NodeLatencyNetworkUnderlay},
+				{nodeName: "node2", ip: net.ParseIP("10.10.1.1"), network: crdv1beta1.NodeLatencyNetworkTunnel},
+			},
+		},
+		{
+			name:          "dual-stack underlay and tunnel",
+			isIPv6Enabled: true,
+			probeTunnel:   true,
+			expectedTargets: []probeTarget{
+				{nodeName: "node2", ip: net.ParseIP("192.168.0.2"), network: crdv1beta1.NodeLatencyNetworkUnderlay},
+				{nodeName: "node2", ip: net.ParseIP("fd00::2"), network: crdv1beta1.NodeLatencyNetworkUnderlay},
+				{nodeName: "node2", ip: net.ParseIP("10.10.1.1"), network: crdv1beta1.NodeLatencyNetworkTunnel},
+				{nodeName: "node2", ip: net.ParseIP("fd10:10:1::1"), network: crdv1beta1.NodeLatencyNetworkTunnel},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newFakeMonitor(t, tt.isIPv6Enabled, tt.probeTunnel)
+			targets := m.getTargets(node)
+			require.Len(t, targets, len(tt.expectedTargets))
+			for i := range targets {
+				assert.Equal(t, tt.expectedTargets[i].nodeName, targets[i].nodeName)
+				assert.True(t, tt.expectedTargets[i].ip.Equal(targets[i].ip), "expected %s, got %s", tt.expectedTargets[i].ip, targets[i].ip)
+				assert.Equal(t, tt.expectedTargets[i].network, targets[i].network)
+			}
+		})
+	}
+}
+
+func TestPingAndReceiveReplies(t *testing.T) {
+	m := newFakeMonitor(t, true, false,
+		newNode("node1", []string{"192.168.0.1", "fd00::1"}, nil),
+		newNode("node2", []string{"192.168.0.2", "fd00::2"}, nil),
+		newNode("node3", []string{"192.168.0.3"}, nil),
+	)
+	ipv4Conn := &fakePacketConn{}
+	ipv6Conn := &fakePacketConn{}
+	m.ipv4Conn = ipv4Conn
+	m.ipv6Conn = ipv6Conn
+
+	m.pingAll()
+	// The local Node is not probed.
+	written := map[string]*icmp.Echo{}
+	for _, p := range ipv4Conn.written {
+		msgType, echo := parseEcho(t, protocolICMP, p.data)
+		assert.Equal(t, ipv4.ICMPTypeEcho, msgType)
+		written[p.addr.String()] = echo
+	}
+	for _, p := range ipv6Conn.written {
+		msgType, echo := parseEcho(t, protocolICMPIPv6, p.data)
+		assert.Equal(t, ipv6.ICMPTypeEchoRequest, msgType)
+		written[p.addr.String()] = echo
+	}
+	require.Len(t, written, 3)
+	for _, addr := range []string{"192.168.0.2", "fd00::2", "192.168.0.3"} {
+		require.Contains(t, written, addr)
+		assert.Equal(t, 1234, written[addr].ID)
+		assert.Equal(t, 1, written[addr].Seq)
+	}
+
+	// Only the replies matching the ID and the sequence number of the probes are recorded.
+	ipv4Conn.toRead = []fakePacket{
+		{data: newEchoReply(t, ipv4.ICMPTypeEchoReply, 1234, 1), addr: &net.IPAddr{IP: net.ParseIP("192.168.0.2")}},
+		{data: newEchoReply(t, ipv4.ICMPTypeEchoReply, 4321, 1), addr: &net.IPAddr{IP: net.ParseIP("192.168.0.3")}},
+		{data: newEchoReply(t, ipv4.ICMPTypeEcho, 1234, 1), addr: &net.IPAddr{IP: net.ParseIP("192.168.0.3")}},
+		{data: []byte{0x1}, addr: &net.IPAddr{IP: net.ParseIP("192.168.0.3")}},
+	}
+	m.receiveReplies(ipv4Conn, protocolICMP)
+	ipv6Conn.toRead = []fakePacket{
+		{data: newEchoReply(t, ipv6.ICMPTypeEchoReply, 1234, 1), addr: &net.IPAddr{IP: net.ParseIP("fd00::2")}},
+	}
+	m.receiveReplies(ipv6Conn, protocolICMPIPv6)
+
+	// The second round of probes counts the unanswered probe of node3 as lost.
+	m.pingAll()
+	stats := m.GetNodeLatencyStats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "node2", stats[0].NodeName)
+	require.Len(t, stats[0].TargetIPLatencyStats, 2)
+	for _, targetIPStats := range stats[0].TargetIPLatencyStats {
+		assert.Equal(t, crdv1beta1.NodeLatencyNetworkUnderlay, targetIPStats.Network)
+		assert.False(t, targetIPStats.LastRecvTime.IsZero())
+		assert.Equal(t, int32(0), targetIPStats.PacketLossPercent)
+	}
+	assert.Equal(t, "node3", stats[1].NodeName)
+	require.Len(t, stats[1].TargetIPLatencyStats, 1)
+	assert.True(t, stats[1].TargetIPLatencyStats[0].LastRecvTime.IsZero())
+	assert.Equal(t, int32(100), stats[1].TargetIPLatencyStats[0].PacketLossPercent)
+}
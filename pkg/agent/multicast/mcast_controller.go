@@ -32,7 +32,9 @@ import (
 	"k8s.io/klog/v2"
 
 	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/flowscheduler"
 	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/metrics"
 	"antrea.io/antrea/pkg/agent/openflow"
 	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/agent/util"
@@ -61,6 +63,9 @@ const (
 	// nodeUpdateKey is a key to trigger the Node list operation and update the OpenFlow group buckets to report
 	// the local multicast groups to other Nodes.
 	nodeUpdateKey = "nodeUpdate"
+
+	// Interval of updating the Prometheus metrics of the multicast traffic of the local Pods.
+	podTrafficMetricsInterval = 30 * time.Second
 )
 
 var workerCount uint8 = 2
@@ -79,9 +84,9 @@ type GroupMemberStatus struct {
 	// localMembers is a map for the local Pod member and its last update time, key is the Pod's interface name,
 	// and value is its last update time.
 	localMembers map[string]time.Time
-	// remoteMembers is a set for Nodes which have joined the multicast group in the cluster. The Node's IP is
-	// added in the set.
-	remoteMembers  sets.Set[string]
+	// remoteMembers is a map for Nodes which have joined the multicast group in the cluster, key is the Node's IP,
+	// and value is the last time an IGMP report was received from the Node.
+	remoteMembers  map[string]time.Time
 	lastIGMPReport time.Time
 	ofGroupID      binding.GroupIDType
 }
@@ -107,7 +112,7 @@ func (c *Controller) addGroupMemberStatus(e *mcastGroupEvent) {
 	status := &GroupMemberStatus{
 		group:         e.group,
 		ofGroupID:     c.v4GroupAllocator.Allocate(),
-		remoteMembers: sets.New[string](),
+		remoteMembers: make(map[string]time.Time),
 		localMembers:  make(map[string]time.Time),
 	}
 	status = addGroupMember(status, e)
@@ -127,13 +132,16 @@ func (c *Controller) updateGroupMemberStatus(obj interface{}, e *mcastGroupEvent
 	newStatus := &GroupMemberStatus{
 		group:          status.group,
 		localMembers:   make(map[string]time.Time),
-		remoteMembers:  status.remoteMembers.Union(nil),
+		remoteMembers:  make(map[string]time.Time),
 		lastIGMPReport: status.lastIGMPReport,
 		ofGroupID:      status.ofGroupID,
 	}
 	for m, t := range status.localMembers {
 		newStatus.localMembers[m] = t
 	}
+	for n, t := range status.remoteMembers {
+		newStatus.remoteMembers[n] = t
+	}
 	exist := memberExists(status, e)
 	switch e.eType {
 	case groupJoin:
@@ -183,6 +191,20 @@ func (c *Controller) clearStaleGroups() {
 	now := time.Now()
 	for _, obj := range c.groupCache.List() {
 		status := obj.(*GroupMemberStatus)
+		// Create a "leave" event for a remote Node if it is not updated before mcastGroupTimeout, e.g., the Node has been
+		// removed or its IGMP leave message has been lost. Other Nodes report their joined groups every queryInterval.
+		for member, lastUpdate := range status.remoteMembers {
+			if now.Sub(lastUpdate) > c.mcastGroupTimeout {
+				event := &mcastGroupEvent{
+					group:   status.group,
+					eType:   groupLeave,
+					time:    now,
+					iface:   &interfacestore.InterfaceConfig{Type: interfacestore.TunnelInterface},
+					srcNode: net.ParseIP(member),
+				}
+				c.groupEventCh <- event
+			}
+		}
 		diff := now.Sub(status.lastIGMPReport)
 		if diff > c.mcastGroupTimeout {
 			// Notify worker to remove the group from groupCache if all its members are not updated before mcastGroupTimeout.
@@ -267,6 +289,15 @@ type Controller struct {
 	// installedNodes is the installed Node set that the IGMP report message is sent to.
 	installedNodes sets.Set[string]
 	encapEnabled   bool
+	// reconcileScheduler shares the OVS programming bandwidth with other features.
+	// It's nil if the scheduler is disabled.
+	reconcileScheduler *flowscheduler.Scheduler
+	// podTrafficMetricsEnabled indicates whether the multicast traffic statistics of the local Pods are exported as
+	// Prometheus metrics.
+	podTrafficMetricsEnabled bool
+	// podsWithTrafficMetrics is the set of Pods for which the traffic metrics have been exported. It is only accessed
+	// by updatePodTrafficMetrics, to delete the metrics of the Pods which have been removed.
+	podsWithTrafficMetrics sets.Set[apitypes.NamespacedName]
 }
 
 func NewMulticastController(ofClient openflow.Client,
@@ -332,6 +363,18 @@ func NewMulticastController(ofClient openflow.Client,
 	return c
 }
 
+// SetReconcileScheduler sets the scheduler used to share the OVS programming bandwidth with other features.
+func (c *Controller) SetReconcileScheduler(scheduler *flowscheduler.Scheduler) {
+	c.reconcileScheduler = scheduler
+}
+
+// EnablePodTrafficMetrics enables exporting the multicast traffic statistics of the local Pods as Prometheus metrics.
+// It must be called before Run.
+func (c *Controller) EnablePodTrafficMetrics() {
+	c.podTrafficMetricsEnabled = true
+	c.podsWithTrafficMetrics = sets.New[apitypes.NamespacedName]()
+}
+
 func (c *Controller) Initialize() error {
 	err := c.mRouteClient.Initialize()
 	if err != nil {
@@ -371,6 +414,10 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 	go wait.NonSlidingUntil(c.clearStaleGroups, c.queryInterval, stopCh)
 	go c.eventHandler(stopCh)
 
+	if c.podTrafficMetricsEnabled {
+		go wait.NonSlidingUntil(c.updatePodTrafficMetrics, podTrafficMetricsInterval, stopCh)
+	}
+
 	for i := 0; i < int(workerCount); i++ {
 		// Process multicast Group membership report or leave messages.
 		go wait.Until(c.worker, time.Second, stopCh)
@@ -408,7 +455,9 @@ func (c *Controller) processNextWorkItem() bool {
 		c.queue.Forget(obj)
 		klog.Errorf("Expected string in work queue but got %#v", obj)
 		return true
-	} else if err := c.syncGroup(key); err == nil {
+	} else if err := c.reconcileScheduler.Do(flowscheduler.FeatureMulticast, func() error {
+		return c.syncGroup(key)
+	}); err == nil {
 		// If no error occurs we Forget this item so it does not get queued again until
 		// another change happens.
 		c.queue.Forget(key)
@@ -498,9 +547,8 @@ func (c *Controller) syncGroup(groupKey string) error {
 					return err
 				}
 			}
-			// TODO: add check on the stale multicast group that is joined by the Pods on a different Node.
-			// remoteMembers is always empty with noEncap mode.
-			if status.remoteMembers.Len() == 0 {
+			// remoteMembers is always empty with noEncap mode. The stale remote members are removed by clearStaleGroups.
+			if len(status.remoteMembers) == 0 {
 				// Remove the multicast OpenFlow flow and group entries if none Pod member on local or remote Node is in the group.
 				if err := c.ofClient.UninstallMulticastFlows(status.group); err != nil {
 					klog.ErrorS(err, "Failed to uninstall multicast flows", "group", groupKey)
@@ -745,9 +793,21 @@ func (c *Controller) GetGroupPods() map[string][]v1beta2.PodReference {
 	return groupPodsMap
 }
 
-// PodTrafficStats encodes the inbound and outbound multicast statistics of each Pod.
+// PodTrafficStats encodes the inbound and outbound multicast statistics of each Pod. Inbound and Outbound are the
+// numbers of packets, InboundBytes and OutboundBytes are the numbers of bytes.
 type PodTrafficStats struct {
-	Inbound, Outbound uint64
+	Inbound, Outbound           uint64
+	InboundBytes, OutboundBytes uint64
+}
+
+func (s *PodTrafficStats) addInbound(m *types.RuleMetric) {
+	s.Inbound += m.Packets
+	s.InboundBytes += m.Bytes
+}
+
+func (s *PodTrafficStats) addOutbound(m *types.RuleMetric) {
+	s.Outbound += m.Packets
+	s.OutboundBytes += m.Bytes
 }
 
 func (c *Controller) GetPodStats(podName string, podNamespace string) *PodTrafficStats {
@@ -755,7 +815,10 @@ func (c *Controller) GetPodStats(podName string, podNamespace string) *PodTraffi
 	for _, iface := range ifaces {
 		egressPodStats := c.ofClient.MulticastEgressPodMetricsByIP(iface.GetIPv4Addr())
 		ingressPodStats := c.ofClient.MulticastIngressPodMetricsByOFPort(iface.OFPort)
-		return &PodTrafficStats{Inbound: ingressPodStats.Packets, Outbound: egressPodStats.Packets}
+		podStats := &PodTrafficStats{}
+		podStats.addInbound(ingressPodStats)
+		podStats.addOutbound(egressPodStats)
+		return podStats
 	}
 	return nil
 }
@@ -768,10 +831,10 @@ func (c *Controller) GetAllPodsStats() map[*interfacestore.InterfaceConfig]*PodT
 		if exist {
 			statEntry, ok := statsMap[iface]
 			if !ok {
-				statsMap[iface] = &PodTrafficStats{Outbound: stats.Packets}
-			} else {
-				statEntry.Outbound += stats.Packets
+				statEntry = &PodTrafficStats{}
+				statsMap[iface] = statEntry
 			}
+			statEntry.addOutbound(stats)
 		}
 	}
 	ingressPodStats := c.ofClient.MulticastIngressPodMetrics()
@@ -780,15 +843,48 @@ func (c *Controller) GetAllPodsStats() map[*interfacestore.InterfaceConfig]*PodT
 		if exist {
 			statEntry, ok := statsMap[iface]
 			if !ok {
-				statsMap[iface] = &PodTrafficStats{Inbound: stats.Packets}
-			} else {
-				statEntry.Inbound += stats.Packets
+				statEntry = &PodTrafficStats{}
+				statsMap[iface] = statEntry
 			}
+			statEntry.addInbound(stats)
 		}
 	}
 	return statsMap
 }
 
+// updatePodTrafficMetrics exports the multicast traffic statistics of the local Pods as Prometheus metrics, and
+// deletes the metrics of the Pods which no longer have any statistics.
+func (c *Controller) updatePodTrafficMetrics() {
+	podStatsMap := make(map[apitypes.NamespacedName]*PodTrafficStats)
+	for iface, stats := range c.GetAllPodsStats() {
+		pod := apitypes.NamespacedName{Namespace: iface.PodNamespace, Name: iface.PodName}
+		podStats, ok := podStatsMap[pod]
+		if !ok {
+			podStats = &PodTrafficStats{}
+			podStatsMap[pod] = podStats
+		}
+		podStats.Inbound += stats.Inbound
+		podStats.InboundBytes += stats.InboundBytes
+		podStats.Outbound += stats.Outbound
+		podStats.OutboundBytes += stats.OutboundBytes
+	}
+	pods := sets.New[apitypes.NamespacedName]()
+	for pod, stats := range podStatsMap {
+		pods.Insert(pod)
+		metrics.PodMulticastPacketCount.WithLabelValues(pod.Namespace, pod.Name, "ingress").Set(float64(stats.Inbound))
+		metrics.PodMulticastPacketCount.WithLabelValues(pod.Namespace, pod.Name, "egress").Set(float64(stats.Outbound))
+		metrics.PodMulticastByteCount.WithLabelValues(pod.Namespace, pod.Name, "ingress").Set(float64(stats.InboundBytes))
+		metrics.PodMulticastByteCount.WithLabelValues(pod.Namespace, pod.Name, "egress").Set(float64(stats.OutboundBytes))
+	}
+	for pod := range c.podsWithTrafficMetrics.Difference(pods) {
+		for _, direction := range []string{"ingress", "egress"} {
+			metrics.PodMulticastPacketCount.DeleteLabelValues(pod.Namespace, pod.Name, direction)
+			metrics.PodMulticastByteCount.DeleteLabelValues(pod.Namespace, pod.Name, direction)
+		}
+	}
+	c.podsWithTrafficMetrics = pods
+}
+
 func (c *Controller) checkNodeUpdate(old interface{}, cur interface{}) {
 	oldNode := old.(*corev1.Node)
 	if oldNode.Name == c.nodeConfig.Name {
@@ -852,7 +948,7 @@ func memberExists(status *GroupMemberStatus, e *mcastGroupEvent) bool {
 	if e.iface.Type == interfacestore.ContainerInterface {
 		_, exist = status.localMembers[e.iface.InterfaceName]
 	} else if e.iface.Type == interfacestore.TunnelInterface {
-		exist = status.remoteMembers.Has(e.srcNode.String())
+		_, exist = status.remoteMembers[e.srcNode.String()]
 	}
 	return exist
 }
@@ -863,7 +959,7 @@ func addGroupMember(status *GroupMemberStatus, e *mcastGroupEvent) *GroupMemberS
 		status.lastIGMPReport = e.time
 		klog.V(2).InfoS("Added local member from multicast group", "group", e.group.String(), "member", e.iface.InterfaceName)
 	} else {
-		status.remoteMembers.Insert(e.srcNode.String())
+		status.remoteMembers[e.srcNode.String()] = e.time
 		klog.V(2).InfoS("Added remote member from multicast group", "group", e.group.String(), "member", e.srcNode)
 	}
 	return status
@@ -874,7 +970,7 @@ func deleteGroupMember(status *GroupMemberStatus, e *mcastGroupEvent) *GroupMemb
 		delete(status.localMembers, e.iface.InterfaceName)
 		klog.V(2).InfoS("Deleted local member from multicast group", "group", e.group.String(), "member", e.iface.InterfaceName)
 	} else {
-		status.remoteMembers.Delete(e.srcNode.String())
+		delete(status.remoteMembers, e.srcNode.String())
 		klog.V(2).InfoS("Deleted remote member from multicast group", "group", e.group.String(), "member", e.srcNode)
 	}
 	return status
@@ -40,10 +40,13 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	basemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/testutil"
 
 	"antrea.io/antrea/pkg/agent/config"
 	"antrea.io/antrea/pkg/agent/interfacestore"
 	ifaceStoretest "antrea.io/antrea/pkg/agent/interfacestore/testing"
+	"antrea.io/antrea/pkg/agent/metrics"
 	multicasttest "antrea.io/antrea/pkg/agent/multicast/testing"
 	"antrea.io/antrea/pkg/agent/openflow"
 	openflowtest "antrea.io/antrea/pkg/agent/openflow/testing"
@@ -393,7 +396,7 @@ func TestGetPodStats(t *testing.T) {
 	iface := if1
 	egressPodStats := &types.RuleMetric{Packets: 2, Bytes: 30}
 	ingressPodStats := &types.RuleMetric{Packets: 4, Bytes: 50}
-	expectedPodStats := &PodTrafficStats{Inbound: 4, Outbound: 2}
+	expectedPodStats := &PodTrafficStats{Inbound: 4, Outbound: 2, InboundBytes: 50, OutboundBytes: 30}
 
 	mockIfaceStore.EXPECT().GetContainerInterfacesByPod(iface.PodName, iface.PodNamespace).Return([]*interfacestore.InterfaceConfig{iface})
 	mockOFClient.EXPECT().MulticastEgressPodMetricsByIP(iface.IPs[0]).Return(egressPodStats)
@@ -421,14 +424,14 @@ func TestGetAllPodStats(t *testing.T) {
 			ingressPodStats: map[uint32]*types.RuleMetric{uint32(if1.OFPort): {Packets: 4, Bytes: 50}},
 			ifaceByIPMap:    map[string]*interfacestore.InterfaceConfig{if1.IPs[0].String(): if1},
 			ifaceByPortMap:  map[uint32]*interfacestore.InterfaceConfig{uint32(if1.OFPort): if1},
-			expectedStats:   map[*interfacestore.InterfaceConfig]*PodTrafficStats{if1: {Inbound: uint64(4), Outbound: uint64(2)}},
+			expectedStats:   map[*interfacestore.InterfaceConfig]*PodTrafficStats{if1: {Inbound: uint64(4), Outbound: uint64(2), InboundBytes: uint64(50), OutboundBytes: uint64(30)}},
 		}, {
 			name:            "two interfaces",
 			egressPodStats:  map[string]*types.RuleMetric{if1.IPs[0].String(): {Packets: 2, Bytes: 30}},
 			ingressPodStats: map[uint32]*types.RuleMetric{uint32(if2.OFPort): {Packets: 4, Bytes: 50}},
 			ifaceByIPMap:    map[string]*interfacestore.InterfaceConfig{if1.IPs[0].String(): if1},
 			ifaceByPortMap:  map[uint32]*interfacestore.InterfaceConfig{uint32(if2.OFPort): if2},
-			expectedStats:   map[*interfacestore.InterfaceConfig]*PodTrafficStats{if2: {Inbound: uint64(4)}, if1: {Outbound: uint64(2)}},
+			expectedStats:   map[*interfacestore.InterfaceConfig]*PodTrafficStats{if2: {Inbound: uint64(4), InboundBytes: uint64(50)}, if1: {Outbound: uint64(2), OutboundBytes: uint64(30)}},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
@@ -446,6 +449,39 @@ func TestGetAllPodStats(t *testing.T) {
 	}
 }
 
+func TestUpdatePodTrafficMetrics(t *testing.T) {
+	metrics.InitializeMulticastMetrics()
+	mctrl := newMockMulticastController(t, false)
+	err := mctrl.initialize(t)
+	require.NoError(t, err)
+	mctrl.EnablePodTrafficMetrics()
+
+	getMetricValue := func(metric *basemetrics.GaugeVec, iface *interfacestore.InterfaceConfig, direction string) float64 {
+		value, err := testutil.GetGaugeMetricValue(metric.WithLabelValues(iface.PodNamespace, iface.PodName, direction))
+		require.NoError(t, err)
+		return value
+	}
+
+	mockOFClient.EXPECT().MulticastEgressPodMetrics().Return(map[string]*types.RuleMetric{if1.IPs[0].String(): {Packets: 2, Bytes: 30}})
+	mockOFClient.EXPECT().MulticastIngressPodMetrics().Return(map[uint32]*types.RuleMetric{uint32(if1.OFPort): {Packets: 4, Bytes: 50}})
+	mockIfaceStore.EXPECT().GetInterfaceByIP(if1.IPs[0].String()).Return(if1, true)
+	mockIfaceStore.EXPECT().GetInterfaceByOFPort(uint32(if1.OFPort)).Return(if1, true)
+	mctrl.updatePodTrafficMetrics()
+	assert.Equal(t, float64(4), getMetricValue(metrics.PodMulticastPacketCount, if1, "ingress"))
+	assert.Equal(t, float64(2), getMetricValue(metrics.PodMulticastPacketCount, if1, "egress"))
+	assert.Equal(t, float64(50), getMetricValue(metrics.PodMulticastByteCount, if1, "ingress"))
+	assert.Equal(t, float64(30), getMetricValue(metrics.PodMulticastByteCount, if1, "egress"))
+	assert.Equal(t, sets.New[apitypes.NamespacedName](apitypes.NamespacedName{Namespace: if1.PodNamespace, Name: if1.PodName}), mctrl.podsWithTrafficMetrics)
+
+	// The metrics of the Pod should be deleted after its statistics are gone.
+	mockOFClient.EXPECT().MulticastEgressPodMetrics().Return(map[string]*types.RuleMetric{})
+	mockOFClient.EXPECT().MulticastIngressPodMetrics().Return(map[uint32]*types.RuleMetric{})
+	mctrl.updatePodTrafficMetrics()
+	assert.Empty(t, mctrl.podsWithTrafficMetrics)
+	assert.False(t, metrics.PodMulticastPacketCount.DeleteLabelValues(if1.PodNamespace, if1.PodName, "ingress"))
+	assert.False(t, metrics.PodMulticastByteCount.DeleteLabelValues(if1.PodNamespace, if1.PodName, "egress"))
+}
+
 func TestClearStaleGroupsCreatingLeaveEvent(t *testing.T) {
 	mctrl := newMockMulticastController(t, false)
 	workerCount = 1
@@ -482,6 +518,31 @@ func TestClearStaleGroupsCreatingLeaveEvent(t *testing.T) {
 	assert.Equal(t, expectedIface, e.iface)
 }
 
+func TestClearStaleRemoteMembersCreatingLeaveEvent(t *testing.T) {
+	mctrl := newMockMulticastController(t, true)
+	workerCount = 1
+	err := mctrl.initialize(t)
+	require.NoError(t, err)
+	now := time.Now()
+	staleTime := now.Add(-mctrl.mcastGroupTimeout - time.Second)
+	activeTime := now.Add(-mctrl.mcastGroupTimeout + time.Second)
+	// The group has no local member, so that only remote members are checked.
+	err = mctrl.groupCache.Add(&GroupMemberStatus{
+		group:          net.ParseIP("224.96.1.6"),
+		localMembers:   map[string]time.Time{},
+		remoteMembers:  map[string]time.Time{"10.1.1.2": staleTime, "10.1.1.3": activeTime},
+		lastIGMPReport: activeTime,
+	})
+	require.NoError(t, err)
+	mctrl.clearStaleGroups()
+	assert.Equal(t, 1, len(mctrl.groupEventCh))
+	e := <-mctrl.groupEventCh
+	assert.Equal(t, net.ParseIP("224.96.1.6"), e.group)
+	assert.Equal(t, groupLeave, e.eType)
+	assert.Equal(t, interfacestore.TunnelInterface, e.iface.Type)
+	assert.Equal(t, net.ParseIP("10.1.1.2"), e.srcNode)
+}
+
 func TestClearStaleGroups(t *testing.T) {
 	mctrl := newMockMulticastController(t, false)
 	workerCount = 1
@@ -1163,7 +1224,7 @@ func testRemoteReport(t *testing.T, mockController *Controller, groups []net.IP,
 			mockOFClient.EXPECT().InstallMulticastFlows(gomock.Any(), gomock.Any())
 		} else {
 			status := obj.(*GroupMemberStatus)
-			exists = status.remoteMembers.Has(node.String())
+			_, exists = status.remoteMembers[node.String()]
 			if nodeJoin && exists || !nodeJoin && !exists {
 				continue
 			}
@@ -1208,9 +1269,9 @@ func testRemoteReport(t *testing.T, mockController *Controller, groups []net.IP,
 		assert.True(t, exists)
 		status := obj.(*GroupMemberStatus)
 		if nodeJoin {
-			assert.True(t, status.remoteMembers.Has(node.String()))
+			assert.Contains(t, status.remoteMembers, node.String())
 		} else {
-			assert.False(t, status.remoteMembers.Has(node.String()))
+			assert.NotContains(t, status.remoteMembers, node.String())
 		}
 	}
 	for _, g := range groups {
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"sort"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,6 +42,7 @@ import (
 	antrearoute "antrea.io/antrea/pkg/agent/route"
 	"antrea.io/antrea/pkg/agent/wireguard"
 	"antrea.io/antrea/pkg/config/agent"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
 )
 
 const (
@@ -56,6 +58,10 @@ const (
 
 	multiclusterWireGuardInterface = "antrea-mc-wg0"
 	multiclusterWireGuardPublicKey = "publicKey"
+
+	// gatewayGroupKey is used to allocate the ID of the group which hashes cross-cluster
+	// connections across the active Gateways.
+	gatewayGroupKey = "multicluster-gateways"
 )
 
 var (
@@ -68,6 +74,7 @@ var (
 type MCDefaultRouteController struct {
 	mcClient             mcclientset.Interface
 	ofClient             openflow.Client
+	groupAllocator       openflow.GroupAllocator
 	routeClient          antrearoute.Interface
 	wireGuardClient      wireguard.Interface
 	nodeConfig           *config.NodeConfig
@@ -88,6 +95,14 @@ type MCDefaultRouteController struct {
 	// Need to use mutex to protect 'installedActiveGW' if we change to
 	// use multiple go routines to handle events
 	installedActiveGW *mcv1alpha1.Gateway
+	// gatewayGroupID is the ID of the group used on a regular Node to hash cross-cluster
+	// connections across the active Gateways. It is 0 when no group is installed.
+	gatewayGroupID binding.GroupIDType
+	// installedGatewayGroupIPs are the InternalIPs of the Gateways in the installed group.
+	installedGatewayGroupIPs sets.Set[string]
+	// useGatewayGroup is true when the installed flows forward cross-cluster requests
+	// with the group of the active Gateways.
+	useGatewayGroup bool
 	// The Namespace where Antrea Multi-cluster Controller is running.
 	namespace                    string
 	enableStretchedNetworkPolicy bool
@@ -100,6 +115,7 @@ func NewMCDefaultRouteController(
 	gwInformer mcinformersv1alpha1.GatewayInformer,
 	ciImportInformer mcinformersv1alpha1.ClusterInfoImportInformer,
 	client openflow.Client,
+	groupAllocator openflow.GroupAllocator,
 	nodeConfig *config.NodeConfig,
 	networkConfig *config.NetworkConfig,
 	routeClient antrearoute.Interface,
@@ -108,6 +124,7 @@ func NewMCDefaultRouteController(
 	controller := &MCDefaultRouteController{
 		mcClient:                     mcClient,
 		ofClient:                     client,
+		groupAllocator:               groupAllocator,
 		routeClient:                  routeClient,
 		nodeConfig:                   nodeConfig,
 		networkConfig:                networkConfig,
@@ -426,24 +443,42 @@ func (c *MCDefaultRouteController) syncMCFlows() error {
 	defer func() {
 		klog.V(4).InfoS("Finished syncing flows for Multi-cluster", "time", time.Since(startTime))
 	}()
-	activeGW, err := c.getActiveGateway()
+	activeGWs, err := c.getActiveGateways()
 	if err != nil {
 		return err
 	}
+	activeGW := c.selectActiveGateway(activeGWs)
 	if activeGW == nil && c.installedActiveGW == nil {
 		klog.V(2).InfoS("No active Gateway is found")
 		return nil
 	}
 
+	// On a regular Node, cross-cluster requests are hashed across the active Gateways
+	// with an OVS select group when there are multiple active Gateways.
+	useGatewayGroup := activeGW != nil && activeGW.Name != c.nodeConfig.Name && len(activeGWs) > 1
+	if useGatewayGroup {
+		if err := c.syncGatewayGroup(activeGWs); err != nil {
+			return err
+		}
+	}
+
 	klog.V(2).InfoS("Installed Gateway", "gateway", klog.KObj(c.installedActiveGW))
-	if activeGW != nil && c.installedActiveGW != nil && activeGW.Name == c.installedActiveGW.Name {
-		// Active Gateway name doesn't change but still do a full flow sync
-		// for any Gateway Spec or ClusterInfoImport changes.
-		if err := c.syncMCFlowsForAllCIImps(activeGW); err != nil {
+	if activeGW != nil && c.installedActiveGW != nil && c.isSameGatewayRole(activeGW, c.installedActiveGW) {
+		// The Node's role doesn't change but still do a full flow sync for any
+		// Gateway Spec or ClusterInfoImport changes. On a regular Node, this is
+		// also the case when the Gateway handling reply traffic changes, so that
+		// the flows can be updated in place.
+		groupChanged := useGatewayGroup != c.useGatewayGroup
+		c.useGatewayGroup = useGatewayGroup
+		if err := c.syncMCFlowsForAllCIImps(activeGW, groupChanged); err != nil {
+			if groupChanged {
+				// Make sure the flows of all ClusterInfoImports are updated in the next sync.
+				c.useGatewayGroup = !useGatewayGroup
+			}
 			return err
 		}
 		c.installedActiveGW = activeGW
-		return nil
+		return c.cleanUpGatewayGroup()
 	}
 
 	if c.installedActiveGW != nil {
@@ -452,6 +487,7 @@ func (c *MCDefaultRouteController) syncMCFlows() error {
 		}
 		klog.V(2).InfoS("Deleted flows for installed Gateway", "gateway", klog.KObj(c.installedActiveGW))
 		c.installedActiveGW = nil
+		c.useGatewayGroup = false
 	}
 
 	if activeGW != nil {
@@ -459,18 +495,83 @@ func (c *MCDefaultRouteController) syncMCFlows() error {
 			return err
 		}
 		c.installedActiveGW = activeGW
-		return c.addMCFlowsForAllCIImps(activeGW)
+		c.useGatewayGroup = useGatewayGroup
+		if err := c.addMCFlowsForAllCIImps(activeGW); err != nil {
+			return err
+		}
+	}
+	return c.cleanUpGatewayGroup()
+}
+
+// selectActiveGateway returns the Gateway whose flows are installed on this Node. On a Gateway
+// Node, it is the Gateway of the Node itself. On a regular Node, it is the first active Gateway,
+// which handles the cross-cluster reply traffic.
+func (c *MCDefaultRouteController) selectActiveGateway(activeGWs []*mcv1alpha1.Gateway) *mcv1alpha1.Gateway {
+	if len(activeGWs) == 0 {
+		return nil
+	}
+	for _, gw := range activeGWs {
+		if gw.Name == c.nodeConfig.Name {
+			return gw
+		}
+	}
+	return activeGWs[0]
+}
+
+// isSameGatewayRole returns true if the Node has the same role, Gateway or regular Node, with
+// both Gateways, and the flows can be updated without being deleted first.
+func (c *MCDefaultRouteController) isSameGatewayRole(gw1, gw2 *mcv1alpha1.Gateway) bool {
+	if gw1.Name == gw2.Name {
+		return true
+	}
+	return gw1.Name != c.nodeConfig.Name && gw2.Name != c.nodeConfig.Name
+}
+
+// syncGatewayGroup installs or updates the group used on a regular Node to hash cross-cluster
+// connections across the active Gateways. The bucket of a failed Gateway is removed from the
+// group as soon as its Gateway is deleted, without updating the flows referring to the group.
+func (c *MCDefaultRouteController) syncGatewayGroup(activeGWs []*mcv1alpha1.Gateway) error {
+	gatewayIPs := sets.New[string]()
+	tunnelPeerIPs := make([]net.IP, 0, len(activeGWs))
+	for _, gw := range activeGWs {
+		gatewayIPs.Insert(gw.InternalIP)
+		tunnelPeerIPs = append(tunnelPeerIPs, net.ParseIP(gw.InternalIP))
+	}
+	if c.installedGatewayGroupIPs != nil && c.installedGatewayGroupIPs.Equal(gatewayIPs) {
+		return nil
+	}
+	if c.gatewayGroupID == 0 {
+		c.gatewayGroupID = c.groupAllocator.AllocateFor(gatewayGroupKey)
+	}
+	klog.InfoS("Installing group for active Gateways", "groupID", c.gatewayGroupID, "gateways", sets.List(gatewayIPs))
+	if err := c.ofClient.InstallMulticlusterGatewayGroup(c.gatewayGroupID, tunnelPeerIPs); err != nil {
+		return fmt.Errorf("failed to install group for active Gateways: %v", err)
+	}
+	c.installedGatewayGroupIPs = gatewayIPs
+	return nil
+}
+
+// cleanUpGatewayGroup removes the group of the active Gateways after the flows stop using it.
+func (c *MCDefaultRouteController) cleanUpGatewayGroup() error {
+	if c.useGatewayGroup || c.gatewayGroupID == 0 {
+		return nil
 	}
+	if err := c.ofClient.UninstallMulticlusterGatewayGroup(c.gatewayGroupID); err != nil {
+		return fmt.Errorf("failed to uninstall group for active Gateways: %v", err)
+	}
+	c.groupAllocator.Release(c.gatewayGroupID)
+	c.gatewayGroupID = 0
+	c.installedGatewayGroupIPs = nil
 	return nil
 }
 
-func (c *MCDefaultRouteController) syncMCFlowsForAllCIImps(activeGW *mcv1alpha1.Gateway) error {
+func (c *MCDefaultRouteController) syncMCFlowsForAllCIImps(activeGW *mcv1alpha1.Gateway, groupChanged bool) error {
 	desiredCIImports, err := c.ciImportLister.List(labels.Everything())
 	if err != nil {
 		return err
 	}
 
-	activeGWChanged := c.checkGatewayIPChange(activeGW)
+	activeGWChanged := c.checkGatewayIPChange(activeGW) || groupChanged
 	installedCIImportNames := sets.KeySet(c.installedCIImports)
 	for _, ciImp := range desiredCIImports {
 		if err = c.addMCFlowsForSingleCIImp(activeGW, ciImp, c.installedCIImports[ciImp.Name], activeGWChanged); err != nil {
@@ -528,7 +629,8 @@ func (c *MCDefaultRouteController) addMCFlowsForSingleCIImp(activeGW *mcv1alpha1
 	var ciImportNoChange bool
 	if installedCIImp != nil {
 		oldTunnelPeerIPToRemoteGW := getPeerGatewayTunnelIP(installedCIImp.Spec, c.wireGuardConfig != nil)
-		ciImportNoChange = oldTunnelPeerIPToRemoteGW.Equal(tunnelPeerIPToRemoteGW) && installedCIImp.Spec.ServiceCIDR == ciImport.Spec.ServiceCIDR
+		ciImportNoChange = oldTunnelPeerIPToRemoteGW.Equal(tunnelPeerIPToRemoteGW) && installedCIImp.Spec.ServiceCIDR == ciImport.Spec.ServiceCIDR &&
+			sets.New[string](getPeerGatewayIPs(installedCIImp.Spec)...).Equal(sets.New[string](getPeerGatewayIPs(ciImport.Spec)...))
 		if c.enablePodToPodConnectivity {
 			ciImportNoChange = ciImportNoChange && sets.New[string](installedCIImp.Spec.PodCIDRs...).Equal(sets.New[string](ciImport.Spec.PodCIDRs...))
 		}
@@ -550,6 +652,14 @@ func (c *MCDefaultRouteController) addMCFlowsForSingleCIImp(activeGW *mcv1alpha1
 		klog.ErrorS(err, "Parse error for serviceCIDR from remote cluster", "clusterinfoimport", ciImport.Name, "gateway", activeGW.Name)
 		return err
 	}
+	var peerGatewayIPs []net.IP
+	if c.wireGuardConfig == nil {
+		// The other active Gateways of the remote cluster may send reply traffic with their own
+		// Gateway IPs, which must be forwarded back to them.
+		for _, ip := range getPeerGatewayIPs(ciImport.Spec) {
+			peerGatewayIPs = append(peerGatewayIPs, net.ParseIP(ip))
+		}
+	}
 	if activeGW.Name == c.nodeConfig.Name {
 		klog.V(2).InfoS("Adding/updating flows to remote Gateway Node for Multi-cluster traffic", "clusterinfoimport", ciImport.Name, "cidrs", allCIDRs)
 		localGatewayIP := getLocalGatewayIP(activeGW, c.wireGuardConfig != nil)
@@ -562,16 +672,23 @@ func (c *MCDefaultRouteController) addMCFlowsForSingleCIImp(activeGW *mcv1alpha1
 			peerConfigs,
 			tunnelPeerIPToRemoteGW,
 			localGatewayIP,
+			peerGatewayIPs,
 			c.enableStretchedNetworkPolicy); err != nil {
 			return fmt.Errorf("failed to install flows to remote Gateway in ClusterInfoImport %s: %v", ciImport.Name, err)
 		}
 	} else {
 		klog.V(2).InfoS("Adding/updating flows to the local active Gateway for Multi-cluster traffic", "clusterinfoimport", ciImport.Name, "cidrs", allCIDRs)
 		tunnelPeerIPToLocalGW := net.ParseIP(activeGW.InternalIP)
+		var gatewayGroupID binding.GroupIDType
+		if c.useGatewayGroup {
+			gatewayGroupID = c.gatewayGroupID
+		}
 		if err := c.ofClient.InstallMulticlusterNodeFlows(
 			ciImport.Name,
 			peerConfigs,
 			tunnelPeerIPToLocalGW,
+			gatewayGroupID,
+			peerGatewayIPs,
 			c.enableStretchedNetworkPolicy); err != nil {
 			return fmt.Errorf("failed to install flows to Gateway %s: %v", activeGW.Name, err)
 		}
@@ -610,18 +727,54 @@ func (c *MCDefaultRouteController) getActiveGateway() (*mcv1alpha1.Gateway, erro
 	return activeGW, nil
 }
 
+// getActiveGateways returns all active Gateways with valid IPs. Only the first active Gateway
+// is returned when WireGuard is enabled, as the WireGuard tunnel is set up with a single
+// Gateway of each cluster.
+func (c *MCDefaultRouteController) getActiveGateways() ([]*mcv1alpha1.Gateway, error) {
+	if c.wireGuardConfig != nil {
+		activeGW, err := c.getActiveGateway()
+		if err != nil || activeGW == nil {
+			return nil, err
+		}
+		return []*mcv1alpha1.Gateway{activeGW}, nil
+	}
+	gws, err := getActiveGateways(c.gwLister)
+	if err != nil {
+		return nil, err
+	}
+	for _, gw := range gws {
+		if net.ParseIP(gw.GatewayIP) == nil || net.ParseIP(gw.InternalIP) == nil {
+			return nil, fmt.Errorf("the active Gateway %s has no valid GatewayIP or InternalIP", gw.Name)
+		}
+	}
+	return gws, nil
+}
+
+// getActiveGateway returns the first active Gateway in the cluster.
 func getActiveGateway(gwLister mclisters.GatewayLister) (*mcv1alpha1.Gateway, error) {
-	gws, err := gwLister.List(labels.Everything())
+	gws, err := getActiveGateways(gwLister)
 	if err != nil {
 		return nil, err
 	}
 	if len(gws) == 0 {
 		return nil, nil
 	}
-	// The Gateway webhook guarantees there will be at most one Gateway in a cluster.
 	return gws[0], nil
 }
 
+// getActiveGateways returns all active Gateways in the cluster sorted by name. There can be
+// multiple Gateways only when active-active Gateways are enabled in the Multi-cluster Controller.
+func getActiveGateways(gwLister mclisters.GatewayLister) ([]*mcv1alpha1.Gateway, error) {
+	gws, err := gwLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(gws, func(i, j int) bool {
+		return gws[i].Name < gws[j].Name
+	})
+	return gws, nil
+}
+
 func generatePeerConfigs(subnets []string, gatewayIP net.IP) (map[*net.IPNet]net.IP, error) {
 	peerConfigs := make(map[*net.IPNet]net.IP, len(subnets))
 	for _, subnet := range subnets {
@@ -653,6 +806,18 @@ func getPeerGatewayTunnelIP(spec mcv1alpha1.ClusterInfo, enableWireGuard bool) n
 	return net.ParseIP(spec.GatewayInfos[0].GatewayIP)
 }
 
+// getPeerGatewayIPs returns the Gateway IPs of the remote cluster's active Gateways other than
+// the first one.
+func getPeerGatewayIPs(spec mcv1alpha1.ClusterInfo) []string {
+	var ips []string
+	for i := 1; i < len(spec.GatewayInfos); i++ {
+		if net.ParseIP(spec.GatewayInfos[i].GatewayIP) != nil {
+			ips = append(ips, spec.GatewayInfos[i].GatewayIP)
+		}
+	}
+	return ips
+}
+
 func getLocalGatewayIP(gateway *mcv1alpha1.Gateway, enableWireGuard bool) net.IP {
 	if enableWireGuard {
 		if gateway.ServiceCIDR == "" {
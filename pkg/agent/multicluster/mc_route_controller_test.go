@@ -30,12 +30,14 @@ import (
 	mcfake "antrea.io/antrea/multicluster/pkg/client/clientset/versioned/fake"
 	mcinformers "antrea.io/antrea/multicluster/pkg/client/informers/externalversions"
 	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/openflow"
 	oftest "antrea.io/antrea/pkg/agent/openflow/testing"
 	antrearoute "antrea.io/antrea/pkg/agent/route"
 	routemock "antrea.io/antrea/pkg/agent/route/testing"
 	"antrea.io/antrea/pkg/agent/wireguard"
 	wireguardmock "antrea.io/antrea/pkg/agent/wireguard/testing"
 	"antrea.io/antrea/pkg/config/agent"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
 )
 
 type fakeRouteController struct {
@@ -76,6 +78,7 @@ func newMCDefaultRouteController(t *testing.T,
 		gwInformer,
 		ciImportInformer,
 		ofClient,
+		openflow.NewGroupAllocator(),
 		nodeConfig,
 		networkConfig,
 		routeClient,
@@ -225,7 +228,7 @@ func TestMCRouteControllerAsWireGuardGateway(t *testing.T) {
 			Create(context.TODO(), &clusterInfoImport3, metav1.CreateOptions{})
 		peerNodeIP3 := getPeerGatewayTunnelIP(clusterInfoImport3.Spec, true)
 		c.ofClient.EXPECT().InstallMulticlusterGatewayFlows(clusterInfoImport3.Name,
-			gomock.Any(), peerNodeIP3, gomock.Any(), true).Times(1)
+			gomock.Any(), peerNodeIP3, gomock.Any(), gomock.Nil(), true).Times(1)
 		mockInterface.EXPECT().AddRouteForLink(gomock.Any(), 0).Times(1)
 		c.processNextWorkItem()
 
@@ -273,14 +276,14 @@ func TestMCRouteControllerAsGateway(t *testing.T) {
 			Create(context.TODO(), &clusterInfoImport1, metav1.CreateOptions{})
 		peerNodeIP1 := getPeerGatewayTunnelIP(clusterInfoImport1.Spec, false)
 		c.ofClient.EXPECT().InstallMulticlusterGatewayFlows(clusterInfoImport1.Name,
-			gomock.Any(), peerNodeIP1, gw1GatewayIP, true).Times(1)
+			gomock.Any(), peerNodeIP1, gw1GatewayIP, gomock.Nil(), true).Times(1)
 		c.processNextWorkItem()
 
 		c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(clusterInfoImport2.GetNamespace()).
 			Create(context.TODO(), &clusterInfoImport2, metav1.CreateOptions{})
 		peerNodeIP2 := getPeerGatewayTunnelIP(clusterInfoImport2.Spec, false)
 		c.ofClient.EXPECT().InstallMulticlusterGatewayFlows(clusterInfoImport2.Name,
-			gomock.Any(), peerNodeIP2, gw1GatewayIP, true).Times(1)
+			gomock.Any(), peerNodeIP2, gw1GatewayIP, gomock.Nil(), true).Times(1)
 		c.processNextWorkItem()
 
 		// Update a ClusterInfoImport
@@ -288,7 +291,7 @@ func TestMCRouteControllerAsGateway(t *testing.T) {
 		c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(clusterInfoImport1.GetNamespace()).
 			Update(context.TODO(), &clusterInfoImport1, metav1.UpdateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterGatewayFlows(clusterInfoImport1.Name,
-			gomock.Any(), peerNodeIP1, gw1GatewayIP, true).Times(1)
+			gomock.Any(), peerNodeIP1, gw1GatewayIP, gomock.Nil(), true).Times(1)
 		c.processNextWorkItem()
 
 		// Delete a ClusterInfoImport
@@ -304,7 +307,7 @@ func TestMCRouteControllerAsGateway(t *testing.T) {
 		c.mcClient.MulticlusterV1alpha1().Gateways(updatedGateway1a.GetNamespace()).Update(context.TODO(),
 			updatedGateway1a, metav1.UpdateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterGatewayFlows(clusterInfoImport1.Name,
-			gomock.Any(), peerNodeIP1, updatedGateway1aIP, true).Times(1)
+			gomock.Any(), peerNodeIP1, updatedGateway1aIP, gomock.Nil(), true).Times(1)
 		c.processNextWorkItem()
 
 		// Update Gateway1's InternalIP
@@ -324,7 +327,7 @@ func TestMCRouteControllerAsGateway(t *testing.T) {
 		c.mcClient.MulticlusterV1alpha1().Gateways(gateway2.GetNamespace()).Create(context.TODO(),
 			&gateway2, metav1.CreateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterClassifierFlows(uint32(1), false).Times(1)
-		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport1.Name, gomock.Any(), gw2InternalIP, true).Times(1)
+		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport1.Name, gomock.Any(), gw2InternalIP, binding.GroupIDType(0), gomock.Nil(), true).Times(1)
 		c.processNextWorkItem()
 	}()
 	select {
@@ -366,13 +369,13 @@ func TestMCRouteControllerAsRegularNode(t *testing.T) {
 		c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(clusterInfoImport1.GetNamespace()).
 			Create(context.TODO(), &clusterInfoImport1, metav1.CreateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport1.Name,
-			gomock.Any(), peerNodeIP1, true).Times(1)
+			gomock.Any(), peerNodeIP1, binding.GroupIDType(0), gomock.Nil(), true).Times(1)
 		c.processNextWorkItem()
 
 		c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(clusterInfoImport2.GetNamespace()).
 			Create(context.TODO(), &clusterInfoImport2, metav1.CreateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport2.Name,
-			gomock.Any(), peerNodeIP1, true).Times(1)
+			gomock.Any(), peerNodeIP1, binding.GroupIDType(0), gomock.Nil(), true).Times(1)
 		c.processNextWorkItem()
 
 		// Update a ClusterInfoImport
@@ -380,7 +383,7 @@ func TestMCRouteControllerAsRegularNode(t *testing.T) {
 		c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(clusterInfoImport1.GetNamespace()).
 			Update(context.TODO(), &clusterInfoImport1, metav1.UpdateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport1.Name,
-			gomock.Any(), peerNodeIP1, true).Times(1)
+			gomock.Any(), peerNodeIP1, binding.GroupIDType(0), gomock.Nil(), true).Times(1)
 		c.processNextWorkItem()
 
 		// Delete a ClusterInfoImport
@@ -403,7 +406,7 @@ func TestMCRouteControllerAsRegularNode(t *testing.T) {
 		c.mcClient.MulticlusterV1alpha1().Gateways(updatedGateway1b.GetNamespace()).Update(context.TODO(),
 			updatedGateway1b, metav1.UpdateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport1.Name,
-			gomock.Any(), updatedGateway1bIP, true).Times(1)
+			gomock.Any(), updatedGateway1bIP, binding.GroupIDType(0), gomock.Nil(), true).Times(1)
 		c.processNextWorkItem()
 
 		// Delete Gateway1
@@ -416,7 +419,93 @@ func TestMCRouteControllerAsRegularNode(t *testing.T) {
 		c.mcClient.MulticlusterV1alpha1().Gateways(gateway2.GetNamespace()).Create(context.TODO(),
 			&gateway2, metav1.CreateOptions{})
 		c.ofClient.EXPECT().InstallMulticlusterClassifierFlows(uint32(1), false).Times(1)
-		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport1.Name, gomock.Any(), peerNodeIP2, true).Times(1)
+		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(clusterInfoImport1.Name, gomock.Any(), peerNodeIP2, binding.GroupIDType(0), gomock.Nil(), true).Times(1)
+		c.processNextWorkItem()
+	}()
+	select {
+	case <-time.After(5 * time.Second):
+		t.Errorf("Test didn't finish in time")
+	case <-finishCh:
+	}
+}
+
+func TestMCRouteControllerWithActiveActiveGateways(t *testing.T) {
+	c := newMCDefaultRouteController(
+		t,
+		&config.NodeConfig{Name: "node-3"},
+		&config.NetworkConfig{},
+		agent.WireGuardConfig{},
+		nil,
+		"none",
+	)
+	defer c.queue.ShutDown()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	c.informerFactory.Start(stopCh)
+	c.informerFactory.WaitForCacheSync(stopCh)
+
+	gateway5 := mcv1alpha1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "node-5",
+			Namespace: "default",
+		},
+		GatewayIP:  "172.17.0.15",
+		InternalIP: "192.17.0.15",
+	}
+	ciImport := clusterInfoImport2.DeepCopy()
+	ciImport.Spec.GatewayInfos = append(ciImport.Spec.GatewayInfos, mcv1alpha1.GatewayInfo{GatewayIP: "12.11.0.11"})
+	peerGatewayIPs := []net.IP{net.ParseIP("12.11.0.11")}
+	gw1InternalIP := net.ParseIP(gateway1.InternalIP)
+	gw5InternalIP := net.ParseIP(gateway5.InternalIP)
+
+	finishCh := make(chan struct{})
+	go func() {
+		defer close(finishCh)
+
+		// Create Gateway1
+		c.mcClient.MulticlusterV1alpha1().Gateways(gateway1.GetNamespace()).Create(context.TODO(),
+			&gateway1, metav1.CreateOptions{})
+		c.ofClient.EXPECT().InstallMulticlusterClassifierFlows(uint32(1), false).Times(1)
+		c.processNextWorkItem()
+
+		// Create a ClusterInfoImport with two Gateways
+		c.mcClient.MulticlusterV1alpha1().ClusterInfoImports(ciImport.GetNamespace()).
+			Create(context.TODO(), ciImport, metav1.CreateOptions{})
+		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(ciImport.Name,
+			gomock.Any(), gw1InternalIP, binding.GroupIDType(0), peerGatewayIPs, true).Times(1)
+		c.processNextWorkItem()
+
+		// Create Gateway2, the requests should be hashed across two Gateways.
+		c.mcClient.MulticlusterV1alpha1().Gateways(gateway2.GetNamespace()).Create(context.TODO(),
+			&gateway2, metav1.CreateOptions{})
+		c.ofClient.EXPECT().InstallMulticlusterGatewayGroup(gomock.Any(), []net.IP{gw1InternalIP, gw2InternalIP}).Times(1)
+		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(ciImport.Name,
+			gomock.Any(), gw1InternalIP, gomock.Not(binding.GroupIDType(0)), peerGatewayIPs, true).Times(1)
+		c.processNextWorkItem()
+
+		// Create Gateway5, only the group should be updated.
+		c.mcClient.MulticlusterV1alpha1().Gateways(gateway5.GetNamespace()).Create(context.TODO(),
+			&gateway5, metav1.CreateOptions{})
+		c.ofClient.EXPECT().InstallMulticlusterGatewayGroup(gomock.Any(), []net.IP{gw1InternalIP, gw2InternalIP, gw5InternalIP}).Times(1)
+		c.processNextWorkItem()
+
+		// Delete Gateway1, reply traffic should be forwarded to Gateway2.
+		c.mcClient.MulticlusterV1alpha1().Gateways(gateway1.GetNamespace()).Delete(context.TODO(),
+			gateway1.Name, metav1.DeleteOptions{})
+		c.ofClient.EXPECT().InstallMulticlusterGatewayGroup(gomock.Any(), []net.IP{gw2InternalIP, gw5InternalIP}).Times(1)
+		c.ofClient.EXPECT().InstallMulticlusterNodeFlows(ciImport.Name,
+			gomock.Any(), gw2InternalIP, gomock.Not(binding.GroupIDType(0)), peerGatewayIPs, true).Times(1)
+		c.processNextWorkItem()
+
+		// Delete Gateway5, the group should be removed after the flows stop using it.
+		c.mcClient.MulticlusterV1alpha1().Gateways(gateway5.GetNamespace()).Delete(context.TODO(),
+			gateway5.Name, metav1.DeleteOptions{})
+		gomock.InOrder(
+			c.ofClient.EXPECT().InstallMulticlusterNodeFlows(ciImport.Name,
+				gomock.Any(), gw2InternalIP, binding.GroupIDType(0), peerGatewayIPs, true).Times(1),
+			c.ofClient.EXPECT().UninstallMulticlusterGatewayGroup(gomock.Any()).Times(1),
+		)
 		c.processNextWorkItem()
 	}()
 	select {
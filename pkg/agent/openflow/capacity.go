@@ -0,0 +1,132 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openflow
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/metrics"
+	"antrea.io/antrea/pkg/agent/types"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
+)
+
+const (
+	capacityCheckInterval = 30 * time.Second
+
+	capacityResourceFlowsPerTable = "flows_per_table"
+	capacityResourceGroups        = "groups"
+	capacityResourceMeters        = "meters"
+)
+
+// capacityUsage is the number of OpenFlow entries installed by the client.
+type capacityUsage struct {
+	// groupCounts is the number of groups, keyed by the feature which
+	// installed them.
+	groupCounts map[string]int
+	meterCount  int
+}
+
+// getCapacityUsage returns the numbers of groups and meters installed by the
+// client, computed from its internal caches.
+func (c *client) getCapacityUsage() capacityUsage {
+	countGroups := func(groupCache *sync.Map) int {
+		count := 0
+		groupCache.Range(func(_, _ interface{}) bool {
+			count++
+			return true
+		})
+		return count
+	}
+	usage := capacityUsage{groupCounts: map[string]int{}}
+	if c.featureService != nil {
+		usage.groupCounts[c.featureService.getFeatureName()] = countGroups(&c.featureService.groupCache)
+	}
+	if c.featureMulticast != nil {
+		usage.groupCounts[c.featureMulticast.getFeatureName()] = countGroups(&c.featureMulticast.groupCache)
+	}
+	// The meters installed by the client are the ones used to rate-limit
+	// packet-in messages, see initialize, and the Pod bandwidth meters.
+	if c.ovsMetersAreSupported {
+		usage.meterCount = 2
+		if c.featurePodConnectivity != nil {
+			c.featurePodConnectivity.podBandwidthMeters.Range(func(_, value interface{}) bool {
+				usage.meterCount += len(value.([]binding.Meter))
+				return true
+			})
+		}
+	}
+	return usage
+}
+
+// MonitorCapacity periodically reports the numbers of OpenFlow groups and
+// meters installed by the client in metrics, and checks them, as well as the
+// number of flows in each table, against the provided soft limits, until
+// stopCh is closed.
+func (c *client) MonitorCapacity(limits types.OVSSoftLimits, stopCh <-chan struct{}) {
+	klog.InfoS("Starting OpenFlow capacity monitor", "flowsPerTableLimit", limits.FlowsPerTable, "groupsLimit", limits.Groups, "metersLimit", limits.Meters)
+	exceeded := map[string]bool{}
+	wait.Until(func() {
+		c.checkCapacity(limits, exceeded)
+	}, capacityCheckInterval, stopCh)
+}
+
+// checkCapacity updates the capacity metrics and logs a warning when a soft
+// limit starts being exceeded. exceeded tracks which soft limits were
+// exceeded during the previous check, to avoid logging the same warning
+// repeatedly.
+func (c *client) checkCapacity(limits types.OVSSoftLimits, exceeded map[string]bool) {
+	usage := c.getCapacityUsage()
+	totalGroups := 0
+	for feature, count := range usage.groupCounts {
+		metrics.OVSGroupCount.WithLabelValues(feature).Set(float64(count))
+		totalGroups += count
+	}
+	metrics.OVSMeterCount.Set(float64(usage.meterCount))
+
+	check := func(resource string, limit, value int, description string) {
+		if limit <= 0 {
+			return
+		}
+		isExceeded := value > limit
+		if isExceeded && !exceeded[resource] {
+			klog.Warningf("Number of %s (%d) exceeds the soft limit %d", description, value, limit)
+		}
+		exceeded[resource] = isExceeded
+		if isExceeded {
+			metrics.OVSSoftLimitExceeded.WithLabelValues(resource).Set(1)
+		} else {
+			metrics.OVSSoftLimitExceeded.WithLabelValues(resource).Set(0)
+		}
+	}
+	if limits.FlowsPerTable > 0 {
+		// Only the largest table is checked, so that a single metric
+		// reports whether any table exceeds the limit.
+		var maxTable string
+		var maxFlowCount uint
+		for _, status := range c.GetFlowTableStatus() {
+			if status.FlowCount > maxFlowCount {
+				maxTable, maxFlowCount = status.Name, status.FlowCount
+			}
+		}
+		check(capacityResourceFlowsPerTable, limits.FlowsPerTable, int(maxFlowCount), fmt.Sprintf("flows in OVS table %s", maxTable))
+	}
+	check(capacityResourceGroups, limits.Groups, totalGroups, "OVS groups")
+	check(capacityResourceMeters, limits.Meters, usage.meterCount, "OVS meters")
+}
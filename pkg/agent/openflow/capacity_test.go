@@ -0,0 +1,89 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openflow
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/component-base/metrics/testutil"
+
+	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/metrics"
+	oftest "antrea.io/antrea/pkg/agent/openflow/testing"
+	"antrea.io/antrea/pkg/agent/types"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
+	ovsoftest "antrea.io/antrea/pkg/ovs/openflow/testing"
+)
+
+func Test_client_checkCapacity(t *testing.T) {
+	metrics.InitializeOVSMetrics()
+	ctrl := gomock.NewController(t)
+	m := oftest.NewMockOFEntryOperations(ctrl)
+	fc := newFakeClient(m, true, false, config.K8sNode, config.TrafficEncapModeEncap)
+	defer resetPipelines()
+	fc.ovsMetersAreSupported = true
+	for i := 1; i <= 3; i++ {
+		fc.featureService.groupCache.Store(binding.GroupIDType(i), ovsoftest.NewMockGroup(ctrl))
+	}
+	mockBridge := ovsoftest.NewMockBridge(ctrl)
+	fc.bridge = mockBridge
+
+	getSoftLimitExceeded := func(resource string) float64 {
+		value, _ := testutil.GetGaugeMetricValue(metrics.OVSSoftLimitExceeded.WithLabelValues(resource))
+		return value
+	}
+
+	usage := fc.getCapacityUsage()
+	assert.Equal(t, 3, usage.groupCounts[fc.featureService.getFeatureName()])
+	assert.Equal(t, 2, usage.meterCount)
+
+	limits := types.OVSSoftLimits{FlowsPerTable: 10, Groups: 2, Meters: 2}
+	exceeded := map[string]bool{}
+	mockBridge.EXPECT().DumpTableStatus().Return([]binding.TableStatus{
+		{ID: 0, Name: "Classifier", FlowCount: 5},
+		{ID: 1, Name: "SpoofGuard", FlowCount: 11},
+	})
+	fc.checkCapacity(limits, exceeded)
+	groupCount, _ := testutil.GetGaugeMetricValue(metrics.OVSGroupCount.WithLabelValues(fc.featureService.getFeatureName()))
+	assert.Equal(t, float64(3), groupCount)
+	meterCount, _ := testutil.GetGaugeMetricValue(metrics.OVSMeterCount)
+	assert.Equal(t, float64(2), meterCount)
+	assert.Equal(t, float64(1), getSoftLimitExceeded(capacityResourceFlowsPerTable))
+	assert.Equal(t, float64(1), getSoftLimitExceeded(capacityResourceGroups))
+	assert.Equal(t, float64(0), getSoftLimitExceeded(capacityResourceMeters))
+	assert.Equal(t, map[string]bool{
+		capacityResourceFlowsPerTable: true,
+		capacityResourceGroups:        true,
+		capacityResourceMeters:        false,
+	}, exceeded)
+
+	// The usage goes back below the limits.
+	fc.featureService.groupCache.Delete(binding.GroupIDType(3))
+	mockBridge.EXPECT().DumpTableStatus().Return([]binding.TableStatus{
+		{ID: 0, Name: "Classifier", FlowCount: 5},
+		{ID: 1, Name: "SpoofGuard", FlowCount: 10},
+	})
+	fc.checkCapacity(limits, exceeded)
+	assert.Equal(t, float64(0), getSoftLimitExceeded(capacityResourceFlowsPerTable))
+	assert.Equal(t, float64(0), getSoftLimitExceeded(capacityResourceGroups))
+	assert.False(t, exceeded[capacityResourceFlowsPerTable])
+	assert.False(t, exceeded[capacityResourceGroups])
+
+	// The Pod bandwidth meters are counted as well.
+	fc.featurePodConnectivity.podBandwidthMeters.Store("pod1-7a2c3f", []binding.Meter{nil, nil})
+	assert.Equal(t, 4, fc.getCapacityUsage().meterCount)
+}
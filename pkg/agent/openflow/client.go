@@ -18,11 +18,17 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
 	"antrea.io/libOpenflow/openflow15"
 	"antrea.io/libOpenflow/protocol"
 	ofutil "antrea.io/libOpenflow/util"
 	"antrea.io/ofnet/ofctrl"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	"antrea.io/antrea/pkg/agent/config"
@@ -33,6 +39,7 @@ import (
 	crdv1alpha2 "antrea.io/antrea/pkg/apis/crd/v1alpha2"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
 	utilip "antrea.io/antrea/pkg/util/ip"
+	"antrea.io/antrea/pkg/util/runtime"
 	"antrea.io/antrea/third_party/proxy"
 )
 
@@ -53,9 +60,11 @@ type Client interface {
 		l7NetworkPolicyConfig *config.L7NetworkPolicyConfig) (<-chan struct{}, error)
 
 	// InstallNodeFlows should be invoked when a connection to a remote Node is going to be set
-	// up. The hostname is used to identify the added flows. When IPsec tunnel is enabled,
-	// ipsecTunOFPort must be set to the OFPort number of the IPsec tunnel port to the remote Node;
-	// otherwise ipsecTunOFPort must be set to 0.
+	// up. The hostname is used to identify the added flows. When a tunnel port is dedicated to the
+	// remote Node, i.e. when IPsec tunnel is enabled or when the tunnel type or destination port
+	// to the remote Node is overridden, tunOFPort must be set to the OFPort number of this tunnel
+	// port, which must use the IPv4 address of the remote Node if it has one, and its IPv6 address
+	// otherwise; otherwise tunOFPort must be set to 0.
 	// InstallNodeFlows has all-or-nothing semantics(call succeeds if all the flows are installed
 	// successfully, otherwise no flows will be installed). Calls to InstallNodeFlows are idempotent.
 	// Concurrent calls to InstallNodeFlows and / or UninstallNodeFlows are supported as long as they
@@ -64,7 +73,7 @@ type Client interface {
 		hostname string,
 		peerConfigs map[*net.IPNet]net.IP,
 		tunnelPeerIP *utilip.DualStackIPs,
-		ipsecTunOFPort uint32,
+		tunOFPort uint32,
 		peerNodeMAC net.HardwareAddr) error
 
 	// UninstallNodeFlows removes the connection to the remote Node specified with the
@@ -83,8 +92,28 @@ type Client interface {
 	// interfaceName. UninstallPodFlows will do nothing if no connection to the Pod was established.
 	UninstallPodFlows(interfaceName string) error
 
+	// IsPodBandwidthMeterSupported returns whether the bandwidth limits of Pods can be enforced with OVS meters, i.e.
+	// the PodBandwidthMeter feature is enabled and the OVS datapath supports meters.
+	IsPodBandwidthMeterSupported() bool
+
+	// InstallPodBandwidthMeters installs the OVS meters enforcing the bandwidth limits of the local Pod specified with
+	// the interfaceName, and the flows applying them to the traffic of the Pod. Any meter previously installed for the
+	// Pod is replaced.
+	InstallPodBandwidthMeters(interfaceName string, ofPort uint32, bandwidth *types.PodBandwidth) error
+
+	// UninstallPodBandwidthMeters removes the OVS meters and flows installed by InstallPodBandwidthMeters for the local
+	// Pod specified with the interfaceName.
+	UninstallPodBandwidthMeters(interfaceName string) error
+
+	// InstallGatewayProxyFlows installs the flows to reply to the ARP requests sent by local Pods for the gateways of
+	// the provided subnets, and to route the traffic between local Pods of these subnets in different VLANs in OVS. The
+	// subnets must include all the subnets of IPPools with GatewayProxy enabled, and the flows installed for the
+	// subnets which are no longer provided are removed.
+	InstallGatewayProxyFlows(subnets []types.GatewayProxySubnet) error
+
 	// InstallServiceGroup installs a group for Service LB. Each endpoint
-	// is a bucket of the group. For now, each bucket has the same weight.
+	// is a bucket of the group, whose weight is the weight of the endpoint,
+	// or a default weight if the endpoint has no specific weight.
 	InstallServiceGroup(groupID binding.GroupIDType, withSessionAffinity bool, endpoints []proxy.Endpoint) error
 	// UninstallServiceGroup removes the group and its buckets that are
 	// installed by InstallServiceGroup.
@@ -100,25 +129,46 @@ type Client interface {
 
 	// InstallServiceFlows installs flows for accessing Service NodePort, LoadBalancer, ExternalIP and ClusterIP. It
 	// installs the flow that uses the group/bucket to do Service LB. If the affinityTimeout is not zero, it also
-	// installs the flow which has a learn action to maintain the LB decision. The group with the groupID must be
-	// installed before, otherwise the installation will fail.
+	// installs the flow which has a learn action to maintain the LB decision, whose key can be customized with
+	// affinityKey. The group with the groupID must be installed before, otherwise the installation will fail.
 	// When externalAddress is set and groupID != clusterGroupID, it also installs the flow to implement short-circuiting
 	// for external Service IPs.
 	// externalAddress indicates that whether the Service is externally accessible, like NodePort, LoadBalancer and ExternalIP.
 	// nested, when setting to true, indicates the Service's Endpoints are ClusterIPs of other Services.
-	InstallServiceFlows(groupID, clusterGroupID binding.GroupIDType, svcIP net.IP, svcPort uint16, protocol binding.Protocol, affinityTimeout uint16, externalAddress, nested bool) error
+	InstallServiceFlows(groupID, clusterGroupID binding.GroupIDType, svcIP net.IP, svcPort uint16, protocol binding.Protocol, affinityTimeout uint16, affinityKey *types.SessionAffinityKey, externalAddress, nested bool) error
+	// InstallServicePortRangeFlows installs the same flows as InstallServiceFlows for a Service port with a port range,
+	// matching the destination ports from svcPort to svcEndPort. The flows can be removed with UninstallServiceFlows
+	// and svcPort.
+	InstallServicePortRangeFlows(groupID, clusterGroupID binding.GroupIDType, svcIP net.IP, svcPort, svcEndPort uint16, protocol binding.Protocol, affinityTimeout uint16, affinityKey *types.SessionAffinityKey, externalAddress bool) error
+	// InstallServiceDSRFlows installs the flows for accessing an external address of a Service in DSR mode. The
+	// connections are load balanced with the group groupID, and the packets to remote Endpoints are forwarded to their
+	// Nodes without DNAT. The packets received from the tunnel are load balanced with the group localGroupID, which
+	// includes the local Endpoints only. The flows can be removed with UninstallServiceFlows.
+	InstallServiceDSRFlows(groupID, localGroupID binding.GroupIDType, svcIP net.IP, svcPort uint16, protocol binding.Protocol) error
 	// UninstallServiceFlows removes flows installed by InstallServiceFlows.
 	UninstallServiceFlows(svcIP net.IP, svcPort uint16, protocol binding.Protocol) error
+	// UpdateNodePortAddresses replaces the NodePort IP addresses provided in the ServiceConfig at initialization, and
+	// updates the flows marking the NodePort connections accordingly.
+	UpdateNodePortAddresses(nodePortAddressesIPv4, nodePortAddressesIPv6 []net.IP) error
 
 	// GetFlowTableStatus should return an array of flow table status, all existing flow tables should be included in the list.
 	GetFlowTableStatus() []binding.TableStatus
 
+	// MonitorCapacity periodically reports the numbers of OpenFlow groups and meters installed by the client in
+	// metrics, and logs a warning when the flows of a table, the groups or the meters exceed the provided soft
+	// limits, until stopCh is closed.
+	MonitorCapacity(limits types.OVSSoftLimits, stopCh <-chan struct{})
+
 	// InstallPolicyRuleFlows installs flows for a new NetworkPolicy rule. Rule should include all fields in the
 	// NetworkPolicy rule. Each ingress/egress policy rule installs Openflow entries on two tables, one for
 	// ruleTable and the other for dropTable. If a packet does not pass the ruleTable, it will be dropped by the
 	// dropTable.
 	InstallPolicyRuleFlows(ofPolicyRule *types.PolicyRule) error
 
+	// IsNetworkPolicyRateLimitSupported returns whether the rate limits of NetworkPolicy rules with action RateLimit
+	// can be enforced, i.e. the OVS datapath supports meters.
+	IsNetworkPolicyRateLimitSupported() bool
+
 	// BatchInstallPolicyRuleFlows installs multiple flows for NetworkPolicy rules in batch.
 	BatchInstallPolicyRuleFlows(ofPolicyRules []*types.PolicyRule) error
 
@@ -137,7 +187,8 @@ type Client interface {
 
 	// InstallSNATMarkFlows installs flows for a local SNAT IP. On Linux, a
 	// single flow is added to mark the packets tunnelled from remote Nodes
-	// that should be SNAT'd with the SNAT IP.
+	// that should be SNAT'd with the SNAT IP. On Windows, the flows to
+	// perform SNAT with the SNAT IP in OVS are added as well.
 	InstallSNATMarkFlows(snatIP net.IP, mark uint32) error
 
 	// UninstallSNATMarkFlows removes the flows installed to set the packet
@@ -158,6 +209,37 @@ type Client interface {
 	// UninstallPodSNATFlows removes the SNAT flows for the local Pod.
 	UninstallPodSNATFlows(ofPort uint32) error
 
+	// InstallPodDestinationSNATFlows installs the SNAT flows for a local
+	// Pod, which only apply to the egress packets destined for the provided
+	// CIDRs. They take precedence over the flow installed by
+	// InstallPodSNATFlows. The CIDRs whose address family doesn't match the
+	// SNAT IP are ignored. snatMark has the same meaning as in
+	// InstallPodSNATFlows.
+	InstallPodDestinationSNATFlows(ofPort uint32, destinationCIDRs []net.IPNet, snatIP net.IP, snatMark uint32) error
+
+	// UninstallPodDestinationSNATFlows removes the SNAT flows installed for
+	// the local Pod and the provided destination CIDRs.
+	UninstallPodDestinationSNATFlows(ofPort uint32, destinationCIDRs []net.IPNet) error
+
+	// InstallPodEgressDefaultDenyFlows installs the flow to drop the
+	// packets from a local Pod to the external network, unless they are
+	// SNAT'd by an Egress or allowed by an Antrea-native policy.
+	InstallPodEgressDefaultDenyFlows(ofPort uint32) error
+
+	// UninstallPodEgressDefaultDenyFlows removes the Egress default deny
+	// flow for the local Pod.
+	UninstallPodEgressDefaultDenyFlows(ofPort uint32) error
+
+	// InstallPodNeighborSpoofGuardFlows installs the flows to drop the ARP
+	// and NDP packets from a local Pod which don't carry the Pod's IP and
+	// MAC addresses. Calls to InstallPodNeighborSpoofGuardFlows are
+	// idempotent.
+	InstallPodNeighborSpoofGuardFlows(ofPort uint32, podInterfaceIPs []net.IP, podInterfaceMAC net.HardwareAddr) error
+
+	// UninstallPodNeighborSpoofGuardFlows removes the neighbor spoof guard
+	// flows for the local Pod.
+	UninstallPodNeighborSpoofGuardFlows(ofPort uint32) error
+
 	// Disconnect disconnects the connection between client and OFSwitch.
 	Disconnect() error
 
@@ -175,6 +257,12 @@ type Client interface {
 	// the new round number.
 	DeleteStaleFlows() error
 
+	// DeleteOrphanedFlowsAndGroups deletes the flows and groups which are installed on the OVS bridge, but are not
+	// expected by any feature anymore, e.g. because an operation failed after OVS had been updated. Only the flows of
+	// the current round which belong to the PodConnectivity, NetworkPolicy, Service and Egress categories are
+	// considered. It returns the orphaned flows and groups, which are not deleted if dryRun is true.
+	DeleteOrphanedFlowsAndGroups(dryRun bool) ([]string, []binding.GroupIDType, error)
+
 	// GetTunnelVirtualMAC() returns GlobalVirtualMAC used for tunnel traffic.
 	GetTunnelVirtualMAC() net.HardwareAddr
 
@@ -205,8 +293,9 @@ type Client interface {
 	// SendTraceflowPacket injects packet to specified OVS port for Openflow.
 	SendTraceflowPacket(dataplaneTag uint8, packet *binding.Packet, inPort uint32, outPort int32) error
 
-	// InstallTraceflowFlows installs flows for a Traceflow request.
-	InstallTraceflowFlows(dataplaneTag uint8, liveTraffic, droppedOnly, receiverOnly bool, packet *binding.Packet, ofPort uint32, timeoutSeconds uint16) error
+	// InstallTraceflowFlows installs flows for a Traceflow request. reverse indicates the flows are to trace the reply
+	// packet of a reverse-path Traceflow.
+	InstallTraceflowFlows(dataplaneTag uint8, liveTraffic, droppedOnly, receiverOnly, reverse bool, packet *binding.Packet, ofPort uint32, timeoutSeconds uint16) error
 
 	// UninstallTraceflowFlows uninstalls flows for a Traceflow request.
 	UninstallTraceflowFlows(dataplaneTag uint8) error
@@ -221,6 +310,10 @@ type Client interface {
 	RegisterPacketInHandler(packetHandlerReason uint8, packetInHandler interface{})
 
 	StartPacketInHandler(stopCh <-chan struct{})
+
+	// SetPacketInRate sets the maximum number of packetIn messages processed per second for each packetIn category.
+	// It can be called before or after StartPacketInHandler.
+	SetPacketInRate(rate int)
 	// Get traffic metrics of each NetworkPolicy rule.
 	NetworkPolicyMetrics() map[uint32]*types.RuleMetric
 
@@ -289,6 +382,10 @@ type Client interface {
 	AddAddressToDNSConjunction(id uint32, addrs []types.Address) error
 	// DeleteAddressFromDNSConjunction removes addresses from the toAddresses of the dns packetIn conjunction.
 	DeleteAddressFromDNSConjunction(id uint32, addrs []types.Address) error
+	// InstallNodeLocalDNSInterceptFlows installs the flows to make sure the dns response packets sent by the NodeLocal
+	// DNSCache listening on the given IPs are processed by the dns packetIn conjunction. The flows installed for the
+	// IPs not in the given list are removed.
+	InstallNodeLocalDNSInterceptFlows(dnsIPs []net.IP) error
 
 	// InstallMulticastFlows installs the flow to forward Multicast traffic normally, and output it to antrea-gw0
 	// to ensure it can be forwarded to the external addresses.
@@ -321,6 +418,12 @@ type Client interface {
 	// UninstallTrafficControlReturnPortFlow removes the flow to classify the packets from a return port.
 	UninstallTrafficControlReturnPortFlow(returnOFPort uint32) error
 
+	// InstallTrafficMirrorFlows installs the flows to mirror the packets matching the filters of a TrafficMirror.
+	InstallTrafficMirrorFlows(name string, sourceOFPorts []uint32, targetOFPort uint32, direction crdv1alpha2.Direction, filters []types.TrafficMirrorFilter) error
+
+	// UninstallTrafficMirrorFlows removes the flows for a TrafficMirror.
+	UninstallTrafficMirrorFlows(name string) error
+
 	InstallMulticastGroup(ofGroupID binding.GroupIDType, localReceivers []uint32, remoteNodeReceivers []net.IP) error
 	// UninstallMulticastGroup removes the group and its buckets that are
 	// installed by InstallMulticastGroup.
@@ -333,21 +436,35 @@ type Client interface {
 		igmp ofutil.Message) error
 
 	// InstallMulticlusterNodeFlows installs flows to handle cross-cluster packets between a regular
-	// Node and a local Gateway.
+	// Node and the local Gateways. When gatewayGroupID is not 0, cross-cluster request packets are
+	// hashed across the local Gateways with the group, otherwise they are tunneled to tunnelPeerIP.
+	// peerGatewayIPs are the Gateway IPs of the remote cluster besides the ones in peerConfigs.
 	InstallMulticlusterNodeFlows(
 		clusterID string,
 		peerConfigs map[*net.IPNet]net.IP,
 		tunnelPeerIP net.IP,
+		gatewayGroupID binding.GroupIDType,
+		peerGatewayIPs []net.IP,
 		enableStretchedNetworkPolicy bool) error
 
 	// InstallMulticlusterGatewayFlows installs flows to handle cross-cluster packets between Gateways.
+	// peerGatewayIPs are the Gateway IPs of the remote cluster besides the ones in peerConfigs.
 	InstallMulticlusterGatewayFlows(
 		clusterID string,
 		peerConfigs map[*net.IPNet]net.IP,
 		tunnelPeerIP net.IP,
 		localGatewayIP net.IP,
+		peerGatewayIPs []net.IP,
 		enableStretchedNetworkPolicy bool) error
 
+	// InstallMulticlusterGatewayGroup installs or updates the group used on a regular Node to hash
+	// cross-cluster connections across the active Gateways of the local cluster.
+	InstallMulticlusterGatewayGroup(groupID binding.GroupIDType, tunnelPeerIPs []net.IP) error
+
+	// UninstallMulticlusterGatewayGroup removes the group used to hash cross-cluster connections
+	// across the active Gateways of the local cluster.
+	UninstallMulticlusterGatewayGroup(groupID binding.GroupIDType) error
+
 	// InstallMulticlusterClassifierFlows installs flows to classify cross-cluster packets.
 	InstallMulticlusterClassifierFlows(tunnelOFPort uint32, isGateway bool) error
 
@@ -525,7 +642,7 @@ func (c *client) deleteAllFlows(cache *flowCategoryCache) error {
 func (c *client) InstallNodeFlows(hostname string,
 	peerConfigs map[*net.IPNet]net.IP,
 	tunnelPeerIPs *utilip.DualStackIPs,
-	ipsecTunOFPort uint32,
+	tunOFPort uint32,
 	remoteGatewayMAC net.HardwareAddr,
 ) error {
 	c.replayMutex.RLock()
@@ -550,6 +667,9 @@ func (c *client) InstallNodeFlows(hostname string,
 		if (!isIPv6 && c.networkConfig.NeedsTunnelToPeer(tunnelPeerIPs.IPv4, c.nodeConfig.NodeTransportIPv4Addr)) ||
 			(isIPv6 && c.networkConfig.NeedsTunnelToPeer(tunnelPeerIPs.IPv6, c.nodeConfig.NodeTransportIPv6Addr)) {
 			flows = append(flows, c.featurePodConnectivity.l3FwdFlowToRemoteViaTun(localGatewayMAC, *peerPodCIDR, tunnelPeerIP))
+			if !isIPv6 && c.enableProxy && c.featureService.enableLoadBalancerModeDSR {
+				flows = append(flows, c.featureService.endpointDSRFlows(*peerPodCIDR, tunnelPeerIP)...)
+			}
 		} else {
 			flows = append(flows, c.featurePodConnectivity.l3FwdFlowToRemoteViaRouting(localGatewayMAC, remoteGatewayMAC, tunnelPeerIP, peerPodCIDR)...)
 		}
@@ -561,11 +681,20 @@ func (c *client) InstallNodeFlows(hostname string,
 			flows = append(flows, c.featurePodConnectivity.l3FwdFlowToRemoteViaUplink(remoteGatewayMAC, *peerPodCIDR, true))
 		}
 	}
-	if ipsecTunOFPort != 0 {
-		// When IPsec tunnel is enabled, packets received from the remote Node are
-		// input from the Node's IPsec tunnel port, not the default tunnel port. So,
-		// add a separate tunnelClassifierFlow for the IPsec tunnel port.
-		flows = append(flows, c.featurePodConnectivity.tunnelClassifierFlow(ipsecTunOFPort))
+	if tunOFPort != 0 {
+		// When a tunnel port is dedicated to the remote Node, packets received from the
+		// remote Node are input from this tunnel port, not the default tunnel port. So,
+		// add a separate tunnelClassifierFlow for the tunnel port.
+		flows = append(flows, c.featurePodConnectivity.tunnelClassifierFlow(tunOFPort))
+		// Packets sent to the remote Node must also be output to this tunnel port, as
+		// its type or destination port may differ from the default tunnel port.
+		tunnelPeerIP := tunnelPeerIPs.IPv4
+		if tunnelPeerIP == nil {
+			tunnelPeerIP = tunnelPeerIPs.IPv6
+		}
+		if tunnelPeerIP != nil {
+			flows = append(flows, c.featurePodConnectivity.l2ForwardCalcFlowToNodeTunnel(tunnelPeerIP, tunOFPort))
+		}
 	}
 
 	// For Windows Noencap Mode, the OVS flows for Node need to be exactly same as the provided 'flows' slice because
@@ -647,6 +776,97 @@ func (c *client) UninstallPodFlows(interfaceName string) error {
 	return nil
 }
 
+func (c *client) IsPodBandwidthMeterSupported() bool {
+	return c.enablePodBandwidthMeter && c.ovsMetersAreSupported
+}
+
+func (c *client) IsNetworkPolicyRateLimitSupported() bool {
+	return c.ovsMetersAreSupported
+}
+
+func (c *client) InstallPodBandwidthMeters(interfaceName string, ofPort uint32, bandwidth *types.PodBandwidth) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	if !c.IsPodBandwidthMeterSupported() {
+		return fmt.Errorf("OVS meters are not supported for Pod bandwidth limits")
+	}
+	if err := c.uninstallPodBandwidthMeters(interfaceName); err != nil {
+		return err
+	}
+
+	ingressMeterID, egressMeterID := podBandwidthMeterIDs(ofPort)
+	var meters []binding.Meter
+	if bandwidth.IngressRate > 0 {
+		meters = append(meters, c.genPodBandwidthMeter(ingressMeterID, bandwidth.IngressRate, bandwidth.IngressBurst))
+	} else {
+		ingressMeterID = 0
+	}
+	if bandwidth.EgressRate > 0 {
+		meters = append(meters, c.genPodBandwidthMeter(egressMeterID, bandwidth.EgressRate, bandwidth.EgressBurst))
+	} else {
+		egressMeterID = 0
+	}
+	if len(meters) == 0 {
+		return nil
+	}
+	// The meters must be added before the flows referencing them.
+	var installedMeters []binding.Meter
+	success := false
+	defer func() {
+		if !success {
+			for _, meter := range installedMeters {
+				if err := meter.Delete(); err != nil {
+					klog.ErrorS(err, "Failed to delete Pod bandwidth meter", "interface", interfaceName)
+				}
+			}
+		}
+	}()
+	for _, meter := range meters {
+		if err := meter.Add(); err != nil {
+			return fmt.Errorf("failed to install OpenFlow meter entry for Pod bandwidth limits: %w", err)
+		}
+		installedMeters = append(installedMeters, meter)
+	}
+	flows := c.featurePodConnectivity.podBandwidthFlows(ofPort, ingressMeterID, egressMeterID)
+	if err := c.addFlows(c.featurePodConnectivity.podBandwidthCachedFlows, interfaceName, flows); err != nil {
+		return err
+	}
+	c.featurePodConnectivity.podBandwidthMeters.Store(interfaceName, meters)
+	success = true
+	return nil
+}
+
+func (c *client) UninstallPodBandwidthMeters(interfaceName string) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.uninstallPodBandwidthMeters(interfaceName)
+}
+
+func (c *client) uninstallPodBandwidthMeters(interfaceName string) error {
+	// The flows must be deleted before the meters referenced by them.
+	if err := c.deleteFlows(c.featurePodConnectivity.podBandwidthCachedFlows, interfaceName); err != nil {
+		return err
+	}
+	value, ok := c.featurePodConnectivity.podBandwidthMeters.Load(interfaceName)
+	if !ok {
+		return nil
+	}
+	for _, meter := range value.([]binding.Meter) {
+		if err := meter.Delete(); err != nil {
+			return fmt.Errorf("failed to delete OpenFlow meter entry for Pod bandwidth limits: %w", err)
+		}
+	}
+	c.featurePodConnectivity.podBandwidthMeters.Delete(interfaceName)
+	return nil
+}
+
+func (c *client) InstallGatewayProxyFlows(subnets []types.GatewayProxySubnet) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	flows := c.featurePodConnectivity.gatewayProxyFlows(subnets)
+	return c.modifyFlows(c.featurePodConnectivity.nodeCachedFlows, "gateway_proxy", flows)
+}
+
 func (c *client) getFlowKeysFromCache(cache *flowCategoryCache, cacheKey string) []string {
 	fCacheI, ok := cache.Load(cacheKey)
 	if !ok {
@@ -674,7 +894,47 @@ func (c *client) InstallServiceGroup(groupID binding.GroupIDType, withSessionAff
 	c.replayMutex.RLock()
 	defer c.replayMutex.RUnlock()
 
-	group := c.featureService.serviceEndpointGroup(groupID, withSessionAffinity, endpoints...)
+	group, subGroups := c.featureService.serviceEndpointGroups(groupID, withSessionAffinity, endpoints...)
+	var installedSubGroups map[binding.GroupIDType]binding.Group
+	if cached, ok := c.featureService.subGroupCache.Load(groupID); ok {
+		installedSubGroups = cached.(map[binding.GroupIDType]binding.Group)
+	}
+	// The sub-groups must exist before the group refers to them, and can only be deleted after the group stops
+	// referring to them.
+	var addSubGroups, modSubGroups []binding.OFEntry
+	staleSubGroups := make(map[binding.GroupIDType]binding.Group)
+	for subGroupID, subGroup := range subGroups {
+		if _, ok := installedSubGroups[subGroupID]; ok {
+			modSubGroups = append(modSubGroups, subGroup)
+		} else {
+			addSubGroups = append(addSubGroups, subGroup)
+		}
+	}
+	for subGroupID, subGroup := range installedSubGroups {
+		if _, ok := subGroups[subGroupID]; !ok {
+			staleSubGroups[subGroupID] = subGroup
+		}
+	}
+	if len(modSubGroups) > 0 {
+		if err := c.ofEntryOperations.ModifyOFEntries(modSubGroups); err != nil {
+			return fmt.Errorf("error when modifying sub-groups of Service Endpoints Group %d: %w", groupID, err)
+		}
+	}
+	if len(addSubGroups) > 0 {
+		if err := c.ofEntryOperations.AddOFEntries(addSubGroups); err != nil {
+			return fmt.Errorf("error when installing sub-groups of Service Endpoints Group %d: %w", groupID, err)
+		}
+		// Cache the sub-groups as soon as they are installed, so that they are modified instead of added again if the
+		// installation of the group fails and is retried. The stale ones are kept until they are deleted.
+		cachedSubGroups := make(map[binding.GroupIDType]binding.Group, len(installedSubGroups)+len(subGroups))
+		for subGroupID, subGroup := range installedSubGroups {
+			cachedSubGroups[subGroupID] = subGroup
+		}
+		for subGroupID, subGroup := range subGroups {
+			cachedSubGroups[subGroupID] = subGroup
+		}
+		c.featureService.subGroupCache.Store(groupID, cachedSubGroups)
+	}
 	_, installed := c.featureService.groupCache.Load(groupID)
 	if !installed {
 		if err := c.ofEntryOperations.AddOFEntries([]binding.OFEntry{group}); err != nil {
@@ -686,6 +946,31 @@ func (c *client) InstallServiceGroup(groupID binding.GroupIDType, withSessionAff
 		}
 	}
 	c.featureService.groupCache.Store(groupID, group)
+	if len(staleSubGroups) > 0 {
+		if err := c.uninstallServiceSubGroups(staleSubGroups); err != nil {
+			return fmt.Errorf("error when deleting stale sub-groups of Service Endpoints Group %d: %w", groupID, err)
+		}
+	}
+	if len(subGroups) > 0 {
+		c.featureService.subGroupCache.Store(groupID, subGroups)
+	} else {
+		c.featureService.subGroupCache.Delete(groupID)
+	}
+	return nil
+}
+
+// uninstallServiceSubGroups deletes the given sub-groups of a Service group and releases their IDs.
+func (c *client) uninstallServiceSubGroups(subGroups map[binding.GroupIDType]binding.Group) error {
+	entries := make([]binding.OFEntry, 0, len(subGroups))
+	for _, subGroup := range subGroups {
+		entries = append(entries, subGroup)
+	}
+	if err := c.ofEntryOperations.DeleteOFEntries(entries); err != nil {
+		return err
+	}
+	for subGroupID := range subGroups {
+		c.featureService.subGroupAllocator.Release(subGroupID)
+	}
 	return nil
 }
 
@@ -699,6 +984,12 @@ func (c *client) UninstallServiceGroup(groupID binding.GroupIDType) error {
 		}
 		c.featureService.groupCache.Delete(groupID)
 	}
+	if cached, ok := c.featureService.subGroupCache.Load(groupID); ok {
+		if err := c.uninstallServiceSubGroups(cached.(map[binding.GroupIDType]binding.Group)); err != nil {
+			return fmt.Errorf("error when deleting sub-groups of Service Endpoints Group %d: %w", groupID, err)
+		}
+		c.featureService.subGroupCache.Delete(groupID)
+	}
 	return nil
 }
 
@@ -723,7 +1014,8 @@ func (c *client) InstallEndpointFlows(protocol binding.Protocol, endpoints []pro
 		portVal := util.PortToUint16(endpointPort)
 		cacheKey := generateEndpointFlowCacheKey(endpoint.IP(), endpointPort, protocol)
 		flows = append(flows, c.featureService.endpointDNATFlow(endpointIP, portVal, protocol))
-		if endpoint.GetIsLocal() {
+		// There is no hairpin Service connection on an external Node, as no Pod runs on it.
+		if endpoint.GetIsLocal() && c.nodeType == config.K8sNode {
 			flows = append(flows, c.featureService.podHairpinSNATFlow(endpointIP))
 		}
 		keyToFlows[cacheKey] = flows
@@ -750,20 +1042,48 @@ func (c *client) UninstallEndpointFlows(protocol binding.Protocol, endpoints []p
 	return c.deleteFlowsWithMultipleKeys(c.featureService.cachedFlows, flowCacheKeys)
 }
 
-func (c *client) InstallServiceFlows(groupID, clusterGroupID binding.GroupIDType, svcIP net.IP, svcPort uint16, protocol binding.Protocol, affinityTimeout uint16, externalAddress, nested bool) error {
+func (c *client) InstallServiceFlows(groupID, clusterGroupID binding.GroupIDType, svcIP net.IP, svcPort uint16, protocol binding.Protocol, affinityTimeout uint16, affinityKey *types.SessionAffinityKey, externalAddress, nested bool) error {
 	c.replayMutex.RLock()
 	defer c.replayMutex.RUnlock()
 	var flows []binding.Flow
 	nodePortAddress := svcIP.Equal(config.VirtualNodePortDNATIPv4) || svcIP.Equal(config.VirtualNodePortDNATIPv6)
-	flows = append(flows, c.featureService.serviceLBFlow(groupID, svcIP, svcPort, protocol, affinityTimeout != 0, externalAddress, nodePortAddress, nested, false))
+	flows = append(flows, c.featureService.serviceLBFlow(groupID, svcIP, svcPort, nil, protocol, affinityTimeout != 0, externalAddress, nodePortAddress, nested, false))
 	if affinityTimeout != 0 {
-		flows = append(flows, c.featureService.serviceLearnFlow(groupID, svcIP, svcPort, protocol, affinityTimeout, externalAddress, nodePortAddress))
+		flows = append(flows, c.featureService.serviceLearnFlow(groupID, svcIP, svcPort, nil, protocol, affinityTimeout, affinityKey, externalAddress, nodePortAddress))
 	}
 	if !externalAddress && !nested {
 		flows = append(flows, c.featureService.endpointRedirectFlowForServiceIP(svcIP, svcPort, protocol, groupID))
 	}
 	if externalAddress && groupID != clusterGroupID {
-		flows = append(flows, c.featureService.serviceLBFlow(clusterGroupID, svcIP, svcPort, protocol, affinityTimeout != 0, true, nodePortAddress, false, true))
+		flows = append(flows, c.featureService.serviceLBFlow(clusterGroupID, svcIP, svcPort, nil, protocol, affinityTimeout != 0, true, nodePortAddress, false, true))
+	}
+	cacheKey := generateServicePortFlowCacheKey(svcIP, svcPort, protocol)
+	return c.addFlows(c.featureService.cachedFlows, cacheKey, flows)
+}
+
+func (c *client) InstallServiceDSRFlows(groupID, localGroupID binding.GroupIDType, svcIP net.IP, svcPort uint16, protocol binding.Protocol) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	flows := c.featureService.serviceDSRLBFlows(groupID, localGroupID, svcIP, svcPort, protocol)
+	cacheKey := generateServicePortFlowCacheKey(svcIP, svcPort, protocol)
+	return c.addFlows(c.featureService.cachedFlows, cacheKey, flows)
+}
+
+func (c *client) InstallServicePortRangeFlows(groupID, clusterGroupID binding.GroupIDType, svcIP net.IP, svcPort, svcEndPort uint16, protocol binding.Protocol, affinityTimeout uint16, affinityKey *types.SessionAffinityKey, externalAddress bool) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	var flows []binding.Flow
+	port := intstr.FromInt(int(svcPort))
+	endPort := int32(svcEndPort)
+	// The port range is matched with the minimum number of bitwise matches.
+	for _, bitRange := range portsToBitRanges(&port, &endPort) {
+		flows = append(flows, c.featureService.serviceLBFlow(groupID, svcIP, bitRange.Value, bitRange.Mask, protocol, affinityTimeout != 0, externalAddress, false, false, false))
+		if affinityTimeout != 0 {
+			flows = append(flows, c.featureService.serviceLearnFlow(groupID, svcIP, bitRange.Value, bitRange.Mask, protocol, affinityTimeout, affinityKey, externalAddress, false))
+		}
+		if externalAddress && groupID != clusterGroupID {
+			flows = append(flows, c.featureService.serviceLBFlow(clusterGroupID, svcIP, bitRange.Value, bitRange.Mask, protocol, affinityTimeout != 0, true, false, false, true))
+		}
 	}
 	cacheKey := generateServicePortFlowCacheKey(svcIP, svcPort, protocol)
 	return c.addFlows(c.featureService.cachedFlows, cacheKey, flows)
@@ -776,6 +1096,50 @@ func (c *client) UninstallServiceFlows(svcIP net.IP, svcPort uint16, protocol bi
 	return c.deleteFlows(c.featureService.cachedFlows, cacheKey)
 }
 
+func (c *client) UpdateNodePortAddresses(nodePortAddressesIPv4, nodePortAddressesIPv6 []net.IP) error {
+	// The flows are regenerated from featureService.nodePortAddresses when replaying flows, so exclusive access is
+	// required.
+	c.replayMutex.Lock()
+	defer c.replayMutex.Unlock()
+	f := c.featureService
+	getFlowMessages := func() map[string]*openflow15.FlowMod {
+		messages := map[string]*openflow15.FlowMod{}
+		for _, flow := range f.nodePortMarkFlows() {
+			messages[flow.MatchString()] = getFlowModMessage(flow, binding.AddMessage)
+		}
+		return messages
+	}
+	oldMessages := getFlowMessages()
+	oldNodePortAddresses := f.nodePortAddresses
+	newNodePortAddresses := make(map[binding.Protocol][]net.IP)
+	for _, ipProtocol := range f.ipProtocols {
+		if ipProtocol == binding.ProtocolIP {
+			newNodePortAddresses[ipProtocol] = nodePortAddressesIPv4
+		} else if ipProtocol == binding.ProtocolIPv6 {
+			newNodePortAddresses[ipProtocol] = nodePortAddressesIPv6
+		}
+	}
+	f.nodePortAddresses = newNodePortAddresses
+	newMessages := getFlowMessages()
+
+	var adds, dels []*openflow15.FlowMod
+	for key, msg := range newMessages {
+		if _, ok := oldMessages[key]; !ok {
+			adds = append(adds, msg)
+		}
+	}
+	for key, msg := range oldMessages {
+		if _, ok := newMessages[key]; !ok {
+			dels = append(dels, msg)
+		}
+	}
+	if err := c.ofEntryOperations.BundleOps(adds, nil, dels); err != nil {
+		f.nodePortAddresses = oldNodePortAddresses
+		return fmt.Errorf("failed to update NodePort mark flows: %w", err)
+	}
+	return nil
+}
+
 func (c *client) GetServiceFlowKeys(svcIP net.IP, svcPort uint16, protocol binding.Protocol, endpoints []proxy.Endpoint) []string {
 	cacheKey := generateServicePortFlowCacheKey(svcIP, svcPort, protocol)
 	flowKeys := c.getFlowKeysFromCache(c.featureService.cachedFlows, cacheKey)
@@ -788,14 +1152,17 @@ func (c *client) GetServiceFlowKeys(svcIP net.IP, svcPort uint16, protocol bindi
 }
 
 func (c *client) initialize() error {
-	if err := c.ofEntryOperations.AddAll(c.defaultFlows()); err != nil {
+	c.initialFlows = c.defaultFlows()
+	if err := c.ofEntryOperations.AddAll(c.initialFlows); err != nil {
 		return fmt.Errorf("failed to install default flows: %v", err)
 	}
 
 	for _, activeFeature := range c.activatedFeatures {
-		if err := c.ofEntryOperations.AddAll(activeFeature.initFlows()); err != nil {
+		flows := activeFeature.initFlows()
+		if err := c.ofEntryOperations.AddAll(flows); err != nil {
 			return fmt.Errorf("failed to install feature %v initial flows: %v", activeFeature.getFeatureName(), err)
 		}
+		c.initialFlows = append(c.initialFlows, flows...)
 	}
 
 	if c.ovsMetersAreSupported {
@@ -875,7 +1242,8 @@ func (c *client) generatePipelines() {
 			c.connectUplinkToBridge,
 			c.enableMulticast,
 			c.proxyAll,
-			c.enableTrafficControl)
+			c.enableTrafficControl,
+			c.IsPodBandwidthMeterSupported())
 		c.activatedFeatures = append(c.activatedFeatures, c.featurePodConnectivity)
 		c.traceableFeatures = append(c.traceableFeatures, c.featurePodConnectivity)
 
@@ -888,14 +1256,31 @@ func (c *client) generatePipelines() {
 			c.enableAntreaPolicy,
 			c.enableProxy,
 			c.proxyAll,
-			c.connectUplinkToBridge)
+			c.connectUplinkToBridge,
+			c.nodeType)
 		c.activatedFeatures = append(c.activatedFeatures, c.featureService)
 		c.traceableFeatures = append(c.traceableFeatures, c.featureService)
 	}
 
 	if c.nodeType == config.ExternalNode {
-		c.featureExternalNodeConnectivity = newFeatureExternalNodeConnectivity(c.cookieAllocator, c.ipProtocols)
+		c.featureExternalNodeConnectivity = newFeatureExternalNodeConnectivity(c.cookieAllocator, c.ipProtocols, c.enableProxy)
 		c.activatedFeatures = append(c.activatedFeatures, c.featureExternalNodeConnectivity)
+
+		if c.enableProxy {
+			// AntreaProxy load-balances the Service connections initiated by the workloads of the external Node.
+			c.featureService = newFeatureService(c.cookieAllocator,
+				c.ipProtocols,
+				c.nodeConfig,
+				c.networkConfig,
+				c.serviceConfig,
+				c.bridge,
+				c.enableAntreaPolicy,
+				c.enableProxy,
+				false,
+				false,
+				c.nodeType)
+			c.activatedFeatures = append(c.activatedFeatures, c.featureService)
+		}
 	}
 
 	c.featureNetworkPolicy = newFeatureNetworkPolicy(c.cookieAllocator,
@@ -909,6 +1294,7 @@ func (c *client) generatePipelines() {
 		c.enableMulticast,
 		c.proxyAll,
 		c.connectUplinkToBridge,
+		c.enableEgress && c.egressConfig.EnableDefaultDeny,
 		c.nodeType)
 	c.activatedFeatures = append(c.activatedFeatures, c.featureNetworkPolicy)
 	c.traceableFeatures = append(c.traceableFeatures, c.featureNetworkPolicy)
@@ -925,7 +1311,7 @@ func (c *client) generatePipelines() {
 	}
 
 	if c.enableMulticluster {
-		c.featureMulticluster = newFeatureMulticluster(c.cookieAllocator, []binding.Protocol{binding.ProtocolIP})
+		c.featureMulticluster = newFeatureMulticluster(c.cookieAllocator, []binding.Protocol{binding.ProtocolIP}, c.bridge)
 		c.activatedFeatures = append(c.activatedFeatures, c.featureMulticluster)
 	}
 
@@ -981,11 +1367,17 @@ func (c *client) generatePipelines() {
 }
 
 func (c *client) InstallSNATMarkFlows(snatIP net.IP, mark uint32) error {
-	flow := c.featureEgress.snatIPFromTunnelFlow(snatIP, mark)
+	flows := []binding.Flow{c.featureEgress.snatIPFromTunnelFlow(snatIP, mark)}
+	if runtime.IsWindowsPlatform() {
+		if c.featureEgress.uplinkGatewayMAC == nil {
+			return fmt.Errorf("unable to install SNAT flows for IP %s as the MAC address of the uplink default gateway is unknown", snatIP)
+		}
+		flows = append(flows, c.featureEgress.snatIPConntrackFlows(snatIP, mark)...)
+	}
 	cacheKey := fmt.Sprintf("s%x", mark)
 	c.replayMutex.RLock()
 	defer c.replayMutex.RUnlock()
-	return c.addFlows(c.featureEgress.cachedFlows, cacheKey, []binding.Flow{flow})
+	return c.addFlows(c.featureEgress.cachedFlows, cacheKey, flows)
 }
 
 func (c *client) UninstallSNATMarkFlows(mark uint32) error {
@@ -1010,6 +1402,67 @@ func (c *client) UninstallPodSNATFlows(ofPort uint32) error {
 	return c.deleteFlows(c.featureEgress.cachedFlows, cacheKey)
 }
 
+func podDestinationSNATFlowsCacheKey(ofPort uint32, destinationCIDRs []net.IPNet) string {
+	cidrs := make([]string, 0, len(destinationCIDRs))
+	for i := range destinationCIDRs {
+		cidrs = append(cidrs, destinationCIDRs[i].String())
+	}
+	sort.Strings(cidrs)
+	return fmt.Sprintf("p%x-%s", ofPort, strings.Join(cidrs, ","))
+}
+
+func (c *client) InstallPodDestinationSNATFlows(ofPort uint32, destinationCIDRs []net.IPNet, snatIP net.IP, snatMark uint32) error {
+	isIPv6 := snatIP.To4() == nil
+	var flows []binding.Flow
+	for _, cidr := range destinationCIDRs {
+		if (cidr.IP.To4() == nil) != isIPv6 {
+			continue
+		}
+		flows = append(flows, c.featureEgress.snatRuleDestinationFlow(ofPort, cidr, snatIP, snatMark, c.nodeConfig.GatewayConfig.MAC))
+	}
+	cacheKey := podDestinationSNATFlowsCacheKey(ofPort, destinationCIDRs)
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.addFlows(c.featureEgress.cachedFlows, cacheKey, flows)
+}
+
+func (c *client) UninstallPodDestinationSNATFlows(ofPort uint32, destinationCIDRs []net.IPNet) error {
+	cacheKey := podDestinationSNATFlowsCacheKey(ofPort, destinationCIDRs)
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.deleteFlows(c.featureEgress.cachedFlows, cacheKey)
+}
+
+func (c *client) InstallPodEgressDefaultDenyFlows(ofPort uint32) error {
+	flows := []binding.Flow{c.featureEgress.podDefaultDenyFlow(ofPort)}
+	cacheKey := fmt.Sprintf("d%x", ofPort)
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.addFlows(c.featureEgress.cachedFlows, cacheKey, flows)
+}
+
+func (c *client) UninstallPodEgressDefaultDenyFlows(ofPort uint32) error {
+	cacheKey := fmt.Sprintf("d%x", ofPort)
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.deleteFlows(c.featureEgress.cachedFlows, cacheKey)
+}
+
+func (c *client) InstallPodNeighborSpoofGuardFlows(ofPort uint32, podInterfaceIPs []net.IP, podInterfaceMAC net.HardwareAddr) error {
+	flows := c.featurePodConnectivity.podNeighborSpoofGuardFlows(podInterfaceIPs, podInterfaceMAC, ofPort)
+	cacheKey := fmt.Sprintf("neighbor_spoofguard_%d", ofPort)
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.modifyFlows(c.featurePodConnectivity.podCachedFlows, cacheKey, flows)
+}
+
+func (c *client) UninstallPodNeighborSpoofGuardFlows(ofPort uint32) error {
+	cacheKey := fmt.Sprintf("neighbor_spoofguard_%d", ofPort)
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.deleteFlows(c.featurePodConnectivity.podCachedFlows, cacheKey)
+}
+
 func (c *client) ReplayFlows() {
 	c.replayMutex.Lock()
 	defer c.replayMutex.Unlock()
@@ -1024,6 +1477,15 @@ func (c *client) ReplayFlows() {
 	if c.enableMulticast {
 		c.featureMulticast.replayGroups()
 	}
+	if c.enableMulticluster {
+		c.featureMulticluster.replayGroups()
+	}
+	if c.featurePodConnectivity != nil {
+		c.featurePodConnectivity.replayMeters()
+	}
+	if c.featureNetworkPolicy != nil {
+		c.featureNetworkPolicy.replayMeters()
+	}
 
 	for _, activeFeature := range c.activatedFeatures {
 		if err := c.ofEntryOperations.AddAll(activeFeature.replayFlows()); err != nil {
@@ -1045,6 +1507,126 @@ func (c *client) DeleteStaleFlows() error {
 	return c.deleteFlowsByRoundNum(*c.roundInfo.PrevRoundNum)
 }
 
+// orphanedFlowCategories are the categories of the flows which are garbage collected by DeleteOrphanedFlowsAndGroups.
+// All the flows of these categories are cached by the features, while some flows of the other categories, e.g. the
+// Traceflow flows, are installed without being cached.
+var orphanedFlowCategories = []cookie.Category{cookie.PodConnectivity, cookie.NetworkPolicy, cookie.Service, cookie.Egress}
+
+func (c *client) DeleteOrphanedFlowsAndGroups(dryRun bool) ([]string, []binding.GroupIDType, error) {
+	// Hold the write lock, so that no flow or group is installed or uninstalled while the installed ones are compared
+	// with the expected ones.
+	c.replayMutex.Lock()
+	defer c.replayMutex.Unlock()
+
+	expectedFlows := sets.New[string]()
+	for _, flow := range c.initialFlows {
+		expectedFlows.Insert(getFlowKey(flow))
+	}
+	for _, activeFeature := range c.activatedFeatures {
+		for _, flow := range activeFeature.replayFlows() {
+			expectedFlows.Insert(getFlowKey(flow))
+		}
+	}
+	var orphanedFlows []*openflow15.FlowMod
+	var orphanedFlowStrings []string
+	for _, category := range orphanedFlowCategories {
+		cookieID, cookieMask := cookie.CookieMaskForCategory(c.roundInfo.RoundNum, category)
+		flowDescs, err := c.bridge.DumpFlowDescs(cookieID, cookieMask)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error when dumping flows of category %s: %w", category, err)
+		}
+		for _, flowDesc := range flowDescs {
+			flow := flowDescToFlowMod(flowDesc)
+			if !expectedFlows.Has(getFlowKey(flow)) {
+				orphanedFlows = append(orphanedFlows, flow)
+				orphanedFlowStrings = append(orphanedFlowStrings, getFlowKey(flow))
+			}
+		}
+	}
+
+	expectedGroups := sets.New[binding.GroupIDType]()
+	var groupCaches []*sync.Map
+	if c.featureService != nil {
+		groupCaches = append(groupCaches, &c.featureService.groupCache)
+	}
+	if c.featureMulticast != nil {
+		groupCaches = append(groupCaches, &c.featureMulticast.groupCache)
+	}
+	if c.featureMulticluster != nil {
+		groupCaches = append(groupCaches, &c.featureMulticluster.groupCache)
+	}
+	for _, groupCache := range groupCaches {
+		groupCache.Range(func(id, _ interface{}) bool {
+			expectedGroups.Insert(id.(binding.GroupIDType))
+			return true
+		})
+	}
+	if c.featureService != nil {
+		c.featureService.subGroupCache.Range(func(_, value interface{}) bool {
+			for subGroupID := range value.(map[binding.GroupIDType]binding.Group) {
+				expectedGroups.Insert(subGroupID)
+			}
+			return true
+		})
+	}
+	groupStrings, err := c.ovsctlClient.DumpGroups()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error when dumping groups: %w", err)
+	}
+	var orphanedGroupIDs []binding.GroupIDType
+	for _, groupString := range groupStrings {
+		groupID, err := parseGroupID(groupString)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !expectedGroups.Has(groupID) {
+			orphanedGroupIDs = append(orphanedGroupIDs, groupID)
+		}
+	}
+
+	if dryRun {
+		return orphanedFlowStrings, orphanedGroupIDs, nil
+	}
+	if len(orphanedFlows) > 0 {
+		if err := c.ofEntryOperations.DeleteAll(orphanedFlows); err != nil {
+			return nil, nil, fmt.Errorf("error when deleting orphaned flows: %w", err)
+		}
+	}
+	// The flows must be deleted before the groups, as a group cannot be deleted while a flow is referring to it.
+	if len(orphanedGroupIDs) > 0 {
+		groups := make([]binding.OFEntry, 0, len(orphanedGroupIDs))
+		for _, groupID := range orphanedGroupIDs {
+			groups = append(groups, c.bridge.NewGroup(groupID))
+		}
+		if err := c.ofEntryOperations.DeleteOFEntries(groups); err != nil {
+			return nil, nil, fmt.Errorf("error when deleting orphaned groups: %w", err)
+		}
+	}
+	return orphanedFlowStrings, orphanedGroupIDs, nil
+}
+
+// flowDescToFlowMod converts a flow dumped from OVS to a FlowMod message which can be used to delete it.
+func flowDescToFlowMod(flowDesc *openflow15.FlowDesc) *openflow15.FlowMod {
+	flowMod := openflow15.NewFlowMod()
+	flowMod.TableId = flowDesc.TableId
+	flowMod.Priority = flowDesc.Priority
+	flowMod.Cookie = flowDesc.Cookie
+	flowMod.Match = flowDesc.Match
+	flowMod.OutPort = openflow15.P_ANY
+	flowMod.OutGroup = openflow15.OFPG_ANY
+	return flowMod
+}
+
+// parseGroupID parses the ID of a group dumped by "ovs-ofctl dump-groups", e.g. "group_id=1,type=select,...".
+func parseGroupID(groupString string) (binding.GroupIDType, error) {
+	idString := strings.TrimPrefix(strings.SplitN(groupString, ",", 2)[0], "group_id=")
+	groupID, err := strconv.ParseUint(idString, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid group %q: %w", groupString, err)
+	}
+	return binding.GroupIDType(groupID), nil
+}
+
 func (c *client) SubscribePacketIn(category uint8, pktInQueue *binding.PacketInQueue) error {
 	return c.bridge.SubscribePacketIn(category, pktInQueue)
 }
@@ -1116,7 +1698,7 @@ func (c *client) SendTraceflowPacket(dataplaneTag uint8, packet *binding.Packet,
 	return c.bridge.SendPacketOut(packetOutObj)
 }
 
-func (c *client) InstallTraceflowFlows(dataplaneTag uint8, liveTraffic, droppedOnly, receiverOnly bool, packet *binding.Packet, ofPort uint32, timeoutSeconds uint16) error {
+func (c *client) InstallTraceflowFlows(dataplaneTag uint8, liveTraffic, droppedOnly, receiverOnly, reverse bool, packet *binding.Packet, ofPort uint32, timeoutSeconds uint16) error {
 	cacheKey := fmt.Sprintf("%x", dataplaneTag)
 	var flows []binding.Flow
 	for _, f := range c.traceableFeatures {
@@ -1125,6 +1707,7 @@ func (c *client) InstallTraceflowFlows(dataplaneTag uint8, liveTraffic, droppedO
 			liveTraffic,
 			droppedOnly,
 			receiverOnly,
+			reverse,
 			packet,
 			ofPort,
 			timeoutSeconds)...)
@@ -1388,6 +1971,21 @@ func (c *client) UninstallTrafficControlReturnPortFlow(returnOFPort uint32) erro
 	return c.deleteFlows(c.featurePodConnectivity.tcCachedFlows, cacheKey)
 }
 
+func (c *client) InstallTrafficMirrorFlows(name string, sourceOFPorts []uint32, targetOFPort uint32, direction crdv1alpha2.Direction, filters []types.TrafficMirrorFilter) error {
+	flows := c.featurePodConnectivity.trafficMirrorMarkFlows(sourceOFPorts, targetOFPort, direction, filters)
+	cacheKey := fmt.Sprintf("tm_%s", name)
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.modifyFlows(c.featurePodConnectivity.tcCachedFlows, cacheKey, flows)
+}
+
+func (c *client) UninstallTrafficMirrorFlows(name string) error {
+	cacheKey := fmt.Sprintf("tm_%s", name)
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	return c.deleteFlows(c.featurePodConnectivity.tcCachedFlows, cacheKey)
+}
+
 func (c *client) SendIGMPRemoteReportPacketOut(
 	dstMAC net.HardwareAddr,
 	dstIP net.IP,
@@ -1443,10 +2041,12 @@ func (c *client) UninstallMulticastGroup(groupID binding.GroupIDType) error {
 }
 
 // InstallMulticlusterNodeFlows installs flows to handle cross-cluster packets between a regular
-// Node and a local Gateway.
+// Node and the local Gateways.
 func (c *client) InstallMulticlusterNodeFlows(clusterID string,
 	peerConfigs map[*net.IPNet]net.IP,
 	tunnelPeerIP net.IP,
+	gatewayGroupID binding.GroupIDType,
+	peerGatewayIPs []net.IP,
 	enableStretchedNetworkPolicy bool) error {
 	c.replayMutex.RLock()
 	defer c.replayMutex.RUnlock()
@@ -1454,7 +2054,17 @@ func (c *client) InstallMulticlusterNodeFlows(clusterID string,
 	var flows []binding.Flow
 	localGatewayMAC := c.nodeConfig.GatewayConfig.MAC
 	for peerCIDR, remoteGatewayIP := range peerConfigs {
-		flows = append(flows, c.featureMulticluster.l3FwdFlowToRemoteGateway(localGatewayMAC, *peerCIDR, tunnelPeerIP, remoteGatewayIP, enableStretchedNetworkPolicy)...)
+		if gatewayGroupID != 0 {
+			flows = append(flows, c.featureMulticluster.l3FwdFlowToRemoteGatewayViaGroup(localGatewayMAC, *peerCIDR, gatewayGroupID))
+			flows = append(flows, c.featureMulticluster.l3FwdFlowsToRemoteGatewayIP(localGatewayMAC, tunnelPeerIP, remoteGatewayIP, enableStretchedNetworkPolicy)...)
+		} else {
+			flows = append(flows, c.featureMulticluster.l3FwdFlowToRemoteGateway(localGatewayMAC, *peerCIDR, tunnelPeerIP, remoteGatewayIP, enableStretchedNetworkPolicy)...)
+		}
+	}
+	// Reply packets destined for the other remote Gateways are forwarded to the local Gateway
+	// tunnelPeerIP as well.
+	for _, peerGatewayIP := range peerGatewayIPs {
+		flows = append(flows, c.featureMulticluster.l3FwdFlowsToRemoteGatewayIP(localGatewayMAC, tunnelPeerIP, peerGatewayIP, enableStretchedNetworkPolicy)...)
 	}
 	return c.modifyFlows(c.featureMulticluster.cachedFlows, cacheKey, flows)
 }
@@ -1464,6 +2074,7 @@ func (c *client) InstallMulticlusterGatewayFlows(clusterID string,
 	peerConfigs map[*net.IPNet]net.IP,
 	tunnelPeerIP net.IP,
 	localGatewayIP net.IP,
+	peerGatewayIPs []net.IP,
 	enableStretchedNetworkPolicy bool,
 ) error {
 	c.replayMutex.RLock()
@@ -1476,9 +2087,45 @@ func (c *client) InstallMulticlusterGatewayFlows(clusterID string,
 		// Add SNAT flows to change cross-cluster packets' source IP to local Gateway IP.
 		flows = append(flows, c.featureMulticluster.snatConntrackFlows(*peerCIDR, localGatewayIP)...)
 	}
+	// Reply packets destined for the other remote Gateways are tunneled to these Gateways directly.
+	for _, peerGatewayIP := range peerGatewayIPs {
+		flows = append(flows, c.featureMulticluster.l3FwdFlowsToRemoteGatewayIP(localGatewayMAC, peerGatewayIP, peerGatewayIP, enableStretchedNetworkPolicy)...)
+	}
 	return c.modifyFlows(c.featureMulticluster.cachedFlows, cacheKey, flows)
 }
 
+func (c *client) InstallMulticlusterGatewayGroup(groupID binding.GroupIDType, tunnelPeerIPs []net.IP) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+
+	group := c.featureMulticluster.gatewayGroup(groupID, tunnelPeerIPs)
+	_, installed := c.featureMulticluster.groupCache.Load(groupID)
+	if !installed {
+		if err := c.ofEntryOperations.AddOFEntries([]binding.OFEntry{group}); err != nil {
+			return fmt.Errorf("error when installing Multicluster Gateway Group %d: %w", groupID, err)
+		}
+	} else {
+		if err := c.ofEntryOperations.ModifyOFEntries([]binding.OFEntry{group}); err != nil {
+			return fmt.Errorf("error when modifying Multicluster Gateway Group %d: %w", groupID, err)
+		}
+	}
+	c.featureMulticluster.groupCache.Store(groupID, group)
+	return nil
+}
+
+func (c *client) UninstallMulticlusterGatewayGroup(groupID binding.GroupIDType) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	gCache, ok := c.featureMulticluster.groupCache.Load(groupID)
+	if ok {
+		if err := c.ofEntryOperations.DeleteOFEntries([]binding.OFEntry{gCache.(binding.Group)}); err != nil {
+			return fmt.Errorf("error when deleting Openflow entries for Multicluster Gateway Group %d: %w", groupID, err)
+		}
+		c.featureMulticluster.groupCache.Delete(groupID)
+	}
+	return nil
+}
+
 // InstallMulticlusterClassifierFlows adds the following flows:
 //   - One flow in L2ForwardingCalcTable for the global virtual multicluster MAC 'aa:bb:cc:dd:ee:f0'
 //     to set its target output port as 'antrea-tun0'. This flow will be on both Gateway and regular Node.
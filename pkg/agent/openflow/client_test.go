@@ -34,10 +34,12 @@ import (
 	"antrea.io/antrea/pkg/agent/config"
 	"antrea.io/antrea/pkg/agent/openflow/cookie"
 	oftest "antrea.io/antrea/pkg/agent/openflow/testing"
+	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/apis/crd/v1alpha2"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
 	ovsoftest "antrea.io/antrea/pkg/ovs/openflow/testing"
 	"antrea.io/antrea/pkg/ovs/ovsconfig"
+	ovsctltest "antrea.io/antrea/pkg/ovs/ovsctl/testing"
 	utilip "antrea.io/antrea/pkg/util/ip"
 	"antrea.io/antrea/pkg/util/runtime"
 	"antrea.io/antrea/third_party/proxy"
@@ -63,6 +65,8 @@ var (
 	_, fakeEgressExceptIPv4CIDR, _ = net.ParseCIDR("192.168.78.0/24")
 	_, fakeEgressExceptIPv6CIDR, _ = net.ParseCIDR("fec0:192:168:78::/80")
 
+	fakePhysicalBridgePatchOFPort = uint32(5)
+
 	fakeL7NPTargetOFPort = uint32(10)
 	fakeL7NPReturnOFPort = uint32(11)
 )
@@ -86,6 +90,16 @@ type clientOptions struct {
 	enableTrafficControl  bool
 	enableMulticluster    bool
 	enableL7NetworkPolicy bool
+	enablePhysicalBridge  bool
+	// enablePodBandwidthMeter enables the PodBandwidthMeter feature, assuming that the OVS datapath supports meters.
+	enablePodBandwidthMeter bool
+	// egressDefaultDeny is nil if Egress default deny is disabled, otherwise it indicates whether default deny applies to
+	// all Namespaces.
+	egressDefaultDeny *bool
+	// dropServicesWithoutEndpoints disables rejecting the packets sent to Services without Endpoints.
+	dropServicesWithoutEndpoints bool
+	disableTTLDecrement          bool
+	sendICMPTimeExceeded         bool
 }
 
 type clientOptionsFn func(*clientOptions)
@@ -104,14 +118,36 @@ func disableProxy(o *clientOptions) {
 	o.proxyAll = false
 }
 
+func dropServicesWithoutEndpoints(o *clientOptions) {
+	o.dropServicesWithoutEndpoints = true
+}
+
+func disableTTLDecrement(o *clientOptions) {
+	o.disableTTLDecrement = true
+}
+
+func enableICMPTimeExceeded(o *clientOptions) {
+	o.sendICMPTimeExceeded = true
+}
+
 func disableEgress(o *clientOptions) {
 	o.enableEgress = false
 }
 
+func enableEgressDefaultDeny(allNamespaces bool) clientOptionsFn {
+	return func(o *clientOptions) {
+		o.egressDefaultDeny = &allNamespaces
+	}
+}
+
 func enableConnectUplinkToBridge(o *clientOptions) {
 	o.connectUplinkToBridge = true
 }
 
+func enablePhysicalBridge(o *clientOptions) {
+	o.enablePhysicalBridge = true
+}
+
 func enableMulticast(o *clientOptions) {
 	o.enableMulticast = true
 }
@@ -128,6 +164,10 @@ func enableTrafficControl(o *clientOptions) {
 	o.enableTrafficControl = true
 }
 
+func enablePodBandwidthMeter(o *clientOptions) {
+	o.enablePodBandwidthMeter = true
+}
+
 func enableMulticluster(o *clientOptions) {
 	o.enableMulticluster = true
 }
@@ -362,8 +402,12 @@ func newFakeClient(mockOFEntryOperations *oftest.MockOFEntryOperations,
 		o.connectUplinkToBridge,
 		o.enableMulticast,
 		o.enableTrafficControl,
-		o.enableMulticluster)
+		o.enableMulticluster,
+		o.enablePodBandwidthMeter)
 	client := cli.(*client)
+	if o.enablePodBandwidthMeter {
+		client.ovsMetersAreSupported = true
+	}
 
 	var egressExceptCIDRs []net.IPNet
 	var serviceIPv4CIDR, serviceIPv6CIDR *net.IPNet
@@ -421,18 +465,30 @@ func newFakeClient(mockOFEntryOperations *oftest.MockOFEntryOperations,
 		},
 	}
 	networkConfig := &config.NetworkConfig{
-		IPv4Enabled:      enableIPv4,
-		IPv6Enabled:      enableIPv6,
-		TrafficEncapMode: trafficEncapMode,
+		IPv4Enabled:          enableIPv4,
+		IPv6Enabled:          enableIPv6,
+		TrafficEncapMode:     trafficEncapMode,
+		DisableTTLDecrement:  o.disableTTLDecrement,
+		SendICMPTimeExceeded: o.sendICMPTimeExceeded,
 	}
 	egressConfig := &config.EgressConfig{
 		ExceptCIDRs: egressExceptCIDRs,
 	}
+	if o.egressDefaultDeny != nil {
+		egressConfig.EnableDefaultDeny = true
+		egressConfig.DefaultDenyAllNamespaces = *o.egressDefaultDeny
+	}
 	serviceConfig := &config.ServiceConfig{
-		ServiceCIDR:           serviceIPv4CIDR,
-		ServiceCIDRv6:         serviceIPv6CIDR,
-		NodePortAddressesIPv4: nodePortAddressesIPv4,
-		NodePortAddressesIPv6: nodePortAddressesIPv6,
+		ServiceCIDR:                    serviceIPv4CIDR,
+		ServiceCIDRv6:                  serviceIPv6CIDR,
+		NodePortAddressesIPv4:          nodePortAddressesIPv4,
+		NodePortAddressesIPv6:          nodePortAddressesIPv6,
+		RejectServicesWithoutEndpoints: !o.dropServicesWithoutEndpoints,
+	}
+
+	if o.enablePhysicalBridge {
+		nodeConfig.OVSPhysicalBridge = "br-phy"
+		nodeConfig.PhysicalBridgePatchOFPort = fakePhysicalBridgePatchOFPort
 	}
 
 	if o.enableL7NetworkPolicy {
@@ -522,7 +578,7 @@ func Test_client_InstallNodeFlows(t *testing.T) {
 		clientOptions    []clientOptionsFn
 		peerConfigs      map[*net.IPNet]net.IP
 		tunnelPeerIPs    *utilip.DualStackIPs
-		ipsecTunOFPort   uint32
+		tunOFPort        uint32
 		trafficEncapMode config.TrafficEncapModeType
 		expectedFlows    []string
 	}{
@@ -531,12 +587,13 @@ func Test_client_InstallNodeFlows(t *testing.T) {
 			enableIPv4:       true,
 			peerConfigs:      map[*net.IPNet]net.IP{peerPodCIDRv4: peerGwIPv4},
 			tunnelPeerIPs:    &utilip.DualStackIPs{IPv4: tunnelPeerIPv4},
-			ipsecTunOFPort:   uint32(100),
+			tunOFPort:        uint32(100),
 			trafficEncapMode: config.TrafficEncapModeEncap,
 			expectedFlows: []string{
 				"cookie=0x1010000000000, table=ARPResponder, priority=200,arp,arp_tpa=10.10.1.1,arp_op=1 actions=move:NXM_OF_ETH_SRC[]->NXM_OF_ETH_DST[],set_field:aa:bb:cc:dd:ee:ff->eth_src,set_field:2->arp_op,move:NXM_NX_ARP_SHA[]->NXM_NX_ARP_THA[],set_field:aa:bb:cc:dd:ee:ff->arp_sha,move:NXM_OF_ARP_SPA[]->NXM_OF_ARP_TPA[],set_field:10.10.1.1->arp_spa,IN_PORT",
 				"cookie=0x1010000000000, table=Classifier, priority=200,in_port=100 actions=set_field:0x1/0xf->reg0,set_field:0x200/0x200->reg0,goto_table:UnSNAT",
 				"cookie=0x1010000000000, table=L3Forwarding, priority=200,ip,nw_dst=10.10.1.0/24 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:ff->eth_dst,set_field:192.168.77.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1010000000000, table=L2ForwardingCalc, priority=210,ip,tun_dst=192.168.77.101,dl_dst=aa:bb:cc:dd:ee:ff actions=set_field:0x64->reg1,set_field:0x100/0x100->reg0,goto_table:IngressSecurityClassifier",
 				"cookie=0x1040000000000, table=EgressMark, priority=210,ip,nw_dst=192.168.77.101 actions=set_field:0x20/0xf0->reg0,goto_table:L2ForwardingCalc",
 			},
 		},
@@ -546,12 +603,13 @@ func Test_client_InstallNodeFlows(t *testing.T) {
 			skipWindows:      true,
 			peerConfigs:      map[*net.IPNet]net.IP{peerPodCIDRv6: peerGwIPv6},
 			tunnelPeerIPs:    &utilip.DualStackIPs{IPv6: tunnelPeerIPv6},
-			ipsecTunOFPort:   uint32(100),
+			tunOFPort:        uint32(100),
 			trafficEncapMode: config.TrafficEncapModeEncap,
 			expectedFlows: []string{
 				"cookie=0x1010000000000, table=Classifier, priority=200,in_port=100 actions=set_field:0x1/0xf->reg0,set_field:0x200/0x200->reg0,goto_table:UnSNAT",
 				"cookie=0x1040000000000, table=EgressMark, priority=210,ipv6,ipv6_dst=fec0:192:168:77::101 actions=set_field:0x20/0xf0->reg0,goto_table:L2ForwardingCalc",
 				"cookie=0x1010000000000, table=L3Forwarding, priority=200,ipv6,ipv6_dst=fec0:10:10:1::/80 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:ff->eth_dst,set_field:fec0:192:168:77::101->tun_ipv6_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1010000000000, table=L2ForwardingCalc, priority=210,ipv6,tun_ipv6_dst=fec0:192:168:77::101,dl_dst=aa:bb:cc:dd:ee:ff actions=set_field:0x64->reg1,set_field:0x100/0x100->reg0,goto_table:IngressSecurityClassifier",
 			},
 		},
 		{
@@ -611,7 +669,7 @@ func Test_client_InstallNodeFlows(t *testing.T) {
 
 			hostname := "node1"
 
-			assert.NoError(t, fc.InstallNodeFlows(hostname, tc.peerConfigs, tc.tunnelPeerIPs, tc.ipsecTunOFPort, peerGwMAC))
+			assert.NoError(t, fc.InstallNodeFlows(hostname, tc.peerConfigs, tc.tunnelPeerIPs, tc.tunOFPort, peerGwMAC))
 			fCacheI, ok := fc.featurePodConnectivity.nodeCachedFlows.Load(hostname)
 			require.True(t, ok)
 			assert.ElementsMatch(t, tc.expectedFlows, getFlowStrings(fCacheI))
@@ -987,6 +1045,18 @@ func Test_client_InstallServiceGroup(t *testing.T) {
 				"bucket=bucket_id:0,weight:100,actions=set_field:0xfec00010001000000000000000000100->xxreg3,set_field:0x50/0xffff->reg4,resubmit:ServiceLB," +
 				"bucket=bucket_id:1,weight:100,actions=set_field:0xfec00010001000000000000000000101->xxreg3,set_field:0x50/0xffff->reg4,resubmit:ServiceLB",
 		},
+		{
+			name: "IPv4 Endpoints with weights",
+			endpoints: []proxy.Endpoint{
+				weightedEndpoint(proxy.NewBaseEndpointInfo("10.10.0.100", "", "", 80, false, true, false, false, nil), 300),
+				proxy.NewBaseEndpointInfo("10.10.0.101", "", "", 80, false, true, false, false, nil),
+				&DrainingEndpoint{proxy.NewBaseEndpointInfo("10.10.0.102", "", "", 80, false, true, false, false, nil)},
+			},
+			expectedGroup: "group_id=100,type=select," +
+				"bucket=bucket_id:0,weight:300,actions=set_field:0xa0a0064->reg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT," +
+				"bucket=bucket_id:1,weight:100,actions=set_field:0xa0a0065->reg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT," +
+				"bucket=bucket_id:2,weight:0,actions=set_field:0xa0a0066->reg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT",
+		},
 		{
 			name: "delete group failed for IPv4 Endpoints",
 			endpoints: []proxy.Endpoint{
@@ -1035,6 +1105,68 @@ func Test_client_InstallServiceGroup(t *testing.T) {
 	}
 }
 
+func Test_client_InstallServiceGroupWithSubGroups(t *testing.T) {
+	defer func(max int) {
+		maxBucketsPerServiceGroup = max
+	}(maxBucketsPerServiceGroup)
+	maxBucketsPerServiceGroup = 2
+	groupID := binding.GroupIDType(100)
+	subGroupAllocator := newServiceSubGroupAllocator()
+	subGroupID0 := subGroupAllocator.AllocateFor("100/0")
+	subGroupID1 := subGroupAllocator.AllocateFor("100/1")
+
+	ctrl := gomock.NewController(t)
+	m := oftest.NewMockOFEntryOperations(ctrl)
+	fc := newFakeClient(m, true, false, config.K8sNode, config.TrafficEncapModeEncap)
+	defer resetPipelines()
+
+	endpoints := []proxy.Endpoint{
+		weightedEndpoint(proxy.NewBaseEndpointInfo("10.10.0.100", "", "", 80, false, true, false, false, nil), 300),
+		proxy.NewBaseEndpointInfo("10.10.0.101", "", "", 80, false, true, false, false, nil),
+		proxy.NewBaseEndpointInfo("10.10.0.102", "", "", 80, false, true, false, false, nil),
+		&DrainingEndpoint{proxy.NewBaseEndpointInfo("10.10.0.103", "", "", 80, false, true, false, false, nil)},
+	}
+	// The sub-groups are installed before the group.
+	gomock.InOrder(
+		m.EXPECT().AddOFEntries(gomock.Len(2)).Return(nil),
+		m.EXPECT().AddOFEntries(gomock.Len(1)).Return(nil),
+	)
+	require.NoError(t, fc.InstallServiceGroup(groupID, false, endpoints))
+	gCacheI, ok := fc.featureService.groupCache.Load(groupID)
+	require.True(t, ok)
+	assert.Equal(t, fmt.Sprintf("group_id=100,type=select,"+
+		"bucket=bucket_id:0,weight:400,actions=group:%d,"+
+		"bucket=bucket_id:1,weight:100,actions=group:%d", subGroupID0, subGroupID1), getGroupFromCache(gCacheI.(binding.Group)))
+	subGroupsI, ok := fc.featureService.subGroupCache.Load(groupID)
+	require.True(t, ok)
+	subGroups := subGroupsI.(map[binding.GroupIDType]binding.Group)
+	require.Len(t, subGroups, 2)
+	assert.Equal(t, fmt.Sprintf("group_id=%d,type=select,", subGroupID0)+
+		"bucket=bucket_id:0,weight:300,actions=set_field:0xa0a0064->reg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT,"+
+		"bucket=bucket_id:1,weight:100,actions=set_field:0xa0a0065->reg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT", getGroupFromCache(subGroups[subGroupID0]))
+	assert.Equal(t, fmt.Sprintf("group_id=%d,type=select,", subGroupID1)+
+		"bucket=bucket_id:0,weight:100,actions=set_field:0xa0a0066->reg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT,"+
+		"bucket=bucket_id:1,weight:0,actions=set_field:0xa0a0067->reg3,set_field:0x50/0xffff->reg4,resubmit:EndpointDNAT", getGroupFromCache(subGroups[subGroupID1]))
+
+	// The group no longer needs sub-groups, they are deleted after the group is modified.
+	gomock.InOrder(
+		m.EXPECT().ModifyOFEntries(gomock.Len(1)).Return(nil),
+		m.EXPECT().DeleteOFEntries(gomock.Len(2)).Return(nil),
+	)
+	require.NoError(t, fc.InstallServiceGroup(groupID, false, endpoints[:2]))
+	_, ok = fc.featureService.subGroupCache.Load(groupID)
+	assert.False(t, ok)
+	assert.Empty(t, fc.featureService.subGroupAllocator.(*groupAllocator).derived)
+
+	m.EXPECT().DeleteOFEntries(gomock.Len(1)).Return(nil)
+	require.NoError(t, fc.UninstallServiceGroup(groupID))
+}
+
+func weightedEndpoint(endpoint *proxy.BaseEndpointInfo, weight uint16) *proxy.BaseEndpointInfo {
+	endpoint.Weight = weight
+	return endpoint
+}
+
 func Test_client_InstallEndpointFlows(t *testing.T) {
 	ep1IPv4 := "10.10.0.100"
 	ep2IPv4 := "10.10.0.101"
@@ -1124,6 +1256,16 @@ func Test_client_InstallEndpointFlows(t *testing.T) {
 				"cookie=0x1030000000000, table=SNATMark, priority=190,ct_state=+new+trk,ipv6,ipv6_src=fec0:10:10::101,ipv6_dst=fec0:10:10::101 actions=ct(commit,table=SNAT,zone=65510,exec(set_field:0x20/0x20->ct_mark,set_field:0x40/0x40->ct_mark))",
 			},
 		},
+		{
+			name:     "UDPv4 Endpoints without port",
+			protocol: binding.ProtocolUDP,
+			endpoints: []proxy.Endpoint{
+				proxy.NewBaseEndpointInfo(ep1IPv4, "", "", 0, false, true, false, false, nil),
+			},
+			expectedFlows: []string{
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,udp,reg3=0xa0a0064,reg4=0x20000/0x7ffff actions=ct(commit,table=AntreaPolicyEgressRule,zone=65520,nat(dst=10.10.0.100),exec(set_field:0x10/0x10->ct_mark,move:NXM_NX_REG0[0..3]->NXM_NX_CT_MARK[0..3]))",
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1172,6 +1314,7 @@ func Test_client_InstallServiceFlows(t *testing.T) {
 		protocol          binding.Protocol
 		svcIP             net.IP
 		affinityTimeout   uint16
+		affinityKey       *types.SessionAffinityKey
 		toExternalAddress bool
 		expectedFlows     []string
 		nested            bool
@@ -1183,7 +1326,7 @@ func Test_client_InstallServiceFlows(t *testing.T) {
 			svcIP:    svcIPv4,
 			expectedFlows: []string{
 				"cookie=0x1030000000000, table=EndpointDNAT, priority=210,tcp,reg3=0xa600064,reg4=0x1020050/0x107ffff actions=group:100",
-				"cookie=0x1030000000000, table=ServiceLB, priority=200,tcp,reg4=0x10000/0x70000,nw_dst=10.96.0.100,tp_dst=80 actions=set_field:0x200/0x200->reg0,set_field:0x20000/0x70000->reg4,set_field:0x64->reg7,group:100",
+				"cookie=0x1030000000000, table=ServiceLB, priority=200,tcp,reg4=0x10000/0x70000,nw_dst=10.96.0.100,tp_dst=80 actions=set_field:0x200/0x200->reg0,set_field:0x20000/0x70000->reg4,set_field:0x64->reg7,set_field:0x4000000/0x4000000->reg4,group:100",
 			},
 		},
 		{
@@ -1192,7 +1335,7 @@ func Test_client_InstallServiceFlows(t *testing.T) {
 			protocol: binding.ProtocolTCP,
 			svcIP:    svcIPv4,
 			expectedFlows: []string{
-				"cookie=0x1030000000000, table=ServiceLB, priority=200,tcp,reg4=0x10000/0x70000,nw_dst=10.96.0.100,tp_dst=80 actions=set_field:0x200/0x200->reg0,set_field:0x20000/0x70000->reg4,set_field:0x64->reg7,set_field:0x1000000/0x1000000->reg4,group:100",
+				"cookie=0x1030000000000, table=ServiceLB, priority=200,tcp,reg4=0x10000/0x70000,nw_dst=10.96.0.100,tp_dst=80 actions=set_field:0x200/0x200->reg0,set_field:0x20000/0x70000->reg4,set_field:0x64->reg7,set_field:0x4000000/0x4000000->reg4,set_field:0x1000000/0x1000000->reg4,group:100",
 			},
 			nested: true,
 		},
@@ -1204,7 +1347,7 @@ func Test_client_InstallServiceFlows(t *testing.T) {
 			affinityTimeout: uint16(100),
 			expectedFlows: []string{
 				"cookie=0x1030000000000, table=EndpointDNAT, priority=210,tcp,reg3=0xa600064,reg4=0x1020050/0x107ffff actions=group:100",
-				"cookie=0x1030000000000, table=ServiceLB, priority=200,tcp,reg4=0x10000/0x70000,nw_dst=10.96.0.100,tp_dst=80 actions=set_field:0x200/0x200->reg0,set_field:0x30000/0x70000->reg4,set_field:0x64->reg7,group:100",
+				"cookie=0x1030000000000, table=ServiceLB, priority=200,tcp,reg4=0x10000/0x70000,nw_dst=10.96.0.100,tp_dst=80 actions=set_field:0x200/0x200->reg0,set_field:0x30000/0x70000->reg4,set_field:0x64->reg7,set_field:0x4000000/0x4000000->reg4,group:100",
 				"cookie=0x1030000000064, table=ServiceLB, priority=190,tcp,reg4=0x30000/0x70000,nw_dst=10.96.0.100,tp_dst=80 actions=learn(table=SessionAffinity,hard_timeout=100,priority=200,delete_learned,cookie=0x1030000000064,eth_type=0x800,nw_proto=0x6,OXM_OF_TCP_DST[],NXM_OF_IP_DST[],NXM_OF_IP_SRC[],load:NXM_NX_REG4[0..15]->NXM_NX_REG4[0..15],load:NXM_NX_REG3[]->NXM_NX_REG3[],load:0x2->NXM_NX_REG4[16..18],load:0x1->NXM_NX_REG0[9]),set_field:0x20000/0x70000->reg4,goto_table:EndpointDNAT",
 			},
 		},
@@ -1216,10 +1359,50 @@ func Test_client_InstallServiceFlows(t *testing.T) {
 			affinityTimeout: uint16(100),
 			expectedFlows: []string{
 				"cookie=0x1030000000000, table=EndpointDNAT, priority=210,tcp6,reg4=0x1020050/0x107ffff,xxreg3=0xfec00010009600000000000000000100 actions=group:100",
-				"cookie=0x1030000000000, table=ServiceLB, priority=200,tcp6,reg4=0x10000/0x70000,ipv6_dst=fec0:10:96::100,tp_dst=80 actions=set_field:0x200/0x200->reg0,set_field:0x30000/0x70000->reg4,set_field:0x64->reg7,group:100",
+				"cookie=0x1030000000000, table=ServiceLB, priority=200,tcp6,reg4=0x10000/0x70000,ipv6_dst=fec0:10:96::100,tp_dst=80 actions=set_field:0x200/0x200->reg0,set_field:0x30000/0x70000->reg4,set_field:0x64->reg7,set_field:0x4000000/0x4000000->reg4,group:100",
 				"cookie=0x1030000000064, table=ServiceLB, priority=190,tcp6,reg4=0x30000/0x70000,ipv6_dst=fec0:10:96::100,tp_dst=80 actions=learn(table=SessionAffinity,hard_timeout=100,priority=200,delete_learned,cookie=0x1030000000064,eth_type=0x86dd,nw_proto=0x6,OXM_OF_TCP_DST[],NXM_NX_IPV6_DST[],NXM_NX_IPV6_SRC[],load:NXM_NX_REG4[0..15]->NXM_NX_REG4[0..15],load:NXM_NX_XXREG3[]->NXM_NX_XXREG3[],load:0x2->NXM_NX_REG4[16..18],load:0x1->NXM_NX_REG0[9]),set_field:0x20000/0x70000->reg4,goto_table:EndpointDNAT",
 			},
 		},
+		{
+			name:            "Service ClusterIP,SessionAffinity,Client IP prefix,Ignore destination port",
+			groupID:         groupID,
+			protocol:        binding.ProtocolTCP,
+			svcIP:           svcIPv4,
+			affinityTimeout: uint16(100),
+			affinityKey:     &types.SessionAffinityKey{ClientIPPrefixLength: 24, IgnoreDstPort: true},
+			expectedFlows: []string{
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=210,tcp,reg3=0xa600064,reg4=0x1020050/0x107ffff actions=group:100",
+				"cookie=0x1030000000000, table=ServiceLB, priority=200,tcp,reg4=0x10000/0x70000,nw_dst=10.96.0.100,tp_dst=80 actions=set_field:0x200/0x200->reg0,set_field:0x30000/0x70000->reg4,set_field:0x64->reg7,set_field:0x4000000/0x4000000->reg4,group:100",
+				"cookie=0x1030000000064, table=ServiceLB, priority=190,tcp,reg4=0x30000/0x70000,nw_dst=10.96.0.100,tp_dst=80 actions=learn(table=SessionAffinity,hard_timeout=100,priority=200,delete_learned,cookie=0x1030000000064,eth_type=0x800,nw_proto=0x6,NXM_OF_IP_DST[],NXM_OF_IP_SRC[8..31],load:NXM_NX_REG4[0..15]->NXM_NX_REG4[0..15],load:NXM_NX_REG3[]->NXM_NX_REG3[],load:0x2->NXM_NX_REG4[16..18],load:0x1->NXM_NX_REG0[9]),set_field:0x20000/0x70000->reg4,goto_table:EndpointDNAT",
+			},
+		},
+		{
+			name:            "Service ClusterIP,IPv6,SessionAffinity,Client IP prefix",
+			groupID:         groupID,
+			protocol:        binding.ProtocolTCPv6,
+			svcIP:           svcIPv6,
+			affinityTimeout: uint16(100),
+			affinityKey:     &types.SessionAffinityKey{ClientIPPrefixLength: 64},
+			expectedFlows: []string{
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=210,tcp6,reg4=0x1020050/0x107ffff,xxreg3=0xfec00010009600000000000000000100 actions=group:100",
+				"cookie=0x1030000000000, table=ServiceLB, priority=200,tcp6,reg4=0x10000/0x70000,ipv6_dst=fec0:10:96::100,tp_dst=80 actions=set_field:0x200/0x200->reg0,set_field:0x30000/0x70000->reg4,set_field:0x64->reg7,set_field:0x4000000/0x4000000->reg4,group:100",
+				"cookie=0x1030000000064, table=ServiceLB, priority=190,tcp6,reg4=0x30000/0x70000,ipv6_dst=fec0:10:96::100,tp_dst=80 actions=learn(table=SessionAffinity,hard_timeout=100,priority=200,delete_learned,cookie=0x1030000000064,eth_type=0x86dd,nw_proto=0x6,OXM_OF_TCP_DST[],NXM_NX_IPV6_DST[],NXM_NX_IPV6_SRC[64..127],load:NXM_NX_REG4[0..15]->NXM_NX_REG4[0..15],load:NXM_NX_XXREG3[]->NXM_NX_XXREG3[],load:0x2->NXM_NX_REG4[16..18],load:0x1->NXM_NX_REG0[9]),set_field:0x20000/0x70000->reg4,goto_table:EndpointDNAT",
+			},
+		},
+		{
+			name:              "Service NodePort,SessionAffinity,Ignore destination port",
+			groupID:           groupID,
+			clusterGroupID:    groupID,
+			protocol:          binding.ProtocolUDP,
+			svcIP:             config.VirtualNodePortDNATIPv4,
+			affinityTimeout:   uint16(100),
+			affinityKey:       &types.SessionAffinityKey{IgnoreDstPort: true},
+			toExternalAddress: true,
+			expectedFlows: []string{
+				"cookie=0x1030000000000, table=ServiceLB, priority=200,udp,reg4=0x90000/0xf0000,tp_dst=80 actions=set_field:0x200/0x200->reg0,set_field:0x30000/0x70000->reg4,set_field:0x200000/0x200000->reg4,set_field:0x64->reg7,group:100",
+				"cookie=0x1030000000064, table=ServiceLB, priority=190,udp,reg4=0xb0000/0xf0000,tp_dst=80 actions=learn(table=SessionAffinity,hard_timeout=100,priority=200,delete_learned,cookie=0x1030000000064,eth_type=0x800,nw_proto=0x11,OXM_OF_UDP_DST[],NXM_OF_IP_DST[],NXM_OF_IP_SRC[],load:NXM_NX_REG4[0..15]->NXM_NX_REG4[0..15],load:NXM_NX_REG3[]->NXM_NX_REG3[],load:0x2->NXM_NX_REG4[16..18],load:0x1->NXM_NX_REG0[9],load:0x1->NXM_NX_REG4[21]),set_field:0x20000/0x70000->reg4,goto_table:EndpointDNAT",
+			},
+		},
 		{
 			name:              "Service NodePort,SessionAffinity",
 			groupID:           groupID,
@@ -1314,7 +1497,7 @@ func Test_client_InstallServiceFlows(t *testing.T) {
 
 			cacheKey := generateServicePortFlowCacheKey(tc.svcIP, port, tc.protocol)
 
-			assert.NoError(t, fc.InstallServiceFlows(tc.groupID, tc.clusterGroupID, tc.svcIP, port, tc.protocol, tc.affinityTimeout, tc.toExternalAddress, tc.nested))
+			assert.NoError(t, fc.InstallServiceFlows(tc.groupID, tc.clusterGroupID, tc.svcIP, port, tc.protocol, tc.affinityTimeout, tc.affinityKey, tc.toExternalAddress, tc.nested))
 			fCacheI, ok := fc.featureService.cachedFlows.Load(cacheKey)
 			require.True(t, ok)
 			assert.ElementsMatch(t, tc.expectedFlows, getFlowStrings(fCacheI))
@@ -1326,6 +1509,75 @@ func Test_client_InstallServiceFlows(t *testing.T) {
 	}
 }
 
+func Test_client_InstallServicePortRangeFlows(t *testing.T) {
+	groupID := binding.GroupIDType(100)
+	clusterGroupID := binding.GroupIDType(101)
+	svcIPv4 := net.ParseIP("10.96.0.100")
+	// The range is matched with 30000/0xfffc (30000-30003) and 30004.
+	port := uint16(30000)
+	endPort := uint16(30004)
+
+	testCases := []struct {
+		name              string
+		clusterGroupID    binding.GroupIDType
+		affinityTimeout   uint16
+		toExternalAddress bool
+		expectedFlows     []string
+	}{
+		{
+			name: "Service ClusterIP",
+			expectedFlows: []string{
+				"cookie=0x1030000000000, table=ServiceLB, priority=200,udp,reg4=0x10000/0x70000,nw_dst=10.96.0.100,tp_dst=0x7530/0xfffc actions=set_field:0x200/0x200->reg0,set_field:0x20000/0x70000->reg4,set_field:0x64->reg7,set_field:0x4000000/0x4000000->reg4,group:100",
+				"cookie=0x1030000000000, table=ServiceLB, priority=200,udp,reg4=0x10000/0x70000,nw_dst=10.96.0.100,tp_dst=30004 actions=set_field:0x200/0x200->reg0,set_field:0x20000/0x70000->reg4,set_field:0x64->reg7,set_field:0x4000000/0x4000000->reg4,group:100",
+			},
+		},
+		{
+			name:            "Service ClusterIP,SessionAffinity",
+			affinityTimeout: uint16(100),
+			expectedFlows: []string{
+				"cookie=0x1030000000000, table=ServiceLB, priority=200,udp,reg4=0x10000/0x70000,nw_dst=10.96.0.100,tp_dst=0x7530/0xfffc actions=set_field:0x200/0x200->reg0,set_field:0x30000/0x70000->reg4,set_field:0x64->reg7,set_field:0x4000000/0x4000000->reg4,group:100",
+				"cookie=0x1030000000000, table=ServiceLB, priority=200,udp,reg4=0x10000/0x70000,nw_dst=10.96.0.100,tp_dst=30004 actions=set_field:0x200/0x200->reg0,set_field:0x30000/0x70000->reg4,set_field:0x64->reg7,set_field:0x4000000/0x4000000->reg4,group:100",
+				"cookie=0x1030000000064, table=ServiceLB, priority=190,udp,reg4=0x30000/0x70000,nw_dst=10.96.0.100,tp_dst=0x7530/0xfffc actions=learn(table=SessionAffinity,hard_timeout=100,priority=200,delete_learned,cookie=0x1030000000064,eth_type=0x800,nw_proto=0x11,OXM_OF_UDP_DST[],NXM_OF_IP_DST[],NXM_OF_IP_SRC[],load:NXM_NX_REG4[0..15]->NXM_NX_REG4[0..15],load:NXM_NX_REG3[]->NXM_NX_REG3[],load:0x2->NXM_NX_REG4[16..18],load:0x1->NXM_NX_REG0[9]),set_field:0x20000/0x70000->reg4,goto_table:EndpointDNAT",
+				"cookie=0x1030000000064, table=ServiceLB, priority=190,udp,reg4=0x30000/0x70000,nw_dst=10.96.0.100,tp_dst=30004 actions=learn(table=SessionAffinity,hard_timeout=100,priority=200,delete_learned,cookie=0x1030000000064,eth_type=0x800,nw_proto=0x11,OXM_OF_UDP_DST[],NXM_OF_IP_DST[],NXM_OF_IP_SRC[],load:NXM_NX_REG4[0..15]->NXM_NX_REG4[0..15],load:NXM_NX_REG3[]->NXM_NX_REG3[],load:0x2->NXM_NX_REG4[16..18],load:0x1->NXM_NX_REG0[9]),set_field:0x20000/0x70000->reg4,goto_table:EndpointDNAT",
+			},
+		},
+		{
+			name:              "Service LoadBalancer,Short-circuiting",
+			clusterGroupID:    clusterGroupID,
+			toExternalAddress: true,
+			expectedFlows: []string{
+				"cookie=0x1030000000000, table=ServiceLB, priority=210,udp,reg4=0x10000/0x70000,nw_src=10.10.0.0/24,nw_dst=10.96.0.100,tp_dst=0x7530/0xfffc actions=set_field:0x200/0x200->reg0,set_field:0x20000/0x70000->reg4,set_field:0x200000/0x200000->reg4,set_field:0x65->reg7,group:101",
+				"cookie=0x1030000000000, table=ServiceLB, priority=210,udp,reg4=0x10000/0x70000,nw_src=10.10.0.0/24,nw_dst=10.96.0.100,tp_dst=30004 actions=set_field:0x200/0x200->reg0,set_field:0x20000/0x70000->reg4,set_field:0x200000/0x200000->reg4,set_field:0x65->reg7,group:101",
+				"cookie=0x1030000000000, table=ServiceLB, priority=200,udp,reg4=0x10000/0x70000,nw_dst=10.96.0.100,tp_dst=0x7530/0xfffc actions=set_field:0x200/0x200->reg0,set_field:0x20000/0x70000->reg4,set_field:0x200000/0x200000->reg4,set_field:0x64->reg7,group:100",
+				"cookie=0x1030000000000, table=ServiceLB, priority=200,udp,reg4=0x10000/0x70000,nw_dst=10.96.0.100,tp_dst=30004 actions=set_field:0x200/0x200->reg0,set_field:0x20000/0x70000->reg4,set_field:0x200000/0x200000->reg4,set_field:0x64->reg7,group:100",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := oftest.NewMockOFEntryOperations(ctrl)
+
+			fc := newFakeClient(m, true, true, config.K8sNode, config.TrafficEncapModeEncap)
+			defer resetPipelines()
+
+			m.EXPECT().AddAll(gomock.Any()).Return(nil).Times(1)
+			m.EXPECT().DeleteAll(gomock.Any()).Return(nil).Times(1)
+
+			cacheKey := generateServicePortFlowCacheKey(svcIPv4, port, binding.ProtocolUDP)
+
+			assert.NoError(t, fc.InstallServicePortRangeFlows(groupID, tc.clusterGroupID, svcIPv4, port, endPort, binding.ProtocolUDP, tc.affinityTimeout, nil, tc.toExternalAddress))
+			fCacheI, ok := fc.featureService.cachedFlows.Load(cacheKey)
+			require.True(t, ok)
+			assert.ElementsMatch(t, tc.expectedFlows, getFlowStrings(fCacheI))
+
+			assert.NoError(t, fc.UninstallServiceFlows(svcIPv4, port, binding.ProtocolUDP))
+			_, ok = fc.featureService.cachedFlows.Load(cacheKey)
+			require.False(t, ok)
+		})
+	}
+}
+
 func Test_client_GetServiceFlowKeys(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	m := oftest.NewMockOFEntryOperations(ctrl)
@@ -1343,7 +1595,7 @@ func Test_client_GetServiceFlowKeys(t *testing.T) {
 		proxy.NewBaseEndpointInfo("10.10.0.12", "", "", 80, true, true, false, false, nil),
 	}
 
-	assert.NoError(t, fc.InstallServiceFlows(groupID, groupID, svcIP, svcPort, bindingProtocol, 100, true, false))
+	assert.NoError(t, fc.InstallServiceFlows(groupID, groupID, svcIP, svcPort, bindingProtocol, 100, nil, true, false))
 	assert.NoError(t, fc.InstallEndpointFlows(bindingProtocol, endpoints))
 	flowKeys := fc.GetServiceFlowKeys(svcIP, svcPort, bindingProtocol, endpoints)
 	expectedFlowKeys := []string{
@@ -1451,6 +1703,91 @@ func Test_client_InstallPodSNATFlows(t *testing.T) {
 	}
 }
 
+func Test_client_InstallPodEgressDefaultDenyFlows(t *testing.T) {
+	ofPort := uint32(100)
+	expectedFlows := []string{
+		"cookie=0x1040000000000, table=EgressDefaultDeny, priority=200,in_port=100 actions=drop",
+	}
+
+	ctrl := gomock.NewController(t)
+	m := oftest.NewMockOFEntryOperations(ctrl)
+
+	fc := newFakeClient(m, true, false, config.K8sNode, config.TrafficEncapModeEncap, enableEgressDefaultDeny(false))
+	defer resetPipelines()
+
+	m.EXPECT().AddAll(gomock.Any()).Return(nil).Times(1)
+	m.EXPECT().DeleteAll(gomock.Any()).Return(nil).Times(1)
+	cacheKey := fmt.Sprintf("d%x", ofPort)
+
+	assert.NoError(t, fc.InstallPodEgressDefaultDenyFlows(ofPort))
+	fCacheI, ok := fc.featureEgress.cachedFlows.Load(cacheKey)
+	require.True(t, ok)
+	assert.ElementsMatch(t, expectedFlows, getFlowStrings(fCacheI))
+
+	assert.NoError(t, fc.UninstallPodEgressDefaultDenyFlows(ofPort))
+	_, ok = fc.featureEgress.cachedFlows.Load(cacheKey)
+	require.False(t, ok)
+}
+
+func Test_client_InstallPodNeighborSpoofGuardFlows(t *testing.T) {
+	podIPv4 := net.ParseIP("10.10.0.66")
+	podIPv6 := net.ParseIP("fec0:10:10::66")
+	podMAC, _ := net.ParseMAC("00:00:10:10:00:66")
+	ofPort := uint32(100)
+
+	testCases := []struct {
+		name            string
+		enableIPv4      bool
+		enableIPv6      bool
+		podInterfaceIPs []net.IP
+		expectedFlows   []string
+	}{
+		{
+			name:            "IPv4",
+			enableIPv4:      true,
+			podInterfaceIPs: []net.IP{podIPv4},
+			expectedFlows: []string{
+				"cookie=0x1010000000000, table=ARPSpoofGuard, priority=190,arp,in_port=100 actions=drop",
+			},
+		},
+		{
+			name:            "IPv4 and IPv6",
+			enableIPv4:      true,
+			enableIPv6:      true,
+			podInterfaceIPs: []net.IP{podIPv4, podIPv6},
+			expectedFlows: []string{
+				"cookie=0x1010000000000, table=ARPSpoofGuard, priority=190,arp,in_port=100 actions=drop",
+				"cookie=0x1010000000000, table=IPv6, priority=210,icmp6,in_port=100,dl_src=00:00:10:10:00:66,icmp_type=135,icmp_code=0 actions=NORMAL",
+				"cookie=0x1010000000000, table=IPv6, priority=201,icmp6,in_port=100,icmp_type=135 actions=drop",
+				"cookie=0x1010000000000, table=IPv6, priority=210,icmp6,in_port=100,dl_src=00:00:10:10:00:66,icmp_type=136,icmp_code=0 actions=NORMAL",
+				"cookie=0x1010000000000, table=IPv6, priority=201,icmp6,in_port=100,icmp_type=136 actions=drop",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := oftest.NewMockOFEntryOperations(ctrl)
+
+			fc := newFakeClient(m, tc.enableIPv4, tc.enableIPv6, config.K8sNode, config.TrafficEncapModeEncap)
+			defer resetPipelines()
+
+			m.EXPECT().AddAll(gomock.Any()).Return(nil).Times(1)
+			m.EXPECT().DeleteAll(gomock.Any()).Return(nil).Times(1)
+			cacheKey := fmt.Sprintf("neighbor_spoofguard_%d", ofPort)
+
+			assert.NoError(t, fc.InstallPodNeighborSpoofGuardFlows(ofPort, tc.podInterfaceIPs, podMAC))
+			fCacheI, ok := fc.featurePodConnectivity.podCachedFlows.Load(cacheKey)
+			require.True(t, ok)
+			assert.ElementsMatch(t, tc.expectedFlows, getFlowStrings(fCacheI))
+
+			assert.NoError(t, fc.UninstallPodNeighborSpoofGuardFlows(ofPort))
+			_, ok = fc.featurePodConnectivity.podCachedFlows.Load(cacheKey)
+			require.False(t, ok)
+		})
+	}
+}
+
 func Test_client_InstallTraceflowFlows(t *testing.T) {
 	type fields struct {
 	}
@@ -1476,7 +1813,7 @@ func Test_client_InstallTraceflowFlows(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			c := tt.prepareFunc(ctrl)
-			if err := c.InstallTraceflowFlows(tt.args.dataplaneTag, false, false, false, nil, 0, 300); (err != nil) != tt.wantErr {
+			if err := c.InstallTraceflowFlows(tt.args.dataplaneTag, false, false, false, false, nil, 0, 300); (err != nil) != tt.wantErr {
 				t.Errorf("InstallTraceflowFlows() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -1706,7 +2043,7 @@ func Test_client_setBasePacketOutBuilder(t *testing.T) {
 }
 
 func prepareSetBasePacketOutBuilder(ctrl *gomock.Controller, success bool) *client {
-	ofClient := NewClient(bridgeName, bridgeMgmtAddr, true, true, false, false, false, false, false, false, false, false)
+	ofClient := NewClient(bridgeName, bridgeMgmtAddr, true, true, false, false, false, false, false, false, false, false, false)
 	c := ofClient.(*client)
 	m := ovsoftest.NewMockBridge(ctrl)
 	c.bridge = m
@@ -2156,6 +2493,126 @@ func Test_client_InstallTrafficControlReturnPortFlow(t *testing.T) {
 	require.False(t, ok)
 }
 
+func Test_client_InstallTrafficMirrorFlows(t *testing.T) {
+	tmName := "test_tm"
+	sourceOFPorts := []uint32{50}
+	targetOFPort := uint32(200)
+	_, dstIPNet, _ := net.ParseCIDR("10.10.0.0/24")
+
+	testCases := []struct {
+		name          string
+		direction     v1alpha2.Direction
+		filters       []types.TrafficMirrorFilter
+		expectedFlows []string
+	}{
+		{
+			name:      "Both,no filter",
+			direction: v1alpha2.DirectionBoth,
+			expectedFlows: []string{
+				"cookie=0x1010000000000, table=TrafficControl, priority=190,reg1=0x32 actions=set_field:0xc8->reg9,set_field:0x400000/0xc00000->reg4,goto_table:IngressSecurityClassifier",
+				"cookie=0x1010000000000, table=TrafficControl, priority=190,in_port=50 actions=set_field:0xc8->reg9,set_field:0x400000/0xc00000->reg4,goto_table:IngressSecurityClassifier",
+			},
+		},
+		{
+			name:      "Egress,filters",
+			direction: v1alpha2.DirectionEgress,
+			filters: []types.TrafficMirrorFilter{
+				{Protocol: binding.ProtocolTCP, DestinationIPNet: dstIPNet, DestinationPort: 80},
+				{Protocol: binding.ProtocolUDP, DestinationPort: 53},
+			},
+			expectedFlows: []string{
+				"cookie=0x1010000000000, table=TrafficControl, priority=190,tcp,in_port=50,nw_dst=10.10.0.0/24,tp_dst=80 actions=set_field:0xc8->reg9,set_field:0x400000/0xc00000->reg4,goto_table:IngressSecurityClassifier",
+				"cookie=0x1010000000000, table=TrafficControl, priority=190,udp,in_port=50,tp_dst=53 actions=set_field:0xc8->reg9,set_field:0x400000/0xc00000->reg4,goto_table:IngressSecurityClassifier",
+			},
+		},
+		{
+			name:      "Ingress,filters",
+			direction: v1alpha2.DirectionIngress,
+			filters: []types.TrafficMirrorFilter{
+				{Protocol: binding.ProtocolTCP, DestinationIPNet: dstIPNet, DestinationPort: 80},
+			},
+			expectedFlows: []string{
+				"cookie=0x1010000000000, table=TrafficControl, priority=190,tcp,reg1=0x32,nw_dst=10.10.0.0/24,tp_dst=80 actions=set_field:0xc8->reg9,set_field:0x400000/0xc00000->reg4,goto_table:IngressSecurityClassifier",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := oftest.NewMockOFEntryOperations(ctrl)
+
+			fc := newFakeClient(m, true, true, config.K8sNode, config.TrafficEncapModeEncap, enableTrafficControl)
+			defer resetPipelines()
+
+			m.EXPECT().AddAll(gomock.Any()).Return(nil).Times(1)
+			m.EXPECT().DeleteAll(gomock.Any()).Return(nil).Times(1)
+
+			cacheKey := fmt.Sprintf("tm_%s", tmName)
+
+			assert.NoError(t, fc.InstallTrafficMirrorFlows(tmName, sourceOFPorts, targetOFPort, tc.direction, tc.filters))
+			fCacheI, ok := fc.featurePodConnectivity.tcCachedFlows.Load(cacheKey)
+			require.True(t, ok)
+			assert.ElementsMatch(t, tc.expectedFlows, getFlowStrings(fCacheI))
+
+			assert.NoError(t, fc.UninstallTrafficMirrorFlows(tmName))
+			_, ok = fc.featurePodConnectivity.tcCachedFlows.Load(cacheKey)
+			require.False(t, ok)
+		})
+	}
+}
+
+func Test_client_InstallGatewayProxyFlows(t *testing.T) {
+	_, subnet100, _ := net.ParseCIDR("10.10.100.0/24")
+	_, subnet200, _ := net.ParseCIDR("10.10.200.0/24")
+	subnets := []types.GatewayProxySubnet{
+		{Gateway: net.ParseIP("10.10.100.1"), Subnet: subnet100, VLANID: 100},
+		{Gateway: net.ParseIP("10.10.200.1"), Subnet: subnet200, VLANID: 200},
+	}
+	expectedFlows := []string{
+		"cookie=0x1010000000000, table=ARPResponder, priority=200,arp,arp_tpa=10.10.100.1,arp_op=1 actions=move:NXM_OF_ETH_SRC[]->NXM_OF_ETH_DST[],set_field:0a:00:00:00:00:01->eth_src,set_field:2->arp_op,move:NXM_NX_ARP_SHA[]->NXM_NX_ARP_THA[],set_field:0a:00:00:00:00:01->arp_sha,move:NXM_OF_ARP_SPA[]->NXM_OF_ARP_TPA[],set_field:10.10.100.1->arp_spa,IN_PORT",
+		"cookie=0x1010000000000, table=ARPResponder, priority=200,arp,arp_tpa=10.10.200.1,arp_op=1 actions=move:NXM_OF_ETH_SRC[]->NXM_OF_ETH_DST[],set_field:0a:00:00:00:00:01->eth_src,set_field:2->arp_op,move:NXM_NX_ARP_SHA[]->NXM_NX_ARP_THA[],set_field:0a:00:00:00:00:01->arp_sha,move:NXM_OF_ARP_SPA[]->NXM_OF_ARP_TPA[],set_field:10.10.200.1->arp_spa,IN_PORT",
+		"cookie=0x1010000000000, table=L3Forwarding, priority=210,ip,reg4=0x100000/0x100000,reg8=0x64/0xfff,dl_dst=0a:00:00:00:00:01,nw_src=10.10.100.0/24,nw_dst=10.10.200.0/24 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:0xc8/0xfff->reg8,resubmit:L3Forwarding",
+		"cookie=0x1010000000000, table=L3Forwarding, priority=210,ip,reg4=0x100000/0x100000,reg8=0xc8/0xfff,dl_dst=0a:00:00:00:00:01,nw_src=10.10.200.0/24,nw_dst=10.10.100.0/24 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:0x64/0xfff->reg8,resubmit:L3Forwarding",
+	}
+
+	ctrl := gomock.NewController(t)
+	m := oftest.NewMockOFEntryOperations(ctrl)
+	fc := newFakeClient(m, true, false, config.K8sNode, config.TrafficEncapModeNoEncap, enableConnectUplinkToBridge)
+	defer resetPipelines()
+
+	m.EXPECT().AddAll(gomock.Any()).Return(nil).Times(1)
+	assert.NoError(t, fc.InstallGatewayProxyFlows(subnets))
+	fCacheI, ok := fc.featurePodConnectivity.nodeCachedFlows.Load("gateway_proxy")
+	require.True(t, ok)
+	assert.ElementsMatch(t, expectedFlows, getFlowStrings(fCacheI))
+
+	// Removing a subnet should remove the flows of the subnet.
+	m.EXPECT().BundleOps(gomock.Len(0), gomock.Len(1), gomock.Len(3)).Return(nil).Times(1)
+	assert.NoError(t, fc.InstallGatewayProxyFlows(subnets[:1]))
+	fCacheI, ok = fc.featurePodConnectivity.nodeCachedFlows.Load("gateway_proxy")
+	require.True(t, ok)
+	assert.ElementsMatch(t, expectedFlows[:1], getFlowStrings(fCacheI))
+}
+
+func Test_client_UpdateNodePortAddresses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := oftest.NewMockOFEntryOperations(ctrl)
+	fc := newFakeClient(m, true, false, config.K8sNode, config.TrafficEncapModeEncap, enableProxyAll)
+	defer resetPipelines()
+
+	// The flow for the new address is added, and the flow for the removed address "127.0.0.1" is deleted.
+	newNodePortAddresses := []net.IP{fakeNodeIPv4, net.ParseIP("192.168.78.100")}
+	m.EXPECT().BundleOps(gomock.Len(1), gomock.Len(0), gomock.Len(1)).Return(nil).Times(1)
+	require.NoError(t, fc.UpdateNodePortAddresses(newNodePortAddresses, nil))
+	assert.Equal(t, newNodePortAddresses, fc.featureService.nodePortAddresses[binding.ProtocolIP])
+
+	// The NodePort addresses are restored if the flows fail to be updated.
+	m.EXPECT().BundleOps(gomock.Len(0), gomock.Len(0), gomock.Len(1)).Return(fmt.Errorf("error")).Times(1)
+	assert.Error(t, fc.UpdateNodePortAddresses([]net.IP{fakeNodeIPv4}, nil))
+	assert.Equal(t, newNodePortAddresses, fc.featureService.nodePortAddresses[binding.ProtocolIP])
+}
+
 func Test_client_InstallMulticastGroup(t *testing.T) {
 	groupID := binding.GroupIDType(101)
 	localReceivers := []uint32{50, 100}
@@ -2234,12 +2691,16 @@ func Test_client_InstallMulticlusterNodeFlows(t *testing.T) {
 	clusterID := "test_cluster"
 	_, peerServiceCIDRIPv4, _ := net.ParseCIDR("10.97.0.0/16")
 	tunnelPeerIPv4 := net.ParseIP("192.168.78.101")
+	remoteGatewayIPv4 := net.ParseIP("192.168.79.101")
+	peerGatewayIPv4 := net.ParseIP("192.168.79.102")
 
 	testCases := []struct {
-		name          string
-		peerConfigs   map[*net.IPNet]net.IP
-		tunnelPeerIP  net.IP
-		expectedFlows []string
+		name           string
+		peerConfigs    map[*net.IPNet]net.IP
+		tunnelPeerIP   net.IP
+		gatewayGroupID binding.GroupIDType
+		peerGatewayIPs []net.IP
+		expectedFlows  []string
 	}{
 		{
 			name:         "IPv4",
@@ -2251,6 +2712,20 @@ func Test_client_InstallMulticlusterNodeFlows(t *testing.T) {
 				"cookie=0x1060000000000, table=L3Forwarding, priority=199,ip,reg0=0x2000/0x2000,nw_dst=192.168.78.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
 			},
 		},
+		{
+			name:           "IPv4 multiple Gateways",
+			peerConfigs:    map[*net.IPNet]net.IP{peerServiceCIDRIPv4: remoteGatewayIPv4},
+			tunnelPeerIP:   tunnelPeerIPv4,
+			gatewayGroupID: binding.GroupIDType(102),
+			peerGatewayIPs: []net.IP{peerGatewayIPv4},
+			expectedFlows: []string{
+				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ip,nw_dst=10.97.0.0/16 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:0x10/0xf0->reg0,group:102",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ct_state=+rpl+trk,ip,nw_dst=192.168.79.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=199,ip,reg0=0x2000/0x2000,nw_dst=192.168.79.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ct_state=+rpl+trk,ip,nw_dst=192.168.79.102 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=199,ip,reg0=0x2000/0x2000,nw_dst=192.168.79.102 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+			},
+		},
 		//TODO: IPv6
 	}
 	for _, tc := range testCases {
@@ -2264,7 +2739,7 @@ func Test_client_InstallMulticlusterNodeFlows(t *testing.T) {
 			m.EXPECT().AddAll(gomock.Any()).Return(nil).Times(1)
 			m.EXPECT().DeleteAll(gomock.Any()).Return(nil).Times(1)
 
-			assert.NoError(t, fc.InstallMulticlusterNodeFlows(clusterID, tc.peerConfigs, tc.tunnelPeerIP, true))
+			assert.NoError(t, fc.InstallMulticlusterNodeFlows(clusterID, tc.peerConfigs, tc.tunnelPeerIP, tc.gatewayGroupID, tc.peerGatewayIPs, true))
 			cacheKey := fmt.Sprintf("cluster_%s", clusterID)
 			fCacheI, ok := fc.featureMulticluster.cachedFlows.Load(cacheKey)
 			require.True(t, ok)
@@ -2282,12 +2757,14 @@ func Test_client_InstallMulticlusterGatewayFlows(t *testing.T) {
 	_, peerServiceCIDRIPv4, _ := net.ParseCIDR("10.97.0.0/16")
 	tunnelPeerIPv4 := net.ParseIP("192.168.78.101")
 	localGatewayIPv4 := net.ParseIP("192.168.77.100")
+	peerGatewayIPv4 := net.ParseIP("192.168.78.102")
 
 	testCases := []struct {
 		name           string
 		peerConfigs    map[*net.IPNet]net.IP
 		tunnelPeerIP   net.IP
 		localGatewayIP net.IP
+		peerGatewayIPs []net.IP
 		expectedFlows  []string
 	}{
 		{
@@ -2304,6 +2781,23 @@ func Test_client_InstallMulticlusterGatewayFlows(t *testing.T) {
 				"cookie=0x1060000000000, table=SNAT, priority=200,ct_state=+new+trk,ip,nw_dst=10.97.0.0/16 actions=ct(commit,table=L2ForwardingCalc,zone=65521,nat(src=192.168.77.100))",
 			},
 		},
+		{
+			name:           "IPv4 multiple peer Gateways",
+			peerConfigs:    map[*net.IPNet]net.IP{peerServiceCIDRIPv4: tunnelPeerIPv4},
+			tunnelPeerIP:   tunnelPeerIPv4,
+			localGatewayIP: localGatewayIPv4,
+			peerGatewayIPs: []net.IP{peerGatewayIPv4},
+			expectedFlows: []string{
+				"cookie=0x1060000000000, table=UnSNAT, priority=200,ip,nw_dst=192.168.77.100 actions=ct(table=ConntrackZone,zone=65521,nat)",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ip,nw_dst=10.97.0.0/16 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ct_state=+rpl+trk,ip,nw_dst=192.168.78.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=199,ip,reg0=0x2000/0x2000,nw_dst=192.168.78.101 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.101->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=200,ct_state=+rpl+trk,ip,nw_dst=192.168.78.102 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.102->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=L3Forwarding, priority=199,ip,reg0=0x2000/0x2000,nw_dst=192.168.78.102 actions=set_field:0a:00:00:00:00:01->eth_src,set_field:aa:bb:cc:dd:ee:f0->eth_dst,set_field:192.168.78.102->tun_dst,set_field:0x10/0xf0->reg0,goto_table:L3DecTTL",
+				"cookie=0x1060000000000, table=SNATMark, priority=210,ct_state=+new+trk,ip,nw_dst=10.97.0.0/16 actions=ct(commit,table=SNAT,zone=65520,exec(set_field:0x20/0x20->ct_mark))",
+				"cookie=0x1060000000000, table=SNAT, priority=200,ct_state=+new+trk,ip,nw_dst=10.97.0.0/16 actions=ct(commit,table=L2ForwardingCalc,zone=65521,nat(src=192.168.77.100))",
+			},
+		},
 		//TODO: IPv6
 	}
 	for _, tc := range testCases {
@@ -2319,7 +2813,7 @@ func Test_client_InstallMulticlusterGatewayFlows(t *testing.T) {
 
 			cacheKey := fmt.Sprintf("cluster_%s", clusterID)
 
-			assert.NoError(t, fc.InstallMulticlusterGatewayFlows(clusterID, tc.peerConfigs, tc.tunnelPeerIP, tc.localGatewayIP, true))
+			assert.NoError(t, fc.InstallMulticlusterGatewayFlows(clusterID, tc.peerConfigs, tc.tunnelPeerIP, tc.localGatewayIP, tc.peerGatewayIPs, true))
 			fCacheI, ok := fc.featureMulticluster.cachedFlows.Load(cacheKey)
 			require.True(t, ok)
 			assert.ElementsMatch(t, tc.expectedFlows, getFlowStrings(fCacheI))
@@ -2331,6 +2825,39 @@ func Test_client_InstallMulticlusterGatewayFlows(t *testing.T) {
 	}
 }
 
+func Test_client_InstallMulticlusterGatewayGroup(t *testing.T) {
+	groupID := binding.GroupIDType(102)
+	ctrl := gomock.NewController(t)
+	m := oftest.NewMockOFEntryOperations(ctrl)
+
+	fc := newFakeClient(m, true, false, config.K8sNode, config.TrafficEncapModeEncap, enableMulticluster)
+	defer resetPipelines()
+
+	m.EXPECT().AddOFEntries(gomock.Any()).Return(nil).Times(1)
+	m.EXPECT().ModifyOFEntries(gomock.Any()).Return(nil).Times(1)
+	m.EXPECT().DeleteOFEntries(gomock.Any()).Return(nil).Times(1)
+
+	assert.NoError(t, fc.InstallMulticlusterGatewayGroup(groupID, []net.IP{net.ParseIP("192.168.78.101"), net.ParseIP("192.168.78.102")}))
+	gCacheI, ok := fc.featureMulticluster.groupCache.Load(groupID)
+	require.True(t, ok)
+	assert.Equal(t, "group_id=102,type=select,"+
+		"bucket=bucket_id:0,weight:100,actions=set_field:192.168.78.101->tun_dst,resubmit:L3DecTTL,"+
+		"bucket=bucket_id:1,weight:100,actions=set_field:192.168.78.102->tun_dst,resubmit:L3DecTTL",
+		getGroupFromCache(gCacheI.(binding.Group)))
+
+	// Update the group when a Gateway is removed.
+	assert.NoError(t, fc.InstallMulticlusterGatewayGroup(groupID, []net.IP{net.ParseIP("192.168.78.102")}))
+	gCacheI, ok = fc.featureMulticluster.groupCache.Load(groupID)
+	require.True(t, ok)
+	assert.Equal(t, "group_id=102,type=select,"+
+		"bucket=bucket_id:0,weight:100,actions=set_field:192.168.78.102->tun_dst,resubmit:L3DecTTL",
+		getGroupFromCache(gCacheI.(binding.Group)))
+
+	assert.NoError(t, fc.UninstallMulticlusterGatewayGroup(groupID))
+	_, ok = fc.featureMulticluster.groupCache.Load(groupID)
+	require.False(t, ok)
+}
+
 func Test_client_InstallMulticlusterClassifierFlows(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	m := oftest.NewMockOFEntryOperations(ctrl)
@@ -2470,6 +2997,66 @@ func Test_client_ReplayFlows(t *testing.T) {
 	assert.ElementsMatch(t, expectedFlows, actualFlows)
 }
 
+func Test_client_DeleteOrphanedFlowsAndGroups(t *testing.T) {
+	for _, dryRun := range []bool{false, true} {
+		t.Run(fmt.Sprintf("dryRun=%t", dryRun), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := oftest.NewMockOFEntryOperations(ctrl)
+			fc := newFakeClient(m, true, false, config.K8sNode, config.TrafficEncapModeEncap)
+			defer resetPipelines()
+			fc.roundInfo = types.RoundInfo{RoundNum: 1}
+
+			snatIP := net.ParseIP("192.168.77.100")
+			expectedFlow := getFlowModMessage(fc.featureEgress.snatIPFromTunnelFlow(snatIP, uint32(100)), binding.AddMessage)
+			fc.featureEgress.cachedFlows.Store("egressFlows", flowMessageCache{getFlowKey(expectedFlow): expectedFlow})
+			orphanedFlow := getFlowModMessage(fc.featureEgress.snatIPFromTunnelFlow(net.ParseIP("192.168.77.101"), uint32(101)), binding.AddMessage)
+			fc.featureService.groupCache.Store(binding.GroupIDType(1), ovsoftest.NewMockGroup(ctrl))
+
+			mockBridge := ovsoftest.NewMockBridge(ctrl)
+			fc.bridge = mockBridge
+			mockOVSCtlClient := ovsctltest.NewMockOVSCtlClient(ctrl)
+			fc.ovsctlClient = mockOVSCtlClient
+			toFlowDesc := func(flowMod *openflow15.FlowMod) *openflow15.FlowDesc {
+				return &openflow15.FlowDesc{TableId: flowMod.TableId, Priority: flowMod.Priority, Cookie: flowMod.Cookie, Match: flowMod.Match}
+			}
+			for _, category := range orphanedFlowCategories {
+				cookieID, cookieMask := cookie.CookieMaskForCategory(1, category)
+				var flowDescs []*openflow15.FlowDesc
+				if category == cookie.Egress {
+					flowDescs = []*openflow15.FlowDesc{toFlowDesc(expectedFlow), toFlowDesc(orphanedFlow)}
+				}
+				mockBridge.EXPECT().DumpFlowDescs(cookieID, cookieMask).Return(flowDescs, nil)
+			}
+			mockOVSCtlClient.EXPECT().DumpGroups().Return([]string{
+				"group_id=1,type=select,bucket=bucket_id:0,weight:100,actions=resubmit(,EndpointDNAT)",
+				"group_id=2,type=select,bucket=bucket_id:0,weight:100,actions=resubmit(,EndpointDNAT)",
+			}, nil)
+			if !dryRun {
+				m.EXPECT().DeleteAll(gomock.Any()).Do(func(flowMessages []*openflow15.FlowMod) {
+					require.Len(t, flowMessages, 1)
+					assert.Equal(t, getFlowKey(orphanedFlow), getFlowKey(flowMessages[0]))
+				}).Return(nil)
+				orphanedGroup := ovsoftest.NewMockGroup(ctrl)
+				mockBridge.EXPECT().NewGroup(binding.GroupIDType(2)).Return(orphanedGroup)
+				m.EXPECT().DeleteOFEntries([]binding.OFEntry{orphanedGroup}).Return(nil)
+			}
+
+			flows, groups, err := fc.DeleteOrphanedFlowsAndGroups(dryRun)
+			require.NoError(t, err)
+			assert.Equal(t, []string{getFlowKey(orphanedFlow)}, flows)
+			assert.Equal(t, []binding.GroupIDType{2}, groups)
+		})
+	}
+}
+
+func Test_parseGroupID(t *testing.T) {
+	groupID, err := parseGroupID("group_id=10,type=all,bucket=bucket_id:0,actions=resubmit(,MulticastOutput)")
+	require.NoError(t, err)
+	assert.Equal(t, binding.GroupIDType(10), groupID)
+	_, err = parseGroupID("type=all")
+	assert.Error(t, err)
+}
+
 func TestCachedFlowIsDrop(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -89,6 +89,12 @@ func CookieMaskForRound(round uint64) (uint64, uint64) {
 	return round << (64 - BitwidthRound), RoundMask
 }
 
+// CookieMaskForCategory returns a cookie and mask value that can be used to
+// select all flows belonging to the provided round and category.
+func CookieMaskForCategory(round uint64, cat Category) (uint64, uint64) {
+	return newID(round, cat, 0).Raw(), RoundMask | CategoryMask
+}
+
 // Raw returns the unit64 type value of the ID.
 func (i ID) Raw() uint64 {
 	return uint64(i)
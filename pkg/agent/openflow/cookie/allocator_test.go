@@ -74,3 +74,11 @@ func TestConcurrentAllocate(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestCookieMaskForCategory(t *testing.T) {
+	a := NewAllocator(10)
+	cookieID, cookieMask := CookieMaskForCategory(10, Service)
+	assert.Equal(t, cookieID, a.RequestWithObjectID(Service, 100).Raw()&cookieMask)
+	assert.NotEqual(t, cookieID, a.RequestWithObjectID(NetworkPolicy, 100).Raw()&cookieMask)
+	assert.NotEqual(t, cookieID, NewAllocator(11).Request(Service).Raw()&cookieMask)
+}
@@ -22,6 +22,7 @@ import (
 	"antrea.io/antrea/pkg/agent/config"
 	"antrea.io/antrea/pkg/agent/openflow/cookie"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
+	"antrea.io/antrea/pkg/util/runtime"
 )
 
 type featureEgress struct {
@@ -34,6 +35,16 @@ type featureEgress struct {
 	nodeIPs     map[binding.Protocol]net.IP
 	gatewayMAC  net.HardwareAddr
 
+	// The following fields are only used on Windows, where SNAT is performed in OVS and the SNAT'd packets are output
+	// to the uplink directly.
+	uplinkPort       uint32
+	uplinkMAC        net.HardwareAddr
+	uplinkGatewayMAC net.HardwareAddr
+	snatCtZones      map[binding.Protocol]int
+
+	enableDefaultDeny        bool
+	defaultDenyAllNamespaces bool
+
 	category cookie.Category
 }
 
@@ -55,28 +66,51 @@ func newFeatureEgress(cookieAllocator cookie.Allocator,
 	}
 
 	nodeIPs := make(map[binding.Protocol]net.IP)
+	snatCtZones := make(map[binding.Protocol]int)
 	for _, ipProtocol := range ipProtocols {
 		if ipProtocol == binding.ProtocolIP {
 			nodeIPs[ipProtocol] = nodeConfig.NodeIPv4Addr.IP
+			snatCtZones[ipProtocol] = SNATCtZone
 		} else if ipProtocol == binding.ProtocolIPv6 {
 			nodeIPs[ipProtocol] = nodeConfig.NodeIPv6Addr.IP
+			snatCtZones[ipProtocol] = SNATCtZoneV6
 		}
 	}
-	return &featureEgress{
+	f := &featureEgress{
 		cachedFlows:     newFlowCategoryCache(),
 		cookieAllocator: cookieAllocator,
 		exceptCIDRs:     exceptCIDRs,
 		ipProtocols:     ipProtocols,
 		nodeIPs:         nodeIPs,
 		gatewayMAC:      nodeConfig.GatewayConfig.MAC,
+		snatCtZones:     snatCtZones,
 		category:        cookie.Egress,
+
+		enableDefaultDeny:        egressConfig.EnableDefaultDeny,
+		defaultDenyAllNamespaces: egressConfig.DefaultDenyAllNamespaces,
 	}
+	if nodeConfig.UplinkNetConfig != nil {
+		f.uplinkPort = nodeConfig.UplinkNetConfig.OFPort
+		f.uplinkMAC = nodeConfig.UplinkNetConfig.MAC
+		f.uplinkGatewayMAC = nodeConfig.UplinkNetConfig.GatewayMAC
+	}
+	return f
 }
 
 func (f *featureEgress) initFlows() []*openflow15.FlowMod {
 	// This installs the flows to enable Pods to communicate to the external IP addresses. The flows identify the packets
 	// from local Pods to the external IP address, and mark the packets to be SNAT'd with the configured SNAT IPs.
-	return GetFlowModMessages(f.externalFlows(), binding.AddMessage)
+	flows := f.externalFlows()
+	if runtime.IsWindowsPlatform() && f.uplinkGatewayMAC != nil {
+		// This installs the flow to output the packets SNAT'd in OVS to the uplink.
+		flows = append(flows, f.snatUplinkGatewayFlow())
+	}
+	if f.enableDefaultDeny {
+		// This installs the flows to drop the packets from local Pods to the external network, which are neither SNAT'd by
+		// an Egress nor allowed by an Antrea-native policy.
+		flows = append(flows, f.defaultDenyFlows()...)
+	}
+	return GetFlowModMessages(flows, binding.AddMessage)
 }
 
 func (f *featureEgress) replayFlows() []*openflow15.FlowMod {
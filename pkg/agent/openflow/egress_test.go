@@ -71,3 +71,39 @@ func Test_featureEgress_initFlows(t *testing.T) {
 		})
 	}
 }
+
+func Test_featureEgress_initFlowsWithDefaultDeny(t *testing.T) {
+	testCases := []struct {
+		name          string
+		allNamespaces bool
+		expectedFlows []string
+	}{
+		{
+			name: "annotated Namespaces",
+			expectedFlows: append(egressInitFlows(true),
+				"cookie=0x1040000000000, table=EgressMark, priority=190,ct_state=+new+trk,ip,reg0=0x3/0xf actions=goto_table:EgressDefaultDeny",
+				"cookie=0x1040000000000, table=EgressDefaultDeny, priority=210,ip,reg0=0x8000/0x8000 actions=set_field:0x20/0xf0->reg0,goto_table:L2ForwardingCalc",
+				"cookie=0x1040000000000, table=EgressDefaultDeny, priority=0 actions=set_field:0x20/0xf0->reg0,goto_table:L2ForwardingCalc",
+			),
+		},
+		{
+			name:          "all Namespaces",
+			allNamespaces: true,
+			expectedFlows: append(egressInitFlows(true),
+				"cookie=0x1040000000000, table=EgressMark, priority=190,ct_state=+new+trk,ip,reg0=0x3/0xf actions=goto_table:EgressDefaultDeny",
+				"cookie=0x1040000000000, table=EgressDefaultDeny, priority=210,ip,reg0=0x8000/0x8000 actions=set_field:0x20/0xf0->reg0,goto_table:L2ForwardingCalc",
+				"cookie=0x1040000000000, table=EgressDefaultDeny, priority=190,ip actions=drop",
+				"cookie=0x1040000000000, table=EgressDefaultDeny, priority=0 actions=set_field:0x20/0xf0->reg0,goto_table:L2ForwardingCalc",
+			),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := newFakeClient(nil, true, false, config.K8sNode, config.TrafficEncapModeEncap, enableEgressDefaultDeny(tc.allNamespaces))
+			defer resetPipelines()
+
+			flows := getFlowStrings(fc.featureEgress.initFlows())
+			assert.ElementsMatch(t, tc.expectedFlows, flows)
+		})
+	}
+}
@@ -31,6 +31,7 @@ type featureExternalNodeConnectivity struct {
 	cookieAllocator cookie.Allocator
 	ipProtocols     []binding.Protocol
 	ctZones         map[binding.Protocol]int
+	enableProxy     bool
 	category        cookie.Category
 
 	uplinkFlowCache *flowCategoryCache
@@ -42,7 +43,8 @@ func (f *featureExternalNodeConnectivity) getFeatureName() string {
 
 func newFeatureExternalNodeConnectivity(
 	cookieAllocator cookie.Allocator,
-	ipProtocols []binding.Protocol) *featureExternalNodeConnectivity {
+	ipProtocols []binding.Protocol,
+	enableProxy bool) *featureExternalNodeConnectivity {
 	ctZones := make(map[binding.Protocol]int)
 	for _, ipProtocol := range ipProtocols {
 		if ipProtocol == binding.ProtocolIP {
@@ -57,6 +59,7 @@ func newFeatureExternalNodeConnectivity(
 		ipProtocols:     ipProtocols,
 		uplinkFlowCache: newFlowCategoryCache(),
 		ctZones:         ctZones,
+		enableProxy:     enableProxy,
 		category:        cookie.ExternalNodeConnectivity,
 	}
 }
@@ -112,14 +115,26 @@ func (f *featureExternalNodeConnectivity) initFlows() []*openflow15.FlowMod {
 	}
 	for _, ipProtocol := range f.ipProtocols {
 		ctZone := f.ctZones[ipProtocol]
+		// This generates the flow to maintain tracked connections in CT zone. When AntreaProxy is enabled, the
+		// packets of Service connections are also translated, so that the replies are un-DNATed.
+		ctAction := ConntrackTable.ofTable.BuildFlow(priorityNormal).
+			Cookie(cookieID).
+			MatchProtocol(ipProtocol).
+			Action().CT(false, ConntrackTable.ofTable.GetNext(), ctZone, nil)
+		if f.enableProxy {
+			ctAction = ctAction.NAT()
+		}
+		// The Service connections have been committed in EndpointDNATTable, so they are not committed again.
+		commitFlowBuilder := ConntrackCommitTable.ofTable.BuildFlow(priorityNormal).
+			Cookie(cookieID).
+			MatchProtocol(ipProtocol).
+			MatchCTStateNew(true).
+			MatchCTStateTrk(true)
+		if f.enableProxy {
+			commitFlowBuilder = commitFlowBuilder.MatchCTMark(NotServiceCTMark)
+		}
 		flows = append(flows,
-			// This generates the flow to maintain tracked connections in CT zone.
-			ConntrackTable.ofTable.BuildFlow(priorityNormal).
-				Cookie(cookieID).
-				MatchProtocol(ipProtocol).
-				Action().CT(false, ConntrackTable.ofTable.GetNext(), ctZone, nil).
-				CTDone().
-				Done(),
+			ctAction.CTDone().Done(),
 			ConntrackStateTable.ofTable.BuildFlow(priorityHigh).
 				Cookie(cookieID).
 				MatchProtocol(ipProtocol).
@@ -127,12 +142,7 @@ func (f *featureExternalNodeConnectivity) initFlows() []*openflow15.FlowMod {
 				MatchCTStateTrk(true).
 				Action().Drop().
 				Done(),
-			ConntrackCommitTable.ofTable.BuildFlow(priorityNormal).
-				Cookie(cookieID).
-				MatchProtocol(ipProtocol).
-				MatchCTStateNew(true).
-				MatchCTStateTrk(true).
-				Action().CT(true, ConntrackCommitTable.GetNext(), ctZone, nil).CTDone().
+			commitFlowBuilder.Action().CT(true, ConntrackCommitTable.GetNext(), ctZone, nil).CTDone().
 				Done(),
 		)
 	}
@@ -101,16 +101,39 @@ func Test_client_InstallPolicyBypassFlows(t *testing.T) {
 }
 
 func Test_featureExternalInodeConnectivity_initFlows(t *testing.T) {
-	fc := newFakeClient(nil, true, false, config.ExternalNode, config.TrafficEncapModeEncap)
-	defer resetPipelines()
-
-	expectedFlows := []string{
-		"cookie=0x1080000000000, table=ConntrackZone, priority=200,ip actions=ct(table=ConntrackState,zone=65520)",
-		"cookie=0x1080000000000, table=ConntrackState, priority=210,ct_state=+inv+trk,ip actions=drop",
-		"cookie=0x1080000000000, table=ConntrackCommit, priority=200,ct_state=+new+trk,ip actions=ct(commit,table=Output,zone=65520)",
-		"cookie=0x1080000000000, table=Output, priority=200,reg0=0x100/0x100 actions=output:NXM_NX_REG1[]",
+	testCases := []struct {
+		name          string
+		clientOptions []clientOptionsFn
+		expectedFlows []string
+	}{
+		{
+			name:          "No Proxy",
+			clientOptions: []clientOptionsFn{disableProxy},
+			expectedFlows: []string{
+				"cookie=0x1080000000000, table=ConntrackZone, priority=200,ip actions=ct(table=ConntrackState,zone=65520)",
+				"cookie=0x1080000000000, table=ConntrackState, priority=210,ct_state=+inv+trk,ip actions=drop",
+				"cookie=0x1080000000000, table=ConntrackCommit, priority=200,ct_state=+new+trk,ip actions=ct(commit,table=Output,zone=65520)",
+				"cookie=0x1080000000000, table=Output, priority=200,reg0=0x100/0x100 actions=output:NXM_NX_REG1[]",
+			},
+		},
+		{
+			name:          "Proxy",
+			clientOptions: []clientOptionsFn{enableProxy},
+			expectedFlows: []string{
+				"cookie=0x1080000000000, table=ConntrackZone, priority=200,ip actions=ct(table=ConntrackState,zone=65520,nat)",
+				"cookie=0x1080000000000, table=ConntrackState, priority=210,ct_state=+inv+trk,ip actions=drop",
+				"cookie=0x1080000000000, table=ConntrackCommit, priority=200,ct_state=+new+trk,ct_mark=0x0/0x10,ip actions=ct(commit,table=Output,zone=65520)",
+				"cookie=0x1080000000000, table=Output, priority=200,reg0=0x100/0x100 actions=output:NXM_NX_REG1[]",
+			},
+		},
 	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := newFakeClient(nil, true, false, config.ExternalNode, config.TrafficEncapModeEncap, tc.clientOptions...)
+			defer resetPipelines()
 
-	flows := getFlowStrings(fc.featureExternalNodeConnectivity.initFlows())
-	assert.ElementsMatch(t, expectedFlows, flows)
+			flows := getFlowStrings(fc.featureExternalNodeConnectivity.initFlows())
+			assert.ElementsMatch(t, tc.expectedFlows, flows)
+		})
+	}
 }
@@ -32,7 +32,7 @@ var (
 	//   - 1: from tunnel port.
 	//   - 2: from Antrea gateway port.
 	//   - 3: from local Pods.
-	//   - 4: from uplink port.
+	//   - 4: from uplink port, or from the patch port connected to the OVS physical bridge.
 	//   - 5: from bridge local port.
 	//   - 6: from traffic control return port.
 	PktSourceField      = binding.NewRegField(0, 0, 3)
@@ -73,6 +73,9 @@ var (
 	GeneratedRejectPacketOutRegMark = binding.NewOneBitRegMark(0, 13)
 	// reg0[14]: Mark to indicate a Service without any Endpoints (used by Proxy)
 	SvcNoEpRegMark = binding.NewOneBitRegMark(0, 14)
+	// reg0[15]: Mark to indicate the packet is allowed by an Antrea-native policy egress rule. It is only loaded when
+	// Egress default deny is enabled, to exempt the packet from being dropped in EgressDefaultDenyTable.
+	APEgressAllowRegMark = binding.NewOneBitRegMark(0, 15)
 	// reg0[19]: Mark to indicate remote SNAT for Egress.
 	RemoteSNATRegMark = binding.NewOneBitRegMark(0, 19)
 	// reg0[20]: Field to indicate redirect action of layer 7 NetworkPolicy.
@@ -123,6 +126,12 @@ var (
 	TrafficControlRedirectRegMark = binding.NewRegMark(TrafficControlActionField, 0b10)
 	// reg4[24]: Mark to indicate that whether the Service is backed by Service IPs of other Services.
 	NestedServiceRegMark = binding.NewOneBitRegMark(4, 24)
+	// reg4[25]: Mark to indicate that the packet is to an external address of a Service load balanced in DSR mode. The
+	// packets to remote Endpoints are forwarded through the tunnel without DNAT.
+	DSRServiceRegMark = binding.NewOneBitRegMark(4, 25)
+	// reg4[26]: Mark to indicate that the packet is to a Service's ClusterIP. It is used to enforce the Antrea-native
+	// policies applied to Services on the Node of the client, before the connection is sent to the selected Endpoint.
+	ToClusterIPRegMark = binding.NewOneBitRegMark(4, 26)
 
 	// reg5(NXM_NX_REG5)
 	// Field to cache the Egress conjunction ID hit by TraceFlow packet.
@@ -196,6 +196,9 @@ func (f *featurePodConnectivity) getRequiredTables() []*Table {
 	if f.enableTrafficControl {
 		tables = append(tables, TrafficControlTable)
 	}
+	if f.enablePodBandwidthMeter {
+		tables = append(tables, PodEgressBandwidthTable, PodIngressBandwidthTable)
+	}
 
 	return tables
 }
@@ -241,6 +244,13 @@ func (f *featureService) getRequiredTables() []*Table {
 	if !f.enableProxy {
 		return []*Table{DNATTable}
 	}
+	if f.nodeType == config.ExternalNode {
+		return []*Table{
+			SessionAffinityTable,
+			ServiceLBTable,
+			EndpointDNATTable,
+		}
+	}
 	tables := []*Table{
 		UnSNATTable,
 		PreRoutingClassifierTable,
@@ -260,10 +270,14 @@ func (f *featureService) getRequiredTables() []*Table {
 }
 
 func (f *featureEgress) getRequiredTables() []*Table {
-	return []*Table{
+	tables := []*Table{
 		L3ForwardingTable,
 		EgressMarkTable,
 	}
+	if f.enableDefaultDeny {
+		tables = append(tables, EgressDefaultDenyTable)
+	}
+	return tables
 }
 
 func (f *featureMulticast) getRequiredTables() []*Table {
@@ -314,7 +328,8 @@ type traceableFeature interface {
 		ovsMetersAreSupported,
 		liveTraffic,
 		droppedOnly,
-		receiverOnly bool,
+		receiverOnly,
+		reverse bool,
 		packet *binding.Packet,
 		ofPort uint32,
 		timeoutSeconds uint16) []binding.Flow
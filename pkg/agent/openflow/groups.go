@@ -15,13 +15,30 @@
 package openflow
 
 import (
+	"hash/fnv"
 	"sync"
 
+	"k8s.io/klog/v2"
+
 	binding "antrea.io/antrea/pkg/ovs/openflow"
 )
 
+const (
+	// minDerivedGroupID and maxDerivedGroupID delimit the range of the group IDs derived from keys by AllocateFor. The
+	// group IDs allocated sequentially by Allocate are expected to stay far below this range.
+	minDerivedGroupID binding.GroupIDType = 0x10000000
+	maxDerivedGroupID binding.GroupIDType = 0xefffffff
+	// minServiceSubGroupID and maxServiceSubGroupID delimit the range of the group IDs of the sub-groups of Service
+	// groups, which are derived from keys by the allocator of featureService. The range ends at OFPG_MAX.
+	minServiceSubGroupID binding.GroupIDType = 0xf0000000
+	maxServiceSubGroupID binding.GroupIDType = 0xffffff00
+)
+
 type GroupAllocator interface {
 	Allocate() binding.GroupIDType
+	// AllocateFor allocates a group ID derived from the key, so that the same key gets the same group ID after the
+	// agent restarts, unless the group ID derived from the key is already allocated to another key.
+	AllocateFor(key string) binding.GroupIDType
 	Next() binding.GroupIDType
 	Release(id binding.GroupIDType)
 }
@@ -32,6 +49,11 @@ type groupAllocator struct {
 
 	groupIDCounter binding.GroupIDType
 	recycled       []binding.GroupIDType
+	// minDerivedID and maxDerivedID delimit the range of the group IDs allocated by AllocateFor.
+	minDerivedID binding.GroupIDType
+	maxDerivedID binding.GroupIDType
+	// derived maps the group IDs allocated by AllocateFor to their keys.
+	derived map[binding.GroupIDType]string
 }
 
 // Allocate allocates a new group ID. It allocates id from the "recycled" slices first, then increases the groupIDCounter if no
@@ -50,6 +72,30 @@ func (a *groupAllocator) Allocate() binding.GroupIDType {
 	return id
 }
 
+// AllocateFor allocates the group ID derived from the FNV-1a hash of the key. In case of collision, the following IDs
+// in the range of derived group IDs are tried in order.
+func (a *groupAllocator) AllocateFor(key string) binding.GroupIDType {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	id := a.minDerivedID + binding.GroupIDType(h.Sum32()%uint32(a.maxDerivedID-a.minDerivedID+1))
+	for {
+		owner, exists := a.derived[id]
+		if !exists || owner == key {
+			break
+		}
+		klog.InfoS("Group ID derived from key is already allocated, trying the next one", "key", key, "groupID", id, "owner", owner)
+		if id == a.maxDerivedID {
+			id = a.minDerivedID
+		} else {
+			id++
+		}
+	}
+	a.derived[id] = key
+	return id
+}
+
 // Next is a readonly method which returns the next available group ID. It's useful in tests to predict the group ID.
 func (a *groupAllocator) Next() binding.GroupIDType {
 	a.mu.Lock()
@@ -67,9 +113,20 @@ func (a *groupAllocator) Release(id binding.GroupIDType) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
+	if id >= a.minDerivedID && id <= a.maxDerivedID {
+		delete(a.derived, id)
+		return
+	}
 	a.recycled = append(a.recycled, id)
 }
 
 func NewGroupAllocator() GroupAllocator {
-	return &groupAllocator{}
+	return &groupAllocator{minDerivedID: minDerivedGroupID, maxDerivedID: maxDerivedGroupID, derived: map[binding.GroupIDType]string{}}
+}
+
+// newServiceSubGroupAllocator returns a GroupAllocator deriving the group IDs of the sub-groups of Service groups from
+// keys within their own range, so that they never conflict with the group IDs allocated by NewGroupAllocator. Its
+// Allocate method must not be used.
+func newServiceSubGroupAllocator() GroupAllocator {
+	return &groupAllocator{minDerivedID: minServiceSubGroupID, maxDerivedID: maxServiceSubGroupID, derived: map[binding.GroupIDType]string{}}
 }
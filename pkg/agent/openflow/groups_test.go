@@ -0,0 +1,73 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	binding "antrea.io/antrea/pkg/ovs/openflow"
+)
+
+func TestGroupAllocatorAllocate(t *testing.T) {
+	a := NewGroupAllocator()
+	assert.Equal(t, binding.GroupIDType(1), a.Next())
+	assert.Equal(t, binding.GroupIDType(1), a.Allocate())
+	assert.Equal(t, binding.GroupIDType(2), a.Allocate())
+	a.Release(1)
+	assert.Equal(t, binding.GroupIDType(1), a.Next())
+	assert.Equal(t, binding.GroupIDType(1), a.Allocate())
+	assert.Equal(t, binding.GroupIDType(3), a.Allocate())
+}
+
+func TestGroupAllocatorAllocateFor(t *testing.T) {
+	a := NewGroupAllocator()
+	id1 := a.AllocateFor("ipv4/ns1/svc1:80/TCP")
+	id2 := a.AllocateFor("ipv4/ns1/svc1:80/TCP/local")
+	assert.NotEqual(t, id1, id2)
+	for _, id := range []binding.GroupIDType{id1, id2} {
+		assert.GreaterOrEqual(t, id, minDerivedGroupID)
+		assert.LessOrEqual(t, id, maxDerivedGroupID)
+	}
+	// The same key gets the same group ID, including from another allocator, e.g. after the agent restarts.
+	assert.Equal(t, id1, a.AllocateFor("ipv4/ns1/svc1:80/TCP"))
+	assert.Equal(t, id1, NewGroupAllocator().AllocateFor("ipv4/ns1/svc1:80/TCP"))
+	// The sequentially allocated group IDs don't conflict with the derived group IDs.
+	assert.Equal(t, binding.GroupIDType(1), a.Allocate())
+
+	// A key whose derived group ID is already allocated gets the next one.
+	ga := a.(*groupAllocator)
+	ga.derived[id1] = "ipv4/ns2/svc2:80/TCP"
+	delete(ga.derived, id1+1)
+	assert.Equal(t, id1+1, a.AllocateFor("ipv4/ns1/svc1:80/TCP"))
+
+	// The released derived group IDs are not recycled for Allocate.
+	a.Release(id2)
+	assert.NotContains(t, ga.derived, id2)
+	assert.Equal(t, binding.GroupIDType(2), a.Allocate())
+	assert.Equal(t, id2, a.AllocateFor("ipv4/ns1/svc1:80/TCP/local"))
+}
+
+func TestServiceSubGroupAllocator(t *testing.T) {
+	a := newServiceSubGroupAllocator()
+	id := a.AllocateFor("100/0")
+	assert.GreaterOrEqual(t, id, minServiceSubGroupID)
+	assert.LessOrEqual(t, id, maxServiceSubGroupID)
+	// The sub-group IDs don't conflict with the group IDs derived by NewGroupAllocator.
+	assert.Greater(t, id, maxDerivedGroupID)
+	a.Release(id)
+	assert.Empty(t, a.(*groupAllocator).derived)
+}
@@ -16,8 +16,10 @@ package openflow
 
 import (
 	"net"
+	"sync"
 
 	"antrea.io/libOpenflow/openflow15"
+	"k8s.io/klog/v2"
 
 	"antrea.io/antrea/pkg/agent/openflow/cookie"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
@@ -34,8 +36,10 @@ const UnknownLabelIdentity = uint32(0xffffff)
 
 type featureMulticluster struct {
 	cookieAllocator cookie.Allocator
+	bridge          binding.Bridge
 	cachedFlows     *flowCategoryCache
 	cachedPodFlows  *flowCategoryCache
+	groupCache      sync.Map
 	category        cookie.Category
 	ipProtocols     []binding.Protocol
 	dnatCtZones     map[binding.Protocol]int
@@ -46,13 +50,14 @@ func (f *featureMulticluster) getFeatureName() string {
 	return "Multicluster"
 }
 
-func newFeatureMulticluster(cookieAllocator cookie.Allocator, ipProtocols []binding.Protocol) *featureMulticluster {
+func newFeatureMulticluster(cookieAllocator cookie.Allocator, ipProtocols []binding.Protocol, bridge binding.Bridge) *featureMulticluster {
 	snatCtZones := make(map[binding.Protocol]int)
 	dnatCtZones := make(map[binding.Protocol]int)
 	snatCtZones[ipProtocols[0]] = SNATCtZone
 	dnatCtZones[ipProtocols[0]] = CtZone
 	return &featureMulticluster{
 		cookieAllocator: cookieAllocator,
+		bridge:          bridge,
 		cachedFlows:     newFlowCategoryCache(),
 		cachedPodFlows:  newFlowCategoryCache(),
 		category:        cookie.Multicluster,
@@ -70,6 +75,19 @@ func (f *featureMulticluster) replayFlows() []*openflow15.FlowMod {
 	return getCachedFlowMessages(f.cachedFlows)
 }
 
+func (f *featureMulticluster) replayGroups() {
+	var groups []binding.OFEntry
+	f.groupCache.Range(func(id, value interface{}) bool {
+		group := value.(binding.Group)
+		group.Reset()
+		groups = append(groups, group)
+		return true
+	})
+	if err := f.bridge.AddOFEntriesInBundle(groups, nil, nil); err != nil {
+		klog.ErrorS(err, "error when replaying cached groups for Multicluster")
+	}
+}
+
 func (f *featureMulticluster) l3FwdFlowToRemoteGateway(
 	localGatewayMAC net.HardwareAddr,
 	peerServiceCIDR net.IPNet,
@@ -78,8 +96,7 @@ func (f *featureMulticluster) l3FwdFlowToRemoteGateway(
 	enableStretchedNetworkPolicy bool) []binding.Flow {
 	ipProtocol := getIPProtocol(peerServiceCIDR.IP)
 	cookieID := f.cookieAllocator.Request(f.category).Raw()
-	var flows []binding.Flow
-	flows = append(flows,
+	flows := []binding.Flow{
 		// This generates the flow to forward cross-cluster request packets based
 		// on Service ClusterIP range.
 		L3ForwardingTable.ofTable.BuildFlow(priorityNormal).
@@ -92,6 +109,39 @@ func (f *featureMulticluster) l3FwdFlowToRemoteGateway(
 			Action().LoadRegMark(ToTunnelRegMark).
 			Action().GotoTable(L3DecTTLTable.GetID()).
 			Done(),
+	}
+	return append(flows, f.l3FwdFlowsToRemoteGatewayIP(localGatewayMAC, tunnelPeer, remoteGatewayIP, enableStretchedNetworkPolicy)...)
+}
+
+// l3FwdFlowToRemoteGatewayViaGroup generates the flow to forward cross-cluster request packets based on Service
+// ClusterIP range with the group groupID, which hashes the connections across the active Gateways of the local cluster.
+func (f *featureMulticluster) l3FwdFlowToRemoteGatewayViaGroup(
+	localGatewayMAC net.HardwareAddr,
+	peerServiceCIDR net.IPNet,
+	groupID binding.GroupIDType) binding.Flow {
+	ipProtocol := getIPProtocol(peerServiceCIDR.IP)
+	return L3ForwardingTable.ofTable.BuildFlow(priorityNormal).
+		Cookie(f.cookieAllocator.Request(f.category).Raw()).
+		MatchProtocol(ipProtocol).
+		MatchDstIPNet(peerServiceCIDR).
+		Action().SetSrcMAC(localGatewayMAC).
+		Action().SetDstMAC(GlobalVirtualMACForMulticluster).
+		Action().LoadRegMark(ToTunnelRegMark).
+		Action().Group(groupID). // The group sets the tunnel destination.
+		Done()
+}
+
+// l3FwdFlowsToRemoteGatewayIP generates the flows to forward cross-cluster reply packets destined for the Gateway IP
+// of a remote Gateway to tunnelPeer.
+func (f *featureMulticluster) l3FwdFlowsToRemoteGatewayIP(
+	localGatewayMAC net.HardwareAddr,
+	tunnelPeer net.IP,
+	remoteGatewayIP net.IP,
+	enableStretchedNetworkPolicy bool) []binding.Flow {
+	ipProtocol := getIPProtocol(remoteGatewayIP)
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	var flows []binding.Flow
+	flows = append(flows,
 		// This generates the flow to forward cross-cluster reply traffic based
 		// on Gateway IP.
 		L3ForwardingTable.ofTable.BuildFlow(priorityNormal).
@@ -127,6 +177,19 @@ func (f *featureMulticluster) l3FwdFlowToRemoteGateway(
 	return flows
 }
 
+// gatewayGroup generates the group used on a regular Node to hash the cross-cluster connections across the active
+// Gateways of the local cluster. Each bucket sets the tunnel destination to the InternalIP of a Gateway.
+func (f *featureMulticluster) gatewayGroup(groupID binding.GroupIDType, tunnelPeers []net.IP) binding.Group {
+	group := f.bridge.NewGroup(groupID)
+	for _, tunnelPeer := range tunnelPeers {
+		group = group.Bucket().Weight(100).
+			SetTunnelDst(tunnelPeer).
+			ResubmitToTable(L3DecTTLTable.GetID()).
+			Done()
+	}
+	return group
+}
+
 func (f *featureMulticluster) tunnelClassifierFlow(tunnelOFPort uint32) binding.Flow {
 	return ClassifierTable.ofTable.BuildFlow(priorityHigh).
 		Cookie(f.cookieAllocator.Request(f.category).Raw()).
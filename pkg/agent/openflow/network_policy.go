@@ -672,6 +672,8 @@ type policyRuleConjunction struct {
 	ruleName     string
 	ruleTableID  uint8
 	ruleLogLabel string
+	// meter is the OVS meter enforcing the rate limit of a rule with action RateLimit, it's nil for other rules.
+	meter binding.Meter
 }
 
 // clause groups conjunctive match flows. Matches in a clause represent source addresses(for fromClause), or destination
@@ -772,6 +774,13 @@ func (c *client) DeleteAddressFromDNSConjunction(id uint32, addrs []types.Addres
 	return c.DeletePolicyRuleAddress(id, types.DstAddress, addrs, &dnsPriority)
 }
 
+func (c *client) InstallNodeLocalDNSInterceptFlows(dnsIPs []net.IP) error {
+	c.replayMutex.RLock()
+	defer c.replayMutex.RUnlock()
+	flows := c.featureNetworkPolicy.nodeLocalDNSResponseFlows(dnsIPs)
+	return c.modifyFlows(c.featureNetworkPolicy.cachedFlows, "nodelocaldns", flows)
+}
+
 func (c *clause) addConjunctiveMatchFlow(featureNetworkPolicy *featureNetworkPolicy, match *conjunctiveMatch, enableLogging, isMCNPRule bool) *conjMatchFlowContextChange {
 	matcherKey := match.generateGlobalMapKey()
 	_, found := c.matches[matcherKey]
@@ -1151,6 +1160,10 @@ func (c *client) InstallPolicyRuleFlows(rule *types.PolicyRule) error {
 	defer c.featureNetworkPolicy.conjMatchFlowLock.Unlock()
 	ctxChanges := c.featureNetworkPolicy.calculateMatchFlowChangesForRule(conj, rule)
 
+	// The meter must be added before the metric flows referencing it.
+	if err := c.featureNetworkPolicy.installRateLimitMeter(conj); err != nil {
+		return err
+	}
 	var flowMessages []*openflow15.FlowMod
 	for _, fm := range append(conj.metricFlows, conj.actionFlows...) {
 		flowMessages = append(flowMessages, fm)
@@ -1201,8 +1214,15 @@ func (f *featureNetworkPolicy) calculateActionFlowChangesForRule(rule *types.Pol
 			actionFlows = append(actionFlows, f.conjunctionActionDenyFlow(ruleOfID, ruleTable, rule.Priority, DispositionRej, rule.EnableLogging))
 		} else if rule.IsAntreaNetworkPolicyRule() && *rule.Action == crdv1alpha1.RuleActionPass {
 			actionFlows = append(actionFlows, f.conjunctionActionPassFlow(ruleOfID, ruleTable, rule.Priority, rule.EnableLogging))
+		} else if rule.IsAntreaNetworkPolicyRule() && *rule.Action == crdv1alpha1.RuleActionRateLimit && rule.RateLimit != nil {
+			// Packets of allowed connections skip the rule tables after the first one, hence the meter is applied by
+			// the metric flows which are hit by all packets of the connections.
+			meterID := networkPolicyRateLimitMeterID(ruleOfID)
+			conj.meter = f.networkPolicyRateLimitMeter(meterID, rule.RateLimit)
+			metricFlows = append(metricFlows, f.allowRulesMetricFlows(ruleOfID, isIngress, rule.TableID, meterID)...)
+			actionFlows = append(actionFlows, f.conjunctionActionFlow(ruleOfID, ruleTable, dropTable.GetNext(), rule.Priority, rule.EnableLogging, rule.L7RuleVlanID)...)
 		} else {
-			metricFlows = append(metricFlows, f.allowRulesMetricFlows(ruleOfID, isIngress, rule.TableID)...)
+			metricFlows = append(metricFlows, f.allowRulesMetricFlows(ruleOfID, isIngress, rule.TableID, 0)...)
 			actionFlows = append(actionFlows, f.conjunctionActionFlow(ruleOfID, ruleTable, dropTable.GetNext(), rule.Priority, rule.EnableLogging, rule.L7RuleVlanID)...)
 		}
 		conj.actionFlows = GetFlowModMessages(actionFlows, binding.AddMessage)
@@ -1300,6 +1320,10 @@ func (c *client) BatchInstallPolicyRuleFlows(ofPolicyRules []*types.PolicyRule)
 
 	for _, rule := range ofPolicyRules {
 		conj := c.featureNetworkPolicy.calculateActionFlowChangesForRule(rule)
+		// The meters must be added before the bundle containing the metric flows referencing them.
+		if err := c.featureNetworkPolicy.installRateLimitMeter(conj); err != nil {
+			return err
+		}
 		c.featureNetworkPolicy.addRuleToConjunctiveMatch(conj, rule)
 		for _, msg := range append(conj.actionFlows, conj.metricFlows...) {
 			allFlowMessages = append(allFlowMessages, msg)
@@ -1566,6 +1590,12 @@ func (c *client) UninstallPolicyRuleFlows(ruleID uint32) ([]string, error) {
 	if err := c.ofEntryOperations.DeleteAll(append(conj.actionFlows, conj.metricFlows...)); err != nil {
 		return nil, err
 	}
+	// The meter must be deleted after the metric flows referencing it.
+	if conj.meter != nil {
+		if err := conj.meter.Delete(); err != nil {
+			return nil, fmt.Errorf("failed to delete OpenFlow meter entry for rate limit of rule %d: %w", ruleID, err)
+		}
+	}
 	c.featureNetworkPolicy.conjMatchFlowLock.Lock()
 	defer c.featureNetworkPolicy.conjMatchFlowLock.Unlock()
 	// Get the conjMatchFlowContext changes.
@@ -1608,6 +1638,39 @@ func (f *featureNetworkPolicy) getStalePriorities(conj *policyRuleConjunction) (
 	return staleOFPriorities
 }
 
+// networkPolicyRateLimitMeterID returns the ID of the meter enforcing the rate limit of the rule with the provided
+// OpenFlow ID. The IDs start from networkPolicyMeterIDBase to avoid conflicting with the Pod bandwidth meters.
+func networkPolicyRateLimitMeterID(ruleOfID uint32) binding.MeterIDType {
+	return binding.MeterIDType(networkPolicyMeterIDBase + ruleOfID)
+}
+
+// installRateLimitMeter adds the meter of the provided policyRuleConjunction to the OVS bridge, if it has one.
+func (f *featureNetworkPolicy) installRateLimitMeter(conj *policyRuleConjunction) error {
+	if conj.meter == nil {
+		return nil
+	}
+	if !f.ovsMetersAreSupported {
+		return fmt.Errorf("OVS meters are not supported for rate limit of rule %d", conj.id)
+	}
+	if err := conj.meter.Add(); err != nil {
+		return fmt.Errorf("failed to install OpenFlow meter entry for rate limit of rule %d: %w", conj.id, err)
+	}
+	return nil
+}
+
+func (f *featureNetworkPolicy) replayMeters() {
+	for _, obj := range f.policyCache.List() {
+		conj := obj.(*policyRuleConjunction)
+		if conj.meter == nil {
+			continue
+		}
+		conj.meter.Reset()
+		if err := conj.meter.Add(); err != nil {
+			klog.ErrorS(err, "Error when replaying NetworkPolicy rate limit meter", "ruleID", conj.id)
+		}
+	}
+}
+
 func (f *featureNetworkPolicy) replayFlows() []*openflow15.FlowMod {
 	var flows []*openflow15.FlowMod
 	addActionFlows := func(conj *policyRuleConjunction) {
@@ -1638,6 +1701,7 @@ func (f *featureNetworkPolicy) replayFlows() []*openflow15.FlowMod {
 	for _, ctx := range f.globalConjMatchFlowCache {
 		addMatchFlows(ctx)
 	}
+	flows = append(flows, getCachedFlowMessages(f.cachedFlows)...)
 	return flows
 }
 
@@ -2046,6 +2110,21 @@ func (c *client) NetworkPolicyMetrics() map[uint32]*types.RuleMetric {
 	// flows to get the correct number of total packets.
 	collectMetricsFromFlows(EgressMetricTable, parseMetricFlow)
 	collectMetricsFromFlows(IngressMetricTable, parseMetricFlow)
+	if c.ovsMetersAreSupported {
+		// The packets dropped by the meters of RateLimit rules are still counted by the metric flows, the numbers of
+		// dropped packets are collected from the meters separately.
+		meterStats, _ := c.ovsctlClient.DumpMeterStats()
+		for _, stats := range meterStats {
+			if stats.MeterID < networkPolicyMeterIDBase {
+				continue
+			}
+			ruleID := stats.MeterID - networkPolicyMeterIDBase
+			if _, ok := result[ruleID]; !ok {
+				result[ruleID] = &types.RuleMetric{}
+			}
+			result[ruleID].DroppedPackets += stats.DroppedPacketCount
+		}
+	}
 	return result
 }
 
@@ -2065,6 +2144,9 @@ type featureNetworkPolicy struct {
 	policyCache cache.Indexer
 	// egressTables map records all IDs of tables related to egress rules.
 	egressTables map[uint8]struct{}
+	// cachedFlows stores the flows which are not generated for NetworkPolicy rules, e.g. the flows to intercept DNS
+	// responses sent by NodeLocal DNSCache.
+	cachedFlows *flowCategoryCache
 
 	ovsMetersAreSupported bool
 	enableDenyTracking    bool
@@ -2072,7 +2154,10 @@ type featureNetworkPolicy struct {
 	enableL7NetworkPolicy bool
 	enableMulticast       bool
 	proxyAll              bool
-	ctZoneSrcField        *binding.RegField
+	// enableEgressDefaultDeny indicates whether the packets allowed by Antrea-native policy egress rules should be
+	// marked to bypass Egress default deny.
+	enableEgressDefaultDeny bool
+	ctZoneSrcField          *binding.RegField
 	// deterministic represents whether to generate flows deterministically.
 	// For example, if a flow has multiple actions, setting it to true can get consistent flow.
 	// Enabling it may carry a performance impact. It's disabled by default and should only be used in testing.
@@ -2097,6 +2182,7 @@ func newFeatureNetworkPolicy(
 	enableMulticast bool,
 	proxyAll bool,
 	connectUplinkToBridge bool,
+	enableEgressDefaultDeny bool,
 	nodeType config.NodeType) *featureNetworkPolicy {
 	return &featureNetworkPolicy{
 		cookieAllocator:          cookieAllocator,
@@ -2107,11 +2193,13 @@ func newFeatureNetworkPolicy(
 		l7NetworkPolicyConfig:    l7NetworkPolicyConfig,
 		globalConjMatchFlowCache: make(map[string]*conjMatchFlowContext),
 		policyCache:              cache.NewIndexer(policyConjKeyFunc, cache.Indexers{priorityIndex: priorityIndexFunc}),
+		cachedFlows:              newFlowCategoryCache(),
 		enableMulticast:          enableMulticast,
 		ovsMetersAreSupported:    ovsMetersAreSupported,
 		enableDenyTracking:       enableDenyTracking,
 		enableAntreaPolicy:       enableAntreaPolicy,
 		proxyAll:                 proxyAll,
+		enableEgressDefaultDeny:  enableEgressDefaultDeny,
 		category:                 cookie.NetworkPolicy,
 		ctZoneSrcField:           getZoneSrcField(connectUplinkToBridge),
 	}
@@ -41,6 +41,7 @@ import (
 	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
 	mocks "antrea.io/antrea/pkg/ovs/openflow/testing"
+	"antrea.io/antrea/pkg/ovs/ovsctl"
 	ovsctltest "antrea.io/antrea/pkg/ovs/ovsctl/testing"
 )
 
@@ -312,6 +313,7 @@ func TestInstallPolicyRuleFlows(t *testing.T) {
 func TestBatchInstallPolicyRuleFlows(t *testing.T) {
 	for _, tt := range []struct {
 		name          string
+		enableIPv6    bool
 		rules         []*types.PolicyRule
 		expectedFlows []string
 	}{
@@ -476,12 +478,46 @@ func TestBatchInstallPolicyRuleFlows(t *testing.T) {
 				"cookie=0x1020000000000, table=IngressMetric, priority=200,reg0=0x400/0x400,reg3=0xe actions=drop",
 			},
 		},
+		{
+			name:       "K8s NetworkPolicy rule in dual-stack cluster",
+			enableIPv6: true,
+			rules: []*types.PolicyRule{
+				{
+					Direction: v1beta2.DirectionOut,
+					From:      parseAddresses([]string{"192.168.1.40", "fec0:192:168:1::40"}),
+					To:        parseAddresses([]string{"192.168.2.0/24", "fec0:192:168:2::/64"}),
+					Service:   []v1beta2.Service{{Protocol: &protocolTCP, Port: &port8080}},
+					FlowID:    uint32(10),
+					PolicyRef: &v1beta2.NetworkPolicyReference{
+						Type:      v1beta2.K8sNetworkPolicy,
+						Namespace: "ns1",
+						Name:      "np1",
+						UID:       "id1",
+					},
+				},
+			},
+			// The conjunction and its metric flows are shared by IPv4 and IPv6.
+			expectedFlows: []string{
+				"cookie=0x1020000000000, table=EgressRule, priority=190,conj_id=10,ip actions=set_field:0xa->reg5,ct(commit,table=EgressMetric,zone=65520,exec(set_field:0xa00000000/0xffffffff00000000->ct_label))",
+				"cookie=0x1020000000000, table=EgressRule, priority=190,conj_id=10,ipv6 actions=set_field:0xa->reg5,ct(commit,table=EgressMetric,zone=65510,exec(set_field:0xa00000000/0xffffffff00000000->ct_label))",
+				"cookie=0x1020000000000, table=EgressRule, priority=200,ip,nw_src=192.168.1.40 actions=conjunction(10,1/3)",
+				"cookie=0x1020000000000, table=EgressRule, priority=200,ipv6,ipv6_src=fec0:192:168:1::40 actions=conjunction(10,1/3)",
+				"cookie=0x1020000000000, table=EgressRule, priority=200,ip,nw_dst=192.168.2.0/24 actions=conjunction(10,2/3)",
+				"cookie=0x1020000000000, table=EgressRule, priority=200,ipv6,ipv6_dst=fec0:192:168:2::/64 actions=conjunction(10,2/3)",
+				"cookie=0x1020000000000, table=EgressRule, priority=200,tcp,tp_dst=8080 actions=conjunction(10,3/3)",
+				"cookie=0x1020000000000, table=EgressRule, priority=200,tcp6,tp_dst=8080 actions=conjunction(10,3/3)",
+				"cookie=0x1020000000000, table=EgressDefaultRule, priority=200,ip,nw_src=192.168.1.40 actions=drop",
+				"cookie=0x1020000000000, table=EgressDefaultRule, priority=200,ipv6,ipv6_src=fec0:192:168:1::40 actions=drop",
+				"cookie=0x1020000000000, table=EgressMetric, priority=200,ct_state=+new,ct_label=0xa00000000/0xffffffff00000000 actions=goto_table:L3Forwarding",
+				"cookie=0x1020000000000, table=EgressMetric, priority=200,ct_state=-new,ct_label=0xa00000000/0xffffffff00000000 actions=goto_table:L3Forwarding",
+			},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockOperations := oftest.NewMockOFEntryOperations(ctrl)
 
-			c := newFakeClient(mockOperations, true, false, config.K8sNode, config.TrafficEncapModeEncap)
+			c := newFakeClient(mockOperations, true, tt.enableIPv6, config.K8sNode, config.TrafficEncapModeEncap)
 			defer resetPipelines()
 			c.featureNetworkPolicy.egressTables = map[uint8]struct{}{EgressRuleTable.GetID(): {}, EgressDefaultTable.GetID(): {}, AntreaPolicyEgressRuleTable.GetID(): {}}
 
@@ -1105,10 +1141,12 @@ func TestParseMetricFlow(t *testing.T) {
 
 func TestNetworkPolicyMetrics(t *testing.T) {
 	tests := []struct {
-		name         string
-		egressFlows  []string
-		ingressFlows []string
-		want         map[uint32]*types.RuleMetric
+		name                  string
+		ovsMetersAreSupported bool
+		egressFlows           []string
+		ingressFlows          []string
+		meterStats            []ovsctl.MeterStats
+		want                  map[uint32]*types.RuleMetric
 	}{
 		{
 			name: "Normal flows",
@@ -1176,6 +1214,30 @@ func TestNetworkPolicyMetrics(t *testing.T) {
 				11: {Bytes: 338, Sessions: 4, Packets: 4},
 			},
 		},
+		{
+			name:                  "Flows with rate limit meters",
+			ovsMetersAreSupported: true,
+			egressFlows: []string{
+				"table=61, n_packets=1, n_bytes=74, priority=200,ct_state=+new,ct_label=0x200000000/0xffffffff00000000,ip actions=meter:1048578,goto_table:70",
+				"table=61, n_packets=11, n_bytes=1661, priority=200,ct_state=-new,ct_label=0x200000000/0xffffffff00000000,ip actions=meter:1048578,goto_table:70",
+				"table=61, n_packets=1502362, n_bytes=601635949, priority=0 actions=goto_table:70",
+			},
+			ingressFlows: []string{
+				"table=101, n_packets=1, n_bytes=74, priority=200,ct_state=+new,ct_label=0x1/0xffffffff,ip actions=resubmit(,105)",
+				"table=101, n_packets=11, n_bytes=1661, priority=200,ct_state=-new,ct_label=0x1/0xffffffff,ip actions=resubmit(,105)",
+				"table=101, n_packets=1407190, n_bytes=509746586, priority=0 actions=resubmit(,105)",
+			},
+			meterStats: []ovsctl.MeterStats{
+				{MeterID: 1, PacketCount: 100, ByteCount: 6400, DroppedPacketCount: 10},
+				{MeterID: 1048578, PacketCount: 12, ByteCount: 1735, DroppedPacketCount: 5},
+				{MeterID: 1048583, PacketCount: 0, ByteCount: 0, DroppedPacketCount: 0},
+			},
+			want: map[uint32]*types.RuleMetric{
+				2: {Bytes: 1735, Sessions: 1, Packets: 12, DroppedPackets: 5},
+				1: {Bytes: 1735, Sessions: 1, Packets: 12},
+				7: {},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1183,12 +1245,16 @@ func TestNetworkPolicyMetrics(t *testing.T) {
 			preparePipelines()
 			defer resetPipelines()
 			c = prepareClient(ctrl, false)
+			c.ovsMetersAreSupported = tt.ovsMetersAreSupported
 			mockOVSClient := ovsctltest.NewMockOVSCtlClient(ctrl)
 			c.ovsctlClient = mockOVSClient
 			gomock.InOrder(
 				mockOVSClient.EXPECT().DumpTableFlows(EgressMetricTable.ofTable.GetID()).Return(tt.egressFlows, nil),
 				mockOVSClient.EXPECT().DumpTableFlows(IngressMetricTable.ofTable.GetID()).Return(tt.ingressFlows, nil),
 			)
+			if tt.ovsMetersAreSupported {
+				mockOVSClient.EXPECT().DumpMeterStats().Return(tt.meterStats, nil)
+			}
 			got := c.NetworkPolicyMetrics()
 			assert.Equal(t, tt.want, got)
 		})
@@ -1374,6 +1440,7 @@ func networkPolicyInitFlows(externalNodeEnabled, l7NetworkPolicyEnabled bool) []
 		"cookie=0x1020000000000, table=IngressSecurityClassifier, priority=200,reg0=0x20/0xf0 actions=goto_table:IngressMetric",
 		"cookie=0x1020000000000, table=IngressSecurityClassifier, priority=200,reg0=0x10/0xf0 actions=goto_table:IngressMetric",
 		"cookie=0x1020000000000, table=IngressSecurityClassifier, priority=200,reg0=0x40/0xf0 actions=goto_table:IngressMetric",
+		"cookie=0x1020000000000, table=IngressSecurityClassifier, priority=201,reg4=0x4000000/0x4000000 actions=goto_table:AntreaPolicyIngressRule",
 		"cookie=0x1020000000000, table=AntreaPolicyEgressRule, priority=64990,ct_state=-new+est,ip actions=goto_table:EgressMetric",
 		"cookie=0x1020000000000, table=AntreaPolicyEgressRule, priority=64990,ct_state=-new+rel,ip actions=goto_table:EgressMetric",
 		"cookie=0x1020000000000, table=AntreaPolicyIngressRule, priority=64990,ct_state=-new+est,ip actions=goto_table:IngressMetric",
@@ -1491,3 +1558,31 @@ func Test_NewDNSPacketInConjunction(t *testing.T) {
 		})
 	}
 }
+
+func Test_client_InstallNodeLocalDNSInterceptFlows(t *testing.T) {
+	dnsIPs := []net.IP{net.ParseIP("169.254.20.10"), net.ParseIP("fd00::20:10")}
+	expectedFlows := []string{
+		"cookie=0x1020000000000, table=IngressSecurityClassifier, priority=211,udp,nw_src=169.254.20.10,tp_src=53 actions=goto_table:AntreaPolicyIngressRule",
+		"cookie=0x1020000000000, table=IngressSecurityClassifier, priority=211,tcp,nw_src=169.254.20.10,tp_src=53 actions=goto_table:AntreaPolicyIngressRule",
+		"cookie=0x1020000000000, table=IngressSecurityClassifier, priority=211,udp6,ipv6_src=fd00::20:10,tp_src=53 actions=goto_table:AntreaPolicyIngressRule",
+		"cookie=0x1020000000000, table=IngressSecurityClassifier, priority=211,tcp6,ipv6_src=fd00::20:10,tp_src=53 actions=goto_table:AntreaPolicyIngressRule",
+	}
+
+	ctrl := gomock.NewController(t)
+	m := oftest.NewMockOFEntryOperations(ctrl)
+	fc := newFakeClient(m, true, true, config.K8sNode, config.TrafficEncapModeEncap)
+	defer resetPipelines()
+
+	m.EXPECT().AddAll(gomock.Any()).Return(nil).Times(1)
+	assert.NoError(t, fc.InstallNodeLocalDNSInterceptFlows(dnsIPs))
+	fCacheI, ok := fc.featureNetworkPolicy.cachedFlows.Load("nodelocaldns")
+	require.True(t, ok)
+	assert.ElementsMatch(t, expectedFlows, getFlowStrings(fCacheI))
+
+	// Removing an IP should remove the flows of the IP.
+	m.EXPECT().BundleOps(gomock.Len(0), gomock.Len(2), gomock.Len(2)).Return(nil).Times(1)
+	assert.NoError(t, fc.InstallNodeLocalDNSInterceptFlows(dnsIPs[:1]))
+	fCacheI, ok = fc.featureNetworkPolicy.cachedFlows.Load("nodelocaldns")
+	require.True(t, ok)
+	assert.ElementsMatch(t, expectedFlows[:2], getFlowStrings(fCacheI))
+}
@@ -26,6 +26,7 @@ import (
 	"golang.org/x/time/rate"
 	"k8s.io/klog/v2"
 
+	"antrea.io/antrea/pkg/agent/cpuaccounting"
 	"antrea.io/antrea/pkg/ovs/openflow"
 )
 
@@ -53,6 +54,9 @@ const (
 	// PacketInCategorySvcReject is used to process the Service packets not matching any
 	// Endpoints within packetIn message.
 	PacketInCategorySvcReject
+	// PacketInCategoryTTLExpired is used to reply to the routed packets whose TTL expires
+	// with ICMP Time Exceeded messages.
+	PacketInCategoryTTLExpired
 
 	// PacketIn operations below are used to decide which operation(s) should be
 	// executed by a handler. It(they) should be loaded in the second byte of the
@@ -88,7 +92,7 @@ const (
 	// PacketInQueueSize defines the size of PacketInQueue.
 	// When PacketInQueue reaches PacketInQueueSize, new packetIn will be dropped.
 	PacketInQueueSize = 200
-	// PacketInQueueRate defines the maximum frequency of getting items from PacketInQueue.
+	// PacketInQueueRate defines the default maximum frequency of getting items from PacketInQueue.
 	// PacketInQueueRate is represented as number of events per second.
 	PacketInQueueRate = 100
 )
@@ -110,9 +114,9 @@ type featureStartPacketIn struct {
 	packetInQueue *openflow.PacketInQueue
 }
 
-func newFeatureStartPacketIn(category uint8, stopCh <-chan struct{}) *featureStartPacketIn {
+func newFeatureStartPacketIn(category uint8, packetInRate int, stopCh <-chan struct{}) *featureStartPacketIn {
 	featurePacketIn := featureStartPacketIn{category: category, stopCh: stopCh}
-	featurePacketIn.packetInQueue = openflow.NewPacketInQueue(PacketInQueueSize, rate.Limit(PacketInQueueRate))
+	featurePacketIn.packetInQueue = openflow.NewPacketInQueue(PacketInQueueSize, rate.Limit(packetInRate))
 
 	return &featurePacketIn
 }
@@ -123,13 +127,28 @@ func (c *client) StartPacketInHandler(stopCh <-chan struct{}) {
 		return
 	}
 
+	c.packetInQueuesMutex.Lock()
+	defer c.packetInQueuesMutex.Unlock()
 	// Iterate through each feature that starts packetIn. Subscribe with their specified category.
 	for category := range c.packetInHandlers {
-		featurePacketIn := newFeatureStartPacketIn(category, stopCh)
+		featurePacketIn := newFeatureStartPacketIn(category, c.packetInRate, stopCh)
 		err := c.subscribeFeaturePacketIn(featurePacketIn)
 		if err != nil {
 			klog.Errorf("received error %+v while subscribing packetIn for each feature", err)
+			continue
 		}
+		c.packetInQueues[category] = featurePacketIn.packetInQueue
+	}
+}
+
+// SetPacketInRate updates the rate limit of the packetIn queues, which takes effect immediately for the subscribed
+// categories.
+func (c *client) SetPacketInRate(packetInRate int) {
+	c.packetInQueuesMutex.Lock()
+	defer c.packetInQueuesMutex.Unlock()
+	c.packetInRate = packetInRate
+	for _, queue := range c.packetInQueues {
+		queue.SetRateLimit(rate.Limit(packetInRate))
 	}
 }
 
@@ -151,7 +170,11 @@ func (c *client) parsePacketIn(featurePacketIn *featureStartPacketIn) {
 		// Use corresponding handler subscribed to the category to handle packetIn
 		if handler, ok := c.packetInHandlers[featurePacketIn.category]; ok {
 			klog.V(2).InfoS("Received packetIn", "category", featurePacketIn.category)
-			if err := handler.HandlePacketIn(pktIn); err != nil {
+			var err error
+			cpuaccounting.Run(cpuaccounting.FeaturePacketIn, func() {
+				err = handler.HandlePacketIn(pktIn)
+			})
+			if err != nil {
 				klog.ErrorS(err, "PacketIn handler failed to process packet", "category", featurePacketIn.category)
 			}
 		}
@@ -114,3 +114,20 @@ func Test_StartPacketInHandler(t *testing.T) {
 		})
 	}
 }
+
+func Test_SetPacketInRate(t *testing.T) {
+	fc := newFakeClient(nil, true, false, config.K8sNode, config.TrafficEncapModeEncap)
+	fc.packetInHandlers[uint8(PacketInCategoryTF)] = &fakeHandler{
+		callChannel: make(chan ofpPacketInCategory),
+		category:    PacketInCategoryTF,
+	}
+	assert.Equal(t, PacketInQueueRate, fc.packetInRate)
+	// The rate set before starting the handlers is used to create the queues.
+	fc.SetPacketInRate(200)
+	fc.StartPacketInHandler(nil)
+	assert.Equal(t, 200, fc.packetInRate)
+	assert.Contains(t, fc.packetInQueues, uint8(PacketInCategoryTF))
+
+	fc.SetPacketInRate(50)
+	assert.Equal(t, 50, fc.packetInRate)
+}
@@ -33,11 +33,22 @@ const (
 
 	icmpDstUnreachableType         uint8 = 3
 	icmpDstHostAdminProhibitedCode uint8 = 10
+	icmpDstPortUnreachableCode     uint8 = 3
 
 	icmpv6DstUnreachableType     uint8 = 1
 	icmpv6DstAdminProhibitedCode uint8 = 1
+	icmpv6DstPortUnreachableCode uint8 = 4
+
+	icmpEchoReplyType        uint8 = 0
+	icmpEchoRequestType      uint8 = 8
+	icmpTimeExceededType     uint8 = 11
+	icmpTTLExceededInTransit uint8 = 0
+	icmpv6TimeExceededType   uint8 = 3
+	icmpv6HopLimitExceeded   uint8 = 0
 )
 
+// SendRejectPacketOut rejects the packet with a TCP RST packet if it's a TCP packet, otherwise with an ICMP host
+// administratively prohibited packet. It's used to reject the packets denied by NetworkPolicies.
 func SendRejectPacketOut(ofClient Client,
 	srcMAC string,
 	dstMAC string,
@@ -49,6 +60,37 @@ func SendRejectPacketOut(ofClient Client,
 	ethernetPkt *protocol.Ethernet,
 	proto uint8,
 	mutateFunc func(binding.PacketOutBuilder) binding.PacketOutBuilder) error {
+	return sendRejectPacketOut(ofClient, srcMAC, dstMAC, srcIP, dstIP, inPort, outPort, isIPv6, ethernetPkt, proto, false, mutateFunc)
+}
+
+// SendServiceRejectPacketOut rejects the packet with a TCP RST packet if it's a TCP packet, otherwise with an ICMP port
+// unreachable packet, like kube-proxy does. It's used to reject the packets sent to Services without Endpoints.
+func SendServiceRejectPacketOut(ofClient Client,
+	srcMAC string,
+	dstMAC string,
+	srcIP string,
+	dstIP string,
+	inPort uint32,
+	outPort uint32,
+	isIPv6 bool,
+	ethernetPkt *protocol.Ethernet,
+	proto uint8,
+	mutateFunc func(binding.PacketOutBuilder) binding.PacketOutBuilder) error {
+	return sendRejectPacketOut(ofClient, srcMAC, dstMAC, srcIP, dstIP, inPort, outPort, isIPv6, ethernetPkt, proto, true, mutateFunc)
+}
+
+func sendRejectPacketOut(ofClient Client,
+	srcMAC string,
+	dstMAC string,
+	srcIP string,
+	dstIP string,
+	inPort uint32,
+	outPort uint32,
+	isIPv6 bool,
+	ethernetPkt *protocol.Ethernet,
+	proto uint8,
+	portUnreachable bool,
+	mutateFunc func(binding.PacketOutBuilder) binding.PacketOutBuilder) error {
 	if proto == protocol.Type_TCP {
 		// Get TCP data.
 		oriTCPSrcPort, oriTCPDstPort, oriTCPSeqNum, _, _, _, _, err := binding.GetTCPHeaderData(ethernetPkt.Data)
@@ -75,13 +117,19 @@ func SendRejectPacketOut(ofClient Client,
 			nil,
 			mutateFunc)
 	}
-	// Use ICMP host administratively prohibited for ICMP, UDP, SCTP reject.
+	// Use ICMP host administratively prohibited or port unreachable for ICMP, UDP, SCTP reject.
 	icmpType := icmpDstUnreachableType
 	icmpCode := icmpDstHostAdminProhibitedCode
+	if portUnreachable {
+		icmpCode = icmpDstPortUnreachableCode
+	}
 	ipHdrLen := ipv4HdrLen
 	if isIPv6 {
 		icmpType = icmpv6DstUnreachableType
 		icmpCode = icmpv6DstAdminProhibitedCode
+		if portUnreachable {
+			icmpCode = icmpv6DstPortUnreachableCode
+		}
 		ipHdrLen = ipv6HdrLen
 	}
 	ipHdr, _ := ethernetPkt.Data.MarshalBinary()
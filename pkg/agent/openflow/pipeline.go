@@ -17,6 +17,7 @@ package openflow
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 	"net"
 	"sort"
 	"sync"
@@ -25,6 +26,7 @@ import (
 	"antrea.io/libOpenflow/openflow15"
 	"antrea.io/libOpenflow/protocol"
 	"antrea.io/ofnet/ofctrl"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 	netutils "k8s.io/utils/net"
@@ -34,6 +36,7 @@ import (
 	"antrea.io/antrea/pkg/agent/openflow/cookie"
 	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/agent/util"
+	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
 	"antrea.io/antrea/pkg/ovs/ovsctl"
 	"antrea.io/antrea/pkg/util/runtime"
@@ -125,6 +128,7 @@ var (
 	ClassifierTable = newTable("Classifier", stageClassifier, pipelineIP, defaultDrop)
 
 	// Tables in stageValidation:
+	PodEgressBandwidthTable   = newTable("PodEgressBandwidth", stageValidation, pipelineIP)
 	SpoofGuardTable           = newTable("SpoofGuard", stageValidation, pipelineIP, defaultDrop)
 	IPv6Table                 = newTable("IPv6", stageValidation, pipelineIP)
 	PipelineIPClassifierTable = newTable("PipelineIPClassifier", stageValidation, pipelineIP)
@@ -152,9 +156,10 @@ var (
 	EgressMetricTable             = newTable("EgressMetric", stageEgressSecurity, pipelineIP)
 
 	// Tables in stageRouting:
-	L3ForwardingTable = newTable("L3Forwarding", stageRouting, pipelineIP)
-	EgressMarkTable   = newTable("EgressMark", stageRouting, pipelineIP)
-	L3DecTTLTable     = newTable("L3DecTTL", stageRouting, pipelineIP)
+	L3ForwardingTable      = newTable("L3Forwarding", stageRouting, pipelineIP)
+	EgressMarkTable        = newTable("EgressMark", stageRouting, pipelineIP)
+	EgressDefaultDenyTable = newTable("EgressDefaultDeny", stageRouting, pipelineIP)
+	L3DecTTLTable          = newTable("L3DecTTL", stageRouting, pipelineIP)
 
 	// Tables in stagePostRouting:
 	SNATMarkTable = newTable("SNATMark", stagePostRouting, pipelineIP)
@@ -175,8 +180,9 @@ var (
 	ConntrackCommitTable = newTable("ConntrackCommit", stageConntrack, pipelineIP)
 
 	// Tables in stageOutput:
-	VLANTable            = newTable("VLAN", stageOutput, pipelineIP)
-	L2ForwardingOutTable = newTable("Output", stageOutput, pipelineIP)
+	PodIngressBandwidthTable = newTable("PodIngressBandwidth", stageOutput, pipelineIP)
+	VLANTable                = newTable("VLAN", stageOutput, pipelineIP)
+	L2ForwardingOutTable     = newTable("Output", stageOutput, pipelineIP)
 
 	// Tables of pipelineMulticast are declared below. Do don't declare any tables of other pipelines here!
 	// Tables in stageEgressSecurity:
@@ -220,6 +226,10 @@ var (
 	arpOpRequest = uint16(1)
 	arpOpReply   = uint16(2)
 
+	// Type field values in ICMPv6 NDP packets
+	icmpv6TypeNeighborSolicitation  = uint8(135)
+	icmpv6TypeNeighborAdvertisement = uint8(136)
+
 	tableNameIndex = "tableNameIndex"
 )
 
@@ -436,6 +446,8 @@ type client struct {
 	traceableFeatures []traceableFeature
 
 	pipelines map[binding.PipelineID]binding.Pipeline
+	// initialFlows are the default flows and the initial flows of the features, which are not cached by the features.
+	initialFlows []*openflow15.FlowMod
 
 	// ofEntryOperations is a wrapper interface for operating multiple OpenFlow entries with action AddAll / ModifyAll / DeleteAll.
 	// It enables convenient mocking in unit tests.
@@ -449,9 +461,17 @@ type client struct {
 	l7NetworkPolicyConfig *config.L7NetworkPolicyConfig
 	// ovsMetersAreSupported indicates whether the OVS datapath supports OpenFlow meters.
 	ovsMetersAreSupported bool
+	// enablePodBandwidthMeter indicates whether the PodBandwidthMeter feature is enabled. The bandwidth limits of Pods
+	// are only enforced with OVS meters if the OVS datapath supports meters.
+	enablePodBandwidthMeter bool
 	// packetInHandlers stores handler to process PacketIn event. When a packetIn
 	// arrives, openflow send packet to registered handler in this map.
 	packetInHandlers map[uint8]PacketInHandler
+	// packetInRate is the maximum number of packetIn messages processed per second for each category.
+	packetInRate int
+	// packetInQueues stores the queues of the packetIn categories, once they are subscribed.
+	packetInQueues      map[uint8]*binding.PacketInQueue
+	packetInQueuesMutex sync.Mutex
 	// Supported IP Protocols (IP or IPv6) on the current Node.
 	ipProtocols []binding.Protocol
 	// ovsctlClient is the interface for executing OVS "ovs-ofctl" and "ovs-appctl" commands.
@@ -614,6 +634,17 @@ func (f *featurePodConnectivity) gatewayClassifierFlow() binding.Flow {
 		Done()
 }
 
+// physicalBridgeClassifierFlow generates the flow to mark the packets from the patch port connected to the OVS physical
+// bridge, i.e. the packets received by the uplink interface and forwarded to the OVS bridge by the OVS physical bridge.
+func (f *featurePodConnectivity) physicalBridgeClassifierFlow() binding.Flow {
+	return ClassifierTable.ofTable.BuildFlow(priorityNormal).
+		Cookie(f.cookieAllocator.Request(f.category).Raw()).
+		MatchInPort(f.phyBridgePatchPort).
+		Action().LoadRegMark(FromUplinkRegMark).
+		Action().GotoStage(stageConntrackState).
+		Done()
+}
+
 // podClassifierFlow generates the flow to mark the packets from a local Pod port.
 // If multi-cluster is enabled, also load podLabelID into LabelIDField.
 func (f *featurePodConnectivity) podClassifierFlow(podOFPort uint32, isAntreaFlexibleIPAM bool, podLabelID *uint32) binding.Flow {
@@ -904,7 +935,8 @@ func (f *featurePodConnectivity) flowsToTrace(dataplaneTag uint8,
 	ovsMetersAreSupported,
 	liveTraffic,
 	droppedOnly,
-	receiverOnly bool,
+	receiverOnly,
+	reverse bool,
 	packet *binding.Packet,
 	ofPort uint32,
 	timeout uint16) []binding.Flow {
@@ -919,17 +951,21 @@ func (f *featurePodConnectivity) flowsToTrace(dataplaneTag uint8,
 					MatchIPDSCP(dataplaneTag).
 					SetHardTimeout(timeout).
 					Action().GotoStage(stagePreRouting).
-					Done(),
-				ConntrackStateTable.ofTable.BuildFlow(priorityLow+2).
-					Cookie(cookieID).
-					MatchProtocol(ipProtocol).
-					MatchCTStateTrk(true).
-					MatchCTStateRpl(true).
-					MatchIPDSCP(dataplaneTag).
-					SetHardTimeout(timeout).
-					Action().Drop().
-					Done(),
-			)
+					Done())
+			// The reply packet of reverse-path Traceflow is in the reply direction of the connection committed by
+			// the forward packet, and must not be dropped.
+			if !reverse {
+				flows = append(flows,
+					ConntrackStateTable.ofTable.BuildFlow(priorityLow+2).
+						Cookie(cookieID).
+						MatchProtocol(ipProtocol).
+						MatchCTStateTrk(true).
+						MatchCTStateRpl(true).
+						MatchIPDSCP(dataplaneTag).
+						SetHardTimeout(timeout).
+						Action().Drop().
+						Done())
+			}
 		}
 	} else {
 		var flowBuilder binding.FlowBuilder
@@ -1088,7 +1124,8 @@ func (f *featureService) flowsToTrace(dataplaneTag uint8,
 	ovsMetersAreSupported,
 	liveTraffic,
 	droppedOnly,
-	receiverOnly bool,
+	receiverOnly,
+	reverse bool,
 	packet *binding.Packet,
 	ofPort uint32,
 	timeout uint16) []binding.Flow {
@@ -1138,7 +1175,8 @@ func (f *featureNetworkPolicy) flowsToTrace(dataplaneTag uint8,
 	ovsMetersAreSupported,
 	liveTraffic,
 	droppedOnly,
-	receiverOnly bool,
+	receiverOnly,
+	reverse bool,
 	packet *binding.Packet,
 	ofPort uint32,
 	timeout uint16) []binding.Flow {
@@ -1232,6 +1270,20 @@ func (f *featurePodConnectivity) l2ForwardCalcFlow(dstMAC net.HardwareAddr, ofPo
 		Done()
 }
 
+// l2ForwardCalcFlowToNodeTunnel generates the flow to output the packets destined for the remote Node, which are
+// tunneled to the Node, to the tunnel port dedicated to the Node instead of the default tunnel port.
+func (f *featurePodConnectivity) l2ForwardCalcFlowToNodeTunnel(tunnelPeer net.IP, tunOFPort uint32) binding.Flow {
+	return L2ForwardingCalcTable.ofTable.BuildFlow(priorityHigh).
+		Cookie(f.cookieAllocator.Request(f.category).Raw()).
+		MatchProtocol(getIPProtocol(tunnelPeer)).
+		MatchTunnelDst(tunnelPeer).
+		MatchDstMAC(GlobalVirtualMAC).
+		Action().LoadToRegField(TargetOFPortField, tunOFPort).
+		Action().LoadRegMark(OFPortFoundRegMark).
+		Action().NextTable().
+		Done()
+}
+
 // l2ForwardOutputHairpinServiceFlow generates the flow to output the packet of hairpin Service connection with IN_PORT
 // action.
 func (f *featureService) l2ForwardOutputHairpinServiceFlow() binding.Flow {
@@ -1533,6 +1585,44 @@ func (f *featurePodConnectivity) arpSpoofGuardFlow(ifIP net.IP, ifMAC net.Hardwa
 		Done()
 }
 
+// podNeighborSpoofGuardFlows generates the flows to drop the ARP and NDP packets from a local Pod which don't carry the
+// Pod's IP and MAC addresses. The ARP packets carrying the Pod's addresses are allowed by the flow generated by
+// arpSpoofGuardFlow, and the source IP of the NDP packets has been checked in SpoofGuardTable.
+func (f *featurePodConnectivity) podNeighborSpoofGuardFlows(ifIPs []net.IP, ifMAC net.HardwareAddr, ifOFPort uint32) []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	var flows []binding.Flow
+	if util.GetIPv4Addr(ifIPs) != nil {
+		flows = append(flows, ARPSpoofGuardTable.ofTable.BuildFlow(priorityLow).
+			Cookie(cookieID).
+			MatchProtocol(binding.ProtocolARP).
+			MatchInPort(ifOFPort).
+			Action().Drop().
+			Done())
+	}
+	if _, err := util.GetIPWithFamily(ifIPs, util.FamilyIPv6); err == nil {
+		for _, icmpv6Type := range []uint8{icmpv6TypeNeighborSolicitation, icmpv6TypeNeighborAdvertisement} {
+			flows = append(flows,
+				IPv6Table.ofTable.BuildFlow(priorityHigh).
+					Cookie(cookieID).
+					MatchProtocol(binding.ProtocolICMPv6).
+					MatchInPort(ifOFPort).
+					MatchSrcMAC(ifMAC).
+					MatchICMPv6Type(icmpv6Type).
+					MatchICMPv6Code(0).
+					Action().Normal().
+					Done(),
+				IPv6Table.ofTable.BuildFlow(priorityNormal+1).
+					Cookie(cookieID).
+					MatchProtocol(binding.ProtocolICMPv6).
+					MatchInPort(ifOFPort).
+					MatchICMPv6Type(icmpv6Type).
+					Action().Drop().
+					Done())
+		}
+	}
+	return flows
+}
+
 // sessionAffinityReselectFlow generates the flow which resubmits the Service accessing packet back to ServiceLBTable
 // if there is no endpointDNAT flow matched. This case will occur if an Endpoint is removed and is the learned Endpoint
 // selection of the Service.
@@ -1615,7 +1705,9 @@ func (f *featurePodConnectivity) arpNormalFlow() binding.Flow {
 		Done()
 }
 
-func (f *featureNetworkPolicy) allowRulesMetricFlows(conjunctionID uint32, ingress bool, tableID uint8) []binding.Flow {
+// allowRulesMetricFlows generates the metric flows of an allow rule. A non-zero meterID means that the packets of the
+// rule are rate limited by the meter.
+func (f *featureNetworkPolicy) allowRulesMetricFlows(conjunctionID uint32, ingress bool, tableID uint8, meterID binding.MeterIDType) []binding.Flow {
 	cookieID := f.cookieAllocator.Request(f.category).Raw()
 	metricTable := IngressMetricTable
 	offset := 0
@@ -1633,13 +1725,18 @@ func (f *featureNetworkPolicy) allowRulesMetricFlows(conjunctionID uint32, ingre
 	if f.enableMulticast && tableID == MulticastIngressRuleTable.GetID() {
 		metricTable = MulticastIngressMetricTable
 	}
-	metricFlow := func(isCTNew bool, protocol binding.Protocol) binding.Flow {
-		return metricTable.ofTable.BuildFlow(priorityNormal).
-			Cookie(cookieID).
-			MatchProtocol(protocol).
-			MatchCTStateNew(isCTNew).
-			MatchCTLabelField(0, uint64(conjunctionID)<<offset, field).
-			Action().NextTable().
+	metricFlow := func(isCTNew bool, protocol *binding.Protocol) binding.Flow {
+		fb := metricTable.ofTable.BuildFlow(priorityNormal).
+			Cookie(cookieID)
+		if protocol != nil {
+			fb = fb.MatchProtocol(*protocol)
+		}
+		fb = fb.MatchCTStateNew(isCTNew).
+			MatchCTLabelField(0, uint64(conjunctionID)<<offset, field)
+		if meterID != 0 {
+			fb = fb.Action().Meter(uint32(meterID))
+		}
+		return fb.Action().NextTable().
 			Done()
 	}
 	var flows []binding.Flow
@@ -1658,8 +1755,13 @@ func (f *featureNetworkPolicy) allowRulesMetricFlows(conjunctionID uint32, ingre
 	// The flow matching 'ct_state=+new' tracks the number of sessions and byte count of the first packet for each
 	// session.
 	// The flow matching 'ct_state=-new' tracks the byte/packet count of an established connection (both directions).
-	for _, ipProtocol := range f.ipProtocols {
-		flows = append(flows, metricFlow(true, ipProtocol), metricFlow(false, ipProtocol))
+	// In dual-stack clusters, the flows are shared by IPv4 and IPv6 connections: matching the IP protocol is not
+	// necessary as the ct_label identifies the rule, and doing so would double the number of metric flows.
+	if len(f.ipProtocols) > 1 {
+		return append(flows, metricFlow(true, nil), metricFlow(false, nil))
+	}
+	for i := range f.ipProtocols {
+		flows = append(flows, metricFlow(true, &f.ipProtocols[i]), metricFlow(false, &f.ipProtocols[i]))
 	}
 	return flows
 }
@@ -1703,14 +1805,14 @@ func (f *featurePodConnectivity) ipv6Flows() []binding.Flow {
 		IPv6Table.ofTable.BuildFlow(priorityNormal).
 			Cookie(cookieID).
 			MatchProtocol(binding.ProtocolICMPv6).
-			MatchICMPv6Type(135).
+			MatchICMPv6Type(icmpv6TypeNeighborSolicitation).
 			MatchICMPv6Code(0).
 			Action().Normal().
 			Done(),
 		IPv6Table.ofTable.BuildFlow(priorityNormal).
 			Cookie(cookieID).
 			MatchProtocol(binding.ProtocolICMPv6).
-			MatchICMPv6Type(136).
+			MatchICMPv6Type(icmpv6TypeNeighborAdvertisement).
 			MatchICMPv6Code(0).
 			Action().Normal().
 			Done(),
@@ -1745,6 +1847,11 @@ func (f *featureNetworkPolicy) conjunctionActionFlow(conjunctionID uint32, table
 		conjReg = TFEgressConjIDField
 		labelField = EgressRuleCTLabel
 	}
+	var allowRegMarks []*binding.RegMark
+	if f.enableEgressDefaultDeny && tableID == AntreaPolicyEgressRuleTable.GetID() {
+		// Mark the packets allowed by Antrea-native policy egress rules, so that they are not dropped by Egress default deny.
+		allowRegMarks = append(allowRegMarks, APEgressAllowRegMark)
+	}
 	conjActionFlow := func(proto binding.Protocol) binding.Flow {
 		ctZone := CtZone
 		if proto == binding.ProtocolIPv6 {
@@ -1759,6 +1866,7 @@ func (f *featureNetworkPolicy) conjunctionActionFlow(conjunctionID uint32, table
 			if l7RuleVlanID != nil {
 				return fb.
 					Action().LoadToRegField(conjReg, conjunctionID).                                                 // Traceflow.
+					Action().LoadRegMark(allowRegMarks...).                                                          // Egress default deny.
 					Action().LoadRegMark(DispositionAllowRegMark, L7NPRedirectRegMark).                              // AntreaPolicy.
 					Action().SendToController([]byte{uint8(PacketInCategoryNP), PacketInNPLoggingOperation}, false). // Enable logging.
 					Action().CT(true, nextTable, ctZone, f.ctZoneSrcField).                                          // CT action requires commit flag if actions other than NAT without arguments are specified.
@@ -1771,6 +1879,7 @@ func (f *featureNetworkPolicy) conjunctionActionFlow(conjunctionID uint32, table
 			}
 			return fb.
 				Action().LoadToRegField(conjReg, conjunctionID).                                                 // Traceflow.
+				Action().LoadRegMark(allowRegMarks...).                                                          // Egress default deny.
 				Action().LoadRegMark(DispositionAllowRegMark).                                                   // AntreaPolicy.
 				Action().SendToController([]byte{uint8(PacketInCategoryNP), PacketInNPLoggingOperation}, false). // Enable logging.
 				Action().CT(true, nextTable, ctZone, f.ctZoneSrcField).                                          // CT action requires commit flag if actions other than NAT without arguments are specified.
@@ -1783,6 +1892,7 @@ func (f *featureNetworkPolicy) conjunctionActionFlow(conjunctionID uint32, table
 			return table.BuildFlow(ofPriority).MatchProtocol(proto).
 				MatchConjID(conjunctionID).
 				Action().LoadToRegField(conjReg, conjunctionID).        // Traceflow.
+				Action().LoadRegMark(allowRegMarks...).                 // Egress default deny.
 				Action().CT(true, nextTable, ctZone, f.ctZoneSrcField). // CT action requires commit flag if actions other than NAT without arguments are specified.
 				LoadToLabelField(uint64(conjunctionID), labelField).
 				LoadToCtMark(L7NPRedirectCTMark).                               // Mark the packets of the connection should be redirected to an application-aware engine.
@@ -1794,6 +1904,7 @@ func (f *featureNetworkPolicy) conjunctionActionFlow(conjunctionID uint32, table
 		return table.BuildFlow(ofPriority).MatchProtocol(proto).
 			MatchConjID(conjunctionID).
 			Action().LoadToRegField(conjReg, conjunctionID).        // Traceflow.
+			Action().LoadRegMark(allowRegMarks...).                 // Egress default deny.
 			Action().CT(true, nextTable, ctZone, f.ctZoneSrcField). // CT action requires commit flag if actions other than NAT without arguments are specified.
 			LoadToLabelField(uint64(conjunctionID), labelField).
 			CTDone().
@@ -2093,6 +2204,31 @@ func (f *featureNetworkPolicy) dnsPacketInFlow(conjunctionID uint32) binding.Flo
 		Done()
 }
 
+// nodeLocalDNSResponseFlows generates the flows to forward the dns response packets sent by the NodeLocal DNSCache on
+// the local Node to AntreaPolicyIngressRuleTable, where they can be intercepted by the dns packetIn conjunction. As the
+// packets are generated by the Node itself, they could otherwise be classified as locally generated probe packets by
+// the flows generated by localProbeFlows and bypass AntreaPolicyIngressRuleTable.
+func (f *featureNetworkPolicy) nodeLocalDNSResponseFlows(dnsIPs []net.IP) []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	var flows []binding.Flow
+	for _, dnsIP := range dnsIPs {
+		protocols := []binding.Protocol{binding.ProtocolUDP, binding.ProtocolTCP}
+		if dnsIP.To4() == nil {
+			protocols = []binding.Protocol{binding.ProtocolUDPv6, binding.ProtocolTCPv6}
+		}
+		for _, protocol := range protocols {
+			flows = append(flows, IngressSecurityClassifierTable.ofTable.BuildFlow(priorityHigh+1).
+				Cookie(cookieID).
+				MatchProtocol(protocol).
+				MatchSrcIP(dnsIP).
+				MatchSrcPort(uint16(dnsPort), nil).
+				Action().GotoTable(AntreaPolicyIngressRuleTable.GetID()).
+				Done())
+		}
+	}
+	return flows
+}
+
 // localProbeFlows generates the flows to forward locally generated request packets to stageConntrack directly, bypassing
 // ingress rule of Network Policies. The packets are sent by kubelet to probe the liveness/readiness of local Pods.
 // On Linux and when OVS kernel datapath is used, the probe packets are identified by matching the HostLocalSourceMark.
@@ -2164,6 +2300,16 @@ func (f *featureNetworkPolicy) ingressClassifierFlows() []binding.Flow {
 			Action().GotoTable(IngressMetricTable.GetID()).
 			Done(),
 	}
+	if f.enableAntreaPolicy {
+		// This generates the flow to match the ClusterIP Service packets and forward them to AntreaPolicyIngressRuleTable,
+		// regardless of where the selected Endpoint is. Policies applied on Services will be enforced in
+		// AntreaPolicyIngressRuleTable.
+		flows = append(flows, IngressSecurityClassifierTable.ofTable.BuildFlow(priorityNormal+1).
+			Cookie(cookieID).
+			MatchRegMark(ToClusterIPRegMark).
+			Action().GotoTable(AntreaPolicyIngressRuleTable.GetID()).
+			Done())
+	}
 	if f.enableAntreaPolicy && f.proxyAll {
 		// This generates the flow to match the NodePort Service packets and forward them to AntreaPolicyIngressRuleTable.
 		// Policies applied on NodePort Service will be enforced in AntreaPolicyIngressRuleTable.
@@ -2192,9 +2338,13 @@ func (f *featureEgress) snatSkipNodeFlow(nodeIP net.IP) binding.Flow {
 // packet's tunnel destination IP.
 func (f *featureEgress) snatIPFromTunnelFlow(snatIP net.IP, mark uint32) binding.Flow {
 	ipProtocol := getIPProtocol(snatIP)
-	return EgressMarkTable.ofTable.BuildFlow(priorityNormal).
+	fb := EgressMarkTable.ofTable.BuildFlow(priorityNormal).
 		Cookie(f.cookieAllocator.Request(f.category).Raw()).
-		MatchProtocol(ipProtocol).
+		MatchProtocol(ipProtocol)
+	if runtime.IsWindowsPlatform() {
+		return f.snatToUplinkFlow(fb.MatchTunnelDst(snatIP), mark)
+	}
+	return fb.
 		MatchCTStateNew(true).
 		MatchCTStateTrk(true).
 		MatchTunnelDst(snatIP).
@@ -2208,14 +2358,31 @@ func (f *featureEgress) snatIPFromTunnelFlow(snatIP net.IP, mark uint32) binding
 // it sets the packet mark with the ID of the SNAT IP, for the traffic from local Pods to external; if the SNAT IP is
 // on a remote Node, it tunnels the packets to the remote Node.
 func (f *featureEgress) snatRuleFlow(ofPort uint32, snatIP net.IP, snatMark uint32, localGatewayMAC net.HardwareAddr) binding.Flow {
+	return f.snatRuleFlowBuilder(priorityNormal, ofPort, snatIP, snatMark, localGatewayMAC, nil)
+}
+
+// snatRuleDestinationFlow generates the flow that applies the SNAT rule for a local Pod, only for the traffic destined
+// for the provided CIDR. It has a higher priority than the flow generated by snatRuleFlow, so that the SNAT IP selected
+// for a destination takes precedence over the default SNAT IP of the Pod.
+func (f *featureEgress) snatRuleDestinationFlow(ofPort uint32, destinationCIDR net.IPNet, snatIP net.IP, snatMark uint32, localGatewayMAC net.HardwareAddr) binding.Flow {
+	return f.snatRuleFlowBuilder(priorityNormal+1, ofPort, snatIP, snatMark, localGatewayMAC, &destinationCIDR)
+}
+
+func (f *featureEgress) snatRuleFlowBuilder(priority uint16, ofPort uint32, snatIP net.IP, snatMark uint32, localGatewayMAC net.HardwareAddr, destinationCIDR *net.IPNet) binding.Flow {
 	cookieID := f.cookieAllocator.Request(f.category).Raw()
 	ipProtocol := getIPProtocol(snatIP)
+	fb := EgressMarkTable.ofTable.BuildFlow(priority).
+		Cookie(cookieID).
+		MatchProtocol(ipProtocol)
+	if destinationCIDR != nil {
+		fb = fb.MatchDstIPNet(*destinationCIDR)
+	}
 	if snatMark != 0 {
 		// Local SNAT IP.
-		return EgressMarkTable.ofTable.BuildFlow(priorityNormal).
-			Cookie(cookieID).
-			MatchProtocol(ipProtocol).
-			MatchCTStateNew(true).
+		if runtime.IsWindowsPlatform() {
+			return f.snatToUplinkFlow(fb.MatchInPort(ofPort), snatMark)
+		}
+		return fb.MatchCTStateNew(true).
 			MatchCTStateTrk(true).
 			MatchInPort(ofPort).
 			Action().LoadPktMarkRange(snatMark, snatPktMarkRange).
@@ -2224,10 +2391,7 @@ func (f *featureEgress) snatRuleFlow(ofPort uint32, snatIP net.IP, snatMark uint
 			Done()
 	}
 	// SNAT IP should be on a remote Node.
-	return EgressMarkTable.ofTable.BuildFlow(priorityNormal).
-		Cookie(cookieID).
-		MatchProtocol(ipProtocol).
-		MatchInPort(ofPort).
+	return fb.MatchInPort(ofPort).
 		Action().SetSrcMAC(localGatewayMAC).
 		Action().SetDstMAC(GlobalVirtualMAC).
 		Action().SetTunnelDst(snatIP). // Set tunnel destination to the SNAT IP.
@@ -2236,6 +2400,75 @@ func (f *featureEgress) snatRuleFlow(ofPort uint32, snatIP net.IP, snatMark uint
 		Done()
 }
 
+// snatToUplinkFlow completes the flow that marks the packets to be SNAT'd with a local SNAT IP on Windows. Unlike Linux,
+// where only the first packet of a connection is marked and SNAT is performed by the host, SNAT is performed in OVS
+// on Windows, so all the request packets of the connection are marked and forwarded to the uplink default gateway.
+func (f *featureEgress) snatToUplinkFlow(fb binding.FlowBuilder, snatMark uint32) binding.Flow {
+	return fb.MatchCTStateTrk(true).
+		Action().SetSrcMAC(f.uplinkMAC).
+		Action().SetDstMAC(f.uplinkGatewayMAC).
+		Action().LoadPktMarkRange(snatMark, snatPktMarkRange).
+		Action().LoadRegMark(ToUplinkRegMark).
+		Action().GotoStage(stageSwitching).
+		Done()
+}
+
+// snatUplinkGatewayFlow generates the flow to match the packets to be SNAT'd on Windows by matching the MAC address of
+// the uplink default gateway, then load the ofPort number of uplink to TargetOFPortField.
+func (f *featureEgress) snatUplinkGatewayFlow() binding.Flow {
+	return L2ForwardingCalcTable.ofTable.BuildFlow(priorityNormal).
+		Cookie(f.cookieAllocator.Request(f.category).Raw()).
+		MatchDstMAC(f.uplinkGatewayMAC).
+		Action().LoadToRegField(TargetOFPortField, f.uplinkPort).
+		Action().LoadRegMark(OFPortFoundRegMark).
+		Action().GotoStage(stageConntrack).
+		Done()
+}
+
+// snatIPConntrackFlows generates the flows to perform SNAT with a local SNAT IP in OVS on Windows, and to restore the
+// destination of the reply packets.
+func (f *featureEgress) snatIPConntrackFlows(snatIP net.IP, mark uint32) []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	ipProtocol := getIPProtocol(snatIP)
+	snatMarkMask := types.SNATIPMarkMask
+	return []binding.Flow{
+		// This generates the flow to perform SNAT in SNAT CT zone for the packets marked with the ID of the SNAT IP,
+		// after the connection has been committed in the default CT zone, then output them to the uplink. The
+		// connections are committed in SNAT CT zone with the first packet, and the subsequent packets are translated
+		// with the existing NAT binding.
+		L2ForwardingOutTable.ofTable.BuildFlow(priorityHigh).
+			Cookie(cookieID).
+			MatchProtocol(ipProtocol).
+			MatchPktMark(mark, &snatMarkMask).
+			MatchRegMark(ToUplinkRegMark, OFPortFoundRegMark).
+			Action().CT(true, binding.LastTableID, f.snatCtZones[ipProtocol], nil).
+			SNAT(&binding.IPRange{StartIP: snatIP, EndIP: snatIP}, nil).
+			CTDone().
+			Action().OutputToRegField(TargetOFPortField).
+			Done(),
+		// This generates the flow to forward the reply packets destined for the SNAT IP from the uplink to
+		// stageConntrackState, instead of the bridge local port.
+		ClassifierTable.ofTable.BuildFlow(priorityHigh).
+			Cookie(cookieID).
+			MatchProtocol(ipProtocol).
+			MatchInPort(f.uplinkPort).
+			MatchDstIP(snatIP).
+			Action().LoadRegMark(FromUplinkRegMark, RewriteMACRegMark).
+			Action().GotoStage(stageConntrackState).
+			Done(),
+		// This generates the flow to unSNAT the reply packets of the connections committed in SNAT CT zone by the
+		// above flow.
+		UnSNATTable.ofTable.BuildFlow(priorityNormal).
+			Cookie(cookieID).
+			MatchProtocol(ipProtocol).
+			MatchDstIP(snatIP).
+			Action().CT(false, UnSNATTable.GetNext(), f.snatCtZones[ipProtocol], nil).
+			NAT().
+			CTDone().
+			Done(),
+	}
+}
+
 // nodePortMarkFlows generates the flows to mark the first packet of Service NodePort connection with ToNodePortAddressRegMark,
 // which indicates the Service type is NodePort.
 func (f *featureService) nodePortMarkFlows() []binding.Flow {
@@ -2268,12 +2501,15 @@ func (f *featureService) nodePortMarkFlows() []binding.Flow {
 }
 
 // serviceLearnFlow generates the flow with learn action which adds new flows in SessionAffinityTable according to the
-// Endpoint selection decision.
+// Endpoint selection decision. The learned flows match the client IP, the Service IP and the Service port by default,
+// which can be customized with affinityKey.
 func (f *featureService) serviceLearnFlow(groupID binding.GroupIDType,
 	svcIP net.IP,
 	svcPort uint16,
+	svcPortMask *uint16,
 	protocol binding.Protocol,
 	affinityTimeout uint16,
+	affinityKey *types.SessionAffinityKey,
 	externalAddress bool,
 	nodePortAddress bool) binding.Flow {
 	// Using unique cookie ID here to avoid learned flow cascade deletion.
@@ -2281,7 +2517,7 @@ func (f *featureService) serviceLearnFlow(groupID binding.GroupIDType,
 	flowBuilder := ServiceLBTable.ofTable.BuildFlow(priorityLow).
 		Cookie(cookieID).
 		MatchProtocol(protocol).
-		MatchDstPort(svcPort, nil)
+		MatchDstPort(svcPort, svcPortMask)
 
 	// EpToLearnRegMark is required to match the packets that have done Endpoint selection.
 	regMarksToMatch := []*binding.RegMark{EpToLearnRegMark}
@@ -2303,11 +2539,18 @@ func (f *featureService) serviceLearnFlow(groupID binding.GroupIDType,
 		Action().Learn(SessionAffinityTable.GetID(), priorityNormal, 0, affinityTimeout, cookieID).
 		DeleteLearned().
 		MatchEthernetProtocol(isIPv6).
-		MatchIPProtocol(protocol).
-		MatchLearnedDstPort(protocol).
-		MatchLearnedDstIP(isIPv6).
-		MatchLearnedSrcIP(isIPv6).
-		LoadFieldToField(EndpointPortField, EndpointPortField)
+		MatchIPProtocol(protocol)
+	// The destination port is always matched for NodePort, as the NodePorts of all Services share the same virtual IP.
+	if affinityKey == nil || !affinityKey.IgnoreDstPort || nodePortAddress {
+		learnFlowBuilderLearnAction = learnFlowBuilderLearnAction.MatchLearnedDstPort(protocol)
+	}
+	learnFlowBuilderLearnAction = learnFlowBuilderLearnAction.MatchLearnedDstIP(isIPv6)
+	if affinityKey != nil && affinityKey.ClientIPPrefixLength > 0 {
+		learnFlowBuilderLearnAction = learnFlowBuilderLearnAction.MatchLearnedSrcIPPrefix(isIPv6, affinityKey.ClientIPPrefixLength)
+	} else {
+		learnFlowBuilderLearnAction = learnFlowBuilderLearnAction.MatchLearnedSrcIP(isIPv6)
+	}
+	learnFlowBuilderLearnAction = learnFlowBuilderLearnAction.LoadFieldToField(EndpointPortField, EndpointPortField)
 	if isIPv6 {
 		learnFlowBuilderLearnAction = learnFlowBuilderLearnAction.LoadXXRegToXXReg(EndpointIP6Field, EndpointIP6Field)
 	} else {
@@ -2329,10 +2572,12 @@ func (f *featureService) serviceLearnFlow(groupID binding.GroupIDType,
 		Done()
 }
 
-// serviceLBFlow generates the flow which uses the specific group to do Endpoint selection.
+// serviceLBFlow generates the flow which uses the specific group to do Endpoint selection. If svcPortMask is not nil,
+// the flow matches the range of destination ports given by svcPort and svcPortMask.
 func (f *featureService) serviceLBFlow(groupID binding.GroupIDType,
 	svcIP net.IP,
 	svcPort uint16,
+	svcPortMask *uint16,
 	protocol binding.Protocol,
 	withSessionAffinity bool,
 	externalAddress bool,
@@ -2345,13 +2590,13 @@ func (f *featureService) serviceLBFlow(groupID binding.GroupIDType,
 		flowBuilder = ServiceLBTable.ofTable.BuildFlow(priorityHigh).
 			Cookie(f.cookieAllocator.Request(f.category).Raw()).
 			MatchProtocol(protocol).
-			MatchDstPort(svcPort, nil).
+			MatchDstPort(svcPort, svcPortMask).
 			MatchSrcIPNet(f.localCIDRs[getIPProtocol(svcIP)])
 	} else {
 		flowBuilder = ServiceLBTable.ofTable.BuildFlow(priorityNormal).
 			Cookie(f.cookieAllocator.Request(f.category).Raw()).
 			MatchProtocol(protocol).
-			MatchDstPort(svcPort, nil)
+			MatchDstPort(svcPort, svcPortMask)
 	}
 
 	// EpToSelectRegMark is required to match the packets that haven't undergone Endpoint selection yet.
@@ -2380,6 +2625,11 @@ func (f *featureService) serviceLBFlow(groupID binding.GroupIDType,
 	}
 	if f.enableAntreaPolicy {
 		regMarksToLoad = append(regMarksToLoad, binding.NewRegMark(ServiceGroupIDField, uint32(groupID)))
+		// The ToClusterIPRegMark is loaded to enforce the policies applied to the Service on the packets to its
+		// ClusterIP, as the Endpoint selected for them may be on another Node.
+		if !externalAddress {
+			regMarksToLoad = append(regMarksToLoad, ToClusterIPRegMark)
+		}
 	}
 	if nested {
 		regMarksToLoad = append(regMarksToLoad, NestedServiceRegMark)
@@ -2414,7 +2664,8 @@ func (f *featureService) endpointRedirectFlowForServiceIP(clusterIP net.IP, svcP
 }
 
 // endpointDNATFlow generates the flow which transforms the Service Cluster IP to the Endpoint IP according to the Endpoint
-// selection decision which is stored in regs.
+// selection decision which is stored in regs. If endpointPort is 0, which is the case for the Endpoints of Services with
+// a port range, the destination port is not changed.
 func (f *featureService) endpointDNATFlow(endpointIP net.IP, endpointPort uint16, protocol binding.Protocol) binding.Flow {
 	unionVal := (EpSelectedRegMark.GetValue() << EndpointPortField.GetRange().Length()) + uint32(endpointPort)
 	flowBuilder := EndpointDNATTable.ofTable.BuildFlow(priorityNormal).
@@ -2431,11 +2682,15 @@ func (f *featureService) endpointDNATFlow(endpointIP net.IP, endpointPort uint16
 		flowBuilder = flowBuilder.MatchXXReg(EndpointIP6Field.GetRegID(), ipVal)
 	}
 
+	var portRange *binding.PortRange
+	if endpointPort != 0 {
+		portRange = &binding.PortRange{StartPort: endpointPort, EndPort: endpointPort}
+	}
 	return flowBuilder.Action().
 		CT(true, EndpointDNATTable.GetNext(), f.dnatCtZones[ipProtocol], f.ctZoneSrcField).
 		DNAT(
 			&binding.IPRange{StartIP: endpointIP, EndIP: endpointIP},
-			&binding.PortRange{StartPort: endpointPort, EndPort: endpointPort},
+			portRange,
 		).
 		LoadToCtMark(ServiceCTMark).
 		MoveToCtMarkField(PktSourceField, ConnSourceCTMarkField).
@@ -2443,14 +2698,202 @@ func (f *featureService) endpointDNATFlow(endpointIP net.IP, endpointPort uint16
 		Done()
 }
 
+// dsrLearnedFlowIdleTimeout is the idle timeout, in seconds, of the flows learned for the connections load balanced in DSR
+// mode. As these connections are not committed to conntrack on the ingress Node, the learned flows keep their subsequent
+// packets forwarded to the same Endpoint.
+const dsrLearnedFlowIdleTimeout = uint16(300)
+
+// serviceDSRLBFlows generates the flows which load balance the connections to an external address of a Service in DSR
+// mode. On the ingress Node, the group of all the Endpoints is used and DSRServiceRegMark is loaded, so that the packets
+// to remote Endpoints are forwarded through the tunnel without DNAT. On the Node of the selected Endpoint, the packets
+// received from the tunnel are load balanced with the group of the local Endpoints, and the replies are sent to the
+// clients directly.
+func (f *featureService) serviceDSRLBFlows(groupID, localGroupID binding.GroupIDType, svcIP net.IP, svcPort uint16, protocol binding.Protocol) []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	// ToExternalAddressRegMark is still loaded, as the connections to remote Endpoints which are not in the Pod subnets
+	// of the Nodes, e.g. hostNetwork Endpoints, are load balanced in NAT mode.
+	regMarksToLoad := []*binding.RegMark{RewriteMACRegMark, EpSelectedRegMark, ToExternalAddressRegMark, DSRServiceRegMark}
+	localRegMarksToLoad := []*binding.RegMark{RewriteMACRegMark, EpSelectedRegMark}
+	if f.enableAntreaPolicy {
+		regMarksToLoad = append(regMarksToLoad, binding.NewRegMark(ServiceGroupIDField, uint32(groupID)))
+		localRegMarksToLoad = append(localRegMarksToLoad, binding.NewRegMark(ServiceGroupIDField, uint32(localGroupID)))
+	}
+	return []binding.Flow{
+		ServiceLBTable.ofTable.BuildFlow(priorityNormal).
+			Cookie(cookieID).
+			MatchProtocol(protocol).
+			MatchDstIP(svcIP).
+			MatchDstPort(svcPort, nil).
+			MatchRegMark(EpToSelectRegMark).
+			Action().LoadRegMark(regMarksToLoad...).
+			Action().Group(groupID).
+			Done(),
+		ServiceLBTable.ofTable.BuildFlow(priorityHigh).
+			Cookie(cookieID).
+			MatchProtocol(protocol).
+			MatchDstIP(svcIP).
+			MatchDstPort(svcPort, nil).
+			MatchRegMark(EpToSelectRegMark, FromTunnelRegMark).
+			Action().LoadRegMark(localRegMarksToLoad...).
+			Action().Group(localGroupID).
+			Done(),
+	}
+}
+
+// endpointDSRFlows generates the flows which forward the packets of the connections load balanced in DSR mode to the
+// Endpoints in the Pod subnet of a remote Node through the tunnel, instead of DNATing them. For every connection, a flow
+// matching its 5-tuple is learned in SessionAffinityTable, so that its subsequent packets are forwarded to the same
+// Endpoint.
+func (f *featureService) endpointDSRFlows(peerSubnet net.IPNet, tunnelPeer net.IP) []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	// The selected Endpoint IP is matched with the prefix of the subnet.
+	prefixLength, _ := peerSubnet.Mask.Size()
+	subnetField := binding.NewRegField(EndpointIPField.GetRegID(), uint32(32-prefixLength), 31)
+	subnetVal := binary.BigEndian.Uint32(peerSubnet.IP.To4()) >> (32 - prefixLength)
+	var flows []binding.Flow
+	for _, protocol := range []binding.Protocol{binding.ProtocolTCP, binding.ProtocolUDP, binding.ProtocolSCTP} {
+		flows = append(flows, EndpointDNATTable.ofTable.BuildFlow(priorityHigh).
+			Cookie(cookieID).
+			MatchProtocol(protocol).
+			MatchRegMark(DSRServiceRegMark, EpSelectedRegMark).
+			MatchRegFieldWithValue(subnetField, subnetVal).
+			Action().Learn(SessionAffinityTable.GetID(), priorityHigh, dsrLearnedFlowIdleTimeout, 0, cookieID).
+			DeleteLearned().
+			MatchEthernetProtocol(false).
+			MatchIPProtocol(protocol).
+			MatchLearnedSrcIP(false).
+			MatchLearnedDstIP(false).
+			MatchLearnedSrcPort(protocol).
+			MatchLearnedDstPort(protocol).
+			LoadFieldToField(EndpointIPField, EndpointIPField).
+			LoadFieldToField(EndpointPortField, EndpointPortField).
+			LoadRegMark(EpSelectedRegMark, RewriteMACRegMark, ToExternalAddressRegMark, DSRServiceRegMark).
+			Done().
+			Action().SetTunnelDst(tunnelPeer).
+			Action().LoadRegMark(ToTunnelRegMark).
+			Action().NextTable().
+			Done())
+	}
+	return flows
+}
+
+// dsrFlows generates the flows for the packets which are load balanced in DSR mode and forwarded to remote Endpoints
+// through the tunnel.
+func (f *featureService) dsrFlows() []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	return []binding.Flow{
+		// This generates the flow to rewrite the MAC addresses of the packets like the packets to remote Pods, as their
+		// destination IP is the Service IP.
+		L3ForwardingTable.ofTable.BuildFlow(priorityHigh).
+			Cookie(cookieID).
+			MatchProtocol(binding.ProtocolIP).
+			MatchRegMark(DSRServiceRegMark, ToTunnelRegMark).
+			Action().SetSrcMAC(f.gatewayMAC).
+			Action().SetDstMAC(GlobalVirtualMAC).
+			Action().GotoTable(L3DecTTLTable.GetID()).
+			Done(),
+		// This generates the flow to skip SNAT for the packets, so that the client IPs are preserved.
+		SNATMarkTable.ofTable.BuildFlow(priorityHigh).
+			Cookie(cookieID).
+			MatchProtocol(binding.ProtocolIP).
+			MatchRegMark(DSRServiceRegMark, ToTunnelRegMark).
+			Action().NextTable().
+			Done(),
+		// This generates the flow to skip committing the connections of the packets. As the replies bypass the ingress
+		// Node, the subsequent packets of a committed TCP connection would be considered invalid by conntrack.
+		ConntrackCommitTable.ofTable.BuildFlow(priorityHigh).
+			Cookie(cookieID).
+			MatchProtocol(binding.ProtocolIP).
+			MatchRegMark(DSRServiceRegMark, ToTunnelRegMark).
+			Action().NextTable().
+			Done(),
+	}
+}
+
+// DrainingEndpoint wraps an Endpoint which has been removed from a Service but whose established connections are
+// allowed to complete. It is added to the Service group with a bucket of zero weight, so that it is not selected for
+// new connections.
+type DrainingEndpoint struct {
+	proxy.Endpoint
+}
+
+// defaultEndpointWeight is the weight of the buckets of Endpoints which have no specific weight.
+const defaultEndpointWeight = uint16(100)
+
+// maxBucketsPerServiceGroup is the maximum number of Endpoint buckets of a Service group, so that every group can be
+// installed with a single OpenFlow message. The Endpoints exceeding it are spread over chained sub-groups.
+var maxBucketsPerServiceGroup = binding.MaxBucketsPerMessage
+
+// endpointWeight returns the weight of the bucket of an Endpoint in a Service group.
+func endpointWeight(endpoint proxy.Endpoint) uint16 {
+	if _, ok := endpoint.(*DrainingEndpoint); ok {
+		return 0
+	}
+	if weight := endpoint.GetWeight(); weight != 0 {
+		return weight
+	}
+	return defaultEndpointWeight
+}
+
+// serviceEndpointGroups creates/modifies the group of Endpoints of a Service. If there are more Endpoints than
+// maxBucketsPerServiceGroup, they are spread over sub-groups created by serviceEndpointGroup, and every bucket of the
+// returned group forwards packets to a sub-group, with a weight proportional to the total weight of its Endpoints.
+// The sub-groups are returned keyed by group ID, and must be installed before the group.
+func (f *featureService) serviceEndpointGroups(groupID binding.GroupIDType, withSessionAffinity bool, endpoints ...proxy.Endpoint) (binding.Group, map[binding.GroupIDType]binding.Group) {
+	if len(endpoints) <= maxBucketsPerServiceGroup {
+		return f.serviceEndpointGroup(groupID, withSessionAffinity, endpoints...), nil
+	}
+
+	subGroups := make(map[binding.GroupIDType]binding.Group)
+	var subGroupIDs []binding.GroupIDType
+	var subGroupWeights []uint32
+	var maxWeight uint32
+	for i := 0; i*maxBucketsPerServiceGroup < len(endpoints); i++ {
+		start := i * maxBucketsPerServiceGroup
+		end := start + maxBucketsPerServiceGroup
+		if end > len(endpoints) {
+			end = len(endpoints)
+		}
+		// The sub-group IDs are derived from the group ID and the index of the sub-group, so that the sub-groups are
+		// reused when the group is updated.
+		subGroupID := f.subGroupAllocator.AllocateFor(fmt.Sprintf("%d/%d", groupID, i))
+		subGroups[subGroupID] = f.serviceEndpointGroup(subGroupID, withSessionAffinity, endpoints[start:end]...)
+		var weight uint32
+		for _, endpoint := range endpoints[start:end] {
+			weight += uint32(endpointWeight(endpoint))
+		}
+		if weight > maxWeight {
+			maxWeight = weight
+		}
+		subGroupIDs = append(subGroupIDs, subGroupID)
+		subGroupWeights = append(subGroupWeights, weight)
+	}
+
+	// The total weights of the sub-groups are scaled down to fit in the 16-bit weights of the buckets. A sub-group
+	// having only draining Endpoints keeps a zero weight.
+	scale := maxWeight/math.MaxUint16 + 1
+	group := f.bridge.NewGroup(groupID)
+	for i, subGroupID := range subGroupIDs {
+		weight := subGroupWeights[i] / scale
+		if weight == 0 && subGroupWeights[i] != 0 {
+			weight = 1
+		}
+		group = group.Bucket().Weight(uint16(weight)).
+			Group(subGroupID).
+			Done()
+	}
+	return group, subGroups
+}
+
 // serviceEndpointGroup creates/modifies the group/buckets of Endpoints. If the withSessionAffinity is true, then buckets
 // will resubmit packets back to ServiceLBTable to trigger the learn flow, the learn flow will then send packets to
-// EndpointDNATTable. Otherwise, buckets will resubmit packets to EndpointDNATTable directly.
+// EndpointDNATTable. Otherwise, buckets will resubmit packets to EndpointDNATTable directly. The weight of each bucket
+// is the weight of its Endpoint if it has one.
 func (f *featureService) serviceEndpointGroup(groupID binding.GroupIDType, withSessionAffinity bool, endpoints ...proxy.Endpoint) binding.Group {
 	group := f.bridge.NewGroup(groupID)
 
 	if len(endpoints) == 0 {
-		return group.Bucket().Weight(100).
+		return group.Bucket().Weight(defaultEndpointWeight).
 			LoadRegMark(SvcNoEpRegMark).
 			ResubmitToTable(EndpointDNATTable.GetID()).
 			Done()
@@ -2467,17 +2910,18 @@ func (f *featureService) serviceEndpointGroup(groupID binding.GroupIDType, withS
 		endpointIP := net.ParseIP(endpoint.IP())
 		portVal := util.PortToUint16(endpointPort)
 		ipProtocol := getIPProtocol(endpointIP)
+		weight := endpointWeight(endpoint)
 
 		if ipProtocol == binding.ProtocolIP {
 			ipVal := binary.BigEndian.Uint32(endpointIP.To4())
-			group = group.Bucket().Weight(100).
+			group = group.Bucket().Weight(weight).
 				LoadToRegField(EndpointIPField, ipVal).
 				LoadToRegField(EndpointPortField, uint32(portVal)).
 				ResubmitToTable(resubmitTableID).
 				Done()
 		} else if ipProtocol == binding.ProtocolIPv6 {
 			ipVal := []byte(endpointIP)
-			group = group.Bucket().Weight(100).
+			group = group.Bucket().Weight(weight).
 				LoadXXReg(EndpointIP6Field.GetRegID(), ipVal).
 				LoadToRegField(EndpointPortField, uint32(portVal)).
 				ResubmitToTable(resubmitTableID).
@@ -2489,8 +2933,13 @@ func (f *featureService) serviceEndpointGroup(groupID binding.GroupIDType, withS
 
 // decTTLFlows generates the flow to process TTL. For the packets forwarded across Nodes, TTL should be decremented by one;
 // for packets which enter OVS pipeline from the Antrea gateway, as the host IP stack should have decremented the TTL
-// already for such packets, TTL should not be decremented again.
+// already for such packets, TTL should not be decremented again. No flow is generated if TTL decrement is disabled, and
+// the packets go to the next table with the table-miss flow. If ICMP Time Exceeded generation is enabled, the packets
+// whose TTL would expire are sent to antrea-agent instead, which replies to them with ICMP Time Exceeded messages.
 func (f *featurePodConnectivity) decTTLFlows() []binding.Flow {
+	if f.networkConfig.DisableTTLDecrement {
+		return nil
+	}
 	cookieID := f.cookieAllocator.Request(f.category).Raw()
 	var flows []binding.Flow
 	for _, ipProtocol := range f.ipProtocols {
@@ -2509,6 +2958,16 @@ func (f *featurePodConnectivity) decTTLFlows() []binding.Flow {
 				Action().NextTable().
 				Done(),
 		)
+		if f.networkConfig.SendICMPTimeExceeded {
+			for _, ttl := range []uint8{0, 1} {
+				flows = append(flows, L3DecTTLTable.ofTable.BuildFlow(priorityNormal+1).
+					Cookie(cookieID).
+					MatchProtocol(ipProtocol).
+					MatchIPTTL(ttl).
+					Action().SendToController([]byte{uint8(PacketInCategoryTTLExpired)}, false).
+					Done())
+			}
+		}
 	}
 	return flows
 }
@@ -2574,6 +3033,62 @@ func (f *featureEgress) externalFlows() []binding.Flow {
 	return flows
 }
 
+// defaultDenyFlows generates the flows to drop the packets of new connections from local Pods to the external network,
+// which are neither SNAT'd by an Egress nor allowed by an Antrea-native policy. The packets which don't match any SNAT
+// rule in EgressMarkTable are forwarded to EgressDefaultDenyTable, where the drop flows are installed for all local Pods
+// or for the local Pods of the Namespaces with default deny enabled.
+func (f *featureEgress) defaultDenyFlows() []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	var flows []binding.Flow
+	for _, ipProtocol := range f.ipProtocols {
+		flows = append(flows,
+			// This generates the flow to match the first packet of connection sourced from local Pods, which doesn't
+			// match any SNAT rule, then forward it to EgressDefaultDenyTable.
+			EgressMarkTable.ofTable.BuildFlow(priorityLow).
+				Cookie(cookieID).
+				MatchProtocol(ipProtocol).
+				MatchCTStateNew(true).
+				MatchCTStateTrk(true).
+				MatchRegMark(FromLocalRegMark).
+				Action().GotoTable(EgressDefaultDenyTable.GetID()).
+				Done(),
+			// This generates the flow to bypass the packets allowed by an Antrea-native policy.
+			EgressDefaultDenyTable.ofTable.BuildFlow(priorityHigh).
+				Cookie(cookieID).
+				MatchProtocol(ipProtocol).
+				MatchRegMark(APEgressAllowRegMark).
+				Action().LoadRegMark(ToGatewayRegMark).
+				Action().GotoStage(stageSwitching).
+				Done(),
+		)
+		if f.defaultDenyAllNamespaces {
+			// This generates the flow to drop the packets from all local Pods.
+			flows = append(flows, EgressDefaultDenyTable.ofTable.BuildFlow(priorityLow).
+				Cookie(cookieID).
+				MatchProtocol(ipProtocol).
+				Action().Drop().
+				Done())
+		}
+	}
+	// This generates the default flow to forward the packets which are not dropped to stageSwitching.
+	flows = append(flows, EgressDefaultDenyTable.ofTable.BuildFlow(priorityMiss).
+		Cookie(cookieID).
+		Action().LoadRegMark(ToGatewayRegMark).
+		Action().GotoStage(stageSwitching).
+		Done())
+	return flows
+}
+
+// podDefaultDenyFlow generates the flow to drop the packets from a local Pod to the external network, which are neither
+// SNAT'd by an Egress nor allowed by an Antrea-native policy.
+func (f *featureEgress) podDefaultDenyFlow(ofPort uint32) binding.Flow {
+	return EgressDefaultDenyTable.ofTable.BuildFlow(priorityNormal).
+		Cookie(f.cookieAllocator.Request(f.category).Raw()).
+		MatchInPort(ofPort).
+		Action().Drop().
+		Done()
+}
+
 // policyConjKeyFunc knows how to get key of a *policyRuleConjunction.
 func policyConjKeyFunc(obj interface{}) (string, error) {
 	conj := obj.(*policyRuleConjunction)
@@ -2600,6 +3115,54 @@ func (c *client) genPacketInMeter(meterID binding.MeterIDType, rate uint32) bind
 	return meter
 }
 
+// networkPolicyRateLimitMeter generates a meter entry which drops the packets exceeding the rate limit of a
+// NetworkPolicy rule, in either packets or bytes per second. The burst is the same as the rate.
+func (f *featureNetworkPolicy) networkPolicyRateLimitMeter(meterID binding.MeterIDType, rateLimit *v1beta2.RateLimit) binding.Meter {
+	flags := ofctrl.MeterBurst | ofctrl.MeterPktps
+	rate := uint32(rateLimit.PacketsPerSecond)
+	if rateLimit.BytesPerSecond > 0 {
+		// OVS meters with the kbps flag take the rate in kilobits per second.
+		flags = ofctrl.MeterBurst | ofctrl.MeterKbps
+		kilobits := (uint64(rateLimit.BytesPerSecond)*8 + 999) / 1000
+		if kilobits > math.MaxUint32 {
+			kilobits = math.MaxUint32
+		}
+		rate = uint32(kilobits)
+	}
+	return f.bridge.NewMeter(meterID, flags).
+		MeterBand().
+		MeterType(ofctrl.MeterDrop).
+		Rate(rate).
+		Burst(rate).
+		Done()
+}
+
+// genPodBandwidthMeter generates a meter entry which drops the packets exceeding the provided rate, in bits per second,
+// and burst, in bits.
+func (c *client) genPodBandwidthMeter(meterID binding.MeterIDType, rate, burst uint64) binding.Meter {
+	// OVS meters with the kbps flag take the rate in kilobits per second and the burst in kilobits.
+	toKilobits := func(bits uint64) uint32 {
+		kilobits := (bits + 999) / 1000
+		if kilobits == 0 {
+			return 1
+		}
+		if kilobits > math.MaxUint32 {
+			return math.MaxUint32
+		}
+		return uint32(kilobits)
+	}
+	if burst == 0 {
+		burst = rate
+	}
+	meter := c.bridge.NewMeter(meterID, ofctrl.MeterBurst|ofctrl.MeterKbps).
+		MeterBand().
+		MeterType(ofctrl.MeterDrop).
+		Rate(toKilobits(rate)).
+		Burst(toKilobits(burst)).
+		Done()
+	return meter
+}
+
 func generatePipeline(pipelineID binding.PipelineID, requiredTables []*Table) binding.Pipeline {
 	var ofTables []binding.Table
 	for _, table := range requiredTables {
@@ -2730,24 +3293,28 @@ func NewClient(bridgeName string,
 	connectUplinkToBridge bool,
 	enableMulticast bool,
 	enableTrafficControl bool,
-	enableMulticluster bool) Client {
+	enableMulticluster bool,
+	enablePodBandwidthMeter bool) Client {
 	bridge := binding.NewOFBridge(bridgeName, mgmtAddr)
 	c := &client{
-		bridge:                bridge,
-		enableProxy:           enableProxy,
-		proxyAll:              proxyAll,
-		enableAntreaPolicy:    enableAntreaPolicy,
-		enableL7NetworkPolicy: enableL7NetworkPolicy,
-		enableDenyTracking:    enableDenyTracking,
-		enableEgress:          enableEgress,
-		enableMulticast:       enableMulticast,
-		enableTrafficControl:  enableTrafficControl,
-		enableMulticluster:    enableMulticluster,
-		connectUplinkToBridge: connectUplinkToBridge,
-		pipelines:             make(map[binding.PipelineID]binding.Pipeline),
-		packetInHandlers:      map[uint8]PacketInHandler{},
-		ovsctlClient:          ovsctl.NewClient(bridgeName),
-		ovsMetersAreSupported: ovsMetersAreSupported(),
+		bridge:                  bridge,
+		enableProxy:             enableProxy,
+		proxyAll:                proxyAll,
+		enableAntreaPolicy:      enableAntreaPolicy,
+		enableL7NetworkPolicy:   enableL7NetworkPolicy,
+		enableDenyTracking:      enableDenyTracking,
+		enableEgress:            enableEgress,
+		enableMulticast:         enableMulticast,
+		enableTrafficControl:    enableTrafficControl,
+		enableMulticluster:      enableMulticluster,
+		enablePodBandwidthMeter: enablePodBandwidthMeter,
+		connectUplinkToBridge:   connectUplinkToBridge,
+		pipelines:               make(map[binding.PipelineID]binding.Pipeline),
+		packetInHandlers:        map[uint8]PacketInHandler{},
+		packetInRate:            PacketInQueueRate,
+		packetInQueues:          map[uint8]*binding.PacketInQueue{},
+		ovsctlClient:            ovsctl.NewClient(bridgeName),
+		ovsMetersAreSupported:   ovsMetersAreSupported(),
 	}
 	c.ofEntryOperations = c
 	return c
@@ -2895,6 +3462,45 @@ func (f *featurePodConnectivity) podVLANFlow(podOFPort uint32, vlanID uint16) bi
 		Done()
 }
 
+// gatewayProxyFlows generates the flows for the subnets of IPPools with GatewayProxy enabled. The ARP requests for the
+// gateways of the subnets are replied with the local Antrea gateway's MAC, and the packets sent to the Antrea gateway's
+// MAC by an Antrea IPAM Pod and destined for another subnet in a different VLAN are routed in OVS: the source MAC is
+// rewritten to the Antrea gateway's MAC, VLANIDField is set to the VLAN ID of the destination subnet, and the packets
+// are resubmitted to L3ForwardingTable, where they are forwarded to the local Pods directly, or to the Antrea gateway
+// if the destination Pods are not on this Node.
+func (f *featurePodConnectivity) gatewayProxyFlows(subnets []types.GatewayProxySubnet) []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	localGatewayMAC := f.nodeConfig.GatewayConfig.MAC
+	var flows []binding.Flow
+	gateways := sets.New[string]()
+	for _, subnet := range subnets {
+		if !gateways.Has(subnet.Gateway.String()) {
+			gateways.Insert(subnet.Gateway.String())
+			flows = append(flows, f.arpResponderFlow(subnet.Gateway, localGatewayMAC))
+		}
+	}
+	for _, src := range subnets {
+		for _, dst := range subnets {
+			if src.VLANID == dst.VLANID {
+				continue
+			}
+			flows = append(flows, L3ForwardingTable.ofTable.BuildFlow(priorityHigh).
+				Cookie(cookieID).
+				MatchProtocol(binding.ProtocolIP).
+				MatchRegMark(AntreaFlexibleIPAMRegMark).
+				MatchRegFieldWithValue(VLANIDField, uint32(src.VLANID)).
+				MatchDstMAC(localGatewayMAC).
+				MatchSrcIPNet(*src.Subnet).
+				MatchDstIPNet(*dst.Subnet).
+				Action().SetSrcMAC(localGatewayMAC).
+				Action().LoadToRegField(VLANIDField, uint32(dst.VLANID)).
+				Action().ResubmitToTables(L3ForwardingTable.GetID()).
+				Done())
+		}
+	}
+	return flows
+}
+
 // preRoutingClassifierFlows generates the flow to classify packets in stagePreRouting.
 func (f *featureService) preRoutingClassifierFlows() []binding.Flow {
 	cookieID := f.cookieAllocator.Request(f.category).Raw()
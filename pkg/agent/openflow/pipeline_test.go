@@ -194,8 +194,20 @@ func Test_client_defaultFlows(t *testing.T) {
 			name:          "IPv4,ExternalNode Node",
 			enableIPv4:    true,
 			nodeType:      config.ExternalNode,
+			clientOptions: []clientOptionsFn{disableProxy},
 			expectedFlows: pipelineDefaultFlows(true, false, false),
 		},
+		{
+			name:       "IPv4,ExternalNode Node,Proxy",
+			enableIPv4: true,
+			nodeType:   config.ExternalNode,
+			expectedFlows: append(replaceFlow(pipelineDefaultFlows(true, false, false),
+				"cookie=0x1000000000000, table=ConntrackState, priority=0 actions=goto_table:EgressSecurityClassifier",
+				"cookie=0x1000000000000, table=ConntrackState, priority=0 actions=goto_table:SessionAffinity"),
+				"cookie=0x1000000000000, table=SessionAffinity, priority=0 actions=goto_table:ServiceLB",
+				"cookie=0x1000000000000, table=ServiceLB, priority=0 actions=goto_table:EndpointDNAT",
+				"cookie=0x1000000000000, table=EndpointDNAT, priority=0 actions=goto_table:EgressSecurityClassifier"),
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
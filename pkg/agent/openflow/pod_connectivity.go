@@ -16,16 +16,27 @@ package openflow
 
 import (
 	"net"
+	"sync"
 
 	"antrea.io/libOpenflow/openflow15"
+	"k8s.io/klog/v2"
 
 	"antrea.io/antrea/pkg/agent/config"
 	"antrea.io/antrea/pkg/agent/openflow/cookie"
+	"antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/apis/crd/v1alpha2"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
 	"antrea.io/antrea/pkg/util/runtime"
 )
 
+// podBandwidthMeterIDBase is the first meter ID used for the Pod bandwidth meters. The lower IDs are reserved for the
+// packet-in meters.
+const podBandwidthMeterIDBase = 256
+
+// networkPolicyMeterIDBase is the first meter ID used for the meters of NetworkPolicy rules with action RateLimit. It
+// is above all the IDs that may be used by the Pod bandwidth meters.
+const networkPolicyMeterIDBase = 1 << 20
+
 type featurePodConnectivity struct {
 	cookieAllocator cookie.Allocator
 	ipProtocols     []binding.Protocol
@@ -33,6 +44,11 @@ type featurePodConnectivity struct {
 	nodeCachedFlows *flowCategoryCache
 	podCachedFlows  *flowCategoryCache
 	tcCachedFlows   *flowCategoryCache
+	// podBandwidthCachedFlows caches the flows applying the bandwidth meters of Pods, keyed by the Pod interface names.
+	podBandwidthCachedFlows *flowCategoryCache
+	// podBandwidthMeters caches the meters enforcing the bandwidth limits of Pods, keyed by the Pod interface names.
+	// They are not managed with bundles like the flows, as OVS doesn't support meter modifications in bundles.
+	podBandwidthMeters sync.Map
 
 	gatewayIPs    map[binding.Protocol]net.IP
 	gatewayPort   uint32
@@ -46,11 +62,14 @@ type featurePodConnectivity struct {
 	networkConfig *config.NetworkConfig
 
 	connectUplinkToBridge bool
+	phyBridgePatchPort    uint32
 	ctZoneSrcField        *binding.RegField
 	ipCtZoneTypeRegMarks  map[binding.Protocol]*binding.RegMark
 	enableMulticast       bool
 	proxyAll              bool
 	enableTrafficControl  bool
+	// enablePodBandwidthMeter indicates whether the bandwidth limits of Pods are enforced with OVS meters.
+	enablePodBandwidthMeter bool
 
 	category cookie.Category
 }
@@ -67,7 +86,8 @@ func newFeaturePodConnectivity(
 	connectUplinkToBridge bool,
 	enableMulticast bool,
 	proxyAll bool,
-	enableTrafficControl bool) *featurePodConnectivity {
+	enableTrafficControl bool,
+	enablePodBandwidthMeter bool) *featurePodConnectivity {
 	ctZones := make(map[binding.Protocol]int)
 	gatewayIPs := make(map[binding.Protocol]net.IP)
 	localCIDRs := make(map[binding.Protocol]net.IPNet)
@@ -103,28 +123,31 @@ func newFeaturePodConnectivity(
 	}
 
 	return &featurePodConnectivity{
-		cookieAllocator:       cookieAllocator,
-		ipProtocols:           ipProtocols,
-		nodeCachedFlows:       newFlowCategoryCache(),
-		podCachedFlows:        newFlowCategoryCache(),
-		tcCachedFlows:         newFlowCategoryCache(),
-		gatewayIPs:            gatewayIPs,
-		gatewayPort:           gatewayPort,
-		uplinkPort:            uplinkPort,
-		hostIfacePort:         nodeConfig.HostInterfaceOFPort,
-		tunnelPort:            nodeConfig.TunnelOFPort,
-		ctZones:               ctZones,
-		localCIDRs:            localCIDRs,
-		nodeIPs:               nodeIPs,
-		nodeConfig:            nodeConfig,
-		networkConfig:         networkConfig,
-		connectUplinkToBridge: connectUplinkToBridge,
-		enableTrafficControl:  enableTrafficControl,
-		ipCtZoneTypeRegMarks:  ipCtZoneTypeRegMarks,
-		ctZoneSrcField:        getZoneSrcField(connectUplinkToBridge),
-		enableMulticast:       enableMulticast,
-		proxyAll:              proxyAll,
-		category:              cookie.PodConnectivity,
+		cookieAllocator:         cookieAllocator,
+		ipProtocols:             ipProtocols,
+		nodeCachedFlows:         newFlowCategoryCache(),
+		podCachedFlows:          newFlowCategoryCache(),
+		tcCachedFlows:           newFlowCategoryCache(),
+		podBandwidthCachedFlows: newFlowCategoryCache(),
+		gatewayIPs:              gatewayIPs,
+		gatewayPort:             gatewayPort,
+		uplinkPort:              uplinkPort,
+		hostIfacePort:           nodeConfig.HostInterfaceOFPort,
+		tunnelPort:              nodeConfig.TunnelOFPort,
+		phyBridgePatchPort:      nodeConfig.PhysicalBridgePatchOFPort,
+		ctZones:                 ctZones,
+		localCIDRs:              localCIDRs,
+		nodeIPs:                 nodeIPs,
+		nodeConfig:              nodeConfig,
+		networkConfig:           networkConfig,
+		connectUplinkToBridge:   connectUplinkToBridge,
+		enableTrafficControl:    enableTrafficControl,
+		enablePodBandwidthMeter: enablePodBandwidthMeter,
+		ipCtZoneTypeRegMarks:    ipCtZoneTypeRegMarks,
+		ctZoneSrcField:          getZoneSrcField(connectUplinkToBridge),
+		enableMulticast:         enableMulticast,
+		proxyAll:                proxyAll,
+		category:                cookie.PodConnectivity,
 	}
 }
 
@@ -163,6 +186,10 @@ func (f *featurePodConnectivity) initFlows() []*openflow15.FlowMod {
 	// Add flow to ensure the liveliness check packet could be forwarded correctly.
 	flows = append(flows, f.localProbeFlows()...)
 
+	if f.phyBridgePatchPort != 0 {
+		flows = append(flows, f.physicalBridgeClassifierFlow())
+	}
+
 	if f.networkConfig.TrafficEncapMode.SupportsEncap() {
 		flows = append(flows, f.tunnelClassifierFlow(f.tunnelPort))
 		flows = append(flows, f.l2ForwardCalcFlow(GlobalVirtualMAC, f.tunnelPort))
@@ -190,13 +217,62 @@ func (f *featurePodConnectivity) replayFlows() []*openflow15.FlowMod {
 	var flows []*openflow15.FlowMod
 
 	// Get cached flows.
-	for _, cachedFlows := range []*flowCategoryCache{f.nodeCachedFlows, f.podCachedFlows, f.tcCachedFlows} {
+	for _, cachedFlows := range []*flowCategoryCache{f.nodeCachedFlows, f.podCachedFlows, f.tcCachedFlows, f.podBandwidthCachedFlows} {
 		flows = append(flows, getCachedFlowMessages(cachedFlows)...)
 	}
 
 	return flows
 }
 
+// replayMeters adds the cached Pod bandwidth meters back to OVS. It must be called before the flows are replayed, as
+// the flows referencing a nonexistent meter are rejected by OVS.
+func (f *featurePodConnectivity) replayMeters() {
+	f.podBandwidthMeters.Range(func(key, value interface{}) bool {
+		for _, meter := range value.([]binding.Meter) {
+			meter.Reset()
+			if err := meter.Add(); err != nil {
+				klog.ErrorS(err, "Error when replaying Pod bandwidth meter", "interface", key)
+			}
+		}
+		return true
+	})
+}
+
+// podBandwidthMeterIDs returns the IDs of the meters enforcing the ingress and egress bandwidth limits of the Pod
+// connected to the provided OVS port. The IDs are derived from the port number, as an OVS port is connected to at most
+// one Pod at a time, and start from podBandwidthMeterIDBase to avoid conflicting with the packet-in meters.
+func podBandwidthMeterIDs(ofPort uint32) (binding.MeterIDType, binding.MeterIDType) {
+	ingressMeterID := binding.MeterIDType(podBandwidthMeterIDBase + 2*ofPort)
+	return ingressMeterID, ingressMeterID + 1
+}
+
+// podBandwidthFlows generates the flows to apply the bandwidth meters of a Pod to the packets it sends and receives.
+// A zero meter ID means that the traffic in the direction is not limited.
+func (f *featurePodConnectivity) podBandwidthFlows(ofPort uint32, ingressMeterID, egressMeterID binding.MeterIDType) []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	var flows []binding.Flow
+	if egressMeterID != 0 {
+		// This generates the flow to apply the egress meter to the packets sent by the Pod.
+		flows = append(flows, PodEgressBandwidthTable.ofTable.BuildFlow(priorityNormal).
+			Cookie(cookieID).
+			MatchInPort(ofPort).
+			Action().Meter(uint32(egressMeterID)).
+			Action().NextTable().
+			Done())
+	}
+	if ingressMeterID != 0 {
+		// This generates the flow to apply the ingress meter to the packets destined for the Pod.
+		flows = append(flows, PodIngressBandwidthTable.ofTable.BuildFlow(priorityNormal).
+			Cookie(cookieID).
+			MatchRegMark(OFPortFoundRegMark).
+			MatchRegFieldWithValue(TargetOFPortField, ofPort).
+			Action().Meter(uint32(ingressMeterID)).
+			Action().NextTable().
+			Done())
+	}
+	return flows
+}
+
 // trafficControlMarkFlows generates the flows to mark the packets that need to be redirected or mirrored.
 func (f *featurePodConnectivity) trafficControlMarkFlows(sourceOFPorts []uint32, targetOFPort uint32, direction v1alpha2.Direction, action v1alpha2.TrafficControlAction) []binding.Flow {
 	cookieID := f.cookieAllocator.Request(f.category).Raw()
@@ -232,6 +308,65 @@ func (f *featurePodConnectivity) trafficControlMarkFlows(sourceOFPorts []uint32,
 	return flows
 }
 
+// trafficMirrorMarkFlows generates the flows to mark the packets sent or received by the provided ports and matching
+// any of the provided filters, so that they are mirrored to the target port by the common traffic control flows. If no
+// filter is provided, all the packets are marked. The flows have a lower priority than the flows of TrafficControl, so
+// that TrafficControl takes precedence over TrafficMirror for the packets both of them apply to.
+func (f *featurePodConnectivity) trafficMirrorMarkFlows(sourceOFPorts []uint32, targetOFPort uint32, direction v1alpha2.Direction, filters []types.TrafficMirrorFilter) []binding.Flow {
+	cookieID := f.cookieAllocator.Request(f.category).Raw()
+	buildFlow := func(filter *types.TrafficMirrorFilter) binding.FlowBuilder {
+		fb := TrafficControlTable.ofTable.BuildFlow(priorityLow).Cookie(cookieID)
+		if filter == nil {
+			return fb
+		}
+		fb = fb.MatchProtocol(filter.Protocol)
+		if filter.SourceIPNet != nil {
+			fb = fb.MatchSrcIPNet(*filter.SourceIPNet)
+		}
+		if filter.DestinationIPNet != nil {
+			fb = fb.MatchDstIPNet(*filter.DestinationIPNet)
+		}
+		if filter.SourcePort != 0 {
+			fb = fb.MatchSrcPort(filter.SourcePort, nil)
+		}
+		if filter.DestinationPort != 0 {
+			fb = fb.MatchDstPort(filter.DestinationPort, nil)
+		}
+		return fb
+	}
+	filterPtrs := []*types.TrafficMirrorFilter{nil}
+	if len(filters) > 0 {
+		filterPtrs = make([]*types.TrafficMirrorFilter, 0, len(filters))
+		for i := range filters {
+			filterPtrs = append(filterPtrs, &filters[i])
+		}
+	}
+	var flows []binding.Flow
+	for _, port := range sourceOFPorts {
+		for _, filter := range filterPtrs {
+			if direction == v1alpha2.DirectionIngress || direction == v1alpha2.DirectionBoth {
+				// This generates the flow to mark the matched packets destined for a provided port.
+				flows = append(flows, buildFlow(filter).
+					MatchRegFieldWithValue(TargetOFPortField, port).
+					Action().LoadToRegField(TrafficControlTargetOFPortField, targetOFPort).
+					Action().LoadRegMark(TrafficControlMirrorRegMark).
+					Action().NextTable().
+					Done())
+			}
+			// This generates the flow to mark the matched packets sourced from a provided port.
+			if direction == v1alpha2.DirectionEgress || direction == v1alpha2.DirectionBoth {
+				flows = append(flows, buildFlow(filter).
+					MatchInPort(port).
+					Action().LoadToRegField(TrafficControlTargetOFPortField, targetOFPort).
+					Action().LoadRegMark(TrafficControlMirrorRegMark).
+					Action().NextTable().
+					Done())
+			}
+		}
+	}
+	return flows
+}
+
 // trafficControlReturnClassifierFlow generates the flow to mark the packets from traffic control return port and forward
 // the packets to stageRouting directly. Note that, for the packets which are originally to be output to a tunnel port,
 // value of NXM_NX_TUN_IPV4_DST for the returned packets needs to be loaded in stageRouting.
@@ -18,8 +18,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/exp/slices"
 
 	"antrea.io/antrea/pkg/agent/config"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
 	"antrea.io/antrea/pkg/util/runtime"
 )
 
@@ -264,6 +266,16 @@ func podConnectivityInitFlows(trafficEncapMode config.TrafficEncapModeType, conn
 	return flows
 }
 
+func withoutFlows(flows []string, removedFlows ...string) []string {
+	var result []string
+	for _, flow := range flows {
+		if !slices.Contains(removedFlows, flow) {
+			result = append(result, flow)
+		}
+	}
+	return result
+}
+
 func Test_featurePodConnectivity_initFlows(t *testing.T) {
 	testCases := []struct {
 		name             string
@@ -331,6 +343,36 @@ func Test_featurePodConnectivity_initFlows(t *testing.T) {
 			clientOptions:    []clientOptionsFn{enableTrafficControl},
 			expectedFlows:    podConnectivityInitFlows(config.TrafficEncapModeEncap, false, true, true, false),
 		},
+		{
+			name:             "IPv4 Encap with physical bridge",
+			enableIPv4:       true,
+			skipWindows:      true,
+			trafficEncapMode: config.TrafficEncapModeEncap,
+			clientOptions:    []clientOptionsFn{enablePhysicalBridge},
+			expectedFlows: append(podConnectivityInitFlows(config.TrafficEncapModeEncap, false, true, false, false),
+				"cookie=0x1010000000000, table=Classifier, priority=200,in_port=5 actions=set_field:0x4/0xf->reg0,goto_table:UnSNAT",
+			),
+		},
+		{
+			name:             "IPv4 Encap with ICMP Time Exceeded",
+			enableIPv4:       true,
+			trafficEncapMode: config.TrafficEncapModeEncap,
+			clientOptions:    []clientOptionsFn{enableICMPTimeExceeded},
+			expectedFlows: append(podConnectivityInitFlows(config.TrafficEncapModeEncap, false, true, false, false),
+				"cookie=0x1010000000000, table=L3DecTTL, priority=201,ip,nw_ttl=0 actions=controller(id=32776,reason=no_match,userdata=05,max_len=128)",
+				"cookie=0x1010000000000, table=L3DecTTL, priority=201,ip,nw_ttl=1 actions=controller(id=32776,reason=no_match,userdata=05,max_len=128)",
+			),
+		},
+		{
+			name:             "IPv4 Encap without TTL decrement",
+			enableIPv4:       true,
+			trafficEncapMode: config.TrafficEncapModeEncap,
+			clientOptions:    []clientOptionsFn{disableTTLDecrement},
+			expectedFlows: withoutFlows(podConnectivityInitFlows(config.TrafficEncapModeEncap, false, true, false, false),
+				"cookie=0x1010000000000, table=L3DecTTL, priority=210,ip,reg0=0x2/0xf actions=goto_table:SNATMark",
+				"cookie=0x1010000000000, table=L3DecTTL, priority=200,ip actions=dec_ttl,goto_table:SNATMark",
+			),
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -344,3 +386,49 @@ func Test_featurePodConnectivity_initFlows(t *testing.T) {
 		})
 	}
 }
+
+func Test_featurePodConnectivity_podBandwidthFlows(t *testing.T) {
+	ingressMeterID, egressMeterID := podBandwidthMeterIDs(5)
+	assert.Equal(t, binding.MeterIDType(266), ingressMeterID)
+	assert.Equal(t, binding.MeterIDType(267), egressMeterID)
+
+	testCases := []struct {
+		name           string
+		ingressMeterID binding.MeterIDType
+		egressMeterID  binding.MeterIDType
+		expectedFlows  []string
+	}{
+		{
+			name:           "ingress and egress",
+			ingressMeterID: ingressMeterID,
+			egressMeterID:  egressMeterID,
+			expectedFlows: []string{
+				"cookie=0x1010000000000, table=PodEgressBandwidth, priority=200,in_port=5 actions=meter:267,goto_table:SpoofGuard",
+				"cookie=0x1010000000000, table=PodIngressBandwidth, priority=200,reg0=0x100/0x100,reg1=0x5 actions=meter:266,goto_table:Output",
+			},
+		},
+		{
+			name:           "ingress only",
+			ingressMeterID: ingressMeterID,
+			expectedFlows: []string{
+				"cookie=0x1010000000000, table=PodIngressBandwidth, priority=200,reg0=0x100/0x100,reg1=0x5 actions=meter:266,goto_table:Output",
+			},
+		},
+		{
+			name:          "egress only",
+			egressMeterID: egressMeterID,
+			expectedFlows: []string{
+				"cookie=0x1010000000000, table=PodEgressBandwidth, priority=200,in_port=5 actions=meter:267,goto_table:SpoofGuard",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fc := newFakeClient(nil, true, false, config.K8sNode, config.TrafficEncapModeEncap, enablePodBandwidthMeter)
+			defer resetPipelines()
+
+			flows := getFlowStrings(fc.featurePodConnectivity.podBandwidthFlows(5, tc.ingressMeterID, tc.egressMeterID))
+			assert.ElementsMatch(t, tc.expectedFlows, flows)
+		})
+	}
+}
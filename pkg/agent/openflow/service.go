@@ -33,6 +33,10 @@ type featureService struct {
 
 	cachedFlows *flowCategoryCache
 	groupCache  sync.Map
+	// subGroupCache stores the sub-groups of the Service groups having more Endpoints than maxBucketsPerServiceGroup,
+	// keyed by the ID of the Service group. The value is a map from sub-group ID to sub-group.
+	subGroupCache     sync.Map
+	subGroupAllocator GroupAllocator
 
 	gatewayIPs             map[binding.Protocol]net.IP
 	virtualIPs             map[binding.Protocol]net.IP
@@ -46,11 +50,14 @@ type featureService struct {
 	networkConfig          *config.NetworkConfig
 	gatewayPort            uint32
 
-	enableAntreaPolicy    bool
-	enableProxy           bool
-	proxyAll              bool
-	connectUplinkToBridge bool
-	ctZoneSrcField        *binding.RegField
+	enableAntreaPolicy             bool
+	enableProxy                    bool
+	proxyAll                       bool
+	connectUplinkToBridge          bool
+	rejectServicesWithoutEndpoints bool
+	enableLoadBalancerModeDSR      bool
+	ctZoneSrcField                 *binding.RegField
+	nodeType                       config.NodeType
 
 	category cookie.Category
 }
@@ -69,7 +76,13 @@ func newFeatureService(
 	enableAntreaPolicy,
 	enableProxy,
 	proxyAll,
-	connectUplinkToBridge bool) *featureService {
+	connectUplinkToBridge bool,
+	nodeType config.NodeType) *featureService {
+	// The Antrea gateway is not created on an external Node.
+	gatewayConfig := nodeConfig.GatewayConfig
+	if gatewayConfig == nil {
+		gatewayConfig = &config.GatewayConfig{}
+	}
 	gatewayIPs := make(map[binding.Protocol]net.IP)
 	virtualIPs := make(map[binding.Protocol]net.IP)
 	virtualNodePortDNATIPs := make(map[binding.Protocol]net.IP)
@@ -80,7 +93,7 @@ func newFeatureService(
 	localCIDRs := make(map[binding.Protocol]net.IPNet)
 	for _, ipProtocol := range ipProtocols {
 		if ipProtocol == binding.ProtocolIP {
-			gatewayIPs[ipProtocol] = nodeConfig.GatewayConfig.IPv4
+			gatewayIPs[ipProtocol] = gatewayConfig.IPv4
 			virtualIPs[ipProtocol] = config.VirtualServiceIPv4
 			virtualNodePortDNATIPs[ipProtocol] = config.VirtualNodePortDNATIPv4
 			dnatCtZones[ipProtocol] = CtZone
@@ -93,7 +106,7 @@ func newFeatureService(
 				localCIDRs[ipProtocol] = *nodeConfig.PodIPv4CIDR
 			}
 		} else if ipProtocol == binding.ProtocolIPv6 {
-			gatewayIPs[ipProtocol] = nodeConfig.GatewayConfig.IPv6
+			gatewayIPs[ipProtocol] = gatewayConfig.IPv6
 			virtualIPs[ipProtocol] = config.VirtualServiceIPv6
 			virtualNodePortDNATIPs[ipProtocol] = config.VirtualNodePortDNATIPv6
 			dnatCtZones[ipProtocol] = CtZoneV6
@@ -107,45 +120,67 @@ func newFeatureService(
 			}
 		}
 	}
+	// The packets of Services without Endpoint are always dropped on an external Node, as the rejection responses are
+	// generated for the Antrea gateway and local Pods.
+	rejectServicesWithoutEndpoints := serviceConfig.RejectServicesWithoutEndpoints && nodeType == config.K8sNode
+	// DSR mode relies on the tunnel to forward the packets to remote Endpoints without DNAT, and only IPv4 is supported.
+	enableLoadBalancerModeDSR := serviceConfig.EnableLoadBalancerModeDSR && enableProxy && nodeType == config.K8sNode &&
+		networkConfig.TrafficEncapMode.SupportsEncap() && nodeConfig.PodIPv4CIDR != nil
 
 	return &featureService{
-		cookieAllocator:        cookieAllocator,
-		ipProtocols:            ipProtocols,
-		bridge:                 bridge,
-		cachedFlows:            newFlowCategoryCache(),
-		groupCache:             sync.Map{},
-		gatewayIPs:             gatewayIPs,
-		virtualIPs:             virtualIPs,
-		virtualNodePortDNATIPs: virtualNodePortDNATIPs,
-		dnatCtZones:            dnatCtZones,
-		snatCtZones:            snatCtZones,
-		nodePortAddresses:      nodePortAddresses,
-		serviceCIDRs:           serviceCIDRs,
-		localCIDRs:             localCIDRs,
-		gatewayMAC:             nodeConfig.GatewayConfig.MAC,
-		gatewayPort:            nodeConfig.GatewayConfig.OFPort,
-		networkConfig:          networkConfig,
-		enableAntreaPolicy:     enableAntreaPolicy,
-		enableProxy:            enableProxy,
-		proxyAll:               proxyAll,
-		connectUplinkToBridge:  connectUplinkToBridge,
-		ctZoneSrcField:         getZoneSrcField(connectUplinkToBridge),
-		category:               cookie.Service,
+		cookieAllocator:                cookieAllocator,
+		ipProtocols:                    ipProtocols,
+		bridge:                         bridge,
+		cachedFlows:                    newFlowCategoryCache(),
+		groupCache:                     sync.Map{},
+		subGroupAllocator:              newServiceSubGroupAllocator(),
+		gatewayIPs:                     gatewayIPs,
+		virtualIPs:                     virtualIPs,
+		virtualNodePortDNATIPs:         virtualNodePortDNATIPs,
+		dnatCtZones:                    dnatCtZones,
+		snatCtZones:                    snatCtZones,
+		nodePortAddresses:              nodePortAddresses,
+		serviceCIDRs:                   serviceCIDRs,
+		localCIDRs:                     localCIDRs,
+		gatewayMAC:                     gatewayConfig.MAC,
+		gatewayPort:                    gatewayConfig.OFPort,
+		networkConfig:                  networkConfig,
+		enableAntreaPolicy:             enableAntreaPolicy,
+		enableProxy:                    enableProxy,
+		proxyAll:                       proxyAll,
+		connectUplinkToBridge:          connectUplinkToBridge,
+		rejectServicesWithoutEndpoints: rejectServicesWithoutEndpoints,
+		enableLoadBalancerModeDSR:      enableLoadBalancerModeDSR,
+		ctZoneSrcField:                 getZoneSrcField(connectUplinkToBridge),
+		nodeType:                       nodeType,
+		category:                       cookie.Service,
 	}
 }
 
-// serviceNoEndpointFlow generates the flow to match the packets to Service without Endpoint and send them to controller.
+// serviceNoEndpointFlow generates the flow to match the packets to Service without Endpoint. The packets are sent to
+// controller to be rejected if rejectServicesWithoutEndpoints is true, otherwise they are dropped.
 func (f *featureService) serviceNoEndpointFlow() binding.Flow {
-	return EndpointDNATTable.ofTable.BuildFlow(priorityNormal).
+	fb := EndpointDNATTable.ofTable.BuildFlow(priorityNormal).
 		Cookie(f.cookieAllocator.Request(f.category).Raw()).
-		MatchRegMark(SvcNoEpRegMark).
-		Action().SendToController([]byte{uint8(PacketInCategorySvcReject)}, false).
+		MatchRegMark(SvcNoEpRegMark)
+	if !f.rejectServicesWithoutEndpoints {
+		return fb.Action().Drop().Done()
+	}
+	return fb.Action().SendToController([]byte{uint8(PacketInCategorySvcReject)}, false).
 		Done()
 }
 
 func (f *featureService) initFlows() []*openflow15.FlowMod {
 	var flows []binding.Flow
-	if f.enableProxy {
+	if f.nodeType == config.ExternalNode {
+		// On an external Node, only the Service connections initiated through the paired host internal port of an
+		// uplink are load-balanced, and the selected Endpoints are always reached through the uplink. The replies
+		// are un-DNATed when they are tracked in ConntrackTable.
+		flows = append(flows, f.conntrackFlows()...)
+		flows = append(flows, f.serviceNeedLBFlow())
+		flows = append(flows, f.sessionAffinityReselectFlow())
+		flows = append(flows, f.serviceNoEndpointFlow())
+	} else if f.enableProxy {
 		flows = append(flows, f.conntrackFlows()...)
 		flows = append(flows, f.preRoutingClassifierFlows()...)
 		flows = append(flows, f.l3FwdFlowToExternalEndpoint())
@@ -155,6 +190,9 @@ func (f *featureService) initFlows() []*openflow15.FlowMod {
 		flows = append(flows, f.sessionAffinityReselectFlow())
 		flows = append(flows, f.serviceNoEndpointFlow())
 		flows = append(flows, f.l2ForwardOutputHairpinServiceFlow())
+		if f.enableLoadBalancerModeDSR {
+			flows = append(flows, f.dsrFlows()...)
+		}
 		if f.proxyAll {
 			// This installs the flows to match the first packet of NodePort connection. The flows set a bit of a register
 			// to mark the Service type of the packet as NodePort, and the mark is consumed in table serviceLBTable.
@@ -176,6 +214,14 @@ func (f *featureService) replayFlows() []*openflow15.FlowMod {
 
 func (f *featureService) replayGroups() {
 	var groups []binding.OFEntry
+	// The sub-groups are added before the Service groups referring to them.
+	f.subGroupCache.Range(func(_, value interface{}) bool {
+		for _, group := range value.(map[binding.GroupIDType]binding.Group) {
+			group.Reset()
+			groups = append(groups, group)
+		}
+		return true
+	})
 	f.groupCache.Range(func(id, value interface{}) bool {
 		group := value.(binding.Group)
 		group.Reset()
@@ -93,11 +93,21 @@ func serviceInitFlows(proxyEnabled, isIPv4, proxyAllEnabled bool) []string {
 	return flows
 }
 
+func replaceFlow(flows []string, oldFlow, newFlow string) []string {
+	for i := range flows {
+		if flows[i] == oldFlow {
+			flows[i] = newFlow
+		}
+	}
+	return flows
+}
+
 func Test_featureService_initFlows(t *testing.T) {
 	testCases := []struct {
 		name          string
 		enableIPv4    bool
 		enableIPv6    bool
+		nodeType      config.NodeType
 		clientOptions []clientOptionsFn
 		expectedFlows []string
 	}{
@@ -125,6 +135,22 @@ func Test_featureService_initFlows(t *testing.T) {
 			clientOptions: []clientOptionsFn{enableProxyAll},
 			expectedFlows: serviceInitFlows(true, false, true),
 		},
+		{
+			name:          "IPv4,Proxy,drop Services without Endpoints",
+			enableIPv4:    true,
+			clientOptions: []clientOptionsFn{enableProxy, dropServicesWithoutEndpoints},
+			expectedFlows: replaceFlow(serviceInitFlows(true, true, false),
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,reg0=0x4000/0x4000 actions=controller(id=32776,reason=no_match,userdata=04,max_len=128)",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,reg0=0x4000/0x4000 actions=drop"),
+		},
+		{
+			name:          "IPv6,Proxy,drop Services without Endpoints",
+			enableIPv6:    true,
+			clientOptions: []clientOptionsFn{enableProxy, dropServicesWithoutEndpoints},
+			expectedFlows: replaceFlow(serviceInitFlows(true, false, false),
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,reg0=0x4000/0x4000 actions=controller(id=32776,reason=no_match,userdata=04,max_len=128)",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,reg0=0x4000/0x4000 actions=drop"),
+		},
 		{
 			name:          "No Proxy",
 			enableIPv4:    true,
@@ -132,10 +158,22 @@ func Test_featureService_initFlows(t *testing.T) {
 			clientOptions: []clientOptionsFn{disableProxy},
 			expectedFlows: serviceInitFlows(false, true, false),
 		},
+		{
+			name:          "IPv4,Proxy,ExternalNode",
+			enableIPv4:    true,
+			nodeType:      config.ExternalNode,
+			clientOptions: []clientOptionsFn{enableProxy},
+			expectedFlows: []string{
+				"cookie=0x1030000000000, table=ConntrackState, priority=200,ct_state=-new+trk,ct_mark=0x10/0x10,ip actions=set_field:0x200/0x200->reg0,goto_table:EgressSecurityClassifier",
+				"cookie=0x1030000000000, table=SessionAffinity, priority=0 actions=set_field:0x10000/0x70000->reg4",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=200,reg0=0x4000/0x4000 actions=drop",
+				"cookie=0x1030000000000, table=EndpointDNAT, priority=190,reg4=0x20000/0x70000 actions=set_field:0x10000/0x70000->reg4,resubmit:ServiceLB",
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			fc := newFakeClient(nil, tc.enableIPv4, tc.enableIPv6, config.K8sNode, config.TrafficEncapModeEncap, tc.clientOptions...)
+			fc := newFakeClient(nil, tc.enableIPv4, tc.enableIPv6, tc.nodeType, config.TrafficEncapModeEncap, tc.clientOptions...)
 			defer resetPipelines()
 
 			flows := getFlowStrings(fc.featureService.initFlows())
@@ -129,6 +129,22 @@ func (mr *MockClientMockRecorder) DeletePolicyRuleAddress(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePolicyRuleAddress", reflect.TypeOf((*MockClient)(nil).DeletePolicyRuleAddress), arg0, arg1, arg2, arg3)
 }
 
+// DeleteOrphanedFlowsAndGroups mocks base method
+func (m *MockClient) DeleteOrphanedFlowsAndGroups(arg0 bool) ([]string, []openflow.GroupIDType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrphanedFlowsAndGroups", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].([]openflow.GroupIDType)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteOrphanedFlowsAndGroups indicates an expected call of DeleteOrphanedFlowsAndGroups
+func (mr *MockClientMockRecorder) DeleteOrphanedFlowsAndGroups(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphanedFlowsAndGroups", reflect.TypeOf((*MockClient)(nil).DeleteOrphanedFlowsAndGroups), arg0)
+}
+
 // DeleteStaleFlows mocks base method
 func (m *MockClient) DeleteStaleFlows() error {
 	m.ctrl.T.Helper()
@@ -274,6 +290,20 @@ func (mr *MockClientMockRecorder) InstallEndpointFlows(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallEndpointFlows", reflect.TypeOf((*MockClient)(nil).InstallEndpointFlows), arg0, arg1)
 }
 
+// InstallGatewayProxyFlows mocks base method
+func (m *MockClient) InstallGatewayProxyFlows(arg0 []types.GatewayProxySubnet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallGatewayProxyFlows", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallGatewayProxyFlows indicates an expected call of InstallGatewayProxyFlows
+func (mr *MockClientMockRecorder) InstallGatewayProxyFlows(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallGatewayProxyFlows", reflect.TypeOf((*MockClient)(nil).InstallGatewayProxyFlows), arg0)
+}
+
 // InstallMulticastFlows mocks base method
 func (m *MockClient) InstallMulticastFlows(arg0 net.IP, arg1 openflow.GroupIDType) error {
 	m.ctrl.T.Helper()
@@ -331,31 +361,45 @@ func (mr *MockClientMockRecorder) InstallMulticlusterClassifierFlows(arg0, arg1
 }
 
 // InstallMulticlusterGatewayFlows mocks base method
-func (m *MockClient) InstallMulticlusterGatewayFlows(arg0 string, arg1 map[*net.IPNet]net.IP, arg2, arg3 net.IP, arg4 bool) error {
+func (m *MockClient) InstallMulticlusterGatewayFlows(arg0 string, arg1 map[*net.IPNet]net.IP, arg2, arg3 net.IP, arg4 []net.IP, arg5 bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InstallMulticlusterGatewayFlows", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "InstallMulticlusterGatewayFlows", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InstallMulticlusterGatewayFlows indicates an expected call of InstallMulticlusterGatewayFlows
-func (mr *MockClientMockRecorder) InstallMulticlusterGatewayFlows(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) InstallMulticlusterGatewayFlows(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallMulticlusterGatewayFlows", reflect.TypeOf((*MockClient)(nil).InstallMulticlusterGatewayFlows), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallMulticlusterGatewayFlows", reflect.TypeOf((*MockClient)(nil).InstallMulticlusterGatewayFlows), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// InstallMulticlusterGatewayGroup mocks base method
+func (m *MockClient) InstallMulticlusterGatewayGroup(arg0 openflow.GroupIDType, arg1 []net.IP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallMulticlusterGatewayGroup", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallMulticlusterGatewayGroup indicates an expected call of InstallMulticlusterGatewayGroup
+func (mr *MockClientMockRecorder) InstallMulticlusterGatewayGroup(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallMulticlusterGatewayGroup", reflect.TypeOf((*MockClient)(nil).InstallMulticlusterGatewayGroup), arg0, arg1)
 }
 
 // InstallMulticlusterNodeFlows mocks base method
-func (m *MockClient) InstallMulticlusterNodeFlows(arg0 string, arg1 map[*net.IPNet]net.IP, arg2 net.IP, arg3 bool) error {
+func (m *MockClient) InstallMulticlusterNodeFlows(arg0 string, arg1 map[*net.IPNet]net.IP, arg2 net.IP, arg3 openflow.GroupIDType, arg4 []net.IP, arg5 bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InstallMulticlusterNodeFlows", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "InstallMulticlusterNodeFlows", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InstallMulticlusterNodeFlows indicates an expected call of InstallMulticlusterNodeFlows
-func (mr *MockClientMockRecorder) InstallMulticlusterNodeFlows(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) InstallMulticlusterNodeFlows(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallMulticlusterNodeFlows", reflect.TypeOf((*MockClient)(nil).InstallMulticlusterNodeFlows), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallMulticlusterNodeFlows", reflect.TypeOf((*MockClient)(nil).InstallMulticlusterNodeFlows), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // InstallMulticlusterPodFlows mocks base method
@@ -386,6 +430,62 @@ func (mr *MockClientMockRecorder) InstallNodeFlows(arg0, arg1, arg2, arg3, arg4
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallNodeFlows", reflect.TypeOf((*MockClient)(nil).InstallNodeFlows), arg0, arg1, arg2, arg3, arg4)
 }
 
+// InstallNodeLocalDNSInterceptFlows mocks base method
+func (m *MockClient) InstallNodeLocalDNSInterceptFlows(arg0 []net.IP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallNodeLocalDNSInterceptFlows", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallNodeLocalDNSInterceptFlows indicates an expected call of InstallNodeLocalDNSInterceptFlows
+func (mr *MockClientMockRecorder) InstallNodeLocalDNSInterceptFlows(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallNodeLocalDNSInterceptFlows", reflect.TypeOf((*MockClient)(nil).InstallNodeLocalDNSInterceptFlows), arg0)
+}
+
+// InstallPodBandwidthMeters mocks base method
+func (m *MockClient) InstallPodBandwidthMeters(arg0 string, arg1 uint32, arg2 *types.PodBandwidth) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallPodBandwidthMeters", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallPodBandwidthMeters indicates an expected call of InstallPodBandwidthMeters
+func (mr *MockClientMockRecorder) InstallPodBandwidthMeters(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallPodBandwidthMeters", reflect.TypeOf((*MockClient)(nil).InstallPodBandwidthMeters), arg0, arg1, arg2)
+}
+
+// InstallPodDestinationSNATFlows mocks base method
+func (m *MockClient) InstallPodDestinationSNATFlows(arg0 uint32, arg1 []net.IPNet, arg2 net.IP, arg3 uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallPodDestinationSNATFlows", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallPodDestinationSNATFlows indicates an expected call of InstallPodDestinationSNATFlows
+func (mr *MockClientMockRecorder) InstallPodDestinationSNATFlows(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallPodDestinationSNATFlows", reflect.TypeOf((*MockClient)(nil).InstallPodDestinationSNATFlows), arg0, arg1, arg2, arg3)
+}
+
+// InstallPodEgressDefaultDenyFlows mocks base method
+func (m *MockClient) InstallPodEgressDefaultDenyFlows(arg0 uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallPodEgressDefaultDenyFlows", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallPodEgressDefaultDenyFlows indicates an expected call of InstallPodEgressDefaultDenyFlows
+func (mr *MockClientMockRecorder) InstallPodEgressDefaultDenyFlows(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallPodEgressDefaultDenyFlows", reflect.TypeOf((*MockClient)(nil).InstallPodEgressDefaultDenyFlows), arg0)
+}
+
 // InstallPodFlows mocks base method
 func (m *MockClient) InstallPodFlows(arg0 string, arg1 []net.IP, arg2 net.HardwareAddr, arg3 uint32, arg4 uint16, arg5 *uint32) error {
 	m.ctrl.T.Helper()
@@ -400,6 +500,20 @@ func (mr *MockClientMockRecorder) InstallPodFlows(arg0, arg1, arg2, arg3, arg4,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallPodFlows", reflect.TypeOf((*MockClient)(nil).InstallPodFlows), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+// InstallPodNeighborSpoofGuardFlows mocks base method
+func (m *MockClient) InstallPodNeighborSpoofGuardFlows(arg0 uint32, arg1 []net.IP, arg2 net.HardwareAddr) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallPodNeighborSpoofGuardFlows", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallPodNeighborSpoofGuardFlows indicates an expected call of InstallPodNeighborSpoofGuardFlows
+func (mr *MockClientMockRecorder) InstallPodNeighborSpoofGuardFlows(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallPodNeighborSpoofGuardFlows", reflect.TypeOf((*MockClient)(nil).InstallPodNeighborSpoofGuardFlows), arg0, arg1, arg2)
+}
+
 // InstallPodSNATFlows mocks base method
 func (m *MockClient) InstallPodSNATFlows(arg0 uint32, arg1 net.IP, arg2 uint32) error {
 	m.ctrl.T.Helper()
@@ -456,18 +570,46 @@ func (mr *MockClientMockRecorder) InstallSNATMarkFlows(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallSNATMarkFlows", reflect.TypeOf((*MockClient)(nil).InstallSNATMarkFlows), arg0, arg1)
 }
 
+// InstallServiceDSRFlows mocks base method
+func (m *MockClient) InstallServiceDSRFlows(arg0, arg1 openflow.GroupIDType, arg2 net.IP, arg3 uint16, arg4 openflow.Protocol) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallServiceDSRFlows", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallServiceDSRFlows indicates an expected call of InstallServiceDSRFlows
+func (mr *MockClientMockRecorder) InstallServiceDSRFlows(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallServiceDSRFlows", reflect.TypeOf((*MockClient)(nil).InstallServiceDSRFlows), arg0, arg1, arg2, arg3, arg4)
+}
+
 // InstallServiceFlows mocks base method
-func (m *MockClient) InstallServiceFlows(arg0, arg1 openflow.GroupIDType, arg2 net.IP, arg3 uint16, arg4 openflow.Protocol, arg5 uint16, arg6, arg7 bool) error {
+func (m *MockClient) InstallServiceFlows(arg0, arg1 openflow.GroupIDType, arg2 net.IP, arg3 uint16, arg4 openflow.Protocol, arg5 uint16, arg6 *types.SessionAffinityKey, arg7, arg8 bool) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InstallServiceFlows", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret := m.ctrl.Call(m, "InstallServiceFlows", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InstallServiceFlows indicates an expected call of InstallServiceFlows
-func (mr *MockClientMockRecorder) InstallServiceFlows(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) InstallServiceFlows(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallServiceFlows", reflect.TypeOf((*MockClient)(nil).InstallServiceFlows), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallServiceFlows", reflect.TypeOf((*MockClient)(nil).InstallServiceFlows), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+}
+
+// InstallServicePortRangeFlows mocks base method
+func (m *MockClient) InstallServicePortRangeFlows(arg0, arg1 openflow.GroupIDType, arg2 net.IP, arg3, arg4 uint16, arg5 openflow.Protocol, arg6 uint16, arg7 *types.SessionAffinityKey, arg8 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallServicePortRangeFlows", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallServicePortRangeFlows indicates an expected call of InstallServicePortRangeFlows
+func (mr *MockClientMockRecorder) InstallServicePortRangeFlows(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallServicePortRangeFlows", reflect.TypeOf((*MockClient)(nil).InstallServicePortRangeFlows), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 }
 
 // InstallServiceGroup mocks base method
@@ -485,17 +627,17 @@ func (mr *MockClientMockRecorder) InstallServiceGroup(arg0, arg1, arg2 interface
 }
 
 // InstallTraceflowFlows mocks base method
-func (m *MockClient) InstallTraceflowFlows(arg0 byte, arg1, arg2, arg3 bool, arg4 *openflow.Packet, arg5 uint32, arg6 uint16) error {
+func (m *MockClient) InstallTraceflowFlows(arg0 byte, arg1, arg2, arg3, arg4 bool, arg5 *openflow.Packet, arg6 uint32, arg7 uint16) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InstallTraceflowFlows", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret := m.ctrl.Call(m, "InstallTraceflowFlows", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InstallTraceflowFlows indicates an expected call of InstallTraceflowFlows
-func (mr *MockClientMockRecorder) InstallTraceflowFlows(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) InstallTraceflowFlows(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallTraceflowFlows", reflect.TypeOf((*MockClient)(nil).InstallTraceflowFlows), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallTraceflowFlows", reflect.TypeOf((*MockClient)(nil).InstallTraceflowFlows), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
 }
 
 // InstallTrafficControlMarkFlows mocks base method
@@ -526,6 +668,20 @@ func (mr *MockClientMockRecorder) InstallTrafficControlReturnPortFlow(arg0 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallTrafficControlReturnPortFlow", reflect.TypeOf((*MockClient)(nil).InstallTrafficControlReturnPortFlow), arg0)
 }
 
+// InstallTrafficMirrorFlows mocks base method
+func (m *MockClient) InstallTrafficMirrorFlows(arg0 string, arg1 []uint32, arg2 uint32, arg3 v1alpha2.Direction, arg4 []types.TrafficMirrorFilter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstallTrafficMirrorFlows", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InstallTrafficMirrorFlows indicates an expected call of InstallTrafficMirrorFlows
+func (mr *MockClientMockRecorder) InstallTrafficMirrorFlows(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstallTrafficMirrorFlows", reflect.TypeOf((*MockClient)(nil).InstallTrafficMirrorFlows), arg0, arg1, arg2, arg3, arg4)
+}
+
 // InstallVMUplinkFlows mocks base method
 func (m *MockClient) InstallVMUplinkFlows(arg0 string, arg1, arg2 int32) error {
 	m.ctrl.T.Helper()
@@ -554,6 +710,46 @@ func (mr *MockClientMockRecorder) IsConnected() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsConnected", reflect.TypeOf((*MockClient)(nil).IsConnected))
 }
 
+// IsNetworkPolicyRateLimitSupported mocks base method
+func (m *MockClient) IsNetworkPolicyRateLimitSupported() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsNetworkPolicyRateLimitSupported")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsNetworkPolicyRateLimitSupported indicates an expected call of IsNetworkPolicyRateLimitSupported
+func (mr *MockClientMockRecorder) IsNetworkPolicyRateLimitSupported() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsNetworkPolicyRateLimitSupported", reflect.TypeOf((*MockClient)(nil).IsNetworkPolicyRateLimitSupported))
+}
+
+// IsPodBandwidthMeterSupported mocks base method
+func (m *MockClient) IsPodBandwidthMeterSupported() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPodBandwidthMeterSupported")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsPodBandwidthMeterSupported indicates an expected call of IsPodBandwidthMeterSupported
+func (mr *MockClientMockRecorder) IsPodBandwidthMeterSupported() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPodBandwidthMeterSupported", reflect.TypeOf((*MockClient)(nil).IsPodBandwidthMeterSupported))
+}
+
+// MonitorCapacity mocks base method
+func (m *MockClient) MonitorCapacity(arg0 types.OVSSoftLimits, arg1 <-chan struct{}) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MonitorCapacity", arg0, arg1)
+}
+
+// MonitorCapacity indicates an expected call of MonitorCapacity
+func (mr *MockClientMockRecorder) MonitorCapacity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MonitorCapacity", reflect.TypeOf((*MockClient)(nil).MonitorCapacity), arg0, arg1)
+}
+
 // MulticastEgressPodMetrics mocks base method
 func (m *MockClient) MulticastEgressPodMetrics() map[string]*types.RuleMetric {
 	m.ctrl.T.Helper()
@@ -788,6 +984,18 @@ func (mr *MockClientMockRecorder) SendUDPPacketOut(arg0, arg1, arg2, arg3, arg4,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendUDPPacketOut", reflect.TypeOf((*MockClient)(nil).SendUDPPacketOut), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
 }
 
+// SetPacketInRate mocks base method
+func (m *MockClient) SetPacketInRate(arg0 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPacketInRate", arg0)
+}
+
+// SetPacketInRate indicates an expected call of SetPacketInRate
+func (mr *MockClientMockRecorder) SetPacketInRate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPacketInRate", reflect.TypeOf((*MockClient)(nil).SetPacketInRate), arg0)
+}
+
 // StartPacketInHandler mocks base method
 func (m *MockClient) StartPacketInHandler(arg0 <-chan struct{}) {
 	m.ctrl.T.Helper()
@@ -870,6 +1078,20 @@ func (mr *MockClientMockRecorder) UninstallMulticlusterFlows(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallMulticlusterFlows", reflect.TypeOf((*MockClient)(nil).UninstallMulticlusterFlows), arg0)
 }
 
+// UninstallMulticlusterGatewayGroup mocks base method
+func (m *MockClient) UninstallMulticlusterGatewayGroup(arg0 openflow.GroupIDType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UninstallMulticlusterGatewayGroup", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UninstallMulticlusterGatewayGroup indicates an expected call of UninstallMulticlusterGatewayGroup
+func (mr *MockClientMockRecorder) UninstallMulticlusterGatewayGroup(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallMulticlusterGatewayGroup", reflect.TypeOf((*MockClient)(nil).UninstallMulticlusterGatewayGroup), arg0)
+}
+
 // UninstallMulticlusterPodFlows mocks base method
 func (m *MockClient) UninstallMulticlusterPodFlows(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -898,6 +1120,48 @@ func (mr *MockClientMockRecorder) UninstallNodeFlows(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallNodeFlows", reflect.TypeOf((*MockClient)(nil).UninstallNodeFlows), arg0)
 }
 
+// UninstallPodBandwidthMeters mocks base method
+func (m *MockClient) UninstallPodBandwidthMeters(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UninstallPodBandwidthMeters", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UninstallPodBandwidthMeters indicates an expected call of UninstallPodBandwidthMeters
+func (mr *MockClientMockRecorder) UninstallPodBandwidthMeters(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallPodBandwidthMeters", reflect.TypeOf((*MockClient)(nil).UninstallPodBandwidthMeters), arg0)
+}
+
+// UninstallPodDestinationSNATFlows mocks base method
+func (m *MockClient) UninstallPodDestinationSNATFlows(arg0 uint32, arg1 []net.IPNet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UninstallPodDestinationSNATFlows", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UninstallPodDestinationSNATFlows indicates an expected call of UninstallPodDestinationSNATFlows
+func (mr *MockClientMockRecorder) UninstallPodDestinationSNATFlows(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallPodDestinationSNATFlows", reflect.TypeOf((*MockClient)(nil).UninstallPodDestinationSNATFlows), arg0, arg1)
+}
+
+// UninstallPodEgressDefaultDenyFlows mocks base method
+func (m *MockClient) UninstallPodEgressDefaultDenyFlows(arg0 uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UninstallPodEgressDefaultDenyFlows", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UninstallPodEgressDefaultDenyFlows indicates an expected call of UninstallPodEgressDefaultDenyFlows
+func (mr *MockClientMockRecorder) UninstallPodEgressDefaultDenyFlows(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallPodEgressDefaultDenyFlows", reflect.TypeOf((*MockClient)(nil).UninstallPodEgressDefaultDenyFlows), arg0)
+}
+
 // UninstallPodFlows mocks base method
 func (m *MockClient) UninstallPodFlows(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -912,6 +1176,20 @@ func (mr *MockClientMockRecorder) UninstallPodFlows(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallPodFlows", reflect.TypeOf((*MockClient)(nil).UninstallPodFlows), arg0)
 }
 
+// UninstallPodNeighborSpoofGuardFlows mocks base method
+func (m *MockClient) UninstallPodNeighborSpoofGuardFlows(arg0 uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UninstallPodNeighborSpoofGuardFlows", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UninstallPodNeighborSpoofGuardFlows indicates an expected call of UninstallPodNeighborSpoofGuardFlows
+func (mr *MockClientMockRecorder) UninstallPodNeighborSpoofGuardFlows(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallPodNeighborSpoofGuardFlows", reflect.TypeOf((*MockClient)(nil).UninstallPodNeighborSpoofGuardFlows), arg0)
+}
+
 // UninstallPodSNATFlows mocks base method
 func (m *MockClient) UninstallPodSNATFlows(arg0 uint32) error {
 	m.ctrl.T.Helper()
@@ -1025,6 +1303,20 @@ func (mr *MockClientMockRecorder) UninstallTrafficControlReturnPortFlow(arg0 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallTrafficControlReturnPortFlow", reflect.TypeOf((*MockClient)(nil).UninstallTrafficControlReturnPortFlow), arg0)
 }
 
+// UninstallTrafficMirrorFlows mocks base method
+func (m *MockClient) UninstallTrafficMirrorFlows(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UninstallTrafficMirrorFlows", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UninstallTrafficMirrorFlows indicates an expected call of UninstallTrafficMirrorFlows
+func (mr *MockClientMockRecorder) UninstallTrafficMirrorFlows(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallTrafficMirrorFlows", reflect.TypeOf((*MockClient)(nil).UninstallTrafficMirrorFlows), arg0)
+}
+
 // UninstallVMUplinkFlows mocks base method
 func (m *MockClient) UninstallVMUplinkFlows(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -1039,6 +1331,20 @@ func (mr *MockClientMockRecorder) UninstallVMUplinkFlows(arg0 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UninstallVMUplinkFlows", reflect.TypeOf((*MockClient)(nil).UninstallVMUplinkFlows), arg0)
 }
 
+// UpdateNodePortAddresses mocks base method
+func (m *MockClient) UpdateNodePortAddresses(arg0, arg1 []net.IP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNodePortAddresses", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNodePortAddresses indicates an expected call of UpdateNodePortAddresses
+func (mr *MockClientMockRecorder) UpdateNodePortAddresses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodePortAddresses", reflect.TypeOf((*MockClient)(nil).UpdateNodePortAddresses), arg0, arg1)
+}
+
 // MockOFEntryOperations is a mock of OFEntryOperations interface
 type MockOFEntryOperations struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,115 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openflow
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"antrea.io/libOpenflow/protocol"
+	"antrea.io/ofnet/ofctrl"
+
+	"antrea.io/antrea/pkg/agent/config"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
+)
+
+// TTLExpiredPacketInHandler replies to the routed packets whose TTL (or hop limit) expires in L3DecTTLTable with ICMP
+// (or ICMPv6) Time Exceeded messages sent from the Antrea gateway IP, as a router does.
+type TTLExpiredPacketInHandler struct {
+	ofClient   Client
+	nodeConfig *config.NodeConfig
+}
+
+func NewTTLExpiredPacketInHandler(ofClient Client, nodeConfig *config.NodeConfig) *TTLExpiredPacketInHandler {
+	return &TTLExpiredPacketInHandler{
+		ofClient:   ofClient,
+		nodeConfig: nodeConfig,
+	}
+}
+
+func (h *TTLExpiredPacketInHandler) HandlePacketIn(pktIn *ofctrl.PacketIn) error {
+	ethernetPkt, err := GetEthernetPacket(pktIn)
+	if err != nil {
+		return err
+	}
+	var (
+		srcIP, gatewayIP   string
+		isIPv6             bool
+		icmpType, icmpCode uint8
+		ipHdrLen           uint16
+	)
+	// Like a router, never reply to ICMP messages other than echo requests and replies, so that no ICMP error message
+	// is sent about an ICMP error message.
+	switch ipPkt := ethernetPkt.Data.(type) {
+	case *protocol.IPv4:
+		if ipPkt.Protocol == protocol.Type_ICMP {
+			icmpPkt, ok := ipPkt.Data.(*protocol.ICMP)
+			if !ok || (icmpPkt.Type != icmpEchoRequestType && icmpPkt.Type != icmpEchoReplyType) {
+				return nil
+			}
+		}
+		srcIP = ipPkt.NWSrc.String()
+		gatewayIP = h.nodeConfig.GatewayConfig.IPv4.String()
+		icmpType, icmpCode = icmpTimeExceededType, icmpTTLExceededInTransit
+		ipHdrLen = ipv4HdrLen
+	case *protocol.IPv6:
+		if ipPkt.NextHeader == protocol.Type_IPv6ICMP {
+			// Other ICMPv6 messages than echo requests and replies are decoded into different types.
+			if _, ok := ipPkt.Data.(*protocol.ICMPv6EchoReqRpl); !ok {
+				return nil
+			}
+		}
+		srcIP = ipPkt.NWSrc.String()
+		gatewayIP = h.nodeConfig.GatewayConfig.IPv6.String()
+		isIPv6 = true
+		icmpType, icmpCode = icmpv6TimeExceededType, icmpv6HopLimitExceeded
+		ipHdrLen = ipv6HdrLen
+	default:
+		return fmt.Errorf("unsupported packet with TTL expired")
+	}
+
+	// The ICMP message includes the IP header and the first 8 bytes of the payload of the expired packet.
+	ipData, err := ethernetPkt.Data.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if len(ipData) > int(ipHdrLen+8) {
+		ipData = ipData[:ipHdrLen+8]
+	}
+	icmpData := make([]byte, int(icmpUnusedHdrLen)+len(ipData))
+	binary.BigEndian.PutUint32(icmpData[:icmpUnusedHdrLen], 0)
+	copy(icmpData[icmpUnusedHdrLen:], ipData)
+
+	// The message is sent as if it came from the Antrea gateway, and is routed to the source of the expired packet,
+	// which may be a local Pod or a remote one, by L3ForwardingTable.
+	l3FwdTableID := L3ForwardingTable.GetID()
+	mutatePacketOut := func(packetOutBuilder binding.PacketOutBuilder) binding.PacketOutBuilder {
+		return packetOutBuilder.AddLoadRegMark(FromGatewayRegMark).
+			AddLoadRegMark(RewriteMACRegMark).
+			AddResubmitAction(nil, &l3FwdTableID)
+	}
+	return h.ofClient.SendICMPPacketOut(
+		h.nodeConfig.GatewayConfig.MAC.String(),
+		ethernetPkt.HWSrc.String(),
+		gatewayIP,
+		srcIP,
+		h.nodeConfig.GatewayConfig.OFPort,
+		0,
+		isIPv6,
+		icmpType,
+		icmpCode,
+		icmpData,
+		mutatePacketOut)
+}
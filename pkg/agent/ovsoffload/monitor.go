@@ -0,0 +1,214 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ovsoffload monitors the hardware offload status of the OVS datapath flows when OVS hardware offload is
+// enabled. Datapath flows are the result of the OpenFlow pipeline lookups and cannot be mapped back to the OpenFlow
+// tables, so they are categorized by the type of their input port instead.
+package ovsoffload
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/metrics"
+	"antrea.io/antrea/pkg/ovs/ovsctl"
+	"antrea.io/antrea/pkg/querier"
+)
+
+const (
+	CategoryPod     = "pod"
+	CategoryGateway = "gateway"
+	CategoryTunnel  = "tunnel"
+	CategoryUplink  = "uplink"
+	CategoryOther   = "other"
+
+	DefaultSampleInterval = 60 * time.Second
+
+	reasonOffloadFallback = "FlowOffloadFallback"
+)
+
+// categories lists the flow categories in the order in which they are reported.
+var categories = []string{CategoryPod, CategoryGateway, CategoryTunnel, CategoryUplink, CategoryOther}
+
+// criticalCategories are the categories of the interfaces which are expected to be offloaded, i.e. the VF
+// representors of the Pods and the ports connected to the physical network. The gateway interface is an OVS internal
+// port, whose flows are not offloaded.
+var criticalCategories = sets.New[string](CategoryPod, CategoryTunnel, CategoryUplink)
+
+// Monitor samples the OVS datapath flows periodically, and counts the offloaded and non-offloaded flows of each
+// category. It reports the counts as metrics, and an Event when the flows of an interface of a critical category
+// start falling back to software.
+type Monitor struct {
+	nodeName       string
+	ovsCtlClient   ovsctl.OVSCtlClient
+	ifaceStore     interfacestore.InterfaceStore
+	recorder       record.EventRecorder
+	sampleInterval time.Duration
+	enableMetrics  bool
+
+	mutex  sync.RWMutex
+	status []querier.OVSOffloadStatus
+
+	// fallbackInterfaces stores the names of the interfaces of the critical categories which had non-offloaded
+	// flows in the last sample. It is only accessed by the sampling goroutine, so no lock is needed.
+	fallbackInterfaces sets.Set[string]
+}
+
+// NewMonitor creates a Monitor. recorder is used to report the interfaces whose flows fall back to software as Events
+// on the Pods or on the Node.
+func NewMonitor(nodeName string,
+	ovsCtlClient ovsctl.OVSCtlClient,
+	ifaceStore interfacestore.InterfaceStore,
+	recorder record.EventRecorder,
+	sampleInterval time.Duration,
+	enableMetrics bool) *Monitor {
+	if sampleInterval <= 0 {
+		sampleInterval = DefaultSampleInterval
+	}
+	return &Monitor{
+		nodeName:           nodeName,
+		ovsCtlClient:       ovsCtlClient,
+		ifaceStore:         ifaceStore,
+		recorder:           recorder,
+		sampleInterval:     sampleInterval,
+		enableMetrics:      enableMetrics,
+		fallbackInterfaces: sets.New[string](),
+	}
+}
+
+// Run samples the OVS datapath flows periodically until stopCh is closed.
+func (m *Monitor) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Starting OVS hardware offload monitor", "sampleInterval", m.sampleInterval)
+	wait.Until(m.sample, m.sampleInterval, stopCh)
+}
+
+// GetOVSOffloadStatus returns the offload status of each flow category in the last sample.
+func (m *Monitor) GetOVSOffloadStatus() []querier.OVSOffloadStatus {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.status
+}
+
+// getInPort returns the name of the input port of a datapath flow dumped with port names, or an empty string if the
+// flow doesn't match the input port.
+func getInPort(flow string) string {
+	const prefix = "in_port("
+	start := strings.Index(flow, prefix)
+	if start < 0 {
+		return ""
+	}
+	start += len(prefix)
+	end := strings.IndexByte(flow[start:], ')')
+	if end < 0 {
+		return ""
+	}
+	return flow[start : start+end]
+}
+
+// categorize returns the category of a flow according to its input port, and the interface of the input port if it
+// is known by the Agent.
+func (m *Monitor) categorize(flow string) (string, *interfacestore.InterfaceConfig) {
+	inPort := getInPort(flow)
+	if inPort == "" {
+		return CategoryOther, nil
+	}
+	iface, ok := m.ifaceStore.GetInterfaceByName(inPort)
+	if !ok {
+		return CategoryOther, nil
+	}
+	switch iface.Type {
+	case interfacestore.ContainerInterface:
+		return CategoryPod, iface
+	case interfacestore.GatewayInterface:
+		return CategoryGateway, iface
+	case interfacestore.TunnelInterface, interfacestore.IPSecTunnelInterface, interfacestore.NodeTunnelInterface:
+		return CategoryTunnel, iface
+	case interfacestore.UplinkInterface:
+		return CategoryUplink, iface
+	}
+	return CategoryOther, iface
+}
+
+func (m *Monitor) sample() {
+	offloadedFlows, err := m.ovsCtlClient.DumpDatapathFlows(true)
+	if err != nil {
+		klog.ErrorS(err, "Failed to dump offloaded OVS datapath flows")
+		return
+	}
+	nonOffloadedFlows, err := m.ovsCtlClient.DumpDatapathFlows(false)
+	if err != nil {
+		klog.ErrorS(err, "Failed to dump non-offloaded OVS datapath flows")
+		return
+	}
+
+	statusMap := make(map[string]*querier.OVSOffloadStatus, len(categories))
+	for _, category := range categories {
+		statusMap[category] = &querier.OVSOffloadStatus{Category: category}
+	}
+	for _, flow := range offloadedFlows {
+		category, _ := m.categorize(flow)
+		statusMap[category].OffloadedFlows++
+	}
+	fallbackInterfaces := map[string]*interfacestore.InterfaceConfig{}
+	for _, flow := range nonOffloadedFlows {
+		category, iface := m.categorize(flow)
+		statusMap[category].NonOffloadedFlows++
+		if criticalCategories.Has(category) {
+			fallbackInterfaces[iface.InterfaceName] = iface
+		}
+	}
+
+	status := make([]querier.OVSOffloadStatus, 0, len(categories))
+	for _, category := range categories {
+		s := statusMap[category]
+		status = append(status, *s)
+		if m.enableMetrics {
+			metrics.OVSDatapathFlowCount.WithLabelValues(category, "true").Set(float64(s.OffloadedFlows))
+			metrics.OVSDatapathFlowCount.WithLabelValues(category, "false").Set(float64(s.NonOffloadedFlows))
+		}
+	}
+	m.mutex.Lock()
+	m.status = status
+	m.mutex.Unlock()
+
+	// Only report the interfaces which start falling back to software, to avoid generating an Event per sample.
+	for name, iface := range fallbackInterfaces {
+		if !m.fallbackInterfaces.Has(name) {
+			m.reportFallback(iface)
+		}
+	}
+	m.fallbackInterfaces = sets.KeySet(fallbackInterfaces)
+}
+
+func (m *Monitor) reportFallback(iface *interfacestore.InterfaceConfig) {
+	klog.InfoS("OVS datapath flows are not offloaded to hardware", "interface", iface.InterfaceName)
+	if iface.Type == interfacestore.ContainerInterface {
+		podRef := &corev1.ObjectReference{Kind: "Pod", Name: iface.PodName, Namespace: iface.PodNamespace}
+		m.recorder.Eventf(podRef, corev1.EventTypeWarning, reasonOffloadFallback,
+			"OVS datapath flows of interface %s are processed in software instead of being offloaded to hardware", iface.InterfaceName)
+		return
+	}
+	nodeRef := &corev1.ObjectReference{Kind: "Node", Name: m.nodeName, UID: types.UID(m.nodeName)}
+	m.recorder.Eventf(nodeRef, corev1.EventTypeWarning, reasonOffloadFallback,
+		"OVS datapath flows of interface %s are processed in software instead of being offloaded to hardware", iface.InterfaceName)
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovsoffload
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/record"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	ovsctltest "antrea.io/antrea/pkg/ovs/ovsctl/testing"
+	"antrea.io/antrea/pkg/querier"
+)
+
+const (
+	podFlow     = "recirc_id(0),in_port(pod1-abc),eth(src=16:fd:c6:0b:60:52),eth_type(0x0800),ipv4(src=10.10.0.2,frag=no), packets:10, bytes:1000, used:0.550s, actions:ct(zone=65520),recirc(0x18)"
+	gatewayFlow = "recirc_id(0),in_port(antrea-gw0),eth_type(0x0800),ipv4(frag=no), packets:10, bytes:1000, used:0.550s, actions:pod1-abc"
+	uplinkFlow  = "recirc_id(0),in_port(eth0),eth_type(0x0800),ipv4(frag=no), packets:10, bytes:1000, used:0.550s, actions:pod1-abc"
+	unknownFlow = "recirc_id(0),in_port(unknown),eth_type(0x0806), packets:1, bytes:42, used:never, actions:drop"
+)
+
+func newFakeMonitor(t *testing.T) (*Monitor, *ovsctltest.MockOVSCtlClient, *record.FakeRecorder) {
+	ctrl := gomock.NewController(t)
+	ovsCtlClient := ovsctltest.NewMockOVSCtlClient(ctrl)
+	ifaceStore := interfacestore.NewInterfaceStore()
+	ifaceStore.AddInterface(interfacestore.NewContainerInterface("pod1-abc", "container1", "pod1", "ns1", nil, nil, 0))
+	ifaceStore.AddInterface(interfacestore.NewGatewayInterface("antrea-gw0", nil))
+	ifaceStore.AddInterface(interfacestore.NewUplinkInterface("eth0"))
+	recorder := record.NewFakeRecorder(10)
+	return NewMonitor("node1", ovsCtlClient, ifaceStore, recorder, 0, false), ovsCtlClient, recorder
+}
+
+func TestGetInPort(t *testing.T) {
+	assert.Equal(t, "pod1-abc", getInPort(podFlow))
+	assert.Equal(t, "", getInPort("recirc_id(0),eth_type(0x0800), packets:0, bytes:0, used:never, actions:drop"))
+	assert.Equal(t, "", getInPort("recirc_id(0),in_port(eth0"))
+}
+
+func TestSample(t *testing.T) {
+	m, ovsCtlClient, recorder := newFakeMonitor(t)
+
+	ovsCtlClient.EXPECT().DumpDatapathFlows(true).Return([]string{podFlow, uplinkFlow, uplinkFlow}, nil)
+	ovsCtlClient.EXPECT().DumpDatapathFlows(false).Return([]string{gatewayFlow, unknownFlow}, nil)
+	m.sample()
+	assert.Equal(t, []querier.OVSOffloadStatus{
+		{Category: CategoryPod, OffloadedFlows: 1},
+		{Category: CategoryGateway, NonOffloadedFlows: 1},
+		{Category: CategoryTunnel},
+		{Category: CategoryUplink, OffloadedFlows: 2},
+		{Category: CategoryOther, NonOffloadedFlows: 1},
+	}, m.GetOVSOffloadStatus())
+	// The flows of the gateway and of unknown ports are not expected to be offloaded.
+	assert.Empty(t, recorder.Events)
+
+	// The flows of the Pod and the uplink fall back to software.
+	ovsCtlClient.EXPECT().DumpDatapathFlows(true).Return(nil, nil)
+	ovsCtlClient.EXPECT().DumpDatapathFlows(false).Return([]string{podFlow, uplinkFlow}, nil)
+	m.sample()
+	assert.Len(t, recorder.Events, 2)
+	for len(recorder.Events) > 0 {
+		assert.Contains(t, <-recorder.Events, "Warning FlowOffloadFallback")
+	}
+
+	// No new Event is reported while the flows are still processed in software.
+	ovsCtlClient.EXPECT().DumpDatapathFlows(true).Return(nil, nil)
+	ovsCtlClient.EXPECT().DumpDatapathFlows(false).Return([]string{podFlow}, nil)
+	m.sample()
+	assert.Empty(t, recorder.Events)
+
+	// A new Event is reported when the flows fall back to software again after being offloaded.
+	ovsCtlClient.EXPECT().DumpDatapathFlows(true).Return([]string{uplinkFlow}, nil)
+	ovsCtlClient.EXPECT().DumpDatapathFlows(false).Return(nil, nil)
+	m.sample()
+	ovsCtlClient.EXPECT().DumpDatapathFlows(true).Return(nil, nil)
+	ovsCtlClient.EXPECT().DumpDatapathFlows(false).Return([]string{uplinkFlow}, nil)
+	m.sample()
+	assert.Equal(t, "Warning FlowOffloadFallback OVS datapath flows of interface eth0 are processed in software instead of being offloaded to hardware", <-recorder.Events)
+}
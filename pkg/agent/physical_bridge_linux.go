@@ -0,0 +1,153 @@
+//go:build linux
+// +build linux
+
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/util"
+	"antrea.io/antrea/pkg/ovs/ovsctl"
+)
+
+// preparePhysicalBridge creates the OVS physical bridge, and connects the OVS bridge to it with a pair of patch ports.
+// The uplink interface is connected to the OVS physical bridge by connectUplinkToPhysicalBridge, after the OpenFlow
+// pipeline of the OVS bridge has been initialized.
+func (i *Initializer) preparePhysicalBridge() error {
+	brName := i.physicalBridgeClient.GetBridgeName()
+	klog.InfoS("Preparing OVS physical bridge", "bridge", brName)
+	if err := i.physicalBridgeClient.Create(); err != nil {
+		return fmt.Errorf("failed to create OVS physical bridge %s: %w", brName, err)
+	}
+	i.nodeConfig.OVSPhysicalBridge = brName
+
+	if err := i.initUplinkNetConfig(); err != nil {
+		return err
+	}
+	uplinkNetConfig := i.nodeConfig.UplinkNetConfig
+	// If the uplink port exists, the uplink interface has been connected to the OVS physical bridge and the interface
+	// with the Node IP is the host interface. This happens when antrea-agent had a hard restart (e.g. SIGKILL).
+	bridgedUplinkName := util.GenerateUplinkInterfaceName(uplinkNetConfig.Name)
+	if uplinkOFPort, err := i.physicalBridgeClient.GetOFPort(bridgedUplinkName, false); err == nil {
+		adapter, err := net.InterfaceByName(bridgedUplinkName)
+		if err != nil {
+			return fmt.Errorf("cannot find uplink interface %s: err=%w", bridgedUplinkName, err)
+		}
+		uplinkNetConfig.Index = adapter.Index
+		uplinkNetConfig.OFPort = uint32(uplinkOFPort)
+		klog.InfoS("Found uplink", "Name", uplinkNetConfig.Name, "Index", uplinkNetConfig.Index, "OFPort", uplinkNetConfig.OFPort)
+	}
+
+	// The ofport of a patch port is only valid when its peer exists, so both patch ports are created before getting it.
+	if _, err := i.ovsBridgeClient.GetOFPort(config.PhysicalBridgePatchPortName, false); err != nil {
+		externalIDs := map[string]interface{}{
+			interfacestore.AntreaInterfaceTypeKey: interfacestore.AntreaPatch,
+		}
+		if _, err := i.ovsBridgeClient.CreatePatchPort(config.PhysicalBridgePatchPortName, config.IntegrationBridgePatchPortName, 0, externalIDs); err != nil {
+			return fmt.Errorf("failed to create patch port %s: %w", config.PhysicalBridgePatchPortName, err)
+		}
+	}
+	if _, err := i.physicalBridgeClient.GetOFPort(config.IntegrationBridgePatchPortName, false); err != nil {
+		if _, err := i.physicalBridgeClient.CreatePatchPort(config.IntegrationBridgePatchPortName, config.PhysicalBridgePatchPortName, 0, nil); err != nil {
+			return fmt.Errorf("failed to create patch port %s on OVS physical bridge: %w", config.IntegrationBridgePatchPortName, err)
+		}
+	}
+	patchOFPort, err := i.ovsBridgeClient.GetOFPort(config.PhysicalBridgePatchPortName, true)
+	if err != nil {
+		return fmt.Errorf("failed to get ofport of patch port %s: %w", config.PhysicalBridgePatchPortName, err)
+	}
+	i.nodeConfig.PhysicalBridgePatchOFPort = uint32(patchOFPort)
+	klog.InfoS("Connected OVS bridge to OVS physical bridge", "bridge", i.ovsBridge, "physicalBridge", brName, "patchOFPort", patchOFPort)
+	return nil
+}
+
+// connectUplinkToPhysicalBridge connects the uplink interface to the OVS physical bridge, moves the IP addresses and
+// routes of the uplink interface to the host interface on the OVS physical bridge, and installs the flows of the OVS
+// physical bridge.
+func (i *Initializer) connectUplinkToPhysicalBridge() error {
+	brName := i.physicalBridgeClient.GetBridgeName()
+	klog.InfoS("Bridging uplink to OVS physical bridge", "bridge", brName)
+	uplinkNetConfig := i.nodeConfig.UplinkNetConfig
+	bridgedUplinkName := util.GenerateUplinkInterfaceName(uplinkNetConfig.Name)
+
+	uplinkOFPort, err := i.physicalBridgeClient.GetOFPort(bridgedUplinkName, false)
+	if err == nil {
+		klog.InfoS("Uplink already exists, skip the configuration", "uplink", bridgedUplinkName, "port", uplinkOFPort)
+	} else {
+		var bridgeErr error
+		if _, uplinkOFPort, bridgeErr = i.bridgeUplink(i.physicalBridgeClient, 0, 0); bridgeErr != nil {
+			return bridgeErr
+		}
+	}
+	uplinkNetConfig.OFPort = uint32(uplinkOFPort)
+	hostOFPort, err := i.physicalBridgeClient.GetOFPort(uplinkNetConfig.Name, false)
+	if err != nil {
+		return fmt.Errorf("failed to get ofport of host interface %s: %w", uplinkNetConfig.Name, err)
+	}
+	patchOFPort, err := i.physicalBridgeClient.GetOFPort(config.IntegrationBridgePatchPortName, false)
+	if err != nil {
+		return fmt.Errorf("failed to get ofport of patch port %s: %w", config.IntegrationBridgePatchPortName, err)
+	}
+	return i.installPhysicalBridgeFlows(physicalBridgeFlows(uplinkOFPort, hostOFPort, patchOFPort, uplinkNetConfig.MAC))
+}
+
+// physicalBridgeFlows returns the flows of the OVS physical bridge:
+//   - the packets received by the uplink interface and sent to the Node are forwarded to the host interface, and the
+//     other packets are forwarded to the OVS bridge through the patch port. Broadcast and multicast packets are
+//     forwarded to both.
+//   - the packets sent from the host interface or the patch port are forwarded to the uplink interface.
+//   - the packets received from the other ports are dropped.
+func physicalBridgeFlows(uplinkOFPort, hostOFPort, patchOFPort int32, uplinkMAC net.HardwareAddr) []string {
+	return []string{
+		fmt.Sprintf("priority=200,in_port=%d,dl_dst=%s,actions=output:%d", uplinkOFPort, uplinkMAC, hostOFPort),
+		fmt.Sprintf("priority=200,in_port=%d,dl_dst=01:00:00:00:00:00/01:00:00:00:00:00,actions=output:%d,output:%d", uplinkOFPort, hostOFPort, patchOFPort),
+		fmt.Sprintf("priority=190,in_port=%d,actions=output:%d", uplinkOFPort, patchOFPort),
+		fmt.Sprintf("priority=200,in_port=%d,actions=output:%d", hostOFPort, uplinkOFPort),
+		fmt.Sprintf("priority=200,in_port=%d,actions=output:%d", patchOFPort, uplinkOFPort),
+		"priority=0,actions=drop",
+	}
+}
+
+// installPhysicalBridgeFlows replaces all the flows of the OVS physical bridge with the provided flows. The flows which
+// are not changed are kept during the replacement, so that the traffic is not disrupted when antrea-agent restarts.
+func (i *Initializer) installPhysicalBridgeFlows(flows []string) error {
+	brName := i.physicalBridgeClient.GetBridgeName()
+	flowsFile, err := os.CreateTemp("", brName+"-flows-")
+	if err != nil {
+		return fmt.Errorf("failed to create flows file: %w", err)
+	}
+	defer os.Remove(flowsFile.Name())
+	if _, err := flowsFile.WriteString(strings.Join(flows, "\n") + "\n"); err != nil {
+		flowsFile.Close()
+		return fmt.Errorf("failed to write flows file: %w", err)
+	}
+	if err := flowsFile.Close(); err != nil {
+		return fmt.Errorf("failed to write flows file: %w", err)
+	}
+	if _, err := ovsctl.NewClient(brName).RunOfctlCmd("replace-flows", flowsFile.Name()); err != nil {
+		return fmt.Errorf("failed to install flows on OVS physical bridge %s: %w", brName, err)
+	}
+	klog.InfoS("Installed flows on OVS physical bridge", "bridge", brName, "flows", len(flows))
+	return nil
+}
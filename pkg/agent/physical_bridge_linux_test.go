@@ -0,0 +1,35 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhysicalBridgeFlows(t *testing.T) {
+	uplinkMAC, _ := net.ParseMAC("aa:bb:cc:dd:ee:ff")
+	expectedFlows := []string{
+		"priority=200,in_port=1,dl_dst=aa:bb:cc:dd:ee:ff,actions=output:2",
+		"priority=200,in_port=1,dl_dst=01:00:00:00:00:00/01:00:00:00:00:00,actions=output:2,output:3",
+		"priority=190,in_port=1,actions=output:3",
+		"priority=200,in_port=2,actions=output:1",
+		"priority=200,in_port=3,actions=output:1",
+		"priority=0,actions=drop",
+	}
+	assert.Equal(t, expectedFlows, physicalBridgeFlows(1, 2, 3, uplinkMAC))
+}
@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	discovery "k8s.io/api/discovery/v1"
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	"antrea.io/antrea/pkg/agent/proxy/types"
@@ -52,16 +53,25 @@ type endpointsChangesTracker struct {
 	// changes contains endpoints changes since the last checkoutChanges call.
 	changes    map[apimachinerytypes.NamespacedName]*endpointsChange
 	sliceCache *EndpointSliceCache
+	// endpoints contains the current Endpoints of the Services, used to compute their EndpointsMap again when
+	// publishNotReadyAddresses of a Service changes. It is only used when EndpointSlice is not enabled.
+	endpoints map[apimachinerytypes.NamespacedName]*corev1.Endpoints
+	// publishNotReadyServices contains the Services with publishNotReadyAddresses set, whose not ready addresses
+	// are considered as ready. It is only used when EndpointSlice is not enabled.
+	publishNotReadyServices sets.Set[apimachinerytypes.NamespacedName]
 }
 
-func newEndpointsChangesTracker(hostname string, enableEndpointSlice bool, isIPv6 bool) *endpointsChangesTracker {
+func newEndpointsChangesTracker(hostname string, enableEndpointSlice bool, isIPv6 bool, endpointReadinessGate string) *endpointsChangesTracker {
 	tracker := &endpointsChangesTracker{
 		hostname: hostname,
 		changes:  map[apimachinerytypes.NamespacedName]*endpointsChange{},
 	}
 
 	if enableEndpointSlice {
-		tracker.sliceCache = NewEndpointSliceCache(hostname, isIPv6)
+		tracker.sliceCache = NewEndpointSliceCache(hostname, isIPv6, endpointReadinessGate)
+	} else {
+		tracker.endpoints = map[apimachinerytypes.NamespacedName]*corev1.Endpoints{}
+		tracker.publishNotReadyServices = sets.New[apimachinerytypes.NamespacedName]()
 	}
 	return tracker
 }
@@ -105,10 +115,59 @@ func (t *endpointsChangesTracker) OnEndpointUpdate(previous, current *corev1.End
 	if reflect.DeepEqual(change.previous, change.current) {
 		delete(t.changes, namespacedName)
 	}
+	if current != nil {
+		t.endpoints[namespacedName] = current
+	} else {
+		delete(t.endpoints, namespacedName)
+	}
 
 	return len(t.changes) > 0
 }
 
+// OnServiceUpdate records whether the given Service publishes the addresses of its not ready Endpoints, based on the
+// <previous, current> Service pair. The Endpoints of such a Service are installed even if they are not ready. It
+// returns true if the Endpoints of the Service must be updated, otherwise it returns false.
+func (t *endpointsChangesTracker) OnServiceUpdate(previous, current *corev1.Service) bool {
+	service := current
+	if service == nil {
+		service = previous
+	}
+	if service == nil {
+		return false
+	}
+	namespacedName := apimachinerytypes.NamespacedName{Namespace: service.Namespace, Name: service.Name}
+	publishNotReadyAddresses := current != nil && current.Spec.PublishNotReadyAddresses
+
+	t.Lock()
+	defer t.Unlock()
+
+	if t.sliceCache != nil {
+		return t.sliceCache.updatePublishNotReadyAddresses(namespacedName, publishNotReadyAddresses)
+	}
+
+	if t.publishNotReadyServices.Has(namespacedName) == publishNotReadyAddresses {
+		return false
+	}
+	endpoints := t.endpoints[namespacedName]
+	change, exists := t.changes[namespacedName]
+	if !exists {
+		change = &endpointsChange{}
+		change.previous = t.endpointsToEndpointsMap(endpoints)
+		t.changes[namespacedName] = change
+	}
+	if publishNotReadyAddresses {
+		t.publishNotReadyServices.Insert(namespacedName)
+	} else {
+		t.publishNotReadyServices.Delete(namespacedName)
+	}
+	change.current = t.endpointsToEndpointsMap(endpoints)
+	if reflect.DeepEqual(change.previous, change.current) {
+		delete(t.changes, namespacedName)
+		return false
+	}
+	return true
+}
+
 // OnEndpointSliceUpdate updates the given service's endpoints change map based on the <previous, current> endpoints pair.
 // It returns true if items changed, otherwise it returns false. Will add/update/delete items of endpointsChange Map.
 // If removeSlice is true, slice will be removed, otherwise it will be added or updated.
@@ -174,6 +233,8 @@ func (t *endpointsChangesTracker) endpointsToEndpointsMap(endpoints *corev1.Endp
 		return nil
 	}
 	endpointsMap := make(types.EndpointsMap)
+	namespacedName := apimachinerytypes.NamespacedName{Namespace: endpoints.Namespace, Name: endpoints.Name}
+	publishNotReadyAddresses := t.publishNotReadyServices.Has(namespacedName)
 	// We need to build a map of portname -> all ip:ports for that
 	// portname.  Explode Endpoints.Subsets[*] into this structure.
 	for i := range endpoints.Subsets {
@@ -185,15 +246,20 @@ func (t *endpointsChangesTracker) endpointsToEndpointsMap(endpoints *corev1.Endp
 				continue
 			}
 			svcPortName := k8sproxy.ServicePortName{
-				NamespacedName: apimachinerytypes.NamespacedName{Namespace: endpoints.Namespace, Name: endpoints.Name},
+				NamespacedName: namespacedName,
 				Protocol:       port.Protocol,
 				Port:           port.Name,
 			}
 			if _, ok := endpointsMap[svcPortName]; !ok {
 				endpointsMap[svcPortName] = map[string]k8sproxy.Endpoint{}
 			}
-			for i := range ss.Addresses {
-				addr := &ss.Addresses[i]
+			addresses := ss.Addresses
+			if publishNotReadyAddresses {
+				// The not ready addresses of a Service with publishNotReadyAddresses are installed as ready ones.
+				addresses = append(append([]corev1.EndpointAddress{}, ss.Addresses...), ss.NotReadyAddresses...)
+			}
+			for i := range addresses {
+				addr := &addresses[i]
 				if addr.IP == "" {
 					klog.Warningf("Ignoring invalid endpoint port %s with empty host", port.Name)
 					continue
@@ -0,0 +1,164 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+
+	"antrea.io/antrea/pkg/agent/proxy/types"
+	agenttypes "antrea.io/antrea/pkg/agent/types"
+)
+
+func readyEndpointIPs(em types.EndpointsMap) []string {
+	var ips []string
+	for _, ep := range em[svcPortName] {
+		if ep.IsReady() {
+			ips = append(ips, ep.IP())
+		}
+	}
+	return ips
+}
+
+func TestEndpointsChangesTrackerPublishNotReadyAddresses(t *testing.T) {
+	svc := makeTestService(svcPortName.Namespace, svcPortName.Name, func(svc *corev1.Service) {})
+	publishNotReadySvc := makeTestService(svcPortName.Namespace, svcPortName.Name, func(svc *corev1.Service) {
+		svc.Spec.PublishNotReadyAddresses = true
+	})
+
+	for _, tt := range []struct {
+		name                 string
+		endpointSliceEnabled bool
+	}{
+		{name: "EndpointSlice enabled", endpointSliceEnabled: true},
+		{name: "EndpointSlice disabled", endpointSliceEnabled: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newEndpointsChangesTracker(hostname, tt.endpointSliceEnabled, false, "")
+			if tt.endpointSliceEnabled {
+				readyEndpoint, port := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IPv4, int32(svcPort), corev1.ProtocolTCP, true)
+				notReadyEndpoint, _ := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep2IPv4, int32(svcPort), corev1.ProtocolTCP, true)
+				notReady := false
+				notReadyEndpoint.Conditions.Ready = &notReady
+				eps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*readyEndpoint, *notReadyEndpoint}, []discovery.EndpointPort{*port}, false)
+				assert.True(t, tracker.OnEndpointSliceUpdate(eps, false))
+			} else {
+				eps := makeTestEndpoints(&svcPortName, []corev1.EndpointSubset{{
+					Addresses:         []corev1.EndpointAddress{{IP: ep1IPv4.String(), NodeName: &hostname}},
+					NotReadyAddresses: []corev1.EndpointAddress{{IP: ep2IPv4.String(), NodeName: &hostname}},
+					Ports:             []corev1.EndpointPort{{Name: svcPortName.Port, Port: int32(svcPort), Protocol: corev1.ProtocolTCP}},
+				}})
+				assert.True(t, tracker.OnEndpointUpdate(nil, eps))
+			}
+			em := types.EndpointsMap{}
+			numLocalEndpoints := map[apimachinerytypes.NamespacedName]int{}
+			assert.False(t, tracker.OnServiceUpdate(nil, svc))
+			tracker.Update(em, numLocalEndpoints)
+			assert.ElementsMatch(t, []string{ep1IPv4.String()}, readyEndpointIPs(em))
+			assert.Equal(t, 1, numLocalEndpoints[svcPortName.NamespacedName])
+
+			assert.True(t, tracker.OnServiceUpdate(svc, publishNotReadySvc))
+			tracker.Update(em, numLocalEndpoints)
+			assert.ElementsMatch(t, []string{ep1IPv4.String(), ep2IPv4.String()}, readyEndpointIPs(em))
+			assert.Equal(t, 2, numLocalEndpoints[svcPortName.NamespacedName])
+
+			assert.False(t, tracker.OnServiceUpdate(publishNotReadySvc, publishNotReadySvc))
+
+			assert.True(t, tracker.OnServiceUpdate(publishNotReadySvc, nil))
+			tracker.Update(em, numLocalEndpoints)
+			assert.ElementsMatch(t, []string{ep1IPv4.String()}, readyEndpointIPs(em))
+			assert.Equal(t, 1, numLocalEndpoints[svcPortName.NamespacedName])
+		})
+	}
+}
+
+func TestEndpointsChangesTrackerEndpointWeights(t *testing.T) {
+	tracker := newEndpointsChangesTracker(hostname, true, false, "")
+	endpoint1, port := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IPv4, int32(svcPort), corev1.ProtocolTCP, true)
+	endpoint2, _ := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep2IPv4, int32(svcPort), corev1.ProtocolTCP, true)
+	eps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*endpoint1, *endpoint2}, []discovery.EndpointPort{*port}, false)
+	// The weight of endpoint2 is invalid and should be ignored.
+	eps.Annotations = map[string]string{
+		agenttypes.EndpointSliceEndpointWeightsAnnotationKey: fmt.Sprintf(`{"%s": 300, "%s": 0}`, ep1IPv4, ep2IPv4),
+	}
+	assert.True(t, tracker.OnEndpointSliceUpdate(eps, false))
+	em := types.EndpointsMap{}
+	tracker.Update(em, map[apimachinerytypes.NamespacedName]int{})
+	getWeights := func() map[string]uint16 {
+		weights := map[string]uint16{}
+		for _, ep := range em[svcPortName] {
+			weights[ep.IP()] = ep.GetWeight()
+		}
+		return weights
+	}
+	assert.Equal(t, map[string]uint16{ep1IPv4.String(): 300, ep2IPv4.String(): 0}, getWeights())
+
+	updatedEps := eps.DeepCopy()
+	updatedEps.Annotations[agenttypes.EndpointSliceEndpointWeightsAnnotationKey] = fmt.Sprintf(`{"%s": 50}`, ep2IPv4)
+	assert.True(t, tracker.OnEndpointSliceUpdate(updatedEps, false))
+	tracker.Update(em, map[apimachinerytypes.NamespacedName]int{})
+	assert.Equal(t, map[string]uint16{ep1IPv4.String(): 0, ep2IPv4.String(): 50}, getWeights())
+
+	// An invalid annotation is ignored.
+	invalidEps := eps.DeepCopy()
+	invalidEps.Annotations[agenttypes.EndpointSliceEndpointWeightsAnnotationKey] = "invalid"
+	assert.True(t, tracker.OnEndpointSliceUpdate(invalidEps, false))
+	tracker.Update(em, map[apimachinerytypes.NamespacedName]int{})
+	assert.Equal(t, map[string]uint16{ep1IPv4.String(): 0, ep2IPv4.String(): 0}, getWeights())
+}
+
+func TestEndpointsChangesTrackerEndpointReadinessGate(t *testing.T) {
+	readinessGate := "example.com/drained"
+	tracker := newEndpointsChangesTracker(hostname, true, false, readinessGate)
+	endpoint1, port := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IPv4, int32(svcPort), corev1.ProtocolTCP, true)
+	endpoint2, _ := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep2IPv4, int32(svcPort), corev1.ProtocolTCP, true)
+	eps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*endpoint1, *endpoint2}, []discovery.EndpointPort{*port}, false)
+	eps.Annotations = map[string]string{
+		readinessGate: fmt.Sprintf(`{"%s": true, "%s": false}`, ep1IPv4, ep2IPv4),
+	}
+	assert.True(t, tracker.OnEndpointSliceUpdate(eps, false))
+	em := types.EndpointsMap{}
+	tracker.Update(em, map[apimachinerytypes.NamespacedName]int{})
+	assert.Equal(t, []string{ep1IPv4.String()}, readyEndpointIPs(em))
+	for _, ep := range em[svcPortName] {
+		if ep.IP() == ep2IPv4.String() {
+			assert.False(t, ep.IsServing(), "Endpoint whose custom condition is false should not be serving")
+		}
+	}
+
+	// Removing the Endpoint from the annotation restores its conditions.
+	updatedEps := eps.DeepCopy()
+	updatedEps.Annotations[readinessGate] = fmt.Sprintf(`{"%s": true}`, ep1IPv4)
+	assert.True(t, tracker.OnEndpointSliceUpdate(updatedEps, false))
+	tracker.Update(em, map[apimachinerytypes.NamespacedName]int{})
+	assert.ElementsMatch(t, []string{ep1IPv4.String(), ep2IPv4.String()}, readyEndpointIPs(em))
+
+	// An invalid annotation is ignored.
+	invalidEps := eps.DeepCopy()
+	invalidEps.Annotations[readinessGate] = "invalid"
+	assert.False(t, tracker.OnEndpointSliceUpdate(invalidEps, false))
+
+	// The annotation is ignored when no readiness gate is configured.
+	tracker = newEndpointsChangesTracker(hostname, true, false, "")
+	assert.True(t, tracker.OnEndpointSliceUpdate(eps, false))
+	em = types.EndpointsMap{}
+	tracker.Update(em, map[apimachinerytypes.NamespacedName]int{})
+	assert.ElementsMatch(t, []string{ep1IPv4.String(), ep2IPv4.String()}, readyEndpointIPs(em))
+}
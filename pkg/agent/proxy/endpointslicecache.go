@@ -34,11 +34,16 @@ limitations under the License.
 // Remove unused standardEndpointInfo.
 // Remove unneeded sort.Sort in endpointsMapFromEndpointInfo.
 // Update import paths.
+// Consider Endpoints of Services with publishNotReadyAddresses as ready.
+// Set the weights of Endpoints from the annotation of EndpointSlices.
+// Apply the custom readiness condition of Endpoints from the annotation of EndpointSlices.
 
 package proxy
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
 	"strings"
@@ -51,6 +56,7 @@ import (
 	utilnet "k8s.io/utils/net"
 
 	"antrea.io/antrea/pkg/agent/proxy/types"
+	agenttypes "antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/features"
 	"antrea.io/antrea/third_party/proxy"
 )
@@ -67,6 +73,15 @@ type EndpointSliceCache struct {
 	// require slice specific caching to prevent endpoints being removed from
 	// the cache when they may have just moved to a different slice.
 	trackerByServiceMap map[apimachinerytypes.NamespacedName]*endpointSliceTracker
+	// publishNotReadyServices contains the Services with publishNotReadyAddresses set, whose Endpoints are all
+	// considered as ready.
+	publishNotReadyServices sets.Set[apimachinerytypes.NamespacedName]
+	// servicesToResync contains the Services whose Endpoints must be computed again in the next checkoutChanges
+	// call even if they have no pending EndpointSlice, as their publishNotReadyAddresses has changed.
+	servicesToResync sets.Set[apimachinerytypes.NamespacedName]
+	// readinessGate is the key of the EndpointSlice annotation providing a custom readiness condition for the
+	// Endpoints of the EndpointSlice. Empty means no custom condition is honored.
+	readinessGate string
 
 	hostname   string
 	isIPv6Mode bool
@@ -104,6 +119,9 @@ type endpointInfo struct {
 	Ready       bool
 	Serving     bool
 	Terminating bool
+
+	// Weight is set from the annotation of the EndpointSlice, 0 means no specific weight.
+	Weight uint16
 }
 
 // spToEndpointMap stores groups Endpoint objects by ServicePortName and
@@ -111,11 +129,14 @@ type endpointInfo struct {
 type spToEndpointMap map[proxy.ServicePortName]map[string]proxy.Endpoint
 
 // NewEndpointSliceCache initializes an EndpointSliceCache.
-func NewEndpointSliceCache(hostname string, isIPv6Mode bool) *EndpointSliceCache {
+func NewEndpointSliceCache(hostname string, isIPv6Mode bool, readinessGate string) *EndpointSliceCache {
 	return &EndpointSliceCache{
-		trackerByServiceMap: map[apimachinerytypes.NamespacedName]*endpointSliceTracker{},
-		hostname:            hostname,
-		isIPv6Mode:          isIPv6Mode,
+		trackerByServiceMap:     map[apimachinerytypes.NamespacedName]*endpointSliceTracker{},
+		publishNotReadyServices: sets.New[apimachinerytypes.NamespacedName](),
+		servicesToResync:        sets.New[apimachinerytypes.NamespacedName](),
+		readinessGate:           readinessGate,
+		hostname:                hostname,
+		isIPv6Mode:              isIPv6Mode,
 	}
 }
 
@@ -127,8 +148,9 @@ func newEndpointSliceTracker() *endpointSliceTracker {
 	}
 }
 
-// newEndpointSliceInfo generates endpointSliceInfo from an EndpointSlice.
-func newEndpointSliceInfo(endpointSlice *discovery.EndpointSlice, remove bool) *endpointSliceInfo {
+// newEndpointSliceInfo generates endpointSliceInfo from an EndpointSlice. If readinessGate is not empty, the Endpoints
+// whose custom readiness condition is false are considered as neither ready nor serving.
+func newEndpointSliceInfo(endpointSlice *discovery.EndpointSlice, remove bool, readinessGate string) *endpointSliceInfo {
 	esInfo := &endpointSliceInfo{
 		Ports:     make([]discovery.EndpointPort, len(endpointSlice.Ports)),
 		Endpoints: []*endpointInfo{},
@@ -140,6 +162,8 @@ func newEndpointSliceInfo(endpointSlice *discovery.EndpointSlice, remove bool) *
 	sort.Sort(byPort(esInfo.Ports))
 
 	if !remove {
+		weights := endpointWeightsFromAnnotation(endpointSlice)
+		conditions := endpointConditionsFromAnnotation(endpointSlice, readinessGate)
 		for _, endpoint := range endpointSlice.Endpoints {
 			epInfo := &endpointInfo{
 				Addresses: endpoint.Addresses,
@@ -161,6 +185,14 @@ func newEndpointSliceInfo(endpointSlice *discovery.EndpointSlice, remove bool) *
 				}
 			}
 
+			if len(endpoint.Addresses) > 0 {
+				epInfo.Weight = weights[endpoint.Addresses[0]]
+				if condition, ok := conditions[endpoint.Addresses[0]]; ok && !condition {
+					epInfo.Ready = false
+					epInfo.Serving = false
+				}
+			}
+
 			esInfo.Endpoints = append(esInfo.Endpoints, epInfo)
 		}
 
@@ -170,6 +202,47 @@ func newEndpointSliceInfo(endpointSlice *discovery.EndpointSlice, remove bool) *
 	return esInfo
 }
 
+// endpointWeightsFromAnnotation returns the weights of the Endpoints of an EndpointSlice, indexed by address, from the
+// annotation of the EndpointSlice. Invalid weights are ignored.
+func endpointWeightsFromAnnotation(endpointSlice *discovery.EndpointSlice) map[string]uint16 {
+	value, ok := endpointSlice.Annotations[agenttypes.EndpointSliceEndpointWeightsAnnotationKey]
+	if !ok {
+		return nil
+	}
+	var weights map[string]int
+	if err := json.Unmarshal([]byte(value), &weights); err != nil {
+		klog.ErrorS(err, "Ignoring invalid Endpoint weights annotation of EndpointSlice", "EndpointSlice", klog.KObj(endpointSlice))
+		return nil
+	}
+	result := make(map[string]uint16, len(weights))
+	for address, weight := range weights {
+		if weight < 1 || weight > math.MaxUint16 {
+			klog.ErrorS(nil, "Ignoring invalid Endpoint weight, it must be between 1 and 65535", "EndpointSlice", klog.KObj(endpointSlice), "address", address, "weight", weight)
+			continue
+		}
+		result[address] = uint16(weight)
+	}
+	return result
+}
+
+// endpointConditionsFromAnnotation returns the custom readiness conditions of the Endpoints of an EndpointSlice, indexed
+// by address, from the annotation of the EndpointSlice with the provided key. An invalid annotation is ignored.
+func endpointConditionsFromAnnotation(endpointSlice *discovery.EndpointSlice, key string) map[string]bool {
+	if key == "" {
+		return nil
+	}
+	value, ok := endpointSlice.Annotations[key]
+	if !ok {
+		return nil
+	}
+	var conditions map[string]bool
+	if err := json.Unmarshal([]byte(value), &conditions); err != nil {
+		klog.ErrorS(err, "Ignoring invalid Endpoint readiness gate annotation of EndpointSlice", "EndpointSlice", klog.KObj(endpointSlice), "key", key)
+		return nil
+	}
+	return conditions
+}
+
 // updatePending updates a pending slice in the cache.
 func (cache *EndpointSliceCache) updatePending(endpointSlice *discovery.EndpointSlice, remove bool) bool {
 	serviceKey, sliceKey, err := endpointSliceCacheKeys(endpointSlice)
@@ -178,7 +251,7 @@ func (cache *EndpointSliceCache) updatePending(endpointSlice *discovery.Endpoint
 		return false
 	}
 
-	esInfo := newEndpointSliceInfo(endpointSlice, remove)
+	esInfo := newEndpointSliceInfo(endpointSlice, remove, cache.readinessGate)
 
 	cache.lock.Lock()
 	defer cache.lock.Unlock()
@@ -196,6 +269,28 @@ func (cache *EndpointSliceCache) updatePending(endpointSlice *discovery.Endpoint
 	return changed
 }
 
+// updatePublishNotReadyAddresses records whether a Service publishes the addresses of its not ready Endpoints. It
+// returns true if the Endpoints of the Service must be updated.
+func (cache *EndpointSliceCache) updatePublishNotReadyAddresses(serviceKey apimachinerytypes.NamespacedName, publishNotReadyAddresses bool) bool {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if cache.publishNotReadyServices.Has(serviceKey) == publishNotReadyAddresses {
+		return false
+	}
+	if publishNotReadyAddresses {
+		cache.publishNotReadyServices.Insert(serviceKey)
+	} else {
+		cache.publishNotReadyServices.Delete(serviceKey)
+	}
+	if esTracker, ok := cache.trackerByServiceMap[serviceKey]; !ok || len(esTracker.applied) == 0 {
+		// The Endpoints of the Service will be computed when its EndpointSlices are applied.
+		return false
+	}
+	cache.servicesToResync.Insert(serviceKey)
+	return true
+}
+
 // checkoutChanges returns a list of all endpointsChanges that are
 // pending and then marks them as applied.
 func (cache *EndpointSliceCache) checkoutChanges() []*endpointsChange {
@@ -205,12 +300,14 @@ func (cache *EndpointSliceCache) checkoutChanges() []*endpointsChange {
 	defer cache.lock.Unlock()
 
 	for serviceNN, esTracker := range cache.trackerByServiceMap {
-		if len(esTracker.pending) == 0 {
+		if len(esTracker.pending) == 0 && !cache.servicesToResync.Has(serviceNN) {
 			continue
 		}
 
 		change := &endpointsChange{}
 
+		// When publishNotReadyAddresses of the Service has changed, previous is computed with the new value, which
+		// doesn't matter as only the ServicePortNames of previous are used to update the EndpointsMap.
 		change.previous = cache.getEndpointsMap(serviceNN, esTracker.applied)
 
 		for name, sliceInfo := range esTracker.pending {
@@ -226,6 +323,7 @@ func (cache *EndpointSliceCache) checkoutChanges() []*endpointsChange {
 		change.current = cache.getEndpointsMap(serviceNN, esTracker.applied)
 		changes = append(changes, change)
 	}
+	cache.servicesToResync = sets.New[apimachinerytypes.NamespacedName]()
 
 	return changes
 }
@@ -295,8 +393,12 @@ func (cache *EndpointSliceCache) addEndpoints(serviceNN apimachinerytypes.Namesp
 			zone = *endpoint.Zone
 		}
 
+		// The Endpoints of a Service with publishNotReadyAddresses are always considered as ready, regardless of
+		// their conditions.
+		ready := endpoint.Ready || cache.publishNotReadyServices.Has(serviceNN)
 		endpointInfo := proxy.NewBaseEndpointInfo(endpoint.Addresses[0], nodeName, zone, portNum, isLocal,
-			endpoint.Ready, endpoint.Serving, endpoint.Terminating, endpoint.ZoneHints)
+			ready, endpoint.Serving, endpoint.Terminating, endpoint.ZoneHints)
+		endpointInfo.Weight = endpoint.Weight
 		// This logic ensures we're deduping potential overlapping endpoints
 		// isLocal should not vary between matching IPs, but if it does, we
 		// favor a true value here if it exists.
@@ -0,0 +1,129 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/proxy/metrics"
+	"antrea.io/antrea/pkg/agent/proxy/types"
+	k8sproxy "antrea.io/antrea/third_party/proxy"
+)
+
+const externalIPConflictReason = "ExternalIPConflict"
+
+// servicePortPrecedes returns whether a Service port takes precedence over another one when both claim the same
+// external IP, port and protocol. The oldest Service takes precedence, and the Namespace and name of the Services are
+// compared when they have the same creation time, so that the result is the same on all Nodes.
+func servicePortPrecedes(name1 k8sproxy.ServicePortName, info1 *types.ServiceInfo, name2 k8sproxy.ServicePortName, info2 *types.ServiceInfo) bool {
+	if !info1.CreationTimestamp.Equal(&info2.CreationTimestamp) {
+		return info1.CreationTimestamp.Before(&info2.CreationTimestamp)
+	}
+	if name1.NamespacedName != name2.NamespacedName {
+		return name1.NamespacedName.String() < name2.NamespacedName.String()
+	}
+	return name1.Port < name2.Port
+}
+
+// resolveExternalIPConflicts detects the external IPs and LoadBalancer IPs claimed by several Services with the same
+// port and protocol. Without it, the Service whose flows are installed last would silently receive the traffic.
+// Only the Service port with precedence gets the flows of a conflicting IP, and the IP is excluded from the other
+// Service ports. It returns the Service ports which take over IPs which were excluded from them so far: they must be
+// installed after the flows of the previous owners of the IPs have been removed.
+func (p *proxier) resolveExternalIPConflicts() sets.Set[k8sproxy.ServicePortName] {
+	takingOver := sets.New[k8sproxy.ServicePortName]()
+	if !p.proxyAll && !p.proxyLoadBalancerIPs {
+		return takingOver
+	}
+	svcPortNames := make([]k8sproxy.ServicePortName, 0, len(p.serviceMap))
+	for svcPortName := range p.serviceMap {
+		svcPortNames = append(svcPortNames, svcPortName)
+	}
+	sort.Slice(svcPortNames, func(i, j int) bool {
+		return servicePortPrecedes(svcPortNames[i], p.serviceMap[svcPortNames[i]].(*types.ServiceInfo),
+			svcPortNames[j], p.serviceMap[svcPortNames[j]].(*types.ServiceInfo))
+	})
+
+	// owners maps an "IP:port/protocol" string to the Service port with precedence which claims it.
+	owners := map[string]k8sproxy.ServicePortName{}
+	conflictCount := 0
+	for _, svcPortName := range svcPortNames {
+		svcInfo := p.serviceMap[svcPortName].(*types.ServiceInfo)
+		var ips []string
+		if p.proxyAll {
+			ips = append(ips, svcInfo.BaseServiceInfo.ExternalIPStrings()...)
+		}
+		if p.proxyLoadBalancerIPs {
+			ips = append(ips, svcInfo.BaseServiceInfo.LoadBalancerIPStrings()...)
+		}
+		conflictingIPs := sets.New[string]()
+		for _, ip := range ips {
+			key := fmt.Sprintf("%s:%d/%s", ip, svcInfo.Port(), svcInfo.OFProtocol)
+			owner, exists := owners[key]
+			if !exists {
+				owners[key] = svcPortName
+				continue
+			}
+			// The same IP can be both an external IP and a LoadBalancer IP of a Service.
+			if owner.NamespacedName == svcPortName.NamespacedName {
+				continue
+			}
+			if !conflictingIPs.Has(ip) {
+				conflictingIPs.Insert(ip)
+				conflictCount++
+				if !svcInfo.ConflictingIPs.Has(ip) {
+					klog.InfoS("External IP is already claimed by another Service, skipping it", "ServicePortName", svcPortName, "ip", ip, "owner", owner)
+					p.recorder.Eventf(serviceReference(svcPortName), corev1.EventTypeWarning, externalIPConflictReason,
+						"IP %s with port %d/%s is already claimed by Service %s", ip, svcInfo.Port(), svcInfo.Protocol(), owner.NamespacedName)
+				}
+			}
+		}
+		if conflictingIPs.Equal(svcInfo.ConflictingIPs) {
+			continue
+		}
+		if svcInfo.ConflictingIPs.Difference(conflictingIPs).Len() > 0 {
+			takingOver.Insert(svcPortName)
+		}
+		// The ServiceInfo is copied rather than updated, as the installed ServiceInfo may be the same object and
+		// must keep the IPs which were installed.
+		newSvcInfo := *svcInfo
+		if conflictingIPs.Len() > 0 {
+			newSvcInfo.ConflictingIPs = conflictingIPs
+		} else {
+			newSvcInfo.ConflictingIPs = nil
+		}
+		p.serviceMap[svcPortName] = &newSvcInfo
+	}
+	if p.isIPv6 {
+		metrics.ExternalIPConflictsV6.Set(float64(conflictCount))
+	} else {
+		metrics.ExternalIPConflicts.Set(float64(conflictCount))
+	}
+	return takingOver
+}
+
+func serviceReference(svcPortName k8sproxy.ServicePortName) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Service",
+		Namespace:  svcPortName.Namespace,
+		Name:       svcPortName.Name,
+	}
+}
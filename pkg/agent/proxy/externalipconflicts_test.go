@@ -0,0 +1,83 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"antrea.io/antrea/pkg/agent/proxy/types"
+	k8sproxy "antrea.io/antrea/third_party/proxy"
+)
+
+func TestResolveExternalIPConflicts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOFClient, mockRouteClient := getMockClients(ctrl)
+	fp := newFakeProxier(mockRouteClient, mockOFClient, nil, nil, false, withProxyAll)
+	recorder := record.NewFakeRecorder(10)
+	fp.recorder = recorder
+
+	lbIP := net.ParseIP("169.254.169.1")
+	now := time.Now()
+	makeService := func(name string, clusterIP net.IP, port int32, creationTimestamp time.Time) (k8sproxy.ServicePortName, *corev1.Service) {
+		svcPortName := k8sproxy.ServicePortName{
+			NamespacedName: apimachinerytypes.NamespacedName{Namespace: "ns", Name: name},
+			Port:           "80",
+			Protocol:       corev1.ProtocolTCP,
+		}
+		svc := makeTestLoadBalancerService(&svcPortName, clusterIP, nil, []net.IP{lbIP}, port, 0, corev1.ProtocolTCP, nil, nil, corev1.ServiceExternalTrafficPolicyTypeCluster)
+		svc.CreationTimestamp = metav1.NewTime(creationTimestamp)
+		return svcPortName, svc
+	}
+	oldSvcPortName, oldSvc := makeService("svc-old", svc1IPv4, 80, now.Add(-time.Minute))
+	newSvcPortName, newSvc := makeService("svc-new", svc2IPv4, 80, now)
+	// A Service using the same IP with a different port doesn't conflict.
+	otherPortSvcPortName, otherPortSvc := makeService("svc-other-port", net.ParseIP("10.20.30.43"), 443, now)
+	makeServiceMap(fp, oldSvc, newSvc, otherPortSvc)
+	fp.serviceChanges.Update(fp.serviceMap)
+
+	getLoadBalancerIPs := func(svcPortName k8sproxy.ServicePortName) []string {
+		return fp.serviceMap[svcPortName].(*types.ServiceInfo).LoadBalancerIPStrings()
+	}
+
+	takingOver := fp.resolveExternalIPConflicts()
+	assert.Empty(t, takingOver)
+	assert.Equal(t, []string{lbIP.String()}, getLoadBalancerIPs(oldSvcPortName))
+	assert.Empty(t, getLoadBalancerIPs(newSvcPortName))
+	assert.Equal(t, []string{lbIP.String()}, getLoadBalancerIPs(otherPortSvcPortName))
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, externalIPConflictReason)
+
+	// The event is not recorded again when the conflict persists.
+	takingOver = fp.resolveExternalIPConflicts()
+	assert.Empty(t, takingOver)
+	assert.Empty(t, getLoadBalancerIPs(newSvcPortName))
+	assert.Len(t, recorder.Events, 0)
+
+	// The newer Service takes over the IP when the older one is deleted.
+	fp.serviceChanges.OnServiceUpdate(oldSvc, nil)
+	fp.serviceChanges.Update(fp.serviceMap)
+	takingOver = fp.resolveExternalIPConflicts()
+	assert.Equal(t, []k8sproxy.ServicePortName{newSvcPortName}, takingOver.UnsortedList())
+	assert.Equal(t, []string{lbIP.String()}, getLoadBalancerIPs(newSvcPortName))
+}
@@ -80,6 +80,49 @@ var (
 			Help:           "The cumulative number of Endpoint updates received by AntreaProxy",
 		},
 	)
+	ExternalIPConflicts = kmetrics.NewGauge(
+		&kmetrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemProxy,
+			ConstLabels:    map[string]string{"ip_family": "v4"},
+			StabilityLevel: kmetrics.ALPHA,
+			Name:           "external_ip_conflicts",
+			Help:           "The number of external IPs or LoadBalancer IPs of Service ports which are not installed by AntreaProxy because another Service claims them with the same port and protocol",
+		},
+	)
+	ServiceGroupBuckets = kmetrics.NewGaugeVec(
+		&kmetrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemProxy,
+			ConstLabels:    map[string]string{"ip_family": "v4"},
+			StabilityLevel: kmetrics.ALPHA,
+			Name:           "service_group_buckets",
+			Help:           "The number of buckets of non-draining Endpoints installed in the OVS groups of Service ports with more than one ready Endpoint",
+		},
+		[]string{"service", "group"},
+	)
+	ServiceReadyEndpoints = kmetrics.NewGaugeVec(
+		&kmetrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemProxy,
+			ConstLabels:    map[string]string{"ip_family": "v4"},
+			StabilityLevel: kmetrics.ALPHA,
+			Name:           "service_ready_endpoints",
+			Help:           "The number of ready Endpoints of Service ports with more than one ready Endpoint",
+		},
+		[]string{"service"},
+	)
+	ServiceGroupTruncated = kmetrics.NewGaugeVec(
+		&kmetrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemProxy,
+			ConstLabels:    map[string]string{"ip_family": "v4"},
+			StabilityLevel: kmetrics.ALPHA,
+			Name:           "service_group_truncated",
+			Help:           "Whether the OVS group of cluster Endpoints of a Service port with more than one ready Endpoint has fewer buckets than ready Endpoints (1) or not (0)",
+		},
+		[]string{"service"},
+	)
 
 	SyncProxyDurationV6 = kmetrics.NewHistogram(
 		&kmetrics.HistogramOpts{
@@ -131,6 +174,49 @@ var (
 			Help:           "The cumulative number of Endpoint updates received by AntreaProxy",
 		},
 	)
+	ExternalIPConflictsV6 = kmetrics.NewGauge(
+		&kmetrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemProxy,
+			ConstLabels:    map[string]string{"ip_family": "v6"},
+			StabilityLevel: kmetrics.ALPHA,
+			Name:           "external_ip_conflicts",
+			Help:           "The number of external IPs or LoadBalancer IPs of Service ports which are not installed by AntreaProxy because another Service claims them with the same port and protocol",
+		},
+	)
+	ServiceGroupBucketsV6 = kmetrics.NewGaugeVec(
+		&kmetrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemProxy,
+			ConstLabels:    map[string]string{"ip_family": "v6"},
+			StabilityLevel: kmetrics.ALPHA,
+			Name:           "service_group_buckets",
+			Help:           "The number of buckets of non-draining Endpoints installed in the OVS groups of Service ports with more than one ready Endpoint",
+		},
+		[]string{"service", "group"},
+	)
+	ServiceReadyEndpointsV6 = kmetrics.NewGaugeVec(
+		&kmetrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemProxy,
+			ConstLabels:    map[string]string{"ip_family": "v6"},
+			StabilityLevel: kmetrics.ALPHA,
+			Name:           "service_ready_endpoints",
+			Help:           "The number of ready Endpoints of Service ports with more than one ready Endpoint",
+		},
+		[]string{"service"},
+	)
+	ServiceGroupTruncatedV6 = kmetrics.NewGaugeVec(
+		&kmetrics.GaugeOpts{
+			Namespace:      metricNamespaceAntrea,
+			Subsystem:      metricSubsystemProxy,
+			ConstLabels:    map[string]string{"ip_family": "v6"},
+			StabilityLevel: kmetrics.ALPHA,
+			Name:           "service_group_truncated",
+			Help:           "Whether the OVS group of cluster Endpoints of a Service port with more than one ready Endpoint has fewer buckets than ready Endpoints (1) or not (0)",
+		},
+		[]string{"service"},
+	)
 )
 
 func Register() {
@@ -142,11 +228,19 @@ func Register() {
 			EndpointsInstalledTotal,
 			ServicesUpdatesTotal,
 			EndpointsUpdatesTotal,
+			ExternalIPConflicts,
+			ServiceGroupBuckets,
+			ServiceReadyEndpoints,
+			ServiceGroupTruncated,
 			SyncProxyDurationV6,
 			ServicesInstalledTotalV6,
 			EndpointsInstalledTotalV6,
 			ServicesUpdatesTotalV6,
 			EndpointsUpdatesTotalV6,
+			ExternalIPConflictsV6,
+			ServiceGroupBucketsV6,
+			ServiceReadyEndpointsV6,
+			ServiceGroupTruncatedV6,
 		)
 	})
 }
@@ -0,0 +1,98 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"strconv"
+
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/util"
+	k8sproxy "antrea.io/antrea/third_party/proxy"
+)
+
+// numaNodeAnnotationKey is the Pod annotation indicating the NUMA node the Pod
+// is pinned to.
+const numaNodeAnnotationKey = "proxy.antrea.io/numa-node"
+
+// getInterfaceNUMANode is meant to be overridden for testing.
+var getInterfaceNUMANode = util.GetInterfaceNUMANode
+
+// NUMAAffinity selects, among the local Endpoints of a Service, the ones whose
+// Pods are pinned to the same NUMA node as the ingress NIC of the Node.
+type NUMAAffinity struct {
+	nicNUMANode int
+	ifaceStore  interfacestore.InterfaceStore
+	podLister   corelisters.PodLister
+}
+
+// NewNUMAAffinity creates a NUMAAffinity for the given NIC. It returns an error
+// if the NUMA node of the NIC cannot be determined.
+func NewNUMAAffinity(nicName string, ifaceStore interfacestore.InterfaceStore, podLister corelisters.PodLister) (*NUMAAffinity, error) {
+	numaNode, err := getInterfaceNUMANode(nicName)
+	if err != nil {
+		return nil, err
+	}
+	klog.InfoS("Preferring NUMA-local Endpoints for Services", "nic", nicName, "numaNode", numaNode)
+	return &NUMAAffinity{
+		nicNUMANode: numaNode,
+		ifaceStore:  ifaceStore,
+		podLister:   podLister,
+	}, nil
+}
+
+// podNUMANode returns the NUMA node of the local Pod owning the given IP.
+func (a *NUMAAffinity) podNUMANode(ip string) (int, error) {
+	ifaceConfig, found := a.ifaceStore.GetInterfaceByIP(ip)
+	if !found || ifaceConfig.ContainerInterfaceConfig == nil {
+		return -1, fmt.Errorf("no local Pod found for IP %s", ip)
+	}
+	pod, err := a.podLister.Pods(ifaceConfig.PodNamespace).Get(ifaceConfig.PodName)
+	if err != nil {
+		return -1, err
+	}
+	value, ok := pod.Annotations[numaNodeAnnotationKey]
+	if !ok {
+		return -1, fmt.Errorf("Pod %s/%s is not annotated with %s", pod.Namespace, pod.Name, numaNodeAnnotationKey)
+	}
+	numaNode, err := strconv.Atoi(value)
+	if err != nil {
+		return -1, fmt.Errorf("invalid value %q of annotation %s on Pod %s/%s: %v", value, numaNodeAnnotationKey, pod.Namespace, pod.Name, err)
+	}
+	return numaNode, nil
+}
+
+// filterEndpoints returns the Endpoints that are on the same NUMA node as the
+// NIC. If there is no such Endpoint, all the given Endpoints are returned.
+func (a *NUMAAffinity) filterEndpoints(endpoints []k8sproxy.Endpoint) []k8sproxy.Endpoint {
+	var numaLocalEndpoints []k8sproxy.Endpoint
+	for _, ep := range endpoints {
+		numaNode, err := a.podNUMANode(ep.IP())
+		if err != nil {
+			klog.V(4).InfoS("Failed to get NUMA node of Endpoint", "endpoint", ep.String(), "err", err)
+			continue
+		}
+		if numaNode == a.nicNUMANode {
+			numaLocalEndpoints = append(numaLocalEndpoints, ep)
+		}
+	}
+	if len(numaLocalEndpoints) == 0 {
+		return endpoints
+	}
+	return numaLocalEndpoints
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/util"
+	k8sproxy "antrea.io/antrea/third_party/proxy"
+)
+
+func newTestNUMAAffinity(t *testing.T, nicNUMANode int, podNUMANodes map[string]string) *NUMAAffinity {
+	ifaceStore := interfacestore.NewInterfaceStore()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	i := 0
+	for ip, numaNode := range podNUMANodes {
+		podName := fmt.Sprintf("pod%d", i)
+		i++
+		ifaceStore.AddInterface(interfacestore.NewContainerInterface(podName, podName, podName, "default", nil, []net.IP{net.ParseIP(ip)}, 0))
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: "default"}}
+		if numaNode != "" {
+			pod.Annotations = map[string]string{numaNodeAnnotationKey: numaNode}
+		}
+		require.NoError(t, indexer.Add(pod))
+	}
+	return &NUMAAffinity{
+		nicNUMANode: nicNUMANode,
+		ifaceStore:  ifaceStore,
+		podLister:   corelisters.NewPodLister(indexer),
+	}
+}
+
+func TestNUMAAffinityFilterEndpoints(t *testing.T) {
+	ep1 := &k8sproxy.BaseEndpointInfo{Endpoint: "10.10.0.1:80", IsLocal: true, Ready: true}
+	ep2 := &k8sproxy.BaseEndpointInfo{Endpoint: "10.10.0.2:80", IsLocal: true, Ready: true}
+	ep3 := &k8sproxy.BaseEndpointInfo{Endpoint: "10.10.0.3:80", IsLocal: true, Ready: true}
+	testCases := []struct {
+		name              string
+		podNUMANodes      map[string]string
+		expectedEndpoints []k8sproxy.Endpoint
+	}{
+		{
+			name:              "prefer NUMA-local Endpoints",
+			podNUMANodes:      map[string]string{"10.10.0.1": "0", "10.10.0.2": "1", "10.10.0.3": "1"},
+			expectedEndpoints: []k8sproxy.Endpoint{ep2, ep3},
+		},
+		{
+			name:              "fall back to all local Endpoints",
+			podNUMANodes:      map[string]string{"10.10.0.1": "0", "10.10.0.2": "0", "10.10.0.3": "2"},
+			expectedEndpoints: []k8sproxy.Endpoint{ep1, ep2, ep3},
+		},
+		{
+			name:              "ignore Endpoints without valid annotation",
+			podNUMANodes:      map[string]string{"10.10.0.1": "", "10.10.0.2": "invalid", "10.10.0.3": "1"},
+			expectedEndpoints: []k8sproxy.Endpoint{ep3},
+		},
+		{
+			name:              "ignore Endpoints without interface",
+			podNUMANodes:      map[string]string{"10.10.0.1": "1"},
+			expectedEndpoints: []k8sproxy.Endpoint{ep1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			affinity := newTestNUMAAffinity(t, 1, tc.podNUMANodes)
+			assert.Equal(t, tc.expectedEndpoints, affinity.filterEndpoints([]k8sproxy.Endpoint{ep1, ep2, ep3}))
+		})
+	}
+}
+
+func TestNewNUMAAffinity(t *testing.T) {
+	defer func() { getInterfaceNUMANode = util.GetInterfaceNUMANode }()
+	getInterfaceNUMANode = func(ifName string) (int, error) {
+		if ifName == "eth0" {
+			return 1, nil
+		}
+		return -1, fmt.Errorf("NUMA locality is not reported for interface %s", ifName)
+	}
+	affinity, err := NewNUMAAffinity("eth0", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, affinity.nicNUMANode)
+	_, err = NewNUMAAffinity("eth1", nil, nil)
+	assert.Error(t, err)
+}
@@ -19,6 +19,7 @@ import (
 	"math"
 	"net"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -32,19 +33,26 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
 	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	utilnet "k8s.io/utils/net"
 	"k8s.io/utils/strings/slices"
 
 	agentconfig "antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/cpuaccounting"
+	"antrea.io/antrea/pkg/agent/flowscheduler"
 	"antrea.io/antrea/pkg/agent/openflow"
 	"antrea.io/antrea/pkg/agent/proxy/metrics"
 	"antrea.io/antrea/pkg/agent/proxy/types"
 	"antrea.io/antrea/pkg/agent/route"
+	agenttypes "antrea.io/antrea/pkg/agent/types"
 	antreaconfig "antrea.io/antrea/pkg/config/agent"
 	"antrea.io/antrea/pkg/features"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
@@ -78,6 +86,19 @@ type Proxier interface {
 	// GetServiceByIP returns the ServicePortName struct for the given serviceString(ClusterIP:Port/Proto).
 	// False is returned if the serviceString is not found in serviceStringMap.
 	GetServiceByIP(serviceStr string) (k8sproxy.ServicePortName, bool)
+	// GetServiceStates returns the state of all the ServicePorts known by the proxier, including their installed
+	// Endpoints and allocated OVS group IDs, sorted by ServicePortName.
+	GetServiceStates() []types.ServiceState
+	// GetServiceIPRouteReferences returns the Service IP routes installed by the proxier and the Service ports
+	// referencing them, sorted by IP.
+	GetServiceIPRouteReferences() []types.ServiceIPRouteReference
+	// UpdateNodePortAddresses updates the IP addresses on which NodePort Services are exposed when proxyAll is
+	// enabled, and the NodePort traffic redirecting rules of the installed Services accordingly.
+	UpdateNodePortAddresses(nodePortAddressesIPv4, nodePortAddressesIPv6 []net.IP) error
+	// UpdateSkipServices replaces the Services which should be ignored by AntreaProxy, specified with ClusterIPs or
+	// Namespace/name strings and a label selector, and uninstalls or installs the flows of the affected Services
+	// accordingly.
+	UpdateSkipServices(skipServices []string, skipServiceSelector labels.Selector)
 }
 
 type proxier struct {
@@ -86,6 +107,8 @@ type proxier struct {
 	endpointsConfig     *config.EndpointsConfig
 	serviceConfig       *config.ServiceConfig
 	nodeConfig          *config.NodeConfig
+	// serviceLister is used to re-evaluate all the Services when the Services to skip are updated.
+	serviceLister corelisters.ServiceLister
 	// endpointsChanges and serviceChanges contains all changes to endpoints and
 	// services that happened since last syncProxyRules call. For a single object,
 	// changes are accumulated. Once both endpointsChanges and serviceChanges
@@ -107,8 +130,19 @@ type proxier struct {
 	serviceEndpointsMapsMutex sync.Mutex
 	// endpointReferenceCounter stores the number of times an Endpoint is referenced by Services.
 	endpointReferenceCounter map[string]int
+	// drainingEndpoints stores the Endpoints which have been removed from Services but are kept in their groups with
+	// zero weight until endpointDrainingTimeout expires. Their flows are still installed and they are still counted in
+	// endpointReferenceCounter.
+	drainingEndpoints       map[k8sproxy.ServicePortName]map[string]*drainingEndpoint
+	endpointDrainingTimeout time.Duration
+	clock                   clock.WithDelayedExecution
 	// groupCounter is used to allocate groupID.
 	groupCounter types.GroupCounter
+	// groupBuckets stores the number of buckets of non-draining Endpoints installed in the groups of each Service
+	// port, keyed by whether the group is for local Endpoints. It is used to report the Endpoint distribution metrics.
+	groupBuckets map[k8sproxy.ServicePortName]map[bool]int
+	// reportedServicePorts stores the Service ports whose Endpoint distribution metrics are reported.
+	reportedServicePorts sets.Set[k8sproxy.ServicePortName]
 	// serviceStringMap provides map from serviceString(ClusterIP:Port/Proto) to ServicePortName.
 	serviceStringMap map[string]k8sproxy.ServicePortName
 	// serviceStringMapMutex protects serviceStringMap object.
@@ -117,13 +151,9 @@ type proxier struct {
 	serviceHealthServer healthcheck.ServiceHealthServer
 	numLocalEndpoints   map[apimachinerytypes.NamespacedName]int
 
-	// serviceIPRouteReferences tracks the references of Service IP routes. The key is the Service IP and the value is
-	// the set of ServiceInfo strings. Because a Service could have multiple ports and each port will generate a
-	// ServicePort (which is the unit of the processing), a Service IP route may be required by several ServicePorts.
-	// With the references, we install a route exactly once as long as it's used by any ServicePorts and uninstall it
-	// exactly once when it's no longer used by any ServicePorts.
-	// It applies to ClusterIP and LoadBalancerIP.
-	serviceIPRouteReferences map[string]sets.Set[string]
+	// serviceIPRouteReferences tracks the references of the external IP and LoadBalancer IP routes. It's shared by
+	// the proxier instances of a dual-stack proxier.
+	serviceIPRouteReferences *serviceIPRouteReferences
 	// syncedOnce returns true if the proxier has synced rules at least once.
 	syncedOnce      bool
 	syncedOnceMutex sync.RWMutex
@@ -140,6 +170,16 @@ type proxier struct {
 	proxyLoadBalancerIPs      bool
 	topologyAwareHintsEnabled bool
 	supportNestedService      bool
+	// loadBalancerModeDSREnabled indicates whether the external addresses of Services can be load balanced in DSR mode.
+	loadBalancerModeDSREnabled bool
+	// reconcileScheduler shares the OVS programming bandwidth with other features.
+	// It's nil if the scheduler is disabled.
+	reconcileScheduler *flowscheduler.Scheduler
+	// numaAffinity is used to prefer local Endpoints on the same NUMA node as the ingress NIC.
+	// It's nil if the preference is disabled.
+	numaAffinity *NUMAAffinity
+	// recorder is used to report the Services whose external IPs conflict with other Services.
+	recorder record.EventRecorder
 }
 
 func (p *proxier) SyncedOnce() bool {
@@ -148,6 +188,13 @@ func (p *proxier) SyncedOnce() bool {
 	return p.syncedOnce
 }
 
+// drainingEndpoint is an Endpoint removed from a Service, which doesn't receive new connections while its established
+// connections can complete until deadline.
+type drainingEndpoint struct {
+	k8sproxy.Endpoint
+	deadline time.Time
+}
+
 func endpointKey(endpoint k8sproxy.Endpoint, protocol binding.Protocol) string {
 	return fmt.Sprintf("%s/%s", endpoint.String(), protocol)
 }
@@ -165,7 +212,7 @@ func (p *proxier) removeStaleServices() {
 		svcInfo := svcPort.(*types.ServiceInfo)
 		svcInfoStr := svcInfo.String()
 		klog.V(2).InfoS("Removing stale Service", "ServicePortName", svcPortName, "ServiceInfo", svcInfoStr)
-		if !p.removeServiceFlows(svcInfo) {
+		if !p.removeServiceFlows(svcPortName, svcInfo) {
 			continue
 		}
 		// Remove Service group which has only local Endpoints.
@@ -183,13 +230,75 @@ func (p *proxier) removeStaleServices() {
 			}
 			delete(p.endpointsInstalledMap, svcPortName)
 		}
+		// Endpoints being drained are removed immediately as the Service group has been removed.
+		if endpoints, ok := p.drainingEndpoints[svcPortName]; ok {
+			if !p.removeStaleEndpoints(svcPortName, svcInfo.OFProtocol, drainingEndpointsToMap(endpoints)) {
+				continue
+			}
+			delete(p.drainingEndpoints, svcPortName)
+		}
+
+		// The connections of the Service can no longer be load-balanced, remove their stale conntrack entries.
+		p.clearUDPConntrackEntries(svcInfo, nil)
 
 		delete(p.serviceInstalledMap, svcPortName)
 		p.deleteServiceByIP(svcInfoStr)
 	}
 }
 
-func (p *proxier) removeServiceFlows(svcInfo *types.ServiceInfo) bool {
+func isUDPService(svcInfo *types.ServiceInfo) bool {
+	return svcInfo.OFProtocol == binding.ProtocolUDP || svcInfo.OFProtocol == binding.ProtocolUDPv6
+}
+
+// clearUDPConntrackEntries deletes the conntrack entries of the connections to a UDP Service which were load-balanced
+// to the given Endpoints, or to any Endpoint if endpoints is nil. Unlike TCP connections, UDP connections are not
+// terminated when their Endpoint is removed, and the stale conntrack entries would keep steering the traffic to the
+// removed Endpoint until they expire. Failures are only logged, as the entries will eventually expire.
+func (p *proxier) clearUDPConntrackEntries(svcInfo *types.ServiceInfo, endpoints map[string]k8sproxy.Endpoint) {
+	if !isUDPService(svcInfo) {
+		return
+	}
+	// A nil Endpoint IP matches the connections load-balanced to any Endpoint.
+	endpointIPs := []net.IP{nil}
+	if endpoints != nil {
+		endpointIPs = make([]net.IP, 0, len(endpoints))
+		for _, endpoint := range endpoints {
+			endpointIPs = append(endpointIPs, net.ParseIP(endpoint.IP()))
+		}
+	}
+	svcPort := uint16(svcInfo.Port())
+	svcProto := svcInfo.OFProtocol
+	type serviceAddress struct {
+		ip   net.IP
+		port uint16
+	}
+	svcAddresses := []serviceAddress{{ip: svcInfo.ClusterIP(), port: svcPort}}
+	if p.proxyAll {
+		if svcInfo.NodePort() > 0 {
+			// The connections to a NodePort may be destined to any Node IP, so they are only matched by port.
+			svcAddresses = append(svcAddresses, serviceAddress{port: uint16(svcInfo.NodePort())})
+		}
+		for _, externalIP := range svcInfo.ExternalIPStrings() {
+			svcAddresses = append(svcAddresses, serviceAddress{ip: net.ParseIP(externalIP), port: svcPort})
+		}
+	}
+	if p.proxyLoadBalancerIPs {
+		for _, ingress := range svcInfo.LoadBalancerIPStrings() {
+			if ingress != "" {
+				svcAddresses = append(svcAddresses, serviceAddress{ip: net.ParseIP(ingress), port: svcPort})
+			}
+		}
+	}
+	for _, address := range svcAddresses {
+		for _, endpointIP := range endpointIPs {
+			if err := p.routeClient.ClearConntrackEntryForService(address.ip, address.port, endpointIP, svcProto); err != nil {
+				klog.ErrorS(err, "Error when clearing conntrack entries for UDP Service", "ServiceInfo", svcInfo.String(), "serviceIP", address.ip, "servicePort", address.port, "endpointIP", endpointIP)
+			}
+		}
+	}
+}
+
+func (p *proxier) removeServiceFlows(svcPortName k8sproxy.ServicePortName, svcInfo *types.ServiceInfo) bool {
 	svcInfoStr := svcInfo.String()
 	svcPort := uint16(svcInfo.Port())
 	svcProto := svcInfo.OFProtocol
@@ -206,14 +315,14 @@ func (p *proxier) removeServiceFlows(svcInfo *types.ServiceInfo) bool {
 			return false
 		}
 		// Remove ExternalIP flows and configurations.
-		if err := p.uninstallExternalIPService(svcInfoStr, svcInfo.ExternalIPStrings(), svcPort, svcProto); err != nil {
+		if err := p.uninstallExternalIPService(svcPortName, svcInfo.ExternalIPStrings(), svcPort, svcProto); err != nil {
 			klog.ErrorS(err, "Error when uninstalling ExternalIP flows and configurations for Service", "ServiceInfo", svcInfoStr)
 			return false
 		}
 	}
 	// Remove LoadBalancer flows and configurations.
 	if p.proxyLoadBalancerIPs {
-		if err := p.uninstallLoadBalancerService(svcInfoStr, svcInfo.LoadBalancerIPStrings(), svcPort, svcProto); err != nil {
+		if err := p.uninstallLoadBalancerService(svcPortName, svcInfo.LoadBalancerIPStrings(), svcPort, svcProto); err != nil {
 			klog.ErrorS(err, "Error when uninstalling LoadBalancer flows and configurations for Service", "ServiceInfo", svcInfoStr)
 			return false
 		}
@@ -245,6 +354,16 @@ func (p *proxier) installServiceGroup(svcPortName k8sproxy.ServicePortName, need
 		return 0, false
 	}
 	success = true
+	buckets := 0
+	for _, endpoint := range endpoints {
+		if _, isDraining := endpoint.(*openflow.DrainingEndpoint); !isDraining {
+			buckets++
+		}
+	}
+	if _, exists := p.groupBuckets[svcPortName]; !exists {
+		p.groupBuckets[svcPortName] = map[bool]int{}
+	}
+	p.groupBuckets[svcPortName][local] = buckets
 	return groupID, true
 }
 
@@ -255,10 +374,71 @@ func (p *proxier) removeServiceGroup(svcPortName k8sproxy.ServicePortName, local
 			return false
 		}
 		p.groupCounter.Recycle(svcPortName, local)
+		delete(p.groupBuckets[svcPortName], local)
+		if len(p.groupBuckets[svcPortName]) == 0 {
+			delete(p.groupBuckets, svcPortName)
+		}
 	}
 	return true
 }
 
+func groupType(local bool) string {
+	if local {
+		return "local"
+	}
+	return "cluster"
+}
+
+// updateEndpointDistributionMetrics reports, for each Service port with more than one ready Endpoint, the number of
+// buckets installed in its groups and whether its group of cluster Endpoints has fewer buckets than ready Endpoints,
+// e.g. because of topology aware hints or NUMA affinity, in which case the connections are not load balanced across
+// all the ready Endpoints.
+func (p *proxier) updateEndpointDistributionMetrics() {
+	bucketsMetric, readyEndpointsMetric, truncatedMetric := metrics.ServiceGroupBuckets, metrics.ServiceReadyEndpoints, metrics.ServiceGroupTruncated
+	if p.isIPv6 {
+		bucketsMetric, readyEndpointsMetric, truncatedMetric = metrics.ServiceGroupBucketsV6, metrics.ServiceReadyEndpointsV6, metrics.ServiceGroupTruncatedV6
+	}
+	reported := sets.New[k8sproxy.ServicePortName]()
+	for svcPortName, groupBuckets := range p.groupBuckets {
+		readyEndpoints := 0
+		for _, endpoint := range p.endpointsMap[svcPortName] {
+			if endpoint.IsReady() {
+				readyEndpoints++
+			}
+		}
+		if readyEndpoints <= 1 {
+			continue
+		}
+		service := svcPortName.String()
+		readyEndpointsMetric.WithLabelValues(service).Set(float64(readyEndpoints))
+		for _, local := range []bool{false, true} {
+			if buckets, exists := groupBuckets[local]; exists {
+				bucketsMetric.WithLabelValues(service, groupType(local)).Set(float64(buckets))
+			} else {
+				bucketsMetric.Delete(map[string]string{"service": service, "group": groupType(local)})
+			}
+		}
+		if buckets, exists := groupBuckets[false]; exists {
+			truncated := 0.0
+			if buckets < readyEndpoints {
+				truncated = 1
+			}
+			truncatedMetric.WithLabelValues(service).Set(truncated)
+		} else {
+			truncatedMetric.Delete(map[string]string{"service": service})
+		}
+		reported.Insert(svcPortName)
+	}
+	for svcPortName := range p.reportedServicePorts.Difference(reported) {
+		service := svcPortName.String()
+		readyEndpointsMetric.Delete(map[string]string{"service": service})
+		bucketsMetric.Delete(map[string]string{"service": service, "group": groupType(false)})
+		bucketsMetric.Delete(map[string]string{"service": service, "group": groupType(true)})
+		truncatedMetric.Delete(map[string]string{"service": service})
+	}
+	p.reportedServicePorts = reported
+}
+
 // removeStaleEndpoints removes flows for the given Endpoints from the data path if these flows are no longer
 // needed by any Service. Endpoints from different Services can have the same characteristics and thus
 // can share the same flows. removeStaleEndpoints must be called whenever Endpoints are no longer used by a
@@ -303,6 +483,83 @@ func (p *proxier) removeStaleEndpoints(svcPortName k8sproxy.ServicePortName, pro
 	return true
 }
 
+// updateDrainingEndpoints starts draining the given stale Endpoints of a ServicePort if endpointDrainingTimeout is set:
+// they are moved from the installed Endpoints to the draining Endpoints and removed from staleEndpoints, so that their
+// flows are kept. Draining Endpoints which are reachable again are moved back to the installed Endpoints and removed
+// from newEndpoints, as their flows are still installed. The draining Endpoints whose deadline has passed are added to
+// staleEndpoints so that they can be removed. It returns true if the draining Endpoints of the ServicePort changed.
+func (p *proxier) updateDrainingEndpoints(svcPortName k8sproxy.ServicePortName, staleEndpoints, newEndpoints map[string]k8sproxy.Endpoint) bool {
+	draining := p.drainingEndpoints[svcPortName]
+	if p.endpointDrainingTimeout == 0 && len(draining) == 0 {
+		return false
+	}
+	if draining == nil {
+		draining = map[string]*drainingEndpoint{}
+		p.drainingEndpoints[svcPortName] = draining
+	}
+	changed := false
+	for key, endpoint := range newEndpoints {
+		if _, ok := draining[key]; ok {
+			klog.V(2).InfoS("Endpoint is reachable again, stopping draining it", "ServicePortName", svcPortName, "Endpoint", key)
+			delete(draining, key)
+			delete(newEndpoints, key)
+			p.endpointsInstalledMap[svcPortName][key] = endpoint
+			changed = true
+		}
+	}
+	now := p.clock.Now()
+	if p.endpointDrainingTimeout > 0 && len(staleEndpoints) > 0 {
+		for key, endpoint := range staleEndpoints {
+			klog.V(2).InfoS("Draining stale Endpoint", "ServicePortName", svcPortName, "Endpoint", key, "timeout", p.endpointDrainingTimeout)
+			draining[key] = &drainingEndpoint{Endpoint: endpoint, deadline: now.Add(p.endpointDrainingTimeout)}
+			delete(staleEndpoints, key)
+			delete(p.endpointsInstalledMap[svcPortName], key)
+		}
+		changed = true
+		// Sync again once the draining has completed, as there may be no other event by then.
+		p.clock.AfterFunc(p.endpointDrainingTimeout, p.runner.Run)
+	}
+	for key, endpoint := range draining {
+		if !now.Before(endpoint.deadline) {
+			klog.V(2).InfoS("Draining of stale Endpoint has completed", "ServicePortName", svcPortName, "Endpoint", key)
+			staleEndpoints[key] = endpoint.Endpoint
+			changed = true
+		}
+	}
+	return changed
+}
+
+// withDrainingEndpoints returns the given Endpoints of a ServicePort group with the draining Endpoints of the
+// ServicePort, whose buckets have zero weight. If the group has no other Endpoints, the draining Endpoints are not
+// added, so that new connections are rejected as for any Service without Endpoint.
+func (p *proxier) withDrainingEndpoints(svcPortName k8sproxy.ServicePortName, endpoints []k8sproxy.Endpoint, localOnly bool) []k8sproxy.Endpoint {
+	draining := p.drainingEndpoints[svcPortName]
+	if len(endpoints) == 0 || len(draining) == 0 {
+		return endpoints
+	}
+	keys := make([]string, 0, len(draining))
+	for key, endpoint := range draining {
+		if !localOnly || endpoint.GetIsLocal() {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	result := make([]k8sproxy.Endpoint, 0, len(endpoints)+len(keys))
+	result = append(result, endpoints...)
+	for _, key := range keys {
+		result = append(result, &openflow.DrainingEndpoint{Endpoint: draining[key].Endpoint})
+	}
+	return result
+}
+
+func drainingEndpointsToMap(endpoints map[string]*drainingEndpoint) map[string]k8sproxy.Endpoint {
+	m := make(map[string]k8sproxy.Endpoint, len(endpoints))
+	for key, endpoint := range endpoints {
+		m[key] = endpoint.Endpoint
+	}
+	return m
+}
+
 func (p *proxier) addNewEndpoints(svcPortName k8sproxy.ServicePortName, protocol binding.Protocol, newEndpoints map[string]k8sproxy.Endpoint) bool {
 	var endpointsToAdd []k8sproxy.Endpoint
 
@@ -336,9 +593,32 @@ func (p *proxier) addNewEndpoints(svcPortName k8sproxy.ServicePortName, protocol
 func serviceIdentityChanged(svcInfo, pSvcInfo *types.ServiceInfo) bool {
 	return svcInfo.ClusterIP().String() != pSvcInfo.ClusterIP().String() ||
 		svcInfo.Port() != pSvcInfo.Port() ||
+		svcInfo.EndPort != pSvcInfo.EndPort ||
 		svcInfo.OFProtocol != pSvcInfo.OFProtocol
 }
 
+// toPortRangeEndpoints returns the Endpoints of a Service port with a port range, whose port is 0, so that the
+// destination port of the connections is kept when they are DNATed to the Endpoints.
+func toPortRangeEndpoints(endpoints map[string]k8sproxy.Endpoint) map[string]k8sproxy.Endpoint {
+	result := make(map[string]k8sproxy.Endpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		portRangeEndpoint := &types.PortRangeEndpoint{Endpoint: endpoint}
+		result[portRangeEndpoint.String()] = portRangeEndpoint
+	}
+	return result
+}
+
+// filterLocalEndpoints returns the local Endpoints among the given Endpoints.
+func filterLocalEndpoints(endpoints []k8sproxy.Endpoint) []k8sproxy.Endpoint {
+	var result []k8sproxy.Endpoint
+	for _, endpoint := range endpoints {
+		if endpoint.GetIsLocal() {
+			result = append(result, endpoint)
+		}
+	}
+	return result
+}
+
 func serviceExternalAddressesChanged(svcInfo, pSvcInfo *types.ServiceInfo) bool {
 	return svcInfo.NodePort() != pSvcInfo.NodePort() ||
 		!slices.Equal(svcInfo.LoadBalancerIPStrings(), pSvcInfo.LoadBalancerIPStrings()) ||
@@ -366,7 +646,7 @@ func smallSliceDifference(s1, s2 []string) []string {
 	return diff
 }
 
-func (p *proxier) installNodePortService(externalGroupID, clusterGroupID binding.GroupIDType, svcPort uint16, protocol binding.Protocol, affinityTimeout uint16) error {
+func (p *proxier) installNodePortService(externalGroupID, clusterGroupID binding.GroupIDType, svcPort uint16, protocol binding.Protocol, affinityTimeout uint16, affinityKey *agenttypes.SessionAffinityKey) error {
 	if svcPort == 0 {
 		return nil
 	}
@@ -374,7 +654,7 @@ func (p *proxier) installNodePortService(externalGroupID, clusterGroupID binding
 	if p.isIPv6 {
 		svcIP = agentconfig.VirtualNodePortDNATIPv6
 	}
-	if err := p.ofClient.InstallServiceFlows(externalGroupID, clusterGroupID, svcIP, svcPort, protocol, affinityTimeout, true, false); err != nil {
+	if err := p.ofClient.InstallServiceFlows(externalGroupID, clusterGroupID, svcIP, svcPort, protocol, affinityTimeout, affinityKey, true, false); err != nil {
 		return fmt.Errorf("failed to install NodePort load balancing flows: %w", err)
 	}
 	if err := p.routeClient.AddNodePort(p.nodePortAddresses, svcPort, protocol); err != nil {
@@ -400,41 +680,54 @@ func (p *proxier) uninstallNodePortService(svcPort uint16, protocol binding.Prot
 	return nil
 }
 
-func (p *proxier) installExternalIPService(svcInfoStr string, externalGroupID, clusterGroupID binding.GroupIDType, externalIPStrings []string, svcPort uint16, protocol binding.Protocol, affinityTimeout uint16) error {
+// installExternalAddressFlows installs the load balancing flows of an external IP or a LoadBalancer IP of a Service
+// port. The destination ports from svcPort to svcEndPort are matched if svcEndPort is not 0. The address is load
+// balanced in DSR mode if dsrLocalGroupID is not 0.
+func (p *proxier) installExternalAddressFlows(externalGroupID, clusterGroupID, dsrLocalGroupID binding.GroupIDType, ip net.IP, svcPort, svcEndPort uint16, protocol binding.Protocol, affinityTimeout uint16, affinityKey *agenttypes.SessionAffinityKey) error {
+	if dsrLocalGroupID != 0 {
+		return p.ofClient.InstallServiceDSRFlows(externalGroupID, dsrLocalGroupID, ip, svcPort, protocol)
+	}
+	if svcEndPort != 0 {
+		return p.ofClient.InstallServicePortRangeFlows(externalGroupID, clusterGroupID, ip, svcPort, svcEndPort, protocol, affinityTimeout, affinityKey, true)
+	}
+	return p.ofClient.InstallServiceFlows(externalGroupID, clusterGroupID, ip, svcPort, protocol, affinityTimeout, affinityKey, true, false)
+}
+
+func (p *proxier) installExternalIPService(svcPortName k8sproxy.ServicePortName, externalGroupID, clusterGroupID, dsrLocalGroupID binding.GroupIDType, externalIPStrings []string, svcPort, svcEndPort uint16, protocol binding.Protocol, affinityTimeout uint16, affinityKey *agenttypes.SessionAffinityKey) error {
 	for _, externalIP := range externalIPStrings {
 		ip := net.ParseIP(externalIP)
-		if err := p.ofClient.InstallServiceFlows(externalGroupID, clusterGroupID, ip, svcPort, protocol, affinityTimeout, true, false); err != nil {
+		if err := p.installExternalAddressFlows(externalGroupID, clusterGroupID, dsrLocalGroupID, ip, svcPort, svcEndPort, protocol, affinityTimeout, affinityKey); err != nil {
 			return fmt.Errorf("failed to install ExternalIP load balancing flows: %w", err)
 		}
-		if err := p.addRouteForServiceIP(svcInfoStr, ip, p.routeClient.AddExternalIPRoute); err != nil {
+		if err := p.serviceIPRouteReferences.addReference(svcPortName, ip, p.routeClient.AddExternalIPRoute); err != nil {
 			return fmt.Errorf("failed to install ExternalIP traffic redirecting routes: %w", err)
 		}
 	}
 	return nil
 }
 
-func (p *proxier) uninstallExternalIPService(svcInfoStr string, externalIPStrings []string, svcPort uint16, protocol binding.Protocol) error {
+func (p *proxier) uninstallExternalIPService(svcPortName k8sproxy.ServicePortName, externalIPStrings []string, svcPort uint16, protocol binding.Protocol) error {
 	for _, externalIP := range externalIPStrings {
 		ip := net.ParseIP(externalIP)
 		if err := p.ofClient.UninstallServiceFlows(ip, svcPort, protocol); err != nil {
 			return fmt.Errorf("failed to remove ExternalIP load balancing flows: %w", err)
 		}
-		if err := p.deleteRouteForServiceIP(svcInfoStr, ip, p.routeClient.DeleteExternalIPRoute); err != nil {
+		if err := p.serviceIPRouteReferences.deleteReference(svcPortName, ip, p.routeClient.DeleteExternalIPRoute); err != nil {
 			return fmt.Errorf("failed to remove ExternalIP traffic redirecting routes: %w", err)
 		}
 	}
 	return nil
 }
 
-func (p *proxier) installLoadBalancerService(svcInfoStr string, externalGroupID, clusterGroupID binding.GroupIDType, loadBalancerIPStrings []string, svcPort uint16, protocol binding.Protocol, affinityTimeout uint16) error {
+func (p *proxier) installLoadBalancerService(svcPortName k8sproxy.ServicePortName, externalGroupID, clusterGroupID, dsrLocalGroupID binding.GroupIDType, loadBalancerIPStrings []string, svcPort, svcEndPort uint16, protocol binding.Protocol, affinityTimeout uint16, affinityKey *agenttypes.SessionAffinityKey) error {
 	for _, ingress := range loadBalancerIPStrings {
 		if ingress != "" {
 			ip := net.ParseIP(ingress)
-			if err := p.ofClient.InstallServiceFlows(externalGroupID, clusterGroupID, ip, svcPort, protocol, affinityTimeout, true, false); err != nil {
+			if err := p.installExternalAddressFlows(externalGroupID, clusterGroupID, dsrLocalGroupID, ip, svcPort, svcEndPort, protocol, affinityTimeout, affinityKey); err != nil {
 				return fmt.Errorf("failed to install LoadBalancer load balancing flows: %w", err)
 			}
 			if p.proxyAll {
-				if err := p.addRouteForServiceIP(svcInfoStr, ip, p.routeClient.AddExternalIPRoute); err != nil {
+				if err := p.serviceIPRouteReferences.addReference(svcPortName, ip, p.routeClient.AddExternalIPRoute); err != nil {
 					return fmt.Errorf("failed to install LoadBalancer traffic redirecting routes: %w", err)
 				}
 			}
@@ -443,24 +736,7 @@ func (p *proxier) installLoadBalancerService(svcInfoStr string, externalGroupID,
 	return nil
 }
 
-func (p *proxier) addRouteForServiceIP(svcInfoStr string, ip net.IP, addRouteFn func(net.IP) error) error {
-	ipStr := ip.String()
-	references, exists := p.serviceIPRouteReferences[ipStr]
-	// If the IP was not referenced by any Service port, install a route for it.
-	// Otherwise, just reference it.
-	if !exists {
-		if err := addRouteFn(ip); err != nil {
-			return err
-		}
-		references = sets.New[string](svcInfoStr)
-		p.serviceIPRouteReferences[ipStr] = references
-	} else {
-		references.Insert(svcInfoStr)
-	}
-	return nil
-}
-
-func (p *proxier) uninstallLoadBalancerService(svcInfoStr string, loadBalancerIPStrings []string, svcPort uint16, protocol binding.Protocol) error {
+func (p *proxier) uninstallLoadBalancerService(svcPortName k8sproxy.ServicePortName, loadBalancerIPStrings []string, svcPort uint16, protocol binding.Protocol) error {
 	for _, ingress := range loadBalancerIPStrings {
 		if ingress != "" {
 			ip := net.ParseIP(ingress)
@@ -468,7 +744,7 @@ func (p *proxier) uninstallLoadBalancerService(svcInfoStr string, loadBalancerIP
 				return fmt.Errorf("failed to remove LoadBalancer load balancing flows: %w", err)
 			}
 			if p.proxyAll {
-				if err := p.deleteRouteForServiceIP(svcInfoStr, ip, p.routeClient.DeleteExternalIPRoute); err != nil {
+				if err := p.serviceIPRouteReferences.deleteReference(svcPortName, ip, p.routeClient.DeleteExternalIPRoute); err != nil {
 					return fmt.Errorf("failed to remove LoadBalancer traffic redirecting routes: %w", err)
 				}
 			}
@@ -477,27 +753,23 @@ func (p *proxier) uninstallLoadBalancerService(svcInfoStr string, loadBalancerIP
 	return nil
 }
 
-func (p *proxier) deleteRouteForServiceIP(svcInfoStr string, ip net.IP, deleteRouteFn func(net.IP) error) error {
-	ipStr := ip.String()
-	references, exists := p.serviceIPRouteReferences[ipStr]
-	// If the IP was not referenced by this Service port, skip it.
-	if exists && references.Has(svcInfoStr) {
-		// Delete the IP only if this Service port is the last one referencing it.
-		// Otherwise, just dereference it.
-		if references.Len() == 1 {
-			if err := deleteRouteFn(ip); err != nil {
-				return err
-			}
-			delete(p.serviceIPRouteReferences, ipStr)
-		} else {
-			references.Delete(svcInfoStr)
+// installServices installs the flows of the Service ports in serviceMap. The Service ports in deferred are installed
+// after the other ones.
+func (p *proxier) installServices(deferred sets.Set[k8sproxy.ServicePortName]) {
+	svcPortNames := make([]k8sproxy.ServicePortName, 0, len(p.serviceMap))
+	for svcPortName := range p.serviceMap {
+		if !deferred.Has(svcPortName) {
+			svcPortNames = append(svcPortNames, svcPortName)
 		}
 	}
-	return nil
+	svcPortNames = append(svcPortNames, deferred.UnsortedList()...)
+	p.installServicePorts(svcPortNames)
 }
 
-func (p *proxier) installServices() {
-	for svcPortName, svcPort := range p.serviceMap {
+// installServicePorts installs or updates the flows and groups of the provided Services, in the provided order.
+func (p *proxier) installServicePorts(svcPortNames []k8sproxy.ServicePortName) {
+	for _, svcPortName := range svcPortNames {
+		svcPort := p.serviceMap[svcPortName]
 		svcInfo := svcPort.(*types.ServiceInfo)
 		svcInfoStr := svcInfo.String()
 		endpointsInstalled, ok := p.endpointsInstalledMap[svcPortName]
@@ -506,6 +778,9 @@ func (p *proxier) installServices() {
 			p.endpointsInstalledMap[svcPortName] = endpointsInstalled
 		}
 		endpointsToInstall := p.endpointsMap[svcPortName]
+		if svcInfo.EndPort != 0 {
+			endpointsToInstall = toPortRangeEndpoints(endpointsToInstall)
+		}
 
 		installedSvcPort, ok := p.serviceInstalledMap[svcPortName]
 		var pSvcInfo *types.ServiceInfo
@@ -518,8 +793,10 @@ func (p *proxier) installServices() {
 			needUpdateService = serviceIdentityChanged(svcInfo, pSvcInfo) ||
 				svcInfo.SessionAffinityType() != pSvcInfo.SessionAffinityType() || // All Service flows use it.
 				svcInfo.StickyMaxAgeSeconds() != pSvcInfo.StickyMaxAgeSeconds() || // All Service flows use it.
+				!reflect.DeepEqual(svcInfo.SessionAffinityKey, pSvcInfo.SessionAffinityKey) || // All Service flows use it.
 				svcInfo.ExternalPolicyLocal() != pSvcInfo.ExternalPolicyLocal() || // It affects the group ID used by external Service flows.
-				svcInfo.InternalPolicyLocal() != pSvcInfo.InternalPolicyLocal() // It affects the group ID used by internal Service flows.
+				svcInfo.InternalPolicyLocal() != pSvcInfo.InternalPolicyLocal() || // It affects the group ID used by internal Service flows.
+				svcInfo.LoadBalancerMode != pSvcInfo.LoadBalancerMode // It affects the flows of external addresses.
 			needUpdateServiceExternalAddresses = serviceExternalAddressesChanged(svcInfo, pSvcInfo)
 			needUpdateEndpoints = pSvcInfo.SessionAffinityType() != svcInfo.SessionAffinityType() ||
 				pSvcInfo.ExternalPolicyLocal() != svcInfo.ExternalPolicyLocal() ||
@@ -534,7 +811,14 @@ func (p *proxier) installServices() {
 		clusterEndpoints, localEndpoints, allReachableEndpoints := p.categorizeEndpoints(endpointsToInstall, svcInfo)
 		// Get the stale Endpoints and new Endpoints based on the diff of endpointsInstalled and allReachableEndpoints.
 		staleEndpoints, newEndpoints := compareEndpoints(endpointsInstalled, allReachableEndpoints)
-		if len(staleEndpoints) > 0 || len(newEndpoints) > 0 {
+		// Get the installed Endpoints whose weight has changed, the groups must be updated for them.
+		reweightedEndpoints := compareEndpointWeights(endpointsInstalled, allReachableEndpoints)
+		if len(staleEndpoints) > 0 || len(newEndpoints) > 0 || len(reweightedEndpoints) > 0 {
+			needUpdateEndpoints = true
+		}
+		// The stale Endpoints are drained first if enabled, then only the Endpoints whose draining has completed
+		// are removed.
+		if p.updateDrainingEndpoints(svcPortName, staleEndpoints, newEndpoints) {
 			needUpdateEndpoints = true
 		}
 
@@ -545,12 +829,29 @@ func (p *proxier) installServices() {
 			if !p.removeStaleEndpoints(svcPortName, svcInfo.OFProtocol, staleEndpoints) {
 				continue
 			}
+			if len(staleEndpoints) > 0 {
+				p.clearUDPConntrackEntries(svcInfo, staleEndpoints)
+			}
+			for key, endpoint := range reweightedEndpoints {
+				p.endpointsInstalledMap[svcPortName][key] = endpoint
+			}
+			for key := range staleEndpoints {
+				delete(p.drainingEndpoints[svcPortName], key)
+			}
+			if len(p.drainingEndpoints[svcPortName]) == 0 {
+				delete(p.drainingEndpoints, svcPortName)
+			}
+			clusterEndpoints = p.withDrainingEndpoints(svcPortName, clusterEndpoints, false)
+			localEndpoints = p.withDrainingEndpoints(svcPortName, localEndpoints, true)
 		}
 
 		withSessionAffinity := svcInfo.SessionAffinityType() == corev1.ServiceAffinityClientIP
 		internalPolicyLocal := svcInfo.InternalPolicyLocal()
 		externalPolicyLocal := svcInfo.ExternalPolicyLocal()
-		var internalGroupID, externalGroupID, clusterGroupID binding.GroupIDType
+		loadBalancerModeDSR := p.loadBalancerModeDSREnabled && svcInfo.LoadBalancerMode == agenttypes.LoadBalancerModeDSR
+		// dsrLocalGroupID is the group of the local Endpoints, used to load balance the connections received from the
+		// tunnel in DSR mode. It's 0 if the Service is not load balanced in DSR mode.
+		var internalGroupID, externalGroupID, clusterGroupID, dsrLocalGroupID binding.GroupIDType
 		// Ensure a group for internal traffic exist.
 		if internalGroupID, ok = p.installServiceGroup(svcPortName, needUpdateEndpoints, internalPolicyLocal, withSessionAffinity, localEndpoints, clusterEndpoints); !ok {
 			continue
@@ -565,6 +866,9 @@ func (p *proxier) installServices() {
 					clusterGroupID = internalGroupID
 				} else {
 					clusterGroupID = externalGroupID
+					if loadBalancerModeDSR {
+						dsrLocalGroupID = internalGroupID
+					}
 				}
 			} else {
 				externalGroupID = internalGroupID
@@ -572,6 +876,12 @@ func (p *proxier) installServices() {
 					if clusterGroupID, ok = p.installServiceGroup(svcPortName, needUpdateEndpoints, false, withSessionAffinity, nil, clusterEndpoints); !ok {
 						continue
 					}
+				} else if loadBalancerModeDSR {
+					// Ensure a group of the local Endpoints exists for the connections received from the tunnel.
+					if dsrLocalGroupID, ok = p.installServiceGroup(svcPortName, needUpdateEndpoints, true, withSessionAffinity, filterLocalEndpoints(clusterEndpoints), nil); !ok {
+						continue
+					}
+					clusterGroupID = externalGroupID
 				} else {
 					// Ensure the other group is removed as ExternalTrafficPolicy is the same as InternalTrafficPolicy.
 					if !p.removeServiceGroup(svcPortName, !internalPolicyLocal) {
@@ -590,15 +900,21 @@ func (p *proxier) installServices() {
 		if needUpdateService {
 			// Delete previous flows.
 			if pSvcInfo != nil {
-				if !p.removeServiceFlows(pSvcInfo) {
+				if !p.removeServiceFlows(svcPortName, pSvcInfo) {
 					continue
 				}
+				// The connections to the previous ClusterIP can no longer be load-balanced.
+				if !pSvcInfo.ClusterIP().Equal(svcInfo.ClusterIP()) && isUDPService(pSvcInfo) {
+					if err := p.routeClient.ClearConntrackEntryForService(pSvcInfo.ClusterIP(), uint16(pSvcInfo.Port()), nil, pSvcInfo.OFProtocol); err != nil {
+						klog.ErrorS(err, "Error when clearing conntrack entries for previous ClusterIP of UDP Service", "ServiceInfo", pSvcInfo.String())
+					}
+				}
 			}
-			if !p.installServiceFlows(svcInfo, internalGroupID, externalGroupID, clusterGroupID) {
+			if !p.installServiceFlows(svcPortName, svcInfo, internalGroupID, externalGroupID, clusterGroupID, dsrLocalGroupID) {
 				continue
 			}
 		} else if needUpdateServiceExternalAddresses {
-			if !p.updateServiceExternalAddresses(pSvcInfo, svcInfo, externalGroupID, clusterGroupID) {
+			if !p.updateServiceExternalAddresses(svcPortName, pSvcInfo, svcInfo, externalGroupID, clusterGroupID, dsrLocalGroupID) {
 				continue
 			}
 		}
@@ -629,9 +945,10 @@ func getAffinityTimeout(svcInfo *types.ServiceInfo) uint16 {
 	return uint16(affinityTimeout)
 }
 
-func (p *proxier) installServiceFlows(svcInfo *types.ServiceInfo, internalGroupID, externalGroupID, clusterGroupID binding.GroupIDType) bool {
+func (p *proxier) installServiceFlows(svcPortName k8sproxy.ServicePortName, svcInfo *types.ServiceInfo, internalGroupID, externalGroupID, clusterGroupID, dsrLocalGroupID binding.GroupIDType) bool {
 	svcInfoStr := svcInfo.String()
 	svcPort := uint16(svcInfo.Port())
+	svcEndPort := uint16(svcInfo.EndPort)
 	svcProto := svcInfo.OFProtocol
 	affinityTimeout := getAffinityTimeout(svcInfo)
 
@@ -643,25 +960,31 @@ func (p *proxier) installServiceFlows(svcInfo *types.ServiceInfo, internalGroupI
 	}
 
 	// Install ClusterIP flows.
-	if err := p.ofClient.InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcInfo.ClusterIP(), svcPort, svcProto, affinityTimeout, false, isNestedService); err != nil {
+	var err error
+	if svcEndPort != 0 {
+		err = p.ofClient.InstallServicePortRangeFlows(internalGroupID, binding.GroupIDType(0), svcInfo.ClusterIP(), svcPort, svcEndPort, svcProto, affinityTimeout, svcInfo.SessionAffinityKey, false)
+	} else {
+		err = p.ofClient.InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcInfo.ClusterIP(), svcPort, svcProto, affinityTimeout, svcInfo.SessionAffinityKey, false, isNestedService)
+	}
+	if err != nil {
 		klog.ErrorS(err, "Error when installing ClusterIP flows for Service", "ServiceInfo", svcInfoStr)
 		return false
 	}
 	if p.proxyAll {
 		// Install NodePort flows and configurations.
-		if err := p.installNodePortService(externalGroupID, clusterGroupID, uint16(svcInfo.NodePort()), svcProto, affinityTimeout); err != nil {
+		if err := p.installNodePortService(externalGroupID, clusterGroupID, uint16(svcInfo.NodePort()), svcProto, affinityTimeout, svcInfo.SessionAffinityKey); err != nil {
 			klog.ErrorS(err, "Error when installing NodePort flows and configurations for Service", "ServiceInfo", svcInfoStr)
 			return false
 		}
 		// Install ExternalIP flows and configurations.
-		if err := p.installExternalIPService(svcInfoStr, externalGroupID, clusterGroupID, svcInfo.ExternalIPStrings(), svcPort, svcProto, affinityTimeout); err != nil {
+		if err := p.installExternalIPService(svcPortName, externalGroupID, clusterGroupID, dsrLocalGroupID, svcInfo.ExternalIPStrings(), svcPort, svcEndPort, svcProto, affinityTimeout, svcInfo.SessionAffinityKey); err != nil {
 			klog.ErrorS(err, "Error when installing ExternalIP flows and configurations for Service", "ServiceInfo", svcInfoStr)
 			return false
 		}
 	}
 	// Install LoadBalancer flows and configurations.
 	if p.proxyLoadBalancerIPs {
-		if err := p.installLoadBalancerService(svcInfoStr, externalGroupID, clusterGroupID, svcInfo.LoadBalancerIPStrings(), svcPort, svcProto, affinityTimeout); err != nil {
+		if err := p.installLoadBalancerService(svcPortName, externalGroupID, clusterGroupID, dsrLocalGroupID, svcInfo.LoadBalancerIPStrings(), svcPort, svcEndPort, svcProto, affinityTimeout, svcInfo.SessionAffinityKey); err != nil {
 			klog.ErrorS(err, "Error when installing LoadBalancer flows and configurations for Service", "ServiceInfo", svcInfoStr)
 			return false
 		}
@@ -669,11 +992,12 @@ func (p *proxier) installServiceFlows(svcInfo *types.ServiceInfo, internalGroupI
 	return true
 }
 
-func (p *proxier) updateServiceExternalAddresses(pSvcInfo, svcInfo *types.ServiceInfo, externalGroupID, clusterGroupID binding.GroupIDType) bool {
+func (p *proxier) updateServiceExternalAddresses(svcPortName k8sproxy.ServicePortName, pSvcInfo, svcInfo *types.ServiceInfo, externalGroupID, clusterGroupID, dsrLocalGroupID binding.GroupIDType) bool {
 	pSvcInfoStr := pSvcInfo.String()
 	svcInfoStr := svcInfo.String()
 	pSvcPort := uint16(pSvcInfo.Port())
 	svcPort := uint16(svcInfo.Port())
+	svcEndPort := uint16(svcInfo.EndPort)
 	pSvcNodePort := uint16(pSvcInfo.NodePort())
 	svcNodePort := uint16(svcInfo.NodePort())
 	pSvcProto := pSvcInfo.OFProtocol
@@ -686,18 +1010,18 @@ func (p *proxier) updateServiceExternalAddresses(pSvcInfo, svcInfo *types.Servic
 				klog.ErrorS(err, "Error when uninstalling NodePort flows and configurations for Service", "ServiceInfo", pSvcInfoStr)
 				return false
 			}
-			if err := p.installNodePortService(externalGroupID, clusterGroupID, svcNodePort, svcProto, affinityTimeout); err != nil {
+			if err := p.installNodePortService(externalGroupID, clusterGroupID, svcNodePort, svcProto, affinityTimeout, svcInfo.SessionAffinityKey); err != nil {
 				klog.ErrorS(err, "Error when installing NodePort flows and configurations for Service", "ServiceInfo", svcInfoStr)
 				return false
 			}
 		}
 		deletedExternalIPs := smallSliceDifference(pSvcInfo.ExternalIPStrings(), svcInfo.ExternalIPStrings())
 		addedExternalIPs := smallSliceDifference(svcInfo.ExternalIPStrings(), pSvcInfo.ExternalIPStrings())
-		if err := p.uninstallExternalIPService(pSvcInfoStr, deletedExternalIPs, pSvcPort, pSvcProto); err != nil {
+		if err := p.uninstallExternalIPService(svcPortName, deletedExternalIPs, pSvcPort, pSvcProto); err != nil {
 			klog.ErrorS(err, "Error when uninstalling ExternalIP flows and configurations for Service", "ServiceInfo", pSvcInfoStr)
 			return false
 		}
-		if err := p.installExternalIPService(svcInfoStr, externalGroupID, clusterGroupID, addedExternalIPs, svcPort, svcProto, affinityTimeout); err != nil {
+		if err := p.installExternalIPService(svcPortName, externalGroupID, clusterGroupID, dsrLocalGroupID, addedExternalIPs, svcPort, svcEndPort, svcProto, affinityTimeout, svcInfo.SessionAffinityKey); err != nil {
 			klog.ErrorS(err, "Error when installing ExternalIP flows and configurations for Service", "ServiceInfo", svcInfoStr)
 			return false
 		}
@@ -705,11 +1029,11 @@ func (p *proxier) updateServiceExternalAddresses(pSvcInfo, svcInfo *types.Servic
 	if p.proxyLoadBalancerIPs {
 		deletedLoadBalancerIPs := smallSliceDifference(pSvcInfo.LoadBalancerIPStrings(), svcInfo.LoadBalancerIPStrings())
 		addedLoadBalancerIPs := smallSliceDifference(svcInfo.LoadBalancerIPStrings(), pSvcInfo.LoadBalancerIPStrings())
-		if err := p.uninstallLoadBalancerService(pSvcInfoStr, deletedLoadBalancerIPs, pSvcPort, pSvcProto); err != nil {
+		if err := p.uninstallLoadBalancerService(svcPortName, deletedLoadBalancerIPs, pSvcPort, pSvcProto); err != nil {
 			klog.ErrorS(err, "Error when uninstalling LoadBalancer flows and configurations for Service", "ServiceInfo", pSvcInfoStr)
 			return false
 		}
-		if err := p.installLoadBalancerService(svcInfoStr, externalGroupID, clusterGroupID, addedLoadBalancerIPs, svcPort, svcProto, affinityTimeout); err != nil {
+		if err := p.installLoadBalancerService(svcPortName, externalGroupID, clusterGroupID, dsrLocalGroupID, addedLoadBalancerIPs, svcPort, svcEndPort, svcProto, affinityTimeout, svcInfo.SessionAffinityKey); err != nil {
 			klog.ErrorS(err, "Error when installing LoadBalancer flows and configurations for Service", "ServiceInfo", svcInfoStr)
 			return false
 		}
@@ -741,6 +1065,17 @@ func compareEndpoints(endpointsCached map[string]k8sproxy.Endpoint, endpointsIns
 	return endpointsToRemove, endpointsToAdd
 }
 
+// compareEndpointWeights returns the Endpoints which are already installed but whose weight has changed.
+func compareEndpointWeights(endpointsCached map[string]k8sproxy.Endpoint, endpointsInstalled []k8sproxy.Endpoint) map[string]k8sproxy.Endpoint {
+	endpointsToUpdate := map[string]k8sproxy.Endpoint{}
+	for _, endpoint := range endpointsInstalled {
+		if cached, exists := endpointsCached[endpoint.String()]; exists && cached.GetWeight() != endpoint.GetWeight() {
+			endpointsToUpdate[endpoint.String()] = endpoint
+		}
+	}
+	return endpointsToUpdate
+}
+
 // syncProxyRules applies current changes in change trackers and then updates
 // flows for services and endpoints. It will return immediately if either
 // endpoints or services resources are not synced. syncProxyRules is only called
@@ -755,6 +1090,10 @@ func (p *proxier) syncProxyRules() {
 		return
 	}
 
+	// Wait for the turn of AntreaProxy if OVS programming is throttled among features.
+	release := p.reconcileScheduler.Acquire(flowscheduler.FeatureProxy)
+	defer release()
+
 	start := time.Now()
 	defer func() {
 		delta := time.Since(start)
@@ -773,8 +1112,9 @@ func (p *proxier) syncProxyRules() {
 	p.endpointsChanges.Update(p.endpointsMap, p.numLocalEndpoints)
 	serviceUpdateResult := p.serviceChanges.Update(p.serviceMap)
 
+	servicesTakingOverIPs := p.resolveExternalIPConflicts()
 	p.removeStaleServices()
-	p.installServices()
+	p.installServices(servicesTakingOverIPs)
 
 	if p.serviceHealthServer != nil {
 		if err := p.serviceHealthServer.SyncServices(serviceUpdateResult.HCServiceNodePorts); err != nil {
@@ -796,6 +1136,7 @@ func (p *proxier) syncProxyRules() {
 		metrics.ServicesInstalledTotal.Set(float64(len(p.serviceMap)))
 		metrics.EndpointsInstalledTotal.Set(float64(counter))
 	}
+	p.updateEndpointDistributionMetrics()
 
 	p.syncedOnceMutex.Lock()
 	defer p.syncedOnceMutex.Unlock()
@@ -879,7 +1220,10 @@ func (p *proxier) OnServiceUpdate(oldService, service *corev1.Service) {
 	} else {
 		metrics.ServicesUpdatesTotal.Inc()
 	}
-	if p.serviceChanges.OnServiceUpdate(oldService, service) {
+	serviceChanged := p.serviceChanges.OnServiceUpdate(oldService, service)
+	// The Endpoints of the Service must be updated when its publishNotReadyAddresses changes.
+	endpointsChanged := p.endpointsChanges.OnServiceUpdate(oldService, service)
+	if serviceChanged || endpointsChanged {
 		if p.isInitialized() {
 			p.runner.Run()
 		}
@@ -904,20 +1248,7 @@ func (p *proxier) OnNodeAdd(node *corev1.Node) {
 	if node.Name != p.hostname {
 		return
 	}
-
-	if reflect.DeepEqual(p.nodeLabels, node.Labels) {
-		return
-	}
-
-	p.serviceEndpointsMapsMutex.Lock()
-	p.nodeLabels = map[string]string{}
-	for k, v := range node.Labels {
-		p.nodeLabels[k] = v
-	}
-	p.serviceEndpointsMapsMutex.Unlock()
-	klog.V(4).InfoS("Updated proxier Node labels", "labels", node.Labels)
-
-	p.syncProxyRules()
+	p.updateNodeLabels(node.Labels)
 }
 
 // OnNodeUpdate is called whenever modification of an existing
@@ -926,33 +1257,65 @@ func (p *proxier) OnNodeUpdate(oldNode, node *corev1.Node) {
 	if node.Name != p.hostname {
 		return
 	}
+	p.updateNodeLabels(node.Labels)
+}
 
-	if reflect.DeepEqual(p.nodeLabels, node.Labels) {
+// OnNodeDelete is called whenever deletion of an existing node
+// object is observed.
+func (p *proxier) OnNodeDelete(node *corev1.Node) {
+	if node.Name != p.hostname {
 		return
 	}
+	p.updateNodeLabels(nil)
+}
 
+// updateNodeLabels saves the labels of the Node. The labels only affect the Services using topology aware routing,
+// so only these Services are re-evaluated, and only when the topology labels of the Node have changed.
+func (p *proxier) updateNodeLabels(labels map[string]string) {
 	p.serviceEndpointsMapsMutex.Lock()
-	p.nodeLabels = map[string]string{}
-	for k, v := range node.Labels {
-		p.nodeLabels[k] = v
+	if reflect.DeepEqual(p.nodeLabels, labels) {
+		p.serviceEndpointsMapsMutex.Unlock()
+		return
+	}
+	topologyChanged := topologyLabelsChanged(p.nodeLabels, labels)
+	var nodeLabels map[string]string
+	if labels != nil {
+		nodeLabels = make(map[string]string, len(labels))
+		for k, v := range labels {
+			nodeLabels[k] = v
+		}
 	}
+	p.nodeLabels = nodeLabels
 	p.serviceEndpointsMapsMutex.Unlock()
-	klog.V(4).InfoS("Updated proxier Node labels", "labels", node.Labels)
+	klog.V(4).InfoS("Updated proxier Node labels", "labels", labels)
 
-	p.syncProxyRules()
+	if topologyChanged {
+		cpuaccounting.Run(cpuaccounting.FeatureProxy, p.syncTopologyAwareServices)
+	}
 }
 
-// OnNodeDelete is called whenever deletion of an existing node
-// object is observed.
-func (p *proxier) OnNodeDelete(node *corev1.Node) {
-	if node.Name != p.hostname {
+// syncTopologyAwareServices re-evaluates the Endpoints of the Services using topology aware routing, after the
+// topology labels of the Node have changed. The installed state of the other Services doesn't depend on the Node
+// labels, and the pending Service and Endpoints changes are left to the next syncProxyRules.
+func (p *proxier) syncTopologyAwareServices() {
+	if !p.isInitialized() {
+		klog.V(4).Info("Not syncing topology aware Services until both Services and Endpoints have been synced")
 		return
 	}
-	p.serviceEndpointsMapsMutex.Lock()
-	p.nodeLabels = nil
-	p.serviceEndpointsMapsMutex.Unlock()
 
-	p.syncProxyRules()
+	release := p.reconcileScheduler.Acquire(flowscheduler.FeatureProxy)
+	defer release()
+
+	p.serviceEndpointsMapsMutex.Lock()
+	defer p.serviceEndpointsMapsMutex.Unlock()
+	var svcPortNames []k8sproxy.ServicePortName
+	for svcPortName, svcPort := range p.serviceMap {
+		if p.usesTopologyHints(svcPort) {
+			svcPortNames = append(svcPortNames, svcPortName)
+		}
+	}
+	klog.V(2).InfoS("Node topology labels changed, re-evaluating topology aware Services", "count", len(svcPortNames))
+	p.installServicePorts(svcPortNames)
 }
 
 // OnNodeSynced is called once all the initial event handlers were
@@ -1048,6 +1411,147 @@ func (p *proxier) GetServiceFlowKeys(serviceName, namespace string) ([]string, [
 	return flows, groups, found
 }
 
+func (p *proxier) UpdateSkipServices(skipServices []string, skipServiceSelector labels.Selector) {
+	services, err := p.serviceLister.List(labels.Everything())
+	if err != nil {
+		// It should never happen as listing from the informer's cache doesn't fail.
+		klog.ErrorS(err, "Failed to list Services")
+		return
+	}
+	if p.serviceChanges.OnSkipServicesUpdate(sets.New[string](skipServices...), skipServiceSelector, services) {
+		klog.InfoS("Services to skip updated, syncing proxy rules", "skipServices", skipServices, "skipServiceSelector", skipServiceSelector)
+		if p.isInitialized() {
+			p.runner.Run()
+		}
+	}
+}
+
+func (p *proxier) UpdateNodePortAddresses(nodePortAddressesIPv4, nodePortAddressesIPv6 []net.IP) error {
+	if !p.proxyAll {
+		return nil
+	}
+	nodePortAddresses := nodePortAddressesIPv4
+	if p.isIPv6 {
+		nodePortAddresses = nodePortAddressesIPv6
+	}
+	// Protect serviceInstalledMap and nodePortAddresses, which are accessed by syncProxyRules.
+	p.serviceEndpointsMapsMutex.Lock()
+	defer p.serviceEndpointsMapsMutex.Unlock()
+
+	ipStrings := func(ips []net.IP) sets.Set[string] {
+		s := sets.New[string]()
+		for _, ip := range ips {
+			s.Insert(ip.String())
+		}
+		return s
+	}
+	oldAddresses, newAddresses := ipStrings(p.nodePortAddresses), ipStrings(nodePortAddresses)
+	var addedAddresses, removedAddresses []net.IP
+	for _, ip := range nodePortAddresses {
+		if !oldAddresses.Has(ip.String()) {
+			addedAddresses = append(addedAddresses, ip)
+		}
+	}
+	for _, ip := range p.nodePortAddresses {
+		if !newAddresses.Has(ip.String()) {
+			removedAddresses = append(removedAddresses, ip)
+		}
+	}
+	if len(addedAddresses) == 0 && len(removedAddresses) == 0 {
+		return nil
+	}
+	klog.InfoS("Updating NodePort addresses", "added", addedAddresses, "removed", removedAddresses)
+
+	var errs []error
+	for _, svcPort := range p.serviceInstalledMap {
+		svcInfo := svcPort.(*types.ServiceInfo)
+		nodePort := uint16(svcInfo.NodePort())
+		if nodePort == 0 {
+			continue
+		}
+		if len(removedAddresses) > 0 {
+			if err := p.routeClient.DeleteNodePort(removedAddresses, nodePort, svcInfo.OFProtocol); err != nil {
+				errs = append(errs, fmt.Errorf("failed to remove NodePort traffic redirecting rules for Service %s: %w", svcInfo.String(), err))
+			}
+		}
+		if len(addedAddresses) > 0 {
+			if err := p.routeClient.AddNodePort(addedAddresses, nodePort, svcInfo.OFProtocol); err != nil {
+				errs = append(errs, fmt.Errorf("failed to install NodePort traffic redirecting rules for Service %s: %w", svcInfo.String(), err))
+			}
+		}
+	}
+	// The new addresses are used for the Services installed later even if some rules failed to be updated.
+	p.nodePortAddresses = nodePortAddresses
+	return utilerrors.NewAggregate(errs)
+}
+
+func (p *proxier) GetServiceStates() []types.ServiceState {
+	p.serviceEndpointsMapsMutex.Lock()
+	defer p.serviceEndpointsMapsMutex.Unlock()
+
+	svcPortNames := sets.New[k8sproxy.ServicePortName]()
+	for svcPortName := range p.serviceMap {
+		svcPortNames.Insert(svcPortName)
+	}
+	for svcPortName := range p.serviceInstalledMap {
+		svcPortNames.Insert(svcPortName)
+	}
+	states := make([]types.ServiceState, 0, len(svcPortNames))
+	for svcPortName := range svcPortNames {
+		state := types.ServiceState{ServicePortName: svcPortName.String()}
+		svcPort, installed := p.serviceInstalledMap[svcPortName]
+		if !installed {
+			svcPort = p.serviceMap[svcPortName]
+		}
+		state.Installed = installed
+		state.ClusterIP = svcPort.ClusterIP().String()
+		state.Port = svcPort.Port()
+		state.EndPort = svcPort.(*types.ServiceInfo).EndPort
+		state.Protocol = string(svcPort.Protocol())
+		state.NodePort = svcPort.NodePort()
+		state.ExternalIPs = svcPort.ExternalIPStrings()
+		state.LoadBalancerIPs = svcPort.LoadBalancerIPStrings()
+		if groupID, ok := p.groupCounter.Get(svcPortName, false); ok {
+			id := uint32(groupID)
+			state.GroupID = &id
+		}
+		if groupID, ok := p.groupCounter.Get(svcPortName, true); ok {
+			id := uint32(groupID)
+			state.LocalGroupID = &id
+		}
+		for _, endpoint := range p.endpointsInstalledMap[svcPortName] {
+			state.Endpoints = append(state.Endpoints, newEndpointState(endpoint, endpoint.GetWeight(), false))
+		}
+		for _, endpoint := range p.drainingEndpoints[svcPortName] {
+			state.Endpoints = append(state.Endpoints, newEndpointState(endpoint.Endpoint, 0, true))
+		}
+		sort.Slice(state.Endpoints, func(i, j int) bool {
+			return state.Endpoints[i].Endpoint < state.Endpoints[j].Endpoint
+		})
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].ServicePortName < states[j].ServicePortName
+	})
+	return states
+}
+
+func (p *proxier) GetServiceIPRouteReferences() []types.ServiceIPRouteReference {
+	return p.serviceIPRouteReferences.getReferences()
+}
+
+func newEndpointState(endpoint k8sproxy.Endpoint, weight uint16, draining bool) types.EndpointState {
+	return types.EndpointState{
+		Endpoint:    endpoint.String(),
+		IsLocal:     endpoint.GetIsLocal(),
+		Ready:       endpoint.IsReady(),
+		Serving:     endpoint.IsServing(),
+		Terminating: endpoint.IsTerminating(),
+		Weight:      weight,
+		Draining:    draining,
+	}
+}
+
 func (p *proxier) HandlePacketIn(pktIn *ofctrl.PacketIn) error {
 	if pktIn == nil {
 		return fmt.Errorf("empty packetin for Antrea Proxy")
@@ -1088,7 +1592,7 @@ func (p *proxier) HandlePacketIn(pktIn *ofctrl.PacketIn) error {
 	// It cannot use CONTROLLER (the default value when inPort is 0) as the inPort due to a bug in Windows ovsext
 	// driver, otherwise the Windows OS would crash. See https://github.com/openvswitch/ovs-issues/issues/280.
 	inPort := uint32(openflow15.P_LOCAL)
-	return openflow.SendRejectPacketOut(p.ofClient,
+	return openflow.SendServiceRejectPacketOut(p.ofClient,
 		srcMAC,
 		dstMAC,
 		srcIP,
@@ -1112,10 +1616,17 @@ func newProxier(
 	nodePortAddresses []net.IP,
 	proxyAllEnabled bool,
 	skipServices []string,
+	skipServiceProtocols []corev1.Protocol,
 	proxyLoadBalancerIPs bool,
+	endpointDrainingTimeout time.Duration,
+	endpointReadinessGate string,
 	groupCounter types.GroupCounter,
-	supportNestedService bool) (*proxier, error) {
-	recorder := record.NewBroadcaster().NewRecorder(
+	supportNestedService bool,
+	reconcileScheduler *flowscheduler.Scheduler,
+	numaAffinity *NUMAAffinity) (*proxier, error) {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: k8sClient.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(
 		runtime.NewScheme(),
 		corev1.EventSource{Component: componentName, Host: hostname},
 	)
@@ -1134,6 +1645,8 @@ func newProxier(
 		}
 	}
 	topologyAwareHintsEnabled := endpointSliceEnabled && features.DefaultFeatureGate.Enabled(features.TopologyAwareHints)
+	// DSR mode is only supported for IPv4.
+	loadBalancerModeDSREnabled := !isIPv6 && features.DefaultFeatureGate.Enabled(features.LoadBalancerModeDSR)
 	ipFamily := corev1.IPv4Protocol
 	if isIPv6 {
 		ipFamily = corev1.IPv6Protocol
@@ -1163,34 +1676,46 @@ func newProxier(
 	serviceLabelSelector = serviceLabelSelector.Add(*serviceProxyNameSelector, *nonHeadlessServiceSelector)
 
 	p := &proxier{
-		serviceConfig:             config.NewServiceConfig(informerFactory.Core().V1().Services(), resyncPeriod),
-		endpointsChanges:          newEndpointsChangesTracker(hostname, endpointSliceEnabled, isIPv6),
-		serviceChanges:            newServiceChangesTracker(recorder, ipFamily, serviceLabelSelector, skipServices),
-		serviceMap:                k8sproxy.ServiceMap{},
-		serviceInstalledMap:       k8sproxy.ServiceMap{},
-		endpointsInstalledMap:     types.EndpointsMap{},
-		endpointsMap:              types.EndpointsMap{},
-		endpointReferenceCounter:  map[string]int{},
-		serviceIPRouteReferences:  map[string]sets.Set[string]{},
-		nodeLabels:                map[string]string{},
-		serviceStringMap:          map[string]k8sproxy.ServicePortName{},
-		groupCounter:              groupCounter,
-		ofClient:                  ofClient,
-		routeClient:               routeClient,
-		nodePortAddresses:         nodePortAddresses,
-		isIPv6:                    isIPv6,
-		proxyAll:                  proxyAllEnabled,
-		endpointSliceEnabled:      endpointSliceEnabled,
-		topologyAwareHintsEnabled: topologyAwareHintsEnabled,
-		proxyLoadBalancerIPs:      proxyLoadBalancerIPs,
-		hostname:                  hostname,
-		serviceHealthServer:       serviceHealthServer,
-		numLocalEndpoints:         map[apimachinerytypes.NamespacedName]int{},
-		supportNestedService:      supportNestedService,
+		serviceConfig:              config.NewServiceConfig(informerFactory.Core().V1().Services(), resyncPeriod),
+		serviceLister:              informerFactory.Core().V1().Services().Lister(),
+		endpointsChanges:           newEndpointsChangesTracker(hostname, endpointSliceEnabled, isIPv6, endpointReadinessGate),
+		serviceChanges:             newServiceChangesTracker(recorder, ipFamily, serviceLabelSelector, skipServices, skipServiceProtocols),
+		serviceMap:                 k8sproxy.ServiceMap{},
+		serviceInstalledMap:        k8sproxy.ServiceMap{},
+		endpointsInstalledMap:      types.EndpointsMap{},
+		endpointsMap:               types.EndpointsMap{},
+		endpointReferenceCounter:   map[string]int{},
+		drainingEndpoints:          map[k8sproxy.ServicePortName]map[string]*drainingEndpoint{},
+		groupBuckets:               map[k8sproxy.ServicePortName]map[bool]int{},
+		reportedServicePorts:       sets.New[k8sproxy.ServicePortName](),
+		endpointDrainingTimeout:    endpointDrainingTimeout,
+		clock:                      clock.RealClock{},
+		serviceIPRouteReferences:   newServiceIPRouteReferences(),
+		nodeLabels:                 map[string]string{},
+		serviceStringMap:           map[string]k8sproxy.ServicePortName{},
+		groupCounter:               groupCounter,
+		ofClient:                   ofClient,
+		routeClient:                routeClient,
+		nodePortAddresses:          nodePortAddresses,
+		isIPv6:                     isIPv6,
+		proxyAll:                   proxyAllEnabled,
+		endpointSliceEnabled:       endpointSliceEnabled,
+		topologyAwareHintsEnabled:  topologyAwareHintsEnabled,
+		proxyLoadBalancerIPs:       proxyLoadBalancerIPs,
+		hostname:                   hostname,
+		serviceHealthServer:        serviceHealthServer,
+		numLocalEndpoints:          map[apimachinerytypes.NamespacedName]int{},
+		supportNestedService:       supportNestedService,
+		loadBalancerModeDSREnabled: loadBalancerModeDSREnabled,
+		reconcileScheduler:         reconcileScheduler,
+		numaAffinity:               numaAffinity,
+		recorder:                   recorder,
 	}
 
 	p.serviceConfig.RegisterEventHandler(p)
-	p.runner = k8sproxy.NewBoundedFrequencyRunner(componentName, p.syncProxyRules, time.Second, 30*time.Second, 2)
+	p.runner = k8sproxy.NewBoundedFrequencyRunner(componentName, func() {
+		cpuaccounting.Run(cpuaccounting.FeatureProxy, p.syncProxyRules)
+	}, time.Second, 30*time.Second, 2)
 	if endpointSliceEnabled {
 		p.endpointSliceConfig = config.NewEndpointSliceConfig(informerFactory.Discovery().V1().EndpointSlices(), resyncPeriod)
 		p.endpointSliceConfig.RegisterEventHandler(p)
@@ -1225,6 +1750,27 @@ func (p *metaProxierWrapper) GetServiceFlowKeys(serviceName, namespace string) (
 	return append(v4Flows, v6Flows...), append(v4Groups, v6Groups...), v4Found || v6Found
 }
 
+func (p *metaProxierWrapper) GetServiceStates() []types.ServiceState {
+	return append(p.ipv4Proxier.GetServiceStates(), p.ipv6Proxier.GetServiceStates()...)
+}
+
+func (p *metaProxierWrapper) GetServiceIPRouteReferences() []types.ServiceIPRouteReference {
+	// The references are shared by the two proxier instances.
+	return p.ipv4Proxier.GetServiceIPRouteReferences()
+}
+
+func (p *metaProxierWrapper) UpdateNodePortAddresses(nodePortAddressesIPv4, nodePortAddressesIPv6 []net.IP) error {
+	return utilerrors.NewAggregate([]error{
+		p.ipv4Proxier.UpdateNodePortAddresses(nodePortAddressesIPv4, nodePortAddressesIPv6),
+		p.ipv6Proxier.UpdateNodePortAddresses(nodePortAddressesIPv4, nodePortAddressesIPv6),
+	})
+}
+
+func (p *metaProxierWrapper) UpdateSkipServices(skipServices []string, skipServiceSelector labels.Selector) {
+	p.ipv4Proxier.UpdateSkipServices(skipServices, skipServiceSelector)
+	p.ipv6Proxier.UpdateSkipServices(skipServices, skipServiceSelector)
+}
+
 func (p *metaProxierWrapper) GetServiceByIP(serviceStr string) (k8sproxy.ServicePortName, bool) {
 	// Format of serviceStr is <clusterIP>:<svcPort>/<protocol>.
 	lastColonIndex := strings.LastIndex(serviceStr, ":")
@@ -1245,10 +1791,15 @@ func newDualStackProxier(
 	nodePortAddressesIPv6 []net.IP,
 	proxyAllEnabled bool,
 	skipServices []string,
+	skipServiceProtocols []corev1.Protocol,
 	proxyLoadBalancerIPs bool,
+	endpointDrainingTimeout time.Duration,
+	endpointReadinessGate string,
 	v4groupCounter types.GroupCounter,
 	v6groupCounter types.GroupCounter,
-	nestedServiceSupport bool) (*metaProxierWrapper, error) {
+	nestedServiceSupport bool,
+	reconcileScheduler *flowscheduler.Scheduler,
+	numaAffinity *NUMAAffinity) (*metaProxierWrapper, error) {
 
 	// Create an IPv4 instance of the single-stack proxier.
 	ipv4Proxier, err := newProxier(hostname,
@@ -1261,9 +1812,14 @@ func newDualStackProxier(
 		nodePortAddressesIPv4,
 		proxyAllEnabled,
 		skipServices,
+		skipServiceProtocols,
 		proxyLoadBalancerIPs,
+		endpointDrainingTimeout,
+		endpointReadinessGate,
 		v4groupCounter,
-		nestedServiceSupport)
+		nestedServiceSupport,
+		reconcileScheduler,
+		numaAffinity)
 	if err != nil {
 		return nil, fmt.Errorf("error when creating IPv4 proxier: %v", err)
 	}
@@ -1278,12 +1834,20 @@ func newDualStackProxier(
 		nodePortAddressesIPv6,
 		proxyAllEnabled,
 		skipServices,
+		skipServiceProtocols,
 		proxyLoadBalancerIPs,
+		endpointDrainingTimeout,
+		endpointReadinessGate,
 		v6groupCounter,
-		nestedServiceSupport)
+		nestedServiceSupport,
+		reconcileScheduler,
+		numaAffinity)
 	if err != nil {
 		return nil, fmt.Errorf("error when creating IPv6 proxier: %v", err)
 	}
+	// Share the Service IP route references between the two proxier instances, which sync their rules concurrently,
+	// so that a route is only removed when the last Service port referencing it goes away.
+	ipv6Proxier.serviceIPRouteReferences = ipv4Proxier.serviceIPRouteReferences
 	// Create a meta-proxier that dispatch calls between the two
 	// single-stack proxier instances.
 	metaProxier := k8sproxy.NewMetaProxier(ipv4Proxier, ipv6Proxier)
@@ -1300,14 +1864,22 @@ func NewProxier(hostname string,
 	nodePortAddressesIPv4 []net.IP,
 	nodePortAddressesIPv6 []net.IP,
 	proxyConfig antreaconfig.AntreaProxyConfig,
+	endpointDrainingTimeout time.Duration,
 	v4GroupCounter types.GroupCounter,
 	v6GroupCounter types.GroupCounter,
 	nestedServiceSupport bool,
-	informerFactory informers.SharedInformerFactory) (Proxier, error) {
+	informerFactory informers.SharedInformerFactory,
+	reconcileScheduler *flowscheduler.Scheduler,
+	numaAffinity *NUMAAffinity) (Proxier, error) {
 	proxyAllEnabled := proxyConfig.ProxyAll
 	skipServices := proxyConfig.SkipServices
+	skipServiceProtocols := make([]corev1.Protocol, 0, len(proxyConfig.SkipServiceProtocols))
+	for _, protocol := range proxyConfig.SkipServiceProtocols {
+		skipServiceProtocols = append(skipServiceProtocols, corev1.Protocol(protocol))
+	}
 	proxyLoadBalancerIPs := *proxyConfig.ProxyLoadBalancerIPs
 	serviceProxyName := proxyConfig.ServiceProxyName
+	endpointReadinessGate := proxyConfig.EndpointReadinessGate
 
 	var proxier Proxier
 	var err error
@@ -1323,10 +1895,15 @@ func NewProxier(hostname string,
 			nodePortAddressesIPv6,
 			proxyAllEnabled,
 			skipServices,
+			skipServiceProtocols,
 			proxyLoadBalancerIPs,
+			endpointDrainingTimeout,
+			endpointReadinessGate,
 			v4GroupCounter,
 			v6GroupCounter,
-			nestedServiceSupport)
+			nestedServiceSupport,
+			reconcileScheduler,
+			numaAffinity)
 		if err != nil {
 			return nil, fmt.Errorf("error when creating dual-stack proxier: %v", err)
 		}
@@ -1341,9 +1918,14 @@ func NewProxier(hostname string,
 			nodePortAddressesIPv4,
 			proxyAllEnabled,
 			skipServices,
+			skipServiceProtocols,
 			proxyLoadBalancerIPs,
+			endpointDrainingTimeout,
+			endpointReadinessGate,
 			v4GroupCounter,
-			nestedServiceSupport)
+			nestedServiceSupport,
+			reconcileScheduler,
+			numaAffinity)
 		if err != nil {
 			return nil, fmt.Errorf("error when creating IPv4 proxier: %v", err)
 		}
@@ -1358,9 +1940,14 @@ func NewProxier(hostname string,
 			nodePortAddressesIPv6,
 			proxyAllEnabled,
 			skipServices,
+			skipServiceProtocols,
 			proxyLoadBalancerIPs,
+			endpointDrainingTimeout,
+			endpointReadinessGate,
 			v6GroupCounter,
-			nestedServiceSupport)
+			nestedServiceSupport,
+			reconcileScheduler,
+			numaAffinity)
 		if err != nil {
 			return nil, fmt.Errorf("error when creating IPv6 proxier: %v", err)
 		}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	discovery "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,8 +34,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
+	kmetrics "k8s.io/component-base/metrics"
 	"k8s.io/component-base/metrics/legacyregistry"
 	"k8s.io/component-base/metrics/testutil"
+	clocktesting "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
 
 	mccommon "antrea.io/antrea/multicluster/controllers/multicluster/common"
@@ -45,6 +48,7 @@ import (
 	"antrea.io/antrea/pkg/agent/proxy/types"
 	"antrea.io/antrea/pkg/agent/route"
 	routemock "antrea.io/antrea/pkg/agent/route/testing"
+	agenttypes "antrea.io/antrea/pkg/agent/types"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
 	k8sproxy "antrea.io/antrea/third_party/proxy"
 )
@@ -329,6 +333,10 @@ type proxyOptions struct {
 	endpointSliceEnabled bool
 	supportNestedService bool
 	serviceProxyNameSet  bool
+	// endpointDrainingTimeout is 0 by default, which disables Endpoint draining.
+	endpointDrainingTimeout    time.Duration
+	skipServiceProtocols       []corev1.Protocol
+	loadBalancerModeDSREnabled bool
 }
 
 type proxyOptionsFn func(*proxyOptions)
@@ -353,6 +361,22 @@ func withServiceProxyNameSet(o *proxyOptions) {
 	o.serviceProxyNameSet = true
 }
 
+func withLoadBalancerModeDSR(o *proxyOptions) {
+	o.loadBalancerModeDSREnabled = true
+}
+
+func withSkipServiceProtocols(protocols ...corev1.Protocol) proxyOptionsFn {
+	return func(o *proxyOptions) {
+		o.skipServiceProtocols = protocols
+	}
+}
+
+func withEndpointDrainingTimeout(timeout time.Duration) proxyOptionsFn {
+	return func(o *proxyOptions) {
+		o.endpointDrainingTimeout = timeout
+	}
+}
+
 func getMockClients(ctrl *gomock.Controller) (*ofmock.MockClient, *routemock.MockInterface) {
 	mockOFClient := ofmock.NewMockClient(ctrl)
 	mockRouteClient := routemock.NewMockInterface(ctrl)
@@ -386,10 +410,14 @@ func newFakeProxier(routeClient route.Interface, ofClient openflow.Client, nodeP
 		nodePortAddresses,
 		o.proxyAllEnabled,
 		[]string{skippedServiceNN, skippedClusterIP},
+		o.skipServiceProtocols,
 		o.proxyLoadBalancerIPs,
-		types.NewGroupCounter(groupIDAllocator, make(chan string, 100)), o.supportNestedService)
+		o.endpointDrainingTimeout,
+		"",
+		types.NewGroupCounter(groupIDAllocator, make(chan string, 100), isIPv6), o.supportNestedService, nil, nil)
 	p.runner = k8sproxy.NewBoundedFrequencyRunner(componentName, p.syncProxyRules, time.Second, 30*time.Second, 2)
-	p.endpointsChanges = newEndpointsChangesTracker(hostname, o.endpointSliceEnabled, isIPv6)
+	p.endpointsChanges = newEndpointsChangesTracker(hostname, o.endpointSliceEnabled, isIPv6, "")
+	p.loadBalancerModeDSREnabled = o.loadBalancerModeDSREnabled
 	return p
 }
 
@@ -461,21 +489,21 @@ func testClusterIPAdd(t *testing.T,
 	if nodeLocalInternal == false {
 		mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.InAnyOrder(expectedAllEps)).Times(1)
 		mockOFClient.EXPECT().InstallServiceGroup(internalGroupID, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, true).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, true).Times(1)
 		if externalIP != nil {
 			externalGroupID = internalGroupID
 			clusterGroupID = internalGroupID
-			mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+			mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		}
 	} else {
 		mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.InAnyOrder(expectedAllEps)).Times(1)
 		mockOFClient.EXPECT().InstallServiceGroup(internalGroupID, false, gomock.InAnyOrder(expectedLocalEps)).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, true).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, true).Times(1)
 		if externalIP != nil {
 			externalGroupID = fp.groupCounter.AllocateIfNotExist(svcPortName, false)
 			clusterGroupID = externalGroupID
 			mockOFClient.EXPECT().InstallServiceGroup(externalGroupID, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
-			mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+			mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		}
 	}
 	if externalIP != nil {
@@ -581,14 +609,14 @@ func testLoadBalancerAdd(t *testing.T,
 			clusterGroupID = internalGroupID
 		}
 		mockOFClient.EXPECT().InstallServiceGroup(internalGroupID, false, gomock.InAnyOrder(clusterIPEps)).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
 		mockOFClient.EXPECT().InstallServiceGroup(externalGroupID, false, gomock.InAnyOrder(nodePortEps)).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		if proxyLoadBalancerIPs {
-			mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+			mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		}
 		if externalIP != nil {
-			mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+			mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		}
 	} else {
 		nodeLocalVal := nodeLocalInternal && nodeLocalExternal
@@ -603,13 +631,13 @@ func testLoadBalancerAdd(t *testing.T,
 			mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
 			mockOFClient.EXPECT().UninstallServiceGroup(fp.groupCounter.AllocateIfNotExist(svcPortName, !nodeLocalVal)).Times(1)
 		}
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, clusterGroupID, vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, clusterGroupID, vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		if proxyLoadBalancerIPs {
-			mockOFClient.EXPECT().InstallServiceFlows(groupID, clusterGroupID, loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+			mockOFClient.EXPECT().InstallServiceFlows(groupID, clusterGroupID, loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		}
 		if externalIP != nil {
-			mockOFClient.EXPECT().InstallServiceFlows(groupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+			mockOFClient.EXPECT().InstallServiceFlows(groupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		}
 	}
 	if proxyLoadBalancerIPs {
@@ -715,11 +743,11 @@ func testNodePortAdd(t *testing.T,
 		}
 
 		mockOFClient.EXPECT().InstallServiceGroup(internalGroupID, false, gomock.InAnyOrder(clusterIPEps)).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
 		mockOFClient.EXPECT().InstallServiceGroup(externalGroupID, false, gomock.InAnyOrder(nodePortEps)).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		if externalIP != nil {
-			mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+			mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		}
 	} else {
 		nodeLocalVal := nodeLocalInternal && nodeLocalExternal
@@ -734,10 +762,10 @@ func testNodePortAdd(t *testing.T,
 			mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
 			mockOFClient.EXPECT().UninstallServiceGroup(fp.groupCounter.AllocateIfNotExist(svcPortName, !nodeLocalVal)).Times(1)
 		}
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, clusterGroupID, vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, clusterGroupID, vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		if externalIP != nil {
-			mockOFClient.EXPECT().InstallServiceFlows(groupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+			mockOFClient.EXPECT().InstallServiceFlows(groupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		}
 	}
 	mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
@@ -976,18 +1004,18 @@ func TestLoadBalancerServiceWithMultiplePorts(t *testing.T) {
 	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.InAnyOrder([]k8sproxy.Endpoint{localEndpointForPort80, remoteEndpointForPort80})).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(gomock.Any(), false, []k8sproxy.Endpoint{localEndpointForPort80}).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(gomock.Any(), false, gomock.InAnyOrder([]k8sproxy.Endpoint{localEndpointForPort80, remoteEndpointForPort80})).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), svc1IPv4, uint16(port80Int32), binding.ProtocolTCP, uint16(0), false, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), agentconfig.VirtualNodePortDNATIPv4, uint16(port30001Int32), binding.ProtocolTCP, uint16(0), true, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), loadBalancerIPv4, uint16(port80Int32), binding.ProtocolTCP, uint16(0), true, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), svc1IPv4, uint16(port80Int32), binding.ProtocolTCP, uint16(0), nil, false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), agentconfig.VirtualNodePortDNATIPv4, uint16(port30001Int32), binding.ProtocolTCP, uint16(0), nil, true, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), loadBalancerIPv4, uint16(port80Int32), binding.ProtocolTCP, uint16(0), nil, true, false).Times(1)
 	mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(port30001Int32), binding.ProtocolTCP).Times(1)
 	mockRouteClient.EXPECT().AddExternalIPRoute(loadBalancerIPv4).Times(1)
 
 	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.InAnyOrder([]k8sproxy.Endpoint{localEndpointForPort443, remoteEndpointForPort443})).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(gomock.Any(), false, []k8sproxy.Endpoint{localEndpointForPort443}).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(gomock.Any(), false, gomock.InAnyOrder([]k8sproxy.Endpoint{localEndpointForPort443, remoteEndpointForPort443})).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), svc1IPv4, uint16(port443Int32), binding.ProtocolTCP, uint16(0), false, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), agentconfig.VirtualNodePortDNATIPv4, uint16(port30002Int32), binding.ProtocolTCP, uint16(0), true, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), loadBalancerIPv4, uint16(port443Int32), binding.ProtocolTCP, uint16(0), true, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), svc1IPv4, uint16(port443Int32), binding.ProtocolTCP, uint16(0), nil, false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), agentconfig.VirtualNodePortDNATIPv4, uint16(port30002Int32), binding.ProtocolTCP, uint16(0), nil, true, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), loadBalancerIPv4, uint16(port443Int32), binding.ProtocolTCP, uint16(0), nil, true, false).Times(1)
 	mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(port30002Int32), binding.ProtocolTCP).Times(1)
 
 	fp.syncProxyRules()
@@ -1014,7 +1042,7 @@ func TestLoadBalancerServiceWithMultiplePorts(t *testing.T) {
 
 	fp.syncProxyRules()
 
-	assert.Emptyf(t, fp.serviceIPRouteReferences, "serviceIPRouteReferences was not cleaned up after Service was removed")
+	assert.Emptyf(t, fp.serviceIPRouteReferences.getReferences(), "serviceIPRouteReferences was not cleaned up after Service was removed")
 }
 
 func TestNodePortAdd(t *testing.T) {
@@ -1148,11 +1176,11 @@ func TestDualStackService(t *testing.T) {
 
 	mockOFClient.EXPECT().InstallServiceGroup(groupIDv4, false, []k8sproxy.Endpoint{k8sproxy.NewBaseEndpointInfo(ep1IPv4.String(), "", "", svcPort, false, true, true, false, nil)}).Times(1)
 	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupIDv4, binding.GroupIDType(0), svc1IPv4, uint16(svcPort), binding.ProtocolTCP, uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupIDv4, binding.GroupIDType(0), svc1IPv4, uint16(svcPort), binding.ProtocolTCP, uint16(0), nil, false, false).Times(1)
 
 	mockOFClient.EXPECT().InstallServiceGroup(groupIDv6, false, []k8sproxy.Endpoint{k8sproxy.NewBaseEndpointInfo(ep1IPv6.String(), "", "", svcPort, false, true, true, false, nil)}).Times(1)
 	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCPv6, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupIDv6, binding.GroupIDType(0), svc1IPv6, uint16(svcPort), binding.ProtocolTCPv6, uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupIDv6, binding.GroupIDType(0), svc1IPv6, uint16(svcPort), binding.ProtocolTCPv6, uint16(0), nil, false, false).Times(1)
 
 	fpv4.syncProxyRules()
 	fpv6.syncProxyRules()
@@ -1199,19 +1227,19 @@ func testClusterIPRemove(t *testing.T, svcIP, externalIP, epIP net.IP, isIPv6 bo
 	if nodeLocalInternal == false {
 		mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.Any()).Times(1)
 		mockOFClient.EXPECT().InstallServiceGroup(internalGroupID, false, gomock.Any()).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, true).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, true).Times(1)
 		mockOFClient.EXPECT().UninstallServiceGroup(gomock.Any()).Times(1)
 		mockOFClient.EXPECT().UninstallEndpointFlows(bindingProtocol, gomock.Any()).Times(1)
 		mockOFClient.EXPECT().UninstallServiceFlows(svcIP, uint16(svcPort), bindingProtocol).Times(1)
 		if externalIP != nil {
 			externalGroupID = internalGroupID
 			clusterGroupID = internalGroupID
-			mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+			mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 			mockOFClient.EXPECT().UninstallServiceFlows(externalIP, uint16(svcPort), bindingProtocol).Times(1)
 		}
 	} else {
 		mockOFClient.EXPECT().InstallServiceGroup(internalGroupID, false, gomock.Any()).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, true).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, true).Times(1)
 		mockOFClient.EXPECT().UninstallServiceGroup(internalGroupID).Times(1)
 		mockOFClient.EXPECT().UninstallServiceFlows(svcIP, uint16(svcPort), bindingProtocol).Times(1)
 		if externalIP != nil {
@@ -1219,7 +1247,7 @@ func testClusterIPRemove(t *testing.T, svcIP, externalIP, epIP net.IP, isIPv6 bo
 			clusterGroupID = externalGroupID
 			mockOFClient.EXPECT().InstallServiceGroup(externalGroupID, false, gomock.Any()).Times(1)
 			mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.Any()).Times(1)
-			mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+			mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 
 			mockOFClient.EXPECT().UninstallServiceGroup(externalGroupID).Times(1)
 			mockOFClient.EXPECT().UninstallEndpointFlows(bindingProtocol, gomock.Any()).Times(1)
@@ -1295,11 +1323,11 @@ func testNodePortRemove(t *testing.T, nodePortAddresses []net.IP, svcIP, externa
 	clusterGroupID := internalGroupID
 	mockOFClient.EXPECT().InstallServiceGroup(externalGroupID, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(internalGroupID, false, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 	mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 	if externalIP != nil {
-		mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddExternalIPRoute(externalIP)
 	}
 
@@ -1379,13 +1407,13 @@ func testLoadBalancerRemove(t *testing.T, nodePortAddresses []net.IP, svcIP, ext
 	clusterGroupID := internalGroupID
 	mockOFClient.EXPECT().InstallServiceGroup(internalGroupID, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(externalGroupID, false, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 	mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 	mockRouteClient.EXPECT().AddExternalIPRoute(loadBalancerIP).Times(1)
 	if externalIP != nil {
-		mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, externalIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddExternalIPRoute(externalIP)
 	}
 
@@ -1508,12 +1536,12 @@ func testClusterIPNoEndpoint(t *testing.T, svcIP net.IP, isIPv6 bool) {
 
 	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
 	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, []k8sproxy.Endpoint{}).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), gomock.Any(), uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), gomock.Any(), uint16(0), nil, false, false).Times(1)
 	fp.syncProxyRules()
 	assert.Contains(t, fp.serviceInstalledMap, svcPortName)
 
 	mockOFClient.EXPECT().UninstallServiceFlows(svcIP, uint16(svcPort), gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort+1), gomock.Any(), uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort+1), gomock.Any(), uint16(0), nil, false, false).Times(1)
 	fp.serviceChanges.OnServiceUpdate(svc, updatedSvc)
 	fp.syncProxyRules()
 }
@@ -1563,16 +1591,16 @@ func testNodePortNoEndpoint(t *testing.T, nodePortAddresses []net.IP, svcIP net.
 	groupIDLocal := fp.groupCounter.AllocateIfNotExist(svcPortName, true)
 	mockOFClient.EXPECT().InstallServiceGroup(groupIDCluster, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(groupIDLocal, false, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupIDCluster, binding.GroupIDType(0), svcIP, uint16(svcPort), gomock.Any(), uint16(0), false, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupIDLocal, groupIDCluster, vIP, uint16(svcNodePort), gomock.Any(), uint16(0), true, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupIDCluster, binding.GroupIDType(0), svcIP, uint16(svcPort), gomock.Any(), uint16(0), nil, false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupIDLocal, groupIDCluster, vIP, uint16(svcNodePort), gomock.Any(), uint16(0), nil, true, false).Times(1)
 	mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), gomock.Any()).Times(1)
 	fp.syncProxyRules()
 
 	mockOFClient.EXPECT().UninstallServiceFlows(svcIP, uint16(svcPort), gomock.Any()).Times(1)
 	mockOFClient.EXPECT().UninstallServiceFlows(vIP, uint16(svcNodePort), gomock.Any()).Times(1)
 	mockRouteClient.EXPECT().DeleteNodePort(nodePortAddresses, uint16(svcNodePort), gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupIDCluster, binding.GroupIDType(0), svcIP, uint16(svcPort+1), gomock.Any(), uint16(0), false, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupIDLocal, groupIDCluster, vIP, uint16(svcNodePort), gomock.Any(), uint16(0), true, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupIDCluster, binding.GroupIDType(0), svcIP, uint16(svcPort+1), gomock.Any(), uint16(0), nil, false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupIDLocal, groupIDCluster, vIP, uint16(svcNodePort), gomock.Any(), uint16(0), nil, true, false).Times(1)
 	mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), gomock.Any()).Times(1)
 	fp.serviceChanges.OnServiceUpdate(svc, updatedSvc)
 	fp.syncProxyRules()
@@ -1629,9 +1657,9 @@ func testLoadBalancerNoEndpoint(t *testing.T, nodePortAddresses []net.IP, svcIP
 	clusterGroupID := internalGroupID
 	mockOFClient.EXPECT().InstallServiceGroup(internalGroupID, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(externalGroupID, false, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), gomock.Any(), uint16(0), false, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, vIP, uint16(svcNodePort), gomock.Any(), uint16(0), true, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, loadBalancerIP, uint16(svcPort), gomock.Any(), uint16(0), true, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort), gomock.Any(), uint16(0), nil, false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, vIP, uint16(svcNodePort), gomock.Any(), uint16(0), nil, true, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, loadBalancerIP, uint16(svcPort), gomock.Any(), uint16(0), nil, true, false).Times(1)
 	mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), gomock.Any()).Times(1)
 	mockRouteClient.EXPECT().AddExternalIPRoute(loadBalancerIP).Times(1)
 	fp.syncProxyRules()
@@ -1641,9 +1669,9 @@ func testLoadBalancerNoEndpoint(t *testing.T, nodePortAddresses []net.IP, svcIP
 	mockOFClient.EXPECT().UninstallServiceFlows(loadBalancerIP, uint16(svcPort), gomock.Any()).Times(1)
 	mockRouteClient.EXPECT().DeleteNodePort(nodePortAddresses, uint16(svcNodePort), gomock.Any()).Times(1)
 	mockRouteClient.EXPECT().DeleteExternalIPRoute(loadBalancerIP).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort+1), gomock.Any(), uint16(0), false, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, vIP, uint16(svcNodePort), gomock.Any(), uint16(0), true, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, loadBalancerIP, uint16(svcPort+1), gomock.Any(), uint16(0), true, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(internalGroupID, binding.GroupIDType(0), svcIP, uint16(svcPort+1), gomock.Any(), uint16(0), nil, false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, vIP, uint16(svcNodePort), gomock.Any(), uint16(0), nil, true, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(externalGroupID, clusterGroupID, loadBalancerIP, uint16(svcPort+1), gomock.Any(), uint16(0), nil, true, false).Times(1)
 	mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), gomock.Any()).Times(1)
 	mockRouteClient.EXPECT().AddExternalIPRoute(loadBalancerIP).Times(1)
 	fp.serviceChanges.OnServiceUpdate(svc, updatedSvc)
@@ -1692,12 +1720,13 @@ func testClusterIPRemoveSamePortEndpoint(t *testing.T, svcIP net.IP, epIP net.IP
 	mockOFClient.EXPECT().InstallServiceGroup(groupIDUDP, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallEndpointFlows(protocolTCP, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallEndpointFlows(protocolUDP, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), protocolTCP, uint16(0), false, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupIDUDP, binding.GroupIDType(0), svcIP, uint16(svcPort), protocolUDP, uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), protocolTCP, uint16(0), nil, false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupIDUDP, binding.GroupIDType(0), svcIP, uint16(svcPort), protocolUDP, uint16(0), nil, false, false).Times(1)
 	fp.syncProxyRules()
 
 	mockOFClient.EXPECT().InstallServiceGroup(groupIDUDP, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().UninstallEndpointFlows(protocolUDP, gomock.Any()).Times(1)
+	mockRouteClient.EXPECT().ClearConntrackEntryForService(svcIP, uint16(svcPort), epIP, protocolUDP).Times(1)
 	fp.endpointsChanges.OnEndpointSliceUpdate(epsUDP, true)
 	fp.syncProxyRules()
 
@@ -1737,7 +1766,7 @@ func testClusterIPRemoveEndpoints(t *testing.T, svcIP net.IP, epIP net.IP, isIPv
 	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
 	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.Any()).Times(1)
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
 	fp.syncProxyRules()
 
 	assert.Contains(t, fp.serviceInstalledMap, svcPortName)
@@ -1764,6 +1793,134 @@ func TestClusterIPRemoveEndpoints(t *testing.T) {
 	})
 }
 
+func TestClusterIPEndpointDraining(t *testing.T) {
+	drainingTimeout := 30 * time.Second
+	// getGroupEndpoints returns the IPs of the active and draining Endpoints installed in the Service group.
+	getGroupEndpoints := func(endpoints []k8sproxy.Endpoint) (active, draining []string) {
+		for _, endpoint := range endpoints {
+			if _, ok := endpoint.(*openflow.DrainingEndpoint); ok {
+				draining = append(draining, endpoint.IP())
+			} else {
+				active = append(active, endpoint.IP())
+			}
+		}
+		return
+	}
+	for _, tc := range []struct {
+		name            string
+		restoreEndpoint bool
+	}{
+		{name: "draining completed"},
+		{name: "endpoint restored while draining", restoreEndpoint: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockOFClient, mockRouteClient := getMockClients(ctrl)
+			groupAllocator := openflow.NewGroupAllocator()
+			fp := newFakeProxier(mockRouteClient, mockOFClient, nil, groupAllocator, false, withEndpointDrainingTimeout(drainingTimeout))
+			fakeClock := clocktesting.NewFakeClock(time.Now())
+			fp.clock = fakeClock
+
+			svc := makeTestClusterIPService(&svcPortName, svc1IPv4, nil, int32(svcPort), corev1.ProtocolTCP, nil, nil, false, nil)
+			makeServiceMap(fp, svc)
+			ep1, epPort := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+			ep2, _ := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep2IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+			eps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*ep1, *ep2}, []discovery.EndpointPort{*epPort}, false)
+			makeEndpointSliceMap(fp, eps)
+
+			groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
+			mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.Any()).Times(1)
+			mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Times(1)
+			mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svc1IPv4, uint16(svcPort), binding.ProtocolTCP, uint16(0), nil, false, false).Times(1)
+			fp.syncProxyRules()
+
+			// Remove ep2 from the Service, it should be kept in the group with zero weight and its flows should not
+			// be removed.
+			mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.Any()).Do(func(_ binding.GroupIDType, _ bool, endpoints []k8sproxy.Endpoint) {
+				active, draining := getGroupEndpoints(endpoints)
+				assert.Equal(t, []string{ep1IPv4.String()}, active)
+				assert.Equal(t, []string{ep2IPv4.String()}, draining)
+			}).Times(1)
+			updatedEps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*ep1}, []discovery.EndpointPort{*epPort}, false)
+			fp.endpointsChanges.OnEndpointSliceUpdate(updatedEps, false)
+			fp.syncProxyRules()
+			assert.Len(t, fp.endpointsInstalledMap[svcPortName], 1)
+			assert.Len(t, fp.drainingEndpoints[svcPortName], 1)
+
+			if tc.restoreEndpoint {
+				// Add ep2 back to the Service, it should be active again without reinstalling its flows.
+				mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.Any()).Do(func(_ binding.GroupIDType, _ bool, endpoints []k8sproxy.Endpoint) {
+					active, draining := getGroupEndpoints(endpoints)
+					assert.ElementsMatch(t, []string{ep1IPv4.String(), ep2IPv4.String()}, active)
+					assert.Empty(t, draining)
+				}).Times(1)
+				fp.endpointsChanges.OnEndpointSliceUpdate(eps, false)
+				fp.syncProxyRules()
+				assert.Len(t, fp.endpointsInstalledMap[svcPortName], 2)
+				assert.NotContains(t, fp.drainingEndpoints, svcPortName)
+
+				// Nothing should be changed once the draining timeout has expired.
+				fakeClock.Step(drainingTimeout)
+				fp.syncProxyRules()
+				return
+			}
+
+			// Nothing should be changed before the draining timeout expires.
+			fakeClock.Step(drainingTimeout - time.Second)
+			fp.syncProxyRules()
+
+			// ep2 should be removed once the draining timeout has expired.
+			mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.Any()).Do(func(_ binding.GroupIDType, _ bool, endpoints []k8sproxy.Endpoint) {
+				active, draining := getGroupEndpoints(endpoints)
+				assert.Equal(t, []string{ep1IPv4.String()}, active)
+				assert.Empty(t, draining)
+			}).Times(1)
+			mockOFClient.EXPECT().UninstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Do(func(_ binding.Protocol, endpoints []k8sproxy.Endpoint) {
+				require.Len(t, endpoints, 1)
+				assert.Equal(t, ep2IPv4.String(), endpoints[0].IP())
+			}).Times(1)
+			fakeClock.Step(time.Second)
+			fp.syncProxyRules()
+			assert.Len(t, fp.endpointsInstalledMap[svcPortName], 1)
+			assert.NotContains(t, fp.drainingEndpoints, svcPortName)
+		})
+	}
+}
+
+func TestClusterIPRemoveServiceWithDrainingEndpoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOFClient, mockRouteClient := getMockClients(ctrl)
+	groupAllocator := openflow.NewGroupAllocator()
+	fp := newFakeProxier(mockRouteClient, mockOFClient, nil, groupAllocator, false, withEndpointDrainingTimeout(30*time.Second))
+
+	svc := makeTestClusterIPService(&svcPortName, svc1IPv4, nil, int32(svcPort), corev1.ProtocolTCP, nil, nil, false, nil)
+	makeServiceMap(fp, svc)
+	ep1, epPort := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+	ep2, _ := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep2IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+	eps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*ep1, *ep2}, []discovery.EndpointPort{*epPort}, false)
+	makeEndpointSliceMap(fp, eps)
+
+	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
+	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.Any()).Times(2)
+	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svc1IPv4, uint16(svcPort), binding.ProtocolTCP, uint16(0), nil, false, false).Times(1)
+	fp.syncProxyRules()
+	updatedEps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*ep1}, []discovery.EndpointPort{*epPort}, false)
+	fp.endpointsChanges.OnEndpointSliceUpdate(updatedEps, false)
+	fp.syncProxyRules()
+	require.Len(t, fp.drainingEndpoints[svcPortName], 1)
+
+	// The draining Endpoints should be removed immediately together with the Service.
+	mockOFClient.EXPECT().UninstallServiceFlows(svc1IPv4, uint16(svcPort), binding.ProtocolTCP).Times(1)
+	mockOFClient.EXPECT().UninstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Times(2)
+	mockOFClient.EXPECT().UninstallServiceGroup(groupID).Times(1)
+	fp.serviceChanges.OnServiceUpdate(svc, nil)
+	fp.endpointsChanges.OnEndpointSliceUpdate(updatedEps, true)
+	fp.syncProxyRules()
+	assert.NotContains(t, fp.drainingEndpoints, svcPortName)
+	assert.Empty(t, fp.endpointReferenceCounter)
+}
+
 func testSessionAffinity(t *testing.T, svcIP net.IP, epIP net.IP, affinitySeconds int32, isIPv6 bool) {
 	ctrl := gomock.NewController(t)
 	mockOFClient, mockRouteClient := getMockClients(ctrl)
@@ -1805,8 +1962,235 @@ func testSessionAffinity(t *testing.T, svcIP net.IP, epIP net.IP, affinitySecond
 	} else {
 		expectedAffinity = uint16(affinitySeconds)
 	}
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, expectedAffinity, false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, expectedAffinity, nil, false, false).Times(1)
+
+	fp.syncProxyRules()
+}
+
+func TestClusterIPEndpointWeights(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOFClient, mockRouteClient := getMockClients(ctrl)
+	groupAllocator := openflow.NewGroupAllocator()
+	fp := newFakeProxier(mockRouteClient, mockOFClient, nil, groupAllocator, false)
+	// getGroupWeights returns the weights of the Endpoints installed in the Service group.
+	getGroupWeights := func(endpoints []k8sproxy.Endpoint) map[string]uint16 {
+		weights := map[string]uint16{}
+		for _, endpoint := range endpoints {
+			weights[endpoint.IP()] = endpoint.GetWeight()
+		}
+		return weights
+	}
+
+	svc := makeTestClusterIPService(&svcPortName, svc1IPv4, nil, int32(svcPort), corev1.ProtocolTCP, nil, nil, false, nil)
+	makeServiceMap(fp, svc)
+	ep1, epPort := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+	ep2, _ := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep2IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+	eps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*ep1, *ep2}, []discovery.EndpointPort{*epPort}, false)
+	eps.Annotations = map[string]string{agenttypes.EndpointSliceEndpointWeightsAnnotationKey: fmt.Sprintf(`{"%s": 200}`, ep1IPv4)}
+	makeEndpointSliceMap(fp, eps)
+
+	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
+	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.Any()).Do(func(_ binding.GroupIDType, _ bool, endpoints []k8sproxy.Endpoint) {
+		assert.Equal(t, map[string]uint16{ep1IPv4.String(): 200, ep2IPv4.String(): 0}, getGroupWeights(endpoints))
+	}).Times(1)
+	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svc1IPv4, uint16(svcPort), binding.ProtocolTCP, uint16(0), nil, false, false).Times(1)
+	fp.syncProxyRules()
+
+	// Update the weights of the Endpoints, only the group should be updated.
+	updatedEps := eps.DeepCopy()
+	updatedEps.Annotations[agenttypes.EndpointSliceEndpointWeightsAnnotationKey] = fmt.Sprintf(`{"%s": 50}`, ep2IPv4)
+	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.Any()).Do(func(_ binding.GroupIDType, _ bool, endpoints []k8sproxy.Endpoint) {
+		assert.Equal(t, map[string]uint16{ep1IPv4.String(): 0, ep2IPv4.String(): 50}, getGroupWeights(endpoints))
+	}).Times(1)
+	fp.endpointsChanges.OnEndpointSliceUpdate(updatedEps, false)
+	fp.syncProxyRules()
+	assert.Equal(t, uint16(50), fp.endpointsInstalledMap[svcPortName][net.JoinHostPort(ep2IPv4.String(), strconv.Itoa(svcPort))].GetWeight())
+
+	// Nothing should be changed if the weights are not changed.
+	fp.syncProxyRules()
+}
+
+func TestClusterIPPortRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOFClient, mockRouteClient := getMockClients(ctrl)
+	groupAllocator := openflow.NewGroupAllocator()
+	fp := newFakeProxier(mockRouteClient, mockOFClient, nil, groupAllocator, false)
+	endPort := svcPort + 10
+
+	svc := makeTestClusterIPService(&svcPortName, svc1IPv4, nil, int32(svcPort), corev1.ProtocolUDP, nil, nil, false, nil)
+	svc.Annotations = map[string]string{agenttypes.ServicePortRangeAnnotationKey: fmt.Sprintf("%d-%d", svcPort, endPort)}
+	makeServiceMap(fp, svc)
+	ep, epPort := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IPv4, int32(svcPort), corev1.ProtocolUDP, false)
+	eps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*ep}, []discovery.EndpointPort{*epPort}, false)
+	makeEndpointSliceMap(fp, eps)
+
+	// The Endpoints have no port, so that the destination port is kept when DNATing the connections.
+	portRangeEndpointKey := net.JoinHostPort(ep1IPv4.String(), "0")
+	getEndpointKeys := func(endpoints []k8sproxy.Endpoint) []string {
+		var keys []string
+		for _, endpoint := range endpoints {
+			keys = append(keys, endpoint.String())
+		}
+		return keys
+	}
+	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
+	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.Any()).Do(func(_ binding.GroupIDType, _ bool, endpoints []k8sproxy.Endpoint) {
+		assert.Equal(t, []string{portRangeEndpointKey}, getEndpointKeys(endpoints))
+	}).Times(1)
+	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolUDP, gomock.Any()).Do(func(_ binding.Protocol, endpoints []k8sproxy.Endpoint) {
+		assert.Equal(t, []string{portRangeEndpointKey}, getEndpointKeys(endpoints))
+	}).Times(1)
+	mockOFClient.EXPECT().InstallServicePortRangeFlows(groupID, binding.GroupIDType(0), svc1IPv4, uint16(svcPort), uint16(endPort), binding.ProtocolUDP, uint16(0), nil, false).Times(1)
+	fp.syncProxyRules()
+	assert.Contains(t, fp.endpointsInstalledMap[svcPortName], portRangeEndpointKey)
+
+	// Removing the port range should reinstall the Service flows and the Endpoints with their port.
+	updatedSvc := svc.DeepCopy()
+	updatedSvc.Annotations = map[string]string{}
+	fp.serviceChanges.OnServiceUpdate(svc, updatedSvc)
+	endpointKey := net.JoinHostPort(ep1IPv4.String(), strconv.Itoa(svcPort))
+	mockOFClient.EXPECT().UninstallServiceFlows(svc1IPv4, uint16(svcPort), binding.ProtocolUDP).Times(1)
+	mockOFClient.EXPECT().UninstallEndpointFlows(binding.ProtocolUDP, gomock.Any()).Do(func(_ binding.Protocol, endpoints []k8sproxy.Endpoint) {
+		assert.Equal(t, []string{portRangeEndpointKey}, getEndpointKeys(endpoints))
+	}).Times(1)
+	mockRouteClient.EXPECT().ClearConntrackEntryForService(svc1IPv4, uint16(svcPort), ep1IPv4, binding.ProtocolUDP).Times(1)
+	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolUDP, gomock.Any()).Do(func(_ binding.Protocol, endpoints []k8sproxy.Endpoint) {
+		assert.Equal(t, []string{endpointKey}, getEndpointKeys(endpoints))
+	}).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.Any()).Do(func(_ binding.GroupIDType, _ bool, endpoints []k8sproxy.Endpoint) {
+		assert.Equal(t, []string{endpointKey}, getEndpointKeys(endpoints))
+	}).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svc1IPv4, uint16(svcPort), binding.ProtocolUDP, uint16(0), nil, false, false).Times(1)
+	fp.syncProxyRules()
+	assert.Contains(t, fp.endpointsInstalledMap[svcPortName], endpointKey)
+	assert.NotContains(t, fp.endpointsInstalledMap[svcPortName], portRangeEndpointKey)
+}
+
+func testUDPServiceConntrackCleanup(t *testing.T, nodePortAddresses []net.IP, svcIP, updatedSvcIP, externalIP, loadBalancerIP, ep1IP, ep2IP net.IP, isIPv6 bool) {
+	ctrl := gomock.NewController(t)
+	mockOFClient, mockRouteClient := getMockClients(ctrl)
+	groupAllocator := openflow.NewGroupAllocator()
+	fp := newFakeProxier(mockRouteClient, mockOFClient, nodePortAddresses, groupAllocator, isIPv6, withProxyAll)
+
+	makeService := func(clusterIP net.IP) *corev1.Service {
+		if loadBalancerIP == nil {
+			return makeTestNodePortService(&svcPortName, clusterIP, []net.IP{externalIP}, int32(svcPort), int32(svcNodePort), corev1.ProtocolUDP, nil, corev1.ServiceInternalTrafficPolicyCluster, corev1.ServiceExternalTrafficPolicyTypeCluster)
+		}
+		return makeTestLoadBalancerService(&svcPortName, clusterIP, []net.IP{externalIP}, []net.IP{loadBalancerIP}, int32(svcPort), int32(svcNodePort), corev1.ProtocolUDP, nil, nil, corev1.ServiceExternalTrafficPolicyTypeCluster)
+	}
+	svc := makeService(svcIP)
+	makeServiceMap(fp, svc)
+	ep1, epPort := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IP, int32(svcPort), corev1.ProtocolUDP, false)
+	ep2, _ := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep2IP, int32(svcPort), corev1.ProtocolUDP, false)
+	eps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*ep1, *ep2}, []discovery.EndpointPort{*epPort}, isIPv6)
+	makeEndpointSliceMap(fp, eps)
+
+	bindingProtocol := binding.ProtocolUDP
+	if isIPv6 {
+		bindingProtocol = binding.ProtocolUDPv6
+	}
+	// The flows and routes of the Service are covered by other tests.
+	mockOFClient.EXPECT().InstallServiceGroup(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockOFClient.EXPECT().UninstallServiceGroup(gomock.Any()).AnyTimes()
+	mockOFClient.EXPECT().InstallEndpointFlows(gomock.Any(), gomock.Any()).AnyTimes()
+	mockOFClient.EXPECT().UninstallEndpointFlows(gomock.Any(), gomock.Any()).AnyTimes()
+	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockOFClient.EXPECT().UninstallServiceFlows(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockRouteClient.EXPECT().AddNodePort(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockRouteClient.EXPECT().DeleteNodePort(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockRouteClient.EXPECT().AddExternalIPRoute(gomock.Any()).AnyTimes()
+	mockRouteClient.EXPECT().DeleteExternalIPRoute(gomock.Any()).AnyTimes()
+	fp.syncProxyRules()
+
+	// The conntrack entries of the connections to all the addresses of the Service which were load-balanced to the
+	// removed Endpoint should be deleted.
+	updatedEps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*ep1}, []discovery.EndpointPort{*epPort}, isIPv6)
+	fp.endpointsChanges.OnEndpointSliceUpdate(updatedEps, false)
+	mockRouteClient.EXPECT().ClearConntrackEntryForService(svcIP, uint16(svcPort), ep2IP, bindingProtocol).Times(1)
+	mockRouteClient.EXPECT().ClearConntrackEntryForService(nil, uint16(svcNodePort), ep2IP, bindingProtocol).Times(1)
+	mockRouteClient.EXPECT().ClearConntrackEntryForService(externalIP, uint16(svcPort), ep2IP, bindingProtocol).Times(1)
+	if loadBalancerIP != nil {
+		mockRouteClient.EXPECT().ClearConntrackEntryForService(loadBalancerIP, uint16(svcPort), ep2IP, bindingProtocol).Times(1)
+	}
+	fp.syncProxyRules()
+
+	// The conntrack entries of the connections to the previous ClusterIP should be deleted.
+	updatedSvc := makeService(updatedSvcIP)
+	fp.serviceChanges.OnServiceUpdate(svc, updatedSvc)
+	mockRouteClient.EXPECT().ClearConntrackEntryForService(svcIP, uint16(svcPort), nil, bindingProtocol).Times(1)
+	fp.syncProxyRules()
+
+	// The conntrack entries of the connections to all the addresses of the Service should be deleted.
+	fp.serviceChanges.OnServiceUpdate(updatedSvc, nil)
+	fp.endpointsChanges.OnEndpointSliceUpdate(updatedEps, true)
+	mockRouteClient.EXPECT().ClearConntrackEntryForService(updatedSvcIP, uint16(svcPort), nil, bindingProtocol).Times(1)
+	mockRouteClient.EXPECT().ClearConntrackEntryForService(nil, uint16(svcNodePort), nil, bindingProtocol).Times(1)
+	mockRouteClient.EXPECT().ClearConntrackEntryForService(externalIP, uint16(svcPort), nil, bindingProtocol).Times(1)
+	if loadBalancerIP != nil {
+		mockRouteClient.EXPECT().ClearConntrackEntryForService(loadBalancerIP, uint16(svcPort), nil, bindingProtocol).Times(1)
+	}
+	fp.syncProxyRules()
+	assert.NotContains(t, fp.serviceInstalledMap, svcPortName)
+}
+
+func TestUDPServiceConntrackCleanup(t *testing.T) {
+	t.Run("NodePort", func(t *testing.T) {
+		t.Run("IPv4", func(t *testing.T) {
+			testUDPServiceConntrackCleanup(t, nodePortAddressesIPv4, svc1IPv4, svc2IPv4, externalIPv4, nil, ep1IPv4, ep2IPv4, false)
+		})
+		t.Run("IPv6", func(t *testing.T) {
+			testUDPServiceConntrackCleanup(t, nodePortAddressesIPv6, svc1IPv6, svc2IPv6, externalIPv6, nil, ep1IPv6, ep2IPv6, true)
+		})
+	})
+	t.Run("LoadBalancer", func(t *testing.T) {
+		t.Run("IPv4", func(t *testing.T) {
+			testUDPServiceConntrackCleanup(t, nodePortAddressesIPv4, svc1IPv4, svc2IPv4, externalIPv4, loadBalancerIPv4, ep1IPv4, ep2IPv4, false)
+		})
+		t.Run("IPv6", func(t *testing.T) {
+			testUDPServiceConntrackCleanup(t, nodePortAddressesIPv6, svc1IPv6, svc2IPv6, externalIPv6, loadBalancerIPv6, ep1IPv6, ep2IPv6, true)
+		})
+	})
+}
+
+func TestLoadBalancerModeDSR(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOFClient, mockRouteClient := getMockClients(ctrl)
+	groupAllocator := openflow.NewGroupAllocator()
+	fp := newFakeProxier(mockRouteClient, mockOFClient, nil, groupAllocator, false, withLoadBalancerModeDSR)
 
+	svc := makeTestLoadBalancerService(&svcPortName, svc1IPv4, nil, []net.IP{loadBalancerIPv4}, int32(svcPort), 0, corev1.ProtocolTCP, nil, nil, corev1.ServiceExternalTrafficPolicyTypeCluster)
+	svc.Annotations = map[string]string{agenttypes.ServiceLoadBalancerModeAnnotationKey: "dsr"}
+	makeServiceMap(fp, svc)
+	localEp, epPort := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IPv4, int32(svcPort), corev1.ProtocolTCP, true)
+	remoteEp, _ := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep2IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+	eps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*localEp, *remoteEp}, []discovery.EndpointPort{*epPort}, false)
+	makeEndpointSliceMap(fp, eps)
+
+	clusterGroupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
+	localGroupID := fp.groupCounter.AllocateIfNotExist(svcPortName, true)
+	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(clusterGroupID, false, gomock.Any()).Do(func(_ binding.GroupIDType, _ bool, endpoints []k8sproxy.Endpoint) {
+		assert.Len(t, endpoints, 2)
+	}).Times(1)
+	// The group of the local Endpoints is used to load balance the connections received from the tunnel.
+	mockOFClient.EXPECT().InstallServiceGroup(localGroupID, false, gomock.Any()).Do(func(_ binding.GroupIDType, _ bool, endpoints []k8sproxy.Endpoint) {
+		require.Len(t, endpoints, 1)
+		assert.Equal(t, ep1IPv4.String(), endpoints[0].IP())
+	}).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(clusterGroupID, binding.GroupIDType(0), svc1IPv4, uint16(svcPort), binding.ProtocolTCP, uint16(0), nil, false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceDSRFlows(clusterGroupID, localGroupID, loadBalancerIPv4, uint16(svcPort), binding.ProtocolTCP).Times(1)
+	fp.syncProxyRules()
+
+	// Switching to NAT mode should reinstall the Service flows and remove the group of the local Endpoints.
+	updatedSvc := svc.DeepCopy()
+	updatedSvc.Annotations = map[string]string{agenttypes.ServiceLoadBalancerModeAnnotationKey: "nat"}
+	fp.serviceChanges.OnServiceUpdate(svc, updatedSvc)
+	mockOFClient.EXPECT().UninstallServiceGroup(localGroupID).Times(1)
+	mockOFClient.EXPECT().UninstallServiceFlows(svc1IPv4, uint16(svcPort), binding.ProtocolTCP).Times(1)
+	mockOFClient.EXPECT().UninstallServiceFlows(loadBalancerIPv4, uint16(svcPort), binding.ProtocolTCP).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(clusterGroupID, binding.GroupIDType(0), svc1IPv4, uint16(svcPort), binding.ProtocolTCP, uint16(0), nil, false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(clusterGroupID, clusterGroupID, loadBalancerIPv4, uint16(svcPort), binding.ProtocolTCP, uint16(0), nil, true, false).Times(1)
 	fp.syncProxyRules()
 }
 
@@ -1827,6 +2211,57 @@ func TestSessionAffinityOverflow(t *testing.T) {
 	testSessionAffinity(t, svc1IPv4, ep1IPv4, affinitySeconds, false)
 }
 
+func TestSessionAffinityKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOFClient, mockRouteClient := getMockClients(ctrl)
+	groupAllocator := openflow.NewGroupAllocator()
+	fp := newFakeProxier(mockRouteClient, mockOFClient, nil, groupAllocator, false)
+
+	affinitySeconds := corev1.DefaultClientIPServiceAffinitySeconds
+	svc := makeTestService(svcPortName.Namespace, svcPortName.Name, func(svc *corev1.Service) {
+		svc.Annotations = map[string]string{
+			agenttypes.ServiceSessionAffinityClientIPPrefixLengthAnnotationKey: "24",
+			agenttypes.ServiceSessionAffinityMatchDstPortAnnotationKey:         "false",
+		}
+		svc.Spec.ClusterIP = svc1IPv4.String()
+		svc.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+		svc.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+			ClientIP: &corev1.ClientIPConfig{
+				TimeoutSeconds: &affinitySeconds,
+			},
+		}
+		svc.Spec.Ports = []corev1.ServicePort{{
+			Name:     svcPortName.Port,
+			Port:     int32(svcPort),
+			Protocol: corev1.ProtocolTCP,
+		}}
+	})
+	makeServiceMap(fp, svc)
+
+	ep, epPort := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+	eps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*ep}, []discovery.EndpointPort{*epPort}, false)
+	makeEndpointSliceMap(fp, eps)
+
+	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
+	expectedAffinityKey := &agenttypes.SessionAffinityKey{ClientIPPrefixLength: 24, IgnoreDstPort: true}
+	mockOFClient.EXPECT().InstallServiceGroup(groupID, true, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svc1IPv4, uint16(svcPort), binding.ProtocolTCP, uint16(affinitySeconds), expectedAffinityKey, false, false).Times(1)
+	fp.syncProxyRules()
+
+	// Changing the session affinity key should reinstall the Service flows.
+	updatedSvc := svc.DeepCopy()
+	updatedSvc.Annotations = map[string]string{
+		agenttypes.ServiceSessionAffinityClientIPPrefixLengthAnnotationKey: "16",
+	}
+	fp.serviceChanges.OnServiceUpdate(svc, updatedSvc)
+
+	expectedAffinityKey = &agenttypes.SessionAffinityKey{ClientIPPrefixLength: 16}
+	mockOFClient.EXPECT().UninstallServiceFlows(svc1IPv4, uint16(svcPort), binding.ProtocolTCP).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svc1IPv4, uint16(svcPort), binding.ProtocolTCP, uint16(affinitySeconds), expectedAffinityKey, false, false).Times(1)
+	fp.syncProxyRules()
+}
+
 func testSessionAffinityNoEndpoint(t *testing.T, svcExternalIPs net.IP, svcIP net.IP, isIPv6 bool) {
 	ctrl := gomock.NewController(t)
 	mockOFClient, mockRouteClient := getMockClients(ctrl)
@@ -1857,7 +2292,7 @@ func testSessionAffinityNoEndpoint(t *testing.T, svcExternalIPs net.IP, svcIP ne
 
 	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
 	mockOFClient.EXPECT().InstallServiceGroup(groupID, true, []k8sproxy.Endpoint{}).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), gomock.Any(), uint16(10800), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), gomock.Any(), uint16(10800), nil, false, false).Times(1)
 	fp.syncProxyRules()
 }
 
@@ -1913,27 +2348,27 @@ func testServiceClusterIPUpdate(t *testing.T,
 	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, expectedEps).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, expectedEps).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
 
 	s1 := mockOFClient.EXPECT().UninstallServiceFlows(svcIP, uint16(svcPort), bindingProtocol).Times(1)
-	s2 := mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), updatedSvcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
+	s2 := mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), updatedSvcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
 	s2.After(s1)
 
 	if svcType == corev1.ServiceTypeNodePort || svcType == corev1.ServiceTypeLoadBalancer {
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 
 		mockOFClient.EXPECT().UninstallServiceFlows(vIP, uint16(svcNodePort), bindingProtocol).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().DeleteNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 		mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 	}
 	if svcType == corev1.ServiceTypeLoadBalancer {
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddExternalIPRoute(loadBalancerIP).Times(1)
 
 		mockOFClient.EXPECT().UninstallServiceFlows(loadBalancerIP, uint16(svcPort), bindingProtocol).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().DeleteExternalIPRoute(loadBalancerIP).Times(1)
 		mockRouteClient.EXPECT().AddExternalIPRoute(loadBalancerIP).Times(1)
 	}
@@ -2014,27 +2449,27 @@ func testServicePortUpdate(t *testing.T,
 	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, expectedEps).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, expectedEps).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
 
 	s1 := mockOFClient.EXPECT().UninstallServiceFlows(svcIP, uint16(svcPort), bindingProtocol).Times(1)
-	s2 := mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort+1), bindingProtocol, uint16(0), false, false).Times(1)
+	s2 := mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort+1), bindingProtocol, uint16(0), nil, false, false).Times(1)
 	s2.After(s1)
 
 	if svcType == corev1.ServiceTypeNodePort || svcType == corev1.ServiceTypeLoadBalancer {
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 
 		mockOFClient.EXPECT().UninstallServiceFlows(vIP, uint16(svcNodePort), bindingProtocol).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().DeleteNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 		mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 	}
 	if svcType == corev1.ServiceTypeLoadBalancer {
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddExternalIPRoute(loadBalancerIP).Times(1)
 
 		s1 = mockOFClient.EXPECT().UninstallServiceFlows(loadBalancerIP, uint16(svcPort), bindingProtocol)
-		s2 = mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort+1), bindingProtocol, uint16(0), true, false).Times(1)
+		s2 = mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort+1), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		s2.After(s1)
 
 		mockRouteClient.EXPECT().DeleteExternalIPRoute(loadBalancerIP).Times(1)
@@ -2113,20 +2548,20 @@ func testServiceNodePortUpdate(t *testing.T,
 	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, expectedEps).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, expectedEps).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
 
 	if svcType == corev1.ServiceTypeNodePort || svcType == corev1.ServiceTypeLoadBalancer {
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 
 		s1 := mockOFClient.EXPECT().UninstallServiceFlows(vIP, uint16(svcNodePort), bindingProtocol)
 		mockRouteClient.EXPECT().DeleteNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
-		s2 := mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort+1), bindingProtocol, uint16(0), true, false).Times(1)
+		s2 := mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort+1), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort+1), bindingProtocol).Times(1)
 		s2.After(s1)
 	}
 	if svcType == corev1.ServiceTypeLoadBalancer {
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddExternalIPRoute(loadBalancerIP).Times(1)
 	}
 
@@ -2204,14 +2639,14 @@ func testServiceExternalTrafficPolicyUpdate(t *testing.T,
 	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.InAnyOrder(expectedAllEps)).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
 
 	if svcType == corev1.ServiceTypeNodePort || svcType == corev1.ServiceTypeLoadBalancer {
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 	}
 	if svcType == corev1.ServiceTypeLoadBalancer {
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddExternalIPRoute(loadBalancerIP).Times(1)
 	}
 	fp.syncProxyRules()
@@ -2222,13 +2657,13 @@ func testServiceExternalTrafficPolicyUpdate(t *testing.T,
 	groupIDLocal := fp.groupCounter.AllocateIfNotExist(svcPortName, true)
 
 	mockOFClient.EXPECT().UninstallServiceFlows(svcIP, uint16(svcPort), bindingProtocol).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
 
 	if svcType == corev1.ServiceTypeNodePort || svcType == corev1.ServiceTypeLoadBalancer {
 		mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
 		mockOFClient.EXPECT().InstallServiceGroup(groupIDLocal, false, expectedLocalEps).Times(1)
 		s1 := mockOFClient.EXPECT().UninstallServiceFlows(vIP, uint16(svcNodePort), bindingProtocol).Times(1)
-		s2 := mockOFClient.EXPECT().InstallServiceFlows(groupIDLocal, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
+		s2 := mockOFClient.EXPECT().InstallServiceFlows(groupIDLocal, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		s2.After(s1)
 
 		mockRouteClient.EXPECT().DeleteNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
@@ -2236,7 +2671,7 @@ func testServiceExternalTrafficPolicyUpdate(t *testing.T,
 	}
 	if svcType == corev1.ServiceTypeLoadBalancer {
 		s1 := mockOFClient.EXPECT().UninstallServiceFlows(loadBalancerIP, uint16(svcPort), bindingProtocol).Times(1)
-		s2 := mockOFClient.EXPECT().InstallServiceFlows(groupIDLocal, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+		s2 := mockOFClient.EXPECT().InstallServiceFlows(groupIDLocal, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		s2.After(s1)
 
 		mockRouteClient.EXPECT().DeleteExternalIPRoute(loadBalancerIP).Times(1)
@@ -2304,7 +2739,7 @@ func testServiceInternalTrafficPolicyUpdate(t *testing.T,
 	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.InAnyOrder(expectedAllEps)).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.InAnyOrder(expectedAllEps)).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
 	fp.syncProxyRules()
 	assert.Contains(t, fp.serviceInstalledMap, svcPortName)
 	assert.Contains(t, fp.endpointsInstalledMap, svcPortName)
@@ -2328,7 +2763,7 @@ func testServiceInternalTrafficPolicyUpdate(t *testing.T,
 	mockOFClient.EXPECT().UninstallServiceGroup(groupID).Times(1)
 	mockOFClient.EXPECT().UninstallServiceFlows(svcIP, uint16(svcPort), bindingProtocol).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(groupIDLocal, false, expectedLocalEps).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupIDLocal, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupIDLocal, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
 	fp.syncProxyRules()
 
 	assert.Contains(t, fp.serviceInstalledMap, svcPortName)
@@ -2390,10 +2825,10 @@ func testServiceIngressIPsUpdate(t *testing.T,
 	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.InAnyOrder(expectedEps)).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, gomock.InAnyOrder(expectedEps)).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 	for _, ip := range loadBalancerIPs {
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), ip, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), ip, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 	}
 	mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 	for _, ip := range loadBalancerIPs {
@@ -2407,7 +2842,7 @@ func testServiceIngressIPsUpdate(t *testing.T,
 		mockRouteClient.EXPECT().DeleteExternalIPRoute(net.ParseIP(ipStr)).Times(1)
 	}
 	for _, ipStr := range toAddLoadBalancerIPs {
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), net.ParseIP(ipStr), uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), net.ParseIP(ipStr), uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddExternalIPRoute(net.ParseIP(ipStr)).Times(1)
 	}
 
@@ -2481,24 +2916,24 @@ func testServiceStickyMaxAgeSecondsUpdate(t *testing.T,
 	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, expectedEps).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(groupID, true, expectedEps).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(affinitySeconds), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(affinitySeconds), nil, false, false).Times(1)
 	mockOFClient.EXPECT().UninstallServiceFlows(svcIP, uint16(svcPort), bindingProtocol).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(updatedAffinitySeconds), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(updatedAffinitySeconds), nil, false, false).Times(1)
 
 	if svcType == corev1.ServiceTypeNodePort || svcType == corev1.ServiceTypeLoadBalancer {
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(affinitySeconds), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(affinitySeconds), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 		mockOFClient.EXPECT().UninstallServiceFlows(vIP, uint16(svcNodePort), bindingProtocol).Times(1)
 		mockRouteClient.EXPECT().DeleteNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(updatedAffinitySeconds), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(updatedAffinitySeconds), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 	}
 	if svcType == corev1.ServiceTypeLoadBalancer {
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(affinitySeconds), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(affinitySeconds), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddExternalIPRoute(loadBalancerIP).Times(1)
 		mockOFClient.EXPECT().UninstallServiceFlows(loadBalancerIP, uint16(svcPort), bindingProtocol).Times(1)
 		mockRouteClient.EXPECT().DeleteExternalIPRoute(loadBalancerIP).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(updatedAffinitySeconds), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(updatedAffinitySeconds), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddExternalIPRoute(loadBalancerIP).Times(1)
 	}
 
@@ -2579,27 +3014,27 @@ func testServiceSessionAffinityTypeUpdate(t *testing.T,
 	groupID := fp.groupCounter.AllocateIfNotExist(svcPortName, false)
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, expectedEps).Times(1)
 	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, expectedEps).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
 
 	mockOFClient.EXPECT().InstallServiceGroup(groupID, true, expectedEps).Times(1)
 	mockOFClient.EXPECT().UninstallServiceFlows(svcIP, uint16(svcPort), bindingProtocol).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(affinitySeconds), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), svcIP, uint16(svcPort), bindingProtocol, uint16(affinitySeconds), nil, false, false).Times(1)
 
 	if svcType == corev1.ServiceTypeNodePort || svcType == corev1.ServiceTypeLoadBalancer {
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 
 		mockOFClient.EXPECT().UninstallServiceFlows(vIP, uint16(svcNodePort), bindingProtocol).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(affinitySeconds), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), vIP, uint16(svcNodePort), bindingProtocol, uint16(affinitySeconds), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().DeleteNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 		mockRouteClient.EXPECT().AddNodePort(nodePortAddresses, uint16(svcNodePort), bindingProtocol).Times(1)
 	}
 	if svcType == corev1.ServiceTypeLoadBalancer {
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(0), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().AddExternalIPRoute(loadBalancerIP).Times(1)
 
 		mockOFClient.EXPECT().UninstallServiceFlows(loadBalancerIP, uint16(svcPort), bindingProtocol)
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(affinitySeconds), true, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, gomock.Any(), loadBalancerIP, uint16(svcPort), bindingProtocol, uint16(affinitySeconds), nil, true, false).Times(1)
 		mockRouteClient.EXPECT().DeleteExternalIPRoute(loadBalancerIP).Times(1)
 		mockRouteClient.EXPECT().AddExternalIPRoute(loadBalancerIP).Times(1)
 	}
@@ -2662,8 +3097,8 @@ func TestServicesWithSameEndpoints(t *testing.T) {
 	mockOFClient.EXPECT().InstallServiceGroup(groupID2, false, gomock.Any()).Times(1)
 	bindingProtocol := binding.ProtocolTCP
 	mockOFClient.EXPECT().InstallEndpointFlows(bindingProtocol, gomock.Any()).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID1, binding.GroupIDType(0), svc1IPv4, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
-	mockOFClient.EXPECT().InstallServiceFlows(groupID2, binding.GroupIDType(0), svc2IPv4, uint16(svcPort), bindingProtocol, uint16(0), false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID1, binding.GroupIDType(0), svc1IPv4, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID2, binding.GroupIDType(0), svc2IPv4, uint16(svcPort), bindingProtocol, uint16(0), nil, false, false).Times(1)
 	mockOFClient.EXPECT().UninstallServiceFlows(svc1IPv4, uint16(svcPort), bindingProtocol).Times(1)
 	mockOFClient.EXPECT().UninstallServiceFlows(svc2IPv4, uint16(svcPort), bindingProtocol).Times(1)
 	mockOFClient.EXPECT().UninstallServiceGroup(groupID1).Times(1)
@@ -2746,6 +3181,49 @@ func TestMetrics(t *testing.T) {
 	}
 }
 
+func TestEndpointDistributionMetrics(t *testing.T) {
+	legacyregistry.Reset()
+	metrics.Register()
+
+	fp := &proxier{
+		endpointsMap: types.EndpointsMap{},
+		groupBuckets: map[k8sproxy.ServicePortName]map[bool]int{},
+	}
+	service := svcPortName.String()
+	ep1 := k8sproxy.NewBaseEndpointInfo(ep1IPv4.String(), "", "", svcPort, false, true, true, false, nil)
+	ep2 := k8sproxy.NewBaseEndpointInfo(ep2IPv4.String(), hostname, "", svcPort, true, true, true, false, nil)
+	fp.endpointsMap[svcPortName] = map[string]k8sproxy.Endpoint{ep1.String(): ep1, ep2.String(): ep2}
+
+	getValue := func(m kmetrics.GaugeMetric) int {
+		v, err := testutil.GetGaugeMetricValue(m)
+		require.NoError(t, err)
+		return int(v)
+	}
+
+	// The cluster group only has the Endpoint hinted for the zone of the Node.
+	fp.groupBuckets[svcPortName] = map[bool]int{false: 1, true: 1}
+	fp.updateEndpointDistributionMetrics()
+	assert.Equal(t, 2, getValue(metrics.ServiceReadyEndpoints.WithLabelValues(service)))
+	assert.Equal(t, 1, getValue(metrics.ServiceGroupBuckets.WithLabelValues(service, "cluster")))
+	assert.Equal(t, 1, getValue(metrics.ServiceGroupBuckets.WithLabelValues(service, "local")))
+	assert.Equal(t, 1, getValue(metrics.ServiceGroupTruncated.WithLabelValues(service)))
+
+	// The cluster group has all the ready Endpoints and the local group is removed.
+	fp.groupBuckets[svcPortName] = map[bool]int{false: 2}
+	fp.updateEndpointDistributionMetrics()
+	assert.Equal(t, 2, getValue(metrics.ServiceGroupBuckets.WithLabelValues(service, "cluster")))
+	assert.Equal(t, 0, getValue(metrics.ServiceGroupTruncated.WithLabelValues(service)))
+	assert.False(t, metrics.ServiceGroupBuckets.Delete(map[string]string{"service": service, "group": "local"}))
+
+	// The metrics are not reported for a Service port with a single ready Endpoint.
+	delete(fp.endpointsMap[svcPortName], ep2.String())
+	fp.updateEndpointDistributionMetrics()
+	assert.Empty(t, fp.reportedServicePorts)
+	assert.False(t, metrics.ServiceReadyEndpoints.Delete(map[string]string{"service": service}))
+	assert.False(t, metrics.ServiceGroupBuckets.Delete(map[string]string{"service": service, "group": "cluster"}))
+	assert.False(t, metrics.ServiceGroupTruncated.Delete(map[string]string{"service": service}))
+}
+
 func TestGetServiceFlowKeys(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockOFClient, mockRouteClient := getMockClients(ctrl)
@@ -2815,8 +3293,8 @@ func TestGetServiceFlowKeys(t *testing.T) {
 				mockRouteClient.EXPECT().AddNodePort(nodePortAddressesIPv4, uint16(svcNodePort), binding.ProtocolTCP).Times(1)
 				mockOFClient.EXPECT().InstallServiceGroup(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
 				mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Times(1)
-				mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), gomock.Any(), uint16(svcNodePort), binding.ProtocolTCP, uint16(0), true, false).Times(1)
-				mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), svc1IPv4, uint16(svcPort), binding.ProtocolTCP, uint16(0), false, false).Times(1)
+				mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), gomock.Any(), uint16(svcNodePort), binding.ProtocolTCP, uint16(0), nil, true, false).Times(1)
+				mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), svc1IPv4, uint16(svcPort), binding.ProtocolTCP, uint16(0), nil, false, false).Times(1)
 				fp.syncProxyRules()
 			}
 
@@ -2834,6 +3312,63 @@ func TestGetServiceFlowKeys(t *testing.T) {
 	}
 }
 
+func TestGetServiceStates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOFClient, mockRouteClient := getMockClients(ctrl)
+	groupAllocator := openflow.NewGroupAllocator()
+	fp := newFakeProxier(mockRouteClient, mockOFClient, nodePortAddressesIPv4, groupAllocator, false, withProxyAll)
+	svc := makeTestNodePortService(&svcPortName,
+		svc1IPv4,
+		nil,
+		int32(svcPort),
+		int32(svcNodePort),
+		corev1.ProtocolTCP,
+		nil,
+		corev1.ServiceInternalTrafficPolicyCluster,
+		corev1.ServiceExternalTrafficPolicyTypeCluster)
+	ep, epPort := makeTestEndpointSliceEndpointAndPort(&svcPortName, ep1IPv4, int32(svcPort), corev1.ProtocolTCP, false)
+	eps := makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*ep}, []discovery.EndpointPort{*epPort}, false)
+	makeServiceMap(fp, svc)
+	makeEndpointSliceMap(fp, eps)
+
+	// The Service is expected but not installed yet.
+	states := fp.GetServiceStates()
+	require.Len(t, states, 1)
+	assert.Equal(t, svcPortName.String(), states[0].ServicePortName)
+	assert.False(t, states[0].Installed)
+	assert.Nil(t, states[0].GroupID)
+	assert.Empty(t, states[0].Endpoints)
+
+	mockRouteClient.EXPECT().AddNodePort(nodePortAddressesIPv4, uint16(svcNodePort), binding.ProtocolTCP)
+	mockOFClient.EXPECT().InstallServiceGroup(gomock.Any(), gomock.Any(), gomock.Any())
+	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any())
+	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), gomock.Any(), uint16(svcNodePort), binding.ProtocolTCP, uint16(0), nil, true, false)
+	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), svc1IPv4, uint16(svcPort), binding.ProtocolTCP, uint16(0), nil, false, false)
+	fp.syncProxyRules()
+
+	groupID, _ := fp.groupCounter.Get(svcPortName, false)
+	expectedGroupID := uint32(groupID)
+	expectedStates := []types.ServiceState{
+		{
+			ServicePortName: svcPortName.String(),
+			Installed:       true,
+			ClusterIP:       svc1IPv4.String(),
+			Port:            svcPort,
+			Protocol:        "TCP",
+			NodePort:        svcNodePort,
+			GroupID:         &expectedGroupID,
+			Endpoints: []types.EndpointState{
+				{
+					Endpoint: net.JoinHostPort(ep1IPv4.String(), strconv.Itoa(svcPort)),
+					Ready:    true,
+					Serving:  true,
+				},
+			},
+		},
+	}
+	assert.Equal(t, expectedStates, fp.GetServiceStates())
+}
+
 func TestServiceLabelSelector(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockOFClient, mockRouteClient := getMockClients(ctrl)
@@ -2863,7 +3398,7 @@ func TestServiceLabelSelector(t *testing.T) {
 
 		groupID := fp.groupCounter.AllocateIfNotExist(svcPortName2, false)
 		mockOFClient.EXPECT().InstallServiceGroup(groupID, false, []k8sproxy.Endpoint{}).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svc2IP, uint16(svcPort), gomock.Any(), uint16(0), false, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svc2IP, uint16(svcPort), gomock.Any(), uint16(0), nil, false, false).Times(1)
 		fp.syncProxyRules()
 		assert.Contains(t, fp.serviceInstalledMap, svcPortName2)
 	})
@@ -2878,8 +3413,32 @@ func TestServiceLabelSelector(t *testing.T) {
 
 		groupID := fp.groupCounter.AllocateIfNotExist(svcPortName1, false)
 		mockOFClient.EXPECT().InstallServiceGroup(groupID, false, []k8sproxy.Endpoint{}).Times(1)
-		mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svc1IP, uint16(svcPort), gomock.Any(), uint16(0), false, false).Times(1)
+		mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svc1IP, uint16(svcPort), gomock.Any(), uint16(0), nil, false, false).Times(1)
 		fp.syncProxyRules()
 		assert.Contains(t, fp.serviceInstalledMap, svcPortName1)
 	})
 }
+
+func TestSkipServiceProtocols(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOFClient, mockRouteClient := getMockClients(ctrl)
+	groupAllocator := openflow.NewGroupAllocator()
+	fp := newFakeProxier(mockRouteClient, mockOFClient, nil, groupAllocator, false, withSkipServiceProtocols(corev1.ProtocolUDP))
+
+	svcPortNameTCP := makeSvcPortName("ns", "svc1", strconv.Itoa(svcPort), corev1.ProtocolTCP)
+	svcPortNameUDP := makeSvcPortName("ns", "svc2", strconv.Itoa(svcPort), corev1.ProtocolUDP)
+	svcTCPIP := net.ParseIP("1.1.1.1")
+	svcUDPIP := net.ParseIP("1.1.1.2")
+	svcTCP := makeTestClusterIPService(&svcPortNameTCP, svcTCPIP, nil, int32(svcPort), corev1.ProtocolTCP, nil, nil, false, nil)
+	svcUDP := makeTestClusterIPService(&svcPortNameUDP, svcUDPIP, nil, int32(svcPort), corev1.ProtocolUDP, nil, nil, false, nil)
+	makeServiceMap(fp, svcTCP, svcUDP)
+	makeEndpointSliceMap(fp)
+
+	// Only the Service port of TCP should be processed, the Service port of UDP is left to kube-proxy.
+	groupID := fp.groupCounter.AllocateIfNotExist(svcPortNameTCP, false)
+	mockOFClient.EXPECT().InstallServiceGroup(groupID, false, []k8sproxy.Endpoint{}).Times(1)
+	mockOFClient.EXPECT().InstallServiceFlows(groupID, binding.GroupIDType(0), svcTCPIP, uint16(svcPort), binding.ProtocolTCP, uint16(0), nil, false, false).Times(1)
+	fp.syncProxyRules()
+	assert.Contains(t, fp.serviceInstalledMap, svcPortNameTCP)
+	assert.NotContains(t, fp.serviceMap, svcPortNameUDP)
+}
@@ -19,6 +19,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 
 	"antrea.io/antrea/pkg/agent/proxy/types"
@@ -32,8 +33,8 @@ type serviceChangesTracker struct {
 	initialized bool
 }
 
-func newServiceChangesTracker(recorder record.EventRecorder, ipFamily v1.IPFamily, serviceLabelSelector labels.Selector, skipServices []string) *serviceChangesTracker {
-	return &serviceChangesTracker{tracker: k8sproxy.NewServiceChangeTracker(types.NewServiceInfo, ipFamily, recorder, nil, serviceLabelSelector, skipServices)}
+func newServiceChangesTracker(recorder record.EventRecorder, ipFamily v1.IPFamily, serviceLabelSelector labels.Selector, skipServices []string, skipServiceProtocols []v1.Protocol) *serviceChangesTracker {
+	return &serviceChangesTracker{tracker: k8sproxy.NewServiceChangeTracker(types.NewServiceInfo, ipFamily, recorder, nil, serviceLabelSelector, skipServices, skipServiceProtocols)}
 }
 
 func (sh *serviceChangesTracker) OnServiceSynced() {
@@ -47,6 +48,10 @@ func (sh *serviceChangesTracker) OnServiceUpdate(previous, current *v1.Service)
 	return sh.tracker.Update(previous, current)
 }
 
+func (sh *serviceChangesTracker) OnSkipServicesUpdate(skipServices sets.Set[string], skipServiceSelector labels.Selector, services []*v1.Service) bool {
+	return sh.tracker.UpdateSkipServices(skipServices, skipServiceSelector, services)
+}
+
 func (sh *serviceChangesTracker) Synced() bool {
 	sh.Lock()
 	defer sh.Unlock()
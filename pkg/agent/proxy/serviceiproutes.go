@@ -0,0 +1,98 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net"
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"antrea.io/antrea/pkg/agent/proxy/types"
+	k8sproxy "antrea.io/antrea/third_party/proxy"
+)
+
+// serviceIPRouteReferences tracks the references of Service IP routes. A Service IP route may be required by several
+// ServicePorts, either because a Service has multiple ports, or because several Services claim the same IP, e.g. the
+// same external IP with different ports. With the references, a route is installed exactly once as long as it's used
+// by any ServicePort, and uninstalled exactly once when the last ServicePort referencing it goes away.
+// It is shared by the IPv4 and IPv6 proxier instances of a dual-stack proxier, which sync their rules concurrently.
+// The route is added or deleted while holding the lock, so that removing the last reference to an IP cannot race with
+// another ServicePort starting to reference it.
+type serviceIPRouteReferences struct {
+	mutex sync.Mutex
+	// references maps a Service IP to the set of ServicePortName strings referencing it.
+	references map[string]sets.Set[string]
+}
+
+func newServiceIPRouteReferences() *serviceIPRouteReferences {
+	return &serviceIPRouteReferences{references: map[string]sets.Set[string]{}}
+}
+
+// addReference adds a reference of the ServicePort to the route of the IP, and calls addRouteFn to install the route
+// if the IP was not referenced by any ServicePort.
+func (r *serviceIPRouteReferences) addReference(svcPortName k8sproxy.ServicePortName, ip net.IP, addRouteFn func(net.IP) error) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	ipStr := ip.String()
+	references, exists := r.references[ipStr]
+	if !exists {
+		if err := addRouteFn(ip); err != nil {
+			return err
+		}
+		references = sets.New[string]()
+		r.references[ipStr] = references
+	}
+	references.Insert(svcPortName.String())
+	return nil
+}
+
+// deleteReference deletes the reference of the ServicePort to the route of the IP, and calls deleteRouteFn to uninstall
+// the route if the ServicePort was the last one referencing it. It's a no-op if the ServicePort doesn't reference the
+// IP.
+func (r *serviceIPRouteReferences) deleteReference(svcPortName k8sproxy.ServicePortName, ip net.IP, deleteRouteFn func(net.IP) error) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	ipStr := ip.String()
+	referent := svcPortName.String()
+	references, exists := r.references[ipStr]
+	if !exists || !references.Has(referent) {
+		return nil
+	}
+	if references.Len() == 1 {
+		if err := deleteRouteFn(ip); err != nil {
+			return err
+		}
+		delete(r.references, ipStr)
+		return nil
+	}
+	references.Delete(referent)
+	return nil
+}
+
+// getReferences returns the Service IP routes and the ServicePorts referencing them, sorted by IP.
+func (r *serviceIPRouteReferences) getReferences() []types.ServiceIPRouteReference {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	result := make([]types.ServiceIPRouteReference, 0, len(r.references))
+	for ip, references := range r.references {
+		result = append(result, types.ServiceIPRouteReference{IP: ip, ServicePorts: sets.List(references)})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].IP < result[j].IP
+	})
+	return result
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimachinerytypes "k8s.io/apimachinery/pkg/types"
+
+	"antrea.io/antrea/pkg/agent/proxy/types"
+	k8sproxy "antrea.io/antrea/third_party/proxy"
+)
+
+type fakeRouteRecorder struct {
+	mutex  sync.Mutex
+	routes map[string]int
+	err    error
+}
+
+func (r *fakeRouteRecorder) add(ip net.IP) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.routes[ip.String()]++
+	return nil
+}
+
+func (r *fakeRouteRecorder) delete(ip net.IP) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	r.routes[ip.String()]--
+	if r.routes[ip.String()] == 0 {
+		delete(r.routes, ip.String())
+	}
+	return nil
+}
+
+func TestServiceIPRouteReferences(t *testing.T) {
+	ip1 := net.ParseIP("192.168.77.100")
+	ip2 := net.ParseIP("192.168.77.101")
+	svc1Port80 := k8sproxy.ServicePortName{NamespacedName: apimachinerytypes.NamespacedName{Namespace: "ns1", Name: "svc1"}, Port: "http"}
+	svc1Port443 := k8sproxy.ServicePortName{NamespacedName: apimachinerytypes.NamespacedName{Namespace: "ns1", Name: "svc1"}, Port: "https"}
+	svc2Port80 := k8sproxy.ServicePortName{NamespacedName: apimachinerytypes.NamespacedName{Namespace: "ns2", Name: "svc2"}, Port: "http"}
+
+	recorder := &fakeRouteRecorder{routes: map[string]int{}}
+	references := newServiceIPRouteReferences()
+
+	require.NoError(t, references.addReference(svc1Port80, ip1, recorder.add))
+	require.NoError(t, references.addReference(svc1Port443, ip1, recorder.add))
+	require.NoError(t, references.addReference(svc2Port80, ip1, recorder.add))
+	require.NoError(t, references.addReference(svc2Port80, ip2, recorder.add))
+	// Adding an existing reference again is a no-op.
+	require.NoError(t, references.addReference(svc2Port80, ip2, recorder.add))
+	// The routes are installed exactly once.
+	assert.Equal(t, map[string]int{ip1.String(): 1, ip2.String(): 1}, recorder.routes)
+	assert.Equal(t, []types.ServiceIPRouteReference{
+		{IP: ip1.String(), ServicePorts: []string{"ns1/svc1:http", "ns1/svc1:https", "ns2/svc2:http"}},
+		{IP: ip2.String(), ServicePorts: []string{"ns2/svc2:http"}},
+	}, references.getReferences())
+
+	// Removing all the ports of a Service doesn't remove the route still referenced by another Service.
+	require.NoError(t, references.deleteReference(svc1Port80, ip1, recorder.delete))
+	require.NoError(t, references.deleteReference(svc1Port443, ip1, recorder.delete))
+	// Deleting a reference which doesn't exist is a no-op.
+	require.NoError(t, references.deleteReference(svc1Port80, ip2, recorder.delete))
+	assert.Equal(t, map[string]int{ip1.String(): 1, ip2.String(): 1}, recorder.routes)
+	assert.Equal(t, []types.ServiceIPRouteReference{
+		{IP: ip1.String(), ServicePorts: []string{"ns2/svc2:http"}},
+		{IP: ip2.String(), ServicePorts: []string{"ns2/svc2:http"}},
+	}, references.getReferences())
+
+	// A failure to delete the route keeps the reference, so that the deletion is retried.
+	recorder.err = fmt.Errorf("route error")
+	assert.Error(t, references.deleteReference(svc2Port80, ip1, recorder.delete))
+	assert.Len(t, references.getReferences(), 2)
+	// A failure to install the route doesn't add the reference.
+	assert.Error(t, references.addReference(svc1Port80, net.ParseIP("192.168.77.102"), recorder.add))
+	assert.Len(t, references.getReferences(), 2)
+	recorder.err = nil
+
+	require.NoError(t, references.deleteReference(svc2Port80, ip1, recorder.delete))
+	require.NoError(t, references.deleteReference(svc2Port80, ip2, recorder.delete))
+	assert.Empty(t, recorder.routes)
+	assert.Empty(t, references.getReferences())
+}
+
+func TestServiceIPRouteReferencesConcurrentUpdates(t *testing.T) {
+	ip := net.ParseIP("192.168.77.100")
+	recorder := &fakeRouteRecorder{routes: map[string]int{}}
+	references := newServiceIPRouteReferences()
+	// Simulate the IPv4 and IPv6 proxier instances claiming and releasing the same IP concurrently. The route must
+	// never be installed more than once, and must be removed once all references are gone.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		svcPortName := k8sproxy.ServicePortName{NamespacedName: apimachinerytypes.NamespacedName{Namespace: "ns", Name: fmt.Sprintf("svc%d", i)}}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.NoError(t, references.addReference(svcPortName, ip, recorder.add))
+				recorder.mutex.Lock()
+				assert.Equal(t, 1, recorder.routes[ip.String()])
+				recorder.mutex.Unlock()
+				assert.NoError(t, references.deleteReference(svcPortName, ip, recorder.delete))
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Empty(t, recorder.routes)
+	assert.Empty(t, references.getReferences())
+}
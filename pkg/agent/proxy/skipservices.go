@@ -0,0 +1,183 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+const (
+	skipServicesControllerName = "AntreaAgentSkipServicesController"
+	// skipServicesKey is the data key of the ConfigMap listing the Services to skip, one ClusterIP or
+	// Namespace/name string per line.
+	skipServicesKey = "services"
+	// skipServiceSelectorKey is the data key of the ConfigMap holding the label selector of the Services to skip.
+	skipServiceSelectorKey = "selector"
+	// There is only one ConfigMap to sync, so a constant key is used for the workqueue.
+	skipServicesWorkerKey = "key"
+
+	skipServicesMinRetryDelay = 5 * time.Second
+	skipServicesMaxRetryDelay = 300 * time.Second
+)
+
+// SkipServicesController watches a ConfigMap listing the Services which should be ignored by AntreaProxy, in
+// addition to the ones specified with the skipServices option, and updates the proxier when it changes. It makes
+// it possible to hand over Services to another proxy, or to take them back, without restarting the Antrea Agent.
+type SkipServicesController struct {
+	proxier Proxier
+	// staticSkipServices are the Services specified with the skipServices option, which are always skipped.
+	staticSkipServices []string
+	configMapInformer  cache.SharedIndexInformer
+	configMapLister    corelisters.ConfigMapLister
+	configMapNamespace string
+	configMapName      string
+	queue              workqueue.RateLimitingInterface
+	// synced indicates whether the proxier has been updated with the content of the ConfigMap at least once.
+	synced atomic.Bool
+}
+
+// NewSkipServicesController returns a new *SkipServicesController which watches the ConfigMap with the provided
+// name and Namespace.
+func NewSkipServicesController(k8sClient clientset.Interface, proxier Proxier, staticSkipServices []string, namespace, name string) *SkipServicesController {
+	configMapInformer := coreinformers.NewFilteredConfigMapInformer(k8sClient, namespace, resyncPeriod, cache.Indexers{}, func(options *metav1.ListOptions) {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+	})
+	c := &SkipServicesController{
+		proxier:            proxier,
+		staticSkipServices: staticSkipServices,
+		configMapInformer:  configMapInformer,
+		configMapLister:    corelisters.NewConfigMapLister(configMapInformer.GetIndexer()),
+		configMapNamespace: namespace,
+		configMapName:      name,
+		queue:              workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(skipServicesMinRetryDelay, skipServicesMaxRetryDelay), "skipServices"),
+	}
+	configMapInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.queue.Add(skipServicesWorkerKey)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.queue.Add(skipServicesWorkerKey)
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.queue.Add(skipServicesWorkerKey)
+		},
+	})
+	return c
+}
+
+// Run starts watching the ConfigMap and blocks until stopCh is closed.
+func (c *SkipServicesController) Run(stopCh <-chan struct{}) {
+	defer c.queue.ShutDown()
+
+	klog.InfoS("Starting controller", "name", skipServicesControllerName)
+	defer klog.InfoS("Shutting down controller", "name", skipServicesControllerName)
+
+	go c.configMapInformer.Run(stopCh)
+	if !cache.WaitForNamedCacheSync(skipServicesControllerName, stopCh, c.configMapInformer.HasSynced) {
+		return
+	}
+	// Sync once in case the ConfigMap doesn't exist.
+	c.queue.Add(skipServicesWorkerKey)
+	go wait.Until(c.worker, time.Second, stopCh)
+	<-stopCh
+}
+
+func (c *SkipServicesController) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *SkipServicesController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+	if err := c.sync(); err != nil {
+		klog.ErrorS(err, "Failed to sync Services to skip, retrying")
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *SkipServicesController) sync() error {
+	var skipServices []string
+	var skipServiceSelector labels.Selector
+	configMap, err := c.configMapLister.ConfigMaps(c.configMapNamespace).Get(c.configMapName)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		// The ConfigMap doesn't exist, only the Services specified with the skipServices option are skipped.
+	} else {
+		skipServices, skipServiceSelector, err = parseSkipServicesConfigMap(configMap)
+		if err != nil {
+			// Retrying doesn't help, wait for the ConfigMap to be fixed and keep the current Services to skip.
+			klog.ErrorS(err, "Invalid ConfigMap of Services to skip, ignoring it", "configMap", klog.KObj(configMap))
+			return nil
+		}
+	}
+	allSkipServices := make([]string, 0, len(c.staticSkipServices)+len(skipServices))
+	allSkipServices = append(allSkipServices, c.staticSkipServices...)
+	allSkipServices = append(allSkipServices, skipServices...)
+	c.proxier.UpdateSkipServices(allSkipServices, skipServiceSelector)
+	c.synced.Store(true)
+	return nil
+}
+
+// HasSynced returns true if the proxier has been updated with the content of the ConfigMap at least once.
+func (c *SkipServicesController) HasSynced() bool {
+	return c.synced.Load()
+}
+
+// parseSkipServicesConfigMap returns the Services listed in the ConfigMap and the label selector it specifies. Empty
+// lines and lines starting with "#" are ignored. The returned selector is nil if the ConfigMap doesn't specify one.
+func parseSkipServicesConfigMap(configMap *corev1.ConfigMap) ([]string, labels.Selector, error) {
+	var skipServices []string
+	for _, line := range strings.Split(configMap.Data[skipServicesKey], "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		skipServices = append(skipServices, line)
+	}
+	var skipServiceSelector labels.Selector
+	if selector := strings.TrimSpace(configMap.Data[skipServiceSelectorKey]); selector != "" {
+		var err error
+		skipServiceSelector, err = labels.Parse(selector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid selector %q: %w", selector, err)
+		}
+	}
+	return skipServices, skipServiceSelector, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+
+	proxytesting "antrea.io/antrea/pkg/agent/proxy/testing"
+	k8sproxy "antrea.io/antrea/third_party/proxy"
+)
+
+func TestParseSkipServicesConfigMap(t *testing.T) {
+	tests := []struct {
+		name             string
+		data             map[string]string
+		expectedServices []string
+		expectedSelector string
+		expectedErr      string
+	}{
+		{
+			name: "services and selector",
+			data: map[string]string{
+				"services": "# DNS\nkube-system/kube-dns\n\n  10.96.0.100  \n",
+				"selector": "app=foo",
+			},
+			expectedServices: []string{"kube-system/kube-dns", "10.96.0.100"},
+			expectedSelector: "app=foo",
+		},
+		{
+			name: "no data",
+		},
+		{
+			name:        "invalid selector",
+			data:        map[string]string{"selector": "app in foo"},
+			expectedErr: "invalid selector",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "skip"}, Data: tt.data}
+			services, selector, err := parseSkipServicesConfigMap(configMap)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedServices, services)
+			if tt.expectedSelector == "" {
+				assert.Nil(t, selector)
+			} else {
+				assert.Equal(t, tt.expectedSelector, selector.String())
+			}
+		})
+	}
+}
+
+func TestSkipServicesController(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "skip"},
+		Data:       map[string]string{"services": "ns1/svc1"},
+	}
+	k8sClient := fake.NewSimpleClientset(configMap)
+	ctrl := gomock.NewController(t)
+	mockProxier := proxytesting.NewMockProxier(ctrl)
+	c := NewSkipServicesController(k8sClient, mockProxier, []string{"10.96.0.10"}, "kube-system", "skip")
+
+	updated := make(chan []string, 10)
+	mockProxier.EXPECT().UpdateSkipServices(gomock.Any(), gomock.Any()).Do(func(skipServices []string, _ labels.Selector) {
+		updated <- skipServices
+	}).AnyTimes()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go c.Run(stopCh)
+
+	expectUpdate := func(expected []string) {
+		select {
+		case skipServices := <-updated:
+			assert.Equal(t, expected, skipServices)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected proxier to be updated with %v", expected)
+		}
+	}
+	// The initial sync may be triggered twice.
+	expectUpdate([]string{"10.96.0.10", "ns1/svc1"})
+	assert.Eventually(t, c.HasSynced, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, k8sClient.CoreV1().ConfigMaps("kube-system").Delete(context.TODO(), "skip", metav1.DeleteOptions{}))
+	for {
+		select {
+		case skipServices := <-updated:
+			if len(skipServices) == 1 {
+				assert.Equal(t, []string{"10.96.0.10"}, skipServices)
+				return
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected proxier to be updated after the ConfigMap is deleted")
+		}
+	}
+}
+
+func TestServiceChangesTrackerSkipServices(t *testing.T) {
+	svc := makeTestService("ns1", "svc1", func(svc *corev1.Service) {
+		svc.Labels = map[string]string{"app": "foo"}
+		svc.Spec.ClusterIP = "10.96.0.1"
+		svc.Spec.Ports = []corev1.ServicePort{{Name: "http", Port: 80, Protocol: corev1.ProtocolTCP}}
+	})
+	tracker := newServiceChangesTracker(nil, corev1.IPv4Protocol, labels.Everything(), nil, nil)
+	serviceMap := k8sproxy.ServiceMap{}
+	tracker.OnServiceUpdate(nil, svc)
+	tracker.Update(serviceMap)
+	assert.Len(t, serviceMap, 1)
+
+	// Skip the Service by name.
+	assert.True(t, tracker.OnSkipServicesUpdate(sets.New[string]("ns1/svc1"), nil, []*corev1.Service{svc}))
+	tracker.Update(serviceMap)
+	assert.Empty(t, serviceMap)
+
+	// Skip the Service by selector, which doesn't change the ServiceMap.
+	selector, _ := labels.Parse("app=foo")
+	assert.False(t, tracker.OnSkipServicesUpdate(sets.New[string](), selector, []*corev1.Service{svc}))
+
+	// Stop skipping the Service.
+	assert.True(t, tracker.OnSkipServicesUpdate(sets.New[string](), nil, []*corev1.Service{svc}))
+	tracker.Update(serviceMap)
+	assert.Len(t, serviceMap, 1)
+}
@@ -20,9 +20,12 @@
 package testing
 
 import (
+	types "antrea.io/antrea/pkg/agent/proxy/types"
 	openflow "antrea.io/antrea/pkg/ovs/openflow"
 	proxy "antrea.io/antrea/third_party/proxy"
 	gomock "github.com/golang/mock/gomock"
+	labels "k8s.io/apimachinery/pkg/labels"
+	net "net"
 	reflect "reflect"
 )
 
@@ -93,3 +96,57 @@ func (mr *MockProxierMockRecorder) GetServiceFlowKeys(arg0, arg1 interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceFlowKeys", reflect.TypeOf((*MockProxier)(nil).GetServiceFlowKeys), arg0, arg1)
 }
+
+// GetServiceIPRouteReferences mocks base method
+func (m *MockProxier) GetServiceIPRouteReferences() []types.ServiceIPRouteReference {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceIPRouteReferences")
+	ret0, _ := ret[0].([]types.ServiceIPRouteReference)
+	return ret0
+}
+
+// GetServiceIPRouteReferences indicates an expected call of GetServiceIPRouteReferences
+func (mr *MockProxierMockRecorder) GetServiceIPRouteReferences() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceIPRouteReferences", reflect.TypeOf((*MockProxier)(nil).GetServiceIPRouteReferences))
+}
+
+// GetServiceStates mocks base method
+func (m *MockProxier) GetServiceStates() []types.ServiceState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceStates")
+	ret0, _ := ret[0].([]types.ServiceState)
+	return ret0
+}
+
+// GetServiceStates indicates an expected call of GetServiceStates
+func (mr *MockProxierMockRecorder) GetServiceStates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceStates", reflect.TypeOf((*MockProxier)(nil).GetServiceStates))
+}
+
+// UpdateNodePortAddresses mocks base method
+func (m *MockProxier) UpdateNodePortAddresses(arg0, arg1 []net.IP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNodePortAddresses", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNodePortAddresses indicates an expected call of UpdateNodePortAddresses
+func (mr *MockProxierMockRecorder) UpdateNodePortAddresses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodePortAddresses", reflect.TypeOf((*MockProxier)(nil).UpdateNodePortAddresses), arg0, arg1)
+}
+
+// UpdateSkipServices mocks base method
+func (m *MockProxier) UpdateSkipServices(arg0 []string, arg1 labels.Selector) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateSkipServices", arg0, arg1)
+}
+
+// UpdateSkipServices indicates an expected call of UpdateSkipServices
+func (mr *MockProxierMockRecorder) UpdateSkipServices(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSkipServices", reflect.TypeOf((*MockProxier)(nil).UpdateSkipServices), arg0, arg1)
+}
@@ -67,6 +67,10 @@ func (p *proxier) categorizeEndpoints(endpoints map[string]k8sproxy.Endpoint, sv
 		}
 		return true
 	})
+	// Prefer the local Endpoints on the same NUMA node as the ingress NIC if possible.
+	if p.numaAffinity != nil && len(localEndpoints) > 0 {
+		localEndpoints = p.numaAffinity.filterEndpoints(localEndpoints)
+	}
 
 	// If there is no local Endpoint, fallback to terminating local Endpoints that are serving. When falling back to
 	// terminating Endpoints, and topology aware routing is NOT considered since this is the best effort attempt to
@@ -160,6 +164,26 @@ func (p *proxier) canUseTopology(endpoints map[string]k8sproxy.Endpoint, svcInfo
 	return true
 }
 
+// usesTopologyHints returns true if the Endpoints selected for the Service may depend on the topology labels of the
+// Node, i.e. if topology aware routing is enabled and requested for the Service.
+func (p *proxier) usesTopologyHints(svcInfo k8sproxy.ServicePort) bool {
+	if !p.topologyAwareHintsEnabled {
+		return false
+	}
+	hintsAnnotation := svcInfo.HintsAnnotation()
+	return hintsAnnotation == "Auto" || hintsAnnotation == "auto"
+}
+
+// topologyLabelsChanged returns true if any Node label used for topology aware routing has changed.
+func topologyLabelsChanged(oldLabels, newLabels map[string]string) bool {
+	for _, label := range []string{v1.LabelTopologyZone, v1.LabelTopologyRegion} {
+		if oldLabels[label] != newLabels[label] {
+			return true
+		}
+	}
+	return false
+}
+
 // availableForTopology checks if this endpoint is available for use on this node, given
 // topology constraints. (It assumes that canUseTopology() returned true.)
 func availableForTopology(endpoint k8sproxy.Endpoint, nodeLabels map[string]string) bool {
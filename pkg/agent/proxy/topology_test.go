@@ -17,12 +17,20 @@ package proxy
 import (
 	"fmt"
 	"net"
+	"strconv"
 	"testing"
 
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	"antrea.io/antrea/pkg/agent/openflow"
+	ofmock "antrea.io/antrea/pkg/agent/openflow/testing"
+	binding "antrea.io/antrea/pkg/ovs/openflow"
 	k8sproxy "antrea.io/antrea/third_party/proxy"
 )
 
@@ -516,3 +524,107 @@ func TestCategorizeEndpoints(t *testing.T) {
 		})
 	}
 }
+
+func TestTopologyLabelsChanged(t *testing.T) {
+	testCases := []struct {
+		name      string
+		oldLabels map[string]string
+		newLabels map[string]string
+		expected  bool
+	}{
+		{
+			name:      "zone changed",
+			oldLabels: map[string]string{v1.LabelTopologyZone: "zone-a"},
+			newLabels: map[string]string{v1.LabelTopologyZone: "zone-b"},
+			expected:  true,
+		},
+		{
+			name:      "region added",
+			oldLabels: map[string]string{v1.LabelTopologyZone: "zone-a"},
+			newLabels: map[string]string{v1.LabelTopologyZone: "zone-a", v1.LabelTopologyRegion: "region-a"},
+			expected:  true,
+		},
+		{
+			name:      "labels deleted",
+			oldLabels: map[string]string{v1.LabelTopologyZone: "zone-a"},
+			newLabels: nil,
+			expected:  true,
+		},
+		{
+			name:      "other label changed",
+			oldLabels: map[string]string{v1.LabelTopologyZone: "zone-a", "foo": "bar"},
+			newLabels: map[string]string{v1.LabelTopologyZone: "zone-a", "foo": "baz"},
+			expected:  false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, topologyLabelsChanged(tc.oldLabels, tc.newLabels))
+		})
+	}
+}
+
+func TestNodeZoneRelabeling(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOFClient, mockRouteClient := getMockClients(ctrl)
+	fp := newFakeProxier(mockRouteClient, mockOFClient, nil, openflow.NewGroupAllocator(), false)
+	fp.topologyAwareHintsEnabled = true
+	fp.nodeLabels = map[string]string{v1.LabelTopologyZone: "zone-a"}
+
+	hintedSvcPortName := makeSvcPortName("ns", "svc-hinted", strconv.Itoa(svcPort), v1.ProtocolTCP)
+	plainSvcPortName := makeSvcPortName("ns", "svc-plain", strconv.Itoa(svcPort), v1.ProtocolTCP)
+	hintedSvc := makeTestClusterIPService(&hintedSvcPortName, svc1IPv4, nil, int32(svcPort), v1.ProtocolTCP, nil, nil, false, nil)
+	hintedSvc.Annotations[v1.AnnotationTopologyAwareHints] = "Auto"
+	plainSvc := makeTestClusterIPService(&plainSvcPortName, svc2IPv4, nil, int32(svcPort), v1.ProtocolTCP, nil, nil, false, nil)
+	makeServiceMap(fp, hintedSvc, plainSvc)
+
+	makeHintedEndpointSlice := func(svcPortName *k8sproxy.ServicePortName, ep1IP, ep2IP net.IP) *discovery.EndpointSlice {
+		ep1, ep1Port := makeTestEndpointSliceEndpointAndPort(svcPortName, ep1IP, int32(svcPort), v1.ProtocolTCP, false)
+		ep1.Hints = &discovery.EndpointHints{ForZones: []discovery.ForZone{{Name: "zone-a"}}}
+		ep2, ep2Port := makeTestEndpointSliceEndpointAndPort(svcPortName, ep2IP, int32(svcPort), v1.ProtocolTCP, false)
+		ep2.Hints = &discovery.EndpointHints{ForZones: []discovery.ForZone{{Name: "zone-b"}}}
+		return makeTestEndpointSlice(svcPortName.Namespace, svcPortName.Name, []discovery.Endpoint{*ep1, *ep2}, []discovery.EndpointPort{*ep1Port, *ep2Port}, false)
+	}
+	makeEndpointSliceMap(fp,
+		makeHintedEndpointSlice(&hintedSvcPortName, ep1IPv4, ep2IPv4),
+		makeHintedEndpointSlice(&plainSvcPortName, net.ParseIP("10.180.0.3"), net.ParseIP("10.180.0.4")))
+
+	mockOFClient.EXPECT().InstallEndpointFlows(gomock.Any(), gomock.Any()).AnyTimes()
+	mockOFClient.EXPECT().InstallServiceGroup(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	mockOFClient.EXPECT().InstallServiceFlows(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	fp.syncProxyRules()
+	assert.Len(t, fp.endpointsInstalledMap[hintedSvcPortName], 1)
+	assert.Len(t, fp.endpointsInstalledMap[plainSvcPortName], 2)
+
+	// From now on, any unexpected OVS operation fails the test.
+	ctrl = gomock.NewController(t)
+	mockOFClient = ofmock.NewMockClient(ctrl)
+	fp.ofClient = mockOFClient
+
+	// Changing a label unrelated to the topology doesn't trigger any re-evaluation.
+	fp.OnNodeUpdate(nil, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: hostname, Labels: map[string]string{v1.LabelTopologyZone: "zone-a", "foo": "bar"}}})
+	assert.Equal(t, "bar", fp.nodeLabels["foo"])
+
+	// Changing the zone only updates the topology aware Service.
+	hintedGroupID, _ := fp.groupCounter.Get(hintedSvcPortName, false)
+	expectedEndpoint := fmt.Sprintf("%s:%d", ep2IPv4, svcPort)
+	staleEndpoint := fmt.Sprintf("%s:%d", ep1IPv4, svcPort)
+	checkEndpoints := func(expected string, endpoints []k8sproxy.Endpoint) {
+		if assert.Len(t, endpoints, 1) {
+			assert.Equal(t, expected, endpoints[0].String())
+		}
+	}
+	mockOFClient.EXPECT().InstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Do(func(_ binding.Protocol, endpoints []k8sproxy.Endpoint) {
+		checkEndpoints(expectedEndpoint, endpoints)
+	}).Times(1)
+	mockOFClient.EXPECT().UninstallEndpointFlows(binding.ProtocolTCP, gomock.Any()).Do(func(_ binding.Protocol, endpoints []k8sproxy.Endpoint) {
+		checkEndpoints(staleEndpoint, endpoints)
+	}).Times(1)
+	mockOFClient.EXPECT().InstallServiceGroup(hintedGroupID, false, gomock.Any()).Do(func(_ binding.GroupIDType, _ bool, endpoints []k8sproxy.Endpoint) {
+		checkEndpoints(expectedEndpoint, endpoints)
+	}).Times(1)
+	fp.OnNodeUpdate(nil, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: hostname, Labels: map[string]string{v1.LabelTopologyZone: "zone-b"}}})
+	assert.Contains(t, fp.endpointsInstalledMap[hintedSvcPortName], expectedEndpoint)
+	assert.NotContains(t, fp.endpointsInstalledMap[hintedSvcPortName], staleEndpoint)
+	assert.Len(t, fp.endpointsInstalledMap[plainSvcPortName], 2)
+}
@@ -28,7 +28,9 @@ import (
 // GroupCounter generates and manages global unique group ID.
 type GroupCounter interface {
 	// AllocateIfNotExist generates a global unique group ID for a Service if the group ID has not been generated, then
-	// return the group ID (newly allocated or already allocated).
+	// return the group ID (newly allocated or already allocated). The group ID is derived from the ServicePortName, the
+	// IP family and the scope of the Endpoints, so that the Service gets the same group ID after the agent restarts and
+	// the stale flows keep referring to the right group until they are replaced.
 	AllocateIfNotExist(svcPortName k8sproxy.ServicePortName, isEndpointsLocal bool) binding.GroupIDType
 	// Get gets the group ID for the Service.
 	Get(svcPortName k8sproxy.ServicePortName, isEndpointsLocal bool) (binding.GroupIDType, bool)
@@ -42,13 +44,14 @@ type groupCounter struct {
 	mu             sync.Mutex
 	groupAllocator openflow.GroupAllocator
 	groupIDUpdates chan<- string
+	isIPv6         bool
 
 	servicePortNamesMap map[string]sets.Set[string]
 	groupMap            map[string]binding.GroupIDType
 }
 
-func NewGroupCounter(groupAllocator openflow.GroupAllocator, groupIDUpdates chan<- string) *groupCounter {
-	return &groupCounter{groupMap: map[string]binding.GroupIDType{}, groupAllocator: groupAllocator, groupIDUpdates: groupIDUpdates, servicePortNamesMap: map[string]sets.Set[string]{}}
+func NewGroupCounter(groupAllocator openflow.GroupAllocator, groupIDUpdates chan<- string, isIPv6 bool) *groupCounter {
+	return &groupCounter{groupMap: map[string]binding.GroupIDType{}, groupAllocator: groupAllocator, groupIDUpdates: groupIDUpdates, isIPv6: isIPv6, servicePortNamesMap: map[string]sets.Set[string]{}}
 }
 
 func keyString(svcPortName k8sproxy.ServicePortName, isEndpointsLocal bool) string {
@@ -86,7 +89,13 @@ func (c *groupCounter) AllocateIfNotExist(svcPortName k8sproxy.ServicePortName,
 	if id, ok := c.groupMap[key]; ok {
 		return id
 	}
-	id := c.groupAllocator.Allocate()
+	// The v4 and v6 groupCounters share the same groupAllocator, so the IP family is part of the key to derive the
+	// group ID from.
+	allocatorKey := "ipv4/" + key
+	if c.isIPv6 {
+		allocatorKey = "ipv6/" + key
+	}
+	id := c.groupAllocator.AllocateFor(allocatorKey)
 	c.groupMap[key] = id
 	c.updateServicePortNameMap(svcPortName.NamespacedName.String(), key)
 	c.groupIDUpdates <- svcPortName.NamespacedName.String()
@@ -15,10 +15,19 @@
 package types
 
 import (
+	"net"
+	"strconv"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
 
 	mccommon "antrea.io/antrea/multicluster/controllers/multicluster/common"
+	agenttypes "antrea.io/antrea/pkg/agent/types"
 	"antrea.io/antrea/pkg/ovs/openflow"
 	k8sproxy "antrea.io/antrea/third_party/proxy"
 )
@@ -32,11 +41,26 @@ type ServiceInfo struct {
 	// Currently it's true for Antrea Multi-cluster Service, determined by whether
 	// there is an Antrea Multi-cluster specific annotation.
 	IsNested bool
+	// SessionAffinityKey customizes the key of the ClientIP session affinity, which is determined by the
+	// service.antrea.io/session-affinity-* annotations of the Service. It's nil if the default key is used.
+	SessionAffinityKey *agenttypes.SessionAffinityKey
+	// CreationTimestamp is the creation time of the Service, which gives precedence to the oldest Service when
+	// several Services claim the same external IP and port.
+	CreationTimestamp metav1.Time
+	// ConflictingIPs are the external IPs and LoadBalancer IPs of the Service port which are claimed by a Service
+	// with precedence, with the same port and protocol. They are not installed.
+	ConflictingIPs sets.Set[string]
+	// EndPort is the end of the port range of the Service port, which is determined by the
+	// service.antrea.io/port-range annotation of the Service. It's 0 if the Service port has no port range.
+	EndPort int
+	// LoadBalancerMode is the mode in which the connections to the external addresses of the Service port are load
+	// balanced, which is determined by the service.antrea.io/load-balancer-mode annotation of the Service.
+	LoadBalancerMode agenttypes.LoadBalancerMode
 }
 
 // NewServiceInfo returns a new k8sproxy.ServicePort which abstracts a serviceInfo.
 func NewServiceInfo(port *corev1.ServicePort, service *corev1.Service, baseInfo *k8sproxy.BaseServiceInfo) k8sproxy.ServicePort {
-	info := &ServiceInfo{BaseServiceInfo: baseInfo}
+	info := &ServiceInfo{BaseServiceInfo: baseInfo, CreationTimestamp: service.CreationTimestamp}
 	info.IsNested = mccommon.IsMulticlusterService(service)
 	if utilnet.IsIPv6(baseInfo.ClusterIP()) {
 		info.OFProtocol = openflow.ProtocolTCPv6
@@ -53,12 +77,210 @@ func NewServiceInfo(port *corev1.ServicePort, service *corev1.Service, baseInfo
 			info.OFProtocol = openflow.ProtocolSCTP
 		}
 	}
+	if service.Spec.SessionAffinity == corev1.ServiceAffinityClientIP {
+		info.SessionAffinityKey = getSessionAffinityKey(service, utilnet.IsIPv6(baseInfo.ClusterIP()))
+	}
+	info.EndPort = getPortRangeEnd(port, service)
+	info.LoadBalancerMode = getLoadBalancerMode(service, info)
 	return info
 }
 
+// ExternalIPStrings returns the external IPs of the Service port, excluding the conflicting ones.
+func (info *ServiceInfo) ExternalIPStrings() []string {
+	return info.withoutConflictingIPs(info.BaseServiceInfo.ExternalIPStrings())
+}
+
+// LoadBalancerIPStrings returns the LoadBalancer IPs of the Service port, excluding the conflicting ones.
+func (info *ServiceInfo) LoadBalancerIPStrings() []string {
+	return info.withoutConflictingIPs(info.BaseServiceInfo.LoadBalancerIPStrings())
+}
+
+func (info *ServiceInfo) withoutConflictingIPs(ips []string) []string {
+	if info.ConflictingIPs.Len() == 0 {
+		return ips
+	}
+	var result []string
+	for _, ip := range ips {
+		if !info.ConflictingIPs.Has(ip) {
+			result = append(result, ip)
+		}
+	}
+	return result
+}
+
+// getSessionAffinityKey parses the session affinity annotations of the Service. Invalid annotations are ignored.
+func getSessionAffinityKey(service *corev1.Service, isIPv6 bool) *agenttypes.SessionAffinityKey {
+	key := agenttypes.SessionAffinityKey{}
+	if value, ok := service.Annotations[agenttypes.ServiceSessionAffinityClientIPPrefixLengthAnnotationKey]; ok {
+		maxPrefixLength := 32
+		if isIPv6 {
+			maxPrefixLength = 128
+		}
+		prefixLength, err := strconv.Atoi(value)
+		if err != nil || prefixLength <= 0 || prefixLength > maxPrefixLength {
+			klog.InfoS("Ignored invalid client IP prefix length of session affinity", "service", klog.KObj(service), "prefixLength", value)
+		} else if prefixLength < maxPrefixLength {
+			key.ClientIPPrefixLength = prefixLength
+		}
+	}
+	if value, ok := service.Annotations[agenttypes.ServiceSessionAffinityMatchDstPortAnnotationKey]; ok {
+		matchDstPort, err := strconv.ParseBool(value)
+		if err != nil {
+			klog.InfoS("Ignored invalid destination port option of session affinity", "service", klog.KObj(service), "matchDstPort", value)
+		} else if !matchDstPort {
+			// The learned flows load the Endpoint port selected for the first connection, so a client can only be
+			// pinned to the same Endpoint across the Service ports if all of them target the same Endpoint port.
+			if hasSameTargetPort(service.Spec.Ports) {
+				key.IgnoreDstPort = true
+			} else {
+				klog.InfoS("Session affinity must match destination port as the Service ports don't have the same numeric target port", "service", klog.KObj(service))
+			}
+		}
+	}
+	if key == (agenttypes.SessionAffinityKey{}) {
+		return nil
+	}
+	return &key
+}
+
+func hasSameTargetPort(ports []corev1.ServicePort) bool {
+	var targetPort int
+	for i, port := range ports {
+		portNumber := int(port.Port)
+		if port.TargetPort.Type == intstr.String {
+			return false
+		} else if port.TargetPort.IntVal != 0 {
+			portNumber = int(port.TargetPort.IntVal)
+		}
+		if i == 0 {
+			targetPort = portNumber
+		} else if portNumber != targetPort {
+			return false
+		}
+	}
+	return true
+}
+
+// getPortRangeEnd parses the port range annotation of the Service, and returns the end of the range if it applies to
+// the given Service port, or 0 otherwise. Invalid annotations are ignored.
+func getPortRangeEnd(port *corev1.ServicePort, service *corev1.Service) int {
+	value, ok := service.Annotations[agenttypes.ServicePortRangeAnnotationKey]
+	if !ok {
+		return 0
+	}
+	startStr, endStr, found := strings.Cut(value, "-")
+	start, startErr := strconv.Atoi(startStr)
+	end, endErr := strconv.Atoi(endStr)
+	if !found || startErr != nil || endErr != nil || start <= 0 || end <= start || end > 65535 {
+		klog.InfoS("Ignored invalid port range", "service", klog.KObj(service), "portRange", value)
+		return 0
+	}
+	if int(port.Port) != start {
+		return 0
+	}
+	// The destination port of the connections is kept when they are DNATed to the Endpoints, so the target port must
+	// be the same as the port. NodePort is not supported as the NodePort is different from the target port.
+	if port.TargetPort.Type == intstr.String || (port.TargetPort.IntVal != 0 && port.TargetPort.IntVal != port.Port) {
+		klog.InfoS("Ignored port range as the target port is different from the port", "service", klog.KObj(service), "port", port.Port, "portRange", value)
+		return 0
+	}
+	if port.NodePort != 0 {
+		klog.InfoS("Ignored port range as the Service port has a NodePort", "service", klog.KObj(service), "port", port.Port, "portRange", value)
+		return 0
+	}
+	return end
+}
+
+// getLoadBalancerMode parses the load balancer mode annotation of the Service. Invalid annotations are ignored, and the
+// DSR mode falls back to the NAT mode when the Service port doesn't support it.
+func getLoadBalancerMode(service *corev1.Service, info *ServiceInfo) agenttypes.LoadBalancerMode {
+	value, ok := service.Annotations[agenttypes.ServiceLoadBalancerModeAnnotationKey]
+	if !ok {
+		return agenttypes.LoadBalancerModeNAT
+	}
+	switch mode := agenttypes.LoadBalancerMode(strings.ToLower(value)); mode {
+	case agenttypes.LoadBalancerModeNAT:
+		return mode
+	case agenttypes.LoadBalancerModeDSR:
+		var reason string
+		switch {
+		case utilnet.IsIPv6(info.ClusterIP()):
+			reason = "DSR mode is only supported for IPv4"
+		case info.ExternalPolicyLocal():
+			// The connections are never forwarded to other Nodes with externalTrafficPolicy Local.
+			reason = "DSR mode is only applicable to externalTrafficPolicy Cluster"
+		case info.SessionAffinityType() == corev1.ServiceAffinityClientIP:
+			reason = "DSR mode doesn't support session affinity"
+		case info.EndPort != 0:
+			reason = "DSR mode doesn't support port ranges"
+		default:
+			return mode
+		}
+		klog.InfoS("Falling back to NAT mode for Service port", "service", klog.KObj(service), "port", info.Port(), "reason", reason)
+		return agenttypes.LoadBalancerModeNAT
+	default:
+		klog.InfoS("Ignored invalid load balancer mode", "service", klog.KObj(service), "loadBalancerMode", value)
+		return agenttypes.LoadBalancerModeNAT
+	}
+}
+
+// PortRangeEndpoint wraps an Endpoint of a Service port with a port range. Its port is 0, so that the destination port
+// of the connections is kept when they are DNATed to the Endpoint.
+type PortRangeEndpoint struct {
+	k8sproxy.Endpoint
+}
+
+func (e *PortRangeEndpoint) String() string {
+	return net.JoinHostPort(e.IP(), "0")
+}
+
+func (e *PortRangeEndpoint) Port() (int, error) {
+	return 0, nil
+}
+
 // NewEndpointInfo returns a new k8sproxy.Endpoint which abstracts an endpointsInfo.
 func NewEndpointInfo(baseInfo *k8sproxy.BaseEndpointInfo) k8sproxy.Endpoint {
 	return baseInfo
 }
 
 type EndpointsMap map[k8sproxy.ServicePortName]map[string]k8sproxy.Endpoint
+
+// ServiceState describes a Service port known by AntreaProxy, and the OVS groups and Endpoints installed for it. It is
+// used to dump the internal state of AntreaProxy, e.g. in support bundles.
+type ServiceState struct {
+	ServicePortName string `json:"servicePortName"`
+	// Installed is false if the Service port is expected but its flows haven't been installed yet.
+	Installed       bool     `json:"installed"`
+	ClusterIP       string   `json:"clusterIP,omitempty"`
+	Port            int      `json:"port,omitempty"`
+	EndPort         int      `json:"endPort,omitempty"`
+	Protocol        string   `json:"protocol,omitempty"`
+	NodePort        int      `json:"nodePort,omitempty"`
+	ExternalIPs     []string `json:"externalIPs,omitempty"`
+	LoadBalancerIPs []string `json:"loadBalancerIPs,omitempty"`
+	// GroupID and LocalGroupID are the IDs of the OVS groups allocated for all Endpoints and for local Endpoints.
+	GroupID      *uint32         `json:"groupID,omitempty"`
+	LocalGroupID *uint32         `json:"localGroupID,omitempty"`
+	Endpoints    []EndpointState `json:"endpoints,omitempty"`
+}
+
+// ServiceIPRouteReference describes a Service IP route installed by AntreaProxy and the Service ports requiring it. The
+// route is uninstalled when the last Service port referencing it goes away.
+type ServiceIPRouteReference struct {
+	IP string `json:"ip"`
+	// ServicePorts are the Service ports referencing the route, in the form "<Namespace>/<Name>:<Port name>".
+	ServicePorts []string `json:"servicePorts"`
+}
+
+// EndpointState describes an Endpoint installed for a Service port.
+type EndpointState struct {
+	Endpoint    string `json:"endpoint"`
+	IsLocal     bool   `json:"isLocal"`
+	Ready       bool   `json:"ready"`
+	Serving     bool   `json:"serving"`
+	Terminating bool   `json:"terminating"`
+	Weight      uint16 `json:"weight"`
+	// Draining is true if the Endpoint has been removed from the Service but is kept until its draining timeout
+	// expires.
+	Draining bool `json:"draining,omitempty"`
+}
@@ -0,0 +1,257 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	agenttypes "antrea.io/antrea/pkg/agent/types"
+	k8sproxy "antrea.io/antrea/third_party/proxy"
+)
+
+func TestGetSessionAffinityKey(t *testing.T) {
+	port80 := corev1.ServicePort{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080)}
+	port443 := corev1.ServicePort{Name: "https", Port: 443, TargetPort: intstr.FromInt(8080)}
+	port8443 := corev1.ServicePort{Name: "https-alt", Port: 8443, TargetPort: intstr.FromInt(8443)}
+	namedPort := corev1.ServicePort{Name: "named", Port: 8080, TargetPort: intstr.FromString("http")}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		ports       []corev1.ServicePort
+		isIPv6      bool
+		expectedKey *agenttypes.SessionAffinityKey
+	}{
+		{
+			name:  "no annotation",
+			ports: []corev1.ServicePort{port80},
+		},
+		{
+			name:        "client IP prefix",
+			annotations: map[string]string{agenttypes.ServiceSessionAffinityClientIPPrefixLengthAnnotationKey: "24"},
+			ports:       []corev1.ServicePort{port80},
+			expectedKey: &agenttypes.SessionAffinityKey{ClientIPPrefixLength: 24},
+		},
+		{
+			name:        "full client IP",
+			annotations: map[string]string{agenttypes.ServiceSessionAffinityClientIPPrefixLengthAnnotationKey: "32"},
+			ports:       []corev1.ServicePort{port80},
+		},
+		{
+			name:        "IPv6 client IP prefix",
+			annotations: map[string]string{agenttypes.ServiceSessionAffinityClientIPPrefixLengthAnnotationKey: "64"},
+			ports:       []corev1.ServicePort{port80},
+			isIPv6:      true,
+			expectedKey: &agenttypes.SessionAffinityKey{ClientIPPrefixLength: 64},
+		},
+		{
+			name:        "invalid client IP prefix",
+			annotations: map[string]string{agenttypes.ServiceSessionAffinityClientIPPrefixLengthAnnotationKey: "64"},
+			ports:       []corev1.ServicePort{port80},
+		},
+		{
+			name:        "ignore destination port",
+			annotations: map[string]string{agenttypes.ServiceSessionAffinityMatchDstPortAnnotationKey: "false"},
+			ports:       []corev1.ServicePort{port80, port443},
+			expectedKey: &agenttypes.SessionAffinityKey{IgnoreDstPort: true},
+		},
+		{
+			name:        "ignore destination port with different target ports",
+			annotations: map[string]string{agenttypes.ServiceSessionAffinityMatchDstPortAnnotationKey: "false"},
+			ports:       []corev1.ServicePort{port80, port8443},
+		},
+		{
+			name:        "ignore destination port with named target port",
+			annotations: map[string]string{agenttypes.ServiceSessionAffinityMatchDstPortAnnotationKey: "false"},
+			ports:       []corev1.ServicePort{namedPort},
+		},
+		{
+			name: "client IP prefix and ignore destination port",
+			annotations: map[string]string{
+				agenttypes.ServiceSessionAffinityClientIPPrefixLengthAnnotationKey: "16",
+				agenttypes.ServiceSessionAffinityMatchDstPortAnnotationKey:         "false",
+			},
+			ports:       []corev1.ServicePort{port80, port443},
+			expectedKey: &agenttypes.SessionAffinityKey{ClientIPPrefixLength: 16, IgnoreDstPort: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "svc", Annotations: tt.annotations},
+				Spec:       corev1.ServiceSpec{Ports: tt.ports},
+			}
+			assert.Equal(t, tt.expectedKey, getSessionAffinityKey(svc, tt.isIPv6))
+		})
+	}
+}
+
+func TestGetPortRangeEnd(t *testing.T) {
+	port := corev1.ServicePort{Name: "rtp", Port: 30000, TargetPort: intstr.FromInt(30000)}
+	tests := []struct {
+		name            string
+		portRange       string
+		port            corev1.ServicePort
+		expectedEndPort int
+	}{
+		{
+			name:            "port range",
+			portRange:       "30000-30100",
+			port:            port,
+			expectedEndPort: 30100,
+		},
+		{
+			name:            "unset target port",
+			portRange:       "30000-30100",
+			port:            corev1.ServicePort{Name: "rtp", Port: 30000},
+			expectedEndPort: 30100,
+		},
+		{
+			name:      "no annotation",
+			portRange: "",
+			port:      port,
+		},
+		{
+			name:      "other Service port",
+			portRange: "30001-30100",
+			port:      port,
+		},
+		{
+			name:      "invalid port range",
+			portRange: "30000",
+			port:      port,
+		},
+		{
+			name:      "reversed port range",
+			portRange: "30000-29000",
+			port:      port,
+		},
+		{
+			name:      "port range out of bounds",
+			portRange: "30000-70000",
+			port:      port,
+		},
+		{
+			name:      "different target port",
+			portRange: "30000-30100",
+			port:      corev1.ServicePort{Name: "rtp", Port: 30000, TargetPort: intstr.FromInt(40000)},
+		},
+		{
+			name:      "named target port",
+			portRange: "30000-30100",
+			port:      corev1.ServicePort{Name: "rtp", Port: 30000, TargetPort: intstr.FromString("rtp")},
+		},
+		{
+			name:      "NodePort",
+			portRange: "30000-30100",
+			port:      corev1.ServicePort{Name: "rtp", Port: 30000, TargetPort: intstr.FromInt(30000), NodePort: 31000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "svc"},
+				Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{tt.port}},
+			}
+			if tt.portRange != "" {
+				svc.Annotations = map[string]string{agenttypes.ServicePortRangeAnnotationKey: tt.portRange}
+			}
+			assert.Equal(t, tt.expectedEndPort, getPortRangeEnd(&tt.port, svc))
+		})
+	}
+}
+
+func TestGetLoadBalancerMode(t *testing.T) {
+	newServiceInfo := func(clusterIP string, externalPolicyLocal bool, sessionAffinity corev1.ServiceAffinity, endPort int) *ServiceInfo {
+		baseInfo := k8sproxy.NewBaseServiceInfo(net.ParseIP(clusterIP), 80, corev1.ProtocolTCP, 0, corev1.LoadBalancerStatus{}, sessionAffinity, 0, nil, nil, 0, externalPolicyLocal, false, nil, "")
+		return &ServiceInfo{BaseServiceInfo: baseInfo, EndPort: endPort}
+	}
+	tests := []struct {
+		name         string
+		annotation   string
+		info         *ServiceInfo
+		expectedMode agenttypes.LoadBalancerMode
+	}{
+		{
+			name:         "no annotation",
+			info:         newServiceInfo("10.96.0.10", false, corev1.ServiceAffinityNone, 0),
+			expectedMode: agenttypes.LoadBalancerModeNAT,
+		},
+		{
+			name:         "DSR",
+			annotation:   "dsr",
+			info:         newServiceInfo("10.96.0.10", false, corev1.ServiceAffinityNone, 0),
+			expectedMode: agenttypes.LoadBalancerModeDSR,
+		},
+		{
+			name:         "DSR in upper case",
+			annotation:   "DSR",
+			info:         newServiceInfo("10.96.0.10", false, corev1.ServiceAffinityNone, 0),
+			expectedMode: agenttypes.LoadBalancerModeDSR,
+		},
+		{
+			name:         "NAT",
+			annotation:   "nat",
+			info:         newServiceInfo("10.96.0.10", false, corev1.ServiceAffinityNone, 0),
+			expectedMode: agenttypes.LoadBalancerModeNAT,
+		},
+		{
+			name:         "invalid mode",
+			annotation:   "tunnel",
+			info:         newServiceInfo("10.96.0.10", false, corev1.ServiceAffinityNone, 0),
+			expectedMode: agenttypes.LoadBalancerModeNAT,
+		},
+		{
+			name:         "DSR with IPv6",
+			annotation:   "dsr",
+			info:         newServiceInfo("fd00::10", false, corev1.ServiceAffinityNone, 0),
+			expectedMode: agenttypes.LoadBalancerModeNAT,
+		},
+		{
+			name:         "DSR with externalTrafficPolicy Local",
+			annotation:   "dsr",
+			info:         newServiceInfo("10.96.0.10", true, corev1.ServiceAffinityNone, 0),
+			expectedMode: agenttypes.LoadBalancerModeNAT,
+		},
+		{
+			name:         "DSR with session affinity",
+			annotation:   "dsr",
+			info:         newServiceInfo("10.96.0.10", false, corev1.ServiceAffinityClientIP, 0),
+			expectedMode: agenttypes.LoadBalancerModeNAT,
+		},
+		{
+			name:         "DSR with port range",
+			annotation:   "dsr",
+			info:         newServiceInfo("10.96.0.10", false, corev1.ServiceAffinityNone, 100),
+			expectedMode: agenttypes.LoadBalancerModeNAT,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "svc"},
+			}
+			if tt.annotation != "" {
+				svc.Annotations = map[string]string{agenttypes.ServiceLoadBalancerModeAnnotationKey: tt.annotation}
+			}
+			assert.Equal(t, tt.expectedMode, getLoadBalancerMode(svc, tt.info))
+		})
+	}
+}
@@ -23,7 +23,9 @@ import (
 
 	"antrea.io/antrea/pkg/agent/config"
 	"antrea.io/antrea/pkg/agent/interfacestore"
+	"antrea.io/antrea/pkg/agent/kubeproxycheck"
 	"antrea.io/antrea/pkg/agent/memberlist"
+	"antrea.io/antrea/pkg/agent/monitortool"
 	"antrea.io/antrea/pkg/agent/openflow"
 	"antrea.io/antrea/pkg/agent/proxy"
 	"antrea.io/antrea/pkg/apis/crd/v1beta1"
@@ -61,6 +63,8 @@ type agentQuerier struct {
 	nplRange                 string
 	memberlistCluster        memberlist.Interface
 	nodeLister               corelisters.NodeLister
+	nodeLatencyQuerier       monitortool.Querier
+	kubeProxyQuerier         kubeproxycheck.Querier
 }
 
 func NewAgentQuerier(
@@ -76,6 +80,8 @@ func NewAgentQuerier(
 	nplRange string,
 	memberlistCluster memberlist.Interface,
 	nodeLister corelisters.NodeLister,
+	nodeLatencyQuerier monitortool.Querier,
+	kubeProxyQuerier kubeproxycheck.Querier,
 ) *agentQuerier {
 	return &agentQuerier{
 		nodeConfig:               nodeConfig,
@@ -90,6 +96,8 @@ func NewAgentQuerier(
 		nplRange:                 nplRange,
 		memberlistCluster:        memberlistCluster,
 		nodeLister:               nodeLister,
+		nodeLatencyQuerier:       nodeLatencyQuerier,
+		kubeProxyQuerier:         kubeProxyQuerier,
 	}
 }
 
@@ -178,7 +186,7 @@ func (aq agentQuerier) getAgentConditions(ovsConnected bool) []v1beta1.AgentCond
 	if !aq.ofClient.IsConnected() {
 		openflowConnectionStatus = v1.ConditionFalse
 	}
-	return []v1beta1.AgentCondition{
+	conditions := []v1beta1.AgentCondition{
 		{
 			Type:              v1beta1.AgentHealthy,
 			Status:            v1.ConditionTrue,
@@ -200,21 +208,49 @@ func (aq agentQuerier) getAgentConditions(ovsConnected bool) []v1beta1.AgentCond
 			LastHeartbeatTime: lastHeartbeatTime,
 		},
 	}
+	if aq.kubeProxyQuerier != nil {
+		conditions = append(conditions, getKubeProxyCondition(aq.kubeProxyQuerier.GetResult(), lastHeartbeatTime))
+	}
+	return conditions
+}
+
+// getKubeProxyCondition gets the condition reporting whether kube-proxy conflicts with AntreaProxy on the Node.
+func getKubeProxyCondition(result *kubeproxycheck.Result, lastHeartbeatTime metav1.Time) v1beta1.AgentCondition {
+	condition := v1beta1.AgentCondition{
+		Type:              v1beta1.KubeProxyCompatible,
+		Status:            v1.ConditionUnknown,
+		LastHeartbeatTime: lastHeartbeatTime,
+	}
+	// The Node has not been checked yet.
+	if result == nil {
+		return condition
+	}
+	condition.Status = v1.ConditionTrue
+	if !result.Compatible() {
+		condition.Status = v1.ConditionFalse
+	}
+	condition.Reason = result.Reason
+	condition.Message = result.Message
+	return condition
 }
 
 // getNetworkPolicyControllerInfo gets current network policy controller info
-// including: number of network policies, address groups and applied to groups.
+// including: number of network policies, address groups and applied to groups,
+// and the supported and unsupported NetworkPolicy features.
 func (aq agentQuerier) getNetworkPolicyControllerInfo() v1beta1.NetworkPolicyControllerInfo {
 	return v1beta1.NetworkPolicyControllerInfo{
-		NetworkPolicyNum:  int32(aq.networkPolicyInfoQuerier.GetNetworkPolicyNum()),
-		AddressGroupNum:   int32(aq.networkPolicyInfoQuerier.GetAddressGroupNum()),
-		AppliedToGroupNum: int32(aq.networkPolicyInfoQuerier.GetAppliedToGroupNum()),
+		NetworkPolicyNum:    int32(aq.networkPolicyInfoQuerier.GetNetworkPolicyNum()),
+		AddressGroupNum:     int32(aq.networkPolicyInfoQuerier.GetAddressGroupNum()),
+		AppliedToGroupNum:   int32(aq.networkPolicyInfoQuerier.GetAppliedToGroupNum()),
+		SupportedFeatures:   aq.networkPolicyInfoQuerier.GetSupportedFeatures(),
+		UnsupportedFeatures: aq.networkPolicyInfoQuerier.GetUnsupportedFeatures(),
 	}
 }
 
 // GetAgentInfo gets current agent pod info.
 func (aq agentQuerier) GetAgentInfo(agentInfo *v1beta1.AntreaAgentInfo, partial bool) {
-	// LocalPodNum, FlowTable, NetworkPolicyControllerInfo, OVSVersion and AgentConditions can be changed, so reset these fields.
+	// LocalPodNum, FlowTable, NetworkPolicyControllerInfo, OVSVersion, AgentConditions and NodeLatencyStats can be
+	// changed, so reset these fields.
 	// Only these fields are updated when partial is true.
 	agentInfo.Name = aq.nodeConfig.Name
 	agentInfo.LocalPodNum = int32(aq.interfaceStore.GetContainerInterfaceNum())
@@ -228,6 +264,9 @@ func (aq agentQuerier) GetAgentInfo(agentInfo *v1beta1.AntreaAgentInfo, partial
 		agentInfo.OVSInfo.Version = ovsVersion
 	}
 	agentInfo.AgentConditions = aq.getAgentConditions(ovsConnected)
+	if aq.nodeLatencyQuerier != nil {
+		agentInfo.NodeLatencyStats = aq.nodeLatencyQuerier.GetNodeLatencyStats()
+	}
 
 	// Some other fields are needed when partial is false.
 	if !partial {
@@ -25,6 +25,8 @@ import (
 
 	"antrea.io/antrea/pkg/agent/config"
 	interfacestoretest "antrea.io/antrea/pkg/agent/interfacestore/testing"
+	"antrea.io/antrea/pkg/agent/kubeproxycheck"
+	"antrea.io/antrea/pkg/agent/monitortool"
 	openflowtest "antrea.io/antrea/pkg/agent/openflow/testing"
 	"antrea.io/antrea/pkg/apis/crd/v1beta1"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
@@ -42,6 +44,14 @@ func getIPNet(ip string) *net.IPNet {
 	return ipNet
 }
 
+type fakeNodeLatencyQuerier struct {
+	stats []v1beta1.PeerNodeLatencyStats
+}
+
+func (q *fakeNodeLatencyQuerier) GetNodeLatencyStats() []v1beta1.PeerNodeLatencyStats {
+	return q.stats
+}
+
 func TestAgentQuerierGetAgentInfo(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	interfaceStore := interfacestoretest.NewMockInterfaceStore(ctrl)
@@ -65,13 +75,16 @@ func TestAgentQuerierGetAgentInfo(t *testing.T) {
 	networkPolicyInfoQuerier.EXPECT().GetAppliedToGroupNum().Return(20).AnyTimes()
 	networkPolicyInfoQuerier.EXPECT().GetAddressGroupNum().Return(30).AnyTimes()
 	networkPolicyInfoQuerier.EXPECT().GetControllerConnectionStatus().Return(true).AnyTimes()
+	networkPolicyInfoQuerier.EXPECT().GetSupportedFeatures().Return([]string{"L7Protocols"}).AnyTimes()
+	networkPolicyInfoQuerier.EXPECT().GetUnsupportedFeatures().Return([]string{"IGMP"}).AnyTimes()
 
 	tests := []struct {
-		name              string
-		nodeConfig        *config.NodeConfig
-		apiPort           int
-		partial           bool
-		expectedAgentInfo *v1beta1.AntreaAgentInfo
+		name               string
+		nodeConfig         *config.NodeConfig
+		apiPort            int
+		partial            bool
+		nodeLatencyQuerier monitortool.Querier
+		expectedAgentInfo  *v1beta1.AntreaAgentInfo
 	}{
 		{
 			name: "networkPolicyOnly-mode non-partial",
@@ -92,9 +105,11 @@ func TestAgentQuerierGetAgentInfo(t *testing.T) {
 					FlowTable:  map[string]int32{"1": 2},
 				},
 				NetworkPolicyControllerInfo: v1beta1.NetworkPolicyControllerInfo{
-					NetworkPolicyNum:  10,
-					AppliedToGroupNum: 20,
-					AddressGroupNum:   30,
+					NetworkPolicyNum:    10,
+					AppliedToGroupNum:   20,
+					AddressGroupNum:     30,
+					SupportedFeatures:   []string{"L7Protocols"},
+					UnsupportedFeatures: []string{"IGMP"},
 				},
 				LocalPodNum: 2,
 				AgentConditions: []v1beta1.AgentCondition{
@@ -141,9 +156,11 @@ func TestAgentQuerierGetAgentInfo(t *testing.T) {
 					FlowTable:  map[string]int32{"1": 2},
 				},
 				NetworkPolicyControllerInfo: v1beta1.NetworkPolicyControllerInfo{
-					NetworkPolicyNum:  10,
-					AppliedToGroupNum: 20,
-					AddressGroupNum:   30,
+					NetworkPolicyNum:    10,
+					AppliedToGroupNum:   20,
+					AddressGroupNum:     30,
+					SupportedFeatures:   []string{"L7Protocols"},
+					UnsupportedFeatures: []string{"IGMP"},
 				},
 				LocalPodNum: 2,
 				AgentConditions: []v1beta1.AgentCondition{
@@ -169,6 +186,65 @@ func TestAgentQuerierGetAgentInfo(t *testing.T) {
 				Version:                "UNKNOWN",
 			},
 		},
+		{
+			name: "partial with node latency stats",
+			nodeConfig: &config.NodeConfig{
+				Name:         "foo",
+				OVSBridge:    "br-int",
+				NodeIPv4Addr: getIPNet("10.10.0.10"),
+			},
+			apiPort: 10350,
+			partial: true,
+			nodeLatencyQuerier: &fakeNodeLatencyQuerier{stats: []v1beta1.PeerNodeLatencyStats{
+				{
+					NodeName: "bar",
+					TargetIPLatencyStats: []v1beta1.TargetIPLatencyStats{
+						{TargetIP: "10.10.0.11", Network: v1beta1.NodeLatencyNetworkUnderlay, LastMeasuredRTTNanoseconds: 100000},
+					},
+				},
+			}},
+			expectedAgentInfo: &v1beta1.AntreaAgentInfo{
+				ObjectMeta: v1.ObjectMeta{Name: "foo"},
+				OVSInfo: v1beta1.OVSInfo{
+					Version:   ovsVersion,
+					FlowTable: map[string]int32{"1": 2},
+				},
+				NetworkPolicyControllerInfo: v1beta1.NetworkPolicyControllerInfo{
+					NetworkPolicyNum:    10,
+					AppliedToGroupNum:   20,
+					AddressGroupNum:     30,
+					SupportedFeatures:   []string{"L7Protocols"},
+					UnsupportedFeatures: []string{"IGMP"},
+				},
+				LocalPodNum: 2,
+				AgentConditions: []v1beta1.AgentCondition{
+					{
+						Type:   v1beta1.AgentHealthy,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   v1beta1.ControllerConnectionUp,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   v1beta1.OVSDBConnectionUp,
+						Status: corev1.ConditionTrue,
+					},
+					{
+						Type:   v1beta1.OpenflowConnectionUp,
+						Status: corev1.ConditionTrue,
+					},
+				},
+				NodeLatencyStats: []v1beta1.PeerNodeLatencyStats{
+					{
+						NodeName: "bar",
+						TargetIPLatencyStats: []v1beta1.TargetIPLatencyStats{
+							{TargetIP: "10.10.0.11", Network: v1beta1.NodeLatencyNetworkUnderlay, LastMeasuredRTTNanoseconds: 100000},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -180,6 +256,7 @@ func TestAgentQuerierGetAgentInfo(t *testing.T) {
 				networkPolicyInfoQuerier: networkPolicyInfoQuerier,
 				apiPort:                  tt.apiPort,
 				nplRange:                 defaultNPLPortRange,
+				nodeLatencyQuerier:       tt.nodeLatencyQuerier,
 			}
 			agentInfo := &v1beta1.AntreaAgentInfo{}
 			aq.GetAgentInfo(agentInfo, tt.partial)
@@ -196,3 +273,50 @@ func TestAgentQuerierGetAgentInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestGetKubeProxyCondition(t *testing.T) {
+	lastHeartbeatTime := v1.Now()
+	tests := []struct {
+		name              string
+		result            *kubeproxycheck.Result
+		expectedCondition v1beta1.AgentCondition
+	}{
+		{
+			name: "not checked",
+			expectedCondition: v1beta1.AgentCondition{
+				Type:              v1beta1.KubeProxyCompatible,
+				Status:            corev1.ConditionUnknown,
+				LastHeartbeatTime: lastHeartbeatTime,
+			},
+		},
+		{
+			name:   "kube-proxy not running",
+			result: &kubeproxycheck.Result{Mode: kubeproxycheck.ModeNone},
+			expectedCondition: v1beta1.AgentCondition{
+				Type:              v1beta1.KubeProxyCompatible,
+				Status:            corev1.ConditionTrue,
+				LastHeartbeatTime: lastHeartbeatTime,
+			},
+		},
+		{
+			name: "conflicting kube-proxy",
+			result: &kubeproxycheck.Result{
+				Mode:    kubeproxycheck.ModeIPVS,
+				Reason:  kubeproxycheck.ReasonIPVSStrictARPDisabled,
+				Message: "strictARP is not set",
+			},
+			expectedCondition: v1beta1.AgentCondition{
+				Type:              v1beta1.KubeProxyCompatible,
+				Status:            corev1.ConditionFalse,
+				LastHeartbeatTime: lastHeartbeatTime,
+				Reason:            kubeproxycheck.ReasonIPVSStrictARPDisabled,
+				Message:           "strictARP is not set",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedCondition, getKubeProxyCondition(tt.result, lastHeartbeatTime))
+		})
+	}
+}
@@ -87,4 +87,9 @@ type Interface interface {
 
 	// DeleteRouteForLink deletes a route entry for a specific link.
 	DeleteRouteForLink(dstCIDR *net.IPNet, linkIndex int) error
+
+	// ClearConntrackEntryForService deletes the conntrack entries of the connections destined to the provided Service
+	// IP and port. If svcIP is nil, the entries of the connections destined to the provided NodePort are deleted. If
+	// endpointIP is not nil, only the entries of the connections load-balanced to the Endpoint are deleted.
+	ClearConntrackEntryForService(svcIP net.IP, svcPort uint16, endpointIP net.IP, protocol binding.Protocol) error
 }
@@ -0,0 +1,485 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/types"
+	"antrea.io/antrea/pkg/agent/util/ipset"
+	"antrea.io/antrea/pkg/agent/util/iptables"
+	"antrea.io/antrea/pkg/agent/util/nftables"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+)
+
+const (
+	// antreaNFTable is the name of the Antrea managed nftables tables. There is one table for each address
+	// family, and all the Antrea managed nftables chains, sets and maps are in them.
+	antreaNFTable = "antrea"
+
+	// Antrea managed nftables base chains. Each of them is the counterpart of an Antrea managed iptables
+	// chain, and is attached to the same hook with the priority of the corresponding iptables table.
+	nftRawPreRoutingChain  = "raw-prerouting"
+	nftRawOutputChain      = "raw-output"
+	nftMangleOutputChain   = "mangle-output"
+	nftFilterForwardChain  = "filter-forward"
+	nftNATPreRoutingChain  = "nat-prerouting"
+	nftNATOutputChain      = "nat-output"
+	nftNATPostRoutingChain = "nat-postrouting"
+
+	nftPriorityRaw    = -300
+	nftPriorityMangle = -150
+	nftPriorityDstNAT = -100
+	nftPriorityFilter = 0
+	nftPrioritySrcNAT = 100
+
+	// Antrea managed nftables maps from the SNAT mark to the SNAT IP, used by the Egress feature.
+	nftSNATMarkToIPMap  = "ANTREA-SNAT-IP"
+	nftSNATMarkToIP6Map = "ANTREA-SNAT-IP6"
+
+	// nftables equivalents of the iptables addrtype matches.
+	nftLocalSrcMatch         = "fib saddr type local"
+	nftLocalDstMatch         = "fib daddr type local"
+	nftNonLocalSrcOnOifMatch = "fib saddr . oif type != local"
+)
+
+// resolveFirewallBackend returns the firewall backend to use. When the backend is "auto", nftables is
+// selected if the Antrea nftables tables exist already, e.g. nftables was selected explicitly before, or if
+// iptables is not available on the Node. Otherwise, iptables is selected.
+func (c *Client) resolveFirewallBackend() config.FirewallBackend {
+	if c.networkConfig.FirewallBackend != config.FirewallBackendAuto {
+		return c.networkConfig.FirewallBackend
+	}
+	// The EKS rules jump to the iptables chains installed by the AWS VPC CNI, which cannot be done with
+	// nftables.
+	if c.isCloudEKS || !nftables.IsAvailable() {
+		return config.FirewallBackendIPTables
+	}
+	nft := &nftables.Client{}
+	for _, family := range c.nftFamilies() {
+		if exists, err := nft.TableExists(family, antreaNFTable); err == nil && exists {
+			return config.FirewallBackendNFTables
+		}
+	}
+	if _, err := exec.LookPath("iptables"); err != nil {
+		return config.FirewallBackendNFTables
+	}
+	return config.FirewallBackendIPTables
+}
+
+func (c *Client) nftFamilies() []nftables.Family {
+	var families []nftables.Family
+	if c.networkConfig.IPv4Enabled {
+		families = append(families, nftables.FamilyIPv4)
+	}
+	if c.networkConfig.IPv6Enabled {
+		families = append(families, nftables.FamilyIPv6)
+	}
+	return families
+}
+
+// cleanupIPTables removes the iptables chains and ipsets installed by Antrea, when switching from the
+// iptables backend to the nftables backend. Errors are ignored as iptables may not be available at all.
+func (c *Client) cleanupIPTables() {
+	ipt, err := iptables.New(c.networkConfig.IPv4Enabled, c.networkConfig.IPv6Enabled)
+	if err != nil {
+		klog.V(2).InfoS("Skipped cleaning up iptables rules as iptables is not available", "err", err)
+		return
+	}
+	for _, rule := range c.iptablesJumpRules() {
+		// The jump rule cannot be checked if the target chain doesn't exist.
+		if exists, err := ipt.ChainExists(iptables.ProtocolDual, rule.table, rule.dstChain); err != nil || !exists {
+			continue
+		}
+		ruleSpec := []string{"-j", rule.dstChain, "-m", "comment", "--comment", rule.comment}
+		if err := ipt.DeleteRule(iptables.ProtocolDual, rule.table, rule.srcChain, ruleSpec); err != nil {
+			klog.ErrorS(err, "Failed to delete stale iptables rule", "table", rule.table, "chain", rule.srcChain)
+			continue
+		}
+		if err := ipt.DeleteChain(iptables.ProtocolDual, rule.table, rule.dstChain); err != nil {
+			klog.ErrorS(err, "Failed to delete stale iptables chain", "table", rule.table, "chain", rule.dstChain)
+		}
+	}
+	ipsetClient := ipset.NewClient()
+	for _, name := range []string{
+		antreaPodIPSet, antreaPodIP6Set,
+		antreaNodePortIPSet, antreaNodePortIP6Set,
+		localAntreaFlexibleIPAMPodIPSet, localAntreaFlexibleIPAMPodIP6Set,
+		clusterNodeIPSet, clusterNodeIP6Set,
+	} {
+		if err := ipsetClient.DestroyIPSet(name); err != nil {
+			klog.ErrorS(err, "Failed to delete stale ipset", "name", name)
+		}
+	}
+	klog.InfoS("Cleaned up iptables rules and ipsets after switching to the nftables backend")
+}
+
+// cleanupNFTables removes the nftables tables installed by Antrea, when switching from the nftables backend
+// to the iptables backend.
+func (c *Client) cleanupNFTables() {
+	if !nftables.IsAvailable() {
+		return
+	}
+	nft := &nftables.Client{}
+	for _, family := range []nftables.Family{nftables.FamilyIPv4, nftables.FamilyIPv6} {
+		exists, err := nft.TableExists(family, antreaNFTable)
+		if err != nil || !exists {
+			continue
+		}
+		if err := nft.DeleteTable(family, antreaNFTable); err != nil {
+			klog.ErrorS(err, "Failed to delete stale nftables table", "family", family, "table", antreaNFTable)
+			continue
+		}
+		klog.InfoS("Deleted nftables table after switching to the iptables backend", "family", family, "table", antreaNFTable)
+	}
+}
+
+// syncNFTables ensures that the nftables chains and maps we use are set up. It's the nftables counterpart
+// of syncIPTables and the generated rules must have the same semantics as the iptables rules. The sets
+// referenced by the rules are maintained by syncIPSet with nftSet.
+// It's idempotent and can safely be called on every startup.
+func (c *Client) syncNFTables() error {
+	c.nftablesMutex.Lock()
+	defer c.nftablesMutex.Unlock()
+	snatMarkToIPv4, snatMarkToIPv6 := c.getSNATMarkToIPs()
+	if c.networkConfig.IPv4Enabled {
+		nftablesData := c.restoreNFTablesData(nftables.FamilyIPv4,
+			c.nodeConfig.PodIPv4CIDR,
+			antreaPodIPSet,
+			localAntreaFlexibleIPAMPodIPSet,
+			antreaNodePortIPSet,
+			clusterNodeIPSet,
+			nftSNATMarkToIPMap,
+			config.VirtualNodePortDNATIPv4,
+			config.VirtualServiceIPv4,
+			snatMarkToIPv4)
+		if err := c.nftables.Apply(nftablesData.String()); err != nil {
+			return err
+		}
+	}
+	if c.networkConfig.IPv6Enabled {
+		nftablesData := c.restoreNFTablesData(nftables.FamilyIPv6,
+			c.nodeConfig.PodIPv6CIDR,
+			antreaPodIP6Set,
+			localAntreaFlexibleIPAMPodIP6Set,
+			antreaNodePortIP6Set,
+			clusterNodeIP6Set,
+			nftSNATMarkToIP6Map,
+			config.VirtualNodePortDNATIPv6,
+			config.VirtualServiceIPv6,
+			snatMarkToIPv6)
+		if err := c.nftables.Apply(nftablesData.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) restoreNFTablesData(family nftables.Family,
+	podCIDR *net.IPNet,
+	podIPSet,
+	localAntreaFlexibleIPAMPodIPSet,
+	nodePortIPSet,
+	clusterNodeIPSet,
+	snatMarkToIPMap string,
+	nodePortDNATVirtualIP,
+	serviceVirtualIP net.IP,
+	snatMarkToIP map[uint32]net.IP) *bytes.Buffer {
+	isIPv6 := family == nftables.FamilyIPv6
+	ipProto, addrType := "ip", "ipv4_addr"
+	if isIPv6 {
+		ipProto, addrType = "ip6", "ipv6_addr"
+	}
+	gatewayName := c.nodeConfig.GatewayConfig.Name
+	// All the chains are flushed and the desired rules are re-created in a single transaction, which is
+	// the equivalent of iptables-restore with --noflush.
+	nftablesData := bytes.NewBuffer(nil)
+	writeLine(nftablesData, "add", "table", string(family), antreaNFTable)
+	for _, chain := range []struct {
+		name      string
+		chainType string
+		hook      string
+		priority  int
+	}{
+		{nftRawPreRoutingChain, "filter", "prerouting", nftPriorityRaw},
+		{nftRawOutputChain, "filter", "output", nftPriorityRaw},
+		// The "route" type makes the packets re-routed if the mark is changed, like the iptables mangle
+		// table does for locally generated packets.
+		{nftMangleOutputChain, "route", "output", nftPriorityMangle},
+		{nftFilterForwardChain, "filter", "forward", nftPriorityFilter},
+		{nftNATPreRoutingChain, "nat", "prerouting", nftPriorityDstNAT},
+		{nftNATOutputChain, "nat", "output", nftPriorityDstNAT},
+		{nftNATPostRoutingChain, "nat", "postrouting", nftPrioritySrcNAT},
+	} {
+		writeLine(nftablesData, "add", "chain", string(family), antreaNFTable, chain.name,
+			fmt.Sprintf("{ type %s hook %s priority %d ; }", chain.chainType, chain.hook, chain.priority))
+		writeLine(nftablesData, "flush", "chain", string(family), antreaNFTable, chain.name)
+	}
+	writeLine(nftablesData, "add", "map", string(family), antreaNFTable, snatMarkToIPMap,
+		fmt.Sprintf("{ type mark : %s ; }", addrType))
+	c.writeNFTSNATMap(nftablesData, family, snatMarkToIPMap, snatMarkToIP)
+
+	addRule := func(chain string, comment string, rule ...string) {
+		words := append([]string{"add", "rule", string(family), antreaNFTable, chain}, rule...)
+		words = append(words, "comment", strconv.Quote(comment))
+		writeLine(nftablesData, words...)
+	}
+
+	if c.networkConfig.TrafficEncapMode.SupportsEncap() {
+		udpPort := 0
+		if c.networkConfig.TunnelType == ovsconfig.GeneveTunnel {
+			udpPort = genevePort
+		} else if c.networkConfig.TunnelType == ovsconfig.VXLANTunnel {
+			udpPort = vxlanPort
+		}
+		if udpPort > 0 {
+			addRule(nftRawPreRoutingChain, "Antrea: do not track incoming encapsulation packets",
+				"udp", "dport", strconv.Itoa(udpPort), nftLocalDstMatch, "notrack")
+			addRule(nftRawOutputChain, "Antrea: do not track outgoing encapsulation packets",
+				"udp", "dport", strconv.Itoa(udpPort), nftLocalSrcMatch, "notrack")
+		}
+		// Multicast is supported for IPv4 only, and an IPv4 CIDR cannot be used in an ip6 table.
+		if c.multicastEnabled && !isIPv6 {
+			addRule(nftRawPreRoutingChain, "Antrea: drop Pod multicast traffic forwarded via underlay network",
+				ipProto, "saddr", "@"+clusterNodeIPSet, ipProto, "daddr", types.McastCIDR.String(), "drop")
+		}
+	}
+
+	addRule(nftMangleOutputChain, "Antrea: mark LOCAL output packets",
+		nftLocalSrcMatch, "oifname", strconv.Quote(gatewayName),
+		"meta", "mark", "set", "meta", "mark", "or", fmt.Sprintf("%#08x", types.HostLocalSourceMark))
+	if c.connectUplinkToBridge {
+		addRule(nftMangleOutputChain, "Antrea: mark LOCAL output packets",
+			nftLocalSrcMatch, "oifname", strconv.Quote(c.nodeConfig.OVSBridge),
+			"meta", "mark", "set", "meta", "mark", "or", fmt.Sprintf("%#08x", types.HostLocalSourceMark))
+	}
+
+	addRule(nftFilterForwardChain, "Antrea: accept packets from local Pods",
+		"iifname", strconv.Quote(gatewayName), "accept")
+	addRule(nftFilterForwardChain, "Antrea: accept packets to local Pods",
+		"oifname", strconv.Quote(gatewayName), "accept")
+	if c.connectUplinkToBridge {
+		addRule(nftFilterForwardChain, "Antrea: accept packets from local AntreaFlexibleIPAM Pods",
+			ipProto, "saddr", "@"+localAntreaFlexibleIPAMPodIPSet, "accept")
+		addRule(nftFilterForwardChain, "Antrea: accept packets to local AntreaFlexibleIPAM Pods",
+			ipProto, "daddr", "@"+localAntreaFlexibleIPAMPodIPSet, "accept")
+	}
+
+	if c.proxyAll {
+		nodePortDNATTarget := nodePortDNATVirtualIP.String()
+		if isIPv6 {
+			nodePortDNATTarget = "[" + nodePortDNATTarget + "]"
+		}
+		nodePortMatch := []string{ipProto, "daddr", ".", "meta", "l4proto", ".", "th", "dport", "@" + nodePortIPSet}
+		addRule(nftNATPreRoutingChain, "Antrea: DNAT external to NodePort packets",
+			append(nodePortMatch, "dnat", "to", nodePortDNATTarget)...)
+		addRule(nftNATOutputChain, "Antrea: DNAT local to NodePort packets",
+			append(nodePortMatch, "dnat", "to", nodePortDNATTarget)...)
+	}
+
+	if c.multicastEnabled && c.networkConfig.TrafficEncapMode.SupportsNoEncap() && !isIPv6 {
+		addRule(nftNATPostRoutingChain, "Antrea: skip masquerade for multicast traffic",
+			ipProto, "saddr", podCIDR.String(), ipProto, "daddr", types.McastCIDR.String(), "return")
+	}
+	// Egress rules must be added before the default masquerade rule. Instead of one rule per SNAT IP,
+	// a map from the SNAT mark to the SNAT IP is used. The lookup fails and the rule doesn't match if the
+	// packet mark is not in the map.
+	addRule(nftNATPostRoutingChain, "Antrea: SNAT Pod to external packets",
+		"oifname", "!=", strconv.Quote(gatewayName),
+		"snat", "to", "meta", "mark", "and", fmt.Sprintf("%#08x", types.SNATIPMarkMask), "map", "@"+snatMarkToIPMap)
+	if !c.noSNAT {
+		addRule(nftNATPostRoutingChain, "Antrea: masquerade Pod to external packets",
+			ipProto, "saddr", podCIDR.String(), ipProto, "daddr", "!=", "@"+podIPSet,
+			"oifname", "!=", strconv.Quote(gatewayName), "masquerade")
+	}
+	addRule(nftNATPostRoutingChain, "Antrea: masquerade LOCAL traffic",
+		"oifname", strconv.Quote(gatewayName), nftNonLocalSrcOnOifMatch, nftLocalSrcMatch,
+		"masquerade", "fully-random")
+	if c.proxyClusterIPsFromHost() {
+		addRule(nftNATPostRoutingChain, "Antrea: masquerade OVS virtual source IP",
+			ipProto, "saddr", serviceVirtualIP.String(), "masquerade")
+	}
+	if c.connectUplinkToBridge {
+		addRule(nftNATPostRoutingChain, "Antrea: masquerade traffic to local AntreaIPAM hostPort Pod",
+			ipProto, "saddr", "!=", podCIDR.String(), ipProto, "daddr", "@"+localAntreaFlexibleIPAMPodIPSet, "masquerade")
+	}
+	return nftablesData
+}
+
+// writeNFTSNATMap writes the commands which replace all the elements of the SNAT map with the provided ones.
+func (c *Client) writeNFTSNATMap(nftablesData *bytes.Buffer, family nftables.Family, snatMarkToIPMap string, snatMarkToIP map[uint32]net.IP) {
+	writeLine(nftablesData, "flush", "map", string(family), antreaNFTable, snatMarkToIPMap)
+	if len(snatMarkToIP) == 0 {
+		return
+	}
+	elements := make([]string, 0, len(snatMarkToIP))
+	for snatMark, snatIP := range snatMarkToIP {
+		elements = append(elements, fmt.Sprintf("%#08x : %s", snatMark, snatIP.String()))
+	}
+	// Sort the elements to generate stable output.
+	sort.Strings(elements)
+	writeLine(nftablesData, "add", "element", string(family), antreaNFTable, snatMarkToIPMap,
+		"{", strings.Join(elements, ", "), "}")
+}
+
+// syncNFTSNATMap updates the SNAT map of the address family of the provided IP with the markToSNATIP cache.
+func (c *Client) syncNFTSNATMap(snatIP net.IP) error {
+	c.nftablesMutex.Lock()
+	defer c.nftablesMutex.Unlock()
+	snatMarkToIPv4, snatMarkToIPv6 := c.getSNATMarkToIPs()
+	nftablesData := bytes.NewBuffer(nil)
+	if snatIP.To4() != nil {
+		c.writeNFTSNATMap(nftablesData, nftables.FamilyIPv4, nftSNATMarkToIPMap, snatMarkToIPv4)
+	} else {
+		c.writeNFTSNATMap(nftablesData, nftables.FamilyIPv6, nftSNATMarkToIP6Map, snatMarkToIPv6)
+	}
+	return c.nftables.Apply(nftablesData.String())
+}
+
+// nftSet implements ipset.Interface with nftables sets, so that the logic maintaining the set members is
+// shared by the iptables and the nftables backends. The sets are created in the Antrea nftables table of
+// the corresponding address family, with the same names as the ipsets. The entries are accepted and
+// returned in the ipset format, e.g. "10.10.0.1,tcp:30000" for a hash:ip,port set.
+type nftSet struct {
+	nft nftables.Interface
+	// families stores the address family of the created sets, keyed by set name.
+	families sync.Map
+}
+
+var _ ipset.Interface = &nftSet{}
+
+func newNFTSet(nft nftables.Interface) *nftSet {
+	return &nftSet{nft: nft}
+}
+
+func (s *nftSet) CreateIPSet(name string, setType ipset.SetType, isIPv6 bool) error {
+	family, addrType := nftables.FamilyIPv4, "ipv4_addr"
+	if isIPv6 {
+		family, addrType = nftables.FamilyIPv6, "ipv6_addr"
+	}
+	var setSpec string
+	switch setType {
+	case ipset.HashNet:
+		setSpec = fmt.Sprintf("{ type %s ; flags interval ; }", addrType)
+	case ipset.HashIP:
+		setSpec = fmt.Sprintf("{ type %s ; }", addrType)
+	case ipset.HashIPPort:
+		setSpec = fmt.Sprintf("{ type %s . inet_proto . inet_service ; }", addrType)
+	default:
+		return fmt.Errorf("unsupported set type %s", setType)
+	}
+	script := fmt.Sprintf("add table %[1]s %[2]s\nadd set %[1]s %[2]s %[3]s %[4]s\n", family, antreaNFTable, name, setSpec)
+	if err := s.nft.Apply(script); err != nil {
+		return fmt.Errorf("error creating nftables set %s: %v", name, err)
+	}
+	s.families.Store(name, family)
+	return nil
+}
+
+func (s *nftSet) getFamily(name string) (nftables.Family, error) {
+	family, ok := s.families.Load(name)
+	if !ok {
+		return "", fmt.Errorf("nftables set %s doesn't exist", name)
+	}
+	return family.(nftables.Family), nil
+}
+
+func (s *nftSet) DestroyIPSet(name string) error {
+	family, err := s.getFamily(name)
+	if err != nil {
+		return nil
+	}
+	if err := s.nft.Apply(fmt.Sprintf("delete set %s %s %s\n", family, antreaNFTable, name)); err != nil {
+		return fmt.Errorf("error deleting nftables set %s: %v", name, err)
+	}
+	s.families.Delete(name)
+	return nil
+}
+
+func (s *nftSet) AddEntry(name string, entry string) error {
+	family, err := s.getFamily(name)
+	if err != nil {
+		return err
+	}
+	script := fmt.Sprintf("add element %s %s %s { %s }\n", family, antreaNFTable, name, ipsetEntryToNFTElement(entry))
+	if err := s.nft.Apply(script); err != nil {
+		return fmt.Errorf("error adding entry %s to nftables set %s: %v", entry, name, err)
+	}
+	return nil
+}
+
+func (s *nftSet) DelEntry(name string, entry string) error {
+	family, err := s.getFamily(name)
+	if err != nil {
+		return err
+	}
+	element := ipsetEntryToNFTElement(entry)
+	// Adding the element first makes the deletion idempotent, like "ipset del -exist".
+	script := fmt.Sprintf("add element %[1]s %[2]s %[3]s { %[4]s }\ndelete element %[1]s %[2]s %[3]s { %[4]s }\n",
+		family, antreaNFTable, name, element)
+	if err := s.nft.Apply(script); err != nil {
+		return fmt.Errorf("error deleting entry %s from nftables set %s: %v", entry, name, err)
+	}
+	return nil
+}
+
+func (s *nftSet) ListEntries(name string) ([]string, error) {
+	family, err := s.getFamily(name)
+	if err != nil {
+		return nil, err
+	}
+	elements, err := s.nft.ListSetElements(family, antreaNFTable, name)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]string, 0, len(elements))
+	for _, element := range elements {
+		entries = append(entries, nftElementToIPSetEntry(element))
+	}
+	return entries, nil
+}
+
+// ipsetEntryToNFTElement converts an ipset entry to a nftables set element, e.g. "10.10.0.1,tcp:30000" to
+// "10.10.0.1 . tcp . 30000". Entries of other formats are returned unchanged.
+func ipsetEntryToNFTElement(entry string) string {
+	idx := strings.LastIndex(entry, ",")
+	if idx == -1 {
+		return entry
+	}
+	protoPort := strings.SplitN(entry[idx+1:], ":", 2)
+	if len(protoPort) != 2 {
+		return entry
+	}
+	return fmt.Sprintf("%s . %s . %s", entry[:idx], protoPort[0], protoPort[1])
+}
+
+// nftElementToIPSetEntry is the reverse of ipsetEntryToNFTElement.
+func nftElementToIPSetEntry(element string) string {
+	parts := strings.Split(element, " . ")
+	if len(parts) != 3 {
+		return element
+	}
+	return fmt.Sprintf("%s,%s:%s", parts[0], parts[1], parts[2])
+}
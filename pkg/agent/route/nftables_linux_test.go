@@ -0,0 +1,202 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route
+
+import (
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/util/ipset"
+	"antrea.io/antrea/pkg/agent/util/nftables"
+	nftablestest "antrea.io/antrea/pkg/agent/util/nftables/testing"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+	"antrea.io/antrea/pkg/util/ip"
+)
+
+func TestSyncNFTables(t *testing.T) {
+	tests := []struct {
+		name                  string
+		proxyAll              bool
+		multicastEnabled      bool
+		connectUplinkToBridge bool
+		networkConfig         *config.NetworkConfig
+		nodeConfig            *config.NodeConfig
+		markToSNATIP          map[uint32]string
+		expectedCalls         func(mockNFTables *nftablestest.MockInterfaceMockRecorder)
+	}{
+		{
+			name:     "encap,egress=true,proxyAll=true",
+			proxyAll: true,
+			networkConfig: &config.NetworkConfig{
+				TrafficEncapMode: config.TrafficEncapModeEncap,
+				TunnelType:       ovsconfig.GeneveTunnel,
+				IPv4Enabled:      true,
+			},
+			nodeConfig: &config.NodeConfig{
+				PodIPv4CIDR: ip.MustParseCIDR("172.16.10.0/24"),
+				GatewayConfig: &config.GatewayConfig{
+					Name: "antrea-gw0",
+				},
+			},
+			markToSNATIP: map[uint32]string{
+				2: "1.1.1.2",
+				1: "1.1.1.1",
+			},
+			expectedCalls: func(mockNFTables *nftablestest.MockInterfaceMockRecorder) {
+				mockNFTables.Apply(`add table ip antrea
+add chain ip antrea raw-prerouting { type filter hook prerouting priority -300 ; }
+flush chain ip antrea raw-prerouting
+add chain ip antrea raw-output { type filter hook output priority -300 ; }
+flush chain ip antrea raw-output
+add chain ip antrea mangle-output { type route hook output priority -150 ; }
+flush chain ip antrea mangle-output
+add chain ip antrea filter-forward { type filter hook forward priority 0 ; }
+flush chain ip antrea filter-forward
+add chain ip antrea nat-prerouting { type nat hook prerouting priority -100 ; }
+flush chain ip antrea nat-prerouting
+add chain ip antrea nat-output { type nat hook output priority -100 ; }
+flush chain ip antrea nat-output
+add chain ip antrea nat-postrouting { type nat hook postrouting priority 100 ; }
+flush chain ip antrea nat-postrouting
+add map ip antrea ANTREA-SNAT-IP { type mark : ipv4_addr ; }
+flush map ip antrea ANTREA-SNAT-IP
+add element ip antrea ANTREA-SNAT-IP { 0x00000001 : 1.1.1.1, 0x00000002 : 1.1.1.2 }
+add rule ip antrea raw-prerouting udp dport 6081 fib daddr type local notrack comment "Antrea: do not track incoming encapsulation packets"
+add rule ip antrea raw-output udp dport 6081 fib saddr type local notrack comment "Antrea: do not track outgoing encapsulation packets"
+add rule ip antrea mangle-output fib saddr type local oifname "antrea-gw0" meta mark set meta mark or 0x80000000 comment "Antrea: mark LOCAL output packets"
+add rule ip antrea filter-forward iifname "antrea-gw0" accept comment "Antrea: accept packets from local Pods"
+add rule ip antrea filter-forward oifname "antrea-gw0" accept comment "Antrea: accept packets to local Pods"
+add rule ip antrea nat-prerouting ip daddr . meta l4proto . th dport @ANTREA-NODEPORT-IP dnat to 169.254.0.252 comment "Antrea: DNAT external to NodePort packets"
+add rule ip antrea nat-output ip daddr . meta l4proto . th dport @ANTREA-NODEPORT-IP dnat to 169.254.0.252 comment "Antrea: DNAT local to NodePort packets"
+add rule ip antrea nat-postrouting oifname != "antrea-gw0" snat to meta mark and 0x000000ff map @ANTREA-SNAT-IP comment "Antrea: SNAT Pod to external packets"
+add rule ip antrea nat-postrouting ip saddr 172.16.10.0/24 ip daddr != @ANTREA-POD-IP oifname != "antrea-gw0" masquerade comment "Antrea: masquerade Pod to external packets"
+add rule ip antrea nat-postrouting oifname "antrea-gw0" fib saddr . oif type != local fib saddr type local masquerade fully-random comment "Antrea: masquerade LOCAL traffic"
+add rule ip antrea nat-postrouting ip saddr 169.254.0.253 masquerade comment "Antrea: masquerade OVS virtual source IP"
+`)
+			},
+		},
+		{
+			name:                  "noencap,IPv6,multicastEnabled=true,connectUplinkToBridge=true",
+			multicastEnabled:      true,
+			connectUplinkToBridge: true,
+			networkConfig: &config.NetworkConfig{
+				TrafficEncapMode: config.TrafficEncapModeNoEncap,
+				IPv6Enabled:      true,
+			},
+			nodeConfig: &config.NodeConfig{
+				PodIPv6CIDR: ip.MustParseCIDR("2001:ab03:cd04:55ef::/64"),
+				OVSBridge:   "br-int",
+				GatewayConfig: &config.GatewayConfig{
+					Name: "antrea-gw0",
+				},
+			},
+			expectedCalls: func(mockNFTables *nftablestest.MockInterfaceMockRecorder) {
+				mockNFTables.Apply(`add table ip6 antrea
+add chain ip6 antrea raw-prerouting { type filter hook prerouting priority -300 ; }
+flush chain ip6 antrea raw-prerouting
+add chain ip6 antrea raw-output { type filter hook output priority -300 ; }
+flush chain ip6 antrea raw-output
+add chain ip6 antrea mangle-output { type route hook output priority -150 ; }
+flush chain ip6 antrea mangle-output
+add chain ip6 antrea filter-forward { type filter hook forward priority 0 ; }
+flush chain ip6 antrea filter-forward
+add chain ip6 antrea nat-prerouting { type nat hook prerouting priority -100 ; }
+flush chain ip6 antrea nat-prerouting
+add chain ip6 antrea nat-output { type nat hook output priority -100 ; }
+flush chain ip6 antrea nat-output
+add chain ip6 antrea nat-postrouting { type nat hook postrouting priority 100 ; }
+flush chain ip6 antrea nat-postrouting
+add map ip6 antrea ANTREA-SNAT-IP6 { type mark : ipv6_addr ; }
+flush map ip6 antrea ANTREA-SNAT-IP6
+add rule ip6 antrea mangle-output fib saddr type local oifname "antrea-gw0" meta mark set meta mark or 0x80000000 comment "Antrea: mark LOCAL output packets"
+add rule ip6 antrea mangle-output fib saddr type local oifname "br-int" meta mark set meta mark or 0x80000000 comment "Antrea: mark LOCAL output packets"
+add rule ip6 antrea filter-forward iifname "antrea-gw0" accept comment "Antrea: accept packets from local Pods"
+add rule ip6 antrea filter-forward oifname "antrea-gw0" accept comment "Antrea: accept packets to local Pods"
+add rule ip6 antrea filter-forward ip6 saddr @LOCAL-FLEXIBLE-IPAM-POD-IP6 accept comment "Antrea: accept packets from local AntreaFlexibleIPAM Pods"
+add rule ip6 antrea filter-forward ip6 daddr @LOCAL-FLEXIBLE-IPAM-POD-IP6 accept comment "Antrea: accept packets to local AntreaFlexibleIPAM Pods"
+add rule ip6 antrea nat-postrouting oifname != "antrea-gw0" snat to meta mark and 0x000000ff map @ANTREA-SNAT-IP6 comment "Antrea: SNAT Pod to external packets"
+add rule ip6 antrea nat-postrouting ip6 saddr 2001:ab03:cd04:55ef::/64 ip6 daddr != @ANTREA-POD-IP6 oifname != "antrea-gw0" masquerade comment "Antrea: masquerade Pod to external packets"
+add rule ip6 antrea nat-postrouting oifname "antrea-gw0" fib saddr . oif type != local fib saddr type local masquerade fully-random comment "Antrea: masquerade LOCAL traffic"
+add rule ip6 antrea nat-postrouting ip6 saddr != 2001:ab03:cd04:55ef::/64 ip6 daddr @LOCAL-FLEXIBLE-IPAM-POD-IP6 masquerade comment "Antrea: masquerade traffic to local AntreaIPAM hostPort Pod"
+`)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockNFTables := nftablestest.NewMockInterface(ctrl)
+			c := &Client{nftables: mockNFTables,
+				firewallBackend:       config.FirewallBackendNFTables,
+				networkConfig:         tt.networkConfig,
+				nodeConfig:            tt.nodeConfig,
+				proxyAll:              tt.proxyAll,
+				multicastEnabled:      tt.multicastEnabled,
+				connectUplinkToBridge: tt.connectUplinkToBridge,
+			}
+			for mark, snatIP := range tt.markToSNATIP {
+				c.markToSNATIP.Store(mark, net.ParseIP(snatIP))
+			}
+			tt.expectedCalls(mockNFTables.EXPECT())
+			assert.NoError(t, c.syncNFTables())
+		})
+	}
+}
+
+func TestNFTablesSNATRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockNFTables := nftablestest.NewMockInterface(ctrl)
+	c := &Client{nftables: mockNFTables,
+		firewallBackend: config.FirewallBackendNFTables,
+		networkConfig:   &config.NetworkConfig{IPv4Enabled: true, IPv6Enabled: true},
+	}
+	mockNFTables.EXPECT().Apply("flush map ip antrea ANTREA-SNAT-IP\nadd element ip antrea ANTREA-SNAT-IP { 0x00000001 : 1.1.1.1 }\n")
+	require.NoError(t, c.AddSNATRule(net.ParseIP("1.1.1.1"), 1))
+	mockNFTables.EXPECT().Apply("flush map ip6 antrea ANTREA-SNAT-IP6\nadd element ip6 antrea ANTREA-SNAT-IP6 { 0x00000002 : fe80::1 }\n")
+	require.NoError(t, c.AddSNATRule(net.ParseIP("fe80::1"), 2))
+	mockNFTables.EXPECT().Apply("flush map ip antrea ANTREA-SNAT-IP\n")
+	require.NoError(t, c.DeleteSNATRule(1))
+}
+
+func TestNFTSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockNFTables := nftablestest.NewMockInterface(ctrl)
+	s := newNFTSet(mockNFTables)
+
+	mockNFTables.EXPECT().Apply("add table ip antrea\nadd set ip antrea ANTREA-POD-IP { type ipv4_addr ; flags interval ; }\n")
+	require.NoError(t, s.CreateIPSet(antreaPodIPSet, ipset.HashNet, false))
+	mockNFTables.EXPECT().Apply("add table ip6 antrea\nadd set ip6 antrea ANTREA-NODEPORT-IP6 { type ipv6_addr . inet_proto . inet_service ; }\n")
+	require.NoError(t, s.CreateIPSet(antreaNodePortIP6Set, ipset.HashIPPort, true))
+
+	mockNFTables.EXPECT().Apply("add element ip antrea ANTREA-POD-IP { 10.10.1.0/24 }\n")
+	require.NoError(t, s.AddEntry(antreaPodIPSet, "10.10.1.0/24"))
+	mockNFTables.EXPECT().Apply("add element ip6 antrea ANTREA-NODEPORT-IP6 { fe80::1 . tcp . 30000 }\n")
+	require.NoError(t, s.AddEntry(antreaNodePortIP6Set, "fe80::1,tcp:30000"))
+	mockNFTables.EXPECT().Apply("add element ip6 antrea ANTREA-NODEPORT-IP6 { fe80::1 . udp . 30001 }\ndelete element ip6 antrea ANTREA-NODEPORT-IP6 { fe80::1 . udp . 30001 }\n")
+	require.NoError(t, s.DelEntry(antreaNodePortIP6Set, "fe80::1,udp:30001"))
+
+	mockNFTables.EXPECT().ListSetElements(nftables.FamilyIPv6, antreaNFTable, antreaNodePortIP6Set).Return([]string{"fe80::1 . tcp . 30000"}, nil)
+	entries, err := s.ListEntries(antreaNodePortIP6Set)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fe80::1,tcp:30000"}, entries)
+
+	// The set was not created.
+	assert.Error(t, s.AddEntry(clusterNodeIPSet, "1.1.1.1"))
+}
@@ -37,6 +37,7 @@ import (
 	"antrea.io/antrea/pkg/agent/util/ipset"
 	"antrea.io/antrea/pkg/agent/util/iptables"
 	utilnetlink "antrea.io/antrea/pkg/agent/util/netlink"
+	"antrea.io/antrea/pkg/agent/util/nftables"
 	"antrea.io/antrea/pkg/agent/util/sysctl"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
 	"antrea.io/antrea/pkg/ovs/ovsconfig"
@@ -89,11 +90,17 @@ var (
 )
 
 // Client takes care of routing container packets in host network, coordinating ip route, ip rule, iptables and ipset.
+// When the nftables backend is used, nftables is used in place of iptables and ipset.
 type Client struct {
 	nodeConfig    *config.NodeConfig
 	networkConfig *config.NetworkConfig
 	noSNAT        bool
-	iptables      iptables.Interface
+	// firewallBackend is the resolved backend, either iptables or nftables.
+	firewallBackend config.FirewallBackend
+	iptables        iptables.Interface
+	nftables        nftables.Interface
+	// nftablesMutex serializes the updates of the nftables rules and maps.
+	nftablesMutex sync.Mutex
 	ipset         ipset.Interface
 	netlink       utilnetlink.Interface
 	// nodeRoutes caches ip routes to remote Pods. It's a map of podCIDR to routes.
@@ -105,6 +112,7 @@ type Client struct {
 	// iptablesInitialized is used to notify when iptables initialization is done.
 	iptablesInitialized   chan struct{}
 	proxyAll              bool
+	hostClusterIPAccess   bool
 	connectUplinkToBridge bool
 	multicastEnabled      bool
 	isCloudEKS            bool
@@ -125,11 +133,12 @@ type Client struct {
 }
 
 // NewClient returns a route client.
-func NewClient(networkConfig *config.NetworkConfig, noSNAT, proxyAll, connectUplinkToBridge, multicastEnabled bool, serviceCIDRProvider servicecidr.Interface) (*Client, error) {
+func NewClient(networkConfig *config.NetworkConfig, noSNAT, proxyAll, hostClusterIPAccess, connectUplinkToBridge, multicastEnabled bool, serviceCIDRProvider servicecidr.Interface) (*Client, error) {
 	return &Client{
 		networkConfig:         networkConfig,
 		noSNAT:                noSNAT,
 		proxyAll:              proxyAll,
+		hostClusterIPAccess:   hostClusterIPAccess,
 		multicastEnabled:      multicastEnabled,
 		connectUplinkToBridge: connectUplinkToBridge,
 		ipset:                 ipset.NewClient(),
@@ -146,31 +155,52 @@ func (c *Client) Initialize(nodeConfig *config.NodeConfig, done func()) error {
 	c.iptablesInitialized = make(chan struct{})
 
 	var err error
+	c.firewallBackend = c.resolveFirewallBackend()
+	klog.InfoS("Selected firewall backend", "backend", c.firewallBackend)
+	if c.firewallBackend == config.FirewallBackendNFTables {
+		if c.isCloudEKS {
+			return fmt.Errorf("the nftables firewall backend is not supported on EKS")
+		}
+		nft, err := nftables.New()
+		if err != nil {
+			return fmt.Errorf("error creating nftables client: %v", err)
+		}
+		c.nftables = nft
+		// nftables sets are used in place of ipsets.
+		c.ipset = newNFTSet(nft)
+		// Remove the rules installed by the other backend, in case the backend has been switched.
+		c.cleanupIPTables()
+	} else {
+		c.cleanupNFTables()
+	}
+
 	// Sets up the ipset that will be used in iptables.
 	if err = c.syncIPSet(); err != nil {
 		return fmt.Errorf("failed to initialize ipset: %v", err)
 	}
 
-	c.iptables, err = iptables.New(c.networkConfig.IPv4Enabled, c.networkConfig.IPv6Enabled)
-	if err != nil {
-		return fmt.Errorf("error creating IPTables instance: %v", err)
+	if c.firewallBackend == config.FirewallBackendIPTables {
+		c.iptables, err = iptables.New(c.networkConfig.IPv4Enabled, c.networkConfig.IPv6Enabled)
+		if err != nil {
+			return fmt.Errorf("error creating IPTables instance: %v", err)
+		}
 	}
 	// Sets up the iptables infrastructure required to route packets in host network.
 	// It's called in a goroutine because xtables lock may not be acquired immediately.
 	go func() {
-		klog.Info("Initializing iptables")
+		klog.InfoS("Initializing firewall rules", "backend", c.firewallBackend)
 		defer done()
 		defer close(c.iptablesInitialized)
 		var backoffTime = 2 * time.Second
 		for {
-			if err := c.syncIPTables(); err != nil {
-				klog.Errorf("Failed to initialize iptables: %v - will retry in %v", err, backoffTime)
+			if err := c.syncFirewall(); err != nil {
+				klog.ErrorS(err, "Failed to initialize firewall rules, will retry", "backend", c.firewallBackend, "backoff", backoffTime)
 				time.Sleep(backoffTime)
 				continue
 			}
 			break
 		}
-		klog.Info("Initialized iptables")
+		klog.InfoS("Initialized firewall rules", "backend", c.firewallBackend)
 	}()
 
 	// Sets up the IP routes and IP rule required to route packets in host network.
@@ -190,13 +220,19 @@ func (c *Client) Initialize(nodeConfig *config.NodeConfig, done func()) error {
 		}
 	}
 
-	// Set up the IP routes and sysctl parameters to support all Services in AntreaProxy.
-	if c.proxyAll {
+	// Set up the IP routes and sysctl parameters to support all Services, or only the ClusterIPs accessed from the host
+	// network namespace, in AntreaProxy.
+	if c.proxyClusterIPsFromHost() {
 		if err := c.initServiceIPRoutes(); err != nil {
 			return fmt.Errorf("failed to initialize Service IP routes: %v", err)
 		}
 	}
 
+	// Set up or revert the rp_filter exceptions of the Antrea-managed interfaces and virtual IPs.
+	if err := c.initRPFilterExceptions(); err != nil {
+		return fmt.Errorf("failed to initialize rp_filter exceptions: %v", err)
+	}
+
 	return nil
 }
 
@@ -215,13 +251,16 @@ func (c *Client) syncIPInfra() {
 		klog.ErrorS(err, "Failed to sync ipset")
 		return
 	}
-	if err := c.syncIPTables(); err != nil {
-		klog.ErrorS(err, "Failed to sync iptables")
+	if err := c.syncFirewall(); err != nil {
+		klog.ErrorS(err, "Failed to sync firewall rules", "backend", c.firewallBackend)
 		return
 	}
 	if err := c.syncRoute(); err != nil {
 		klog.ErrorS(err, "Failed to sync route")
 	}
+	if err := c.syncRPFilterExceptions(); err != nil {
+		klog.ErrorS(err, "Failed to sync rp_filter exceptions")
+	}
 	klog.V(3).Info("Successfully synced iptables, ipset and route")
 }
 
@@ -257,7 +296,7 @@ func (c *Client) syncRoute() error {
 		}
 		return true
 	})
-	if c.proxyAll {
+	if c.proxyClusterIPsFromHost() {
 		c.serviceRoutes.Range(func(_, v interface{}) bool {
 			route := v.(*netlink.Route)
 			return restoreRoute(route)
@@ -466,18 +505,23 @@ func (c *Client) writeEKSNATRules(iptablesData *bytes.Buffer) {
 	}...)
 }
 
-// syncIPTables ensure that the iptables infrastructure we use is set up.
-// It's idempotent and can safely be called on every startup.
-func (c *Client) syncIPTables() error {
-	// Create the antrea managed chains and link them to built-in chains.
-	// We cannot use iptables-restore for these jump rules because there
-	// are non antrea managed rules in built-in chains.
-	type jumpRule struct {
-		table    string
-		srcChain string
-		dstChain string
-		comment  string
+// syncFirewall syncs the firewall rules with the selected backend.
+func (c *Client) syncFirewall() error {
+	if c.firewallBackend == config.FirewallBackendNFTables {
+		return c.syncNFTables()
 	}
+	return c.syncIPTables()
+}
+
+type jumpRule struct {
+	table    string
+	srcChain string
+	dstChain string
+	comment  string
+}
+
+// iptablesJumpRules returns the rules which link the antrea managed chains to built-in chains.
+func (c *Client) iptablesJumpRules() []jumpRule {
 	jumpRules := []jumpRule{
 		{iptables.RawTable, iptables.PreRoutingChain, antreaPreRoutingChain, "Antrea: jump to Antrea prerouting rules"},
 		{iptables.RawTable, iptables.OutputChain, antreaOutputChain, "Antrea: jump to Antrea output rules"},
@@ -492,16 +536,11 @@ func (c *Client) syncIPTables() error {
 	if c.proxyAll {
 		jumpRules = append(jumpRules, jumpRule{iptables.NATTable, iptables.OutputChain, antreaOutputChain, "Antrea: jump to Antrea output rules"})
 	}
-	for _, rule := range jumpRules {
-		if err := c.iptables.EnsureChain(iptables.ProtocolDual, rule.table, rule.dstChain); err != nil {
-			return err
-		}
-		ruleSpec := []string{"-j", rule.dstChain, "-m", "comment", "--comment", rule.comment}
-		if err := c.iptables.AppendRule(iptables.ProtocolDual, rule.table, rule.srcChain, ruleSpec); err != nil {
-			return err
-		}
-	}
+	return jumpRules
+}
 
+// getSNATMarkToIPs returns the SNAT marks and IPs of the markToSNATIP cache, grouped by address family.
+func (c *Client) getSNATMarkToIPs() (map[uint32]net.IP, map[uint32]net.IP) {
 	snatMarkToIPv4 := map[uint32]net.IP{}
 	snatMarkToIPv6 := map[uint32]net.IP{}
 	c.markToSNATIP.Range(func(key, value interface{}) bool {
@@ -514,6 +553,26 @@ func (c *Client) syncIPTables() error {
 		}
 		return true
 	})
+	return snatMarkToIPv4, snatMarkToIPv6
+}
+
+// syncIPTables ensure that the iptables infrastructure we use is set up.
+// It's idempotent and can safely be called on every startup.
+func (c *Client) syncIPTables() error {
+	// Create the antrea managed chains and link them to built-in chains.
+	// We cannot use iptables-restore for these jump rules because there
+	// are non antrea managed rules in built-in chains.
+	for _, rule := range c.iptablesJumpRules() {
+		if err := c.iptables.EnsureChain(iptables.ProtocolDual, rule.table, rule.dstChain); err != nil {
+			return err
+		}
+		ruleSpec := []string{"-j", rule.dstChain, "-m", "comment", "--comment", rule.comment}
+		if err := c.iptables.AppendRule(iptables.ProtocolDual, rule.table, rule.srcChain, ruleSpec); err != nil {
+			return err
+		}
+	}
+
+	snatMarkToIPv4, snatMarkToIPv6 := c.getSNATMarkToIPs()
 
 	// Use iptables-restore to configure IPv4 settings.
 	if c.networkConfig.IPv4Enabled {
@@ -748,9 +807,9 @@ func (c *Client) restoreIptablesData(podCIDR *net.IPNet,
 		"-j", iptables.MasqueradeTarget, "--random-fully",
 	}...)
 
-	// If AntreaProxy full support is enabled, it SNATs the packets whose source IP is VirtualServiceIPv4/VirtualServiceIPv6
-	// so the packets can be routed back to this Node.
-	if c.proxyAll {
+	// If AntreaProxy full support or host ClusterIP access is enabled, it SNATs the packets whose source IP is
+	// VirtualServiceIPv4/VirtualServiceIPv6 so the packets can be routed back to this Node.
+	if c.proxyClusterIPsFromHost() {
 		writeLine(iptablesData, []string{
 			"-A", antreaPostRoutingChain,
 			"-m", "comment", "--comment", `"Antrea: masquerade OVS virtual source IP"`,
@@ -820,21 +879,33 @@ func (c *Client) initIPRoutes() error {
 	return nil
 }
 
+// proxyClusterIPsFromHost returns true if the traffic from the host network namespace to ClusterIPs is load-balanced
+// by AntreaProxy, i.e. if either proxyAll or hostClusterIPAccess is enabled.
+func (c *Client) proxyClusterIPsFromHost() bool {
+	return c.proxyAll || c.hostClusterIPAccess
+}
+
+// initServiceIPRoutes installs the routes which forward the traffic to the virtual Service IP and to the Service CIDRs
+// to the Antrea gateway. The route for the virtual NodePort DNAT IP is only needed when proxyAll is enabled.
 func (c *Client) initServiceIPRoutes() error {
 	if c.networkConfig.IPv4Enabled {
 		if err := c.addVirtualServiceIPRoute(false); err != nil {
 			return err
 		}
-		if err := c.addVirtualNodePortDNATIPRoute(false); err != nil {
-			return err
+		if c.proxyAll {
+			if err := c.addVirtualNodePortDNATIPRoute(false); err != nil {
+				return err
+			}
 		}
 	}
 	if c.networkConfig.IPv6Enabled {
 		if err := c.addVirtualServiceIPRoute(true); err != nil {
 			return err
 		}
-		if err := c.addVirtualNodePortDNATIPRoute(true); err != nil {
-			return err
+		if c.proxyAll {
+			if err := c.addVirtualNodePortDNATIPRoute(true); err != nil {
+				return err
+			}
 		}
 	}
 	c.serviceCIDRProvider.AddEventHandler(func(serviceCIDRs []*net.IPNet) {
@@ -896,8 +967,8 @@ func (c *Client) Reconcile(podCIDRs []string) error {
 		if desiredIPv6GWs.Has(route.Dst.IP.String()) {
 			continue
 		}
-		// Don't delete the routes which are added by AntreaProxy when proxyAll is enabled.
-		if c.proxyAll && c.isServiceRoute(&route) {
+		// Don't delete the routes which are added by AntreaProxy when proxyAll or hostClusterIPAccess is enabled.
+		if c.proxyClusterIPsFromHost() && c.isServiceRoute(&route) {
 			continue
 		}
 
@@ -1238,6 +1309,9 @@ func (c *Client) AddSNATRule(snatIP net.IP, mark uint32) error {
 		protocol = iptables.ProtocolIPv6
 	}
 	c.markToSNATIP.Store(mark, snatIP)
+	if c.firewallBackend == config.FirewallBackendNFTables {
+		return c.syncNFTSNATMap(snatIP)
+	}
 	return c.iptables.InsertRule(protocol, iptables.NATTable, antreaPostRoutingChain, c.snatRuleSpec(snatIP, mark))
 }
 
@@ -1249,6 +1323,9 @@ func (c *Client) DeleteSNATRule(mark uint32) error {
 	}
 	c.markToSNATIP.Delete(mark)
 	snatIP := value.(net.IP)
+	if c.firewallBackend == config.FirewallBackendNFTables {
+		return c.syncNFTSNATMap(snatIP)
+	}
 	protocol := iptables.ProtocolIPv4
 	if snatIP.To4() == nil {
 		protocol = iptables.ProtocolIPv6
@@ -1591,6 +1668,50 @@ func (c *Client) DeleteRouteForLink(cidr *net.IPNet, linkIndex int) error {
 	return nil
 }
 
+// ClearConntrackEntryForService deletes the conntrack entries matching the original destination IP and port of the
+// Service and, if provided, the reply source IP of the Endpoint. For NodePort Services, svcIP is nil and the entries
+// are only matched by the original destination port, as the connections may be destined to any Node IP.
+func (c *Client) ClearConntrackEntryForService(svcIP net.IP, svcPort uint16, endpointIP net.IP, protocol binding.Protocol) error {
+	filter := &netlink.ConntrackFilter{}
+	if err := filter.AddProtocol(getTransProtocolNumber(protocol)); err != nil {
+		return fmt.Errorf("error adding protocol to conntrack filter: %v", err)
+	}
+	if svcIP != nil {
+		if err := filter.AddIP(netlink.ConntrackOrigDstIP, svcIP); err != nil {
+			return fmt.Errorf("error adding original destination IP to conntrack filter: %v", err)
+		}
+	}
+	if err := filter.AddPort(netlink.ConntrackOrigDstPort, svcPort); err != nil {
+		return fmt.Errorf("error adding original destination port to conntrack filter: %v", err)
+	}
+	if endpointIP != nil {
+		if err := filter.AddIP(netlink.ConntrackReplySrcIP, endpointIP); err != nil {
+			return fmt.Errorf("error adding reply source IP to conntrack filter: %v", err)
+		}
+	}
+	family := netlink.InetFamily(unix.AF_INET)
+	if isIPv6Protocol(protocol) {
+		family = unix.AF_INET6
+	}
+	deleted, err := c.netlink.ConntrackDeleteFilter(netlink.ConntrackTable, family, filter)
+	if err != nil {
+		return fmt.Errorf("error deleting conntrack entries for Service %s:%d: %v", svcIP, svcPort, err)
+	}
+	klog.V(4).InfoS("Deleted conntrack entries for Service", "serviceIP", svcIP, "servicePort", svcPort, "endpointIP", endpointIP, "count", deleted)
+	return nil
+}
+
+func getTransProtocolNumber(protocol binding.Protocol) uint8 {
+	if protocol == binding.ProtocolTCP || protocol == binding.ProtocolTCPv6 {
+		return unix.IPPROTO_TCP
+	} else if protocol == binding.ProtocolUDP || protocol == binding.ProtocolUDPv6 {
+		return unix.IPPROTO_UDP
+	} else if protocol == binding.ProtocolSCTP || protocol == binding.ProtocolSCTPv6 {
+		return unix.IPPROTO_SCTP
+	}
+	return 0
+}
+
 func getTransProtocolStr(protocol binding.Protocol) string {
 	if protocol == binding.ProtocolTCP || protocol == binding.ProtocolTCPv6 {
 		return "tcp"
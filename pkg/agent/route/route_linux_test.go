@@ -23,6 +23,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 
 	"antrea.io/antrea/pkg/agent/config"
 	servicecidrtest "antrea.io/antrea/pkg/agent/servicecidr/testing"
@@ -233,6 +234,7 @@ func TestSyncIPTables(t *testing.T) {
 		name                  string
 		isCloudEKS            bool
 		proxyAll              bool
+		hostClusterIPAccess   bool
 		multicastEnabled      bool
 		connectUplinkToBridge bool
 		networkConfig         *config.NetworkConfig
@@ -471,6 +473,58 @@ COMMIT
 -A ANTREA-POSTROUTING -m comment --comment "Antrea: masquerade LOCAL traffic" -o antrea-gw0 -m addrtype ! --src-type LOCAL --limit-iface-out -m addrtype --src-type LOCAL -j MASQUERADE --random-fully
 -A ANTREA-POSTROUTING -m comment --comment "Antrea: masquerade traffic to local AntreaIPAM hostPort Pod" ! -s 172.16.10.0/24 -m set --match-set LOCAL-FLEXIBLE-IPAM-POD-IP dst -j MASQUERADE
 COMMIT
+`, false, false)
+			},
+		},
+		{
+			name:                "encap,hostClusterIPAccess=true",
+			hostClusterIPAccess: true,
+			networkConfig: &config.NetworkConfig{
+				TrafficEncapMode: config.TrafficEncapModeEncap,
+				TunnelType:       ovsconfig.GeneveTunnel,
+				IPv4Enabled:      true,
+			},
+			nodeConfig: &config.NodeConfig{
+				PodIPv4CIDR: ip.MustParseCIDR("172.16.10.0/24"),
+				GatewayConfig: &config.GatewayConfig{
+					Name: "antrea-gw0",
+				},
+			},
+			expectedCalls: func(mockIPTables *iptablestest.MockInterfaceMockRecorder) {
+				mockIPTables.EnsureChain(iptables.ProtocolDual, iptables.RawTable, antreaPreRoutingChain)
+				mockIPTables.AppendRule(iptables.ProtocolDual, iptables.RawTable, iptables.PreRoutingChain, []string{"-j", antreaPreRoutingChain, "-m", "comment", "--comment", "Antrea: jump to Antrea prerouting rules"})
+				mockIPTables.EnsureChain(iptables.ProtocolDual, iptables.RawTable, antreaOutputChain)
+				mockIPTables.AppendRule(iptables.ProtocolDual, iptables.RawTable, iptables.OutputChain, []string{"-j", antreaOutputChain, "-m", "comment", "--comment", "Antrea: jump to Antrea output rules"})
+				mockIPTables.EnsureChain(iptables.ProtocolDual, iptables.FilterTable, antreaForwardChain)
+				mockIPTables.AppendRule(iptables.ProtocolDual, iptables.FilterTable, iptables.ForwardChain, []string{"-j", antreaForwardChain, "-m", "comment", "--comment", "Antrea: jump to Antrea forwarding rules"})
+				mockIPTables.EnsureChain(iptables.ProtocolDual, iptables.NATTable, antreaPostRoutingChain)
+				mockIPTables.AppendRule(iptables.ProtocolDual, iptables.NATTable, iptables.PostRoutingChain, []string{"-j", antreaPostRoutingChain, "-m", "comment", "--comment", "Antrea: jump to Antrea postrouting rules"})
+				mockIPTables.EnsureChain(iptables.ProtocolDual, iptables.MangleTable, antreaMangleChain)
+				mockIPTables.AppendRule(iptables.ProtocolDual, iptables.MangleTable, iptables.PreRoutingChain, []string{"-j", antreaMangleChain, "-m", "comment", "--comment", "Antrea: jump to Antrea mangle rules"})
+				mockIPTables.EnsureChain(iptables.ProtocolDual, iptables.MangleTable, antreaOutputChain)
+				mockIPTables.AppendRule(iptables.ProtocolDual, iptables.MangleTable, iptables.OutputChain, []string{"-j", antreaOutputChain, "-m", "comment", "--comment", "Antrea: jump to Antrea output rules"})
+				mockIPTables.Restore(`*raw
+:ANTREA-PREROUTING - [0:0]
+:ANTREA-OUTPUT - [0:0]
+-A ANTREA-PREROUTING -m comment --comment "Antrea: do not track incoming encapsulation packets" -m udp -p udp --dport 6081 -m addrtype --dst-type LOCAL -j NOTRACK
+-A ANTREA-OUTPUT -m comment --comment "Antrea: do not track outgoing encapsulation packets" -m udp -p udp --dport 6081 -m addrtype --src-type LOCAL -j NOTRACK
+COMMIT
+*mangle
+:ANTREA-MANGLE - [0:0]
+:ANTREA-OUTPUT - [0:0]
+-A ANTREA-OUTPUT -m comment --comment "Antrea: mark LOCAL output packets" -m addrtype --src-type LOCAL -o antrea-gw0 -j MARK --or-mark 0x80000000
+COMMIT
+*filter
+:ANTREA-FORWARD - [0:0]
+-A ANTREA-FORWARD -m comment --comment "Antrea: accept packets from local Pods" -i antrea-gw0 -j ACCEPT
+-A ANTREA-FORWARD -m comment --comment "Antrea: accept packets to local Pods" -o antrea-gw0 -j ACCEPT
+COMMIT
+*nat
+:ANTREA-POSTROUTING - [0:0]
+-A ANTREA-POSTROUTING -m comment --comment "Antrea: masquerade Pod to external packets" -s 172.16.10.0/24 -m set ! --match-set ANTREA-POD-IP dst ! -o antrea-gw0 -j MASQUERADE
+-A ANTREA-POSTROUTING -m comment --comment "Antrea: masquerade LOCAL traffic" -o antrea-gw0 -m addrtype ! --src-type LOCAL --limit-iface-out -m addrtype --src-type LOCAL -j MASQUERADE --random-fully
+-A ANTREA-POSTROUTING -m comment --comment "Antrea: masquerade OVS virtual source IP" -s 169.254.0.253 -j MASQUERADE
+COMMIT
 `, false, false)
 			},
 		},
@@ -483,6 +537,7 @@ COMMIT
 				networkConfig:         tt.networkConfig,
 				nodeConfig:            tt.nodeConfig,
 				proxyAll:              tt.proxyAll,
+				hostClusterIPAccess:   tt.hostClusterIPAccess,
 				isCloudEKS:            tt.isCloudEKS,
 				multicastEnabled:      tt.multicastEnabled,
 				connectUplinkToBridge: tt.connectUplinkToBridge,
@@ -1715,3 +1770,98 @@ func TestAddAndDeleteNodeIP(t *testing.T) {
 		})
 	}
 }
+
+func TestClearConntrackEntryForService(t *testing.T) {
+	newFlow := func(dstIP string, dstPort uint16, endpointIP string, protocol uint8) *netlink.ConntrackFlow {
+		flow := &netlink.ConntrackFlow{}
+		flow.Forward.SrcIP = net.ParseIP("192.168.77.200")
+		flow.Forward.DstIP = net.ParseIP(dstIP)
+		flow.Forward.DstPort = dstPort
+		flow.Forward.Protocol = protocol
+		flow.Reverse.SrcIP = net.ParseIP(endpointIP)
+		flow.Reverse.DstIP = flow.Forward.SrcIP
+		flow.Reverse.Protocol = protocol
+		return flow
+	}
+	tests := []struct {
+		name            string
+		svcIP           net.IP
+		svcPort         uint16
+		endpointIP      net.IP
+		protocol        openflow.Protocol
+		expectedFamily  netlink.InetFamily
+		matchedFlows    []*netlink.ConntrackFlow
+		unmatchedFlows  []*netlink.ConntrackFlow
+		deleteFilterErr error
+		expectedErr     string
+	}{
+		{
+			name:           "IPv4 ClusterIP with Endpoint",
+			svcIP:          net.ParseIP("10.96.0.10"),
+			svcPort:        53,
+			endpointIP:     net.ParseIP("10.10.0.2"),
+			protocol:       openflow.ProtocolUDP,
+			expectedFamily: netlink.InetFamily(unix.AF_INET),
+			matchedFlows:   []*netlink.ConntrackFlow{newFlow("10.96.0.10", 53, "10.10.0.2", unix.IPPROTO_UDP)},
+			unmatchedFlows: []*netlink.ConntrackFlow{
+				newFlow("10.96.0.10", 53, "10.10.0.3", unix.IPPROTO_UDP),
+				newFlow("10.96.0.10", 53, "10.10.0.2", unix.IPPROTO_TCP),
+				newFlow("10.96.0.11", 53, "10.10.0.2", unix.IPPROTO_UDP),
+				newFlow("10.96.0.10", 54, "10.10.0.2", unix.IPPROTO_UDP),
+			},
+		},
+		{
+			name:           "IPv4 NodePort without Endpoint",
+			svcPort:        30053,
+			protocol:       openflow.ProtocolUDP,
+			expectedFamily: netlink.InetFamily(unix.AF_INET),
+			matchedFlows: []*netlink.ConntrackFlow{
+				newFlow("192.168.77.100", 30053, "10.10.0.2", unix.IPPROTO_UDP),
+				newFlow("192.168.77.101", 30053, "10.10.0.3", unix.IPPROTO_UDP),
+			},
+			unmatchedFlows: []*netlink.ConntrackFlow{newFlow("192.168.77.100", 30054, "10.10.0.2", unix.IPPROTO_UDP)},
+		},
+		{
+			name:           "IPv6 LoadBalancer with Endpoint",
+			svcIP:          net.ParseIP("fec0::169:254:169:1"),
+			svcPort:        53,
+			endpointIP:     net.ParseIP("fd74:ca9b:172:16::2"),
+			protocol:       openflow.ProtocolUDPv6,
+			expectedFamily: netlink.InetFamily(unix.AF_INET6),
+			matchedFlows:   []*netlink.ConntrackFlow{newFlow("fec0::169:254:169:1", 53, "fd74:ca9b:172:16::2", unix.IPPROTO_UDP)},
+			unmatchedFlows: []*netlink.ConntrackFlow{newFlow("fec0::169:254:169:1", 53, "fd74:ca9b:172:16::3", unix.IPPROTO_UDP)},
+		},
+		{
+			name:            "failed to delete entries",
+			svcIP:           net.ParseIP("10.96.0.10"),
+			svcPort:         53,
+			protocol:        openflow.ProtocolUDP,
+			expectedFamily:  netlink.InetFamily(unix.AF_INET),
+			deleteFilterErr: fmt.Errorf("operation not permitted"),
+			expectedErr:     "error deleting conntrack entries for Service 10.96.0.10:53: operation not permitted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockNetlink := netlinktest.NewMockInterface(ctrl)
+			c := &Client{netlink: mockNetlink}
+			mockNetlink.EXPECT().ConntrackDeleteFilter(netlink.ConntrackTableType(netlink.ConntrackTable), tt.expectedFamily, gomock.Any()).DoAndReturn(
+				func(_ netlink.ConntrackTableType, _ netlink.InetFamily, filter netlink.CustomConntrackFilter) (uint, error) {
+					for _, flow := range tt.matchedFlows {
+						assert.True(t, filter.MatchConntrackFlow(flow), "Flow %s should be matched", flow)
+					}
+					for _, flow := range tt.unmatchedFlows {
+						assert.False(t, filter.MatchConntrackFlow(flow), "Flow %s should not be matched", flow)
+					}
+					return uint(len(tt.matchedFlows)), tt.deleteFilterErr
+				})
+			err := c.ClearConntrackEntryForService(tt.svcIP, tt.svcPort, tt.endpointIP, tt.protocol)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
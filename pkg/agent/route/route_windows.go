@@ -63,21 +63,25 @@ type Client struct {
 	serviceRoutes *sync.Map
 	// netNatStaticMappings caches Windows NetNat for NodePort.
 	netNatStaticMappings *sync.Map
-	fwClient             *winfirewall.Client
-	bridgeInfIndex       int
-	noSNAT               bool
-	proxyAll             bool
+	// nodePortProxies caches the port proxies for NodePort accessed from the host. It's a map of NodePort key to
+	// port proxies.
+	nodePortProxies *sync.Map
+	fwClient        *winfirewall.Client
+	bridgeInfIndex  int
+	noSNAT          bool
+	proxyAll        bool
 	// The latest calculated Service CIDRs can be got from serviceCIDRProvider.
 	serviceCIDRProvider servicecidr.Interface
 }
 
 // NewClient returns a route client.
-func NewClient(networkConfig *config.NetworkConfig, noSNAT, proxyAll, connectUplinkToBridge, multicastEnabled bool, serviceCIDRProvider servicecidr.Interface) (*Client, error) {
+func NewClient(networkConfig *config.NetworkConfig, noSNAT, proxyAll, hostClusterIPAccess, connectUplinkToBridge, multicastEnabled bool, serviceCIDRProvider servicecidr.Interface) (*Client, error) {
 	return &Client{
 		networkConfig:        networkConfig,
 		nodeRoutes:           &sync.Map{},
 		serviceRoutes:        &sync.Map{},
 		netNatStaticMappings: &sync.Map{},
+		nodePortProxies:      &sync.Map{},
 		fwClient:             winfirewall.NewClient(),
 		noSNAT:               noSNAT,
 		proxyAll:             proxyAll,
@@ -151,7 +155,8 @@ func (c *Client) initServiceIPRoutes() error {
 }
 
 // Reconcile removes the orphaned routes and related configuration based on the desired podCIDRs and Service IPs. Only
-// the route entries on the host gateway interface are stored in the cache.
+// the route entries on the host gateway interface and, in noEncap mode, the route entries to peer Pod CIDRs on the OVS
+// bridge interface are considered.
 func (c *Client) Reconcile(podCIDRs []string) error {
 	desiredPodCIDRs := sets.New[string](podCIDRs...)
 	routes, err := c.listIPRoutesOnGW()
@@ -174,6 +179,21 @@ func (c *Client) Reconcile(podCIDRs []string) error {
 			return err
 		}
 	}
+	if c.networkConfig.TrafficEncapMode.SupportsNoEncap() {
+		routes, err := c.listNoEncapRoutesOnBridge()
+		if err != nil {
+			return err
+		}
+		for dst, rt := range routes {
+			if desiredPodCIDRs.Has(dst) {
+				continue
+			}
+			klog.V(2).InfoS("Deleting orphaned noEncap route", "route", rt)
+			if err := util.RemoveNetRoute(rt); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -193,13 +213,17 @@ func (c *Client) AddRoutes(podCIDR *net.IPNet, nodeName string, peerNodeIP, peer
 		// Set the peerNodeIP as next hop.
 		route.LinkIndex = c.bridgeInfIndex
 		route.GatewayAddress = peerNodeIP
+		route.RouteMetric = util.MetricNoEncap
+	} else if !c.noSNAT {
+		// NoEncap traffic to Node on the different subnet needs underlying routing support, and the host default
+		// route inside the Node is used. As WinNAT cannot exclude destinations, such traffic is forwarded by the host
+		// network stack and will be SNAT'd with the Node IP.
+		klog.InfoS("Peer Node is not on the same subnet, Pod traffic to it will be SNAT'd in noEncap mode unless noSNAT is set", "node", nodeName, "peerNodeIP", peerNodeIP)
 	}
-	// NoEncap traffic to Node on the different subnet needs underlying routing support.
-	// Use host default route inside the Node.
 
 	if found {
 		existingRoute := obj.(*util.Route)
-		if existingRoute.GatewayAddress.Equal(route.GatewayAddress) {
+		if existingRoute.GatewayAddress.Equal(route.GatewayAddress) && existingRoute.LinkIndex == route.LinkIndex {
 			klog.V(4).Infof("Route with destination %s already exists on %s (%s)", podCIDR.String(), nodeName, peerNodeIP)
 			return nil
 		}
@@ -219,7 +243,7 @@ func (c *Client) AddRoutes(podCIDR *net.IPNet, nodeName string, peerNodeIP, peer
 	}
 
 	c.nodeRoutes.Store(podCIDR.String(), route)
-	klog.V(2).Infof("Added route with destination %s via %s on host gateway on %s (%s)", podCIDR.String(), peerGwIP.String(), nodeName, peerNodeIP)
+	klog.V(2).Infof("Added route with destination %s via %s on interface %d on %s (%s)", podCIDR.String(), route.GatewayAddress.String(), route.LinkIndex, nodeName, peerNodeIP)
 	return nil
 }
 
@@ -415,6 +439,15 @@ func (c *Client) syncNetNatStaticMapping() error {
 		}
 		return true
 	})
+	c.nodePortProxies.Range(func(_, v interface{}) bool {
+		for _, proxy := range v.([]*util.PortProxy) {
+			if err := util.ReplacePortProxy(proxy); err != nil {
+				klog.ErrorS(err, "Failed to add port proxy", "portProxy", proxy)
+				return false
+			}
+		}
+		return true
+	})
 
 	return nil
 }
@@ -466,6 +499,28 @@ func (c *Client) listIPRoutesOnGW() (map[string]*util.Route, error) {
 	return rtMap, nil
 }
 
+// listNoEncapRoutesOnBridge returns the routes to peer Pod CIDRs installed on the OVS bridge interface in noEncap mode.
+// They are identified by util.MetricNoEncap, as the next hops are the peer Node IPs which are not known after an agent
+// restart.
+func (c *Client) listNoEncapRoutesOnBridge() (map[string]*util.Route, error) {
+	routes, err := util.GetNetRoutesAll()
+	if err != nil {
+		return nil, err
+	}
+	rtMap := make(map[string]*util.Route)
+	for idx := range routes {
+		rt := routes[idx]
+		if rt.LinkIndex != c.bridgeInfIndex || rt.RouteMetric != util.MetricNoEncap {
+			continue
+		}
+		if rt.DestinationSubnet.IP.To4() == nil || rt.GatewayAddress.To4() == nil || rt.GatewayAddress.IsUnspecified() {
+			continue
+		}
+		rtMap[rt.DestinationSubnet.String()] = &rt
+	}
+	return rtMap, nil
+}
+
 // initFwRules adds Windows Firewall rules to accept the traffic that is sent to or from local Pods.
 func (c *Client) initFwRules() error {
 	err := c.fwClient.AddRuleAllowIP(inboundFirewallRuleName, winfirewall.FWRuleIn, c.nodeConfig.PodIPv4CIDR)
@@ -487,8 +542,11 @@ func (c *Client) DeleteSNATRule(mark uint32) error {
 	return nil
 }
 
-// TODO: nodePortAddresses is not supported currently.
+// AddNodePort adds a NetNatStaticMapping to DNAT the NodePort traffic from remote to the virtual NodePort DNAT IP. As
+// WinNAT doesn't process the traffic originated from the host, port proxies are also added for TCP NodePort to relay
+// the connections to the provided nodePortAddresses and the loopback address to the virtual NodePort DNAT IP.
 func (c *Client) AddNodePort(nodePortAddresses []net.IP, port uint16, protocol binding.Protocol) error {
+	key := fmt.Sprintf("%d-%s", port, protocol)
 	netNatStaticMapping := &util.NetNatStaticMapping{
 		Name:         antreaNatNodePort,
 		ExternalIP:   net.ParseIP("0.0.0.0"),
@@ -500,13 +558,35 @@ func (c *Client) AddNodePort(nodePortAddresses []net.IP, port uint16, protocol b
 	if err := util.ReplaceNetNatStaticMapping(netNatStaticMapping); err != nil {
 		return err
 	}
-	c.netNatStaticMappings.Store(fmt.Sprintf("%d-%s", port, protocol), netNatStaticMapping)
+	c.netNatStaticMappings.Store(key, netNatStaticMapping)
 	klog.V(4).InfoS("Added NetNatStaticMapping for NodePort", "NetNatStaticMapping", netNatStaticMapping)
+
+	// Port proxy only supports TCP. The UDP NodePort traffic originated from the host is not supported.
+	if protocol != binding.ProtocolTCP {
+		return nil
+	}
+	proxies := generateNodePortProxies(nodePortAddresses, port)
+	for _, proxy := range proxies {
+		if err := util.ReplacePortProxy(proxy); err != nil {
+			return fmt.Errorf("failed to add port proxy for NodePort %d: %w", port, err)
+		}
+	}
+	c.nodePortProxies.Store(key, proxies)
+	klog.V(4).InfoS("Added port proxies for NodePort", "port", port, "count", len(proxies))
 	return nil
 }
 
 func (c *Client) DeleteNodePort(nodePortAddresses []net.IP, port uint16, protocol binding.Protocol) error {
 	key := fmt.Sprintf("%d-%s", port, protocol)
+	if obj, found := c.nodePortProxies.Load(key); found {
+		for _, proxy := range obj.([]*util.PortProxy) {
+			if err := util.RemovePortProxy(proxy); err != nil {
+				return fmt.Errorf("failed to delete port proxy for NodePort %d: %w", port, err)
+			}
+		}
+		c.nodePortProxies.Delete(key)
+		klog.V(4).InfoS("Deleted port proxies for NodePort", "port", port)
+	}
 	obj, found := c.netNatStaticMappings.Load(key)
 	if !found {
 		klog.V(2).InfoS("Didn't find corresponding NetNatStaticMapping for NodePort", "port", port, "protocol", protocol)
@@ -521,6 +601,28 @@ func (c *Client) DeleteNodePort(nodePortAddresses []net.IP, port uint16, protoco
 	return nil
 }
 
+// generateNodePortProxies generates the port proxies for the NodePort traffic originated from the host. IPv6 addresses
+// are ignored as IPv6 is not supported on Windows.
+func generateNodePortProxies(nodePortAddresses []net.IP, port uint16) []*util.PortProxy {
+	listenIPs := []net.IP{net.IPv4(127, 0, 0, 1)}
+	for _, ip := range nodePortAddresses {
+		if ip.To4() == nil || ip.IsLoopback() {
+			continue
+		}
+		listenIPs = append(listenIPs, ip)
+	}
+	proxies := make([]*util.PortProxy, 0, len(listenIPs))
+	for _, ip := range listenIPs {
+		proxies = append(proxies, &util.PortProxy{
+			ListenIP:    ip,
+			ListenPort:  port,
+			ConnectIP:   config.VirtualNodePortDNATIPv4,
+			ConnectPort: port,
+		})
+	}
+	return proxies
+}
+
 // AddExternalIPRoute adds a route entry that forwards traffic destined for the external IP to the Antrea gateway interface.
 func (c *Client) AddExternalIPRoute(externalIP net.IP) error {
 	externalIPStr := externalIP.String()
@@ -591,3 +693,9 @@ func (c *Client) AddRouteForLink(dstCIDR *net.IPNet, linkIndex int) error {
 func (c *Client) DeleteRouteForLink(dstCIDR *net.IPNet, linkIndex int) error {
 	return errors.New("DeleteRouteForLink is not implemented on Windows")
 }
+
+// ClearConntrackEntryForService is a no-op on Windows, as the conntrack entries of the Service connections are not
+// maintained by the host.
+func (c *Client) ClearConntrackEntryForService(svcIP net.IP, svcPort uint16, endpointIP net.IP, protocol binding.Protocol) error {
+	return nil
+}
@@ -60,7 +60,7 @@ func TestRouteOperation(t *testing.T) {
 	gwIP2 := net.ParseIP("192.168.3.1")
 	_, destCIDR2, _ := net.ParseCIDR(dest2)
 
-	client, err := NewClient(&config.NetworkConfig{}, true, false, false, false, nil)
+	client, err := NewClient(&config.NetworkConfig{}, true, false, false, false, false, nil)
 
 	require.Nil(t, err)
 	called := false
@@ -95,6 +95,53 @@ func TestRouteOperation(t *testing.T) {
 	assert.Equal(t, 0, len(routes7))
 }
 
+func TestNoEncapRouteOperation(t *testing.T) {
+	peerNodeIP1 := net.ParseIP("10.0.0.2")
+	peerNodeIP2 := net.ParseIP("10.0.0.3")
+	gwIP1 := net.ParseIP("192.168.4.1")
+	_, destCIDR1, _ := net.ParseCIDR("192.168.4.0/24")
+	dest2 := "192.168.5.0/24"
+	gwIP2 := net.ParseIP("192.168.5.1")
+	_, destCIDR2, _ := net.ParseCIDR(dest2)
+	_, transportCIDR, _ := net.ParseCIDR("10.0.0.1/24")
+	noEncapNodeConfig := *nodeConfig
+	noEncapNodeConfig.NodeTransportIPv4Addr = transportCIDR
+
+	client, err := NewClient(&config.NetworkConfig{TrafficEncapMode: config.TrafficEncapModeNoEncap}, true, false, false, false, false, nil)
+	require.Nil(t, err)
+	err = client.Initialize(&noEncapNodeConfig, func() {})
+	require.Nil(t, err)
+
+	// Peer Nodes on the same subnet are reached via the OVS bridge interface with the peer Node IP as next hop.
+	err = client.AddRoutes(destCIDR1, "node1", peerNodeIP1, gwIP1)
+	require.Nil(t, err)
+	routes1, err := util.GetNetRoutes(gwLink, destCIDR1)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(routes1))
+	assert.Equal(t, peerNodeIP1, routes1[0].GatewayAddress)
+	assert.Equal(t, util.MetricNoEncap, routes1[0].RouteMetric)
+
+	err = client.AddRoutes(destCIDR2, "node2", peerNodeIP2, gwIP2)
+	require.Nil(t, err)
+
+	// Simulate an agent restart, after which the routes are no longer cached.
+	client, err = NewClient(&config.NetworkConfig{TrafficEncapMode: config.TrafficEncapModeNoEncap}, true, false, false, false, false, nil)
+	require.Nil(t, err)
+	err = client.Initialize(&noEncapNodeConfig, func() {})
+	require.Nil(t, err)
+	err = client.Reconcile([]string{dest2})
+	require.Nil(t, err)
+
+	routes1, err = util.GetNetRoutes(gwLink, destCIDR1)
+	require.Nil(t, err)
+	assert.Equal(t, 0, len(routes1))
+	routes2, err := util.GetNetRoutes(gwLink, destCIDR2)
+	require.Nil(t, err)
+	assert.Equal(t, 1, len(routes2))
+
+	require.Nil(t, util.RemoveNetRoute(&routes2[0]))
+}
+
 func TestAddAndDeleteExternalIPRoute(t *testing.T) {
 	c := &Client{
 		nodeConfig:    nodeConfig,
@@ -119,3 +166,26 @@ func TestAddAndDeleteExternalIPRoute(t *testing.T) {
 	_, ok = c.serviceRoutes.Load(externalIP.String())
 	assert.False(t, ok)
 }
+
+func TestGenerateNodePortProxies(t *testing.T) {
+	nodePortAddresses := []net.IP{
+		net.ParseIP("127.0.0.1"),
+		net.ParseIP("192.168.77.100"),
+		net.ParseIP("fec0::192:168:77:100"),
+	}
+	expectedProxies := []*util.PortProxy{
+		{
+			ListenIP:    net.IPv4(127, 0, 0, 1),
+			ListenPort:  30001,
+			ConnectIP:   config.VirtualNodePortDNATIPv4,
+			ConnectPort: 30001,
+		},
+		{
+			ListenIP:    net.ParseIP("192.168.77.100"),
+			ListenPort:  30001,
+			ConnectIP:   config.VirtualNodePortDNATIPv4,
+			ConnectPort: 30001,
+		},
+	}
+	assert.Equal(t, expectedProxies, generateNodePortProxies(nodePortAddresses, 30001))
+}
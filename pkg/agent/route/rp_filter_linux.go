@@ -0,0 +1,229 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/agent/util/sysctl"
+)
+
+const (
+	// Values of the rp_filter sysctl parameter, see RFC 3704. The effective mode of an interface is the max of
+	// conf/{all,interface}/rp_filter.
+	rpFilterStrict = 1
+	rpFilterLoose  = 2
+)
+
+var (
+	// rpFilterStateFile persists the rp_filter exceptions installed by antrea-agent, including the original rp_filter
+	// values of the interfaces, so that they can be reverted after antrea-agent restarts with the exceptions disabled.
+	rpFilterStateFile = "/var/run/antrea/rp-filter-exceptions.json"
+
+	getSysctlNet = sysctl.GetSysctlNet
+	setSysctlNet = sysctl.SetSysctlNet
+)
+
+// rpFilterState is the content of rpFilterStateFile.
+type rpFilterState struct {
+	// Interfaces maps the name of each interface whose rp_filter was changed to its original value.
+	Interfaces map[string]int `json:"interfaces,omitempty"`
+	// VirtualServiceIPRoute is true if the route of the virtual Service IP was installed for the exceptions.
+	VirtualServiceIPRoute bool `json:"virtualServiceIPRoute,omitempty"`
+}
+
+func rpFilterSysctl(iface string) string {
+	return fmt.Sprintf("ipv4/conf/%s/rp_filter", iface)
+}
+
+func loadRPFilterState() (*rpFilterState, error) {
+	state := &rpFilterState{}
+	data, err := os.ReadFile(rpFilterStateFile)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read rp_filter state file %s: %w", rpFilterStateFile, err)
+	} else if err == nil {
+		if err := json.Unmarshal(data, state); err != nil {
+			return nil, fmt.Errorf("failed to parse rp_filter state file %s: %w", rpFilterStateFile, err)
+		}
+	}
+	if state.Interfaces == nil {
+		state.Interfaces = map[string]int{}
+	}
+	return state, nil
+}
+
+func saveRPFilterState(state *rpFilterState) error {
+	if len(state.Interfaces) == 0 && !state.VirtualServiceIPRoute {
+		if err := os.Remove(rpFilterStateFile); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove rp_filter state file %s: %w", rpFilterStateFile, err)
+		}
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(rpFilterStateFile), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(rpFilterStateFile, data, 0600)
+}
+
+// rpFilterExceptionInterfaces returns the interfaces whose rp_filter should be set to loose mode: the Antrea gateway
+// interface, the tunnel interface if any, and the interfaces specified by the user.
+func (c *Client) rpFilterExceptionInterfaces() sets.Set[string] {
+	ifaces := sets.New[string](c.nodeConfig.GatewayConfig.Name)
+	if c.networkConfig.NeedsTunnelInterface() && c.nodeConfig.DefaultTunName != "" {
+		ifaces.Insert(c.nodeConfig.DefaultTunName)
+	}
+	return ifaces.Insert(c.networkConfig.RPFilterExceptionInterfaces...)
+}
+
+// initRPFilterExceptions installs the rp_filter exceptions if they are enabled, otherwise it reverts the exceptions
+// installed previously. It is idempotent and can be safely called on every startup.
+func (c *Client) initRPFilterExceptions() error {
+	state, err := loadRPFilterState()
+	if err != nil {
+		return err
+	}
+	// rp_filter only applies to IPv4.
+	enabled := c.networkConfig.EnableRPFilterExceptions && c.networkConfig.IPv4Enabled
+	// When the ClusterIPs are proxied from the host, the route of the virtual Service IP is installed anyway.
+	// Otherwise, the packets SNAT'd with the virtual Service IP by OVS would fail the reverse path check on the
+	// Antrea gateway interface, as there is no route of the virtual Service IP via it.
+	needVirtualServiceIPRoute := enabled && !c.proxyClusterIPsFromHost()
+	if needVirtualServiceIPRoute {
+		state.VirtualServiceIPRoute = true
+		if err := saveRPFilterState(state); err != nil {
+			return err
+		}
+		if err := c.addVirtualServiceIPRoute(false); err != nil {
+			return err
+		}
+	} else if state.VirtualServiceIPRoute {
+		if !c.proxyClusterIPsFromHost() {
+			if err := c.deleteVirtualServiceIPRoute(); err != nil {
+				return err
+			}
+		}
+		state.VirtualServiceIPRoute = false
+		if err := saveRPFilterState(state); err != nil {
+			return err
+		}
+	}
+	return c.syncRPFilter(state)
+}
+
+// syncRPFilterExceptions ensures the rp_filter of the interfaces is still in loose mode, in case it has been reset.
+func (c *Client) syncRPFilterExceptions() error {
+	if !c.networkConfig.EnableRPFilterExceptions || !c.networkConfig.IPv4Enabled {
+		return nil
+	}
+	state, err := loadRPFilterState()
+	if err != nil {
+		return err
+	}
+	return c.syncRPFilter(state)
+}
+
+func (c *Client) syncRPFilter(state *rpFilterState) error {
+	desiredIfaces := sets.New[string]()
+	if c.networkConfig.EnableRPFilterExceptions && c.networkConfig.IPv4Enabled {
+		desiredIfaces = c.rpFilterExceptionInterfaces()
+	}
+	// Restore the original rp_filter of the interfaces which no longer need an exception.
+	for iface, origVal := range state.Interfaces {
+		if desiredIfaces.Has(iface) {
+			continue
+		}
+		if err := setSysctlNet(rpFilterSysctl(iface), origVal); err != nil {
+			if !os.IsNotExist(err) {
+				return fmt.Errorf("failed to restore rp_filter of interface %s: %w", iface, err)
+			}
+			// The interface has been deleted, there is nothing to restore.
+		} else {
+			klog.InfoS("Restored rp_filter of interface", "interface", iface, "value", origVal)
+		}
+		delete(state.Interfaces, iface)
+		if err := saveRPFilterState(state); err != nil {
+			return err
+		}
+	}
+	if desiredIfaces.Len() == 0 {
+		return nil
+	}
+	all, err := getSysctlNet(rpFilterSysctl("all"))
+	if err != nil {
+		return fmt.Errorf("failed to get rp_filter of all interfaces: %w", err)
+	}
+	for _, iface := range sets.List(desiredIfaces) {
+		val, err := getSysctlNet(rpFilterSysctl(iface))
+		if err != nil {
+			// The interface may not exist yet, e.g. an extra interface created after antrea-agent starts. It will be
+			// handled in a later sync.
+			klog.ErrorS(err, "Failed to get rp_filter of interface", "interface", iface)
+			continue
+		}
+		// Only strict mode needs an exception, loose mode and no validation accept the asymmetric traffic.
+		effectiveVal := val
+		if all > effectiveVal {
+			effectiveVal = all
+		}
+		if effectiveVal != rpFilterStrict {
+			continue
+		}
+		// Persist the original value before changing it, so that it can always be restored.
+		if _, ok := state.Interfaces[iface]; !ok {
+			state.Interfaces[iface] = val
+			if err := saveRPFilterState(state); err != nil {
+				return err
+			}
+		}
+		if err := setSysctlNet(rpFilterSysctl(iface), rpFilterLoose); err != nil {
+			return fmt.Errorf("failed to set rp_filter of interface %s to loose mode: %w", iface, err)
+		}
+		klog.InfoS("Set rp_filter of interface to loose mode", "interface", iface, "originalValue", val)
+	}
+	return nil
+}
+
+// deleteVirtualServiceIPRoute deletes the route and the neighbor of the virtual Service IP installed by
+// addVirtualServiceIPRoute.
+func (c *Client) deleteVirtualServiceIPRoute() error {
+	linkIndex := c.nodeConfig.GatewayConfig.LinkIndex
+	svcIP := config.VirtualServiceIPv4
+	route := generateRoute(svcIP, net.IPv4len*8, nil, linkIndex, netlink.SCOPE_LINK)
+	if err := c.netlink.RouteDel(route); err != nil && err != unix.ESRCH {
+		return fmt.Errorf("failed to delete route for virtual Service IP %s: %w", svcIP.String(), err)
+	}
+	c.serviceRoutes.Delete(svcIP.String())
+	neigh := generateNeigh(svcIP, linkIndex)
+	if err := c.netlink.NeighDel(neigh); err != nil && err != unix.ENOENT {
+		return fmt.Errorf("failed to delete IP neighbour for %s: %w", svcIP.String(), err)
+	}
+	c.serviceNeighbors.Delete(svcIP.String())
+	klog.InfoS("Deleted virtual Service IP route", "route", route)
+	return nil
+}
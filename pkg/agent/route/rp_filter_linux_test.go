@@ -0,0 +1,225 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package route
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vishvananda/netlink"
+
+	"antrea.io/antrea/pkg/agent/config"
+	netlinktest "antrea.io/antrea/pkg/agent/util/netlink/testing"
+)
+
+func mockRPFilterSysctls(t *testing.T, values map[string]int) {
+	origGetSysctlNet, origSetSysctlNet, origStateFile := getSysctlNet, setSysctlNet, rpFilterStateFile
+	t.Cleanup(func() {
+		getSysctlNet, setSysctlNet, rpFilterStateFile = origGetSysctlNet, origSetSysctlNet, origStateFile
+	})
+	getSysctlNet = func(sysctl string) (int, error) {
+		val, ok := values[sysctl]
+		if !ok {
+			return -1, os.ErrNotExist
+		}
+		return val, nil
+	}
+	setSysctlNet = func(sysctl string, newVal int) error {
+		if _, ok := values[sysctl]; !ok {
+			return os.ErrNotExist
+		}
+		values[sysctl] = newVal
+		return nil
+	}
+	rpFilterStateFile = filepath.Join(t.TempDir(), "rp-filter-exceptions.json")
+}
+
+func TestInitRPFilterExceptions(t *testing.T) {
+	virtualServiceIPNeigh := &netlink.Neigh{
+		LinkIndex:    10,
+		Family:       netlink.FAMILY_V4,
+		State:        netlink.NUD_PERMANENT,
+		IP:           config.VirtualServiceIPv4,
+		HardwareAddr: globalVMAC,
+	}
+	virtualServiceIPRoute := &netlink.Route{
+		Dst: &net.IPNet{
+			IP:   config.VirtualServiceIPv4,
+			Mask: net.CIDRMask(32, 32),
+		},
+		Scope:     netlink.SCOPE_LINK,
+		LinkIndex: 10,
+	}
+	nodeConfig := &config.NodeConfig{
+		GatewayConfig:  &config.GatewayConfig{Name: "antrea-gw0", LinkIndex: 10},
+		DefaultTunName: "antrea-tun0",
+	}
+	tests := []struct {
+		name           string
+		enable         bool
+		proxyAll       bool
+		extraIfaces    []string
+		existingState  *rpFilterState
+		sysctls        map[string]int
+		expectedCalls  func(mockNetlink *netlinktest.MockInterfaceMockRecorder)
+		expectedSysctl map[string]int
+		expectedState  *rpFilterState
+	}{
+		{
+			name:        "enable with strict mode for all interfaces",
+			enable:      true,
+			extraIfaces: []string{"eth1", "eth2"},
+			sysctls: map[string]int{
+				"ipv4/conf/all/rp_filter":         1,
+				"ipv4/conf/antrea-gw0/rp_filter":  0,
+				"ipv4/conf/antrea-tun0/rp_filter": 1,
+				"ipv4/conf/eth1/rp_filter":        2,
+			},
+			expectedCalls: func(mockNetlink *netlinktest.MockInterfaceMockRecorder) {
+				mockNetlink.NeighSet(virtualServiceIPNeigh)
+				mockNetlink.RouteReplace(virtualServiceIPRoute)
+			},
+			expectedSysctl: map[string]int{
+				"ipv4/conf/all/rp_filter":         1,
+				"ipv4/conf/antrea-gw0/rp_filter":  2,
+				"ipv4/conf/antrea-tun0/rp_filter": 2,
+				"ipv4/conf/eth1/rp_filter":        2,
+			},
+			expectedState: &rpFilterState{
+				Interfaces:            map[string]int{"antrea-gw0": 0, "antrea-tun0": 1},
+				VirtualServiceIPRoute: true,
+			},
+		},
+		{
+			name:     "enable with proxyAll and strict mode for the gateway interface",
+			enable:   true,
+			proxyAll: true,
+			sysctls: map[string]int{
+				"ipv4/conf/all/rp_filter":         0,
+				"ipv4/conf/antrea-gw0/rp_filter":  1,
+				"ipv4/conf/antrea-tun0/rp_filter": 0,
+			},
+			expectedSysctl: map[string]int{
+				"ipv4/conf/all/rp_filter":         0,
+				"ipv4/conf/antrea-gw0/rp_filter":  2,
+				"ipv4/conf/antrea-tun0/rp_filter": 0,
+			},
+			expectedState: &rpFilterState{
+				Interfaces: map[string]int{"antrea-gw0": 1},
+			},
+		},
+		{
+			name:        "enable with existing exceptions",
+			enable:      true,
+			extraIfaces: []string{"eth1"},
+			existingState: &rpFilterState{
+				Interfaces:            map[string]int{"antrea-gw0": 1, "antrea-tun0": 0, "eth2": 1},
+				VirtualServiceIPRoute: true,
+			},
+			sysctls: map[string]int{
+				"ipv4/conf/all/rp_filter":         1,
+				"ipv4/conf/antrea-gw0/rp_filter":  2,
+				"ipv4/conf/antrea-tun0/rp_filter": 1,
+				"ipv4/conf/eth1/rp_filter":        1,
+				"ipv4/conf/eth2/rp_filter":        2,
+			},
+			expectedCalls: func(mockNetlink *netlinktest.MockInterfaceMockRecorder) {
+				mockNetlink.NeighSet(virtualServiceIPNeigh)
+				mockNetlink.RouteReplace(virtualServiceIPRoute)
+			},
+			expectedSysctl: map[string]int{
+				"ipv4/conf/all/rp_filter":         1,
+				"ipv4/conf/antrea-gw0/rp_filter":  2,
+				"ipv4/conf/antrea-tun0/rp_filter": 2,
+				"ipv4/conf/eth1/rp_filter":        2,
+				"ipv4/conf/eth2/rp_filter":        1,
+			},
+			expectedState: &rpFilterState{
+				Interfaces:            map[string]int{"antrea-gw0": 1, "antrea-tun0": 0, "eth1": 1},
+				VirtualServiceIPRoute: true,
+			},
+		},
+		{
+			name: "disable with existing exceptions",
+			existingState: &rpFilterState{
+				Interfaces:            map[string]int{"antrea-gw0": 1, "antrea-tun0": 0, "eth1": 1},
+				VirtualServiceIPRoute: true,
+			},
+			sysctls: map[string]int{
+				"ipv4/conf/all/rp_filter":         1,
+				"ipv4/conf/antrea-gw0/rp_filter":  2,
+				"ipv4/conf/antrea-tun0/rp_filter": 2,
+			},
+			expectedCalls: func(mockNetlink *netlinktest.MockInterfaceMockRecorder) {
+				mockNetlink.RouteDel(virtualServiceIPRoute)
+				mockNetlink.NeighDel(virtualServiceIPNeigh)
+			},
+			expectedSysctl: map[string]int{
+				"ipv4/conf/all/rp_filter":         1,
+				"ipv4/conf/antrea-gw0/rp_filter":  1,
+				"ipv4/conf/antrea-tun0/rp_filter": 0,
+			},
+		},
+		{
+			name: "disable without existing exceptions",
+			sysctls: map[string]int{
+				"ipv4/conf/all/rp_filter":        1,
+				"ipv4/conf/antrea-gw0/rp_filter": 1,
+			},
+			expectedSysctl: map[string]int{
+				"ipv4/conf/all/rp_filter":        1,
+				"ipv4/conf/antrea-gw0/rp_filter": 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRPFilterSysctls(t, tt.sysctls)
+			if tt.existingState != nil {
+				require.NoError(t, saveRPFilterState(tt.existingState))
+			}
+			ctrl := gomock.NewController(t)
+			mockNetlink := netlinktest.NewMockInterface(ctrl)
+			c := &Client{
+				netlink: mockNetlink,
+				networkConfig: &config.NetworkConfig{
+					TrafficEncapMode:            config.TrafficEncapModeEncap,
+					IPv4Enabled:                 true,
+					EnableRPFilterExceptions:    tt.enable,
+					RPFilterExceptionInterfaces: tt.extraIfaces,
+				},
+				nodeConfig: nodeConfig,
+				proxyAll:   tt.proxyAll,
+			}
+			if tt.expectedCalls != nil {
+				tt.expectedCalls(mockNetlink.EXPECT())
+			}
+			require.NoError(t, c.initRPFilterExceptions())
+			assert.Equal(t, tt.expectedSysctl, tt.sysctls)
+			if tt.expectedState == nil {
+				assert.NoFileExists(t, rpFilterStateFile)
+			} else {
+				state, err := loadRPFilterState()
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedState, state)
+			}
+		})
+	}
+}
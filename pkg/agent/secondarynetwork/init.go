@@ -0,0 +1,122 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secondarynetwork
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/TomCodeLV/OVSDB-golang-lib/pkg/ovsdb"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	componentbaseconfig "k8s.io/component-base/config"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/cniserver"
+	"antrea.io/antrea/pkg/agent/secondarynetwork/cnipodcache"
+	"antrea.io/antrea/pkg/agent/secondarynetwork/podwatch"
+	agentconfig "antrea.io/antrea/pkg/config/agent"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+	"antrea.io/antrea/pkg/util/k8s"
+)
+
+var (
+	// Declared for testing.
+	newOVSBridgeFn = func(bridgeName string, ovsDatapathType ovsconfig.OVSDatapathType, ovsdb *ovsdb.OVSDB) ovsconfig.OVSBridgeClient {
+		return ovsconfig.NewOVSBridge(bridgeName, ovsDatapathType, ovsdb)
+	}
+	interfaceByNameFn = net.InterfaceByName
+)
+
+// Initialize sets up the OVS bridge for VLAN secondary networks if one is configured, and starts the Pod controller
+// which configures the secondary network interfaces of the Pods with the k8s.v1.cni.cncf.io/networks annotation.
+func Initialize(
+	clientConnectionConfig componentbaseconfig.ClientConnectionConfiguration,
+	kubeAPIServerOverride string,
+	k8sClient clientset.Interface,
+	podInformer cache.SharedIndexInformer,
+	nodeName string,
+	podInfoStore cnipodcache.CNIPodInfoStore,
+	cniServer *cniserver.CNIServer,
+	secNetConfig *agentconfig.SecondaryNetworkConfig,
+	ovsdb *ovsdb.OVSDB,
+	stopCh <-chan struct{},
+) error {
+	ovsBridgeClient, err := createOVSBridge(secNetConfig.OVSBridges, ovsdb)
+	if err != nil {
+		return err
+	}
+
+	// Create the NetworkAttachmentDefinition client, which handles access to secondary network object definition from the API Server.
+	netAttachDefClient, err := k8s.CreateNetworkAttachDefClient(clientConnectionConfig, kubeAPIServerOverride)
+	if err != nil {
+		return fmt.Errorf("NetworkAttachmentDefinition client creation failed. %v", err)
+	}
+	// Create podController to handle secondary network configuration for Pods with k8s.v1.cni.cncf.io/networks Annotation defined.
+	podWatchController := podwatch.NewPodController(
+		k8sClient,
+		netAttachDefClient,
+		podInformer,
+		nodeName,
+		podInfoStore,
+		// safe to call given that cniServer.Initialize has been called already.
+		cniserver.NewSecondaryInterfaceConfigurator(cniServer.GetPodConfigurator(), ovsBridgeClient))
+	go podWatchController.Run(stopCh)
+	return nil
+}
+
+// createOVSBridge creates the OVS bridge for VLAN secondary networks and connects the physical interfaces to it. It
+// returns a nil OVSBridgeClient if no bridge is configured.
+func createOVSBridge(bridges []agentconfig.OVSBridgeConfig, ovsdb *ovsdb.OVSDB) (ovsconfig.OVSBridgeClient, error) {
+	if len(bridges) == 0 {
+		return nil, nil
+	}
+	// Only one OVS bridge is supported, which is checked when validating the agent configuration.
+	bridgeConfig := bridges[0]
+	for _, phyInterface := range bridgeConfig.PhysicalInterfaces {
+		if _, err := interfaceByNameFn(phyInterface); err != nil {
+			return nil, fmt.Errorf("failed to get interface %s: %v", phyInterface, err)
+		}
+	}
+
+	ovsBridgeClient := newOVSBridgeFn(bridgeConfig.BridgeName, ovsconfig.OVSDatapathSystem, ovsdb)
+	if err := ovsBridgeClient.Create(); err != nil {
+		return nil, fmt.Errorf("failed to create OVS bridge %s: %v", bridgeConfig.BridgeName, err)
+	}
+	klog.InfoS("OVS bridge created for secondary networks", "bridge", bridgeConfig.BridgeName)
+
+	if len(bridgeConfig.PhysicalInterfaces) == 0 {
+		return ovsBridgeClient, nil
+	}
+	ports, err := ovsBridgeClient.GetPortList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OVS ports of bridge %s: %v", bridgeConfig.BridgeName, err)
+	}
+	existingPorts := make(map[string]bool, len(ports))
+	for _, port := range ports {
+		existingPorts[port.Name] = true
+	}
+	for _, phyInterface := range bridgeConfig.PhysicalInterfaces {
+		if existingPorts[phyInterface] {
+			continue
+		}
+		// The physical interface is connected as a trunk port, which carries the traffic of all the VLAN networks.
+		if _, err := ovsBridgeClient.CreateUplinkPort(phyInterface, 0, nil); err != nil {
+			return nil, fmt.Errorf("failed to connect interface %s to OVS bridge %s: %v", phyInterface, bridgeConfig.BridgeName, err)
+		}
+		klog.InfoS("Physical interface connected to OVS bridge", "interface", phyInterface, "bridge", bridgeConfig.BridgeName)
+	}
+	return ovsBridgeClient, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secondarynetwork
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/TomCodeLV/OVSDB-golang-lib/pkg/ovsdb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	agentconfig "antrea.io/antrea/pkg/config/agent"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+	ovsconfigtest "antrea.io/antrea/pkg/ovs/ovsconfig/testing"
+)
+
+func mockNewOVSBridge(t *testing.T, brClient ovsconfig.OVSBridgeClient) {
+	originalFn := newOVSBridgeFn
+	newOVSBridgeFn = func(bridgeName string, ovsDatapathType ovsconfig.OVSDatapathType, ovsdb *ovsdb.OVSDB) ovsconfig.OVSBridgeClient {
+		return brClient
+	}
+	t.Cleanup(func() { newOVSBridgeFn = originalFn })
+}
+
+func mockInterfaceByName(t *testing.T, interfaces ...string) {
+	originalFn := interfaceByNameFn
+	interfaceByNameFn = func(name string) (*net.Interface, error) {
+		for _, iface := range interfaces {
+			if iface == name {
+				return &net.Interface{Name: name}, nil
+			}
+		}
+		return nil, fmt.Errorf("no such network interface")
+	}
+	t.Cleanup(func() { interfaceByNameFn = originalFn })
+}
+
+func TestCreateOVSBridge(t *testing.T) {
+	tests := []struct {
+		name              string
+		bridges           []agentconfig.OVSBridgeConfig
+		existingPorts     []ovsconfig.OVSPortData
+		expectedCalls     func(m *ovsconfigtest.MockOVSBridgeClientMockRecorder)
+		expectedNilBridge bool
+		expectedErr       string
+	}{
+		{
+			name:              "no bridge",
+			expectedNilBridge: true,
+		},
+		{
+			name:    "bridge without physical interface",
+			bridges: []agentconfig.OVSBridgeConfig{{BridgeName: "br-secondary"}},
+			expectedCalls: func(m *ovsconfigtest.MockOVSBridgeClientMockRecorder) {
+				m.Create().Return(nil)
+			},
+		},
+		{
+			name:    "connect physical interface",
+			bridges: []agentconfig.OVSBridgeConfig{{BridgeName: "br-secondary", PhysicalInterfaces: []string{"eth1"}}},
+			expectedCalls: func(m *ovsconfigtest.MockOVSBridgeClientMockRecorder) {
+				m.Create().Return(nil)
+				m.GetPortList().Return(nil, nil)
+				m.CreateUplinkPort("eth1", int32(0), nil).Return("uuid1", nil)
+			},
+		},
+		{
+			name:    "physical interface already connected",
+			bridges: []agentconfig.OVSBridgeConfig{{BridgeName: "br-secondary", PhysicalInterfaces: []string{"eth1"}}},
+			expectedCalls: func(m *ovsconfigtest.MockOVSBridgeClientMockRecorder) {
+				m.Create().Return(nil)
+				m.GetPortList().Return([]ovsconfig.OVSPortData{{UUID: "uuid1", Name: "eth1"}}, nil)
+			},
+		},
+		{
+			name:              "physical interface not found",
+			bridges:           []agentconfig.OVSBridgeConfig{{BridgeName: "br-secondary", PhysicalInterfaces: []string{"eth2"}}},
+			expectedNilBridge: true,
+			expectedErr:       "failed to get interface eth2: no such network interface",
+		},
+		{
+			name:    "failed to create bridge",
+			bridges: []agentconfig.OVSBridgeConfig{{BridgeName: "br-secondary"}},
+			expectedCalls: func(m *ovsconfigtest.MockOVSBridgeClientMockRecorder) {
+				m.Create().Return(ovsconfig.NewTransactionError(fmt.Errorf("transaction failed"), false))
+			},
+			expectedNilBridge: true,
+			expectedErr:       "failed to create OVS bridge br-secondary: transaction failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBridge := ovsconfigtest.NewMockOVSBridgeClient(ctrl)
+			mockNewOVSBridge(t, mockBridge)
+			mockInterfaceByName(t, "eth1")
+			if tt.expectedCalls != nil {
+				tt.expectedCalls(mockBridge.EXPECT())
+			}
+			brClient, err := createOVSBridge(tt.bridges, nil)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.expectedNilBridge {
+				assert.Nil(t, brClient)
+			} else {
+				assert.Equal(t, mockBridge, brClient)
+			}
+		})
+	}
+}
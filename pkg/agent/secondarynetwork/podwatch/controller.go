@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"strings"
 	"sync"
 	"time"
@@ -72,10 +73,17 @@ var (
 type podSriovVFDeviceIDInfo struct {
 	vfDeviceID string
 	ifName     string
+	// hostIface is the VF interface in the host network namespace before it is moved to the container network
+	// namespace. It is nil if the VF has not been configured or has been released.
+	hostIface *current.Interface
+	vfConfig  *cniserver.SriovVFConfig
 }
 
 type InterfaceConfigurator interface {
-	ConfigureSriovSecondaryInterface(podName string, podNameSpace string, containerID string, containerNetNS string, containerIFDev string, mtu int, podSriovVFDeviceID string, result *current.Result) error
+	ConfigureSriovSecondaryInterface(podName string, podNameSpace string, containerID string, containerNetNS string, containerIFDev string, mtu int, podSriovVFDeviceID string, vfConfig *cniserver.SriovVFConfig, result *current.Result) error
+	ReleaseSriovSecondaryInterface(podSriovVFDeviceID string, hostIface *current.Interface, vfConfig *cniserver.SriovVFConfig) error
+	ConfigureVLANSecondaryInterface(podName string, podNamespace string, containerID string, containerNetNS string, containerIFDev string, mtu int, vlanID uint16, result *current.Result) error
+	DeleteVLANSecondaryInterface(podName, podNamespace, containerID, containerIFDev string) error
 }
 
 type PodController struct {
@@ -87,6 +95,7 @@ type PodController struct {
 	podCache              cnipodcache.CNIPodInfoStore
 	interfaceConfigurator InterfaceConfigurator
 	vfDeviceIDUsageMap    sync.Map
+	vlanNetworks          *vlanNetworkUsage
 }
 
 func NewPodController(
@@ -105,6 +114,7 @@ func NewPodController(
 		nodeName:              nodeName,
 		podCache:              podCache,
 		interfaceConfigurator: interfaceConfigurator,
+		vlanNetworks:          newVLANNetworkUsage(),
 	}
 	podInformer.AddEventHandlerWithResyncPeriod(
 		cache.ResourceEventHandlerFuncs{
@@ -148,6 +158,27 @@ func (pc *PodController) buildVFDeviceIDListPerPod(podName, podNamespace string)
 	return vfDeviceIDInfoCache, nil
 }
 
+// releaseVFDeviceIDListPerPod restores the configuration of all the VFs configured for a Pod. It returns an error if any
+// VF cannot be released yet, for example because the Pod's network namespace has not been deleted.
+func (pc *PodController) releaseVFDeviceIDListPerPod(podName, podNamespace string) error {
+	podKey := podNamespace + "/" + podName
+	deviceCache, cacheFound := pc.vfDeviceIDUsageMap.Load(podKey)
+	if !cacheFound {
+		return nil
+	}
+	cache := deviceCache.([]podSriovVFDeviceIDInfo)
+	for idx := range cache {
+		if cache[idx].hostIface == nil {
+			continue
+		}
+		if err := pc.interfaceConfigurator.ReleaseSriovSecondaryInterface(cache[idx].vfDeviceID, cache[idx].hostIface, cache[idx].vfConfig); err != nil {
+			return err
+		}
+		cache[idx].hostIface = nil
+	}
+	return nil
+}
+
 func (pc *PodController) deleteVFDeviceIDListPerPod(podName, podNamespace string) {
 	podKey := podNamespace + "/" + podName
 	_, cacheFound := pc.vfDeviceIDUsageMap.Load(podKey)
@@ -158,6 +189,24 @@ func (pc *PodController) deleteVFDeviceIDListPerPod(podName, podNamespace string
 	return
 }
 
+// setSriovVFHostInterface records the host interface and the configuration of a VF once it is configured for a Pod, so
+// that the VF can be released when the Pod is deleted.
+func (pc *PodController) setSriovVFHostInterface(podName, podNamespace, vfDeviceID string, hostIface *current.Interface, vfConfig *cniserver.SriovVFConfig) {
+	podKey := podNamespace + "/" + podName
+	deviceCache, cacheFound := pc.vfDeviceIDUsageMap.Load(podKey)
+	if !cacheFound {
+		return
+	}
+	cache := deviceCache.([]podSriovVFDeviceIDInfo)
+	for idx := range cache {
+		if cache[idx].vfDeviceID == vfDeviceID {
+			cache[idx].hostIface = hostIface
+			cache[idx].vfConfig = vfConfig
+			return
+		}
+	}
+}
+
 func (pc *PodController) assignUnusedSriovVFDeviceIDPerPod(podName, podNamespace, interfaceName string) (string, error) {
 	var cache []podSriovVFDeviceIDInfo
 	cache, err := pc.buildVFDeviceIDListPerPod(podName, podNamespace)
@@ -203,14 +252,23 @@ func whereaboutsArgsBuilder(cmd string, interfaceName string, podCNIInfo *cnipod
 
 }
 
-func removePodAllSecondaryNetwork(podCNIInfo *cnipodcache.CNIConfigInfo) error {
+func (pc *PodController) removePodAllSecondaryNetwork(podCNIInfo *cnipodcache.CNIConfigInfo) error {
 	var cmdArgs *invoke.Args
 	// Clean-up IPAM at whereabouts db (etcd or kubernetes API server) for all the secondary networks of the Pod which is getting removed.
 	// PluginArgs added to provide additional arguments required for whereabouts v0.5.1 and above.
-	// NOTE: SR-IOV VF interface clean-up, upon Pod delete will be handled by SR-IOV device plugin. Not handled here.
+	// NOTE: SR-IOV VFs are released by releaseVFDeviceIDListPerPod before the IPAM clean-up.
 	cmdArgs = whereaboutsArgsBuilder("DEL", "", podCNIInfo)
 	// example: podCNIInfo.NetworkConfig = {"eth1": net1-cniconfig, "eth2": net2-cniconfig}
 	for secNetInstIface, secNetInstConfig := range podCNIInfo.NetworkConfig {
+		var networkConfig SecondaryNetworkConfig
+		if err := json.Unmarshal(secNetInstConfig, &networkConfig); err == nil && networkConfig.NetworkType == vlanNetworkType {
+			// The host veth and the OVS port of the interface must be deleted explicitly, as the OVS port is not
+			// removed with the Pod's network namespace.
+			if err := pc.interfaceConfigurator.DeleteVLANSecondaryInterface(podCNIInfo.PodName, podCNIInfo.PodNameSpace, podCNIInfo.ContainerID, secNetInstIface); err != nil {
+				return fmt.Errorf("failed to delete VLAN secondary interface %s: %v", secNetInstIface, err)
+			}
+			pc.vlanNetworks.remove(vlanInterfaceKey(podCNIInfo.PodNameSpace, podCNIInfo.PodName, secNetInstIface))
+		}
 		cmdArgs.IfName = secNetInstIface
 		// Do DelIPAMSubnetAddress on network config (secNetInstConfig) and command argument (updated with interface name).
 		err := ipamDelegator.DelIPAMSubnetAddress(secNetInstConfig, cmdArgs)
@@ -293,9 +351,15 @@ func (pc *PodController) handleRemovePod(key string) error {
 	// Read the CNI info (stored during Pod creation by cniserver) from cache.
 	// Delete CNI info shared in cache for a specific Pod which is getting removed/deleted.
 	podCNIInfo := pc.podCache.GetAllCNIConfigInfoPerPod(pod[1], pod[0])
+	// Restore the VFs before the Pod specific VF cache is deleted. This fails and the Pod delete is requeued if the
+	// VFs are not returned to the host network namespace yet.
+	if err := pc.releaseVFDeviceIDListPerPod(pod[1], pod[0]); err != nil {
+		klog.ErrorS(err, "Failed to release SR-IOV VFs", "Pod", key)
+		return err
+	}
 	for _, containerInfo := range podCNIInfo {
 		// Release IPAM of all the secondary interfaces and delete CNI cache.
-		if err = removePodAllSecondaryNetwork(containerInfo); err != nil {
+		if err = pc.removePodAllSecondaryNetwork(containerInfo); err != nil {
 			// Return error to requeue pod delete.
 			return err
 		} else {
@@ -341,7 +405,11 @@ func (pc *PodController) processNextWorkItem() bool {
 }
 
 // Configure SRIOV VF as a Secondary Network Interface.
-func (pc *PodController) configureSriovAsSecondaryInterface(pod *corev1.Pod, network *netdefv1.NetworkSelectionElement, containerInfo *cnipodcache.CNIConfigInfo, result *current.Result) error {
+func (pc *PodController) configureSriovAsSecondaryInterface(pod *corev1.Pod, network *netdefv1.NetworkSelectionElement, containerInfo *cnipodcache.CNIConfigInfo, networkConfig *SecondaryNetworkConfig, result *current.Result) error {
+	vfConfig, err := sriovVFConfigFromNetwork(network, networkConfig)
+	if err != nil {
+		return err
+	}
 	podSriovVFDeviceID, err := pc.assignUnusedSriovVFDeviceIDPerPod(pod.Name, pod.Namespace, network.InterfaceRequest)
 	if err != nil {
 		return fmt.Errorf("getPodContainerDeviceIDs failed: %v", err)
@@ -355,15 +423,72 @@ func (pc *PodController) configureSriovAsSecondaryInterface(pod *corev1.Pod, net
 		network.InterfaceRequest,
 		containerInfo.MTU,
 		podSriovVFDeviceID,
+		vfConfig,
 		result,
 	); err != nil {
 		return fmt.Errorf("SRIOV Interface creation failed: %v", err)
 	}
+	if len(result.Interfaces) > 0 {
+		pc.setSriovVFHostInterface(pod.Name, pod.Namespace, podSriovVFDeviceID, result.Interfaces[0], vfConfig)
+	}
+	return nil
+}
+
+// sriovVFConfigFromNetwork builds the VF configuration from the MAC address requested in the Pod annotation and the
+// VLAN and trust parameters of the NetworkAttachmentDefinition.
+func sriovVFConfigFromNetwork(network *netdefv1.NetworkSelectionElement, networkConfig *SecondaryNetworkConfig) (*cniserver.SriovVFConfig, error) {
+	if networkConfig.VLAN < 0 || networkConfig.VLAN > 4094 {
+		return nil, fmt.Errorf("invalid VLAN ID %d", networkConfig.VLAN)
+	}
+	vfConfig := &cniserver.SriovVFConfig{
+		VLAN:  networkConfig.VLAN,
+		Trust: networkConfig.Trust,
+	}
+	if network.MacRequest != "" {
+		mac, err := net.ParseMAC(network.MacRequest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid MAC address %s: %v", network.MacRequest, err)
+		}
+		vfConfig.MAC = mac
+	}
+	return vfConfig, nil
+}
+
+// Configure VLAN secondary network interface, connected to the secondary OVS bridge.
+func (pc *PodController) configureVLANAsSecondaryInterface(pod *corev1.Pod, network *netdefv1.NetworkSelectionElement, containerInfo *cnipodcache.CNIConfigInfo, networkConfig *SecondaryNetworkConfig, result *current.Result) error {
+	if networkConfig.VLAN < 0 || networkConfig.VLAN > 4094 {
+		return fmt.Errorf("invalid VLAN ID %d", networkConfig.VLAN)
+	}
+	vlanID := uint16(networkConfig.VLAN)
+	mtu := containerInfo.MTU
+	if networkConfig.MTU > 0 {
+		mtu = networkConfig.MTU
+	}
+	// The network must be recorded before the interface is configured, so that concurrent workers cannot attach
+	// Pods to conflicting networks.
+	networkKey := network.Namespace + "/" + network.Name
+	interfaceKey := vlanInterfaceKey(pod.Namespace, pod.Name, network.InterfaceRequest)
+	if err := pc.vlanNetworks.add(networkKey, vlanID, networkConfig.Isolation, interfaceKey); err != nil {
+		return err
+	}
+	if err := pc.interfaceConfigurator.ConfigureVLANSecondaryInterface(
+		containerInfo.PodName,
+		containerInfo.PodNameSpace,
+		containerInfo.ContainerID,
+		containerInfo.ContainerNetNS,
+		network.InterfaceRequest,
+		mtu,
+		vlanID,
+		result,
+	); err != nil {
+		pc.vlanNetworks.remove(interfaceKey)
+		return fmt.Errorf("VLAN interface creation failed: %v", err)
+	}
 	return nil
 }
 
 // Configure Secondary Network Interface.
-func (pc *PodController) configureSecondaryInterface(pod *corev1.Pod, network *netdefv1.NetworkSelectionElement, podCNIInfo *cnipodcache.CNIConfigInfo, cniConfig []byte) error {
+func (pc *PodController) configureSecondaryInterface(pod *corev1.Pod, network *netdefv1.NetworkSelectionElement, podCNIInfo *cnipodcache.CNIConfigInfo, networkConfig *SecondaryNetworkConfig, cniConfig []byte) error {
 	// Generate and assign new interface name, If secondary interface name was not provided in Pod annotation.
 	if len(network.InterfaceRequest) == 0 {
 		var err error
@@ -386,9 +511,14 @@ func (pc *PodController) configureSecondaryInterface(pod *corev1.Pod, network *n
 	for _, ip := range result.IPs {
 		ip.Interface = current.Int(1)
 	}
-	// Configure SRIOV as a secondary network interface
-	if err := pc.configureSriovAsSecondaryInterface(pod, network, podCNIInfo, result); err != nil {
-		// SRIOV interface creation failed. Free allocated IP address
+	switch networkConfig.NetworkType {
+	case sriovNetworkType:
+		err = pc.configureSriovAsSecondaryInterface(pod, network, podCNIInfo, networkConfig, result)
+	case vlanNetworkType:
+		err = pc.configureVLANAsSecondaryInterface(pod, network, podCNIInfo, networkConfig, result)
+	}
+	if err != nil {
+		// Secondary interface creation failed. Free allocated IP address
 		if err := ipamDelegator.DelIPAMSubnetAddress(cniConfig, cmdArgs); err != nil {
 			klog.ErrorS(err, "IPAM de-allocation failed: ", err)
 		}
@@ -425,13 +555,13 @@ func (pc *PodController) configureSecondaryNetwork(pod *corev1.Pod, networklist
 			klog.InfoS("NetworkAttachmentDefinition is not of type 'antrea', ignoring", "NetworkAttachmentDefinition", klog.KObj(netDefCRD))
 			continue
 		}
-		if networkConfig.NetworkType != sriovNetworkType {
+		if networkConfig.NetworkType != sriovNetworkType && networkConfig.NetworkType != vlanNetworkType {
 			// same as above, if updated, we will not process the request again.
 			klog.ErrorS(err, "NetworkType not supported for Pod", "NetworkAttachmentDefinition", klog.KObj(netDefCRD), "Pod", klog.KObj(pod))
 			continue
 		}
 		// secondary network information retrieved from API server. Proceed to configure secondary interface now.
-		if err = pc.configureSecondaryInterface(pod, network, podCNIInfo, cniConfig); err != nil {
+		if err = pc.configureSecondaryInterface(pod, network, podCNIInfo, &networkConfig, cniConfig); err != nil {
 			// Secondary interface configuration failed. return error to re-queue and re-try.
 			return fmt.Errorf("secondary interface configuration failed: %v", err)
 		}
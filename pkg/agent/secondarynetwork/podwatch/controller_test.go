@@ -37,6 +37,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/util/workqueue"
 
+	"antrea.io/antrea/pkg/agent/cniserver"
 	"antrea.io/antrea/pkg/agent/secondarynetwork/cnipodcache"
 	ipamtesting "antrea.io/antrea/pkg/agent/secondarynetwork/ipam/testing"
 	podwatchtesting "antrea.io/antrea/pkg/agent/secondarynetwork/podwatch/testing"
@@ -187,6 +188,7 @@ func TestPodControllerRun(t *testing.T) {
 
 	ipamResult := testIPAMResult("148.14.24.100/24")
 
+	hostIface := &current.Interface{Name: "enp0s1v0", Mac: "aa:bb:cc:dd:ee:ff"}
 	var interfaceConfigured int32
 	interfaceConfigurator.EXPECT().ConfigureSriovSecondaryInterface(
 		podName,
@@ -196,8 +198,10 @@ func TestPodControllerRun(t *testing.T) {
 		interfaceName,
 		defaultMTU,
 		sriovDeviceID,
+		&cniserver.SriovVFConfig{},
 		ipamResult, // just check for pointer equality
-	).Do(func(string, string, string, string, string, int, string, *current.Result) {
+	).Do(func(_, _, _, _, _ string, _ int, _ string, _ *cniserver.SriovVFConfig, result *current.Result) {
+		result.Interfaces = []*current.Interface{hostIface}
 		atomic.AddInt32(&interfaceConfigured, 1)
 	})
 	mockIPAM.EXPECT().GetIPAMSubnetAddress(gomock.Any(), gomock.Any()).Return(ipamResult, nil)
@@ -221,7 +225,11 @@ func TestPodControllerRun(t *testing.T) {
 		return atomic.LoadInt32(&interfaceConfigured) > 0, nil
 	}))
 
-	mockIPAM.EXPECT().DelIPAMSubnetAddress(gomock.Any(), gomock.Any())
+	// the VF must be released before the IP address is returned to the IPAM.
+	gomock.InOrder(
+		interfaceConfigurator.EXPECT().ReleaseSriovSecondaryInterface(sriovDeviceID, hostIface, &cniserver.SriovVFConfig{}),
+		mockIPAM.EXPECT().DelIPAMSubnetAddress(gomock.Any(), gomock.Any()),
+	)
 
 	require.NotNil(t, podCache.GetCNIConfigInfoByContainerID(podName, testNamespace, containerID) == nil)
 	require.NoError(t, client.CoreV1().Pods(testNamespace).Delete(context.Background(), podName, metav1.DeleteOptions{}), "error when deleting test Pod")
@@ -251,6 +259,7 @@ func TestPodControllerAddPod(t *testing.T) {
 			nodeName:              testNode,
 			podCache:              podCache,
 			interfaceConfigurator: interfaceConfigurator,
+			vlanNetworks:          newVLANNetworkUsage(),
 		}, mockIPAM, interfaceConfigurator
 	}
 
@@ -297,6 +306,7 @@ func TestPodControllerAddPod(t *testing.T) {
 			defaultMTU,
 			gomock.Any(),
 			gomock.Any(),
+			gomock.Any(),
 		)
 		interfaceConfigurator.EXPECT().ConfigureSriovSecondaryInterface(
 			podName,
@@ -307,6 +317,7 @@ func TestPodControllerAddPod(t *testing.T) {
 			defaultMTU,
 			gomock.Any(),
 			gomock.Any(),
+			gomock.Any(),
 		)
 
 		mockIPAM.EXPECT().GetIPAMSubnetAddress(gomock.Any(), gomock.Any()).Return(testIPAMResult("148.14.24.100/24"), nil)
@@ -392,6 +403,7 @@ func TestPodControllerAddPod(t *testing.T) {
 			defaultMTU,
 			sriovDeviceID,
 			gomock.Any(),
+			gomock.Any(),
 		)
 		mockIPAM.EXPECT().GetIPAMSubnetAddress(gomock.Any(), gomock.Any()).Return(testIPAMResult("148.14.24.100/24"), nil)
 
@@ -403,6 +415,167 @@ func TestPodControllerAddPod(t *testing.T) {
 		assert.NoError(t, podController.handleAddUpdatePod(pod))
 	})
 
+	t.Run("VF configuration", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		podController, mockIPAM, interfaceConfigurator := newPodController(ctrl)
+
+		pod, cniConfig := testPod(podName, containerID, podIP, netdefv1.NetworkSelectionElement{
+			Name:             networkName,
+			InterfaceRequest: interfaceName,
+			MacRequest:       "aa:bb:cc:dd:ee:01",
+		})
+		network := testNetwork(networkName)
+		network.Spec.Config = `{
+    "cniVersion": "0.3.0",
+    "type": "antrea",
+    "networkType": "sriov",
+    "vlan": 100,
+    "trust": true,
+    "ipam": {
+        "type": "whereabouts",
+        "range": "148.14.24.0/24"
+    }
+}`
+		mac, _ := net.ParseMAC("aa:bb:cc:dd:ee:01")
+		hostIface := &current.Interface{Name: "enp0s1v0", Mac: "aa:bb:cc:dd:ee:ff"}
+
+		interfaceConfigurator.EXPECT().ConfigureSriovSecondaryInterface(
+			podName,
+			testNamespace,
+			containerID,
+			containerNetNs(containerID),
+			interfaceName,
+			defaultMTU,
+			sriovDeviceID,
+			&cniserver.SriovVFConfig{MAC: mac, VLAN: 100, Trust: true},
+			gomock.Any(),
+		).Do(func(_, _, _, _, _ string, _ int, _ string, _ *cniserver.SriovVFConfig, result *current.Result) {
+			result.Interfaces = []*current.Interface{hostIface}
+		})
+		mockIPAM.EXPECT().GetIPAMSubnetAddress(gomock.Any(), gomock.Any()).Return(testIPAMResult("148.14.24.100/24"), nil)
+
+		podController.podCache.AddCNIConfigInfo(cniConfig)
+		_, err := podController.kubeClient.CoreV1().Pods(testNamespace).Create(context.Background(), pod, metav1.CreateOptions{})
+		require.NoError(t, err, "error when creating test Pod")
+		_, err = podController.netAttachDefClient.NetworkAttachmentDefinitions(testNamespace).Create(context.Background(), network, metav1.CreateOptions{})
+		require.NoError(t, err, "error when creating test NetworkAttachmentDefinition")
+		require.NoError(t, podController.handleAddUpdatePod(pod))
+
+		// the VF is released with the original host interface and the same VF configuration.
+		interfaceConfigurator.EXPECT().ReleaseSriovSecondaryInterface(sriovDeviceID, hostIface, &cniserver.SriovVFConfig{MAC: mac, VLAN: 100, Trust: true}).Return(fmt.Errorf("VF not found"))
+		assert.Error(t, podController.releaseVFDeviceIDListPerPod(podName, testNamespace))
+		interfaceConfigurator.EXPECT().ReleaseSriovSecondaryInterface(sriovDeviceID, hostIface, gomock.Any())
+		assert.NoError(t, podController.releaseVFDeviceIDListPerPod(podName, testNamespace))
+		// a released VF is not released again.
+		assert.NoError(t, podController.releaseVFDeviceIDListPerPod(podName, testNamespace))
+	})
+
+	t.Run("VLAN network", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		podController, mockIPAM, interfaceConfigurator := newPodController(ctrl)
+
+		pod, cniConfig := testPod(podName, containerID, podIP, netdefv1.NetworkSelectionElement{
+			Name:             networkName,
+			InterfaceRequest: interfaceName,
+		})
+		network := testNetwork(networkName)
+		network.Spec.Config = `{
+    "cniVersion": "0.3.0",
+    "type": "antrea",
+    "networkType": "vlan",
+    "vlan": 100,
+    "isolation": true,
+    "mtu": 1400,
+    "ipam": {
+        "type": "whereabouts",
+        "range": "148.14.24.0/24"
+    }
+}`
+
+		interfaceConfigurator.EXPECT().ConfigureVLANSecondaryInterface(
+			podName,
+			testNamespace,
+			containerID,
+			containerNetNs(containerID),
+			interfaceName,
+			1400,
+			uint16(100),
+			gomock.Any(),
+		)
+		mockIPAM.EXPECT().GetIPAMSubnetAddress(gomock.Any(), gomock.Any()).Return(testIPAMResult("148.14.24.100/24"), nil)
+
+		podController.podCache.AddCNIConfigInfo(cniConfig)
+		_, err := podController.kubeClient.CoreV1().Pods(testNamespace).Create(context.Background(), pod, metav1.CreateOptions{})
+		require.NoError(t, err, "error when creating test Pod")
+		_, err = podController.netAttachDefClient.NetworkAttachmentDefinitions(testNamespace).Create(context.Background(), network, metav1.CreateOptions{})
+		require.NoError(t, err, "error when creating test NetworkAttachmentDefinition")
+		require.NoError(t, podController.handleAddUpdatePod(pod))
+
+		// another network cannot use the VLAN of the isolated network.
+		assert.Error(t, podController.vlanNetworks.add("nsB/net2", 100, false, vlanInterfaceKey("nsB", "pod2", "eth1")))
+
+		gomock.InOrder(
+			interfaceConfigurator.EXPECT().DeleteVLANSecondaryInterface(podName, testNamespace, containerID, interfaceName),
+			mockIPAM.EXPECT().DelIPAMSubnetAddress(gomock.Any(), gomock.Any()),
+		)
+		assert.NoError(t, podController.removePodAllSecondaryNetwork(cniConfig))
+		assert.Empty(t, podController.vlanNetworks.networks)
+	})
+
+	t.Run("VLAN network conflicting with isolated network", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		podController, mockIPAM, _ := newPodController(ctrl)
+		require.NoError(t, podController.vlanNetworks.add("nsB/net2", 100, true, vlanInterfaceKey("nsB", "pod2", "eth1")))
+
+		pod, cniConfig := testPod(podName, containerID, podIP, netdefv1.NetworkSelectionElement{
+			Name:             networkName,
+			InterfaceRequest: interfaceName,
+		})
+		network := testNetwork(networkName)
+		network.Spec.Config = `{
+    "cniVersion": "0.3.0",
+    "type": "antrea",
+    "networkType": "vlan",
+    "vlan": 100,
+    "ipam": {
+        "type": "whereabouts",
+        "range": "148.14.24.0/24"
+    }
+}`
+
+		mockIPAM.EXPECT().GetIPAMSubnetAddress(gomock.Any(), gomock.Any()).Return(testIPAMResult("148.14.24.100/24"), nil)
+		mockIPAM.EXPECT().DelIPAMSubnetAddress(gomock.Any(), gomock.Any())
+
+		podController.podCache.AddCNIConfigInfo(cniConfig)
+		_, err := podController.kubeClient.CoreV1().Pods(testNamespace).Create(context.Background(), pod, metav1.CreateOptions{})
+		require.NoError(t, err, "error when creating test Pod")
+		_, err = podController.netAttachDefClient.NetworkAttachmentDefinitions(testNamespace).Create(context.Background(), network, metav1.CreateOptions{})
+		require.NoError(t, err, "error when creating test NetworkAttachmentDefinition")
+		assert.Error(t, podController.handleAddUpdatePod(pod))
+	})
+
+	t.Run("invalid MAC address", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		podController, mockIPAM, _ := newPodController(ctrl)
+
+		pod, cniConfig := testPod(podName, containerID, podIP, netdefv1.NetworkSelectionElement{
+			Name:             networkName,
+			InterfaceRequest: interfaceName,
+			MacRequest:       "invalid-mac",
+		})
+		network := testNetwork(networkName)
+
+		mockIPAM.EXPECT().GetIPAMSubnetAddress(gomock.Any(), gomock.Any()).Return(testIPAMResult("148.14.24.100/24"), nil)
+		mockIPAM.EXPECT().DelIPAMSubnetAddress(gomock.Any(), gomock.Any())
+
+		podController.podCache.AddCNIConfigInfo(cniConfig)
+		_, err := podController.kubeClient.CoreV1().Pods(testNamespace).Create(context.Background(), pod, metav1.CreateOptions{})
+		require.NoError(t, err, "error when creating test Pod")
+		_, err = podController.netAttachDefClient.NetworkAttachmentDefinitions(testNamespace).Create(context.Background(), network, metav1.CreateOptions{})
+		require.NoError(t, err, "error when creating test NetworkAttachmentDefinition")
+		assert.Error(t, podController.handleAddUpdatePod(pod))
+	})
+
 	t.Run("no interface name", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		podController, mockIPAM, interfaceConfigurator := newPodController(ctrl)
@@ -431,6 +604,7 @@ func TestPodControllerAddPod(t *testing.T) {
 			defaultMTU,
 			gomock.Any(),
 			gomock.Any(),
+			gomock.Any(),
 		)
 		interfaceConfigurator.EXPECT().ConfigureSriovSecondaryInterface(
 			podName,
@@ -441,6 +615,7 @@ func TestPodControllerAddPod(t *testing.T) {
 			defaultMTU,
 			gomock.Any(),
 			gomock.Any(),
+			gomock.Any(),
 		)
 
 		mockIPAM.EXPECT().GetIPAMSubnetAddress(gomock.Any(), gomock.Any()).Return(testIPAMResult("148.14.24.100/24"), nil)
@@ -469,6 +644,7 @@ func TestPodControllerAddPod(t *testing.T) {
 			defaultMTU,
 			gomock.Any(),
 			gomock.Any(),
+			gomock.Any(),
 		).Return(fmt.Errorf("error when creating interface"))
 
 		mockIPAM.EXPECT().GetIPAMSubnetAddress(gomock.Any(), gomock.Any()).Return(testIPAMResult("148.14.24.100/24"), nil)
@@ -20,6 +20,7 @@
 package testing
 
 import (
+	cniserver "antrea.io/antrea/pkg/agent/cniserver"
 	types100 "github.com/containernetworking/cni/pkg/types/100"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
@@ -49,15 +50,57 @@ func (m *MockInterfaceConfigurator) EXPECT() *MockInterfaceConfiguratorMockRecor
 }
 
 // ConfigureSriovSecondaryInterface mocks base method
-func (m *MockInterfaceConfigurator) ConfigureSriovSecondaryInterface(arg0, arg1, arg2, arg3, arg4 string, arg5 int, arg6 string, arg7 *types100.Result) error {
+func (m *MockInterfaceConfigurator) ConfigureSriovSecondaryInterface(arg0, arg1, arg2, arg3, arg4 string, arg5 int, arg6 string, arg7 *cniserver.SriovVFConfig, arg8 *types100.Result) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ConfigureSriovSecondaryInterface", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret := m.ctrl.Call(m, "ConfigureSriovSecondaryInterface", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ConfigureSriovSecondaryInterface indicates an expected call of ConfigureSriovSecondaryInterface
-func (mr *MockInterfaceConfiguratorMockRecorder) ConfigureSriovSecondaryInterface(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+func (mr *MockInterfaceConfiguratorMockRecorder) ConfigureSriovSecondaryInterface(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureSriovSecondaryInterface", reflect.TypeOf((*MockInterfaceConfigurator)(nil).ConfigureSriovSecondaryInterface), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureSriovSecondaryInterface", reflect.TypeOf((*MockInterfaceConfigurator)(nil).ConfigureSriovSecondaryInterface), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+}
+
+// ConfigureVLANSecondaryInterface mocks base method
+func (m *MockInterfaceConfigurator) ConfigureVLANSecondaryInterface(arg0, arg1, arg2, arg3, arg4 string, arg5 int, arg6 uint16, arg7 *types100.Result) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfigureVLANSecondaryInterface", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfigureVLANSecondaryInterface indicates an expected call of ConfigureVLANSecondaryInterface
+func (mr *MockInterfaceConfiguratorMockRecorder) ConfigureVLANSecondaryInterface(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigureVLANSecondaryInterface", reflect.TypeOf((*MockInterfaceConfigurator)(nil).ConfigureVLANSecondaryInterface), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
+
+// DeleteVLANSecondaryInterface mocks base method
+func (m *MockInterfaceConfigurator) DeleteVLANSecondaryInterface(arg0, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVLANSecondaryInterface", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVLANSecondaryInterface indicates an expected call of DeleteVLANSecondaryInterface
+func (mr *MockInterfaceConfiguratorMockRecorder) DeleteVLANSecondaryInterface(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVLANSecondaryInterface", reflect.TypeOf((*MockInterfaceConfigurator)(nil).DeleteVLANSecondaryInterface), arg0, arg1, arg2, arg3)
+}
+
+// ReleaseSriovSecondaryInterface mocks base method
+func (m *MockInterfaceConfigurator) ReleaseSriovSecondaryInterface(arg0 string, arg1 *types100.Interface, arg2 *cniserver.SriovVFConfig) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseSriovSecondaryInterface", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseSriovSecondaryInterface indicates an expected call of ReleaseSriovSecondaryInterface
+func (mr *MockInterfaceConfiguratorMockRecorder) ReleaseSriovSecondaryInterface(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseSriovSecondaryInterface", reflect.TypeOf((*MockInterfaceConfigurator)(nil).ReleaseSriovSecondaryInterface), arg0, arg1, arg2)
 }
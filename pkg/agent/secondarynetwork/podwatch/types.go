@@ -29,6 +29,7 @@ type IPAMConfig struct {
 
 const (
 	sriovNetworkType = "sriov"
+	vlanNetworkType  = "vlan"
 )
 
 type SecondaryNetworkConfig struct {
@@ -36,7 +37,16 @@ type SecondaryNetworkConfig struct {
 	Name       string `json:"name,omitempty"`
 	// Set type to "antrea"
 	Type string `json:"type,omitempty"`
-	// Set networkType to "sriov"
-	NetworkType string     `json:"networkType,omitempty"`
-	IPAM        IPAMConfig `json:"ipam,omitempty"`
+	// Set networkType to "sriov" or "vlan"
+	NetworkType string `json:"networkType,omitempty"`
+	// VLAN ID of the SR-IOV VF, or of the OVS port for a VLAN network. The traffic is not tagged if it is not set.
+	VLAN int `json:"vlan,omitempty"`
+	// Set trust to true to enable the trusted mode of the SR-IOV VF.
+	Trust bool `json:"trust,omitempty"`
+	// Set isolation to true to ensure that, on a VLAN network, Pods can only talk to Pods attached to the same
+	// network. It requires a non-zero VLAN ID which is not used by any other network.
+	Isolation bool `json:"isolation,omitempty"`
+	// MTU of the interface for a VLAN network. The MTU of the primary network is used if it is not set.
+	MTU  int        `json:"mtu,omitempty"`
+	IPAM IPAMConfig `json:"ipam,omitempty"`
 }
@@ -0,0 +1,98 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podwatch
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+type vlanNetworkInfo struct {
+	vlanID   uint16
+	isolated bool
+	// interfaces are the Pod interfaces attached to the network, in the format <Pod namespace>/<Pod name>/<interface>.
+	interfaces sets.Set[string]
+}
+
+// vlanNetworkUsage records the VLAN networks which have Pod interfaces attached to the secondary OVS bridge of the
+// Node, to enforce the isolation of the networks which require it. Pods attached to an isolated network can only talk
+// to each other as long as no other network uses the same VLAN ID. Networks with VLAN 0 are not allowed either, as
+// their Pod interfaces are connected to trunk ports which receive the traffic of all VLANs.
+type vlanNetworkUsage struct {
+	mutex sync.Mutex
+	// networks maps the <namespace>/<name> of the NetworkAttachmentDefinitions to their usage.
+	networks map[string]*vlanNetworkInfo
+}
+
+func newVLANNetworkUsage() *vlanNetworkUsage {
+	return &vlanNetworkUsage{networks: map[string]*vlanNetworkInfo{}}
+}
+
+// add records that a Pod interface is attached to a VLAN network. It returns an error, and doesn't record the
+// interface, if the network or another network using the same VLAN ID requires isolation.
+func (u *vlanNetworkUsage) add(network string, vlanID uint16, isolated bool, interfaceKey string) error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	if isolated && vlanID == 0 {
+		return fmt.Errorf("isolated network %s must have a non-zero VLAN ID", network)
+	}
+	for name, info := range u.networks {
+		if name == network {
+			if info.vlanID != vlanID {
+				return fmt.Errorf("network %s is already used with VLAN %d", network, info.vlanID)
+			}
+			continue
+		}
+		if info.vlanID != vlanID && info.vlanID != 0 && vlanID != 0 {
+			continue
+		}
+		if isolated {
+			return fmt.Errorf("isolated network %s conflicts with network %s using VLAN %d", network, name, info.vlanID)
+		}
+		if info.isolated {
+			return fmt.Errorf("network %s conflicts with isolated network %s using VLAN %d", network, name, info.vlanID)
+		}
+	}
+	info, ok := u.networks[network]
+	if !ok {
+		info = &vlanNetworkInfo{vlanID: vlanID, isolated: isolated, interfaces: sets.New[string]()}
+		u.networks[network] = info
+	}
+	info.interfaces.Insert(interfaceKey)
+	return nil
+}
+
+// remove deletes a Pod interface from the network it is attached to, and deletes the network once no interface is
+// attached to it anymore.
+func (u *vlanNetworkUsage) remove(interfaceKey string) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	for name, info := range u.networks {
+		if !info.interfaces.Has(interfaceKey) {
+			continue
+		}
+		info.interfaces.Delete(interfaceKey)
+		if info.interfaces.Len() == 0 {
+			delete(u.networks, name)
+		}
+		return
+	}
+}
+
+func vlanInterfaceKey(podNamespace, podName, interfaceName string) string {
+	return podNamespace + "/" + podName + "/" + interfaceName
+}
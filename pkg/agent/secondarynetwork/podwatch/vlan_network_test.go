@@ -0,0 +1,113 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podwatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVLANNetworkUsage(t *testing.T) {
+	type network struct {
+		name     string
+		vlanID   uint16
+		isolated bool
+	}
+	tests := []struct {
+		name        string
+		existing    []network
+		add         network
+		expectedErr string
+	}{
+		{
+			name:     "shared VLAN",
+			existing: []network{{name: "ns/net1", vlanID: 100}},
+			add:      network{name: "ns/net2", vlanID: 100},
+		},
+		{
+			name:     "different VLANs",
+			existing: []network{{name: "ns/net1", vlanID: 100, isolated: true}},
+			add:      network{name: "ns/net2", vlanID: 200, isolated: true},
+		},
+		{
+			name:     "same isolated network",
+			existing: []network{{name: "ns/net1", vlanID: 100, isolated: true}},
+			add:      network{name: "ns/net1", vlanID: 100, isolated: true},
+		},
+		{
+			name:        "isolated network without VLAN",
+			add:         network{name: "ns/net1", isolated: true},
+			expectedErr: "isolated network ns/net1 must have a non-zero VLAN ID",
+		},
+		{
+			name:        "isolated network sharing VLAN",
+			existing:    []network{{name: "ns/net1", vlanID: 100}},
+			add:         network{name: "ns/net2", vlanID: 100, isolated: true},
+			expectedErr: "isolated network ns/net2 conflicts with network ns/net1 using VLAN 100",
+		},
+		{
+			name:        "network sharing VLAN of isolated network",
+			existing:    []network{{name: "ns/net1", vlanID: 100, isolated: true}},
+			add:         network{name: "ns/net2", vlanID: 100},
+			expectedErr: "network ns/net2 conflicts with isolated network ns/net1 using VLAN 100",
+		},
+		{
+			name:        "untagged network with isolated network",
+			existing:    []network{{name: "ns/net1", vlanID: 100, isolated: true}},
+			add:         network{name: "ns/net2"},
+			expectedErr: "network ns/net2 conflicts with isolated network ns/net1 using VLAN 100",
+		},
+		{
+			name:        "isolated network with untagged network",
+			existing:    []network{{name: "ns/net1"}},
+			add:         network{name: "ns/net2", vlanID: 100, isolated: true},
+			expectedErr: "isolated network ns/net2 conflicts with network ns/net1 using VLAN 0",
+		},
+		{
+			name:        "network VLAN changed",
+			existing:    []network{{name: "ns/net1", vlanID: 100}},
+			add:         network{name: "ns/net1", vlanID: 200},
+			expectedErr: "network ns/net1 is already used with VLAN 100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newVLANNetworkUsage()
+			for i, n := range tt.existing {
+				assert.NoError(t, u.add(n.name, n.vlanID, n.isolated, vlanInterfaceKey("ns", "pod"+string(rune('a'+i)), "eth1")))
+			}
+			err := u.add(tt.add.name, tt.add.vlanID, tt.add.isolated, vlanInterfaceKey("ns", "pod", "eth2"))
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVLANNetworkUsageRemove(t *testing.T) {
+	u := newVLANNetworkUsage()
+	assert.NoError(t, u.add("ns/net1", 100, false, vlanInterfaceKey("ns", "pod1", "eth1")))
+	assert.NoError(t, u.add("ns/net1", 100, false, vlanInterfaceKey("ns", "pod2", "eth1")))
+	assert.Error(t, u.add("ns/net2", 100, true, vlanInterfaceKey("ns", "pod3", "eth1")))
+
+	u.remove(vlanInterfaceKey("ns", "pod1", "eth1"))
+	assert.Error(t, u.add("ns/net2", 100, true, vlanInterfaceKey("ns", "pod3", "eth1")), "network should be kept until all its interfaces are removed")
+	u.remove(vlanInterfaceKey("ns", "pod2", "eth1"))
+	assert.Empty(t, u.networks)
+	assert.NoError(t, u.add("ns/net2", 100, true, vlanInterfaceKey("ns", "pod3", "eth1")))
+}
@@ -16,6 +16,7 @@ package stats
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -47,6 +48,12 @@ type statsCollection struct {
 	antreaClusterNetworkPolicyStats map[types.UID]map[string]*statsv1alpha1.TrafficStats
 	// antreaNetworkPolicyStats is a mapping from Antrea NetworkPolicy UIDs to their traffic stats.
 	antreaNetworkPolicyStats map[types.UID]map[string]*statsv1alpha1.TrafficStats
+	// antreaClusterNetworkPolicyFQDNStats is a mapping from Antrea ClusterNetworkPolicy UIDs to the match counts of
+	// the FQDNs of their rules, keyed by rule names and then by FQDNs.
+	antreaClusterNetworkPolicyFQDNStats map[types.UID]map[string]map[string]int64
+	// antreaNetworkPolicyFQDNStats is a mapping from Antrea NetworkPolicy UIDs to the match counts of the FQDNs of
+	// their rules, keyed by rule names and then by FQDNs.
+	antreaNetworkPolicyFQDNStats map[types.UID]map[string]map[string]int64
 	// multicastGroups is a map that encodes the list of Pods that has joined the multicast group.
 	multicastGroups map[string][]cpv1beta.PodReference
 }
@@ -136,15 +143,44 @@ func (m *Collector) collect() *statsCollection {
 			addRuleStatsUp(annpStatsMap, ruleStats, rule)
 		}
 	}
+	acnpFQDNStatsMap := map[types.UID]map[string]map[string]int64{}
+	annpFQDNStatsMap := map[types.UID]map[string]map[string]int64{}
+	for _, ruleMatches := range m.networkPolicyQuerier.GetFQDNRuleMatches() {
+		switch ruleMatches.PolicyRef.Type {
+		case cpv1beta.AntreaClusterNetworkPolicy:
+			addFQDNStats(acnpFQDNStatsMap, ruleMatches)
+		case cpv1beta.AntreaNetworkPolicy:
+			addFQDNStats(annpFQDNStatsMap, ruleMatches)
+		}
+	}
 	var multicastGroupMap map[string][]cpv1beta.PodReference
 	if m.multicastEnabled {
 		multicastGroupMap = m.multicastQuerier.GetGroupPods()
 	}
 	return &statsCollection{
-		networkPolicyStats:              npStatsMap,
-		antreaClusterNetworkPolicyStats: acnpStatsMap,
-		antreaNetworkPolicyStats:        annpStatsMap,
-		multicastGroups:                 multicastGroupMap,
+		networkPolicyStats:                  npStatsMap,
+		antreaClusterNetworkPolicyStats:     acnpStatsMap,
+		antreaNetworkPolicyStats:            annpStatsMap,
+		antreaClusterNetworkPolicyFQDNStats: acnpFQDNStatsMap,
+		antreaNetworkPolicyFQDNStats:        annpFQDNStatsMap,
+		multicastGroups:                     multicastGroupMap,
+	}
+}
+
+func addFQDNStats(fqdnStatsMap map[types.UID]map[string]map[string]int64, ruleMatches agenttypes.FQDNRuleMatches) {
+	ruleFQDNStats, exists := fqdnStatsMap[ruleMatches.PolicyRef.UID]
+	if !exists {
+		ruleFQDNStats = make(map[string]map[string]int64)
+		fqdnStatsMap[ruleMatches.PolicyRef.UID] = ruleFQDNStats
+	}
+	fqdnStats, fqdnStatsExists := ruleFQDNStats[ruleMatches.RuleName]
+	if !fqdnStatsExists {
+		fqdnStats = make(map[string]int64, len(ruleMatches.Matches))
+		ruleFQDNStats[ruleMatches.RuleName] = fqdnStats
+	}
+	// Add the counts up in case multiple rules of the policy share the same name.
+	for fqdn, matches := range ruleMatches.Matches {
+		fqdnStats[fqdn] += matches
 	}
 }
 
@@ -175,6 +211,7 @@ func addUp(stats *statsv1alpha1.TrafficStats, inc *agenttypes.RuleMetric) {
 	stats.Sessions += int64(inc.Sessions)
 	stats.Packets += int64(inc.Packets)
 	stats.Bytes += int64(inc.Bytes)
+	stats.DroppedPackets += int64(inc.DroppedPackets)
 }
 
 func isIdenticalMulticastGroupMap(a, b map[string][]cpv1beta.PodReference) bool {
@@ -208,9 +245,73 @@ func (m *Collector) calculateNPStats(curStatsCollection *statsCollection) (npSta
 	npStats = calculateDiff(curStatsCollection.networkPolicyStats, m.lastStatsCollection.networkPolicyStats)
 	acnpStats = calculateRuleDiff(curStatsCollection.antreaClusterNetworkPolicyStats, m.lastStatsCollection.antreaClusterNetworkPolicyStats)
 	annpStats = calculateRuleDiff(curStatsCollection.antreaNetworkPolicyStats, m.lastStatsCollection.antreaNetworkPolicyStats)
+	acnpStats = mergeFQDNStats(acnpStats, calculateFQDNDiff(curStatsCollection.antreaClusterNetworkPolicyFQDNStats, m.lastStatsCollection.antreaClusterNetworkPolicyFQDNStats))
+	annpStats = mergeFQDNStats(annpStats, calculateFQDNDiff(curStatsCollection.antreaNetworkPolicyFQDNStats, m.lastStatsCollection.antreaNetworkPolicyFQDNStats))
 	return npStats, acnpStats, annpStats
 }
 
+// mergeFQDNStats merges the FQDN match counts of rules into the rule traffic stats of the policies. A rule which has
+// FQDN matches but no traffic is added with empty traffic stats.
+func mergeFQDNStats(statsList []cpv1beta.NetworkPolicyStats, fqdnStatsMap map[types.UID]map[string][]statsv1alpha1.FQDNStats) []cpv1beta.NetworkPolicyStats {
+	uidIndexMap := make(map[types.UID]int, len(statsList))
+	for i, stats := range statsList {
+		uidIndexMap[stats.NetworkPolicy.UID] = i
+	}
+	for uid, ruleFQDNStats := range fqdnStatsMap {
+		index, exists := uidIndexMap[uid]
+		if !exists {
+			statsList = append(statsList, cpv1beta.NetworkPolicyStats{NetworkPolicy: cpv1beta.NetworkPolicyReference{UID: uid}})
+			index = len(statsList) - 1
+		}
+		policyStats := &statsList[index]
+		for ruleName, fqdnStats := range ruleFQDNStats {
+			merged := false
+			for i := range policyStats.RuleTrafficStats {
+				if policyStats.RuleTrafficStats[i].Name == ruleName {
+					policyStats.RuleTrafficStats[i].FQDNStats = fqdnStats
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				policyStats.RuleTrafficStats = append(policyStats.RuleTrafficStats, statsv1alpha1.RuleTrafficStats{Name: ruleName, FQDNStats: fqdnStats})
+			}
+		}
+	}
+	return statsList
+}
+
+// calculateFQDNDiff calculates the delta of the FQDN match counts of the rules. Rules and FQDNs without new matches are
+// omitted.
+func calculateFQDNDiff(curStatsMap, lastStatsMap map[types.UID]map[string]map[string]int64) map[types.UID]map[string][]statsv1alpha1.FQDNStats {
+	diffMap := make(map[types.UID]map[string][]statsv1alpha1.FQDNStats)
+	for uid, curRuleStats := range curStatsMap {
+		for ruleName, curFQDNStats := range curRuleStats {
+			lastFQDNStats := lastStatsMap[uid][ruleName]
+			var fqdnStats []statsv1alpha1.FQDNStats
+			for fqdn, curMatches := range curFQDNStats {
+				matches := curMatches
+				// curMatches < lastMatches could happen as rules with same name can be deleted and recreated later.
+				if lastMatches, exists := lastFQDNStats[fqdn]; exists && curMatches >= lastMatches {
+					matches = curMatches - lastMatches
+				}
+				if matches != 0 {
+					fqdnStats = append(fqdnStats, statsv1alpha1.FQDNStats{FQDN: fqdn, Matches: matches})
+				}
+			}
+			if len(fqdnStats) == 0 {
+				continue
+			}
+			sort.Slice(fqdnStats, func(i, j int) bool { return fqdnStats[i].FQDN < fqdnStats[j].FQDN })
+			if _, exists := diffMap[uid]; !exists {
+				diffMap[uid] = make(map[string][]statsv1alpha1.FQDNStats)
+			}
+			diffMap[uid][ruleName] = fqdnStats
+		}
+	}
+	return diffMap
+}
+
 func (m *Collector) calculateNodeStatsSummary(curStatsCollection *statsCollection) *cpv1beta.NodeStatsSummary {
 	var multicastGroups []cpv1beta.MulticastGroupInfo
 	multicastGroupsUpdated := false
@@ -328,9 +429,10 @@ func calculateRuleDiff(curStatsMap, lastStatsMap map[types.UID]map[string]*stats
 					ruleTrafficStats := statsv1alpha1.RuleTrafficStats{
 						Name: name,
 						TrafficStats: statsv1alpha1.TrafficStats{
-							Bytes:    curRuleStats.Bytes - lastRuleStats.Bytes,
-							Sessions: curRuleStats.Sessions - lastRuleStats.Sessions,
-							Packets:  curRuleStats.Packets - lastRuleStats.Packets,
+							Bytes:          curRuleStats.Bytes - lastRuleStats.Bytes,
+							Sessions:       curRuleStats.Sessions - lastRuleStats.Sessions,
+							Packets:        curRuleStats.Packets - lastRuleStats.Packets,
+							DroppedPackets: curRuleStats.DroppedPackets - lastRuleStats.DroppedPackets,
 						},
 					}
 					stats = append(stats, ruleTrafficStats)
@@ -62,6 +62,7 @@ func TestCollect(t *testing.T) {
 		name                    string
 		ruleStats               map[uint32]*agenttypes.RuleMetric
 		ofIDToPolicyMap         map[uint32]*agenttypes.PolicyRule
+		fqdnRuleMatches         []agenttypes.FQDNRuleMatches
 		expectedStatsCollection *statsCollection
 	}{
 		{
@@ -101,8 +102,10 @@ func TestCollect(t *testing.T) {
 						Sessions: 3,
 					},
 				},
-				antreaClusterNetworkPolicyStats: map[types.UID]map[string]*statsv1alpha1.TrafficStats{},
-				antreaNetworkPolicyStats:        map[types.UID]map[string]*statsv1alpha1.TrafficStats{},
+				antreaClusterNetworkPolicyStats:     map[types.UID]map[string]*statsv1alpha1.TrafficStats{},
+				antreaNetworkPolicyStats:            map[types.UID]map[string]*statsv1alpha1.TrafficStats{},
+				antreaClusterNetworkPolicyFQDNStats: map[types.UID]map[string]map[string]int64{},
+				antreaNetworkPolicyFQDNStats:        map[types.UID]map[string]map[string]int64{},
 			},
 		},
 		{
@@ -155,6 +158,49 @@ func TestCollect(t *testing.T) {
 						},
 					},
 				},
+				antreaClusterNetworkPolicyFQDNStats: map[types.UID]map[string]map[string]int64{},
+				antreaNetworkPolicyFQDNStats:        map[types.UID]map[string]map[string]int64{},
+			},
+		},
+		{
+			name: "FQDN rules",
+			ruleStats: map[uint32]*agenttypes.RuleMetric{
+				1: {
+					Bytes:    15,
+					Packets:  2,
+					Sessions: 1,
+				},
+			},
+			ofIDToPolicyMap: map[uint32]*agenttypes.PolicyRule{
+				1: {Name: "rule1", PolicyRef: &acnp1},
+			},
+			fqdnRuleMatches: []agenttypes.FQDNRuleMatches{
+				{PolicyRef: &acnp1, RuleName: "rule1", Matches: map[string]int64{"*.example.com": 3, "www.antrea.io": 0}},
+				{PolicyRef: &annp1, RuleName: "rule2", Matches: map[string]int64{"www.antrea.io": 2}},
+				{PolicyRef: &annp1, RuleName: "rule2", Matches: map[string]int64{"www.antrea.io": 1}},
+			},
+			expectedStatsCollection: &statsCollection{
+				networkPolicyStats: map[types.UID]*statsv1alpha1.TrafficStats{},
+				antreaClusterNetworkPolicyStats: map[types.UID]map[string]*statsv1alpha1.TrafficStats{
+					acnp1.UID: {
+						"rule1": {
+							Bytes:    15,
+							Packets:  2,
+							Sessions: 1,
+						},
+					},
+				},
+				antreaNetworkPolicyStats: map[types.UID]map[string]*statsv1alpha1.TrafficStats{},
+				antreaClusterNetworkPolicyFQDNStats: map[types.UID]map[string]map[string]int64{
+					acnp1.UID: {
+						"rule1": {"*.example.com": 3, "www.antrea.io": 0},
+					},
+				},
+				antreaNetworkPolicyFQDNStats: map[types.UID]map[string]map[string]int64{
+					annp1.UID: {
+						"rule2": {"www.antrea.io": 3},
+					},
+				},
 			},
 		},
 		{
@@ -183,8 +229,10 @@ func TestCollect(t *testing.T) {
 						Sessions: 1,
 					},
 				},
-				antreaClusterNetworkPolicyStats: map[types.UID]map[string]*statsv1alpha1.TrafficStats{},
-				antreaNetworkPolicyStats:        map[types.UID]map[string]*statsv1alpha1.TrafficStats{},
+				antreaClusterNetworkPolicyStats:     map[types.UID]map[string]*statsv1alpha1.TrafficStats{},
+				antreaNetworkPolicyStats:            map[types.UID]map[string]*statsv1alpha1.TrafficStats{},
+				antreaClusterNetworkPolicyFQDNStats: map[types.UID]map[string]map[string]int64{},
+				antreaNetworkPolicyFQDNStats:        map[types.UID]map[string]map[string]int64{},
 			},
 		},
 	}
@@ -197,6 +245,7 @@ func TestCollect(t *testing.T) {
 			for ofID, policy := range tt.ofIDToPolicyMap {
 				npQuerier.EXPECT().GetRuleByFlowID(ofID).Return(policy)
 			}
+			npQuerier.EXPECT().GetFQDNRuleMatches().Return(tt.fqdnRuleMatches)
 
 			m := &Collector{ofClient: ofClient, networkPolicyQuerier: npQuerier, multicastQuerier: mcQuerier}
 			actualPolicyStats := m.collect()
@@ -837,3 +886,76 @@ func TestCalculateRuleDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateNPStatsWithFQDNStats(t *testing.T) {
+	lastStatsCollection := &statsCollection{
+		antreaClusterNetworkPolicyStats: map[types.UID]map[string]*statsv1alpha1.TrafficStats{
+			"uid1": {
+				"rule1": {Bytes: 10, Packets: 1, Sessions: 1},
+			},
+		},
+		antreaClusterNetworkPolicyFQDNStats: map[types.UID]map[string]map[string]int64{
+			"uid1": {
+				"rule1": {"*.example.com": 2, "www.antrea.io": 1},
+			},
+			"uid2": {
+				"rule3": {"www.antrea.io": 5},
+			},
+		},
+	}
+	curStatsCollection := &statsCollection{
+		antreaClusterNetworkPolicyStats: map[types.UID]map[string]*statsv1alpha1.TrafficStats{
+			"uid1": {
+				"rule1": {Bytes: 30, Packets: 3, Sessions: 2},
+			},
+		},
+		antreaClusterNetworkPolicyFQDNStats: map[types.UID]map[string]map[string]int64{
+			"uid1": {
+				"rule1": {"*.example.com": 5, "www.antrea.io": 1},
+				"rule2": {"www.example.com": 1},
+			},
+			// The rule was recreated and its counts were reset.
+			"uid2": {
+				"rule3": {"www.antrea.io": 2},
+			},
+			// No new matches since the last collection.
+			"uid3": {
+				"rule4": {"www.antrea.io": 0},
+			},
+		},
+	}
+	expectedACNPStats := []cpv1beta.NetworkPolicyStats{
+		{
+			NetworkPolicy: cpv1beta.NetworkPolicyReference{UID: "uid1"},
+			RuleTrafficStats: []statsv1alpha1.RuleTrafficStats{
+				{
+					Name:         "rule1",
+					TrafficStats: statsv1alpha1.TrafficStats{Bytes: 20, Packets: 2, Sessions: 1},
+					FQDNStats:    []statsv1alpha1.FQDNStats{{FQDN: "*.example.com", Matches: 3}},
+				},
+				{
+					Name:      "rule2",
+					FQDNStats: []statsv1alpha1.FQDNStats{{FQDN: "www.example.com", Matches: 1}},
+				},
+			},
+		},
+		{
+			NetworkPolicy: cpv1beta.NetworkPolicyReference{UID: "uid2"},
+			RuleTrafficStats: []statsv1alpha1.RuleTrafficStats{
+				{
+					Name:      "rule3",
+					FQDNStats: []statsv1alpha1.FQDNStats{{FQDN: "www.antrea.io", Matches: 2}},
+				},
+			},
+		},
+	}
+	m := &Collector{lastStatsCollection: lastStatsCollection}
+	_, acnpStats, annpStats := m.calculateNPStats(curStatsCollection)
+	for _, v := range acnpStats {
+		sort.SliceStable(v.RuleTrafficStats, func(i, j int) bool {
+			return v.RuleTrafficStats[i].Name < v.RuleTrafficStats[j].Name
+		})
+	}
+	assert.ElementsMatch(t, expectedACNPStats, acnpStats)
+	assert.Empty(t, annpStats)
+}
@@ -268,6 +268,18 @@ func (c *SupportBundleController) generateSupportBundle(supportBundle *cpv1b2.Su
 	if err = agentDumper.DumpOVSPorts(basedir); err != nil {
 		return err
 	}
+	if err = agentDumper.DumpOVSGroups(basedir); err != nil {
+		return err
+	}
+	if err = agentDumper.DumpOVSMeters(basedir); err != nil {
+		return err
+	}
+	if err = agentDumper.DumpProxierState(basedir); err != nil {
+		return err
+	}
+	if err = agentDumper.DumpNetworkPolicyRealization(basedir); err != nil {
+		return err
+	}
 
 	outputFile, err := afero.TempFile(defaultFS, "", "bundle_*.tar.gz")
 	if err != nil {
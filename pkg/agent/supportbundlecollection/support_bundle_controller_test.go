@@ -32,6 +32,7 @@ import (
 	agentquerier "antrea.io/antrea/pkg/agent/querier"
 	"antrea.io/antrea/pkg/apis/controlplane"
 	cpv1b2 "antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	cpstreamv1alpha1 "antrea.io/antrea/pkg/apis/controlplanestream/v1alpha1"
 	"antrea.io/antrea/pkg/client/clientset/versioned"
 	fakeversioned "antrea.io/antrea/pkg/client/clientset/versioned/fake"
 	"antrea.io/antrea/pkg/ovs/ovsctl"
@@ -52,6 +53,10 @@ func (g *antreaClientGetter) GetAntreaClient() (versioned.Interface, error) {
 	return g.clientset, nil
 }
 
+func (g *antreaClientGetter) GetControlplaneStreamClient() (cpstreamv1alpha1.ControlplaneStreamClient, error) {
+	return nil, fmt.Errorf("controlplane stream is not supported")
+}
+
 func newFakeController(t *testing.T) (*fakeController, *fakeversioned.Clientset) {
 	controller := gomock.NewController(t)
 	clientset := &fakeversioned.Clientset{}
@@ -216,14 +221,18 @@ func generateSupportbundleCollection(name string, url string) *cpv1b2.SupportBun
 }
 
 type mockAgentDumper struct {
-	dumpLogErr                    error
-	dumpFlowsErr                  error
-	dumpHostNetworkInfoErr        error
-	dumpAgentInfoErr              error
-	dumpNetworkPolicyResourcesErr error
-	dumpHeapPprofErr              error
-	dumpOVSPortsErr               error
-	dumpMemberlistErr             error
+	dumpLogErr                      error
+	dumpFlowsErr                    error
+	dumpHostNetworkInfoErr          error
+	dumpAgentInfoErr                error
+	dumpNetworkPolicyResourcesErr   error
+	dumpHeapPprofErr                error
+	dumpOVSPortsErr                 error
+	dumpOVSGroupsErr                error
+	dumpOVSMetersErr                error
+	dumpProxierStateErr             error
+	dumpNetworkPolicyRealizationErr error
+	dumpMemberlistErr               error
 }
 
 func (d *mockAgentDumper) DumpLog(basedir string) error {
@@ -254,6 +263,22 @@ func (d *mockAgentDumper) DumpOVSPorts(basedir string) error {
 	return d.dumpOVSPortsErr
 }
 
+func (d *mockAgentDumper) DumpOVSGroups(basedir string) error {
+	return d.dumpOVSGroupsErr
+}
+
+func (d *mockAgentDumper) DumpOVSMeters(basedir string) error {
+	return d.dumpOVSMetersErr
+}
+
+func (d *mockAgentDumper) DumpProxierState(basedir string) error {
+	return d.dumpProxierStateErr
+}
+
+func (d *mockAgentDumper) DumpNetworkPolicyRealization(basedir string) error {
+	return d.dumpNetworkPolicyRealizationErr
+}
+
 func (d *mockAgentDumper) DumpMemberlist(basedir string) error {
 	return d.dumpMemberlistErr
 }
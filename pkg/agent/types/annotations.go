@@ -27,6 +27,51 @@ const (
 	// NodeMaxEgressIPsAnnotationKey represents the key of maximum Egress IP number in the Annotations of the Node.
 	NodeMaxEgressIPsAnnotationKey string = "node.antrea.io/max-egress-ips"
 
+	// NodeTunnelTypeAnnotationKey represents the key of the tunnel type to use for the tunnels to and from the Node in
+	// the Annotations of the Node. It overrides the tunnelType configuration of antrea-agent for the Node.
+	NodeTunnelTypeAnnotationKey string = "node.antrea.io/tunnel-type"
+
+	// NodeTunnelPortAnnotationKey represents the key of the tunnel destination port to use for the tunnels to and from
+	// the Node in the Annotations of the Node. It overrides the tunnelPort configuration of antrea-agent for the Node.
+	NodeTunnelPortAnnotationKey string = "node.antrea.io/tunnel-port"
+
 	// ServiceExternalIPPoolAnnotationKey is the key of the Service annotation that specifies the Service's desired external IP pool.
 	ServiceExternalIPPoolAnnotationKey string = "service.antrea.io/external-ip-pool"
+
+	// ServiceSessionAffinityClientIPPrefixLengthAnnotationKey is the key of the Service annotation that specifies the
+	// prefix length of the client IPs on which the ClientIP session affinity is based.
+	ServiceSessionAffinityClientIPPrefixLengthAnnotationKey string = "service.antrea.io/session-affinity-client-ip-prefix-length"
+
+	// ServiceSessionAffinityMatchDstPortAnnotationKey is the key of the Service annotation that specifies whether the
+	// destination port is part of the ClientIP session affinity.
+	ServiceSessionAffinityMatchDstPortAnnotationKey string = "service.antrea.io/session-affinity-match-destination-port"
+
+	// ServicePortRangeAnnotationKey is the key of the Service annotation that specifies a contiguous range of ports,
+	// e.g. "30000-30100", for the Service port whose port is the start of the range. The connections to any port of
+	// the range are load balanced to the same port of the Endpoints.
+	ServicePortRangeAnnotationKey string = "service.antrea.io/port-range"
+
+	// ServiceLoadBalancerModeAnnotationKey is the key of the Service annotation that specifies how the connections to
+	// the external addresses of the Service are load balanced, either "nat" (the default) or "dsr".
+	ServiceLoadBalancerModeAnnotationKey string = "service.antrea.io/load-balancer-mode"
+
+	// EndpointSliceEndpointWeightsAnnotationKey is the key of the EndpointSlice annotation that specifies the weights
+	// of its Endpoints when load balancing the traffic of the Service, as a JSON object mapping Endpoint addresses to
+	// weights.
+	EndpointSliceEndpointWeightsAnnotationKey string = "service.antrea.io/endpoint-weights"
+
+	// NamespaceEgressDefaultDenyAnnotationKey is the key of the Namespace annotation that specifies whether the traffic
+	// from the Namespace's Pods to the external network is dropped, unless it is SNAT'd by an Egress or allowed by an
+	// Antrea-native policy. It takes effect only when Egress default deny is enabled in the agent configuration.
+	NamespaceEgressDefaultDenyAnnotationKey string = "egress.antrea.io/default-deny-external"
+
+	// NamespaceFlowExportAnnotationKey is the key of the Namespace annotation that specifies whether the connections of
+	// the Namespace's Pods are exported by the FlowExporter. "true" or "false" overrides the Namespaces included in or
+	// excluded from export by the agent configuration.
+	NamespaceFlowExportAnnotationKey string = "flowexporter.antrea.io/export"
+
+	// NamespaceNeighborSpoofGuardAnnotationKey is the key of the Namespace annotation that specifies whether the ARP
+	// and NDP packets from the Namespace's Pods are checked against the Pods' IP and MAC addresses. Setting it to
+	// "false" opts the Namespace out. It takes effect only when the NeighborSpoofGuard feature is enabled.
+	NamespaceNeighborSpoofGuardAnnotationKey string = "spoofguard.antrea.io/neighbor"
 )
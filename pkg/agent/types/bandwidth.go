@@ -0,0 +1,25 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// PodBandwidth is the bandwidth limits of a Pod, as requested with the kubernetes.io/ingress-bandwidth and
+// kubernetes.io/egress-bandwidth annotations. The rates are in bits per second and the bursts are in bits. A zero rate
+// means that the traffic in the direction is not limited.
+type PodBandwidth struct {
+	IngressRate  uint64
+	IngressBurst uint64
+	EgressRate   uint64
+	EgressBurst  uint64
+}
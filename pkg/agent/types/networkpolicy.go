@@ -15,6 +15,9 @@
 package types
 
 import (
+	"net"
+	"time"
+
 	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
 	secv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
 	binding "antrea.io/antrea/pkg/ovs/openflow"
@@ -90,6 +93,7 @@ type PolicyRule struct {
 	PolicyRef     *v1beta2.NetworkPolicyReference
 	EnableLogging bool
 	LogLabel      string
+	RateLimit     *v1beta2.RateLimit
 }
 
 // IsAntreaNetworkPolicyRule returns if a PolicyRule is created for Antrea NetworkPolicy types.
@@ -142,12 +146,15 @@ func (bp ByPriority) Less(i, j int) bool { return bp[i].Less(bp[j]) }
 
 type RuleMetric struct {
 	Bytes, Packets, Sessions uint64
+	// DroppedPackets is the number of packets dropped by the rate limit of the rule.
+	DroppedPackets uint64
 }
 
 func (m *RuleMetric) Merge(m1 *RuleMetric) {
 	m.Bytes += m1.Bytes
 	m.Packets += m1.Packets
 	m.Sessions += m1.Sessions
+	m.DroppedPackets += m1.DroppedPackets
 }
 
 // A BitRange is a representation of a range of values from base value with a
@@ -156,3 +163,66 @@ type BitRange struct {
 	Value uint16
 	Mask  *uint16
 }
+
+// DNSCacheEntry describes the IP addresses a FQDN selected by FQDN policy rules is resolved to.
+type DNSCacheEntry struct {
+	FQDN           string
+	IPAddresses    []net.IP
+	ExpirationTime time.Time
+	// RuleIDs are the IDs of the rules which have a FQDN selector matching the FQDN.
+	RuleIDs []string
+}
+
+// FQDNRuleMatches describes the number of DNS responses matched by the FQDNs of a policy rule.
+type FQDNRuleMatches struct {
+	PolicyRef *v1beta2.NetworkPolicyReference
+	RuleName  string
+	// Matches maps the FQDNs as specified in the rule to the number of DNS responses whose
+	// queried name matched them, since the rule was realized on the Node.
+	Matches map[string]int64
+}
+
+// NetworkPolicyRuleSummary summarizes a rule in the NetworkPolicy rule cache of the agent and
+// whether it can be realized. It is used to dump the NetworkPolicy realization state, e.g. in
+// support bundles.
+type NetworkPolicyRuleSummary struct {
+	ID              string   `json:"id"`
+	Policy          string   `json:"policy"`
+	Name            string   `json:"name,omitempty"`
+	Direction       string   `json:"direction"`
+	Priority        int32    `json:"priority"`
+	AppliedToGroups []string `json:"appliedToGroups,omitempty"`
+	AddressGroups   []string `json:"addressGroups,omitempty"`
+	// Effective is true if the rule applies to any member on the Node.
+	Effective bool `json:"effective"`
+	// Realizable is true if all the groups referenced by the rule have been received.
+	Realizable bool `json:"realizable"`
+	// NumTargetMembers and NumAddresses are the numbers of members of the applied-to groups and
+	// of the address groups of the rule. They are only set if the rule is realizable.
+	NumTargetMembers int `json:"numTargetMembers,omitempty"`
+	NumAddresses     int `json:"numAddresses,omitempty"`
+}
+
+// PolicySimulationStage is the result of evaluating a flow in a stage of the NetworkPolicy
+// pipeline. The stages are named after the OVS tables implementing them.
+type PolicySimulationStage struct {
+	Stage string `json:"stage"`
+	// Policy and Rule identify the rule which decided the action of the stage. They are empty
+	// if no rule matched the flow in the stage.
+	Policy string `json:"policy,omitempty"`
+	Rule   string `json:"rule,omitempty"`
+	// Action is the action applied to the flow in the stage: Allow, Drop, Reject, Pass, or
+	// NoMatch if no rule matched the flow.
+	Action string `json:"action"`
+	// MatchedRules lists all the rules of the stage matching the flow, in the order of
+	// precedence, including the ones shadowed by the deciding rule.
+	MatchedRules []string `json:"matchedRules,omitempty"`
+}
+
+// PolicySimulationResult is the result of evaluating a flow against the NetworkPolicy rules in
+// the rule cache of the agent, without sending any packet.
+type PolicySimulationResult struct {
+	Stages []PolicySimulationStage `json:"stages"`
+	// Verdict is the final action applied to the flow: Allow, Drop or Reject.
+	Verdict string `json:"verdict"`
+}
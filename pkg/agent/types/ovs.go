@@ -0,0 +1,28 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// OVSSoftLimits are the numbers of OpenFlow entries above which a warning is
+// logged and reported in metrics. They are not enforced: entries are still
+// installed after a soft limit is exceeded. A zero limit disables the
+// corresponding check.
+type OVSSoftLimits struct {
+	// FlowsPerTable is the number of flows in any single OVS flow table.
+	FlowsPerTable int
+	// Groups is the total number of OVS groups installed by the Agent.
+	Groups int
+	// Meters is the total number of OVS meters installed by the Agent.
+	Meters int
+}
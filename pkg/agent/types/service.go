@@ -0,0 +1,38 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// SessionAffinityKey describes the fields of the packets on which the ClientIP session affinity of a Service is based:
+// the packets with the same key are sent to the same Endpoint.
+type SessionAffinityKey struct {
+	// ClientIPPrefixLength is the length of the prefix of the client IP which is part of the key, so that the clients in
+	// the same subnet are sent to the same Endpoint. 0 means the full client IP.
+	ClientIPPrefixLength int
+	// IgnoreDstPort excludes the destination port from the key, so that the connections of a client to all the ports
+	// of the Service are sent to the same Endpoint.
+	IgnoreDstPort bool
+}
+
+// LoadBalancerMode is the mode in which the connections to the external addresses of a Service are load balanced.
+type LoadBalancerMode string
+
+const (
+	// LoadBalancerModeNAT DNATs the connections to the selected Endpoints, and SNATs them if the Endpoints are on
+	// other Nodes, so that the replies are sent back through the ingress Node.
+	LoadBalancerModeNAT LoadBalancerMode = "nat"
+	// LoadBalancerModeDSR forwards the connections to the Nodes of the selected Endpoints through the tunnel without
+	// NAT, so that the client IPs are preserved and the replies are sent to the clients directly (Direct Server Return).
+	LoadBalancerModeDSR LoadBalancerMode = "dsr"
+)
@@ -0,0 +1,33 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"net"
+
+	binding "antrea.io/antrea/pkg/ovs/openflow"
+)
+
+// TrafficMirrorFilter is the parsed form of a TrafficMirror filter, for a single IP family. Fields which are not set
+// match any value.
+type TrafficMirrorFilter struct {
+	// Protocol is the OpenFlow protocol to match, e.g. ProtocolTCP or ProtocolIPv6. It determines the IP family of the
+	// filter and must always be set.
+	Protocol         binding.Protocol
+	SourceIPNet      *net.IPNet
+	DestinationIPNet *net.IPNet
+	SourcePort       uint16
+	DestinationPort  uint16
+}
@@ -37,6 +37,8 @@ var memberPattern = regexp.MustCompile("(?m)^(.*\n)*Members:\n")
 type Interface interface {
 	CreateIPSet(name string, setType SetType, isIPv6 bool) error
 
+	DestroyIPSet(name string) error
+
 	AddEntry(name string, entry string) error
 
 	DelEntry(name string, entry string) error
@@ -68,6 +70,18 @@ func (c *Client) CreateIPSet(name string, setType SetType, isIPv6 bool) error {
 	return nil
 }
 
+// DestroyIPSet destroys the set, it will ignore error when the set doesn't exist.
+func (c *Client) DestroyIPSet(name string) error {
+	output, err := exec.Command("ipset", "destroy", name).CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(output), "does not exist") {
+			return nil
+		}
+		return fmt.Errorf("error destroying ipset %s: %v", name, err)
+	}
+	return nil
+}
+
 // AddEntry adds a new entry to the set, it will ignore error when the entry already exists.
 func (c *Client) AddEntry(name string, entry string) error {
 	cmd := exec.Command("ipset", "add", name, entry, "-exist")
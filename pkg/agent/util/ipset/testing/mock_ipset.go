@@ -90,6 +90,20 @@ func (mr *MockInterfaceMockRecorder) DelEntry(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelEntry", reflect.TypeOf((*MockInterface)(nil).DelEntry), arg0, arg1)
 }
 
+// DestroyIPSet mocks base method
+func (m *MockInterface) DestroyIPSet(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DestroyIPSet", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DestroyIPSet indicates an expected call of DestroyIPSet
+func (mr *MockInterfaceMockRecorder) DestroyIPSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyIPSet", reflect.TypeOf((*MockInterface)(nil).DestroyIPSet), arg0)
+}
+
 // ListEntries mocks base method
 func (m *MockInterface) ListEntries(arg0 string) ([]string, error) {
 	m.ctrl.T.Helper()
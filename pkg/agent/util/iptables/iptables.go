@@ -44,8 +44,10 @@ const (
 	NoTrackTarget    = "NOTRACK"
 	SNATTarget       = "SNAT"
 	DNATTarget       = "DNAT"
+	RejectTarget     = "REJECT"
 
 	PreRoutingChain  = "PREROUTING"
+	InputChain       = "INPUT"
 	ForwardChain     = "FORWARD"
 	PostRoutingChain = "POSTROUTING"
 	OutputChain      = "OUTPUT"
@@ -49,6 +49,8 @@ const (
 
 // NeighborAdvertisement sends an unsolicited Neighbor Advertisement ICMPv6 multicast packet,
 // over interface 'iface' from 'srcIP', announcing a given IPv6 address('srcIP') to all IPv6 nodes as per RFC4861.
+// The packet is sent to the link-local all-nodes multicast address with the index of 'iface' as the zone, so that it
+// is sent over 'iface' instead of the default multicast interface of the Node.
 func NeighborAdvertisement(srcIP net.IP, iface *net.Interface) error {
 	if !utilnet.IsIPv6(srcIP) {
 		return fmt.Errorf("invalid IPv6 address: %v", srcIP)
@@ -65,11 +67,16 @@ func NeighborAdvertisement(srcIP net.IP, iface *net.Interface) error {
 	}
 	defer syscall.Close(sockInet6)
 
-	syscall.SetsockoptInt(sockInet6, syscall.IPPROTO_IPV6, syscall.IPV6_MULTICAST_HOPS, hopLimit)
+	if err := syscall.SetsockoptInt(sockInet6, syscall.IPPROTO_IPV6, syscall.IPV6_MULTICAST_HOPS, hopLimit); err != nil {
+		return fmt.Errorf("failed to set multicast hop limit: %v", err)
+	}
+	if err := syscall.SetsockoptInt(sockInet6, syscall.IPPROTO_IPV6, syscall.IPV6_MULTICAST_IF, iface.Index); err != nil {
+		return fmt.Errorf("failed to set multicast interface to %s: %v", iface.Name, err)
+	}
 
 	var r [16]byte
 	copy(r[:], net.IPv6linklocalallnodes.To16())
-	toSockAddrInet6 := syscall.SockaddrInet6{Addr: r}
+	toSockAddrInet6 := syscall.SockaddrInet6{Addr: r, ZoneId: uint32(iface.Index)}
 	if err := syscall.Sendto(sockInet6, mb, 0, &toSockAddrInet6); err != nil {
 		return err
 	}
@@ -41,6 +41,10 @@ const (
 	FamilyIPv6 uint8 = 6
 
 	bridgedUplinkSuffix = "~"
+
+	// NodeLocalDNSInterfaceName is the name of the dummy interface created by NodeLocal DNSCache on the Node, which is
+	// assigned the IPs NodeLocal DNSCache listens on.
+	NodeLocalDNSInterfaceName = "nodelocaldns"
 )
 
 var (
@@ -100,6 +104,14 @@ func GenerateContainerInterfaceName(podName, podNamespace, containerID string) s
 	return generateInterfaceName(containerID, podName, true)
 }
 
+// GenerateContainerSecondaryInterfaceName generates a unique interface name
+// for the host side of a Pod's secondary network interface, using the Pod's
+// name as the prefix, and the container ID and the container interface name
+// as the hashing key.
+func GenerateContainerSecondaryInterfaceName(podName, podNamespace, containerID, containerIfaceName string) string {
+	return generateInterfaceName(containerID+"/"+containerIfaceName, podName, true)
+}
+
 // GenerateNodeTunnelInterfaceName generates a unique interface name for the
 // tunnel to the Node, using the Node's name.
 func GenerateNodeTunnelInterfaceName(nodeName string) string {
@@ -351,12 +363,39 @@ func longestCommonPrefixLen(a, b net.IP) (cpl int) {
 // GetAllNodeAddresses gets all Node IP addresses (not including IPv6 link local address).
 func GetAllNodeAddresses(excludeDevices []string) ([]net.IP, []net.IP, error) {
 	var nodeAddressesIPv4, nodeAddressesIPv6 []net.IP
+	if err := visitNodeAddresses(excludeDevices, func(_ string, ip net.IP) {
+		if ip.To4() != nil {
+			nodeAddressesIPv4 = append(nodeAddressesIPv4, ip)
+		} else {
+			nodeAddressesIPv6 = append(nodeAddressesIPv6, ip)
+		}
+	}); err != nil {
+		return nil, nil, err
+	}
+	return nodeAddressesIPv4, nodeAddressesIPv6, nil
+}
+
+// GetNodeAddressesByInterface gets the Node IP addresses (not including IPv6 link local address) of
+// each interface, keyed by the interface name.
+func GetNodeAddressesByInterface(excludeDevices []string) (map[string][]net.IP, error) {
+	nodeAddresses := make(map[string][]net.IP)
+	if err := visitNodeAddresses(excludeDevices, func(ifaceName string, ip net.IP) {
+		nodeAddresses[ifaceName] = append(nodeAddresses[ifaceName], ip)
+	}); err != nil {
+		return nil, err
+	}
+	return nodeAddresses, nil
+}
+
+// visitNodeAddresses calls visit for every Node IP address (not including IPv6 link local address)
+// of the interfaces which are not excluded.
+func visitNodeAddresses(excludeDevices []string, visit func(ifaceName string, ip net.IP)) error {
 	_, ipv6LinkLocalNet, _ := net.ParseCIDR("fe80::/64")
 
 	// Get all interfaces.
 	interfaces, err := netInterfaces()
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
 	// Transform excludeDevices to a set
@@ -371,7 +410,7 @@ func GetAllNodeAddresses(excludeDevices []string) ([]net.IP, []net.IP, error) {
 		// Get all IPs of every interface
 		addrs, err := netInterfaceAddrs(&interfaces[i])
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
 
 		for _, addr := range addrs {
@@ -379,15 +418,10 @@ func GetAllNodeAddresses(excludeDevices []string) ([]net.IP, []net.IP, error) {
 			if ipv6LinkLocalNet.Contains(ip) {
 				continue // Skip IPv6 link local address
 			}
-
-			if ip.To4() != nil {
-				nodeAddressesIPv4 = append(nodeAddressesIPv4, ip)
-			} else {
-				nodeAddressesIPv6 = append(nodeAddressesIPv6, ip)
-			}
+			visit(interfaces[i].Name, ip)
 		}
 	}
-	return nodeAddressesIPv4, nodeAddressesIPv6, nil
+	return nil
 }
 
 // Copied from github.com/vishvananda/netlink/netlink.go
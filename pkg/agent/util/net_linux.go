@@ -23,6 +23,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -261,6 +262,28 @@ func HostInterfaceExists(ifName string) bool {
 	return false
 }
 
+// GetNodeLocalDNSIPs returns the IPs NodeLocal DNSCache listens on, i.e. the IPs assigned to the nodelocaldns
+// interface. Unlike GetAllIPNetsByName, link-local IPs are included, as NodeLocal DNSCache usually listens on a
+// link-local IP, e.g. 169.254.20.10. nil is returned if the interface doesn't exist.
+func GetNodeLocalDNSIPs() ([]net.IP, error) {
+	link, err := netlinkUtil.LinkByName(NodeLocalDNSInterfaceName)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	addrs, err := netlinkUtil.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, err
+	}
+	ips := make([]net.IP, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP)
+	}
+	return ips, nil
+}
+
 func GetInterfaceConfig(ifName string) (*net.Interface, []*net.IPNet, []interface{}, error) {
 	iface, err := netInterfaceByName(ifName)
 	if err != nil {
@@ -277,6 +300,25 @@ func GetInterfaceConfig(ifName string) (*net.Interface, []*net.IPNet, []interfac
 	return iface, addrs, routes, nil
 }
 
+// GetInterfaceNUMANode returns the NUMA node the PCI device backing the given
+// interface is attached to. An error is returned if the interface is not backed
+// by a PCI device or if the kernel does not report NUMA locality for it.
+func GetInterfaceNUMANode(ifName string) (int, error) {
+	path := filepath.Join("/sys/class/net", ifName, "device", "numa_node")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return -1, fmt.Errorf("failed to read NUMA node of interface %s: %v", ifName, err)
+	}
+	numaNode, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return -1, fmt.Errorf("invalid NUMA node %q for interface %s: %v", string(data), ifName, err)
+	}
+	if numaNode < 0 {
+		return -1, fmt.Errorf("NUMA locality is not reported for interface %s", ifName)
+	}
+	return numaNode, nil
+}
+
 func RenameInterface(from, to string) error {
 	klog.InfoS("Renaming interface", "oldName", from, "newName", to)
 	var renameErr error
@@ -479,6 +479,57 @@ func TestHostInterfaceExists(t *testing.T) {
 	}
 }
 
+func TestGetNodeLocalDNSIPs(t *testing.T) {
+	testLink := mockLink{name: NodeLocalDNSInterfaceName}
+	linkLocalIPNet := net.IPNet{IP: net.ParseIP("169.254.20.10"), Mask: net.CIDRMask(32, 32)}
+	tests := []struct {
+		name          string
+		expectedCalls func(mockNetlink *netlinktest.MockInterfaceMockRecorder)
+		wantIPs       []net.IP
+		wantErr       error
+	}{
+		{
+			name: "Interface Exists",
+			expectedCalls: func(mockNetlink *netlinktest.MockInterfaceMockRecorder) {
+				mockNetlink.LinkByName(NodeLocalDNSInterfaceName).Return(testLink, nil)
+				mockNetlink.AddrList(testLink, netlink.FAMILY_ALL).Return([]netlink.Addr{{IPNet: &linkLocalIPNet}, {IPNet: &ipv4PublicIPNet}}, nil)
+			},
+			wantIPs: []net.IP{linkLocalIPNet.IP, ipv4PublicIPNet.IP},
+		},
+		{
+			name: "Interface Not Exist",
+			expectedCalls: func(mockNetlink *netlinktest.MockInterfaceMockRecorder) {
+				mockNetlink.LinkByName(NodeLocalDNSInterfaceName).Return(nil, netlink.LinkNotFoundError{})
+			},
+		},
+		{
+			name: "Interface Fail",
+			expectedCalls: func(mockNetlink *netlinktest.MockInterfaceMockRecorder) {
+				mockNetlink.LinkByName(NodeLocalDNSInterfaceName).Return(nil, testInvalidErr)
+			},
+			wantErr: testInvalidErr,
+		},
+		{
+			name: "Addr List Err",
+			expectedCalls: func(mockNetlink *netlinktest.MockInterfaceMockRecorder) {
+				mockNetlink.LinkByName(NodeLocalDNSInterfaceName).Return(testLink, nil)
+				mockNetlink.AddrList(testLink, netlink.FAMILY_ALL).Return(nil, testInvalidErr)
+			},
+			wantErr: testInvalidErr,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer mockUtilNetlink(ctrl, tc.expectedCalls)()
+			gotIPs, gotErr := GetNodeLocalDNSIPs()
+			assert.Equal(t, tc.wantIPs, gotIPs)
+			assert.Equal(t, tc.wantErr, gotErr)
+		})
+	}
+}
+
 func TestGetInterfaceConfig(t *testing.T) {
 	routes := []netlink.Route{{
 		LinkIndex: 0,
@@ -70,6 +70,18 @@ func TestGenerateContainerInterfaceName(t *testing.T) {
 	}
 }
 
+func TestGenerateContainerSecondaryInterfaceName(t *testing.T) {
+	podNamespace := "namespace1"
+	podName := "pod1-abcde-12345"
+	containerID := "container0"
+	iface0 := GenerateContainerSecondaryInterfaceName(podName, podNamespace, containerID, "eth1")
+	assert.Len(t, iface0, interfaceNameLength)
+	assert.True(t, strings.HasPrefix(iface0, "pod1-abc"), "failed to use first 8 valid characters")
+	iface1 := GenerateContainerSecondaryInterfaceName(podName, podNamespace, containerID, "eth2")
+	assert.NotEqual(t, iface0, iface1, "failed to differentiate secondary interfaces of the same container")
+	assert.NotEqual(t, GenerateContainerInterfaceName(podName, podNamespace, containerID), iface0, "secondary interface name should not conflict with primary interface name")
+}
+
 func TestGetIPNetDeviceFromIP(t *testing.T) {
 	testNetInterfaces := generateNetInterfaces()
 	tests := []struct {
@@ -408,6 +420,46 @@ func TestGetAllNodeAddresses(t *testing.T) {
 	}
 }
 
+func TestGetNodeAddressesByInterface(t *testing.T) {
+	testNetInterfaces := generateNetInterfaces()
+	tests := []struct {
+		name                string
+		excludeDevices      []string
+		testNetInterfaceErr error
+		wantNodeAddrs       map[string][]net.IP
+	}{
+		{
+			name:           "All Node Addrs",
+			excludeDevices: []string{},
+			wantNodeAddrs: map[string][]net.IP{
+				"0": {ipv4Public},
+				"1": {ipv6Global},
+			},
+		},
+		{
+			name:           "Exclude Node Addrs",
+			excludeDevices: []string{"1"},
+			wantNodeAddrs: map[string][]net.IP{
+				"0": {ipv4Public},
+			},
+		},
+		{
+			name:                "Invalid",
+			testNetInterfaceErr: testInvalidErr,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer mockNetInterfaceGet(testNetInterfaces, tc.testNetInterfaceErr)()
+			defer mockNetInterfaceAddrsMultiple(testNetInterfaces, true, nil)()
+			gotNodeAddrs, gotErr := GetNodeAddressesByInterface(tc.excludeDevices)
+			assert.Equal(t, tc.wantNodeAddrs, gotNodeAddrs)
+			assert.Equal(t, tc.testNetInterfaceErr, gotErr)
+		})
+	}
+}
+
 func TestNewIPNet(t *testing.T) {
 	gotIPNet := NewIPNet(net.IPv4allrouter)
 	assert.Equal(t, net.IPv4allrouter.To4(), gotIPNet.IP.To4())
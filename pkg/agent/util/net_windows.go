@@ -49,6 +49,9 @@ const (
 
 	MetricDefault = 256
 	MetricHigh    = 50
+	// MetricNoEncap is used by the routes to peer Pod CIDRs installed on the OVS bridge interface in noEncap mode.
+	// The distinct metric makes it possible to identify these routes after an agent restart.
+	MetricNoEncap = 255
 
 	AntreaNatName = "antrea-nat"
 	LocalVMSwitch = "antrea-switch"
@@ -107,6 +110,20 @@ func (n NetNatStaticMapping) String() string {
 	return fmt.Sprintf("Name: %s, ExternalIP %s, ExternalPort: %d, InternalIP: %s, InternalPort: %d, Protocol: %s", n.Name, n.ExternalIP, n.ExternalPort, n.InternalIP, n.InternalPort, n.Protocol)
 }
 
+// PortProxy is a netsh port proxy, which relays the TCP connections to ListenIP:ListenPort to ConnectIP:ConnectPort.
+// Unlike NetNatStaticMapping, it applies to the connections originated from the host, including the ones to the loopback
+// addresses.
+type PortProxy struct {
+	ListenIP    net.IP
+	ListenPort  uint16
+	ConnectIP   net.IP
+	ConnectPort uint16
+}
+
+func (p PortProxy) String() string {
+	return fmt.Sprintf("ListenIP: %s, ListenPort: %d, ConnectIP: %s, ConnectPort: %d", p.ListenIP, p.ListenPort, p.ConnectIP, p.ConnectPort)
+}
+
 func GetNSPath(containerNetNS string) (string, error) {
 	return containerNetNS, nil
 }
@@ -609,6 +626,11 @@ func HostInterfaceExists(ifaceName string) bool {
 	return true
 }
 
+// GetNodeLocalDNSIPs always returns nil as NodeLocal DNSCache is not supported on Windows.
+func GetNodeLocalDNSIPs() ([]net.IP, error) {
+	return nil, nil
+}
+
 // SetInterfaceMTU configures interface MTU on host for Pods. MTU change cannot be realized with HNSEndpoint because
 // there's no MTU field in HNSEndpoint:
 // https://github.com/Microsoft/hcsshim/blob/4a468a6f7ae547974bc32911395c51fb1862b7df/internal/hns/hnsendpoint.go#L12
@@ -841,6 +863,24 @@ func RemoveNetNatStaticMappingByNAME(netNatName string) error {
 	return err
 }
 
+// ReplacePortProxy adds a port proxy, replacing the existing one listening on the same address and port.
+func ReplacePortProxy(proxy *PortProxy) error {
+	cmd := fmt.Sprintf("netsh interface portproxy add v4tov4 listenaddress=%s listenport=%d connectaddress=%s connectport=%d",
+		proxy.ListenIP, proxy.ListenPort, proxy.ConnectIP, proxy.ConnectPort)
+	_, err := runCommand(cmd)
+	return err
+}
+
+// RemovePortProxy removes the port proxy listening on the provided address and port. It doesn't return an error if the
+// port proxy doesn't exist.
+func RemovePortProxy(proxy *PortProxy) error {
+	cmd := fmt.Sprintf("netsh interface portproxy delete v4tov4 listenaddress=%s listenport=%d", proxy.ListenIP, proxy.ListenPort)
+	if _, err := runCommand(cmd); err != nil && !strings.Contains(err.Error(), "The system cannot find the file specified") {
+		return err
+	}
+	return nil
+}
+
 // GetNetNeighbor gets neighbor cache entries with Get-NetNeighbor.
 func GetNetNeighbor(neighbor *Neighbor) ([]Neighbor, error) {
 	cmd := fmt.Sprintf("Get-NetNeighbor -InterfaceIndex %d -IPAddress %s | Format-Table -HideTableHeaders", neighbor.LinkIndex, neighbor.IPAddress.String())
@@ -1092,6 +1132,11 @@ func isOVSExtensionEnabled() (bool, error) {
 	return parseOVSExtensionOutput(out), nil
 }
 
+// GetInterfaceNUMANode is not supported on Windows.
+func GetInterfaceNUMANode(ifName string) (int, error) {
+	return -1, fmt.Errorf("getting the NUMA node of an interface is not supported on Windows")
+}
+
 func renameHostInterface(oriName string, newName string) error {
 	cmd := fmt.Sprintf(`Get-NetAdapter -Name "%s" | Rename-NetAdapter -NewName "%s"`, oriName, newName)
 	_, err := runCommand(cmd)
@@ -762,6 +762,72 @@ func TestRemoveNetNatStaticMapping(t *testing.T) {
 	}
 }
 
+func TestReplacePortProxy(t *testing.T) {
+	testProxy := &PortProxy{
+		ListenIP:    net.ParseIP("127.0.0.1"),
+		ListenPort:  30001,
+		ConnectIP:   net.ParseIP("169.254.0.252"),
+		ConnectPort: 30001,
+	}
+	addCmd := "netsh interface portproxy add v4tov4 listenaddress=127.0.0.1 listenport=30001 connectaddress=169.254.0.252 connectport=30001"
+	tests := []struct {
+		name       string
+		commandErr error
+		wantErr    error
+	}{
+		{
+			name: "Add Port Proxy",
+		},
+		{
+			name:       "Add Port Proxy Err",
+			commandErr: testInvalidErr,
+			wantErr:    testInvalidErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer mockRunCommand(t, []string{addCmd}, "", tc.commandErr, true)()
+			gotErr := ReplacePortProxy(testProxy)
+			assert.Equal(t, tc.wantErr, gotErr)
+		})
+	}
+}
+
+func TestRemovePortProxy(t *testing.T) {
+	testProxy := &PortProxy{
+		ListenIP:    net.ParseIP("127.0.0.1"),
+		ListenPort:  30001,
+		ConnectIP:   net.ParseIP("169.254.0.252"),
+		ConnectPort: 30001,
+	}
+	deleteCmd := "netsh interface portproxy delete v4tov4 listenaddress=127.0.0.1 listenport=30001"
+	tests := []struct {
+		name       string
+		commandErr error
+		wantErr    error
+	}{
+		{
+			name: "Remove Port Proxy",
+		},
+		{
+			name:       "Port Proxy Not Found",
+			commandErr: fmt.Errorf("The system cannot find the file specified."),
+		},
+		{
+			name:       "Remove Port Proxy Err",
+			commandErr: testInvalidErr,
+			wantErr:    testInvalidErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer mockRunCommand(t, []string{deleteCmd}, "", tc.commandErr, true)()
+			gotErr := RemovePortProxy(testProxy)
+			assert.Equal(t, tc.wantErr, gotErr)
+		})
+	}
+}
+
 func TestReplaceNetNeighbor(t *testing.T) {
 	netNeighborNotFoundErr := fmt.Errorf("received error No matching MSFT_NetNeighbor objects")
 	testNeighbor := &Neighbor{
@@ -59,4 +59,12 @@ type Interface interface {
 	LinkSetName(link netlink.Link, name string) error
 
 	LinkSetUp(link netlink.Link) error
+
+	LinkSetVfHardwareAddr(link netlink.Link, vf int, hwaddr net.HardwareAddr) error
+
+	LinkSetVfVlan(link netlink.Link, vf, vlan int) error
+
+	LinkSetVfTrust(link netlink.Link, vf int, state bool) error
+
+	ConntrackDeleteFilter(table netlink.ConntrackTableType, family netlink.InetFamily, filter netlink.CustomConntrackFilter) (uint, error)
 }
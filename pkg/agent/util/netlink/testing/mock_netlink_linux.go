@@ -106,6 +106,21 @@ func (mr *MockInterfaceMockRecorder) AddrReplace(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddrReplace", reflect.TypeOf((*MockInterface)(nil).AddrReplace), arg0, arg1)
 }
 
+// ConntrackDeleteFilter mocks base method
+func (m *MockInterface) ConntrackDeleteFilter(arg0 netlink.ConntrackTableType, arg1 netlink.InetFamily, arg2 netlink.CustomConntrackFilter) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConntrackDeleteFilter", arg0, arg1, arg2)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConntrackDeleteFilter indicates an expected call of ConntrackDeleteFilter
+func (mr *MockInterfaceMockRecorder) ConntrackDeleteFilter(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConntrackDeleteFilter", reflect.TypeOf((*MockInterface)(nil).ConntrackDeleteFilter), arg0, arg1, arg2)
+}
+
 // LinkByIndex mocks base method
 func (m *MockInterface) LinkByIndex(arg0 int) (netlink.Link, error) {
 	m.ctrl.T.Helper()
@@ -220,6 +235,48 @@ func (mr *MockInterfaceMockRecorder) LinkSetUp(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetUp", reflect.TypeOf((*MockInterface)(nil).LinkSetUp), arg0)
 }
 
+// LinkSetVfHardwareAddr mocks base method
+func (m *MockInterface) LinkSetVfHardwareAddr(arg0 netlink.Link, arg1 int, arg2 net.HardwareAddr) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfHardwareAddr", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfHardwareAddr indicates an expected call of LinkSetVfHardwareAddr
+func (mr *MockInterfaceMockRecorder) LinkSetVfHardwareAddr(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfHardwareAddr", reflect.TypeOf((*MockInterface)(nil).LinkSetVfHardwareAddr), arg0, arg1, arg2)
+}
+
+// LinkSetVfTrust mocks base method
+func (m *MockInterface) LinkSetVfTrust(arg0 netlink.Link, arg1 int, arg2 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfTrust", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfTrust indicates an expected call of LinkSetVfTrust
+func (mr *MockInterfaceMockRecorder) LinkSetVfTrust(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfTrust", reflect.TypeOf((*MockInterface)(nil).LinkSetVfTrust), arg0, arg1, arg2)
+}
+
+// LinkSetVfVlan mocks base method
+func (m *MockInterface) LinkSetVfVlan(arg0 netlink.Link, arg1, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfVlan", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfVlan indicates an expected call of LinkSetVfVlan
+func (mr *MockInterfaceMockRecorder) LinkSetVfVlan(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfVlan", reflect.TypeOf((*MockInterface)(nil).LinkSetVfVlan), arg0, arg1, arg2)
+}
+
 // NeighDel mocks base method
 func (m *MockInterface) NeighDel(arg0 *netlink.Neigh) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,173 @@
+//go:build !windows
+// +build !windows
+
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nftables
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const nftCmd = "nft"
+
+// Family is the address family of a nftables table.
+type Family string
+
+const (
+	FamilyIPv4 Family = "ip"
+	FamilyIPv6 Family = "ip6"
+)
+
+// Interface is a thin wrapper of the nft command line tool.
+type Interface interface {
+	// Apply executes the provided nft script in a single transaction, i.e. either all the commands take effect
+	// or none of them does.
+	Apply(script string) error
+
+	// TableExists checks whether the table exists.
+	TableExists(family Family, table string) (bool, error)
+
+	// DeleteTable deletes the table and everything in it. It's a no-op if the table doesn't exist.
+	DeleteTable(family Family, table string) error
+
+	// ListSetElements lists the elements of the set, in nft syntax.
+	ListSetElements(family Family, table, set string) ([]string, error)
+}
+
+type Client struct{}
+
+var _ Interface = &Client{}
+
+// New returns a nftables client. It returns an error if the nft command is not available.
+func New() (*Client, error) {
+	if !IsAvailable() {
+		return nil, fmt.Errorf("%s command not found", nftCmd)
+	}
+	return &Client{}, nil
+}
+
+// IsAvailable returns whether the nft command is available on the host.
+func IsAvailable() bool {
+	_, err := exec.LookPath(nftCmd)
+	return err == nil
+}
+
+func (c *Client) Apply(script string) error {
+	cmd := exec.Command(nftCmd, "-f", "-")
+	cmd.Stdin = bytes.NewBufferString(script)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		klog.ErrorS(err, "Failed to execute nft command", "stdin", script, "stderr", stderr)
+		return fmt.Errorf("error executing nft: %v", err)
+	}
+	return nil
+}
+
+func (c *Client) TableExists(family Family, table string) (bool, error) {
+	// #nosec G204 -- inputs are not controlled by users
+	output, err := exec.Command(nftCmd, "list", "tables", string(family)).CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("error listing nftables tables: %v", err)
+	}
+	expectedLine := fmt.Sprintf("table %s %s", family, table)
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.TrimSpace(line) == expectedLine {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c *Client) DeleteTable(family Family, table string) error {
+	// Adding the table first makes the deletion idempotent, as "add" doesn't fail if the table exists.
+	return c.Apply(fmt.Sprintf("add table %[1]s %[2]s\ndelete table %[1]s %[2]s\n", family, table))
+}
+
+func (c *Client) ListSetElements(family Family, table, set string) ([]string, error) {
+	// #nosec G204 -- inputs are not controlled by users
+	output, err := exec.Command(nftCmd, "-j", "list", "set", string(family), table, set).Output()
+	if err != nil {
+		return nil, fmt.Errorf("error listing nftables set %s: %v", set, err)
+	}
+	return parseSetElements(output)
+}
+
+// parseSetElements parses the JSON output of "nft -j list set" and returns the elements in nft syntax.
+func parseSetElements(data []byte) ([]string, error) {
+	var output struct {
+		Nftables []struct {
+			Set *struct {
+				Elem []interface{} `json:"elem"`
+			} `json:"set"`
+		} `json:"nftables"`
+	}
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("error parsing nft output: %v", err)
+	}
+	var elements []string
+	for _, obj := range output.Nftables {
+		if obj.Set == nil {
+			continue
+		}
+		for _, elem := range obj.Set.Elem {
+			element, err := formatElement(elem)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, element)
+		}
+	}
+	return elements, nil
+}
+
+func formatElement(elem interface{}) (string, error) {
+	switch v := elem.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatInt(int64(v), 10), nil
+	case map[string]interface{}:
+		if prefix, ok := v["prefix"].(map[string]interface{}); ok {
+			addr, _ := prefix["addr"].(string)
+			prefixLen, _ := prefix["len"].(float64)
+			return fmt.Sprintf("%s/%d", addr, int(prefixLen)), nil
+		}
+		if concat, ok := v["concat"].([]interface{}); ok {
+			parts := make([]string, 0, len(concat))
+			for _, c := range concat {
+				part, err := formatElement(c)
+				if err != nil {
+					return "", err
+				}
+				parts = append(parts, part)
+			}
+			return strings.Join(parts, " . "), nil
+		}
+		// Elements with extra attributes, e.g. timeout or comment, are wrapped in an "elem" object.
+		if inner, ok := v["elem"].(map[string]interface{}); ok {
+			return formatElement(inner["val"])
+		}
+	}
+	return "", fmt.Errorf("unsupported nftables set element %v", elem)
+}
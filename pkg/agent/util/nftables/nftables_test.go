@@ -0,0 +1,66 @@
+//go:build !windows
+// +build !windows
+
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nftables
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSetElements(t *testing.T) {
+	tests := []struct {
+		name             string
+		output           string
+		expectedElements []string
+		expectedErr      bool
+	}{
+		{
+			name:             "empty set",
+			output:           `{"nftables": [{"metainfo": {"version": "1.0.2"}}, {"set": {"family": "ip", "name": "ANTREA-POD-IP", "table": "antrea", "type": "ipv4_addr"}}]}`,
+			expectedElements: nil,
+		},
+		{
+			name:             "addresses and prefixes",
+			output:           `{"nftables": [{"metainfo": {"version": "1.0.2"}}, {"set": {"family": "ip", "name": "ANTREA-POD-IP", "table": "antrea", "type": "ipv4_addr", "flags": ["interval"], "elem": [{"prefix": {"addr": "10.10.0.0", "len": 24}}, "10.10.1.1"]}}]}`,
+			expectedElements: []string{"10.10.0.0/24", "10.10.1.1"},
+		},
+		{
+			name:             "concatenations",
+			output:           `{"nftables": [{"metainfo": {"version": "1.0.2"}}, {"set": {"family": "ip6", "name": "ANTREA-NODEPORT-IP6", "table": "antrea", "type": ["ipv6_addr", "inet_proto", "inet_service"], "elem": [{"concat": ["fe80::1", "tcp", 30000]}, {"elem": {"val": {"concat": ["::1", "udp", 30001]}, "comment": "test"}}]}}]}`,
+			expectedElements: []string{"fe80::1 . tcp . 30000", "::1 . udp . 30001"},
+		},
+		{
+			name:        "invalid output",
+			output:      `table ip antrea`,
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elements, err := parseSetElements([]byte(tt.output))
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedElements, elements)
+		})
+	}
+}
@@ -45,8 +45,8 @@ type fwRuleProtocol string
 
 const (
 	fwRuleIPProtocol  fwRuleProtocol = "Any"
-	fwRuleTCPProtocol fwRuleProtocol = "TCP" //nolint: unused
-	fwRuleUDPProtocol fwRuleProtocol = "UDP" //nolint: unused
+	fwRuleTCPProtocol fwRuleProtocol = "TCP"
+	fwRuleUDPProtocol fwRuleProtocol = "UDP"
 )
 
 const (
@@ -109,6 +109,16 @@ func (c *Client) AddRuleBlockIP(name string, direction FWRuleDirection, ipNet *n
 	return c.addIPRule(name, direction, ipNet, fwRuleDeny)
 }
 
+// AddRuleAllowTCPPort adds Windows firewall rule to accept TCP packets on the given local port.
+func (c *Client) AddRuleAllowTCPPort(name string, direction FWRuleDirection, port uint16) error {
+	return c.addPortRule(name, direction, fwRuleTCPProtocol, port)
+}
+
+// AddRuleAllowUDPPort adds Windows firewall rule to accept UDP packets on the given local port.
+func (c *Client) AddRuleAllowUDPPort(name string, direction FWRuleDirection, port uint16) error {
+	return c.addPortRule(name, direction, fwRuleUDPProtocol, port)
+}
+
 func (c *Client) FirewallRuleExists(name string) (bool, error) {
 	cmd := fmt.Sprintf("Get-NetfirewallRule -DisplayName '%s'", name)
 	result, err := ps.RunCommand(cmd)
@@ -171,6 +181,29 @@ func (c *Client) addIPRule(name string, direction FWRuleDirection, ipNet *net.IP
 	return nil
 }
 
+func (c *Client) addPortRule(name string, direction FWRuleDirection, protocol fwRuleProtocol, port uint16) error {
+	exist, err := c.FirewallRuleExists(name)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return nil
+	}
+	rule := &winFirewallRule{
+		name:       name,
+		action:     fwRuleAllow,
+		direction:  direction,
+		protocol:   protocol,
+		localPorts: []uint16{port},
+	}
+	if err := rule.add(); err != nil {
+		klog.Errorf("Failed to add firewall rule %s", rule.getCommandString())
+		return err
+	}
+	klog.V(2).Infof("Added firewall rule %s", rule.getCommandString())
+	return nil
+}
+
 func NewClient() *Client {
 	return &Client{}
 }
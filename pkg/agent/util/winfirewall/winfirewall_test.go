@@ -54,3 +54,23 @@ func TestWinFirewallRules(t *testing.T) {
 	require.Nil(t, err)
 	checkExistence(expectedRules, false)
 }
+
+func TestWinFirewallPortRules(t *testing.T) {
+	client := NewClient()
+
+	tcpRule := "tcp-rule"
+	udpRule := "udp-rule"
+	for _, ruleName := range []string{tcpRule, udpRule} {
+		exists, err := client.FirewallRuleExists(ruleName)
+		require.Nil(t, err)
+		assert.False(t, exists)
+	}
+	require.Nil(t, client.AddRuleAllowTCPPort(tcpRule, FWRuleIn, 10351))
+	require.Nil(t, client.AddRuleAllowUDPPort(udpRule, FWRuleIn, 10351))
+	for _, ruleName := range []string{tcpRule, udpRule} {
+		exists, err := client.FirewallRuleExists(ruleName)
+		require.Nil(t, err)
+		assert.True(t, exists)
+		require.Nil(t, client.DelFirewallRuleByName(ruleName))
+	}
+}
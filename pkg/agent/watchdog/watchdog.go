@@ -0,0 +1,234 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package watchdog provides a watchdog which monitors the memory usage, the
+// installed OVS flow count and the depth of work queues of the Antrea Agent.
+// When any of them exceeds its threshold, the Agent enters a degraded state in
+// which optional work is shed (e.g. the Flow Exporter polls conntrack less
+// often), so that the Agent has a chance to recover before being OOM-killed by
+// the kubelet. The Agent leaves the degraded state once all the monitored
+// values have dropped below the recovery ratio of their thresholds.
+package watchdog
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/agent/metrics"
+)
+
+const (
+	DefaultCheckInterval = 10 * time.Second
+
+	// recoveryRatio is the ratio of the thresholds below which all the monitored
+	// values must drop for the Agent to leave the degraded state. It prevents
+	// the Agent from flapping between states when a value hovers around its
+	// threshold.
+	recoveryRatio = 0.9
+
+	resourceMemory     = "memory"
+	resourceFlowCount  = "flow_count"
+	resourceQueueDepth = "queue_depth"
+
+	reasonDegraded  = "AgentDegraded"
+	reasonRecovered = "AgentRecovered"
+)
+
+// Config contains the thresholds of the Watchdog. A zero threshold disables
+// the corresponding check.
+type Config struct {
+	CheckInterval time.Duration
+	// MemoryThreshold is the memory obtained from the OS by the Agent, in bytes.
+	MemoryThreshold uint64
+	// FlowCountThreshold is the total number of installed OVS flows.
+	FlowCountThreshold int
+	// QueueDepthThreshold is the number of items pending in any monitored queue.
+	QueueDepthThreshold int
+}
+
+// DegradationHandler is called when the Agent enters (degraded is true) or
+// leaves (degraded is false) the degraded state. Handlers are called from the
+// Watchdog goroutine and should not block.
+type DegradationHandler func(degraded bool)
+
+type Watchdog struct {
+	nodeName string
+	config   Config
+	recorder record.EventRecorder
+	// getMemoryUsage and getFlowCount return the current values of the
+	// monitored resources. They can be overridden in tests.
+	getMemoryUsage func() uint64
+	getFlowCount   func() int
+
+	mutex    sync.RWMutex
+	queues   map[string]func() int
+	handlers []DegradationHandler
+	degraded bool
+}
+
+// NewWatchdog creates a Watchdog. getFlowCount is used to get the total number
+// of installed OVS flows, and recorder is used to report state changes as
+// Events on the Node.
+func NewWatchdog(nodeName string, config Config, getFlowCount func() int, recorder record.EventRecorder) *Watchdog {
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = DefaultCheckInterval
+	}
+	return &Watchdog{
+		nodeName:       nodeName,
+		config:         config,
+		recorder:       recorder,
+		getMemoryUsage: getMemoryUsage,
+		getFlowCount:   getFlowCount,
+		queues:         map[string]func() int{},
+	}
+}
+
+// getMemoryUsage returns the memory obtained from the OS by the Go runtime
+// minus the memory returned to it, which approximates the resident memory of
+// the Agent process.
+func getMemoryUsage() uint64 {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.Sys - m.HeapReleased
+}
+
+// AddQueue registers a work queue whose depth is monitored. getLength must
+// return the number of items pending in the queue.
+func (w *Watchdog) AddQueue(name string, getLength func() int) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.queues[name] = getLength
+}
+
+// AddDegradationHandler registers a handler which is notified of the state
+// changes of the Agent.
+func (w *Watchdog) AddDegradationHandler(handler DegradationHandler) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.handlers = append(w.handlers, handler)
+}
+
+// Degraded returns whether the Agent is in the degraded state.
+func (w *Watchdog) Degraded() bool {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	return w.degraded
+}
+
+// Run checks the monitored resources periodically until stopCh is closed.
+func (w *Watchdog) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Starting watchdog", "checkInterval", w.config.CheckInterval)
+	wait.Until(w.check, w.config.CheckInterval, stopCh)
+}
+
+// exceeded is the result of checking one monitored value against its
+// threshold.
+type exceeded struct {
+	resource string
+	message  string
+}
+
+// checkThresholds compares the monitored values with their thresholds. It returns the
+// values which exceed their thresholds, and whether all values are below the
+// recovery ratio of their thresholds.
+func (w *Watchdog) checkThresholds() ([]exceeded, bool) {
+	var exceededList []exceeded
+	recovered := true
+	checkValue := func(resource, name string, value, threshold uint64) {
+		if value > threshold {
+			exceededList = append(exceededList, exceeded{
+				resource: resource,
+				message:  fmt.Sprintf("%s %d exceeds threshold %d", name, value, threshold),
+			})
+		}
+		if float64(value) >= float64(threshold)*recoveryRatio {
+			recovered = false
+		}
+	}
+	if w.config.MemoryThreshold > 0 {
+		checkValue(resourceMemory, "memory usage", w.getMemoryUsage(), w.config.MemoryThreshold)
+	}
+	if w.config.FlowCountThreshold > 0 && w.getFlowCount != nil {
+		checkValue(resourceFlowCount, "OVS flow count", uint64(w.getFlowCount()), uint64(w.config.FlowCountThreshold))
+	}
+	if w.config.QueueDepthThreshold > 0 {
+		w.mutex.RLock()
+		names := make([]string, 0, len(w.queues))
+		for name := range w.queues {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			checkValue(resourceQueueDepth, fmt.Sprintf("depth of %s queue", name), uint64(w.queues[name]()), uint64(w.config.QueueDepthThreshold))
+		}
+		w.mutex.RUnlock()
+	}
+	return exceededList, recovered
+}
+
+func (w *Watchdog) check() {
+	exceededList, recovered := w.checkThresholds()
+
+	w.mutex.Lock()
+	wasDegraded := w.degraded
+	switch {
+	case !wasDegraded && len(exceededList) > 0:
+		w.degraded = true
+	case wasDegraded && recovered:
+		w.degraded = false
+	}
+	degraded := w.degraded
+	handlers := make([]DegradationHandler, len(w.handlers))
+	copy(handlers, w.handlers)
+	w.mutex.Unlock()
+
+	if degraded == wasDegraded {
+		return
+	}
+	nodeRef := &corev1.ObjectReference{Kind: "Node", Name: w.nodeName, UID: types.UID(w.nodeName)}
+	if degraded {
+		messages := make([]string, 0, len(exceededList))
+		for _, e := range exceededList {
+			metrics.WatchdogThresholdExceededCount.WithLabelValues(e.resource).Inc()
+			messages = append(messages, e.message)
+			// Return the freed memory to the OS right away instead of waiting for the
+			// background scavenger, to reduce the resident memory seen by the kubelet.
+			if e.resource == resourceMemory {
+				debug.FreeOSMemory()
+			}
+		}
+		message := strings.Join(messages, "; ")
+		klog.InfoS("Agent entered degraded state, shedding optional work", "reason", message)
+		metrics.WatchdogDegraded.Set(1)
+		w.recorder.Eventf(nodeRef, corev1.EventTypeWarning, reasonDegraded, "antrea-agent entered degraded state: %s", message)
+	} else {
+		klog.InfoS("Agent recovered from degraded state")
+		metrics.WatchdogDegraded.Set(0)
+		w.recorder.Event(nodeRef, corev1.EventTypeNormal, reasonRecovered, "antrea-agent recovered from degraded state")
+	}
+	for _, handler := range handlers {
+		handler(degraded)
+	}
+}
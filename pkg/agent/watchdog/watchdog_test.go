@@ -0,0 +1,111 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchdog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestWatchdogCheck(t *testing.T) {
+	var memoryUsage uint64
+	var flowCount, queueDepth int
+	recorder := record.NewFakeRecorder(10)
+	w := NewWatchdog("node1", Config{
+		MemoryThreshold:     1000,
+		FlowCountThreshold:  100,
+		QueueDepthThreshold: 10,
+	}, func() int { return flowCount }, recorder)
+	w.getMemoryUsage = func() uint64 { return memoryUsage }
+	w.AddQueue("networkpolicy", func() int { return queueDepth })
+	var notified []bool
+	w.AddDegradationHandler(func(degraded bool) {
+		notified = append(notified, degraded)
+	})
+
+	steps := []struct {
+		name             string
+		memoryUsage      uint64
+		flowCount        int
+		queueDepth       int
+		expectedDegraded bool
+		expectedEvent    string
+	}{
+		{
+			name:        "below thresholds",
+			memoryUsage: 500,
+			flowCount:   50,
+			queueDepth:  5,
+		},
+		{
+			name:             "flow count exceeds threshold",
+			memoryUsage:      500,
+			flowCount:        101,
+			queueDepth:       5,
+			expectedDegraded: true,
+			expectedEvent:    "Warning AgentDegraded antrea-agent entered degraded state: OVS flow count 101 exceeds threshold 100",
+		},
+		{
+			name:             "flow count above recovery threshold",
+			memoryUsage:      500,
+			flowCount:        95,
+			queueDepth:       5,
+			expectedDegraded: true,
+		},
+		{
+			name:          "flow count below recovery threshold",
+			memoryUsage:   500,
+			flowCount:     80,
+			queueDepth:    5,
+			expectedEvent: "Normal AgentRecovered antrea-agent recovered from degraded state",
+		},
+		{
+			name:             "memory and queue depth exceed thresholds",
+			memoryUsage:      2000,
+			flowCount:        80,
+			queueDepth:       20,
+			expectedDegraded: true,
+			expectedEvent:    "Warning AgentDegraded antrea-agent entered degraded state: memory usage 2000 exceeds threshold 1000; depth of networkpolicy queue 20 exceeds threshold 10",
+		},
+	}
+	var expectedNotified []bool
+	for _, step := range steps {
+		memoryUsage, flowCount, queueDepth = step.memoryUsage, step.flowCount, step.queueDepth
+		wasDegraded := w.Degraded()
+		w.check()
+		assert.Equal(t, step.expectedDegraded, w.Degraded(), step.name)
+		if wasDegraded != step.expectedDegraded {
+			expectedNotified = append(expectedNotified, step.expectedDegraded)
+		}
+		assert.Equal(t, expectedNotified, notified, step.name)
+		if step.expectedEvent != "" {
+			assert.Equal(t, step.expectedEvent, <-recorder.Events, step.name)
+		}
+		assert.Empty(t, recorder.Events, step.name)
+	}
+}
+
+func TestWatchdogDisabledChecks(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	w := NewWatchdog("node1", Config{}, func() int { return 1000000 }, recorder)
+	w.getMemoryUsage = func() uint64 { return 1 << 40 }
+	w.AddQueue("networkpolicy", func() int { return 1000000 })
+	w.check()
+	assert.False(t, w.Degraded())
+	assert.Empty(t, recorder.Events)
+	assert.Equal(t, DefaultCheckInterval, w.config.CheckInterval)
+}
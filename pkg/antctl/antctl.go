@@ -19,15 +19,24 @@ import (
 	"reflect"
 
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/agentinfo"
+	"antrea.io/antrea/pkg/agent/apiserver/handlers/connections"
+	"antrea.io/antrea/pkg/agent/apiserver/handlers/deletedrules"
+	"antrea.io/antrea/pkg/agent/apiserver/handlers/fqdncache"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/memberlist"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/multicast"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/ovsflows"
+	"antrea.io/antrea/pkg/agent/apiserver/handlers/ovsoffload"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/podinterface"
+	"antrea.io/antrea/pkg/agent/apiserver/handlers/policypause"
 	"antrea.io/antrea/pkg/agent/apiserver/handlers/serviceexternalip"
 	"antrea.io/antrea/pkg/agent/openflow"
 	fallbackversion "antrea.io/antrea/pkg/antctl/fallback/version"
+	"antrea.io/antrea/pkg/antctl/raw/check/cluster"
 	"antrea.io/antrea/pkg/antctl/raw/featuregates"
+	"antrea.io/antrea/pkg/antctl/raw/groupmember"
 	"antrea.io/antrea/pkg/antctl/raw/multicluster"
+	"antrea.io/antrea/pkg/antctl/raw/policybundle"
+	"antrea.io/antrea/pkg/antctl/raw/policysimulation"
 	"antrea.io/antrea/pkg/antctl/raw/proxy"
 	"antrea.io/antrea/pkg/antctl/raw/set"
 	"antrea.io/antrea/pkg/antctl/raw/supportbundle"
@@ -40,6 +49,7 @@ import (
 	"antrea.io/antrea/pkg/antctl/transform/version"
 	cpv1beta "antrea.io/antrea/pkg/apis/controlplane/v1beta2"
 	systemv1beta1 "antrea.io/antrea/pkg/apis/system/v1beta1"
+	"antrea.io/antrea/pkg/apiserver/handlers/ipgroup"
 	controllerinforest "antrea.io/antrea/pkg/apiserver/registry/system/controllerinfo"
 	"antrea.io/antrea/pkg/client/clientset/versioned/scheme"
 	controllernetworkpolicy "antrea.io/antrea/pkg/controller/networkpolicy"
@@ -489,6 +499,29 @@ $ antctl get podmulticaststats pod -n namespace`,
 			},
 			transformedResponse: reflect.TypeOf(controllernetworkpolicy.EndpointQueryResponse{}),
 		},
+		{
+			use:   "ipgroup",
+			short: "Filter AppliedToGroups and AddressGroups which contain an IP.",
+			long:  "Filter AppliedToGroups and AddressGroups which have a member with the provided IP, along with the network policies which reference these groups.",
+			example: `  Query the groups which contain IP 10.10.1.2
+  $ antctl query ipgroup 10.10.1.2
+`,
+			commandGroup: query,
+			controllerEndpoint: &endpoint{
+				nonResourceEndpoint: &nonResourceEndpoint{
+					path: "/ipgroup",
+					params: []flagInfo{
+						{
+							name:  "ip",
+							usage: "IP address of the group member",
+							arg:   true,
+						},
+					},
+					outputType: multiple,
+				},
+			},
+			transformedResponse: reflect.TypeOf(ipgroup.Response{}),
+		},
 		{
 			use:   "flowrecords",
 			short: "Print the matching flow records in the flow aggregator",
@@ -583,6 +616,204 @@ $ antctl get podmulticaststats pod -n namespace`,
 			},
 			transformedResponse: reflect.TypeOf(memberlist.Response{}),
 		},
+		{
+			use:     "fqdncache",
+			aliases: []string{"fqdn"},
+			short:   "Print the DNS cache of FQDN policies",
+			long:    "Print the DNS cache of the FQDNs selected by FQDN policy rules, including the IP addresses, the expiration time of the addresses, and the IDs of the rules selecting each FQDN",
+			example: `  Get the DNS cache of all FQDNs
+  $ antctl get fqdncache
+  Get the DNS cache of the FQDNs matching a wildcard pattern
+  $ antctl get fqdncache -d "*.example.com"`,
+			commandGroup: get,
+			agentEndpoint: &endpoint{
+				nonResourceEndpoint: &nonResourceEndpoint{
+					path: "/fqdncache",
+					params: []flagInfo{
+						{
+							name:      "domain",
+							usage:     "Only get the DNS cache of the FQDNs matching the provided name or wildcard pattern.",
+							shorthand: "d",
+						},
+					},
+					outputType: multiple,
+				},
+			},
+			transformedResponse: reflect.TypeOf(fqdncache.Response{}),
+		},
+		{
+			use:     "pausednetworkpolicy",
+			aliases: []string{"pausednetworkpolicies", "pausednetpol"},
+			short:   "Print the NetworkPolicies whose realization is paused",
+			long:    "Print the names of the NetworkPolicies whose realization was paused on the Node with the pause-networkpolicy command",
+			example: `  Get the NetworkPolicies whose realization is paused
+  $ antctl get pausednetworkpolicy`,
+			commandGroup: get,
+			agentEndpoint: &endpoint{
+				nonResourceEndpoint: &nonResourceEndpoint{
+					path:       "/networkpolicies/paused",
+					outputType: multiple,
+				},
+			},
+			transformedResponse: reflect.TypeOf(policypause.Response{}),
+		},
+		{
+			use:     "connections",
+			aliases: []string{"connection", "conn"},
+			short:   "Print the conntrack connections of the Node",
+			long:    "Print the Antrea connections tracked by conntrack on the Node, including their state, their remaining timeout, and their original destination before DNAT, e.g. the ClusterIP of a Service",
+			example: `  Get all the connections of the Node
+  $ antctl get connections
+  Get the connections from or to a local Pod
+  $ antctl get connections -p pod1 -n ns1
+  Get the connections to a Service
+  $ antctl get connections -S svc1 -n ns1
+  Get the TCP connections to an IP address and port, which can be the original or the translated destination
+  $ antctl get connections --protocol tcp --dst-ip 10.96.0.10 --dst-port 53`,
+			commandGroup: get,
+			agentEndpoint: &endpoint{
+				nonResourceEndpoint: &nonResourceEndpoint{
+					path: "/connections",
+					params: []flagInfo{
+						{
+							name:      "namespace",
+							usage:     "Namespace of the Pod or the Service",
+							shorthand: "n",
+						},
+						{
+							name:      "pod",
+							usage:     "Name of a local Pod. If present, Namespace must be provided.",
+							shorthand: "p",
+						},
+						{
+							name:      "service",
+							usage:     "Name of a Service. If present, Namespace must be provided.",
+							shorthand: "S",
+						},
+						{
+							name:  "src-ip",
+							usage: "Source IP address of the connections",
+						},
+						{
+							name:  "src-port",
+							usage: "Source port of the connections",
+						},
+						{
+							name:  "dst-ip",
+							usage: "Original or translated destination IP address of the connections",
+						},
+						{
+							name:  "dst-port",
+							usage: "Original or translated destination port of the connections",
+						},
+						{
+							name:            "protocol",
+							usage:           "Protocol of the connections",
+							supportedValues: []string{"tcp", "udp", "sctp", "icmp", "icmpv6"},
+						},
+					},
+					outputType: multiple,
+				},
+			},
+			transformedResponse: reflect.TypeOf(connections.Response{}),
+		},
+		{
+			use:     "ovsoffload",
+			aliases: []string{"offload"},
+			short:   "Print the OVS hardware offload status",
+			long:    "Print the numbers of OVS datapath flows offloaded to hardware and processed in software, for each flow category determined by the type of the input port of the flows. Only available when OVS hardware offload is enabled.",
+			example: `  Get the OVS hardware offload status of the Node
+  $ antctl get ovsoffload`,
+			commandGroup: get,
+			agentEndpoint: &endpoint{
+				nonResourceEndpoint: &nonResourceEndpoint{
+					path:       "/ovsoffload",
+					outputType: multiple,
+				},
+			},
+			transformedResponse: reflect.TypeOf(ovsoffload.Response{}),
+		},
+		{
+			use:   "flush-fqdncache",
+			short: "Flush the DNS cache of a FQDN",
+			long:  "Flush the DNS cache of a FQDN selected by FQDN policy rules, which makes the Antrea agent resolve the FQDN again immediately. The cached IP addresses are kept until the new DNS response is received.",
+			example: `  Flush the DNS cache of www.example.com
+  $ antctl flush-fqdncache www.example.com`,
+			commandGroup: flat,
+			agentEndpoint: &endpoint{
+				nonResourceEndpoint: &nonResourceEndpoint{
+					path: "/fqdncache/flush",
+					params: []flagInfo{
+						{
+							name:  "domain",
+							usage: "The FQDN to flush",
+							arg:   true,
+						},
+					},
+					outputType: single,
+				},
+			},
+			transformedResponse: reflect.TypeOf(fqdncache.Response{}),
+		},
+		{
+			use:   "pause-networkpolicy",
+			short: "Pause the realization of a NetworkPolicy on the Node",
+			long:  "Pause the realization of a NetworkPolicy on the Node. The current OVS flows of the NetworkPolicy are kept, and updates to the NetworkPolicy or to the groups it references are not applied until the realization is resumed. The NetworkPolicy is identified by the name returned by 'antctl get networkpolicy'. The paused state is lost when the Antrea agent restarts.",
+			example: `  Pause the realization of a NetworkPolicy
+  $ antctl pause-networkpolicy 6001549b-ba63-4752-8267-30f52b4332db`,
+			commandGroup: flat,
+			agentEndpoint: &endpoint{
+				nonResourceEndpoint: &nonResourceEndpoint{
+					path: "/networkpolicies/pause",
+					params: []flagInfo{
+						{
+							name:  "name",
+							usage: "The name of the NetworkPolicy to pause",
+							arg:   true,
+						},
+					},
+					outputType: single,
+				},
+			},
+			transformedResponse: reflect.TypeOf(policypause.Response{}),
+		},
+		{
+			use:   "resume-networkpolicy",
+			short: "Resume the realization of a NetworkPolicy on the Node",
+			long:  "Resume the realization of a NetworkPolicy paused with the pause-networkpolicy command. The OVS flows of the NetworkPolicy are updated to match its current state.",
+			example: `  Resume the realization of a NetworkPolicy
+  $ antctl resume-networkpolicy 6001549b-ba63-4752-8267-30f52b4332db`,
+			commandGroup: flat,
+			agentEndpoint: &endpoint{
+				nonResourceEndpoint: &nonResourceEndpoint{
+					path: "/networkpolicies/resume",
+					params: []flagInfo{
+						{
+							name:  "name",
+							usage: "The name of the NetworkPolicy to resume",
+							arg:   true,
+						},
+					},
+					outputType: single,
+				},
+			},
+			transformedResponse: reflect.TypeOf(policypause.Response{}),
+		},
+		{
+			use:   "expire-deletedrules",
+			short: "Expire the rules of deleted NetworkPolicies on the Node",
+			long:  "Expire the rules of deleted NetworkPolicies immediately. The Antrea agent keeps deleted rules for a while, so that the flows of existing connections can still be mapped to them, e.g. by the Flow Exporter. The number of rules kept is reported by the antrea_agent_networkpolicy_async_delete_pending_rule_count metric.",
+			example: `  Expire the rules of deleted NetworkPolicies
+  $ antctl expire-deletedrules`,
+			commandGroup: flat,
+			agentEndpoint: &endpoint{
+				nonResourceEndpoint: &nonResourceEndpoint{
+					path:       "/networkpolicies/deletedrules/expire",
+					outputType: single,
+				},
+			},
+			transformedResponse: reflect.TypeOf(deletedrules.Response{}),
+		},
 	},
 	rawCommands: []rawCommand{
 		{
@@ -600,12 +831,28 @@ $ antctl get podmulticaststats pod -n namespace`,
 			supportAgent:      false,
 			supportController: true,
 		},
+		{
+			cobraCommand:      policysimulation.Command,
+			supportAgent:      true,
+			supportController: false,
+		},
+		{
+			cobraCommand:      policybundle.Command,
+			supportAgent:      false,
+			supportController: true,
+		},
 		{
 			cobraCommand:      featuregates.Command,
 			supportAgent:      true,
 			supportController: true,
 			commandGroup:      get,
 		},
+		{
+			cobraCommand:      groupmember.Command,
+			supportAgent:      false,
+			supportController: true,
+			commandGroup:      get,
+		},
 		{
 			cobraCommand:      multicluster.GetCmd,
 			supportAgent:      false,
@@ -654,6 +901,12 @@ $ antctl get podmulticaststats pod -n namespace`,
 			supportController: false,
 			commandGroup:      mc,
 		},
+		{
+			cobraCommand:      cluster.Command,
+			supportAgent:      false,
+			supportController: false,
+			commandGroup:      check,
+		},
 		{
 			cobraCommand:          set.SetCmd,
 			supportAgent:          false,
@@ -76,6 +76,7 @@ const (
 	get
 	query
 	mc
+	check
 )
 
 var groupCommands = map[commandGroup]*cobra.Command{
@@ -94,6 +95,11 @@ var groupCommands = map[commandGroup]*cobra.Command{
 		Short: "Sub-commands of multi-cluster feature",
 		Long:  "Sub-commands of multi-cluster feature",
 	},
+	check: {
+		Use:   "check",
+		Short: "Check the cluster and the Antrea installation",
+		Long:  "Check the cluster and the Antrea installation",
+	},
 }
 
 type endpointResponder interface {
@@ -540,6 +546,7 @@ func (cd *commandDefinition) output(resp io.Reader, writer io.Writer, ft formatt
 			if cd.controllerEndpoint.nonResourceEndpoint.path == "/endpoint" {
 				return cd.tableOutputForQueryEndpoint(obj, writer)
 			}
+			return output.TableOutputForGetCommands(obj, writer)
 		} else {
 			return output.TableOutput(obj, writer)
 		}
@@ -63,7 +63,7 @@ func (cl *commandList) applyToRootCommand(root *cobra.Command, client AntctlClie
 		if (runtime.Mode == runtime.ModeAgent && cmd.supportAgent) ||
 			(runtime.Mode == runtime.ModeController && cmd.supportController) ||
 			(runtime.Mode == runtime.ModeFlowAggregator && cmd.supportFlowAggregator) ||
-			(!runtime.InPod && cmd.commandGroup == mc) {
+			(!runtime.InPod && (cmd.commandGroup == mc || cmd.commandGroup == check)) {
 			if groupCommand, ok := groupCommands[cmd.commandGroup]; ok {
 				groupCommand.AddCommand(cmd.cobraCommand)
 			} else {
@@ -124,6 +124,15 @@ func (cl *commandList) GetDebugCommands(mode string) [][]string {
 			// log-level command does not support remote execution.
 			continue
 		}
+		if def.use == "flush-fqdncache" {
+			// flush-fqdncache command requires a FQDN and changes the state of the agent.
+			continue
+		}
+		if def.use == "pause-networkpolicy" || def.use == "resume-networkpolicy" {
+			// pause-networkpolicy and resume-networkpolicy commands require a NetworkPolicy
+			// name and change the state of the agent.
+			continue
+		}
 		if mode == runtime.ModeAgent && def.agentEndpoint != nil ||
 			mode == runtime.ModeController && def.controllerEndpoint != nil ||
 			mode == runtime.ModeFlowAggregator && def.flowAggregatorEndpoint != nil {
@@ -141,6 +150,18 @@ func (cl *commandList) GetDebugCommands(mode string) [][]string {
 			// cannot be used as is in e2e tests.
 			continue
 		}
+		if cmd.cobraCommand.Use == "simulate-policy" {
+			// simulate-policy requires a flow to be provided.
+			continue
+		}
+		if cmd.cobraCommand.Use == "policy-bundle" {
+			// policy-bundle only groups the export and diff commands.
+			continue
+		}
+		if cmd.cobraCommand.Name() == "groupmember" {
+			// groupmember requires a ClusterGroup or Group name to be provided.
+			continue
+		}
 		if mode == runtime.ModeController && cmd.supportController ||
 			mode == runtime.ModeAgent && cmd.supportAgent {
 			var currentCommand []string
@@ -0,0 +1,446 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"antrea.io/antrea/pkg/agent/config"
+	"antrea.io/antrea/pkg/antctl/raw"
+	crdv1beta1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
+	antrea "antrea.io/antrea/pkg/client/clientset/versioned"
+	agentconfig "antrea.io/antrea/pkg/config/agent"
+	"antrea.io/antrea/pkg/ovs/ovsconfig"
+)
+
+const (
+	antreaNamespace       = "kube-system"
+	antreaAgentDaemonSet  = "antrea-agent"
+	antreaControllerName  = "antrea-controller"
+	antreaConfigVolume    = "antrea-config"
+	antreaAgentConfigKey  = "antrea-agent.conf"
+	kubeProxyDaemonSet    = "kube-proxy"
+	antreaControllerPort  = 10349
+	antreaAgentPort       = 10350
+	portCheckTimeoutInSec = 2
+)
+
+type Status string
+
+const (
+	StatusPass    Status = "Pass"
+	StatusWarning Status = "Warning"
+	StatusFail    Status = "Fail"
+	StatusSkip    Status = "Skip"
+)
+
+// severity is used to aggregate the statuses of a check on multiple Nodes.
+var severity = map[Status]int{StatusSkip: 0, StatusPass: 1, StatusWarning: 2, StatusFail: 3}
+
+// Result is the result of a check.
+type Result struct {
+	Check   string   `json:"check"`
+	Status  Status   `json:"status"`
+	Details []string `json:"details,omitempty"`
+}
+
+// Report is the structured report of all the checks.
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+func (r *Report) failedChecks() int {
+	failed := 0
+	for _, result := range r.Results {
+		if result.Status == StatusFail {
+			failed++
+		}
+	}
+	return failed
+}
+
+type testContext struct {
+	client       kubernetes.Interface
+	antreaClient antrea.Interface
+	namespace    string
+	exec         podExecutor
+	// nodes are the Linux Nodes of the cluster, sorted by name.
+	nodes []corev1.Node
+	// checkerPods maps the name of each Node to the checker Pod running on it.
+	checkerPods map[string]*corev1.Pod
+	// agentConfig is the configuration of the Antrea Agent, or nil if Antrea is not installed.
+	agentConfig *agentconfig.AgentConfig
+}
+
+type check struct {
+	name string
+	run  func(ctx context.Context, tc *testContext) (Status, []string)
+}
+
+var checks = []check{
+	{"Antrea components", checkAntreaComponents},
+	{"proxyAll and kube-proxy", checkKubeProxyConflicts},
+	{"OVS kernel module", checkOVSKernelModule},
+	{"Required sysctls", checkSysctls},
+	{"Antrea API ports", checkAPIPorts},
+	{"Node-to-Node MTU", checkNodeMTU},
+}
+
+func (tc *testContext) run(ctx context.Context) *Report {
+	agentConfig, err := tc.getAgentConfig(ctx)
+	if err != nil {
+		return &Report{Results: []Result{{Check: "Antrea configuration", Status: StatusFail, Details: []string{err.Error()}}}}
+	}
+	tc.agentConfig = agentConfig
+	report := &Report{}
+	for _, c := range checks {
+		status, details := c.run(ctx, tc)
+		report.Results = append(report.Results, Result{Check: c.name, Status: status, Details: details})
+	}
+	return report
+}
+
+// getAgentConfig returns the configuration of the Antrea Agent, read from the ConfigMap mounted by the antrea-agent
+// DaemonSet, or nil if Antrea is not installed.
+func (tc *testContext) getAgentConfig(ctx context.Context) (*agentconfig.AgentConfig, error) {
+	ds, err := tc.client.AppsV1().DaemonSets(antreaNamespace).Get(ctx, antreaAgentDaemonSet, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get DaemonSet %s: %w", antreaAgentDaemonSet, err)
+	}
+	for _, volume := range ds.Spec.Template.Spec.Volumes {
+		if volume.Name != antreaConfigVolume || volume.ConfigMap == nil {
+			continue
+		}
+		configMap, err := tc.client.CoreV1().ConfigMaps(antreaNamespace).Get(ctx, volume.ConfigMap.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ConfigMap %s: %w", volume.ConfigMap.Name, err)
+		}
+		var agentConfig agentconfig.AgentConfig
+		if err := yaml.Unmarshal([]byte(configMap.Data[antreaAgentConfigKey]), &agentConfig); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Antrea Agent configuration: %w", err)
+		}
+		return &agentConfig, nil
+	}
+	return nil, fmt.Errorf("failed to find the %s volume of DaemonSet %s", antreaConfigVolume, antreaAgentDaemonSet)
+}
+
+// forEachNode runs fn for the checker Pod of each Node concurrently, and aggregates the results: the status is the
+// most severe one, and the details are prefixed with the Node names.
+func (tc *testContext) forEachNode(ctx context.Context, fn func(ctx context.Context, node *corev1.Node, pod *corev1.Pod) (Status, []string)) (Status, []string) {
+	statuses := make([]Status, len(tc.nodes))
+	details := make([][]string, len(tc.nodes))
+	var wg sync.WaitGroup
+	for i := range tc.nodes {
+		node := &tc.nodes[i]
+		pod, ok := tc.checkerPods[node.Name]
+		if !ok {
+			statuses[i], details[i] = StatusWarning, []string{"no checker Pod is running on the Node"}
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			statuses[i], details[i] = fn(ctx, node, pod)
+		}(i)
+	}
+	wg.Wait()
+
+	status := StatusSkip
+	var allDetails []string
+	for i := range tc.nodes {
+		if severity[statuses[i]] > severity[status] {
+			status = statuses[i]
+		}
+		for _, detail := range details[i] {
+			allDetails = append(allDetails, tc.nodes[i].Name+": "+detail)
+		}
+	}
+	return status, allDetails
+}
+
+// nodeIP returns the IP of a Node used for the Node-to-Node checks, preferring IPv4.
+func nodeIP(node *corev1.Node) net.IP {
+	ips, err := raw.GetNodeAddrs(node)
+	if err != nil {
+		return nil
+	}
+	if ips.IPv4 != nil {
+		return ips.IPv4
+	}
+	return ips.IPv6
+}
+
+// peerIPs returns the IPs of the Nodes other than the given one.
+func (tc *testContext) peerIPs(node *corev1.Node) []net.IP {
+	var ips []net.IP
+	for i := range tc.nodes {
+		if tc.nodes[i].Name == node.Name {
+			continue
+		}
+		if ip := nodeIP(&tc.nodes[i]); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+func checkAntreaComponents(ctx context.Context, tc *testContext) (Status, []string) {
+	if tc.agentConfig == nil {
+		return StatusSkip, []string{"Antrea is not installed"}
+	}
+	status := StatusPass
+	var details []string
+	agentDS, err := tc.client.AppsV1().DaemonSets(antreaNamespace).Get(ctx, antreaAgentDaemonSet, metav1.GetOptions{})
+	if err != nil {
+		return StatusFail, []string{fmt.Sprintf("failed to get DaemonSet %s: %v", antreaAgentDaemonSet, err)}
+	}
+	details = append(details, fmt.Sprintf("%d/%d Antrea Agents ready", agentDS.Status.NumberReady, agentDS.Status.DesiredNumberScheduled))
+	if agentDS.Status.NumberReady != agentDS.Status.DesiredNumberScheduled {
+		status = StatusFail
+	}
+	controller, err := tc.client.AppsV1().Deployments(antreaNamespace).Get(ctx, antreaControllerName, metav1.GetOptions{})
+	if err != nil {
+		return StatusFail, append(details, fmt.Sprintf("failed to get Deployment %s: %v", antreaControllerName, err))
+	}
+	if controller.Status.AvailableReplicas == 0 {
+		status = StatusFail
+		details = append(details, "Antrea Controller not available")
+	} else {
+		details = append(details, "Antrea Controller available")
+	}
+	return status, details
+}
+
+func checkKubeProxyConflicts(ctx context.Context, tc *testContext) (Status, []string) {
+	if tc.agentConfig == nil {
+		return StatusSkip, []string{"Antrea is not installed"}
+	}
+	_, err := tc.client.AppsV1().DaemonSets(antreaNamespace).Get(ctx, kubeProxyDaemonSet, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return StatusFail, []string{fmt.Sprintf("failed to get DaemonSet %s: %v", kubeProxyDaemonSet, err)}
+	}
+	kubeProxyDeployed := err == nil
+	if !tc.agentConfig.AntreaProxy.ProxyAll {
+		if !kubeProxyDeployed {
+			return StatusWarning, []string{"kube-proxy is not deployed and proxyAll is disabled, NodePort and LoadBalancer Services may not work"}
+		}
+		return StatusPass, []string{"proxyAll is disabled, Services are handled by kube-proxy"}
+	}
+	if !kubeProxyDeployed {
+		if tc.agentConfig.KubeAPIServerOverride == "" {
+			return StatusFail, []string{"kube-proxy is not deployed and kubeAPIServerOverride is not set, the Antrea Agents may not be able to reach the K8s apiserver"}
+		}
+		return StatusPass, []string{"kube-proxy is not deployed, all Services are handled by AntreaProxy"}
+	}
+	status := StatusWarning
+	details := []string{"kube-proxy is deployed and takes priority over AntreaProxy for NodePort Services, consider removing it"}
+	agentInfos, err := tc.antreaClient.CrdV1beta1().AntreaAgentInfos().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return StatusFail, append(details, fmt.Sprintf("failed to list AntreaAgentInfos: %v", err))
+	}
+	sort.Slice(agentInfos.Items, func(i, j int) bool { return agentInfos.Items[i].Name < agentInfos.Items[j].Name })
+	for _, agentInfo := range agentInfos.Items {
+		for _, condition := range agentInfo.AgentConditions {
+			if condition.Type == crdv1beta1.KubeProxyCompatible && condition.Status == corev1.ConditionFalse {
+				status = StatusFail
+				details = append(details, fmt.Sprintf("%s: %s: %s", agentInfo.Name, condition.Reason, condition.Message))
+			}
+		}
+	}
+	return status, details
+}
+
+func checkOVSKernelModule(ctx context.Context, tc *testContext) (Status, []string) {
+	if tc.agentConfig != nil && tc.agentConfig.OVSDatapathType == string(ovsconfig.OVSDatapathNetdev) {
+		return StatusSkip, []string{"the OVS netdev datapath is used"}
+	}
+	const script = `if [ -d /sys/module/openvswitch ]; then echo loaded; ` +
+		`elif grep -qs '/openvswitch.ko' /lib/modules/$(uname -r)/modules.dep /lib/modules/$(uname -r)/modules.builtin; then echo available; ` +
+		`else echo missing; fi`
+	return tc.forEachNode(ctx, func(ctx context.Context, node *corev1.Node, pod *corev1.Pod) (Status, []string) {
+		out, err := tc.exec(ctx, pod, script)
+		if err != nil {
+			return StatusFail, []string{err.Error()}
+		}
+		switch strings.TrimSpace(out) {
+		case "loaded":
+			return StatusPass, nil
+		case "available":
+			return StatusPass, []string{"openvswitch module can be loaded"}
+		}
+		return StatusFail, []string{"openvswitch module not found, install it or use the OVS netdev datapath"}
+	})
+}
+
+func checkSysctls(ctx context.Context, tc *testContext) (Status, []string) {
+	return tc.forEachNode(ctx, func(ctx context.Context, node *corev1.Node, pod *corev1.Pod) (Status, []string) {
+		ips, err := raw.GetNodeAddrs(node)
+		if err != nil {
+			return StatusFail, []string{err.Error()}
+		}
+		// IP forwarding is required for the traffic between the Pods and the host network.
+		var sysctls []string
+		if ips.IPv4 != nil {
+			sysctls = append(sysctls, "net/ipv4/ip_forward")
+		}
+		if ips.IPv6 != nil {
+			sysctls = append(sysctls, "net/ipv6/conf/all/forwarding")
+		}
+		out, err := tc.exec(ctx, pod, fmt.Sprintf(`for f in %s; do echo "$f $(cat /proc/sys/$f)"; done`, strings.Join(sysctls, " ")))
+		if err != nil {
+			return StatusFail, []string{err.Error()}
+		}
+		status := StatusPass
+		var details []string
+		for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+			name := strings.ReplaceAll(fields[0], "/", ".")
+			if len(fields) != 2 || fields[1] != "1" {
+				status = StatusFail
+				details = append(details, fmt.Sprintf("%s must be set to 1", name))
+			}
+		}
+		return status, details
+	})
+}
+
+func checkAPIPorts(ctx context.Context, tc *testContext) (Status, []string) {
+	if len(tc.nodes) < 2 {
+		return StatusSkip, []string{"at least 2 Nodes are required"}
+	}
+	return tc.forEachNode(ctx, func(ctx context.Context, node *corev1.Node, pod *corev1.Pod) (Status, []string) {
+		var targets []string
+		for _, ip := range tc.peerIPs(node) {
+			for _, port := range []int{antreaControllerPort, antreaAgentPort} {
+				targets = append(targets, net.JoinHostPort(ip.String(), strconv.Itoa(port)))
+			}
+		}
+		// The exit code of the connection is 0 if it succeeds, 124 if it times out, or 1 if it is refused.
+		script := fmt.Sprintf(`for t in %s; do h=${t%%:*}; h=${h#\[}; h=${h%%]}; timeout %d bash -c "</dev/tcp/$h/${t##*:}" >/dev/null 2>&1; echo "$t $?"; done`,
+			strings.Join(targets, " "), portCheckTimeoutInSec)
+		out, err := tc.exec(ctx, pod, script)
+		if err != nil {
+			return StatusFail, []string{err.Error()}
+		}
+		status := StatusPass
+		var details []string
+		for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) != 2 {
+				continue
+			}
+			switch fields[1] {
+			case "0":
+			case "124", "143":
+				status = StatusFail
+				details = append(details, fmt.Sprintf("connection to %s timed out, it may be blocked by a firewall", fields[0]))
+			default:
+				// A closed port cannot be distinguished from a port rejected by a firewall.
+				if tc.agentConfig != nil {
+					status = StatusFail
+				} else if status == StatusPass {
+					status = StatusWarning
+				}
+				details = append(details, fmt.Sprintf("connection to %s refused", fields[0]))
+			}
+		}
+		return status, details
+	})
+}
+
+func checkNodeMTU(ctx context.Context, tc *testContext) (Status, []string) {
+	if len(tc.nodes) < 2 {
+		return StatusSkip, []string{"at least 2 Nodes are required"}
+	}
+	networkConfig := &config.NetworkConfig{TrafficEncapMode: config.TrafficEncapModeEncap, TunnelType: ovsconfig.GeneveTunnel}
+	if tc.agentConfig != nil {
+		if ok, mode := config.GetTrafficEncapModeFromStr(tc.agentConfig.TrafficEncapMode); ok {
+			networkConfig.TrafficEncapMode = mode
+		}
+		if tc.agentConfig.TunnelType != "" {
+			networkConfig.TunnelType = ovsconfig.TunnelType(tc.agentConfig.TunnelType)
+		}
+	}
+	// The MTU deductions are calculated beforehand, as the Nodes are checked concurrently.
+	mtuDeductions := map[bool]int{false: networkConfig.CalculateMTUDeduction(false), true: networkConfig.CalculateMTUDeduction(true)}
+	return tc.forEachNode(ctx, func(ctx context.Context, node *corev1.Node, pod *corev1.Pod) (Status, []string) {
+		peers := tc.peerIPs(node)
+		if len(peers) == 0 {
+			return StatusSkip, nil
+		}
+		var peerStrs []string
+		for _, ip := range peers {
+			peerStrs = append(peerStrs, ip.String())
+		}
+		// For each peer, the MTU of the interface routing to the peer is used as the size of an unfragmentable
+		// ping, which only succeeds if the MTU of the path is not lower. The IP and ICMP headers are 28 bytes for
+		// IPv4 and 48 bytes for IPv6.
+		script := fmt.Sprintf(`for p in %s; do dev=$(ip -o route get $p | sed -n 's/.* dev \([^ ]*\).*/\1/p'); mtu=$(cat /sys/class/net/$dev/mtu); `+
+			`case $p in *:*) h=48;; *) h=28;; esac; `+
+			`if ping -c 1 -W 2 -M do -s $((mtu-h)) $p >/dev/null 2>&1; then r=ok; elif ping -c 1 -W 2 $p >/dev/null 2>&1; then r=fragmented; else r=unreachable; fi; `+
+			`echo "$p $dev $mtu $r"; done`, strings.Join(peerStrs, " "))
+		out, err := tc.exec(ctx, pod, script)
+		if err != nil {
+			return StatusFail, []string{err.Error()}
+		}
+		status := StatusPass
+		var details []string
+		minMTU := 0
+		for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) != 4 {
+				continue
+			}
+			peer, dev, mtuStr, result := fields[0], fields[1], fields[2], fields[3]
+			mtu, _ := strconv.Atoi(mtuStr)
+			switch result {
+			case "ok":
+				if minMTU == 0 || mtu < minMTU {
+					minMTU = mtu
+				}
+			case "fragmented":
+				status = StatusFail
+				details = append(details, fmt.Sprintf("the MTU of the path to %s is lower than the MTU %d of %s, set defaultMTU to a lower value", peer, mtu, dev))
+			default:
+				if status == StatusPass {
+					status = StatusWarning
+				}
+				details = append(details, fmt.Sprintf("%s is unreachable with ICMP", peer))
+			}
+		}
+		if minMTU > 0 {
+			isIPv6 := nodeIP(node).To4() == nil
+			details = append(details, fmt.Sprintf("transport MTU %d, Pod MTU %d in %s mode", minMTU, minMTU-mtuDeductions[isIPv6], networkConfig.TrafficEncapMode))
+		}
+		return status, details
+	})
+}
@@ -0,0 +1,256 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/utils/pointer"
+
+	"antrea.io/antrea/pkg/antctl/output"
+	"antrea.io/antrea/pkg/antctl/raw"
+	antrea "antrea.io/antrea/pkg/client/clientset/versioned"
+)
+
+const (
+	checkerName          = "cluster-checker"
+	defaultCheckerImage  = "projects.registry.vmware.com/antrea/netshoot:v0.1"
+	defaultReadyTimeout  = 2 * time.Minute
+	testNamespacePrefix  = "antrea-check"
+	linuxNodeSelectorKey = "kubernetes.io/os"
+)
+
+var (
+	Command *cobra.Command
+	option  = &struct {
+		image        string
+		readyTimeout time.Duration
+		outputType   string
+	}{}
+	getClients = getConfigAndClients
+	// newPodExecutor is parameterized for testing.
+	newPodExecutor = newRemotePodExecutor
+)
+
+func init() {
+	Command = &cobra.Command{
+		Use:   "cluster",
+		Short: "Check whether the cluster meets the requirements of Antrea",
+		Long: "Run connectivity and configuration checks from within the cluster, before or after installing Antrea. A checker Pod using the host network is deployed " +
+			"to each Linux Node in a temporary Namespace, from which the OVS kernel module, the required sysctls, the reachability of the Antrea API ports and the MTU of " +
+			"the path between Nodes are checked. When Antrea is installed, the status of its components and the conflicts between proxyAll and kube-proxy are checked too. " +
+			"The command fails if any check fails.",
+		Example: `  Check the cluster
+  $ antctl check cluster
+  Check the cluster using a custom checker image, and print the report in JSON
+  $ antctl check cluster --image my-registry/netshoot:v0.1 -o json`,
+		RunE: runE,
+		Args: cobra.NoArgs,
+	}
+	Command.Flags().StringVar(&option.image, "image", defaultCheckerImage, "image of the checker Pods, which must provide bash, timeout, ip and ping")
+	Command.Flags().DurationVar(&option.readyTimeout, "ready-timeout", defaultReadyTimeout, "how long to wait for the checker Pods to be ready")
+	Command.Flags().StringVarP(&option.outputType, "output", "o", "table", "output type: table (default), json, yaml")
+}
+
+// podExecutor runs a shell script in a container of a Pod and returns its stdout.
+type podExecutor func(ctx context.Context, pod *corev1.Pod, script string) (string, error)
+
+func getConfigAndClients(cmd *cobra.Command) (*rest.Config, kubernetes.Interface, antrea.Interface, error) {
+	kubeconfig, err := raw.ResolveKubeconfig(cmd)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	k8sClientset, antreaClientset, err := raw.SetupClients(kubeconfig)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create clientset: %w", err)
+	}
+	return kubeconfig, k8sClientset, antreaClientset, nil
+}
+
+func newRemotePodExecutor(kubeconfig *rest.Config, client kubernetes.Interface) podExecutor {
+	return func(ctx context.Context, pod *corev1.Pod, script string) (string, error) {
+		request := client.CoreV1().RESTClient().Post().
+			Namespace(pod.Namespace).
+			Resource("pods").
+			Name(pod.Name).
+			SubResource("exec").
+			VersionedParams(&corev1.PodExecOptions{
+				Container: checkerName,
+				Command:   []string{"sh", "-c", script},
+				Stdout:    true,
+				Stderr:    true,
+			}, scheme.ParameterCodec)
+		executor, err := remotecommand.NewSPDYExecutor(kubeconfig, "POST", request.URL())
+		if err != nil {
+			return "", err
+		}
+		var stdout, stderr bytes.Buffer
+		if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr}); err != nil {
+			return stdout.String(), fmt.Errorf("error when running command in Pod %s/%s: %w, stderr: %s", pod.Namespace, pod.Name, err, stderr.String())
+		}
+		return stdout.String(), nil
+	}
+}
+
+func runE(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	kubeconfig, k8sClient, antreaClient, err := getClients(cmd)
+	if err != nil {
+		return err
+	}
+	tc := &testContext{
+		client:       k8sClient,
+		antreaClient: antreaClient,
+		namespace:    testNamespacePrefix + "-" + rand.String(5),
+		exec:         newPodExecutor(kubeconfig, k8sClient),
+	}
+	defer func() {
+		if err := tc.teardown(); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Failed to delete Namespace %s, please delete it manually: %v\n", tc.namespace, err)
+		}
+	}()
+	if err := tc.setup(ctx, option.image, option.readyTimeout); err != nil {
+		return err
+	}
+	report := tc.run(ctx)
+
+	switch option.outputType {
+	case "json":
+		err = output.JsonOutput(report, cmd.OutOrStdout())
+	case "yaml":
+		err = output.YamlOutput(report, cmd.OutOrStdout())
+	default:
+		err = tableOutput(report, cmd.OutOrStdout())
+	}
+	if err != nil {
+		return err
+	}
+	if failed := report.failedChecks(); failed > 0 {
+		return fmt.Errorf("%d checks failed", failed)
+	}
+	return nil
+}
+
+func newCheckerDaemonSet(namespace, image string) *appsv1.DaemonSet {
+	labels := map[string]string{"app": "antrea", "component": checkerName}
+	hostPathType := corev1.HostPathDirectory
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: checkerName, Namespace: namespace, Labels: labels},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					// The checks are about the Nodes, and they must be able to run before a CNI is installed.
+					HostNetwork:  true,
+					NodeSelector: map[string]string{linuxNodeSelectorKey: "linux"},
+					// Tolerate all taints, including the ones of the control-plane Nodes and of the Nodes which are
+					// not ready because no CNI is installed yet.
+					Tolerations:                   []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					TerminationGracePeriodSeconds: pointer.Int64(0),
+					Containers: []corev1.Container{{
+						Name:    checkerName,
+						Image:   image,
+						Command: []string{"sleep", "3600"},
+						SecurityContext: &corev1.SecurityContext{
+							Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"NET_RAW"}},
+						},
+						VolumeMounts: []corev1.VolumeMount{{Name: "host-lib-modules", MountPath: "/lib/modules", ReadOnly: true}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "host-lib-modules",
+						VolumeSource: corev1.VolumeSource{
+							HostPath: &corev1.HostPathVolumeSource{Path: "/lib/modules", Type: &hostPathType},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// setup lists the Linux Nodes, and deploys a checker Pod to each of them in the test Namespace.
+func (tc *testContext) setup(ctx context.Context, image string, readyTimeout time.Duration) error {
+	nodes, err := tc.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: linuxNodeSelectorKey + "=linux"})
+	if err != nil {
+		return fmt.Errorf("failed to list Nodes: %w", err)
+	}
+	if len(nodes.Items) == 0 {
+		return fmt.Errorf("no Linux Node found")
+	}
+	tc.nodes = nodes.Items
+	sort.Slice(tc.nodes, func(i, j int) bool { return tc.nodes[i].Name < tc.nodes[j].Name })
+
+	if _, err := tc.client.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: tc.namespace}}, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create Namespace %s: %w", tc.namespace, err)
+	}
+	if _, err := tc.client.AppsV1().DaemonSets(tc.namespace).Create(ctx, newCheckerDaemonSet(tc.namespace, image), metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create checker DaemonSet: %w", err)
+	}
+	if err := wait.PollImmediate(time.Second, readyTimeout, func() (bool, error) {
+		ds, err := tc.client.AppsV1().DaemonSets(tc.namespace).Get(ctx, checkerName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return ds.Status.DesiredNumberScheduled > 0 && ds.Status.NumberReady == ds.Status.DesiredNumberScheduled, nil
+	}); err != nil {
+		return fmt.Errorf("checker Pods are not ready, check the Pods in Namespace %s: %w", tc.namespace, err)
+	}
+	pods, err := tc.client.CoreV1().Pods(tc.namespace).List(ctx, metav1.ListOptions{LabelSelector: "component=" + checkerName})
+	if err != nil {
+		return fmt.Errorf("failed to list checker Pods: %w", err)
+	}
+	tc.checkerPods = make(map[string]*corev1.Pod, len(pods.Items))
+	for i := range pods.Items {
+		tc.checkerPods[pods.Items[i].Spec.NodeName] = &pods.Items[i]
+	}
+	return nil
+}
+
+// teardown deletes the test Namespace, along with the checker Pods. It doesn't wait for the deletion to complete.
+func (tc *testContext) teardown() error {
+	err := tc.client.CoreV1().Namespaces().Delete(context.TODO(), tc.namespace, metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func tableOutput(report *Report, writer io.Writer) error {
+	rows := [][]string{{"CHECK", "STATUS", "DETAILS"}}
+	for _, result := range report.Results {
+		rows = append(rows, []string{result.Check, string(result.Status), strings.Join(result.Details, "; ")})
+	}
+	numRows, numCols := len(rows), len(rows[0])
+	widths := output.GetColumnWidths(numRows, numCols, rows)
+	return output.ConstructTable(numRows, numCols, widths, rows, writer)
+}
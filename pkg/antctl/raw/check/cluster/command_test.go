@@ -0,0 +1,200 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	crdv1beta1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
+	antreafakeclientset "antrea.io/antrea/pkg/client/clientset/versioned/fake"
+)
+
+func newNode(name, ip string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{linuxNodeSelectorKey: "linux"}},
+		Status:     corev1.NodeStatus{Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: ip}}},
+	}
+}
+
+func newAntreaObjects(agentConf string, readyAgents int32) []runtime.Object {
+	agent := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: antreaAgentDaemonSet, Namespace: antreaNamespace},
+		Spec: appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+			Name:         antreaConfigVolume,
+			VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "antrea-config"}}},
+		}}}}},
+		Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, NumberReady: readyAgents},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "antrea-config", Namespace: antreaNamespace},
+		Data:       map[string]string{antreaAgentConfigKey: agentConf},
+	}
+	controller := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: antreaControllerName, Namespace: antreaNamespace},
+		Status:     appsv1.DeploymentStatus{AvailableReplicas: 1},
+	}
+	return []runtime.Object{agent, configMap, controller}
+}
+
+// fakeExec returns the canned outputs of the scripts run on each Node, identified by a keyword of the script.
+func fakeExec(outputs map[string]map[string]string) podExecutor {
+	return func(ctx context.Context, pod *corev1.Pod, script string) (string, error) {
+		for keyword, nodeOutputs := range outputs {
+			if strings.Contains(script, keyword) {
+				return nodeOutputs[pod.Spec.NodeName], nil
+			}
+		}
+		return "", fmt.Errorf("unexpected script %q", script)
+	}
+}
+
+func newTestContext(objects []runtime.Object, agentInfos []runtime.Object, exec podExecutor) *testContext {
+	nodes := []corev1.Node{newNode("node1", "192.168.0.1"), newNode("node2", "192.168.0.2")}
+	tc := &testContext{
+		client:       fake.NewSimpleClientset(objects...),
+		antreaClient: antreafakeclientset.NewSimpleClientset(agentInfos...),
+		namespace:    "antrea-check-test",
+		exec:         exec,
+		nodes:        nodes,
+		checkerPods:  map[string]*corev1.Pod{},
+	}
+	for _, node := range nodes {
+		tc.checkerPods[node.Name] = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: checkerName + "-" + node.Name, Namespace: tc.namespace},
+			Spec:       corev1.PodSpec{NodeName: node.Name},
+		}
+	}
+	return tc
+}
+
+func TestRunBeforeInstallation(t *testing.T) {
+	tc := newTestContext(nil, nil, fakeExec(map[string]map[string]string{
+		"openvswitch": {"node1": "loaded\n", "node2": "missing\n"},
+		"/proc/sys":   {"node1": "net/ipv4/ip_forward 1\n", "node2": "net/ipv4/ip_forward 0\n"},
+		"/dev/tcp": {
+			"node1": "192.168.0.2:10349 1\n192.168.0.2:10350 1\n",
+			"node2": "192.168.0.1:10349 124\n192.168.0.1:10350 1\n",
+		},
+		"ping": {"node1": "192.168.0.2 eth0 1500 ok\n", "node2": "192.168.0.1 eth0 9000 fragmented\n"},
+	}))
+	report := tc.run(context.TODO())
+	assert.Equal(t, []Result{
+		{Check: "Antrea components", Status: StatusSkip, Details: []string{"Antrea is not installed"}},
+		{Check: "proxyAll and kube-proxy", Status: StatusSkip, Details: []string{"Antrea is not installed"}},
+		{Check: "OVS kernel module", Status: StatusFail, Details: []string{"node2: openvswitch module not found, install it or use the OVS netdev datapath"}},
+		{Check: "Required sysctls", Status: StatusFail, Details: []string{"node2: net.ipv4.ip_forward must be set to 1"}},
+		{Check: "Antrea API ports", Status: StatusFail, Details: []string{
+			"node1: connection to 192.168.0.2:10349 refused",
+			"node1: connection to 192.168.0.2:10350 refused",
+			"node2: connection to 192.168.0.1:10349 timed out, it may be blocked by a firewall",
+			"node2: connection to 192.168.0.1:10350 refused",
+		}},
+		{Check: "Node-to-Node MTU", Status: StatusFail, Details: []string{
+			"node1: transport MTU 1500, Pod MTU 1450 in encap mode",
+			"node2: the MTU of the path to 192.168.0.1 is lower than the MTU 9000 of eth0, set defaultMTU to a lower value",
+		}},
+	}, report.Results)
+	assert.Equal(t, 4, report.failedChecks())
+}
+
+func TestRunAfterInstallation(t *testing.T) {
+	objects := newAntreaObjects("trafficEncapMode: noEncap\nantreaProxy:\n  proxyAll: true\n", 2)
+	objects = append(objects, &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: kubeProxyDaemonSet, Namespace: antreaNamespace}})
+	agentInfos := []runtime.Object{
+		&crdv1beta1.AntreaAgentInfo{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+			AgentConditions: []crdv1beta1.AgentCondition{{
+				Type:    crdv1beta1.KubeProxyCompatible,
+				Status:  corev1.ConditionFalse,
+				Reason:  "IPVSStrictARPDisabled",
+				Message: "kube-proxy is running in IPVS mode without strictARP",
+			}},
+		},
+		&crdv1beta1.AntreaAgentInfo{
+			ObjectMeta:      metav1.ObjectMeta{Name: "node2"},
+			AgentConditions: []crdv1beta1.AgentCondition{{Type: crdv1beta1.KubeProxyCompatible, Status: corev1.ConditionTrue}},
+		},
+	}
+	tc := newTestContext(objects, agentInfos, fakeExec(map[string]map[string]string{
+		"openvswitch": {"node1": "loaded\n", "node2": "available\n"},
+		"/proc/sys":   {"node1": "net/ipv4/ip_forward 1\n", "node2": "net/ipv4/ip_forward 1\n"},
+		"/dev/tcp": {
+			"node1": "192.168.0.2:10349 0\n192.168.0.2:10350 0\n",
+			"node2": "192.168.0.1:10349 0\n192.168.0.1:10350 1\n",
+		},
+		"ping": {"node1": "192.168.0.2 eth0 1500 ok\n", "node2": "192.168.0.1 eth0 1500 unreachable\n"},
+	}))
+	report := tc.run(context.TODO())
+	assert.Equal(t, []Result{
+		{Check: "Antrea components", Status: StatusPass, Details: []string{"2/2 Antrea Agents ready", "Antrea Controller available"}},
+		{Check: "proxyAll and kube-proxy", Status: StatusFail, Details: []string{
+			"kube-proxy is deployed and takes priority over AntreaProxy for NodePort Services, consider removing it",
+			"node1: IPVSStrictARPDisabled: kube-proxy is running in IPVS mode without strictARP",
+		}},
+		{Check: "OVS kernel module", Status: StatusPass, Details: []string{"node2: openvswitch module can be loaded"}},
+		{Check: "Required sysctls", Status: StatusPass},
+		{Check: "Antrea API ports", Status: StatusFail, Details: []string{"node2: connection to 192.168.0.1:10350 refused"}},
+		{Check: "Node-to-Node MTU", Status: StatusWarning, Details: []string{
+			"node1: transport MTU 1500, Pod MTU 1500 in noEncap mode",
+			"node2: 192.168.0.1 is unreachable with ICMP",
+		}},
+	}, report.Results)
+}
+
+func TestRunWithKubeProxyRemoved(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		agentConf      string
+		expectedStatus Status
+	}{
+		{name: "proxyAll with kubeAPIServerOverride", agentConf: "kubeAPIServerOverride: https://192.168.0.1:6443\nantreaProxy:\n  proxyAll: true\n", expectedStatus: StatusPass},
+		{name: "proxyAll without kubeAPIServerOverride", agentConf: "antreaProxy:\n  proxyAll: true\n", expectedStatus: StatusFail},
+		{name: "proxyAll disabled", agentConf: "", expectedStatus: StatusWarning},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTestContext(newAntreaObjects(tt.agentConf, 2), nil, nil)
+			agentConfig, err := tc.getAgentConfig(context.TODO())
+			require.NoError(t, err)
+			tc.agentConfig = agentConfig
+			status, _ := checkKubeProxyConflicts(context.TODO(), tc)
+			assert.Equal(t, tt.expectedStatus, status)
+		})
+	}
+}
+
+func TestTableOutput(t *testing.T) {
+	report := &Report{Results: []Result{
+		{Check: "Antrea components", Status: StatusSkip, Details: []string{"Antrea is not installed"}},
+		{Check: "Required sysctls", Status: StatusPass},
+	}}
+	var buf bytes.Buffer
+	require.NoError(t, tableOutput(report, &buf))
+	assert.Equal(t, `CHECK             STATUS DETAILS                
+Antrea components Skip   Antrea is not installed
+Required sysctls  Pass   <NONE>                 
+`, buf.String())
+}
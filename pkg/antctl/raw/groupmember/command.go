@@ -0,0 +1,183 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupmember
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"antrea.io/antrea/pkg/antctl/output"
+	"antrea.io/antrea/pkg/antctl/raw"
+	"antrea.io/antrea/pkg/antctl/runtime"
+	"antrea.io/antrea/pkg/antctl/transform/common"
+	cpv1beta "antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	antrea "antrea.io/antrea/pkg/client/clientset/versioned"
+	cpclient "antrea.io/antrea/pkg/client/clientset/versioned/typed/controlplane/v1beta2"
+)
+
+// defaultChunkSize is the default number of members retrieved per request.
+const defaultChunkSize = 500
+
+var (
+	Command *cobra.Command
+	option  = &struct {
+		namespace  string
+		chunkSize  int64
+		outputType string
+	}{}
+	getClient = getControlplaneClient
+)
+
+func init() {
+	Command = &cobra.Command{
+		Use:     "groupmember NAME",
+		Aliases: []string{"groupmembers", "gm"},
+		Short:   "Print the members of a ClusterGroup or Group",
+		Long: "Print the effective members of a ClusterGroup, or of a Group when a Namespace is provided. " +
+			"Members are retrieved from the Antrea Controller in chunks, to avoid oversized responses for large groups.",
+		Example: `  Get the members of ClusterGroup cg1
+  $ antctl get groupmember cg1
+  Get the members of Group g1 in Namespace ns1, retrieving 100 members per request
+  $ antctl get groupmember g1 -n ns1 --chunk-size 100
+  Get the members of ClusterGroup cg1 in JSON format
+  $ antctl get groupmember cg1 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: runE,
+	}
+	Command.Flags().StringVarP(&option.namespace, "namespace", "n", "", "Namespace of the Group. If empty, the members of the ClusterGroup are printed.")
+	Command.Flags().Int64Var(&option.chunkSize, "chunk-size", defaultChunkSize, "Maximum number of members retrieved per request. Set to 0 to retrieve all members with a single request.")
+	Command.Flags().StringVarP(&option.outputType, "output", "o", "table", "output type: table (default), json, yaml")
+}
+
+// Response describes a member of a ClusterGroup or Group. Kind is one of Pod, ExternalEntity, Node and IPBlock.
+type Response struct {
+	Kind      string `json:"kind" yaml:"kind"`
+	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	Name      string `json:"name,omitempty" yaml:"name,omitempty"`
+	IPs       string `json:"ips,omitempty" yaml:"ips,omitempty"`
+}
+
+var _ common.TableOutput = new(Response)
+
+func (r Response) GetTableHeader() []string {
+	return []string{"KIND", "NAMESPACE", "NAME", "IPS"}
+}
+
+func (r Response) GetTableRow(maxColumnLength int) []string {
+	return []string{r.Kind, r.Namespace, r.Name, r.IPs}
+}
+
+// SortRows returns false as members are already sorted by the Antrea Controller.
+func (r Response) SortRows() bool {
+	return false
+}
+
+func getControlplaneClient(cmd *cobra.Command) (cpclient.ControlplaneV1beta2Interface, error) {
+	kubeconfig, err := raw.ResolveKubeconfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if runtime.InPod {
+		raw.SetupLocalKubeconfig(kubeconfig)
+	}
+	client, err := antrea.NewForConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clientset: %w", err)
+	}
+	return client.ControlplaneV1beta2(), nil
+}
+
+func runE(cmd *cobra.Command, args []string) error {
+	if option.chunkSize < 0 {
+		return fmt.Errorf("invalid chunk size %d, it must not be negative", option.chunkSize)
+	}
+	client, err := getClient(cmd)
+	if err != nil {
+		return err
+	}
+	members, ipBlocks, err := getMembers(cmd.Context(), client, option.namespace, args[0], option.chunkSize)
+	if err != nil {
+		return err
+	}
+	resp := make([]Response, 0, len(ipBlocks)+len(members))
+	for _, ipBlock := range ipBlocks {
+		ipNet := net.IPNet{IP: net.IP(ipBlock.IP), Mask: net.CIDRMask(int(ipBlock.PrefixLength), len(ipBlock.IP)*8)}
+		resp = append(resp, Response{Kind: "IPBlock", IPs: ipNet.String()})
+	}
+	for _, member := range members {
+		resp = append(resp, memberTransform(member))
+	}
+	switch option.outputType {
+	case "json":
+		return output.JsonOutput(resp, cmd.OutOrStdout())
+	case "yaml":
+		return output.YamlOutput(resp, cmd.OutOrStdout())
+	default:
+		return output.TableOutputForGetCommands(resp, cmd.OutOrStdout())
+	}
+}
+
+// getMembers retrieves all the members of the ClusterGroup or Group, following the continue tokens returned by the
+// Antrea Controller until the last chunk is received.
+func getMembers(ctx context.Context, client cpclient.ControlplaneV1beta2Interface, namespace, name string, chunkSize int64) ([]cpv1beta.GroupMember, []cpv1beta.IPNet, error) {
+	var members []cpv1beta.GroupMember
+	var ipBlocks []cpv1beta.IPNet
+	options := &cpv1beta.PaginationGetOptions{Limit: chunkSize}
+	for {
+		var continueToken string
+		if namespace != "" {
+			groupMembers, err := client.GroupMembers(namespace).GetWithPagination(ctx, name, options)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error when getting members of Group %s/%s: %w", namespace, name, err)
+			}
+			members = append(members, groupMembers.EffectiveMembers...)
+			ipBlocks = groupMembers.EffectiveIPBlocks
+			continueToken = groupMembers.Continue
+		} else {
+			clusterGroupMembers, err := client.ClusterGroupMembers().GetWithPagination(ctx, name, options)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error when getting members of ClusterGroup %s: %w", name, err)
+			}
+			members = append(members, clusterGroupMembers.EffectiveMembers...)
+			ipBlocks = clusterGroupMembers.EffectiveIPBlocks
+			continueToken = clusterGroupMembers.Continue
+		}
+		if continueToken == "" {
+			return members, ipBlocks, nil
+		}
+		options.Continue = continueToken
+	}
+}
+
+func memberTransform(member cpv1beta.GroupMember) Response {
+	ips := make([]string, 0, len(member.IPs))
+	for _, ip := range member.IPs {
+		ips = append(ips, net.IP(ip).String())
+	}
+	resp := Response{IPs: strings.Join(ips, ",")}
+	switch {
+	case member.Pod != nil:
+		resp.Kind, resp.Namespace, resp.Name = "Pod", member.Pod.Namespace, member.Pod.Name
+	case member.ExternalEntity != nil:
+		resp.Kind, resp.Namespace, resp.Name = "ExternalEntity", member.ExternalEntity.Namespace, member.ExternalEntity.Name
+	case member.Node != nil:
+		resp.Kind, resp.Name = "Node", member.Node.Name
+	}
+	return resp
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupmember
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+
+	cpv1beta "antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	antreafakeclient "antrea.io/antrea/pkg/client/clientset/versioned/fake"
+	cpclient "antrea.io/antrea/pkg/client/clientset/versioned/typed/controlplane/v1beta2"
+)
+
+func newPodMember(name string, ip byte) cpv1beta.GroupMember {
+	return cpv1beta.GroupMember{
+		Pod: &cpv1beta.PodReference{Name: name, Namespace: "ns1"},
+		IPs: []cpv1beta.IPAddress{[]byte{10, 0, 0, ip}},
+	}
+}
+
+// newFakeClient returns a fake client which returns the provided members in chunks of the requested size, using the
+// index of the next member as the continue token.
+func newFakeClient(members []cpv1beta.GroupMember, ipBlocks []cpv1beta.IPNet) (cpclient.ControlplaneV1beta2Interface, *int) {
+	client := antreafakeclient.NewSimpleClientset()
+	requests := 0
+	reactor := func(action k8stesting.Action) (bool, runtime.Object, error) {
+		requests++
+		options := action.(k8stesting.GenericAction).GetValue().(*cpv1beta.PaginationGetOptions)
+		begin := 0
+		if options.Continue != "" {
+			begin, _ = strconv.Atoi(options.Continue)
+		}
+		end := len(members)
+		continueToken := ""
+		if options.Limit > 0 && begin+int(options.Limit) < end {
+			end = begin + int(options.Limit)
+			continueToken = strconv.Itoa(end)
+		}
+		objectMeta := metav1.ObjectMeta{Name: "g1", Namespace: action.GetNamespace()}
+		if action.GetResource().Resource == "groupmembers" {
+			return true, &cpv1beta.GroupMembers{ObjectMeta: objectMeta, EffectiveMembers: members[begin:end], EffectiveIPBlocks: ipBlocks, Continue: continueToken}, nil
+		}
+		return true, &cpv1beta.ClusterGroupMembers{ObjectMeta: objectMeta, EffectiveMembers: members[begin:end], EffectiveIPBlocks: ipBlocks, Continue: continueToken}, nil
+	}
+	client.PrependReactor("get", "groupmembers", reactor)
+	client.PrependReactor("get", "clustergroupmembers", reactor)
+	return client.ControlplaneV1beta2(), &requests
+}
+
+func TestGetMembers(t *testing.T) {
+	members := []cpv1beta.GroupMember{newPodMember("pod1", 1), newPodMember("pod2", 2), newPodMember("pod3", 3)}
+	tests := []struct {
+		name             string
+		namespace        string
+		chunkSize        int64
+		expectedRequests int
+	}{
+		{
+			name:             "clustergroup-single-request",
+			chunkSize:        0,
+			expectedRequests: 1,
+		},
+		{
+			name:             "clustergroup-chunks",
+			chunkSize:        2,
+			expectedRequests: 2,
+		},
+		{
+			name:             "group-chunks",
+			namespace:        "ns1",
+			chunkSize:        1,
+			expectedRequests: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := newFakeClient(members, nil)
+			actualMembers, _, err := getMembers(context.TODO(), client, tt.namespace, "g1", tt.chunkSize)
+			require.NoError(t, err)
+			assert.Equal(t, members, actualMembers)
+			assert.Equal(t, tt.expectedRequests, *requests)
+		})
+	}
+}
+
+func TestRunE(t *testing.T) {
+	members := []cpv1beta.GroupMember{
+		newPodMember("pod1", 1),
+		{
+			ExternalEntity: &cpv1beta.ExternalEntityReference{Name: "ee1", Namespace: "ns1"},
+			IPs:            []cpv1beta.IPAddress{[]byte{10, 0, 1, 1}, []byte{10, 0, 1, 2}},
+		},
+	}
+	ipBlocks := []cpv1beta.IPNet{{IP: []byte{192, 168, 0, 0}, PrefixLength: 16}}
+	tests := []struct {
+		name           string
+		outputType     string
+		chunkSize      int64
+		expectedOutput string
+		expectedErr    string
+	}{
+		{
+			name:       "table",
+			outputType: "table",
+			chunkSize:  1,
+			expectedOutput: "KIND           NAMESPACE NAME   IPS              \n" +
+				"IPBlock        <NONE>    <NONE> 192.168.0.0/16   \n" +
+				"Pod            ns1       pod1   10.0.0.1         \n" +
+				"ExternalEntity ns1       ee1    10.0.1.1,10.0.1.2\n",
+		},
+		{
+			name:       "json",
+			outputType: "json",
+			chunkSize:  1,
+			expectedOutput: `[
+  {
+    "kind": "IPBlock",
+    "ips": "192.168.0.0/16"
+  },
+  {
+    "kind": "Pod",
+    "namespace": "ns1",
+    "name": "pod1",
+    "ips": "10.0.0.1"
+  },
+  {
+    "kind": "ExternalEntity",
+    "namespace": "ns1",
+    "name": "ee1",
+    "ips": "10.0.1.1,10.0.1.2"
+  }
+]
+`,
+		},
+		{
+			name:        "invalid-chunk-size",
+			outputType:  "table",
+			chunkSize:   -1,
+			expectedErr: "invalid chunk size -1, it must not be negative",
+		},
+	}
+	defer func() {
+		getClient = getControlplaneClient
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newFakeClient(members, ipBlocks)
+			getClient = func(cmd *cobra.Command) (cpclient.ControlplaneV1beta2Interface, error) {
+				return client, nil
+			}
+			option.outputType, option.chunkSize = tt.outputType, tt.chunkSize
+			buf := new(bytes.Buffer)
+			Command.SetOut(buf)
+			Command.SetContext(context.Background())
+			err := runE(Command, []string{"cg1"})
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, buf.String())
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	certutil "k8s.io/client-go/util/cert"
 
 	agentapiserver "antrea.io/antrea/pkg/agent/apiserver"
 	"antrea.io/antrea/pkg/antctl/runtime"
@@ -101,6 +102,21 @@ func GetControllerCACert(ctx context.Context, client kubernetes.Interface) ([]by
 	return []byte(ca), nil
 }
 
+// isCABundle returns whether the provided PEM data only includes CA certificates, in which case the
+// Agent API certificate is not self-signed.
+func isCABundle(data []byte) bool {
+	certs, err := certutil.ParseCertsPEM(data)
+	if err != nil {
+		return false
+	}
+	for _, c := range certs {
+		if !c.IsCA {
+			return false
+		}
+	}
+	return true
+}
+
 func CreateAgentClientCfgFromObjects(
 	ctx context.Context,
 	k8sClientset kubernetes.Interface,
@@ -132,8 +148,14 @@ func CreateAgentClientCfgFromObjects(
 			return nil, fmt.Errorf("no cert available")
 		}
 		cfg.Insecure = false
-		// The self-signed Agent certificate is only valid for localhost / 127.0.0.1
-		cfg.ServerName = "localhost"
+		if isCABundle(cert) {
+			// The Agent certificate is signed by the CA published by the Antrea Controller and
+			// issued for the Node, which validates the identity of the Agent.
+			cfg.ServerName = node.Name
+		} else {
+			// The self-signed Agent certificate is only valid for localhost / 127.0.0.1
+			cfg.ServerName = "localhost"
+		}
 		cfg.CAData = cert
 	}
 
@@ -16,6 +16,8 @@ package raw
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"fmt"
 	"net"
 	"testing"
@@ -27,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	fakeclient "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
+	certutil "k8s.io/client-go/util/cert"
 
 	"antrea.io/antrea/pkg/apis"
 	v1beta1 "antrea.io/antrea/pkg/apis/crd/v1beta1"
@@ -80,13 +83,20 @@ var (
 func TestCreateAgentClientCfg(t *testing.T) {
 	ctx := context.Background()
 	fakeCertData := []byte("foobar")
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	caCert, err := certutil.NewSelfSignedCACert(certutil.Config{CommonName: "antrea-agent-serving-ca"}, caKey)
+	require.NoError(t, err)
+	caCertData, err := certutil.EncodeCertificates(caCert)
+	require.NoError(t, err)
 	apiHost := fmt.Sprintf("https://%s", net.JoinHostPort(nodeIP, fmt.Sprint(apis.AntreaAgentAPIPort)))
 
 	testCases := []struct {
-		name        string
-		certData    []byte
-		insecure    bool
-		expectedErr string
+		name               string
+		certData           []byte
+		insecure           bool
+		expectedServerName string
+		expectedErr        string
 	}{
 		{
 			name:     "insecure",
@@ -94,9 +104,16 @@ func TestCreateAgentClientCfg(t *testing.T) {
 			insecure: true,
 		},
 		{
-			name:     "secure",
-			certData: fakeCertData,
-			insecure: false,
+			name:               "secure",
+			certData:           fakeCertData,
+			insecure:           false,
+			expectedServerName: "localhost",
+		},
+		{
+			name:               "secure with CA bundle",
+			certData:           caCertData,
+			insecure:           false,
+			expectedServerName: node.Name,
 		},
 		{
 			name:        "secure missing cert",
@@ -122,7 +139,7 @@ func TestCreateAgentClientCfg(t *testing.T) {
 				require.NotNil(t, cfg)
 				assert.Equal(t, tc.insecure, cfg.Insecure)
 				if !tc.insecure {
-					assert.Equal(t, "localhost", cfg.ServerName)
+					assert.Equal(t, tc.expectedServerName, cfg.ServerName)
 					assert.Equal(t, tc.certData, cfg.CAData)
 					assert.Equal(t, apiHost, cfg.Host)
 				} else {
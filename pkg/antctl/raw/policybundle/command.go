@@ -0,0 +1,189 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policybundle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+
+	"antrea.io/antrea/pkg/antctl/output"
+	"antrea.io/antrea/pkg/antctl/raw"
+	"antrea.io/antrea/pkg/antctl/runtime"
+	"antrea.io/antrea/pkg/apiserver/handlers/policybundle"
+	"antrea.io/antrea/pkg/controller/networkpolicy"
+)
+
+var (
+	Command *cobra.Command
+	option  = &struct {
+		file       string
+		outputType string
+		insecure   bool
+	}{}
+	getRestClient = getControllerRestClient
+	// stdin is parameterized for testing.
+	stdin io.Reader = os.Stdin
+)
+
+func init() {
+	Command = &cobra.Command{
+		Use:   "policy-bundle",
+		Short: "Export or diff the Antrea-native policies as a bundle",
+		Long: "Export the Antrea-native policies, along with the Tiers, ClusterGroups and Groups, as a normalized bundle: the resources are sorted, " +
+			"and the fields set by the system, including the default Tier and the generated rule names, are stripped, so that the bundle can be stored in a Git repository. " +
+			"A bundle can then be compared with the cluster to detect drift.",
+	}
+	exportCommand := &cobra.Command{
+		Use:   "export",
+		Short: "Export the Antrea-native policies as a bundle",
+		Example: `  Export the Antrea-native policies as a YAML bundle
+  $ antctl policy-bundle export > bundle.yaml`,
+		RunE: exportRunE,
+		Args: cobra.NoArgs,
+	}
+	exportCommand.Flags().StringVarP(&option.outputType, "output", "o", "yaml", "output type: yaml (default), json")
+	diffCommand := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare a bundle with the Antrea-native policies of the cluster",
+		Long: "Compare a bundle with the Antrea-native policies of the cluster. A resource is reported as Missing if it is only in the bundle, Unexpected if it is only in the cluster, " +
+			"and Modified if its labels, annotations or spec differ. The command fails if any drift is found.",
+		Example: `  Compare a bundle with the cluster
+  $ antctl policy-bundle diff -f bundle.yaml
+  Compare a bundle read from stdin with the cluster, and print the drift in JSON
+  $ cat bundle.yaml | antctl policy-bundle diff -f - -o json`,
+		RunE: diffRunE,
+		Args: cobra.NoArgs,
+	}
+	diffCommand.Flags().StringVarP(&option.file, "file", "f", "", "file containing the bundle, in JSON or YAML format. Use '-' to read from stdin.")
+	diffCommand.Flags().StringVarP(&option.outputType, "output", "o", "table", "output type: table (default), json, yaml")
+	if err := diffCommand.MarkFlagRequired("file"); err != nil {
+		panic(err)
+	}
+	Command.AddCommand(exportCommand, diffCommand)
+	if !runtime.InPod {
+		Command.PersistentFlags().BoolVar(&option.insecure, "insecure", false, "Skip TLS verification when connecting to Antrea API.")
+	}
+}
+
+func getControllerRestClient(cmd *cobra.Command) (rest.Interface, error) {
+	kubeconfig, err := raw.ResolveKubeconfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+	cfg := rest.CopyConfig(kubeconfig)
+	cfg.GroupVersion = &schema.GroupVersion{Group: "", Version: ""}
+	if runtime.InPod {
+		raw.SetupLocalKubeconfig(cfg)
+	} else {
+		k8sClientset, antreaClientset, err := raw.SetupClients(kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create clientset: %w", err)
+		}
+		if cfg, err = raw.CreateControllerClientCfg(cmd.Context(), k8sClientset, antreaClientset, cfg, option.insecure); err != nil {
+			return nil, fmt.Errorf("error when creating controller client config: %w", err)
+		}
+	}
+	client, err := rest.RESTClientFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rest client: %w", err)
+	}
+	return client, nil
+}
+
+func exportRunE(cmd *cobra.Command, _ []string) error {
+	client, err := getRestClient(cmd)
+	if err != nil {
+		return err
+	}
+	rawResp, err := client.Get().AbsPath("/policybundle").DoRaw(context.TODO())
+	if err != nil {
+		return fmt.Errorf("error when exporting policy bundle: %w", err)
+	}
+	var bundle networkpolicy.PolicyBundle
+	if err := json.Unmarshal(rawResp, &bundle); err != nil {
+		return fmt.Errorf("failed to unmarshal policy bundle: %w", err)
+	}
+	if option.outputType == "json" {
+		return output.JsonOutput(bundle, cmd.OutOrStdout())
+	}
+	return output.YamlOutput(bundle, cmd.OutOrStdout())
+}
+
+func diffRunE(cmd *cobra.Command, _ []string) error {
+	var data []byte
+	var err error
+	if option.file == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(option.file)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	body, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse policy bundle: %w", err)
+	}
+	client, err := getRestClient(cmd)
+	if err != nil {
+		return err
+	}
+	rawResp, err := client.Post().AbsPath("/policybundle/diff").Body(body).DoRaw(context.TODO())
+	if err != nil {
+		return fmt.Errorf("error when diffing policy bundle: %w", err)
+	}
+	var resp policybundle.DiffResponse
+	if err := json.Unmarshal(rawResp, &resp); err != nil {
+		return fmt.Errorf("failed to unmarshal policy bundle diff: %w", err)
+	}
+	switch option.outputType {
+	case "json":
+		err = output.JsonOutput(resp, cmd.OutOrStdout())
+	case "yaml":
+		err = output.YamlOutput(resp, cmd.OutOrStdout())
+	default:
+		err = tableOutput(resp, cmd.OutOrStdout())
+	}
+	if err != nil {
+		return err
+	}
+	if !resp.InSync {
+		return fmt.Errorf("found %d drifted resources", len(resp.Drifts))
+	}
+	return nil
+}
+
+func tableOutput(resp policybundle.DiffResponse, writer io.Writer) error {
+	if resp.InSync {
+		_, err := fmt.Fprintln(writer, "The cluster matches the policy bundle")
+		return err
+	}
+	rows := [][]string{{"KIND", "NAMESPACE", "NAME", "DRIFT", "FIELDS"}}
+	for _, drift := range resp.Drifts {
+		rows = append(rows, []string{drift.Kind, drift.Namespace, drift.Name, drift.Type, strings.Join(drift.Fields, ",")})
+	}
+	numRows, numCols := len(rows), len(rows[0])
+	widths := output.GetColumnWidths(numRows, numCols, rows)
+	return output.ConstructTable(numRows, numCols, widths, rows, writer)
+}
@@ -0,0 +1,243 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policysimulation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+
+	"antrea.io/antrea/pkg/agent/apiserver/handlers/policysimulation"
+	"antrea.io/antrea/pkg/antctl/output"
+	"antrea.io/antrea/pkg/antctl/raw"
+	"antrea.io/antrea/pkg/apis/crd/v1alpha1"
+)
+
+var (
+	Command *cobra.Command
+	option  = &struct {
+		file       string
+		srcIP      string
+		dstIP      string
+		protocol   string
+		srcPort    string
+		dstPort    string
+		outputType string
+	}{}
+	getRestClient = getAgentRestClient
+	// stdin is parameterized for testing.
+	stdin io.Reader = os.Stdin
+)
+
+func init() {
+	Command = &cobra.Command{
+		Use:   "simulate-policy",
+		Short: "Simulate the NetworkPolicy evaluation of a flow",
+		Long: "Evaluate a flow against the NetworkPolicy rules realized on the Node, without sending any packet, and report the action applied to it at each stage of the NetworkPolicy pipeline. " +
+			"The flow can be provided with flags, or read from a file containing a flow record (e.g. the JSON output of 'antctl get flowrecords'), or a live-traffic Traceflow with a captured packet (e.g. the JSON or YAML of the Traceflow resource, or the JSON output of 'antctl traceflow'). " +
+			"Flags take precedence over the file. Only the rules applied to the Pods on the Node are evaluated, so the command should be run on the Nodes of both the source and the destination.",
+		Example: `  Simulate a TCP flow from 10.10.1.2 to port 80 of 10.10.2.3
+  $ antctl simulate-policy --src-ip 10.10.1.2 --dst-ip 10.10.2.3 --protocol tcp --dst-port 80
+  Simulate the flow of a flow record exported by the Flow Aggregator
+  $ antctl simulate-policy -f flowrecord.json
+  Simulate the flow captured by a live-traffic Traceflow, reading it from stdin
+  $ kubectl get traceflow tf1 -o json | kubectl exec -i -n kube-system <antrea-agent Pod> -- antctl simulate-policy -f -`,
+		RunE: runE,
+		Args: cobra.NoArgs,
+	}
+	Command.Flags().StringVarP(&option.file, "file", "f", "", "file containing a flow record or a Traceflow, in JSON or YAML format. Use '-' to read from stdin.")
+	Command.Flags().StringVar(&option.srcIP, "src-ip", "", "source IP address of the flow")
+	Command.Flags().StringVar(&option.dstIP, "dst-ip", "", "destination IP address of the flow")
+	Command.Flags().StringVar(&option.protocol, "protocol", "", "protocol of the flow: a protocol name (tcp, udp, sctp, icmp, icmpv6, igmp) or number. Defaults to tcp.")
+	Command.Flags().StringVar(&option.srcPort, "src-port", "", "source port of the flow")
+	Command.Flags().StringVar(&option.dstPort, "dst-port", "", "destination port of the flow")
+	Command.Flags().StringVarP(&option.outputType, "output", "o", "table", "output type: table (default), json, yaml")
+}
+
+// flowDescriptor contains the fields of a flow record or a Traceflow that describe a flow.
+type flowDescriptor struct {
+	// Fields of a flow record.
+	SourceIPv4Address        string `json:"sourceIPv4Address,omitempty"`
+	DestinationIPv4Address   string `json:"destinationIPv4Address,omitempty"`
+	SourceIPv6Address        string `json:"sourceIPv6Address,omitempty"`
+	DestinationIPv6Address   string `json:"destinationIPv6Address,omitempty"`
+	ProtocolIdentifier       *int32 `json:"protocolIdentifier,omitempty"`
+	SourceTransportPort      int32  `json:"sourceTransportPort,omitempty"`
+	DestinationTransportPort int32  `json:"destinationTransportPort,omitempty"`
+	// Field of the output of "antctl traceflow".
+	CapturedPacket *v1alpha1.Packet `json:"capturedPacket,omitempty"`
+	// Field of a Traceflow.
+	Status struct {
+		CapturedPacket *v1alpha1.Packet `json:"capturedPacket,omitempty"`
+	} `json:"status,omitempty"`
+}
+
+// parseFlowFile returns the URL query parameters describing the flow in the content of the file.
+func parseFlowFile(data []byte) (url.Values, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+	var descriptor flowDescriptor
+	if strings.HasPrefix(strings.TrimSpace(string(jsonData)), "[") {
+		var descriptors []flowDescriptor
+		if err := json.Unmarshal(jsonData, &descriptors); err != nil {
+			return nil, fmt.Errorf("failed to parse file: %w", err)
+		}
+		if len(descriptors) != 1 {
+			return nil, fmt.Errorf("file must contain exactly one flow, got %d", len(descriptors))
+		}
+		descriptor = descriptors[0]
+	} else if err := json.Unmarshal(jsonData, &descriptor); err != nil {
+		return nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	params := url.Values{}
+	packet := descriptor.CapturedPacket
+	if packet == nil {
+		packet = descriptor.Status.CapturedPacket
+	}
+	if packet != nil {
+		params.Set("src-ip", packet.SrcIP)
+		params.Set("dst-ip", packet.DstIP)
+		protocol := packet.IPHeader.Protocol
+		if packet.IPv6Header != nil && packet.IPv6Header.NextHeader != nil {
+			protocol = *packet.IPv6Header.NextHeader
+		}
+		params.Set("protocol", strconv.Itoa(int(protocol)))
+		if tcp := packet.TransportHeader.TCP; tcp != nil {
+			params.Set("src-port", strconv.Itoa(int(tcp.SrcPort)))
+			params.Set("dst-port", strconv.Itoa(int(tcp.DstPort)))
+		} else if udp := packet.TransportHeader.UDP; udp != nil {
+			params.Set("src-port", strconv.Itoa(int(udp.SrcPort)))
+			params.Set("dst-port", strconv.Itoa(int(udp.DstPort)))
+		}
+		return params, nil
+	}
+	srcIP, dstIP := descriptor.SourceIPv4Address, descriptor.DestinationIPv4Address
+	if srcIP == "" {
+		srcIP, dstIP = descriptor.SourceIPv6Address, descriptor.DestinationIPv6Address
+	}
+	if srcIP == "" || dstIP == "" || descriptor.ProtocolIdentifier == nil {
+		return nil, fmt.Errorf("file contains neither a flow record nor a Traceflow with a captured packet")
+	}
+	params.Set("src-ip", srcIP)
+	params.Set("dst-ip", dstIP)
+	params.Set("protocol", strconv.Itoa(int(*descriptor.ProtocolIdentifier)))
+	params.Set("src-port", strconv.Itoa(int(descriptor.SourceTransportPort)))
+	params.Set("dst-port", strconv.Itoa(int(descriptor.DestinationTransportPort)))
+	return params, nil
+}
+
+func newQueryParams() (url.Values, error) {
+	params := url.Values{}
+	if option.file != "" {
+		var data []byte
+		var err error
+		if option.file == "-" {
+			data, err = io.ReadAll(stdin)
+		} else {
+			data, err = os.ReadFile(option.file)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		if params, err = parseFlowFile(data); err != nil {
+			return nil, err
+		}
+	}
+	for name, value := range map[string]string{
+		"src-ip":   option.srcIP,
+		"dst-ip":   option.dstIP,
+		"protocol": option.protocol,
+		"src-port": option.srcPort,
+		"dst-port": option.dstPort,
+	} {
+		if value != "" {
+			params.Set(name, value)
+		}
+	}
+	if params.Get("src-ip") == "" || params.Get("dst-ip") == "" {
+		return nil, fmt.Errorf("the source and destination IPs of the flow must be provided with flags or a file")
+	}
+	return params, nil
+}
+
+func getAgentRestClient(cmd *cobra.Command) (rest.Interface, error) {
+	kubeconfig, err := raw.ResolveKubeconfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+	cfg := rest.CopyConfig(kubeconfig)
+	cfg.GroupVersion = &schema.GroupVersion{Group: "", Version: ""}
+	raw.SetupLocalKubeconfig(cfg)
+	client, err := rest.RESTClientFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rest client: %w", err)
+	}
+	return client, nil
+}
+
+func runE(cmd *cobra.Command, _ []string) error {
+	params, err := newQueryParams()
+	if err != nil {
+		return err
+	}
+	client, err := getRestClient(cmd)
+	if err != nil {
+		return err
+	}
+	u := url.URL{Path: "/policysimulation", RawQuery: params.Encode()}
+	rawResp, err := client.Get().RequestURI(u.RequestURI()).DoRaw(context.TODO())
+	if err != nil {
+		return fmt.Errorf("error when requesting policy simulation: %w", err)
+	}
+	var resp policysimulation.Response
+	if err := json.Unmarshal(rawResp, &resp); err != nil {
+		return fmt.Errorf("failed to unmarshal policy simulation result: %w", err)
+	}
+	switch option.outputType {
+	case "json":
+		return output.JsonOutput(resp, cmd.OutOrStdout())
+	case "yaml":
+		return output.YamlOutput(resp, cmd.OutOrStdout())
+	default:
+		return tableOutput(resp, cmd.OutOrStdout())
+	}
+}
+
+func tableOutput(resp policysimulation.Response, writer io.Writer) error {
+	rows := [][]string{{"STAGE", "ACTION", "POLICY", "RULE", "MATCHED-RULES"}}
+	for _, stage := range resp.Stages {
+		rows = append(rows, []string{stage.Stage, stage.Action, stage.Policy, stage.Rule, strings.Join(stage.MatchedRules, ",")})
+	}
+	numRows, numCols := len(rows), len(rows[0])
+	widths := output.GetColumnWidths(numRows, numCols, rows)
+	if err := output.ConstructTable(numRows, numCols, widths, rows, writer); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, "\nVerdict: %s\n", resp.Verdict)
+	return err
+}
@@ -0,0 +1,292 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policysimulation
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/rest/fake"
+)
+
+const (
+	flowRecordJSON = `[
+  {
+    "sourceIPv4Address": "10.10.0.2",
+    "destinationIPv4Address": "10.10.1.3",
+    "sourceIPv6Address": "",
+    "destinationIPv6Address": "",
+    "protocolIdentifier": 6,
+    "sourceTransportPort": 35402,
+    "destinationTransportPort": 80
+  }
+]`
+	traceflowYAML = `apiVersion: crd.antrea.io/v1alpha1
+kind: Traceflow
+metadata:
+  name: tf1
+spec:
+  liveTraffic: true
+status:
+  capturedPacket:
+    srcIP: fd00:10:10::2
+    dstIP: fd00:10:10:1::3
+    length: 80
+    ipv6Header:
+      hopLimit: 64
+      nextHeader: 17
+    transportHeader:
+      udp:
+        srcPort: 40000
+        dstPort: 53
+`
+	traceflowOutputJSON = `{
+  "phase": "Succeeded",
+  "capturedPacket": {
+    "srcIP": "10.10.0.2",
+    "dstIP": "10.10.1.3",
+    "length": 84,
+    "ipHeader": {
+      "protocol": 1,
+      "ttl": 64
+    },
+    "transportHeader": {
+      "icmp": {
+        "id": 1
+      }
+    }
+  }
+}`
+	response = `{"stages":[{"stage":"AntreaPolicyEgressRule","action":"Pass","policy":"AntreaClusterNetworkPolicy:acnp1","rule":"rule1","matchedRules":["AntreaClusterNetworkPolicy:acnp1 (rule rule1)"]},{"stage":"EgressRule","action":"Allow","policy":"K8sNetworkPolicy:default/np1","rule":"8f8e7bb1b0ac29d1"}],"verdict":"Allow"}`
+)
+
+func TestParseFlowFile(t *testing.T) {
+	tests := []struct {
+		name           string
+		data           string
+		expectedParams url.Values
+		expectedErr    string
+	}{
+		{
+			name: "flow record",
+			data: flowRecordJSON,
+			expectedParams: url.Values{
+				"src-ip":   []string{"10.10.0.2"},
+				"dst-ip":   []string{"10.10.1.3"},
+				"protocol": []string{"6"},
+				"src-port": []string{"35402"},
+				"dst-port": []string{"80"},
+			},
+		},
+		{
+			name: "Traceflow",
+			data: traceflowYAML,
+			expectedParams: url.Values{
+				"src-ip":   []string{"fd00:10:10::2"},
+				"dst-ip":   []string{"fd00:10:10:1::3"},
+				"protocol": []string{"17"},
+				"src-port": []string{"40000"},
+				"dst-port": []string{"53"},
+			},
+		},
+		{
+			name: "antctl traceflow output",
+			data: traceflowOutputJSON,
+			expectedParams: url.Values{
+				"src-ip":   []string{"10.10.0.2"},
+				"dst-ip":   []string{"10.10.1.3"},
+				"protocol": []string{"1"},
+			},
+		},
+		{
+			name:        "multiple flow records",
+			data:        `[{"sourceIPv4Address": "10.10.0.2"}, {"sourceIPv4Address": "10.10.0.3"}]`,
+			expectedErr: "file must contain exactly one flow, got 2",
+		},
+		{
+			name:        "Traceflow without captured packet",
+			data:        "apiVersion: crd.antrea.io/v1alpha1\nkind: Traceflow\nstatus:\n  phase: Running\n",
+			expectedErr: "file contains neither a flow record nor a Traceflow with a captured packet",
+		},
+		{
+			name:        "invalid file",
+			data:        "{",
+			expectedErr: "failed to parse file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := parseFlowFile([]byte(tt.data))
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedParams, params)
+			}
+		})
+	}
+}
+
+func TestSimulatePolicy(t *testing.T) {
+	flowRecordFile := filepath.Join(t.TempDir(), "flowrecord.json")
+	require.NoError(t, os.WriteFile(flowRecordFile, []byte(flowRecordJSON), 0644))
+
+	tests := []struct {
+		name           string
+		file           string
+		stdin          string
+		srcIP          string
+		dstIP          string
+		protocol       string
+		dstPort        string
+		outputType     string
+		expectedQuery  url.Values
+		expectedOutput string
+		expectedErr    string
+	}{
+		{
+			name:       "flags with table output",
+			srcIP:      "10.10.0.2",
+			dstIP:      "10.10.1.3",
+			protocol:   "udp",
+			dstPort:    "53",
+			outputType: "table",
+			expectedQuery: url.Values{
+				"src-ip":   []string{"10.10.0.2"},
+				"dst-ip":   []string{"10.10.1.3"},
+				"protocol": []string{"udp"},
+				"dst-port": []string{"53"},
+			},
+			expectedOutput: `STAGE                  ACTION POLICY                           RULE             MATCHED-RULES                                
+AntreaPolicyEgressRule Pass   AntreaClusterNetworkPolicy:acnp1 rule1            AntreaClusterNetworkPolicy:acnp1 (rule rule1)
+EgressRule             Allow  K8sNetworkPolicy:default/np1     8f8e7bb1b0ac29d1 <NONE>                                       
+
+Verdict: Allow
+`,
+		},
+		{
+			name:       "file with overriding flag and json output",
+			file:       flowRecordFile,
+			dstPort:    "443",
+			outputType: "json",
+			expectedQuery: url.Values{
+				"src-ip":   []string{"10.10.0.2"},
+				"dst-ip":   []string{"10.10.1.3"},
+				"protocol": []string{"6"},
+				"src-port": []string{"35402"},
+				"dst-port": []string{"443"},
+			},
+			expectedOutput: `{
+  "stages": [
+    {
+      "stage": "AntreaPolicyEgressRule",
+      "policy": "AntreaClusterNetworkPolicy:acnp1",
+      "rule": "rule1",
+      "action": "Pass",
+      "matchedRules": [
+        "AntreaClusterNetworkPolicy:acnp1 (rule rule1)"
+      ]
+    },
+    {
+      "stage": "EgressRule",
+      "policy": "K8sNetworkPolicy:default/np1",
+      "rule": "8f8e7bb1b0ac29d1",
+      "action": "Allow"
+    }
+  ],
+  "verdict": "Allow"
+}
+`,
+		},
+		{
+			name:       "stdin",
+			file:       "-",
+			stdin:      traceflowYAML,
+			outputType: "yaml",
+			expectedQuery: url.Values{
+				"src-ip":   []string{"fd00:10:10::2"},
+				"dst-ip":   []string{"fd00:10:10:1::3"},
+				"protocol": []string{"17"},
+				"src-port": []string{"40000"},
+				"dst-port": []string{"53"},
+			},
+			expectedOutput: `stages:
+- action: Pass
+  matchedRules:
+  - AntreaClusterNetworkPolicy:acnp1 (rule rule1)
+  policy: AntreaClusterNetworkPolicy:acnp1
+  rule: rule1
+  stage: AntreaPolicyEgressRule
+- action: Allow
+  policy: K8sNetworkPolicy:default/np1
+  rule: 8f8e7bb1b0ac29d1
+  stage: EgressRule
+verdict: Allow
+`,
+		},
+		{
+			name:        "missing destination IP",
+			srcIP:       "10.10.0.2",
+			expectedErr: "the source and destination IPs of the flow must be provided with flags or a file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(origGetRestClient func(*cobra.Command) (rest.Interface, error), origStdin io.Reader) {
+				getRestClient = origGetRestClient
+				stdin = origStdin
+			}(getRestClient, stdin)
+			option.file = tt.file
+			option.srcIP = tt.srcIP
+			option.dstIP = tt.dstIP
+			option.protocol = tt.protocol
+			option.srcPort = ""
+			option.dstPort = tt.dstPort
+			option.outputType = tt.outputType
+			stdin = strings.NewReader(tt.stdin)
+			var query url.Values
+			getRestClient = func(cmd *cobra.Command) (rest.Interface, error) {
+				return &fake.RESTClient{
+					Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+						assert.Equal(t, "/policysimulation", req.URL.Path)
+						query = req.URL.Query()
+						return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(response))}, nil
+					}),
+				}, nil
+			}
+
+			buf := new(bytes.Buffer)
+			Command.SetOut(buf)
+			err := runE(Command, nil)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedQuery, query)
+			assert.Equal(t, tt.expectedOutput, buf.String())
+		})
+	}
+}
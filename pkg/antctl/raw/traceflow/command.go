@@ -51,6 +51,8 @@ var (
 		flow        string
 		liveTraffic bool
 		droppedOnly bool
+		dualStack   bool
+		reversePath bool
 		timeout     time.Duration
 		nowait      bool
 	}{}
@@ -101,6 +103,8 @@ func init() {
   $antctl traceflow -S pod1 -D svc1 -f tcp --live-traffic -t 1m
   Start a Traceflow to capture the first dropped TCP packet to pod1 on port 80, within 10 minutes
   $antctl traceflow -D pod1 -f tcp,tcp_dst=80 --live-traffic --dropped-only -t 10m
+  Start a Traceflow from pod1 to both the IPv4 and IPv6 ClusterIPs of dual-stack Service svc1
+  $antctl traceflow -S pod1 -D svc1 -f tcp,tcp_dst=80 --dual-stack
 `,
 		RunE: runE,
 		Args: cobra.NoArgs,
@@ -112,6 +116,8 @@ func init() {
 	Command.Flags().StringVarP(&option.flow, "flow", "f", "", "specify the flow (packet headers) of the Traceflow packet, including tcp_src, tcp_dst, tcp_flags, udp_src, udp_dst, ipv6")
 	Command.Flags().BoolVarP(&option.liveTraffic, "live-traffic", "L", false, "if set, the Traceflow will trace the first packet of the matched live traffic flow")
 	Command.Flags().BoolVarP(&option.droppedOnly, "dropped-only", "", false, "if set, capture only the dropped packet in a live-traffic Traceflow")
+	Command.Flags().BoolVarP(&option.dualStack, "dual-stack", "", false, "if set, trace a packet of each IP family to a dual-stack destination Pod or Service")
+	Command.Flags().BoolVarP(&option.reversePath, "reverse-path", "", false, "if set, also trace the reply packet from the destination Pod or Service back to the source Pod")
 	Command.Flags().BoolVarP(&option.nowait, "nowait", "", false, "if set, command returns without retrieving results")
 }
 
@@ -157,6 +163,16 @@ func runE(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
+	if option.liveTraffic && option.dualStack {
+		fmt.Fprintf(cmd.OutOrStdout(), "--dual-stack does not work with live-traffic Traceflow")
+		return nil
+	}
+
+	if option.liveTraffic && option.reversePath {
+		fmt.Fprintf(cmd.OutOrStdout(), "--reverse-path does not work with live-traffic Traceflow")
+		return nil
+	}
+
 	k8sclient, client, err := getClients(cmd)
 	if err != nil {
 		return err
@@ -295,6 +311,8 @@ func newTraceflow(client kubernetes.Interface) (*v1alpha1.Traceflow, error) {
 			Packet:      *pkt,
 			LiveTraffic: option.liveTraffic,
 			DroppedOnly: option.droppedOnly,
+			DualStack:   option.dualStack,
+			ReversePath: option.reversePath,
 			Timeout:     uint16(option.timeout.Seconds()),
 		},
 	}
@@ -19,4 +19,11 @@ const (
 	AntreaOrganizationName = "antrea.io"
 	// AntreaIPsecCSRSignerName is the signer name for signing IPsec certificates for antrea-agents.
 	AntreaIPsecCSRSignerName = "antrea.io/antrea-agent-ipsec-tunnel"
+	// AntreaAgentServingCSRSignerName is the signer name for signing serving certificates of the
+	// antrea-agent API servers.
+	AntreaAgentServingCSRSignerName = "antrea.io/antrea-agent-serving"
+	// AntreaAgentServingCAName is the name of the Secret which stores the CA certificate and key
+	// for signing antrea-agent serving certificates, and of the ConfigMap which publishes the
+	// CA certificate.
+	AntreaAgentServingCAName = "antrea-agent-serving-ca"
 )
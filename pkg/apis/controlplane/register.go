@@ -56,6 +56,7 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&NetworkPolicyStatus{},
 		&NodeStatsSummary{},
 		&ClusterGroupMembers{},
+		&GroupMembers{},
 		&PaginationGetOptions{},
 		&GroupAssociation{},
 		&IPGroupAssociation{},
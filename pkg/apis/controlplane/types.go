@@ -104,6 +104,25 @@ type ClusterGroupMembers struct {
 	TotalMembers      int64
 	TotalPages        int64
 	CurrentPage       int64
+	// Continue is set when more members are available, and can be passed back in PaginationGetOptions
+	// to retrieve the next chunk of members.
+	Continue string
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GroupMembers is a list of GroupMember objects or ipBlocks that are currently selected by a Group.
+type GroupMembers struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	EffectiveMembers  []GroupMember
+	EffectiveIPBlocks []IPNet
+	TotalMembers      int64
+	TotalPages        int64
+	CurrentPage       int64
+	// Continue is set when more members are available, and can be passed back in PaginationGetOptions
+	// to retrieve the next chunk of members.
+	Continue string
 }
 
 // +k8s:conversion-gen:explicit-from=net/url.Values
@@ -114,6 +133,9 @@ type PaginationGetOptions struct {
 	metav1.TypeMeta
 	Page  int64
 	Limit int64
+	// Continue is the token returned by a previous request, to retrieve the next chunk of members. When it's
+	// set, Page is ignored. Setting Limit without Page or Continue retrieves the first chunk of members.
+	Continue string
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -262,7 +284,35 @@ type NetworkPolicyRule struct {
 	L7Protocols []L7Protocol
 	// LogLabel is a user-defined arbitrary string which will be printed in the NetworkPolicy logs.
 	LogLabel string
-}
+	// RequiredFeatures is a list of NetworkPolicy features which must be supported by
+	// the agent to enforce this rule. An agent which doesn't support any of them must
+	// not enforce the rule partially, and must report it as not realized instead.
+	RequiredFeatures []string
+	// RateLimit specifies the rate limit enforced on the traffic matching this rule.
+	// It is set if and only if Action is RateLimit.
+	RateLimit *RateLimit
+}
+
+// RateLimit describes the maximum rate of the traffic matching a rule. Exactly one
+// of PacketsPerSecond and BytesPerSecond is set.
+type RateLimit struct {
+	// PacketsPerSecond is the maximum number of packets per second.
+	PacketsPerSecond int32
+	// BytesPerSecond is the maximum number of bytes per second.
+	BytesPerSecond int64
+}
+
+// NetworkPolicy features which may be required by a NetworkPolicyRule. The agents
+// advertise the features they support, so that the rules requiring other features can
+// be reported as not realized instead of being enforced partially.
+const (
+	// NetworkPolicyFeatureL7Protocols is required by the rules with L7Protocols.
+	NetworkPolicyFeatureL7Protocols = "L7Protocols"
+	// NetworkPolicyFeatureIGMP is required by the rules matching IGMP traffic.
+	NetworkPolicyFeatureIGMP = "IGMP"
+	// NetworkPolicyFeatureRateLimit is required by the rules with the RateLimit action.
+	NetworkPolicyFeatureRateLimit = "RateLimit"
+)
 
 // Protocol defines network protocols supported for things like container ports.
 type Protocol string
@@ -495,10 +495,38 @@ func (m *GroupMember) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GroupMember proto.InternalMessageInfo
 
+func (m *GroupMembers) Reset()      { *m = GroupMembers{} }
+func (*GroupMembers) ProtoMessage() {}
+func (*GroupMembers) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fbaa7d016762fa1d, []int{16}
+}
+func (m *GroupMembers) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GroupMembers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *GroupMembers) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupMembers.Merge(m, src)
+}
+func (m *GroupMembers) XXX_Size() int {
+	return m.Size()
+}
+func (m *GroupMembers) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupMembers.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GroupMembers proto.InternalMessageInfo
+
 func (m *GroupReference) Reset()      { *m = GroupReference{} }
 func (*GroupReference) ProtoMessage() {}
 func (*GroupReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{16}
+	return fileDescriptor_fbaa7d016762fa1d, []int{17}
 }
 func (m *GroupReference) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -526,7 +554,7 @@ var xxx_messageInfo_GroupReference proto.InternalMessageInfo
 func (m *HTTPProtocol) Reset()      { *m = HTTPProtocol{} }
 func (*HTTPProtocol) ProtoMessage() {}
 func (*HTTPProtocol) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{17}
+	return fileDescriptor_fbaa7d016762fa1d, []int{18}
 }
 func (m *HTTPProtocol) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -554,7 +582,7 @@ var xxx_messageInfo_HTTPProtocol proto.InternalMessageInfo
 func (m *IPBlock) Reset()      { *m = IPBlock{} }
 func (*IPBlock) ProtoMessage() {}
 func (*IPBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{18}
+	return fileDescriptor_fbaa7d016762fa1d, []int{19}
 }
 func (m *IPBlock) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -582,7 +610,7 @@ var xxx_messageInfo_IPBlock proto.InternalMessageInfo
 func (m *IPGroupAssociation) Reset()      { *m = IPGroupAssociation{} }
 func (*IPGroupAssociation) ProtoMessage() {}
 func (*IPGroupAssociation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{19}
+	return fileDescriptor_fbaa7d016762fa1d, []int{20}
 }
 func (m *IPGroupAssociation) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -610,7 +638,7 @@ var xxx_messageInfo_IPGroupAssociation proto.InternalMessageInfo
 func (m *IPNet) Reset()      { *m = IPNet{} }
 func (*IPNet) ProtoMessage() {}
 func (*IPNet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{20}
+	return fileDescriptor_fbaa7d016762fa1d, []int{21}
 }
 func (m *IPNet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -638,7 +666,7 @@ var xxx_messageInfo_IPNet proto.InternalMessageInfo
 func (m *L7Protocol) Reset()      { *m = L7Protocol{} }
 func (*L7Protocol) ProtoMessage() {}
 func (*L7Protocol) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{21}
+	return fileDescriptor_fbaa7d016762fa1d, []int{22}
 }
 func (m *L7Protocol) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -666,7 +694,7 @@ var xxx_messageInfo_L7Protocol proto.InternalMessageInfo
 func (m *MulticastGroupInfo) Reset()      { *m = MulticastGroupInfo{} }
 func (*MulticastGroupInfo) ProtoMessage() {}
 func (*MulticastGroupInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{22}
+	return fileDescriptor_fbaa7d016762fa1d, []int{23}
 }
 func (m *MulticastGroupInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -694,7 +722,7 @@ var xxx_messageInfo_MulticastGroupInfo proto.InternalMessageInfo
 func (m *NamedPort) Reset()      { *m = NamedPort{} }
 func (*NamedPort) ProtoMessage() {}
 func (*NamedPort) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{23}
+	return fileDescriptor_fbaa7d016762fa1d, []int{24}
 }
 func (m *NamedPort) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -722,7 +750,7 @@ var xxx_messageInfo_NamedPort proto.InternalMessageInfo
 func (m *NetworkPolicy) Reset()      { *m = NetworkPolicy{} }
 func (*NetworkPolicy) ProtoMessage() {}
 func (*NetworkPolicy) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{24}
+	return fileDescriptor_fbaa7d016762fa1d, []int{25}
 }
 func (m *NetworkPolicy) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -750,7 +778,7 @@ var xxx_messageInfo_NetworkPolicy proto.InternalMessageInfo
 func (m *NetworkPolicyList) Reset()      { *m = NetworkPolicyList{} }
 func (*NetworkPolicyList) ProtoMessage() {}
 func (*NetworkPolicyList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{25}
+	return fileDescriptor_fbaa7d016762fa1d, []int{26}
 }
 func (m *NetworkPolicyList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -778,7 +806,7 @@ var xxx_messageInfo_NetworkPolicyList proto.InternalMessageInfo
 func (m *NetworkPolicyNodeStatus) Reset()      { *m = NetworkPolicyNodeStatus{} }
 func (*NetworkPolicyNodeStatus) ProtoMessage() {}
 func (*NetworkPolicyNodeStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{26}
+	return fileDescriptor_fbaa7d016762fa1d, []int{27}
 }
 func (m *NetworkPolicyNodeStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -806,7 +834,7 @@ var xxx_messageInfo_NetworkPolicyNodeStatus proto.InternalMessageInfo
 func (m *NetworkPolicyPeer) Reset()      { *m = NetworkPolicyPeer{} }
 func (*NetworkPolicyPeer) ProtoMessage() {}
 func (*NetworkPolicyPeer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{27}
+	return fileDescriptor_fbaa7d016762fa1d, []int{28}
 }
 func (m *NetworkPolicyPeer) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -834,7 +862,7 @@ var xxx_messageInfo_NetworkPolicyPeer proto.InternalMessageInfo
 func (m *NetworkPolicyReference) Reset()      { *m = NetworkPolicyReference{} }
 func (*NetworkPolicyReference) ProtoMessage() {}
 func (*NetworkPolicyReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{28}
+	return fileDescriptor_fbaa7d016762fa1d, []int{29}
 }
 func (m *NetworkPolicyReference) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -862,7 +890,7 @@ var xxx_messageInfo_NetworkPolicyReference proto.InternalMessageInfo
 func (m *NetworkPolicyRule) Reset()      { *m = NetworkPolicyRule{} }
 func (*NetworkPolicyRule) ProtoMessage() {}
 func (*NetworkPolicyRule) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{29}
+	return fileDescriptor_fbaa7d016762fa1d, []int{30}
 }
 func (m *NetworkPolicyRule) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -890,7 +918,7 @@ var xxx_messageInfo_NetworkPolicyRule proto.InternalMessageInfo
 func (m *NetworkPolicyStats) Reset()      { *m = NetworkPolicyStats{} }
 func (*NetworkPolicyStats) ProtoMessage() {}
 func (*NetworkPolicyStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{30}
+	return fileDescriptor_fbaa7d016762fa1d, []int{31}
 }
 func (m *NetworkPolicyStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -918,7 +946,7 @@ var xxx_messageInfo_NetworkPolicyStats proto.InternalMessageInfo
 func (m *NetworkPolicyStatus) Reset()      { *m = NetworkPolicyStatus{} }
 func (*NetworkPolicyStatus) ProtoMessage() {}
 func (*NetworkPolicyStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{31}
+	return fileDescriptor_fbaa7d016762fa1d, []int{32}
 }
 func (m *NetworkPolicyStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -946,7 +974,7 @@ var xxx_messageInfo_NetworkPolicyStatus proto.InternalMessageInfo
 func (m *NodeReference) Reset()      { *m = NodeReference{} }
 func (*NodeReference) ProtoMessage() {}
 func (*NodeReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{32}
+	return fileDescriptor_fbaa7d016762fa1d, []int{33}
 }
 func (m *NodeReference) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -974,7 +1002,7 @@ var xxx_messageInfo_NodeReference proto.InternalMessageInfo
 func (m *NodeStatsSummary) Reset()      { *m = NodeStatsSummary{} }
 func (*NodeStatsSummary) ProtoMessage() {}
 func (*NodeStatsSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{33}
+	return fileDescriptor_fbaa7d016762fa1d, []int{34}
 }
 func (m *NodeStatsSummary) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1002,7 +1030,7 @@ var xxx_messageInfo_NodeStatsSummary proto.InternalMessageInfo
 func (m *PaginationGetOptions) Reset()      { *m = PaginationGetOptions{} }
 func (*PaginationGetOptions) ProtoMessage() {}
 func (*PaginationGetOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{34}
+	return fileDescriptor_fbaa7d016762fa1d, []int{35}
 }
 func (m *PaginationGetOptions) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1030,7 +1058,7 @@ var xxx_messageInfo_PaginationGetOptions proto.InternalMessageInfo
 func (m *PodReference) Reset()      { *m = PodReference{} }
 func (*PodReference) ProtoMessage() {}
 func (*PodReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{35}
+	return fileDescriptor_fbaa7d016762fa1d, []int{36}
 }
 func (m *PodReference) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1055,10 +1083,38 @@ func (m *PodReference) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PodReference proto.InternalMessageInfo
 
+func (m *RateLimit) Reset()      { *m = RateLimit{} }
+func (*RateLimit) ProtoMessage() {}
+func (*RateLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fbaa7d016762fa1d, []int{37}
+}
+func (m *RateLimit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RateLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *RateLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateLimit.Merge(m, src)
+}
+func (m *RateLimit) XXX_Size() int {
+	return m.Size()
+}
+func (m *RateLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RateLimit proto.InternalMessageInfo
+
 func (m *Service) Reset()      { *m = Service{} }
 func (*Service) ProtoMessage() {}
 func (*Service) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{36}
+	return fileDescriptor_fbaa7d016762fa1d, []int{38}
 }
 func (m *Service) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1086,7 +1142,7 @@ var xxx_messageInfo_Service proto.InternalMessageInfo
 func (m *ServiceReference) Reset()      { *m = ServiceReference{} }
 func (*ServiceReference) ProtoMessage() {}
 func (*ServiceReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{37}
+	return fileDescriptor_fbaa7d016762fa1d, []int{39}
 }
 func (m *ServiceReference) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1114,7 +1170,7 @@ var xxx_messageInfo_ServiceReference proto.InternalMessageInfo
 func (m *SupportBundleCollection) Reset()      { *m = SupportBundleCollection{} }
 func (*SupportBundleCollection) ProtoMessage() {}
 func (*SupportBundleCollection) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{38}
+	return fileDescriptor_fbaa7d016762fa1d, []int{40}
 }
 func (m *SupportBundleCollection) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1142,7 +1198,7 @@ var xxx_messageInfo_SupportBundleCollection proto.InternalMessageInfo
 func (m *SupportBundleCollectionList) Reset()      { *m = SupportBundleCollectionList{} }
 func (*SupportBundleCollectionList) ProtoMessage() {}
 func (*SupportBundleCollectionList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{39}
+	return fileDescriptor_fbaa7d016762fa1d, []int{41}
 }
 func (m *SupportBundleCollectionList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1170,7 +1226,7 @@ var xxx_messageInfo_SupportBundleCollectionList proto.InternalMessageInfo
 func (m *SupportBundleCollectionNodeStatus) Reset()      { *m = SupportBundleCollectionNodeStatus{} }
 func (*SupportBundleCollectionNodeStatus) ProtoMessage() {}
 func (*SupportBundleCollectionNodeStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{40}
+	return fileDescriptor_fbaa7d016762fa1d, []int{42}
 }
 func (m *SupportBundleCollectionNodeStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1198,7 +1254,7 @@ var xxx_messageInfo_SupportBundleCollectionNodeStatus proto.InternalMessageInfo
 func (m *SupportBundleCollectionStatus) Reset()      { *m = SupportBundleCollectionStatus{} }
 func (*SupportBundleCollectionStatus) ProtoMessage() {}
 func (*SupportBundleCollectionStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_fbaa7d016762fa1d, []int{41}
+	return fileDescriptor_fbaa7d016762fa1d, []int{43}
 }
 func (m *SupportBundleCollectionStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1240,6 +1296,7 @@ func init() {
 	proto.RegisterType((*ExternalEntityReference)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.ExternalEntityReference")
 	proto.RegisterType((*GroupAssociation)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.GroupAssociation")
 	proto.RegisterType((*GroupMember)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.GroupMember")
+	proto.RegisterType((*GroupMembers)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.GroupMembers")
 	proto.RegisterType((*GroupReference)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.GroupReference")
 	proto.RegisterType((*HTTPProtocol)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.HTTPProtocol")
 	proto.RegisterType((*IPBlock)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.IPBlock")
@@ -1260,6 +1317,7 @@ func init() {
 	proto.RegisterType((*NodeStatsSummary)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.NodeStatsSummary")
 	proto.RegisterType((*PaginationGetOptions)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.PaginationGetOptions")
 	proto.RegisterType((*PodReference)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.PodReference")
+	proto.RegisterType((*RateLimit)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.RateLimit")
 	proto.RegisterType((*Service)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.Service")
 	proto.RegisterType((*ServiceReference)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.ServiceReference")
 	proto.RegisterType((*SupportBundleCollection)(nil), "antrea_io.antrea.pkg.apis.controlplane.v1beta2.SupportBundleCollection")
@@ -1273,183 +1331,191 @@ func init() {
 }
 
 var fileDescriptor_fbaa7d016762fa1d = []byte{
-	// 2804 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3a, 0xcd, 0x6f, 0x24, 0x47,
-	0xf5, 0xdb, 0x9e, 0x19, 0xdb, 0xf3, 0xc6, 0xf6, 0x7a, 0xcb, 0x49, 0xd6, 0xbf, 0x24, 0x6b, 0x6f,
-	0x3a, 0x3f, 0xa2, 0x05, 0x85, 0x99, 0xd8, 0x24, 0xd9, 0x25, 0x5f, 0xe0, 0xf1, 0x7a, 0x9d, 0x21,
-	0xb6, 0x33, 0x29, 0x3b, 0x8a, 0x94, 0x90, 0x90, 0x76, 0x77, 0xcd, 0x4c, 0xb3, 0x3d, 0x5d, 0x9d,
-	0xea, 0x1a, 0x67, 0x9d, 0x03, 0x0a, 0x0a, 0x1c, 0xc2, 0x57, 0x10, 0x17, 0xc4, 0x8d, 0x1b, 0x17,
-	0xfe, 0x82, 0xdc, 0x40, 0x42, 0xca, 0x31, 0x08, 0x21, 0x72, 0xb2, 0x88, 0x11, 0x41, 0x39, 0x70,
-	0xe1, 0xc6, 0x22, 0x24, 0x54, 0xd5, 0xd5, 0xdd, 0xd5, 0x3d, 0x9e, 0xf5, 0x8e, 0xed, 0x35, 0x12,
-	0xc9, 0x69, 0xa6, 0xdf, 0x77, 0x55, 0xbd, 0x57, 0xef, 0xa3, 0x1b, 0x9e, 0xb1, 0x7c, 0xce, 0x88,
-	0x55, 0x75, 0x69, 0x2d, 0xfa, 0x57, 0x0b, 0xae, 0xb7, 0x6b, 0x56, 0xe0, 0x86, 0x35, 0x9b, 0xfa,
-	0x9c, 0x51, 0x2f, 0xf0, 0x2c, 0x9f, 0xd4, 0x76, 0x16, 0xb6, 0x09, 0xb7, 0x16, 0x6b, 0x6d, 0xe2,
-	0x13, 0x66, 0x71, 0xe2, 0x54, 0x03, 0x46, 0x39, 0x45, 0xd5, 0x88, 0xeb, 0x5b, 0x2e, 0x55, 0xff,
-	0xaa, 0xc1, 0xf5, 0x76, 0x55, 0xf0, 0x57, 0x75, 0xfe, 0xaa, 0xe2, 0xbf, 0xf7, 0xca, 0x60, 0x7d,
-	0x21, 0xb7, 0x78, 0x58, 0xdb, 0x59, 0xb0, 0xbc, 0xa0, 0x63, 0x2d, 0xe4, 0x35, 0xdd, 0xfb, 0xe5,
-	0xb6, 0xcb, 0x3b, 0xbd, 0xed, 0xaa, 0x4d, 0xbb, 0xb5, 0x36, 0x6d, 0xd3, 0x9a, 0x04, 0x6f, 0xf7,
-	0x5a, 0xf2, 0x49, 0x3e, 0xc8, 0x7f, 0x8a, 0xfc, 0xd1, 0xeb, 0x57, 0x42, 0xa9, 0x25, 0x70, 0xbb,
-	0x96, 0xdd, 0x71, 0x7d, 0xc2, 0x76, 0x53, 0x5d, 0x5d, 0xc2, 0xad, 0xda, 0x4e, 0xbf, 0x92, 0xda,
-	0x20, 0x2e, 0xd6, 0xf3, 0xb9, 0xdb, 0x25, 0x7d, 0x0c, 0x8f, 0x1f, 0xc6, 0x10, 0xda, 0x1d, 0xd2,
-	0xb5, 0xfa, 0xf8, 0xbe, 0x32, 0x88, 0xaf, 0xc7, 0x5d, 0xaf, 0xe6, 0xfa, 0x3c, 0xe4, 0x2c, 0xcf,
-	0x64, 0xfe, 0xcd, 0x80, 0x89, 0x25, 0xc7, 0x61, 0x24, 0x0c, 0x57, 0x19, 0xed, 0x05, 0xe8, 0x75,
-	0x18, 0x17, 0x2b, 0x71, 0x2c, 0x6e, 0xcd, 0x1a, 0x17, 0x8d, 0x4b, 0x95, 0xc5, 0x47, 0xaa, 0x91,
-	0xe0, 0xaa, 0x2e, 0x38, 0x3d, 0x13, 0x41, 0x5d, 0xdd, 0x59, 0xa8, 0x3e, 0xbf, 0xfd, 0x6d, 0x62,
-	0xf3, 0x75, 0xc2, 0xad, 0x3a, 0xfa, 0x60, 0x6f, 0xfe, 0xcc, 0xfe, 0xde, 0x3c, 0xa4, 0x30, 0x9c,
-	0x48, 0x45, 0x3d, 0x98, 0x68, 0x0b, 0x55, 0xeb, 0xa4, 0xbb, 0x4d, 0x58, 0x38, 0x3b, 0x72, 0xb1,
-	0x70, 0xa9, 0xb2, 0xf8, 0xe4, 0x90, 0xc7, 0x5e, 0x5d, 0x4d, 0x65, 0xd4, 0xef, 0x52, 0x0a, 0x27,
-	0x34, 0x60, 0x88, 0x33, 0x6a, 0xcc, 0x3f, 0x18, 0x30, 0xad, 0xaf, 0x74, 0xcd, 0x0d, 0x39, 0xfa,
-	0x66, 0xdf, 0x6a, 0xab, 0xb7, 0xb7, 0x5a, 0xc1, 0x2d, 0xd7, 0x3a, 0xad, 0x54, 0x8f, 0xc7, 0x10,
-	0x6d, 0xa5, 0x16, 0x94, 0x5c, 0x4e, 0xba, 0xf1, 0x12, 0x9f, 0x1a, 0x76, 0x89, 0xba, 0xb9, 0xf5,
-	0x49, 0xa5, 0xa8, 0xd4, 0x10, 0x22, 0x71, 0x24, 0xd9, 0x7c, 0xb7, 0x00, 0xe7, 0x74, 0xb2, 0xa6,
-	0xc5, 0xed, 0xce, 0x29, 0x1c, 0xe2, 0xf7, 0x0c, 0x38, 0x67, 0x39, 0x0e, 0x71, 0x56, 0x4f, 0xf8,
-	0x28, 0xff, 0x4f, 0xa9, 0x15, 0xab, 0xca, 0x4a, 0xc7, 0xfd, 0x0a, 0xd1, 0x0f, 0x0c, 0x98, 0x61,
-	0xa4, 0x4b, 0x77, 0x72, 0x86, 0x14, 0x8e, 0x6f, 0xc8, 0x7d, 0xca, 0x90, 0x19, 0xdc, 0x2f, 0x1f,
-	0x1f, 0xa4, 0xd4, 0xfc, 0xd4, 0x80, 0xa9, 0xa5, 0x20, 0xf0, 0x5c, 0xe2, 0x6c, 0xd1, 0xff, 0xf1,
-	0x68, 0xfa, 0x93, 0x01, 0x28, 0xbb, 0xd6, 0x53, 0x88, 0x27, 0x3b, 0x1b, 0x4f, 0xcf, 0x0c, 0x1d,
-	0x4f, 0x19, 0x83, 0x07, 0x44, 0xd4, 0x0f, 0x0b, 0x30, 0x93, 0x25, 0xfc, 0x3c, 0xa6, 0xfe, 0x7b,
-	0x31, 0xf5, 0x06, 0xcc, 0xd4, 0xad, 0xd0, 0xb5, 0x97, 0x7a, 0xbc, 0x43, 0x7c, 0xee, 0xda, 0x16,
-	0x77, 0xa9, 0x8f, 0x1e, 0x86, 0xf1, 0x5e, 0x48, 0x98, 0x6f, 0x75, 0x89, 0x3c, 0x8c, 0x72, 0xea,
-	0x37, 0x2f, 0x2a, 0x38, 0x4e, 0x28, 0x04, 0x75, 0x60, 0x85, 0xe1, 0x9b, 0x94, 0x39, 0xb3, 0x23,
-	0x59, 0xea, 0xa6, 0x82, 0xe3, 0x84, 0xc2, 0x5c, 0x80, 0xe9, 0x7a, 0xcf, 0x77, 0x3c, 0x72, 0xcd,
-	0xf5, 0xc8, 0x26, 0x61, 0x3b, 0x84, 0xa1, 0x0b, 0x50, 0xe8, 0x31, 0x4f, 0xa9, 0xaa, 0x28, 0xe6,
-	0xc2, 0x8b, 0x78, 0x0d, 0x0b, 0xb8, 0xf9, 0xde, 0x08, 0x5c, 0x88, 0x78, 0x22, 0x7a, 0x61, 0xed,
-	0x32, 0xf5, 0x5b, 0x6e, 0xbb, 0xc7, 0x22, 0x83, 0x1f, 0x83, 0xca, 0x36, 0xb1, 0x18, 0x61, 0x5b,
-	0xf4, 0x3a, 0xf1, 0x95, 0xa0, 0x19, 0x25, 0xa8, 0x52, 0x4f, 0x51, 0x58, 0xa7, 0x43, 0x0f, 0xc1,
-	0xa8, 0x15, 0xb8, 0xcf, 0x91, 0x5d, 0x65, 0xf7, 0x94, 0xe2, 0x18, 0x5d, 0x6a, 0x36, 0x9e, 0x23,
-	0xbb, 0x58, 0x61, 0xd1, 0x4f, 0x0c, 0x98, 0xd9, 0xee, 0xdf, 0xa7, 0xd9, 0x82, 0x74, 0xd4, 0xe5,
-	0x61, 0xcf, 0xec, 0x80, 0x2d, 0xaf, 0x9f, 0x17, 0xe7, 0x76, 0x00, 0x02, 0x1f, 0xa4, 0xd8, 0xfc,
-	0x65, 0x11, 0x66, 0x96, 0xbd, 0x5e, 0xc8, 0x09, 0xcb, 0x38, 0xd7, 0x9d, 0x8f, 0xa2, 0xef, 0x1a,
-	0x30, 0x4d, 0x5a, 0x2d, 0x62, 0x73, 0x77, 0x87, 0x9c, 0x60, 0x10, 0xcd, 0x2a, 0xad, 0xd3, 0x2b,
-	0x39, 0xe1, 0xb8, 0x4f, 0x1d, 0xfa, 0x0e, 0x9c, 0x4b, 0x60, 0x8d, 0x66, 0xdd, 0xa3, 0xf6, 0xf5,
-	0x38, 0x7e, 0x1e, 0x1b, 0xd6, 0x86, 0x46, 0x73, 0x83, 0xf0, 0x34, 0x84, 0x57, 0xf2, 0x72, 0x71,
-	0xbf, 0x2a, 0x74, 0x05, 0x26, 0x38, 0xe5, 0x96, 0x17, 0x2f, 0xbf, 0x78, 0xd1, 0xb8, 0x54, 0x48,
-	0xef, 0xf5, 0x2d, 0x0d, 0x87, 0x33, 0x94, 0x68, 0x11, 0x40, 0x3e, 0x37, 0xad, 0x36, 0x09, 0x67,
-	0x4b, 0x92, 0x2f, 0xd9, 0xef, 0xad, 0x04, 0x83, 0x35, 0x2a, 0xe1, 0xdb, 0x76, 0x8f, 0x31, 0xe2,
-	0x73, 0xf1, 0x3c, 0x3b, 0x2a, 0x99, 0x12, 0xdf, 0x5e, 0x4e, 0x51, 0x58, 0xa7, 0x33, 0x3f, 0x31,
-	0xa0, 0xb2, 0xd2, 0xfe, 0x0c, 0x54, 0x9e, 0xbf, 0x37, 0xe0, 0xac, 0xb6, 0xd0, 0x53, 0x48, 0x94,
-	0xaf, 0x67, 0x13, 0xe5, 0xd0, 0x2b, 0xd4, 0xac, 0x1d, 0x90, 0x25, 0x7f, 0x54, 0x80, 0x69, 0x8d,
-	0x2a, 0x4a, 0x91, 0x0e, 0x00, 0x4d, 0xf6, 0xfd, 0x44, 0xcf, 0x50, 0x93, 0xfb, 0x79, 0x9a, 0x3c,
-	0x20, 0x4d, 0x7a, 0x70, 0x7e, 0xe5, 0x06, 0x17, 0xe9, 0xce, 0x5b, 0xf1, 0xb9, 0xcb, 0x77, 0x31,
-	0x69, 0x11, 0x46, 0x7c, 0x9b, 0xa0, 0x8b, 0x50, 0xd4, 0xd2, 0xe4, 0x84, 0x12, 0x5d, 0xdc, 0x10,
-	0x29, 0x52, 0x62, 0x50, 0x0d, 0xca, 0xe2, 0x37, 0x0c, 0x2c, 0x9b, 0xa8, 0x3c, 0x73, 0x4e, 0x91,
-	0x95, 0x37, 0x62, 0x04, 0x4e, 0x69, 0xcc, 0x7f, 0x19, 0x30, 0x2d, 0xd5, 0x2f, 0x85, 0x21, 0xb5,
-	0xdd, 0x28, 0xc3, 0x9d, 0x4a, 0x7d, 0x34, 0x6d, 0x29, 0x8d, 0x6a, 0xfd, 0x47, 0x2e, 0x05, 0x25,
-	0x77, 0xb2, 0x49, 0xe9, 0xe5, 0xbe, 0x94, 0x93, 0x8f, 0xfb, 0x34, 0x9a, 0xef, 0x17, 0xa1, 0xa2,
-	0x6d, 0x3e, 0x7a, 0x09, 0x0a, 0x01, 0x75, 0xd4, 0x9a, 0x87, 0xee, 0xf1, 0x9a, 0xd4, 0x49, 0xcd,
-	0x18, 0x13, 0x55, 0x85, 0x80, 0x08, 0x89, 0xe8, 0x1d, 0x03, 0xa6, 0x48, 0xe6, 0x54, 0xe5, 0xe9,
-	0x54, 0x16, 0x57, 0x87, 0x8e, 0xe7, 0x83, 0x7d, 0xa3, 0x8e, 0xf6, 0xf7, 0xe6, 0xa7, 0x72, 0xc8,
-	0x9c, 0x4a, 0xf4, 0x10, 0x14, 0xdc, 0x20, 0x72, 0xeb, 0x89, 0xfa, 0x5d, 0xc2, 0xc0, 0x46, 0x33,
-	0xbc, 0xb9, 0x37, 0x5f, 0x6e, 0x34, 0x55, 0xe3, 0x89, 0x05, 0x01, 0x7a, 0x0d, 0x4a, 0x01, 0x65,
-	0x5c, 0x24, 0x1b, 0x71, 0x22, 0x5f, 0x1d, 0xd6, 0x46, 0xe1, 0x69, 0x4e, 0x93, 0x32, 0x9e, 0xde,
-	0x38, 0xe2, 0x29, 0xc4, 0x91, 0x58, 0xf4, 0x0a, 0x14, 0x7d, 0xea, 0x10, 0x99, 0x93, 0x2a, 0x8b,
-	0x4f, 0x0f, 0x2d, 0x9e, 0x3a, 0x24, 0x5d, 0xf8, 0xb8, 0x0c, 0x01, 0x01, 0x92, 0x42, 0x51, 0x1b,
-	0xc6, 0x42, 0xc2, 0x76, 0x5c, 0x3b, 0x4a, 0x5f, 0x95, 0xc5, 0xaf, 0x0f, 0x2b, 0x7f, 0x33, 0x62,
-	0x4f, 0x55, 0x54, 0xf6, 0xf7, 0xe6, 0xc7, 0x62, 0x68, 0x2c, 0xdd, 0xfc, 0x95, 0x01, 0x53, 0x59,
-	0xdf, 0xcb, 0x86, 0x9f, 0x71, 0x78, 0xf8, 0x25, 0x11, 0x3d, 0x32, 0x30, 0xa2, 0xeb, 0x50, 0xe8,
-	0xb9, 0x8e, 0xac, 0xfe, 0xca, 0xf5, 0x47, 0x92, 0x72, 0xb5, 0x71, 0xf5, 0xe6, 0xde, 0xfc, 0x03,
-	0x83, 0xc6, 0x44, 0x7c, 0x37, 0x20, 0x61, 0xf5, 0xc5, 0xc6, 0x55, 0x2c, 0x98, 0xcd, 0xb7, 0x60,
-	0xe2, 0xd9, 0xad, 0xad, 0x66, 0x93, 0x51, 0x4e, 0x6d, 0xea, 0x09, 0xad, 0x1d, 0x1a, 0xf2, 0xfc,
-	0x3d, 0xf2, 0x2c, 0x0d, 0x39, 0x96, 0x18, 0x51, 0xac, 0x76, 0x09, 0xef, 0x50, 0x27, 0x5f, 0xac,
-	0xae, 0x4b, 0x28, 0x56, 0x58, 0x21, 0x29, 0xb0, 0x78, 0x47, 0x99, 0x97, 0x48, 0x6a, 0x5a, 0xbc,
-	0x83, 0x25, 0xc6, 0xfc, 0x8d, 0x01, 0x63, 0xaa, 0x98, 0x41, 0x2f, 0x41, 0xd1, 0x76, 0x1d, 0xa6,
-	0xe2, 0xeb, 0x88, 0xe5, 0x53, 0xa2, 0x64, 0xb9, 0x71, 0x15, 0x63, 0x29, 0x10, 0xbd, 0x0a, 0xa3,
-	0xe4, 0x86, 0x4d, 0x02, 0xae, 0xee, 0x90, 0x23, 0x8a, 0x4e, 0x56, 0xb9, 0x22, 0x85, 0x61, 0x25,
-	0xd4, 0xfc, 0xb7, 0x01, 0xa8, 0xd1, 0xfc, 0xec, 0x5e, 0x93, 0x2d, 0x28, 0xc9, 0x0d, 0x42, 0x0f,
-	0xc2, 0x88, 0x1b, 0xc8, 0xb5, 0x4e, 0xd4, 0x67, 0xf6, 0xf7, 0xe6, 0x47, 0x1a, 0xcd, 0xec, 0xf5,
-	0x31, 0xe2, 0x06, 0xa2, 0x62, 0x0d, 0x18, 0x69, 0xb9, 0x37, 0xd6, 0x88, 0xdf, 0xe6, 0x1d, 0xe9,
-	0x41, 0xa5, 0xb4, 0xba, 0x6a, 0x6a, 0x38, 0x9c, 0xa1, 0x34, 0x3b, 0x00, 0x6b, 0x97, 0x13, 0x2f,
-	0x7d, 0x19, 0x8a, 0x1d, 0xce, 0x83, 0xa3, 0xde, 0xc6, 0xba, 0xc7, 0x47, 0x97, 0x84, 0x80, 0x60,
-	0x29, 0xd3, 0xfc, 0x85, 0x01, 0x68, 0xbd, 0xe7, 0x89, 0x1e, 0x27, 0xe4, 0x72, 0x95, 0x0d, 0xbf,
-	0x45, 0xd1, 0x83, 0x50, 0x92, 0xe5, 0x9e, 0x8a, 0x8c, 0xe4, 0xf6, 0x8a, 0xf6, 0x2e, 0xc2, 0xa1,
-	0xd7, 0xa0, 0x18, 0x50, 0xe7, 0xc8, 0x93, 0xc0, 0x4c, 0x96, 0x48, 0x23, 0x86, 0x3a, 0x21, 0x96,
-	0x72, 0xcd, 0x77, 0x0d, 0x28, 0x27, 0x37, 0xa8, 0x8c, 0x30, 0xca, 0xa2, 0x58, 0x2d, 0xe9, 0xf4,
-	0x8c, 0x63, 0x89, 0xb9, 0x8d, 0x3b, 0xe4, 0x0a, 0x8c, 0x07, 0x6a, 0x27, 0x54, 0xa4, 0xde, 0x9f,
-	0x34, 0xcd, 0x0a, 0x7e, 0x53, 0xfb, 0x8f, 0x13, 0x6a, 0xf3, 0xef, 0x05, 0x98, 0xdc, 0x20, 0xfc,
-	0x4d, 0xca, 0xae, 0x37, 0xa9, 0xe7, 0xda, 0xbb, 0xa7, 0xe0, 0xf4, 0x2d, 0x28, 0xb1, 0x9e, 0x47,
-	0xe2, 0x0d, 0x5e, 0x1a, 0x3a, 0x3d, 0xe8, 0xf6, 0xe2, 0x9e, 0x47, 0xd2, 0x73, 0x14, 0x4f, 0x21,
-	0x8e, 0xc4, 0xa3, 0xa7, 0xe1, 0xac, 0x95, 0x19, 0x0e, 0x45, 0x99, 0xb1, 0x2c, 0x3d, 0xfb, 0x6c,
-	0x76, 0x6e, 0x14, 0xe2, 0x3c, 0x2d, 0xba, 0x24, 0x36, 0xd5, 0xa5, 0x4c, 0xe4, 0x72, 0xd1, 0x94,
-	0x19, 0xf5, 0x89, 0x68, 0x43, 0x23, 0x18, 0x4e, 0xb0, 0xe8, 0x51, 0x98, 0xe0, 0x2e, 0x61, 0x31,
-	0x46, 0xa6, 0xbd, 0x52, 0x7d, 0x5a, 0xb6, 0x6f, 0x1a, 0x1c, 0x67, 0xa8, 0x50, 0x08, 0xe5, 0x90,
-	0xf6, 0x98, 0xcc, 0x43, 0x2a, 0x93, 0x5d, 0x3b, 0xde, 0x56, 0x24, 0x5e, 0x37, 0x29, 0xf2, 0xd1,
-	0x66, 0x2c, 0x1c, 0xa7, 0x7a, 0xcc, 0x3f, 0x1a, 0x70, 0x2e, 0xc3, 0x74, 0x0a, 0x1d, 0xce, 0x76,
-	0xb6, 0xc3, 0x79, 0xfa, 0x58, 0x8b, 0x1c, 0xd0, 0xe3, 0xfc, 0xc3, 0x80, 0xf3, 0x19, 0x3a, 0x51,
-	0x30, 0x6c, 0x72, 0x8b, 0xf7, 0x42, 0xf4, 0x30, 0x8c, 0x8b, 0xc2, 0x61, 0xe3, 0x80, 0x01, 0xd4,
-	0x86, 0x82, 0xe3, 0x84, 0x42, 0x74, 0xd5, 0xea, 0xc5, 0x8b, 0x4b, 0x7d, 0x19, 0x73, 0x5a, 0x57,
-	0xbd, 0x9a, 0x60, 0xb0, 0x46, 0x85, 0xbe, 0x01, 0x88, 0x11, 0xcb, 0x73, 0xdf, 0x92, 0x8f, 0xd7,
-	0x2c, 0xd7, 0xeb, 0x31, 0x22, 0x23, 0x71, 0xbc, 0x7e, 0xaf, 0xe2, 0x45, 0xb8, 0x8f, 0x02, 0x1f,
-	0xc0, 0x85, 0xbe, 0x08, 0x63, 0x5d, 0x12, 0x86, 0xa2, 0x3b, 0x2f, 0x4a, 0x63, 0xcf, 0x2a, 0x01,
-	0x63, 0xeb, 0x11, 0x18, 0xc7, 0x78, 0xf9, 0x42, 0x21, 0xb3, 0xe8, 0x26, 0x21, 0x0c, 0x5d, 0x86,
-	0x49, 0x4b, 0x7b, 0xcb, 0x10, 0xce, 0x1a, 0xd2, 0xe9, 0xcf, 0xed, 0xef, 0xcd, 0x4f, 0xea, 0xaf,
-	0x1f, 0x42, 0x9c, 0xa5, 0x43, 0x04, 0xc6, 0xdd, 0x40, 0x0d, 0x40, 0xa2, 0xa3, 0xba, 0x3c, 0x7c,
-	0x9a, 0x95, 0xfc, 0xe9, 0x06, 0x27, 0x93, 0x8f, 0x44, 0x34, 0x9a, 0x87, 0x52, 0xeb, 0x0d, 0xc7,
-	0x8f, 0x83, 0xb1, 0x2c, 0xce, 0xf2, 0xda, 0x0b, 0x57, 0x37, 0x42, 0x1c, 0xc1, 0x11, 0x07, 0xe0,
-	0x54, 0x55, 0x63, 0x71, 0x89, 0x7a, 0xfc, 0x1a, 0x4f, 0x9b, 0x8c, 0xc4, 0xb2, 0xb1, 0xa6, 0x47,
-	0xdc, 0x16, 0x9e, 0xb5, 0x4d, 0xbc, 0x86, 0x43, 0x44, 0x31, 0xed, 0xca, 0x91, 0x4a, 0xe1, 0xd2,
-	0x64, 0x74, 0x5b, 0xac, 0x65, 0x51, 0x38, 0x4f, 0x6b, 0x7e, 0x62, 0xc0, 0x3d, 0x07, 0x47, 0x23,
-	0x7a, 0x0c, 0x8a, 0xa2, 0x5e, 0x53, 0xbe, 0xf7, 0x40, 0x7c, 0x7f, 0x6f, 0xed, 0x06, 0xe4, 0xe6,
-	0xde, 0x7c, 0xf6, 0x04, 0x05, 0x10, 0x4b, 0xf2, 0xa1, 0x5b, 0xbd, 0x24, 0x4f, 0x14, 0x0e, 0xab,
-	0x35, 0x8b, 0xc7, 0xa9, 0x35, 0x7f, 0x3b, 0x9a, 0x73, 0x3a, 0x71, 0xe7, 0xa2, 0xa7, 0xa0, 0xec,
-	0xb8, 0x8c, 0xd8, 0x32, 0x68, 0xa2, 0x85, 0xce, 0xc5, 0xc6, 0x5e, 0x8d, 0x11, 0x37, 0xf5, 0x07,
-	0x9c, 0x32, 0x20, 0x1b, 0x8a, 0x2d, 0x46, 0xbb, 0xaa, 0x65, 0x3a, 0x5e, 0x42, 0x10, 0x31, 0x90,
-	0x2e, 0xfe, 0x1a, 0xa3, 0x5d, 0x2c, 0x85, 0xa3, 0x57, 0x61, 0x84, 0x53, 0x35, 0x65, 0x3d, 0x01,
-	0x15, 0xa0, 0x54, 0x8c, 0x6c, 0x51, 0x3c, 0xc2, 0xa9, 0x88, 0x9e, 0x30, 0xeb, 0xb3, 0x97, 0x8f,
-	0xe8, 0xb3, 0x69, 0xf4, 0x24, 0x8e, 0x9a, 0x88, 0x96, 0xf3, 0xf1, 0x5c, 0x9e, 0x49, 0x53, 0x7d,
-	0x5f, 0x66, 0x7a, 0x09, 0x46, 0xad, 0xe8, 0x4c, 0x46, 0xe5, 0x99, 0x7c, 0x4d, 0xce, 0xa3, 0xe3,
-	0xc3, 0x58, 0xb8, 0xc5, 0xdb, 0x7f, 0xe6, 0x24, 0xef, 0xe2, 0xab, 0xe2, 0x84, 0x23, 0x26, 0xac,
-	0xc4, 0xa1, 0x27, 0x61, 0x92, 0xf8, 0xd6, 0xb6, 0x47, 0xd6, 0x68, 0xbb, 0xed, 0xfa, 0xed, 0xd9,
-	0x31, 0x79, 0xd9, 0xdd, 0xad, 0x6c, 0x99, 0x5c, 0xd1, 0x91, 0x38, 0x4b, 0x7b, 0x50, 0x62, 0x1e,
-	0x1f, 0x22, 0x31, 0xc7, 0x7e, 0x5e, 0x1e, 0xe8, 0xe7, 0x6f, 0x40, 0xc5, 0x4b, 0xea, 0xcc, 0x70,
-	0x16, 0xe4, 0x71, 0x3c, 0x31, 0xec, 0x71, 0xa4, 0xa5, 0x6a, 0x3a, 0x21, 0x4d, 0x61, 0x21, 0xd6,
-	0x75, 0x88, 0x73, 0xf1, 0x68, 0x5b, 0x5e, 0x13, 0xb3, 0x95, 0x6c, 0x92, 0x59, 0x53, 0x70, 0x9c,
-	0x50, 0x98, 0xef, 0x15, 0x00, 0x65, 0x5c, 0x4a, 0xa4, 0xaa, 0x10, 0xbd, 0x63, 0xc0, 0xa4, 0xaf,
-	0x83, 0x55, 0x36, 0x3e, 0xa9, 0xba, 0x20, 0x39, 0x9e, 0x2c, 0x3e, 0xab, 0x13, 0x05, 0x30, 0xc1,
-	0x99, 0xd5, 0x6a, 0xb9, 0xb6, 0xb4, 0x4a, 0x45, 0xe5, 0xe3, 0xb7, 0xb0, 0x41, 0x7e, 0xbb, 0x51,
-	0x4d, 0xfc, 0x65, 0x4b, 0xe3, 0xd6, 0x26, 0xd9, 0x1a, 0x14, 0x67, 0x34, 0xa0, 0xb7, 0x0d, 0x98,
-	0x16, 0x35, 0x9b, 0x4e, 0xa2, 0x86, 0x73, 0x4f, 0xdc, 0xbe, 0x5a, 0x9c, 0x93, 0x90, 0xb6, 0x40,
-	0x79, 0x0c, 0xee, 0xd3, 0x66, 0xfe, 0xd5, 0x80, 0x99, 0xbe, 0x13, 0xe9, 0x9d, 0xc6, 0x4b, 0x10,
-	0x0f, 0x4a, 0xa2, 0xf8, 0x88, 0x73, 0xee, 0xea, 0xb1, 0xce, 0x3a, 0x2d, 0x7b, 0xd2, 0x42, 0x49,
-	0xc0, 0x42, 0x1c, 0x29, 0x31, 0x17, 0x60, 0x32, 0x33, 0x5e, 0x39, 0x7c, 0xe6, 0x68, 0xbe, 0x5f,
-	0x82, 0xe9, 0x58, 0x6e, 0xb8, 0xd9, 0xeb, 0x76, 0x2d, 0x76, 0x1a, 0x6d, 0xc2, 0xf7, 0x0d, 0x38,
-	0xab, 0x3b, 0xa6, 0x9b, 0x6c, 0x51, 0xfd, 0x58, 0x5b, 0x14, 0xf9, 0xc6, 0x79, 0xa5, 0xfb, 0xec,
-	0x46, 0x56, 0x05, 0xce, 0xeb, 0x44, 0xbf, 0x36, 0xe0, 0xfe, 0x48, 0x8b, 0x7a, 0x49, 0x96, 0xe3,
-	0x50, 0x8e, 0x7a, 0x12, 0x46, 0xfd, 0xbf, 0x32, 0xea, 0xfe, 0xa5, 0x5b, 0xe8, 0xc3, 0xb7, 0xb4,
-	0x06, 0xfd, 0xdc, 0x80, 0xbb, 0x23, 0x82, 0xbc, 0x9d, 0xc5, 0x13, 0xb3, 0xf3, 0x82, 0xb2, 0xf3,
-	0xee, 0xa5, 0x83, 0x14, 0xe1, 0x83, 0xf5, 0x8b, 0x86, 0xa7, 0x1b, 0xb7, 0xe4, 0xb2, 0xb6, 0x3a,
-	0x82, 0x31, 0xfd, 0x3d, 0x7d, 0x5a, 0x14, 0x25, 0x38, 0x9c, 0xea, 0x31, 0x5f, 0x85, 0xbb, 0x9a,
-	0x56, 0xdb, 0xf5, 0x65, 0x8d, 0xbd, 0x4a, 0xf8, 0xf3, 0x81, 0xf8, 0x13, 0x46, 0x83, 0xad, 0x76,
-	0xe4, 0xf6, 0x05, 0x7d, 0xb0, 0xd5, 0x26, 0x58, 0x62, 0xd0, 0x83, 0x50, 0xf2, 0xdc, 0xae, 0xcb,
-	0x55, 0x0f, 0x90, 0x84, 0xd3, 0x9a, 0x00, 0xe2, 0x08, 0x67, 0x5a, 0x30, 0xa1, 0xf7, 0xfb, 0x77,
-	0x62, 0x82, 0xff, 0xbb, 0x02, 0xc4, 0xb3, 0x49, 0xf4, 0xa8, 0xd6, 0xe8, 0x47, 0x2a, 0x66, 0x0f,
-	0x6f, 0xf2, 0xd1, 0x86, 0x1a, 0x31, 0x8c, 0x1c, 0x12, 0xa7, 0x3d, 0xee, 0x7a, 0xd5, 0xe8, 0xe3,
-	0xb3, 0x6a, 0xc3, 0xe7, 0xcf, 0xb3, 0x4d, 0xce, 0x5c, 0xbf, 0x1d, 0x0d, 0x57, 0xb4, 0x81, 0xc4,
-	0x17, 0x60, 0x8c, 0xf8, 0x72, 0x7a, 0x21, 0xcb, 0xa9, 0x52, 0x34, 0x3f, 0x5d, 0x89, 0x40, 0x38,
-	0xc6, 0x89, 0x06, 0xda, 0xb5, 0xbb, 0x81, 0x28, 0x69, 0x65, 0xc9, 0x59, 0x8a, 0x1a, 0xe8, 0xc6,
-	0xf2, 0x7a, 0x53, 0x96, 0xb9, 0x09, 0x36, 0xa6, 0x5c, 0x8e, 0x67, 0xc6, 0x1a, 0xa5, 0x80, 0xe1,
-	0x04, 0x2b, 0x29, 0xdb, 0x4a, 0xe6, 0xa8, 0x46, 0xb9, 0x9a, 0xc8, 0x54, 0x58, 0x74, 0x45, 0xbd,
-	0x40, 0x54, 0x2d, 0x8f, 0x2c, 0x50, 0xca, 0xb9, 0x77, 0x80, 0xf1, 0x54, 0x2b, 0x43, 0x29, 0x96,
-	0x17, 0x32, 0x5b, 0x2e, 0x6f, 0x3c, 0x5d, 0xde, 0x66, 0x04, 0xc2, 0x31, 0x0e, 0x55, 0x01, 0x42,
-	0x66, 0xab, 0x55, 0xcb, 0x62, 0xa4, 0x54, 0x9f, 0x12, 0xb7, 0xd9, 0x66, 0x02, 0xc5, 0x1a, 0x85,
-	0x49, 0x60, 0x3a, 0xdf, 0x94, 0xdc, 0x09, 0x77, 0x79, 0xaf, 0x08, 0xe7, 0x37, 0x7b, 0x81, 0x38,
-	0xa8, 0xe8, 0x33, 0x87, 0x65, 0xea, 0x79, 0xaa, 0xce, 0xbe, 0xf3, 0x97, 0xf6, 0x2b, 0x50, 0x26,
-	0x37, 0x02, 0x97, 0x11, 0x67, 0x29, 0xf6, 0xb7, 0x2f, 0xdd, 0x9e, 0x8a, 0x2d, 0xb7, 0x4b, 0xd2,
-	0xa5, 0xad, 0xc4, 0x42, 0x70, 0x2a, 0x4f, 0xec, 0x45, 0xe8, 0xfa, 0x36, 0x11, 0xa4, 0xaa, 0xcb,
-	0x49, 0x18, 0x36, 0x63, 0x04, 0x4e, 0x69, 0x44, 0x27, 0xd9, 0x4a, 0x3e, 0x0c, 0x91, 0x3e, 0x78,
-	0x84, 0x4e, 0x32, 0xff, 0x81, 0x49, 0xba, 0x03, 0x29, 0x0c, 0x6b, 0x7a, 0xd0, 0x8f, 0x0d, 0x98,
-	0xb2, 0xb2, 0xdf, 0x76, 0x44, 0x2f, 0x42, 0xd6, 0x8f, 0xa6, 0x7a, 0xc0, 0x77, 0x2a, 0xf5, 0x7b,
-	0x94, 0x1d, 0x53, 0xb9, 0x8f, 0x3c, 0x72, 0xca, 0xcd, 0x4f, 0x0d, 0xb8, 0x6f, 0x80, 0x47, 0x9c,
-	0xc2, 0xf4, 0xc7, 0xcb, 0x4e, 0x7f, 0x86, 0x2e, 0x6f, 0x06, 0x58, 0x3e, 0x60, 0x0e, 0xf4, 0xb3,
-	0x11, 0x78, 0x60, 0x00, 0xc7, 0x91, 0x27, 0x42, 0x4f, 0xc2, 0x64, 0xfc, 0x5f, 0x0f, 0xc3, 0xb4,
-	0x98, 0xd6, 0x91, 0x38, 0x4b, 0x1b, 0xab, 0x92, 0x17, 0x56, 0xa1, 0x5f, 0x55, 0x74, 0x69, 0xc5,
-	0x14, 0xc2, 0xc3, 0x6d, 0xda, 0x0d, 0x3c, 0xc2, 0x49, 0xd4, 0xa6, 0x8f, 0xa7, 0x1e, 0xbe, 0x1c,
-	0x23, 0x70, 0x4a, 0x23, 0x92, 0x14, 0x61, 0x8c, 0x32, 0xe9, 0x61, 0xda, 0x40, 0x7b, 0x45, 0x00,
-	0x71, 0x84, 0x33, 0xff, 0x69, 0xc0, 0x85, 0x01, 0x9b, 0x72, 0x6a, 0x55, 0xee, 0x4e, 0xb6, 0xca,
-	0x7d, 0xe1, 0x84, 0xdc, 0xe0, 0xb0, 0x7a, 0xb7, 0xbe, 0xf5, 0xc1, 0xc7, 0x73, 0x67, 0x3e, 0xfc,
-	0x78, 0xee, 0xcc, 0x47, 0x1f, 0xcf, 0x9d, 0x79, 0x7b, 0x7f, 0xce, 0xf8, 0x60, 0x7f, 0xce, 0xf8,
-	0x70, 0x7f, 0xce, 0xf8, 0x68, 0x7f, 0xce, 0xf8, 0xf3, 0xfe, 0x9c, 0xf1, 0xd3, 0xbf, 0xcc, 0x9d,
-	0x79, 0xb9, 0x3a, 0xdc, 0x77, 0xf0, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0xdf, 0x22, 0xe8, 0xa6,
-	0x38, 0x2f, 0x00, 0x00,
+	// 2933 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x3b, 0xcd, 0x6f, 0x24, 0x47,
+	0xf5, 0xdb, 0x9e, 0x19, 0x7f, 0x3c, 0x8f, 0xbd, 0xe3, 0x72, 0x92, 0x9d, 0x5f, 0x92, 0xb5, 0x37,
+	0x9d, 0xdf, 0x2f, 0xda, 0x1f, 0x82, 0x99, 0xd8, 0x24, 0xd9, 0x85, 0x7c, 0x10, 0x8f, 0xd7, 0xeb,
+	0x0c, 0xb1, 0x9d, 0x49, 0xd9, 0x51, 0xa4, 0x84, 0x84, 0xb4, 0xbb, 0x6b, 0x66, 0x1a, 0xf7, 0x74,
+	0xf5, 0x56, 0x57, 0x3b, 0xeb, 0x1c, 0x50, 0x10, 0x70, 0x08, 0x01, 0x02, 0x5c, 0x10, 0x7f, 0x01,
+	0x17, 0xfe, 0x82, 0xdc, 0x38, 0x20, 0x72, 0x42, 0x41, 0x08, 0x91, 0x93, 0x45, 0x8c, 0x00, 0x21,
+	0xc1, 0x85, 0x1b, 0x8b, 0x90, 0x50, 0x55, 0x57, 0x7f, 0x8e, 0x67, 0x9d, 0xb1, 0xbd, 0x46, 0x22,
+	0x7b, 0xf2, 0xf4, 0xfb, 0xac, 0x57, 0xf5, 0x5e, 0xbd, 0x8f, 0x6e, 0xc3, 0x33, 0x86, 0xcb, 0x19,
+	0x31, 0x6a, 0x36, 0xad, 0x87, 0xbf, 0xea, 0xde, 0x4e, 0xa7, 0x6e, 0x78, 0xb6, 0x5f, 0x37, 0xa9,
+	0xcb, 0x19, 0x75, 0x3c, 0xc7, 0x70, 0x49, 0x7d, 0x77, 0x61, 0x9b, 0x70, 0x63, 0xb1, 0xde, 0x21,
+	0x2e, 0x61, 0x06, 0x27, 0x56, 0xcd, 0x63, 0x94, 0x53, 0x54, 0x0b, 0xb9, 0xbe, 0x6a, 0x53, 0xf5,
+	0xab, 0xe6, 0xed, 0x74, 0x6a, 0x82, 0xbf, 0x96, 0xe6, 0xaf, 0x29, 0xfe, 0xfb, 0xaf, 0x0e, 0xd6,
+	0xe7, 0x73, 0x83, 0xfb, 0xf5, 0xdd, 0x05, 0xc3, 0xf1, 0xba, 0xc6, 0x42, 0x5e, 0xd3, 0xfd, 0x9f,
+	0xeb, 0xd8, 0xbc, 0x1b, 0x6c, 0xd7, 0x4c, 0xda, 0xab, 0x77, 0x68, 0x87, 0xd6, 0x25, 0x78, 0x3b,
+	0x68, 0xcb, 0x27, 0xf9, 0x20, 0x7f, 0x29, 0xf2, 0xc7, 0x76, 0xae, 0xfa, 0x52, 0x8b, 0x67, 0xf7,
+	0x0c, 0xb3, 0x6b, 0xbb, 0x84, 0xed, 0x25, 0xba, 0x7a, 0x84, 0x1b, 0xf5, 0xdd, 0x7e, 0x25, 0xf5,
+	0x41, 0x5c, 0x2c, 0x70, 0xb9, 0xdd, 0x23, 0x7d, 0x0c, 0x4f, 0x1c, 0xc5, 0xe0, 0x9b, 0x5d, 0xd2,
+	0x33, 0xfa, 0xf8, 0x3e, 0x3f, 0x88, 0x2f, 0xe0, 0xb6, 0x53, 0xb7, 0x5d, 0xee, 0x73, 0x96, 0x67,
+	0xd2, 0xff, 0xac, 0x41, 0x79, 0xc9, 0xb2, 0x18, 0xf1, 0xfd, 0x55, 0x46, 0x03, 0x0f, 0xbd, 0x01,
+	0xe3, 0xc2, 0x12, 0xcb, 0xe0, 0x46, 0x55, 0xbb, 0xa4, 0x5d, 0x9e, 0x5c, 0x7c, 0xb4, 0x16, 0x0a,
+	0xae, 0xa5, 0x05, 0x27, 0x67, 0x22, 0xa8, 0x6b, 0xbb, 0x0b, 0xb5, 0x17, 0xb6, 0xbf, 0x46, 0x4c,
+	0xbe, 0x4e, 0xb8, 0xd1, 0x40, 0x1f, 0xec, 0xcf, 0x9f, 0x3b, 0xd8, 0x9f, 0x87, 0x04, 0x86, 0x63,
+	0xa9, 0x28, 0x80, 0x72, 0x47, 0xa8, 0x5a, 0x27, 0xbd, 0x6d, 0xc2, 0xfc, 0xea, 0xc8, 0xa5, 0xc2,
+	0xe5, 0xc9, 0xc5, 0x27, 0x87, 0x3c, 0xf6, 0xda, 0x6a, 0x22, 0xa3, 0x71, 0x8f, 0x52, 0x58, 0x4e,
+	0x01, 0x7d, 0x9c, 0x51, 0xa3, 0xff, 0x46, 0x83, 0x4a, 0xda, 0xd2, 0x35, 0xdb, 0xe7, 0xe8, 0x2b,
+	0x7d, 0xd6, 0xd6, 0x3e, 0x99, 0xb5, 0x82, 0x5b, 0xda, 0x5a, 0x51, 0xaa, 0xc7, 0x23, 0x48, 0xca,
+	0x52, 0x03, 0x4a, 0x36, 0x27, 0xbd, 0xc8, 0xc4, 0xa7, 0x86, 0x35, 0x31, 0xbd, 0xdc, 0xc6, 0x94,
+	0x52, 0x54, 0x6a, 0x0a, 0x91, 0x38, 0x94, 0xac, 0xbf, 0x53, 0x80, 0x99, 0x34, 0x59, 0xcb, 0xe0,
+	0x66, 0xf7, 0x0c, 0x0e, 0xf1, 0x5b, 0x1a, 0xcc, 0x18, 0x96, 0x45, 0xac, 0xd5, 0x53, 0x3e, 0xca,
+	0xff, 0x51, 0x6a, 0x67, 0x96, 0xf2, 0xd2, 0x71, 0xbf, 0x42, 0xf4, 0x1d, 0x0d, 0x66, 0x19, 0xe9,
+	0xd1, 0xdd, 0xdc, 0x42, 0x0a, 0x27, 0x5f, 0xc8, 0x03, 0x6a, 0x21, 0xb3, 0xb8, 0x5f, 0x3e, 0x3e,
+	0x4c, 0xa9, 0xfe, 0x17, 0x0d, 0xa6, 0x97, 0x3c, 0xcf, 0xb1, 0x89, 0xb5, 0x45, 0xff, 0xcb, 0xa3,
+	0xe9, 0x77, 0x1a, 0xa0, 0xac, 0xad, 0x67, 0x10, 0x4f, 0x66, 0x36, 0x9e, 0x9e, 0x19, 0x3a, 0x9e,
+	0x32, 0x0b, 0x1e, 0x10, 0x51, 0xef, 0x16, 0x60, 0x36, 0x4b, 0x78, 0x37, 0xa6, 0xfe, 0x73, 0x31,
+	0x75, 0x03, 0x66, 0x1b, 0x86, 0x6f, 0x9b, 0x4b, 0x01, 0xef, 0x12, 0x97, 0xdb, 0xa6, 0xc1, 0x6d,
+	0xea, 0xa2, 0xcf, 0xc2, 0x78, 0xe0, 0x13, 0xe6, 0x1a, 0x3d, 0x22, 0x0f, 0x63, 0x22, 0xf1, 0x9b,
+	0x97, 0x14, 0x1c, 0xc7, 0x14, 0x82, 0xda, 0x33, 0x7c, 0xff, 0x4d, 0xca, 0xac, 0xea, 0x48, 0x96,
+	0xba, 0xa5, 0xe0, 0x38, 0xa6, 0xd0, 0x17, 0xa0, 0xd2, 0x08, 0x5c, 0xcb, 0x21, 0xd7, 0x6d, 0x87,
+	0x6c, 0x12, 0xb6, 0x4b, 0x18, 0xba, 0x08, 0x85, 0x80, 0x39, 0x4a, 0xd5, 0xa4, 0x62, 0x2e, 0xbc,
+	0x84, 0xd7, 0xb0, 0x80, 0xeb, 0xef, 0x8d, 0xc0, 0xc5, 0x90, 0x27, 0xa4, 0x17, 0xab, 0x5d, 0xa6,
+	0x6e, 0xdb, 0xee, 0x04, 0x2c, 0x5c, 0xf0, 0xe3, 0x30, 0xb9, 0x4d, 0x0c, 0x46, 0xd8, 0x16, 0xdd,
+	0x21, 0xae, 0x12, 0x34, 0xab, 0x04, 0x4d, 0x36, 0x12, 0x14, 0x4e, 0xd3, 0xa1, 0x47, 0x60, 0xd4,
+	0xf0, 0xec, 0xe7, 0xc9, 0x9e, 0x5a, 0xf7, 0xb4, 0xe2, 0x18, 0x5d, 0x6a, 0x35, 0x9f, 0x27, 0x7b,
+	0x58, 0x61, 0xd1, 0xf7, 0x35, 0x98, 0xdd, 0xee, 0xdf, 0xa7, 0x6a, 0x41, 0x3a, 0xea, 0xf2, 0xb0,
+	0x67, 0x76, 0xc8, 0x96, 0x37, 0x2e, 0x88, 0x73, 0x3b, 0x04, 0x81, 0x0f, 0x53, 0xac, 0xff, 0xaa,
+	0x08, 0xb3, 0xcb, 0x4e, 0xe0, 0x73, 0xc2, 0x32, 0xce, 0x75, 0xe7, 0xa3, 0xe8, 0x1b, 0x1a, 0x54,
+	0x48, 0xbb, 0x4d, 0x4c, 0x6e, 0xef, 0x92, 0x53, 0x0c, 0xa2, 0xaa, 0xd2, 0x5a, 0x59, 0xc9, 0x09,
+	0xc7, 0x7d, 0xea, 0xd0, 0xd7, 0x61, 0x26, 0x86, 0x35, 0x5b, 0x0d, 0x87, 0x9a, 0x3b, 0x51, 0xfc,
+	0x3c, 0x3e, 0xec, 0x1a, 0x9a, 0xad, 0x0d, 0xc2, 0x93, 0x10, 0x5e, 0xc9, 0xcb, 0xc5, 0xfd, 0xaa,
+	0xd0, 0x55, 0x28, 0x73, 0xca, 0x0d, 0x27, 0x32, 0xbf, 0x78, 0x49, 0xbb, 0x5c, 0x48, 0xee, 0xf5,
+	0xad, 0x14, 0x0e, 0x67, 0x28, 0xd1, 0x22, 0x80, 0x7c, 0x6e, 0x19, 0x1d, 0xe2, 0x57, 0x4b, 0x92,
+	0x2f, 0xde, 0xef, 0xad, 0x18, 0x83, 0x53, 0x54, 0xc2, 0xb7, 0xcd, 0x80, 0x31, 0xe2, 0x72, 0xf1,
+	0x5c, 0x1d, 0x95, 0x4c, 0xb1, 0x6f, 0x2f, 0x27, 0x28, 0x9c, 0xa6, 0x13, 0x51, 0x29, 0x0c, 0xb6,
+	0xdd, 0x80, 0x54, 0xc7, 0xb2, 0x51, 0xb9, 0xac, 0xe0, 0x38, 0xa6, 0xd0, 0xff, 0xa4, 0xc1, 0xe4,
+	0x4a, 0xe7, 0x53, 0x50, 0xa7, 0xfe, 0x5a, 0x83, 0xf3, 0x29, 0x43, 0xcf, 0x20, 0xad, 0xbe, 0x91,
+	0x4d, 0xab, 0x43, 0x5b, 0x98, 0x5a, 0xed, 0x80, 0x9c, 0xfa, 0xdd, 0x02, 0x54, 0x52, 0x54, 0x61,
+	0x42, 0xb5, 0x00, 0x68, 0xbc, 0xef, 0xa7, 0x7a, 0x86, 0x29, 0xb9, 0x77, 0x93, 0xea, 0x21, 0x49,
+	0xd5, 0x81, 0x0b, 0x2b, 0x37, 0xb9, 0x48, 0x8e, 0xce, 0x8a, 0xcb, 0x6d, 0xbe, 0x87, 0x49, 0x9b,
+	0x30, 0xe2, 0x9a, 0x04, 0x5d, 0x82, 0x62, 0x2a, 0xa9, 0x96, 0x95, 0xe8, 0xe2, 0x86, 0x48, 0xa8,
+	0x12, 0x83, 0xea, 0x30, 0x21, 0xfe, 0xfa, 0x9e, 0x61, 0x12, 0x95, 0x95, 0x66, 0x14, 0xd9, 0xc4,
+	0x46, 0x84, 0xc0, 0x09, 0x8d, 0xfe, 0x4f, 0x0d, 0x2a, 0x52, 0xfd, 0x92, 0xef, 0x53, 0xd3, 0x0e,
+	0xf3, 0xe1, 0x99, 0x54, 0x53, 0x15, 0x43, 0x69, 0x54, 0xf6, 0x1f, 0xbb, 0x70, 0x94, 0xdc, 0xf1,
+	0x26, 0x25, 0xa9, 0x60, 0x29, 0x27, 0x1f, 0xf7, 0x69, 0xd4, 0xdf, 0x2f, 0xc2, 0x64, 0x6a, 0xf3,
+	0xd1, 0xcb, 0x50, 0xf0, 0xa8, 0xa5, 0x6c, 0x1e, 0xba, 0x23, 0x6c, 0x51, 0x2b, 0x59, 0xc6, 0x98,
+	0xa8, 0x41, 0x04, 0x44, 0x48, 0x44, 0xdf, 0xd4, 0x60, 0x9a, 0x64, 0x4e, 0x55, 0x9e, 0xce, 0xe4,
+	0xe2, 0xea, 0xd0, 0xf1, 0x7c, 0xb8, 0x6f, 0x34, 0xd0, 0xc1, 0xfe, 0xfc, 0x74, 0x0e, 0x99, 0x53,
+	0x89, 0x1e, 0x81, 0x82, 0xed, 0x85, 0x6e, 0x5d, 0x6e, 0xdc, 0x23, 0x16, 0xd8, 0x6c, 0xf9, 0xb7,
+	0xf6, 0xe7, 0x27, 0x9a, 0x2d, 0xd5, 0xa6, 0x62, 0x41, 0x80, 0x5e, 0x87, 0x92, 0x47, 0x19, 0x17,
+	0xa9, 0x49, 0x9c, 0xc8, 0x17, 0x86, 0x5d, 0xa3, 0xf0, 0x34, 0xab, 0x45, 0x19, 0x4f, 0x6e, 0x1c,
+	0xf1, 0xe4, 0xe3, 0x50, 0x2c, 0x7a, 0x15, 0x8a, 0x2e, 0xb5, 0x88, 0xcc, 0x60, 0x93, 0x8b, 0x4f,
+	0x0f, 0x2d, 0x9e, 0x5a, 0x24, 0x31, 0x7c, 0x5c, 0x86, 0x80, 0x00, 0x49, 0xa1, 0xa8, 0x03, 0x63,
+	0x3e, 0x61, 0xbb, 0xb6, 0x19, 0x26, 0xbb, 0xc9, 0xc5, 0x67, 0x87, 0x95, 0xbf, 0x19, 0xb2, 0x27,
+	0x2a, 0x26, 0x0f, 0xf6, 0xe7, 0xc7, 0x22, 0x68, 0x24, 0x5d, 0xff, 0x65, 0x11, 0xca, 0x77, 0xcb,
+	0xa7, 0xbb, 0xe5, 0xd3, 0xc9, 0xcb, 0xa7, 0x9f, 0x6a, 0x30, 0x9d, 0xbd, 0xc5, 0xb2, 0x17, 0xb9,
+	0x76, 0xf4, 0x45, 0x1e, 0xe7, 0x86, 0x91, 0x81, 0xb9, 0xa1, 0x01, 0x85, 0xc0, 0xb6, 0x64, 0xd7,
+	0x31, 0xd1, 0x78, 0x34, 0x6e, 0x93, 0x9a, 0xd7, 0x6e, 0xed, 0xcf, 0x3f, 0x34, 0x68, 0x3c, 0xc9,
+	0xf7, 0x3c, 0xe2, 0xd7, 0x5e, 0x6a, 0x5e, 0xc3, 0x82, 0x59, 0x7f, 0x0b, 0xca, 0xcf, 0x6d, 0x6d,
+	0xb5, 0x5a, 0x8c, 0x72, 0x6a, 0x52, 0x47, 0x68, 0xed, 0x52, 0x9f, 0xe7, 0x33, 0xd2, 0x73, 0xd4,
+	0xe7, 0x58, 0x62, 0x44, 0x93, 0xd4, 0x23, 0xbc, 0x4b, 0xad, 0x7c, 0x93, 0xb4, 0x2e, 0xa1, 0x58,
+	0x61, 0x85, 0x24, 0xcf, 0xe0, 0xdd, 0x6a, 0x21, 0x2b, 0xa9, 0x65, 0xf0, 0x2e, 0x96, 0x18, 0xfd,
+	0xe7, 0x1a, 0x8c, 0x29, 0x2f, 0x40, 0x2f, 0x43, 0xd1, 0xb4, 0x2d, 0xa6, 0xc2, 0xec, 0x98, 0x7e,
+	0x17, 0x2b, 0x59, 0x6e, 0x5e, 0xc3, 0x58, 0x0a, 0x44, 0xaf, 0xc1, 0x28, 0xb9, 0x69, 0x12, 0x8f,
+	0xab, 0xb0, 0x3a, 0xa6, 0xe8, 0xd8, 0xca, 0x15, 0x29, 0x0c, 0x2b, 0xa1, 0xfa, 0xbf, 0x34, 0x40,
+	0xcd, 0xd6, 0xa7, 0x37, 0xe1, 0xb6, 0xa1, 0x24, 0x37, 0x08, 0x3d, 0x0c, 0x23, 0xb6, 0x27, 0x6d,
+	0x2d, 0x37, 0x66, 0x0f, 0xf6, 0xe7, 0x47, 0x9a, 0xad, 0x6c, 0x22, 0x1a, 0xb1, 0x3d, 0x11, 0xea,
+	0x1e, 0x23, 0x6d, 0xfb, 0xe6, 0x1a, 0x71, 0x3b, 0xbc, 0x2b, 0x3d, 0xa8, 0x94, 0x84, 0x7a, 0x2b,
+	0x85, 0xc3, 0x19, 0x4a, 0xbd, 0x0b, 0xb0, 0x76, 0x25, 0xf6, 0xd2, 0x57, 0xa0, 0xd8, 0xe5, 0xdc,
+	0x3b, 0x6e, 0x5e, 0x4f, 0x7b, 0x7c, 0x98, 0x6e, 0x04, 0x04, 0x4b, 0x99, 0xfa, 0x4f, 0x34, 0x40,
+	0xeb, 0x81, 0x23, 0x7a, 0x6b, 0x9f, 0x4b, 0x2b, 0x9b, 0x6e, 0x9b, 0xa2, 0x87, 0xa1, 0x24, 0x1b,
+	0x07, 0x15, 0x19, 0x71, 0x1e, 0x0c, 0xf7, 0x2e, 0xc4, 0xa1, 0xd7, 0xa1, 0xe8, 0x51, 0xeb, 0xd8,
+	0x13, 0xe8, 0x4c, 0xbd, 0x91, 0x44, 0x0c, 0xb5, 0x7c, 0x2c, 0xe5, 0xea, 0xef, 0x68, 0x30, 0x11,
+	0xe7, 0x62, 0x19, 0x61, 0x94, 0x85, 0xb1, 0x5a, 0x4a, 0xd3, 0x33, 0x8e, 0x8b, 0x9e, 0xa2, 0x38,
+	0xe2, 0x0e, 0xb9, 0x0a, 0xe3, 0x9e, 0xda, 0x09, 0x15, 0xa9, 0x0f, 0xc6, 0xc3, 0x1a, 0x05, 0xbf,
+	0x95, 0xfa, 0x8d, 0x63, 0x6a, 0xfd, 0x6f, 0x05, 0x98, 0xda, 0x20, 0xfc, 0x4d, 0xca, 0x76, 0x5a,
+	0xd4, 0xb1, 0xcd, 0xbd, 0x33, 0x70, 0xfa, 0x36, 0x94, 0x58, 0xe0, 0x90, 0x68, 0x83, 0x97, 0x86,
+	0x2e, 0x34, 0xd2, 0xeb, 0xc5, 0x81, 0x43, 0x92, 0x73, 0x14, 0x4f, 0x3e, 0x0e, 0xc5, 0xa3, 0xa7,
+	0xe1, 0xbc, 0x91, 0x19, 0x4a, 0x86, 0x09, 0x71, 0x42, 0x7a, 0xf6, 0xf9, 0xec, 0xbc, 0xd2, 0xc7,
+	0x79, 0x5a, 0x74, 0x59, 0x6c, 0xaa, 0x4d, 0x99, 0xa8, 0x0a, 0x45, 0x36, 0xd3, 0x1a, 0xe5, 0x70,
+	0x43, 0x43, 0x18, 0x8e, 0xb1, 0xe8, 0x31, 0x28, 0x73, 0x9b, 0xb0, 0x08, 0x23, 0x73, 0x58, 0xa9,
+	0x51, 0x91, 0x79, 0x2f, 0x05, 0xc7, 0x19, 0x2a, 0xe4, 0xc3, 0x84, 0x4f, 0x03, 0x26, 0x2b, 0x1a,
+	0x55, 0x13, 0x5d, 0x3f, 0xd9, 0x56, 0xc4, 0x5e, 0x37, 0x25, 0xf2, 0xd1, 0x66, 0x24, 0x1c, 0x27,
+	0x7a, 0xf4, 0xdf, 0x6a, 0x30, 0x93, 0x61, 0x3a, 0x83, 0x5e, 0x79, 0x3b, 0xdb, 0x2b, 0x3f, 0x7d,
+	0x22, 0x23, 0x07, 0x74, 0xcb, 0x7f, 0xd7, 0xe0, 0x42, 0x86, 0x4e, 0x94, 0x9e, 0x9b, 0xdc, 0xe0,
+	0x81, 0x2f, 0xb2, 0xbe, 0x28, 0x41, 0x37, 0x0e, 0x19, 0x7c, 0x6e, 0x28, 0x38, 0x8e, 0x29, 0x44,
+	0x39, 0xa2, 0x5e, 0xf8, 0x89, 0x61, 0xe0, 0x48, 0xb6, 0x1c, 0x59, 0x8d, 0x31, 0x38, 0x45, 0x85,
+	0xbe, 0x0c, 0x88, 0x11, 0xc3, 0xb1, 0xdf, 0x92, 0x8f, 0xd7, 0x0d, 0xdb, 0x09, 0x18, 0x91, 0x91,
+	0x38, 0xde, 0xb8, 0x5f, 0xf1, 0x22, 0xdc, 0x47, 0x81, 0x0f, 0xe1, 0x42, 0xff, 0x0f, 0x63, 0x3d,
+	0xe2, 0xfb, 0xa2, 0xac, 0x29, 0xca, 0xc5, 0x9e, 0x57, 0x02, 0xc6, 0xd6, 0x43, 0x30, 0x8e, 0xf0,
+	0xf2, 0x45, 0x56, 0xc6, 0xe8, 0x16, 0x21, 0x0c, 0x5d, 0x81, 0x29, 0x23, 0xf5, 0x76, 0xcb, 0xaf,
+	0x6a, 0xd2, 0xe9, 0x67, 0x0e, 0xf6, 0xe7, 0xa7, 0xd2, 0xaf, 0xbd, 0x7c, 0x9c, 0xa5, 0x43, 0x04,
+	0xc6, 0x6d, 0x4f, 0x55, 0x8e, 0xe1, 0x51, 0x5d, 0x19, 0x3e, 0xcd, 0x4a, 0xfe, 0x64, 0x83, 0xe3,
+	0x92, 0x31, 0x16, 0x8d, 0xe6, 0xa1, 0xd4, 0xbe, 0x61, 0xb9, 0x51, 0x30, 0x4e, 0x88, 0xb3, 0xbc,
+	0xfe, 0xe2, 0xb5, 0x0d, 0x1f, 0x87, 0x70, 0xc4, 0x45, 0x41, 0xa8, 0xea, 0xfa, 0xa8, 0xd9, 0x39,
+	0x79, 0xb7, 0x90, 0x2a, 0x29, 0x23, 0xd9, 0x38, 0xa5, 0x47, 0xdc, 0x16, 0x8e, 0xb1, 0x4d, 0x9c,
+	0xa6, 0x45, 0x44, 0x5b, 0x66, 0xcb, 0x5a, 0xb4, 0x70, 0x79, 0x2a, 0xbc, 0x2d, 0xd6, 0xb2, 0x28,
+	0x9c, 0xa7, 0x15, 0xb3, 0xb6, 0xfb, 0x0e, 0x8f, 0x46, 0xf4, 0x38, 0x14, 0x45, 0xbd, 0xa6, 0x7c,
+	0xef, 0xa1, 0xe8, 0xfe, 0xde, 0xda, 0xf3, 0xc8, 0xad, 0xfd, 0xf9, 0xec, 0x09, 0x0a, 0x20, 0x96,
+	0xe4, 0x43, 0x0f, 0x0d, 0xe2, 0x3c, 0x51, 0x38, 0xaa, 0xd6, 0x2c, 0x9e, 0xa4, 0xd6, 0xfc, 0xeb,
+	0x58, 0xce, 0xe9, 0xc4, 0x9d, 0x8b, 0x9e, 0x82, 0x09, 0xcb, 0x66, 0xa2, 0x27, 0xa0, 0xd1, 0xa4,
+	0x7e, 0x2e, 0x5a, 0xec, 0xb5, 0x08, 0x71, 0x2b, 0xfd, 0x80, 0x13, 0x06, 0x64, 0x42, 0xb1, 0xcd,
+	0x68, 0x4f, 0x35, 0xdf, 0x27, 0x4b, 0x08, 0x22, 0x06, 0x12, 0xe3, 0xaf, 0x33, 0xda, 0xc3, 0x52,
+	0x38, 0x7a, 0x0d, 0x46, 0x38, 0xad, 0x16, 0x4e, 0x4b, 0x05, 0x28, 0x15, 0x23, 0x5b, 0x14, 0x8f,
+	0x70, 0x2a, 0xa2, 0xc7, 0xcf, 0xfa, 0xec, 0x95, 0x63, 0xfa, 0x6c, 0x12, 0x3d, 0xb1, 0xa3, 0xc6,
+	0xa2, 0xe5, 0x7b, 0x99, 0x5c, 0x9e, 0x49, 0x52, 0x7d, 0x5f, 0x66, 0x7a, 0x19, 0x46, 0x8d, 0xf0,
+	0x4c, 0x46, 0xe5, 0x99, 0x7c, 0x49, 0xbe, 0x07, 0x89, 0x0e, 0x63, 0xe1, 0x36, 0x5f, 0x9d, 0x30,
+	0x2b, 0xfe, 0x06, 0xa4, 0x26, 0x4e, 0x38, 0x64, 0xc2, 0x4a, 0x1c, 0x7a, 0x12, 0xa6, 0x88, 0x6b,
+	0x6c, 0x3b, 0x64, 0x8d, 0x76, 0x3a, 0xb6, 0xdb, 0x91, 0xed, 0xd4, 0x78, 0xe3, 0x5e, 0xb5, 0x96,
+	0xa9, 0x95, 0x34, 0x12, 0x67, 0x69, 0x0f, 0x4b, 0xcc, 0xe3, 0x43, 0x24, 0xe6, 0xc8, 0xcf, 0x27,
+	0x06, 0xfa, 0xf9, 0x0d, 0x98, 0x74, 0xe2, 0x3a, 0xd3, 0xaf, 0x82, 0x3c, 0x8e, 0x2f, 0x0e, 0x7b,
+	0x1c, 0x49, 0xa9, 0x9a, 0xb4, 0x96, 0x09, 0xcc, 0xc7, 0x69, 0x1d, 0xe2, 0x5c, 0x1c, 0xda, 0x91,
+	0xd7, 0x44, 0x75, 0x32, 0x9b, 0x64, 0xd6, 0x14, 0x1c, 0xc7, 0x14, 0xe8, 0x59, 0xa8, 0x30, 0x72,
+	0x23, 0xb0, 0x19, 0xb1, 0xae, 0x13, 0x83, 0x07, 0x8c, 0xf8, 0xd5, 0xb2, 0xdc, 0x02, 0x31, 0xff,
+	0xa9, 0xe0, 0x1c, 0x0e, 0xf7, 0x51, 0xa3, 0x36, 0x4c, 0x30, 0x83, 0x93, 0x35, 0xbb, 0x67, 0xf3,
+	0xea, 0xd4, 0x25, 0xed, 0x38, 0x03, 0x21, 0x1c, 0x09, 0x08, 0x0b, 0x86, 0xf8, 0x11, 0x27, 0xa2,
+	0xf5, 0xf7, 0x0a, 0x80, 0x32, 0xce, 0x2f, 0x92, 0xaa, 0x2f, 0x26, 0x67, 0x53, 0x6e, 0x1a, 0x5c,
+	0xd5, 0x4e, 0xb5, 0x82, 0x89, 0x1d, 0x29, 0x8b, 0xcf, 0xea, 0x44, 0x1e, 0x94, 0x39, 0x33, 0xda,
+	0x6d, 0xdb, 0x94, 0xab, 0x52, 0xf7, 0xc7, 0x13, 0xb7, 0x59, 0x83, 0xfc, 0xba, 0xa9, 0x16, 0x7b,
+	0xf6, 0x56, 0x8a, 0x3b, 0x35, 0xac, 0x48, 0x41, 0x71, 0x46, 0x03, 0x7a, 0x5b, 0x83, 0x8a, 0xa8,
+	0x2e, 0xd3, 0x24, 0xd5, 0xc2, 0x91, 0xfe, 0x95, 0x53, 0x8b, 0x73, 0x12, 0x92, 0x66, 0x2d, 0x8f,
+	0xc1, 0x7d, 0xda, 0xf4, 0x3f, 0x6a, 0x30, 0xdb, 0x77, 0x22, 0xc1, 0x59, 0xcc, 0xb9, 0x1c, 0x28,
+	0x89, 0x32, 0x29, 0xaa, 0x0e, 0x56, 0x4f, 0x74, 0xd6, 0x49, 0x81, 0x96, 0x94, 0x74, 0x02, 0xe6,
+	0xe3, 0x50, 0x89, 0xbe, 0x00, 0x53, 0x99, 0x91, 0xe2, 0xd1, 0x73, 0x76, 0xfd, 0xfd, 0x12, 0x54,
+	0x22, 0xb9, 0xfe, 0x66, 0xd0, 0xeb, 0x19, 0xec, 0x2c, 0x1a, 0x9a, 0x6f, 0x6b, 0x70, 0x3e, 0xed,
+	0x98, 0x76, 0xbc, 0x45, 0x8d, 0x13, 0x6d, 0x51, 0xe8, 0x1b, 0x17, 0x94, 0xee, 0xf3, 0x1b, 0x59,
+	0x15, 0x38, 0xaf, 0x13, 0xfd, 0x4c, 0x83, 0x07, 0x43, 0x2d, 0xea, 0x35, 0x72, 0x8e, 0xa3, 0x5a,
+	0x38, 0xb5, 0x45, 0xfd, 0xaf, 0x5a, 0xd4, 0x83, 0x4b, 0xb7, 0xd1, 0x87, 0x6f, 0xbb, 0x1a, 0xf4,
+	0x63, 0x0d, 0xee, 0x0d, 0x09, 0xf2, 0xeb, 0x2c, 0x9e, 0xda, 0x3a, 0x2f, 0xaa, 0x75, 0xde, 0xbb,
+	0x74, 0x98, 0x22, 0x7c, 0xb8, 0x7e, 0xd1, 0x9a, 0xf5, 0xa2, 0xe1, 0x41, 0xb5, 0x74, 0xbc, 0xc5,
+	0xf4, 0x4f, 0x1f, 0x92, 0xf2, 0x2d, 0xc6, 0xe1, 0x44, 0x8f, 0xfe, 0xae, 0x06, 0xf7, 0xb4, 0x8c,
+	0x8e, 0xed, 0xca, 0x76, 0x60, 0x95, 0xf0, 0x17, 0x3c, 0xf1, 0xc3, 0x0f, 0x67, 0x70, 0x9d, 0xd0,
+	0xef, 0x0b, 0xe9, 0x19, 0x5c, 0x87, 0x60, 0x89, 0x11, 0x63, 0x0d, 0x47, 0x26, 0x82, 0xb0, 0x5d,
+	0x89, 0xe3, 0x29, 0xbc, 0xcd, 0x43, 0x5c, 0x66, 0xf8, 0x59, 0x38, 0x72, 0xf8, 0x69, 0x40, 0x39,
+	0x3d, 0xc8, 0xb8, 0x13, 0x2f, 0xb9, 0x7e, 0xa8, 0x41, 0x92, 0x73, 0xd0, 0x35, 0xa8, 0x78, 0x86,
+	0xb9, 0x43, 0xb8, 0xdf, 0x22, 0x6c, 0x93, 0x98, 0xd4, 0xb5, 0xd4, 0x4c, 0x24, 0xbe, 0x1c, 0x5b,
+	0x39, 0x3c, 0xee, 0xe3, 0x40, 0xcf, 0xc0, 0xf4, 0xf6, 0x1e, 0x27, 0x29, 0x19, 0xe1, 0x96, 0xdc,
+	0xa7, 0x64, 0x4c, 0x37, 0x32, 0x58, 0x9c, 0xa3, 0xd6, 0x7f, 0x51, 0x80, 0xe8, 0x95, 0x02, 0x7a,
+	0x2c, 0x35, 0x55, 0x09, 0xcd, 0xae, 0x1e, 0x3d, 0x51, 0x41, 0x1b, 0x6a, 0x9e, 0x33, 0x72, 0xc4,
+	0x55, 0x23, 0xbe, 0x30, 0xad, 0x85, 0x5f, 0x98, 0xd6, 0x9a, 0x2e, 0x7f, 0x81, 0x6d, 0x72, 0x66,
+	0xbb, 0x9d, 0xc6, 0x78, 0x6e, 0xfa, 0xf3, 0x7f, 0x30, 0x46, 0x5c, 0x39, 0x2a, 0x92, 0xa7, 0x56,
+	0x0a, 0x5f, 0x7b, 0xac, 0x84, 0x20, 0x1c, 0xe1, 0xc4, 0xb4, 0xc2, 0x36, 0x7b, 0x9e, 0xe8, 0x1f,
+	0x64, 0x7d, 0x5f, 0x0a, 0xa7, 0x15, 0xcd, 0xe5, 0xf5, 0x96, 0x80, 0xe1, 0x18, 0x1b, 0x51, 0x2e,
+	0x47, 0xaf, 0x7a, 0x52, 0x94, 0x02, 0x86, 0x63, 0xac, 0xa4, 0xec, 0x28, 0x99, 0xa3, 0x29, 0xca,
+	0xd5, 0x58, 0xa6, 0xc2, 0x8a, 0x91, 0xa0, 0x9c, 0x9d, 0xa9, 0xfe, 0x52, 0x0d, 0xd7, 0xb3, 0xaf,
+	0xee, 0x15, 0x0e, 0x67, 0x28, 0x85, 0x79, 0x3e, 0x33, 0xa5, 0x79, 0xe3, 0x89, 0x79, 0x9b, 0x21,
+	0x08, 0x47, 0x38, 0x54, 0x03, 0xf0, 0x99, 0xa9, 0xac, 0x96, 0x95, 0x5f, 0xa9, 0x31, 0x2d, 0x2e,
+	0xe4, 0xcd, 0x18, 0x8a, 0x53, 0x14, 0x3a, 0x81, 0x4a, 0xbe, 0x03, 0xbc, 0x13, 0x2e, 0xfc, 0x5e,
+	0x11, 0x2e, 0x6c, 0x06, 0x9e, 0x38, 0xa8, 0xf0, 0x5b, 0xa6, 0x65, 0xea, 0x38, 0xaa, 0xa9, 0xb9,
+	0xf3, 0x79, 0xe7, 0x55, 0x98, 0x20, 0x37, 0x3d, 0x51, 0x16, 0x2e, 0x45, 0xfe, 0xf6, 0x99, 0x4f,
+	0xa6, 0x62, 0xcb, 0xee, 0x91, 0xc4, 0xb4, 0x95, 0x48, 0x08, 0x4e, 0xe4, 0x89, 0xbd, 0xf0, 0x6d,
+	0xd7, 0x24, 0x82, 0x54, 0xdd, 0x17, 0x31, 0xc3, 0x66, 0x84, 0xc0, 0x09, 0x8d, 0x68, 0xdb, 0xdb,
+	0xf1, 0xd7, 0x5f, 0xd2, 0x07, 0x8f, 0xd1, 0xb6, 0xe7, 0xbf, 0x22, 0x4b, 0x76, 0x20, 0x81, 0xe1,
+	0x94, 0x1e, 0xf4, 0x3d, 0x0d, 0xa6, 0x8d, 0xec, 0x07, 0x5c, 0xe1, 0xfb, 0xcb, 0xf5, 0xe3, 0xa9,
+	0x1e, 0xf0, 0x31, 0x5a, 0x72, 0x81, 0xe4, 0xbe, 0xe4, 0xca, 0x29, 0x17, 0x1f, 0xb4, 0x3e, 0x30,
+	0xc0, 0x23, 0xce, 0x60, 0xd4, 0xe6, 0x64, 0x47, 0x6d, 0x43, 0x57, 0x68, 0x03, 0x56, 0x3e, 0x60,
+	0xe8, 0xf6, 0xa3, 0x11, 0x78, 0x68, 0x00, 0xc7, 0xb1, 0xc7, 0x6f, 0x4f, 0xc2, 0x54, 0xf4, 0x3b,
+	0x1d, 0x86, 0x49, 0x3f, 0x90, 0x46, 0xe2, 0x2c, 0x6d, 0xa4, 0x4a, 0x5e, 0x58, 0x85, 0x7e, 0x55,
+	0xe1, 0xa5, 0x15, 0x51, 0x08, 0x0f, 0x37, 0x69, 0xcf, 0x73, 0x08, 0x27, 0xe1, 0x4c, 0x64, 0x3c,
+	0xf1, 0xf0, 0xe5, 0x08, 0x81, 0x13, 0x1a, 0x91, 0x66, 0x09, 0x63, 0x94, 0x55, 0x4b, 0xd9, 0xb7,
+	0x07, 0x2b, 0x02, 0x88, 0x43, 0x9c, 0xfe, 0x0f, 0x0d, 0x2e, 0x0e, 0xd8, 0x94, 0x33, 0x2b, 0xd4,
+	0x77, 0xb3, 0x85, 0xfa, 0x8b, 0xa7, 0xe4, 0x06, 0x47, 0x95, 0xec, 0x8d, 0xad, 0x0f, 0x3e, 0x9e,
+	0x3b, 0xf7, 0xe1, 0xc7, 0x73, 0xe7, 0x3e, 0xfa, 0x78, 0xee, 0xdc, 0xdb, 0x07, 0x73, 0xda, 0x07,
+	0x07, 0x73, 0xda, 0x87, 0x07, 0x73, 0xda, 0x47, 0x07, 0x73, 0xda, 0xef, 0x0f, 0xe6, 0xb4, 0x1f,
+	0xfc, 0x61, 0xee, 0xdc, 0x2b, 0xb5, 0xe1, 0xfe, 0xd9, 0xe5, 0xdf, 0x03, 0x00, 0x68, 0xbc, 0x44,
+	0xbe, 0x1d, 0x33, 0x00, 0x00,
 }
 
 func (m *AddressGroup) Marshal() (dAtA []byte, err error) {
@@ -1888,6 +1954,11 @@ func (m *ClusterGroupMembers) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	i -= len(m.Continue)
+	copy(dAtA[i:], m.Continue)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Continue)))
+	i--
+	dAtA[i] = 0x3a
 	i = encodeVarintGenerated(dAtA, i, uint64(m.CurrentPage))
 	i--
 	dAtA[i] = 0x30
@@ -2267,6 +2338,81 @@ func (m *GroupMember) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *GroupMembers) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GroupMembers) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GroupMembers) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i -= len(m.Continue)
+	copy(dAtA[i:], m.Continue)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Continue)))
+	i--
+	dAtA[i] = 0x3a
+	i = encodeVarintGenerated(dAtA, i, uint64(m.CurrentPage))
+	i--
+	dAtA[i] = 0x30
+	i = encodeVarintGenerated(dAtA, i, uint64(m.TotalPages))
+	i--
+	dAtA[i] = 0x28
+	i = encodeVarintGenerated(dAtA, i, uint64(m.TotalMembers))
+	i--
+	dAtA[i] = 0x20
+	if len(m.EffectiveIPBlocks) > 0 {
+		for iNdEx := len(m.EffectiveIPBlocks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.EffectiveIPBlocks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.EffectiveMembers) > 0 {
+		for iNdEx := len(m.EffectiveMembers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.EffectiveMembers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	{
+		size, err := m.ObjectMeta.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintGenerated(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func (m *GroupReference) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2892,6 +3038,27 @@ func (m *NetworkPolicyRule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.RateLimit != nil {
+		{
+			size, err := m.RateLimit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenerated(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	if len(m.RequiredFeatures) > 0 {
+		for iNdEx := len(m.RequiredFeatures) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.RequiredFeatures[iNdEx])
+			copy(dAtA[i:], m.RequiredFeatures[iNdEx])
+			i = encodeVarintGenerated(dAtA, i, uint64(len(m.RequiredFeatures[iNdEx])))
+			i--
+			dAtA[i] = 0x62
+		}
+	}
 	i -= len(m.LogLabel)
 	copy(dAtA[i:], m.LogLabel)
 	i = encodeVarintGenerated(dAtA, i, uint64(len(m.LogLabel)))
@@ -3226,6 +3393,11 @@ func (m *PaginationGetOptions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	i -= len(m.Continue)
+	copy(dAtA[i:], m.Continue)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.Continue)))
+	i--
+	dAtA[i] = 0x1a
 	i = encodeVarintGenerated(dAtA, i, uint64(m.Limit))
 	i--
 	dAtA[i] = 0x10
@@ -3268,6 +3440,35 @@ func (m *PodReference) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RateLimit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RateLimit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RateLimit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i = encodeVarintGenerated(dAtA, i, uint64(m.BytesPerSecond))
+	i--
+	dAtA[i] = 0x10
+	i = encodeVarintGenerated(dAtA, i, uint64(m.PacketsPerSecond))
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
+}
+
 func (m *Service) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3780,6 +3981,8 @@ func (m *ClusterGroupMembers) Size() (n int) {
 	n += 1 + sovGenerated(uint64(m.TotalMembers))
 	n += 1 + sovGenerated(uint64(m.TotalPages))
 	n += 1 + sovGenerated(uint64(m.CurrentPage))
+	l = len(m.Continue)
+	n += 1 + l + sovGenerated(uint64(l))
 	return n
 }
 
@@ -3907,6 +4110,34 @@ func (m *GroupMember) Size() (n int) {
 	return n
 }
 
+func (m *GroupMembers) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ObjectMeta.Size()
+	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.EffectiveMembers) > 0 {
+		for _, e := range m.EffectiveMembers {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	if len(m.EffectiveIPBlocks) > 0 {
+		for _, e := range m.EffectiveIPBlocks {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	n += 1 + sovGenerated(uint64(m.TotalMembers))
+	n += 1 + sovGenerated(uint64(m.TotalPages))
+	n += 1 + sovGenerated(uint64(m.CurrentPage))
+	l = len(m.Continue)
+	n += 1 + l + sovGenerated(uint64(l))
+	return n
+}
+
 func (m *GroupReference) Size() (n int) {
 	if m == nil {
 		return 0
@@ -4189,6 +4420,16 @@ func (m *NetworkPolicyRule) Size() (n int) {
 	}
 	l = len(m.LogLabel)
 	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.RequiredFeatures) > 0 {
+		for _, s := range m.RequiredFeatures {
+			l = len(s)
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
+	if m.RateLimit != nil {
+		l = m.RateLimit.Size()
+		n += 1 + l + sovGenerated(uint64(l))
+	}
 	return n
 }
 
@@ -4282,6 +4523,8 @@ func (m *PaginationGetOptions) Size() (n int) {
 	_ = l
 	n += 1 + sovGenerated(uint64(m.Page))
 	n += 1 + sovGenerated(uint64(m.Limit))
+	l = len(m.Continue)
+	n += 1 + l + sovGenerated(uint64(l))
 	return n
 }
 
@@ -4298,6 +4541,17 @@ func (m *PodReference) Size() (n int) {
 	return n
 }
 
+func (m *RateLimit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovGenerated(uint64(m.PacketsPerSecond))
+	n += 1 + sovGenerated(uint64(m.BytesPerSecond))
+	return n
+}
+
 func (m *Service) Size() (n int) {
 	if m == nil {
 		return 0
@@ -4587,6 +4841,7 @@ func (this *ClusterGroupMembers) String() string {
 		`TotalMembers:` + fmt.Sprintf("%v", this.TotalMembers) + `,`,
 		`TotalPages:` + fmt.Sprintf("%v", this.TotalPages) + `,`,
 		`CurrentPage:` + fmt.Sprintf("%v", this.CurrentPage) + `,`,
+		`Continue:` + fmt.Sprintf("%v", this.Continue) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -4692,14 +4947,40 @@ func (this *GroupMember) String() string {
 	}, "")
 	return s
 }
-func (this *GroupReference) String() string {
+func (this *GroupMembers) String() string {
 	if this == nil {
 		return "nil"
 	}
-	s := strings.Join([]string{`&GroupReference{`,
-		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
-		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
-		`UID:` + fmt.Sprintf("%v", this.UID) + `,`,
+	repeatedStringForEffectiveMembers := "[]GroupMember{"
+	for _, f := range this.EffectiveMembers {
+		repeatedStringForEffectiveMembers += strings.Replace(strings.Replace(f.String(), "GroupMember", "GroupMember", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForEffectiveMembers += "}"
+	repeatedStringForEffectiveIPBlocks := "[]IPNet{"
+	for _, f := range this.EffectiveIPBlocks {
+		repeatedStringForEffectiveIPBlocks += strings.Replace(strings.Replace(f.String(), "IPNet", "IPNet", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForEffectiveIPBlocks += "}"
+	s := strings.Join([]string{`&GroupMembers{`,
+		`ObjectMeta:` + strings.Replace(strings.Replace(fmt.Sprintf("%v", this.ObjectMeta), "ObjectMeta", "v1.ObjectMeta", 1), `&`, ``, 1) + `,`,
+		`EffectiveMembers:` + repeatedStringForEffectiveMembers + `,`,
+		`EffectiveIPBlocks:` + repeatedStringForEffectiveIPBlocks + `,`,
+		`TotalMembers:` + fmt.Sprintf("%v", this.TotalMembers) + `,`,
+		`TotalPages:` + fmt.Sprintf("%v", this.TotalPages) + `,`,
+		`CurrentPage:` + fmt.Sprintf("%v", this.CurrentPage) + `,`,
+		`Continue:` + fmt.Sprintf("%v", this.Continue) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *GroupReference) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&GroupReference{`,
+		`Namespace:` + fmt.Sprintf("%v", this.Namespace) + `,`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`UID:` + fmt.Sprintf("%v", this.UID) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -4909,6 +5190,8 @@ func (this *NetworkPolicyRule) String() string {
 		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
 		`L7Protocols:` + repeatedStringForL7Protocols + `,`,
 		`LogLabel:` + fmt.Sprintf("%v", this.LogLabel) + `,`,
+		`RequiredFeatures:` + fmt.Sprintf("%v", this.RequiredFeatures) + `,`,
+		`RateLimit:` + strings.Replace(this.RateLimit.String(), "RateLimit", "RateLimit", 1) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -4997,6 +5280,7 @@ func (this *PaginationGetOptions) String() string {
 	s := strings.Join([]string{`&PaginationGetOptions{`,
 		`Page:` + fmt.Sprintf("%v", this.Page) + `,`,
 		`Limit:` + fmt.Sprintf("%v", this.Limit) + `,`,
+		`Continue:` + fmt.Sprintf("%v", this.Continue) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -5012,6 +5296,17 @@ func (this *PodReference) String() string {
 	}, "")
 	return s
 }
+func (this *RateLimit) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&RateLimit{`,
+		`PacketsPerSecond:` + fmt.Sprintf("%v", this.PacketsPerSecond) + `,`,
+		`BytesPerSecond:` + fmt.Sprintf("%v", this.BytesPerSecond) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *Service) String() string {
 	if this == nil {
 		return "nil"
@@ -6412,6 +6707,38 @@ func (m *ClusterGroupMembers) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Continue", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Continue = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -7309,6 +7636,246 @@ func (m *GroupMember) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *GroupMembers) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GroupMembers: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GroupMembers: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObjectMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ObjectMeta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EffectiveMembers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EffectiveMembers = append(m.EffectiveMembers, GroupMember{})
+			if err := m.EffectiveMembers[len(m.EffectiveMembers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EffectiveIPBlocks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EffectiveIPBlocks = append(m.EffectiveIPBlocks, IPNet{})
+			if err := m.EffectiveIPBlocks[len(m.EffectiveIPBlocks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalMembers", wireType)
+			}
+			m.TotalMembers = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalMembers |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalPages", wireType)
+			}
+			m.TotalPages = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalPages |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CurrentPage", wireType)
+			}
+			m.CurrentPage = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CurrentPage |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Continue", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Continue = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *GroupReference) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -9559,6 +10126,74 @@ func (m *NetworkPolicyRule) Unmarshal(dAtA []byte) error {
 			}
 			m.LogLabel = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequiredFeatures", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RequiredFeatures = append(m.RequiredFeatures, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RateLimit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RateLimit == nil {
+				m.RateLimit = &RateLimit{}
+			}
+			if err := m.RateLimit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -10215,6 +10850,38 @@ func (m *PaginationGetOptions) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Continue", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Continue = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -10350,6 +11017,94 @@ func (m *PodReference) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RateLimit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RateLimit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RateLimit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PacketsPerSecond", wireType)
+			}
+			m.PacketsPerSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PacketsPerSecond |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BytesPerSecond", wireType)
+			}
+			m.BytesPerSecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BytesPerSecond |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Service) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -107,6 +107,27 @@ type ClusterGroupMembers struct {
 	TotalMembers      int64         `json:"totalMembers" protobuf:"varint,4,opt,name=totalMembers"`
 	TotalPages        int64         `json:"totalPages" protobuf:"varint,5,opt,name=totalPages"`
 	CurrentPage       int64         `json:"currentPage" protobuf:"varint,6,opt,name=currentPage"`
+	// Continue is set when more members are available, and can be passed back in PaginationGetOptions
+	// to retrieve the next chunk of members.
+	Continue string `json:"continue,omitempty" protobuf:"bytes,7,opt,name=continue"`
+}
+
+// +genclient
+// +genclient:onlyVerbs=get
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// GroupMembers is a list of GroupMember objects or ipBlocks that are currently selected by a Group.
+type GroupMembers struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	EffectiveMembers  []GroupMember `json:"effectiveMembers" protobuf:"bytes,2,rep,name=effectiveMembers"`
+	EffectiveIPBlocks []IPNet       `json:"effectiveIPBlocks" protobuf:"bytes,3,rep,name=effectiveIPBlocks"`
+	TotalMembers      int64         `json:"totalMembers" protobuf:"varint,4,opt,name=totalMembers"`
+	TotalPages        int64         `json:"totalPages" protobuf:"varint,5,opt,name=totalPages"`
+	CurrentPage       int64         `json:"currentPage" protobuf:"varint,6,opt,name=currentPage"`
+	// Continue is set when more members are available, and can be passed back in PaginationGetOptions
+	// to retrieve the next chunk of members.
+	Continue string `json:"continue,omitempty" protobuf:"bytes,7,opt,name=continue"`
 }
 
 // +k8s:conversion-gen:explicit-from=net/url.Values
@@ -116,6 +137,9 @@ type PaginationGetOptions struct {
 	metav1.TypeMeta `json:",inline"`
 	Page            int64 `json:"page" protobuf:"varint,1,opt,name=page"`
 	Limit           int64 `json:"limit" protobuf:"varint,2,opt,name=limit"`
+	// Continue is the token returned by a previous request, to retrieve the next chunk of members. When it's
+	// set, Page is ignored. Setting Limit without Page or Continue retrieves the first chunk of members.
+	Continue string `json:"continue,omitempty" protobuf:"bytes,3,opt,name=continue"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -260,7 +284,35 @@ type NetworkPolicyRule struct {
 	L7Protocols []L7Protocol `json:"l7Protocols,omitempty" protobuf:"bytes,10,rep,name=l7Protocols"`
 	// LogLabel is a user-defined arbitrary string which will be printed in the NetworkPolicy logs.
 	LogLabel string `json:"logLabel,omitempty" protobuf:"bytes,11,opt,name=logLabel"`
-}
+	// RequiredFeatures is a list of NetworkPolicy features which must be supported by
+	// the agent to enforce this rule. An agent which doesn't support any of them must
+	// not enforce the rule partially, and must report it as not realized instead.
+	RequiredFeatures []string `json:"requiredFeatures,omitempty" protobuf:"bytes,12,rep,name=requiredFeatures"`
+	// RateLimit specifies the rate limit enforced on the traffic matching this rule.
+	// It is set if and only if Action is RateLimit.
+	RateLimit *RateLimit `json:"rateLimit,omitempty" protobuf:"bytes,13,opt,name=rateLimit"`
+}
+
+// RateLimit describes the maximum rate of the traffic matching a rule. Exactly one
+// of PacketsPerSecond and BytesPerSecond is set.
+type RateLimit struct {
+	// PacketsPerSecond is the maximum number of packets per second.
+	PacketsPerSecond int32 `json:"packetsPerSecond,omitempty" protobuf:"varint,1,opt,name=packetsPerSecond"`
+	// BytesPerSecond is the maximum number of bytes per second.
+	BytesPerSecond int64 `json:"bytesPerSecond,omitempty" protobuf:"varint,2,opt,name=bytesPerSecond"`
+}
+
+// NetworkPolicy features which may be required by a NetworkPolicyRule. The agents
+// advertise the features they support, so that the rules requiring other features can
+// be reported as not realized instead of being enforced partially.
+const (
+	// NetworkPolicyFeatureL7Protocols is required by the rules with L7Protocols.
+	NetworkPolicyFeatureL7Protocols = "L7Protocols"
+	// NetworkPolicyFeatureIGMP is required by the rules matching IGMP traffic.
+	NetworkPolicyFeatureIGMP = "IGMP"
+	// NetworkPolicyFeatureRateLimit is required by the rules with the RateLimit action.
+	NetworkPolicyFeatureRateLimit = "RateLimit"
+)
 
 // Protocol defines network protocols supported for things like container ports.
 type Protocol string
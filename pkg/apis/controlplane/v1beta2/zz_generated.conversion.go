@@ -199,6 +199,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GroupMembers)(nil), (*controlplane.GroupMembers)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_GroupMembers_To_controlplane_GroupMembers(a.(*GroupMembers), b.(*controlplane.GroupMembers), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*controlplane.GroupMembers)(nil), (*GroupMembers)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_controlplane_GroupMembers_To_v1beta2_GroupMembers(a.(*controlplane.GroupMembers), b.(*GroupMembers), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GroupReference)(nil), (*controlplane.GroupReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_GroupReference_To_controlplane_GroupReference(a.(*GroupReference), b.(*controlplane.GroupReference), scope)
 	}); err != nil {
@@ -399,6 +409,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RateLimit)(nil), (*controlplane.RateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_RateLimit_To_controlplane_RateLimit(a.(*RateLimit), b.(*controlplane.RateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*controlplane.RateLimit)(nil), (*RateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_controlplane_RateLimit_To_v1beta2_RateLimit(a.(*controlplane.RateLimit), b.(*RateLimit), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Service)(nil), (*controlplane.Service)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_Service_To_controlplane_Service(a.(*Service), b.(*controlplane.Service), scope)
 	}); err != nil {
@@ -846,6 +866,7 @@ func autoConvert_v1beta2_ClusterGroupMembers_To_controlplane_ClusterGroupMembers
 	out.TotalMembers = in.TotalMembers
 	out.TotalPages = in.TotalPages
 	out.CurrentPage = in.CurrentPage
+	out.Continue = in.Continue
 	return nil
 }
 
@@ -871,6 +892,7 @@ func autoConvert_controlplane_ClusterGroupMembers_To_v1beta2_ClusterGroupMembers
 	out.TotalMembers = in.TotalMembers
 	out.TotalPages = in.TotalPages
 	out.CurrentPage = in.CurrentPage
+	out.Continue = in.Continue
 	return nil
 }
 
@@ -1101,6 +1123,58 @@ func Convert_controlplane_GroupMember_To_v1beta2_GroupMember(in *controlplane.Gr
 	return autoConvert_controlplane_GroupMember_To_v1beta2_GroupMember(in, out, s)
 }
 
+func autoConvert_v1beta2_GroupMembers_To_controlplane_GroupMembers(in *GroupMembers, out *controlplane.GroupMembers, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if in.EffectiveMembers != nil {
+		in, out := &in.EffectiveMembers, &out.EffectiveMembers
+		*out = make([]controlplane.GroupMember, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta2_GroupMember_To_controlplane_GroupMember(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.EffectiveMembers = nil
+	}
+	out.EffectiveIPBlocks = *(*[]controlplane.IPNet)(unsafe.Pointer(&in.EffectiveIPBlocks))
+	out.TotalMembers = in.TotalMembers
+	out.TotalPages = in.TotalPages
+	out.CurrentPage = in.CurrentPage
+	out.Continue = in.Continue
+	return nil
+}
+
+// Convert_v1beta2_GroupMembers_To_controlplane_GroupMembers is an autogenerated conversion function.
+func Convert_v1beta2_GroupMembers_To_controlplane_GroupMembers(in *GroupMembers, out *controlplane.GroupMembers, s conversion.Scope) error {
+	return autoConvert_v1beta2_GroupMembers_To_controlplane_GroupMembers(in, out, s)
+}
+
+func autoConvert_controlplane_GroupMembers_To_v1beta2_GroupMembers(in *controlplane.GroupMembers, out *GroupMembers, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if in.EffectiveMembers != nil {
+		in, out := &in.EffectiveMembers, &out.EffectiveMembers
+		*out = make([]GroupMember, len(*in))
+		for i := range *in {
+			if err := Convert_controlplane_GroupMember_To_v1beta2_GroupMember(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.EffectiveMembers = nil
+	}
+	out.EffectiveIPBlocks = *(*[]IPNet)(unsafe.Pointer(&in.EffectiveIPBlocks))
+	out.TotalMembers = in.TotalMembers
+	out.TotalPages = in.TotalPages
+	out.CurrentPage = in.CurrentPage
+	out.Continue = in.Continue
+	return nil
+}
+
+// Convert_controlplane_GroupMembers_To_v1beta2_GroupMembers is an autogenerated conversion function.
+func Convert_controlplane_GroupMembers_To_v1beta2_GroupMembers(in *controlplane.GroupMembers, out *GroupMembers, s conversion.Scope) error {
+	return autoConvert_controlplane_GroupMembers_To_v1beta2_GroupMembers(in, out, s)
+}
+
 func autoConvert_v1beta2_GroupReference_To_controlplane_GroupReference(in *GroupReference, out *controlplane.GroupReference, s conversion.Scope) error {
 	out.Namespace = in.Namespace
 	out.Name = in.Name
@@ -1483,6 +1557,8 @@ func autoConvert_v1beta2_NetworkPolicyRule_To_controlplane_NetworkPolicyRule(in
 	out.Name = in.Name
 	out.L7Protocols = *(*[]controlplane.L7Protocol)(unsafe.Pointer(&in.L7Protocols))
 	out.LogLabel = in.LogLabel
+	out.RequiredFeatures = *(*[]string)(unsafe.Pointer(&in.RequiredFeatures))
+	out.RateLimit = (*controlplane.RateLimit)(unsafe.Pointer(in.RateLimit))
 	return nil
 }
 
@@ -1517,6 +1593,8 @@ func autoConvert_controlplane_NetworkPolicyRule_To_v1beta2_NetworkPolicyRule(in
 	out.AppliedToGroups = *(*[]string)(unsafe.Pointer(&in.AppliedToGroups))
 	out.L7Protocols = *(*[]L7Protocol)(unsafe.Pointer(&in.L7Protocols))
 	out.LogLabel = in.LogLabel
+	out.RequiredFeatures = *(*[]string)(unsafe.Pointer(&in.RequiredFeatures))
+	out.RateLimit = (*RateLimit)(unsafe.Pointer(in.RateLimit))
 	return nil
 }
 
@@ -1626,6 +1704,7 @@ func Convert_controlplane_NodeStatsSummary_To_v1beta2_NodeStatsSummary(in *contr
 func autoConvert_v1beta2_PaginationGetOptions_To_controlplane_PaginationGetOptions(in *PaginationGetOptions, out *controlplane.PaginationGetOptions, s conversion.Scope) error {
 	out.Page = in.Page
 	out.Limit = in.Limit
+	out.Continue = in.Continue
 	return nil
 }
 
@@ -1637,6 +1716,7 @@ func Convert_v1beta2_PaginationGetOptions_To_controlplane_PaginationGetOptions(i
 func autoConvert_controlplane_PaginationGetOptions_To_v1beta2_PaginationGetOptions(in *controlplane.PaginationGetOptions, out *PaginationGetOptions, s conversion.Scope) error {
 	out.Page = in.Page
 	out.Limit = in.Limit
+	out.Continue = in.Continue
 	return nil
 }
 
@@ -1662,6 +1742,13 @@ func autoConvert_url_Values_To_v1beta2_PaginationGetOptions(in *url.Values, out
 	} else {
 		out.Limit = 0
 	}
+	if values, ok := map[string][]string(*in)["continue"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_string(&values, &out.Continue, s); err != nil {
+			return err
+		}
+	} else {
+		out.Continue = ""
+	}
 	return nil
 }
 
@@ -1692,6 +1779,28 @@ func Convert_controlplane_PodReference_To_v1beta2_PodReference(in *controlplane.
 	return autoConvert_controlplane_PodReference_To_v1beta2_PodReference(in, out, s)
 }
 
+func autoConvert_v1beta2_RateLimit_To_controlplane_RateLimit(in *RateLimit, out *controlplane.RateLimit, s conversion.Scope) error {
+	out.PacketsPerSecond = in.PacketsPerSecond
+	out.BytesPerSecond = in.BytesPerSecond
+	return nil
+}
+
+// Convert_v1beta2_RateLimit_To_controlplane_RateLimit is an autogenerated conversion function.
+func Convert_v1beta2_RateLimit_To_controlplane_RateLimit(in *RateLimit, out *controlplane.RateLimit, s conversion.Scope) error {
+	return autoConvert_v1beta2_RateLimit_To_controlplane_RateLimit(in, out, s)
+}
+
+func autoConvert_controlplane_RateLimit_To_v1beta2_RateLimit(in *controlplane.RateLimit, out *RateLimit, s conversion.Scope) error {
+	out.PacketsPerSecond = in.PacketsPerSecond
+	out.BytesPerSecond = in.BytesPerSecond
+	return nil
+}
+
+// Convert_controlplane_RateLimit_To_v1beta2_RateLimit is an autogenerated conversion function.
+func Convert_controlplane_RateLimit_To_v1beta2_RateLimit(in *controlplane.RateLimit, out *RateLimit, s conversion.Scope) error {
+	return autoConvert_controlplane_RateLimit_To_v1beta2_RateLimit(in, out, s)
+}
+
 func autoConvert_v1beta2_Service_To_controlplane_Service(in *Service, out *controlplane.Service, s conversion.Scope) error {
 	out.Protocol = (*controlplane.Protocol)(unsafe.Pointer(in.Protocol))
 	out.Port = (*intstr.IntOrString)(unsafe.Pointer(in.Port))
@@ -99,6 +99,19 @@ const (
 	EtherTypeIPv6 uint16 = 0x86DD
 )
 
+// List the IP families reported in the results of dual-stack Traceflow.
+const (
+	IPFamilyIPv4 = "IPv4"
+	IPFamilyIPv6 = "IPv6"
+)
+
+// List the directions of the packets reported in the results of reverse-path
+// Traceflow.
+const (
+	TraceflowDirectionForward = "Forward"
+	TraceflowDirectionReverse = "Reverse"
+)
+
 // Default timeout in seconds.
 const DefaultTraceflowTimeout uint16 = 20
 
@@ -126,6 +139,17 @@ type TraceflowSpec struct {
 	// DroppedOnly indicates only the dropped packet should be captured in a
 	// live-traffic Traceflow.
 	DroppedOnly bool `json:"droppedOnly,omitempty"`
+	// DualStack indicates the Traceflow is to trace a packet of each IP
+	// family, when the destination Pod or Service has both an IPv4 and an
+	// IPv6 address. The observations of each IP family are reported in
+	// separate NodeResults. It is not supported for live-traffic Traceflow.
+	DualStack bool `json:"dualStack,omitempty"`
+	// ReversePath indicates the Traceflow is to also trace the reply packet,
+	// injected from the destination Pod after the forward packet is
+	// delivered to it. The observations of the forward and reply packets
+	// are reported in separate NodeResults. It is not supported for
+	// live-traffic Traceflow.
+	ReversePath bool `json:"reversePath,omitempty"`
 	// Timeout specifies the timeout of the Traceflow in seconds. Defaults
 	// to 20 seconds if not set.
 	Timeout uint16 `json:"timeout,omitempty"`
@@ -236,6 +260,9 @@ type TraceflowStatus struct {
 	StartTime *metav1.Time `json:"startTime,omitempty"`
 	// DataplaneTag is a tag to identify a traceflow session across Nodes.
 	DataplaneTag uint8 `json:"dataplaneTag,omitempty"`
+	// ReverseDataplaneTag is a tag to identify the reply packet of a
+	// reverse-path Traceflow across Nodes.
+	ReverseDataplaneTag uint8 `json:"reverseDataplaneTag,omitempty"`
 	// Results is the collection of all observations on different nodes.
 	Results []NodeResult `json:"results,omitempty"`
 	// CapturedPacket is the captured packet in live-traffic Traceflow.
@@ -247,6 +274,12 @@ type NodeResult struct {
 	Node string `json:"node,omitempty" yaml:"node,omitempty"`
 	// Role of the node like sender, receiver, etc.
 	Role string `json:"role,omitempty" yaml:"role,omitempty"`
+	// IPFamily is the IP family (IPv4 or IPv6) of the traced packet. It is
+	// only set for dual-stack Traceflow.
+	IPFamily string `json:"ipFamily,omitempty" yaml:"ipFamily,omitempty"`
+	// Direction is the direction (Forward or Reverse) of the traced
+	// packet. It is only set for reverse-path Traceflow.
+	Direction string `json:"direction,omitempty" yaml:"direction,omitempty"`
 	// Timestamp is the timestamp of the observations on the node.
 	Timestamp int64 `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
 	// Observations includes all observations from sender nodes, receiver ones, etc.
@@ -289,6 +322,38 @@ type TraceflowList struct {
 	Items []Traceflow `json:"items"`
 }
 
+const (
+	// AuditLogFileAnnotationKey can be added to an Antrea-native policy to write the audit log entries of the policy to
+	// a dedicated file, instead of the default np.log file. The value is a file name in the NetworkPolicy log directory.
+	AuditLogFileAnnotationKey = "networkpolicy.antrea.io/audit-log-file"
+	// AuditLogRateLimitAnnotationKey can be added to an Antrea-native policy to limit the number of audit log entries
+	// written for the policy per second. The log entries in excess of the limit are dropped.
+	AuditLogRateLimitAnnotationKey = "networkpolicy.antrea.io/audit-log-rate-limit"
+	// AuditLogSeverityAnnotationKey can be added to an Antrea-native policy to set the severity of the connections
+	// denied by the policy. The Antrea Agent emits Kubernetes Events for the denials of the policies whose severity
+	// reaches the configured threshold. The value must be one of AuditLogSeverities.
+	AuditLogSeverityAnnotationKey = "networkpolicy.antrea.io/audit-log-severity"
+	// L7FailModeAnnotationKey can be added to an Antrea-native policy to set how the traffic matched by its Layer 7
+	// rules is handled when the L7 engine fails to load the rules. The value must be one of L7FailModes, the default is
+	// L7FailModeClosed.
+	L7FailModeAnnotationKey = "networkpolicy.antrea.io/l7-fail-mode"
+)
+
+const (
+	// L7FailModeClosed rejects the traffic matched by the Layer 7 rules of a policy when they cannot be loaded.
+	L7FailModeClosed = "closed"
+	// L7FailModeOpen lets the traffic matched by the Layer 7 rules of a policy pass without Layer 7 filtering when
+	// they cannot be loaded.
+	L7FailModeOpen = "open"
+)
+
+// L7FailModes are the valid values of the L7FailModeAnnotationKey annotation.
+var L7FailModes = []string{L7FailModeClosed, L7FailModeOpen}
+
+// AuditLogSeverities are the valid values of the AuditLogSeverityAnnotationKey annotation, in increasing order of
+// severity.
+var AuditLogSeverities = []string{"Low", "Medium", "High", "Critical"}
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -441,6 +506,23 @@ type Rule struct {
 	// conjunction with NetworkPolicySpec/ClusterNetworkPolicySpec.AppliedTo.
 	// +optional
 	AppliedTo []AppliedTo `json:"appliedTo,omitempty"`
+	// RateLimit specifies the rate limit enforced on the traffic matching the rule.
+	// It must be set if and only if Action is RateLimit.
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+}
+
+// RateLimit describes the maximum rate of the traffic matching a rule. The rate
+// limit is enforced on each Node independently, and it applies to the aggregate
+// traffic matching the rule on the Node. Exactly one of PacketsPerSecond and
+// BytesPerSecond must be set.
+type RateLimit struct {
+	// PacketsPerSecond is the maximum number of packets per second.
+	// +optional
+	PacketsPerSecond int32 `json:"packetsPerSecond,omitempty"`
+	// BytesPerSecond is the maximum number of bytes per second.
+	// +optional
+	BytesPerSecond int64 `json:"bytesPerSecond,omitempty"`
 }
 
 // NetworkPolicyPeer describes the grouping selector of workloads.
@@ -483,9 +565,10 @@ type NetworkPolicyPeer struct {
 	// an Ingress or Egress rule in place of a stand-alone selector.
 	// A Group cannot be set with any other selector.
 	Group string `json:"group,omitempty"`
-	// Restrict egress access to the Fully Qualified Domain Names prescribed
-	// by name or by wildcard match patterns. This field can only be set for
-	// NetworkPolicyPeer of egress rules.
+	// Restrict egress access to, or ingress access from, the Fully Qualified
+	// Domain Names prescribed by name or by wildcard match patterns. For
+	// ingress rules, the FQDNs are matched against the IP addresses resolved
+	// from DNS responses seen by the Node, which is best-effort.
 	// Supported formats are:
 	//  Exact FQDNs, i.e. "google.com", "db-svc.default.svc.cluster.local"
 	//  Wildcard expressions, i.e. "*wayfair.com".
@@ -541,14 +624,30 @@ type AppliedTo struct {
 	// Select a certain Service which matches the NamespacedName.
 	// A Service can only be set in either policy level AppliedTo field in a policy
 	// that only has ingress rules or rule level AppliedTo field in an ingress rule.
-	// Only a NodePort Service can be referred by this field.
+	// The policy is enforced on the traffic destined to the ClusterIP of the
+	// Service and, if the Service is of type NodePort, to its NodePorts.
 	// Cannot be set with any other selector.
 	// +optional
 	Service *NamespacedName `json:"service,omitempty"`
+	// Select certain Nodes which match the label selector. The policy is
+	// enforced on the host network traffic of the selected Nodes.
+	// A NodeSelector can only be set in ClusterNetworkPolicy, and if it is
+	// set in any AppliedTo of a policy, all AppliedTo fields of the policy
+	// must be set with NodeSelector.
+	// Cannot be set with any other selector.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
 }
 
 type PeerNamespaces struct {
+	// Selects from the same Namespace of the appliedTo workloads.
+	// Cannot be set with SameLabels.
 	Match NamespaceMatchType `json:"match,omitempty"`
+	// Selects from the Namespaces which have the same values for the given
+	// label keys as the Namespace of the appliedTo workloads. Namespaces
+	// which do not have all the label keys are not selected.
+	// Cannot be set with Match.
+	SameLabels []string `json:"sameLabels,omitempty"`
 }
 
 // NamespaceMatchType describes Namespace matching strategy.
@@ -571,6 +670,11 @@ type IPBlock struct {
 	// CIDR is a string representing the IP Block
 	// Valid examples are "192.168.1.1/24".
 	CIDR string `json:"cidr"`
+	// Except is a slice of CIDRs that should not be included within the IP
+	// Block. Except values will be rejected if they are outside the CIDR range.
+	// It is only supported in the ipBlocks of ClusterGroups and Groups.
+	// +optional
+	Except []string `json:"except,omitempty"`
 }
 
 // NetworkPolicyPort describes the port and protocol to match in a rule.
@@ -613,6 +717,10 @@ const (
 	// RuleActionReject indicates that the traffic matching the rule must be rejected and the
 	// client will receive a response.
 	RuleActionReject RuleAction = "Reject"
+	// RuleActionRateLimit indicates that the traffic matching the rule must be allowed
+	// within the rate limit specified in the rule, and the packets exceeding the rate
+	// limit will be dropped.
+	RuleActionRateLimit RuleAction = "RateLimit"
 
 	IGMPQuery    int32 = 0x11
 	IGMPReportV1 int32 = 0x12
@@ -715,6 +823,63 @@ type TierList struct {
 	Items []Tier `json:"items"`
 }
 
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TierEntitlement entitles some users, groups and ServiceAccounts to manage the
+// Antrea-native policies in some Tiers. Once a Tier is referenced by at least
+// one TierEntitlement, only the subjects of the TierEntitlements referencing it
+// can create, update and delete the Antrea-native policies in the Tier.
+type TierEntitlement struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard metadata of the object.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of TierEntitlement.
+	Spec TierEntitlementSpec `json:"spec"`
+}
+
+// TierEntitlementSpec defines the desired state for TierEntitlement.
+type TierEntitlementSpec struct {
+	// Tiers is the list of names of the Tiers protected by this
+	// TierEntitlement.
+	Tiers []string `json:"tiers"`
+	// Subjects is the list of users, groups and ServiceAccounts entitled to
+	// manage the Antrea-native policies in the Tiers.
+	Subjects []TierEntitlementSubject `json:"subjects"`
+}
+
+type TierEntitlementSubjectKind string
+
+const (
+	TierEntitlementSubjectKindUser           TierEntitlementSubjectKind = "User"
+	TierEntitlementSubjectKindGroup          TierEntitlementSubjectKind = "Group"
+	TierEntitlementSubjectKindServiceAccount TierEntitlementSubjectKind = "ServiceAccount"
+)
+
+// TierEntitlementSubject refers to a user, a group or a ServiceAccount.
+type TierEntitlementSubject struct {
+	// Kind of the subject, which can be User, Group or ServiceAccount.
+	Kind TierEntitlementSubjectKind `json:"kind"`
+	// Name of the subject.
+	Name string `json:"name"`
+	// Namespace of the ServiceAccount. It must be set if and only if Kind is
+	// ServiceAccount.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type TierEntitlementList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []TierEntitlement `json:"items"`
+}
+
 // NamespacedName refers to a Namespace scoped resource.
 // All fields must be used together.
 type NamespacedName struct {
@@ -56,6 +56,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&IPPoolList{},
 		&TrafficControl{},
 		&TrafficControlList{},
+		&TrafficMirror{},
+		&TrafficMirrorList{},
 	)
 
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
@@ -116,12 +116,13 @@ type GroupSpec struct {
 	IPBlock *v1alpha1.IPBlock `json:"ipBlock,omitempty"`
 	// IPBlocks is a list of IPAddresses/IPBlocks that is matched in to/from.
 	// IPBlock cannot be set as part of the AppliedTo field.
-	// Cannot be set with any other selector or ServiceReference.
+	// Cannot be set with any other selector. Can be set with ServiceReference.
 	// Cannot be set with IPBlock.
 	// +optional
 	IPBlocks []v1alpha1.IPBlock `json:"ipBlocks,omitempty"`
 	// Select backend Pods of the referred Service.
-	// Cannot be set with any other selector or ipBlock.
+	// Cannot be set with any other selector or ipBlock. Can be set with
+	// ipBlocks.
 	// +optional
 	ServiceReference *v1alpha1.NamespacedName `json:"serviceReference,omitempty"`
 	// Select ExternalEntities from all Namespaces as workloads
@@ -228,6 +229,12 @@ type EgressSpec struct {
 	// same index in EgressIPs and ExternalIPPools are correlated.
 	// Cannot be set with ExternalIPPool.
 	ExternalIPPools []string `json:"externalIPPools,omitempty"`
+	// DestinationCIDRs restricts the Egress to the traffic destined for the provided CIDRs, e.g. 192.168.10.0/24.
+	// If it is empty, the Egress applies to all the traffic to the external network.
+	// A Pod can be selected by an Egress without DestinationCIDRs and by Egresses with different DestinationCIDRs at
+	// the same time, in which case the traffic destined for the DestinationCIDRs of an Egress is SNAT'd to the IP of
+	// this Egress, and the rest of the traffic is SNAT'd to the IP of the Egress without DestinationCIDRs.
+	DestinationCIDRs []string `json:"destinationCIDRs,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -263,6 +270,28 @@ type ExternalIPPoolSpec struct {
 	IPRanges []IPRange `json:"ipRanges"`
 	// The Nodes that the external IPs can be assigned to. If empty, it means all Nodes.
 	NodeSelector metav1.LabelSelector `json:"nodeSelector"`
+	// Placement specifies how the external IPs are distributed among the Nodes selected by NodeSelector.
+	// If nil, each IP is assigned to one of the selected Nodes by consistent hashing, without any constraint.
+	// +optional
+	Placement *ExternalIPPoolPlacement `json:"placement,omitempty"`
+}
+
+// ExternalIPPoolPlacement describes the constraints applied when electing the owner Nodes of the IPs of an
+// ExternalIPPool.
+type ExternalIPPoolPlacement struct {
+	// SpreadTopologyKey is the key of the Node label used to group Nodes into failure domains, e.g.
+	// topology.kubernetes.io/zone. If set, the IPs are spread evenly across failure domains first, then across the
+	// Nodes of each failure domain. Nodes without the label are considered to be in the same failure domain.
+	// +optional
+	SpreadTopologyKey string `json:"spreadTopologyKey,omitempty"`
+	// MaxIPsPerNode is the maximum number of IPs of this pool that can be assigned to a single Node. 0 means no
+	// limit. It is enforced in addition to the Node capacity configured for Egress.
+	// +optional
+	MaxIPsPerNode int32 `json:"maxIPsPerNode,omitempty"`
+	// PreferredNodeSelector selects the Nodes, among the ones selected by NodeSelector, that IPs should be assigned
+	// to in priority. When none of the preferred Nodes is available, IPs fall back to the other selected Nodes.
+	// +optional
+	PreferredNodeSelector *metav1.LabelSelector `json:"preferredNodeSelector,omitempty"`
 }
 
 // IPRange is a set of contiguous IP addresses, represented by a CIDR or a pair of start and end IPs.
@@ -319,6 +348,31 @@ type IPPoolSpec struct {
 	IPVersion IPVersion `json:"ipVersion"`
 	// List IP ranges, along with subnet definition.
 	IPRanges []SubnetIPRange `json:"ipRanges"`
+	// GatewayProxy enables antrea-agent to reply to the ARP requests sent by local Pods for the gateways of the IP
+	// ranges, and to forward the traffic between local Pods of IPPools with GatewayProxy enabled in different VLANs
+	// directly, instead of sending it to the underlay router. It only takes effect in bridging mode, and only IPv4
+	// IPPools are supported.
+	GatewayProxy bool `json:"gatewayProxy,omitempty"`
+	// Reservations reserves IPs of the IPPool for the Pods with the specified names. A reserved IP is only allocated
+	// to the Pod it is reserved for, and the Pod always gets the reserved IP, including after it is recreated or
+	// rescheduled to another Node.
+	Reservations []IPReservation `json:"reservations,omitempty"`
+	// PodSelector selects the Pods which can be allocated IPs from the IPPool, when the IPPool is specified with the
+	// IPPool annotation of their Namespace. It allows a Namespace to be annotated with multiple IPPools, in which case
+	// a Pod is allocated IPs from the first IPPool selecting it. An IPPool without PodSelector selects all the Pods of
+	// the Namespace. It is ignored when the IPPool is specified with the IPPool annotation of the Pod.
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// IPReservation reserves an IP of an IPPool for a Pod.
+type IPReservation struct {
+	// IP address to reserve. It must be in one of the IP ranges of the IPPool.
+	IPAddress string `json:"ipAddress"`
+	// Namespace of the Pod.
+	Namespace string `json:"namespace"`
+	// Name of the Pod. The name of a StatefulSet Pod is the name of the StatefulSet suffixed by its ordinal, e.g.
+	// "web-0", so that an IP can be reserved for a given ordinal of a StatefulSet.
+	PodName string `json:"podName"`
 }
 
 // SubnetInfo specifies subnet attributes for IP Range
@@ -522,3 +576,68 @@ type TrafficControlList struct {
 
 	Items []TrafficControl `json:"items"`
 }
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TrafficMirror allows mirroring the traffic Pods send or receive to a local network device or to a remote collector
+// through an ERSPAN tunnel, typically for integration with an Intrusion Detection System. Unlike TrafficControl, it can
+// select the traffic to mirror with 5-tuple filters.
+type TrafficMirror struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard metadata of the object.
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of TrafficMirror.
+	Spec TrafficMirrorSpec `json:"spec"`
+}
+
+type TrafficMirrorSpec struct {
+	// AppliedTo selects Pods whose traffic will be mirrored.
+	AppliedTo AppliedTo `json:"appliedTo"`
+
+	// The direction of traffic that should be mirrored. It can be Ingress, Egress, or Both.
+	Direction Direction `json:"direction"`
+
+	// Filters select the traffic that should be mirrored. A packet is mirrored if it matches any of the filters. If no
+	// filter is specified, all the traffic of the selected Pods in the given direction is mirrored.
+	Filters []TrafficMirrorFilter `json:"filters,omitempty"`
+
+	// The target to which the mirrored traffic should be sent.
+	Target TrafficMirrorTarget `json:"target"`
+}
+
+// TrafficMirrorFilter matches packets by their 5-tuple. Fields which are not set match any value.
+type TrafficMirrorFilter struct {
+	// The protocol of the packets. It can be TCP, UDP, SCTP or ICMP. Ports can only be set when it is TCP, UDP or SCTP.
+	Protocol *string `json:"protocol,omitempty"`
+	// The source CIDR of the packets.
+	SourceCIDR string `json:"sourceCIDR,omitempty"`
+	// The destination CIDR of the packets.
+	DestinationCIDR string `json:"destinationCIDR,omitempty"`
+	// The source transport layer port of the packets.
+	SourcePort *int32 `json:"sourcePort,omitempty"`
+	// The destination transport layer port of the packets.
+	DestinationPort *int32 `json:"destinationPort,omitempty"`
+}
+
+// TrafficMirrorTarget represents the target of traffic mirroring. Exactly one of the fields should be set.
+type TrafficMirrorTarget struct {
+	// Device represents a local network device, e.g. an interface connected to an IDS appliance. It must exist on all
+	// Nodes. Antrea will attach it to the OVS bridge if it is not attached.
+	Device *NetworkDevice `json:"device,omitempty"`
+	// ERSPAN represents an ERSPAN tunnel to a remote collector.
+	ERSPAN *ERSPANTunnel `json:"erspan,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type TrafficMirrorList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []TrafficMirror `json:"items"`
+}
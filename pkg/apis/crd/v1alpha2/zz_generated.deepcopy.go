@@ -228,6 +228,11 @@ func (in *EgressSpec) DeepCopyInto(out *EgressSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DestinationCIDRs != nil {
+		in, out := &in.DestinationCIDRs, &out.DestinationCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -420,6 +425,27 @@ func (in *ExternalIPPoolList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalIPPoolPlacement) DeepCopyInto(out *ExternalIPPoolPlacement) {
+	*out = *in
+	if in.PreferredNodeSelector != nil {
+		in, out := &in.PreferredNodeSelector, &out.PreferredNodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalIPPoolPlacement.
+func (in *ExternalIPPoolPlacement) DeepCopy() *ExternalIPPoolPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalIPPoolPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalIPPoolSpec) DeepCopyInto(out *ExternalIPPoolSpec) {
 	*out = *in
@@ -429,6 +455,11 @@ func (in *ExternalIPPoolSpec) DeepCopyInto(out *ExternalIPPoolSpec) {
 		copy(*out, *in)
 	}
 	in.NodeSelector.DeepCopyInto(&out.NodeSelector)
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(ExternalIPPoolPlacement)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -513,12 +544,14 @@ func (in *GroupSpec) DeepCopyInto(out *GroupSpec) {
 	if in.IPBlock != nil {
 		in, out := &in.IPBlock, &out.IPBlock
 		*out = new(v1alpha1.IPBlock)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.IPBlocks != nil {
 		in, out := &in.IPBlocks, &out.IPBlocks
 		*out = make([]v1alpha1.IPBlock, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ServiceReference != nil {
 		in, out := &in.ServiceReference, &out.ServiceReference
@@ -683,6 +716,16 @@ func (in *IPPoolSpec) DeepCopyInto(out *IPPoolSpec) {
 		*out = make([]SubnetIPRange, len(*in))
 		copy(*out, *in)
 	}
+	if in.Reservations != nil {
+		in, out := &in.Reservations, &out.Reservations
+		*out = make([]IPReservation, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -752,6 +795,22 @@ func (in *IPRange) DeepCopy() *IPRange {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPReservation) DeepCopyInto(out *IPReservation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPReservation.
+func (in *IPReservation) DeepCopy() *IPReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(IPReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamedPort) DeepCopyInto(out *NamedPort) {
 	*out = *in
@@ -995,6 +1054,148 @@ func (in *TrafficControlSpec) DeepCopy() *TrafficControlSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficMirror) DeepCopyInto(out *TrafficMirror) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficMirror.
+func (in *TrafficMirror) DeepCopy() *TrafficMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrafficMirror) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficMirrorFilter) DeepCopyInto(out *TrafficMirrorFilter) {
+	*out = *in
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.SourcePort != nil {
+		in, out := &in.SourcePort, &out.SourcePort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DestinationPort != nil {
+		in, out := &in.DestinationPort, &out.DestinationPort
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficMirrorFilter.
+func (in *TrafficMirrorFilter) DeepCopy() *TrafficMirrorFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficMirrorFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficMirrorList) DeepCopyInto(out *TrafficMirrorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TrafficMirror, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficMirrorList.
+func (in *TrafficMirrorList) DeepCopy() *TrafficMirrorList {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficMirrorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TrafficMirrorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficMirrorSpec) DeepCopyInto(out *TrafficMirrorSpec) {
+	*out = *in
+	in.AppliedTo.DeepCopyInto(&out.AppliedTo)
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]TrafficMirrorFilter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Target.DeepCopyInto(&out.Target)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficMirrorSpec.
+func (in *TrafficMirrorSpec) DeepCopy() *TrafficMirrorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficMirrorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficMirrorTarget) DeepCopyInto(out *TrafficMirrorTarget) {
+	*out = *in
+	if in.Device != nil {
+		in, out := &in.Device, &out.Device
+		*out = new(NetworkDevice)
+		**out = **in
+	}
+	if in.ERSPAN != nil {
+		in, out := &in.ERSPAN, &out.ERSPAN
+		*out = new(ERSPANTunnel)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficMirrorTarget.
+func (in *TrafficMirrorTarget) DeepCopy() *TrafficMirrorTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficMirrorTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UDPTunnel) DeepCopyInto(out *UDPTunnel) {
 	*out = *in
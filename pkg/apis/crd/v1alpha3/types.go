@@ -51,11 +51,13 @@ type GroupSpec struct {
 	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 	// IPBlocks describe the IPAddresses/IPBlocks that are matched in to/from.
 	// IPBlocks cannot be set as part of the AppliedTo field.
-	// Cannot be set with any other selector or ServiceReference.
+	// Cannot be set with any other selector. Can be set with ServiceReference,
+	// in which case the Group matches both the IPBlocks and the backend Pods of
+	// the referred Service.
 	// +optional
 	IPBlocks []v1alpha1.IPBlock `json:"ipBlocks,omitempty"`
 	// Select backend Pods of the referred Service.
-	// Cannot be set with any other selector or ipBlock.
+	// Cannot be set with any other selector. Can be set with ipBlocks.
 	// +optional
 	ServiceReference *v1alpha1.NamespacedName `json:"serviceReference,omitempty"`
 	// Select ExternalEntities from all Namespaces as workloads
@@ -49,6 +49,8 @@ type AntreaAgentInfo struct {
 	APICABundle []byte `json:"apiCABundle,omitempty"`
 	// The port range used by NodePortLocal
 	NodePortLocalPortRange string `json:"nodePortLocalPortRange,omitempty"`
+	// The latency statistics of the other Nodes, reported when the NodeLatencyMonitor feature is enabled
+	NodeLatencyStats []PeerNodeLatencyStats `json:"nodeLatencyStats,omitempty"`
 }
 
 type OVSInfo struct {
@@ -58,6 +60,38 @@ type OVSInfo struct {
 	FlowTable map[string]int32 `json:"flowTable,omitempty"`
 }
 
+// NodeLatencyNetwork is the network over which the IP of a peer Node is probed.
+type NodeLatencyNetwork string
+
+const (
+	// NodeLatencyNetworkTunnel means that the gateway IP of the peer Node is probed through the tunnel.
+	NodeLatencyNetworkTunnel NodeLatencyNetwork = "Tunnel"
+	// NodeLatencyNetworkUnderlay means that the transport IP of the peer Node is probed through the underlay network.
+	NodeLatencyNetworkUnderlay NodeLatencyNetwork = "Underlay"
+)
+
+type PeerNodeLatencyStats struct {
+	// The name of the peer Node
+	NodeName string `json:"nodeName,omitempty"`
+	// The latency statistics of each probed IP of the peer Node
+	TargetIPLatencyStats []TargetIPLatencyStats `json:"targetIPLatencyStats,omitempty"`
+}
+
+type TargetIPLatencyStats struct {
+	// The probed IP of the peer Node
+	TargetIP string `json:"targetIP,omitempty"`
+	// The network over which the IP is probed, one of Tunnel and Underlay
+	Network NodeLatencyNetwork `json:"network,omitempty"`
+	// The time when the last probe was sent
+	LastSendTime metav1.Time `json:"lastSendTime,omitempty"`
+	// The time when the last reply was received
+	LastRecvTime metav1.Time `json:"lastRecvTime,omitempty"`
+	// The round-trip time of the last answered probe, in nanoseconds
+	LastMeasuredRTTNanoseconds int64 `json:"lastMeasuredRTTNanoseconds,omitempty"`
+	// The percentage of the recent probes which were not answered
+	PacketLossPercent int32 `json:"packetLossPercent,omitempty"`
+}
+
 type AgentConditionType string
 
 const (
@@ -69,6 +103,9 @@ const (
 	OVSDBConnectionUp AgentConditionType = "OVSDBConnectionUp"
 	// OpenflowConnectionUp is used to mark Openflow connection status.
 	OpenflowConnectionUp AgentConditionType = "OpenflowConnectionUp"
+	// KubeProxyCompatible is used to mark whether kube-proxy is running on the Node with a configuration conflicting
+	// with AntreaProxy. It is only reported when proxyAll is enabled.
+	KubeProxyCompatible AgentConditionType = "KubeProxyCompatible"
 )
 
 type AgentCondition struct {
@@ -123,6 +160,13 @@ type NetworkPolicyControllerInfo struct {
 	NetworkPolicyNum  int32 `json:"networkPolicyNum,omitempty"`
 	AddressGroupNum   int32 `json:"addressGroupNum,omitempty"`
 	AppliedToGroupNum int32 `json:"appliedToGroupNum,omitempty"`
+	// SupportedFeatures is the list of NetworkPolicy features which can be required
+	// by NetworkPolicy rules and are supported by the Antrea Agent.
+	SupportedFeatures []string `json:"supportedFeatures,omitempty"`
+	// UnsupportedFeatures is the list of NetworkPolicy features required by some
+	// NetworkPolicy rules received by the Antrea Agent but not supported by it. These
+	// rules are not enforced.
+	UnsupportedFeatures []string `json:"unsupportedFeatures,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
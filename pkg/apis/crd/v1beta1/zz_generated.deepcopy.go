@@ -53,7 +53,7 @@ func (in *AntreaAgentInfo) DeepCopyInto(out *AntreaAgentInfo) {
 		copy(*out, *in)
 	}
 	in.OVSInfo.DeepCopyInto(&out.OVSInfo)
-	out.NetworkPolicyControllerInfo = in.NetworkPolicyControllerInfo
+	in.NetworkPolicyControllerInfo.DeepCopyInto(&out.NetworkPolicyControllerInfo)
 	if in.AgentConditions != nil {
 		in, out := &in.AgentConditions, &out.AgentConditions
 		*out = make([]AgentCondition, len(*in))
@@ -66,6 +66,13 @@ func (in *AntreaAgentInfo) DeepCopyInto(out *AntreaAgentInfo) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.NodeLatencyStats != nil {
+		in, out := &in.NodeLatencyStats, &out.NodeLatencyStats
+		*out = make([]PeerNodeLatencyStats, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -128,7 +135,7 @@ func (in *AntreaControllerInfo) DeepCopyInto(out *AntreaControllerInfo) {
 	out.PodRef = in.PodRef
 	out.NodeRef = in.NodeRef
 	out.ServiceRef = in.ServiceRef
-	out.NetworkPolicyControllerInfo = in.NetworkPolicyControllerInfo
+	in.NetworkPolicyControllerInfo.DeepCopyInto(&out.NetworkPolicyControllerInfo)
 	if in.ControllerConditions != nil {
 		in, out := &in.ControllerConditions, &out.ControllerConditions
 		*out = make([]ControllerCondition, len(*in))
@@ -210,6 +217,16 @@ func (in *ControllerCondition) DeepCopy() *ControllerCondition {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkPolicyControllerInfo) DeepCopyInto(out *NetworkPolicyControllerInfo) {
 	*out = *in
+	if in.SupportedFeatures != nil {
+		in, out := &in.SupportedFeatures, &out.SupportedFeatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnsupportedFeatures != nil {
+		in, out := &in.UnsupportedFeatures, &out.UnsupportedFeatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -245,3 +262,44 @@ func (in *OVSInfo) DeepCopy() *OVSInfo {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeerNodeLatencyStats) DeepCopyInto(out *PeerNodeLatencyStats) {
+	*out = *in
+	if in.TargetIPLatencyStats != nil {
+		in, out := &in.TargetIPLatencyStats, &out.TargetIPLatencyStats
+		*out = make([]TargetIPLatencyStats, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeerNodeLatencyStats.
+func (in *PeerNodeLatencyStats) DeepCopy() *PeerNodeLatencyStats {
+	if in == nil {
+		return nil
+	}
+	out := new(PeerNodeLatencyStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetIPLatencyStats) DeepCopyInto(out *TargetIPLatencyStats) {
+	*out = *in
+	in.LastSendTime.DeepCopyInto(&out.LastSendTime)
+	in.LastRecvTime.DeepCopyInto(&out.LastRecvTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetIPLatencyStats.
+func (in *TargetIPLatencyStats) DeepCopy() *TargetIPLatencyStats {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetIPLatencyStats)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -127,10 +127,22 @@ type TrafficStats struct {
 	Bytes int64
 	// Sessions is the sessions count hit by the NetworkPolicy.
 	Sessions int64
+	// DroppedPackets is the packets count dropped by the rate limit of the NetworkPolicy.
+	DroppedPackets int64
 }
 
 // RuleTrafficStats contains TrafficStats of single rule inside a NetworkPolicy.
 type RuleTrafficStats struct {
 	Name         string
 	TrafficStats TrafficStats
+	// FQDNStats contains the match counts of the FQDNs of the rule. It is only set for rules with FQDN peers.
+	FQDNStats []FQDNStats
+}
+
+// FQDNStats contains the number of DNS responses matched by an FQDN of a rule.
+type FQDNStats struct {
+	// FQDN is the FQDN as specified in the rule, which can include a wildcard, e.g. "*.example.com".
+	FQDN string
+	// Matches is the number of DNS responses whose queried name matched the FQDN.
+	Matches int64
 }
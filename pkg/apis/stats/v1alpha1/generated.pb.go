@@ -152,10 +152,38 @@ func (m *AntreaNetworkPolicyStatsList) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_AntreaNetworkPolicyStatsList proto.InternalMessageInfo
 
+func (m *FQDNStats) Reset()      { *m = FQDNStats{} }
+func (*FQDNStats) ProtoMessage() {}
+func (*FQDNStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_91b517c6fa558473, []int{4}
+}
+func (m *FQDNStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FQDNStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	b = b[:cap(b)]
+	n, err := m.MarshalToSizedBuffer(b)
+	if err != nil {
+		return nil, err
+	}
+	return b[:n], nil
+}
+func (m *FQDNStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FQDNStats.Merge(m, src)
+}
+func (m *FQDNStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *FQDNStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_FQDNStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FQDNStats proto.InternalMessageInfo
+
 func (m *MulticastGroup) Reset()      { *m = MulticastGroup{} }
 func (*MulticastGroup) ProtoMessage() {}
 func (*MulticastGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_91b517c6fa558473, []int{4}
+	return fileDescriptor_91b517c6fa558473, []int{5}
 }
 func (m *MulticastGroup) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -183,7 +211,7 @@ var xxx_messageInfo_MulticastGroup proto.InternalMessageInfo
 func (m *MulticastGroupList) Reset()      { *m = MulticastGroupList{} }
 func (*MulticastGroupList) ProtoMessage() {}
 func (*MulticastGroupList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_91b517c6fa558473, []int{5}
+	return fileDescriptor_91b517c6fa558473, []int{6}
 }
 func (m *MulticastGroupList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -211,7 +239,7 @@ var xxx_messageInfo_MulticastGroupList proto.InternalMessageInfo
 func (m *NetworkPolicyStats) Reset()      { *m = NetworkPolicyStats{} }
 func (*NetworkPolicyStats) ProtoMessage() {}
 func (*NetworkPolicyStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_91b517c6fa558473, []int{6}
+	return fileDescriptor_91b517c6fa558473, []int{7}
 }
 func (m *NetworkPolicyStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -239,7 +267,7 @@ var xxx_messageInfo_NetworkPolicyStats proto.InternalMessageInfo
 func (m *NetworkPolicyStatsList) Reset()      { *m = NetworkPolicyStatsList{} }
 func (*NetworkPolicyStatsList) ProtoMessage() {}
 func (*NetworkPolicyStatsList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_91b517c6fa558473, []int{7}
+	return fileDescriptor_91b517c6fa558473, []int{8}
 }
 func (m *NetworkPolicyStatsList) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -267,7 +295,7 @@ var xxx_messageInfo_NetworkPolicyStatsList proto.InternalMessageInfo
 func (m *PodReference) Reset()      { *m = PodReference{} }
 func (*PodReference) ProtoMessage() {}
 func (*PodReference) Descriptor() ([]byte, []int) {
-	return fileDescriptor_91b517c6fa558473, []int{8}
+	return fileDescriptor_91b517c6fa558473, []int{9}
 }
 func (m *PodReference) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -295,7 +323,7 @@ var xxx_messageInfo_PodReference proto.InternalMessageInfo
 func (m *RuleTrafficStats) Reset()      { *m = RuleTrafficStats{} }
 func (*RuleTrafficStats) ProtoMessage() {}
 func (*RuleTrafficStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_91b517c6fa558473, []int{9}
+	return fileDescriptor_91b517c6fa558473, []int{10}
 }
 func (m *RuleTrafficStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -323,7 +351,7 @@ var xxx_messageInfo_RuleTrafficStats proto.InternalMessageInfo
 func (m *TrafficStats) Reset()      { *m = TrafficStats{} }
 func (*TrafficStats) ProtoMessage() {}
 func (*TrafficStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_91b517c6fa558473, []int{10}
+	return fileDescriptor_91b517c6fa558473, []int{11}
 }
 func (m *TrafficStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -353,6 +381,7 @@ func init() {
 	proto.RegisterType((*AntreaClusterNetworkPolicyStatsList)(nil), "antrea_io.antrea.pkg.apis.stats.v1alpha1.AntreaClusterNetworkPolicyStatsList")
 	proto.RegisterType((*AntreaNetworkPolicyStats)(nil), "antrea_io.antrea.pkg.apis.stats.v1alpha1.AntreaNetworkPolicyStats")
 	proto.RegisterType((*AntreaNetworkPolicyStatsList)(nil), "antrea_io.antrea.pkg.apis.stats.v1alpha1.AntreaNetworkPolicyStatsList")
+	proto.RegisterType((*FQDNStats)(nil), "antrea_io.antrea.pkg.apis.stats.v1alpha1.FQDNStats")
 	proto.RegisterType((*MulticastGroup)(nil), "antrea_io.antrea.pkg.apis.stats.v1alpha1.MulticastGroup")
 	proto.RegisterType((*MulticastGroupList)(nil), "antrea_io.antrea.pkg.apis.stats.v1alpha1.MulticastGroupList")
 	proto.RegisterType((*NetworkPolicyStats)(nil), "antrea_io.antrea.pkg.apis.stats.v1alpha1.NetworkPolicyStats")
@@ -367,51 +396,57 @@ func init() {
 }
 
 var fileDescriptor_91b517c6fa558473 = []byte{
-	// 704 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x56, 0x4f, 0x6b, 0x13, 0x4d,
-	0x18, 0xcf, 0x34, 0x2d, 0x6d, 0xa6, 0x79, 0xdf, 0xb7, 0xef, 0x20, 0x12, 0x8a, 0x6c, 0x4b, 0x7a,
-	0x89, 0xa0, 0xb3, 0xb6, 0x48, 0x29, 0xe2, 0xc5, 0xf5, 0x20, 0x05, 0x1b, 0xc3, 0xd4, 0x83, 0x88,
-	0xa2, 0x93, 0xcd, 0x64, 0x33, 0x26, 0xbb, 0xb3, 0xec, 0xcc, 0x56, 0x7a, 0xeb, 0x07, 0xf0, 0xe0,
-	0xa7, 0xf0, 0xb3, 0xf4, 0x58, 0x6f, 0xf5, 0x52, 0x6c, 0x44, 0xf0, 0x2a, 0x5e, 0x3c, 0xca, 0xcc,
-	0x6e, 0xba, 0xd9, 0x86, 0xd2, 0xed, 0x25, 0x1e, 0xf4, 0x94, 0x9d, 0xe7, 0xdf, 0xef, 0xf7, 0x3c,
-	0xcf, 0x6f, 0x86, 0xc0, 0x2d, 0x1a, 0xa8, 0x88, 0x51, 0xcc, 0x85, 0x9d, 0x7c, 0xd9, 0x61, 0xdf,
-	0xb3, 0x69, 0xc8, 0xa5, 0x2d, 0x15, 0x55, 0xd2, 0xde, 0x5b, 0xa7, 0x83, 0xb0, 0x47, 0xd7, 0x6d,
-	0x8f, 0x05, 0x2c, 0xa2, 0x8a, 0x75, 0x70, 0x18, 0x09, 0x25, 0x50, 0x23, 0x89, 0x7f, 0xc5, 0x05,
-	0x4e, 0x6b, 0x84, 0x7d, 0x0f, 0xeb, 0x4c, 0x6c, 0x32, 0xf1, 0x28, 0x73, 0xf9, 0xb6, 0xc7, 0x55,
-	0x2f, 0x6e, 0x63, 0x57, 0xf8, 0xb6, 0x27, 0x3c, 0x61, 0x9b, 0x02, 0xed, 0xb8, 0x6b, 0x4e, 0xe6,
-	0x60, 0xbe, 0x92, 0xc2, 0xcb, 0x77, 0xfb, 0x5b, 0xd2, 0xf0, 0x09, 0xb9, 0x4f, 0xdd, 0x1e, 0x0f,
-	0x58, 0xb4, 0x9f, 0xb1, 0xf2, 0x99, 0xa2, 0xf6, 0xde, 0x04, 0x9d, 0x65, 0xfb, 0xa2, 0xac, 0x28,
-	0x0e, 0x14, 0xf7, 0xd9, 0x44, 0xc2, 0xe6, 0x65, 0x09, 0xd2, 0xed, 0x31, 0x9f, 0x9e, 0xcf, 0xab,
-	0xff, 0x9c, 0x81, 0x2b, 0x0f, 0x4c, 0xc3, 0x0f, 0x07, 0xb1, 0x54, 0x2c, 0x6a, 0x32, 0xf5, 0x56,
-	0x44, 0xfd, 0x96, 0x18, 0x70, 0x77, 0x7f, 0x57, 0xb7, 0x8e, 0x5e, 0xc3, 0x05, 0xcd, 0xb3, 0x43,
-	0x15, 0xad, 0x81, 0x55, 0xd0, 0x58, 0xdc, 0xb8, 0x83, 0x13, 0x38, 0x3c, 0x0e, 0x97, 0x4d, 0x4c,
-	0x47, 0xe3, 0xbd, 0x75, 0xfc, 0xa4, 0xfd, 0x86, 0xb9, 0x6a, 0x87, 0x29, 0xea, 0xa0, 0xc3, 0x93,
-	0x95, 0xd2, 0xf0, 0x64, 0x05, 0x66, 0x36, 0x72, 0x56, 0x15, 0x85, 0xb0, 0xaa, 0x22, 0xda, 0xed,
-	0x72, 0xd7, 0x20, 0xd6, 0x66, 0x0c, 0xca, 0x26, 0x2e, 0xba, 0x14, 0xfc, 0x74, 0x2c, 0xdb, 0xb9,
-	0x96, 0x62, 0x55, 0xc7, 0xad, 0x24, 0x87, 0x80, 0x0e, 0x00, 0x5c, 0x8a, 0xe2, 0x01, 0x1b, 0x0f,
-	0xa9, 0x95, 0x57, 0xcb, 0x8d, 0xc5, 0x8d, 0x7b, 0xc5, 0x61, 0xc9, 0xb9, 0x0a, 0x4e, 0x2d, 0x85,
-	0x5e, 0x3a, 0xef, 0x21, 0x13, 0x68, 0xf5, 0x1f, 0x00, 0xae, 0x5d, 0x32, 0xfa, 0xc7, 0x5c, 0x2a,
-	0xf4, 0x62, 0x62, 0xfc, 0xb8, 0xd8, 0xf8, 0x75, 0xb6, 0x19, 0xfe, 0x52, 0xca, 0x6a, 0x61, 0x64,
-	0x19, 0x1b, 0x7d, 0x00, 0xe7, 0xb8, 0x62, 0xbe, 0x9e, 0xb9, 0x6e, 0x7e, 0xbb, 0x78, 0xf3, 0x97,
-	0x70, 0x77, 0xfe, 0x49, 0x51, 0xe7, 0xb6, 0x75, 0x7d, 0x92, 0xc0, 0xd4, 0xbf, 0xcf, 0xc0, 0x5a,
-	0x92, 0xf9, 0x57, 0x69, 0xd3, 0x52, 0xda, 0x57, 0x00, 0x6f, 0x5c, 0x34, 0xf3, 0x29, 0x48, 0xcc,
-	0xcb, 0x4b, 0xcc, 0xb9, 0xaa, 0xc4, 0x8a, 0x6b, 0x0b, 0xc0, 0x7f, 0x77, 0xe2, 0x81, 0xe2, 0x2e,
-	0x95, 0xea, 0x51, 0x24, 0xe2, 0x70, 0x0a, 0x8a, 0x5a, 0x83, 0x73, 0x9e, 0x86, 0x32, 0x52, 0xaa,
-	0x64, 0xcc, 0x0c, 0x3e, 0x49, 0x7c, 0xe8, 0x19, 0x9c, 0x0d, 0x45, 0x67, 0xb4, 0xf7, 0x2b, 0xc8,
-	0xad, 0x25, 0x3a, 0x84, 0x75, 0x59, 0xc4, 0x02, 0x97, 0x39, 0xd5, 0xb4, 0xf6, 0x6c, 0x4b, 0x74,
-	0x24, 0x31, 0x15, 0xeb, 0x1f, 0x01, 0x44, 0xf9, 0x9e, 0xa7, 0xb0, 0xd1, 0x97, 0xf9, 0x8d, 0x6e,
-	0x15, 0xef, 0x27, 0x4f, 0xf5, 0x82, 0x3d, 0x7e, 0x03, 0x10, 0xfd, 0x19, 0xaf, 0x43, 0xfd, 0x13,
-	0x80, 0xd7, 0x7f, 0xcb, 0xa5, 0xa4, 0xf9, 0x15, 0xde, 0x2f, 0xde, 0x63, 0xe1, 0xeb, 0x48, 0x61,
-	0x75, 0x5c, 0xbe, 0x68, 0x15, 0xce, 0x06, 0xd4, 0x67, 0xa6, 0x99, 0x4a, 0x26, 0xe6, 0x26, 0xf5,
-	0x19, 0x31, 0x1e, 0x64, 0xc3, 0x8a, 0xfe, 0x95, 0x21, 0x75, 0x59, 0x7a, 0x9f, 0xfe, 0x4f, 0xc3,
-	0x2a, 0xcd, 0x91, 0x83, 0x64, 0x31, 0xf5, 0x0f, 0x00, 0x4e, 0x3c, 0x80, 0x05, 0x70, 0xa6, 0xbf,
-	0xe7, 0x77, 0x00, 0xe6, 0xdc, 0xe8, 0x26, 0x9c, 0x0f, 0xa9, 0xdb, 0x67, 0x4a, 0x1a, 0x9e, 0x65,
-	0xe7, 0xbf, 0xb4, 0xca, 0x7c, 0x2b, 0x31, 0x93, 0x91, 0x5f, 0xbf, 0x30, 0xed, 0x7d, 0xc5, 0x12,
-	0x9a, 0xe5, 0x6c, 0xd8, 0x8e, 0x36, 0x92, 0xc4, 0x87, 0x6e, 0xc1, 0x05, 0xc9, 0xa4, 0xe4, 0x22,
-	0xd0, 0xaf, 0x8c, 0x8e, 0x3b, 0xdb, 0xfe, 0x6e, 0x6a, 0x27, 0x67, 0x11, 0x4e, 0xf3, 0xf0, 0xd4,
-	0x2a, 0x1d, 0x9d, 0x5a, 0xa5, 0xe3, 0x53, 0xab, 0x74, 0x30, 0xb4, 0xc0, 0xe1, 0xd0, 0x02, 0x47,
-	0x43, 0x0b, 0x1c, 0x0f, 0x2d, 0xf0, 0x79, 0x68, 0x81, 0xf7, 0x5f, 0xac, 0xd2, 0xf3, 0x46, 0xd1,
-	0xbf, 0xd3, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0x73, 0x1f, 0x1a, 0xcb, 0x79, 0x0b, 0x00, 0x00,
+	// 785 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x57, 0x41, 0x6f, 0xd3, 0x4a,
+	0x10, 0xce, 0xa6, 0xa9, 0xda, 0x6c, 0xf3, 0xfa, 0xfa, 0xac, 0xa7, 0x2a, 0xaa, 0x9e, 0xdc, 0xca,
+	0xbd, 0xe4, 0x49, 0x60, 0xd3, 0x82, 0xaa, 0x0a, 0x21, 0x24, 0x4c, 0x05, 0xaa, 0x44, 0x43, 0xd8,
+	0x72, 0xa8, 0x10, 0x08, 0x36, 0xf6, 0xc6, 0x31, 0x89, 0xbd, 0xc6, 0xbb, 0x29, 0xea, 0xad, 0x3f,
+	0x80, 0x03, 0x3f, 0xab, 0x07, 0x0e, 0xe5, 0x56, 0x2e, 0x15, 0x0d, 0x42, 0xe2, 0x5a, 0x71, 0xe1,
+	0x88, 0x76, 0xed, 0xc4, 0x76, 0xa2, 0xaa, 0xee, 0x25, 0x1c, 0xe0, 0x14, 0x7b, 0x66, 0xbe, 0xf9,
+	0x66, 0x66, 0xbf, 0x1d, 0x2b, 0x70, 0x13, 0xfb, 0x3c, 0x24, 0x58, 0x77, 0xa9, 0x11, 0x3d, 0x19,
+	0x41, 0xc7, 0x31, 0x70, 0xe0, 0x32, 0x83, 0x71, 0xcc, 0x99, 0xb1, 0xbf, 0x86, 0xbb, 0x41, 0x1b,
+	0xaf, 0x19, 0x0e, 0xf1, 0x49, 0x88, 0x39, 0xb1, 0xf5, 0x20, 0xa4, 0x9c, 0x2a, 0xb5, 0x28, 0xfe,
+	0xa5, 0x4b, 0xf5, 0x38, 0x47, 0xd0, 0x71, 0x74, 0x81, 0xd4, 0x25, 0x52, 0x1f, 0x20, 0x97, 0xae,
+	0x3b, 0x2e, 0x6f, 0xf7, 0x9a, 0xba, 0x45, 0x3d, 0xc3, 0xa1, 0x0e, 0x35, 0x64, 0x82, 0x66, 0xaf,
+	0x25, 0xdf, 0xe4, 0x8b, 0x7c, 0x8a, 0x12, 0x2f, 0xdd, 0xea, 0x6c, 0x32, 0x59, 0x4f, 0xe0, 0x7a,
+	0xd8, 0x6a, 0xbb, 0x3e, 0x09, 0x0f, 0x92, 0xaa, 0x3c, 0xc2, 0xb1, 0xb1, 0x3f, 0x56, 0xce, 0x92,
+	0x71, 0x11, 0x2a, 0xec, 0xf9, 0xdc, 0xf5, 0xc8, 0x18, 0x60, 0xe3, 0x32, 0x00, 0xb3, 0xda, 0xc4,
+	0xc3, 0xa3, 0x38, 0xed, 0x47, 0x11, 0x2e, 0xdf, 0x93, 0x0d, 0xdf, 0xef, 0xf6, 0x18, 0x27, 0x61,
+	0x9d, 0xf0, 0xb7, 0x34, 0xec, 0x34, 0x68, 0xd7, 0xb5, 0x0e, 0x76, 0x45, 0xeb, 0xca, 0x2b, 0x38,
+	0x2b, 0xea, 0xb4, 0x31, 0xc7, 0x55, 0xb0, 0x02, 0x6a, 0x73, 0xeb, 0x37, 0xf4, 0x88, 0x4e, 0x4f,
+	0xd3, 0x25, 0x13, 0x13, 0xd1, 0xfa, 0xfe, 0x9a, 0xfe, 0xb8, 0xf9, 0x9a, 0x58, 0x7c, 0x87, 0x70,
+	0x6c, 0x2a, 0x47, 0xa7, 0xcb, 0x85, 0xfe, 0xe9, 0x32, 0x4c, 0x6c, 0x68, 0x98, 0x55, 0x09, 0x60,
+	0x85, 0x87, 0xb8, 0xd5, 0x72, 0x2d, 0xc9, 0x58, 0x2d, 0x4a, 0x96, 0x0d, 0x3d, 0xef, 0xa1, 0xe8,
+	0x4f, 0x53, 0x68, 0xf3, 0xdf, 0x98, 0xab, 0x92, 0xb6, 0xa2, 0x0c, 0x83, 0x72, 0x08, 0xe0, 0x42,
+	0xd8, 0xeb, 0x92, 0x74, 0x48, 0x75, 0x6a, 0x65, 0xaa, 0x36, 0xb7, 0x7e, 0x3b, 0x3f, 0x2d, 0x1a,
+	0xc9, 0x60, 0x56, 0x63, 0xea, 0x85, 0x51, 0x0f, 0x1a, 0x63, 0xd3, 0xbe, 0x03, 0xb8, 0x7a, 0xc9,
+	0xe8, 0x1f, 0xb9, 0x8c, 0x2b, 0xcf, 0xc7, 0xc6, 0xaf, 0xe7, 0x1b, 0xbf, 0x40, 0xcb, 0xe1, 0x2f,
+	0xc4, 0x55, 0xcd, 0x0e, 0x2c, 0xa9, 0xd1, 0xfb, 0x70, 0xda, 0xe5, 0xc4, 0x13, 0x33, 0x17, 0xcd,
+	0x6f, 0xe7, 0x6f, 0xfe, 0x92, 0xda, 0xcd, 0xbf, 0x62, 0xd6, 0xe9, 0x6d, 0x91, 0x1f, 0x45, 0x34,
+	0xda, 0x79, 0x11, 0x56, 0x23, 0xe4, 0x1f, 0xa5, 0x4d, 0x4a, 0x69, 0x5f, 0x01, 0xfc, 0xef, 0xa2,
+	0x99, 0x4f, 0x40, 0x62, 0x4e, 0x56, 0x62, 0xe6, 0x55, 0x25, 0x96, 0x5b, 0x5b, 0x7b, 0xb0, 0xfc,
+	0xe0, 0xc9, 0x56, 0x3d, 0x9a, 0xfb, 0x0a, 0x2c, 0xb5, 0xde, 0xd8, 0xbe, 0xec, 0xa7, 0x6c, 0x56,
+	0x62, 0x40, 0x49, 0x04, 0x20, 0xe9, 0x51, 0xfe, 0x87, 0x33, 0x1e, 0xe6, 0x56, 0x9b, 0x44, 0x32,
+	0x98, 0x32, 0xff, 0x8e, 0x83, 0x66, 0x76, 0x22, 0x33, 0x1a, 0xf8, 0xb5, 0x73, 0x00, 0xe7, 0x77,
+	0x7a, 0x5d, 0xee, 0x5a, 0x98, 0xf1, 0x87, 0x21, 0xed, 0x05, 0x13, 0xd0, 0xea, 0x2a, 0x9c, 0x76,
+	0x04, 0x95, 0xac, 0xae, 0x9c, 0xf4, 0x2c, 0xf9, 0x51, 0xe4, 0x53, 0xf6, 0x60, 0x29, 0xa0, 0xf6,
+	0x40, 0x51, 0x57, 0x10, 0x72, 0x83, 0xda, 0x88, 0xb4, 0x48, 0x48, 0x7c, 0x8b, 0x24, 0xe3, 0x69,
+	0x50, 0x9b, 0x21, 0x99, 0x51, 0xfb, 0x08, 0xa0, 0x92, 0xed, 0x79, 0x02, 0x5a, 0x79, 0x91, 0xd5,
+	0xca, 0x66, 0xfe, 0x7e, 0xb2, 0xa5, 0x5e, 0xa0, 0x90, 0x6f, 0x00, 0x2a, 0xbf, 0xc7, 0xde, 0xd1,
+	0x3e, 0x01, 0xb8, 0xf8, 0x4b, 0xae, 0x3b, 0xce, 0x1e, 0xe1, 0x9d, 0xfc, 0x3d, 0xe6, 0xbe, 0xe8,
+	0x18, 0x56, 0xd2, 0xf2, 0x15, 0x77, 0xdd, 0xc7, 0x1e, 0x19, 0xbd, 0xeb, 0x75, 0xec, 0x11, 0x24,
+	0x3d, 0x8a, 0x01, 0xcb, 0xe2, 0x97, 0x05, 0xd8, 0x22, 0xf1, 0x7d, 0xfa, 0x27, 0x0e, 0x2b, 0xd7,
+	0x07, 0x0e, 0x94, 0xc4, 0x68, 0xef, 0x8a, 0x70, 0x6c, 0xb5, 0xe6, 0xe0, 0x99, 0xfc, 0xf7, 0xc5,
+	0x86, 0x65, 0xb1, 0xcd, 0xd2, 0xdf, 0x95, 0x9b, 0xf9, 0xe9, 0x86, 0xfb, 0x32, 0x19, 0xc7, 0xd0,
+	0x84, 0x92, 0xc4, 0xda, 0x07, 0x00, 0x33, 0x45, 0x88, 0xe5, 0x19, 0x60, 0xab, 0x43, 0x38, 0xab,
+	0x82, 0xec, 0xf2, 0x6c, 0x44, 0x66, 0x34, 0xf0, 0x8b, 0x3d, 0xd6, 0x3c, 0xe0, 0xc3, 0x2d, 0x3b,
+	0x3c, 0x52, 0x53, 0x18, 0x51, 0xe4, 0x53, 0xae, 0xc1, 0x59, 0x46, 0x18, 0x73, 0xa9, 0x2f, 0xba,
+	0x10, 0x71, 0x43, 0x8d, 0xed, 0xc6, 0x76, 0x34, 0x8c, 0x50, 0xee, 0xc2, 0x79, 0x3b, 0xa4, 0x41,
+	0x40, 0xec, 0x98, 0xad, 0x5a, 0x92, 0x98, 0xc5, 0x18, 0x33, 0xbf, 0x95, 0xf1, 0xa2, 0x91, 0x68,
+	0xb3, 0x7e, 0x74, 0xa6, 0x16, 0x8e, 0xcf, 0xd4, 0xc2, 0xc9, 0x99, 0x5a, 0x38, 0xec, 0xab, 0xe0,
+	0xa8, 0xaf, 0x82, 0xe3, 0xbe, 0x0a, 0x4e, 0xfa, 0x2a, 0xf8, 0xdc, 0x57, 0xc1, 0xfb, 0x2f, 0x6a,
+	0xe1, 0x59, 0x2d, 0xef, 0xdf, 0x89, 0x9f, 0x03, 0x00, 0xe1, 0x41, 0x30, 0x9e, 0x79, 0x0c, 0x00,
+	0x00,
 }
 
 func (m *AntreaClusterNetworkPolicyStats) Marshal() (dAtA []byte, err error) {
@@ -622,6 +657,37 @@ func (m *AntreaNetworkPolicyStatsList) MarshalToSizedBuffer(dAtA []byte) (int, e
 	return len(dAtA) - i, nil
 }
 
+func (m *FQDNStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FQDNStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FQDNStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i = encodeVarintGenerated(dAtA, i, uint64(m.Matches))
+	i--
+	dAtA[i] = 0x10
+	i -= len(m.FQDN)
+	copy(dAtA[i:], m.FQDN)
+	i = encodeVarintGenerated(dAtA, i, uint64(len(m.FQDN)))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func (m *MulticastGroup) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -864,6 +930,20 @@ func (m *RuleTrafficStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.FQDNStats) > 0 {
+		for iNdEx := len(m.FQDNStats) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.FQDNStats[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenerated(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	{
 		size, err := m.TrafficStats.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -902,6 +982,9 @@ func (m *TrafficStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	i = encodeVarintGenerated(dAtA, i, uint64(m.DroppedPackets))
+	i--
+	dAtA[i] = 0x20
 	i = encodeVarintGenerated(dAtA, i, uint64(m.Sessions))
 	i--
 	dAtA[i] = 0x18
@@ -997,6 +1080,18 @@ func (m *AntreaNetworkPolicyStatsList) Size() (n int) {
 	return n
 }
 
+func (m *FQDNStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.FQDN)
+	n += 1 + l + sovGenerated(uint64(l))
+	n += 1 + sovGenerated(uint64(m.Matches))
+	return n
+}
+
 func (m *MulticastGroup) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1086,6 +1181,12 @@ func (m *RuleTrafficStats) Size() (n int) {
 	n += 1 + l + sovGenerated(uint64(l))
 	l = m.TrafficStats.Size()
 	n += 1 + l + sovGenerated(uint64(l))
+	if len(m.FQDNStats) > 0 {
+		for _, e := range m.FQDNStats {
+			l = e.Size()
+			n += 1 + l + sovGenerated(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1098,6 +1199,7 @@ func (m *TrafficStats) Size() (n int) {
 	n += 1 + sovGenerated(uint64(m.Packets))
 	n += 1 + sovGenerated(uint64(m.Bytes))
 	n += 1 + sovGenerated(uint64(m.Sessions))
+	n += 1 + sovGenerated(uint64(m.DroppedPackets))
 	return n
 }
 
@@ -1173,6 +1275,17 @@ func (this *AntreaNetworkPolicyStatsList) String() string {
 	}, "")
 	return s
 }
+func (this *FQDNStats) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&FQDNStats{`,
+		`FQDN:` + fmt.Sprintf("%v", this.FQDN) + `,`,
+		`Matches:` + fmt.Sprintf("%v", this.Matches) + `,`,
+		`}`,
+	}, "")
+	return s
+}
 func (this *MulticastGroup) String() string {
 	if this == nil {
 		return "nil"
@@ -1248,9 +1361,15 @@ func (this *RuleTrafficStats) String() string {
 	if this == nil {
 		return "nil"
 	}
+	repeatedStringForFQDNStats := "[]FQDNStats{"
+	for _, f := range this.FQDNStats {
+		repeatedStringForFQDNStats += strings.Replace(strings.Replace(f.String(), "FQDNStats", "FQDNStats", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForFQDNStats += "}"
 	s := strings.Join([]string{`&RuleTrafficStats{`,
 		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
 		`TrafficStats:` + strings.Replace(strings.Replace(this.TrafficStats.String(), "TrafficStats", "TrafficStats", 1), `&`, ``, 1) + `,`,
+		`FQDNStats:` + repeatedStringForFQDNStats + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1263,6 +1382,7 @@ func (this *TrafficStats) String() string {
 		`Packets:` + fmt.Sprintf("%v", this.Packets) + `,`,
 		`Bytes:` + fmt.Sprintf("%v", this.Bytes) + `,`,
 		`Sessions:` + fmt.Sprintf("%v", this.Sessions) + `,`,
+		`DroppedPackets:` + fmt.Sprintf("%v", this.DroppedPackets) + `,`,
 		`}`,
 	}, "")
 	return s
@@ -1809,6 +1929,107 @@ func (m *AntreaNetworkPolicyStatsList) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *FQDNStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenerated
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FQDNStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FQDNStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FQDN", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FQDN = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matches", wireType)
+			}
+			m.Matches = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Matches |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenerated(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *MulticastGroup) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2516,6 +2737,40 @@ func (m *RuleTrafficStats) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FQDNStats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenerated
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FQDNStats = append(m.FQDNStats, FQDNStats{})
+			if err := m.FQDNStats[len(m.FQDNStats)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -2623,6 +2878,25 @@ func (m *TrafficStats) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DroppedPackets", wireType)
+			}
+			m.DroppedPackets = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenerated
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DroppedPackets |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenerated(dAtA[iNdEx:])
@@ -139,10 +139,22 @@ type TrafficStats struct {
 	Bytes int64 `json:"bytes,omitempty" protobuf:"varint,2,opt,name=bytes"`
 	// Sessions is the sessions count hit by the NetworkPolicy.
 	Sessions int64 `json:"sessions,omitempty" protobuf:"varint,3,opt,name=sessions"`
+	// DroppedPackets is the packets count dropped by the rate limit of the NetworkPolicy.
+	DroppedPackets int64 `json:"droppedPackets,omitempty" protobuf:"varint,4,opt,name=droppedPackets"`
 }
 
 // RuleTrafficStats contains TrafficStats of single rule inside a NetworkPolicy.
 type RuleTrafficStats struct {
 	Name         string       `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
 	TrafficStats TrafficStats `json:"trafficStats,omitempty" protobuf:"bytes,2,opt,name=trafficStats"`
+	// FQDNStats contains the match counts of the FQDNs of the rule. It is only set for rules with FQDN peers.
+	FQDNStats []FQDNStats `json:"fqdnStats,omitempty" protobuf:"bytes,3,rep,name=fqdnStats"`
+}
+
+// FQDNStats contains the number of DNS responses matched by an FQDN of a rule.
+type FQDNStats struct {
+	// FQDN is the FQDN as specified in the rule, which can include a wildcard, e.g. "*.example.com".
+	FQDN string `json:"fqdn,omitempty" protobuf:"bytes,1,opt,name=fqdn"`
+	// Matches is the number of DNS responses whose queried name matched the FQDN.
+	Matches int64 `json:"matches,omitempty" protobuf:"varint,2,opt,name=matches"`
 }
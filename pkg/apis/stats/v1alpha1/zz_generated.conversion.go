@@ -74,6 +74,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*FQDNStats)(nil), (*stats.FQDNStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FQDNStats_To_stats_FQDNStats(a.(*FQDNStats), b.(*stats.FQDNStats), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*stats.FQDNStats)(nil), (*FQDNStats)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_stats_FQDNStats_To_v1alpha1_FQDNStats(a.(*stats.FQDNStats), b.(*FQDNStats), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MulticastGroup)(nil), (*stats.MulticastGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_MulticastGroup_To_stats_MulticastGroup(a.(*MulticastGroup), b.(*stats.MulticastGroup), scope)
 	}); err != nil {
@@ -247,6 +257,28 @@ func Convert_stats_AntreaNetworkPolicyStatsList_To_v1alpha1_AntreaNetworkPolicyS
 	return autoConvert_stats_AntreaNetworkPolicyStatsList_To_v1alpha1_AntreaNetworkPolicyStatsList(in, out, s)
 }
 
+func autoConvert_v1alpha1_FQDNStats_To_stats_FQDNStats(in *FQDNStats, out *stats.FQDNStats, s conversion.Scope) error {
+	out.FQDN = in.FQDN
+	out.Matches = in.Matches
+	return nil
+}
+
+// Convert_v1alpha1_FQDNStats_To_stats_FQDNStats is an autogenerated conversion function.
+func Convert_v1alpha1_FQDNStats_To_stats_FQDNStats(in *FQDNStats, out *stats.FQDNStats, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FQDNStats_To_stats_FQDNStats(in, out, s)
+}
+
+func autoConvert_stats_FQDNStats_To_v1alpha1_FQDNStats(in *stats.FQDNStats, out *FQDNStats, s conversion.Scope) error {
+	out.FQDN = in.FQDN
+	out.Matches = in.Matches
+	return nil
+}
+
+// Convert_stats_FQDNStats_To_v1alpha1_FQDNStats is an autogenerated conversion function.
+func Convert_stats_FQDNStats_To_v1alpha1_FQDNStats(in *stats.FQDNStats, out *FQDNStats, s conversion.Scope) error {
+	return autoConvert_stats_FQDNStats_To_v1alpha1_FQDNStats(in, out, s)
+}
+
 func autoConvert_v1alpha1_MulticastGroup_To_stats_MulticastGroup(in *MulticastGroup, out *stats.MulticastGroup, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	out.Group = in.Group
@@ -368,6 +400,7 @@ func autoConvert_v1alpha1_RuleTrafficStats_To_stats_RuleTrafficStats(in *RuleTra
 	if err := Convert_v1alpha1_TrafficStats_To_stats_TrafficStats(&in.TrafficStats, &out.TrafficStats, s); err != nil {
 		return err
 	}
+	out.FQDNStats = *(*[]stats.FQDNStats)(unsafe.Pointer(&in.FQDNStats))
 	return nil
 }
 
@@ -381,6 +414,7 @@ func autoConvert_stats_RuleTrafficStats_To_v1alpha1_RuleTrafficStats(in *stats.R
 	if err := Convert_stats_TrafficStats_To_v1alpha1_TrafficStats(&in.TrafficStats, &out.TrafficStats, s); err != nil {
 		return err
 	}
+	out.FQDNStats = *(*[]FQDNStats)(unsafe.Pointer(&in.FQDNStats))
 	return nil
 }
 
@@ -393,6 +427,7 @@ func autoConvert_v1alpha1_TrafficStats_To_stats_TrafficStats(in *TrafficStats, o
 	out.Packets = in.Packets
 	out.Bytes = in.Bytes
 	out.Sessions = in.Sessions
+	out.DroppedPackets = in.DroppedPackets
 	return nil
 }
 
@@ -405,6 +440,7 @@ func autoConvert_stats_TrafficStats_To_v1alpha1_TrafficStats(in *stats.TrafficSt
 	out.Packets = in.Packets
 	out.Bytes = in.Bytes
 	out.Sessions = in.Sessions
+	out.DroppedPackets = in.DroppedPackets
 	return nil
 }
 
@@ -32,7 +32,9 @@ func (in *AntreaClusterNetworkPolicyStats) DeepCopyInto(out *AntreaClusterNetwor
 	if in.RuleTrafficStats != nil {
 		in, out := &in.RuleTrafficStats, &out.RuleTrafficStats
 		*out = make([]RuleTrafficStats, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -97,7 +99,9 @@ func (in *AntreaNetworkPolicyStats) DeepCopyInto(out *AntreaNetworkPolicyStats)
 	if in.RuleTrafficStats != nil {
 		in, out := &in.RuleTrafficStats, &out.RuleTrafficStats
 		*out = make([]RuleTrafficStats, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -153,6 +157,22 @@ func (in *AntreaNetworkPolicyStatsList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FQDNStats) DeepCopyInto(out *FQDNStats) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FQDNStats.
+func (in *FQDNStats) DeepCopy() *FQDNStats {
+	if in == nil {
+		return nil
+	}
+	out := new(FQDNStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MulticastGroup) DeepCopyInto(out *MulticastGroup) {
 	*out = *in
@@ -297,6 +317,11 @@ func (in *PodReference) DeepCopy() *PodReference {
 func (in *RuleTrafficStats) DeepCopyInto(out *RuleTrafficStats) {
 	*out = *in
 	out.TrafficStats = in.TrafficStats
+	if in.FQDNStats != nil {
+		in, out := &in.FQDNStats, &out.FQDNStats
+		*out = make([]FQDNStats, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -18,6 +18,7 @@ import (
 	"context"
 	"time"
 
+	"google.golang.org/grpc"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -33,14 +34,20 @@ import (
 
 	"antrea.io/antrea/pkg/apis/controlplane"
 	cpinstall "antrea.io/antrea/pkg/apis/controlplane/install"
+	cpv1beta2 "antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	cpstreamv1alpha1 "antrea.io/antrea/pkg/apis/controlplanestream/v1alpha1"
 	apistats "antrea.io/antrea/pkg/apis/stats"
 	statsinstall "antrea.io/antrea/pkg/apis/stats/install"
 	systeminstall "antrea.io/antrea/pkg/apis/system/install"
 	system "antrea.io/antrea/pkg/apis/system/v1beta1"
 	"antrea.io/antrea/pkg/apiserver/certificate"
+	"antrea.io/antrea/pkg/apiserver/controlplanestream"
 	"antrea.io/antrea/pkg/apiserver/handlers/endpoint"
+	"antrea.io/antrea/pkg/apiserver/handlers/externaliplease"
 	"antrea.io/antrea/pkg/apiserver/handlers/featuregates"
+	"antrea.io/antrea/pkg/apiserver/handlers/ipgroup"
 	"antrea.io/antrea/pkg/apiserver/handlers/loglevel"
+	"antrea.io/antrea/pkg/apiserver/handlers/policybundle"
 	"antrea.io/antrea/pkg/apiserver/handlers/webhook"
 	"antrea.io/antrea/pkg/apiserver/registry/controlplane/egressgroup"
 	"antrea.io/antrea/pkg/apiserver/registry/controlplane/nodestatssummary"
@@ -49,6 +56,7 @@ import (
 	"antrea.io/antrea/pkg/apiserver/registry/networkpolicy/appliedtogroup"
 	"antrea.io/antrea/pkg/apiserver/registry/networkpolicy/clustergroupmember"
 	"antrea.io/antrea/pkg/apiserver/registry/networkpolicy/groupassociation"
+	"antrea.io/antrea/pkg/apiserver/registry/networkpolicy/groupmember"
 	"antrea.io/antrea/pkg/apiserver/registry/networkpolicy/ipgroupassociation"
 	"antrea.io/antrea/pkg/apiserver/registry/networkpolicy/networkpolicy"
 	"antrea.io/antrea/pkg/apiserver/registry/stats/antreaclusternetworkpolicystats"
@@ -60,6 +68,7 @@ import (
 	"antrea.io/antrea/pkg/apiserver/storage"
 	crdv1a2informers "antrea.io/antrea/pkg/client/informers/externalversions/crd/v1alpha2"
 	"antrea.io/antrea/pkg/controller/egress"
+	controllerexternaliplease "antrea.io/antrea/pkg/controller/externaliplease"
 	"antrea.io/antrea/pkg/controller/externalippool"
 	"antrea.io/antrea/pkg/controller/ipam"
 	controllernetworkpolicy "antrea.io/antrea/pkg/controller/networkpolicy"
@@ -116,6 +125,7 @@ type ExtraConfig struct {
 	statsAggregator               *stats.Aggregator
 	networkPolicyStatusController *controllernetworkpolicy.StatusController
 	bundleCollectionController    *controllerbundlecollection.Controller
+	externalIPLeaseController     *controllerexternaliplease.Controller
 }
 
 // Config defines the config for Antrea apiserver.
@@ -158,7 +168,8 @@ func NewConfig(
 	endpointQuerier controllernetworkpolicy.EndpointQuerier,
 	npController *controllernetworkpolicy.NetworkPolicyController,
 	egressController *egress.EgressController,
-	bundleCollectionController *controllerbundlecollection.Controller) *Config {
+	bundleCollectionController *controllerbundlecollection.Controller,
+	externalIPLeaseController *controllerexternaliplease.Controller) *Config {
 	return &Config{
 		genericConfig: genericConfig,
 		extraConfig: ExtraConfig{
@@ -178,6 +189,7 @@ func NewConfig(
 			networkPolicyStatusController: networkPolicyStatusController,
 			egressController:              egressController,
 			bundleCollectionController:    bundleCollectionController,
+			externalIPLeaseController:     externalIPLeaseController,
 		},
 	}
 }
@@ -192,6 +204,7 @@ func installAPIGroup(s *APIServer, c completedConfig) error {
 	networkPolicyStorage := networkpolicy.NewREST(c.extraConfig.networkPolicyStore)
 	networkPolicyStatusStorage := networkpolicy.NewStatusREST(c.extraConfig.networkPolicyStatusController)
 	clusterGroupMembershipStorage := clustergroupmember.NewREST(c.extraConfig.networkPolicyController)
+	groupMembershipStorage := groupmember.NewREST(c.extraConfig.networkPolicyController)
 	groupAssociationStorage := groupassociation.NewREST(c.extraConfig.networkPolicyController)
 	ipGroupAssociationStorage := ipgroupassociation.NewREST(c.extraConfig.podInformer, c.extraConfig.eeInformer, c.extraConfig.networkPolicyController, c.extraConfig.networkPolicyController)
 	nodeStatsSummaryStorage := nodestatssummary.NewREST(c.extraConfig.statsAggregator)
@@ -208,6 +221,7 @@ func installAPIGroup(s *APIServer, c completedConfig) error {
 	cpv1beta2Storage["groupassociations"] = groupAssociationStorage
 	cpv1beta2Storage["ipgroupassociations"] = ipGroupAssociationStorage
 	cpv1beta2Storage["clustergroupmembers"] = clusterGroupMembershipStorage
+	cpv1beta2Storage["groupmembers"] = groupMembershipStorage
 	cpv1beta2Storage["egressgroups"] = egressGroupStorage
 	cpv1beta2Storage["supportbundlecollections"] = bundleCollectionStorage
 	cpv1beta2Storage["supportbundlecollections/status"] = bundleCollectionStatusStorage
@@ -254,10 +268,26 @@ func (c completedConfig) New() (*APIServer, error) {
 		return nil, err
 	}
 	installHandlers(c.extraConfig, s.GenericAPIServer)
+	if features.DefaultFeatureGate.Enabled(features.ControlplaneGRPC) {
+		installControlplaneStream(c, s.GenericAPIServer)
+	}
 
 	return s, nil
 }
 
+// installControlplaneStream serves the ControlplaneStream gRPC service on the secure port of the
+// apiserver, so that it shares the TLS configuration, the authentication and the authorization of
+// the controlplane API.
+func installControlplaneStream(c completedConfig, s *genericapiserver.GenericAPIServer) {
+	info, _ := runtime.SerializerInfoForMediaType(Codecs.SupportedMediaTypes(), runtime.ContentTypeProtobuf)
+	encoder := Codecs.EncoderForVersion(info.Serializer, cpv1beta2.SchemeGroupVersion)
+	minWatchTimeout := time.Duration(c.genericConfig.MinRequestTimeout) * time.Second
+	streamServer := controlplanestream.NewServer(c.extraConfig.addressGroupStore, c.extraConfig.appliedToGroupStore, c.extraConfig.networkPolicyStore, encoder, minWatchTimeout)
+	grpcServer := grpc.NewServer()
+	cpstreamv1alpha1.RegisterControlplaneStreamServer(grpcServer, streamServer)
+	s.Handler.NonGoRestfulMux.HandlePrefix(controlplanestream.PathPrefix, grpcServer)
+}
+
 // CleanupDeprecatedAPIServices deletes the registered APIService resources for
 // the deprecated Antrea API groups.
 func CleanupDeprecatedAPIServices(aggregatorClient clientset.Interface) error {
@@ -288,6 +318,7 @@ func installHandlers(c *ExtraConfig, s *genericapiserver.GenericAPIServer) {
 	s.Handler.NonGoRestfulMux.HandleFunc("/loglevel", loglevel.HandleFunc())
 	s.Handler.NonGoRestfulMux.HandleFunc("/featuregates", featuregates.HandleFunc(c.k8sClient))
 	s.Handler.NonGoRestfulMux.HandleFunc("/endpoint", endpoint.HandleFunc(c.endpointQuerier))
+	s.Handler.NonGoRestfulMux.HandleFunc("/ipgroup", ipgroup.HandleFunc(c.endpointQuerier))
 	// Webhook to mutate Namespace labels and add its metadata.name as a label
 	s.Handler.NonGoRestfulMux.HandleFunc("/mutate/namespace", webhook.HandleMutationLabels())
 	if features.DefaultFeatureGate.Enabled(features.AntreaPolicy) {
@@ -302,12 +333,19 @@ func installHandlers(c *ExtraConfig, s *genericapiserver.GenericAPIServer) {
 		v := controllernetworkpolicy.NewNetworkPolicyValidator(c.networkPolicyController)
 		// Install handlers for NetworkPolicy related validation
 		s.Handler.NonGoRestfulMux.HandleFunc("/validate/tier", webhook.HandlerForValidateFunc(v.Validate))
+		s.Handler.NonGoRestfulMux.HandleFunc("/validate/tierentitlement", webhook.HandlerForValidateFunc(v.Validate))
 		s.Handler.NonGoRestfulMux.HandleFunc("/validate/acnp", webhook.HandlerForValidateFunc(v.Validate))
 		s.Handler.NonGoRestfulMux.HandleFunc("/validate/annp", webhook.HandlerForValidateFunc(v.Validate))
 		s.Handler.NonGoRestfulMux.HandleFunc("/validate/anp", webhook.HandlerForValidateFunc(v.Validate))
 		s.Handler.NonGoRestfulMux.HandleFunc("/validate/clustergroup", webhook.HandlerForValidateFunc(v.Validate))
 		s.Handler.NonGoRestfulMux.HandleFunc("/validate/group", webhook.HandlerForValidateFunc(v.Validate))
 
+		// Install handlers to export the Antrea-native policies as a bundle and to diff a bundle
+		// against the cluster.
+		b := controllernetworkpolicy.NewPolicyBundler(c.networkPolicyController)
+		s.Handler.NonGoRestfulMux.HandleFunc("/policybundle", policybundle.HandleExportFunc(b))
+		s.Handler.NonGoRestfulMux.HandleFunc("/policybundle/diff", policybundle.HandleDiffFunc(b))
+
 		// Install handlers for CRD conversion between versions
 		s.Handler.NonGoRestfulMux.HandleFunc("/convert/clustergroup", webhook.HandleCRDConversion(controllernetworkpolicy.ConvertClusterGroupCRD))
 
@@ -322,6 +360,10 @@ func installHandlers(c *ExtraConfig, s *genericapiserver.GenericAPIServer) {
 		s.Handler.NonGoRestfulMux.HandleFunc("/validate/externalippool", webhook.HandlerForValidateFunc(c.externalIPPoolController.ValidateExternalIPPool))
 	}
 
+	if features.DefaultFeatureGate.Enabled(features.ExternalIPLease) {
+		s.Handler.NonGoRestfulMux.HandleFunc("/externaliplease", externaliplease.HandleFunc(c.externalIPLeaseController))
+	}
+
 	if features.DefaultFeatureGate.Enabled(features.Egress) {
 		s.Handler.NonGoRestfulMux.HandleFunc("/validate/egress", webhook.HandlerForValidateFunc(c.egressController.ValidateEgress))
 	}
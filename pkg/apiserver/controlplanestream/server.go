@@ -0,0 +1,155 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplanestream
+
+import (
+	"bytes"
+	"context"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/apis/controlplanestream/v1alpha1"
+	"antrea.io/antrea/pkg/apiserver/registry/networkpolicy"
+	"antrea.io/antrea/pkg/apiserver/storage"
+)
+
+const (
+	// PathPrefix is the prefix of the HTTP paths of the ControlplaneStream gRPC methods.
+	PathPrefix = "/antrea_io.antrea.pkg.apis.controlplanestream.v1alpha1.ControlplaneStream/"
+
+	// maxBatchSize is the maximum number of events sent in a single batch.
+	maxBatchSize = 100
+)
+
+// Server implements the ControlplaneStream gRPC service. It streams the events of the controlplane
+// NetworkPolicies, AddressGroups and AppliedToGroups, sending the events which are pending at the
+// same time in a single batch.
+type Server struct {
+	v1alpha1.UnimplementedControlplaneStreamServer
+	stores map[string]storage.Interface
+	// encoder encodes the internal controlplane objects in the protobuf format of the
+	// controlplane v1beta2 API.
+	encoder runtime.Encoder
+	// minWatchTimeout is the minimum duration of a stream, after which it is closed by the
+	// server. Like for the HTTP watch API, the actual duration is randomized in
+	// [minWatchTimeout, 2*minWatchTimeout] to spread the reconnections of the clients.
+	minWatchTimeout time.Duration
+}
+
+func NewServer(addressGroupStore, appliedToGroupStore, networkPolicyStore storage.Interface, encoder runtime.Encoder, minWatchTimeout time.Duration) *Server {
+	return &Server{
+		stores: map[string]storage.Interface{
+			"addressgroups":   addressGroupStore,
+			"appliedtogroups": appliedToGroupStore,
+			"networkpolicies": networkPolicyStore,
+		},
+		encoder:         encoder,
+		minWatchTimeout: minWatchTimeout,
+	}
+}
+
+func (s *Server) Watch(req *v1alpha1.WatchRequest, stream v1alpha1.ControlplaneStream_WatchServer) error {
+	store, ok := s.stores[req.Resource]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unsupported resource %q", req.Resource)
+	}
+	fieldSelector, err := fields.ParseSelector(req.FieldSelector)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid field selector %q: %v", req.FieldSelector, err)
+	}
+	key, label, field := networkpolicy.GetSelectors(&internalversion.ListOptions{FieldSelector: fieldSelector})
+
+	timeout := time.Duration(float64(s.minWatchTimeout) * (rand.Float64() + 1.0))
+	ctx, cancel := context.WithTimeout(stream.Context(), timeout)
+	defer cancel()
+	watcher, err := store.Watch(ctx, key, label, field)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to watch %s: %v", req.Resource, err)
+	}
+	defer watcher.Stop()
+	klog.V(2).InfoS("Started stream", "resource", req.Resource, "fieldSelector", req.FieldSelector, "timeout", timeout)
+
+	for {
+		select {
+		case <-ctx.Done():
+			// The client will start a new stream if the stream timed out.
+			return nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+			batch := &v1alpha1.WatchEventBatch{}
+			closed := false
+		batching:
+			for {
+				watchEvent, err := s.newWatchEvent(event)
+				if err != nil {
+					return status.Errorf(codes.Internal, "failed to encode %s event: %v", event.Type, err)
+				}
+				batch.Events = append(batch.Events, watchEvent)
+				if len(batch.Events) == maxBatchSize {
+					break
+				}
+				// Add the events which are already pending to the batch.
+				select {
+				case event, ok = <-watcher.ResultChan():
+					if !ok {
+						closed = true
+						break batching
+					}
+				default:
+					break batching
+				}
+			}
+			if err := stream.Send(batch); err != nil {
+				return err
+			}
+			if closed {
+				return nil
+			}
+		}
+	}
+}
+
+func (s *Server) newWatchEvent(event watch.Event) (*v1alpha1.WatchEvent, error) {
+	var buf bytes.Buffer
+	if err := s.encoder.Encode(event.Object, &buf); err != nil {
+		return nil, err
+	}
+	return &v1alpha1.WatchEvent{Type: string(event.Type), Object: buf.Bytes()}, nil
+}
+
+// WithLongRunningRequests returns a LongRunningRequestCheck which considers the requests of the
+// ControlplaneStream service as long-running, in addition to the ones accepted by the provided
+// check, so that they are not subject to the request timeout of the apiserver.
+func WithLongRunningRequests(check apirequest.LongRunningRequestCheck) apirequest.LongRunningRequestCheck {
+	return func(r *http.Request, requestInfo *apirequest.RequestInfo) bool {
+		if strings.HasPrefix(r.URL.Path, PathPrefix) {
+			return true
+		}
+		return check(r, requestInfo)
+	}
+}
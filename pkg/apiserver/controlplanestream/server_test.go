@@ -0,0 +1,214 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplanestream
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+
+	cpinstall "antrea.io/antrea/pkg/apis/controlplane/install"
+	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	"antrea.io/antrea/pkg/apis/controlplanestream/v1alpha1"
+	"antrea.io/antrea/pkg/apiserver/storage"
+	"antrea.io/antrea/pkg/client/clientset/versioned/scheme"
+	"antrea.io/antrea/pkg/controller/networkpolicy/store"
+	"antrea.io/antrea/pkg/controller/types"
+)
+
+type fakeWatchServer struct {
+	grpc.ServerStream
+	ctx     context.Context
+	batches chan *v1alpha1.WatchEventBatch
+}
+
+func (s *fakeWatchServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeWatchServer) Send(batch *v1alpha1.WatchEventBatch) error {
+	s.batches <- batch
+	return nil
+}
+
+type event struct {
+	eventType watch.EventType
+	name      string
+}
+
+func newTestServer(networkPolicyStore storage.Interface, minWatchTimeout time.Duration) *Server {
+	s := runtime.NewScheme()
+	cpinstall.Install(s)
+	codecs := serializer.NewCodecFactory(s)
+	info, _ := runtime.SerializerInfoForMediaType(codecs.SupportedMediaTypes(), runtime.ContentTypeProtobuf)
+	encoder := codecs.EncoderForVersion(info.Serializer, v1beta2.SchemeGroupVersion)
+	return NewServer(store.NewAddressGroupStore(), store.NewAppliedToGroupStore(), networkPolicyStore, encoder, minWatchTimeout)
+}
+
+// receiveEvents decodes the events of the received batches until the expected number of events
+// is reached.
+func receiveEvents(t *testing.T, batches <-chan *v1alpha1.WatchEventBatch, numEvents int) []event {
+	var events []event
+	for len(events) < numEvents {
+		select {
+		case batch := <-batches:
+			for _, e := range batch.Events {
+				obj, _, err := scheme.Codecs.UniversalDeserializer().Decode(e.Object, nil, nil)
+				require.NoError(t, err)
+				policy, ok := obj.(*v1beta2.NetworkPolicy)
+				require.True(t, ok, "Unexpected object type %T", obj)
+				events = append(events, event{eventType: watch.EventType(e.Type), name: policy.Name})
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Failed to receive %d events in time, got %v", numEvents, events)
+		}
+	}
+	return events
+}
+
+func TestWatch(t *testing.T) {
+	networkPolicyStore := store.NewNetworkPolicyStore()
+	for _, name := range []string{"np1", "np2"} {
+		networkPolicyStore.Create(&types.NetworkPolicy{
+			Name:     name,
+			SpanMeta: types.SpanMeta{NodeNames: sets.New[string]("node1")},
+		})
+	}
+	networkPolicyStore.Create(&types.NetworkPolicy{
+		Name:     "np3",
+		SpanMeta: types.SpanMeta{NodeNames: sets.New[string]("node2")},
+	})
+	server := newTestServer(networkPolicyStore, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &fakeWatchServer{ctx: ctx, batches: make(chan *v1alpha1.WatchEventBatch, 10)}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Watch(&v1alpha1.WatchRequest{Resource: "networkpolicies", FieldSelector: "nodeName=node1"}, stream)
+	}()
+
+	events := receiveEvents(t, stream.batches, 3)
+	assert.ElementsMatch(t, []event{{watch.Added, "np1"}, {watch.Added, "np2"}}, events[:2])
+	assert.Equal(t, event{watch.Bookmark, ""}, events[2])
+
+	networkPolicyStore.Create(&types.NetworkPolicy{
+		Name:     "np4",
+		SpanMeta: types.SpanMeta{NodeNames: sets.New[string]("node1")},
+	})
+	networkPolicyStore.Delete("np1")
+	events = receiveEvents(t, stream.batches, 2)
+	assert.Equal(t, []event{{watch.Added, "np4"}, {watch.Deleted, "np1"}}, events)
+
+	cancel()
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Stream was not closed after the context was canceled")
+	}
+}
+
+func TestWatchTimeout(t *testing.T) {
+	server := newTestServer(store.NewNetworkPolicyStore(), 100*time.Millisecond)
+	stream := &fakeWatchServer{ctx: context.Background(), batches: make(chan *v1alpha1.WatchEventBatch, 10)}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Watch(&v1alpha1.WatchRequest{Resource: "networkpolicies"}, stream)
+	}()
+	select {
+	case err := <-errCh:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Stream was not closed after the timeout")
+	}
+}
+
+func TestWatchInvalidRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *v1alpha1.WatchRequest
+		expectedErr string
+	}{
+		{
+			name:        "unsupported resource",
+			request:     &v1alpha1.WatchRequest{Resource: "egressgroups"},
+			expectedErr: `unsupported resource "egressgroups"`,
+		},
+		{
+			name:        "invalid field selector",
+			request:     &v1alpha1.WatchRequest{Resource: "addressgroups", FieldSelector: "nodeName"},
+			expectedErr: `invalid field selector "nodeName"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(store.NewNetworkPolicyStore(), time.Minute)
+			stream := &fakeWatchServer{ctx: context.Background()}
+			err := server.Watch(tt.request, stream)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestWithLongRunningRequests(t *testing.T) {
+	check := WithLongRunningRequests(func(r *http.Request, requestInfo *apirequest.RequestInfo) bool {
+		return requestInfo.Verb == "watch"
+	})
+	tests := []struct {
+		name     string
+		path     string
+		verb     string
+		expected bool
+	}{
+		{
+			name:     "ControlplaneStream request",
+			path:     PathPrefix + "Watch",
+			verb:     "post",
+			expected: true,
+		},
+		{
+			name:     "watch request",
+			path:     "/apis/controlplane.antrea.io/v1beta2/networkpolicies",
+			verb:     "watch",
+			expected: true,
+		},
+		{
+			name:     "list request",
+			path:     "/apis/controlplane.antrea.io/v1beta2/networkpolicies",
+			verb:     "list",
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			assert.Equal(t, tt.expected, check(req, &apirequest.RequestInfo{Verb: tt.verb}))
+		})
+	}
+}
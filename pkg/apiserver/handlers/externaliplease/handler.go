@@ -0,0 +1,87 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package externaliplease
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"antrea.io/antrea/pkg/controller/externaliplease"
+	"antrea.io/antrea/pkg/controller/externalippool"
+)
+
+// HandleFunc returns the function which can handle the /externaliplease API request:
+//   - GET lists the leases, optionally filtered with the "externalIPPool" and "owner" query
+//     parameters.
+//   - POST acquires or renews a lease, the request body being a LeaseRequest.
+//   - DELETE releases the lease identified by the "externalIPPool", "ip" and "owner" query
+//     parameters.
+func HandleFunc(leaseManager externaliplease.Interface) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.Method {
+		case http.MethodGet:
+			leases, err := leaseManager.List(query.Get("externalIPPool"), query.Get("owner"))
+			if err != nil {
+				http.Error(w, err.Error(), statusForError(err))
+				return
+			}
+			writeJSON(w, leases)
+		case http.MethodPost:
+			var request externaliplease.LeaseRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				http.Error(w, "failed to decode request: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			lease, err := leaseManager.Acquire(&request)
+			if err != nil {
+				http.Error(w, err.Error(), statusForError(err))
+				return
+			}
+			writeJSON(w, lease)
+		case http.MethodDelete:
+			if err := leaseManager.Release(query.Get("externalIPPool"), query.Get("ip"), query.Get("owner")); err != nil {
+				http.Error(w, err.Error(), statusForError(err))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		http.Error(w, "failed to encode response: "+err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, externaliplease.ErrInvalidLease):
+		return http.StatusBadRequest
+	case errors.Is(err, externaliplease.ErrLeaseNotFound), errors.Is(err, externalippool.ErrExternalIPPoolNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, externaliplease.ErrIPConflict):
+		return http.StatusConflict
+	case errors.Is(err, externaliplease.ErrNotReady):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package externaliplease
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"antrea.io/antrea/pkg/controller/externaliplease"
+	"antrea.io/antrea/pkg/controller/externalippool"
+)
+
+type fakeLeaseManager struct {
+	leases       []externaliplease.Lease
+	acquireErr   error
+	releaseErr   error
+	gotRequest   *externaliplease.LeaseRequest
+	gotReleaseIP string
+}
+
+func (m *fakeLeaseManager) Acquire(request *externaliplease.LeaseRequest) (*externaliplease.Lease, error) {
+	m.gotRequest = request
+	if m.acquireErr != nil {
+		return nil, m.acquireErr
+	}
+	return &externaliplease.Lease{ExternalIPPool: request.ExternalIPPool, IP: "1.2.3.4", Owner: request.Owner}, nil
+}
+
+func (m *fakeLeaseManager) Release(externalIPPool, ip, owner string) error {
+	m.gotReleaseIP = ip
+	return m.releaseErr
+}
+
+func (m *fakeLeaseManager) List(externalIPPool, owner string) ([]externaliplease.Lease, error) {
+	var leases []externaliplease.Lease
+	for _, lease := range m.leases {
+		if externalIPPool != "" && lease.ExternalIPPool != externalIPPool {
+			continue
+		}
+		leases = append(leases, lease)
+	}
+	return leases, nil
+}
+
+func TestListLeases(t *testing.T) {
+	m := &fakeLeaseManager{leases: []externaliplease.Lease{
+		{ExternalIPPool: "eip1", IP: "1.2.3.4", Owner: "lb1"},
+		{ExternalIPPool: "eip2", IP: "1.2.4.4", Owner: "lb1"},
+	}}
+	recorder := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/externaliplease?externalIPPool=eip2", nil)
+	require.NoError(t, err)
+	HandleFunc(m).ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var leases []externaliplease.Lease
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &leases))
+	assert.Equal(t, []externaliplease.Lease{{ExternalIPPool: "eip2", IP: "1.2.4.4", Owner: "lb1"}}, leases)
+}
+
+func TestAcquireLease(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		acquireErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			body:           `{"externalIPPool": "eip1", "owner": "lb1"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "malformed request",
+			body:           `{"externalIPPool":`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid request",
+			body:           `{"externalIPPool": "eip1"}`,
+			acquireErr:     fmt.Errorf("%w: owner must be provided", externaliplease.ErrInvalidLease),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "pool not found",
+			body:           `{"externalIPPool": "eip1", "owner": "lb1"}`,
+			acquireErr:     externalippool.ErrExternalIPPoolNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "conflict",
+			body:           `{"externalIPPool": "eip1", "ip": "1.2.3.4", "owner": "lb1"}`,
+			acquireErr:     fmt.Errorf("%w: 1.2.3.4 is leased by another owner", externaliplease.ErrIPConflict),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "not ready",
+			body:           `{"externalIPPool": "eip1", "owner": "lb1"}`,
+			acquireErr:     externaliplease.ErrNotReady,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &fakeLeaseManager{acquireErr: tt.acquireErr}
+			recorder := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPost, "/externaliplease", strings.NewReader(tt.body))
+			require.NoError(t, err)
+			HandleFunc(m).ServeHTTP(recorder, req)
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var lease externaliplease.Lease
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &lease))
+				assert.Equal(t, externaliplease.Lease{ExternalIPPool: "eip1", IP: "1.2.3.4", Owner: "lb1"}, lease)
+			}
+		})
+	}
+}
+
+func TestReleaseLease(t *testing.T) {
+	tests := []struct {
+		name           string
+		releaseErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "success",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "lease not found",
+			releaseErr:     externaliplease.ErrLeaseNotFound,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "not owner",
+			releaseErr:     fmt.Errorf("%w: 1.2.3.4 is leased by another owner", externaliplease.ErrIPConflict),
+			expectedStatus: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &fakeLeaseManager{releaseErr: tt.releaseErr}
+			recorder := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodDelete, "/externaliplease?externalIPPool=eip1&ip=1.2.3.4&owner=lb1", nil)
+			require.NoError(t, err)
+			HandleFunc(m).ServeHTTP(recorder, req)
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, "1.2.3.4", m.gotReleaseIP)
+		})
+	}
+}
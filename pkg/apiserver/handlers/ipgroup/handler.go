@@ -0,0 +1,81 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipgroup
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+
+	"antrea.io/antrea/pkg/antctl/transform/common"
+	"antrea.io/antrea/pkg/controller/networkpolicy"
+)
+
+// Response describes the response struct of the ipgroup query command.
+type Response struct {
+	networkpolicy.IPGroup
+}
+
+// HandleFunc creates a http.HandlerFunc which uses an EndpointQuerier to query the
+// AppliedToGroups and AddressGroups which contain the IP provided with the "ip" parameter,
+// along with the policies which reference them.
+func HandleFunc(eq networkpolicy.EndpointQuerier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ipStr := r.URL.Query().Get("ip")
+		if ipStr == "" {
+			http.Error(w, "ip must be provided", http.StatusBadRequest)
+			return
+		}
+		ip := net.ParseIP(ipStr)
+		if ip == nil {
+			http.Error(w, "invalid IP address "+ipStr, http.StatusBadRequest)
+			return
+		}
+		groups, err := eq.QueryGroupsByIP(ip)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp := make([]Response, 0, len(groups))
+		for _, group := range groups {
+			resp = append(resp, Response{group})
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			http.Error(w, "failed to encode response: "+err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+var _ common.TableOutput = new(Response)
+
+func (r Response) GetTableHeader() []string {
+	return []string{"TYPE", "NAME", "POLICIES"}
+}
+
+func (r Response) GetTableRow(maxColumnLength int) []string {
+	policies := make([]string, 0, len(r.Policies))
+	for _, policy := range r.Policies {
+		if policy.Namespace == "" {
+			policies = append(policies, policy.Name)
+		} else {
+			policies = append(policies, policy.Namespace+"/"+policy.Name)
+		}
+	}
+	return []string{r.Type, r.Name, common.GenerateTableElementWithSummary(policies, maxColumnLength)}
+}
+
+func (r Response) SortRows() bool {
+	return false
+}
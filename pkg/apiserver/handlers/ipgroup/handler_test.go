@@ -0,0 +1,126 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipgroup
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"antrea.io/antrea/pkg/controller/networkpolicy"
+	queriermock "antrea.io/antrea/pkg/controller/networkpolicy/testing"
+)
+
+func TestIPGroupQuery(t *testing.T) {
+	groups := []networkpolicy.IPGroup{
+		{
+			Type:     networkpolicy.IPGroupTypeAppliedToGroup,
+			Name:     "atg1",
+			Policies: []networkpolicy.PolicyRef{{Namespace: "ns1", Name: "np1", UID: "uid1"}},
+		},
+		{
+			Type: networkpolicy.IPGroupTypeAddressGroup,
+			Name: "ag1",
+			Policies: []networkpolicy.PolicyRef{
+				{Namespace: "ns1", Name: "np1", UID: "uid1"},
+				{Name: "acnp1", UID: "uid2"},
+			},
+		},
+	}
+	tests := []struct {
+		name             string
+		query            string
+		expectedCalls    func(eq *queriermock.MockEndpointQuerierMockRecorder)
+		expectedStatus   int
+		expectedResponse []Response
+	}{
+		{
+			name:  "groups found",
+			query: "?ip=10.10.0.1",
+			expectedCalls: func(eq *queriermock.MockEndpointQuerierMockRecorder) {
+				eq.QueryGroupsByIP(net.ParseIP("10.10.0.1")).Return(groups, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedResponse: []Response{{groups[0]}, {groups[1]}},
+		},
+		{
+			name:  "no group found",
+			query: "?ip=fec0::1",
+			expectedCalls: func(eq *queriermock.MockEndpointQuerierMockRecorder) {
+				eq.QueryGroupsByIP(net.ParseIP("fec0::1")).Return([]networkpolicy.IPGroup{}, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedResponse: []Response{},
+		},
+		{
+			name:           "no IP",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid IP",
+			query:          "?ip=10.10.0",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "query error",
+			query: "?ip=10.10.0.1",
+			expectedCalls: func(eq *queriermock.MockEndpointQuerierMockRecorder) {
+				eq.QueryGroupsByIP(net.ParseIP("10.10.0.1")).Return(nil, fmt.Errorf("index not found"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			eq := queriermock.NewMockEndpointQuerier(ctrl)
+			if tt.expectedCalls != nil {
+				tt.expectedCalls(eq.EXPECT())
+			}
+			handler := HandleFunc(eq)
+
+			req, err := http.NewRequest(http.MethodGet, tt.query, nil)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var received []Response
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &received))
+				assert.Equal(t, tt.expectedResponse, received)
+			}
+		})
+	}
+}
+
+func TestGetTableRow(t *testing.T) {
+	r := Response{networkpolicy.IPGroup{
+		Type: networkpolicy.IPGroupTypeAddressGroup,
+		Name: "ag1",
+		Policies: []networkpolicy.PolicyRef{
+			{Namespace: "ns1", Name: "np1"},
+			{Name: "acnp1"},
+		},
+	}}
+	assert.Equal(t, []string{"AddressGroup", "ag1", "acnp1,ns1/np1"}, r.GetTableRow(32))
+}
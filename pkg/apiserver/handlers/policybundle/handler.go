@@ -0,0 +1,76 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policybundle
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"antrea.io/antrea/pkg/controller/networkpolicy"
+)
+
+// DiffResponse describes the response of the /policybundle/diff API.
+type DiffResponse struct {
+	// InSync is true when the cluster matches the bundle.
+	InSync bool                              `json:"inSync"`
+	Drifts []networkpolicy.PolicyBundleDrift `json:"drifts"`
+}
+
+// HandleExportFunc returns the function which can handle the /policybundle API request, which
+// exports the Antrea-native policies, the Tiers and the groups of the cluster as a normalized
+// PolicyBundle.
+func HandleExportFunc(bundler networkpolicy.PolicyBundler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		bundle, err := bundler.ExportPolicyBundle()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, bundle)
+	}
+}
+
+// HandleDiffFunc returns the function which can handle the /policybundle/diff API request, which
+// compares the PolicyBundle provided in the request body with the cluster.
+func HandleDiffFunc(bundler networkpolicy.PolicyBundler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		var bundle networkpolicy.PolicyBundle
+		if err := json.NewDecoder(r.Body).Decode(&bundle); err != nil {
+			http.Error(w, "failed to decode policy bundle: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		drifts, err := bundler.DiffPolicyBundle(&bundle)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, DiffResponse{InSync: len(drifts) == 0, Drifts: drifts})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		http.Error(w, "failed to encode response: "+err.Error(), http.StatusInternalServerError)
+	}
+}
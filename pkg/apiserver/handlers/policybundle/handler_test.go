@@ -0,0 +1,153 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policybundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	"antrea.io/antrea/pkg/controller/networkpolicy"
+	queriermock "antrea.io/antrea/pkg/controller/networkpolicy/testing"
+)
+
+var testBundle = &networkpolicy.PolicyBundle{
+	Tiers: []crdv1alpha1.Tier{
+		{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "crd.antrea.io/v1alpha1", Kind: "Tier"},
+			ObjectMeta: metav1.ObjectMeta{Name: "tier1"},
+			Spec:       crdv1alpha1.TierSpec{Priority: 10},
+		},
+	},
+}
+
+func TestPolicyBundleExport(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		expectedCalls  func(b *queriermock.MockPolicyBundlerMockRecorder)
+		expectedStatus int
+	}{
+		{
+			name:   "export",
+			method: http.MethodGet,
+			expectedCalls: func(b *queriermock.MockPolicyBundlerMockRecorder) {
+				b.ExportPolicyBundle().Return(testBundle, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "export error",
+			method: http.MethodGet,
+			expectedCalls: func(b *queriermock.MockPolicyBundlerMockRecorder) {
+				b.ExportPolicyBundle().Return(nil, fmt.Errorf("failed to list Tiers"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "invalid method",
+			method:         http.MethodPost,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			bundler := queriermock.NewMockPolicyBundler(ctrl)
+			if tt.expectedCalls != nil {
+				tt.expectedCalls(bundler.EXPECT())
+			}
+			req, err := http.NewRequest(tt.method, "/policybundle", nil)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			HandleExportFunc(bundler).ServeHTTP(recorder, req)
+			require.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var received networkpolicy.PolicyBundle
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &received))
+			assert.Equal(t, *testBundle, received)
+		})
+	}
+}
+
+func TestPolicyBundleDiff(t *testing.T) {
+	body, err := json.Marshal(testBundle)
+	require.NoError(t, err)
+	drifts := []networkpolicy.PolicyBundleDrift{
+		{Kind: "Tier", Name: "tier1", Type: networkpolicy.PolicyBundleDriftModified, Fields: []string{"spec.priority"}},
+	}
+	tests := []struct {
+		name             string
+		body             []byte
+		expectedCalls    func(b *queriermock.MockPolicyBundlerMockRecorder)
+		expectedStatus   int
+		expectedResponse DiffResponse
+	}{
+		{
+			name: "in sync",
+			body: body,
+			expectedCalls: func(b *queriermock.MockPolicyBundlerMockRecorder) {
+				b.DiffPolicyBundle(testBundle).Return([]networkpolicy.PolicyBundleDrift{}, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedResponse: DiffResponse{InSync: true, Drifts: []networkpolicy.PolicyBundleDrift{}},
+		},
+		{
+			name: "drift",
+			body: body,
+			expectedCalls: func(b *queriermock.MockPolicyBundlerMockRecorder) {
+				b.DiffPolicyBundle(testBundle).Return(drifts, nil)
+			},
+			expectedStatus:   http.StatusOK,
+			expectedResponse: DiffResponse{InSync: false, Drifts: drifts},
+		},
+		{
+			name:           "invalid bundle",
+			body:           []byte("tiers: []"),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			bundler := queriermock.NewMockPolicyBundler(ctrl)
+			if tt.expectedCalls != nil {
+				tt.expectedCalls(bundler.EXPECT())
+			}
+			req, err := http.NewRequest(http.MethodPost, "/policybundle/diff", bytes.NewReader(tt.body))
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			HandleDiffFunc(bundler).ServeHTTP(recorder, req)
+			require.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var received DiffResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &received))
+			assert.Equal(t, tt.expectedResponse, received)
+		})
+	}
+}
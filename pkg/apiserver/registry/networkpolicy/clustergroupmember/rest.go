@@ -17,13 +17,13 @@ package clustergroupmember
 import (
 	"context"
 	"fmt"
-	"sort"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/registry/rest"
 
 	"antrea.io/antrea/pkg/apis/controlplane"
+	"antrea.io/antrea/pkg/apiserver/registry/networkpolicy"
 )
 
 type REST struct {
@@ -81,8 +81,14 @@ func (r *REST) Get(ctx context.Context, name string, options runtime.Object) (ru
 	}
 	memberList.Name = name
 	memberList.TotalMembers = int64(len(memberList.EffectiveMembers))
-	err = paginateMemberList(memberList, getOptions)
-	return memberList, err
+	paginated, err := networkpolicy.PaginateMembers(memberList.EffectiveMembers, getOptions)
+	if err != nil {
+		return nil, err
+	}
+	memberList.EffectiveMembers = paginated.Members
+	memberList.TotalPages, memberList.CurrentPage = paginated.TotalPages, paginated.CurrentPage
+	memberList.Continue = paginated.Continue
+	return memberList, nil
 }
 
 // NewGetOptions returns the default options for Get, so options object is never nil.
@@ -93,47 +99,3 @@ func (r *REST) NewGetOptions() (runtime.Object, bool, string) {
 func (r *REST) NamespaceScoped() bool {
 	return false
 }
-
-// paginateMemberList returns paginated results if meaningful options are provided, options should never be nil.
-// Paginated results are continuous only when there is no member change across multiple calls.
-// Pagination is not processed if either page number or limit = 0, thus returns full member list.
-// Returns an error for invalid options; returns empty list for page number beyond the total pages range.
-func paginateMemberList(memberList *controlplane.ClusterGroupMembers, pageInfo *controlplane.PaginationGetOptions) error {
-	if pageInfo.Limit < 0 {
-		return errors.NewBadRequest(fmt.Sprintf("received invalid page limit %d for pagination", pageInfo.Limit))
-	} else if pageInfo.Page < 0 {
-		return errors.NewBadRequest(fmt.Sprintf("received invalid page number %d for pagination", pageInfo.Page))
-	}
-	if memberList.TotalMembers == 0 {
-		memberList.TotalPages, memberList.CurrentPage = 0, 0
-		return nil
-	}
-	// Sort members based on name of ee/pod to realize consistent pagination support.
-	sort.SliceStable(memberList.EffectiveMembers, func(i, j int) bool {
-		if memberList.EffectiveMembers[i].Pod != nil && memberList.EffectiveMembers[j].Pod != nil {
-			return memberList.EffectiveMembers[i].Pod.Name < memberList.EffectiveMembers[j].Pod.Name
-		} else if memberList.EffectiveMembers[i].ExternalEntity != nil && memberList.EffectiveMembers[j].ExternalEntity != nil {
-			return memberList.EffectiveMembers[i].ExternalEntity.Name < memberList.EffectiveMembers[j].ExternalEntity.Name
-		} else {
-			return true
-		}
-	})
-	if pageInfo.Limit == 0 {
-		memberList.TotalPages, memberList.CurrentPage = 1, 1
-		return nil
-	}
-	totalPages := (int64(len(memberList.EffectiveMembers)) + pageInfo.Limit - 1) / pageInfo.Limit
-	memberList.TotalPages = totalPages
-	memberList.CurrentPage = pageInfo.Page
-	if totalPages >= pageInfo.Page && pageInfo.Page > 0 {
-		beginMember := (pageInfo.Page - 1) * pageInfo.Limit
-		memberList.EffectiveMembers = memberList.EffectiveMembers[beginMember:]
-		if pageInfo.Limit < int64(len(memberList.EffectiveMembers)) {
-			memberList.EffectiveMembers = memberList.EffectiveMembers[:pageInfo.Limit]
-		}
-	} else if totalPages < pageInfo.Page {
-		// Returns empty memberList if page number exceeds total pages, to indicate end of list.
-		memberList.EffectiveMembers = memberList.EffectiveMembers[:0]
-	}
-	return nil
-}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/endpoints/request"
@@ -369,3 +370,43 @@ func TestRESTGetPagination(t *testing.T) {
 		assert.Equal(t, tt.expectedObj, actualGroupList)
 	}
 }
+
+func TestRESTGetContinue(t *testing.T) {
+	rest := NewREST(fakeQuerier{members: getTestMembersPagination()})
+	var podNames []string
+	var continueToken string
+	for i := 0; i < 3; i++ {
+		obj, err := rest.Get(request.NewDefaultContext(), "cgA", &controlplane.PaginationGetOptions{Limit: 2, Continue: continueToken})
+		require.NoError(t, err)
+		memberList := obj.(*controlplane.ClusterGroupMembers)
+		assert.Equal(t, int64(3), memberList.TotalMembers)
+		assert.Equal(t, int64(2), memberList.TotalPages)
+		for _, member := range memberList.EffectiveMembers {
+			podNames = append(podNames, member.Pod.Name)
+		}
+		continueToken = memberList.Continue
+		if continueToken == "" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"pod1", "pod2", "pod3"}, podNames)
+	assert.Empty(t, continueToken)
+
+	t.Run("continue-ignores-page", func(t *testing.T) {
+		obj, err := rest.Get(request.NewDefaultContext(), "cgA", &controlplane.PaginationGetOptions{Limit: 1})
+		require.NoError(t, err)
+		firstChunk := obj.(*controlplane.ClusterGroupMembers)
+		require.NotEmpty(t, firstChunk.Continue)
+		obj, err = rest.Get(request.NewDefaultContext(), "cgA", &controlplane.PaginationGetOptions{Page: 1, Limit: 1, Continue: firstChunk.Continue})
+		require.NoError(t, err)
+		secondChunk := obj.(*controlplane.ClusterGroupMembers)
+		require.Len(t, secondChunk.EffectiveMembers, 1)
+		assert.Equal(t, "pod2", secondChunk.EffectiveMembers[0].Pod.Name)
+	})
+
+	t.Run("invalid-continue-token", func(t *testing.T) {
+		_, err := rest.Get(request.NewDefaultContext(), "cgA", &controlplane.PaginationGetOptions{Limit: 2, Continue: "!invalid!"})
+		require.Error(t, err)
+		assert.True(t, errors.IsBadRequest(err))
+	})
+}
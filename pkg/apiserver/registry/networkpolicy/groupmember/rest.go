@@ -0,0 +1,109 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupmember
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"antrea.io/antrea/pkg/apis/controlplane"
+	"antrea.io/antrea/pkg/apiserver/registry/networkpolicy"
+)
+
+type REST struct {
+	querier groupMembershipQuerier
+}
+
+var (
+	_ rest.Storage           = &REST{}
+	_ rest.Scoper            = &REST{}
+	_ rest.GetterWithOptions = &REST{}
+)
+
+// NewREST returns a REST object that will work against API services.
+func NewREST(querier groupMembershipQuerier) *REST {
+	return &REST{querier}
+}
+
+type groupMembershipQuerier interface {
+	GetGroupMembers(name string) (controlplane.GroupMemberSet, []controlplane.IPBlock, error)
+}
+
+func (r *REST) New() runtime.Object {
+	return &controlplane.GroupMembers{}
+}
+
+func (r *REST) Destroy() {
+}
+
+func (r *REST) Get(ctx context.Context, name string, options runtime.Object) (runtime.Object, error) {
+	ns, ok := request.NamespaceFrom(ctx)
+	if !ok || len(ns) == 0 {
+		return nil, errors.NewBadRequest("Namespace parameter required.")
+	}
+	// Namespaced Groups are indexed by their Namespace and name in the querier.
+	groupMembers, ipBlocks, err := r.querier.GetGroupMembers(types.NamespacedName{Namespace: ns, Name: name}.String())
+	if err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+	// Retrieve options used for pagination.
+	getOptions, ok := options.(*controlplane.PaginationGetOptions)
+	if !ok || getOptions == nil {
+		return nil, errors.NewInternalError(fmt.Errorf("received error while retrieving options for pagination"))
+	}
+	memberList := &controlplane.GroupMembers{}
+	if len(ipBlocks) > 0 {
+		effectiveIPBlocks := make([]controlplane.IPNet, 0, len(ipBlocks))
+		for _, ipb := range ipBlocks {
+			// Group ipBlock does not support Except slices, so no need to generate an effective
+			// list of IPs by removing Except slices from allowed CIDR.
+			effectiveIPBlocks = append(effectiveIPBlocks, ipb.CIDR)
+		}
+		memberList.EffectiveIPBlocks = effectiveIPBlocks
+	}
+	if len(groupMembers) > 0 {
+		effectiveMembers := make([]controlplane.GroupMember, 0, len(groupMembers))
+		for _, member := range groupMembers {
+			effectiveMembers = append(effectiveMembers, *member)
+		}
+		memberList.EffectiveMembers = effectiveMembers
+	}
+	memberList.Namespace = ns
+	memberList.Name = name
+	memberList.TotalMembers = int64(len(memberList.EffectiveMembers))
+	paginated, err := networkpolicy.PaginateMembers(memberList.EffectiveMembers, getOptions)
+	if err != nil {
+		return nil, err
+	}
+	memberList.EffectiveMembers = paginated.Members
+	memberList.TotalPages, memberList.CurrentPage = paginated.TotalPages, paginated.CurrentPage
+	memberList.Continue = paginated.Continue
+	return memberList, nil
+}
+
+// NewGetOptions returns the default options for Get, so options object is never nil.
+func (r *REST) NewGetOptions() (runtime.Object, bool, string) {
+	return &controlplane.PaginationGetOptions{}, false, ""
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return true
+}
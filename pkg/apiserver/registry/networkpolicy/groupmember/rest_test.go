@@ -0,0 +1,205 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupmember
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"antrea.io/antrea/pkg/apis/controlplane"
+)
+
+type fakeQuerier struct {
+	members   map[string]controlplane.GroupMemberSet
+	ipMembers map[string][]controlplane.IPBlock
+}
+
+func (q fakeQuerier) GetGroupMembers(name string) (controlplane.GroupMemberSet, []controlplane.IPBlock, error) {
+	if ipMemberList, ok := q.ipMembers[name]; ok {
+		return nil, ipMemberList, nil
+	}
+	if memberList, ok := q.members[name]; ok {
+		return memberList, nil, nil
+	}
+	return nil, nil, nil
+}
+
+func newPodMember(name string) *controlplane.GroupMember {
+	return &controlplane.GroupMember{
+		Pod: &controlplane.PodReference{
+			Name:      name,
+			Namespace: "ns1",
+		},
+		IPs: []controlplane.IPAddress{
+			[]byte{127, 10, 0, 1},
+		},
+	}
+}
+
+func TestREST(t *testing.T) {
+	r := NewREST(nil)
+	assert.Equal(t, &controlplane.GroupMembers{}, r.New())
+	assert.True(t, r.NamespaceScoped())
+}
+
+func TestRESTGet(t *testing.T) {
+	querier := fakeQuerier{
+		members: map[string]controlplane.GroupMemberSet{
+			"ns1/gA": {
+				"memberKey1": newPodMember("pod3"),
+				"memberKey2": newPodMember("pod2"),
+				"memberKey3": newPodMember("pod1"),
+			},
+		},
+		ipMembers: map[string][]controlplane.IPBlock{
+			"ns1/gIPBlock": {{CIDR: controlplane.IPNet{IP: controlplane.IPAddress(net.ParseIP("10.0.0.1")), PrefixLength: 24}}},
+		},
+	}
+	tests := []struct {
+		name              string
+		namespace         string
+		groupName         string
+		paginationOptions *controlplane.PaginationGetOptions
+		expectedObj       runtime.Object
+		expectedErr       bool
+	}{
+		{
+			name:              "all-members",
+			namespace:         "ns1",
+			groupName:         "gA",
+			paginationOptions: &controlplane.PaginationGetOptions{},
+			expectedObj: &controlplane.GroupMembers{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "gA",
+				},
+				EffectiveMembers: []controlplane.GroupMember{*newPodMember("pod1"), *newPodMember("pod2"), *newPodMember("pod3")},
+				TotalMembers:     3,
+				TotalPages:       1,
+				CurrentPage:      1,
+			},
+		},
+		{
+			name:              "page-based",
+			namespace:         "ns1",
+			groupName:         "gA",
+			paginationOptions: &controlplane.PaginationGetOptions{Page: 2, Limit: 2},
+			expectedObj: &controlplane.GroupMembers{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "gA",
+				},
+				EffectiveMembers: []controlplane.GroupMember{*newPodMember("pod3")},
+				TotalMembers:     3,
+				TotalPages:       2,
+				CurrentPage:      2,
+			},
+		},
+		{
+			name:              "first-chunk",
+			namespace:         "ns1",
+			groupName:         "gA",
+			paginationOptions: &controlplane.PaginationGetOptions{Limit: 2},
+			expectedObj: &controlplane.GroupMembers{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "gA",
+				},
+				EffectiveMembers: []controlplane.GroupMember{*newPodMember("pod1"), *newPodMember("pod2")},
+				TotalMembers:     3,
+				TotalPages:       2,
+				// base64 encoding of "Pod/ns1/pod2".
+				Continue: "UG9kL25zMS9wb2Qy",
+			},
+		},
+		{
+			name:              "last-chunk",
+			namespace:         "ns1",
+			groupName:         "gA",
+			paginationOptions: &controlplane.PaginationGetOptions{Limit: 2, Continue: "UG9kL25zMS9wb2Qy"},
+			expectedObj: &controlplane.GroupMembers{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "gA",
+				},
+				EffectiveMembers: []controlplane.GroupMember{*newPodMember("pod3")},
+				TotalMembers:     3,
+				TotalPages:       2,
+			},
+		},
+		{
+			name:              "ipBlock-group",
+			namespace:         "ns1",
+			groupName:         "gIPBlock",
+			paginationOptions: &controlplane.PaginationGetOptions{},
+			expectedObj: &controlplane.GroupMembers{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "gIPBlock",
+				},
+				EffectiveIPBlocks: []controlplane.IPNet{
+					{
+						IP:           controlplane.IPAddress(net.ParseIP("10.0.0.1")),
+						PrefixLength: int32(24),
+					},
+				},
+			},
+		},
+		{
+			name:              "group-in-other-namespace",
+			namespace:         "ns2",
+			groupName:         "gA",
+			paginationOptions: &controlplane.PaginationGetOptions{},
+			expectedObj: &controlplane.GroupMembers{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns2",
+					Name:      "gA",
+				},
+			},
+		},
+		{
+			name:              "missing-namespace",
+			groupName:         "gA",
+			paginationOptions: &controlplane.PaginationGetOptions{},
+			expectedErr:       true,
+		},
+		{
+			name:              "invalid-continue-token",
+			namespace:         "ns1",
+			groupName:         "gA",
+			paginationOptions: &controlplane.PaginationGetOptions{Limit: 2, Continue: "!invalid!"},
+			expectedErr:       true,
+		},
+	}
+	rest := NewREST(querier)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := request.WithNamespace(request.NewContext(), tt.namespace)
+			actualGroupList, err := rest.Get(ctx, tt.groupName, tt.paginationOptions)
+			if tt.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedObj, actualGroupList)
+		})
+	}
+}
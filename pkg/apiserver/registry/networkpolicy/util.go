@@ -15,9 +15,17 @@
 package networkpolicy
 
 import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+
+	"antrea.io/antrea/pkg/apis/controlplane"
 )
 
 // GetSelectors extracts label selector, field selector, and key selector from the provided options.
@@ -33,3 +41,110 @@ func GetSelectors(options *internalversion.ListOptions) (string, labels.Selector
 	key, _ := field.RequiresExactMatch("metadata.name")
 	return key, label, field
 }
+
+// PaginatedMembers is the result of paginating a list of GroupMembers.
+type PaginatedMembers struct {
+	Members     []controlplane.GroupMember
+	TotalPages  int64
+	CurrentPage int64
+	// Continue is the token to retrieve the next chunk of members, empty if there are no more members.
+	Continue string
+}
+
+// PaginateMembers returns paginated results if meaningful options are provided, options should never be nil.
+// Members are sorted by a stable key to realize consistent pagination support. Two modes are supported:
+//   - Page based: when Page > 0 and Continue is empty, the page of the given number is returned. An empty list is
+//     returned for a page number beyond the total pages range. Paginated results are continuous only when there is
+//     no member change across multiple calls.
+//   - Continue based: when Continue is set, or when Limit > 0 and Page = 0, at most Limit members following the
+//     position encoded in Continue are returned, and Continue is set in the result if more members are available.
+//     Results are continuous even if members change across multiple calls.
+//
+// Pagination is not processed if both Limit and Continue are unset, thus returns full member list.
+// Returns an error for invalid options.
+func PaginateMembers(members []controlplane.GroupMember, options *controlplane.PaginationGetOptions) (*PaginatedMembers, error) {
+	if options.Limit < 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("received invalid page limit %d for pagination", options.Limit))
+	} else if options.Page < 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("received invalid page number %d for pagination", options.Page))
+	}
+	var lastKey string
+	if options.Continue != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(options.Continue)
+		if err != nil || len(decoded) == 0 {
+			return nil, errors.NewBadRequest(fmt.Sprintf("received invalid continue token %q for pagination", options.Continue))
+		}
+		lastKey = string(decoded)
+	}
+	result := &PaginatedMembers{Members: members}
+	if len(members) == 0 {
+		return result, nil
+	}
+	keys := make([]string, len(members))
+	for i := range members {
+		keys[i] = memberKey(&members[i])
+	}
+	sort.Sort(membersByKey{members: members, keys: keys})
+	if options.Limit == 0 && options.Continue == "" {
+		result.TotalPages, result.CurrentPage = 1, 1
+		return result, nil
+	}
+	result.TotalPages = 1
+	if options.Limit > 0 {
+		result.TotalPages = (int64(len(members)) + options.Limit - 1) / options.Limit
+	}
+	if options.Continue == "" && options.Page > 0 {
+		result.CurrentPage = options.Page
+		if result.TotalPages >= options.Page {
+			beginMember := (options.Page - 1) * options.Limit
+			result.Members = members[beginMember:]
+			if options.Limit < int64(len(result.Members)) {
+				result.Members = result.Members[:options.Limit]
+			}
+		} else {
+			// Returns empty memberList if page number exceeds total pages, to indicate end of list.
+			result.Members = members[:0]
+		}
+		return result, nil
+	}
+	begin := sort.SearchStrings(keys, lastKey)
+	if begin < len(keys) && keys[begin] == lastKey {
+		begin++
+	}
+	result.Members = members[begin:]
+	if options.Limit > 0 && options.Limit < int64(len(result.Members)) {
+		result.Members = result.Members[:options.Limit]
+		result.Continue = base64.RawURLEncoding.EncodeToString([]byte(keys[begin+int(options.Limit)-1]))
+	}
+	return result, nil
+}
+
+// memberKey returns a key which uniquely identifies the GroupMember within a group, used to sort members and to
+// encode continue tokens.
+func memberKey(member *controlplane.GroupMember) string {
+	switch {
+	case member.Pod != nil:
+		return "Pod/" + member.Pod.Namespace + "/" + member.Pod.Name
+	case member.ExternalEntity != nil:
+		return "ExternalEntity/" + member.ExternalEntity.Namespace + "/" + member.ExternalEntity.Name
+	case member.Node != nil:
+		return "Node/" + member.Node.Name
+	}
+	ips := make([]string, 0, len(member.IPs))
+	for _, ip := range member.IPs {
+		ips = append(ips, ip.String())
+	}
+	return "IP/" + strings.Join(ips, ",")
+}
+
+type membersByKey struct {
+	members []controlplane.GroupMember
+	keys    []string
+}
+
+func (m membersByKey) Len() int           { return len(m.members) }
+func (m membersByKey) Less(i, j int) bool { return m.keys[i] < m.keys[j] }
+func (m membersByKey) Swap(i, j int) {
+	m.members[i], m.members[j] = m.members[j], m.members[i]
+	m.keys[i], m.keys[j] = m.keys[j], m.keys[i]
+}
@@ -16,6 +16,11 @@ package antreanetworkpolicystats
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -23,8 +28,10 @@ import (
 	metatable "k8s.io/apimachinery/pkg/api/meta/table"
 	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
 
@@ -42,6 +49,22 @@ var (
 	}
 )
 
+const (
+	// namePrefixField is the field label selecting the stats of the Antrea NetworkPolicies whose name has the given
+	// prefix.
+	namePrefixField = "metadata.namePrefix"
+	// sortByField is the field label sorting the stats by descending value of the given traffic counter, instead of
+	// by Namespace and name.
+	sortByField = "sortBy"
+)
+
+// trafficStatsGetters maps the values supported by sortByField to the traffic counters they sort by.
+var trafficStatsGetters = map[string]func(stats *statsv1alpha1.TrafficStats) int64{
+	"trafficStats.sessions": func(stats *statsv1alpha1.TrafficStats) int64 { return stats.Sessions },
+	"trafficStats.packets":  func(stats *statsv1alpha1.TrafficStats) int64 { return stats.Packets },
+	"trafficStats.bytes":    func(stats *statsv1alpha1.TrafficStats) int64 { return stats.Bytes },
+}
+
 type REST struct {
 	statsProvider statsProvider
 }
@@ -86,20 +109,113 @@ func (r *REST) List(ctx context.Context, options *internalversion.ListOptions) (
 	if options != nil && options.LabelSelector != nil {
 		labelSelector = options.LabelSelector
 	}
+	fieldSelector := fields.Everything()
+	if options != nil && options.FieldSelector != nil {
+		fieldSelector = options.FieldSelector
+	}
+	fieldSelector, namePrefix, sortBy, err := parseFieldSelector(fieldSelector)
+	if err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+	offset := 0
+	if options != nil && options.Continue != "" {
+		if offset, err = decodeContinue(options.Continue); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("invalid continue token: %v", err))
+		}
+	}
+
 	ns, _ := request.NamespaceFrom(ctx)
 	stats := r.statsProvider.ListAntreaNetworkPolicyStats(ns)
 	items := make([]statsv1alpha1.AntreaNetworkPolicyStats, 0, len(stats))
 	for i := range stats {
-		if labelSelector.Matches(labels.Set(stats[i].Labels)) {
-			items = append(items, stats[i])
+		if !labelSelector.Matches(labels.Set(stats[i].Labels)) {
+			continue
+		}
+		if !fieldSelector.Matches(fields.Set{"metadata.name": stats[i].Name, "metadata.namespace": stats[i].Namespace}) {
+			continue
+		}
+		if !strings.HasPrefix(stats[i].Name, namePrefix) {
+			continue
 		}
+		items = append(items, stats[i])
 	}
-	metricList := &statsv1alpha1.AntreaNetworkPolicyStatsList{
-		Items: items,
+	// The items are always sorted, so that the pages of a paginated list are consistent.
+	sort.Slice(items, func(i, j int) bool {
+		if sortBy != nil {
+			if vi, vj := sortBy(&items[i].TrafficStats), sortBy(&items[j].TrafficStats); vi != vj {
+				return vi > vj
+			}
+		}
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	metricList := &statsv1alpha1.AntreaNetworkPolicyStatsList{}
+	if offset > len(items) {
+		offset = len(items)
+	}
+	items = items[offset:]
+	if options != nil && options.Limit > 0 && int64(len(items)) > options.Limit {
+		remainingItemCount := int64(len(items)) - options.Limit
+		metricList.Continue = encodeContinue(offset + int(options.Limit))
+		metricList.RemainingItemCount = &remainingItemCount
+		items = items[:options.Limit]
 	}
+	metricList.Items = items
 	return metricList, nil
 }
 
+// parseFieldSelector extracts the name prefix and the traffic counter to sort by from the field selector, and returns
+// the selector of the other fields.
+func parseFieldSelector(selector fields.Selector) (fields.Selector, string, func(*statsv1alpha1.TrafficStats) int64, error) {
+	var selectors []fields.Selector
+	var namePrefix string
+	var sortBy func(*statsv1alpha1.TrafficStats) int64
+	for _, requirement := range selector.Requirements() {
+		switch requirement.Field {
+		case namePrefixField, sortByField:
+			if requirement.Operator == selection.NotEquals {
+				return nil, "", nil, fmt.Errorf("field label %s only supports the = and == operators", requirement.Field)
+			}
+			if requirement.Field == namePrefixField {
+				namePrefix = requirement.Value
+			} else if sortBy = trafficStatsGetters[requirement.Value]; sortBy == nil {
+				return nil, "", nil, fmt.Errorf("unsupported value for field label %s: %s", sortByField, requirement.Value)
+			}
+		default:
+			if requirement.Operator == selection.NotEquals {
+				selectors = append(selectors, fields.OneTermNotEqualSelector(requirement.Field, requirement.Value))
+			} else {
+				selectors = append(selectors, fields.OneTermEqualSelector(requirement.Field, requirement.Value))
+			}
+		}
+	}
+	return fields.AndSelectors(selectors...), namePrefix, sortBy, nil
+}
+
+// encodeContinue returns the continue token of a list starting at the given offset.
+func encodeContinue(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// decodeContinue returns the offset encoded in a continue token.
+func decodeContinue(token string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, err
+	}
+	offset, err := strconv.Atoi(string(data))
+	if err != nil {
+		return 0, err
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("negative offset %d", offset)
+	}
+	return offset, nil
+}
+
 func (r *REST) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
 	if !features.DefaultFeatureGate.Enabled(features.NetworkPolicyStats) {
 		return &statsv1alpha1.AntreaNetworkPolicyStats{}, nil
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/endpoints/request"
@@ -342,6 +343,70 @@ func TestRESTList(t *testing.T) {
 	}
 }
 
+func TestRESTListWithFieldSelectorAndPagination(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.NetworkPolicyStats, true)()
+	defer featuregatetesting.SetFeatureGateDuringTest(t, features.DefaultFeatureGate, features.AntreaPolicy, true)()
+
+	newStats := func(namespace, name string, bytes int64) statsv1alpha1.AntreaNetworkPolicyStats {
+		return statsv1alpha1.AntreaNetworkPolicyStats{
+			ObjectMeta:   metav1.ObjectMeta{Namespace: namespace, Name: name},
+			TrafficStats: statsv1alpha1.TrafficStats{Bytes: bytes},
+		}
+	}
+	webA := newStats("foo", "web-a", 100)
+	webB := newStats("foo", "web-b", 300)
+	db := newStats("foo", "db", 200)
+	webC := newStats("bar", "web-c", 50)
+	r := &REST{
+		statsProvider: &fakeStatsProvider{stats: map[string]map[string]statsv1alpha1.AntreaNetworkPolicyStats{
+			"foo": {"web-a": webA, "web-b": webB, "db": db},
+			"bar": {"web-c": webC},
+		}},
+	}
+	list := func(fieldSelector string, limit int64, continueToken string) (*statsv1alpha1.AntreaNetworkPolicyStatsList, error) {
+		selector, err := fields.ParseSelector(fieldSelector)
+		require.NoError(t, err)
+		obj, err := r.List(request.WithNamespace(context.TODO(), ""), &internalversion.ListOptions{FieldSelector: selector, Limit: limit, Continue: continueToken})
+		if err != nil {
+			return nil, err
+		}
+		return obj.(*statsv1alpha1.AntreaNetworkPolicyStatsList), nil
+	}
+
+	// The stats are sorted by Namespace and name by default.
+	result, err := list("", 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, []statsv1alpha1.AntreaNetworkPolicyStats{webC, db, webA, webB}, result.Items)
+	assert.Empty(t, result.Continue)
+
+	result, err = list("metadata.namespace=foo,metadata.namePrefix=web-", 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, []statsv1alpha1.AntreaNetworkPolicyStats{webA, webB}, result.Items)
+
+	result, err = list("metadata.name!=db,sortBy=trafficStats.bytes", 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, []statsv1alpha1.AntreaNetworkPolicyStats{webB, webA, webC}, result.Items)
+
+	// The top-2 policies by traffic volume, then the next page.
+	result, err = list("sortBy=trafficStats.bytes", 2, "")
+	require.NoError(t, err)
+	assert.Equal(t, []statsv1alpha1.AntreaNetworkPolicyStats{webB, db}, result.Items)
+	require.NotEmpty(t, result.Continue)
+	assert.Equal(t, int64(2), *result.RemainingItemCount)
+	result, err = list("sortBy=trafficStats.bytes", 2, result.Continue)
+	require.NoError(t, err)
+	assert.Equal(t, []statsv1alpha1.AntreaNetworkPolicyStats{webA, webC}, result.Items)
+	assert.Empty(t, result.Continue)
+	assert.Nil(t, result.RemainingItemCount)
+
+	_, err = list("sortBy=trafficStats.foo", 0, "")
+	assert.Error(t, err)
+	_, err = list("metadata.namePrefix!=web-", 0, "")
+	assert.Error(t, err)
+	_, err = list("", 2, "invalid")
+	assert.Error(t, err)
+}
+
 func TestRESTConvertToTable(t *testing.T) {
 	stats := &statsv1alpha1.AntreaNetworkPolicyStats{
 		ObjectMeta: metav1.ObjectMeta{
@@ -269,6 +269,10 @@ func (r *supportBundleREST) collectAgent(ctx context.Context, since string) (*sy
 		dumper.DumpAgentInfo,
 		dumper.DumpHeapPprof,
 		dumper.DumpOVSPorts,
+		dumper.DumpOVSGroups,
+		dumper.DumpOVSMeters,
+		dumper.DumpProxierState,
+		dumper.DumpNetworkPolicyRealization,
 		dumper.DumpMemberlist,
 	)
 }
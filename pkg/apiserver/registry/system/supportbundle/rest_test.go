@@ -237,6 +237,22 @@ func (f *fakeAgentDumper) DumpOVSPorts(basedir string) error {
 	return f.returnErr
 }
 
+func (f *fakeAgentDumper) DumpOVSGroups(basedir string) error {
+	return f.returnErr
+}
+
+func (f *fakeAgentDumper) DumpOVSMeters(basedir string) error {
+	return f.returnErr
+}
+
+func (f *fakeAgentDumper) DumpProxierState(basedir string) error {
+	return f.returnErr
+}
+
+func (f *fakeAgentDumper) DumpNetworkPolicyRealization(basedir string) error {
+	return f.returnErr
+}
+
 func (f *fakeAgentDumper) DumpMemberlist(basedir string) error {
 	return f.returnErr
 }
@@ -0,0 +1,40 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta2
+
+import (
+	"context"
+
+	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+	"antrea.io/antrea/pkg/client/clientset/versioned/scheme"
+)
+
+// The ClusterGroupMembersExpansion interface allows manually adding extra methods to the ClusterGroupMembersInterface.
+type ClusterGroupMembersExpansion interface {
+	// GetWithPagination retrieves a chunk of the members of a ClusterGroup according to the provided pagination
+	// options.
+	GetWithPagination(ctx context.Context, name string, options *v1beta2.PaginationGetOptions) (*v1beta2.ClusterGroupMembers, error)
+}
+
+func (c *clusterGroupMembers) GetWithPagination(ctx context.Context, name string, options *v1beta2.PaginationGetOptions) (*v1beta2.ClusterGroupMembers, error) {
+	result := &v1beta2.ClusterGroupMembers{}
+	err := c.client.Get().
+		Resource("clustergroupmembers").
+		Name(name).
+		VersionedParams(options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
@@ -31,6 +31,7 @@ type ControlplaneV1beta2Interface interface {
 	ClusterGroupMembersGetter
 	EgressGroupsGetter
 	GroupAssociationsGetter
+	GroupMembersGetter
 	IPGroupAssociationsGetter
 	NetworkPoliciesGetter
 	NodeStatsSummariesGetter
@@ -62,6 +63,10 @@ func (c *ControlplaneV1beta2Client) GroupAssociations(namespace string) GroupAss
 	return newGroupAssociations(c, namespace)
 }
 
+func (c *ControlplaneV1beta2Client) GroupMembers(namespace string) GroupMembersInterface {
+	return newGroupMembers(c, namespace)
+}
+
 func (c *ControlplaneV1beta2Client) IPGroupAssociations() IPGroupAssociationInterface {
 	return newIPGroupAssociations(c)
 }
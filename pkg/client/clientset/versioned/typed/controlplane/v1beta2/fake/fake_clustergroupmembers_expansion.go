@@ -0,0 +1,35 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fake
+
+import (
+	"context"
+
+	"k8s.io/client-go/testing"
+
+	"antrea.io/antrea/pkg/apis/controlplane/v1beta2"
+)
+
+func (c *FakeClusterGroupMembers) GetWithPagination(ctx context.Context, name string, options *v1beta2.PaginationGetOptions) (*v1beta2.ClusterGroupMembers, error) {
+	action := testing.GenericActionImpl{
+		ActionImpl: testing.ActionImpl{Verb: "get", Resource: clustergroupmembersResource},
+		Value:      options,
+	}
+	obj, err := c.Fake.Invokes(action, &v1beta2.ClusterGroupMembers{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta2.ClusterGroupMembers), err
+}
@@ -20,8 +20,6 @@ type AddressGroupExpansion interface{}
 
 type AppliedToGroupExpansion interface{}
 
-type ClusterGroupMembersExpansion interface{}
-
 type EgressGroupExpansion interface{}
 
 type GroupAssociationExpansion interface{}
@@ -31,6 +31,7 @@ type CrdV1alpha1Interface interface {
 	NetworkPoliciesGetter
 	SupportBundleCollectionsGetter
 	TiersGetter
+	TierEntitlementsGetter
 	TraceflowsGetter
 }
 
@@ -59,6 +60,10 @@ func (c *CrdV1alpha1Client) Tiers() TierInterface {
 	return newTiers(c)
 }
 
+func (c *CrdV1alpha1Client) TierEntitlements() TierEntitlementInterface {
+	return newTierEntitlements(c)
+}
+
 func (c *CrdV1alpha1Client) Traceflows() TraceflowInterface {
 	return newTraceflows(c)
 }
@@ -46,6 +46,10 @@ func (c *FakeCrdV1alpha1) Tiers() v1alpha1.TierInterface {
 	return &FakeTiers{c}
 }
 
+func (c *FakeCrdV1alpha1) TierEntitlements() v1alpha1.TierEntitlementInterface {
+	return &FakeTierEntitlements{c}
+}
+
 func (c *FakeCrdV1alpha1) Traceflows() v1alpha1.TraceflowInterface {
 	return &FakeTraceflows{c}
 }
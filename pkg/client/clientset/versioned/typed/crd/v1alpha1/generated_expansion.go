@@ -26,4 +26,6 @@ type SupportBundleCollectionExpansion interface{}
 
 type TierExpansion interface{}
 
+type TierEntitlementExpansion interface{}
+
 type TraceflowExpansion interface{}
@@ -32,6 +32,7 @@ type CrdV1alpha2Interface interface {
 	ExternalIPPoolsGetter
 	IPPoolsGetter
 	TrafficControlsGetter
+	TrafficMirrorsGetter
 }
 
 // CrdV1alpha2Client is used to interact with features provided by the crd.antrea.io group.
@@ -63,6 +64,10 @@ func (c *CrdV1alpha2Client) TrafficControls() TrafficControlInterface {
 	return newTrafficControls(c)
 }
 
+func (c *CrdV1alpha2Client) TrafficMirrors() TrafficMirrorInterface {
+	return newTrafficMirrors(c)
+}
+
 // NewForConfig creates a new CrdV1alpha2Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
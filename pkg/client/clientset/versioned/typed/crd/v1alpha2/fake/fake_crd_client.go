@@ -50,6 +50,10 @@ func (c *FakeCrdV1alpha2) TrafficControls() v1alpha2.TrafficControlInterface {
 	return &FakeTrafficControls{c}
 }
 
+func (c *FakeCrdV1alpha2) TrafficMirrors() v1alpha2.TrafficMirrorInterface {
+	return &FakeTrafficMirrors{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeCrdV1alpha2) RESTClient() rest.Interface {
@@ -27,3 +27,5 @@ type ExternalIPPoolExpansion interface{}
 type IPPoolExpansion interface{}
 
 type TrafficControlExpansion interface{}
+
+type TrafficMirrorExpansion interface{}
@@ -32,6 +32,8 @@ type Interface interface {
 	SupportBundleCollections() SupportBundleCollectionInformer
 	// Tiers returns a TierInformer.
 	Tiers() TierInformer
+	// TierEntitlements returns a TierEntitlementInformer.
+	TierEntitlements() TierEntitlementInformer
 	// Traceflows returns a TraceflowInformer.
 	Traceflows() TraceflowInformer
 }
@@ -72,6 +74,11 @@ func (v *version) Tiers() TierInformer {
 	return &tierInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// TierEntitlements returns a TierEntitlementInformer.
+func (v *version) TierEntitlements() TierEntitlementInformer {
+	return &tierEntitlementInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Traceflows returns a TraceflowInformer.
 func (v *version) Traceflows() TraceflowInformer {
 	return &traceflowInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -34,6 +34,8 @@ type Interface interface {
 	IPPools() IPPoolInformer
 	// TrafficControls returns a TrafficControlInformer.
 	TrafficControls() TrafficControlInformer
+	// TrafficMirrors returns a TrafficMirrorInformer.
+	TrafficMirrors() TrafficMirrorInformer
 }
 
 type version struct {
@@ -76,3 +78,8 @@ func (v *version) IPPools() IPPoolInformer {
 func (v *version) TrafficControls() TrafficControlInformer {
 	return &trafficControlInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// TrafficMirrors returns a TrafficMirrorInformer.
+func (v *version) TrafficMirrors() TrafficMirrorInformer {
+	return &trafficMirrorInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
@@ -62,6 +62,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().NetworkPolicies().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("supportbundlecollections"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().SupportBundleCollections().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("tierentitlements"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().TierEntitlements().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("tiers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha1().Tiers().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("traceflows"):
@@ -80,6 +82,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha2().IPPools().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("trafficcontrols"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha2().TrafficControls().Informer()}, nil
+	case v1alpha2.SchemeGroupVersion.WithResource("trafficmirrors"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Crd().V1alpha2().TrafficMirrors().Informer()}, nil
 
 		// Group=crd.antrea.io, Version=v1alpha3
 	case v1alpha3.SchemeGroupVersion.WithResource("clustergroups"):
@@ -44,6 +44,10 @@ type SupportBundleCollectionListerExpansion interface{}
 // TierLister.
 type TierListerExpansion interface{}
 
+// TierEntitlementListerExpansion allows custom methods to be added to
+// TierEntitlementLister.
+type TierEntitlementListerExpansion interface{}
+
 // TraceflowListerExpansion allows custom methods to be added to
 // TraceflowLister.
 type TraceflowListerExpansion interface{}
@@ -43,3 +43,7 @@ type IPPoolListerExpansion interface{}
 // TrafficControlListerExpansion allows custom methods to be added to
 // TrafficControlLister.
 type TrafficControlListerExpansion interface{}
+
+// TrafficMirrorListerExpansion allows custom methods to be added to
+// TrafficMirrorLister.
+type TrafficMirrorListerExpansion interface{}
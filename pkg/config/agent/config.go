@@ -33,14 +33,27 @@ type AgentConfig struct {
 	// Make sure it doesn't conflict with your existing OpenVSwitch bridges.
 	// Defaults to br-int.
 	OVSBridge string `yaml:"ovsBridge,omitempty"`
-	// Datapath type to use for the OpenVSwitch bridge created by Antrea. At the moment, the only supported
-	// value is 'system', which corresponds to the kernel datapath.
+	// Name of a second OpenVSwitch bridge antrea-agent will create and use to connect the uplink interface
+	// of the Node, in order to keep the infrastructure traffic (e.g. host management traffic) on this bridge
+	// while the Pod traffic is forwarded by OVSBridge. The two bridges are connected with a pair of OVS patch
+	// ports. Only supported on Linux Nodes, and cannot be used together with AntreaFlexibleIPAM.
+	// Defaults to "", which means that no such bridge is created.
+	OVSPhysicalBridge string `yaml:"ovsPhysicalBridge,omitempty"`
+	// Datapath type to use for the OpenVSwitch bridge created by Antrea. Supported values are:
+	// - system: the kernel datapath.
+	// - netdev: the userspace datapath, with which the Pod and uplink interfaces are accessed through AF_XDP
+	//   sockets. Only supported on Linux Nodes, and requires OVS to be built with AF_XDP support.
+	// Defaults to "system".
 	OVSDatapathType string `yaml:"ovsDatapathType,omitempty"`
 	// Runtime data directory used by Open vSwitch.
 	// Default value:
 	// - On Linux platform: /var/run/openvswitch
 	// - On Windows platform: C:\openvswitch\var\run\openvswitch
 	OVSRunDir string `yaml:"ovsRunDir,omitempty"`
+	// Configuration of the OpenFlow connection to the OVS bridge, when it is managed by a remote OVS instance, e.g.
+	// running on the VM host or on a DPU. By default, antrea-agent connects to the local OVS bridge via the unix
+	// socket under ovsRunDir.
+	OVSOpenFlowConnection OVSOpenFlowConnectionConfig `yaml:"ovsOpenFlowConnection,omitempty"`
 	// Name of the interface antrea-agent will create and use for host <--> pod communication.
 	// Make sure it doesn't conflict with your existing interfaces.
 	// Defaults to antrea-gw0.
@@ -61,6 +74,14 @@ type AgentConfig struct {
 	// the external network needs not be SNAT'd. In the networkPolicyOnly mode, antrea-agent never
 	// performs SNAT and this option will be ignored; for other modes it must be set to false.
 	NoSNAT bool `yaml:"noSNAT,omitempty"`
+	// The backend used to program the host firewall rules, e.g. the masquerade, NodePort and
+	// skip-conntrack rules. This option is for Linux Nodes only. Supported values:
+	// - auto (default): nftables is used if Antrea used the nftables backend previously on the Node
+	//                   or if iptables is not available, otherwise iptables is used.
+	// - iptables:       iptables and ipset are used.
+	// - nftables:       nftables is used. It's not supported on EKS.
+	// When the backend is switched, the rules installed by the previous backend are removed.
+	FirewallBackend string `yaml:"firewallBackend,omitempty"`
 	// Tunnel protocols used for encapsulating traffic across Nodes. Supported values:
 	// - geneve (default)
 	// - vxlan
@@ -123,6 +144,11 @@ type AgentConfig struct {
 	// datapath doesn't support TX checksum offloading, which causes packets to be dropped due to bad checksum.
 	// It affects Pods running on Linux Nodes only.
 	DisableTXChecksumOffload bool `yaml:"disableTXChecksumOffload,omitempty"`
+	// TTL related configurations of the Pod traffic routed by OVS.
+	TTL TTLConfig `yaml:"ttl,omitempty"`
+	// Reverse path filtering (rp_filter) exceptions managed by antrea-agent. This is for Linux Nodes
+	// only.
+	RPFilterExceptions RPFilterExceptionsConfig `yaml:"rpFilterExceptions,omitempty"`
 	// APIPort is the port for the antrea-agent APIServer to serve on.
 	// Defaults to 10350.
 	APIPort int `yaml:"apiPort,omitempty"`
@@ -147,6 +173,8 @@ type AgentConfig struct {
 	NodePortLocal NodePortLocalConfig `yaml:"nodePortLocal,omitempty"`
 	// FlowExporter configuration options.
 	FlowExporter FlowExporterConfig `yaml:"flowExporter,omitempty"`
+	// OTelMetricsExporter configuration options.
+	OTelMetricsExporter OTelMetricsExporterConfig `yaml:"otelMetricsExporter,omitempty"`
 	// Provide the address of Kubernetes apiserver, to override any value provided in kubeconfig or InClusterConfig.
 	// It is typically used when kube-proxy is not deployed (replaced by AntreaProxy).
 	// Defaults to "". It must be a host string, a host:port pair, or a URL to the base of the apiserver.
@@ -196,6 +224,25 @@ type AgentConfig struct {
 	ExternalNode ExternalNodeConfig `yaml:"externalNode,omitempty"`
 	// Antrea's native secondary network configuration.
 	SecondaryNetwork SecondaryNetworkConfig `yaml:"secondaryNetwork,omitempty"`
+	// ReconcileScheduler related configurations.
+	ReconcileScheduler ReconcileSchedulerConfig `yaml:"reconcileScheduler,omitempty"`
+	// Watchdog related configurations.
+	Watchdog WatchdogConfig `yaml:"watchdog,omitempty"`
+	// FlowGC related configurations.
+	FlowGC FlowGCConfig `yaml:"flowGC,omitempty"`
+	// Soft limits of the number of OpenFlow entries installed by antrea-agent.
+	OVSSoftLimits OVSSoftLimitsConfig `yaml:"ovsSoftLimits,omitempty"`
+	// The log verbosity of antrea-agent. It overrides the "--v" command-line flag when set.
+	// It can be updated without restarting antrea-agent.
+	LogVerbosity *int `yaml:"logVerbosity,omitempty"`
+	// The maximum number of packet-in messages processed per second for each feature relying on
+	// packet-in (e.g. NetworkPolicy audit logging, Traceflow, DNS interception for FQDN policies).
+	// It can be updated without restarting antrea-agent. Defaults to 100.
+	PacketInRate int `yaml:"packetInRate,omitempty"`
+	// Audit logging related configurations of Antrea-native policies.
+	AuditLogging AuditLoggingConfig `yaml:"auditLogging,omitempty"`
+	// NodeLatencyMonitor related configurations.
+	NodeLatencyMonitor NodeLatencyMonitorConfig `yaml:"nodeLatencyMonitor,omitempty"`
 }
 
 type AntreaProxyConfig struct {
@@ -203,13 +250,39 @@ type AntreaProxyConfig struct {
 	// regardless of where they come from. Therefore, running kube-proxy is no longer required. This requires the AntreaProxy
 	// feature to be enabled.
 	ProxyAll bool `yaml:"proxyAll,omitempty"`
+	// HostClusterIPAccess tells antrea-agent to install the routes and iptables rules required for the traffic from the
+	// host network namespace to ClusterIPs to be load-balanced by AntreaProxy, without proxying NodePort and
+	// LoadBalancer traffic. It allows host processes to access ClusterIPs when kube-proxy is not running. It is implied
+	// by ProxyAll. This requires the AntreaProxy feature to be enabled.
+	HostClusterIPAccess bool `yaml:"hostClusterIPAccess,omitempty"`
+	// When ProxyAll is enabled, antrea-agent checks periodically whether kube-proxy is running on the Node with a
+	// configuration conflicting with AntreaProxy, and reports it with the KubeProxyCompatible condition of the
+	// AntreaAgentInfo. When InstallKubeProxyCompatibilityRules is set to true, antrea-agent also configures the Node to
+	// resolve the conflicts when possible, e.g. it enables strict ARP when kube-proxy runs in IPVS mode without
+	// strictARP. Defaults to false.
+	InstallKubeProxyCompatibilityRules bool `yaml:"installKubeProxyCompatibilityRules,omitempty"`
 	// A string array of values which specifies the host IPv4/IPv6 addresses for NodePorts. Values may be valid IP blocks.
 	// (e.g. 1.2.3.0/24, 1.2.3.4/32). An empty string slice is meant to select all host IPv4/IPv6 addresses.
 	NodePortAddresses []string `yaml:"nodePortAddresses,omitempty"`
+	// A list of host network interfaces whose IPv4/IPv6 addresses are used for NodePorts, in addition to the
+	// addresses selected by NodePortAddresses. Each item selects the interfaces whose names match a regular
+	// expression, and can be restricted to one IP family, e.g. only the IPv4 addresses of eth0 and only the IPv6
+	// addresses of eth1. The NodePort addresses are updated when the addresses of the interfaces change.
+	NodePortInterfaces []NodePortInterface `yaml:"nodePortInterfaces,omitempty"`
 	// An array of string values to specify a list of Services which should be ignored by AntreaProxy (traffic to these
 	// Services will not be load-balanced). Values can be a valid ClusterIP (e.g. 10.11.1.2) or a Service name
 	// with Namespace (e.g. kube-system/kube-dns)
 	SkipServices []string `yaml:"skipServices,omitempty"`
+	// The name of a ConfigMap in the Antrea Namespace listing Services which should be ignored by AntreaProxy, in
+	// addition to the ones specified with SkipServices. Its "services" key holds ClusterIPs or Service names with
+	// Namespace, one per line, and its "selector" key holds a label selector of the Services to skip. AntreaProxy
+	// uninstalls and reinstalls the flows of Services when they are added to or removed from the ConfigMap, without
+	// restarting the Antrea Agent. Defaults to "", which means no ConfigMap is watched.
+	SkipServicesConfigMap string `yaml:"skipServicesConfigMap,omitempty"`
+	// An array of Service protocols which should be ignored by AntreaProxy, so that the Service ports with these
+	// protocols can be handled by kube-proxy instead. Supported values are "TCP", "UDP" and "SCTP". kube-proxy must
+	// be running when it is set, otherwise traffic to these Service ports will not be load-balanced.
+	SkipServiceProtocols []string `yaml:"skipServiceProtocols,omitempty"`
 	// When ProxyLoadBalancerIPs is set to false, AntreaProxy no longer load-balances traffic destined to the
 	// External IPs of LoadBalancer Services. This is useful when the external LoadBalancer provides additional
 	// capabilities (e.g. TLS termination) and it is desirable for Pod-to-ExternalIP traffic to be sent to the
@@ -222,6 +295,157 @@ type AntreaProxyConfig struct {
 	// AntreaProxy only handles the Service objects matching this label. The default value is empty string, which
 	// means that AntreaProxy will manage all Service objects without the mentioned label.
 	ServiceProxyName string `yaml:"serviceProxyName,omitempty"`
+	// The grace period during which an Endpoint removed from a Service is kept in the Service's OpenFlow group with
+	// zero weight, so that it doesn't receive new connections while its established connections can complete. Its
+	// flows are uninstalled after the grace period. Endpoints are removed immediately when it's set to "0s".
+	// Defaults to "0s". Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	EndpointDrainingTimeout string `yaml:"endpointDrainingTimeout,omitempty"`
+	// The key of an EndpointSlice annotation providing a custom readiness condition for the Endpoints of the
+	// EndpointSlice, e.g. set by an operator or a readiness controller to drain the traffic of an Endpoint without
+	// editing its Pod. The value of the annotation must be a JSON object mapping Endpoint addresses to booleans, and
+	// the Endpoints whose condition is false are considered as neither ready nor serving by AntreaProxy. Endpoints
+	// missing from the annotation are not affected. It only takes effect when the EndpointSlice feature is enabled.
+	// Defaults to "", which means no custom condition is honored.
+	EndpointReadinessGate string `yaml:"endpointReadinessGate,omitempty"`
+	// When RejectServicesWithoutEndpoints is set to true, the connections to Services without any available Endpoint
+	// are rejected with a TCP RST packet for TCP, or an ICMP port unreachable packet for other protocols, like
+	// kube-proxy does. Otherwise, the packets are dropped silently and the clients only fail after timing out.
+	// Defaults to true.
+	RejectServicesWithoutEndpoints *bool `yaml:"rejectServicesWithoutEndpoints,omitempty"`
+	// When PreferNUMALocalEndpoints is set to true, AntreaProxy prefers the local Endpoints whose Pods are pinned to
+	// the same NUMA node as the transport interface when selecting the Endpoints of Services using local Endpoints,
+	// and falls back to all local Endpoints if there is none. The NUMA node of a Pod is given by the
+	// "proxy.antrea.io/numa-node" annotation. It's only supported on Linux Nodes whose kernel reports the NUMA
+	// locality of the transport interface. Defaults to false.
+	PreferNUMALocalEndpoints bool `yaml:"preferNUMALocalEndpoints,omitempty"`
+}
+
+type NodePortInterface struct {
+	// A regular expression which must match the whole name of the interfaces, e.g. "eth0" or "eth[0-9]+".
+	Name string `yaml:"name"`
+	// The IP family of the addresses to use, "IPv4" or "IPv6". Both families are used when it is empty.
+	IPFamily string `yaml:"ipFamily,omitempty"`
+}
+
+type ReconcileSchedulerConfig struct {
+	// Enable the scheduler which shares the OVS programming bandwidth among features, so that a
+	// burst of reconcile operations of one feature (e.g. a NetworkPolicy storm) cannot delay the
+	// operations of other features (e.g. Service Endpoint updates) indefinitely.
+	// Defaults to false.
+	Enable bool `yaml:"enable,omitempty"`
+	// The relative weight of each feature. Supported keys are "networkpolicy", "proxy", "egress"
+	// and "multicast". A feature with weight N can run N reconcile operations for every operation
+	// of a feature with weight 1 when both have pending operations. Missing features default to 1.
+	FeatureWeights map[string]int `yaml:"featureWeights,omitempty"`
+	// The maximum time a reconcile operation can wait for its turn. An operation which has waited
+	// longer is executed before all other pending operations, regardless of weights.
+	// Defaults to "2s". Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	StarvationTimeout string `yaml:"starvationTimeout,omitempty"`
+}
+
+type OVSOpenFlowConnectionConfig struct {
+	// The address of the remote OVS instance, in the form "tcp:<host>:<port>" or "ssl:<host>:<port>". The OVS bridge
+	// must be configured to accept controller connections on it, e.g. with "ovs-vsctl set-controller <bridge>
+	// pssl:<port>". Defaults to "", which means the local OVS bridge is used.
+	Address string `yaml:"address,omitempty"`
+	// Path of the CA certificate used to verify the certificate of the remote OVS instance. Required for "ssl".
+	CACertFile string `yaml:"caCertFile,omitempty"`
+	// Paths of the certificate and private key antrea-agent presents to the remote OVS instance. Required for "ssl".
+	CertFile string `yaml:"certFile,omitempty"`
+	KeyFile  string `yaml:"keyFile,omitempty"`
+}
+
+type WatchdogConfig struct {
+	// Enable the watchdog which monitors the memory usage, the installed OVS flow count and the
+	// depth of the NetworkPolicy rule queue of antrea-agent. When any of them exceeds its threshold,
+	// antrea-agent sheds optional work (e.g. the Flow Exporter polls conntrack less often) and
+	// reports a Warning Event on the Node, until all of them drop below 90% of their thresholds.
+	// Defaults to false.
+	Enable bool `yaml:"enable,omitempty"`
+	// The interval at which the monitored resources are checked. Defaults to "10s". Valid time
+	// units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	CheckInterval string `yaml:"checkInterval,omitempty"`
+	// The memory usage threshold, as a Kubernetes quantity (e.g. "1Gi"). It should be lower than
+	// the memory limit of the antrea-agent container. Memory usage is not checked if empty.
+	MemoryThreshold string `yaml:"memoryThreshold,omitempty"`
+	// The threshold of the total number of installed OVS flows. Not checked if 0.
+	FlowCountThreshold int `yaml:"flowCountThreshold,omitempty"`
+	// The threshold of the number of NetworkPolicy rules waiting to be reconciled. Not checked if 0.
+	QueueDepthThreshold int `yaml:"queueDepthThreshold,omitempty"`
+}
+
+type FlowGCConfig struct {
+	// Enable the garbage collector of orphaned OVS flows and groups, i.e. the flows and groups
+	// installed by antrea-agent which are not expected by it anymore, for example because an
+	// operation failed after OVS had been updated. Defaults to false.
+	Enable bool `yaml:"enable,omitempty"`
+	// The interval at which the orphaned flows and groups are collected. Defaults to "10m". Valid
+	// time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	Interval string `yaml:"interval,omitempty"`
+	// Only log and report in metrics the orphaned flows and groups, without deleting them.
+	// Defaults to false.
+	DryRun bool `yaml:"dryRun,omitempty"`
+}
+
+type OVSSoftLimitsConfig struct {
+	// The maximum number of flows in a single OVS table. When the number of flows in a table
+	// exceeds it, antrea-agent logs a warning and reports it in metrics. 0 means no limit.
+	FlowsPerTable int `yaml:"flowsPerTable,omitempty"`
+	// The maximum number of OVS groups. 0 means no limit.
+	Groups int `yaml:"groups,omitempty"`
+	// The maximum number of OVS meters. 0 means no limit.
+	Meters int `yaml:"meters,omitempty"`
+}
+
+type TTLConfig struct {
+	// Decrement the TTL (IPv4) or hop limit (IPv6) of the Pod traffic forwarded across Nodes or
+	// routed by OVS, as a router does. Defaults to true.
+	Decrement *bool `yaml:"decrement,omitempty"`
+	// Reply to the routed Pod packets whose TTL expires with ICMP (or ICMPv6) Time Exceeded
+	// messages from the Antrea gateway IP, instead of dropping them silently, so that tools like
+	// traceroute can report the Nodes on the path. It requires decrement to be true. Defaults to
+	// false.
+	SendTimeExceeded bool `yaml:"sendTimeExceeded,omitempty"`
+}
+
+type RPFilterExceptionsConfig struct {
+	// Enable managing rp_filter exceptions, for Nodes where strict reverse path filtering drops
+	// legitimate asymmetric Service traffic. When enabled, antrea-agent sets rp_filter to loose mode
+	// on the Antrea gateway and tunnel interfaces if they are in strict mode, and routes the virtual
+	// Service IP to the Antrea gateway. When disabled, the original settings are restored. Defaults
+	// to false.
+	Enable bool `yaml:"enable,omitempty"`
+	// Additional interfaces whose rp_filter should be set to loose mode, e.g. the interfaces
+	// receiving NodePort or LoadBalancer traffic whose replies are routed through another interface.
+	ExtraInterfaces []string `yaml:"extraInterfaces,omitempty"`
+}
+
+type NodeLatencyMonitorConfig struct {
+	// The interval at which the other Nodes are probed, when the NodeLatencyMonitor feature is
+	// enabled. A probe which is not answered before the next probe is sent is considered lost.
+	// Defaults to "60s". Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	PingInterval string `yaml:"pingInterval,omitempty"`
+}
+
+type AuditLoggingConfig struct {
+	// The options of the rotation of the audit log file, which can be updated without restarting
+	// antrea-agent.
+	// The maximum size in megabytes of the log file before it gets rotated. Defaults to 500.
+	MaxSize int `yaml:"maxSize,omitempty"`
+	// The maximum number of old log files to retain. If set to 0, all log files are retained.
+	// Defaults to 3.
+	MaxBackups *int `yaml:"maxBackups,omitempty"`
+	// The maximum number of days to retain old log files. If set to 0, old log files are not
+	// removed based on their age. Defaults to 28.
+	MaxAge *int `yaml:"maxAge,omitempty"`
+	// Whether the rotated log files are compressed with gzip. Defaults to true.
+	Compress *bool `yaml:"compress,omitempty"`
+	// The minimum severity of the Antrea-native policies for which a Kubernetes Event is emitted
+	// on the Pod when one of their rules denies a connection. The severity of a policy is set with
+	// the "networkpolicy.antrea.io/audit-log-severity" annotation. Valid values are "Low",
+	// "Medium", "High" and "Critical". If empty, no Event is emitted. Changing this option
+	// requires restarting antrea-agent.
+	EventSeverityThreshold string `yaml:"eventSeverityThreshold,omitempty"`
 }
 
 type WireGuardConfig struct {
@@ -281,6 +505,60 @@ type FlowExporterConfig struct {
 	// Defaults to "15s". Valid time units are "ns", "us" (or "µs"), "ms", "s",
 	// "m", "h".
 	IdleFlowExportTimeout string `yaml:"idleFlowExportTimeout,omitempty"`
+	// Provide the format of the flow records sent to the collector. Supported
+	// formats are "IPFIX", "NetFlowV9" and "sFlow". "NetFlowV9" and "sFlow" are
+	// meant for the collectors which don't support IPFIX: they require the "udp"
+	// transport protocol, and the Antrea-specific fields which cannot be
+	// represented in these formats, e.g. the Pod names, are not exported. The
+	// Flow Aggregator only supports "IPFIX".
+	// Defaults to "IPFIX".
+	RecordFormat string `yaml:"recordFormat,omitempty"`
+	// Filter the connections exported to the collector.
+	ExportFilter FlowExportFilterConfig `yaml:"exportFilter,omitempty"`
+	// Estimate the smoothed RTT of TCP connections from the duration of their
+	// handshakes, and export it in flow records. It relies on conntrack events,
+	// so it is only supported on Linux with the OVS kernel datapath.
+	// Defaults to false.
+	EstimateTCPRTT bool `yaml:"estimateTCPRTT,omitempty"`
+}
+
+// FlowExportFilterConfig selects the connections exported by the FlowExporter. A connection is exported if its
+// protocol is selected, and if its source or destination Pod is selected. All connections are exported by default.
+// The "flowexporter.antrea.io/export" annotation of a Namespace can be set to "true" or "false" to include or exclude
+// the Namespace regardless of includeNamespaces and excludeNamespaces.
+type OTelMetricsExporterConfig struct {
+	// Enable pushing the Antrea Agent metrics to an OpenTelemetry collector periodically, using
+	// OTLP over HTTP. The exported metrics are the ones exposed via Prometheus, so
+	// enablePrometheusMetrics must be true.
+	Enable bool `yaml:"enable,omitempty"`
+	// The URL of the OTLP/HTTP endpoint of the collector, e.g. "http://otel-collector.otel:4318".
+	// "/v1/metrics" is used as the path if the URL doesn't include one. Both "http" and "https"
+	// schemes are supported.
+	Endpoint string `yaml:"endpoint,omitempty"`
+	// Provide the interval between two exports as a duration string.
+	// Defaults to "60s". Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
+	ExportInterval string `yaml:"exportInterval,omitempty"`
+	// Headers added to every export request, e.g. to provide an API key expected by the collector.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Path to a file containing a bearer token, which is sent in the Authorization header of every
+	// export request. The file is read before each export, so the token can be rotated.
+	BearerTokenFile string `yaml:"bearerTokenFile,omitempty"`
+	// Path to a PEM-encoded CA bundle used to verify the certificate of the collector when the
+	// "https" scheme is used. The system CA bundle is used if empty.
+	CAFile string `yaml:"caFile,omitempty"`
+}
+
+type FlowExportFilterConfig struct {
+	// The Namespaces of the Pods whose connections are exported. If empty, all Namespaces are included.
+	IncludeNamespaces []string `yaml:"includeNamespaces,omitempty"`
+	// The Namespaces of the Pods whose connections are not exported. It takes precedence over includeNamespaces.
+	ExcludeNamespaces []string `yaml:"excludeNamespaces,omitempty"`
+	// A label selector in string format, e.g. "app=web,tier!=db", which selects the Pods whose connections are
+	// exported. If empty, Pods are not filtered by labels.
+	PodSelector string `yaml:"podSelector,omitempty"`
+	// The protocols of the exported connections, among "TCP", "UDP", "SCTP", "ICMP", "IPv6-ICMP" and "IGMP". If
+	// empty, connections of all protocols are exported.
+	Protocols []string `yaml:"protocols,omitempty"`
 }
 
 type MulticastConfig struct {
@@ -304,6 +582,20 @@ type EgressConfig struct {
 	// the number of secondary IPs a Node can have, e.g. EKS. It must not be greater than 255.
 	// Defaults to 255.
 	MaxEgressIPsPerNode int `yaml:"maxEgressIPsPerNode,omitempty"`
+	// Configuration to drop the traffic from Pods to the external network by default.
+	DefaultDenyExternal EgressDefaultDenyConfig `yaml:"defaultDenyExternal,omitempty"`
+}
+
+type EgressDefaultDenyConfig struct {
+	// Enable dropping the traffic from Pods to the external network, unless it is SNAT'd by an Egress or allowed by an
+	// Antrea-native policy. When enabled, it applies to the Pods of the Namespaces annotated with
+	// "egress.antrea.io/default-deny-external: true".
+	// Defaults to false.
+	Enable bool `yaml:"enable,omitempty"`
+	// Apply default deny to the Pods of all Namespaces, regardless of the Namespace annotation. It can only be set
+	// when enable is true.
+	// Defaults to false.
+	AllNamespaces bool `yaml:"allNamespaces,omitempty"`
 }
 
 type IPsecConfig struct {
@@ -366,6 +658,17 @@ type SecondaryNetworkConfig struct {
 	OVS SecondaryNetworkOVSConfig `yaml:"ovs,omitempty"`
 	// TunnelType to be used for node to node transport, which is part of the same virtual network.
 	TunnelType string `yaml:"tunnelType,omitempty"`
+	// Configuration of OVS bridges for VLAN secondary networks. At the moment, at most one OVS bridge can be
+	// specified.
+	OVSBridges []OVSBridgeConfig `yaml:"ovsBridges,omitempty"`
+}
+
+type OVSBridgeConfig struct {
+	// Name of the OVS bridge. Antrea creates the bridge if it doesn't exist.
+	BridgeName string `yaml:"bridgeName"`
+	// Names of the physical interfaces connected to the OVS bridge, which carry the VLAN traffic of the secondary
+	// networks across Nodes. At the moment, at most one physical interface can be specified.
+	PhysicalInterfaces []string `yaml:"physicalInterfaces,omitempty"`
 }
 
 type SecondaryNetworkOVSConfig struct {
@@ -75,8 +75,23 @@ type ControllerConfig struct {
 	NodeIPAM NodeIPAMConfig `yaml:"nodeIPAM"`
 	// IPsec CSR signer configuration
 	IPsecCSRSignerConfig IPsecCSRSignerConfig `yaml:"ipsecCSRSigner"`
+	// antrea-agent serving certificate CSR signer configuration
+	AgentServingCSRSignerConfig AgentServingCSRSignerConfig `yaml:"agentServingCSRSigner"`
 	// Multicluster configuration options.
 	Multicluster MulticlusterConfig `yaml:"multicluster,omitempty"`
+	// Per-Node flow budget of Antrea-native policies.
+	NetworkPolicyFlowBudget NetworkPolicyFlowBudgetConfig `yaml:"networkPolicyFlowBudget"`
+}
+
+type NetworkPolicyFlowBudgetConfig struct {
+	// The maximum number of OVS flows that an Antrea-native policy is estimated to install on a
+	// single Node. The estimate is computed when the policy is created or updated, based on its
+	// rules and on the current members of its selectors and groups. Defaults to 0, which disables
+	// the check.
+	MaxFlowsPerNode int `yaml:"maxFlowsPerNode,omitempty"`
+	// The action taken when a policy exceeds maxFlowsPerNode: "Reject" rejects the policy, "Warn"
+	// accepts the policy and returns a warning to the client. Defaults to "Reject".
+	Action string `yaml:"action,omitempty"`
 }
 
 type MulticlusterConfig struct {
@@ -99,3 +114,15 @@ type IPsecCSRSignerConfig struct {
 	// Defaults to true.
 	AutoApprove *bool `yaml:"autoApprove,omitempty"`
 }
+
+type AgentServingCSRSignerConfig struct {
+	// Indicates whether to use auto-generated self-signed CA certificate.
+	// If false, a Secret named "antrea-agent-serving-ca" must be provided with the following keys:
+	//   tls.crt: <CA certificate>
+	//   tls.key: <CA private key>
+	// Defaults to true.
+	SelfSignedCA *bool `yaml:"selfSignedCA,omitempty"`
+	// Antrea signer auto approve policy.
+	// Defaults to true.
+	AutoApprove *bool `yaml:"autoApprove,omitempty"`
+}
@@ -56,6 +56,9 @@ type FlowAggregatorConfig struct {
 	S3Uploader S3UploaderConfig `yaml:"s3Uploader,omitempty"`
 	// FlowLogger contains configuration options for writing flow records to a local log file.
 	FlowLogger FlowLoggerConfig `yaml:"flowLogger,omitempty"`
+	// Rollup contains configuration options for writing periodic rollup records, which summarize
+	// the traffic between workloads, to a local log file.
+	Rollup RollupConfig `yaml:"rollup,omitempty"`
 }
 
 type RecordContentsConfig struct {
@@ -85,7 +88,9 @@ type FlowCollectorConfig struct {
 	// is not available), a value will be randomly generated, which may vary across restarts of the flow
 	// aggregator.
 	ObservationDomainID *uint32 `yaml:"observationDomainID,omitempty"`
-	// Provide format for records sent to the configured flow collector. Supported formats are IPFIX and JSON.
+	// Provide format for records sent to the configured flow collector. Supported formats are IPFIX, JSON,
+	// NetFlowV9 and sFlow. NetFlowV9 and sFlow require the udp transport protocol, and the Antrea-specific
+	// fields which cannot be represented in these formats, e.g. the Pod names, are not exported.
 	// Defaults to "IPFIX"
 	RecordFormat string `yaml:"recordFormat,omitempty"`
 }
@@ -166,6 +171,31 @@ type FlowLoggerConfig struct {
 	PrettyPrint *bool `yaml:"prettyPrint,omitempty"`
 }
 
+type RollupConfig struct {
+	// Enable is the switch to enable writing rollup records to a local log file. A rollup
+	// record aggregates the traffic of all connections between a source workload and a
+	// destination Service or workload over one rollup interval. Rollup records are written in
+	// addition to the per-connection records exported by the other exporters.
+	Enable bool `yaml:"enable,omitempty"`
+	// Interval is the duration of the time window over which connections are aggregated
+	// before a rollup record is written. Defaults to "60s". Valid time units are "ns", "us"
+	// (or "µs"), "ms", "s", "m", "h". Min value allowed is "1s".
+	Interval string `yaml:"interval,omitempty"`
+	// Path is the path to the local log file. Defaults to the antrea-flows-rollup.log file in
+	// the operating system's default directory for temporary files (provided by os.TempDir).
+	Path string `yaml:"path,omitempty"`
+	// MaxSize is the maximum size in MB of a log file before it gets rotated. Defaults to 100MB.
+	MaxSize int32 `yaml:"maxSize,omitempty"`
+	// MaxBackups is the maximum number of old log files to retain. If set to 0, all log files
+	// will be retained (unless MaxAge causes them to be deleted). Defaults to 3.
+	MaxBackups int32 `yaml:"maxBackups,omitempty"`
+	// MaxAge is the maximum number of days to retain old log files based on the timestamp
+	// encoded in their filename. The default (0) is not to remove old log files based on age.
+	MaxAge int32 `yaml:"maxAge,omitempty"`
+	// Compress enables gzip compression on rotated files. Defaults to true.
+	Compress *bool `yaml:"compress,omitempty"`
+}
+
 type NetworkPolicyRuleAction string
 
 const (
@@ -44,6 +44,9 @@ const (
 	DefaultLoggerMaxSize      = 100
 	DefaultLoggerMaxBackups   = 3
 	DefaultLoggerRecordFormat = "CSV"
+
+	DefaultRollupInterval = "60s"
+	MinRollupInterval     = 1 * time.Second
 )
 
 func SetConfigDefaults(flowAggregatorConf *FlowAggregatorConfig) {
@@ -108,4 +111,20 @@ func SetConfigDefaults(flowAggregatorConf *FlowAggregatorConfig) {
 		flowAggregatorConf.FlowLogger.PrettyPrint = new(bool)
 		*flowAggregatorConf.FlowLogger.PrettyPrint = true
 	}
+	if flowAggregatorConf.Rollup.Interval == "" {
+		flowAggregatorConf.Rollup.Interval = DefaultRollupInterval
+	}
+	if flowAggregatorConf.Rollup.Path == "" {
+		flowAggregatorConf.Rollup.Path = filepath.Join(os.TempDir(), "antrea-flows-rollup.log")
+	}
+	if flowAggregatorConf.Rollup.MaxSize == 0 {
+		flowAggregatorConf.Rollup.MaxSize = DefaultLoggerMaxSize
+	}
+	if flowAggregatorConf.Rollup.MaxBackups == 0 {
+		flowAggregatorConf.Rollup.MaxBackups = DefaultLoggerMaxBackups
+	}
+	if flowAggregatorConf.Rollup.Compress == nil {
+		flowAggregatorConf.Rollup.Compress = new(bool)
+		*flowAggregatorConf.Rollup.Compress = true
+	}
 }
@@ -0,0 +1,142 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certificatesigningrequest
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"reflect"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	antreaapis "antrea.io/antrea/pkg/apis"
+)
+
+const (
+	agentServingCSRApproverName = "AntreaAgentServingCSRApprover"
+)
+
+// agentServingCSRApprover approves the CertificateSigningRequests created by antrea-agents for the
+// serving certificates of their API servers. The certificate of an antrea-agent must be issued for
+// the Node it runs on: the common name must be the Node name, and the subjectAltNames are limited
+// to the Node name, the addresses of the Node and the loopback names and addresses.
+type agentServingCSRApprover struct {
+	client clientset.Interface
+}
+
+var agentServingUsages = sets.New[string](
+	string(certificatesv1.UsageDigitalSignature),
+	string(certificatesv1.UsageKeyEncipherment),
+	string(certificatesv1.UsageServerAuth),
+)
+
+var _ approver = (*agentServingCSRApprover)(nil)
+
+func (ac *agentServingCSRApprover) recognize(csr *certificatesv1.CertificateSigningRequest) bool {
+	return csr.Spec.SignerName == antreaapis.AntreaAgentServingCSRSignerName
+}
+
+func (ac *agentServingCSRApprover) verify(csr *certificatesv1.CertificateSigningRequest) (bool, error) {
+	var failedReasons []string
+	cr, err := decodeCertificateRequest(csr.Spec.Request)
+	if err != nil {
+		return false, err
+	}
+	if err := ac.verifyCertificateRequest(cr, csr.Spec.Usages); err != nil {
+		if _, ok := err.(*transientError); ok {
+			return false, err
+		}
+		failedReasons = append(failedReasons, err.Error())
+	}
+	if err := verifyAgentIdentity(ac.client, cr.Subject.CommonName, csr); err != nil {
+		if _, ok := err.(*transientError); ok {
+			return false, err
+		}
+		failedReasons = append(failedReasons, err.Error())
+	}
+
+	if len(failedReasons) > 0 {
+		klog.InfoS("Verifying CertificateSigningRequest for antrea-agent serving certificate failed", "reasons", failedReasons, "CSR", csr.Name)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (ac *agentServingCSRApprover) name() string {
+	return agentServingCSRApproverName
+}
+
+func (ac *agentServingCSRApprover) verifyCertificateRequest(req *x509.CertificateRequest, usages []certificatesv1.KeyUsage) error {
+	if !reflect.DeepEqual(req.Subject.Organization, []string{antreaapis.AntreaOrganizationName}) {
+		return errOrganizationNotAntrea
+	}
+	nodeName := req.Subject.CommonName
+	if nodeName == "" {
+		return errCommonNameRequired
+	}
+	if len(req.URIs) > 0 {
+		return errURISANNotAllowed
+	}
+	if len(req.EmailAddresses) > 0 {
+		return errEmailSANNotAllowed
+	}
+	dnsNames := sets.New[string](req.DNSNames...)
+	if !dnsNames.Has(nodeName) {
+		return errDNSSANNotMatchCommonName
+	}
+	if unexpected := dnsNames.Difference(sets.New[string](nodeName, "localhost")); unexpected.Len() > 0 {
+		return fmt.Errorf("DNS subjectAltNames are not allowed: %v", sets.List(unexpected))
+	}
+	serverAuth := false
+	for _, u := range usages {
+		if !agentServingUsages.Has(string(u)) {
+			return fmt.Errorf("unsupported key usage: %v", u)
+		}
+		if u == certificatesv1.UsageServerAuth {
+			serverAuth = true
+		}
+	}
+	if !serverAuth {
+		return fmt.Errorf("key usage %v is required", certificatesv1.UsageServerAuth)
+	}
+	node, err := ac.client.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	if err != nil && apierrors.IsNotFound(err) {
+		return fmt.Errorf("requested Node %s not found", nodeName)
+	} else if err != nil {
+		return &transientError{err}
+	}
+	nodeIPs := sets.New[string]()
+	for _, addr := range node.Status.Addresses {
+		if addr.Type == corev1.NodeInternalIP || addr.Type == corev1.NodeExternalIP {
+			if ip := net.ParseIP(addr.Address); ip != nil {
+				nodeIPs.Insert(ip.String())
+			}
+		}
+	}
+	for _, ip := range req.IPAddresses {
+		if !ip.IsLoopback() && !nodeIPs.Has(ip.String()) {
+			return fmt.Errorf("IP subjectAltName %s is not an address of Node %s", ip, nodeName)
+		}
+	}
+	return nil
+}
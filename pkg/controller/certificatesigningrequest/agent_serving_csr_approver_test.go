@@ -0,0 +1,156 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certificatesigningrequest
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_verifyAgentServingCSR(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "worker-node-1",
+		},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeInternalIP, Address: "192.168.1.10"},
+				{Type: corev1.NodeInternalIP, Address: "fd00::10"},
+				{Type: corev1.NodeHostName, Address: "worker-node-1"},
+			},
+		},
+	}
+	subject := pkix.Name{
+		Organization: []string{"antrea.io"},
+		CommonName:   "worker-node-1",
+	}
+	servingUsages := []certificatesv1.KeyUsage{
+		certificatesv1.UsageDigitalSignature,
+		certificatesv1.UsageServerAuth,
+	}
+	tests := []struct {
+		name        string
+		cr          *x509.CertificateRequest
+		keyUsages   []certificatesv1.KeyUsage
+		expectedErr error
+	}{
+		{
+			name: "valid CSR",
+			cr: &x509.CertificateRequest{
+				Subject:     subject,
+				DNSNames:    []string{"worker-node-1", "localhost"},
+				IPAddresses: []net.IP{net.ParseIP("127.0.0.1"), net.IPv6loopback, net.ParseIP("192.168.1.10"), net.ParseIP("fd00::10")},
+			},
+			keyUsages: servingUsages,
+		},
+		{
+			name: "invalid organization",
+			cr: &x509.CertificateRequest{
+				Subject: pkix.Name{
+					Organization: []string{"example.com"},
+					CommonName:   "worker-node-1",
+				},
+				DNSNames: []string{"worker-node-1"},
+			},
+			keyUsages:   servingUsages,
+			expectedErr: errOrganizationNotAntrea,
+		},
+		{
+			name: "missing Node name in DNS SANs",
+			cr: &x509.CertificateRequest{
+				Subject:  subject,
+				DNSNames: []string{"localhost"},
+			},
+			keyUsages:   servingUsages,
+			expectedErr: errDNSSANNotMatchCommonName,
+		},
+		{
+			name: "unexpected DNS SAN",
+			cr: &x509.CertificateRequest{
+				Subject:  subject,
+				DNSNames: []string{"worker-node-1", "worker-node-2"},
+			},
+			keyUsages:   servingUsages,
+			expectedErr: fmt.Errorf("DNS subjectAltNames are not allowed: [worker-node-2]"),
+		},
+		{
+			name: "IP SAN not of the Node",
+			cr: &x509.CertificateRequest{
+				Subject:     subject,
+				DNSNames:    []string{"worker-node-1"},
+				IPAddresses: []net.IP{net.ParseIP("192.168.1.11")},
+			},
+			keyUsages:   servingUsages,
+			expectedErr: fmt.Errorf("IP subjectAltName 192.168.1.11 is not an address of Node worker-node-1"),
+		},
+		{
+			name: "unsupported key usage",
+			cr: &x509.CertificateRequest{
+				Subject:  subject,
+				DNSNames: []string{"worker-node-1"},
+			},
+			keyUsages: []certificatesv1.KeyUsage{
+				certificatesv1.UsageServerAuth,
+				certificatesv1.UsageClientAuth,
+			},
+			expectedErr: fmt.Errorf("unsupported key usage: client auth"),
+		},
+		{
+			name: "missing server auth key usage",
+			cr: &x509.CertificateRequest{
+				Subject:  subject,
+				DNSNames: []string{"worker-node-1"},
+			},
+			keyUsages: []certificatesv1.KeyUsage{
+				certificatesv1.UsageDigitalSignature,
+			},
+			expectedErr: fmt.Errorf("key usage server auth is required"),
+		},
+		{
+			name: "Node not found",
+			cr: &x509.CertificateRequest{
+				Subject: pkix.Name{
+					Organization: []string{"antrea.io"},
+					CommonName:   "worker-node-2",
+				},
+				DNSNames: []string{"worker-node-2"},
+			},
+			keyUsages:   servingUsages,
+			expectedErr: fmt.Errorf("requested Node worker-node-2 not found"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ac := &agentServingCSRApprover{
+				client: fake.NewSimpleClientset(node),
+			}
+			err := ac.verifyCertificateRequest(tt.cr, tt.keyUsages)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
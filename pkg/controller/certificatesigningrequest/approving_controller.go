@@ -63,6 +63,9 @@ func NewCSRApprovingController(client clientset.Interface, csrInformer cache.Sha
 			&ipsecCSRApprover{
 				client: client,
 			},
+			&agentServingCSRApprover{
+				client: client,
+			},
 		},
 	}
 	csrInformer.AddEventHandlerWithResyncPeriod(
@@ -50,17 +50,25 @@ import (
 )
 
 const (
-	ipsecRootCAName               = "antrea-ipsec-ca"
-	ipsecCSRSigningControllerName = "IPsecCertificateSigningRequestSigningController"
-	workerItemKey                 = "key"
-	rootCACertKey                 = "ca.crt"
+	ipsecRootCAName                      = "antrea-ipsec-ca"
+	ipsecCSRSigningControllerName        = "IPsecCertificateSigningRequestSigningController"
+	agentServingCSRSigningControllerName = "AgentServingCertificateSigningRequestSigningController"
+	workerItemKey                        = "key"
+	rootCACertKey                        = "ca.crt"
 
 	duration365d = time.Hour * 24 * 365
 	duration10y  = duration365d * 10
 )
 
-// IPsecCSRSigningController is responsible for signing CertificateSigningRequests.
-type IPsecCSRSigningController struct {
+// CSRSigningController is responsible for signing CertificateSigningRequests of a signer with the CA
+// stored in a Secret of the same name as the CA.
+type CSRSigningController struct {
+	name       string
+	signerName string
+	// rootCAName is the name of the Secret storing the CA certificate and key, and of the ConfigMap
+	// publishing the CA certificate.
+	rootCAName string
+
 	client          clientset.Interface
 	csrInformer     cache.SharedIndexInformer
 	csrLister       csrlister.CertificateSigningRequestLister
@@ -108,16 +116,28 @@ func (c *certificateAuthority) signCSR(template *x509.Certificate, requestKey cr
 	return certs[0], nil
 }
 
-// NewIPsecCSRSigningController returns a new *IPsecCSRSigningController.
-func NewIPsecCSRSigningController(client clientset.Interface, csrInformer cache.SharedIndexInformer, csrLister csrlister.CertificateSigningRequestLister, selfSignedCA bool) *IPsecCSRSigningController {
+// NewIPsecCSRSigningController returns a new *CSRSigningController for IPsec certificates.
+func NewIPsecCSRSigningController(client clientset.Interface, csrInformer cache.SharedIndexInformer, csrLister csrlister.CertificateSigningRequestLister, selfSignedCA bool) *CSRSigningController {
+	return newCSRSigningController(ipsecCSRSigningControllerName, antreaapis.AntreaIPsecCSRSignerName, ipsecRootCAName, client, csrInformer, csrLister, selfSignedCA)
+}
+
+// NewAgentServingCSRSigningController returns a new *CSRSigningController for the serving certificates
+// of antrea-agent API servers.
+func NewAgentServingCSRSigningController(client clientset.Interface, csrInformer cache.SharedIndexInformer, csrLister csrlister.CertificateSigningRequestLister, selfSignedCA bool) *CSRSigningController {
+	return newCSRSigningController(agentServingCSRSigningControllerName, antreaapis.AntreaAgentServingCSRSignerName, antreaapis.AntreaAgentServingCAName, client, csrInformer, csrLister, selfSignedCA)
+}
 
+func newCSRSigningController(name, signerName, rootCAName string, client clientset.Interface, csrInformer cache.SharedIndexInformer, csrLister csrlister.CertificateSigningRequestLister, selfSignedCA bool) *CSRSigningController {
 	caConfigMapInformer := corev1informers.NewFilteredConfigMapInformer(client, env.GetAntreaNamespace(), resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, func(listOptions *metav1.ListOptions) {
-		listOptions.FieldSelector = fields.OneTermEqualSelector("metadata.name", ipsecRootCAName).String()
+		listOptions.FieldSelector = fields.OneTermEqualSelector("metadata.name", rootCAName).String()
 	})
 
 	configMapLister := corev1listers.NewConfigMapLister(caConfigMapInformer.GetIndexer())
 
-	c := &IPsecCSRSigningController{
+	c := &CSRSigningController{
+		name:                  name,
+		signerName:            signerName,
+		rootCAName:            rootCAName,
 		client:                client,
 		csrInformer:           csrInformer,
 		csrLister:             csrLister,
@@ -158,17 +178,17 @@ func NewIPsecCSRSigningController(client clientset.Interface, csrInformer cache.
 	return c
 }
 
-// Run begins watching and syncing of the IPsecCSRSigningController.
-func (c *IPsecCSRSigningController) Run(stopCh <-chan struct{}) {
+// Run begins watching and syncing of the CSRSigningController.
+func (c *CSRSigningController) Run(stopCh <-chan struct{}) {
 	defer c.queue.ShutDown()
 
-	klog.Infof("Starting %s", ipsecCSRSigningControllerName)
-	defer klog.Infof("Shutting down %s", ipsecCSRSigningControllerName)
+	klog.Infof("Starting %s", c.name)
+	defer klog.Infof("Shutting down %s", c.name)
 
 	go c.configMapInformer.Run(stopCh)
 
 	cacheSyncs := []cache.InformerSynced{c.csrListerSynced, c.configMapListerSynced}
-	if !cache.WaitForNamedCacheSync(ipsecCSRSigningControllerName, stopCh, cacheSyncs...) {
+	if !cache.WaitForNamedCacheSync(c.name, stopCh, cacheSyncs...) {
 		return
 	}
 	c.fixturesQueue.Add(workerItemKey)
@@ -177,7 +197,7 @@ func (c *IPsecCSRSigningController) Run(stopCh <-chan struct{}) {
 
 	go wait.NonSlidingUntil(func() {
 		if err := c.watchSecretChanges(stopCh); err != nil {
-			klog.ErrorS(err, "Watch Secret error", "secret", ipsecRootCAName)
+			klog.ErrorS(err, "Watch Secret error", "secret", c.rootCAName)
 		}
 	}, time.Second*10, stopCh)
 
@@ -188,24 +208,24 @@ func (c *IPsecCSRSigningController) Run(stopCh <-chan struct{}) {
 	<-stopCh
 }
 
-func (c *IPsecCSRSigningController) syncRootCertificateAndKey() error {
+func (c *CSRSigningController) syncRootCertificateAndKey() error {
 	var caBytes, caKeyBytes []byte
-	caSecret, err := c.client.CoreV1().Secrets(env.GetAntreaNamespace()).Get(context.TODO(), ipsecRootCAName, metav1.GetOptions{})
+	caSecret, err := c.client.CoreV1().Secrets(env.GetAntreaNamespace()).Get(context.TODO(), c.rootCAName, metav1.GetOptions{})
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return err
 		}
 		if !c.selfSignedCA {
-			klog.InfoS("Self-signed CA is disabled. Ensure CA Secret exists", "name", ipsecRootCAName, "namespace", env.GetAntreaNamespace())
+			klog.InfoS("Self-signed CA is disabled. Ensure CA Secret exists", "name", c.rootCAName, "namespace", env.GetAntreaNamespace())
 			return nil
 		}
-		caBytes, caKeyBytes, err = generateSelfSignedRootCertificate(ipsecRootCAName)
+		caBytes, caKeyBytes, err = generateSelfSignedRootCertificate(c.rootCAName)
 		if err != nil {
 			return err
 		}
 		caSecret = &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      ipsecRootCAName,
+				Name:      c.rootCAName,
 				Namespace: env.GetAntreaNamespace(),
 			},
 			Type: corev1.SecretTypeTLS,
@@ -218,7 +238,7 @@ func (c *IPsecCSRSigningController) syncRootCertificateAndKey() error {
 		if err != nil {
 			return err
 		}
-		klog.Info("Created Secret for self-signed IPsec root CA")
+		klog.InfoS("Created Secret for self-signed root CA", "name", c.rootCAName)
 	}
 	caCertificate, err := certutil.ParseCertsPEM(caSecret.Data[corev1.TLSCertKey])
 	if err != nil {
@@ -245,14 +265,14 @@ func (c *IPsecCSRSigningController) syncRootCertificateAndKey() error {
 	desiredConfigMapData := map[string]string{
 		rootCACertKey: string(caSecret.Data[corev1.TLSCertKey]),
 	}
-	caConfigMap, err := c.configMapLister.ConfigMaps(env.GetAntreaNamespace()).Get(ipsecRootCAName)
+	caConfigMap, err := c.configMapLister.ConfigMaps(env.GetAntreaNamespace()).Get(c.rootCAName)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return err
 		}
 		caConfigMap = &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      ipsecRootCAName,
+				Name:      c.rootCAName,
 				Namespace: env.GetAntreaNamespace(),
 			},
 			Data: desiredConfigMapData,
@@ -261,7 +281,7 @@ func (c *IPsecCSRSigningController) syncRootCertificateAndKey() error {
 		if err != nil {
 			return err
 		}
-		klog.InfoS("Created ConfigMap for self-signed IPsec root CA")
+		klog.InfoS("Created ConfigMap for self-signed root CA", "name", c.rootCAName)
 	}
 	if !reflect.DeepEqual(desiredConfigMapData, caConfigMap.Data) {
 		toUpdate := caConfigMap.DeepCopy()
@@ -274,17 +294,17 @@ func (c *IPsecCSRSigningController) syncRootCertificateAndKey() error {
 	return nil
 }
 
-func (c *IPsecCSRSigningController) csrWorker() {
+func (c *CSRSigningController) csrWorker() {
 	for c.processNextWorkItem() {
 	}
 }
 
 // watchSecretChanges uses watch API directly to watch for Secret changes.
 // Antrea Controller should not have List permission for Secrets.
-func (c *IPsecCSRSigningController) watchSecretChanges(endCh <-chan struct{}) error {
+func (c *CSRSigningController) watchSecretChanges(endCh <-chan struct{}) error {
 	watcher, err := c.client.CoreV1().Secrets(env.GetAntreaNamespace()).Watch(context.TODO(), metav1.SingleObject(metav1.ObjectMeta{
 		Namespace: env.GetAntreaNamespace(),
-		Name:      ipsecRootCAName,
+		Name:      c.rootCAName,
 	}))
 	if err != nil {
 		return fmt.Errorf("failed to create Secret watcher: %v", err)
@@ -307,12 +327,12 @@ func (c *IPsecCSRSigningController) watchSecretChanges(endCh <-chan struct{}) er
 	}
 }
 
-func (c *IPsecCSRSigningController) fixturesWorker() {
+func (c *CSRSigningController) fixturesWorker() {
 	for c.processNextFixtureWorkItem() {
 	}
 }
 
-func (c *IPsecCSRSigningController) enqueueCertificateSigningRequest(obj interface{}) {
+func (c *CSRSigningController) enqueueCertificateSigningRequest(obj interface{}) {
 	csr, ok := obj.(*certificatesv1.CertificateSigningRequest)
 	if !ok {
 		return
@@ -320,7 +340,7 @@ func (c *IPsecCSRSigningController) enqueueCertificateSigningRequest(obj interfa
 	c.queue.Add(csr.Name)
 }
 
-func (c *IPsecCSRSigningController) syncCSR(key string) error {
+func (c *CSRSigningController) syncCSR(key string) error {
 	startTime := time.Now()
 	defer func() {
 		d := time.Since(startTime)
@@ -333,7 +353,7 @@ func (c *IPsecCSRSigningController) syncCSR(key string) error {
 		}
 		return err
 	}
-	if csr.Spec.SignerName != antreaapis.AntreaIPsecCSRSignerName {
+	if csr.Spec.SignerName != c.signerName {
 		return nil
 	}
 	if len(csr.Status.Certificate) != 0 {
@@ -349,7 +369,7 @@ func (c *IPsecCSRSigningController) syncCSR(key string) error {
 		klog.ErrorS(err, "Failed to decode CertificateSigningRequest", "CertificateSigningRequest", csr.Name)
 		return nil
 	}
-	template, err := newCertificateTemplate(req, csr.Spec.Usages)
+	template, err := newCertificateTemplate(req, csr.Spec.Usages, csr.Spec.ExpirationSeconds)
 	if err != nil {
 		return err
 	}
@@ -374,7 +394,7 @@ func (c *IPsecCSRSigningController) syncCSR(key string) error {
 	return nil
 }
 
-func newCertificateTemplate(certReq *x509.CertificateRequest, usage []certificatesv1.KeyUsage) (*x509.Certificate, error) {
+func newCertificateTemplate(certReq *x509.CertificateRequest, usage []certificatesv1.KeyUsage, expirationSeconds *int32) (*x509.Certificate, error) {
 	var sn big.Int
 	snBytes := make([]byte, 18)
 	_, err := rand.Read(snBytes)
@@ -386,13 +406,21 @@ func newCertificateTemplate(certReq *x509.CertificateRequest, usage []certificat
 	if err != nil {
 		return nil, err
 	}
+	// Defaults to 1 year, the requested duration can only shorten it.
+	duration := duration365d
+	if expirationSeconds != nil {
+		if requested := time.Duration(*expirationSeconds) * time.Second; requested < duration {
+			duration = requested
+		}
+	}
 	template := &x509.Certificate{
 		Subject:               certReq.Subject,
 		SignatureAlgorithm:    x509.SHA512WithRSA,
 		NotBefore:             time.Now().Add(-5 * time.Minute),
-		NotAfter:              time.Now().Add(duration365d), // defaults to 1 year
+		NotAfter:              time.Now().Add(duration),
 		SerialNumber:          &sn,
 		DNSNames:              certReq.DNSNames,
+		IPAddresses:           certReq.IPAddresses,
 		BasicConstraintsValid: true,
 		KeyUsage:              keyUsage,
 		ExtKeyUsage:           extKeyUsage,
@@ -400,7 +428,7 @@ func newCertificateTemplate(certReq *x509.CertificateRequest, usage []certificat
 	return template, nil
 }
 
-func (c *IPsecCSRSigningController) processNextFixtureWorkItem() bool {
+func (c *CSRSigningController) processNextFixtureWorkItem() bool {
 	key, quit := c.fixturesQueue.Get()
 	if quit {
 		return false
@@ -416,7 +444,7 @@ func (c *IPsecCSRSigningController) processNextFixtureWorkItem() bool {
 	return true
 }
 
-func (c *IPsecCSRSigningController) processNextWorkItem() bool {
+func (c *CSRSigningController) processNextWorkItem() bool {
 	key, quit := c.queue.Get()
 	if quit {
 		return false
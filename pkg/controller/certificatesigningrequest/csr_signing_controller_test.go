@@ -18,6 +18,7 @@ import (
 	"context"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"net"
 	"testing"
 	"time"
 
@@ -137,3 +138,87 @@ func TestIPsecCertificateApproverAndSigner(t *testing.T) {
 		})
 	}
 }
+
+func TestAgentServingCertificateApproverAndSigner(t *testing.T) {
+	cr := x509.CertificateRequest{
+		Subject: pkix.Name{
+			Organization: []string{"antrea.io"},
+			CommonName:   "worker-node-1",
+		},
+		DNSNames:    []string{"worker-node-1", "localhost"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("192.168.1.10")},
+	}
+	_, crBytes := x509CRtoPEM(t, &cr)
+	expirationSeconds := int32(3600)
+	objects := []runtime.Object{
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "worker-node-1",
+			},
+			Status: corev1.NodeStatus{
+				Addresses: []corev1.NodeAddress{
+					{Type: corev1.NodeInternalIP, Address: "192.168.1.10"},
+				},
+			},
+		},
+	}
+	csr := &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "worker-node-1-serving",
+		},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:           crBytes,
+			SignerName:        "antrea.io/antrea-agent-serving",
+			ExpirationSeconds: &expirationSeconds,
+			Usages: []certificatesv1.KeyUsage{
+				certificatesv1.UsageDigitalSignature,
+				certificatesv1.UsageServerAuth,
+			},
+			Username: "system:serviceaccount:kube-system:antrea-agent",
+		},
+	}
+	clientset := fake.NewSimpleClientset(objects...)
+	informerFactory := informers.NewSharedInformerFactory(clientset, 0)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	csrInformer := informerFactory.Certificates().V1().CertificateSigningRequests()
+
+	approvingController := NewCSRApprovingController(clientset, csrInformer.Informer(), csrInformer.Lister())
+	signingController := NewAgentServingCSRSigningController(clientset, csrInformer.Informer(), csrInformer.Lister(), true)
+
+	informerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+
+	go approvingController.Run(stopCh)
+	go signingController.Run(stopCh)
+
+	_, err := clientset.CertificatesV1().CertificateSigningRequests().Create(context.TODO(), csr, metav1.CreateOptions{})
+	require.NoError(t, err)
+	err = wait.PollImmediate(200*time.Millisecond, 10*time.Second, func() (done bool, err error) {
+		csr, err = clientset.CertificatesV1().CertificateSigningRequests().Get(context.TODO(), csr.Name, metav1.GetOptions{})
+		require.NoError(t, err)
+		return isCertificateRequestApproved(csr) && len(csr.Status.Certificate) > 0, nil
+	})
+	require.NoError(t, err)
+	parsed, err := certutil.ParseCertsPEM(csr.Status.Certificate)
+	require.NoError(t, err)
+	require.Len(t, parsed, 1)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), parsed[0].NotAfter, time.Minute)
+
+	var caConfigMap *corev1.ConfigMap
+	err = wait.PollImmediate(200*time.Millisecond, 10*time.Second, func() (done bool, err error) {
+		caConfigMap, err = clientset.CoreV1().ConfigMaps("kube-system").Get(context.TODO(), "antrea-agent-serving-ca", metav1.GetOptions{})
+		return err == nil, nil
+	})
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM([]byte(caConfigMap.Data["ca.crt"])))
+	for _, name := range []string{"worker-node-1", "localhost", "192.168.1.10"} {
+		_, err = parsed[0].Verify(x509.VerifyOptions{
+			DNSName:   name,
+			Roots:     roots,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		})
+		assert.NoError(t, err, "certificate should be valid for %s", name)
+	}
+}
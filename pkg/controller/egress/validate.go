@@ -53,6 +53,11 @@ func (c *EgressController) ValidateEgress(review *admv1.AdmissionReview) *admv1.
 		if len(newEgress.Spec.ExternalIPPools) > 0 {
 			return false, "spec.externalIPPools is not supported yet"
 		}
+		for _, cidr := range newEgress.Spec.DestinationCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return false, fmt.Sprintf("destination CIDR %s is not valid", cidr)
+			}
+		}
 		// Allow it if EgressIP and ExternalIPPool don't change.
 		if newEgress.Spec.EgressIP == oldEgress.Spec.EgressIP && newEgress.Spec.ExternalIPPool == oldEgress.Spec.ExternalIPPool {
 			return true, ""
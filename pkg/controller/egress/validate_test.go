@@ -80,6 +80,25 @@ func TestEgressControllerValidateEgress(t *testing.T) {
 			},
 			expectedResponse: &admv1.AdmissionResponse{Allowed: true},
 		},
+		{
+			name:                   "Requesting invalid destination CIDR should not be allowed",
+			existingExternalIPPool: newExternalIPPool("bar", "10.10.10.0/24", "", ""),
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "CREATE",
+				Object: runtime.RawExtension{Raw: marshal(func() *crdv1alpha2.Egress {
+					egress := newEgress("foo", "10.10.10.1", "bar", nil, nil)
+					egress.Spec.DestinationCIDRs = []string{"192.168.10.0/24", "192.168.20.0"}
+					return egress
+				}())},
+			},
+			expectedResponse: &admv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "destination CIDR 192.168.20.0 is not valid",
+				},
+			},
+		},
 		{
 			name:                   "Updating EgressIP to invalid one should not be allowed",
 			existingExternalIPPool: newExternalIPPool("bar", "10.10.10.0/24", "", ""),
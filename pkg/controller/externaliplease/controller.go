@@ -0,0 +1,491 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package externaliplease
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+
+	"antrea.io/antrea/pkg/controller/externalippool"
+)
+
+const (
+	controllerName = "ExternalIPLeaseController"
+	// DefaultLeasesConfigMapName is the name of the ConfigMap used to persist the leases, so that
+	// they survive antrea-controller restarts.
+	DefaultLeasesConfigMapName = "antrea-external-ip-leases"
+	leasesConfigMapKey         = "leases"
+	// DefaultLeaseDuration is the lease duration used when a request doesn't specify one.
+	DefaultLeaseDuration = time.Hour
+	// MaxLeaseDuration is the maximum duration of a lease. Owners must renew their leases
+	// before they expire to keep the IPs.
+	MaxLeaseDuration = 7 * 24 * time.Hour
+	// How often to check for expired leases.
+	defaultGCInterval = 30 * time.Second
+	// leaseKind is used as the Kind of the ObjectReference of the IP allocations made on behalf
+	// of leases, to tell them apart from the allocations made by Egress or Services.
+	leaseKind = "ExternalIPLease"
+)
+
+var (
+	ErrNotReady      = errors.New("leases have not been restored yet")
+	ErrInvalidLease  = errors.New("invalid lease request")
+	ErrLeaseNotFound = errors.New("lease not found")
+	// ErrIPConflict indicates that the requested IP is already in use, either by Antrea itself
+	// or by another lease.
+	ErrIPConflict = errors.New("IP is already allocated")
+)
+
+// Lease is an IP allocated from an ExternalIPPool on behalf of an external owner, for a limited
+// period of time.
+type Lease struct {
+	// ExternalIPPool is the name of the ExternalIPPool the IP is allocated from.
+	ExternalIPPool string `json:"externalIPPool"`
+	// IP is the allocated IP.
+	IP string `json:"ip"`
+	// Owner identifies the integration which owns the lease. Only the owner can renew or
+	// release the lease.
+	Owner string `json:"owner"`
+	// Labels can be used by the owner to record what the IP is used for.
+	Labels map[string]string `json:"labels,omitempty"`
+	// ExpireTime is the time at which the IP is reclaimed if the lease is not renewed.
+	ExpireTime metav1.Time `json:"expireTime"`
+}
+
+// DeepCopy returns a deep copy of the Lease.
+func (l *Lease) DeepCopy() *Lease {
+	out := *l
+	if l.Labels != nil {
+		out.Labels = make(map[string]string, len(l.Labels))
+		for k, v := range l.Labels {
+			out.Labels[k] = v
+		}
+	}
+	return &out
+}
+
+// LeaseRequest is a request to acquire or renew a Lease.
+type LeaseRequest struct {
+	ExternalIPPool string `json:"externalIPPool"`
+	// IP is the IP to lease. If empty, the next available IP of the pool is allocated.
+	// Requesting an IP which is already leased by the same owner renews the lease.
+	IP     string            `json:"ip,omitempty"`
+	Owner  string            `json:"owner"`
+	Labels map[string]string `json:"labels,omitempty"`
+	// DurationSeconds is the requested lease duration. If 0, DefaultLeaseDuration is used.
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+}
+
+// Interface is used to manage the leases.
+type Interface interface {
+	// Acquire allocates an IP for the request, or renews the existing lease if the IP is
+	// already leased by the same owner.
+	Acquire(request *LeaseRequest) (*Lease, error)
+	// Release releases a leased IP. It fails if the IP is not leased by the given owner.
+	Release(externalIPPool, ip, owner string) error
+	// List returns the leases matching the provided ExternalIPPool and owner. Empty values
+	// match all leases.
+	List(externalIPPool, owner string) ([]Lease, error)
+}
+
+var _ Interface = (*Controller)(nil)
+
+// Controller lets external integrations (e.g. non-Antrea load balancers) lease IPs from
+// ExternalIPPools. IPs are allocated with the same ExternalIPAllocator used by Egress and
+// ServiceExternalIP, which guarantees that leased IPs never conflict with Antrea's own allocations.
+type Controller struct {
+	k8sClient           clientset.Interface
+	namespace           string
+	configMapName       string
+	externalIPAllocator externalippool.ExternalIPAllocator
+	clock               clock.WithTicker
+	gcInterval          time.Duration
+
+	mutex sync.Mutex
+	// leases is a map from "<ExternalIPPool>/<IP>" to Lease.
+	leases map[string]*Lease
+	// restored indicates whether the persisted leases have been restored.
+	restored bool
+	// resyncCh is used to trigger the cleanup of leases when an ExternalIPPool changes.
+	resyncCh chan struct{}
+}
+
+// NewController returns a new *Controller.
+func NewController(k8sClient clientset.Interface, namespace string, externalIPAllocator externalippool.ExternalIPAllocator) *Controller {
+	return newControllerWithClock(k8sClient, namespace, externalIPAllocator, clock.RealClock{})
+}
+
+func newControllerWithClock(k8sClient clientset.Interface, namespace string, externalIPAllocator externalippool.ExternalIPAllocator, clock clock.WithTicker) *Controller {
+	c := &Controller{
+		k8sClient:           k8sClient,
+		namespace:           namespace,
+		configMapName:       DefaultLeasesConfigMapName,
+		externalIPAllocator: externalIPAllocator,
+		clock:               clock,
+		gcInterval:          defaultGCInterval,
+		leases:              map[string]*Lease{},
+		resyncCh:            make(chan struct{}, 1),
+	}
+	// The handler must be registered before the ExternalIPPoolController starts, so that other
+	// consumers cannot allocate IPs before the leases have been restored.
+	externalIPAllocator.AddEventHandler(func(string) {
+		select {
+		case c.resyncCh <- struct{}{}:
+		default:
+		}
+	})
+	return c
+}
+
+func leaseKey(externalIPPool string, ip net.IP) string {
+	return externalIPPool + "/" + ip.String()
+}
+
+func (c *Controller) Acquire(request *LeaseRequest) (*Lease, error) {
+	if request.ExternalIPPool == "" || request.Owner == "" {
+		return nil, fmt.Errorf("%w: externalIPPool and owner must be provided", ErrInvalidLease)
+	}
+	duration := DefaultLeaseDuration
+	if request.DurationSeconds < 0 {
+		return nil, fmt.Errorf("%w: durationSeconds must not be negative", ErrInvalidLease)
+	} else if request.DurationSeconds > 0 {
+		duration = time.Duration(request.DurationSeconds) * time.Second
+	}
+	if duration > MaxLeaseDuration {
+		return nil, fmt.Errorf("%w: lease duration must not exceed %v", ErrInvalidLease, MaxLeaseDuration)
+	}
+	var requestedIP net.IP
+	if request.IP != "" {
+		if requestedIP = net.ParseIP(request.IP); requestedIP == nil {
+			return nil, fmt.Errorf("%w: %s is not a valid IP", ErrInvalidLease, request.IP)
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.restored {
+		return nil, ErrNotReady
+	}
+	if !c.externalIPAllocator.IPPoolExists(request.ExternalIPPool) {
+		return nil, externalippool.ErrExternalIPPoolNotFound
+	}
+	expireTime := metav1.NewTime(c.clock.Now().Add(duration))
+
+	if requestedIP != nil {
+		if lease, exists := c.leases[leaseKey(request.ExternalIPPool, requestedIP)]; exists {
+			if lease.Owner != request.Owner {
+				return nil, fmt.Errorf("%w: %s is leased by another owner", ErrIPConflict, request.IP)
+			}
+			renewed := *lease
+			renewed.Labels = request.Labels
+			renewed.ExpireTime = expireTime
+			if err := c.persistLeases(&renewed); err != nil {
+				return nil, err
+			}
+			*lease = renewed
+			klog.V(2).InfoS("Renewed external IP lease", "ipPool", lease.ExternalIPPool, "ip", lease.IP, "owner", lease.Owner, "expireTime", lease.ExpireTime)
+			return lease.DeepCopy(), nil
+		}
+		if !c.externalIPAllocator.IPPoolHasIP(request.ExternalIPPool, requestedIP) {
+			return nil, fmt.Errorf("%w: %s is not in ExternalIPPool %s", ErrInvalidLease, request.IP, request.ExternalIPPool)
+		}
+		if err := c.externalIPAllocator.UpdateIPAllocation(request.ExternalIPPool, requestedIP); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrIPConflict, err)
+		}
+	} else {
+		ip, err := c.externalIPAllocator.AllocateIPFromPool(request.ExternalIPPool)
+		if err != nil {
+			return nil, err
+		}
+		requestedIP = ip
+	}
+
+	lease := &Lease{
+		ExternalIPPool: request.ExternalIPPool,
+		IP:             requestedIP.String(),
+		Owner:          request.Owner,
+		Labels:         request.Labels,
+		ExpireTime:     expireTime,
+	}
+	key := leaseKey(lease.ExternalIPPool, requestedIP)
+	c.leases[key] = lease
+	if err := c.persistLeases(nil); err != nil {
+		delete(c.leases, key)
+		if releaseErr := c.externalIPAllocator.ReleaseIP(lease.ExternalIPPool, requestedIP); releaseErr != nil {
+			klog.ErrorS(releaseErr, "Failed to release IP after failing to persist lease", "ipPool", lease.ExternalIPPool, "ip", lease.IP)
+		}
+		return nil, err
+	}
+	klog.InfoS("Acquired external IP lease", "ipPool", lease.ExternalIPPool, "ip", lease.IP, "owner", lease.Owner, "expireTime", lease.ExpireTime)
+	return lease.DeepCopy(), nil
+}
+
+func (c *Controller) Release(externalIPPool, ip, owner string) error {
+	parsedIP := net.ParseIP(ip)
+	if externalIPPool == "" || owner == "" || parsedIP == nil {
+		return fmt.Errorf("%w: externalIPPool, owner and a valid ip must be provided", ErrInvalidLease)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.restored {
+		return ErrNotReady
+	}
+	key := leaseKey(externalIPPool, parsedIP)
+	lease, exists := c.leases[key]
+	if !exists {
+		return ErrLeaseNotFound
+	}
+	if lease.Owner != owner {
+		return fmt.Errorf("%w: %s is leased by another owner", ErrIPConflict, ip)
+	}
+	delete(c.leases, key)
+	if err := c.persistLeases(nil); err != nil {
+		c.leases[key] = lease
+		return err
+	}
+	c.releaseIP(lease)
+	klog.InfoS("Released external IP lease", "ipPool", lease.ExternalIPPool, "ip", lease.IP, "owner", lease.Owner)
+	return nil
+}
+
+func (c *Controller) List(externalIPPool, owner string) ([]Lease, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.restored {
+		return nil, ErrNotReady
+	}
+	return c.listLeases(externalIPPool, owner), nil
+}
+
+// listLeases returns the matching leases, sorted by ExternalIPPool and IP. It must be called
+// with the mutex held.
+func (c *Controller) listLeases(externalIPPool, owner string) []Lease {
+	leases := make([]Lease, 0, len(c.leases))
+	for _, lease := range c.leases {
+		if externalIPPool != "" && lease.ExternalIPPool != externalIPPool {
+			continue
+		}
+		if owner != "" && lease.Owner != owner {
+			continue
+		}
+		leases = append(leases, *lease.DeepCopy())
+	}
+	sort.Slice(leases, func(i, j int) bool {
+		if leases[i].ExternalIPPool != leases[j].ExternalIPPool {
+			return leases[i].ExternalIPPool < leases[j].ExternalIPPool
+		}
+		return leases[i].IP < leases[j].IP
+	})
+	return leases
+}
+
+// releaseIP releases the IP of the lease to its ExternalIPPool, unless the IP no longer belongs
+// to the pool (e.g. the pool has been deleted).
+func (c *Controller) releaseIP(lease *Lease) {
+	ip := net.ParseIP(lease.IP)
+	if !c.externalIPAllocator.IPPoolHasIP(lease.ExternalIPPool, ip) {
+		return
+	}
+	if err := c.externalIPAllocator.ReleaseIP(lease.ExternalIPPool, ip); err != nil {
+		klog.ErrorS(err, "Failed to release leased IP", "ipPool", lease.ExternalIPPool, "ip", lease.IP)
+	}
+}
+
+// persistLeases writes the current leases to the ConfigMap. If override is not nil, it replaces
+// the lease with the same key, which allows persisting a lease update before applying it in
+// memory. It must be called with the mutex held.
+func (c *Controller) persistLeases(override *Lease) error {
+	leases := c.listLeases("", "")
+	if override != nil {
+		for i := range leases {
+			if leases[i].ExternalIPPool == override.ExternalIPPool && leases[i].IP == override.IP {
+				leases[i] = *override
+			}
+		}
+	}
+	data, err := json.Marshal(leases)
+	if err != nil {
+		return fmt.Errorf("error when marshaling leases: %v", err)
+	}
+	configMaps := c.k8sClient.CoreV1().ConfigMaps(c.namespace)
+	configMap, err := configMaps.Get(context.TODO(), c.configMapName, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("error when getting '%s/%s' ConfigMap: %v", c.namespace, c.configMapName, err)
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      c.configMapName,
+				Namespace: c.namespace,
+				Labels: map[string]string{
+					"app": "antrea",
+				},
+			},
+			Data: map[string]string{leasesConfigMapKey: string(data)},
+		}
+		if _, err := configMaps.Create(context.TODO(), configMap, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("error when creating '%s/%s' ConfigMap: %v", c.namespace, c.configMapName, err)
+		}
+		return nil
+	}
+	configMap.Data = map[string]string{leasesConfigMapKey: string(data)}
+	if _, err := configMaps.Update(context.TODO(), configMap, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error when updating '%s/%s' ConfigMap: %v", c.namespace, c.configMapName, err)
+	}
+	return nil
+}
+
+// loadLeases reads the persisted leases from the ConfigMap.
+func (c *Controller) loadLeases() ([]Lease, error) {
+	configMap, err := c.k8sClient.CoreV1().ConfigMaps(c.namespace).Get(context.TODO(), c.configMapName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error when getting '%s/%s' ConfigMap: %v", c.namespace, c.configMapName, err)
+	}
+	data, ok := configMap.Data[leasesConfigMapKey]
+	if !ok || data == "" {
+		return nil, nil
+	}
+	var leases []Lease
+	if err := json.Unmarshal([]byte(data), &leases); err != nil {
+		return nil, fmt.Errorf("error when unmarshaling leases: %v", err)
+	}
+	return leases, nil
+}
+
+// restoreLeases restores the persisted leases which have not expired yet, and marks their IPs as
+// allocated in the ExternalIPPools. Leases whose IPs cannot be allocated anymore are dropped.
+func (c *Controller) restoreLeases(leases []Lease) error {
+	now := c.clock.Now()
+	var allocations []externalippool.IPAllocation
+	leasesByKey := map[string]*Lease{}
+	for i := range leases {
+		lease := &leases[i]
+		ip := net.ParseIP(lease.IP)
+		if ip == nil || !lease.ExpireTime.Time.After(now) {
+			continue
+		}
+		leasesByKey[leaseKey(lease.ExternalIPPool, ip)] = lease
+		allocations = append(allocations, externalippool.IPAllocation{
+			ObjectReference: corev1.ObjectReference{
+				Kind: leaseKind,
+				Name: lease.Owner,
+			},
+			IPPoolName: lease.ExternalIPPool,
+			IP:         ip,
+		})
+	}
+	succeeded := c.externalIPAllocator.RestoreIPAllocations(allocations)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, allocation := range succeeded {
+		key := leaseKey(allocation.IPPoolName, allocation.IP)
+		c.leases[key] = leasesByKey[key]
+	}
+	c.restored = true
+	if len(succeeded) != len(leases) {
+		klog.InfoS("Some external IP leases could not be restored", "restored", len(succeeded), "total", len(leases))
+		return c.persistLeases(nil)
+	}
+	return nil
+}
+
+// removeStaleLeases removes the leases which have expired, or whose IPs no longer belong to their
+// ExternalIPPools.
+func (c *Controller) removeStaleLeases() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := c.clock.Now()
+	var removed []*Lease
+	for key, lease := range c.leases {
+		if lease.ExpireTime.Time.After(now) && c.externalIPAllocator.IPPoolHasIP(lease.ExternalIPPool, net.ParseIP(lease.IP)) {
+			continue
+		}
+		delete(c.leases, key)
+		removed = append(removed, lease)
+	}
+	if len(removed) == 0 {
+		return
+	}
+	if err := c.persistLeases(nil); err != nil {
+		// Keep the leases in memory so that removing them is retried at the next resync.
+		for _, lease := range removed {
+			c.leases[leaseKey(lease.ExternalIPPool, net.ParseIP(lease.IP))] = lease
+		}
+		klog.ErrorS(err, "Failed to remove stale external IP leases")
+		return
+	}
+	for _, lease := range removed {
+		c.releaseIP(lease)
+		klog.InfoS("Removed stale external IP lease", "ipPool", lease.ExternalIPPool, "ip", lease.IP, "owner", lease.Owner, "expireTime", lease.ExpireTime)
+	}
+}
+
+// Run begins restoring the persisted leases and reclaiming stale leases.
+func (c *Controller) Run(stopCh <-chan struct{}) {
+	klog.InfoS("Starting " + controllerName)
+	defer klog.InfoS("Shutting down " + controllerName)
+
+	if err := wait.PollImmediateUntil(100*time.Millisecond, func() (bool, error) {
+		return c.externalIPAllocator.HasSynced(), nil
+	}, stopCh); err != nil {
+		return
+	}
+
+	var leases []Lease
+	if err := wait.PollImmediateUntil(time.Second, func() (bool, error) {
+		var err error
+		if leases, err = c.loadLeases(); err != nil {
+			klog.ErrorS(err, "Failed to load external IP leases, will retry")
+			return false, nil
+		}
+		return true, nil
+	}, stopCh); err != nil {
+		return
+	}
+	if err := c.restoreLeases(leases); err != nil {
+		klog.ErrorS(err, "Failed to persist restored external IP leases")
+	}
+
+	ticker := c.clock.NewTicker(c.gcInterval)
+	defer ticker.Stop()
+	for {
+		c.removeStaleLeases()
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C():
+		case <-c.resyncCh:
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package externaliplease
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	antreacrds "antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	fakeversioned "antrea.io/antrea/pkg/client/clientset/versioned/fake"
+	crdinformers "antrea.io/antrea/pkg/client/informers/externalversions"
+	"antrea.io/antrea/pkg/controller/externalippool"
+)
+
+const testNamespace = "kube-system"
+
+// Use the local time zone as metav1.Time is always unmarshaled as local time.
+var testStartTime = time.Date(2023, 6, 1, 0, 0, 0, 0, time.Local)
+
+type fakeController struct {
+	*Controller
+	client              kubernetes.Interface
+	clock               *clocktesting.FakeClock
+	externalIPAllocator *externalippool.ExternalIPPoolController
+}
+
+func newExternalIPPool(name, start, end string) *antreacrds.ExternalIPPool {
+	return &antreacrds.ExternalIPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: antreacrds.ExternalIPPoolSpec{
+			IPRanges: []antreacrds.IPRange{{Start: start, End: end}},
+		},
+	}
+}
+
+func newLeasesConfigMap(t *testing.T, leases ...Lease) *corev1.ConfigMap {
+	data, err := json.Marshal(leases)
+	require.NoError(t, err)
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: DefaultLeasesConfigMapName, Namespace: testNamespace},
+		Data:       map[string]string{leasesConfigMapKey: string(data)},
+	}
+}
+
+func newLease(pool, ip, owner string, expireTime time.Time) Lease {
+	return Lease{ExternalIPPool: pool, IP: ip, Owner: owner, ExpireTime: metav1.NewTime(expireTime)}
+}
+
+// startController starts the controller and waits for the leases to be restored.
+func startController(t *testing.T, objects, crdObjects []runtime.Object) *fakeController {
+	client := fake.NewSimpleClientset(objects...)
+	crdClient := fakeversioned.NewSimpleClientset(crdObjects...)
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	externalIPPoolController := externalippool.NewExternalIPPoolController(crdClient, crdInformerFactory.Crd().V1alpha2().ExternalIPPools())
+	fakeClock := clocktesting.NewFakeClock(testStartTime)
+	controller := newControllerWithClock(client, testNamespace, externalIPPoolController, fakeClock)
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+	crdInformerFactory.Start(stopCh)
+	crdInformerFactory.WaitForCacheSync(stopCh)
+	go externalIPPoolController.Run(stopCh)
+	require.True(t, cache.WaitForCacheSync(stopCh, externalIPPoolController.HasSynced))
+	go controller.Run(stopCh)
+	require.NoError(t, wait.PollImmediate(10*time.Millisecond, time.Second, func() (bool, error) {
+		_, err := controller.List("", "")
+		return err == nil, nil
+	}))
+	return &fakeController{
+		Controller:          controller,
+		client:              client,
+		clock:               fakeClock,
+		externalIPAllocator: externalIPPoolController,
+	}
+}
+
+func getPersistedLeases(t *testing.T, client kubernetes.Interface) []Lease {
+	configMap, err := client.CoreV1().ConfigMaps(testNamespace).Get(context.TODO(), DefaultLeasesConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+	var leases []Lease
+	require.NoError(t, json.Unmarshal([]byte(configMap.Data[leasesConfigMapKey]), &leases))
+	return leases
+}
+
+func TestRestoreLeases(t *testing.T) {
+	validLease := newLease("eip1", "1.2.3.6", "lb0", testStartTime.Add(time.Hour))
+	expiredLease := newLease("eip1", "1.2.3.5", "lb0", testStartTime.Add(-time.Hour))
+	unknownPoolLease := newLease("eip2", "1.2.4.4", "lb0", testStartTime.Add(time.Hour))
+	c := startController(t,
+		[]runtime.Object{newLeasesConfigMap(t, validLease, expiredLease, unknownPoolLease)},
+		[]runtime.Object{newExternalIPPool("eip1", "1.2.3.4", "1.2.3.6")})
+
+	leases, err := c.List("", "")
+	require.NoError(t, err)
+	assert.Equal(t, []Lease{validLease}, leases)
+	// Leases which could not be restored should be removed from the ConfigMap.
+	assert.Equal(t, []Lease{validLease}, getPersistedLeases(t, c.client))
+
+	// The IP of the restored lease should not be allocated again.
+	ip, err := c.externalIPAllocator.AllocateIPFromPool("eip1")
+	require.NoError(t, err)
+	assert.Equal(t, net.ParseIP("1.2.3.4"), ip)
+	ip, err = c.externalIPAllocator.AllocateIPFromPool("eip1")
+	require.NoError(t, err)
+	assert.Equal(t, net.ParseIP("1.2.3.5"), ip)
+	_, err = c.externalIPAllocator.AllocateIPFromPool("eip1")
+	assert.Error(t, err)
+}
+
+func TestAcquireAndRelease(t *testing.T) {
+	c := startController(t, nil, []runtime.Object{newExternalIPPool("eip1", "1.2.3.4", "1.2.3.6")})
+	// Simulate an IP allocated by Antrea for an Egress.
+	require.NoError(t, c.externalIPAllocator.UpdateIPAllocation("eip1", net.ParseIP("1.2.3.5")))
+
+	labels := map[string]string{"service": "vip1"}
+	lease, err := c.Acquire(&LeaseRequest{ExternalIPPool: "eip1", Owner: "lb1", Labels: labels})
+	require.NoError(t, err)
+	expectedLease := Lease{ExternalIPPool: "eip1", IP: "1.2.3.4", Owner: "lb1", Labels: labels, ExpireTime: metav1.NewTime(testStartTime.Add(DefaultLeaseDuration))}
+	assert.Equal(t, &expectedLease, lease)
+	assert.Equal(t, []Lease{expectedLease}, getPersistedLeases(t, c.client))
+
+	_, err = c.Acquire(&LeaseRequest{ExternalIPPool: "eip1", IP: "1.2.3.5", Owner: "lb1"})
+	assert.ErrorIs(t, err, ErrIPConflict, "IP allocated by Antrea should not be leased")
+	_, err = c.Acquire(&LeaseRequest{ExternalIPPool: "eip1", IP: "1.2.3.4", Owner: "lb2"})
+	assert.ErrorIs(t, err, ErrIPConflict, "IP leased by another owner should not be leased")
+	_, err = c.Acquire(&LeaseRequest{ExternalIPPool: "eip1", IP: "1.2.4.4", Owner: "lb1"})
+	assert.ErrorIs(t, err, ErrInvalidLease)
+	_, err = c.Acquire(&LeaseRequest{ExternalIPPool: "eip2", Owner: "lb1"})
+	assert.ErrorIs(t, err, externalippool.ErrExternalIPPoolNotFound)
+	_, err = c.Acquire(&LeaseRequest{ExternalIPPool: "eip1", Owner: "lb1", DurationSeconds: int64(2 * MaxLeaseDuration / time.Second)})
+	assert.ErrorIs(t, err, ErrInvalidLease)
+
+	// Renew the lease.
+	c.clock.Step(time.Minute)
+	lease, err = c.Acquire(&LeaseRequest{ExternalIPPool: "eip1", IP: "1.2.3.4", Owner: "lb1", DurationSeconds: 60})
+	require.NoError(t, err)
+	expectedLease.Labels = nil
+	expectedLease.ExpireTime = metav1.NewTime(testStartTime.Add(2 * time.Minute))
+	assert.Equal(t, &expectedLease, lease)
+	assert.Equal(t, []Lease{expectedLease}, getPersistedLeases(t, c.client))
+
+	assert.ErrorIs(t, c.Release("eip1", "1.2.3.4", "lb2"), ErrIPConflict)
+	assert.ErrorIs(t, c.Release("eip1", "1.2.3.5", "lb1"), ErrLeaseNotFound)
+	require.NoError(t, c.Release("eip1", "1.2.3.4", "lb1"))
+	assert.Empty(t, getPersistedLeases(t, c.client))
+	assert.True(t, canAllocate(c.externalIPAllocator, "1.2.3.4"), "IP of the released lease should be available")
+}
+
+func canAllocate(allocator *externalippool.ExternalIPPoolController, ip string) bool {
+	if err := allocator.UpdateIPAllocation("eip1", net.ParseIP(ip)); err != nil {
+		return false
+	}
+	allocator.ReleaseIP("eip1", net.ParseIP(ip))
+	return true
+}
+
+func TestRemoveStaleLeases(t *testing.T) {
+	c := startController(t, nil, []runtime.Object{newExternalIPPool("eip1", "1.2.3.4", "1.2.3.6")})
+	_, err := c.Acquire(&LeaseRequest{ExternalIPPool: "eip1", IP: "1.2.3.4", Owner: "lb1", DurationSeconds: 60})
+	require.NoError(t, err)
+	_, err = c.Acquire(&LeaseRequest{ExternalIPPool: "eip1", IP: "1.2.3.5", Owner: "lb1", DurationSeconds: 120})
+	require.NoError(t, err)
+
+	c.clock.Step(90 * time.Second)
+	c.removeStaleLeases()
+
+	leases, err := c.List("eip1", "lb1")
+	require.NoError(t, err)
+	require.Len(t, leases, 1)
+	assert.Equal(t, "1.2.3.5", leases[0].IP)
+	assert.Len(t, getPersistedLeases(t, c.client), 1)
+	assert.True(t, canAllocate(c.externalIPAllocator, "1.2.3.4"), "IP of the expired lease should be released")
+	assert.False(t, canAllocate(c.externalIPAllocator, "1.2.3.5"))
+}
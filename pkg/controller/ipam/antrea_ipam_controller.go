@@ -43,6 +43,7 @@ import (
 	"antrea.io/antrea/pkg/client/informers/externalversions"
 	crdinformers "antrea.io/antrea/pkg/client/informers/externalversions/crd/v1alpha2"
 	crdlisters "antrea.io/antrea/pkg/client/listers/crd/v1alpha2"
+	"antrea.io/antrea/pkg/controller/metrics"
 	annotation "antrea.io/antrea/pkg/ipam"
 	"antrea.io/antrea/pkg/ipam/poolallocator"
 	"antrea.io/antrea/pkg/util/k8s"
@@ -282,7 +283,17 @@ func (c *AntreaIPAMController) getIPPoolsForStatefulSet(ss *appsv1.StatefulSet)
 
 	annotations, exists = namespace.Annotations[annotation.AntreaIPAMAnnotationKey]
 	if exists {
-		return strings.Split(annotations, annotation.AntreaIPAMAnnotationDelimiter)
+		// The Namespace can be annotated with multiple IPPools, select the first one matching
+		// the labels of the StatefulSet Pods.
+		ipPools, err := annotation.SelectIPPoolsForPod(strings.Split(annotations, annotation.AntreaIPAMAnnotationDelimiter), ss.Spec.Template.Labels, c.ipPoolLister)
+		if err != nil {
+			klog.ErrorS(err, "Failed to select IPPools for StatefulSet", "StatefulSet", klog.KObj(ss))
+			return nil
+		}
+		if len(ipPools) > 1 {
+			return ipPools[:1]
+		}
+		return ipPools
 	}
 
 	return nil
@@ -375,6 +386,8 @@ func (c *AntreaIPAMController) updateIPPoolCounters(poolName string) error {
 	ipPool, err := c.ipPoolLister.Get(poolName)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			metrics.IPPoolTotalIPs.DeleteLabelValues(poolName)
+			metrics.IPPoolUsedIPs.DeleteLabelValues(poolName)
 			return nil
 		}
 		return fmt.Errorf("failed to retrieve IPPool %s, error: %v", poolName, err)
@@ -391,6 +404,8 @@ func (c *AntreaIPAMController) updateIPPoolCounters(poolName string) error {
 
 	// Used is gathered from IP allocation status within the CRD - as it can be set by each one of the agents
 	used := len(ipPool.Status.IPAddresses)
+	metrics.IPPoolTotalIPs.WithLabelValues(poolName).Set(float64(total))
+	metrics.IPPoolUsedIPs.WithLabelValues(poolName).Set(float64(used))
 
 	// If update has no effect, exit
 	if ipPool.Status.Usage.Used == used && ipPool.Status.Usage.Total == total {
@@ -424,6 +439,24 @@ func (c *AntreaIPAMController) updateHandler(oldObj, newObj interface{}) {
 	c.statusQueue.Add(ipPool.Name)
 }
 
+func (c *AntreaIPAMController) deleteHandler(obj interface{}) {
+	ipPool, ok := obj.(*crdv1a2.IPPool)
+	if !ok {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Errorf("Received unexpected object: %v", obj)
+			return
+		}
+		ipPool, ok = deletedState.Obj.(*crdv1a2.IPPool)
+		if !ok {
+			klog.Errorf("DeletedFinalStateUnknown contains non-IPPool object: %v", deletedState.Obj)
+			return
+		}
+	}
+	// Remove the usage metrics of the IPPool.
+	c.statusQueue.Add(ipPool.Name)
+}
+
 func (c *AntreaIPAMController) processNextWorkItem() bool {
 	key, quit := c.statusQueue.Get()
 	if quit {
@@ -461,6 +494,7 @@ func (c *AntreaIPAMController) Run(stopCh <-chan struct{}) {
 	c.ipPoolInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.createHandler,
 		UpdateFunc: c.updateHandler,
+		DeleteFunc: c.deleteHandler,
 	})
 
 	cacheSyncs := []cache.InformerSynced{c.namespaceListerSynced, c.podInformerSynced, c.statefulSetListerSynced, c.ipPoolListerSynced}
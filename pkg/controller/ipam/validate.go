@@ -23,9 +23,11 @@ import (
 
 	admv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	crdv1alpha2 "antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	"antrea.io/antrea/pkg/util/k8s"
 )
 
 func ValidateIPPool(review *admv1.AdmissionReview) *admv1.AdmissionResponse {
@@ -51,6 +53,13 @@ func ValidateIPPool(review *admv1.AdmissionReview) *admv1.AdmissionResponse {
 	case admv1.Create:
 		klog.V(2).Info("Validating CREATE request for IPPool")
 
+		if allowed, msg = validateGatewayProxy(&newObj); !allowed {
+			return validationResult(allowed, msg)
+		}
+		if allowed, msg = validatePodSelector(&newObj); !allowed {
+			return validationResult(allowed, msg)
+		}
+
 		// Validate individual ranges
 		for _, r := range newObj.Spec.IPRanges {
 			allowed, msg = validateIPRange(r, newObj.Spec.IPVersion)
@@ -68,8 +77,16 @@ func ValidateIPPool(review *admv1.AdmissionReview) *admv1.AdmissionResponse {
 				}
 			}
 		}
+
+		allowed, msg = validateReservations(&newObj)
 	case admv1.Update:
 		klog.V(2).Info("Validating UPDATE request for IPPool")
+		if allowed, msg = validateGatewayProxy(&newObj); !allowed {
+			return validationResult(allowed, msg)
+		}
+		if allowed, msg = validatePodSelector(&newObj); !allowed {
+			return validationResult(allowed, msg)
+		}
 		deletedIPRanges := getIPRangeDifference(oldObj.Spec.IPRanges, newObj.Spec.IPRanges)
 		if len(deletedIPRanges) > 0 {
 			msg = fmt.Sprintf("existing IPRanges %s cannot be updated or deleted", humanReadableIPRanges(deletedIPRanges))
@@ -90,6 +107,8 @@ func ValidateIPPool(review *admv1.AdmissionReview) *admv1.AdmissionResponse {
 				}
 			}
 		}
+
+		allowed, msg = validateReservations(&newObj)
 	case admv1.Delete:
 		klog.V(2).Info("Validating DELETE request for IPPool")
 		if len(oldObj.Status.IPAddresses) > 0 {
@@ -101,6 +120,71 @@ func ValidateIPPool(review *admv1.AdmissionReview) *admv1.AdmissionResponse {
 	return validationResult(allowed, msg)
 }
 
+// validateGatewayProxy checks that GatewayProxy is only enabled for IPv4 IPPools, as bridging mode doesn't support
+// IPv6 yet and NDP requests are not proxied.
+func validateGatewayProxy(ipPool *crdv1alpha2.IPPool) (bool, string) {
+	if ipPool.Spec.GatewayProxy && ipPool.Spec.IPVersion != crdv1alpha2.IPv4 {
+		return false, "gatewayProxy is only supported for IPv4 IPPools"
+	}
+	return true, ""
+}
+
+// validatePodSelector checks that the PodSelector of the IPPool, if set, is a valid label selector.
+func validatePodSelector(ipPool *crdv1alpha2.IPPool) (bool, string) {
+	if ipPool.Spec.PodSelector == nil {
+		return true, ""
+	}
+	if _, err := metav1.LabelSelectorAsSelector(ipPool.Spec.PodSelector); err != nil {
+		return false, fmt.Sprintf("Invalid podSelector: %v", err)
+	}
+	return true, ""
+}
+
+// validateReservations checks that each reserved IP is a valid IP in one of the IP ranges of the IPPool, and that
+// neither an IP nor a Pod is reserved more than once.
+func validateReservations(ipPool *crdv1alpha2.IPPool) (bool, string) {
+	reservedIPs := sets.New[string]()
+	reservedPods := sets.New[string]()
+	for _, reservation := range ipPool.Spec.Reservations {
+		ip := net.ParseIP(reservation.IPAddress)
+		if ip == nil {
+			return false, fmt.Sprintf("Invalid reserved IP %s", reservation.IPAddress)
+		}
+		if !ipPoolContainsIP(ipPool, ip) {
+			return false, fmt.Sprintf("Reserved IP %s is not in any IPRange", reservation.IPAddress)
+		}
+		if reservedIPs.Has(ip.String()) {
+			return false, fmt.Sprintf("IP %s is reserved more than once", reservation.IPAddress)
+		}
+		reservedIPs.Insert(ip.String())
+		podKey := k8s.NamespacedName(reservation.Namespace, reservation.PodName)
+		if reservedPods.Has(podKey) {
+			return false, fmt.Sprintf("Pod %s has more than one reserved IP", podKey)
+		}
+		reservedPods.Insert(podKey)
+	}
+	return true, ""
+}
+
+// ipPoolContainsIP returns whether the IP is in one of the IP ranges of the IPPool and is not the gateway IP of the
+// IP range.
+func ipPoolContainsIP(ipPool *crdv1alpha2.IPPool, ip net.IP) bool {
+	for _, r := range ipPool.Spec.IPRanges {
+		if ip.Equal(net.ParseIP(r.Gateway)) {
+			continue
+		}
+		if r.CIDR != "" {
+			_, cidr, _ := net.ParseCIDR(r.CIDR)
+			if cidr != nil && cidr.Contains(ip) {
+				return true
+			}
+		} else if ipInRange(net.ParseIP(r.Start), net.ParseIP(r.End), ip) {
+			return true
+		}
+	}
+	return false
+}
+
 func validationResult(allowed bool, msg string) *admv1.AdmissionResponse {
 	var result *metav1.Status
 
@@ -212,6 +212,74 @@ func TestEgressControllerValidateExternalIPPool(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "CREATE operation with gatewayProxy for IPv6 IPPool should not be allowed",
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "CREATE",
+				Object: runtime.RawExtension{Raw: marshal(&crdv1alpha2.IPPool{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-ipv6-pool"},
+					Spec: crdv1alpha2.IPPoolSpec{
+						IPVersion: crdv1alpha2.IPv6,
+						IPRanges: []crdv1alpha2.SubnetIPRange{
+							{
+								IPRange:    crdv1alpha2.IPRange{CIDR: "10:2400::0/96"},
+								SubnetInfo: crdv1alpha2.SubnetInfo{Gateway: "10:2400::01", PrefixLength: 64},
+							},
+						},
+						GatewayProxy: true,
+					},
+				})},
+			},
+			expectedResponse: &admv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "gatewayProxy is only supported for IPv4 IPPools",
+				},
+			},
+		},
+		{
+			name: "Enabling gatewayProxy should be allowed",
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "UPDATE",
+				OldObject: runtime.RawExtension{Raw: marshal(testIPPool)},
+				Object: runtime.RawExtension{Raw: marshal(copyAndMutateIPPool(testIPPool, func(pool *crdv1alpha2.IPPool) {
+					pool.Spec.GatewayProxy = true
+				}))},
+			},
+			expectedResponse: &admv1.AdmissionResponse{Allowed: true},
+		},
+		{
+			name: "CREATE operation with invalid podSelector should not be allowed",
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "CREATE",
+				Object: runtime.RawExtension{Raw: marshal(copyAndMutateIPPool(testIPPool, func(pool *crdv1alpha2.IPPool) {
+					pool.Spec.PodSelector = &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Foo"}},
+					}
+				}))},
+			},
+			expectedResponse: &admv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: `Invalid podSelector: "Foo" is not a valid label selector operator`,
+				},
+			},
+		},
+		{
+			name: "Adding podSelector should be allowed",
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "UPDATE",
+				OldObject: runtime.RawExtension{Raw: marshal(testIPPool)},
+				Object: runtime.RawExtension{Raw: marshal(copyAndMutateIPPool(testIPPool, func(pool *crdv1alpha2.IPPool) {
+					pool.Spec.PodSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "frontend"}}
+				}))},
+			},
+			expectedResponse: &admv1.AdmissionResponse{Allowed: true},
+		},
 		{
 			name: "Deleting IPRange should not be allowed",
 			request: &admv1.AdmissionRequest{
@@ -292,6 +360,96 @@ func TestEgressControllerValidateExternalIPPool(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "CREATE operation with reservations should be allowed",
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "CREATE",
+				Object: runtime.RawExtension{Raw: marshal(copyAndMutateIPPool(testIPPool, func(pool *crdv1alpha2.IPPool) {
+					pool.Spec.Reservations = []crdv1alpha2.IPReservation{
+						{IPAddress: "192.168.0.10", Namespace: "ns1", PodName: "pod1"},
+						{IPAddress: "192.168.3.10", Namespace: "ns1", PodName: "web-0"},
+					}
+				}))},
+			},
+			expectedResponse: &admv1.AdmissionResponse{Allowed: true},
+		},
+		{
+			name: "CREATE operation with reserved IP out of IPRanges should not be allowed",
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "CREATE",
+				Object: runtime.RawExtension{Raw: marshal(copyAndMutateIPPool(testIPPool, func(pool *crdv1alpha2.IPPool) {
+					pool.Spec.Reservations = []crdv1alpha2.IPReservation{
+						{IPAddress: "192.168.3.21", Namespace: "ns1", PodName: "pod1"},
+					}
+				}))},
+			},
+			expectedResponse: &admv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "Reserved IP 192.168.3.21 is not in any IPRange",
+				},
+			},
+		},
+		{
+			name: "CREATE operation with reserved gateway IP should not be allowed",
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "CREATE",
+				Object: runtime.RawExtension{Raw: marshal(copyAndMutateIPPool(testIPPool, func(pool *crdv1alpha2.IPPool) {
+					pool.Spec.Reservations = []crdv1alpha2.IPReservation{
+						{IPAddress: "192.168.0.1", Namespace: "ns1", PodName: "pod1"},
+					}
+				}))},
+			},
+			expectedResponse: &admv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "Reserved IP 192.168.0.1 is not in any IPRange",
+				},
+			},
+		},
+		{
+			name: "Reserving IP more than once should not be allowed",
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "UPDATE",
+				OldObject: runtime.RawExtension{Raw: marshal(testIPPool)},
+				Object: runtime.RawExtension{Raw: marshal(copyAndMutateIPPool(testIPPool, func(pool *crdv1alpha2.IPPool) {
+					pool.Spec.Reservations = []crdv1alpha2.IPReservation{
+						{IPAddress: "192.168.0.10", Namespace: "ns1", PodName: "pod1"},
+						{IPAddress: "192.168.0.10", Namespace: "ns1", PodName: "pod2"},
+					}
+				}))},
+			},
+			expectedResponse: &admv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "IP 192.168.0.10 is reserved more than once",
+				},
+			},
+		},
+		{
+			name: "Reserving more than one IP for Pod should not be allowed",
+			request: &admv1.AdmissionRequest{
+				Name:      "foo",
+				Operation: "UPDATE",
+				OldObject: runtime.RawExtension{Raw: marshal(testIPPool)},
+				Object: runtime.RawExtension{Raw: marshal(copyAndMutateIPPool(testIPPool, func(pool *crdv1alpha2.IPPool) {
+					pool.Spec.Reservations = []crdv1alpha2.IPReservation{
+						{IPAddress: "192.168.0.10", Namespace: "ns1", PodName: "pod1"},
+						{IPAddress: "192.168.1.10", Namespace: "ns1", PodName: "pod1"},
+					}
+				}))},
+			},
+			expectedResponse: &admv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "Pod ns1/pod1 has more than one reserved IP",
+				},
+			},
+		},
 		{
 			name: "Deleting IPPool in use should not be allowed",
 			request: &admv1.AdmissionRequest{
@@ -117,6 +117,20 @@ var (
 		Help:           "The total number of actual status updates performed for Antrea ClusterNetworkPolicy Custom Resources",
 		StabilityLevel: metrics.ALPHA,
 	})
+	IPPoolTotalIPs = metrics.NewGaugeVec(&metrics.GaugeOpts{
+		Namespace:      metricNamespaceAntrea,
+		Subsystem:      metricSubsystemController,
+		Name:           "ippool_total_ips",
+		Help:           "The number of IPs in the IP ranges of an IPPool, partitioned by IPPool",
+		StabilityLevel: metrics.ALPHA,
+	}, []string{"ippool"})
+	IPPoolUsedIPs = metrics.NewGaugeVec(&metrics.GaugeOpts{
+		Namespace:      metricNamespaceAntrea,
+		Subsystem:      metricSubsystemController,
+		Name:           "ippool_used_ips",
+		Help:           "The number of IPs allocated, preallocated or reserved from an IPPool, partitioned by IPPool",
+		StabilityLevel: metrics.ALPHA,
+	}, []string{"ippool"})
 )
 
 // Initialize Prometheus metrics collection.
@@ -156,4 +170,10 @@ func InitializePrometheusMetrics() {
 	if err := legacyregistry.Register(AntreaClusterNetworkPolicyStatusUpdates); err != nil {
 		klog.Errorf("Failed to register antrea_controller_acnp_status_updates with Prometheus: %s", err.Error())
 	}
+	if err := legacyregistry.Register(IPPoolTotalIPs); err != nil {
+		klog.Errorf("Failed to register antrea_controller_ippool_total_ips with Prometheus: %s", err.Error())
+	}
+	if err := legacyregistry.Register(IPPoolUsedIPs); err != nil {
+		klog.Errorf("Failed to register antrea_controller_ippool_used_ips with Prometheus: %s", err.Error())
+	}
 }
@@ -98,6 +98,7 @@ func (n *NetworkPolicyController) processAntreaNetworkPolicy(np *crdv1alpha1.Net
 	for idx, ingressRule := range np.Spec.Ingress {
 		// Set default action to ALLOW to allow traffic.
 		services, namedPortExists := toAntreaServicesForCRD(ingressRule.Ports, ingressRule.Protocols)
+		l7Protocols := toAntreaL7ProtocolsForCRD(ingressRule.L7Protocols)
 		// Create AppliedToGroup for each AppliedTo present in the ingress rule.
 		atgs := n.processAppliedTo(np.Namespace, ingressRule.AppliedTo)
 		appliedToGroups = mergeAppliedToGroups(appliedToGroups, atgs...)
@@ -107,22 +108,25 @@ func (n *NetworkPolicyController) processAntreaNetworkPolicy(np *crdv1alpha1.Net
 		}
 		addressGroups = mergeAddressGroups(addressGroups, ags...)
 		rules = append(rules, controlplane.NetworkPolicyRule{
-			Direction:       controlplane.DirectionIn,
-			From:            *peer,
-			Services:        services,
-			Name:            ingressRule.Name,
-			Action:          ingressRule.Action,
-			Priority:        int32(idx),
-			EnableLogging:   ingressRule.EnableLogging,
-			AppliedToGroups: getAppliedToGroupNames(atgs),
-			L7Protocols:     toAntreaL7ProtocolsForCRD(ingressRule.L7Protocols),
-			LogLabel:        ingressRule.LogLabel,
+			Direction:        controlplane.DirectionIn,
+			From:             *peer,
+			Services:         services,
+			Name:             ingressRule.Name,
+			Action:           ingressRule.Action,
+			Priority:         int32(idx),
+			EnableLogging:    ingressRule.EnableLogging,
+			AppliedToGroups:  getAppliedToGroupNames(atgs),
+			L7Protocols:      l7Protocols,
+			LogLabel:         ingressRule.LogLabel,
+			RequiredFeatures: getRequiredFeatures(ingressRule.Action, services, l7Protocols),
+			RateLimit:        toAntreaRateLimitForCRD(ingressRule.RateLimit),
 		})
 	}
 	// Compute NetworkPolicyRule for Egress Rule.
 	for idx, egressRule := range np.Spec.Egress {
 		// Set default action to ALLOW to allow traffic.
 		services, namedPortExists := toAntreaServicesForCRD(egressRule.Ports, egressRule.Protocols)
+		l7Protocols := toAntreaL7ProtocolsForCRD(egressRule.L7Protocols)
 		// Create AppliedToGroup for each AppliedTo present in the egress rule.
 		atgs := n.processAppliedTo(np.Namespace, egressRule.AppliedTo)
 		appliedToGroups = mergeAppliedToGroups(appliedToGroups, atgs...)
@@ -139,16 +143,18 @@ func (n *NetworkPolicyController) processAntreaNetworkPolicy(np *crdv1alpha1.Net
 			}
 		}
 		rules = append(rules, controlplane.NetworkPolicyRule{
-			Direction:       controlplane.DirectionOut,
-			To:              *peer,
-			Services:        services,
-			Name:            egressRule.Name,
-			Action:          egressRule.Action,
-			Priority:        int32(idx),
-			EnableLogging:   egressRule.EnableLogging,
-			AppliedToGroups: getAppliedToGroupNames(atgs),
-			L7Protocols:     toAntreaL7ProtocolsForCRD(egressRule.L7Protocols),
-			LogLabel:        egressRule.LogLabel,
+			Direction:        controlplane.DirectionOut,
+			To:               *peer,
+			Services:         services,
+			Name:             egressRule.Name,
+			Action:           egressRule.Action,
+			Priority:         int32(idx),
+			EnableLogging:    egressRule.EnableLogging,
+			AppliedToGroups:  getAppliedToGroupNames(atgs),
+			L7Protocols:      l7Protocols,
+			LogLabel:         egressRule.LogLabel,
+			RequiredFeatures: getRequiredFeatures(egressRule.Action, services, l7Protocols),
+			RateLimit:        toAntreaRateLimitForCRD(egressRule.RateLimit),
 		})
 	}
 	tierPriority := n.getTierPriority(np.Spec.Tier)
@@ -167,6 +173,7 @@ func (n *NetworkPolicyController) processAntreaNetworkPolicy(np *crdv1alpha1.Net
 		Priority:         &np.Spec.Priority,
 		TierPriority:     &tierPriority,
 		AppliedToPerRule: appliedToPerRule,
+		Annotations:      getAgentAnnotations(np.Annotations),
 	}
 	if n.stretchNPEnabled {
 		n.labelIdentityInterface.RemoveStalePolicySelectors(clusterSetScopeSelectorKeys, internalNetworkPolicyKeyFunc(np))
@@ -190,6 +197,24 @@ func (n *NetworkPolicyController) processAppliedTo(namespace string, appliedTo [
 	return appliedToGroups
 }
 
+// getAgentAnnotations returns the annotations of an Antrea-native policy which are consumed by the Antrea Agents, i.e.
+// the audit logging settings and the L7 fail mode, and are passed to them with the internal NetworkPolicy. It returns
+// nil if none of them is set.
+func getAgentAnnotations(annotations map[string]string) map[string]string {
+	var agentAnnotations map[string]string
+	for _, key := range []string{crdv1alpha1.AuditLogFileAnnotationKey, crdv1alpha1.AuditLogRateLimitAnnotationKey, crdv1alpha1.AuditLogSeverityAnnotationKey, crdv1alpha1.L7FailModeAnnotationKey} {
+		value, exists := annotations[key]
+		if !exists {
+			continue
+		}
+		if agentAnnotations == nil {
+			agentAnnotations = map[string]string{}
+		}
+		agentAnnotations[key] = value
+	}
+	return agentAnnotations
+}
+
 // ErrNetworkPolicyAppliedToUnsupportedGroup is an error response when
 // a Group with Pods in other Namespaces is used as AppliedTo.
 type ErrNetworkPolicyAppliedToUnsupportedGroup struct {
@@ -561,6 +561,75 @@ func TestProcessAntreaNetworkPolicy(t *testing.T) {
 			expectedAppliedToGroups: 1,
 			expectedAddressGroups:   1,
 		},
+		{
+			name: "rules-with-service-account",
+			inputPolicy: &crdv1alpha1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns6", Name: "npG", UID: "uidG"},
+				Spec: crdv1alpha1.NetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{PodSelector: &selectorA},
+					},
+					Priority: p10,
+					Ingress: []crdv1alpha1.Rule{
+						{
+							Ports: []crdv1alpha1.NetworkPolicyPort{
+								{
+									Port: &int80,
+								},
+							},
+							From: []crdv1alpha1.NetworkPolicyPeer{
+								{
+									ServiceAccount: &crdv1alpha1.NamespacedName{
+										Name: "sa1",
+									},
+								},
+								{
+									ServiceAccount: &crdv1alpha1.NamespacedName{
+										Name:      "sa2",
+										Namespace: "ns7",
+									},
+								},
+							},
+							Action: &allowAction,
+						},
+					},
+				},
+			},
+			expectedPolicy: &antreatypes.NetworkPolicy{
+				UID:  "uidG",
+				Name: "uidG",
+				SourceRef: &controlplane.NetworkPolicyReference{
+					Type:      controlplane.AntreaNetworkPolicy,
+					Namespace: "ns6",
+					Name:      "npG",
+					UID:       "uidG",
+				},
+				Priority:     &p10,
+				TierPriority: &DefaultTierPriority,
+				Rules: []controlplane.NetworkPolicyRule{
+					{
+						Direction: controlplane.DirectionIn,
+						From: controlplane.NetworkPolicyPeer{
+							AddressGroups: []string{
+								getNormalizedUID(antreatypes.NewGroupSelector("ns6", serviceAccountNameToPodSelector("sa1"), nil, nil, nil).NormalizedName),
+								getNormalizedUID(antreatypes.NewGroupSelector("ns7", serviceAccountNameToPodSelector("sa2"), nil, nil, nil).NormalizedName),
+							},
+						},
+						Services: []controlplane.Service{
+							{
+								Protocol: &protocolTCP,
+								Port:     &int80,
+							},
+						},
+						Priority: 0,
+						Action:   &allowAction,
+					},
+				},
+				AppliedToGroups: []string{getNormalizedUID(antreatypes.NewGroupSelector("ns6", &selectorA, nil, nil, nil).NormalizedName)},
+			},
+			expectedAppliedToGroups: 1,
+			expectedAddressGroups:   2,
+		},
 		{
 			name: "rules-with-icmp-protocol",
 			inputPolicy: &crdv1alpha1.NetworkPolicy{
@@ -663,9 +732,10 @@ func TestProcessAntreaNetworkPolicy(t *testing.T) {
 						From: controlplane.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(antreatypes.NewGroupSelector("", &selectorB, &selectorC, nil, nil).NormalizedName)},
 						},
-						L7Protocols: []controlplane.L7Protocol{{HTTP: &controlplane.HTTPProtocol{Host: "test.com", Method: "GET", Path: "/admin"}}},
-						Priority:    0,
-						Action:      &allowAction,
+						L7Protocols:      []controlplane.L7Protocol{{HTTP: &controlplane.HTTPProtocol{Host: "test.com", Method: "GET", Path: "/admin"}}},
+						RequiredFeatures: []string{controlplane.NetworkPolicyFeatureL7Protocols},
+						Priority:         0,
+						Action:           &allowAction,
 					},
 				},
 				AppliedToGroups: []string{getNormalizedUID(antreatypes.NewGroupSelector("ns8", &selectorA, nil, nil, nil).NormalizedName)},
@@ -736,6 +806,72 @@ func TestProcessAntreaNetworkPolicy(t *testing.T) {
 			expectedAppliedToGroups: 1,
 			expectedAddressGroups:   1,
 		},
+		{
+			name: "with-audit-logging-annotations",
+			inputPolicy: &crdv1alpha1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns10",
+					Name:      "npJ",
+					UID:       "uidJ",
+					Annotations: map[string]string{
+						crdv1alpha1.AuditLogFileAnnotationKey:      "npJ.log",
+						crdv1alpha1.AuditLogRateLimitAnnotationKey: "10",
+						crdv1alpha1.AuditLogSeverityAnnotationKey:  "High",
+						crdv1alpha1.L7FailModeAnnotationKey:        "open",
+						"foo":                                      "bar",
+					},
+				},
+				Spec: crdv1alpha1.NetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{PodSelector: &selectorA},
+					},
+					Priority: p10,
+					Ingress: []crdv1alpha1.Rule{
+						{
+							From: []crdv1alpha1.NetworkPolicyPeer{
+								{
+									PodSelector: &selectorB,
+								},
+							},
+							Action:        &allowAction,
+							EnableLogging: true,
+						},
+					},
+				},
+			},
+			expectedPolicy: &antreatypes.NetworkPolicy{
+				UID:  "uidJ",
+				Name: "uidJ",
+				SourceRef: &controlplane.NetworkPolicyReference{
+					Type:      controlplane.AntreaNetworkPolicy,
+					Namespace: "ns10",
+					Name:      "npJ",
+					UID:       "uidJ",
+				},
+				Priority:     &p10,
+				TierPriority: &DefaultTierPriority,
+				Rules: []controlplane.NetworkPolicyRule{
+					{
+						Direction: controlplane.DirectionIn,
+						From: controlplane.NetworkPolicyPeer{
+							AddressGroups: []string{getNormalizedUID(antreatypes.NewGroupSelector("ns10", &selectorB, nil, nil, nil).NormalizedName)},
+						},
+						Priority:      0,
+						Action:        &allowAction,
+						EnableLogging: true,
+					},
+				},
+				AppliedToGroups: []string{getNormalizedUID(antreatypes.NewGroupSelector("ns10", &selectorA, nil, nil, nil).NormalizedName)},
+				Annotations: map[string]string{
+					crdv1alpha1.AuditLogFileAnnotationKey:      "npJ.log",
+					crdv1alpha1.AuditLogRateLimitAnnotationKey: "10",
+					crdv1alpha1.AuditLogSeverityAnnotationKey:  "High",
+					crdv1alpha1.L7FailModeAnnotationKey:        "open",
+				},
+			},
+			expectedAppliedToGroups: 1,
+			expectedAddressGroups:   1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
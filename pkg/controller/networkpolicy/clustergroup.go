@@ -65,14 +65,7 @@ func (c *NetworkPolicyController) updateClusterGroup(oldObj, curObj interface{})
 		return true
 	}
 	ipBlocksUpdated := func() bool {
-		oldIPBs, newIPBs := sets.Set[string]{}, sets.Set[string]{}
-		for _, ipb := range oldGroup.IPBlocks {
-			oldIPBs.Insert(ipb.CIDR.String())
-		}
-		for _, ipb := range newGroup.IPBlocks {
-			newIPBs.Insert(ipb.CIDR.String())
-		}
-		return !oldIPBs.Equal(newIPBs)
+		return !ipBlocksEqual(oldGroup.IPBlocks, newGroup.IPBlocks)
 	}
 	childGroupsUpdated := func() bool {
 		oldChildGroups, newChildGroups := sets.Set[string]{}, sets.Set[string]{}
@@ -128,16 +121,8 @@ func (c *NetworkPolicyController) processClusterGroup(cg *crdv1alpha3.ClusterGro
 		}
 		return &internalGroup
 	}
-	if len(cg.Spec.IPBlocks) > 0 {
-		for i := range cg.Spec.IPBlocks {
-			ipb, _ := toAntreaIPBlockForCRD(&cg.Spec.IPBlocks[i])
-			internalGroup.IPBlocks = append(internalGroup.IPBlocks, *ipb)
-			// CIDR format is already validated by the webhook
-			_, ipNet, _ := net.ParseCIDR(cg.Spec.IPBlocks[i].CIDR)
-			internalGroup.IPNets = append(internalGroup.IPNets, *ipNet)
-		}
-		return &internalGroup
-	}
+	// IPBlocks can be set together with a ServiceReference.
+	setInternalGroupIPBlocks(&internalGroup, cg.Spec.IPBlocks)
 	svcSelector := cg.Spec.ServiceReference
 	if svcSelector != nil {
 		// ServiceReference will be converted to groupSelector once the internalGroup is synced.
@@ -145,7 +130,7 @@ func (c *NetworkPolicyController) processClusterGroup(cg *crdv1alpha3.ClusterGro
 			Namespace: svcSelector.Namespace,
 			Name:      svcSelector.Name,
 		}
-	} else {
+	} else if len(cg.Spec.IPBlocks) == 0 {
 		groupSelector := antreatypes.NewGroupSelector("", cg.Spec.PodSelector, cg.Spec.NamespaceSelector, cg.Spec.ExternalEntitySelector, nil)
 		internalGroup.Selector = groupSelector
 	}
@@ -249,6 +234,7 @@ func (c *NetworkPolicyController) syncInternalClusterGroup(grp *antreatypes.Grou
 			Selector:         grp.Selector,
 			IPBlocks:         grp.IPBlocks,
 			IPNets:           grp.IPNets,
+			ExceptIPNets:     grp.ExceptIPNets,
 			ServiceReference: grp.ServiceReference,
 			ChildGroups:      grp.ChildGroups,
 		}
@@ -397,6 +383,15 @@ func (c *NetworkPolicyController) GetAssociatedGroups(name, namespace string) []
 	return groupObjs[:j]
 }
 
+func ipNetsContain(ipNets []net.IPNet, ip net.IP) bool {
+	for _, ipNet := range ipNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // getAssociatedGroupsByName retrieves the internal Group and all it's parent Group objects
 // (if any) by Group name.
 func (c *NetworkPolicyController) getAssociatedGroupsByName(grpName string) []antreatypes.Group {
@@ -422,8 +417,8 @@ func (c *NetworkPolicyController) getParentGroups(grpName string) []antreatypes.
 	return groups
 }
 
-// GetGroupMembers returns the current members of a ClusterGroup.
-// If the ClusterGroup is defined with IPBlocks, the returned members will be []controlplane.IPBlock.
+// GetGroupMembers returns the current members of a ClusterGroup, or of a Group when cgName is in the format of
+// "namespace/name". If the group is defined with IPBlocks, the returned members will be []controlplane.IPBlock.
 // Otherwise, the returned members will be of type controlplane.GroupMemberSet.
 func (c *NetworkPolicyController) GetGroupMembers(cgName string) (controlplane.GroupMemberSet, []controlplane.IPBlock, error) {
 	groupObj, found, _ := c.internalGroupStore.Get(cgName)
@@ -440,8 +435,8 @@ func (c *NetworkPolicyController) GetAssociatedIPBlockGroups(ip net.IP) []antrea
 	var matchedGroups []antreatypes.Group
 	for _, obj := range ipBlockGroupObjs {
 		group := obj.(*antreatypes.Group)
-		for _, ipNet := range group.IPNets {
-			if ipNet.Contains(ip) {
+		for i, ipNet := range group.IPNets {
+			if ipNet.Contains(ip) && (group.ExceptIPNets == nil || !ipNetsContain(group.ExceptIPNets[i], ip)) {
 				matchedGroups = append(matchedGroups, *group)
 				// Append all parent groups to matchedGroups
 				parentGroups := c.getParentGroups(group.SourceReference.ToGroupName())
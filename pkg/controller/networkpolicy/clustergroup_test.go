@@ -41,6 +41,9 @@ func TestProcessClusterGroup(t *testing.T) {
 	cidr := "10.0.0.0/24"
 	controlplaneIPNet, _ := cidrStrToIPNet(cidr)
 	_, ipNet, _ := net.ParseCIDR(cidr)
+	exceptCIDR := "10.0.0.128/25"
+	controlplaneExceptIPNet, _ := cidrStrToIPNet(exceptCIDR)
+	_, exceptIPNet, _ := net.ParseCIDR(exceptCIDR)
 	tests := []struct {
 		name          string
 		inputGroup    *crdv1alpha3.ClusterGroup
@@ -148,6 +151,43 @@ func TestProcessClusterGroup(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "cg-with-svc-reference-and-ip-block-with-except",
+			inputGroup: &crdv1alpha3.ClusterGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "cgG", UID: "uidG"},
+				Spec: crdv1alpha3.GroupSpec{
+					ServiceReference: &crdv1alpha1.NamespacedName{
+						Name:      "test-svc",
+						Namespace: "test-ns",
+					},
+					IPBlocks: []crdv1alpha1.IPBlock{
+						{
+							CIDR:   cidr,
+							Except: []string{exceptCIDR},
+						},
+					},
+				},
+			},
+			expectedGroup: &antreatypes.Group{
+				UID: "uidG",
+				SourceReference: &controlplane.GroupReference{
+					Name: "cgG",
+					UID:  "uidG",
+				},
+				ServiceReference: &controlplane.ServiceReference{
+					Name:      "test-svc",
+					Namespace: "test-ns",
+				},
+				IPBlocks: []controlplane.IPBlock{
+					{
+						CIDR:   *controlplaneIPNet,
+						Except: []controlplane.IPNet{*controlplaneExceptIPNet},
+					},
+				},
+				IPNets:       []net.IPNet{*ipNet},
+				ExceptIPNets: [][]net.IPNet{{*exceptIPNet}},
+			},
+		},
 		{
 			name: "cg-with-child-groups",
 			inputGroup: &crdv1alpha3.ClusterGroup{
@@ -1150,8 +1190,16 @@ func TestGetAssociatedIPBlockGroups(t *testing.T) {
 			},
 		},
 	}
+	cg3 := &crdv1alpha3.ClusterGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "ipBlockExceptGrp", UID: "UID4"},
+		Spec: crdv1alpha3.GroupSpec{
+			IPBlocks: []crdv1alpha1.IPBlock{
+				{CIDR: "172.70.0.0/16", Except: []string{"172.70.1.0/24"}},
+			},
+		},
+	}
 
-	_, npc := newControllerWithoutEventHandler(nil, []runtime.Object{cg1, cg2, cg2Parent})
+	_, npc := newControllerWithoutEventHandler(nil, []runtime.Object{cg1, cg2, cg2Parent, cg3})
 	stopCh := make(chan struct{})
 	defer close(stopCh)
 	npc.crdInformerFactory.Start(stopCh)
@@ -1163,6 +1211,8 @@ func TestGetAssociatedIPBlockGroups(t *testing.T) {
 	npc.syncInternalGroup(internalGroupKeyFunc(cg2))
 	npc.addClusterGroup(cg2Parent)
 	npc.syncInternalGroup(internalGroupKeyFunc(cg2Parent))
+	npc.addClusterGroup(cg3)
+	npc.syncInternalGroup(internalGroupKeyFunc(cg3))
 
 	tests := []struct {
 		name           string
@@ -1184,6 +1234,16 @@ func TestGetAssociatedIPBlockGroups(t *testing.T) {
 			ipQuery:        net.ParseIP("172.160.0.1"),
 			expectedGroups: []string{},
 		},
+		{
+			name:           "group-association-outside-except",
+			ipQuery:        net.ParseIP("172.70.2.1"),
+			expectedGroups: []string{"ipBlockExceptGrp"},
+		},
+		{
+			name:           "no-group-association-in-except",
+			ipQuery:        net.ParseIP("172.70.1.1"),
+			expectedGroups: []string{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -16,6 +16,7 @@ package networkpolicy
 
 import (
 	"reflect"
+	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -111,7 +112,7 @@ func (n *NetworkPolicyController) filterPerNamespaceRuleACNPsByNSLabels(nsLabels
 
 	peerNamespacesSelectorExists := func(peers []crdv1alpha1.NetworkPolicyPeer) bool {
 		for _, peer := range peers {
-			if peer.Namespaces != nil && peer.Namespaces.Match == crdv1alpha1.NamespaceMatchSelf {
+			if isPerNamespacePeer(peer) {
 				return true
 			}
 		}
@@ -179,6 +180,13 @@ func (n *NetworkPolicyController) updateNamespace(oldObj, curObj interface{}) {
 		affectedACNPsByOldLabels := n.filterPerNamespaceRuleACNPsByNSLabels(oldNamespace.Labels)
 		affectedACNPsByCurLabels := n.filterPerNamespaceRuleACNPsByNSLabels(curNamespace.Labels)
 		affectedACNPs := utilsets.SymmetricDifferenceString(affectedACNPsByOldLabels, affectedACNPsByCurLabels)
+		// A ClusterNetworkPolicy applied to the Namespace before and after the update is also affected if
+		// the values of any of its sameLabels keys change, as the Namespace is moved to another group.
+		for cnpName := range affectedACNPsByOldLabels.Intersection(affectedACNPsByCurLabels) {
+			if cnp, err := n.acnpLister.Get(cnpName); err == nil && sameLabelsValuesChanged(cnp, oldNamespace.Labels, curNamespace.Labels) {
+				affectedACNPs.Insert(cnpName)
+			}
+		}
 		for cnpName := range affectedACNPs {
 			// Ignore the ClusterNetworkPolicy if it has been removed during the process.
 			if cnp, err := n.acnpLister.Get(cnpName); err == nil {
@@ -235,6 +243,19 @@ func (c *NetworkPolicyController) filterAGsFromNodeLabels(node *v1.Node) sets.Se
 	return ags
 }
 
+func (c *NetworkPolicyController) filterATGsFromNodeLabels(node *v1.Node) sets.Set[string] {
+	atgs := sets.New[string]()
+	appliedToGroupObjs, _ := c.appliedToGroupStore.GetByIndex(store.IsAppliedToNodeIndex, "true")
+	for _, appliedToGroupObj := range appliedToGroupObjs {
+		appliedToGroup := appliedToGroupObj.(*antreatypes.AppliedToGroup)
+		nodeSelector := appliedToGroup.Selector.NodeSelector
+		if nodeSelector.Matches(labels.Set(node.GetLabels())) {
+			atgs.Insert(appliedToGroup.Name)
+		}
+	}
+	return atgs
+}
+
 func (c *NetworkPolicyController) getATGsAppliedToService() sets.Set[string] {
 	atgs := sets.New[string]()
 	appliedToGroupObjs, _ := c.appliedToGroupStore.GetByIndex(store.IsAppliedToServiceIndex, "true")
@@ -253,10 +274,11 @@ func (c *NetworkPolicyController) addNode(obj interface{}) {
 	}
 	// All AppliedToGroups that are applied to Services need re-sync.
 	affectedATGs := c.getATGsAppliedToService()
+	affectedATGs = utilsets.MergeString(affectedATGs, c.filterATGsFromNodeLabels(node))
 	for key := range affectedATGs {
 		c.enqueueAppliedToGroup(key)
 	}
-	klog.V(2).InfoS("Processed Node CREATE event", "nodeName", node.Name, "affectedAGs", affectedAGs.Len())
+	klog.V(2).InfoS("Processed Node CREATE event", "nodeName", node.Name, "affectedAGs", affectedAGs.Len(), "affectedATGs", affectedATGs.Len())
 }
 
 func (c *NetworkPolicyController) deleteNode(obj interface{}) {
@@ -280,10 +302,11 @@ func (c *NetworkPolicyController) deleteNode(obj interface{}) {
 	}
 	// All AppliedToGroups that are applied to Services need re-sync.
 	affectedATGs := c.getATGsAppliedToService()
+	affectedATGs = utilsets.MergeString(affectedATGs, c.filterATGsFromNodeLabels(node))
 	for key := range affectedATGs {
 		c.enqueueAppliedToGroup(key)
 	}
-	klog.V(2).InfoS("Processed Node DELETE event", "nodeName", node.Name, "affectedAGs", affectedAGs.Len())
+	klog.V(2).InfoS("Processed Node DELETE event", "nodeName", node.Name, "affectedAGs", affectedAGs.Len(), "affectedATGs", affectedATGs.Len())
 }
 
 func nodeIPChanged(oldNode, newNode *v1.Node) (changed bool) {
@@ -303,18 +326,25 @@ func (c *NetworkPolicyController) updateNode(oldObj, newObj interface{}) {
 	}
 
 	affectedAGs := c.filterAGsFromNodeLabels(node)
+	affectedATGs := c.filterATGsFromNodeLabels(node)
 	if labelsChanged {
 		oldAGs := c.filterAGsFromNodeLabels(oldNode)
+		oldATGs := c.filterATGsFromNodeLabels(oldNode)
 		if ipChanged {
 			affectedAGs = utilsets.MergeString(affectedAGs, oldAGs)
+			affectedATGs = utilsets.MergeString(affectedATGs, oldATGs)
 		} else {
 			affectedAGs = utilsets.SymmetricDifferenceString(affectedAGs, oldAGs)
+			affectedATGs = utilsets.SymmetricDifferenceString(affectedATGs, oldATGs)
 		}
 	}
 	for ag := range affectedAGs {
 		c.enqueueAddressGroup(ag)
 	}
-	klog.V(2).InfoS("Processed Node UPDATE event", "nodeName", node.Name, "affectedAGs", affectedAGs.Len())
+	for atg := range affectedATGs {
+		c.enqueueAppliedToGroup(atg)
+	}
+	klog.V(2).InfoS("Processed Node UPDATE event", "nodeName", node.Name, "affectedAGs", affectedAGs.Len(), "affectedATGs", affectedATGs.Len())
 }
 
 // processClusterNetworkPolicy creates an internal NetworkPolicy instance
@@ -326,8 +356,8 @@ func (c *NetworkPolicyController) updateNode(oldObj, newObj interface{}) {
 func (n *NetworkPolicyController) processClusterNetworkPolicy(cnp *crdv1alpha1.ClusterNetworkPolicy) (*antreatypes.NetworkPolicy, map[string]*antreatypes.AppliedToGroup, map[string]*antreatypes.AddressGroup) {
 	hasPerNamespaceRule := hasPerNamespaceRule(cnp)
 	// If one of the ACNP rule is a per-namespace rule (a peer in that rule has namespaces.Match set
-	// to Self), the policy will need to be converted to appliedTo per rule policy, as the appliedTo
-	// will be different for rules created for each namespace.
+	// to Self or namespaces.SameLabels set), the policy will need to be converted to appliedTo per
+	// rule policy, as the appliedTo will be different for rules created for each namespace.
 	appliedToPerRule := len(cnp.Spec.AppliedTo) == 0 || hasPerNamespaceRule
 	// appliedToGroups tracks all distinct appliedToGroups referred to by the ClusterNetworkPolicy,
 	// either in the spec section or in ingress/egress rules.
@@ -345,39 +375,27 @@ func (n *NetworkPolicyController) processClusterNetworkPolicy(cnp *crdv1alpha1.C
 	// be used to remove any stale selector from the policy in the labelIdentityInterface.
 	var clusterSetScopeSelectorKeys sets.Set[string]
 	if hasPerNamespaceRule && len(cnp.Spec.AppliedTo) > 0 {
-		for _, at := range cnp.Spec.AppliedTo {
-			if at.ServiceAccount != nil {
-				atg := n.createAppliedToGroup(at.ServiceAccount.Namespace, serviceAccountNameToPodSelector(at.ServiceAccount.Name), nil, nil)
-				appliedToGroups = mergeAppliedToGroups(appliedToGroups, atg)
-				clusterAppliedToAffectedNS = append(clusterAppliedToAffectedNS, at.ServiceAccount.Namespace)
-				atgForNamespace = append(atgForNamespace, atg)
-			} else {
-				affectedNS := n.getAffectedNamespacesForAppliedTo(at)
-				for _, ns := range affectedNS {
-					atg := n.createAppliedToGroup(ns, at.PodSelector, nil, at.ExternalEntitySelector)
-					appliedToGroups = mergeAppliedToGroups(appliedToGroups, atg)
-					clusterAppliedToAffectedNS = append(clusterAppliedToAffectedNS, ns)
-					atgForNamespace = append(atgForNamespace, atg)
-				}
-			}
-		}
+		clusterAppliedToAffectedNS, atgForNamespace = n.splitAppliedToByNamespace(cnp.Spec.AppliedTo)
 	}
 	var rules []controlplane.NetworkPolicyRule
 	processRules := func(cnpRules []crdv1alpha1.Rule, direction controlplane.Direction) {
 		for idx, cnpRule := range cnpRules {
 			services, namedPortExists := toAntreaServicesForCRD(cnpRule.Ports, cnpRule.Protocols)
-			clusterPeers, perNSPeers := splitPeersByScope(cnpRule, direction)
+			l7Protocols := toAntreaL7ProtocolsForCRD(cnpRule.L7Protocols)
+			clusterPeers, perNSPeers, sameLabelsPeers := splitPeersByScope(cnpRule, direction)
 			addRule := func(peer *controlplane.NetworkPolicyPeer, ruleAddressGroups []*antreatypes.AddressGroup, dir controlplane.Direction, ruleAppliedTos []*antreatypes.AppliedToGroup) {
 				rule := controlplane.NetworkPolicyRule{
-					Direction:       dir,
-					Services:        services,
-					Name:            cnpRule.Name,
-					Action:          cnpRule.Action,
-					Priority:        int32(idx),
-					EnableLogging:   cnpRule.EnableLogging,
-					AppliedToGroups: getAppliedToGroupNames(ruleAppliedTos),
-					L7Protocols:     toAntreaL7ProtocolsForCRD(cnpRule.L7Protocols),
-					LogLabel:        cnpRule.LogLabel,
+					Direction:        dir,
+					Services:         services,
+					Name:             cnpRule.Name,
+					Action:           cnpRule.Action,
+					Priority:         int32(idx),
+					EnableLogging:    cnpRule.EnableLogging,
+					AppliedToGroups:  getAppliedToGroupNames(ruleAppliedTos),
+					L7Protocols:      l7Protocols,
+					LogLabel:         cnpRule.LogLabel,
+					RequiredFeatures: getRequiredFeatures(cnpRule.Action, services, l7Protocols),
+					RateLimit:        toAntreaRateLimitForCRD(cnpRule.RateLimit),
 				}
 				if dir == controlplane.DirectionIn {
 					rule.From = *peer
@@ -390,7 +408,7 @@ func (n *NetworkPolicyController) processClusterNetworkPolicy(cnp *crdv1alpha1.C
 			}
 			// When a rule's NetworkPolicyPeer is empty, a cluster level rule should be created
 			// with an Antrea peer matching all addresses.
-			if len(clusterPeers) > 0 || len(perNSPeers) == 0 {
+			if len(clusterPeers) > 0 || (len(perNSPeers) == 0 && len(sameLabelsPeers) == 0) {
 				ruleAppliedTos := cnpRule.AppliedTo
 				// For ACNPs that have per-namespace rules, cluster-level rules will be created with appliedTo
 				// set as the spec appliedTo for each rule.
@@ -409,35 +427,33 @@ func (n *NetworkPolicyController) processClusterNetworkPolicy(cnp *crdv1alpha1.C
 					addRule(peer, ags, direction, ruleATGs)
 				}
 			}
+			if len(perNSPeers) == 0 && len(sameLabelsPeers) == 0 {
+				continue
+			}
+			// The affected Namespaces of appliedTo at spec level have been computed already, otherwise
+			// compute the affected Namespaces of appliedTo at rule level.
+			affectedNS, affectedNSATGs := clusterAppliedToAffectedNS, atgForNamespace
+			if len(cnp.Spec.AppliedTo) == 0 {
+				affectedNS, affectedNSATGs = n.splitAppliedToByNamespace(cnpRule.AppliedTo)
+			}
 			if len(perNSPeers) > 0 {
-				if len(cnp.Spec.AppliedTo) > 0 {
-					// Create a rule for each affected Namespace of appliedTo at spec level
-					for i := range clusterAppliedToAffectedNS {
-						klog.V(4).Infof("Adding a new per-namespace rule with appliedTo %v for rule %d of %s", clusterAppliedToAffectedNS[i], idx, cnp.Name)
-						peer, ags, selKeys := n.toNamespacedPeerForCRD(perNSPeers, cnp, clusterAppliedToAffectedNS[i])
-						clusterSetScopeSelectorKeys = clusterSetScopeSelectorKeys.Union(selKeys)
-						addRule(peer, ags, direction, []*antreatypes.AppliedToGroup{atgForNamespace[i]})
-					}
-				} else {
-					// Create a rule for each affected Namespace of appliedTo at rule level
-					for _, at := range cnpRule.AppliedTo {
-						if at.ServiceAccount != nil {
-							atg := n.createAppliedToGroup(at.ServiceAccount.Namespace, serviceAccountNameToPodSelector(at.ServiceAccount.Name), nil, nil)
-							klog.V(4).Infof("Adding a new per-namespace rule with appliedTo %v for rule %d of %s", atg, idx, cnp.Name)
-							peer, ags, selKeys := n.toNamespacedPeerForCRD(perNSPeers, cnp, at.ServiceAccount.Namespace)
-							clusterSetScopeSelectorKeys = clusterSetScopeSelectorKeys.Union(selKeys)
-							addRule(peer, ags, direction, []*antreatypes.AppliedToGroup{atg})
-						} else {
-							affectedNS := n.getAffectedNamespacesForAppliedTo(at)
-							for _, ns := range affectedNS {
-								atg := n.createAppliedToGroup(ns, at.PodSelector, nil, at.ExternalEntitySelector)
-								klog.V(4).Infof("Adding a new per-namespace rule with appliedTo %v for rule %d of %s", atg, idx, cnp.Name)
-								peer, ags, selKeys := n.toNamespacedPeerForCRD(perNSPeers, cnp, ns)
-								clusterSetScopeSelectorKeys = clusterSetScopeSelectorKeys.Union(selKeys)
-								addRule(peer, ags, direction, []*antreatypes.AppliedToGroup{atg})
-							}
-						}
-					}
+				// Create a rule for each affected Namespace of appliedTo
+				for i := range affectedNS {
+					klog.V(4).Infof("Adding a new per-namespace rule with appliedTo %v for rule %d of %s", affectedNSATGs[i], idx, cnp.Name)
+					peer, ags, selKeys := n.toNamespacedPeerForCRD(perNSPeers, cnp, affectedNS[i], nil)
+					clusterSetScopeSelectorKeys = clusterSetScopeSelectorKeys.Union(selKeys)
+					addRule(peer, ags, direction, []*antreatypes.AppliedToGroup{affectedNSATGs[i]})
+				}
+			}
+			for _, sameLabelsPeer := range sameLabelsPeers {
+				// Create a rule for each group of affected Namespaces which have the same values for the
+				// sameLabels keys, so that a single addressGroup is created for each group.
+				for _, nsGroup := range n.groupNamespacesBySameLabels(affectedNS, affectedNSATGs, sameLabelsPeer.Namespaces.SameLabels) {
+					klog.V(4).Infof("Adding a new same-labels rule with appliedTo %v for rule %d of %s", nsGroup.appliedToGroups, idx, cnp.Name)
+					nsSelector := &metav1.LabelSelector{MatchLabels: nsGroup.labels}
+					peer, ags, selKeys := n.toNamespacedPeerForCRD([]crdv1alpha1.NetworkPolicyPeer{sameLabelsPeer}, cnp, "", nsSelector)
+					clusterSetScopeSelectorKeys = clusterSetScopeSelectorKeys.Union(selKeys)
+					addRule(peer, ags, direction, nsGroup.appliedToGroups)
 				}
 			}
 		}
@@ -465,6 +481,7 @@ func (n *NetworkPolicyController) processClusterNetworkPolicy(cnp *crdv1alpha1.C
 		Priority:         &cnp.Spec.Priority,
 		TierPriority:     &tierPriority,
 		AppliedToPerRule: appliedToPerRule,
+		Annotations:      getAgentAnnotations(cnp.Annotations),
 	}
 	if n.stretchNPEnabled {
 		n.labelIdentityInterface.RemoveStalePolicySelectors(clusterSetScopeSelectorKeys, internalNetworkPolicyKeyFunc(cnp))
@@ -480,18 +497,33 @@ func serviceAccountNameToPodSelector(saName string) *metav1.LabelSelector {
 	}
 }
 
+// serviceAccountNamespace returns the Namespace of the ServiceAccount referred by a policy peer. The Namespace of the
+// policy is used when it's not set, which is only allowed in Antrea NetworkPolicies.
+func serviceAccountNamespace(sa *crdv1alpha1.NamespacedName, policyNamespace string) string {
+	if sa.Namespace == "" {
+		return policyNamespace
+	}
+	return sa.Namespace
+}
+
+// isPerNamespacePeer returns true if the peer selects workloads based on the Namespace of the appliedTo
+// workloads, i.e. namespaces.Match is set to Self or namespaces.SameLabels is set.
+func isPerNamespacePeer(peer crdv1alpha1.NetworkPolicyPeer) bool {
+	return peer.Namespaces != nil && (peer.Namespaces.Match == crdv1alpha1.NamespaceMatchSelf || len(peer.Namespaces.SameLabels) > 0)
+}
+
 // hasPerNamespaceRule returns true if there is at least one per-namespace rule
 func hasPerNamespaceRule(cnp *crdv1alpha1.ClusterNetworkPolicy) bool {
 	for _, ingress := range cnp.Spec.Ingress {
 		for _, peer := range ingress.From {
-			if peer.Namespaces != nil && peer.Namespaces.Match == crdv1alpha1.NamespaceMatchSelf {
+			if isPerNamespacePeer(peer) {
 				return true
 			}
 		}
 	}
 	for _, egress := range cnp.Spec.Egress {
 		for _, peer := range egress.To {
-			if peer.Namespaces != nil && peer.Namespaces.Match == crdv1alpha1.NamespaceMatchSelf {
+			if isPerNamespacePeer(peer) {
 				return true
 			}
 		}
@@ -499,6 +531,37 @@ func hasPerNamespaceRule(cnp *crdv1alpha1.ClusterNetworkPolicy) bool {
 	return false
 }
 
+// sameLabelsValuesChanged returns true if the values of any sameLabels key of the ClusterNetworkPolicy
+// differ between the old and the new labels of a Namespace.
+func sameLabelsValuesChanged(cnp *crdv1alpha1.ClusterNetworkPolicy, oldLabels, newLabels map[string]string) bool {
+	changed := func(peers []crdv1alpha1.NetworkPolicyPeer) bool {
+		for _, peer := range peers {
+			if peer.Namespaces == nil {
+				continue
+			}
+			for _, key := range peer.Namespaces.SameLabels {
+				oldValue, oldExists := oldLabels[key]
+				newValue, newExists := newLabels[key]
+				if oldExists != newExists || oldValue != newValue {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for _, ingress := range cnp.Spec.Ingress {
+		if changed(ingress.From) {
+			return true
+		}
+	}
+	for _, egress := range cnp.Spec.Egress {
+		if changed(egress.To) {
+			return true
+		}
+	}
+	return false
+}
+
 // processClusterAppliedTo processes appliedTo groups in Antrea ClusterNetworkPolicy set
 // at cluster level (appliedTo groups which will not need to be split by Namespaces).
 func (n *NetworkPolicyController) processClusterAppliedTo(appliedTo []crdv1alpha1.AppliedTo) []*antreatypes.AppliedToGroup {
@@ -511,6 +574,8 @@ func (n *NetworkPolicyController) processClusterAppliedTo(appliedTo []crdv1alpha
 			atg = n.createAppliedToGroupForService(at.Service)
 		} else if at.ServiceAccount != nil {
 			atg = n.createAppliedToGroup(at.ServiceAccount.Namespace, serviceAccountNameToPodSelector(at.ServiceAccount.Name), nil, nil)
+		} else if at.NodeSelector != nil {
+			atg = n.createAppliedToGroupForNodeSelector(at.NodeSelector)
 		} else {
 			atg = n.createAppliedToGroup("", at.PodSelector, at.NamespaceSelector, at.ExternalEntitySelector)
 		}
@@ -522,9 +587,9 @@ func (n *NetworkPolicyController) processClusterAppliedTo(appliedTo []crdv1alpha
 }
 
 // splitPeersByScope splits the ClusterNetworkPolicy peers in the rule by whether the peer
-// is cluster-scoped or per-namespace.
-func splitPeersByScope(rule crdv1alpha1.Rule, dir controlplane.Direction) ([]crdv1alpha1.NetworkPolicyPeer, []crdv1alpha1.NetworkPolicyPeer) {
-	var clusterPeers, perNSPeers []crdv1alpha1.NetworkPolicyPeer
+// is cluster-scoped, per-namespace, or selects the Namespaces with the same label values.
+func splitPeersByScope(rule crdv1alpha1.Rule, dir controlplane.Direction) ([]crdv1alpha1.NetworkPolicyPeer, []crdv1alpha1.NetworkPolicyPeer, []crdv1alpha1.NetworkPolicyPeer) {
+	var clusterPeers, perNSPeers, sameLabelsPeers []crdv1alpha1.NetworkPolicyPeer
 	peers := rule.From
 	if dir == controlplane.DirectionOut {
 		peers = rule.To
@@ -532,11 +597,85 @@ func splitPeersByScope(rule crdv1alpha1.Rule, dir controlplane.Direction) ([]crd
 	for _, peer := range peers {
 		if peer.Namespaces != nil && peer.Namespaces.Match == crdv1alpha1.NamespaceMatchSelf {
 			perNSPeers = append(perNSPeers, peer)
+		} else if peer.Namespaces != nil && len(peer.Namespaces.SameLabels) > 0 {
+			sameLabelsPeers = append(sameLabelsPeers, peer)
 		} else {
 			clusterPeers = append(clusterPeers, peer)
 		}
 	}
-	return clusterPeers, perNSPeers
+	return clusterPeers, perNSPeers, sameLabelsPeers
+}
+
+// splitAppliedToByNamespace creates an appliedToGroup for each affected Namespace of the appliedTos. It
+// returns the affected Namespaces and their appliedToGroups, at the same index of the two slices.
+func (n *NetworkPolicyController) splitAppliedToByNamespace(appliedTos []crdv1alpha1.AppliedTo) ([]string, []*antreatypes.AppliedToGroup) {
+	var affectedNS []string
+	var atgs []*antreatypes.AppliedToGroup
+	for _, at := range appliedTos {
+		if at.ServiceAccount != nil {
+			atg := n.createAppliedToGroup(at.ServiceAccount.Namespace, serviceAccountNameToPodSelector(at.ServiceAccount.Name), nil, nil)
+			affectedNS = append(affectedNS, at.ServiceAccount.Namespace)
+			atgs = append(atgs, atg)
+		} else {
+			for _, ns := range n.getAffectedNamespacesForAppliedTo(at) {
+				atg := n.createAppliedToGroup(ns, at.PodSelector, nil, at.ExternalEntitySelector)
+				affectedNS = append(affectedNS, ns)
+				atgs = append(atgs, atg)
+			}
+		}
+	}
+	return affectedNS, atgs
+}
+
+// sameLabelsNamespaceGroup is a group of Namespaces which have the same values for the sameLabels keys
+// of a peer.
+type sameLabelsNamespaceGroup struct {
+	// labels are the sameLabels keys and their values shared by the Namespaces.
+	labels map[string]string
+	// appliedToGroups are the appliedToGroups of the Namespaces, sorted by name.
+	appliedToGroups []*antreatypes.AppliedToGroup
+}
+
+// groupNamespacesBySameLabels groups the affected Namespaces by their values of the sameLabels keys. The
+// Namespaces which do not have all the keys are ignored. The groups are sorted by label values.
+func (n *NetworkPolicyController) groupNamespacesBySameLabels(affectedNS []string, atgs []*antreatypes.AppliedToGroup, sameLabels []string) []*sameLabelsNamespaceGroup {
+	groups := map[string]*sameLabelsNamespaceGroup{}
+	atgsByGroup := map[string]map[string]*antreatypes.AppliedToGroup{}
+	for i, nsName := range affectedNS {
+		ns, err := n.namespaceLister.Get(nsName)
+		if err != nil {
+			continue
+		}
+		nsLabels := map[string]string{}
+		values := make([]string, 0, len(sameLabels))
+		for _, key := range sameLabels {
+			value, exists := ns.Labels[key]
+			if !exists {
+				break
+			}
+			nsLabels[key] = value
+			values = append(values, value)
+		}
+		if len(values) < len(sameLabels) {
+			continue
+		}
+		// Label values cannot contain commas, so the joined values identify the group.
+		groupKey := strings.Join(values, ",")
+		if _, exists := groups[groupKey]; !exists {
+			groups[groupKey] = &sameLabelsNamespaceGroup{labels: nsLabels}
+			atgsByGroup[groupKey] = map[string]*antreatypes.AppliedToGroup{}
+		}
+		atgsByGroup[groupKey][atgs[i].Name] = atgs[i]
+	}
+	var result []*sameLabelsNamespaceGroup
+	for _, key := range sets.List(sets.KeySet(groups)) {
+		group := groups[key]
+		for _, atgName := range sets.List(sets.KeySet(atgsByGroup[key])) {
+			group.appliedToGroups = append(group.appliedToGroups, atgsByGroup[key][atgName])
+		}
+		result = append(result, group)
+	}
+	return result
 }
 
 // getAffectedNamespacesForAppliedTo computes the Namespaces currently affected by the appliedTo
@@ -570,10 +709,8 @@ func (n *NetworkPolicyController) getAffectedNamespacesForAppliedTo(appliedTo cr
 // to determine whether an addressGroup needs to be created, and returns any ipBlocks contained
 // by the internal Group as well.
 func (n *NetworkPolicyController) processInternalGroupForRule(group *antreatypes.Group) (bool, []controlplane.IPBlock) {
-	if len(group.IPBlocks) > 0 {
-		return false, group.IPBlocks
-	} else if len(group.ChildGroups) == 0 {
-		return true, nil
+	if len(group.ChildGroups) == 0 {
+		return groupSelectsWorkloads(group), group.IPBlocks
 	}
 	var ipBlocks []controlplane.IPBlock
 	createAddrGroup := false
@@ -422,9 +422,10 @@ func TestProcessClusterNetworkPolicy(t *testing.T) {
 						From: controlplane.NetworkPolicyPeer{
 							AddressGroups: []string{getNormalizedUID(antreatypes.NewGroupSelector("", &selectorB, nil, nil, nil).NormalizedName)},
 						},
-						L7Protocols: []controlplane.L7Protocol{{HTTP: &controlplane.HTTPProtocol{Host: "test.com", Method: "GET", Path: "/admin"}}},
-						Priority:    0,
-						Action:      &allowAction,
+						L7Protocols:      []controlplane.L7Protocol{{HTTP: &controlplane.HTTPProtocol{Host: "test.com", Method: "GET", Path: "/admin"}}},
+						RequiredFeatures: []string{controlplane.NetworkPolicyFeatureL7Protocols},
+						Priority:         0,
+						Action:           &allowAction,
 					},
 				},
 				AppliedToGroups: []string{getNormalizedUID(antreatypes.NewGroupSelector("", &selectorA, nil, nil, nil).NormalizedName)},
@@ -1485,8 +1486,9 @@ func TestProcessClusterNetworkPolicy(t *testing.T) {
 								GroupAddress: queryAddr,
 							},
 						},
-						Priority: 0,
-						Action:   &dropAction,
+						Priority:         0,
+						Action:           &dropAction,
+						RequiredFeatures: []string{controlplane.NetworkPolicyFeatureIGMP},
 						From: controlplane.NetworkPolicyPeer{
 							IPBlocks: []controlplane.IPBlock{
 								{CIDR: controlplane.IPNet{IP: controlplane.IPAddress(net.IPv4zero), PrefixLength: 0}},
@@ -1544,8 +1546,9 @@ func TestProcessClusterNetworkPolicy(t *testing.T) {
 								GroupAddress: reportAddr,
 							},
 						},
-						Priority: 0,
-						Action:   &dropAction,
+						Priority:         0,
+						Action:           &dropAction,
+						RequiredFeatures: []string{controlplane.NetworkPolicyFeatureIGMP},
 						To: controlplane.NetworkPolicyPeer{
 							IPBlocks: []controlplane.IPBlock{
 								{CIDR: controlplane.IPNet{IP: controlplane.IPAddress(net.IPv4zero), PrefixLength: 0}},
@@ -1712,6 +1715,217 @@ func TestProcessClusterNetworkPolicy(t *testing.T) {
 	}
 }
 
+func TestProcessClusterNetworkPolicyWithSameLabels(t *testing.T) {
+	p10 := float64(10)
+	allowAction := crdv1alpha1.RuleActionAllow
+	dropAction := crdv1alpha1.RuleActionDrop
+	newNamespace := func(name string, labels map[string]string) *v1.Namespace {
+		return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	namespaces := []*v1.Namespace{
+		newNamespace("ns1", map[string]string{"tenant": "a", "env": "prod"}),
+		newNamespace("ns2", map[string]string{"tenant": "a", "env": "dev"}),
+		newNamespace("ns3", map[string]string{"tenant": "b", "env": "prod"}),
+		newNamespace("ns4", map[string]string{"env": "prod"}),
+	}
+	nsGroupName := func(namespace string, podSelector *metav1.LabelSelector) string {
+		return getNormalizedUID(antreatypes.NewGroupSelector(namespace, podSelector, nil, nil, nil).NormalizedName)
+	}
+	sameLabelsGroupName := func(podSelector *metav1.LabelSelector, nsLabels map[string]string) string {
+		return getNormalizedUID(antreatypes.NewGroupSelector("", podSelector, &metav1.LabelSelector{MatchLabels: nsLabels}, nil, nil).NormalizedName)
+	}
+	selectorA := metav1.LabelSelector{MatchLabels: map[string]string{"foo1": "bar1"}}
+	tests := []struct {
+		name                    string
+		inputPolicy             *crdv1alpha1.ClusterNetworkPolicy
+		expectedRules           []controlplane.NetworkPolicyRule
+		expectedAppliedToGroups []string
+		expectedAddressGroups   int
+	}{
+		{
+			name: "spec-appliedTo",
+			inputPolicy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnpA", UID: "uidA"},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{NamespaceSelector: &metav1.LabelSelector{}},
+					},
+					Priority: p10,
+					Ingress: []crdv1alpha1.Rule{
+						{
+							From: []crdv1alpha1.NetworkPolicyPeer{
+								{Namespaces: &crdv1alpha1.PeerNamespaces{SameLabels: []string{"tenant"}}},
+							},
+							Action: &allowAction,
+						},
+						{
+							From: []crdv1alpha1.NetworkPolicyPeer{
+								{NamespaceSelector: &metav1.LabelSelector{}},
+							},
+							Action: &dropAction,
+						},
+					},
+				},
+			},
+			expectedRules: []controlplane.NetworkPolicyRule{
+				{
+					Direction:       controlplane.DirectionIn,
+					AppliedToGroups: sets.List(sets.New[string](nsGroupName("ns1", nil), nsGroupName("ns2", nil))),
+					From: controlplane.NetworkPolicyPeer{
+						AddressGroups: []string{sameLabelsGroupName(nil, map[string]string{"tenant": "a"})},
+					},
+					Priority: 0,
+					Action:   &allowAction,
+				},
+				{
+					Direction:       controlplane.DirectionIn,
+					AppliedToGroups: []string{nsGroupName("ns3", nil)},
+					From: controlplane.NetworkPolicyPeer{
+						AddressGroups: []string{sameLabelsGroupName(nil, map[string]string{"tenant": "b"})},
+					},
+					Priority: 0,
+					Action:   &allowAction,
+				},
+				{
+					Direction:       controlplane.DirectionIn,
+					AppliedToGroups: []string{getNormalizedUID(antreatypes.NewGroupSelector("", nil, &metav1.LabelSelector{}, nil, nil).NormalizedName)},
+					From: controlplane.NetworkPolicyPeer{
+						AddressGroups: []string{getNormalizedUID(antreatypes.NewGroupSelector("", nil, &metav1.LabelSelector{}, nil, nil).NormalizedName)},
+					},
+					Priority: 1,
+					Action:   &dropAction,
+				},
+			},
+			expectedAppliedToGroups: []string{
+				nsGroupName("ns1", nil),
+				nsGroupName("ns2", nil),
+				nsGroupName("ns3", nil),
+				getNormalizedUID(antreatypes.NewGroupSelector("", nil, &metav1.LabelSelector{}, nil, nil).NormalizedName),
+			},
+			expectedAddressGroups: 3,
+		},
+		{
+			name: "rule-appliedTo-multiple-keys",
+			inputPolicy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "cnpB", UID: "uidB"},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					Priority: p10,
+					Egress: []crdv1alpha1.Rule{
+						{
+							AppliedTo: []crdv1alpha1.AppliedTo{
+								{
+									NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+									PodSelector:       &selectorA,
+								},
+							},
+							To: []crdv1alpha1.NetworkPolicyPeer{
+								{
+									Namespaces:  &crdv1alpha1.PeerNamespaces{SameLabels: []string{"tenant", "env"}},
+									PodSelector: &selectorA,
+								},
+							},
+							Action: &allowAction,
+						},
+					},
+				},
+			},
+			expectedRules: []controlplane.NetworkPolicyRule{
+				{
+					Direction:       controlplane.DirectionOut,
+					AppliedToGroups: []string{nsGroupName("ns1", &selectorA)},
+					To: controlplane.NetworkPolicyPeer{
+						AddressGroups: []string{sameLabelsGroupName(&selectorA, map[string]string{"tenant": "a", "env": "prod"})},
+					},
+					Priority: 0,
+					Action:   &allowAction,
+				},
+				{
+					Direction:       controlplane.DirectionOut,
+					AppliedToGroups: []string{nsGroupName("ns3", &selectorA)},
+					To: controlplane.NetworkPolicyPeer{
+						AddressGroups: []string{sameLabelsGroupName(&selectorA, map[string]string{"tenant": "b", "env": "prod"})},
+					},
+					Priority: 0,
+					Action:   &allowAction,
+				},
+			},
+			expectedAppliedToGroups: []string{
+				nsGroupName("ns1", &selectorA),
+				nsGroupName("ns3", &selectorA),
+			},
+			expectedAddressGroups: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c := newController(nil, nil)
+			for _, ns := range namespaces {
+				c.namespaceStore.Add(ns)
+			}
+			actualPolicy, actualAppliedToGroups, actualAddressGroups := c.processClusterNetworkPolicy(tt.inputPolicy)
+			assert.True(t, actualPolicy.AppliedToPerRule)
+			assert.ElementsMatch(t, tt.expectedRules, actualPolicy.Rules)
+			assert.ElementsMatch(t, tt.expectedAppliedToGroups, actualPolicy.AppliedToGroups)
+			assert.Equal(t, len(tt.expectedAppliedToGroups), len(actualAppliedToGroups))
+			assert.Equal(t, tt.expectedAddressGroups, len(actualAddressGroups))
+		})
+	}
+}
+
+func TestSameLabelsValuesChanged(t *testing.T) {
+	cnp := &crdv1alpha1.ClusterNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cnpA"},
+		Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+			AppliedTo: []crdv1alpha1.AppliedTo{
+				{NamespaceSelector: &metav1.LabelSelector{}},
+			},
+			Egress: []crdv1alpha1.Rule{
+				{
+					To: []crdv1alpha1.NetworkPolicyPeer{
+						{Namespaces: &crdv1alpha1.PeerNamespaces{SameLabels: []string{"tenant"}}},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		oldLabels map[string]string
+		newLabels map[string]string
+		want      bool
+	}{
+		{
+			name:      "value changed",
+			oldLabels: map[string]string{"tenant": "a"},
+			newLabels: map[string]string{"tenant": "b"},
+			want:      true,
+		},
+		{
+			name:      "key added",
+			oldLabels: map[string]string{"env": "prod"},
+			newLabels: map[string]string{"env": "prod", "tenant": "a"},
+			want:      true,
+		},
+		{
+			name:      "key removed",
+			oldLabels: map[string]string{"tenant": "a"},
+			newLabels: map[string]string{},
+			want:      true,
+		},
+		{
+			name:      "other label changed",
+			oldLabels: map[string]string{"tenant": "a", "env": "prod"},
+			newLabels: map[string]string{"tenant": "a", "env": "dev"},
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, sameLabelsValuesChanged(cnp, tt.oldLabels, tt.newLabels))
+		})
+	}
+}
+
 func TestAddCNP(t *testing.T) {
 	_, npc := newController(nil, nil)
 	cnp := getCNP()
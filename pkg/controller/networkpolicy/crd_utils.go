@@ -15,6 +15,8 @@
 package networkpolicy
 
 import (
+	"net"
+	"sort"
 	"strings"
 	"time"
 
@@ -124,6 +126,37 @@ func toAntreaL7ProtocolsForCRD(l7Protocols []v1alpha1.L7Protocol) []controlplane
 	return antreaL7Protocols
 }
 
+// toAntreaRateLimitForCRD converts a v1alpha1.RateLimit object to an Antrea
+// RateLimit object.
+func toAntreaRateLimitForCRD(rateLimit *v1alpha1.RateLimit) *controlplane.RateLimit {
+	if rateLimit == nil {
+		return nil
+	}
+	return &controlplane.RateLimit{
+		PacketsPerSecond: rateLimit.PacketsPerSecond,
+		BytesPerSecond:   rateLimit.BytesPerSecond,
+	}
+}
+
+// getRequiredFeatures returns the NetworkPolicy features which must be supported by
+// the agents to enforce a rule with the given action, Services and L7Protocols.
+func getRequiredFeatures(action *v1alpha1.RuleAction, services []controlplane.Service, l7Protocols []controlplane.L7Protocol) []string {
+	var features []string
+	if action != nil && *action == v1alpha1.RuleActionRateLimit {
+		features = append(features, controlplane.NetworkPolicyFeatureRateLimit)
+	}
+	if len(l7Protocols) > 0 {
+		features = append(features, controlplane.NetworkPolicyFeatureL7Protocols)
+	}
+	for _, service := range services {
+		if service.Protocol != nil && *service.Protocol == controlplane.ProtocolIGMP {
+			features = append(features, controlplane.NetworkPolicyFeatureIGMP)
+			break
+		}
+	}
+	return features
+}
+
 // toAntreaIPBlockForCRD converts a v1alpha1.IPBlock to an Antrea IPBlock.
 func toAntreaIPBlockForCRD(ipBlock *v1alpha1.IPBlock) (*controlplane.IPBlock, error) {
 	// Convert the allowed IPBlock to networkpolicy.IPNet.
@@ -131,14 +164,68 @@ func toAntreaIPBlockForCRD(ipBlock *v1alpha1.IPBlock) (*controlplane.IPBlock, er
 	if err != nil {
 		return nil, err
 	}
+	exceptNets := []controlplane.IPNet{}
+	for _, exc := range ipBlock.Except {
+		exceptNet, err := cidrStrToIPNet(exc)
+		if err != nil {
+			return nil, err
+		}
+		exceptNets = append(exceptNets, *exceptNet)
+	}
 	antreaIPBlock := &controlplane.IPBlock{
-		CIDR: *ipNet,
-		// secv1alpha.IPBlock does not have the Except slices.
-		Except: []controlplane.IPNet{},
+		CIDR:   *ipNet,
+		Except: exceptNets,
 	}
 	return antreaIPBlock, nil
 }
 
+// setInternalGroupIPBlocks sets the IPBlocks of a ClusterGroup or Group, and the corresponding IPNets used for IP
+// association queries, in the internal Group.
+func setInternalGroupIPBlocks(internalGroup *antreatypes.Group, ipBlocks []v1alpha1.IPBlock) {
+	exceptIPNets := make([][]net.IPNet, len(ipBlocks))
+	hasExcept := false
+	for i := range ipBlocks {
+		// CIDR format is already validated by the webhook
+		ipb, _ := toAntreaIPBlockForCRD(&ipBlocks[i])
+		internalGroup.IPBlocks = append(internalGroup.IPBlocks, *ipb)
+		_, ipNet, _ := net.ParseCIDR(ipBlocks[i].CIDR)
+		internalGroup.IPNets = append(internalGroup.IPNets, *ipNet)
+		for _, exc := range ipBlocks[i].Except {
+			_, exceptIPNet, _ := net.ParseCIDR(exc)
+			exceptIPNets[i] = append(exceptIPNets[i], *exceptIPNet)
+			hasExcept = true
+		}
+	}
+	// ExceptIPNets is left nil when no IPBlock has except CIDRs.
+	if hasExcept {
+		internalGroup.ExceptIPNets = exceptIPNets
+	}
+}
+
+// ipBlocksEqual returns whether two lists of IPBlocks contain the same CIDRs with the same except CIDRs, regardless
+// of their order.
+func ipBlocksEqual(ipBlocks1, ipBlocks2 []controlplane.IPBlock) bool {
+	toKeys := func(ipBlocks []controlplane.IPBlock) sets.Set[string] {
+		keys := sets.New[string]()
+		for _, ipb := range ipBlocks {
+			excepts := make([]string, 0, len(ipb.Except))
+			for _, exc := range ipb.Except {
+				excepts = append(excepts, exc.String())
+			}
+			sort.Strings(excepts)
+			keys.Insert(ipb.CIDR.String() + "-" + strings.Join(excepts, ","))
+		}
+		return keys
+	}
+	return toKeys(ipBlocks1).Equal(toKeys(ipBlocks2))
+}
+
+// groupSelectsWorkloads returns whether an internal Group without child Groups selects workloads, i.e. it is not
+// defined with IPBlocks only. A Group defined with a ServiceReference can also have IPBlocks.
+func groupSelectsWorkloads(group *antreatypes.Group) bool {
+	return len(group.IPBlocks) == 0 || group.ServiceReference != nil
+}
+
 // toAntreaPeerForCRD creates an Antrea controlplane NetworkPolicyPeer for crdv1alpha1 NetworkPolicyPeer.
 // It is used when peer's Namespaces are not matched by NamespaceMatchTypes, for which the controlplane
 // NetworkPolicyPeers will need to be created on a per-Namespace basis.
@@ -192,7 +279,7 @@ func (n *NetworkPolicyController) toAntreaPeerForCRD(peers []v1alpha1.NetworkPol
 		} else if peer.FQDN != "" {
 			fqdns = append(fqdns, peer.FQDN)
 		} else if peer.ServiceAccount != nil {
-			addressGroup := n.createAddressGroup(peer.ServiceAccount.Namespace, serviceAccountNameToPodSelector(peer.ServiceAccount.Name), nil, nil, nil)
+			addressGroup := n.createAddressGroup(serviceAccountNamespace(peer.ServiceAccount, np.GetNamespace()), serviceAccountNameToPodSelector(peer.ServiceAccount.Name), nil, nil, nil)
 			addressGroups = append(addressGroups, addressGroup)
 		} else if peer.NodeSelector != nil {
 			addressGroup := n.createAddressGroup("", nil, nil, nil, peer.NodeSelector)
@@ -224,19 +311,20 @@ func (n *NetworkPolicyController) toAntreaPeerForCRD(peers []v1alpha1.NetworkPol
 }
 
 // toNamespacedPeerForCRD creates an Antrea controlplane NetworkPolicyPeer for crdv1alpha1 NetworkPolicyPeer
-// for a particular Namespace. It is used when a single crdv1alpha1 NetworkPolicyPeer maps to multiple
-// controlplane NetworkPolicyPeers because the appliedTo workloads reside in different Namespaces.
+// for a particular Namespace, or for the Namespaces selected by nsSelector if namespace is empty. It is used
+// when a single crdv1alpha1 NetworkPolicyPeer maps to multiple controlplane NetworkPolicyPeers because the
+// appliedTo workloads reside in different Namespaces.
 func (n *NetworkPolicyController) toNamespacedPeerForCRD(peers []v1alpha1.NetworkPolicyPeer,
-	np metav1.Object, namespace string) (*controlplane.NetworkPolicyPeer, []*antreatypes.AddressGroup, sets.Set[string]) {
+	np metav1.Object, namespace string, nsSelector *metav1.LabelSelector) (*controlplane.NetworkPolicyPeer, []*antreatypes.AddressGroup, sets.Set[string]) {
 	var addressGroups []*antreatypes.AddressGroup
 	var labelIdentities []uint32
 	uniqueLabelIDs := map[uint32]struct{}{}
 	clusterSetScopeSelectorKeys := sets.New[string]()
 	for _, peer := range peers {
-		addressGroup := n.createAddressGroup(namespace, peer.PodSelector, nil, peer.ExternalEntitySelector, nil)
+		addressGroup := n.createAddressGroup(namespace, peer.PodSelector, nsSelector, peer.ExternalEntitySelector, nil)
 		addressGroups = append(addressGroups, addressGroup)
 		if n.stretchNPEnabled && peer.Scope == v1alpha1.ScopeClusterSet {
-			newClusterSetScopeSelector := antreatypes.NewGroupSelector(namespace, peer.PodSelector, nil, peer.ExternalEntitySelector, nil)
+			newClusterSetScopeSelector := antreatypes.NewGroupSelector(namespace, peer.PodSelector, nsSelector, peer.ExternalEntitySelector, nil)
 			clusterSetScopeSelectorKeys.Insert(newClusterSetScopeSelector.NormalizedName)
 			// In addition to getting the matched Label Identity IDs, AddSelector also registers the selector
 			// with the labelIdentityInterface.
@@ -296,6 +384,18 @@ func (n *NetworkPolicyController) createAppliedToGroupForService(service *v1alph
 	return appliedToGroup
 }
 
+// createAppliedToGroupForNodeSelector creates an AppliedToGroup object corresponding to a NodeSelector.
+// Its members are the Nodes selected by the NodeSelector, which are calculated via NodeLister directly.
+func (n *NetworkPolicyController) createAppliedToGroupForNodeSelector(nodeSelector *metav1.LabelSelector) *antreatypes.AppliedToGroup {
+	groupSelector := antreatypes.NewGroupSelector("", nil, nil, nil, nodeSelector)
+	appliedToGroupUID := getNormalizedUID(groupSelector.NormalizedName)
+	return &antreatypes.AppliedToGroup{
+		Name:     appliedToGroupUID,
+		UID:      types.UID(appliedToGroupUID),
+		Selector: groupSelector,
+	}
+}
+
 // createAppliedToGroupForGroup creates an AppliedToGroup object corresponding to a ClusterGroup or a Group.
 // The namespace parameter is only provided when the group is namespace scoped.
 func (n *NetworkPolicyController) createAppliedToGroupForGroup(namespace, group string) *antreatypes.AppliedToGroup {
@@ -318,7 +418,7 @@ func (n *NetworkPolicyController) createAppliedToGroupForGroup(namespace, group
 	// as the Group could also be used as AddressGroup.
 	// To keep the behavior consistent regarding IPBlocks, we ignore Groups containing only IPBlocks when it's used as
 	// AppliedTo.
-	if len(intGrp.IPBlocks) > 0 {
+	if !groupSelectsWorkloads(intGrp) {
 		klog.V(2).InfoS("Group with IPBlocks can not be used as AppliedTo", "Group", key)
 		return nil
 	}
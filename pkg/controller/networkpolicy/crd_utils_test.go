@@ -226,6 +226,50 @@ func TestToAntreaL7ProtocolsForCRD(t *testing.T) {
 	}
 }
 
+func TestToAntreaRateLimitForCRD(t *testing.T) {
+	assert.Nil(t, toAntreaRateLimitForCRD(nil))
+	assert.Equal(t, &controlplane.RateLimit{PacketsPerSecond: 100}, toAntreaRateLimitForCRD(&crdv1alpha1.RateLimit{PacketsPerSecond: 100}))
+	assert.Equal(t, &controlplane.RateLimit{BytesPerSecond: 1000000}, toAntreaRateLimitForCRD(&crdv1alpha1.RateLimit{BytesPerSecond: 1000000}))
+}
+
+func TestGetRequiredFeatures(t *testing.T) {
+	tables := []struct {
+		action      *crdv1alpha1.RuleAction
+		services    []controlplane.Service
+		l7Protocols []controlplane.L7Protocol
+		expValue    []string
+	}{
+		{
+			&allowAction,
+			[]controlplane.Service{{Protocol: &protocolTCP}},
+			nil,
+			nil,
+		},
+		{
+			&allowAction,
+			[]controlplane.Service{{Protocol: &protocolTCP}},
+			[]controlplane.L7Protocol{{HTTP: &controlplane.HTTPProtocol{Host: "test.com"}}},
+			[]string{controlplane.NetworkPolicyFeatureL7Protocols},
+		},
+		{
+			nil,
+			[]controlplane.Service{{Protocol: &protocolIGMP}, {Protocol: &protocolIGMP}},
+			nil,
+			[]string{controlplane.NetworkPolicyFeatureIGMP},
+		},
+		{
+			&rateLimitAction,
+			[]controlplane.Service{{Protocol: &protocolTCP}},
+			nil,
+			[]string{controlplane.NetworkPolicyFeatureRateLimit},
+		},
+	}
+	for _, table := range tables {
+		gotValue := getRequiredFeatures(table.action, table.services, table.l7Protocols)
+		assert.Equal(t, table.expValue, gotValue)
+	}
+}
+
 func TestToAntreaIPBlockForCRD(t *testing.T) {
 	expIPNet := controlplane.IPNet{
 		IP:           ipStrToIPAddress("10.0.0.0"),
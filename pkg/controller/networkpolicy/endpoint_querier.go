@@ -18,8 +18,12 @@
 package networkpolicy
 
 import (
+	"net"
+	"sort"
+
 	"k8s.io/apimachinery/pkg/types"
 
+	"antrea.io/antrea/pkg/apis/controlplane"
 	cpv1beta "antrea.io/antrea/pkg/apis/controlplane/v1beta2"
 	"antrea.io/antrea/pkg/controller/networkpolicy/store"
 	antreatypes "antrea.io/antrea/pkg/controller/types"
@@ -31,6 +35,9 @@ type EndpointQuerier interface {
 	// along with the list NetworkPolicies which select the provided Pod in one of their policy
 	// rules (ingress or egress).
 	QueryNetworkPolicies(namespace string, podName string) (*EndpointQueryResponse, error)
+	// QueryGroupsByIP returns the list of AppliedToGroups and AddressGroups which contain the
+	// provided IP, along with the NetworkPolicies which reference each of these groups.
+	QueryGroupsByIP(ip net.IP) ([]IPGroup, error)
 }
 
 // endpointQuerier implements the EndpointQuerier interface
@@ -66,6 +73,20 @@ type Rule struct {
 	RuleIndex int                `json:"ruleindex,omitempty"`
 }
 
+const (
+	IPGroupTypeAppliedToGroup = "AppliedToGroup"
+	IPGroupTypeAddressGroup   = "AddressGroup"
+)
+
+// IPGroup is the reply struct for antctl IP queries. It describes a group which contains the
+// queried IP, and the policies which reference the group.
+type IPGroup struct {
+	// Type is either AppliedToGroup or AddressGroup.
+	Type     string      `json:"type"`
+	Name     string      `json:"name"`
+	Policies []PolicyRef `json:"policies,omitempty"`
+}
+
 // NewEndpointQuerier returns a new *endpointQuerier.
 func NewEndpointQuerier(networkPolicyController *NetworkPolicyController) *endpointQuerier {
 	n := &endpointQuerier{
@@ -196,3 +217,83 @@ func (eq *endpointQuerier) QueryNetworkPolicies(namespace string, podName string
 	}
 	return &EndpointQueryResponse{[]Endpoint{endpoint}}, nil
 }
+
+// QueryGroupsByIP returns the AppliedToGroups and AddressGroups which have a member with the
+// provided IP, along with the references to the policies which use these groups. Like
+// QueryNetworkPolicies, it iterates over all the groups, which is acceptable for user queries.
+// Note that ipBlocks are not represented by groups, so an IP only matched by the ipBlock of a
+// policy rule is not reported.
+func (eq *endpointQuerier) QueryGroupsByIP(ip net.IP) ([]IPGroup, error) {
+	groups := make([]IPGroup, 0)
+	for _, obj := range eq.networkPolicyController.appliedToGroupStore.List() {
+		appliedToGroup := obj.(*antreatypes.AppliedToGroup)
+		found := false
+		for _, memberSet := range appliedToGroup.GroupMemberByNode {
+			if groupMemberSetHasIP(memberSet, ip) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			continue
+		}
+		policies, err := eq.getPolicyRefsByIndex(store.AppliedToGroupIndex, appliedToGroup.Name)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, IPGroup{Type: IPGroupTypeAppliedToGroup, Name: appliedToGroup.Name, Policies: policies})
+	}
+	for _, obj := range eq.networkPolicyController.addressGroupStore.List() {
+		addressGroup := obj.(*antreatypes.AddressGroup)
+		if !groupMemberSetHasIP(addressGroup.GroupMembers, ip) {
+			continue
+		}
+		policies, err := eq.getPolicyRefsByIndex(store.AddressGroupIndex, addressGroup.Name)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, IPGroup{Type: IPGroupTypeAddressGroup, Name: addressGroup.Name, Policies: policies})
+	}
+	// List AppliedToGroups first, then AddressGroups, each sorted by name.
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Type != groups[j].Type {
+			return groups[i].Type > groups[j].Type
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	return groups, nil
+}
+
+func (eq *endpointQuerier) getPolicyRefsByIndex(indexName, groupName string) ([]PolicyRef, error) {
+	policies, err := eq.networkPolicyController.internalNetworkPolicyStore.GetByIndex(indexName, groupName)
+	if err != nil {
+		return nil, err
+	}
+	policyRefs := make([]PolicyRef, 0, len(policies))
+	for _, obj := range policies {
+		policy := obj.(*antreatypes.NetworkPolicy)
+		policyRefs = append(policyRefs, PolicyRef{
+			Namespace: policy.SourceRef.Namespace,
+			Name:      policy.SourceRef.Name,
+			UID:       policy.SourceRef.UID,
+		})
+	}
+	sort.Slice(policyRefs, func(i, j int) bool {
+		if policyRefs[i].Namespace != policyRefs[j].Namespace {
+			return policyRefs[i].Namespace < policyRefs[j].Namespace
+		}
+		return policyRefs[i].Name < policyRefs[j].Name
+	})
+	return policyRefs, nil
+}
+
+func groupMemberSetHasIP(memberSet controlplane.GroupMemberSet, ip net.IP) bool {
+	for _, member := range memberSet {
+		for _, memberIP := range member.IPs {
+			if net.IP(memberIP).Equal(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
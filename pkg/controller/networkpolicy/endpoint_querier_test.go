@@ -15,6 +15,7 @@
 package networkpolicy
 
 import (
+	"net"
 	"testing"
 	"time"
 
@@ -275,3 +276,58 @@ func TestEndpointQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryGroupsByIP(t *testing.T) {
+	policyRef0 := PolicyRef{policies[0].Namespace, policies[0].Name, policies[0].UID}
+	policyRef1 := PolicyRef{policies[1].Namespace, policies[1].Name, policies[1].UID}
+
+	testCases := []struct {
+		name          string
+		objs          []runtime.Object
+		ip            string
+		expectedTypes []string
+		// expectedPolicies are the policies referencing each of the returned groups.
+		expectedPolicies [][]PolicyRef
+	}{
+		{
+			name: "NoPolicy",
+			objs: []runtime.Object{namespaces[0], pods[0]},
+			ip:   "1.2.3.4",
+		},
+		{
+			name: "UnknownIP",
+			objs: []runtime.Object{namespaces[0], pods[0], policies[0]},
+			ip:   "1.2.3.5",
+		},
+		{
+			name:             "SingleAppliedIngressEgressPolicy",
+			objs:             []runtime.Object{namespaces[0], pods[0], policies[0]},
+			ip:               "1.2.3.4",
+			expectedTypes:    []string{IPGroupTypeAppliedToGroup, IPGroupTypeAddressGroup},
+			expectedPolicies: [][]PolicyRef{{policyRef0}, {policyRef0}},
+		},
+		{
+			name:             "MultiplePolicy",
+			objs:             []runtime.Object{namespaces[0], pods[0], policies[0], policies[1]},
+			ip:               "1.2.3.4",
+			expectedTypes:    []string{IPGroupTypeAppliedToGroup, IPGroupTypeAddressGroup},
+			expectedPolicies: [][]PolicyRef{{policyRef1, policyRef0}, {policyRef0}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpointQuerier := makeControllerAndEndpointQuerier(tc.objs...)
+			groups, err := endpointQuerier.QueryGroupsByIP(net.ParseIP(tc.ip))
+			require.NoError(t, err)
+			require.Len(t, groups, len(tc.expectedTypes))
+			for i := range groups {
+				assert.Equal(t, tc.expectedTypes[i], groups[i].Type)
+				assert.Equal(t, tc.expectedPolicies[i], groups[i].Policies)
+			}
+		})
+	}
+}
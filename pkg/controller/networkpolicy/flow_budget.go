@@ -0,0 +1,212 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	"antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	"antrea.io/antrea/pkg/controller/grouping"
+	antreatypes "antrea.io/antrea/pkg/controller/types"
+	"antrea.io/antrea/pkg/util/k8s"
+)
+
+// flowEstimateGroupType is the type of the temporary groups registered to the grouping interface to compute the
+// members selected by the selectors of a policy being validated.
+const flowEstimateGroupType grouping.GroupType = "flowEstimateGroup"
+
+// FlowBudgetConfig includes the settings of the per-Node flow budget of Antrea-native policies.
+type FlowBudgetConfig struct {
+	// MaxFlowsPerNode is the maximum number of OVS flows that a policy is estimated to install on a single Node.
+	// 0 means no limit.
+	MaxFlowsPerNode int
+	// Reject determines whether a policy exceeding the budget is rejected. Otherwise it is accepted with a warning.
+	Reject bool
+}
+
+// SetFlowBudgetConfig sets the per-Node flow budget checked when Antrea-native policies are created or updated.
+func (n *NetworkPolicyController) SetFlowBudgetConfig(config FlowBudgetConfig) {
+	n.flowBudget = config
+}
+
+// estimatePolicyFlowsPerNode returns an estimate of the maximum number of OVS flows that an Antrea-native policy
+// installs on a single Node, and the name of that Node. Each rule is realized with conjunctive flows on the Nodes
+// where it is applied, so the flows of a rule on a Node are roughly the sum of the workloads it is applied to on the
+// Node, of the addresses of its peers, and of its services, plus the action flows of the conjunction. The estimate is
+// computed with the current members of the selectors and groups, so it may be exceeded as the cluster grows.
+func (n *NetworkPolicyController) estimatePolicyFlowsPerNode(namespace string, specAppliedTo []v1alpha1.AppliedTo, ingress, egress []v1alpha1.Rule) (int, string) {
+	flowsPerNode := map[string]int{}
+	for _, rule := range append(append([]v1alpha1.Rule{}, ingress...), egress...) {
+		appliedTo := rule.AppliedTo
+		if len(appliedTo) == 0 {
+			appliedTo = specAppliedTo
+		}
+		peers := rule.From
+		if len(peers) == 0 {
+			peers = rule.To
+		}
+		services := len(rule.Ports) + len(rule.Protocols) + len(rule.ToServices)
+		if services == 0 {
+			// A rule without services matches all traffic with a single flow.
+			services = 1
+		}
+		// The conjunction of a rule also has flows for its action and its metrics.
+		ruleFlows := n.estimatePeerAddresses(namespace, peers) + services + 2
+		for node, members := range n.estimateAppliedToMembersPerNode(namespace, appliedTo) {
+			flowsPerNode[node] += members + ruleFlows
+		}
+	}
+	var maxFlows int
+	var maxNode string
+	for node, flows := range flowsPerNode {
+		if flows > maxFlows || (flows == maxFlows && node < maxNode) {
+			maxFlows, maxNode = flows, node
+		}
+	}
+	return maxFlows, maxNode
+}
+
+// estimateAppliedToMembersPerNode returns the number of members selected by the provided appliedTo on each Node.
+func (n *NetworkPolicyController) estimateAppliedToMembersPerNode(namespace string, appliedTo []v1alpha1.AppliedTo) map[string]int {
+	membersPerNode := map[string]int{}
+	addWorkloads := func(pods []*v1.Pod, ees []*v1alpha2.ExternalEntity) {
+		for _, pod := range pods {
+			if pod.Spec.NodeName != "" {
+				membersPerNode[pod.Spec.NodeName]++
+			}
+		}
+		for _, ee := range ees {
+			if ee.Spec.ExternalNode != "" {
+				membersPerNode[ee.Spec.ExternalNode]++
+			}
+		}
+	}
+	for _, at := range appliedTo {
+		switch {
+		case at.Group != "":
+			addWorkloads(n.getGroupWorkloadsForEstimate(k8s.NamespacedName(namespace, at.Group)))
+		case at.ServiceAccount != nil:
+			addWorkloads(n.getSelectorWorkloadsForEstimate(antreatypes.NewGroupSelector(at.ServiceAccount.Namespace, serviceAccountNameToPodSelector(at.ServiceAccount.Name), nil, nil, nil)))
+		case at.NodeSelector != nil:
+			for _, node := range n.listNodesForEstimate(at.NodeSelector) {
+				membersPerNode[node.Name]++
+			}
+		case at.Service != nil:
+			// A rule applied to a Service is realized on all Nodes.
+			for _, node := range n.listNodesForEstimate(&metav1.LabelSelector{}) {
+				membersPerNode[node.Name]++
+			}
+		default:
+			addWorkloads(n.getSelectorWorkloadsForEstimate(antreatypes.NewGroupSelector(namespace, at.PodSelector, at.NamespaceSelector, at.ExternalEntitySelector, nil)))
+		}
+	}
+	return membersPerNode
+}
+
+// estimatePeerAddresses returns the number of addresses matched by the provided peers. An empty list of peers matches
+// all addresses with a single flow.
+func (n *NetworkPolicyController) estimatePeerAddresses(namespace string, peers []v1alpha1.NetworkPolicyPeer) int {
+	var addresses int
+	for _, peer := range peers {
+		switch {
+		case peer.IPBlock != nil:
+			addresses += 1 + len(peer.IPBlock.Except)
+		case peer.FQDN != "":
+			// The addresses of FQDN peers are resolved by the Agents, count the rule itself only.
+			addresses++
+		case peer.Group != "":
+			pods, ees := n.getGroupWorkloadsForEstimate(k8s.NamespacedName(namespace, peer.Group))
+			addresses += len(pods) + len(ees)
+			if obj, found, _ := n.internalGroupStore.Get(k8s.NamespacedName(namespace, peer.Group)); found {
+				addresses += len(obj.(*antreatypes.Group).IPBlocks)
+			}
+		case peer.ServiceAccount != nil:
+			pods, _ := n.getSelectorWorkloadsForEstimate(antreatypes.NewGroupSelector(serviceAccountNamespace(peer.ServiceAccount, namespace), serviceAccountNameToPodSelector(peer.ServiceAccount.Name), nil, nil, nil))
+			addresses += len(pods)
+		case peer.NodeSelector != nil:
+			addresses += len(n.listNodesForEstimate(peer.NodeSelector))
+		case peer.PodSelector != nil || peer.NamespaceSelector != nil || peer.ExternalEntitySelector != nil:
+			pods, ees := n.getSelectorWorkloadsForEstimate(antreatypes.NewGroupSelector(namespace, peer.PodSelector, peer.NamespaceSelector, peer.ExternalEntitySelector, nil))
+			addresses += len(pods) + len(ees)
+		}
+	}
+	if addresses == 0 {
+		return 1
+	}
+	return addresses
+}
+
+// getSelectorWorkloadsForEstimate returns the workloads selected by the provided selector. The selector is registered
+// to the grouping interface as a temporary group with a unique name, which is deleted once its members are computed.
+func (n *NetworkPolicyController) getSelectorWorkloadsForEstimate(selector *antreatypes.GroupSelector) ([]*v1.Pod, []*v1alpha2.ExternalEntity) {
+	name := fmt.Sprintf("%s-%d", getNormalizedUID(selector.NormalizedName), n.flowEstimateGroupCounter.Add(1))
+	n.groupingInterface.AddGroup(flowEstimateGroupType, name, selector)
+	defer n.groupingInterface.DeleteGroup(flowEstimateGroupType, name)
+	return n.groupingInterface.GetEntities(flowEstimateGroupType, name)
+}
+
+// getGroupWorkloadsForEstimate returns the workloads selected by the Group or ClusterGroup with the provided key,
+// including the ones of its child groups. It returns nothing if the group does not exist yet.
+func (n *NetworkPolicyController) getGroupWorkloadsForEstimate(key string) ([]*v1.Pod, []*v1alpha2.ExternalEntity) {
+	obj, found, _ := n.internalGroupStore.Get(key)
+	if !found {
+		return nil, nil
+	}
+	group := obj.(*antreatypes.Group)
+	if len(group.ChildGroups) == 0 {
+		return n.groupingInterface.GetEntities(internalGroupType, group.SourceReference.ToGroupName())
+	}
+	var pods []*v1.Pod
+	var ees []*v1alpha2.ExternalEntity
+	for _, childName := range group.ChildGroups {
+		childPods, childEEs := n.getGroupWorkloadsForEstimate(k8s.NamespacedName(group.SourceReference.Namespace, childName))
+		pods = append(pods, childPods...)
+		ees = append(ees, childEEs...)
+	}
+	return pods, ees
+}
+
+// listNodesForEstimate returns the Nodes selected by the provided selector.
+func (n *NetworkPolicyController) listNodesForEstimate(nodeSelector *metav1.LabelSelector) []*v1.Node {
+	selector, err := metav1.LabelSelectorAsSelector(nodeSelector)
+	if err != nil {
+		selector = labels.Nothing()
+	}
+	nodes, _ := n.nodeLister.List(selector)
+	return nodes
+}
+
+// validateFlowBudget checks the estimated number of flows installed by an Antrea-native policy on a single Node
+// against the configured budget. When the budget is exceeded, the policy is rejected, or accepted with a warning
+// returned as the reason, depending on the configuration.
+func (v *antreaPolicyValidator) validateFlowBudget(namespace string, specAppliedTo []v1alpha1.AppliedTo, ingress, egress []v1alpha1.Rule) (string, bool) {
+	budget := v.networkPolicyController.flowBudget
+	if budget.MaxFlowsPerNode <= 0 {
+		return "", true
+	}
+	flows, node := v.networkPolicyController.estimatePolicyFlowsPerNode(namespace, specAppliedTo, ingress, egress)
+	if flows <= budget.MaxFlowsPerNode {
+		return "", true
+	}
+	reason := fmt.Sprintf("the policy is estimated to install %d OVS flows on Node %s, which exceeds the per-Node flow budget of %d", flows, node, budget.MaxFlowsPerNode)
+	klog.InfoS("Antrea-native policy exceeds the per-Node flow budget", "estimatedFlows", flows, "node", node, "budget", budget.MaxFlowsPerNode, "reject", budget.Reject)
+	return reason, !budget.Reject
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+)
+
+func newFlowBudgetTestPolicy() *crdv1alpha1.NetworkPolicy {
+	port80 := intstr.FromInt(80)
+	allowAction := crdv1alpha1.RuleActionAllow
+	return &crdv1alpha1.NetworkPolicy{
+		TypeMeta:   metav1.TypeMeta{Kind: "NetworkPolicy", APIVersion: crdv1alpha1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-annp", Namespace: "ns1"},
+		Spec: crdv1alpha1.NetworkPolicySpec{
+			Priority: 1,
+			AppliedTo: []crdv1alpha1.AppliedTo{
+				{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+			},
+			Ingress: []crdv1alpha1.Rule{
+				{
+					Name:   "rule1",
+					Action: &allowAction,
+					From: []crdv1alpha1.NetworkPolicyPeer{
+						{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "client"}}},
+						{IPBlock: &crdv1alpha1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}}},
+					},
+					Ports: []crdv1alpha1.NetworkPolicyPort{{Port: &port80}},
+				},
+			},
+		},
+	}
+}
+
+func addFlowBudgetTestPods(c *networkPolicyController) {
+	// 3 web Pods on node1 and 1 on node2, 5 client Pods.
+	for i, nodeName := range []string{"node1", "node1", "node1", "node2"} {
+		pod := getPod(fmt.Sprintf("web-%d", i), "ns1", nodeName, fmt.Sprintf("1.1.1.%d", i+1), false)
+		pod.Labels = map[string]string{"app": "web"}
+		c.groupingInterface.AddPod(pod)
+	}
+	for i := 0; i < 5; i++ {
+		pod := getPod(fmt.Sprintf("client-%d", i), "ns1", "node3", fmt.Sprintf("1.1.2.%d", i+1), false)
+		pod.Labels = map[string]string{"app": "client"}
+		c.groupingInterface.AddPod(pod)
+	}
+}
+
+func TestEstimatePolicyFlowsPerNode(t *testing.T) {
+	_, c := newController(nil, nil)
+	addFlowBudgetTestPods(c)
+	policy := newFlowBudgetTestPolicy()
+
+	flows, node := c.estimatePolicyFlowsPerNode(policy.Namespace, policy.Spec.AppliedTo, policy.Spec.Ingress, policy.Spec.Egress)
+	// 3 appliedTo Pods, 5 client Pods, 2 ipBlock addresses, 1 port and 2 action flows.
+	assert.Equal(t, 13, flows)
+	assert.Equal(t, "node1", node)
+
+	// A policy selecting no workloads installs no flows.
+	policy.Spec.AppliedTo[0].PodSelector.MatchLabels["app"] = "none"
+	flows, node = c.estimatePolicyFlowsPerNode(policy.Namespace, policy.Spec.AppliedTo, policy.Spec.Ingress, policy.Spec.Egress)
+	assert.Equal(t, 0, flows)
+	assert.Equal(t, "", node)
+}
+
+func TestValidateFlowBudget(t *testing.T) {
+	tests := []struct {
+		name             string
+		config           FlowBudgetConfig
+		expectedAllowed  bool
+		expectedMessage  string
+		expectedWarnings []string
+	}{
+		{
+			name:            "budget disabled",
+			config:          FlowBudgetConfig{},
+			expectedAllowed: true,
+		},
+		{
+			name:            "within budget",
+			config:          FlowBudgetConfig{MaxFlowsPerNode: 13, Reject: true},
+			expectedAllowed: true,
+		},
+		{
+			name:            "exceeded budget rejected",
+			config:          FlowBudgetConfig{MaxFlowsPerNode: 12, Reject: true},
+			expectedAllowed: false,
+			expectedMessage: "the policy is estimated to install 13 OVS flows on Node node1, which exceeds the per-Node flow budget of 12",
+		},
+		{
+			name:             "exceeded budget warned",
+			config:           FlowBudgetConfig{MaxFlowsPerNode: 12},
+			expectedAllowed:  true,
+			expectedWarnings: []string{"the policy is estimated to install 13 OVS flows on Node node1, which exceeds the per-Node flow budget of 12"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c := newController(nil, nil)
+			addFlowBudgetTestPods(c)
+			c.SetFlowBudgetConfig(tt.config)
+			validator := NewNetworkPolicyValidator(c.NetworkPolicyController)
+			raw, err := json.Marshal(newFlowBudgetTestPolicy())
+			require.NoError(t, err)
+			review := &admv1.AdmissionReview{
+				Request: &admv1.AdmissionRequest{
+					Kind:      metav1.GroupVersionKind{Kind: "NetworkPolicy"},
+					Operation: admv1.Create,
+					Object:    runtime.RawExtension{Raw: raw},
+				},
+			}
+			response := validator.Validate(review)
+			assert.Equal(t, tt.expectedAllowed, response.Allowed)
+			assert.Equal(t, tt.expectedWarnings, response.Warnings)
+			if tt.expectedMessage != "" {
+				require.NotNil(t, response.Result)
+				assert.Equal(t, tt.expectedMessage, response.Result.Message)
+			} else {
+				assert.Nil(t, response.Result)
+			}
+		})
+	}
+}
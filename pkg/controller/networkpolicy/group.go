@@ -16,7 +16,6 @@ package networkpolicy
 
 import (
 	"context"
-	"net"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -63,14 +62,7 @@ func (n *NetworkPolicyController) updateGroup(oldObj, curObj interface{}) {
 		return true
 	}
 	ipBlocksUpdated := func() bool {
-		oldIPBs, newIPBs := sets.Set[string]{}, sets.Set[string]{}
-		for _, ipb := range oldGroup.IPBlocks {
-			oldIPBs.Insert(ipb.CIDR.String())
-		}
-		for _, ipb := range newGroup.IPBlocks {
-			newIPBs.Insert(ipb.CIDR.String())
-		}
-		return oldIPBs.Equal(newIPBs)
+		return !ipBlocksEqual(oldGroup.IPBlocks, newGroup.IPBlocks)
 	}
 	childGroupsUpdated := func() bool {
 		oldChildGroups, newChildGroups := sets.Set[string]{}, sets.Set[string]{}
@@ -126,16 +118,8 @@ func (n *NetworkPolicyController) processGroup(g *crdv1alpha3.Group) *antreatype
 		}
 		return &internalGroup
 	}
-	if len(g.Spec.IPBlocks) > 0 {
-		for i := range g.Spec.IPBlocks {
-			ipb, _ := toAntreaIPBlockForCRD(&g.Spec.IPBlocks[i])
-			internalGroup.IPBlocks = append(internalGroup.IPBlocks, *ipb)
-			// CIDR format is already validated by the webhook
-			_, ipNet, _ := net.ParseCIDR(g.Spec.IPBlocks[i].CIDR)
-			internalGroup.IPNets = append(internalGroup.IPNets, *ipNet)
-		}
-		return &internalGroup
-	}
+	// IPBlocks can be set together with a ServiceReference.
+	setInternalGroupIPBlocks(&internalGroup, g.Spec.IPBlocks)
 	svcSelector := g.Spec.ServiceReference
 	if svcSelector != nil {
 		// ServiceReference will be converted to groupSelector once the internalGroup is synced.
@@ -143,7 +127,7 @@ func (n *NetworkPolicyController) processGroup(g *crdv1alpha3.Group) *antreatype
 			Namespace: svcSelector.Namespace,
 			Name:      svcSelector.Name,
 		}
-	} else {
+	} else if len(g.Spec.IPBlocks) == 0 {
 		groupSelector := antreatypes.NewGroupSelector(g.Namespace, g.Spec.PodSelector, g.Spec.NamespaceSelector, g.Spec.ExternalEntitySelector, nil)
 		internalGroup.Selector = groupSelector
 	}
@@ -207,6 +191,7 @@ func (n *NetworkPolicyController) syncInternalNamespacedGroup(grp *antreatypes.G
 			Selector:         grp.Selector,
 			IPBlocks:         grp.IPBlocks,
 			IPNets:           grp.IPNets,
+			ExceptIPNets:     grp.ExceptIPNets,
 			ServiceReference: grp.ServiceReference,
 			ChildGroups:      grp.ChildGroups,
 		}
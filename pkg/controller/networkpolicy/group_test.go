@@ -37,6 +37,9 @@ func TestProcessGroup(t *testing.T) {
 	cidr := "10.0.0.0/24"
 	controlplaneIPNet, _ := cidrStrToIPNet(cidr)
 	_, ipNet, _ := net.ParseCIDR(cidr)
+	exceptCIDR := "10.0.0.128/25"
+	controlplaneExceptIPNet, _ := cidrStrToIPNet(exceptCIDR)
+	_, exceptIPNet, _ := net.ParseCIDR(exceptCIDR)
 	tests := []struct {
 		name          string
 		inputGroup    *crdv1alpha3.Group
@@ -149,6 +152,44 @@ func TestProcessGroup(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "g-with-svc-reference-and-ip-block-with-except",
+			inputGroup: &crdv1alpha3.Group{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "nsG", Name: "gG", UID: "uidG"},
+				Spec: crdv1alpha3.GroupSpec{
+					ServiceReference: &crdv1alpha1.NamespacedName{
+						Name:      "test-svc",
+						Namespace: "nsG",
+					},
+					IPBlocks: []crdv1alpha1.IPBlock{
+						{
+							CIDR:   cidr,
+							Except: []string{exceptCIDR},
+						},
+					},
+				},
+			},
+			expectedGroup: &antreatypes.Group{
+				UID: "uidG",
+				SourceReference: &controlplane.GroupReference{
+					Name:      "gG",
+					Namespace: "nsG",
+					UID:       "uidG",
+				},
+				ServiceReference: &controlplane.ServiceReference{
+					Name:      "test-svc",
+					Namespace: "nsG",
+				},
+				IPBlocks: []controlplane.IPBlock{
+					{
+						CIDR:   *controlplaneIPNet,
+						Except: []controlplane.IPNet{*controlplaneExceptIPNet},
+					},
+				},
+				IPNets:       []net.IPNet{*ipNet},
+				ExceptIPNets: [][]net.IPNet{{*exceptIPNet}},
+			},
+		},
 		{
 			name: "g-with-child-groups",
 			inputGroup: &crdv1alpha3.Group{
@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -41,6 +42,7 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 	networkinglisters "k8s.io/client-go/listers/networking/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
@@ -142,6 +144,8 @@ type NetworkPolicyController struct {
 	kubeClient clientset.Interface
 	// crdClient is the clientset for CRD API group.
 	crdClient versioned.Interface
+	// eventRecorder reports the Antrea-native policy changes rejected because of TierEntitlements.
+	eventRecorder record.EventRecorder
 
 	namespaceInformer coreinformers.NamespaceInformer
 	// namespaceLister is able to list/get Namespaces and is populated by the shared informer passed to
@@ -185,6 +189,11 @@ type NetworkPolicyController struct {
 	// tierListerSynced is a function which returns true if the Tiers shared informer has been synced at least once.
 	tierListerSynced cache.InformerSynced
 
+	tierEntitlementInformer secinformers.TierEntitlementInformer
+	// tierEntitlementListerSynced is a function which returns true if the TierEntitlements shared informer has been
+	// synced at least once.
+	tierEntitlementListerSynced cache.InformerSynced
+
 	cgInformer crdv1a3informers.ClusterGroupInformer
 	// cgLister is able to list/get ClusterGroups and is populated by the shared informer passed to
 	// NewClusterGroupController.
@@ -243,6 +252,11 @@ type NetworkPolicyController struct {
 	// Enable Stretched Networkpolicy feature which allows Antrea-native policies to select peer
 	// from other clusters in a ClusterSet.
 	stretchNPEnabled bool
+	// flowBudget is the per-Node flow budget of Antrea-native policies, which is checked when they are validated.
+	flowBudget FlowBudgetConfig
+	// flowEstimateGroupCounter is used to generate unique names for the temporary groups registered to estimate
+	// the number of flows of a policy.
+	flowEstimateGroupCounter atomic.Uint64
 	// heartbeatCh is an internal channel for testing. It's used to know whether all tasks have been
 	// processed, and to count executions of each function.
 	heartbeatCh chan heartbeat
@@ -385,16 +399,19 @@ func NewNetworkPolicyController(kubeClient clientset.Interface,
 	acnpInformer secinformers.ClusterNetworkPolicyInformer,
 	annpInformer secinformers.NetworkPolicyInformer,
 	tierInformer secinformers.TierInformer,
+	tierEntitlementInformer secinformers.TierEntitlementInformer,
 	cgInformer crdv1a3informers.ClusterGroupInformer,
 	grpInformer crdv1a3informers.GroupInformer,
 	addressGroupStore storage.Interface,
 	appliedToGroupStore storage.Interface,
 	internalNetworkPolicyStore storage.Interface,
 	internalGroupStore storage.Interface,
+	eventRecorder record.EventRecorder,
 	stretchedNPEnabled bool) *NetworkPolicyController {
 	n := &NetworkPolicyController{
 		kubeClient:                 kubeClient,
 		crdClient:                  crdClient,
+		eventRecorder:              eventRecorder,
 		networkPolicyInformer:      networkPolicyInformer,
 		networkPolicyLister:        networkPolicyInformer.Lister(),
 		networkPolicyListerSynced:  networkPolicyInformer.Informer().HasSynced,
@@ -444,6 +461,8 @@ func NewNetworkPolicyController(kubeClient clientset.Interface,
 		n.tierInformer = tierInformer
 		n.tierLister = tierInformer.Lister()
 		n.tierListerSynced = tierInformer.Informer().HasSynced
+		n.tierEntitlementInformer = tierEntitlementInformer
+		n.tierEntitlementListerSynced = tierEntitlementInformer.Informer().HasSynced
 		n.cgInformer = cgInformer
 		n.cgLister = cgInformer.Lister()
 		n.cgListerSynced = cgInformer.Informer().HasSynced
@@ -477,6 +496,7 @@ func NewNetworkPolicyController(kubeClient clientset.Interface,
 			resyncPeriod,
 		)
 		tierInformer.Informer().AddIndexers(tierIndexers)
+		tierEntitlementInformer.Informer().AddIndexers(tierEntitlementIndexers)
 		acnpInformer.Informer().AddIndexers(acnpIndexers)
 		acnpInformer.Informer().AddEventHandlerWithResyncPeriod(
 			cache.ResourceEventHandlerFuncs{
@@ -896,9 +916,9 @@ func (n *NetworkPolicyController) Run(stopCh <-chan struct{}) {
 	defer klog.Infof("Shutting down %s", controllerName)
 
 	cacheSyncs := []cache.InformerSynced{n.networkPolicyListerSynced, n.groupingInterfaceSynced}
-	// Only wait for acnpListerSynced and annpListerSynced when AntreaPolicy feature gate is enabled.
+	// Only wait for the listers of Antrea-native policies when AntreaPolicy feature gate is enabled.
 	if features.DefaultFeatureGate.Enabled(features.AntreaPolicy) {
-		cacheSyncs = append(cacheSyncs, n.acnpListerSynced, n.annpListerSynced, n.cgListerSynced)
+		cacheSyncs = append(cacheSyncs, n.acnpListerSynced, n.annpListerSynced, n.cgListerSynced, n.tierEntitlementListerSynced)
 	}
 	if !cache.WaitForNamedCacheSync(controllerName, stopCh, cacheSyncs...) {
 		return
@@ -1099,10 +1119,11 @@ func (n *NetworkPolicyController) getAddressGroupMemberSet(g *antreatypes.Addres
 // all the entities selected by an internal Group. For internal Groups that has childGroups,
 // the members are computed as the union of all its childGroup's members.
 func (n *NetworkPolicyController) getInternalGroupMembers(group *antreatypes.Group) (controlplane.GroupMemberSet, []controlplane.IPBlock) {
-	if len(group.IPBlocks) > 0 {
-		return nil, group.IPBlocks
-	} else if len(group.ChildGroups) == 0 {
-		return n.getMemberSetForGroupType(internalGroupType, group.SourceReference.ToGroupName()), nil
+	if len(group.ChildGroups) == 0 {
+		if !groupSelectsWorkloads(group) {
+			return nil, group.IPBlocks
+		}
+		return n.getMemberSetForGroupType(internalGroupType, group.SourceReference.ToGroupName()), group.IPBlocks
 	}
 	var ipBlocks []controlplane.IPBlock
 	groupMemberSet := controlplane.GroupMemberSet{}
@@ -1236,7 +1257,7 @@ func (n *NetworkPolicyController) syncAppliedToGroup(key string) error {
 	memberSetByNode := make(map[string]controlplane.GroupMemberSet)
 	var updatedAppliedToGroup *antreatypes.AppliedToGroup
 	if appliedToGroup.Service != nil {
-		// AppliedToGroup for NodePort Service span to all Nodes.
+		// AppliedToGroup for Service span to all Nodes.
 		nodeList, err := n.nodeLister.List(labels.Everything())
 		if err != nil {
 			return fmt.Errorf("unable to list Nodes")
@@ -1254,6 +1275,24 @@ func (n *NetworkPolicyController) syncAppliedToGroup(key string) error {
 			SpanMeta:          antreatypes.SpanMeta{NodeNames: appGroupNodeNames},
 		}
 		klog.V(2).InfoS("Updating existing AppliedToGroup", "Service", *appliedToGroup.Service, "numNodes", appGroupNodeNames.Len())
+	} else if appliedToGroup.Selector != nil && appliedToGroup.Selector.NodeSelector != nil {
+		// AppliedToGroup for Nodes spans to the selected Nodes, and each of them is the only member on its Node.
+		nodeList, err := n.nodeLister.List(appliedToGroup.Selector.NodeSelector)
+		if err != nil {
+			return fmt.Errorf("unable to list Nodes")
+		}
+		for _, node := range nodeList {
+			appGroupNodeNames.Insert(node.Name)
+			memberSetByNode[node.Name] = controlplane.NewGroupMemberSet(nodeToGroupMember(node))
+		}
+		updatedAppliedToGroup = &antreatypes.AppliedToGroup{
+			UID:               appliedToGroup.UID,
+			Name:              appliedToGroup.Name,
+			Selector:          appliedToGroup.Selector,
+			GroupMemberByNode: memberSetByNode,
+			SpanMeta:          antreatypes.SpanMeta{NodeNames: appGroupNodeNames},
+		}
+		klog.V(2).InfoS("Updating existing AppliedToGroup", "NodeSelector", appliedToGroup.Selector.NodeSelector.String(), "numNodes", appGroupNodeNames.Len())
 	} else {
 		pods, externalEntities, err := n.getAppliedToWorkloads(appliedToGroup)
 		if err != nil {
@@ -1505,7 +1544,9 @@ func (n *NetworkPolicyController) syncInternalNetworkPolicy(key *controlplane.Ne
 			}
 			klog.V(2).InfoS("Creating new AppliedToGroup", "name", name, "uid", appliedToGroup.UID, "selector", appliedToGroup.Selector, "service", appliedToGroup.Service)
 			n.appliedToGroupStore.Create(appliedToGroup)
-			if appliedToGroup.Selector != nil {
+			// For an AppliedToGroup that selects Nodes via nodeSelector, we calculate its members via NodeLister
+			// directly, instead of groupingInterface which handles Pod and ExternalEntity currently.
+			if appliedToGroup.Selector != nil && appliedToGroup.Selector.NodeSelector == nil {
 				n.groupingInterface.AddGroup(appliedToGroupType, appliedToGroup.Name, appliedToGroup.Selector)
 			}
 			appliedToGroupsToSync.Insert(name)
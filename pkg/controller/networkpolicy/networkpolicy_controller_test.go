@@ -40,6 +40,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
 	fakemcsversioned "antrea.io/antrea/multicluster/pkg/client/clientset/versioned/fake"
@@ -90,6 +91,7 @@ type networkPolicyController struct {
 	acnpStore                  cache.Store
 	annpStore                  cache.Store
 	tierStore                  cache.Store
+	tierEntitlementStore       cache.Store
 	cgStore                    cache.Store
 	gStore                     cache.Store
 	appliedToGroupStore        storage.Interface
@@ -135,12 +137,14 @@ func newController(k8sObjects, crdObjects []runtime.Object) (*fake.Clientset, *n
 		crdInformerFactory.Crd().V1alpha1().ClusterNetworkPolicies(),
 		crdInformerFactory.Crd().V1alpha1().NetworkPolicies(),
 		crdInformerFactory.Crd().V1alpha1().Tiers(),
+		crdInformerFactory.Crd().V1alpha1().TierEntitlements(),
 		cgInformer,
 		gInformer,
 		addressGroupStore,
 		appliedToGroupStore,
 		internalNetworkPolicyStore,
 		internalGroupStore,
+		record.NewFakeRecorder(100),
 		true)
 	npController.namespaceLister = informerFactory.Core().V1().Namespaces().Lister()
 	npController.namespaceListerSynced = alwaysReady
@@ -161,6 +165,7 @@ func newController(k8sObjects, crdObjects []runtime.Object) (*fake.Clientset, *n
 		crdInformerFactory.Crd().V1alpha1().ClusterNetworkPolicies().Informer().GetStore(),
 		crdInformerFactory.Crd().V1alpha1().NetworkPolicies().Informer().GetStore(),
 		crdInformerFactory.Crd().V1alpha1().Tiers().Informer().GetStore(),
+		crdInformerFactory.Crd().V1alpha1().TierEntitlements().Informer().GetStore(),
 		crdInformerFactory.Crd().V1alpha3().ClusterGroups().Informer().GetStore(),
 		crdInformerFactory.Crd().V1alpha3().Groups().Informer().GetStore(),
 		appliedToGroupStore,
@@ -232,6 +237,7 @@ func newControllerWithoutEventHandler(k8sObjects, crdObjects []runtime.Object) (
 		acnpInformer.Informer().GetStore(),
 		annpInformer.Informer().GetStore(),
 		tierInformer.Informer().GetStore(),
+		nil,
 		cgInformer.Informer().GetStore(),
 		groupInformer.Informer().GetStore(),
 		appliedToGroupStore,
@@ -2797,6 +2803,47 @@ func TestAddressGroupWithNodeSelector(t *testing.T) {
 	assert.False(t, groupMembers.Has(memberNode1))
 }
 
+func TestAppliedToGroupWithNodeSelector(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	_, c := newController(nil, nil)
+	c.informerFactory.Start(stopCh)
+	c.informerFactory.WaitForCacheSync(stopCh)
+
+	nodeSelectorA := metav1.LabelSelector{MatchLabels: map[string]string{"env": "pro"}}
+	fakeNode0 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "fakeNode0", Labels: nodeSelectorA.MatchLabels},
+		Status:     corev1.NodeStatus{Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "1.1.1.1"}}},
+	}
+	fakeNode1 := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "fakeNode1"},
+		Status:     corev1.NodeStatus{Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "1.1.1.2"}}},
+	}
+	for _, node := range []*corev1.Node{fakeNode0, fakeNode1} {
+		_, err := c.kubeClient.CoreV1().Nodes().Create(context.TODO(), node, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	assert.NoError(t, wait.Poll(time.Millisecond*100, time.Second, func() (done bool, err error) {
+		nodes, err := c.nodeLister.List(labels.Everything())
+		return len(nodes) == 2, err
+	}))
+
+	atg := c.createAppliedToGroupForNodeSelector(&nodeSelectorA)
+	assert.NoError(t, c.appliedToGroupStore.Create(atg))
+	assert.NoError(t, c.syncAppliedToGroup(atg.Name))
+	atgObj, _, err := c.appliedToGroupStore.Get(atg.Name)
+	require.NoError(t, err)
+	actualATG := atgObj.(*antreatypes.AppliedToGroup)
+	expectedMember := &controlplane.GroupMember{
+		Node: &controlplane.NodeReference{Name: "fakeNode0"},
+		IPs:  []controlplane.IPAddress{ipStrToIPAddress("1.1.1.1")},
+	}
+	assert.Equal(t, sets.New[string]("fakeNode0"), actualATG.SpanMeta.NodeNames)
+	assert.Equal(t, map[string]controlplane.GroupMemberSet{"fakeNode0": controlplane.NewGroupMemberSet(expectedMember)}, actualATG.GroupMemberByNode)
+	assert.Equal(t, sets.New[string](atg.Name), c.filterATGsFromNodeLabels(fakeNode0))
+	assert.Empty(t, c.filterATGsFromNodeLabels(fakeNode1))
+}
+
 func getK8sNetworkPolicyObj() *networkingv1.NetworkPolicy {
 	ns := metav1.NamespaceDefault
 	npName := "testing-1"
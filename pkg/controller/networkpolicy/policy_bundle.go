@@ -0,0 +1,403 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	secv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	crdv1alpha3 "antrea.io/antrea/pkg/apis/crd/v1alpha3"
+)
+
+const (
+	// PolicyBundleDriftMissing means that a resource of the bundle doesn't exist in the cluster.
+	PolicyBundleDriftMissing = "Missing"
+	// PolicyBundleDriftUnexpected means that a resource of the cluster doesn't exist in the bundle.
+	PolicyBundleDriftUnexpected = "Unexpected"
+	// PolicyBundleDriftModified means that a resource differs between the bundle and the cluster.
+	PolicyBundleDriftModified = "Modified"
+
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// PolicyBundle contains the Antrea-native policies of a cluster, along with the Tiers and the groups they
+// can reference, in a normalized form: the resources are sorted by Namespace and name, and the fields set
+// by the system, such as the status, the server-side metadata, and the fields defaulted by the mutating
+// webhook, are stripped. The system generated Tiers are not included.
+type PolicyBundle struct {
+	Tiers                  []secv1alpha1.Tier                 `json:"tiers,omitempty"`
+	ClusterGroups          []crdv1alpha3.ClusterGroup         `json:"clusterGroups,omitempty"`
+	Groups                 []crdv1alpha3.Group                `json:"groups,omitempty"`
+	ClusterNetworkPolicies []secv1alpha1.ClusterNetworkPolicy `json:"clusterNetworkPolicies,omitempty"`
+	NetworkPolicies        []secv1alpha1.NetworkPolicy        `json:"networkPolicies,omitempty"`
+}
+
+// MarshalJSON omits the empty status and creation timestamp of the resources, so that the bundle only
+// contains the fields set by users.
+func (b PolicyBundle) MarshalJSON() ([]byte, error) {
+	type policyBundle PolicyBundle
+	data, err := json.Marshal(policyBundle(b))
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var bundle map[string][]map[string]interface{}
+	if err := decoder.Decode(&bundle); err != nil {
+		return nil, err
+	}
+	for _, objects := range bundle {
+		for _, object := range objects {
+			delete(object, "status")
+			if meta, ok := object["metadata"].(map[string]interface{}); ok && meta["creationTimestamp"] == nil {
+				delete(meta, "creationTimestamp")
+			}
+		}
+	}
+	return json.Marshal(bundle)
+}
+
+// PolicyBundleDrift describes a difference between a PolicyBundle and the cluster.
+type PolicyBundleDrift struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Type is one of Missing, Unexpected and Modified.
+	Type string `json:"type"`
+	// Fields are the paths of the top-level fields which differ, for a Modified resource.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// PolicyBundler exports the Antrea-native policies of the cluster as a PolicyBundle, and compares a
+// PolicyBundle with the cluster.
+type PolicyBundler interface {
+	ExportPolicyBundle() (*PolicyBundle, error)
+	DiffPolicyBundle(bundle *PolicyBundle) ([]PolicyBundleDrift, error)
+}
+
+// policyBundler implements the PolicyBundler interface.
+type policyBundler struct {
+	networkPolicyController *NetworkPolicyController
+}
+
+// NewPolicyBundler returns a new PolicyBundler.
+func NewPolicyBundler(networkPolicyController *NetworkPolicyController) *policyBundler {
+	return &policyBundler{networkPolicyController: networkPolicyController}
+}
+
+func (b *policyBundler) ExportPolicyBundle() (*PolicyBundle, error) {
+	c := b.networkPolicyController
+	bundle := &PolicyBundle{}
+	tiers, err := c.tierLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Tiers: %w", err)
+	}
+	for _, t := range tiers {
+		bundle.Tiers = append(bundle.Tiers, *t)
+	}
+	cgs, err := c.cgLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ClusterGroups: %w", err)
+	}
+	for _, cg := range cgs {
+		bundle.ClusterGroups = append(bundle.ClusterGroups, *cg)
+	}
+	groups, err := c.grpLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Groups: %w", err)
+	}
+	for _, g := range groups {
+		bundle.Groups = append(bundle.Groups, *g)
+	}
+	acnps, err := c.acnpLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ClusterNetworkPolicies: %w", err)
+	}
+	for _, acnp := range acnps {
+		bundle.ClusterNetworkPolicies = append(bundle.ClusterNetworkPolicies, *acnp)
+	}
+	annps, err := c.annpLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list NetworkPolicies: %w", err)
+	}
+	for _, annp := range annps {
+		bundle.NetworkPolicies = append(bundle.NetworkPolicies, *annp)
+	}
+	return NormalizePolicyBundle(bundle), nil
+}
+
+func (b *policyBundler) DiffPolicyBundle(bundle *PolicyBundle) ([]PolicyBundleDrift, error) {
+	live, err := b.ExportPolicyBundle()
+	if err != nil {
+		return nil, err
+	}
+	desiredObjects, err := bundleObjects(NormalizePolicyBundle(bundle))
+	if err != nil {
+		return nil, err
+	}
+	liveObjects, err := bundleObjects(live)
+	if err != nil {
+		return nil, err
+	}
+	keys := sets.New[bundleObjectKey]()
+	for key := range desiredObjects {
+		keys.Insert(key)
+	}
+	for key := range liveObjects {
+		keys.Insert(key)
+	}
+	sortedKeys := keys.UnsortedList()
+	sort.Slice(sortedKeys, func(i, j int) bool {
+		return sortedKeys[i].less(sortedKeys[j])
+	})
+	drifts := []PolicyBundleDrift{}
+	for _, key := range sortedKeys {
+		drift := PolicyBundleDrift{Kind: key.kind, Namespace: key.namespace, Name: key.name}
+		desired, inBundle := desiredObjects[key]
+		actual, inCluster := liveObjects[key]
+		switch {
+		case !inCluster:
+			drift.Type = PolicyBundleDriftMissing
+		case !inBundle:
+			drift.Type = PolicyBundleDriftUnexpected
+		default:
+			drift.Fields = diffFields(desired, actual)
+			if len(drift.Fields) == 0 {
+				continue
+			}
+			drift.Type = PolicyBundleDriftModified
+		}
+		drifts = append(drifts, drift)
+	}
+	return drifts, nil
+}
+
+// NormalizePolicyBundle returns a normalized copy of the PolicyBundle, which can be compared with other
+// normalized bundles.
+func NormalizePolicyBundle(bundle *PolicyBundle) *PolicyBundle {
+	normalized := &PolicyBundle{}
+	for i := range bundle.Tiers {
+		t := bundle.Tiers[i].DeepCopy()
+		if isSystemGeneratedTier(t.Name) {
+			continue
+		}
+		t.TypeMeta = metav1.TypeMeta{APIVersion: secv1alpha1.SchemeGroupVersion.String(), Kind: "Tier"}
+		t.ObjectMeta = normalizeObjectMeta(t.ObjectMeta)
+		normalized.Tiers = append(normalized.Tiers, *t)
+	}
+	for i := range bundle.ClusterGroups {
+		cg := bundle.ClusterGroups[i].DeepCopy()
+		cg.TypeMeta = metav1.TypeMeta{APIVersion: crdv1alpha3.SchemeGroupVersion.String(), Kind: "ClusterGroup"}
+		cg.ObjectMeta = normalizeObjectMeta(cg.ObjectMeta)
+		cg.Status = crdv1alpha3.GroupStatus{}
+		normalized.ClusterGroups = append(normalized.ClusterGroups, *cg)
+	}
+	for i := range bundle.Groups {
+		g := bundle.Groups[i].DeepCopy()
+		g.TypeMeta = metav1.TypeMeta{APIVersion: crdv1alpha3.SchemeGroupVersion.String(), Kind: "Group"}
+		g.ObjectMeta = normalizeObjectMeta(g.ObjectMeta)
+		g.Status = crdv1alpha3.GroupStatus{}
+		normalized.Groups = append(normalized.Groups, *g)
+	}
+	for i := range bundle.ClusterNetworkPolicies {
+		acnp := bundle.ClusterNetworkPolicies[i].DeepCopy()
+		acnp.TypeMeta = metav1.TypeMeta{APIVersion: secv1alpha1.SchemeGroupVersion.String(), Kind: "ClusterNetworkPolicy"}
+		acnp.ObjectMeta = normalizeObjectMeta(acnp.ObjectMeta)
+		acnp.Status = secv1alpha1.NetworkPolicyStatus{}
+		acnp.Spec.Tier = normalizeTier(acnp.Spec.Tier)
+		normalizeRuleNames("ingress", acnp.Spec.Ingress)
+		normalizeRuleNames("egress", acnp.Spec.Egress)
+		normalized.ClusterNetworkPolicies = append(normalized.ClusterNetworkPolicies, *acnp)
+	}
+	for i := range bundle.NetworkPolicies {
+		annp := bundle.NetworkPolicies[i].DeepCopy()
+		annp.TypeMeta = metav1.TypeMeta{APIVersion: secv1alpha1.SchemeGroupVersion.String(), Kind: "NetworkPolicy"}
+		annp.ObjectMeta = normalizeObjectMeta(annp.ObjectMeta)
+		annp.Status = secv1alpha1.NetworkPolicyStatus{}
+		annp.Spec.Tier = normalizeTier(annp.Spec.Tier)
+		normalizeRuleNames("ingress", annp.Spec.Ingress)
+		normalizeRuleNames("egress", annp.Spec.Egress)
+		normalized.NetworkPolicies = append(normalized.NetworkPolicies, *annp)
+	}
+	sort.Slice(normalized.Tiers, func(i, j int) bool {
+		return normalized.Tiers[i].Name < normalized.Tiers[j].Name
+	})
+	sort.Slice(normalized.ClusterGroups, func(i, j int) bool {
+		return normalized.ClusterGroups[i].Name < normalized.ClusterGroups[j].Name
+	})
+	sort.Slice(normalized.Groups, func(i, j int) bool {
+		return lessNamespacedName(normalized.Groups[i].ObjectMeta, normalized.Groups[j].ObjectMeta)
+	})
+	sort.Slice(normalized.ClusterNetworkPolicies, func(i, j int) bool {
+		return normalized.ClusterNetworkPolicies[i].Name < normalized.ClusterNetworkPolicies[j].Name
+	})
+	sort.Slice(normalized.NetworkPolicies, func(i, j int) bool {
+		return lessNamespacedName(normalized.NetworkPolicies[i].ObjectMeta, normalized.NetworkPolicies[j].ObjectMeta)
+	})
+	return normalized
+}
+
+func isSystemGeneratedTier(name string) bool {
+	for _, t := range systemGeneratedTiers {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeObjectMeta only keeps the name, the Namespace, the labels and the annotations set by users.
+func normalizeObjectMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	normalized := metav1.ObjectMeta{Name: meta.Name, Namespace: meta.Namespace}
+	if len(meta.Labels) > 0 {
+		normalized.Labels = meta.Labels
+	}
+	for k, v := range meta.Annotations {
+		if k == lastAppliedConfigAnnotation {
+			continue
+		}
+		if normalized.Annotations == nil {
+			normalized.Annotations = map[string]string{}
+		}
+		normalized.Annotations[k] = v
+	}
+	return normalized
+}
+
+// normalizeTier strips the Tier set by the mutating webhook when it is unset.
+func normalizeTier(tier string) string {
+	if tier == defaultTierName {
+		return ""
+	}
+	return tier
+}
+
+// normalizeRuleNames strips the rule names generated by the mutating webhook when they are unset.
+func normalizeRuleNames(prefix string, rules []secv1alpha1.Rule) {
+	for i := range rules {
+		if rules[i].Name == "" || rules[i].Action == nil {
+			continue
+		}
+		rule := *rules[i].DeepCopy()
+		rule.Name = ""
+		_, generatedNames := generateRuleNames(prefix, []secv1alpha1.Rule{rule})
+		if len(generatedNames) == 1 && generatedNames[0] == rules[i].Name {
+			rules[i].Name = ""
+		}
+	}
+}
+
+func lessNamespacedName(a, b metav1.ObjectMeta) bool {
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+type bundleObjectKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func (k bundleObjectKey) less(other bundleObjectKey) bool {
+	if k.kind != other.kind {
+		return k.kind < other.kind
+	}
+	if k.namespace != other.namespace {
+		return k.namespace < other.namespace
+	}
+	return k.name < other.name
+}
+
+// bundleObjects returns the resources of a normalized PolicyBundle as generic objects, indexed by kind,
+// Namespace and name.
+func bundleObjects(bundle *PolicyBundle) (map[bundleObjectKey]map[string]interface{}, error) {
+	objects := map[bundleObjectKey]map[string]interface{}{}
+	add := func(kind string, meta metav1.ObjectMeta, obj interface{}) error {
+		data, err := json.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s %s: %w", kind, meta.Name, err)
+		}
+		var object map[string]interface{}
+		if err := json.Unmarshal(data, &object); err != nil {
+			return fmt.Errorf("failed to unmarshal %s %s: %w", kind, meta.Name, err)
+		}
+		objects[bundleObjectKey{kind: kind, namespace: meta.Namespace, name: meta.Name}] = object
+		return nil
+	}
+	for i := range bundle.Tiers {
+		if err := add("Tier", bundle.Tiers[i].ObjectMeta, &bundle.Tiers[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range bundle.ClusterGroups {
+		if err := add("ClusterGroup", bundle.ClusterGroups[i].ObjectMeta, &bundle.ClusterGroups[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range bundle.Groups {
+		if err := add("Group", bundle.Groups[i].ObjectMeta, &bundle.Groups[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range bundle.ClusterNetworkPolicies {
+		if err := add("ClusterNetworkPolicy", bundle.ClusterNetworkPolicies[i].ObjectMeta, &bundle.ClusterNetworkPolicies[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range bundle.NetworkPolicies {
+		if err := add("NetworkPolicy", bundle.NetworkPolicies[i].ObjectMeta, &bundle.NetworkPolicies[i]); err != nil {
+			return nil, err
+		}
+	}
+	return objects, nil
+}
+
+// diffFields returns the sorted paths of the labels, the annotations and the top-level spec fields which
+// differ between two generic objects.
+func diffFields(desired, actual map[string]interface{}) []string {
+	var fields []string
+	desiredMeta, _ := desired["metadata"].(map[string]interface{})
+	actualMeta, _ := actual["metadata"].(map[string]interface{})
+	for _, field := range []string{"labels", "annotations"} {
+		if !reflect.DeepEqual(desiredMeta[field], actualMeta[field]) {
+			fields = append(fields, "metadata."+field)
+		}
+	}
+	desiredSpec, _ := desired["spec"].(map[string]interface{})
+	actualSpec, _ := actual["spec"].(map[string]interface{})
+	specFields := sets.New[string]()
+	for field := range desiredSpec {
+		specFields.Insert(field)
+	}
+	for field := range actualSpec {
+		specFields.Insert(field)
+	}
+	for _, field := range sets.List(specFields) {
+		if !reflect.DeepEqual(desiredSpec[field], actualSpec[field]) {
+			fields = append(fields, "spec."+field)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	"antrea.io/antrea/pkg/apis/crd/v1alpha3"
+)
+
+func newBundleTestObjects() (*crdv1alpha1.Tier, *v1alpha3.ClusterGroup, *crdv1alpha1.ClusterNetworkPolicy, *crdv1alpha1.NetworkPolicy) {
+	allowAction := crdv1alpha1.RuleActionAllow
+	tier := &crdv1alpha1.Tier{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "tier1",
+			ResourceVersion: "10",
+			Annotations:     map[string]string{lastAppliedConfigAnnotation: "{}"},
+		},
+		Spec: crdv1alpha1.TierSpec{Priority: 10},
+	}
+	cg := &v1alpha3.ClusterGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "cg1", UID: "uid1"},
+		Spec:       v1alpha3.GroupSpec{IPBlocks: []crdv1alpha1.IPBlock{{CIDR: "10.0.0.0/24"}}},
+		Status:     v1alpha3.GroupStatus{Conditions: []v1alpha3.GroupCondition{{Type: v1alpha3.GroupMembersComputed}}},
+	}
+	rule := crdv1alpha1.Rule{
+		Action: &allowAction,
+		From:   []crdv1alpha1.NetworkPolicyPeer{{Group: "cg1"}},
+	}
+	_, generatedNames := generateRuleNames("ingress", []crdv1alpha1.Rule{rule})
+	rule.Name = generatedNames[0]
+	acnp := &crdv1alpha1.ClusterNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "acnp1", Generation: 2, Labels: map[string]string{"app": "web"}},
+		Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+			Tier:     defaultTierName,
+			Priority: 1,
+			AppliedTo: []crdv1alpha1.AppliedTo{{
+				PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			}},
+			Ingress: []crdv1alpha1.Rule{rule},
+		},
+		Status: crdv1alpha1.NetworkPolicyStatus{Phase: crdv1alpha1.NetworkPolicyRealized, ObservedGeneration: 2},
+	}
+	annp := &crdv1alpha1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "annp1"},
+		Spec: crdv1alpha1.NetworkPolicySpec{
+			Tier:     "tier1",
+			Priority: 1,
+			AppliedTo: []crdv1alpha1.AppliedTo{{
+				PodSelector: &metav1.LabelSelector{},
+			}},
+			Egress: []crdv1alpha1.Rule{{Name: "allow-dns", Action: &allowAction}},
+		},
+	}
+	return tier, cg, acnp, annp
+}
+
+func TestExportPolicyBundle(t *testing.T) {
+	_, npc := newController(nil, nil)
+	tier, cg, acnp, annp := newBundleTestObjects()
+	npc.tierStore.Add(systemGeneratedTiers[0])
+	npc.tierStore.Add(tier)
+	npc.cgStore.Add(cg)
+	npc.acnpStore.Add(acnp)
+	npc.annpStore.Add(annp)
+
+	bundle, err := NewPolicyBundler(npc.NetworkPolicyController).ExportPolicyBundle()
+	require.NoError(t, err)
+	data, err := json.Marshal(bundle)
+	require.NoError(t, err)
+	expected := `{
+  "clusterGroups": [{"apiVersion": "crd.antrea.io/v1alpha3", "kind": "ClusterGroup", "metadata": {"name": "cg1"}, "spec": {"ipBlocks": [{"cidr": "10.0.0.0/24"}]}}],
+  "clusterNetworkPolicies": [{"apiVersion": "crd.antrea.io/v1alpha1", "kind": "ClusterNetworkPolicy", "metadata": {"name": "acnp1", "labels": {"app": "web"}},
+    "spec": {"priority": 1, "appliedTo": [{"podSelector": {"matchLabels": {"app": "web"}}}], "ingress": [{"action": "Allow", "from": [{"group": "cg1"}], "enableLogging": false}]}}],
+  "networkPolicies": [{"apiVersion": "crd.antrea.io/v1alpha1", "kind": "NetworkPolicy", "metadata": {"name": "annp1", "namespace": "ns1"},
+    "spec": {"tier": "tier1", "priority": 1, "appliedTo": [{"podSelector": {}}], "egress": [{"action": "Allow", "name": "allow-dns", "enableLogging": false}]}}],
+  "tiers": [{"apiVersion": "crd.antrea.io/v1alpha1", "kind": "Tier", "metadata": {"name": "tier1"}, "spec": {"priority": 10}}]
+}`
+	assert.JSONEq(t, expected, string(data))
+}
+
+func TestDiffPolicyBundle(t *testing.T) {
+	tier, cg, acnp, annp := newBundleTestObjects()
+	bundle := &PolicyBundle{
+		Tiers:                  []crdv1alpha1.Tier{*tier},
+		ClusterGroups:          []v1alpha3.ClusterGroup{*cg},
+		ClusterNetworkPolicies: []crdv1alpha1.ClusterNetworkPolicy{*acnp},
+		NetworkPolicies:        []crdv1alpha1.NetworkPolicy{*annp},
+	}
+	// The bundle doesn't contain the fields set by the system.
+	bundle.ClusterNetworkPolicies[0].Spec.Tier = ""
+	bundle.ClusterNetworkPolicies[0].Spec.Ingress = []crdv1alpha1.Rule{*acnp.Spec.Ingress[0].DeepCopy()}
+	bundle.ClusterNetworkPolicies[0].Spec.Ingress[0].Name = ""
+
+	tests := []struct {
+		name           string
+		updateCluster  func(npc *networkPolicyController)
+		expectedDrifts []PolicyBundleDrift
+	}{
+		{
+			name:           "in sync",
+			expectedDrifts: []PolicyBundleDrift{},
+		},
+		{
+			name: "drifts",
+			updateCluster: func(npc *networkPolicyController) {
+				npc.cgStore.Delete(cg)
+				updatedACNP := acnp.DeepCopy()
+				updatedACNP.Labels = nil
+				updatedACNP.Spec.Priority = 2
+				npc.acnpStore.Update(updatedACNP)
+				npc.gStore.Add(&v1alpha3.Group{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "g1"}})
+			},
+			expectedDrifts: []PolicyBundleDrift{
+				{Kind: "ClusterGroup", Name: "cg1", Type: PolicyBundleDriftMissing},
+				{Kind: "ClusterNetworkPolicy", Name: "acnp1", Type: PolicyBundleDriftModified, Fields: []string{"metadata.labels", "spec.priority"}},
+				{Kind: "Group", Namespace: "ns1", Name: "g1", Type: PolicyBundleDriftUnexpected},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, npc := newController(nil, nil)
+			npc.tierStore.Add(systemGeneratedTiers[0])
+			npc.tierStore.Add(tier)
+			npc.cgStore.Add(cg)
+			npc.acnpStore.Add(acnp)
+			npc.annpStore.Add(annp)
+			if tt.updateCluster != nil {
+				tt.updateCluster(npc)
+			}
+			drifts, err := NewPolicyBundler(npc.NetworkPolicyController).DiffPolicyBundle(bundle)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedDrifts, drifts)
+		})
+	}
+}
@@ -28,7 +28,10 @@ import (
 	"antrea.io/antrea/pkg/controller/types"
 )
 
-const IsAppliedToServiceIndex = "isAppliedToService"
+const (
+	IsAppliedToServiceIndex = "isAppliedToService"
+	IsAppliedToNodeIndex    = "isAppliedToNode"
+)
 
 // appliedToGroupEvent implements storage.InternalEvent.
 type appliedToGroupEvent struct {
@@ -185,6 +188,13 @@ func NewAppliedToGroupStore() storage.Interface {
 			}
 			return []string{"true"}, nil
 		},
+		IsAppliedToNodeIndex: func(obj interface{}) ([]string, error) {
+			atg, ok := obj.(*types.AppliedToGroup)
+			if !ok || atg.Selector == nil || atg.Selector.NodeSelector == nil {
+				return []string{}, nil
+			}
+			return []string{"true"}, nil
+		},
 	}
 	return ram.NewStore(AppliedToGroupKeyFunc, indexers, genAppliedToGroupEvent, keyAndSpanSelectFunc, func() runtime.Object { return new(controlplane.AppliedToGroup) })
 }
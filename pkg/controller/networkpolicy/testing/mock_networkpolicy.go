@@ -14,7 +14,7 @@
 //
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: antrea.io/antrea/pkg/controller/networkpolicy (interfaces: EndpointQuerier)
+// Source: antrea.io/antrea/pkg/controller/networkpolicy (interfaces: EndpointQuerier,PolicyBundler)
 
 // Package testing is a generated GoMock package.
 package testing
@@ -22,6 +22,7 @@ package testing
 import (
 	networkpolicy "antrea.io/antrea/pkg/controller/networkpolicy"
 	gomock "github.com/golang/mock/gomock"
+	net "net"
 	reflect "reflect"
 )
 
@@ -48,6 +49,21 @@ func (m *MockEndpointQuerier) EXPECT() *MockEndpointQuerierMockRecorder {
 	return m.recorder
 }
 
+// QueryGroupsByIP mocks base method
+func (m *MockEndpointQuerier) QueryGroupsByIP(arg0 net.IP) ([]networkpolicy.IPGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryGroupsByIP", arg0)
+	ret0, _ := ret[0].([]networkpolicy.IPGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryGroupsByIP indicates an expected call of QueryGroupsByIP
+func (mr *MockEndpointQuerierMockRecorder) QueryGroupsByIP(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryGroupsByIP", reflect.TypeOf((*MockEndpointQuerier)(nil).QueryGroupsByIP), arg0)
+}
+
 // QueryNetworkPolicies mocks base method
 func (m *MockEndpointQuerier) QueryNetworkPolicies(arg0, arg1 string) (*networkpolicy.EndpointQueryResponse, error) {
 	m.ctrl.T.Helper()
@@ -62,3 +78,56 @@ func (mr *MockEndpointQuerierMockRecorder) QueryNetworkPolicies(arg0, arg1 inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryNetworkPolicies", reflect.TypeOf((*MockEndpointQuerier)(nil).QueryNetworkPolicies), arg0, arg1)
 }
+
+// MockPolicyBundler is a mock of PolicyBundler interface
+type MockPolicyBundler struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyBundlerMockRecorder
+}
+
+// MockPolicyBundlerMockRecorder is the mock recorder for MockPolicyBundler
+type MockPolicyBundlerMockRecorder struct {
+	mock *MockPolicyBundler
+}
+
+// NewMockPolicyBundler creates a new mock instance
+func NewMockPolicyBundler(ctrl *gomock.Controller) *MockPolicyBundler {
+	mock := &MockPolicyBundler{ctrl: ctrl}
+	mock.recorder = &MockPolicyBundlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPolicyBundler) EXPECT() *MockPolicyBundlerMockRecorder {
+	return m.recorder
+}
+
+// DiffPolicyBundle mocks base method
+func (m *MockPolicyBundler) DiffPolicyBundle(arg0 *networkpolicy.PolicyBundle) ([]networkpolicy.PolicyBundleDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffPolicyBundle", arg0)
+	ret0, _ := ret[0].([]networkpolicy.PolicyBundleDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffPolicyBundle indicates an expected call of DiffPolicyBundle
+func (mr *MockPolicyBundlerMockRecorder) DiffPolicyBundle(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffPolicyBundle", reflect.TypeOf((*MockPolicyBundler)(nil).DiffPolicyBundle), arg0)
+}
+
+// ExportPolicyBundle mocks base method
+func (m *MockPolicyBundler) ExportPolicyBundle() (*networkpolicy.PolicyBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportPolicyBundle")
+	ret0, _ := ret[0].(*networkpolicy.PolicyBundle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportPolicyBundle indicates an expected call of ExportPolicyBundle
+func (mr *MockPolicyBundlerMockRecorder) ExportPolicyBundle() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportPolicyBundle", reflect.TypeOf((*MockPolicyBundler)(nil).ExportPolicyBundle))
+}
@@ -0,0 +1,260 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"fmt"
+	"strings"
+
+	admv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+	"antrea.io/antrea/pkg/util/env"
+)
+
+const (
+	// tierEntitlementDeniedReason is the reason of the Events reporting the Antrea-native policy changes rejected
+	// because the requesting user is not entitled to the Tier of the policy.
+	tierEntitlementDeniedReason = "TierEntitlementDenied"
+)
+
+var tierEntitlementIndexers = cache.Indexers{
+	TierIndex: func(obj interface{}) ([]string, error) {
+		te, ok := obj.(*crdv1alpha1.TierEntitlement)
+		if !ok {
+			return []string{}, nil
+		}
+		return te.Spec.Tiers, nil
+	},
+}
+
+// tierEntitlementValidator implements the validator interface for TierEntitlement resources.
+type tierEntitlementValidator resourceValidator
+
+// policyEntitlementValidator implements the validator interface for Antrea-native policies. It rejects the changes
+// of the policies in the Tiers protected by TierEntitlements, unless the requesting user is entitled to the Tiers.
+type policyEntitlementValidator resourceValidator
+
+// RegisterTierEntitlementValidator registers a TierEntitlement validator to the resource registry.
+// A new validator must be registered by calling this function before the Run phase of the APIServer.
+func (v *NetworkPolicyValidator) RegisterTierEntitlementValidator(t validator) {
+	v.tierEntitlementValidators = append(v.tierEntitlementValidators, t)
+}
+
+// validateTierEntitlement validates the admission of a TierEntitlement resource.
+func (v *NetworkPolicyValidator) validateTierEntitlement(curTE, oldTE *crdv1alpha1.TierEntitlement, op admv1.Operation, userInfo authenticationv1.UserInfo) (string, bool) {
+	allowed := true
+	reason := ""
+	switch op {
+	case admv1.Create:
+		klog.V(2).Info("Validating CREATE request for TierEntitlement")
+		for _, val := range v.tierEntitlementValidators {
+			reason, allowed = val.createValidate(curTE, userInfo)
+			if !allowed {
+				return reason, allowed
+			}
+		}
+	case admv1.Update:
+		klog.V(2).Info("Validating UPDATE request for TierEntitlement")
+		for _, val := range v.tierEntitlementValidators {
+			reason, allowed = val.updateValidate(curTE, oldTE, userInfo)
+			if !allowed {
+				return reason, allowed
+			}
+		}
+	case admv1.Delete:
+		klog.V(2).Info("Validating DELETE request for TierEntitlement")
+		for _, val := range v.tierEntitlementValidators {
+			reason, allowed = val.deleteValidate(oldTE, userInfo)
+			if !allowed {
+				return reason, allowed
+			}
+		}
+	}
+	return reason, allowed
+}
+
+// createValidate validates the CREATE events of TierEntitlement resources.
+func (t *tierEntitlementValidator) createValidate(curObj interface{}, userInfo authenticationv1.UserInfo) (string, bool) {
+	return validateTierEntitlementSpec(&curObj.(*crdv1alpha1.TierEntitlement).Spec)
+}
+
+// updateValidate validates the UPDATE events of TierEntitlement resources.
+func (t *tierEntitlementValidator) updateValidate(curObj, oldObj interface{}, userInfo authenticationv1.UserInfo) (string, bool) {
+	return validateTierEntitlementSpec(&curObj.(*crdv1alpha1.TierEntitlement).Spec)
+}
+
+// deleteValidate validates the DELETE events of TierEntitlement resources.
+func (t *tierEntitlementValidator) deleteValidate(oldObj interface{}, userInfo authenticationv1.UserInfo) (string, bool) {
+	return "", true
+}
+
+func validateTierEntitlementSpec(spec *crdv1alpha1.TierEntitlementSpec) (string, bool) {
+	if len(spec.Tiers) == 0 {
+		return "tiers must not be empty", false
+	}
+	for _, tier := range spec.Tiers {
+		if tier == "" {
+			return "tier name must not be empty", false
+		}
+		if tier != normalizeTierName(tier) {
+			return fmt.Sprintf("tier %s must be referred to by its lowercase name %s", tier, normalizeTierName(tier)), false
+		}
+	}
+	if len(spec.Subjects) == 0 {
+		return "subjects must not be empty", false
+	}
+	for _, subject := range spec.Subjects {
+		if subject.Name == "" {
+			return "subject name must not be empty", false
+		}
+		switch subject.Kind {
+		case crdv1alpha1.TierEntitlementSubjectKindUser, crdv1alpha1.TierEntitlementSubjectKindGroup:
+			if subject.Namespace != "" {
+				return fmt.Sprintf("namespace must not be set for %s subject %s", subject.Kind, subject.Name), false
+			}
+		case crdv1alpha1.TierEntitlementSubjectKindServiceAccount:
+			if subject.Namespace == "" {
+				return fmt.Sprintf("namespace must be set for ServiceAccount subject %s", subject.Name), false
+			}
+		default:
+			return fmt.Sprintf("invalid subject kind %q: must be User, Group or ServiceAccount", subject.Kind), false
+		}
+	}
+	return "", true
+}
+
+// normalizeTierName returns the name of the Tier CRD referred to by the Tier name of an Antrea-native policy. An empty
+// Tier name refers to the default Application Tier, and the names of the deprecated static Tiers refer to the Tier
+// CRDs with the lowercase names.
+func normalizeTierName(tier string) string {
+	if tier == "" {
+		return defaultTierName
+	}
+	if staticTierSet.Has(tier) {
+		return strings.ToLower(tier)
+	}
+	return tier
+}
+
+// createValidate validates the CREATE events of Antrea-native policies.
+func (v *policyEntitlementValidator) createValidate(curObj interface{}, userInfo authenticationv1.UserInfo) (string, bool) {
+	return v.validateEntitlement(admv1.Create, curObj, userInfo)
+}
+
+// updateValidate validates the UPDATE events of Antrea-native policies. The user must be entitled to both the old
+// Tier and the new Tier of the policy, so that a policy cannot be moved into or out of a protected Tier by others.
+func (v *policyEntitlementValidator) updateValidate(curObj, oldObj interface{}, userInfo authenticationv1.UserInfo) (string, bool) {
+	if reason, allowed := v.validateEntitlement(admv1.Update, oldObj, userInfo); !allowed {
+		return reason, allowed
+	}
+	if getPolicyTier(curObj) == getPolicyTier(oldObj) {
+		return "", true
+	}
+	return v.validateEntitlement(admv1.Update, curObj, userInfo)
+}
+
+// deleteValidate validates the DELETE events of Antrea-native policies.
+func (v *policyEntitlementValidator) deleteValidate(oldObj interface{}, userInfo authenticationv1.UserInfo) (string, bool) {
+	return v.validateEntitlement(admv1.Delete, oldObj, userInfo)
+}
+
+func getPolicyTier(obj interface{}) string {
+	switch p := obj.(type) {
+	case *crdv1alpha1.ClusterNetworkPolicy:
+		return normalizeTierName(p.Spec.Tier)
+	case *crdv1alpha1.NetworkPolicy:
+		return normalizeTierName(p.Spec.Tier)
+	}
+	return ""
+}
+
+func (v *policyEntitlementValidator) validateEntitlement(op admv1.Operation, obj interface{}, userInfo authenticationv1.UserInfo) (string, bool) {
+	tier := getPolicyTier(obj)
+	entitled, err := v.isEntitled(tier, userInfo)
+	if err != nil {
+		return fmt.Sprintf("failed to get the TierEntitlements of tier %s: %v", tier, err), false
+	}
+	if entitled {
+		return "", true
+	}
+	var kind, name string
+	switch p := obj.(type) {
+	case *crdv1alpha1.ClusterNetworkPolicy:
+		kind, name = "Antrea ClusterNetworkPolicy", p.Name
+	case *crdv1alpha1.NetworkPolicy:
+		kind, name = "Antrea NetworkPolicy", p.Namespace+"/"+p.Name
+	}
+	verb := strings.ToLower(string(op))
+	klog.InfoS("Rejected Antrea-native policy change not entitled by TierEntitlements", "operation", op, "kind", kind, "policy", name, "tier", tier, "user", userInfo.Username, "groups", userInfo.Groups)
+	// The Event is recorded on the Tier, as the policy may not exist yet.
+	if t, err := v.networkPolicyController.tierLister.Get(tier); err == nil {
+		v.networkPolicyController.eventRecorder.Eventf(t, corev1.EventTypeWarning, tierEntitlementDeniedReason,
+			"User %s is not entitled to %s %s %s in Tier %s", userInfo.Username, verb, kind, name, tier)
+	}
+	return fmt.Sprintf("user %s is not entitled to %s %s in tier %s", userInfo.Username, verb, kind, tier), false
+}
+
+// isEntitled returns whether the user is entitled to manage the Antrea-native policies in the Tier. All users are
+// entitled to the Tiers which are not referenced by any TierEntitlement. The antrea-controller ServiceAccount and the
+// members of the system:masters group, which bypass K8s RBAC as well, are entitled to all Tiers.
+func (v *policyEntitlementValidator) isEntitled(tier string, userInfo authenticationv1.UserInfo) (bool, error) {
+	if serviceaccount.MatchesUsername(env.GetAntreaNamespace(), env.GetAntreaControllerServiceAccount(), userInfo.Username) {
+		return true, nil
+	}
+	for _, group := range userInfo.Groups {
+		if group == user.SystemPrivilegedGroup {
+			return true, nil
+		}
+	}
+	tierEntitlements, err := v.networkPolicyController.tierEntitlementInformer.Informer().GetIndexer().ByIndex(TierIndex, tier)
+	if err != nil {
+		return false, err
+	}
+	if len(tierEntitlements) == 0 {
+		return true, nil
+	}
+	for _, obj := range tierEntitlements {
+		te := obj.(*crdv1alpha1.TierEntitlement)
+		for _, subject := range te.Spec.Subjects {
+			if subjectMatches(subject, userInfo) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func subjectMatches(subject crdv1alpha1.TierEntitlementSubject, userInfo authenticationv1.UserInfo) bool {
+	switch subject.Kind {
+	case crdv1alpha1.TierEntitlementSubjectKindUser:
+		return userInfo.Username == subject.Name
+	case crdv1alpha1.TierEntitlementSubjectKindGroup:
+		for _, group := range userInfo.Groups {
+			if group == subject.Name {
+				return true
+			}
+		}
+	case crdv1alpha1.TierEntitlementSubjectKindServiceAccount:
+		return serviceaccount.MatchesUsername(subject.Namespace, subject.Name, userInfo.Username)
+	}
+	return false
+}
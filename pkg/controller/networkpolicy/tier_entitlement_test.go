@@ -0,0 +1,291 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	crdv1alpha1 "antrea.io/antrea/pkg/apis/crd/v1alpha1"
+)
+
+func TestValidateTierEntitlement(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           crdv1alpha1.TierEntitlementSpec
+		operation      admv1.Operation
+		expectedReason string
+	}{
+		{
+			name: "create-pass",
+			spec: crdv1alpha1.TierEntitlementSpec{
+				Tiers: []string{"emergency", "securityops"},
+				Subjects: []crdv1alpha1.TierEntitlementSubject{
+					{Kind: crdv1alpha1.TierEntitlementSubjectKindUser, Name: "alice"},
+					{Kind: crdv1alpha1.TierEntitlementSubjectKindGroup, Name: "secops"},
+					{Kind: crdv1alpha1.TierEntitlementSubjectKindServiceAccount, Name: "policy-bot", Namespace: "ops"},
+				},
+			},
+			operation: admv1.Create,
+		},
+		{
+			name: "create-no-tiers",
+			spec: crdv1alpha1.TierEntitlementSpec{
+				Subjects: []crdv1alpha1.TierEntitlementSubject{
+					{Kind: crdv1alpha1.TierEntitlementSubjectKindUser, Name: "alice"},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "tiers must not be empty",
+		},
+		{
+			name: "update-static-tier-name",
+			spec: crdv1alpha1.TierEntitlementSpec{
+				Tiers: []string{"Emergency"},
+				Subjects: []crdv1alpha1.TierEntitlementSubject{
+					{Kind: crdv1alpha1.TierEntitlementSubjectKindUser, Name: "alice"},
+				},
+			},
+			operation:      admv1.Update,
+			expectedReason: "tier Emergency must be referred to by its lowercase name emergency",
+		},
+		{
+			name: "create-no-subjects",
+			spec: crdv1alpha1.TierEntitlementSpec{
+				Tiers: []string{"emergency"},
+			},
+			operation:      admv1.Create,
+			expectedReason: "subjects must not be empty",
+		},
+		{
+			name: "create-user-with-namespace",
+			spec: crdv1alpha1.TierEntitlementSpec{
+				Tiers: []string{"emergency"},
+				Subjects: []crdv1alpha1.TierEntitlementSubject{
+					{Kind: crdv1alpha1.TierEntitlementSubjectKindUser, Name: "alice", Namespace: "ops"},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "namespace must not be set for User subject alice",
+		},
+		{
+			name: "create-serviceaccount-without-namespace",
+			spec: crdv1alpha1.TierEntitlementSpec{
+				Tiers: []string{"emergency"},
+				Subjects: []crdv1alpha1.TierEntitlementSubject{
+					{Kind: crdv1alpha1.TierEntitlementSubjectKindServiceAccount, Name: "policy-bot"},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "namespace must be set for ServiceAccount subject policy-bot",
+		},
+		{
+			name: "create-invalid-kind",
+			spec: crdv1alpha1.TierEntitlementSpec{
+				Tiers: []string{"emergency"},
+				Subjects: []crdv1alpha1.TierEntitlementSubject{
+					{Kind: "Role", Name: "admin"},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: `invalid subject kind "Role": must be User, Group or ServiceAccount`,
+		},
+		{
+			name:      "delete-pass",
+			operation: admv1.Delete,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, controller := newController(nil, nil)
+			validator := NewNetworkPolicyValidator(controller.NetworkPolicyController)
+			te := &crdv1alpha1.TierEntitlement{
+				ObjectMeta: metav1.ObjectMeta{Name: "te"},
+				Spec:       tt.spec,
+			}
+			actualReason, allowed := validator.validateTierEntitlement(te, te, tt.operation, authenticationv1.UserInfo{})
+			assert.Equal(t, tt.expectedReason, actualReason)
+			assert.Equal(t, tt.expectedReason == "", allowed)
+		})
+	}
+}
+
+func TestValidatePolicyEntitlement(t *testing.T) {
+	applicationTier := &crdv1alpha1.Tier{
+		ObjectMeta: metav1.ObjectMeta{Name: "application"},
+		Spec:       crdv1alpha1.TierSpec{Priority: DefaultTierPriority},
+	}
+	emergencyTier := &crdv1alpha1.Tier{
+		ObjectMeta: metav1.ObjectMeta{Name: "emergency"},
+		Spec:       crdv1alpha1.TierSpec{Priority: 50},
+	}
+	tierEntitlement := &crdv1alpha1.TierEntitlement{
+		ObjectMeta: metav1.ObjectMeta{Name: "emergency-admins"},
+		Spec: crdv1alpha1.TierEntitlementSpec{
+			Tiers: []string{"emergency"},
+			Subjects: []crdv1alpha1.TierEntitlementSubject{
+				{Kind: crdv1alpha1.TierEntitlementSubjectKindUser, Name: "alice"},
+				{Kind: crdv1alpha1.TierEntitlementSubjectKindGroup, Name: "secops"},
+				{Kind: crdv1alpha1.TierEntitlementSubjectKindServiceAccount, Name: "policy-bot", Namespace: "ops"},
+			},
+		},
+	}
+	newACNP := func(tier string) *crdv1alpha1.ClusterNetworkPolicy {
+		return &crdv1alpha1.ClusterNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "acnp"},
+			Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+				AppliedTo: []crdv1alpha1.AppliedTo{
+					{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}}},
+				},
+				Tier: tier,
+			},
+		}
+	}
+	newANNP := func(tier string) *crdv1alpha1.NetworkPolicy {
+		return &crdv1alpha1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "annp", Namespace: "ns1"},
+			Spec: crdv1alpha1.NetworkPolicySpec{
+				AppliedTo: []crdv1alpha1.AppliedTo{
+					{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}}},
+				},
+				Tier: tier,
+			},
+		}
+	}
+	tests := []struct {
+		name           string
+		curPolicy      interface{}
+		oldPolicy      interface{}
+		operation      admv1.Operation
+		user           authenticationv1.UserInfo
+		expectedReason string
+		expectedEvent  string
+	}{
+		{
+			name:      "create-unprotected-tier",
+			curPolicy: newACNP("application"),
+			operation: admv1.Create,
+			user:      authenticationv1.UserInfo{Username: "bob"},
+		},
+		{
+			name:      "create-default-tier",
+			curPolicy: newANNP(""),
+			operation: admv1.Create,
+			user:      authenticationv1.UserInfo{Username: "bob"},
+		},
+		{
+			name:      "create-entitled-user",
+			curPolicy: newACNP("emergency"),
+			operation: admv1.Create,
+			user:      authenticationv1.UserInfo{Username: "alice"},
+		},
+		{
+			name:      "create-entitled-group",
+			curPolicy: newANNP("emergency"),
+			operation: admv1.Create,
+			user:      authenticationv1.UserInfo{Username: "bob", Groups: []string{"system:authenticated", "secops"}},
+		},
+		{
+			name:      "create-entitled-serviceaccount",
+			curPolicy: newACNP("Emergency"),
+			operation: admv1.Create,
+			user:      authenticationv1.UserInfo{Username: "system:serviceaccount:ops:policy-bot"},
+		},
+		{
+			name:      "create-privileged-group",
+			curPolicy: newACNP("emergency"),
+			operation: admv1.Create,
+			user:      authenticationv1.UserInfo{Username: "kubernetes-admin", Groups: []string{"system:masters"}},
+		},
+		{
+			name:           "create-not-entitled",
+			curPolicy:      newACNP("emergency"),
+			operation:      admv1.Create,
+			user:           authenticationv1.UserInfo{Username: "bob"},
+			expectedReason: "user bob is not entitled to create Antrea ClusterNetworkPolicy in tier emergency",
+			expectedEvent:  "Warning TierEntitlementDenied User bob is not entitled to create Antrea ClusterNetworkPolicy acnp in Tier emergency",
+		},
+		{
+			name:           "create-not-entitled-static-tier-name",
+			curPolicy:      newANNP("Emergency"),
+			operation:      admv1.Create,
+			user:           authenticationv1.UserInfo{Username: "system:serviceaccount:default:policy-bot"},
+			expectedReason: "user system:serviceaccount:default:policy-bot is not entitled to create Antrea NetworkPolicy in tier emergency",
+			expectedEvent:  "Warning TierEntitlementDenied User system:serviceaccount:default:policy-bot is not entitled to create Antrea NetworkPolicy ns1/annp in Tier emergency",
+		},
+		{
+			name:           "update-into-protected-tier",
+			curPolicy:      newACNP("emergency"),
+			oldPolicy:      newACNP("application"),
+			operation:      admv1.Update,
+			user:           authenticationv1.UserInfo{Username: "bob"},
+			expectedReason: "user bob is not entitled to update Antrea ClusterNetworkPolicy in tier emergency",
+			expectedEvent:  "Warning TierEntitlementDenied User bob is not entitled to update Antrea ClusterNetworkPolicy acnp in Tier emergency",
+		},
+		{
+			name:           "update-out-of-protected-tier",
+			curPolicy:      newACNP("application"),
+			oldPolicy:      newACNP("emergency"),
+			operation:      admv1.Update,
+			user:           authenticationv1.UserInfo{Username: "bob"},
+			expectedReason: "user bob is not entitled to update Antrea ClusterNetworkPolicy in tier emergency",
+			expectedEvent:  "Warning TierEntitlementDenied User bob is not entitled to update Antrea ClusterNetworkPolicy acnp in Tier emergency",
+		},
+		{
+			name:      "update-entitled-user",
+			curPolicy: newACNP("application"),
+			oldPolicy: newACNP("emergency"),
+			operation: admv1.Update,
+			user:      authenticationv1.UserInfo{Username: "alice"},
+		},
+		{
+			name:           "delete-not-entitled",
+			oldPolicy:      newANNP("emergency"),
+			operation:      admv1.Delete,
+			user:           authenticationv1.UserInfo{Username: "bob"},
+			expectedReason: "user bob is not entitled to delete Antrea NetworkPolicy in tier emergency",
+			expectedEvent:  "Warning TierEntitlementDenied User bob is not entitled to delete Antrea NetworkPolicy ns1/annp in Tier emergency",
+		},
+		{
+			name:      "delete-unprotected-tier",
+			oldPolicy: newANNP("application"),
+			operation: admv1.Delete,
+			user:      authenticationv1.UserInfo{Username: "bob"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, controller := newController(nil, nil)
+			controller.tierStore.Add(applicationTier)
+			controller.tierStore.Add(emergencyTier)
+			controller.tierEntitlementStore.Add(tierEntitlement)
+			validator := NewNetworkPolicyValidator(controller.NetworkPolicyController)
+			actualReason, allowed := validator.validateAntreaPolicy(tt.curPolicy, tt.oldPolicy, tt.operation, tt.user)
+			assert.Equal(t, tt.expectedReason, actualReason)
+			assert.Equal(t, tt.expectedReason == "", allowed)
+			recorder := controller.eventRecorder.(*record.FakeRecorder)
+			if tt.expectedEvent == "" {
+				assert.Empty(t, recorder.Events)
+			} else {
+				assert.Equal(t, tt.expectedEvent, <-recorder.Events)
+			}
+		})
+	}
+}
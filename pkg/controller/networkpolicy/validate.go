@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"strings"
 
+	"golang.org/x/exp/slices"
 	admv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/core/v1"
@@ -45,7 +46,8 @@ import (
 // by any resource validator.
 type validator interface {
 	// createValidate is the interface which must be satisfied for resource
-	// CREATE events.
+	// CREATE events. A reason returned along with an allowed event is
+	// surfaced as a warning to the client.
 	createValidate(curObj interface{}, userInfo authenticationv1.UserInfo) (string, bool)
 	// updateValidate is the interface which must be satisfied for resource
 	// UPDATE events.
@@ -119,6 +121,9 @@ type NetworkPolicyValidator struct {
 	// groupValidators maintains a list of validator objects which
 	// implement the validator interface for ClusterGroup resources.
 	groupValidators []validator
+	// tierEntitlementValidators maintains a list of validator objects which
+	// implement the validator interface for TierEntitlement resources.
+	tierEntitlementValidators []validator
 }
 
 // NewNetworkPolicyValidator returns a new *NetworkPolicyValidator.
@@ -126,6 +131,11 @@ func NewNetworkPolicyValidator(networkPolicyController *NetworkPolicyController)
 	// initialize the validator registry with the default validators that need to
 	// be called.
 	vr := NetworkPolicyValidator{}
+	// pev is an instance of policyEntitlementValidator to check that the
+	// users are entitled to the Tiers of Antrea-native policy events.
+	pev := policyEntitlementValidator{
+		networkPolicyController: networkPolicyController,
+	}
 	// apv is an instance of antreaPolicyValidator to validate Antrea-native
 	// policy events.
 	apv := antreaPolicyValidator{
@@ -140,13 +150,22 @@ func NewNetworkPolicyValidator(networkPolicyController *NetworkPolicyController)
 	gv := groupValidator{
 		networkPolicyController: networkPolicyController,
 	}
+	// tev is an instance of tierEntitlementValidator to validate
+	// TierEntitlement resource events.
+	tev := tierEntitlementValidator{
+		networkPolicyController: networkPolicyController,
+	}
+	// The entitlement is checked first so that the users who are not
+	// entitled to a Tier are rejected regardless of the policy content.
+	vr.RegisterAntreaPolicyValidator(&pev)
 	vr.RegisterAntreaPolicyValidator(&apv)
 	vr.RegisterTierValidator(&tv)
 	vr.RegisterGroupValidator(&gv)
+	vr.RegisterTierEntitlementValidator(&tev)
 	return &vr
 }
 
-// Validate function validates a Group, ClusterGroup, Tier or Antrea Policy object
+// Validate function validates a Group, ClusterGroup, Tier, TierEntitlement or Antrea Policy object
 func (v *NetworkPolicyValidator) Validate(ar *admv1.AdmissionReview) *admv1.AdmissionResponse {
 	var result *metav1.Status
 	var msg string
@@ -172,6 +191,22 @@ func (v *NetworkPolicyValidator) Validate(ar *admv1.AdmissionReview) *admv1.Admi
 			}
 		}
 		msg, allowed = v.validateTier(&curTier, &oldTier, op, ui)
+	case "TierEntitlement":
+		klog.V(2).Info("Validating TierEntitlement CRD")
+		var curTE, oldTE crdv1alpha1.TierEntitlement
+		if curRaw != nil {
+			if err := json.Unmarshal(curRaw, &curTE); err != nil {
+				klog.Errorf("Error de-serializing current TierEntitlement")
+				return GetAdmissionResponseForErr(err)
+			}
+		}
+		if oldRaw != nil {
+			if err := json.Unmarshal(oldRaw, &oldTE); err != nil {
+				klog.Errorf("Error de-serializing old TierEntitlement")
+				return GetAdmissionResponseForErr(err)
+			}
+		}
+		msg, allowed = v.validateTierEntitlement(&curTE, &oldTE, op, ui)
 	case "ClusterGroup":
 		klog.V(2).Info("Validating ClusterGroup CRD")
 		var curCG, oldCG crdv1alpha2.ClusterGroup
@@ -237,14 +272,20 @@ func (v *NetworkPolicyValidator) Validate(ar *admv1.AdmissionReview) *admv1.Admi
 		}
 		msg, allowed = v.validateAntreaPolicy(&curANNP, &oldANNP, op, ui)
 	}
+	var warnings []string
 	if msg != "" {
-		result = &metav1.Status{
-			Message: msg,
+		if allowed {
+			warnings = []string{msg}
+		} else {
+			result = &metav1.Status{
+				Message: msg,
+			}
 		}
 	}
 	return &admv1.AdmissionResponse{
-		Allowed: allowed,
-		Result:  result,
+		Allowed:  allowed,
+		Result:   result,
+		Warnings: warnings,
 	}
 }
 
@@ -268,8 +309,6 @@ func (v *NetworkPolicyValidator) validateAntreaPolicy(curObj, oldObj interface{}
 			}
 		}
 	case admv1.Delete:
-		// Delete of Antrea Policies have no validation. This will be an
-		// empty for loop.
 		for _, val := range v.antreaPolicyValidators {
 			reason, allowed = val.deleteValidate(oldObj, userInfo)
 			if !allowed {
@@ -409,22 +448,28 @@ func (v *antreaPolicyValidator) createValidate(curObj interface{}, userInfo auth
 
 // validatePolicy validates the CREATE and UPDATE events of Antrea-native policies,
 func (v *antreaPolicyValidator) validatePolicy(curObj interface{}) (string, bool) {
-	var tier string
+	var tier, namespace string
 	var ingress, egress []crdv1alpha1.Rule
 	var specAppliedTo []crdv1alpha1.AppliedTo
+	var annotations map[string]string
+	var isClusterPolicy bool
 	switch curObj.(type) {
 	case *crdv1alpha1.ClusterNetworkPolicy:
 		curACNP := curObj.(*crdv1alpha1.ClusterNetworkPolicy)
+		isClusterPolicy = true
 		tier = curACNP.Spec.Tier
 		ingress = curACNP.Spec.Ingress
 		egress = curACNP.Spec.Egress
 		specAppliedTo = curACNP.Spec.AppliedTo
+		annotations = curACNP.Annotations
 	case *crdv1alpha1.NetworkPolicy:
 		curANNP := curObj.(*crdv1alpha1.NetworkPolicy)
+		namespace = curANNP.Namespace
 		tier = curANNP.Spec.Tier
 		ingress = curANNP.Spec.Ingress
 		egress = curANNP.Spec.Egress
 		specAppliedTo = curANNP.Spec.AppliedTo
+		annotations = curANNP.Annotations
 	}
 	reason, allowed := v.validateTierForPolicy(tier)
 	if !allowed {
@@ -441,6 +486,10 @@ func (v *antreaPolicyValidator) validatePolicy(curObj interface{}) (string, bool
 	if !allowed {
 		return reason, allowed
 	}
+	reason, allowed = v.validateAppliedToNode(isClusterPolicy, ingress, egress, specAppliedTo)
+	if !allowed {
+		return reason, allowed
+	}
 	reason, allowed = v.validatePeers(ingress, egress)
 	if !allowed {
 		return reason, allowed
@@ -449,7 +498,7 @@ func (v *antreaPolicyValidator) validatePolicy(curObj interface{}) (string, bool
 	if !allowed {
 		return reason, allowed
 	}
-	reason, allowed = v.validateFQDNSelectors(egress)
+	reason, allowed = v.validateFQDNSelectors(ingress, egress)
 	if !allowed {
 		return reason, allowed
 	}
@@ -465,9 +514,43 @@ func (v *antreaPolicyValidator) validatePolicy(curObj interface{}) (string, bool
 	if !allowed {
 		return reason, allowed
 	}
+	reason, allowed = v.validateRateLimit(ingress, egress)
+	if !allowed {
+		return reason, allowed
+	}
 	if err := v.validatePort(ingress, egress); err != nil {
 		return err.Error(), false
 	}
+	reason, allowed = validateAuditLoggingAnnotations(annotations)
+	if !allowed {
+		return reason, allowed
+	}
+	if failMode, exists := annotations[crdv1alpha1.L7FailModeAnnotationKey]; exists && !slices.Contains(crdv1alpha1.L7FailModes, failMode) {
+		return fmt.Sprintf("invalid value %q for annotation %s: must be one of %s", failMode, crdv1alpha1.L7FailModeAnnotationKey, strings.Join(crdv1alpha1.L7FailModes, ", ")), false
+	}
+	// The flow budget is checked last, as it may return a warning for an allowed policy.
+	return v.validateFlowBudget(namespace, specAppliedTo, ingress, egress)
+}
+
+// validateAuditLoggingAnnotations validates the audit logging annotations set in Antrea-native policies. The log file
+// must be a plain file name, as it is created in the NetworkPolicy log directory of the Antrea Agent, and the rate limit
+// must be a positive number of log entries per second. The severity must be one of the supported severities.
+func validateAuditLoggingAnnotations(annotations map[string]string) (string, bool) {
+	if logFile, exists := annotations[crdv1alpha1.AuditLogFileAnnotationKey]; exists {
+		if logFile == "" || logFile == "." || logFile == ".." || strings.ContainsAny(logFile, `/\`) {
+			return fmt.Sprintf("invalid value %q for annotation %s: must be a file name without directory", logFile, crdv1alpha1.AuditLogFileAnnotationKey), false
+		}
+	}
+	if rateLimit, exists := annotations[crdv1alpha1.AuditLogRateLimitAnnotationKey]; exists {
+		if limit, err := strconv.Atoi(rateLimit); err != nil || limit <= 0 {
+			return fmt.Sprintf("invalid value %q for annotation %s: must be a positive integer", rateLimit, crdv1alpha1.AuditLogRateLimitAnnotationKey), false
+		}
+	}
+	if severity, exists := annotations[crdv1alpha1.AuditLogSeverityAnnotationKey]; exists {
+		if !slices.Contains(crdv1alpha1.AuditLogSeverities, severity) {
+			return fmt.Sprintf("invalid value %q for annotation %s: must be one of %s", severity, crdv1alpha1.AuditLogSeverityAnnotationKey, strings.Join(crdv1alpha1.AuditLogSeverities, ", ")), false
+		}
+	}
 	return "", true
 }
 
@@ -535,7 +618,10 @@ func (v *antreaPolicyValidator) validateAppliedTo(ingress, egress []crdv1alpha1.
 				}
 				appliedToSvcNum++
 			}
-			if reason, allowed := checkSelectorsLabels(eachAppliedTo.PodSelector, eachAppliedTo.NamespaceSelector, eachAppliedTo.ExternalEntitySelector); !allowed {
+			if eachAppliedTo.NodeSelector != nil && appliedToFieldsNum > 1 {
+				return "nodeSelector cannot be set with other peers in appliedTo", false
+			}
+			if reason, allowed := checkSelectorsLabels(eachAppliedTo.PodSelector, eachAppliedTo.NamespaceSelector, eachAppliedTo.ExternalEntitySelector, eachAppliedTo.NodeSelector); !allowed {
 				return reason, allowed
 			}
 		}
@@ -565,6 +651,61 @@ func (v *antreaPolicyValidator) validateAppliedTo(ingress, egress []crdv1alpha1.
 	return "", true
 }
 
+// validateAppliedToNode ensures that if a policy is applied to Nodes, it is a ClusterNetworkPolicy which
+// is applied to Nodes only, and its rules don't use the fields which cannot be enforced on the host network.
+func (v *antreaPolicyValidator) validateAppliedToNode(isClusterPolicy bool, ingress, egress []crdv1alpha1.Rule, specAppliedTo []crdv1alpha1.AppliedTo) (string, bool) {
+	appliedToNum, appliedToNodeNum := 0, 0
+	countAppliedTo := func(appliedTo []crdv1alpha1.AppliedTo) {
+		for _, eachAppliedTo := range appliedTo {
+			appliedToNum++
+			if eachAppliedTo.NodeSelector != nil {
+				appliedToNodeNum++
+			}
+		}
+	}
+	countAppliedTo(specAppliedTo)
+	for _, rule := range ingress {
+		countAppliedTo(rule.AppliedTo)
+	}
+	for _, rule := range egress {
+		countAppliedTo(rule.AppliedTo)
+	}
+	if appliedToNodeNum == 0 {
+		return "", true
+	}
+	if !features.DefaultFeatureGate.Enabled(features.NodeNetworkPolicy) {
+		return "nodeSelector can only be used in appliedTo when NodeNetworkPolicy is enabled", false
+	}
+	if !isClusterPolicy {
+		return "nodeSelector can only be used in appliedTo of ClusterNetworkPolicy", false
+	}
+	if appliedToNodeNum < appliedToNum {
+		return "a policy cannot be applied to Nodes and other peers at the same time", false
+	}
+	checkRule := func(rule crdv1alpha1.Rule, peers []crdv1alpha1.NetworkPolicyPeer) (string, bool) {
+		if len(rule.ToServices) > 0 || len(rule.L7Protocols) > 0 {
+			return "toServices and l7Protocols cannot be used in a policy applied to Nodes", false
+		}
+		for _, peer := range peers {
+			if peer.FQDN != "" || peer.Namespaces != nil {
+				return "fqdn and namespaces cannot be used in a policy applied to Nodes", false
+			}
+		}
+		return "", true
+	}
+	for _, rule := range ingress {
+		if reason, allowed := checkRule(rule, rule.From); !allowed {
+			return reason, allowed
+		}
+	}
+	for _, rule := range egress {
+		if reason, allowed := checkRule(rule, rule.To); !allowed {
+			return reason, allowed
+		}
+	}
+	return "", true
+}
+
 // validatePeers ensures that the NetworkPolicyPeer object set in rules are valid, i.e.
 // currently it ensures that a Group cannot be set with other stand-alone selectors or IPBlock.
 func (v *antreaPolicyValidator) validatePeers(ingress, egress []crdv1alpha1.Rule) (string, bool) {
@@ -573,6 +714,16 @@ func (v *antreaPolicyValidator) validatePeers(ingress, egress []crdv1alpha1.Rule
 			if peer.NamespaceSelector != nil && peer.Namespaces != nil {
 				return "namespaces and namespaceSelector cannot be set at the same time for a single NetworkPolicyPeer", false
 			}
+			if peer.Namespaces != nil {
+				if peer.Namespaces.Match != "" && len(peer.Namespaces.SameLabels) > 0 {
+					return "match and sameLabels cannot be set at the same time for namespaces in a single NetworkPolicyPeer", false
+				}
+				for _, k := range peer.Namespaces.SameLabels {
+					if err := validation.IsQualifiedName(k); err != nil {
+						return fmt.Sprintf("Invalid label key in sameLabels: %s: %s", k, strings.Join(err, "; ")), false
+					}
+				}
+			}
 			peerFieldsNum := numFieldsSetInStruct(peer)
 			if peer.Group != "" && peerFieldsNum > 1 {
 				return "group cannot be set with other peers in rules", false
@@ -625,8 +776,8 @@ func (v *antreaPolicyValidator) validateAppliedToServiceIngressPeer(specAppliedT
 	for _, rule := range ingress {
 		if policyAppliedToService || isAppliedToService(rule.AppliedTo) {
 			for _, peer := range rule.From {
-				if peer.IPBlock == nil || numFieldsSetInStruct(peer) > 1 {
-					return "a rule/policy that is applied to Services can only use ipBlock to select workloads", false
+				if peer.FQDN != "" || peer.NodeSelector != nil || peer.ExternalEntitySelector != nil || peer.ServiceAccount != nil {
+					return "a rule/policy that is applied to Services can only use ipBlock, podSelector, namespaceSelector or group to select workloads", false
 				}
 			}
 		}
@@ -813,8 +964,48 @@ func (v *antreaPolicyValidator) validateL7Protocols(ingressRules, egressRules []
 	return "", true
 }
 
-// validateFQDNSelectors validates the toFQDN field set in Antrea-native policy egress rules are valid.
-func (v *antreaPolicyValidator) validateFQDNSelectors(egressRules []crdv1alpha1.Rule) (string, bool) {
+// validateRateLimit validates that the RateLimit field is set in an Antrea-native
+// policy rule if and only if its action is RateLimit, and that it specifies a valid rate.
+func (v *antreaPolicyValidator) validateRateLimit(ingressRules, egressRules []crdv1alpha1.Rule) (string, bool) {
+	for _, r := range append(ingressRules, egressRules...) {
+		if *r.Action != crdv1alpha1.RuleActionRateLimit {
+			if r.RateLimit != nil {
+				return "rateLimit can only be set when action is RateLimit", false
+			}
+			continue
+		}
+		if r.RateLimit == nil {
+			return "rateLimit must be set when action is RateLimit", false
+		}
+		if (r.RateLimit.PacketsPerSecond > 0) == (r.RateLimit.BytesPerSecond > 0) {
+			return "exactly one of packetsPerSecond and bytesPerSecond must be set to a positive value in rateLimit", false
+		}
+		for _, protocol := range r.Protocols {
+			if protocol.IGMP != nil {
+				return "protocol IGMP does not support RateLimit", false
+			}
+		}
+		for _, to := range r.To {
+			if to.IPBlock == nil {
+				continue
+			}
+			if ip, _, err := net.ParseCIDR(to.IPBlock.CIDR); err == nil && ip.IsMulticast() {
+				return "multicast does not support action RateLimit", false
+			}
+		}
+	}
+	return "", true
+}
+
+// validateFQDNSelectors validates the fqdn field set in Antrea-native policy ingress and egress rules are valid.
+func (v *antreaPolicyValidator) validateFQDNSelectors(ingressRules, egressRules []crdv1alpha1.Rule) (string, bool) {
+	for _, r := range ingressRules {
+		for _, peer := range r.From {
+			if len(peer.FQDN) > 0 && !allowedFQDNChars.MatchString(peer.FQDN) {
+				return fmt.Sprintf("invalid characters in ingress rule fqdn field: %s", peer.FQDN), false
+			}
+		}
+	}
 	for _, r := range egressRules {
 		for _, peer := range r.To {
 			if len(peer.FQDN) > 0 && !allowedFQDNChars.MatchString(peer.FQDN) {
@@ -898,8 +1089,9 @@ func validateAntreaClusterGroupSpec(s crdv1alpha2.GroupSpec) (string, bool) {
 	if setFieldNum > 2 {
 		return errMsg, false
 	} else if setFieldNum == 2 {
-		// If two fields are set, only nsSel+pSel and nsSel+eeSel are valid.
-		if !(s.NamespaceSelector != nil && (s.PodSelector != nil || s.ExternalEntitySelector != nil)) {
+		// If two fields are set, only nsSel+pSel, nsSel+eeSel and serviceReference+ipBlocks are valid.
+		if !(s.NamespaceSelector != nil && (s.PodSelector != nil || s.ExternalEntitySelector != nil)) &&
+			!(s.ServiceReference != nil && len(s.IPBlocks) > 0) {
 			return errMsg, false
 		}
 	}
@@ -908,6 +1100,9 @@ func validateAntreaClusterGroupSpec(s crdv1alpha2.GroupSpec) (string, bool) {
 			return reason, allowed
 		}
 	}
+	if s.IPBlock != nil && len(s.IPBlock.Except) > 0 {
+		return "except can only be set in ipBlocks", false
+	}
 	multicast := false
 	unicast := false
 	for _, ipb := range s.IPBlocks {
@@ -915,6 +1110,9 @@ func validateAntreaClusterGroupSpec(s crdv1alpha2.GroupSpec) (string, bool) {
 		if err != nil {
 			return fmt.Sprintf("invalid ip address: %v", err), false
 		}
+		if reason, allowed := validateIPBlockExcept(ipb); !allowed {
+			return reason, allowed
+		}
 		if ipaddr.IsMulticast() {
 			multicast = true
 		} else {
@@ -933,8 +1131,9 @@ func validateAntreaGroupSpec(s crdv1alpha3.GroupSpec) (string, bool) {
 	if setFieldNum > 2 {
 		return errMsg, false
 	} else if setFieldNum == 2 {
-		// If two fields are set, only nsSel+pSel and nsSel+eeSel are valid.
-		if !(s.NamespaceSelector != nil && (s.PodSelector != nil || s.ExternalEntitySelector != nil)) {
+		// If two fields are set, only nsSel+pSel, nsSel+eeSel and serviceReference+ipBlocks are valid.
+		if !(s.NamespaceSelector != nil && (s.PodSelector != nil || s.ExternalEntitySelector != nil)) &&
+			!(s.ServiceReference != nil && len(s.IPBlocks) > 0) {
 			return errMsg, false
 		}
 	}
@@ -943,6 +1142,31 @@ func validateAntreaGroupSpec(s crdv1alpha3.GroupSpec) (string, bool) {
 			return reason, allowed
 		}
 	}
+	for _, ipb := range s.IPBlocks {
+		if _, _, err := net.ParseCIDR(ipb.CIDR); err != nil {
+			return fmt.Sprintf("invalid ip address: %v", err), false
+		}
+		if reason, allowed := validateIPBlockExcept(ipb); !allowed {
+			return reason, allowed
+		}
+	}
+	return "", true
+}
+
+// validateIPBlockExcept validates that the except CIDRs of an IPBlock are valid and contained in its CIDR.
+func validateIPBlockExcept(ipb crdv1alpha1.IPBlock) (string, bool) {
+	_, ipNet, _ := net.ParseCIDR(ipb.CIDR)
+	ipNetPrefixLen, _ := ipNet.Mask.Size()
+	for _, exc := range ipb.Except {
+		_, exceptNet, err := net.ParseCIDR(exc)
+		if err != nil {
+			return fmt.Sprintf("invalid except CIDR %s: %v", exc, err), false
+		}
+		exceptPrefixLen, _ := exceptNet.Mask.Size()
+		if !ipNet.Contains(exceptNet.IP) || exceptPrefixLen < ipNetPrefixLen {
+			return fmt.Sprintf("except CIDR %s is not contained in CIDR %s", exc, ipb.CIDR), false
+		}
+	}
 	return "", true
 }
 
@@ -32,11 +32,12 @@ import (
 )
 
 var (
-	query       = crdv1alpha1.IGMPQuery
-	report      = crdv1alpha1.IGMPReportV1
-	allowAction = crdv1alpha1.RuleActionAllow
-	passAction  = crdv1alpha1.RuleActionPass
-	portNum80   = int32(80)
+	query           = crdv1alpha1.IGMPQuery
+	report          = crdv1alpha1.IGMPReportV1
+	allowAction     = crdv1alpha1.RuleActionAllow
+	passAction      = crdv1alpha1.RuleActionPass
+	rateLimitAction = crdv1alpha1.RuleActionRateLimit
+	portNum80       = int32(80)
 )
 
 func TestValidateAntreaClusterNetworkPolicy(t *testing.T) {
@@ -703,6 +704,94 @@ func TestValidateAntreaClusterNetworkPolicy(t *testing.T) {
 			operation:      admv1.Create,
 			expectedReason: "namespaces and namespaceSelector cannot be set at the same time for a single NetworkPolicyPeer",
 		},
+		{
+			name: "acnp-rule-ns-match-set-with-samelabels",
+			policy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-rule-ns-match-set-with-samelabels",
+				},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							NamespaceSelector: &metav1.LabelSelector{},
+						},
+					},
+					Ingress: []crdv1alpha1.Rule{
+						{
+							Action: &allowAction,
+							From: []crdv1alpha1.NetworkPolicyPeer{
+								{
+									Namespaces: &crdv1alpha1.PeerNamespaces{
+										Match:      crdv1alpha1.NamespaceMatchSelf,
+										SameLabels: []string{"tenant"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "match and sameLabels cannot be set at the same time for namespaces in a single NetworkPolicyPeer",
+		},
+		{
+			name: "acnp-rule-ns-samelabels-invalid-key",
+			policy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-rule-ns-samelabels-invalid-key",
+				},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							NamespaceSelector: &metav1.LabelSelector{},
+						},
+					},
+					Egress: []crdv1alpha1.Rule{
+						{
+							Action: &allowAction,
+							To: []crdv1alpha1.NetworkPolicyPeer{
+								{
+									Namespaces: &crdv1alpha1.PeerNamespaces{
+										SameLabels: []string{"tenant", "foo=bar"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "Invalid label key in sameLabels: foo=bar: name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')",
+		},
+		{
+			name: "acnp-rule-ns-samelabels",
+			policy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-rule-ns-samelabels",
+				},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							NamespaceSelector: &metav1.LabelSelector{},
+						},
+					},
+					Ingress: []crdv1alpha1.Rule{
+						{
+							Action: &allowAction,
+							From: []crdv1alpha1.NetworkPolicyPeer{
+								{
+									Namespaces: &crdv1alpha1.PeerNamespaces{
+										SameLabels: []string{"tenant", "env"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "",
+		},
 		{
 			name: "acnp-toservice-set-with-to",
 			policy: &crdv1alpha1.ClusterNetworkPolicy{
@@ -869,6 +958,35 @@ func TestValidateAntreaClusterNetworkPolicy(t *testing.T) {
 			operation:      admv1.Create,
 			expectedReason: "invalid characters in egress rule fqdn field: foo!bar",
 		},
+		{
+			name: "acnp-invalid-ingress-fqdn",
+			policy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-invalid-ingress-fqdn",
+				},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							NamespaceSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1alpha1.Rule{
+						{
+							Action: &allowAction,
+							From: []crdv1alpha1.NetworkPolicyPeer{
+								{
+									FQDN: "foo!bar",
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "invalid characters in ingress rule fqdn field: foo!bar",
+		},
 		{
 			name: "acnp-valid-fqdn",
 			policy: &crdv1alpha1.ClusterNetworkPolicy{
@@ -1299,7 +1417,37 @@ func TestValidateAntreaClusterNetworkPolicy(t *testing.T) {
 				},
 			},
 			operation:      admv1.Create,
-			expectedReason: "a rule/policy that is applied to Services can only use ipBlock to select workloads",
+			expectedReason: "",
+		},
+		{
+			name: "acnp-appliedto-service-from-fqdn",
+			policy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "ingress-rule-appliedto-service",
+				},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							Service: &crdv1alpha1.NamespacedName{
+								Namespace: "foo1",
+								Name:      "bar1",
+							},
+						},
+					},
+					Ingress: []crdv1alpha1.Rule{
+						{
+							Action: &allowAction,
+							From: []crdv1alpha1.NetworkPolicyPeer{
+								{
+									FQDN: "foo.com",
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "a rule/policy that is applied to Services can only use ipBlock, podSelector, namespaceSelector or group to select workloads",
 		},
 		{
 			name: "acnp-appliedto-service-valid",
@@ -1402,6 +1550,116 @@ func TestValidateAntreaClusterNetworkPolicy(t *testing.T) {
 			operation:      admv1.Create,
 			expectedReason: "layer 7 protocols only support Allow",
 		},
+		{
+			name: "acnp-ratelimit-valid",
+			policy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-ratelimit-valid",
+				},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							NamespaceSelector: &metav1.LabelSelector{},
+						},
+					},
+					Egress: []crdv1alpha1.Rule{
+						{
+							Action: &rateLimitAction,
+							Ports: []crdv1alpha1.NetworkPolicyPort{
+								{
+									Protocol: &k8sProtocolUDP,
+									Port:     &int80,
+								},
+							},
+							RateLimit: &crdv1alpha1.RateLimit{
+								PacketsPerSecond: 100,
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "",
+		},
+		{
+			name: "acnp-ratelimit-missing",
+			policy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-ratelimit-missing",
+				},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							NamespaceSelector: &metav1.LabelSelector{},
+						},
+					},
+					Egress: []crdv1alpha1.Rule{
+						{
+							Action: &rateLimitAction,
+							Ports: []crdv1alpha1.NetworkPolicyPort{
+								{
+									Protocol: &k8sProtocolUDP,
+									Port:     &int80,
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "rateLimit must be set when action is RateLimit",
+		},
+		{
+			name: "acnp-ratelimit-both-rates",
+			policy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-ratelimit-both-rates",
+				},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							NamespaceSelector: &metav1.LabelSelector{},
+						},
+					},
+					Egress: []crdv1alpha1.Rule{
+						{
+							Action: &rateLimitAction,
+							RateLimit: &crdv1alpha1.RateLimit{
+								PacketsPerSecond: 100,
+								BytesPerSecond:   100000,
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "exactly one of packetsPerSecond and bytesPerSecond must be set to a positive value in rateLimit",
+		},
+		{
+			name: "acnp-ratelimit-used-with-allow",
+			policy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-ratelimit-used-with-allow",
+				},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							NamespaceSelector: &metav1.LabelSelector{},
+						},
+					},
+					Egress: []crdv1alpha1.Rule{
+						{
+							Action: &allowAction,
+							RateLimit: &crdv1alpha1.RateLimit{
+								BytesPerSecond: 100000,
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "rateLimit can only be set when action is RateLimit",
+		},
 		{
 			name:         "acnp-l7protocols-HTTP-used-with-UDP",
 			featureGates: map[featuregate.Feature]bool{features.L7NetworkPolicy: true},
@@ -1641,6 +1899,151 @@ func TestValidateAntreaClusterNetworkPolicy(t *testing.T) {
 			operation:      admv1.Create,
 			expectedReason: "protocol IGMP does not support Pass or Reject",
 		},
+		{
+			name:         "acnp-appliedto-node-feature-disabled",
+			featureGates: map[featuregate.Feature]bool{features.NodeNetworkPolicy: false},
+			policy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-appliedto-node-feature-disabled",
+				},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							NodeSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1alpha1.Rule{
+						{
+							Action: &allowAction,
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "nodeSelector can only be used in appliedTo when NodeNetworkPolicy is enabled",
+		},
+		{
+			name:         "acnp-appliedto-node-and-pod",
+			featureGates: map[featuregate.Feature]bool{features.NodeNetworkPolicy: true},
+			policy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-appliedto-node-and-pod",
+				},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							NodeSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo2": "bar2"},
+							},
+						},
+					},
+					Ingress: []crdv1alpha1.Rule{
+						{
+							Action: &allowAction,
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "a policy cannot be applied to Nodes and other peers at the same time",
+		},
+		{
+			name:         "acnp-appliedto-node-with-other-selector",
+			featureGates: map[featuregate.Feature]bool{features.NodeNetworkPolicy: true},
+			policy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-appliedto-node-with-other-selector",
+				},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							NodeSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+							NamespaceSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo2": "bar2"},
+							},
+						},
+					},
+					Ingress: []crdv1alpha1.Rule{
+						{
+							Action: &allowAction,
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "nodeSelector cannot be set with other peers in appliedTo",
+		},
+		{
+			name:         "acnp-appliedto-node-fqdn",
+			featureGates: map[featuregate.Feature]bool{features.NodeNetworkPolicy: true},
+			policy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-appliedto-node-fqdn",
+				},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					Egress: []crdv1alpha1.Rule{
+						{
+							Action: &allowAction,
+							AppliedTo: []crdv1alpha1.AppliedTo{
+								{
+									NodeSelector: &metav1.LabelSelector{
+										MatchLabels: map[string]string{"foo1": "bar1"},
+									},
+								},
+							},
+							To: []crdv1alpha1.NetworkPolicyPeer{
+								{
+									FQDN: "www.example.com",
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "fqdn and namespaces cannot be used in a policy applied to Nodes",
+		},
+		{
+			name:         "acnp-appliedto-node",
+			featureGates: map[featuregate.Feature]bool{features.NodeNetworkPolicy: true},
+			policy: &crdv1alpha1.ClusterNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "acnp-appliedto-node",
+				},
+				Spec: crdv1alpha1.ClusterNetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							NodeSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo1": "bar1"},
+							},
+						},
+					},
+					Ingress: []crdv1alpha1.Rule{
+						{
+							Action: &allowAction,
+							From: []crdv1alpha1.NetworkPolicyPeer{
+								{
+									IPBlock: &crdv1alpha1.IPBlock{
+										CIDR: "10.0.0.0/24",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "",
+		},
 		// Update use same validate function as create. Only provide one update case here.
 		{
 			name: "acnp-non-existent-tier",
@@ -1733,6 +2136,146 @@ func TestValidateAntreaNetworkPolicy(t *testing.T) {
 			operation:      admv1.Update,
 			expectedReason: "tier non-existent-tier does not exist",
 		},
+		{
+			name: "annp-valid-audit-logging-annotations",
+			policy: &crdv1alpha1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "valid-audit-logging-annotations",
+					Namespace: "x",
+					Annotations: map[string]string{
+						crdv1alpha1.AuditLogFileAnnotationKey:      "x-policy.log",
+						crdv1alpha1.AuditLogRateLimitAnnotationKey: "100",
+						crdv1alpha1.AuditLogSeverityAnnotationKey:  "Critical",
+					},
+				},
+				Spec: crdv1alpha1.NetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo": "bar"},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "",
+		},
+		{
+			name: "annp-invalid-audit-log-file",
+			policy: &crdv1alpha1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "invalid-audit-log-file",
+					Namespace: "x",
+					Annotations: map[string]string{
+						crdv1alpha1.AuditLogFileAnnotationKey: "../x-policy.log",
+					},
+				},
+				Spec: crdv1alpha1.NetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo": "bar"},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "invalid value \"../x-policy.log\" for annotation networkpolicy.antrea.io/audit-log-file: must be a file name without directory",
+		},
+		{
+			name: "annp-invalid-audit-log-rate-limit",
+			policy: &crdv1alpha1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "invalid-audit-log-rate-limit",
+					Namespace: "x",
+					Annotations: map[string]string{
+						crdv1alpha1.AuditLogRateLimitAnnotationKey: "0",
+					},
+				},
+				Spec: crdv1alpha1.NetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo": "bar"},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "invalid value \"0\" for annotation networkpolicy.antrea.io/audit-log-rate-limit: must be a positive integer",
+		},
+		{
+			name: "annp-invalid-audit-log-severity",
+			policy: &crdv1alpha1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "invalid-audit-log-severity",
+					Namespace: "x",
+					Annotations: map[string]string{
+						crdv1alpha1.AuditLogSeverityAnnotationKey: "high",
+					},
+				},
+				Spec: crdv1alpha1.NetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo": "bar"},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "invalid value \"high\" for annotation networkpolicy.antrea.io/audit-log-severity: must be one of Low, Medium, High, Critical",
+		},
+		{
+			name: "annp-valid-l7-fail-mode",
+			policy: &crdv1alpha1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "valid-l7-fail-mode",
+					Namespace: "x",
+					Annotations: map[string]string{
+						crdv1alpha1.L7FailModeAnnotationKey: "open",
+					},
+				},
+				Spec: crdv1alpha1.NetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo": "bar"},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "",
+		},
+		{
+			name: "annp-invalid-l7-fail-mode",
+			policy: &crdv1alpha1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "invalid-l7-fail-mode",
+					Namespace: "x",
+					Annotations: map[string]string{
+						crdv1alpha1.L7FailModeAnnotationKey: "Open",
+					},
+				},
+				Spec: crdv1alpha1.NetworkPolicySpec{
+					AppliedTo: []crdv1alpha1.AppliedTo{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"foo": "bar"},
+							},
+						},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "invalid value \"Open\" for annotation networkpolicy.antrea.io/l7-fail-mode: must be one of closed, open",
+		},
 	}
 
 	for _, tt := range tests {
@@ -2145,6 +2688,57 @@ func TestValidateAntreaGroup(t *testing.T) {
 			operation:      admv1.Create,
 			expectedReason: "At most one of podSelector, externalEntitySelector, serviceReference, ipBlocks or childGroups can be set for a Group",
 		},
+		{
+			name: "annp-group-set-with-svcref-and-ipblocks-with-except",
+			curGroup: &crdv1alpha3.Group{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "annp-group-set-with-svcref-and-ipblocks-with-except",
+					Namespace: "x",
+				},
+				Spec: crdv1alpha3.GroupSpec{
+					ServiceReference: &crdv1alpha1.NamespacedName{
+						Name:      "svc",
+						Namespace: "x",
+					},
+					IPBlocks: []crdv1alpha1.IPBlock{
+						{CIDR: "10.0.0.0/24", Except: []string{"10.0.0.128/25"}},
+					},
+				},
+			},
+			operation: admv1.Create,
+		},
+		{
+			name: "annp-group-set-with-except-outside-cidr",
+			curGroup: &crdv1alpha3.Group{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "annp-group-set-with-except-outside-cidr",
+					Namespace: "x",
+				},
+				Spec: crdv1alpha3.GroupSpec{
+					IPBlocks: []crdv1alpha1.IPBlock{
+						{CIDR: "10.0.0.0/24", Except: []string{"10.0.1.0/25"}},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "except CIDR 10.0.1.0/25 is not contained in CIDR 10.0.0.0/24",
+		},
+		{
+			name: "annp-group-set-with-larger-except",
+			curGroup: &crdv1alpha3.Group{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "annp-group-set-with-larger-except",
+					Namespace: "x",
+				},
+				Spec: crdv1alpha3.GroupSpec{
+					IPBlocks: []crdv1alpha1.IPBlock{
+						{CIDR: "10.0.0.0/24", Except: []string{"10.0.0.0/16"}},
+					},
+				},
+			},
+			operation:      admv1.Create,
+			expectedReason: "except CIDR 10.0.0.0/16 is not contained in CIDR 10.0.0.0/24",
+		},
 		{
 			name: "annp-group-set-with-ipblock",
 			curGroup: &crdv1alpha3.Group{
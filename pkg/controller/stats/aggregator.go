@@ -449,26 +449,29 @@ func addUp(stats *statsv1alpha1.TrafficStats, inc *statsv1alpha1.TrafficStats) {
 	stats.Sessions += inc.Sessions
 	stats.Packets += inc.Packets
 	stats.Bytes += inc.Bytes
+	stats.DroppedPackets += inc.DroppedPackets
 }
 
 func addRulesUp(ruleStats *[]statsv1alpha1.RuleTrafficStats, ruleSumStats *statsv1alpha1.TrafficStats, inc []statsv1alpha1.RuleTrafficStats) {
-	incMap := make(map[string]*statsv1alpha1.TrafficStats)
+	incMap := make(map[string]*statsv1alpha1.RuleTrafficStats)
 	for i, v := range inc {
-		incMap[v.Name] = &inc[i].TrafficStats
+		incMap[v.Name] = &inc[i]
 	}
 	// accumulate incMap traffics stats to the current traffic stats
 	for _, v := range incMap {
-		addUp(ruleSumStats, v)
+		addUp(ruleSumStats, &v.TrafficStats)
 	}
 	// accumulate the rule traffic stats as the rule has already 'existed' in the ruleStats
 	for i, v := range *ruleStats {
 		stats, exist := incMap[v.Name]
 		if exist {
 			(*ruleStats)[i].TrafficStats = statsv1alpha1.TrafficStats{
-				Packets:  v.TrafficStats.Packets + stats.Packets,
-				Bytes:    v.TrafficStats.Bytes + stats.Bytes,
-				Sessions: v.TrafficStats.Sessions + stats.Sessions,
+				Packets:        v.TrafficStats.Packets + stats.TrafficStats.Packets,
+				Bytes:          v.TrafficStats.Bytes + stats.TrafficStats.Bytes,
+				Sessions:       v.TrafficStats.Sessions + stats.TrafficStats.Sessions,
+				DroppedPackets: v.TrafficStats.DroppedPackets + stats.TrafficStats.DroppedPackets,
 			}
+			addFQDNStatsUp(&(*ruleStats)[i].FQDNStats, stats.FQDNStats)
 		}
 		delete(incMap, v.Name)
 	}
@@ -476,8 +479,26 @@ func addRulesUp(ruleStats *[]statsv1alpha1.RuleTrafficStats, ruleSumStats *stats
 	for k, v := range incMap {
 		rs := statsv1alpha1.RuleTrafficStats{
 			Name:         k,
-			TrafficStats: *v,
+			TrafficStats: v.TrafficStats,
 		}
+		addFQDNStatsUp(&rs.FQDNStats, v.FQDNStats)
 		*ruleStats = append(*ruleStats, rs)
 	}
 }
+
+// addFQDNStatsUp accumulates the FQDN match counts of a rule.
+func addFQDNStatsUp(fqdnStats *[]statsv1alpha1.FQDNStats, inc []statsv1alpha1.FQDNStats) {
+	for _, incStats := range inc {
+		found := false
+		for i := range *fqdnStats {
+			if (*fqdnStats)[i].FQDN == incStats.FQDN {
+				(*fqdnStats)[i].Matches += incStats.Matches
+				found = true
+				break
+			}
+		}
+		if !found {
+			*fqdnStats = append(*fqdnStats, incStats)
+		}
+	}
+}
@@ -136,6 +136,9 @@ func TestAggregatorCollectListGet(t *testing.T) {
 										Packets:  52,
 										Sessions: 22,
 									},
+									FQDNStats: []statsv1alpha1.FQDNStats{
+										{FQDN: "*.example.com", Matches: 3},
+									},
 								},
 							},
 						},
@@ -181,6 +184,10 @@ func TestAggregatorCollectListGet(t *testing.T) {
 										Packets:  8,
 										Sessions: 5,
 									},
+									FQDNStats: []statsv1alpha1.FQDNStats{
+										{FQDN: "*.example.com", Matches: 2},
+										{FQDN: "www.antrea.io", Matches: 1},
+									},
 								},
 							},
 						},
@@ -255,6 +262,10 @@ func TestAggregatorCollectListGet(t *testing.T) {
 								Packets:  60,
 								Sessions: 27,
 							},
+							FQDNStats: []statsv1alpha1.FQDNStats{
+								{FQDN: "*.example.com", Matches: 5},
+								{FQDN: "www.antrea.io", Matches: 1},
+							},
 						},
 					},
 				},
@@ -25,6 +25,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
@@ -251,10 +252,11 @@ func (c *Controller) syncTraceflow(traceflowName string) error {
 func (c *Controller) startTraceflow(tf *crdv1alpha1.Traceflow) error {
 	if err := c.validateTraceflow(tf); err != nil {
 		klog.ErrorS(err, "Invalid Traceflow request", "request", tf)
-		return c.updateTraceflowStatus(tf, crdv1alpha1.Failed, fmt.Sprintf("Invalid Traceflow request, err: %+v", err), 0)
+		return c.updateTraceflowStatus(tf, crdv1alpha1.Failed, fmt.Sprintf("Invalid Traceflow request, err: %+v", err), 0, 0)
 	}
-	// Allocate data plane tag.
-	tag, err := c.allocateTag(tf.Name)
+	// Allocate data plane tag, and another one for the reply packet in
+	// reverse-path Traceflow.
+	tag, reverseTag, err := c.allocateTag(tf.Name, tf.Spec.ReversePath)
 	if err != nil {
 		return err
 	}
@@ -262,13 +264,24 @@ func (c *Controller) startTraceflow(tf *crdv1alpha1.Traceflow) error {
 		return nil
 	}
 
-	err = c.updateTraceflowStatus(tf, crdv1alpha1.Running, "", tag)
+	err = c.updateTraceflowStatus(tf, crdv1alpha1.Running, "", tag, reverseTag)
 	if err != nil {
 		c.deallocateTag(tf.Name, tag)
+		if reverseTag != 0 {
+			c.deallocateTag(tf.Name, reverseTag)
+		}
 	}
 	return err
 }
 
+// tfResultKey identifies the packet traced by a NodeResult. The IP family is
+// only set in the results of dual-stack Traceflow, and the direction is only
+// set in the results of reverse-path Traceflow.
+type tfResultKey struct {
+	ipFamily  string
+	direction string
+}
+
 // checkTraceflowStatus is only called for Traceflows in the Running phase
 func (c *Controller) checkTraceflowStatus(tf *crdv1alpha1.Traceflow) error {
 	succeeded := false
@@ -279,18 +292,26 @@ func (c *Controller) checkTraceflowStatus(tf *crdv1alpha1.Traceflow) error {
 			succeeded = true
 		}
 	} else {
-		sender := false
-		receiver := false
+		// The packets for which results have been received from the
+		// sender and the receiver, and the packets which have been
+		// delivered.
+		senders := sets.New[tfResultKey]()
+		receivers := sets.New[tfResultKey]()
+		delivered := sets.New[tfResultKey]()
 		for i, nodeResult := range tf.Status.Results {
+			key := tfResultKey{ipFamily: nodeResult.IPFamily, direction: nodeResult.Direction}
 			for j, ob := range nodeResult.Observations {
 				if ob.Component == crdv1alpha1.ComponentSpoofGuard {
-					sender = true
+					senders.Insert(key)
 				}
 				if ob.Action == crdv1alpha1.ActionDelivered ||
 					ob.Action == crdv1alpha1.ActionDropped ||
 					ob.Action == crdv1alpha1.ActionRejected ||
 					ob.Action == crdv1alpha1.ActionForwardedOutOfOverlay {
-					receiver = true
+					receivers.Insert(key)
+				}
+				if ob.Action == crdv1alpha1.ActionDelivered {
+					delivered.Insert(key)
 				}
 				if ob.TranslatedDstIP != "" {
 					// Add Pod ns/name to observation if TranslatedDstIP (a.k.a. Service Endpoint address) is Pod IP.
@@ -311,12 +332,33 @@ func (c *Controller) checkTraceflowStatus(tf *crdv1alpha1.Traceflow) error {
 		// When the Source Pod is specified, the Traceflow should receive
 		// results from both the sender and the receiver. When the Source
 		// Pod is not specified (in live-traffic Traceflow), only the
-		// receiver Node will report the results.
-		succeeded = (sender && receiver) || (receiver && tf.Spec.Source.Pod == "")
+		// receiver Node will report the results. Dual-stack Traceflow
+		// succeeds once the packets of both IP families have been traced.
+		// Reverse-path Traceflow also waits for the reply packet, which
+		// is only injected if the forward packet has been delivered.
+		traced := func(key tfResultKey) bool {
+			sender, receiver := senders.Has(key), receivers.Has(key)
+			return (sender && receiver) || (receiver && tf.Spec.Source.Pod == "")
+		}
+		ipFamilies := []string{""}
+		if tf.Spec.DualStack {
+			ipFamilies = []string{crdv1alpha1.IPFamilyIPv4, crdv1alpha1.IPFamilyIPv6}
+		}
+		succeeded = true
+		for _, ipFamily := range ipFamilies {
+			forward := tfResultKey{ipFamily: ipFamily}
+			if !tf.Spec.ReversePath {
+				succeeded = succeeded && traced(forward)
+				continue
+			}
+			forward.direction = crdv1alpha1.TraceflowDirectionForward
+			reverse := tfResultKey{ipFamily: ipFamily, direction: crdv1alpha1.TraceflowDirectionReverse}
+			succeeded = succeeded && traced(forward) && (!delivered.Has(forward) || traced(reverse))
+		}
 	}
 	if succeeded {
 		c.deallocateTagForTF(tf)
-		return c.updateTraceflowStatus(tf, crdv1alpha1.Succeeded, "", 0)
+		return c.updateTraceflowStatus(tf, crdv1alpha1.Succeeded, "", 0, 0)
 	}
 
 	var timeout time.Duration
@@ -336,12 +378,12 @@ func (c *Controller) checkTraceflowStatus(tf *crdv1alpha1.Traceflow) error {
 	}
 	if startTime.Add(timeout).Before(time.Now()) {
 		c.deallocateTagForTF(tf)
-		return c.updateTraceflowStatus(tf, crdv1alpha1.Failed, traceflowTimeout, 0)
+		return c.updateTraceflowStatus(tf, crdv1alpha1.Failed, traceflowTimeout, 0, 0)
 	}
 	return nil
 }
 
-func (c *Controller) updateTraceflowStatus(tf *crdv1alpha1.Traceflow, phase crdv1alpha1.TraceflowPhase, reason string, dataPlaneTag uint8) error {
+func (c *Controller) updateTraceflowStatus(tf *crdv1alpha1.Traceflow, phase crdv1alpha1.TraceflowPhase, reason string, dataPlaneTag, reverseDataPlaneTag uint8) error {
 	update := tf.DeepCopy()
 	update.Status.Phase = phase
 	if phase == crdv1alpha1.Running && tf.Status.StartTime == nil {
@@ -349,6 +391,7 @@ func (c *Controller) updateTraceflowStatus(tf *crdv1alpha1.Traceflow, phase crdv
 		update.Status.StartTime = &t
 	}
 	update.Status.DataplaneTag = dataPlaneTag
+	update.Status.ReverseDataplaneTag = reverseDataPlaneTag
 	if reason != "" {
 		update.Status.Reason = reason
 	}
@@ -357,51 +400,73 @@ func (c *Controller) updateTraceflowStatus(tf *crdv1alpha1.Traceflow, phase crdv
 }
 
 func (c *Controller) occupyTag(tf *crdv1alpha1.Traceflow) error {
-	tag := tf.Status.DataplaneTag
-	if tag < minTagNum || tag > maxTagNum {
-		return errors.New("this Traceflow CRD's data plane tag is out of range")
+	tags := []uint8{tf.Status.DataplaneTag}
+	if tf.Status.ReverseDataplaneTag != 0 {
+		tags = append(tags, tf.Status.ReverseDataplaneTag)
+	}
+	for _, tag := range tags {
+		if tag < minTagNum || tag > maxTagNum {
+			return errors.New("this Traceflow CRD's data plane tag is out of range")
+		}
 	}
 
 	c.runningTraceflowsMutex.Lock()
 	defer c.runningTraceflowsMutex.Unlock()
-	if existingTraceflowName, ok := c.runningTraceflows[tag]; ok {
-		if tf.Name == existingTraceflowName {
-			return nil
+	for _, tag := range tags {
+		if existingTraceflowName, ok := c.runningTraceflows[tag]; ok && tf.Name != existingTraceflowName {
+			return errors.New("this Traceflow's CRD data plane tag is already taken")
 		}
-		return errors.New("this Traceflow's CRD data plane tag is already taken")
 	}
-
-	c.runningTraceflows[tag] = tf.Name
+	for _, tag := range tags {
+		c.runningTraceflows[tag] = tf.Name
+	}
 	return nil
 }
 
-// Allocates a tag. If the Traceflow request has been allocated with a tag
-// already, 0 is returned. If number of existing Traceflow requests reaches
-// the upper limit, an error is returned.
-func (c *Controller) allocateTag(name string) (uint8, error) {
+// Allocates a tag, and a second tag for the reply packet if reversePath is
+// true. If the Traceflow request has been allocated with a tag already, 0 is
+// returned. If number of existing Traceflow requests reaches the upper limit,
+// an error is returned.
+func (c *Controller) allocateTag(name string, reversePath bool) (uint8, uint8, error) {
 	c.runningTraceflowsMutex.Lock()
 	defer c.runningTraceflowsMutex.Unlock()
 
 	for _, n := range c.runningTraceflows {
 		if n == name {
 			// The Traceflow request has been processed already.
-			return 0, nil
+			return 0, 0, nil
 		}
 	}
-	for i := minTagNum; i <= maxTagNum; i += tagStep {
+	numTags := 1
+	if reversePath {
+		numTags = 2
+	}
+	var tags []uint8
+	for i := minTagNum; i <= maxTagNum && len(tags) < numTags; i += tagStep {
 		if _, ok := c.runningTraceflows[i]; !ok {
-			c.runningTraceflows[i] = name
-			return i, nil
+			tags = append(tags, i)
 		}
 	}
-	return 0, fmt.Errorf("number of on-going Traceflow operations already reached the upper limit: %d", maxTagNum)
+	if len(tags) < numTags {
+		return 0, 0, fmt.Errorf("number of on-going Traceflow operations already reached the upper limit: %d", maxTagNum)
+	}
+	for _, tag := range tags {
+		c.runningTraceflows[tag] = name
+	}
+	if reversePath {
+		return tags[0], tags[1], nil
+	}
+	return tags[0], 0, nil
 }
 
-// Deallocates tag from cache. Ignore DataplaneTag == 0 which is an invalid case.
+// Deallocates tags from cache. Ignore DataplaneTag == 0 which is an invalid case.
 func (c *Controller) deallocateTagForTF(tf *crdv1alpha1.Traceflow) {
 	if tf.Status.DataplaneTag != 0 {
 		c.deallocateTag(tf.Name, tf.Status.DataplaneTag)
 	}
+	if tf.Status.ReverseDataplaneTag != 0 {
+		c.deallocateTag(tf.Name, tf.Status.ReverseDataplaneTag)
+	}
 }
 
 func (c *Controller) deallocateTag(name string, tag uint8) {
@@ -415,6 +480,22 @@ func (c *Controller) deallocateTag(name string, tag uint8) {
 }
 
 func (c *Controller) validateTraceflow(tf *crdv1alpha1.Traceflow) error {
+	if tf.Spec.DualStack {
+		if tf.Spec.LiveTraffic {
+			return fmt.Errorf("dual-stack is not supported in live-traffic Traceflow")
+		}
+		if tf.Spec.Destination.Pod == "" && tf.Spec.Destination.Service == "" {
+			return fmt.Errorf("dual-stack Traceflow requires a destination Pod or Service")
+		}
+	}
+	if tf.Spec.ReversePath {
+		if tf.Spec.LiveTraffic {
+			return fmt.Errorf("reverse path is not supported in live-traffic Traceflow")
+		}
+		if tf.Spec.Destination.Pod == "" && tf.Spec.Destination.Service == "" {
+			return fmt.Errorf("reverse-path Traceflow requires a destination Pod or Service")
+		}
+	}
 	if !tf.Spec.LiveTraffic {
 		srcPod, err := c.podLister.Pods(tf.Spec.Source.Namespace).Get(tf.Spec.Source.Pod)
 		if err != nil {
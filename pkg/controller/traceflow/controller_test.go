@@ -136,6 +136,166 @@ func TestTraceflow(t *testing.T) {
 		tfc.client.CrdV1alpha1().Traceflows().Delete(context.TODO(), "tf1", metav1.DeleteOptions{})
 	})
 
+	t.Run("dualStackTraceflow", func(t *testing.T) {
+		tf3 := crdv1alpha1.Traceflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "tf3", UID: "uid3"},
+			Spec: crdv1alpha1.TraceflowSpec{
+				Source:      crdv1alpha1.Source{Namespace: "ns1", Pod: "pod1"},
+				Destination: crdv1alpha1.Destination{Namespace: "ns2", Pod: "pod2"},
+				DualStack:   true,
+				Timeout:     10,
+			},
+		}
+		tfc.client.CrdV1alpha1().Traceflows().Create(context.TODO(), &tf3, metav1.CreateOptions{})
+		res, _ := tfc.waitForTraceflow("tf3", crdv1alpha1.Running, time.Second)
+		require.NotNil(t, res)
+
+		// The Traceflow should not succeed before the packets of both IP families are traced.
+		res.Status.Results = []crdv1alpha1.NodeResult{
+			{
+				IPFamily:     crdv1alpha1.IPFamilyIPv4,
+				Observations: []crdv1alpha1.Observation{{Component: crdv1alpha1.ComponentSpoofGuard}},
+			},
+			{
+				IPFamily:     crdv1alpha1.IPFamilyIPv4,
+				Observations: []crdv1alpha1.Observation{{Action: crdv1alpha1.ActionDelivered}},
+			},
+			{
+				IPFamily:     crdv1alpha1.IPFamilyIPv6,
+				Observations: []crdv1alpha1.Observation{{Component: crdv1alpha1.ComponentSpoofGuard}},
+			},
+		}
+		res, _ = tfc.client.CrdV1alpha1().Traceflows().Update(context.TODO(), res, metav1.UpdateOptions{})
+		require.NotNil(t, res)
+		succeeded, _ := tfc.waitForTraceflow("tf3", crdv1alpha1.Succeeded, 500*time.Millisecond)
+		assert.Nil(t, succeeded)
+
+		res.Status.Results = append(res.Status.Results, crdv1alpha1.NodeResult{
+			IPFamily:     crdv1alpha1.IPFamilyIPv6,
+			Observations: []crdv1alpha1.Observation{{Action: crdv1alpha1.ActionDelivered}},
+		})
+		tfc.client.CrdV1alpha1().Traceflows().Update(context.TODO(), res, metav1.UpdateOptions{})
+		res, _ = tfc.waitForTraceflow("tf3", crdv1alpha1.Succeeded, time.Second)
+		assert.NotNil(t, res)
+		assert.Equal(t, numRunningTraceflows(), 0)
+		tfc.client.CrdV1alpha1().Traceflows().Delete(context.TODO(), "tf3", metav1.DeleteOptions{})
+	})
+
+	t.Run("invalidDualStackTraceflow", func(t *testing.T) {
+		tf4 := crdv1alpha1.Traceflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "tf4", UID: "uid4"},
+			Spec: crdv1alpha1.TraceflowSpec{
+				Source:      crdv1alpha1.Source{Namespace: "ns1", Pod: "pod1"},
+				Destination: crdv1alpha1.Destination{IP: "10.1.2.3"},
+				DualStack:   true,
+			},
+		}
+		tfc.client.CrdV1alpha1().Traceflows().Create(context.TODO(), &tf4, metav1.CreateOptions{})
+		res, _ := tfc.waitForTraceflow("tf4", crdv1alpha1.Failed, time.Second)
+		require.NotNil(t, res)
+		assert.Contains(t, res.Status.Reason, "dual-stack Traceflow requires a destination Pod or Service")
+		assert.Equal(t, numRunningTraceflows(), 0)
+		tfc.client.CrdV1alpha1().Traceflows().Delete(context.TODO(), "tf4", metav1.DeleteOptions{})
+	})
+
+	t.Run("reversePathTraceflow", func(t *testing.T) {
+		tf5 := crdv1alpha1.Traceflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "tf5", UID: "uid5"},
+			Spec: crdv1alpha1.TraceflowSpec{
+				Source:      crdv1alpha1.Source{Namespace: "ns1", Pod: "pod1"},
+				Destination: crdv1alpha1.Destination{Namespace: "ns2", Pod: "pod2"},
+				ReversePath: true,
+				Timeout:     10,
+			},
+		}
+		tfc.client.CrdV1alpha1().Traceflows().Create(context.TODO(), &tf5, metav1.CreateOptions{})
+		res, _ := tfc.waitForTraceflow("tf5", crdv1alpha1.Running, time.Second)
+		require.NotNil(t, res)
+		assert.NotZero(t, res.Status.ReverseDataplaneTag)
+		assert.NotEqual(t, res.Status.DataplaneTag, res.Status.ReverseDataplaneTag)
+		assert.Equal(t, numRunningTraceflows(), 2)
+
+		// The Traceflow should not succeed before the reply packet is traced.
+		res.Status.Results = []crdv1alpha1.NodeResult{
+			{
+				Direction:    crdv1alpha1.TraceflowDirectionForward,
+				Observations: []crdv1alpha1.Observation{{Component: crdv1alpha1.ComponentSpoofGuard}},
+			},
+			{
+				Direction:    crdv1alpha1.TraceflowDirectionForward,
+				Observations: []crdv1alpha1.Observation{{Action: crdv1alpha1.ActionDelivered}},
+			},
+			{
+				Direction:    crdv1alpha1.TraceflowDirectionReverse,
+				Observations: []crdv1alpha1.Observation{{Component: crdv1alpha1.ComponentSpoofGuard}},
+			},
+		}
+		res, _ = tfc.client.CrdV1alpha1().Traceflows().Update(context.TODO(), res, metav1.UpdateOptions{})
+		require.NotNil(t, res)
+		succeeded, _ := tfc.waitForTraceflow("tf5", crdv1alpha1.Succeeded, 500*time.Millisecond)
+		assert.Nil(t, succeeded)
+
+		res.Status.Results = append(res.Status.Results, crdv1alpha1.NodeResult{
+			Direction:    crdv1alpha1.TraceflowDirectionReverse,
+			Observations: []crdv1alpha1.Observation{{Action: crdv1alpha1.ActionDropped}},
+		})
+		tfc.client.CrdV1alpha1().Traceflows().Update(context.TODO(), res, metav1.UpdateOptions{})
+		res, _ = tfc.waitForTraceflow("tf5", crdv1alpha1.Succeeded, time.Second)
+		assert.NotNil(t, res)
+		assert.Equal(t, numRunningTraceflows(), 0)
+		tfc.client.CrdV1alpha1().Traceflows().Delete(context.TODO(), "tf5", metav1.DeleteOptions{})
+	})
+
+	t.Run("reversePathTraceflowWithDroppedForwardPacket", func(t *testing.T) {
+		tf6 := crdv1alpha1.Traceflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "tf6", UID: "uid6"},
+			Spec: crdv1alpha1.TraceflowSpec{
+				Source:      crdv1alpha1.Source{Namespace: "ns1", Pod: "pod1"},
+				Destination: crdv1alpha1.Destination{Namespace: "ns2", Pod: "pod2"},
+				ReversePath: true,
+				Timeout:     10,
+			},
+		}
+		tfc.client.CrdV1alpha1().Traceflows().Create(context.TODO(), &tf6, metav1.CreateOptions{})
+		res, _ := tfc.waitForTraceflow("tf6", crdv1alpha1.Running, time.Second)
+		require.NotNil(t, res)
+
+		// No reply packet is injected if the forward packet is dropped.
+		res.Status.Results = []crdv1alpha1.NodeResult{
+			{
+				Direction:    crdv1alpha1.TraceflowDirectionForward,
+				Observations: []crdv1alpha1.Observation{{Component: crdv1alpha1.ComponentSpoofGuard}},
+			},
+			{
+				Direction:    crdv1alpha1.TraceflowDirectionForward,
+				Observations: []crdv1alpha1.Observation{{Action: crdv1alpha1.ActionDropped}},
+			},
+		}
+		tfc.client.CrdV1alpha1().Traceflows().Update(context.TODO(), res, metav1.UpdateOptions{})
+		res, _ = tfc.waitForTraceflow("tf6", crdv1alpha1.Succeeded, time.Second)
+		assert.NotNil(t, res)
+		assert.Equal(t, numRunningTraceflows(), 0)
+		tfc.client.CrdV1alpha1().Traceflows().Delete(context.TODO(), "tf6", metav1.DeleteOptions{})
+	})
+
+	t.Run("invalidReversePathTraceflow", func(t *testing.T) {
+		tf7 := crdv1alpha1.Traceflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "tf7", UID: "uid7"},
+			Spec: crdv1alpha1.TraceflowSpec{
+				Source:      crdv1alpha1.Source{Namespace: "ns1", Pod: "pod1"},
+				Destination: crdv1alpha1.Destination{Namespace: "ns2", Pod: "pod2"},
+				LiveTraffic: true,
+				ReversePath: true,
+			},
+		}
+		tfc.client.CrdV1alpha1().Traceflows().Create(context.TODO(), &tf7, metav1.CreateOptions{})
+		res, _ := tfc.waitForTraceflow("tf7", crdv1alpha1.Failed, time.Second)
+		require.NotNil(t, res)
+		assert.Contains(t, res.Status.Reason, "reverse path is not supported in live-traffic Traceflow")
+		assert.Equal(t, numRunningTraceflows(), 0)
+		tfc.client.CrdV1alpha1().Traceflows().Delete(context.TODO(), "tf7", metav1.DeleteOptions{})
+	})
+
 	t.Run("timeoutTraceflow", func(t *testing.T) {
 		startTime := time.Now()
 		tfc.client.CrdV1alpha1().Traceflows().Create(context.TODO(), &tf1, metav1.CreateOptions{})
@@ -122,7 +122,7 @@ type Group struct {
 	// of the Group. It is updated during the syncInternalGroup process.
 	MembersComputed v1.ConditionStatus
 	// Selector describes how the internal group selects Pods to get their addresses.
-	// Selector is nil if Group is defined with ipBlock, or if it has ServiceReference
+	// Selector is nil if Group is defined with ipBlocks only, or if it has ServiceReference
 	// and has not been processed by the controller yet / Service cannot be found.
 	Selector *GroupSelector
 	IPBlocks []controlplane.IPBlock
@@ -130,6 +130,10 @@ type Group struct {
 	// It is used for IP association query tests, so that for IP membership tests
 	// we do not need to instantiate an IPNet object each time.
 	IPNets []net.IPNet
+	// ExceptIPNets stores net.IPNet objects for the except CIDRs of each IPBlock,
+	// at the same index as the corresponding CIDR in IPNets. It is nil if none
+	// of the IPBlocks has except CIDRs.
+	ExceptIPNets [][]net.IPNet
 	// ServiceReference is reference to a v1.Service, which this Group keeps in sync
 	// and updates Selector based on the Service's selector.
 	ServiceReference *controlplane.ServiceReference
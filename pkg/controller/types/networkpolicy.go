@@ -105,6 +105,9 @@ type NetworkPolicy struct {
 	AppliedToPerRule bool
 	// SyncError is the Error encountered when syncing this NetworkPolicy.
 	SyncError error
+	// Annotations are the annotations of the original Network Policy which are consumed by the Antrea Agent, e.g. the
+	// audit logging settings. Other annotations are not included.
+	Annotations map[string]string
 }
 
 // GetAddressGroups returns AddressGroups used by this NetworkPolicy.
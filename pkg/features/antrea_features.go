@@ -125,6 +125,49 @@ const (
 	// Enable users to protect their applications by specifying how they are allowed to communicate with others, taking
 	// into account application context.
 	L7NetworkPolicy featuregate.Feature = "L7NetworkPolicy"
+
+	// alpha: v1.13
+	// Enable Antrea-native ClusterNetworkPolicies to be applied to the host network traffic of Nodes.
+	NodeNetworkPolicy featuregate.Feature = "NodeNetworkPolicy"
+
+	// alpha: v1.13
+	// Enable the API which lets external integrations lease IPs from ExternalIPPools.
+	ExternalIPLease featuregate.Feature = "ExternalIPLease"
+
+	// alpha: v1.13
+	// Enable mirroring the traffic Pods send or receive, selected with 5-tuple filters, to a local device or an ERSPAN
+	// collector.
+	TrafficMirror featuregate.Feature = "TrafficMirror"
+
+	// alpha: v1.13
+	// Enable periodically probing the other Nodes to measure the latency and packet loss of the tunnel and of the
+	// underlay network.
+	NodeLatencyMonitor featuregate.Feature = "NodeLatencyMonitor"
+
+	// alpha: v1.13
+	// Enable load balancing the external addresses of Services in Direct Server Return (DSR) mode in AntreaProxy, in
+	// which the replies of the connections load balanced to remote Endpoints bypass the ingress Node.
+	LoadBalancerModeDSR featuregate.Feature = "LoadBalancerModeDSR"
+
+	// alpha: v1.13
+	// Enable disseminating NetworkPolicies, AddressGroups and AppliedToGroups from antrea-controller to antrea-agents
+	// with a gRPC streaming API, which batches the events, instead of the HTTP watch API.
+	ControlplaneGRPC featuregate.Feature = "ControlplaneGRPC"
+
+	// alpha: v1.13
+	// Enable enforcing the bandwidth limits of Pods with OVS meters instead of TC qdiscs, when the OVS datapath
+	// supports meters.
+	PodBandwidthMeter featuregate.Feature = "PodBandwidthMeter"
+
+	// alpha: v1.13
+	// Enable antrea-agent to obtain the serving certificate of its API server from a CertificateSigningRequest
+	// signed by antrea-controller, and to rotate it automatically, instead of using a self-signed certificate.
+	AgentServingCertificate featuregate.Feature = "AgentServingCertificate"
+
+	// beta: v1.13
+	// Enable dropping the ARP and NDP packets from Pods which don't carry the Pod's IP and MAC addresses, unless the
+	// Pod's Namespace opts out with an annotation.
+	NeighborSpoofGuard featuregate.Feature = "NeighborSpoofGuard"
 )
 
 var (
@@ -159,6 +202,15 @@ var (
 		ExternalNode:            {Default: false, PreRelease: featuregate.Alpha},
 		SupportBundleCollection: {Default: false, PreRelease: featuregate.Alpha},
 		L7NetworkPolicy:         {Default: false, PreRelease: featuregate.Alpha},
+		NodeNetworkPolicy:       {Default: false, PreRelease: featuregate.Alpha},
+		ExternalIPLease:         {Default: false, PreRelease: featuregate.Alpha},
+		TrafficMirror:           {Default: false, PreRelease: featuregate.Alpha},
+		NodeLatencyMonitor:      {Default: false, PreRelease: featuregate.Alpha},
+		LoadBalancerModeDSR:     {Default: false, PreRelease: featuregate.Alpha},
+		ControlplaneGRPC:        {Default: false, PreRelease: featuregate.Alpha},
+		PodBandwidthMeter:       {Default: false, PreRelease: featuregate.Alpha},
+		AgentServingCertificate: {Default: false, PreRelease: featuregate.Alpha},
+		NeighborSpoofGuard:      {Default: true, PreRelease: featuregate.Beta},
 	}
 
 	// UnsupportedFeaturesOnWindows records the features not supported on
@@ -172,7 +224,6 @@ var (
 	// can have different FeatureSpecs between Linux and Windows, we should
 	// still define a separate defaultAntreaFeatureGates map for Windows.
 	unsupportedFeaturesOnWindows = map[featuregate.Feature]struct{}{
-		Egress:            {},
 		AntreaIPAM:        {},
 		Multicast:         {},
 		SecondaryNetwork:  {},
@@ -180,8 +231,14 @@ var (
 		IPsecCertAuth:     {},
 		// Multicluster feature is not validated on Windows yet. This can removed
 		// in the future if it's fully tested on Windows.
-		Multicluster:    {},
-		L7NetworkPolicy: {},
+		Multicluster:        {},
+		L7NetworkPolicy:     {},
+		NodeNetworkPolicy:   {},
+		TrafficMirror:       {},
+		NodeLatencyMonitor:  {},
+		LoadBalancerModeDSR: {},
+		PodBandwidthMeter:   {},
+		NeighborSpoofGuard:  {},
 	}
 	// supportedFeaturesOnExternalNode records the features supported on an external
 	// Node. Antrea Agent checks the enabled features if it is running on an
@@ -192,6 +249,15 @@ var (
 		NetworkPolicyStats:      {},
 		SupportBundleCollection: {},
 		L7NetworkPolicy:         {},
+		AntreaProxy:             {},
+	}
+	// defaultDisabledFeaturesOnExternalNode records the features which are supported
+	// on an external Node, but are disabled by default even if they are enabled by
+	// default on a K8s Node. They must be enabled explicitly in the configuration.
+	defaultDisabledFeaturesOnExternalNode = map[featuregate.Feature]struct{}{
+		// AntreaProxy requires additional permissions for antrea-agent on the
+		// external Node to watch Services and Endpoints.
+		AntreaProxy: {},
 	}
 )
 
@@ -229,3 +295,13 @@ func SupportedOnExternalNode(feature featuregate.Feature) bool {
 	_, exists = supportedFeaturesOnExternalNode[feature]
 	return exists
 }
+
+// EnabledByDefaultOnExternalNode checks whether a feature is enabled by default
+// on an external Node.
+func EnabledByDefaultOnExternalNode(feature featuregate.Feature) bool {
+	if !SupportedOnExternalNode(feature) || !DefaultAntreaFeatureGates[feature].Default {
+		return false
+	}
+	_, exists := defaultDisabledFeaturesOnExternalNode[feature]
+	return !exists
+}
@@ -34,7 +34,7 @@ func TestSupportedOnWindows(t *testing.T) {
 		},
 		{
 			name:     "Feature unsupported on Windows Node",
-			feature:  Egress,
+			feature:  Multicast,
 			expected: false,
 		},
 		{
@@ -82,3 +82,13 @@ func TestSupportedOnExternalNode(t *testing.T) {
 		})
 	}
 }
+
+func TestEnabledByDefaultOnExternalNode(t *testing.T) {
+	assert.True(t, EnabledByDefaultOnExternalNode(AntreaPolicy))
+	// AntreaProxy is supported but must be enabled explicitly.
+	assert.True(t, SupportedOnExternalNode(AntreaProxy))
+	assert.False(t, EnabledByDefaultOnExternalNode(AntreaProxy))
+	assert.False(t, EnabledByDefaultOnExternalNode(NodePortLocal))
+	// ExternalNode is supported but disabled by default.
+	assert.False(t, EnabledByDefaultOnExternalNode(ExternalNode))
+}
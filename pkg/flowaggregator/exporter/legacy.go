@@ -0,0 +1,126 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"fmt"
+	"net"
+
+	ipfixentities "github.com/vmware/go-ipfix/pkg/entities"
+	ipfixregistry "github.com/vmware/go-ipfix/pkg/registry"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/flowaggregator/flowrecord"
+	"antrea.io/antrea/pkg/flowaggregator/options"
+	"antrea.io/antrea/pkg/legacyflow"
+)
+
+// LegacyExporter sends the flow records to the external flow collector in the NetFlowV9 or sFlow format.
+type LegacyExporter struct {
+	externalFlowCollectorAddr string
+	recordFormat              legacyflow.Format
+	observationDomainID       uint32
+	exporter                  *legacyflow.Exporter
+}
+
+func NewLegacyExporter(k8sClient kubernetes.Interface, opt *options.Options) *LegacyExporter {
+	var observationDomainID uint32
+	if opt.Config.FlowCollector.ObservationDomainID != nil {
+		observationDomainID = *opt.Config.FlowCollector.ObservationDomainID
+	} else {
+		observationDomainID = genObservationDomainID(k8sClient)
+	}
+	klog.InfoS("Flow aggregator Observation Domain ID", "Domain ID", observationDomainID)
+
+	return &LegacyExporter{
+		externalFlowCollectorAddr: opt.ExternalFlowCollectorAddr,
+		recordFormat:              legacyflow.Format(opt.Config.FlowCollector.RecordFormat),
+		observationDomainID:       observationDomainID,
+	}
+}
+
+func (e *LegacyExporter) Start() {
+	// no-op
+}
+
+func (e *LegacyExporter) Stop() {
+	e.closeExporter()
+}
+
+func (e *LegacyExporter) closeExporter() {
+	if e.exporter != nil {
+		e.exporter.Close()
+		e.exporter = nil
+	}
+}
+
+func (e *LegacyExporter) AddRecord(record ipfixentities.Record, isRecordIPv6 bool) error {
+	if e.exporter == nil {
+		exporter, err := legacyflow.NewExporter(e.recordFormat, e.externalFlowCollectorAddr, e.observationDomainID)
+		if err != nil {
+			// in case of error, the FlowAggregator flowExportLoop will retry after activeFlowRecordTimeout
+			return fmt.Errorf("error when initializing %s exporter: %v", e.recordFormat, err)
+		}
+		e.exporter = exporter
+	}
+	sentBytes, err := e.exporter.Export(toLegacyRecord(flowrecord.GetFlowRecord(record)))
+	if err != nil {
+		e.closeExporter()
+		return fmt.Errorf("error when sending %s record: %v", e.recordFormat, err)
+	}
+	klog.V(4).InfoS("Record sent successfully", "recordFormat", e.recordFormat, "bytes sent", sentBytes)
+	return nil
+}
+
+func (e *LegacyExporter) UpdateOptions(opt *options.Options) {
+	recordFormat := legacyflow.Format(opt.Config.FlowCollector.RecordFormat)
+	if opt.ExternalFlowCollectorAddr == e.externalFlowCollectorAddr && recordFormat == e.recordFormat {
+		return
+	}
+	e.externalFlowCollectorAddr = opt.ExternalFlowCollectorAddr
+	e.recordFormat = recordFormat
+	klog.InfoS("Config ExternalFlowCollectorAddr or RecordFormat is changed", "address", e.externalFlowCollectorAddr, "recordFormat", e.recordFormat)
+	e.closeExporter()
+}
+
+func isDeniedRuleAction(ruleAction uint8) bool {
+	return ruleAction == ipfixregistry.NetworkPolicyRuleActionDrop || ruleAction == ipfixregistry.NetworkPolicyRuleActionReject
+}
+
+// toLegacyRecord converts the aggregated flow record to a NetFlow v9 or sFlow record, with the fields which can be
+// represented in these formats.
+func toLegacyRecord(r *flowrecord.FlowRecord) legacyflow.Record {
+	record := legacyflow.Record{
+		StartTime:          r.FlowStartSeconds,
+		EndTime:            r.FlowEndSeconds,
+		EndReason:          r.FlowEndReason,
+		SourceAddress:      net.ParseIP(r.SourceIP),
+		DestinationAddress: net.ParseIP(r.DestinationIP),
+		SourcePort:         r.SourceTransportPort,
+		DestinationPort:    r.DestinationTransportPort,
+		Protocol:           r.ProtocolIdentifier,
+		Packets:            r.PacketDeltaCount,
+		Octets:             r.OctetDeltaCount,
+		ReversePackets:     r.ReversePacketDeltaCount,
+		ReverseOctets:      r.ReverseOctetDeltaCount,
+		Dropped:            isDeniedRuleAction(r.IngressNetworkPolicyRuleAction) || isDeniedRuleAction(r.EgressNetworkPolicyRuleAction),
+	}
+	if r.DestinationServicePortName != "" {
+		record.ServiceAddress = net.ParseIP(r.DestinationClusterIP)
+		record.ServicePort = r.DestinationServicePort
+	}
+	return record
+}
@@ -0,0 +1,98 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ipfixentitiestesting "github.com/vmware/go-ipfix/pkg/entities/testing"
+
+	flowaggregatorconfig "antrea.io/antrea/pkg/config/flowaggregator"
+	flowrecordtesting "antrea.io/antrea/pkg/flowaggregator/flowrecord/testing"
+	"antrea.io/antrea/pkg/flowaggregator/options"
+	"antrea.io/antrea/pkg/legacyflow"
+)
+
+func TestLegacyExporter_AddRecord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRecord := ipfixentitiestesting.NewMockRecord(ctrl)
+	// The record has no packets in either direction, which is still exported as a NetFlow v9 record.
+	mockRecord.EXPECT().GetInfoElementWithValue(gomock.Any()).Return(nil, 0, false).AnyTimes()
+
+	collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer collector.Close()
+
+	observationDomainID := uint32(testObservationDomainID)
+	legacyExporter := NewLegacyExporter(nil, &options.Options{
+		Config: &flowaggregatorconfig.FlowAggregatorConfig{
+			FlowCollector: flowaggregatorconfig.FlowCollectorConfig{
+				ObservationDomainID: &observationDomainID,
+				RecordFormat:        "NetFlowV9",
+			},
+		},
+		ExternalFlowCollectorAddr: collector.LocalAddr().String(),
+	})
+	legacyExporter.Start()
+	defer legacyExporter.Stop()
+
+	require.NoError(t, legacyExporter.AddRecord(mockRecord, false))
+	require.NotNil(t, legacyExporter.exporter)
+	assert.Equal(t, legacyflow.FormatNetFlowV9, legacyExporter.exporter.Format())
+	buf := make([]byte, 65535)
+	require.NoError(t, collector.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, _, err = collector.ReadFrom(buf)
+	require.NoError(t, err)
+
+	// The exporter is closed when the options change, and it's created again for the next record.
+	legacyExporter.UpdateOptions(&options.Options{
+		Config: &flowaggregatorconfig.FlowAggregatorConfig{
+			FlowCollector: flowaggregatorconfig.FlowCollectorConfig{
+				RecordFormat: "sFlow",
+			},
+		},
+		ExternalFlowCollectorAddr: collector.LocalAddr().String(),
+	})
+	assert.Nil(t, legacyExporter.exporter)
+	require.NoError(t, legacyExporter.AddRecord(mockRecord, false))
+	assert.Equal(t, legacyflow.FormatSFlow, legacyExporter.exporter.Format())
+}
+
+func TestToLegacyRecord(t *testing.T) {
+	record := flowrecordtesting.PrepareTestFlowRecord()
+	assert.Equal(t, legacyflow.Record{
+		StartTime:          record.FlowStartSeconds,
+		EndTime:            record.FlowEndSeconds,
+		EndReason:          3,
+		SourceAddress:      net.ParseIP("10.10.0.79"),
+		DestinationAddress: net.ParseIP("10.10.0.80"),
+		SourcePort:         44752,
+		DestinationPort:    5201,
+		Protocol:           6,
+		Packets:            241333,
+		Octets:             8982624938,
+		ReversePackets:     136211,
+		ReverseOctets:      7083284,
+		ServiceAddress:     net.ParseIP("10.10.1.10"),
+		ServicePort:        5202,
+		// The ingress rule action is Drop.
+		Dropped: true,
+	}, toLegacyRecord(record))
+}
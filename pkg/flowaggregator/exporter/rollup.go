@@ -0,0 +1,146 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	ipfixentities "github.com/vmware/go-ipfix/pkg/entities"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	flowaggregatorconfig "antrea.io/antrea/pkg/config/flowaggregator"
+	"antrea.io/antrea/pkg/flowaggregator/flowlogger"
+	"antrea.io/antrea/pkg/flowaggregator/flowrecord"
+	"antrea.io/antrea/pkg/flowaggregator/options"
+	"antrea.io/antrea/pkg/flowaggregator/rollup"
+)
+
+// RollupExporter aggregates flow records by source workload and destination
+// Service or workload, and periodically writes the resulting rollup records to
+// a local log file.
+type RollupExporter struct {
+	config    flowaggregatorconfig.RollupConfig
+	interval  time.Duration
+	podLister corelisters.PodLister
+	// mutex protects aggregator, which is accessed both by AddRecord and by
+	// the flush goroutine.
+	mutex      sync.Mutex
+	aggregator *rollup.Aggregator
+	flowLogger *flowlogger.FlowLogger
+	stopCh     chan struct{}
+	wg         sync.WaitGroup
+	// clock is used for unit testing.
+	clock func() time.Time
+}
+
+func NewRollupExporter(podLister corelisters.PodLister, opt *options.Options) (*RollupExporter, error) {
+	config := opt.Config.Rollup
+	klog.InfoS("Rollup configuration", "interval", opt.RollupInterval, "path", config.Path, "maxSize", config.MaxSize, "maxBackups", config.MaxBackups, "maxAge", config.MaxAge, "compress", *config.Compress)
+	return &RollupExporter{
+		config:    config,
+		interval:  opt.RollupInterval,
+		podLister: podLister,
+		clock:     time.Now,
+	}, nil
+}
+
+func (e *RollupExporter) AddRecord(record ipfixentities.Record, isRecordIPv6 bool) error {
+	r := flowrecord.GetFlowRecord(record)
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.aggregator.Add(r)
+	return nil
+}
+
+func (e *RollupExporter) Start() {
+	e.start()
+}
+
+func (e *RollupExporter) Stop() {
+	e.stop()
+}
+
+func (e *RollupExporter) start() {
+	e.stopCh = make(chan struct{})
+	e.aggregator = rollup.NewAggregator(e.podLister, e.clock())
+	e.flowLogger = flowlogger.NewFlowLogger(
+		e.config.Path,
+		// these are all valid conversions from int32 to int
+		int(e.config.MaxSize),
+		int(e.config.MaxBackups),
+		int(e.config.MaxAge),
+		*e.config.Compress,
+	)
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		e.flushLoop(e.stopCh)
+	}()
+}
+
+func (e *RollupExporter) stop() {
+	close(e.stopCh)
+	e.wg.Wait()
+	e.flowLogger.Close()
+	e.flowLogger = nil
+}
+
+func (e *RollupExporter) flushLoop(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			// Write the records for the current (partial) time window, so
+			// that no traffic is lost when the exporter is stopped.
+			e.flush()
+			return
+		case <-ticker.C:
+			e.flush()
+		}
+	}
+}
+
+func (e *RollupExporter) flush() {
+	e.mutex.Lock()
+	records := e.aggregator.Flush(e.clock())
+	e.mutex.Unlock()
+	for idx := range records {
+		if err := e.flowLogger.WriteFields(records[idx].Fields()); err != nil {
+			klog.ErrorS(err, "Error when writing rollup record")
+			return
+		}
+	}
+	if err := e.flowLogger.Flush(); err != nil {
+		klog.ErrorS(err, "Error when flushing rollup records")
+	}
+	klog.V(4).InfoS("Wrote rollup records", "count", len(records))
+}
+
+func (e *RollupExporter) UpdateOptions(opt *options.Options) {
+	config := opt.Config.Rollup
+	if reflect.DeepEqual(e.config, config) {
+		return
+	}
+	klog.InfoS("Updating Rollup")
+	e.stop()
+	e.config = config
+	e.interval = opt.RollupInterval
+	klog.InfoS("New Rollup configuration", "interval", opt.RollupInterval, "path", config.Path, "maxSize", config.MaxSize, "maxBackups", config.MaxBackups, "maxAge", config.MaxAge, "compress", *config.Compress)
+	e.start()
+}
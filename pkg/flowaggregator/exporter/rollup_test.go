@@ -0,0 +1,105 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ipfixentitiestesting "github.com/vmware/go-ipfix/pkg/entities/testing"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	flowaggregatorconfig "antrea.io/antrea/pkg/config/flowaggregator"
+	"antrea.io/antrea/pkg/flowaggregator/options"
+	flowaggregatortesting "antrea.io/antrea/pkg/flowaggregator/testing"
+)
+
+func TestRollup_UpdateOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRecord1 := ipfixentitiestesting.NewMockRecord(ctrl)
+	flowaggregatortesting.PrepareMockIpfixRecord(mockRecord1, true)
+	mockRecord2 := ipfixentitiestesting.NewMockRecord(ctrl)
+	flowaggregatortesting.PrepareMockIpfixRecord(mockRecord2, true)
+	mockRecord3 := ipfixentitiestesting.NewMockRecord(ctrl)
+	flowaggregatortesting.PrepareMockIpfixRecord(mockRecord3, true)
+
+	dir, err := os.MkdirTemp("", "flows")
+	defer os.RemoveAll(dir)
+	require.NoError(t, err)
+	path1 := filepath.Join(dir, "1.log")
+	path2 := filepath.Join(dir, "2.log")
+
+	opt := func(path string) *options.Options {
+		return &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				Rollup: flowaggregatorconfig.RollupConfig{
+					Enable:   true,
+					Interval: "1h",
+					Path:     path,
+					Compress: new(bool),
+				},
+			},
+			RollupInterval: time.Hour,
+		}
+	}
+
+	readLines := func(path string) []string {
+		data, err := os.ReadFile(path)
+		if err != nil { // assume this always means the file does not exist
+			return nil
+		}
+		return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	}
+
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	podLister := informerFactory.Core().V1().Pods().Lister()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+
+	rollupExporter, _ := NewRollupExporter(podLister, opt(path1))
+	now := time.Unix(1637706900, 0)
+	rollupExporter.clock = func() time.Time { return now }
+	rollupExporter.Start()
+	// The same connection reported twice is only counted once.
+	require.NoError(t, rollupExporter.AddRecord(mockRecord1, false))
+	require.NoError(t, rollupExporter.AddRecord(mockRecord2, false))
+	now = now.Add(time.Minute)
+	// The records for the current time window are written when the exporter is stopped.
+	rollupExporter.UpdateOptions(opt(path2))
+	lines := readLines(path1)
+	require.Len(t, lines, 1)
+	fields := strings.Split(lines[0], ",")
+	require.Len(t, fields, 13)
+	assert.Equal(t, []string{"1637706900", "1637706960", "Pod", "antrea-test", "perftest-a", "Service", "", "perftest"}, fields[:8])
+	assert.Equal(t, "1", fields[12])
+	assert.Empty(t, readLines(path2))
+
+	require.NoError(t, rollupExporter.AddRecord(mockRecord3, false))
+	now = now.Add(time.Minute)
+	rollupExporter.Stop()
+	lines = readLines(path2)
+	require.Len(t, lines, 1)
+	assert.True(t, strings.HasPrefix(lines[0], "1637706960,1637707020,Pod,antrea-test,perftest-a,"))
+}
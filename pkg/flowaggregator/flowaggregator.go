@@ -41,6 +41,7 @@ import (
 	"antrea.io/antrea/pkg/flowaggregator/options"
 	"antrea.io/antrea/pkg/flowaggregator/querier"
 	"antrea.io/antrea/pkg/ipfix"
+	"antrea.io/antrea/pkg/legacyflow"
 )
 
 var (
@@ -92,6 +93,9 @@ const (
 // these are used for unit testing
 var (
 	newIPFIXExporter = func(k8sClient kubernetes.Interface, opt *options.Options, registry ipfix.IPFIXRegistry) exporter.Interface {
+		if legacyflow.IsValidFormat(opt.Config.FlowCollector.RecordFormat) {
+			return exporter.NewLegacyExporter(k8sClient, opt)
+		}
 		return exporter.NewIPFIXExporter(k8sClient, opt, registry)
 	}
 	newClickHouseExporter = func(k8sClient kubernetes.Interface, opt *options.Options) (exporter.Interface, error) {
@@ -103,6 +107,9 @@ var (
 	newLogExporter = func(opt *options.Options) (exporter.Interface, error) {
 		return exporter.NewLogExporter(opt)
 	}
+	newRollupExporter = func(podLister corelisters.PodLister, opt *options.Options) (exporter.Interface, error) {
+		return exporter.NewRollupExporter(podLister, opt)
+	}
 )
 
 type flowAggregator struct {
@@ -123,9 +130,11 @@ type flowAggregator struct {
 	configData                  []byte
 	APIServer                   flowaggregatorconfig.APIServerConfig
 	ipfixExporter               exporter.Interface
+	flowCollectorRecordFormat   string
 	clickHouseExporter          exporter.Interface
 	s3Exporter                  exporter.Interface
 	logExporter                 exporter.Interface
+	rollupExporter              exporter.Interface
 	logTickerDuration           time.Duration
 	podLister                   corelisters.PodLister
 }
@@ -208,8 +217,16 @@ func NewFlowAggregator(
 			return nil, fmt.Errorf("error when creating log export process: %v", err)
 		}
 	}
+	if opt.Config.Rollup.Enable {
+		var err error
+		fa.rollupExporter, err = newRollupExporter(fa.podLister, opt)
+		if err != nil {
+			return nil, fmt.Errorf("error when creating rollup export process: %v", err)
+		}
+	}
 	if opt.Config.FlowCollector.Enable {
 		fa.ipfixExporter = newIPFIXExporter(k8sClient, opt, registry)
+		fa.flowCollectorRecordFormat = opt.Config.FlowCollector.RecordFormat
 	}
 	podInformer.Informer().AddIndexers(cache.Indexers{podInfoIndex: podInfoIndexFunc})
 	return fa, nil
@@ -315,6 +332,9 @@ func (fa *flowAggregator) Run(stopCh <-chan struct{}) {
 	if fa.logExporter != nil {
 		fa.logExporter.Start()
 	}
+	if fa.rollupExporter != nil {
+		fa.rollupExporter.Start()
+	}
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
@@ -365,6 +385,9 @@ func (fa *flowAggregator) flowExportLoop(stopCh <-chan struct{}) {
 		if fa.logExporter != nil {
 			fa.logExporter.Stop()
 		}
+		if fa.rollupExporter != nil {
+			fa.rollupExporter.Stop()
+		}
 	}()
 	updateCh := fa.updateCh
 	for {
@@ -431,6 +454,11 @@ func (fa *flowAggregator) sendFlowKeyRecord(key ipfixintermediate.FlowKey, recor
 			return err
 		}
 	}
+	if fa.rollupExporter != nil {
+		if err := fa.rollupExporter.AddRecord(record.Record, !isRecordIPv4); err != nil {
+			return err
+		}
+	}
 	if err := fa.aggregationProcess.ResetStatAndThroughputElementsInRecord(record.Record); err != nil {
 		return err
 	}
@@ -605,9 +633,16 @@ func (fa *flowAggregator) updateFlowAggregator(opt *options.Options) {
 			fa.ipfixExporter = newIPFIXExporter(fa.k8sClient, opt, fa.registry)
 			fa.ipfixExporter.Start()
 			klog.InfoS("Enabled Flow-Collector")
+		} else if legacyflow.IsValidFormat(opt.Config.FlowCollector.RecordFormat) != legacyflow.IsValidFormat(fa.flowCollectorRecordFormat) {
+			// The records in the NetFlowV9 and sFlow formats are sent by a different exporter.
+			klog.InfoS("Recreating Flow-Collector exporter as the record format is changed", "recordFormat", opt.Config.FlowCollector.RecordFormat)
+			fa.ipfixExporter.Stop()
+			fa.ipfixExporter = newIPFIXExporter(fa.k8sClient, opt, fa.registry)
+			fa.ipfixExporter.Start()
 		} else {
 			fa.ipfixExporter.UpdateOptions(opt)
 		}
+		fa.flowCollectorRecordFormat = opt.Config.FlowCollector.RecordFormat
 	} else {
 		if fa.ipfixExporter != nil {
 			klog.InfoS("Disabling Flow-Collector")
@@ -682,4 +717,26 @@ func (fa *flowAggregator) updateFlowAggregator(opt *options.Options) {
 			klog.InfoS("Disabled FlowLogger")
 		}
 	}
+	if opt.Config.Rollup.Enable {
+		if fa.rollupExporter == nil {
+			klog.InfoS("Enabling Rollup")
+			var err error
+			fa.rollupExporter, err = newRollupExporter(fa.podLister, opt)
+			if err != nil {
+				klog.ErrorS(err, "Error when creating rollup export process")
+				return
+			}
+			fa.rollupExporter.Start()
+			klog.InfoS("Enabled Rollup")
+		} else {
+			fa.rollupExporter.UpdateOptions(opt)
+		}
+	} else {
+		if fa.rollupExporter != nil {
+			klog.InfoS("Disabling Rollup")
+			fa.rollupExporter.Stop()
+			fa.rollupExporter = nil
+			klog.InfoS("Disabled Rollup")
+		}
+	}
 }
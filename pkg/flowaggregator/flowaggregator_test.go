@@ -36,6 +36,7 @@ import (
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 
 	flowaggregatorconfig "antrea.io/antrea/pkg/config/flowaggregator"
@@ -264,16 +265,19 @@ func TestFlowAggregator_updateFlowAggregator(t *testing.T) {
 	mockClickHouseExporter := exportertesting.NewMockInterface(ctrl)
 	mockS3Exporter := exportertesting.NewMockInterface(ctrl)
 	mockLogExporter := exportertesting.NewMockInterface(ctrl)
+	mockRollupExporter := exportertesting.NewMockInterface(ctrl)
 
 	newIPFIXExporterSaved := newIPFIXExporter
 	newClickHouseExporterSaved := newClickHouseExporter
 	newS3ExporterSaved := newS3Exporter
 	newLogExporterSaved := newLogExporter
+	newRollupExporterSaved := newRollupExporter
 	defer func() {
 		newIPFIXExporter = newIPFIXExporterSaved
 		newClickHouseExporter = newClickHouseExporterSaved
 		newS3Exporter = newS3ExporterSaved
 		newLogExporter = newLogExporterSaved
+		newRollupExporter = newRollupExporterSaved
 	}()
 	newIPFIXExporter = func(kubernetes.Interface, *options.Options, ipfix.IPFIXRegistry) exporter.Interface {
 		return mockIPFIXExporter
@@ -287,6 +291,9 @@ func TestFlowAggregator_updateFlowAggregator(t *testing.T) {
 	newLogExporter = func(opt *options.Options) (exporter.Interface, error) {
 		return mockLogExporter, nil
 	}
+	newRollupExporter = func(corelisters.PodLister, *options.Options) (exporter.Interface, error) {
+		return mockRollupExporter, nil
+	}
 
 	t.Run("updateIPFIX", func(t *testing.T) {
 		flowAggregator := &flowAggregator{
@@ -303,6 +310,26 @@ func TestFlowAggregator_updateFlowAggregator(t *testing.T) {
 		mockIPFIXExporter.EXPECT().UpdateOptions(opt)
 		flowAggregator.updateFlowAggregator(opt)
 	})
+	t.Run("updateIPFIXRecordFormat", func(t *testing.T) {
+		flowAggregator := &flowAggregator{
+			ipfixExporter:             mockIPFIXExporter,
+			flowCollectorRecordFormat: "IPFIX",
+		}
+		opt := &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				FlowCollector: flowaggregatorconfig.FlowCollectorConfig{
+					Enable:       true,
+					Address:      "10.10.10.10:2055:udp",
+					RecordFormat: "NetFlowV9",
+				},
+			},
+		}
+		// The exporter is recreated when switching to the NetFlowV9 format.
+		mockIPFIXExporter.EXPECT().Stop()
+		mockIPFIXExporter.EXPECT().Start()
+		flowAggregator.updateFlowAggregator(opt)
+		assert.Equal(t, "NetFlowV9", flowAggregator.flowCollectorRecordFormat)
+	})
 	t.Run("disableIPFIX", func(t *testing.T) {
 		flowAggregator := &flowAggregator{
 			ipfixExporter: mockIPFIXExporter,
@@ -441,6 +468,49 @@ func TestFlowAggregator_updateFlowAggregator(t *testing.T) {
 		mockLogExporter.EXPECT().UpdateOptions(opt)
 		flowAggregator.updateFlowAggregator(opt)
 	})
+	t.Run("enableRollup", func(t *testing.T) {
+		flowAggregator := &flowAggregator{}
+		opt := &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				Rollup: flowaggregatorconfig.RollupConfig{
+					Enable: true,
+				},
+			},
+			RollupInterval: time.Minute,
+		}
+		mockRollupExporter.EXPECT().Start()
+		flowAggregator.updateFlowAggregator(opt)
+	})
+	t.Run("disableRollup", func(t *testing.T) {
+		flowAggregator := &flowAggregator{
+			rollupExporter: mockRollupExporter,
+		}
+		opt := &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				Rollup: flowaggregatorconfig.RollupConfig{
+					Enable: false,
+				},
+			},
+		}
+		mockRollupExporter.EXPECT().Stop()
+		flowAggregator.updateFlowAggregator(opt)
+	})
+	t.Run("updateRollup", func(t *testing.T) {
+		flowAggregator := &flowAggregator{
+			rollupExporter: mockRollupExporter,
+		}
+		opt := &options.Options{
+			Config: &flowaggregatorconfig.FlowAggregatorConfig{
+				Rollup: flowaggregatorconfig.RollupConfig{
+					Enable:   true,
+					Interval: "30s",
+				},
+			},
+			RollupInterval: 30 * time.Second,
+		}
+		mockRollupExporter.EXPECT().UpdateOptions(opt)
+		flowAggregator.updateFlowAggregator(opt)
+	})
 }
 
 func TestFlowAggregator_Run(t *testing.T) {
@@ -118,6 +118,11 @@ func (fl *FlowLogger) WriteRecord(r *flowrecord.FlowRecord, prettyPrint bool) er
 		r.EgressIP,
 	}
 
+	return fl.WriteFields(fields)
+}
+
+// WriteFields writes the provided fields to the log file as a single CSV line.
+func (fl *FlowLogger) WriteFields(fields []string) error {
 	str := strings.Join(fields, ",")
 
 	fl.Lock()
@@ -22,6 +22,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	flowaggregatorconfig "antrea.io/antrea/pkg/config/flowaggregator"
+	"antrea.io/antrea/pkg/legacyflow"
 	"antrea.io/antrea/pkg/util/flowexport"
 )
 
@@ -42,6 +43,8 @@ type Options struct {
 	ClickHouseCommitInterval time.Duration
 	// Flow records batch upload interval from flow aggregator to S3 bucket
 	S3UploadInterval time.Duration
+	// Duration of the time window covered by each rollup record
+	RollupInterval time.Duration
 }
 
 func LoadConfig(configBytes []byte) (*Options, error) {
@@ -57,7 +60,7 @@ func LoadConfig(configBytes []byte) (*Options, error) {
 	if opt.Config.S3Uploader.Enable && opt.Config.S3Uploader.BucketName == "" {
 		return nil, fmt.Errorf("s3Uploader enabled without specifying bucket name")
 	}
-	if !opt.Config.FlowCollector.Enable && !opt.Config.ClickHouse.Enable && !opt.Config.S3Uploader.Enable && !opt.Config.FlowLogger.Enable {
+	if !opt.Config.FlowCollector.Enable && !opt.Config.ClickHouse.Enable && !opt.Config.S3Uploader.Enable && !opt.Config.FlowLogger.Enable && !opt.Config.Rollup.Enable {
 		return nil, fmt.Errorf("external flow collector or ClickHouse or S3Uploader should be configured")
 	}
 	// Validate common parameters
@@ -85,7 +88,11 @@ func LoadConfig(configBytes []byte) (*Options, error) {
 		opt.ExternalFlowCollectorAddr = net.JoinHostPort(host, port)
 		opt.ExternalFlowCollectorProto = proto
 
-		if opt.Config.FlowCollector.RecordFormat != "IPFIX" && opt.Config.FlowCollector.RecordFormat != "JSON" {
+		if legacyflow.IsValidFormat(opt.Config.FlowCollector.RecordFormat) {
+			if proto != "udp" {
+				return nil, fmt.Errorf("record format %s is only supported with the udp transport protocol", opt.Config.FlowCollector.RecordFormat)
+			}
+		} else if opt.Config.FlowCollector.RecordFormat != "IPFIX" && opt.Config.FlowCollector.RecordFormat != "JSON" {
 			return nil, fmt.Errorf("record format %s is not supported", opt.Config.FlowCollector.RecordFormat)
 		}
 	}
@@ -120,5 +127,16 @@ func LoadConfig(configBytes []byte) (*Options, error) {
 			return nil, fmt.Errorf("record format %s is not supported", opt.Config.FlowLogger.RecordFormat)
 		}
 	}
+	// Validate Rollup specific parameters
+	if opt.Config.Rollup.Enable {
+		opt.RollupInterval, err = time.ParseDuration(opt.Config.Rollup.Interval)
+		if err != nil {
+			return nil, err
+		}
+		if opt.RollupInterval < flowaggregatorconfig.MinRollupInterval {
+			return nil, fmt.Errorf("rollup interval %s is too small: shortest supported interval is %v",
+				opt.Config.Rollup.Interval, flowaggregatorconfig.MinRollupInterval)
+		}
+	}
 	return &opt, nil
 }
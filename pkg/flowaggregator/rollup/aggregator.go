@@ -0,0 +1,234 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	"antrea.io/antrea/pkg/flowaggregator/flowrecord"
+)
+
+const (
+	WorkloadKindPod        = "Pod"
+	WorkloadKindDeployment = "Deployment"
+	WorkloadKindReplicaSet = "ReplicaSet"
+	WorkloadKindService    = "Service"
+	// WorkloadKindExternal is used for endpoints which are not Pods in the
+	// cluster. The IP address is used as the workload name.
+	WorkloadKindExternal = "External"
+)
+
+// Workload identifies one side of the traffic summarized by a rollup Record.
+type Workload struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// Key is the key of a rollup Record: all the connections from the same source
+// workload to the same destination Service or workload are aggregated together.
+type Key struct {
+	Source      Workload
+	Destination Workload
+}
+
+// Record summarizes the traffic for a given Key over the [WindowStart, WindowEnd)
+// time window.
+type Record struct {
+	Key
+	WindowStart        time.Time
+	WindowEnd          time.Time
+	OctetCount         uint64
+	PacketCount        uint64
+	ReverseOctetCount  uint64
+	ReversePacketCount uint64
+	// ConnectionCount is the number of distinct connections which reported
+	// some activity during the time window.
+	ConnectionCount uint64
+}
+
+type connectionKey struct {
+	sourceIP                 string
+	destinationIP            string
+	sourceTransportPort      uint16
+	destinationTransportPort uint16
+	protocolIdentifier       uint8
+}
+
+type entry struct {
+	record      Record
+	connections map[connectionKey]struct{}
+}
+
+// Aggregator accumulates flow records into rollup Records. It is not safe for
+// concurrent access.
+type Aggregator struct {
+	podLister   corelisters.PodLister
+	windowStart time.Time
+	entries     map[Key]*entry
+}
+
+func NewAggregator(podLister corelisters.PodLister, now time.Time) *Aggregator {
+	return &Aggregator{
+		podLister:   podLister,
+		windowStart: now,
+		entries:     make(map[Key]*entry),
+	}
+}
+
+// Add accounts for the delta counters of the provided flow record in the
+// current time window.
+func (a *Aggregator) Add(r *flowrecord.FlowRecord) {
+	key := Key{
+		Source:      a.sourceWorkload(r),
+		Destination: a.destinationWorkload(r),
+	}
+	e, ok := a.entries[key]
+	if !ok {
+		e = &entry{
+			record:      Record{Key: key},
+			connections: make(map[connectionKey]struct{}),
+		}
+		a.entries[key] = e
+	}
+	e.record.OctetCount += r.OctetDeltaCount
+	e.record.PacketCount += r.PacketDeltaCount
+	e.record.ReverseOctetCount += r.ReverseOctetDeltaCount
+	e.record.ReversePacketCount += r.ReversePacketDeltaCount
+	e.connections[connectionKey{
+		sourceIP:                 r.SourceIP,
+		destinationIP:            r.DestinationIP,
+		sourceTransportPort:      r.SourceTransportPort,
+		destinationTransportPort: r.DestinationTransportPort,
+		protocolIdentifier:       r.ProtocolIdentifier,
+	}] = struct{}{}
+}
+
+// Flush returns the rollup Records for the current time window, which ends at
+// now, and starts a new time window. Records are sorted by Key.
+func (a *Aggregator) Flush(now time.Time) []Record {
+	records := make([]Record, 0, len(a.entries))
+	for _, e := range a.entries {
+		r := e.record
+		r.WindowStart = a.windowStart
+		r.WindowEnd = now
+		r.ConnectionCount = uint64(len(e.connections))
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return lessKey(&records[i].Key, &records[j].Key)
+	})
+	a.windowStart = now
+	a.entries = make(map[Key]*entry)
+	return records
+}
+
+func lessWorkload(w1, w2 *Workload) bool {
+	if w1.Kind != w2.Kind {
+		return w1.Kind < w2.Kind
+	}
+	if w1.Namespace != w2.Namespace {
+		return w1.Namespace < w2.Namespace
+	}
+	return w1.Name < w2.Name
+}
+
+func lessKey(k1, k2 *Key) bool {
+	if k1.Source != k2.Source {
+		return lessWorkload(&k1.Source, &k2.Source)
+	}
+	return lessWorkload(&k1.Destination, &k2.Destination)
+}
+
+func (a *Aggregator) sourceWorkload(r *flowrecord.FlowRecord) Workload {
+	if r.SourcePodName == "" {
+		return Workload{Kind: WorkloadKindExternal, Name: r.SourceIP}
+	}
+	return a.podWorkload(r.SourcePodNamespace, r.SourcePodName)
+}
+
+func (a *Aggregator) destinationWorkload(r *flowrecord.FlowRecord) Workload {
+	// destinationServicePortName has the "<namespace>/<name>:<port>" format.
+	if r.DestinationServicePortName != "" {
+		namespace, name, found := strings.Cut(r.DestinationServicePortName, "/")
+		if !found {
+			namespace, name = "", namespace
+		}
+		name, _, _ = strings.Cut(name, ":")
+		return Workload{Kind: WorkloadKindService, Namespace: namespace, Name: name}
+	}
+	if r.DestinationPodName == "" {
+		return Workload{Kind: WorkloadKindExternal, Name: r.DestinationIP}
+	}
+	return a.podWorkload(r.DestinationPodNamespace, r.DestinationPodName)
+}
+
+// podWorkload returns the workload which owns the Pod. If the Pod cannot be
+// found, e.g. because it has already been deleted, the Pod itself is used.
+func (a *Aggregator) podWorkload(namespace, name string) Workload {
+	pod, err := a.podLister.Pods(namespace).Get(name)
+	if err != nil {
+		klog.V(4).InfoS("Failed to get Pod for rollup record", "namespace", namespace, "name", name, "err", err)
+		return Workload{Kind: WorkloadKindPod, Namespace: namespace, Name: name}
+	}
+	return workloadForPod(pod)
+}
+
+func workloadForPod(pod *corev1.Pod) Workload {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return Workload{Kind: WorkloadKindPod, Namespace: pod.Namespace, Name: pod.Name}
+	}
+	if owner.Kind == WorkloadKindReplicaSet {
+		// ReplicaSets created by a Deployment are named after the Deployment,
+		// with the pod-template-hash as suffix. This lets us avoid watching
+		// ReplicaSets.
+		if hash, ok := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; ok {
+			if name := strings.TrimSuffix(owner.Name, "-"+hash); name != owner.Name {
+				return Workload{Kind: WorkloadKindDeployment, Namespace: pod.Namespace, Name: name}
+			}
+		}
+	}
+	return Workload{Kind: owner.Kind, Namespace: pod.Namespace, Name: owner.Name}
+}
+
+// Fields returns the fields of the rollup Record, in the order used when
+// writing records to file.
+func (r *Record) Fields() []string {
+	return []string{
+		fmt.Sprintf("%d", r.WindowStart.Unix()),
+		fmt.Sprintf("%d", r.WindowEnd.Unix()),
+		r.Source.Kind,
+		r.Source.Namespace,
+		r.Source.Name,
+		r.Destination.Kind,
+		r.Destination.Namespace,
+		r.Destination.Name,
+		fmt.Sprintf("%d", r.OctetCount),
+		fmt.Sprintf("%d", r.PacketCount),
+		fmt.Sprintf("%d", r.ReverseOctetCount),
+		fmt.Sprintf("%d", r.ReversePacketCount),
+		fmt.Sprintf("%d", r.ConnectionCount),
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"antrea.io/antrea/pkg/flowaggregator/flowrecord"
+)
+
+func newPod(namespace, name string, labels map[string]string, owner *metav1.OwnerReference) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    labels,
+		},
+	}
+	if owner != nil {
+		controller := true
+		owner.Controller = &controller
+		pod.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return pod
+}
+
+func TestWorkloadForPod(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pod      *corev1.Pod
+		expected Workload
+	}{
+		{
+			name:     "standalone Pod",
+			pod:      newPod("ns1", "pod1", nil, nil),
+			expected: Workload{Kind: WorkloadKindPod, Namespace: "ns1", Name: "pod1"},
+		},
+		{
+			name: "Deployment",
+			pod: newPod("ns1", "web-5d4f8b6c7-x2x9z", map[string]string{"pod-template-hash": "5d4f8b6c7"},
+				&metav1.OwnerReference{Kind: "ReplicaSet", Name: "web-5d4f8b6c7"}),
+			expected: Workload{Kind: WorkloadKindDeployment, Namespace: "ns1", Name: "web"},
+		},
+		{
+			name:     "standalone ReplicaSet",
+			pod:      newPod("ns1", "rs-abcde", nil, &metav1.OwnerReference{Kind: "ReplicaSet", Name: "rs"}),
+			expected: Workload{Kind: WorkloadKindReplicaSet, Namespace: "ns1", Name: "rs"},
+		},
+		{
+			name:     "StatefulSet",
+			pod:      newPod("ns2", "db-0", nil, &metav1.OwnerReference{Kind: "StatefulSet", Name: "db"}),
+			expected: Workload{Kind: "StatefulSet", Namespace: "ns2", Name: "db"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, workloadForPod(tc.pod))
+		})
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newPod("ns1", "client-7b9f6d5c4-aaaaa", map[string]string{"pod-template-hash": "7b9f6d5c4"},
+			&metav1.OwnerReference{Kind: "ReplicaSet", Name: "client-7b9f6d5c4"}),
+		newPod("ns1", "client-7b9f6d5c4-bbbbb", map[string]string{"pod-template-hash": "7b9f6d5c4"},
+			&metav1.OwnerReference{Kind: "ReplicaSet", Name: "client-7b9f6d5c4"}),
+		newPod("ns2", "db-0", nil, &metav1.OwnerReference{Kind: "StatefulSet", Name: "db"}),
+	)
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	podInformer := informerFactory.Core().V1().Pods()
+	// Register the Pod informer before starting the factory.
+	podInformer.Informer()
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	informerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+
+	start := time.Unix(1000, 0)
+	a := NewAggregator(podInformer.Lister(), start)
+
+	// Two connections from different Pods of the same Deployment to the same Service.
+	a.Add(&flowrecord.FlowRecord{
+		SourceIP:                   "10.0.0.1",
+		DestinationIP:              "10.96.0.10",
+		SourceTransportPort:        40000,
+		DestinationTransportPort:   80,
+		ProtocolIdentifier:         6,
+		OctetDeltaCount:            100,
+		PacketDeltaCount:           2,
+		ReverseOctetDeltaCount:     1000,
+		ReversePacketDeltaCount:    3,
+		SourcePodNamespace:         "ns1",
+		SourcePodName:              "client-7b9f6d5c4-aaaaa",
+		DestinationPodNamespace:    "ns2",
+		DestinationPodName:         "server-0",
+		DestinationServicePortName: "ns2/server:http",
+	})
+	a.Add(&flowrecord.FlowRecord{
+		SourceIP:                   "10.0.0.2",
+		DestinationIP:              "10.96.0.10",
+		SourceTransportPort:        40001,
+		DestinationTransportPort:   80,
+		ProtocolIdentifier:         6,
+		OctetDeltaCount:            200,
+		PacketDeltaCount:           4,
+		SourcePodNamespace:         "ns1",
+		SourcePodName:              "client-7b9f6d5c4-bbbbb",
+		DestinationServicePortName: "ns2/server:http",
+	})
+	// A new report for the first connection.
+	a.Add(&flowrecord.FlowRecord{
+		SourceIP:                   "10.0.0.1",
+		DestinationIP:              "10.96.0.10",
+		SourceTransportPort:        40000,
+		DestinationTransportPort:   80,
+		ProtocolIdentifier:         6,
+		OctetDeltaCount:            50,
+		PacketDeltaCount:           1,
+		SourcePodNamespace:         "ns1",
+		SourcePodName:              "client-7b9f6d5c4-aaaaa",
+		DestinationServicePortName: "ns2/server:http",
+	})
+	// A connection to a Pod, from a Pod which is no longer in the cache.
+	a.Add(&flowrecord.FlowRecord{
+		SourceIP:                 "10.0.0.3",
+		DestinationIP:            "10.0.1.1",
+		SourceTransportPort:      40002,
+		DestinationTransportPort: 5432,
+		ProtocolIdentifier:       6,
+		OctetDeltaCount:          10,
+		PacketDeltaCount:         1,
+		SourcePodNamespace:       "ns1",
+		SourcePodName:            "deleted",
+		DestinationPodNamespace:  "ns2",
+		DestinationPodName:       "db-0",
+	})
+	// A connection to an external destination.
+	a.Add(&flowrecord.FlowRecord{
+		SourceIP:                 "10.0.0.1",
+		DestinationIP:            "8.8.8.8",
+		SourceTransportPort:      40003,
+		DestinationTransportPort: 53,
+		ProtocolIdentifier:       17,
+		OctetDeltaCount:          60,
+		PacketDeltaCount:         1,
+		SourcePodNamespace:       "ns1",
+		SourcePodName:            "client-7b9f6d5c4-aaaaa",
+	})
+
+	end := start.Add(time.Minute)
+	records := a.Flush(end)
+	deployment := Workload{Kind: WorkloadKindDeployment, Namespace: "ns1", Name: "client"}
+	expected := []Record{
+		{
+			Key:         Key{Source: deployment, Destination: Workload{Kind: WorkloadKindExternal, Name: "8.8.8.8"}},
+			WindowStart: start, WindowEnd: end,
+			OctetCount: 60, PacketCount: 1,
+			ConnectionCount: 1,
+		},
+		{
+			Key:         Key{Source: deployment, Destination: Workload{Kind: WorkloadKindService, Namespace: "ns2", Name: "server"}},
+			WindowStart: start, WindowEnd: end,
+			OctetCount: 350, PacketCount: 7, ReverseOctetCount: 1000, ReversePacketCount: 3,
+			ConnectionCount: 2,
+		},
+		{
+			Key: Key{
+				Source:      Workload{Kind: WorkloadKindPod, Namespace: "ns1", Name: "deleted"},
+				Destination: Workload{Kind: "StatefulSet", Namespace: "ns2", Name: "db"},
+			},
+			WindowStart: start, WindowEnd: end,
+			OctetCount: 10, PacketCount: 1,
+			ConnectionCount: 1,
+		},
+	}
+	assert.Equal(t, expected, records)
+
+	assert.Equal(t, []string{"1000", "1060", "Deployment", "ns1", "client", "Service", "ns2", "server", "350", "7", "1000", "3", "2"}, records[1].Fields())
+
+	// A new time window is started after each flush.
+	assert.Empty(t, a.Flush(end.Add(time.Minute)))
+}
@@ -86,15 +86,19 @@ func NewCIDRAllocator(cidr *net.IPNet, reservedIPs []net.IP) (*SingleIPAllocator
 // The start IP and end IP are inclusive.
 func NewIPRangeAllocator(startIP, endIP net.IP) (*SingleIPAllocator, error) {
 	ipRangeStr := fmt.Sprintf("%s-%s", startIP.String(), endIP.String())
+	if utilnet.IsIPv4(startIP) != utilnet.IsIPv4(endIP) {
+		return nil, fmt.Errorf("invalid IP range %s: start IP and end IP must be of the same IP family", ipRangeStr)
+	}
 	base := utilnet.BigForIP(startIP)
-	offset := big.NewInt(0).Sub(utilnet.BigForIP(endIP), base).Int64()
-	if offset < 0 {
+	// The offset of an IPv6 range may not fit in an int64, so it must be compared as a big.Int.
+	offset := big.NewInt(0).Sub(utilnet.BigForIP(endIP), base)
+	if offset.Sign() < 0 {
 		return nil, fmt.Errorf("invalid IP range %s", ipRangeStr)
 	}
-	max := offset + 1
+	max := int64(65536)
 	// In case a big range occupies too much memory, allow at most 65536 IP for each ipset.
-	if max > 65536 {
-		max = 65536
+	if offset.Cmp(big.NewInt(max-1)) < 0 {
+		max = offset.Int64() + 1
 	}
 
 	allocator := &SingleIPAllocator{
@@ -94,6 +94,60 @@ func TestAllocateNext(t *testing.T) {
 	}
 }
 
+func TestNewIPRangeAllocator(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end string
+		wantMax    int
+		wantErr    string
+	}{
+		{
+			name:    "IPv4-range",
+			start:   "1.1.1.10",
+			end:     "1.1.1.20",
+			wantMax: 11,
+		},
+		{
+			name:    "IPv6-range",
+			start:   "2021:1::10",
+			end:     "2021:1::20",
+			wantMax: 17,
+		},
+		{
+			name:    "IPv6-range-larger-than-int64",
+			start:   "2021:1::1",
+			end:     "2021:1:ffff:ffff:ffff:ffff:ffff:ffff",
+			wantMax: 65536,
+		},
+		{
+			name:    "reversed-range",
+			start:   "1.1.1.20",
+			end:     "1.1.1.10",
+			wantErr: "invalid IP range 1.1.1.20-1.1.1.10",
+		},
+		{
+			name:    "mixed-IP-families",
+			start:   "1.1.1.10",
+			end:     "2021:1::20",
+			wantErr: "invalid IP range 1.1.1.10-2021:1::20: start IP and end IP must be of the same IP family",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocator, err := NewIPRangeAllocator(net.ParseIP(tt.start), net.ParseIP(tt.end))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMax, allocator.Total())
+			ip, err := allocator.AllocateNext()
+			require.NoError(t, err)
+			assert.Equal(t, net.ParseIP(tt.start), ip)
+		})
+	}
+}
+
 func TestAllocateIP(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -119,9 +119,56 @@ func (a *IPPoolAllocator) initIPAllocators(ipPool *v1alpha2.IPPool) (ipallocator
 		}
 	}
 
+	// Mark IPs reserved in pool spec as unavailable too, so that they are only allocated to the Pods they are
+	// reserved for.
+	for _, reservation := range ipPool.Spec.Reservations {
+		ip := net.ParseIP(reservation.IPAddress)
+		if ip == nil || !allocators.Has(ip) || isIPAllocated(ipPool, ip) {
+			continue
+		}
+		if err := allocators.AllocateIP(ip); err != nil {
+			return allocators, fmt.Errorf("failed to reserve IP %s in IP Pool %s: %v", ip, ipPool.Name, err)
+		}
+	}
+
 	return allocators, nil
 }
 
+// isIPAllocated returns whether the IP is in the IPAddresses list of the IPPool's status.
+func isIPAllocated(ipPool *v1alpha2.IPPool, ip net.IP) bool {
+	ipString := ip.String()
+	for _, ipAddress := range ipPool.Status.IPAddresses {
+		if ipAddress.IPAddress == ipString {
+			return true
+		}
+	}
+	return false
+}
+
+// getPodReservedIP returns the IP reserved for the Pod network interface in the IPPool's spec. IPs can only be
+// reserved for the primary network interfaces of Pods.
+func getPodReservedIP(ipPool *v1alpha2.IPPool, podOwner *v1alpha2.PodOwner) net.IP {
+	if podOwner == nil || podOwner.IFName != "" {
+		return nil
+	}
+	for _, reservation := range ipPool.Spec.Reservations {
+		if reservation.Namespace == podOwner.Namespace && reservation.PodName == podOwner.Name {
+			return net.ParseIP(reservation.IPAddress)
+		}
+	}
+	return nil
+}
+
+// isIPReserved returns whether the IP is reserved in the IPPool's spec.
+func isIPReserved(ipPool *v1alpha2.IPPool, ip net.IP) bool {
+	for _, reservation := range ipPool.Spec.Reservations {
+		if ip.Equal(net.ParseIP(reservation.IPAddress)) {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *IPPoolAllocator) getPoolAndInitIPAllocators() (*v1alpha2.IPPool, ipallocator.MultiIPAllocator, error) {
 	ipPool, err := a.getPool()
 
@@ -226,7 +273,8 @@ func (a *IPPoolAllocator) removeIPAddressState(ipPool *v1alpha2.IPPool, ip net.I
 			newList = append(newList, entry)
 		} else {
 			allocated = true
-			if entry.Owner.StatefulSet != nil {
+			// The entry of an IP reserved in the pool spec is removed, as the IP is kept for the Pod by the spec.
+			if entry.Owner.StatefulSet != nil && !isIPReserved(ipPool, ip) {
 				entry = *entry.DeepCopy()
 				entry.Owner.Pod = nil
 				entry.Phase = v1alpha2.IPAddressPhaseReserved
@@ -294,12 +342,16 @@ func (a *IPPoolAllocator) AllocateIP(ip net.IP, state v1alpha2.IPAddressPhase, o
 			return err
 		}
 
+		// The IP reserved for the Pod has been marked as unavailable already if it is not allocated yet.
+		reserved := ip.Equal(getPodReservedIP(ipPool, owner.Pod)) && !isIPAllocated(ipPool, ip)
 		index := len(allocators)
 		for i, allocator := range allocators {
 			if allocator.Has(ip) {
-				err := allocator.AllocateIP(ip)
-				if err != nil {
-					return err
+				if !reserved {
+					err := allocator.AllocateIP(ip)
+					if err != nil {
+						return err
+					}
 				}
 				index = i
 				break
@@ -323,6 +375,51 @@ func (a *IPPoolAllocator) AllocateIP(ip net.IP, state v1alpha2.IPAddressPhase, o
 	return subnetSpec, err
 }
 
+// allocatePodReservedIP allocates the IP reserved for the Pod in the pool spec. If the IP is still allocated to a
+// previous container of the Pod, e.g. when the Pod was rescheduled to another Node before the IP was released, the
+// allocation is taken over by the new container.
+func (a *IPPoolAllocator) allocatePodReservedIP(ip net.IP, state v1alpha2.IPAddressPhase, owner v1alpha2.IPAddressOwner) (*v1alpha2.SubnetInfo, error) {
+	var subnetSpec *v1alpha2.SubnetInfo
+	// Retry on CRD update conflict which is caused by multiple agents updating a pool at same time.
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		ipPool, allocators, err := a.getPoolAndInitIPAllocators()
+		if err != nil {
+			return err
+		}
+
+		index := -1
+		for i, allocator := range allocators {
+			if allocator.Has(ip) {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			// Failed to find matching range
+			return fmt.Errorf("IP %v does not belong to IPPool %s", ip, a.ipPoolName)
+		}
+
+		subnetSpec = &ipPool.Spec.IPRanges[index].SubnetInfo
+		ipString := ip.String()
+		for _, ipAddress := range ipPool.Status.IPAddresses {
+			if ipAddress.IPAddress != ipString {
+				continue
+			}
+			prevPod := ipAddress.Owner.Pod
+			if prevPod != nil && (prevPod.Namespace != owner.Pod.Namespace || prevPod.Name != owner.Pod.Name) {
+				return fmt.Errorf("IP %v reserved for Pod %s/%s is allocated to Pod %s/%s", ip, owner.Pod.Namespace, owner.Pod.Name, prevPod.Namespace, prevPod.Name)
+			}
+			return a.updateIPAddressState(ipPool, ip, state, owner)
+		}
+		return a.appendPoolUsage(ipPool, ip, state, owner)
+	})
+
+	if err != nil {
+		klog.ErrorS(err, "Failed to allocate reserved IP address", "ip", ip, "IPPool", a.ipPoolName)
+	}
+	return subnetSpec, err
+}
+
 // AllocateNext allocates the next available IP. It returns error if pool is exausted,
 // or in case CRD failed to update its state.
 // In case of success, IPPool CRD status is updated with allocated IP/state/resource/container.
@@ -343,6 +440,16 @@ func (a *IPPoolAllocator) AllocateNext(state v1alpha2.IPAddressPhase, owner v1al
 		return ip, subnetSpec, err
 	}
 
+	// The IP reserved for the Pod in the pool spec is always allocated to the Pod.
+	ip, err = a.getPodReservedIP(podOwner)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ip != nil {
+		subnetSpec, err = a.allocatePodReservedIP(ip, state, owner)
+		return ip, subnetSpec, err
+	}
+
 	// Retry on CRD update conflict which is caused by multiple agents updating a pool at same time.
 	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		ipPool, allocators, err := a.getPoolAndInitIPAllocators()
@@ -380,7 +487,17 @@ func (a *IPPoolAllocator) AllocateNext(state v1alpha2.IPAddressPhase, owner v1al
 // success, IP pool status is updated with allocated IP/state/resource/container.
 // AllocateReservedOrNext returns subnet details for the requested IP, as defined in IP pool spec.
 func (a *IPPoolAllocator) AllocateReservedOrNext(state v1alpha2.IPAddressPhase, owner v1alpha2.IPAddressOwner) (net.IP, *v1alpha2.SubnetInfo, error) {
-	ip, err := a.getReservedIP(owner)
+	// The IP reserved for the Pod in the pool spec takes precedence over the IP reserved for the StatefulSet
+	// ordinal in the pool status.
+	ip, err := a.getPodReservedIP(owner.Pod)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ip != nil {
+		return a.AllocateNext(state, owner)
+	}
+
+	ip, err = a.getReservedIP(owner)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -617,6 +734,16 @@ func (a *IPPoolAllocator) getReservedIP(reservedOwner v1alpha2.IPAddressOwner) (
 	return nil, nil
 }
 
+// getPodReservedIP returns the IP reserved for the Pod network interface in the pool spec. It returns error if the
+// resource crd fails to be retrieved.
+func (a *IPPoolAllocator) getPodReservedIP(podOwner *v1alpha2.PodOwner) (net.IP, error) {
+	ipPool, err := a.getPool()
+	if err != nil {
+		return nil, err
+	}
+	return getPodReservedIP(ipPool, podOwner), nil
+}
+
 func (a IPPoolAllocator) Total() int {
 	_, allocators, err := a.getPoolAndInitIPAllocators()
 	if err != nil {
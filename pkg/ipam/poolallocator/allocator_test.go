@@ -434,3 +434,83 @@ func TestAllocateReleaseStatefulSet(t *testing.T) {
 	// Make sure reserved IPs are released
 	validateAllocationSequence(t, allocator, subnetInfo, []string{"10.2.2.100"})
 }
+
+func TestAllocateReservedIPForPod(t *testing.T) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	poolName := uuid.New().String()
+	ipRange := crdv1a2.IPRange{
+		Start: "10.2.2.100",
+		End:   "10.2.2.120",
+	}
+	subnetInfo := crdv1a2.SubnetInfo{
+		Gateway:      "10.2.2.1",
+		PrefixLength: 24,
+	}
+	subnetRange := crdv1a2.SubnetIPRange{IPRange: ipRange,
+		SubnetInfo: subnetInfo}
+
+	pool := crdv1a2.IPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: poolName},
+		Spec: crdv1a2.IPPoolSpec{
+			IPRanges: []crdv1a2.SubnetIPRange{subnetRange},
+			Reservations: []crdv1a2.IPReservation{
+				{IPAddress: "10.2.2.100", Namespace: testNamespace, PodName: "reservedPod"},
+				{IPAddress: "10.2.2.105", Namespace: testNamespace, PodName: "fakeSet-0"},
+			},
+		},
+	}
+
+	allocator := newTestIPPoolAllocator(&pool, stopCh)
+	require.NotNil(t, allocator)
+
+	// Make sure reserved IPs are not allocated to other Pods
+	validateAllocationSequence(t, allocator, subnetInfo, []string{"10.2.2.101", "10.2.2.102"})
+	_, err := allocator.AllocateIP(net.ParseIP("10.2.2.105"), crdv1a2.IPAddressPhaseAllocated, fakePodOwner)
+	require.Error(t, err)
+
+	// The reserved IP is allocated to the Pod, and taken over by the new container after the Pod is rescheduled
+	for i := 0; i < 2; i++ {
+		owner := crdv1a2.IPAddressOwner{
+			Pod: &crdv1a2.PodOwner{
+				Name:        "reservedPod",
+				Namespace:   testNamespace,
+				ContainerID: uuid.New().String(),
+			},
+		}
+		ip, returnInfo, err := allocator.AllocateNext(crdv1a2.IPAddressPhaseAllocated, owner)
+		require.NoError(t, err)
+		assert.Equal(t, net.ParseIP("10.2.2.100"), ip)
+		assert.Equal(t, subnetInfo, *returnInfo)
+	}
+
+	// The IP reserved for the StatefulSet Pod takes precedence over the next available IP
+	owner := crdv1a2.IPAddressOwner{
+		Pod: &crdv1a2.PodOwner{
+			Name:        "fakeSet-0",
+			Namespace:   testNamespace,
+			ContainerID: "fakeSetContainer",
+		},
+		StatefulSet: &crdv1a2.StatefulSetOwner{
+			Name:      "fakeSet",
+			Namespace: testNamespace,
+			Index:     0,
+		},
+	}
+	ip, _, err := allocator.AllocateReservedOrNext(crdv1a2.IPAddressPhaseAllocated, owner)
+	require.NoError(t, err)
+	assert.Equal(t, net.ParseIP("10.2.2.105"), ip)
+
+	// The released IP is removed from the pool status, but is still reserved for the Pod
+	require.Eventually(t, func() bool {
+		ip, err := allocator.GetContainerIP("fakeSetContainer", "")
+		return err == nil && ip != nil
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, allocator.ReleaseContainer("fakeSetContainer", ""))
+	require.Eventually(t, func() bool {
+		ipPool, err := allocator.getPool()
+		return err == nil && !isIPAllocated(ipPool, net.ParseIP("10.2.2.105"))
+	}, time.Second, 10*time.Millisecond)
+	validateAllocationSequence(t, allocator, subnetInfo, []string{"10.2.2.103", "10.2.2.104", "10.2.2.106"})
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	crdlisters "antrea.io/antrea/pkg/client/listers/crd/v1alpha2"
+)
+
+// SelectIPPoolsForPod returns the IPPools among poolNames, which are specified with the IPPool
+// annotation of a Namespace, whose PodSelector selects a Pod with the provided labels. The order
+// of poolNames is preserved. IPPools without PodSelector select all Pods, and IPPools which are
+// not found are kept so that the caller can report them.
+func SelectIPPoolsForPod(poolNames []string, podLabels map[string]string, ipPoolLister crdlisters.IPPoolLister) ([]string, error) {
+	var selected []string
+	for _, poolName := range poolNames {
+		ipPool, err := ipPoolLister.Get(poolName)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				selected = append(selected, poolName)
+				continue
+			}
+			return nil, fmt.Errorf("failed to get IPPool %s: %w", poolName, err)
+		}
+		if ipPool.Spec.PodSelector == nil {
+			selected = append(selected, poolName)
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(ipPool.Spec.PodSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid podSelector of IPPool %s: %w", poolName, err)
+		}
+		if selector.Matches(labels.Set(podLabels)) {
+			selected = append(selected, poolName)
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	crdv1a2 "antrea.io/antrea/pkg/apis/crd/v1alpha2"
+	crdlisters "antrea.io/antrea/pkg/client/listers/crd/v1alpha2"
+)
+
+func TestSelectIPPoolsForPod(t *testing.T) {
+	newIPPool := func(name string, podSelector *metav1.LabelSelector) *crdv1a2.IPPool {
+		return &crdv1a2.IPPool{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: crdv1a2.IPPoolSpec{
+				IPVersion:   crdv1a2.IPv4,
+				PodSelector: podSelector,
+			},
+		}
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ipPool := range []*crdv1a2.IPPool{
+		newIPPool("frontend", &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "frontend"}}),
+		newIPPool("backend", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"backend", "db"}},
+		}}),
+		newIPPool("default", nil),
+		newIPPool("invalid", &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "tier", Operator: "Foo"},
+		}}),
+	} {
+		require.NoError(t, indexer.Add(ipPool))
+	}
+	ipPoolLister := crdlisters.NewIPPoolLister(indexer)
+
+	tests := []struct {
+		name          string
+		poolNames     []string
+		podLabels     map[string]string
+		expectedPools []string
+		expectedErr   string
+	}{
+		{
+			name:          "select by matchLabels",
+			poolNames:     []string{"frontend", "backend", "default"},
+			podLabels:     map[string]string{"tier": "frontend"},
+			expectedPools: []string{"frontend", "default"},
+		},
+		{
+			name:          "select by matchExpressions",
+			poolNames:     []string{"frontend", "backend", "default"},
+			podLabels:     map[string]string{"tier": "db"},
+			expectedPools: []string{"backend", "default"},
+		},
+		{
+			name:          "no label",
+			poolNames:     []string{"frontend", "backend", "default"},
+			expectedPools: []string{"default"},
+		},
+		{
+			name:      "no IPPool selected",
+			poolNames: []string{"frontend", "backend"},
+			podLabels: map[string]string{"tier": "cache"},
+		},
+		{
+			name:          "IPPool not found",
+			poolNames:     []string{"frontend", "missing"},
+			podLabels:     map[string]string{"tier": "frontend"},
+			expectedPools: []string{"frontend", "missing"},
+		},
+		{
+			name:        "invalid podSelector",
+			poolNames:   []string{"invalid", "default"},
+			expectedErr: "invalid podSelector of IPPool invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pools, err := SelectIPPoolsForPod(tt.poolNames, tt.podLabels, ipPoolLister)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedPools, pools)
+			}
+		})
+	}
+}
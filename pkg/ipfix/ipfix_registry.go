@@ -17,10 +17,18 @@ package ipfix
 import (
 	ipfixentities "github.com/vmware/go-ipfix/pkg/entities"
 	ipfixregistry "github.com/vmware/go-ipfix/pkg/registry"
+	"k8s.io/klog/v2"
 )
 
 var _ IPFIXRegistry = new(ipfixRegistry)
 
+// antreaInfoElements are the Antrea information elements which are not defined in the registry of go-ipfix yet.
+var antreaInfoElements = []*ipfixentities.InfoElement{
+	ipfixentities.NewInfoElement("tcpStateFlags", 155, ipfixentities.Unsigned8, ipfixregistry.AntreaEnterpriseID, 1),
+	ipfixentities.NewInfoElement("tcpSynRetransmissionCount", 156, ipfixentities.Unsigned32, ipfixregistry.AntreaEnterpriseID, 4),
+	ipfixentities.NewInfoElement("tcpSmoothedRttMicroseconds", 157, ipfixentities.Unsigned32, ipfixregistry.AntreaEnterpriseID, 4),
+}
+
 // IPFIXRegistry interface is added to facilitate unit testing without involving the code from go-ipfix library.
 type IPFIXRegistry interface {
 	LoadRegistry()
@@ -35,6 +43,11 @@ func NewIPFIXRegistry() *ipfixRegistry {
 
 func (reg *ipfixRegistry) LoadRegistry() {
 	ipfixregistry.LoadRegistry()
+	for _, ie := range antreaInfoElements {
+		if err := ipfixregistry.PutInfoElement(*ie, ipfixregistry.AntreaEnterpriseID); err != nil {
+			klog.ErrorS(err, "Failed to add information element to the registry", "name", ie.Name)
+		}
+	}
 }
 
 func (reg *ipfixRegistry) GetInfoElement(name string, enterpriseID uint32) (*ipfixentities.InfoElement, error) {
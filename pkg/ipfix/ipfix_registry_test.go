@@ -42,6 +42,13 @@ func TestGetInfoElement(t *testing.T) {
 			expectedElementID: 100,
 			expectedError:     "",
 		},
+		{
+			testname:          "Information element added to the registry by Antrea",
+			name:              "tcpSmoothedRttMicroseconds",
+			enterpriseID:      56506,
+			expectedElementID: 157,
+			expectedError:     "",
+		},
 		{
 			testname:      "Information element with given name does not exist in registry",
 			name:          "sourcePod",
@@ -0,0 +1,137 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package legacyflow exports flow records in the formats which predate IPFIX, for the collectors which don't support
+// IPFIX: NetFlow v9 (RFC 3954) and sFlow v5. Only the fields which can be represented in these formats are exported,
+// the other Antrea-specific fields (e.g. the Pod names) are omitted.
+package legacyflow
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatNetFlowV9 Format = "NetFlowV9"
+	FormatSFlow     Format = "sFlow"
+)
+
+// IsValidFormat returns whether the format is one of the formats supported by this package.
+func IsValidFormat(format string) bool {
+	return format == string(FormatNetFlowV9) || format == string(FormatSFlow)
+}
+
+// maxDatagramSize is the maximum size of the datagrams sent to the collector, which should fit in the MTU of most
+// networks to avoid IP fragmentation.
+const maxDatagramSize = 1400
+
+// Record is a flow record, with the fields which can be represented in all the supported formats.
+type Record struct {
+	StartTime time.Time
+	EndTime   time.Time
+	// EndReason is the IPFIX flowEndReason of the record.
+	EndReason          uint8
+	SourceAddress      net.IP
+	DestinationAddress net.IP
+	SourcePort         uint16
+	DestinationPort    uint16
+	Protocol           uint8
+	// Packets and Octets are the counters of the original direction since the last export of the flow.
+	Packets uint64
+	Octets  uint64
+	// ReversePackets and ReverseOctets are the counters of the reply direction since the last export of the flow.
+	ReversePackets uint64
+	ReverseOctets  uint64
+	// ServiceAddress and ServicePort are the ClusterIP and port of the Service, if the flow is a Service flow. The
+	// destination of the flow is then the Endpoint selected after DNAT.
+	ServiceAddress net.IP
+	ServicePort    uint16
+	// Dropped indicates that the flow is denied by a NetworkPolicy.
+	Dropped bool
+}
+
+func (r *Record) isIPv6() bool {
+	return r.SourceAddress.To4() == nil
+}
+
+type encoder interface {
+	// encode encodes the records in one or more datagrams.
+	encode(records []Record, now time.Time) [][]byte
+}
+
+// Exporter sends flow records to a collector over UDP. It's not thread-safe.
+type Exporter struct {
+	format  Format
+	conn    net.Conn
+	encoder encoder
+}
+
+// NewExporter creates an Exporter sending the records in the provided format to the collector address. The
+// observationDomainID identifies the exporter, it is used as the Source ID for NetFlow v9 and as the sub-agent ID for
+// sFlow.
+func NewExporter(format Format, collectorAddr string, observationDomainID uint32) (*Exporter, error) {
+	conn, err := net.Dial("udp", collectorAddr)
+	if err != nil {
+		return nil, fmt.Errorf("error when connecting to collector %s: %w", collectorAddr, err)
+	}
+	now := time.Now()
+	var enc encoder
+	switch format {
+	case FormatNetFlowV9:
+		enc = newNetFlowV9Encoder(observationDomainID, now)
+	case FormatSFlow:
+		enc = newSFlowEncoder(conn.LocalAddr().(*net.UDPAddr).IP, observationDomainID, now)
+	default:
+		conn.Close()
+		return nil, fmt.Errorf("unsupported flow record format %s", format)
+	}
+	return &Exporter{
+		format:  format,
+		conn:    conn,
+		encoder: enc,
+	}, nil
+}
+
+func (e *Exporter) Format() Format {
+	return e.format
+}
+
+// Export sends the records to the collector and returns the number of bytes sent.
+func (e *Exporter) Export(records ...Record) (int, error) {
+	sentBytes := 0
+	for _, datagram := range e.encoder.encode(records, time.Now()) {
+		n, err := e.conn.Write(datagram)
+		sentBytes += n
+		if err != nil {
+			return sentBytes, fmt.Errorf("error when sending %s datagram: %w", e.format, err)
+		}
+	}
+	return sentBytes, nil
+}
+
+func (e *Exporter) Close() error {
+	return e.conn.Close()
+}
+
+// uptimeMilliseconds returns the milliseconds elapsed from start to t, which is used as the system uptime by both
+// formats. It wraps around after 49.7 days as expected by the collectors, and is 0 for a time before start.
+func uptimeMilliseconds(start, t time.Time) uint32 {
+	if t.Before(start) {
+		return 0
+	}
+	return uint32(t.Sub(start).Milliseconds())
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package legacyflow
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExporter(t *testing.T) {
+	for _, tc := range []struct {
+		format          Format
+		expectedVersion func(datagram []byte) uint32
+		expectedValue   uint32
+	}{
+		{
+			format:          FormatNetFlowV9,
+			expectedVersion: func(datagram []byte) uint32 { return uint32(binary.BigEndian.Uint16(datagram)) },
+			expectedValue:   netFlowV9Version,
+		},
+		{
+			format:          FormatSFlow,
+			expectedVersion: func(datagram []byte) uint32 { return binary.BigEndian.Uint32(datagram) },
+			expectedValue:   sFlowVersion,
+		},
+	} {
+		t.Run(string(tc.format), func(t *testing.T) {
+			collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer collector.Close()
+
+			exporter, err := NewExporter(tc.format, collector.LocalAddr().String(), 12345)
+			require.NoError(t, err)
+			defer exporter.Close()
+			assert.Equal(t, tc.format, exporter.Format())
+
+			sentBytes, err := exporter.Export(testRecordIPv4)
+			require.NoError(t, err)
+			buf := make([]byte, 65535)
+			require.NoError(t, collector.SetReadDeadline(time.Now().Add(5*time.Second)))
+			n, _, err := collector.ReadFrom(buf)
+			require.NoError(t, err)
+			assert.Equal(t, sentBytes, n)
+			assert.Equal(t, tc.expectedValue, tc.expectedVersion(buf[:n]))
+		})
+	}
+
+	_, err := NewExporter("IPFIX", "127.0.0.1:4739", 12345)
+	assert.EqualError(t, err, "unsupported flow record format IPFIX")
+}
@@ -0,0 +1,254 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package legacyflow
+
+import (
+	"encoding/binary"
+	"net"
+	"time"
+)
+
+const (
+	netFlowV9Version         = 9
+	netFlowV9HeaderLen       = 20
+	netFlowV9FlowSetLen      = 4
+	netFlowV9TemplateFlowSet = 0
+	netFlowV9TemplateIDv4    = 256
+	netFlowV9TemplateIDv6    = 257
+	// The templates are sent periodically, as the collectors may restart and UDP is not reliable. This is the same
+	// as the template refresh timeout of the IPFIX exporters over UDP.
+	netFlowV9TemplateRefreshInterval = 30 * time.Minute
+
+	// Values of the FORWARDING_STATUS field, defined in RFC 7270.
+	netFlowV9ForwardingStatusForwarded  = 64
+	netFlowV9ForwardingStatusDroppedACL = 130
+)
+
+// Field types defined in RFC 3954. The field types greater than 127 are the IPFIX Information Elements which are
+// commonly supported by NetFlow v9 collectors.
+const (
+	netFlowV9InBytes                  = 1
+	netFlowV9InPkts                   = 2
+	netFlowV9Protocol                 = 4
+	netFlowV9L4SrcPort                = 7
+	netFlowV9IPv4SrcAddr              = 8
+	netFlowV9L4DstPort                = 11
+	netFlowV9IPv4DstAddr              = 12
+	netFlowV9LastSwitched             = 21
+	netFlowV9FirstSwitched            = 22
+	netFlowV9OutBytes                 = 23
+	netFlowV9OutPkts                  = 24
+	netFlowV9IPv6SrcAddr              = 27
+	netFlowV9IPv6DstAddr              = 28
+	netFlowV9ForwardingStatus         = 89
+	netFlowV9FlowEndReason            = 136
+	netFlowV9PostNATDstIPv4Addr       = 226
+	netFlowV9PostNAPTDstTransportPort = 228
+	netFlowV9PostNATDstIPv6Addr       = 282
+)
+
+type netFlowV9Field struct {
+	fieldType uint16
+	length    uint16
+}
+
+// The fields of the templates, in the order they are encoded by appendRecord. For Service flows, the destination is
+// the Service ClusterIP and port, and the Endpoint is exported as the post-NAT destination, in the same way as the
+// firewalls exporting NAT events with NetFlow v9.
+var (
+	netFlowV9CommonFields = []netFlowV9Field{
+		{netFlowV9InBytes, 8},
+		{netFlowV9InPkts, 8},
+		{netFlowV9OutBytes, 8},
+		{netFlowV9OutPkts, 8},
+		{netFlowV9Protocol, 1},
+		{netFlowV9FirstSwitched, 4},
+		{netFlowV9LastSwitched, 4},
+		{netFlowV9FlowEndReason, 1},
+		{netFlowV9ForwardingStatus, 1},
+		{netFlowV9L4SrcPort, 2},
+		{netFlowV9L4DstPort, 2},
+		{netFlowV9PostNAPTDstTransportPort, 2},
+	}
+	netFlowV9FieldsIPv4 = append(netFlowV9CommonFields, []netFlowV9Field{
+		{netFlowV9IPv4SrcAddr, 4},
+		{netFlowV9IPv4DstAddr, 4},
+		{netFlowV9PostNATDstIPv4Addr, 4},
+	}...)
+	netFlowV9FieldsIPv6 = append(netFlowV9CommonFields, []netFlowV9Field{
+		{netFlowV9IPv6SrcAddr, 16},
+		{netFlowV9IPv6DstAddr, 16},
+		{netFlowV9PostNATDstIPv6Addr, 16},
+	}...)
+	netFlowV9RecordLenIPv4 = recordLength(netFlowV9FieldsIPv4)
+	netFlowV9RecordLenIPv6 = recordLength(netFlowV9FieldsIPv6)
+)
+
+func recordLength(fields []netFlowV9Field) int {
+	length := 0
+	for _, field := range fields {
+		length += int(field.length)
+	}
+	return length
+}
+
+type netFlowV9Encoder struct {
+	sourceID  uint32
+	startTime time.Time
+	sequence  uint32
+	// lastTemplateTime is the time when the templates were sent last time, zero if they have never been sent.
+	lastTemplateTime time.Time
+}
+
+func newNetFlowV9Encoder(sourceID uint32, startTime time.Time) *netFlowV9Encoder {
+	return &netFlowV9Encoder{
+		sourceID:  sourceID,
+		startTime: startTime,
+	}
+}
+
+func (e *netFlowV9Encoder) encode(records []Record, now time.Time) [][]byte {
+	if len(records) == 0 {
+		return nil
+	}
+	var datagrams [][]byte
+	var buf []byte
+	var count uint16
+	// The start offset and the template ID of the current data FlowSet, -1 if there is no data FlowSet.
+	flowSetStart := -1
+	var flowSetID uint16
+
+	startDatagram := func() {
+		buf = make([]byte, netFlowV9HeaderLen, maxDatagramSize)
+		count = 0
+		if e.lastTemplateTime.IsZero() || now.Sub(e.lastTemplateTime) >= netFlowV9TemplateRefreshInterval {
+			buf = appendNetFlowV9Templates(buf)
+			count += 2
+			e.lastTemplateTime = now
+		}
+	}
+	finishFlowSet := func() {
+		if flowSetStart < 0 {
+			return
+		}
+		// Each FlowSet is padded to a 32-bit boundary.
+		for (len(buf)-flowSetStart)%4 != 0 {
+			buf = append(buf, 0)
+		}
+		binary.BigEndian.PutUint16(buf[flowSetStart:], flowSetID)
+		binary.BigEndian.PutUint16(buf[flowSetStart+2:], uint16(len(buf)-flowSetStart))
+		flowSetStart = -1
+	}
+	finishDatagram := func() {
+		finishFlowSet()
+		binary.BigEndian.PutUint16(buf[0:], netFlowV9Version)
+		binary.BigEndian.PutUint16(buf[2:], count)
+		binary.BigEndian.PutUint32(buf[4:], uptimeMilliseconds(e.startTime, now))
+		binary.BigEndian.PutUint32(buf[8:], uint32(now.Unix()))
+		binary.BigEndian.PutUint32(buf[12:], e.sequence)
+		binary.BigEndian.PutUint32(buf[16:], e.sourceID)
+		e.sequence++
+		datagrams = append(datagrams, buf)
+	}
+
+	startDatagram()
+	for i := range records {
+		r := &records[i]
+		templateID, length := uint16(netFlowV9TemplateIDv4), netFlowV9RecordLenIPv4
+		if r.isIPv6() {
+			templateID, length = netFlowV9TemplateIDv6, netFlowV9RecordLenIPv6
+		}
+		// Reserve the space for a new FlowSet header and the padding.
+		if count > 0 && len(buf)+netFlowV9FlowSetLen+length+3 > maxDatagramSize {
+			finishDatagram()
+			startDatagram()
+		}
+		if flowSetStart < 0 || flowSetID != templateID {
+			finishFlowSet()
+			flowSetStart = len(buf)
+			flowSetID = templateID
+			buf = append(buf, make([]byte, netFlowV9FlowSetLen)...)
+		}
+		buf = e.appendRecord(buf, r)
+		count++
+	}
+	finishDatagram()
+	return datagrams
+}
+
+func appendNetFlowV9Templates(buf []byte) []byte {
+	start := len(buf)
+	buf = binary.BigEndian.AppendUint16(buf, netFlowV9TemplateFlowSet)
+	// The length is set after the templates are added.
+	buf = binary.BigEndian.AppendUint16(buf, 0)
+	for _, template := range []struct {
+		id     uint16
+		fields []netFlowV9Field
+	}{
+		{netFlowV9TemplateIDv4, netFlowV9FieldsIPv4},
+		{netFlowV9TemplateIDv6, netFlowV9FieldsIPv6},
+	} {
+		buf = binary.BigEndian.AppendUint16(buf, template.id)
+		buf = binary.BigEndian.AppendUint16(buf, uint16(len(template.fields)))
+		for _, field := range template.fields {
+			buf = binary.BigEndian.AppendUint16(buf, field.fieldType)
+			buf = binary.BigEndian.AppendUint16(buf, field.length)
+		}
+	}
+	binary.BigEndian.PutUint16(buf[start+2:], uint16(len(buf)-start))
+	return buf
+}
+
+func (e *netFlowV9Encoder) appendRecord(buf []byte, r *Record) []byte {
+	dstAddress, dstPort := r.DestinationAddress, r.DestinationPort
+	if r.ServiceAddress != nil {
+		dstAddress, dstPort = r.ServiceAddress, r.ServicePort
+	}
+	forwardingStatus := uint8(netFlowV9ForwardingStatusForwarded)
+	if r.Dropped {
+		forwardingStatus = netFlowV9ForwardingStatusDroppedACL
+	}
+	buf = binary.BigEndian.AppendUint64(buf, r.Octets)
+	buf = binary.BigEndian.AppendUint64(buf, r.Packets)
+	buf = binary.BigEndian.AppendUint64(buf, r.ReverseOctets)
+	buf = binary.BigEndian.AppendUint64(buf, r.ReversePackets)
+	buf = append(buf, r.Protocol)
+	buf = binary.BigEndian.AppendUint32(buf, uptimeMilliseconds(e.startTime, r.StartTime))
+	buf = binary.BigEndian.AppendUint32(buf, uptimeMilliseconds(e.startTime, r.EndTime))
+	buf = append(buf, r.EndReason, forwardingStatus)
+	buf = binary.BigEndian.AppendUint16(buf, r.SourcePort)
+	buf = binary.BigEndian.AppendUint16(buf, dstPort)
+	buf = binary.BigEndian.AppendUint16(buf, r.DestinationPort)
+	isIPv6 := r.isIPv6()
+	buf = appendIP(buf, r.SourceAddress, isIPv6)
+	buf = appendIP(buf, dstAddress, isIPv6)
+	buf = appendIP(buf, r.DestinationAddress, isIPv6)
+	return buf
+}
+
+// appendIP appends the IP with the length of the IP family. An IP which doesn't belong to the IP family is encoded as
+// the unspecified address.
+func appendIP(buf []byte, ip net.IP, isIPv6 bool) []byte {
+	if isIPv6 {
+		if ip.To4() == nil && ip.To16() != nil {
+			return append(buf, ip.To16()...)
+		}
+		return append(buf, net.IPv6zero...)
+	}
+	if ip.To4() != nil {
+		return append(buf, ip.To4()...)
+	}
+	return append(buf, net.IPv4zero.To4()...)
+}
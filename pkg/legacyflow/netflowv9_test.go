@@ -0,0 +1,187 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package legacyflow
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testStartTime = time.Unix(1690000000, 0)
+
+	testRecordIPv4 = Record{
+		StartTime:          testStartTime.Add(time.Second),
+		EndTime:            testStartTime.Add(3 * time.Second),
+		EndReason:          1,
+		SourceAddress:      net.ParseIP("10.10.0.1"),
+		DestinationAddress: net.ParseIP("10.10.1.2"),
+		SourcePort:         32768,
+		DestinationPort:    8080,
+		Protocol:           6,
+		Packets:            10,
+		Octets:             1000,
+		ReversePackets:     5,
+		ReverseOctets:      5000,
+		ServiceAddress:     net.ParseIP("10.96.0.10"),
+		ServicePort:        80,
+	}
+	testRecordIPv6 = Record{
+		StartTime:          testStartTime.Add(time.Second),
+		EndTime:            testStartTime.Add(2 * time.Second),
+		EndReason:          2,
+		SourceAddress:      net.ParseIP("fd00:10:10::1"),
+		DestinationAddress: net.ParseIP("fd00:10:10:1::2"),
+		SourcePort:         32768,
+		DestinationPort:    53,
+		Protocol:           17,
+		Packets:            1,
+		Octets:             100,
+		Dropped:            true,
+	}
+)
+
+type netFlowV9FlowSet struct {
+	id   uint16
+	data []byte
+}
+
+func parseNetFlowV9Datagram(t *testing.T, datagram []byte) (count uint16, sequence uint32, flowSets []netFlowV9FlowSet) {
+	require.GreaterOrEqual(t, len(datagram), netFlowV9HeaderLen)
+	assert.Equal(t, uint16(netFlowV9Version), binary.BigEndian.Uint16(datagram[0:]))
+	assert.Equal(t, uint32(12345), binary.BigEndian.Uint32(datagram[16:]))
+	count = binary.BigEndian.Uint16(datagram[2:])
+	sequence = binary.BigEndian.Uint32(datagram[12:])
+	for data := datagram[netFlowV9HeaderLen:]; len(data) > 0; {
+		require.GreaterOrEqual(t, len(data), netFlowV9FlowSetLen)
+		length := int(binary.BigEndian.Uint16(data[2:]))
+		require.Zero(t, length%4, "FlowSet must be padded to a 32-bit boundary")
+		require.LessOrEqual(t, length, len(data))
+		flowSets = append(flowSets, netFlowV9FlowSet{
+			id:   binary.BigEndian.Uint16(data[0:]),
+			data: data[netFlowV9FlowSetLen:length],
+		})
+		data = data[length:]
+	}
+	return
+}
+
+func TestNetFlowV9Encode(t *testing.T) {
+	encoder := newNetFlowV9Encoder(12345, testStartTime)
+	now := testStartTime.Add(5 * time.Second)
+
+	datagrams := encoder.encode([]Record{testRecordIPv4, testRecordIPv6}, now)
+	require.Len(t, datagrams, 1)
+	assert.Equal(t, uint32(5000), binary.BigEndian.Uint32(datagrams[0][4:]))
+	assert.Equal(t, uint32(now.Unix()), binary.BigEndian.Uint32(datagrams[0][8:]))
+	count, sequence, flowSets := parseNetFlowV9Datagram(t, datagrams[0])
+	assert.Equal(t, uint16(4), count)
+	assert.Equal(t, uint32(0), sequence)
+	require.Len(t, flowSets, 3)
+
+	templates := flowSets[0]
+	assert.Equal(t, uint16(netFlowV9TemplateFlowSet), templates.id)
+	assert.Equal(t, uint16(netFlowV9TemplateIDv4), binary.BigEndian.Uint16(templates.data[0:]))
+	assert.Equal(t, uint16(len(netFlowV9FieldsIPv4)), binary.BigEndian.Uint16(templates.data[2:]))
+	// The first field of the IPv4 template.
+	assert.Equal(t, uint16(netFlowV9InBytes), binary.BigEndian.Uint16(templates.data[4:]))
+	assert.Equal(t, uint16(8), binary.BigEndian.Uint16(templates.data[6:]))
+	templateIPv6 := templates.data[4+4*len(netFlowV9FieldsIPv4):]
+	assert.Equal(t, uint16(netFlowV9TemplateIDv6), binary.BigEndian.Uint16(templateIPv6[0:]))
+	assert.Equal(t, uint16(len(netFlowV9FieldsIPv6)), binary.BigEndian.Uint16(templateIPv6[2:]))
+
+	dataIPv4 := flowSets[1]
+	assert.Equal(t, uint16(netFlowV9TemplateIDv4), dataIPv4.id)
+	assert.Equal(t, 61, netFlowV9RecordLenIPv4)
+	require.GreaterOrEqual(t, len(dataIPv4.data), netFlowV9RecordLenIPv4)
+	record := dataIPv4.data
+	assert.Equal(t, uint64(1000), binary.BigEndian.Uint64(record[0:]))
+	assert.Equal(t, uint64(10), binary.BigEndian.Uint64(record[8:]))
+	assert.Equal(t, uint64(5000), binary.BigEndian.Uint64(record[16:]))
+	assert.Equal(t, uint64(5), binary.BigEndian.Uint64(record[24:]))
+	assert.Equal(t, uint8(6), record[32])
+	assert.Equal(t, uint32(1000), binary.BigEndian.Uint32(record[33:]))
+	assert.Equal(t, uint32(3000), binary.BigEndian.Uint32(record[37:]))
+	assert.Equal(t, uint8(1), record[41])
+	assert.Equal(t, uint8(netFlowV9ForwardingStatusForwarded), record[42])
+	assert.Equal(t, uint16(32768), binary.BigEndian.Uint16(record[43:]))
+	// The destination is the Service, and the post-NAT destination is the Endpoint.
+	assert.Equal(t, uint16(80), binary.BigEndian.Uint16(record[45:]))
+	assert.Equal(t, uint16(8080), binary.BigEndian.Uint16(record[47:]))
+	assert.Equal(t, net.ParseIP("10.10.0.1").To4(), net.IP(record[49:53]))
+	assert.Equal(t, net.ParseIP("10.96.0.10").To4(), net.IP(record[53:57]))
+	assert.Equal(t, net.ParseIP("10.10.1.2").To4(), net.IP(record[57:61]))
+
+	dataIPv6 := flowSets[2]
+	assert.Equal(t, uint16(netFlowV9TemplateIDv6), dataIPv6.id)
+	record = dataIPv6.data
+	require.GreaterOrEqual(t, len(record), netFlowV9RecordLenIPv6)
+	assert.Equal(t, uint8(netFlowV9ForwardingStatusDroppedACL), record[42])
+	// The destination is the same before and after NAT.
+	assert.Equal(t, uint16(53), binary.BigEndian.Uint16(record[45:]))
+	assert.Equal(t, uint16(53), binary.BigEndian.Uint16(record[47:]))
+	assert.Equal(t, net.ParseIP("fd00:10:10::1"), net.IP(record[49:65]))
+	assert.Equal(t, net.ParseIP("fd00:10:10:1::2"), net.IP(record[65:81]))
+	assert.Equal(t, net.ParseIP("fd00:10:10:1::2"), net.IP(record[81:97]))
+
+	// The templates are not sent again until the refresh interval has elapsed.
+	datagrams = encoder.encode([]Record{testRecordIPv4}, now.Add(time.Minute))
+	require.Len(t, datagrams, 1)
+	count, sequence, flowSets = parseNetFlowV9Datagram(t, datagrams[0])
+	assert.Equal(t, uint16(1), count)
+	assert.Equal(t, uint32(1), sequence)
+	require.Len(t, flowSets, 1)
+	assert.Equal(t, uint16(netFlowV9TemplateIDv4), flowSets[0].id)
+
+	datagrams = encoder.encode([]Record{testRecordIPv4}, now.Add(netFlowV9TemplateRefreshInterval))
+	require.Len(t, datagrams, 1)
+	count, sequence, flowSets = parseNetFlowV9Datagram(t, datagrams[0])
+	assert.Equal(t, uint16(3), count)
+	assert.Equal(t, uint32(2), sequence)
+	require.Len(t, flowSets, 2)
+	assert.Equal(t, uint16(netFlowV9TemplateFlowSet), flowSets[0].id)
+}
+
+func TestNetFlowV9EncodeMultipleDatagrams(t *testing.T) {
+	encoder := newNetFlowV9Encoder(12345, testStartTime)
+	records := make([]Record, 100)
+	for i := range records {
+		records[i] = testRecordIPv6
+	}
+	datagrams := encoder.encode(records, testStartTime)
+	require.Greater(t, len(datagrams), 1)
+	totalRecords := 0
+	for i, datagram := range datagrams {
+		assert.LessOrEqual(t, len(datagram), maxDatagramSize)
+		count, sequence, flowSets := parseNetFlowV9Datagram(t, datagram)
+		assert.Equal(t, uint32(i), sequence)
+		for _, flowSet := range flowSets {
+			if flowSet.id == netFlowV9TemplateIDv6 {
+				totalRecords += len(flowSet.data) / netFlowV9RecordLenIPv6
+			}
+		}
+		if i == 0 {
+			// The templates are only sent in the first datagram.
+			count -= 2
+		}
+		assert.Equal(t, int(count), len(flowSets[len(flowSets)-1].data)/netFlowV9RecordLenIPv6)
+	}
+	assert.Equal(t, len(records), totalRecords)
+}
@@ -0,0 +1,238 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package legacyflow
+
+import (
+	"encoding/binary"
+	"math"
+	"net"
+	"time"
+)
+
+const (
+	sFlowVersion = 5
+
+	sFlowAddressTypeIPv4 = 1
+	sFlowAddressTypeIPv6 = 2
+
+	// Data formats of the sample and flow records, with the enterprise 0 defined in the sFlow v5 specification.
+	sFlowFlowSampleFormat      = 1
+	sFlowSampledIPv4Format     = 3
+	sFlowSampledIPv6Format     = 4
+	sFlowExtendedNATFormat     = 1007
+	sFlowExtendedNATPortFormat = 1020
+
+	// The output interface of the discarded packets is 0x40000000 with the reason code in the lower 30 bits.
+	sFlowOutputDiscarded  = 0x40000000
+	sFlowDiscardReasonACL = 258
+)
+
+// sFlowEncoder encodes the flow records as sFlow v5 flow samples. As sFlow is a packet sampling protocol, the counters
+// of each direction of a flow are represented with a flow sample, whose sampling rate is the number of packets and
+// whose packet length is the average packet length, so that the collectors can estimate the original counters as
+// they do for sampled packets. The directions without new packets since the last export are not exported.
+type sFlowEncoder struct {
+	agentAddress   net.IP
+	subAgentID     uint32
+	startTime      time.Time
+	sequence       uint32
+	sampleSequence uint32
+	samplePool     uint32
+}
+
+func newSFlowEncoder(agentAddress net.IP, subAgentID uint32, startTime time.Time) *sFlowEncoder {
+	return &sFlowEncoder{
+		agentAddress: agentAddress,
+		subAgentID:   subAgentID,
+		startTime:    startTime,
+	}
+}
+
+// sFlowSample is a direction of a flow to be encoded as a flow sample.
+type sFlowSample struct {
+	srcAddress, dstAddress net.IP
+	srcPort, dstPort       uint16
+	// The addresses and ports after NAT, nil if the direction is not translated.
+	natSrcAddress, natDstAddress net.IP
+	natSrcPort, natDstPort       uint16
+	protocol                     uint8
+	packets, octets              uint64
+	dropped                      bool
+	isIPv6                       bool
+}
+
+// samples returns the samples of both directions of the record. For Service flows, the original direction is sent to
+// the Service ClusterIP and translated to the Endpoint, and the reply direction is sent by the Endpoint and translated
+// to the Service ClusterIP.
+func samples(r *Record) []sFlowSample {
+	var samples []sFlowSample
+	isIPv6 := r.isIPv6()
+	if r.Packets > 0 {
+		sample := sFlowSample{
+			srcAddress: r.SourceAddress,
+			dstAddress: r.DestinationAddress,
+			srcPort:    r.SourcePort,
+			dstPort:    r.DestinationPort,
+			protocol:   r.Protocol,
+			packets:    r.Packets,
+			octets:     r.Octets,
+			dropped:    r.Dropped,
+			isIPv6:     isIPv6,
+		}
+		if r.ServiceAddress != nil {
+			sample.dstAddress, sample.dstPort = r.ServiceAddress, r.ServicePort
+			sample.natSrcAddress, sample.natSrcPort = r.SourceAddress, r.SourcePort
+			sample.natDstAddress, sample.natDstPort = r.DestinationAddress, r.DestinationPort
+		}
+		samples = append(samples, sample)
+	}
+	if r.ReversePackets > 0 {
+		sample := sFlowSample{
+			srcAddress: r.DestinationAddress,
+			dstAddress: r.SourceAddress,
+			srcPort:    r.DestinationPort,
+			dstPort:    r.SourcePort,
+			protocol:   r.Protocol,
+			packets:    r.ReversePackets,
+			octets:     r.ReverseOctets,
+			dropped:    r.Dropped,
+			isIPv6:     isIPv6,
+		}
+		if r.ServiceAddress != nil {
+			sample.natSrcAddress, sample.natSrcPort = r.ServiceAddress, r.ServicePort
+			sample.natDstAddress, sample.natDstPort = r.SourceAddress, r.SourcePort
+		}
+		samples = append(samples, sample)
+	}
+	return samples
+}
+
+func (e *sFlowEncoder) encode(records []Record, now time.Time) [][]byte {
+	var datagrams [][]byte
+	var buf []byte
+	var count uint32
+	// The offset of the number of samples in the datagram header.
+	var countOffset int
+
+	startDatagram := func() {
+		buf = make([]byte, 0, maxDatagramSize)
+		buf = binary.BigEndian.AppendUint32(buf, sFlowVersion)
+		buf = appendSFlowAddress(buf, e.agentAddress, e.agentAddress.To4() == nil)
+		buf = binary.BigEndian.AppendUint32(buf, e.subAgentID)
+		buf = binary.BigEndian.AppendUint32(buf, e.sequence)
+		buf = binary.BigEndian.AppendUint32(buf, uptimeMilliseconds(e.startTime, now))
+		countOffset = len(buf)
+		buf = binary.BigEndian.AppendUint32(buf, 0)
+		count = 0
+	}
+	finishDatagram := func() {
+		binary.BigEndian.PutUint32(buf[countOffset:], count)
+		e.sequence++
+		datagrams = append(datagrams, buf)
+		buf = nil
+	}
+
+	for i := range records {
+		for _, sample := range samples(&records[i]) {
+			encoded := e.encodeSample(&sample)
+			if buf != nil && len(buf)+len(encoded) > maxDatagramSize {
+				finishDatagram()
+			}
+			if buf == nil {
+				startDatagram()
+			}
+			buf = append(buf, encoded...)
+			count++
+		}
+	}
+	if buf != nil {
+		finishDatagram()
+	}
+	return datagrams
+}
+
+func (e *sFlowEncoder) encodeSample(sample *sFlowSample) []byte {
+	samplingRate := uint32(math.MaxUint32)
+	if sample.packets < math.MaxUint32 {
+		samplingRate = uint32(sample.packets)
+	}
+	e.samplePool += samplingRate
+	var output uint32
+	if sample.dropped {
+		output = sFlowOutputDiscarded | sFlowDiscardReasonACL
+	}
+	numRecords := uint32(1)
+	if sample.natDstAddress != nil {
+		numRecords = 3
+	}
+
+	buf := binary.BigEndian.AppendUint32(nil, sFlowFlowSampleFormat)
+	// The length is set after the flow records are added.
+	buf = binary.BigEndian.AppendUint32(buf, 0)
+	buf = binary.BigEndian.AppendUint32(buf, e.sampleSequence)
+	// The source ID, with the type and index of the data source both set to 0.
+	buf = binary.BigEndian.AppendUint32(buf, 0)
+	buf = binary.BigEndian.AppendUint32(buf, samplingRate)
+	buf = binary.BigEndian.AppendUint32(buf, e.samplePool)
+	// The number of drops due to lack of resources.
+	buf = binary.BigEndian.AppendUint32(buf, 0)
+	// The input interface is unknown.
+	buf = binary.BigEndian.AppendUint32(buf, 0)
+	buf = binary.BigEndian.AppendUint32(buf, output)
+	buf = binary.BigEndian.AppendUint32(buf, numRecords)
+
+	if sample.isIPv6 {
+		buf = binary.BigEndian.AppendUint32(buf, sFlowSampledIPv6Format)
+		buf = binary.BigEndian.AppendUint32(buf, 56)
+	} else {
+		buf = binary.BigEndian.AppendUint32(buf, sFlowSampledIPv4Format)
+		buf = binary.BigEndian.AppendUint32(buf, 32)
+	}
+	buf = binary.BigEndian.AppendUint32(buf, uint32(sample.octets/sample.packets))
+	buf = binary.BigEndian.AppendUint32(buf, uint32(sample.protocol))
+	buf = appendIP(buf, sample.srcAddress, sample.isIPv6)
+	buf = appendIP(buf, sample.dstAddress, sample.isIPv6)
+	buf = binary.BigEndian.AppendUint32(buf, uint32(sample.srcPort))
+	buf = binary.BigEndian.AppendUint32(buf, uint32(sample.dstPort))
+	// The TCP flags and the ToS (or priority for IPv6) are unknown.
+	buf = binary.BigEndian.AppendUint32(buf, 0)
+	buf = binary.BigEndian.AppendUint32(buf, 0)
+
+	if sample.natDstAddress != nil {
+		start := len(buf)
+		buf = binary.BigEndian.AppendUint32(buf, sFlowExtendedNATFormat)
+		buf = binary.BigEndian.AppendUint32(buf, 0)
+		buf = appendSFlowAddress(buf, sample.natSrcAddress, sample.isIPv6)
+		buf = appendSFlowAddress(buf, sample.natDstAddress, sample.isIPv6)
+		binary.BigEndian.PutUint32(buf[start+4:], uint32(len(buf)-start-8))
+
+		buf = binary.BigEndian.AppendUint32(buf, sFlowExtendedNATPortFormat)
+		buf = binary.BigEndian.AppendUint32(buf, 8)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(sample.natSrcPort))
+		buf = binary.BigEndian.AppendUint32(buf, uint32(sample.natDstPort))
+	}
+	binary.BigEndian.PutUint32(buf[4:], uint32(len(buf)-8))
+	e.sampleSequence++
+	return buf
+}
+
+func appendSFlowAddress(buf []byte, ip net.IP, isIPv6 bool) []byte {
+	if isIPv6 {
+		buf = binary.BigEndian.AppendUint32(buf, sFlowAddressTypeIPv6)
+	} else {
+		buf = binary.BigEndian.AppendUint32(buf, sFlowAddressTypeIPv4)
+	}
+	return appendIP(buf, ip, isIPv6)
+}
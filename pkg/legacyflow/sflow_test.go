@@ -0,0 +1,158 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package legacyflow
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sFlowTestSample struct {
+	sequence     uint32
+	samplingRate uint32
+	samplePool   uint32
+	output       uint32
+	records      map[uint32][]byte
+}
+
+func parseSFlowDatagram(t *testing.T, datagram []byte) (sequence uint32, samples []sFlowTestSample) {
+	assert.Equal(t, uint32(sFlowVersion), binary.BigEndian.Uint32(datagram[0:]))
+	assert.Equal(t, uint32(sFlowAddressTypeIPv4), binary.BigEndian.Uint32(datagram[4:]))
+	assert.Equal(t, net.ParseIP("192.168.0.1").To4(), net.IP(datagram[8:12]))
+	assert.Equal(t, uint32(12345), binary.BigEndian.Uint32(datagram[12:]))
+	sequence = binary.BigEndian.Uint32(datagram[16:])
+	numSamples := int(binary.BigEndian.Uint32(datagram[24:]))
+	data := datagram[28:]
+	for i := 0; i < numSamples; i++ {
+		require.Equal(t, uint32(sFlowFlowSampleFormat), binary.BigEndian.Uint32(data[0:]))
+		length := int(binary.BigEndian.Uint32(data[4:]))
+		sampleData := data[8 : 8+length]
+		sample := sFlowTestSample{
+			sequence:     binary.BigEndian.Uint32(sampleData[0:]),
+			samplingRate: binary.BigEndian.Uint32(sampleData[8:]),
+			samplePool:   binary.BigEndian.Uint32(sampleData[12:]),
+			output:       binary.BigEndian.Uint32(sampleData[24:]),
+			records:      map[uint32][]byte{},
+		}
+		numRecords := int(binary.BigEndian.Uint32(sampleData[28:]))
+		recordData := sampleData[32:]
+		for j := 0; j < numRecords; j++ {
+			format := binary.BigEndian.Uint32(recordData[0:])
+			recordLength := int(binary.BigEndian.Uint32(recordData[4:]))
+			sample.records[format] = recordData[8 : 8+recordLength]
+			recordData = recordData[8+recordLength:]
+		}
+		assert.Empty(t, recordData)
+		samples = append(samples, sample)
+		data = data[8+length:]
+	}
+	assert.Empty(t, data)
+	return
+}
+
+func TestSFlowEncode(t *testing.T) {
+	encoder := newSFlowEncoder(net.ParseIP("192.168.0.1"), 12345, testStartTime)
+	now := testStartTime.Add(5 * time.Second)
+	// The reverse direction of the IPv6 record has no packets and is not exported.
+	datagrams := encoder.encode([]Record{testRecordIPv4, testRecordIPv6}, now)
+	require.Len(t, datagrams, 1)
+	assert.Equal(t, uint32(5000), binary.BigEndian.Uint32(datagrams[0][20:]))
+	sequence, samples := parseSFlowDatagram(t, datagrams[0])
+	assert.Equal(t, uint32(0), sequence)
+	require.Len(t, samples, 3)
+
+	// The original direction of the Service flow, sent to the ClusterIP and translated to the Endpoint.
+	sample := samples[0]
+	assert.Equal(t, uint32(0), sample.sequence)
+	assert.Equal(t, uint32(10), sample.samplingRate)
+	assert.Equal(t, uint32(10), sample.samplePool)
+	assert.Equal(t, uint32(0), sample.output)
+	require.Len(t, sample.records, 3)
+	sampled := sample.records[sFlowSampledIPv4Format]
+	require.Len(t, sampled, 32)
+	assert.Equal(t, uint32(100), binary.BigEndian.Uint32(sampled[0:]))
+	assert.Equal(t, uint32(6), binary.BigEndian.Uint32(sampled[4:]))
+	assert.Equal(t, net.ParseIP("10.10.0.1").To4(), net.IP(sampled[8:12]))
+	assert.Equal(t, net.ParseIP("10.96.0.10").To4(), net.IP(sampled[12:16]))
+	assert.Equal(t, uint32(32768), binary.BigEndian.Uint32(sampled[16:]))
+	assert.Equal(t, uint32(80), binary.BigEndian.Uint32(sampled[20:]))
+	nat := sample.records[sFlowExtendedNATFormat]
+	require.Len(t, nat, 16)
+	assert.Equal(t, net.ParseIP("10.10.0.1").To4(), net.IP(nat[4:8]))
+	assert.Equal(t, net.ParseIP("10.10.1.2").To4(), net.IP(nat[12:16]))
+	natPort := sample.records[sFlowExtendedNATPortFormat]
+	require.Len(t, natPort, 8)
+	assert.Equal(t, uint32(32768), binary.BigEndian.Uint32(natPort[0:]))
+	assert.Equal(t, uint32(8080), binary.BigEndian.Uint32(natPort[4:]))
+
+	// The reply direction of the Service flow, sent by the Endpoint and translated to the ClusterIP.
+	sample = samples[1]
+	assert.Equal(t, uint32(1), sample.sequence)
+	assert.Equal(t, uint32(5), sample.samplingRate)
+	assert.Equal(t, uint32(15), sample.samplePool)
+	sampled = sample.records[sFlowSampledIPv4Format]
+	assert.Equal(t, uint32(1000), binary.BigEndian.Uint32(sampled[0:]))
+	assert.Equal(t, net.ParseIP("10.10.1.2").To4(), net.IP(sampled[8:12]))
+	assert.Equal(t, net.ParseIP("10.10.0.1").To4(), net.IP(sampled[12:16]))
+	nat = sample.records[sFlowExtendedNATFormat]
+	assert.Equal(t, net.ParseIP("10.96.0.10").To4(), net.IP(nat[4:8]))
+	assert.Equal(t, net.ParseIP("10.10.0.1").To4(), net.IP(nat[12:16]))
+
+	// The dropped IPv6 flow.
+	sample = samples[2]
+	assert.Equal(t, uint32(1), sample.samplingRate)
+	assert.Equal(t, uint32(sFlowOutputDiscarded|sFlowDiscardReasonACL), sample.output)
+	require.Len(t, sample.records, 1)
+	sampled = sample.records[sFlowSampledIPv6Format]
+	require.Len(t, sampled, 56)
+	assert.Equal(t, uint32(100), binary.BigEndian.Uint32(sampled[0:]))
+	assert.Equal(t, uint32(17), binary.BigEndian.Uint32(sampled[4:]))
+	assert.Equal(t, net.ParseIP("fd00:10:10::1"), net.IP(sampled[8:24]))
+	assert.Equal(t, net.ParseIP("fd00:10:10:1::2"), net.IP(sampled[24:40]))
+
+	datagrams = encoder.encode([]Record{testRecordIPv6}, now)
+	require.Len(t, datagrams, 1)
+	sequence, samples = parseSFlowDatagram(t, datagrams[0])
+	assert.Equal(t, uint32(1), sequence)
+	require.Len(t, samples, 1)
+	assert.Equal(t, uint32(3), samples[0].sequence)
+	assert.Equal(t, uint32(17), samples[0].samplePool)
+
+	// Nothing is exported for the flows without new packets.
+	assert.Empty(t, encoder.encode([]Record{{SourceAddress: net.ParseIP("10.10.0.1")}}, now))
+}
+
+func TestSFlowEncodeMultipleDatagrams(t *testing.T) {
+	encoder := newSFlowEncoder(net.ParseIP("192.168.0.1"), 12345, testStartTime)
+	records := make([]Record, 50)
+	for i := range records {
+		records[i] = testRecordIPv4
+	}
+	datagrams := encoder.encode(records, testStartTime)
+	require.Greater(t, len(datagrams), 1)
+	totalSamples := 0
+	for i, datagram := range datagrams {
+		assert.LessOrEqual(t, len(datagram), maxDatagramSize)
+		sequence, samples := parseSFlowDatagram(t, datagram)
+		assert.Equal(t, uint32(i), sequence)
+		totalSamples += len(samples)
+	}
+	assert.Equal(t, 2*len(records), totalSamples)
+}
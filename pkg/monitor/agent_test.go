@@ -150,8 +150,12 @@ func newAgentMonitor(crdClient *fakeclientset.Clientset, t *testing.T) *agentMon
 	networkPolicyInfoQuerier.EXPECT().GetAppliedToGroupNum().Return(20).AnyTimes()
 	networkPolicyInfoQuerier.EXPECT().GetAddressGroupNum().Return(30).AnyTimes()
 	networkPolicyInfoQuerier.EXPECT().GetControllerConnectionStatus().Return(true).AnyTimes()
+	networkPolicyInfoQuerier.EXPECT().GetSupportedFeatures().Return([]string{"L7Protocols"}).AnyTimes()
+	networkPolicyInfoQuerier.EXPECT().GetUnsupportedFeatures().Return([]string{"IGMP"}).AnyTimes()
 
-	querier := querier.NewAgentQuerier(nodeConfig, nil, interfaceStore, client, ofClient, ovsBridgeClient, nil, networkPolicyInfoQuerier, 10349, "", nil, nil)
+	querier := querier.NewAgentQuerier(nodeConfig, nil, interfaceStore, client, ofClient, ovsBridgeClient, nil, networkPolicyInfoQuerier, 10349, "", nil, nil, nil, nil)
 
-	return NewAgentMonitor(crdClient, querier, fakeCertData)
+	return NewAgentMonitor(crdClient, querier, func() []byte {
+		return fakeCertData
+	})
 }
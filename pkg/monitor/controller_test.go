@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	cgtesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
 
 	"antrea.io/antrea/pkg/apis/crd/v1alpha1"
 	"antrea.io/antrea/pkg/apis/crd/v1beta1"
@@ -64,6 +65,7 @@ func newControllerMonitor(crdClient *fakeclientset.Clientset) *fakeController {
 	acnpInformer := crdInformerFactory.Crd().V1alpha1().ClusterNetworkPolicies()
 	annpInformer := crdInformerFactory.Crd().V1alpha1().NetworkPolicies()
 	tierInformer := crdInformerFactory.Crd().V1alpha1().Tiers()
+	tierEntitlementInformer := crdInformerFactory.Crd().V1alpha1().TierEntitlements()
 	cgInformer := crdInformerFactory.Crd().V1alpha3().ClusterGroups()
 	grpInformer := crdInformerFactory.Crd().V1alpha3().Groups()
 	externalNodeInformer := crdInformerFactory.Crd().V1alpha1().ExternalNodes()
@@ -86,12 +88,14 @@ func newControllerMonitor(crdClient *fakeclientset.Clientset) *fakeController {
 		acnpInformer,
 		annpInformer,
 		tierInformer,
+		tierEntitlementInformer,
 		cgInformer,
 		grpInformer,
 		addressGroupStore,
 		appliedToGroupStore,
 		networkPolicyStore,
 		groupStore,
+		record.NewFakeRecorder(10),
 		false)
 
 	controllerQuerier := querier.NewControllerQuerier(networkPolicyController, 10349)
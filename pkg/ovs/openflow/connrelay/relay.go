@@ -0,0 +1,249 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connrelay relays the OpenFlow connection of the local OpenFlow client to a remote OVS instance, e.g. running
+// on the VM host or on a DPU. The OpenFlow client only supports connecting to the OVS bridge via unix socket, so the
+// relay listens on a local unix socket, and forwards every connection accepted on it to the remote OVS instance over
+// TCP or SSL.
+package connrelay
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	NetworkTCP = "tcp"
+	NetworkSSL = "ssl"
+
+	defaultDialTimeout     = 5 * time.Second
+	defaultMaxDialAttempts = 3
+	defaultRetryInterval   = 1 * time.Second
+)
+
+// ParseAddress parses an OVS style connection address, which is in the form "tcp:<host>:<port>" or
+// "ssl:<host>:<port>". An IPv6 host must be enclosed in square brackets.
+func ParseAddress(address string) (string, string, error) {
+	network, hostPort, found := strings.Cut(address, ":")
+	if !found {
+		return "", "", fmt.Errorf("invalid address %q, it must be in the form <tcp|ssl>:<host>:<port>", address)
+	}
+	if network != NetworkTCP && network != NetworkSSL {
+		return "", "", fmt.Errorf("unsupported connection type %q in address %q, supported types are %q and %q", network, address, NetworkTCP, NetworkSSL)
+	}
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid address %q: %w", address, err)
+	}
+	if host == "" || port == "" {
+		return "", "", fmt.Errorf("invalid address %q, both host and port must be specified", address)
+	}
+	return network, hostPort, nil
+}
+
+// NewTLSConfig creates the TLS configuration used to connect to the remote OVS instance over SSL. The certificate of
+// the remote OVS instance is verified with the CA certificate, and the client certificate is presented to it, as OVS
+// requires peer certificates for SSL connections.
+func NewTLSConfig(caCertFile, certFile, keyFile string) (*tls.Config, error) {
+	caCert, err := os.ReadFile(caCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA certificate file %s: %w", caCertFile, err)
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no valid certificate found in CA certificate file %s", caCertFile)
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error loading certificate and key: %w", err)
+	}
+	return &tls.Config{
+		RootCAs:      caCertPool,
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// ConnRelay forwards the connections accepted on a local unix socket to a remote OVS instance. Each local connection
+// is paired with a new remote connection, and both are closed as soon as either of them is closed. In particular,
+// when the remote connection is lost, the local connection is closed, so that the OpenFlow client detects the
+// disconnection and reconnects, which in turn makes the relay reconnect to the remote OVS instance.
+type ConnRelay struct {
+	sockPath  string
+	network   string
+	address   string
+	tlsConfig *tls.Config
+
+	dialTimeout     time.Duration
+	maxDialAttempts int
+	retryInterval   time.Duration
+
+	listener net.Listener
+	// conns stores the active local connections, which are closed when the relay is stopped.
+	conns      map[net.Conn]struct{}
+	connsMutex sync.Mutex
+}
+
+// NewConnRelay creates a ConnRelay which listens on sockPath and forwards the connections to the remote address, in
+// the form "tcp:<host>:<port>" or "ssl:<host>:<port>". tlsConfig is required for "ssl" addresses.
+func NewConnRelay(sockPath, address string, tlsConfig *tls.Config) (*ConnRelay, error) {
+	network, hostPort, err := ParseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	if network == NetworkSSL {
+		if tlsConfig == nil {
+			return nil, fmt.Errorf("TLS configuration is required for address %q", address)
+		}
+		tlsConfig = tlsConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			host, _, _ := net.SplitHostPort(hostPort)
+			tlsConfig.ServerName = host
+		}
+	}
+	return &ConnRelay{
+		sockPath:        sockPath,
+		network:         network,
+		address:         hostPort,
+		tlsConfig:       tlsConfig,
+		dialTimeout:     defaultDialTimeout,
+		maxDialAttempts: defaultMaxDialAttempts,
+		retryInterval:   defaultRetryInterval,
+		conns:           map[net.Conn]struct{}{},
+	}, nil
+}
+
+// SockPath returns the path of the local unix socket, which should be used as the management address of the OVS
+// bridge by the OpenFlow client.
+func (r *ConnRelay) SockPath() string {
+	return r.sockPath
+}
+
+// Start starts listening on the local unix socket and relaying the accepted connections in the background, until
+// stopCh is closed. It returns once the socket is ready to accept connections.
+func (r *ConnRelay) Start(stopCh <-chan struct{}) error {
+	// Remove the stale socket file left by a previous run.
+	if err := os.Remove(r.sockPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing stale socket file %s: %w", r.sockPath, err)
+	}
+	listener, err := net.Listen("unix", r.sockPath)
+	if err != nil {
+		return fmt.Errorf("error listening on socket file %s: %w", r.sockPath, err)
+	}
+	r.listener = listener
+	klog.InfoS("Relaying OpenFlow connections to remote OVS", "socket", r.sockPath, "network", r.network, "address", r.address)
+
+	go func() {
+		<-stopCh
+		r.listener.Close()
+		r.connsMutex.Lock()
+		defer r.connsMutex.Unlock()
+		for conn := range r.conns {
+			conn.Close()
+		}
+	}()
+	go r.acceptLoop(stopCh)
+	return nil
+}
+
+func (r *ConnRelay) acceptLoop(stopCh <-chan struct{}) {
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			select {
+			case <-stopCh:
+				return
+			default:
+			}
+			klog.ErrorS(err, "Error accepting OpenFlow connection", "socket", r.sockPath)
+			time.Sleep(r.retryInterval)
+			continue
+		}
+		go r.relay(conn, stopCh)
+	}
+}
+
+func (r *ConnRelay) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: r.dialTimeout}
+	if r.network == NetworkSSL {
+		return tls.DialWithDialer(dialer, "tcp", r.address, r.tlsConfig)
+	}
+	return dialer.Dial("tcp", r.address)
+}
+
+// dialWithRetry connects to the remote OVS instance, retrying up to maxDialAttempts times. It gives up earlier if
+// stopCh is closed.
+func (r *ConnRelay) dialWithRetry(stopCh <-chan struct{}) (net.Conn, error) {
+	var err error
+	for attempt := 1; attempt <= r.maxDialAttempts; attempt++ {
+		var conn net.Conn
+		conn, err = r.dial()
+		if err == nil {
+			return conn, nil
+		}
+		klog.ErrorS(err, "Failed to connect to remote OVS", "network", r.network, "address", r.address, "attempt", attempt)
+		select {
+		case <-stopCh:
+			return nil, fmt.Errorf("relay is stopped")
+		case <-time.After(r.retryInterval):
+		}
+	}
+	return nil, err
+}
+
+func (r *ConnRelay) trackConn(conn net.Conn, add bool) {
+	r.connsMutex.Lock()
+	defer r.connsMutex.Unlock()
+	if add {
+		r.conns[conn] = struct{}{}
+	} else {
+		delete(r.conns, conn)
+	}
+}
+
+func (r *ConnRelay) relay(localConn net.Conn, stopCh <-chan struct{}) {
+	r.trackConn(localConn, true)
+	defer r.trackConn(localConn, false)
+	defer localConn.Close()
+
+	remoteConn, err := r.dialWithRetry(stopCh)
+	if err != nil {
+		// Closing the local connection makes the OpenFlow client reconnect, which triggers another attempt.
+		klog.ErrorS(err, "Giving up connecting to remote OVS, closing local OpenFlow connection", "network", r.network, "address", r.address)
+		return
+	}
+	defer remoteConn.Close()
+	klog.InfoS("Connected to remote OVS", "network", r.network, "address", r.address)
+
+	// Close both connections as soon as either direction ends, so that a disconnection on one side is propagated to
+	// the other side.
+	done := make(chan struct{}, 2)
+	copyConn := func(dst, src net.Conn) {
+		io.Copy(dst, src)
+		done <- struct{}{}
+	}
+	go copyConn(remoteConn, localConn)
+	go copyConn(localConn, remoteConn)
+	<-done
+	klog.InfoS("OpenFlow connection to remote OVS is closed", "network", r.network, "address", r.address)
+}
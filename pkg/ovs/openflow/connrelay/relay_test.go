@@ -0,0 +1,168 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connrelay
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certutil "k8s.io/client-go/util/cert"
+)
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		address          string
+		expectedNetwork  string
+		expectedHostPort string
+		expectedErr      string
+	}{
+		{address: "tcp:10.0.0.1:6653", expectedNetwork: "tcp", expectedHostPort: "10.0.0.1:6653"},
+		{address: "ssl:ovs.example.com:6653", expectedNetwork: "ssl", expectedHostPort: "ovs.example.com:6653"},
+		{address: "ssl:[fd00::1]:6653", expectedNetwork: "ssl", expectedHostPort: "[fd00::1]:6653"},
+		{address: "10.0.0.1", expectedErr: "it must be in the form"},
+		{address: "unix:/var/run/openvswitch/br-int.mgmt", expectedErr: "unsupported connection type"},
+		{address: "tcp:10.0.0.1", expectedErr: "missing port"},
+		{address: "tcp::6653", expectedErr: "both host and port must be specified"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			network, hostPort, err := ParseAddress(tt.address)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedNetwork, network)
+			assert.Equal(t, tt.expectedHostPort, hostPort)
+		})
+	}
+}
+
+// runEchoServer accepts connections on the listener and echoes back the received data. The accepted connections are
+// sent to connCh so that tests can close them.
+func runEchoServer(listener net.Listener, connCh chan<- net.Conn) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		connCh <- conn
+		go io.Copy(conn, conn)
+	}
+}
+
+func testRelay(t *testing.T, relay *ConnRelay, connCh <-chan net.Conn) {
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	require.NoError(t, relay.Start(stopCh))
+
+	localConn, err := net.Dial("unix", relay.SockPath())
+	require.NoError(t, err)
+	defer localConn.Close()
+
+	_, err = localConn.Write([]byte("hello"))
+	require.NoError(t, err)
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(localConn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf))
+
+	// Closing the remote connection must close the local connection.
+	remoteConn := <-connCh
+	remoteConn.Close()
+	localConn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = localConn.Read(buf)
+	assert.ErrorIs(t, err, io.EOF)
+
+	// A new local connection is relayed to a new remote connection.
+	localConn2, err := net.Dial("unix", relay.SockPath())
+	require.NoError(t, err)
+	defer localConn2.Close()
+	_, err = localConn2.Write([]byte("world"))
+	require.NoError(t, err)
+	_, err = io.ReadFull(localConn2, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "world", string(buf))
+}
+
+func TestConnRelayTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	connCh := make(chan net.Conn, 10)
+	go runEchoServer(listener, connCh)
+
+	relay, err := NewConnRelay(filepath.Join(t.TempDir(), "br-int.mgmt"), "tcp:"+listener.Addr().String(), nil)
+	require.NoError(t, err)
+	testRelay(t, relay, connCh)
+}
+
+func TestConnRelaySSL(t *testing.T) {
+	certPEM, keyPEM, err := certutil.GenerateSelfSignedCertKey("127.0.0.1", []net.IP{net.ParseIP("127.0.0.1")}, nil)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0600))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0600))
+
+	serverCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{serverCert}})
+	require.NoError(t, err)
+	defer listener.Close()
+	connCh := make(chan net.Conn, 10)
+	go runEchoServer(listener, connCh)
+
+	tlsConfig, err := NewTLSConfig(certFile, certFile, keyFile)
+	require.NoError(t, err)
+	relay, err := NewConnRelay(filepath.Join(dir, "br-int.mgmt"), "ssl:"+listener.Addr().String(), tlsConfig)
+	require.NoError(t, err)
+	testRelay(t, relay, connCh)
+}
+
+func TestConnRelayRemoteUnreachable(t *testing.T) {
+	// Get a free port which nothing listens on.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	relay, err := NewConnRelay(filepath.Join(t.TempDir(), "br-int.mgmt"), "tcp:"+address, nil)
+	require.NoError(t, err)
+	relay.retryInterval = 10 * time.Millisecond
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	require.NoError(t, relay.Start(stopCh))
+
+	localConn, err := net.Dial("unix", relay.SockPath())
+	require.NoError(t, err)
+	defer localConn.Close()
+	localConn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = localConn.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestNewConnRelaySSLWithoutTLSConfig(t *testing.T) {
+	_, err := NewConnRelay("/tmp/br-int.mgmt", "ssl:10.0.0.1:6653", nil)
+	assert.ErrorContains(t, err, "TLS configuration is required")
+}
@@ -116,6 +116,9 @@ type Bridge interface {
 	// DumpFlows queries the Openflow entries from OFSwitch. The filter of the query is Openflow cookieID; the result is
 	// a map from flow cookieID to FlowStates.
 	DumpFlows(cookieID, cookieMask uint64) (map[uint64]*FlowStates, error)
+	// DumpFlowDescs queries the Openflow entries from OFSwitch. The filter of the query is Openflow cookieID; the
+	// result is the descriptions of the Openflow entries, including their matches.
+	DumpFlowDescs(cookieID, cookieMask uint64) ([]*openflow15.FlowDesc, error)
 	// DeleteFlowsByCookie removes Openflow entries from OFSwitch. The removed Openflow entries use the specific CookieID.
 	DeleteFlowsByCookie(cookieID, cookieMask uint64) error
 	// AddFlowsInBundle syncs multiple Openflow entries in a single transaction. This operation could add new flows in
@@ -272,6 +275,7 @@ type FlowBuilder interface {
 	MatchARPTpa(ip net.IP) FlowBuilder
 	MatchARPOp(op uint16) FlowBuilder
 	MatchIPDSCP(dscp uint8) FlowBuilder
+	MatchIPTTL(ttl uint8) FlowBuilder
 	MatchCTStateNew(isSet bool) FlowBuilder
 	MatchCTStateRel(isSet bool) FlowBuilder
 	MatchCTStateRpl(isSet bool) FlowBuilder
@@ -322,6 +326,7 @@ type LearnAction interface {
 	MatchLearnedDstPort(protocol Protocol) LearnAction
 	MatchLearnedSrcPort(protocol Protocol) LearnAction
 	MatchLearnedSrcIP(isIPv6 bool) LearnAction
+	MatchLearnedSrcIPPrefix(isIPv6 bool, prefixLength int) LearnAction
 	MatchLearnedDstIP(isIPv6 bool) LearnAction
 	MatchRegMark(marks ...*RegMark) LearnAction
 	LoadRegMark(marks ...*RegMark) LearnAction
@@ -343,6 +348,7 @@ type BucketBuilder interface {
 	LoadRegMark(mark *RegMark) BucketBuilder
 	ResubmitToTable(tableID uint8) BucketBuilder
 	SetTunnelDst(addr net.IP) BucketBuilder
+	Group(id GroupIDType) BucketBuilder
 	Done() Group
 }
 
@@ -563,6 +563,21 @@ func (a *ofLearnAction) MatchLearnedSrcIP(isIPv6 bool) LearnAction {
 	return a
 }
 
+// MatchLearnedSrcIPPrefix makes the learned flow match the first prefixLength bits of the nw_src of current IP packet,
+// i.e. the learned flow matches all the packets from the same subnet.
+func (a *ofLearnAction) MatchLearnedSrcIPPrefix(isIPv6 bool, prefixLength int) LearnAction {
+	regName := NxmFieldSrcIPv4
+	ipBits := 4 * 8
+	if isIPv6 {
+		regName = NxmFieldSrcIPv6
+		ipBits = 16 * 8
+	}
+	// The most significant bits of the field are the highest bits of the field range.
+	start := uint16(ipBits - prefixLength)
+	a.nxLearn.AddMatch(&ofctrl.LearnField{Name: regName, Start: start}, uint16(prefixLength), &ofctrl.LearnField{Name: regName, Start: start}, nil)
+	return a
+}
+
 // MatchLearnedDstIP makes the learned flow match the nw_dst of current IP packet.
 func (a *ofLearnAction) MatchLearnedDstIP(isIPv6 bool) LearnAction {
 	regName := NxmFieldDstIPv4
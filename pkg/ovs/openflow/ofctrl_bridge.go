@@ -395,6 +395,12 @@ func (b *OFBridge) DumpFlows(cookieID, cookieMask uint64) (map[uint64]*FlowState
 	return parseFlowStats(ofStats), nil
 }
 
+// DumpFlowDescs queries the Openflow entries from OFSwitch. The filter of the query is Openflow cookieID; the result is
+// the descriptions of the Openflow entries, including their matches.
+func (b *OFBridge) DumpFlowDescs(cookieID, cookieMask uint64) ([]*openflow15.FlowDesc, error) {
+	return b.ofSwitch.DumpFlowStats(cookieID, &cookieMask, nil, nil)
+}
+
 // DeleteFlowsByCookie removes Openflow entries from OFSwitch. The removed Openflow entries use the specific CookieID.
 func (b *OFBridge) DeleteFlowsByCookie(cookieID, cookieMask uint64) error {
 	flowMod := openflow15.NewFlowMod()
@@ -614,6 +620,11 @@ func NewPacketInQueue(size int, r rate.Limit) *PacketInQueue {
 	return &PacketInQueue{rateLimiter: rate.NewLimiter(r, 1), packetsCh: make(chan *ofctrl.PacketIn, size)}
 }
 
+// SetRateLimit updates the maximum frequency of getting items from the queue.
+func (q *PacketInQueue) SetRateLimit(r rate.Limit) {
+	q.rateLimiter.SetLimit(r)
+}
+
 func (q *PacketInQueue) AddOrDrop(packet *ofctrl.PacketIn) bool {
 	select {
 	case q.packetsCh <- packet:
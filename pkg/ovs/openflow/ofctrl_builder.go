@@ -388,6 +388,13 @@ func (b *ofFlowBuilder) MatchIPDSCP(dscp uint8) FlowBuilder {
 	return b
 }
 
+// MatchIPTTL adds match condition for matching the TTL field in the IPv4 header, or the hop limit field in the IPv6
+// header. The field name is shown as "nw_ttl" with OVS command line.
+func (b *ofFlowBuilder) MatchIPTTL(ttl uint8) FlowBuilder {
+	b.Match.IpTtl = &ttl
+	return b
+}
+
 // MatchConjID adds match condition for matching conj_id.
 func (b *ofFlowBuilder) MatchConjID(value uint32) FlowBuilder {
 	b.Match.ConjunctionID = &value
@@ -150,6 +150,12 @@ func (b *bucketBuilder) SetTunnelDst(addr net.IP) BucketBuilder {
 	return b
 }
 
+// Group is an action to forward packets to another group when the bucket is selected, which allows to chain groups.
+func (b *bucketBuilder) Group(id GroupIDType) BucketBuilder {
+	b.bucket.AddAction(openflow15.NewActionGroup(uint32(id)))
+	return b
+}
+
 // Weight sets the weight of a bucket.
 func (b *bucketBuilder) Weight(val uint16) BucketBuilder {
 	weight := openflow15.NewGroupBucketPropWeight(val)
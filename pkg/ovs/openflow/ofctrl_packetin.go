@@ -83,9 +83,12 @@ func GetUDPHeaderData(ipPkt util.Message) (udpSrcPort, udpDstPort uint16, err er
 	var udpIn *protocol.UDP
 	switch typedIPPkt := ipPkt.(type) {
 	case *protocol.IPv4:
-		udpIn = typedIPPkt.Data.(*protocol.UDP)
+		udpIn, _ = typedIPPkt.Data.(*protocol.UDP)
 	case *protocol.IPv6:
-		udpIn = typedIPPkt.Data.(*protocol.UDP)
+		udpIn, _ = typedIPPkt.Data.(*protocol.UDP)
+	}
+	if udpIn == nil {
+		return 0, 0, errors.New("failed to get UDP header from IP packet")
 	}
 	return udpIn.PortSrc, udpIn.PortDst, nil
 }
@@ -93,7 +96,10 @@ func GetUDPHeaderData(ipPkt util.Message) (udpSrcPort, udpDstPort uint16, err er
 func getICMPHeaderData(ipPkt util.Message) (icmpType, icmpCode uint8, icmpEchoID, icmpEchoSeq uint16, err error) {
 	switch typedIPPkt := ipPkt.(type) {
 	case *protocol.IPv4:
-		icmpIn := typedIPPkt.Data.(*protocol.ICMP)
+		icmpIn, ok := typedIPPkt.Data.(*protocol.ICMP)
+		if !ok {
+			return 0, 0, 0, 0, errors.New("failed to get ICMP header from IPv4 packet")
+		}
 		if icmpIn.Type == icmpEchoRequestType {
 			if len(icmpIn.Data) < 4 {
 				return 0, 0, 0, 0, errors.New("ICMP payload is too short to unmarshal an ICMP echo message")
@@ -104,7 +110,12 @@ func getICMPHeaderData(ipPkt util.Message) (icmpType, icmpCode uint8, icmpEchoID
 		icmpType = icmpIn.Type
 		icmpCode = icmpIn.Code
 	case *protocol.IPv6:
-		icmpIn := typedIPPkt.Data.(*protocol.ICMPv6EchoReqRpl)
+		// Other ICMPv6 messages than echo request and reply (e.g.
+		// Neighbor Discovery) are decoded into different types.
+		icmpIn, ok := typedIPPkt.Data.(*protocol.ICMPv6EchoReqRpl)
+		if !ok {
+			return 0, 0, 0, 0, errors.New("failed to get ICMPv6 echo header from IPv6 packet")
+		}
 		if icmpIn.Type == icmp6EchoRequestType {
 			icmpEchoID = icmpIn.Identifier
 			icmpEchoSeq = icmpIn.SeqNum
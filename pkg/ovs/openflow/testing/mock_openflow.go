@@ -151,6 +151,21 @@ func (mr *MockBridgeMockRecorder) Disconnect() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnect", reflect.TypeOf((*MockBridge)(nil).Disconnect))
 }
 
+// DumpFlowDescs mocks base method
+func (m *MockBridge) DumpFlowDescs(arg0, arg1 uint64) ([]*openflow15.FlowDesc, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DumpFlowDescs", arg0, arg1)
+	ret0, _ := ret[0].([]*openflow15.FlowDesc)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DumpFlowDescs indicates an expected call of DumpFlowDescs
+func (mr *MockBridgeMockRecorder) DumpFlowDescs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DumpFlowDescs", reflect.TypeOf((*MockBridge)(nil).DumpFlowDescs), arg0, arg1)
+}
+
 // DumpFlows mocks base method
 func (m *MockBridge) DumpFlows(arg0, arg1 uint64) (map[uint64]*openflow.FlowStates, error) {
 	m.ctrl.T.Helper()
@@ -1845,6 +1860,20 @@ func (mr *MockFlowBuilderMockRecorder) MatchIPDSCP(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchIPDSCP", reflect.TypeOf((*MockFlowBuilder)(nil).MatchIPDSCP), arg0)
 }
 
+// MatchIPTTL mocks base method
+func (m *MockFlowBuilder) MatchIPTTL(arg0 byte) openflow.FlowBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MatchIPTTL", arg0)
+	ret0, _ := ret[0].(openflow.FlowBuilder)
+	return ret0
+}
+
+// MatchIPTTL indicates an expected call of MatchIPTTL
+func (mr *MockFlowBuilderMockRecorder) MatchIPTTL(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MatchIPTTL", reflect.TypeOf((*MockFlowBuilder)(nil).MatchIPTTL), arg0)
+}
+
 // MatchIPProtocolValue mocks base method
 func (m *MockFlowBuilder) MatchIPProtocolValue(arg0 bool, arg1 byte) openflow.FlowBuilder {
 	m.ctrl.T.Helper()
@@ -2272,6 +2301,20 @@ func (mr *MockBucketBuilderMockRecorder) Done() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Done", reflect.TypeOf((*MockBucketBuilder)(nil).Done))
 }
 
+// Group mocks base method
+func (m *MockBucketBuilder) Group(arg0 openflow.GroupIDType) openflow.BucketBuilder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Group", arg0)
+	ret0, _ := ret[0].(openflow.BucketBuilder)
+	return ret0
+}
+
+// Group indicates an expected call of Group
+func (mr *MockBucketBuilderMockRecorder) Group(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Group", reflect.TypeOf((*MockBucketBuilder)(nil).Group), arg0)
+}
+
 // LoadRegMark mocks base method
 func (m *MockBucketBuilder) LoadRegMark(arg0 *openflow.RegMark) openflow.BucketBuilder {
 	m.ctrl.T.Helper()
@@ -414,6 +414,11 @@ func matchIpDscpToString(field *openflow15.MatchField) string {
 	return fmt.Sprintf("ip_dscp=%d", field.Value.(*openflow15.IpDscpField).Dscp)
 }
 
+func matchNwTTLToString(field *openflow15.MatchField) string {
+	data, _ := field.Value.MarshalBinary()
+	return fmt.Sprintf("nw_ttl=%d", data[0])
+}
+
 func matchTpPortToString(field *openflow15.MatchField, isCt, isSrc bool) string {
 	var matchKey string
 	if isCt {
@@ -1076,7 +1081,11 @@ func getFlowModMatch(flowMod *openflow15.FlowMod) string {
 		parts = append(parts, matchIpDscpToString(field))
 	}
 
-	// TODO: add support for field "nw_ecn", "nw_ttl", other match conditions about MPLS, and "nw_frag"
+	if field, ok := matchMap["nw_ttl"]; ok {
+		parts = append(parts, matchNwTTLToString(field))
+	}
+
+	// TODO: add support for field "nw_ecn", other match conditions about MPLS, and "nw_frag"
 
 	if field, ok := matchMap["icmp_type"]; ok {
 		parts = append(parts, matchIcmpTypeToString(field))
@@ -222,6 +222,12 @@ func TestFlowModMatchString(t *testing.T) {
 				return fb.MatchProtocol(ProtocolIP).MatchIPDSCP(12).Done()
 			},
 			expectedMatch: "table=1,priority=100,ip,in_port=3,ip_dscp=12",
+		}, {
+			name: "IP TTL flow",
+			flowFunc: func(fb *ofFlowBuilder) Flow {
+				return fb.MatchProtocol(ProtocolIP).MatchIPTTL(1).Done()
+			},
+			expectedMatch: "table=1,priority=100,ip,in_port=3,nw_ttl=1",
 		}, {
 			name: "IPv6 TCP flow",
 			flowFunc: func(fb *ofFlowBuilder) Flow {
@@ -30,6 +30,12 @@ const (
 	ERSPANTunnel = "erspan"
 
 	OVSDatapathSystem OVSDatapathType = "system"
+	// OVSDatapathNetdev is the userspace datapath. With it, the network devices attached to the bridge, e.g. the
+	// uplink and the Pod interfaces, are accessed by OVS through AF_XDP sockets.
+	OVSDatapathNetdev OVSDatapathType = "netdev"
+
+	// AFXDPInterfaceType is the type of the OVS Interfaces backed by an AF_XDP socket.
+	AFXDPInterfaceType = "afxdp"
 
 	OVSOtherConfigDatapathIDKey string = "datapath-id"
 )
@@ -46,6 +52,7 @@ type OVSBridgeClient interface {
 	CreatePort(name, ifDev string, externalIDs map[string]interface{}) (string, Error)
 	CreateAccessPort(name, ifDev string, externalIDs map[string]interface{}, vlanID uint16) (string, Error)
 	CreateInternalPort(name string, ofPortRequest int32, mac string, externalIDs map[string]interface{}) (string, Error)
+	CreatePatchPort(name, peerName string, ofPortRequest int32, externalIDs map[string]interface{}) (string, Error)
 	CreateTunnelPort(name string, tunnelType TunnelType, ofPortRequest int32) (string, Error)
 	CreateTunnelPortExt(name string, tunnelType TunnelType, ofPortRequest int32, csum bool, localIP string, remoteIP string, remoteName string, psk string, extraOptions, externalIDs map[string]interface{}) (string, Error)
 	CreateUplinkPort(name string, ofPortRequest int32, externalIDs map[string]interface{}) (string, Error)
@@ -57,6 +64,8 @@ type OVSBridgeClient interface {
 	AllocateOFPort(startPort int) (int32, error)
 	SetInterfaceMTU(name string, MTU int) error
 	GetOVSVersion() (string, Error)
+	GetOVSInterfaceTypes() ([]string, Error)
+	GetInterfaceStatistics(ifType string) (map[string]map[string]int64, Error)
 	AddOVSOtherConfig(configs map[string]interface{}) Error
 	GetOVSOtherConfig() (map[string]string, Error)
 	UpdateOVSOtherConfig(configs map[string]interface{}) Error
@@ -372,6 +372,20 @@ func (br *OVSBridge) CreateInternalPort(name string, ofPortRequest int32, mac st
 	return br.createPort(name, name, "internal", ofPortRequest, 0, mac, externalIDs, nil)
 }
 
+// CreatePatchPort creates a patch port with the specified name on the bridge,
+// whose peer is the patch port specified by peerName, which is expected to be
+// created on another bridge.
+// If externalIDs is not empty, the map key/value pairs will be set to the
+// port's external_ids.
+// If ofPortRequest is not zero, it will be passed to the OVS port creation.
+func (br *OVSBridge) CreatePatchPort(name, peerName string, ofPortRequest int32, externalIDs map[string]interface{}) (string, Error) {
+	if ofPortRequest < 0 || ofPortRequest > ofPortRequestMax {
+		return "", newInvalidArgumentsError(fmt.Sprint("invalid ofPortRequest value: ", ofPortRequest))
+	}
+	options := map[string]interface{}{"peer": peerName}
+	return br.createPort(name, name, "patch", ofPortRequest, 0, "", externalIDs, options)
+}
+
 // CreateTunnelPort creates a tunnel port with the specified name and type on
 // the bridge.
 // If ofPortRequest is not zero, it will be passed to the OVS port creation.
@@ -533,7 +547,7 @@ func ParseTunnelInterfaceOptions(portData *OVSPortData) (net.IP, net.IP, int32,
 
 // CreateUplinkPort creates uplink port.
 func (br *OVSBridge) CreateUplinkPort(name string, ofPortRequest int32, externalIDs map[string]interface{}) (string, Error) {
-	return br.createPort(name, name, "", ofPortRequest, 0, "", externalIDs, nil)
+	return br.createPort(name, name, br.attachedInterfaceType(), ofPortRequest, 0, "", externalIDs, nil)
 }
 
 // CreatePort creates a port with the specified name on the bridge, and connects
@@ -541,7 +555,7 @@ func (br *OVSBridge) CreateUplinkPort(name string, ofPortRequest int32, external
 // If externalIDs is not empty, the map key/value pairs will be set to the
 // port's external_ids.
 func (br *OVSBridge) CreatePort(name, ifDev string, externalIDs map[string]interface{}) (string, Error) {
-	return br.createPort(name, ifDev, "", 0, 0, "", externalIDs, nil)
+	return br.createPort(name, ifDev, br.attachedInterfaceType(), 0, 0, "", externalIDs, nil)
 }
 
 // CreateAccessPort creates a port with the specified name and VLAN ID on the bridge, and connects
@@ -550,7 +564,16 @@ func (br *OVSBridge) CreatePort(name, ifDev string, externalIDs map[string]inter
 // port's external_ids.
 // vlanID=0 will perform same behavior as CreatePort.
 func (br *OVSBridge) CreateAccessPort(name, ifDev string, externalIDs map[string]interface{}, vlanID uint16) (string, Error) {
-	return br.createPort(name, ifDev, "", 0, vlanID, "", externalIDs, nil)
+	return br.createPort(name, ifDev, br.attachedInterfaceType(), 0, vlanID, "", externalIDs, nil)
+}
+
+// attachedInterfaceType returns the type of the Interfaces created for the existing network devices attached to the
+// bridge. With the netdev datapath, OVS accesses the devices through AF_XDP sockets.
+func (br *OVSBridge) attachedInterfaceType() string {
+	if br.datapathType == OVSDatapathNetdev {
+		return AFXDPInterfaceType
+	}
+	return ""
 }
 
 func (br *OVSBridge) createPort(name, ifName, ifType string, ofPortRequest int32, vlanID uint16, mac string, externalIDs, options map[string]interface{}) (string, Error) {
@@ -682,6 +705,37 @@ func buildMapFromOVSDBMap(data []interface{}) map[string]string {
 	return map[string]string{}
 }
 
+// buildStatisticsFromOVSDBMap converts an OVSDB map of integers, e.g. the "statistics" column of the "Interface"
+// table, to a Go map.
+func buildStatisticsFromOVSDBMap(data []interface{}) map[string]int64 {
+	ret := make(map[string]int64)
+	if data[0] == "map" {
+		for _, pair := range data[1].([]interface{}) {
+			ret[pair.([]interface{})[0].(string)] = int64(pair.([]interface{})[1].(float64))
+		}
+	}
+	return ret
+}
+
+// buildSliceFromOVSDBSet converts an OVSDB set of strings to a Go slice. A set with a single element is encoded as the
+// element itself.
+func buildSliceFromOVSDBSet(data interface{}) []string {
+	switch v := data.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		if len(v) == 2 && v[0] == "set" {
+			elems := v[1].([]interface{})
+			ret := make([]string, 0, len(elems))
+			for _, elem := range elems {
+				ret = append(ret, elem.(string))
+			}
+			return ret
+		}
+	}
+	return nil
+}
+
 func buildPortDataCommon(port, intf map[string]interface{}, portData *OVSPortData) {
 	portData.Name = port["name"].(string)
 	portData.ExternalIDs = buildMapFromOVSDBMap(port["external_ids"].([]interface{}))
@@ -865,6 +919,53 @@ func (br *OVSBridge) GetOVSVersion() (string, Error) {
 	return parseOvsVersion(res[0].Rows[0])
 }
 
+// GetOVSInterfaceTypes returns the Interface types supported by OVS, from the "iface_types" column of the single
+// record in the "Open_vSwitch" table.
+func (br *OVSBridge) GetOVSInterfaceTypes() ([]string, Error) {
+	tx := br.ovsdb.Transaction(openvSwitchSchema)
+
+	tx.Select(dbtransaction.Select{
+		Table:   openvSwitchSchema,
+		Columns: []string{"iface_types"},
+	})
+
+	res, err, temporary := tx.Commit()
+	if err != nil {
+		klog.Error("Transaction failed: ", err)
+		return nil, NewTransactionError(err, temporary)
+	}
+
+	if len(res[0].Rows) == 0 {
+		klog.Warning("Could not find iface_types in the OVS query result")
+		return nil, NewTransactionError(fmt.Errorf("no results from OVS query"), false)
+	}
+	return buildSliceFromOVSDBSet(res[0].Rows[0].(map[string]interface{})["iface_types"]), nil
+}
+
+// GetInterfaceStatistics returns the statistics of the Interfaces of the given type, keyed by Interface name.
+func (br *OVSBridge) GetInterfaceStatistics(ifType string) (map[string]map[string]int64, Error) {
+	tx := br.ovsdb.Transaction(openvSwitchSchema)
+
+	tx.Select(dbtransaction.Select{
+		Table:   "Interface",
+		Columns: []string{"name", "statistics"},
+		Where:   [][]interface{}{{"type", "==", ifType}},
+	})
+
+	res, err, temporary := tx.Commit()
+	if err != nil {
+		klog.Error("Transaction failed: ", err)
+		return nil, NewTransactionError(err, temporary)
+	}
+
+	statistics := make(map[string]map[string]int64, len(res[0].Rows))
+	for _, row := range res[0].Rows {
+		intf := row.(map[string]interface{})
+		statistics[intf["name"].(string)] = buildStatisticsFromOVSDBMap(intf["statistics"].([]interface{}))
+	}
+	return statistics, nil
+}
+
 // parseOvsVersion parses the version from an interface type, which can be a map of string[interface] or string[string], and returns it as a string, we have special logic here so that a panic doesn't happen.
 func parseOvsVersion(ovsReturnRow interface{}) (string, Error) {
 	errorMessage := fmt.Errorf("unexpected transaction result when querying OVSDB %v", defaultOvsVersionMessage)
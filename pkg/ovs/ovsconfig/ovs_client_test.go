@@ -118,3 +118,14 @@ func TestBuildPortDataCommon(t *testing.T) {
 	}
 
 }
+
+func TestBuildSliceFromOVSDBSet(t *testing.T) {
+	assert.Equal(t, []string{"afxdp"}, buildSliceFromOVSDBSet("afxdp"))
+	assert.Equal(t, []string{"afxdp", "internal", "tap"}, buildSliceFromOVSDBSet([]interface{}{"set", []interface{}{"afxdp", "internal", "tap"}}))
+	assert.Equal(t, []string{}, buildSliceFromOVSDBSet([]interface{}{"set", []interface{}{}}))
+}
+
+func TestBuildStatisticsFromOVSDBMap(t *testing.T) {
+	assert.Equal(t, map[string]int64{"rx_packets": 10, "tx_bytes": 1000}, buildStatisticsFromOVSDBMap([]interface{}{"map", []interface{}{[]interface{}{"rx_packets", float64(10)}, []interface{}{"tx_bytes", float64(1000)}}}))
+	assert.Equal(t, map[string]int64{}, buildStatisticsFromOVSDBMap([]interface{}{"map", []interface{}{}}))
+}
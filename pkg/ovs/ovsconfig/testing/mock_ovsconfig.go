@@ -136,6 +136,21 @@ func (mr *MockOVSBridgeClientMockRecorder) CreateInternalPort(arg0, arg1, arg2,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInternalPort", reflect.TypeOf((*MockOVSBridgeClient)(nil).CreateInternalPort), arg0, arg1, arg2, arg3)
 }
 
+// CreatePatchPort mocks base method
+func (m *MockOVSBridgeClient) CreatePatchPort(arg0, arg1 string, arg2 int32, arg3 map[string]interface{}) (string, ovsconfig.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePatchPort", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(ovsconfig.Error)
+	return ret0, ret1
+}
+
+// CreatePatchPort indicates an expected call of CreatePatchPort
+func (mr *MockOVSBridgeClientMockRecorder) CreatePatchPort(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePatchPort", reflect.TypeOf((*MockOVSBridgeClient)(nil).CreatePatchPort), arg0, arg1, arg2, arg3)
+}
+
 // CreatePort mocks base method
 func (m *MockOVSBridgeClient) CreatePort(arg0, arg1 string, arg2 map[string]interface{}) (string, ovsconfig.Error) {
 	m.ctrl.T.Helper()
@@ -311,6 +326,21 @@ func (mr *MockOVSBridgeClientMockRecorder) GetInterfaceOptions(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterfaceOptions", reflect.TypeOf((*MockOVSBridgeClient)(nil).GetInterfaceOptions), arg0)
 }
 
+// GetInterfaceStatistics mocks base method
+func (m *MockOVSBridgeClient) GetInterfaceStatistics(arg0 string) (map[string]map[string]int64, ovsconfig.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInterfaceStatistics", arg0)
+	ret0, _ := ret[0].(map[string]map[string]int64)
+	ret1, _ := ret[1].(ovsconfig.Error)
+	return ret0, ret1
+}
+
+// GetInterfaceStatistics indicates an expected call of GetInterfaceStatistics
+func (mr *MockOVSBridgeClientMockRecorder) GetInterfaceStatistics(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterfaceStatistics", reflect.TypeOf((*MockOVSBridgeClient)(nil).GetInterfaceStatistics), arg0)
+}
+
 // GetOFPort mocks base method
 func (m *MockOVSBridgeClient) GetOFPort(arg0 string, arg1 bool) (int32, ovsconfig.Error) {
 	m.ctrl.T.Helper()
@@ -340,6 +370,21 @@ func (mr *MockOVSBridgeClientMockRecorder) GetOVSDatapathType() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOVSDatapathType", reflect.TypeOf((*MockOVSBridgeClient)(nil).GetOVSDatapathType))
 }
 
+// GetOVSInterfaceTypes mocks base method
+func (m *MockOVSBridgeClient) GetOVSInterfaceTypes() ([]string, ovsconfig.Error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOVSInterfaceTypes")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(ovsconfig.Error)
+	return ret0, ret1
+}
+
+// GetOVSInterfaceTypes indicates an expected call of GetOVSInterfaceTypes
+func (mr *MockOVSBridgeClientMockRecorder) GetOVSInterfaceTypes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOVSInterfaceTypes", reflect.TypeOf((*MockOVSBridgeClient)(nil).GetOVSInterfaceTypes))
+}
+
 // GetOVSOtherConfig mocks base method
 func (m *MockOVSBridgeClient) GetOVSOtherConfig() (map[string]string, ovsconfig.Error) {
 	m.ctrl.T.Helper()
@@ -42,6 +42,10 @@ type OVSCtlClient interface {
 	DumpGroup(groupID uint32) (string, error)
 	// DumpGroups returns OpenFlow groups of the bridge.
 	DumpGroups() ([]string, error)
+	// DumpMeters returns OpenFlow meters of the bridge.
+	DumpMeters() ([]string, error)
+	// DumpMeterStats returns the statistics of the OpenFlow meters of the bridge.
+	DumpMeterStats() ([]MeterStats, error)
 	// DumpPortsDesc returns OpenFlow ports descriptions of the bridge.
 	DumpPortsDesc() ([][]string, error)
 	// SetPortNoFlood sets the given port with config "no-flood". This configuration must work with OpenFlow10.
@@ -52,6 +56,22 @@ type OVSCtlClient interface {
 	GetDPFeatures() (map[DPFeature]bool, error)
 	// DeleteDPInterface executes "ovs-appctl dpctl/del-if ovs-system $name" to delete OVS datapath interface.
 	DeleteDPInterface(name string) error
+	// DumpDatapathFlows executes "ovs-appctl dpctl/dump-flows" to dump the flows of the OVS datapath, using port
+	// names instead of port numbers. If offloaded is true, only the flows offloaded to hardware are returned,
+	// otherwise only the flows processed in software are returned.
+	DumpDatapathFlows(offloaded bool) ([]string, error)
+}
+
+// MeterStats contains the statistics of an OpenFlow meter.
+type MeterStats struct {
+	MeterID uint32
+	// PacketCount and ByteCount are the numbers of packets and bytes processed by the meter.
+	PacketCount uint64
+	ByteCount   uint64
+	// DroppedPacketCount and DroppedByteCount are the numbers of packets and bytes dropped by
+	// the bands of the meter.
+	DroppedPacketCount uint64
+	DroppedByteCount   uint64
 }
 
 type BadRequestError string
@@ -256,6 +256,26 @@ func (c *ovsCtlClient) DeleteDPInterface(name string) error {
 	return nil
 }
 
+func (c *ovsCtlClient) DumpDatapathFlows(offloaded bool) ([]string, error) {
+	flowType := "type=ovs"
+	if offloaded {
+		flowType = "type=offloaded"
+	}
+	out, execErr := c.ovsAppctlRunner.RunAppctlCmd("dpctl/dump-flows", false, "--names", flowType)
+	if execErr != nil {
+		return nil, execErr
+	}
+	var flows []string
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	scanner.Split(bufio.ScanLines)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			flows = append(flows, line)
+		}
+	}
+	return flows, nil
+}
+
 func newBadRequestError(msg string) BadRequestError {
 	return BadRequestError(msg)
 }
@@ -365,6 +385,81 @@ func (c *ovsCtlClient) DumpGroups() ([]string, error) {
 	return groupList, nil
 }
 
+func (c *ovsCtlClient) DumpMeters() ([]string, error) {
+	metersDump, err := c.ovsOfctlRunner.RunOfctlCmd("dump-meters")
+	if err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(strings.NewReader(string(metersDump)))
+	scanner.Split(bufio.ScanLines)
+	// Skip the first line.
+	scanner.Scan()
+	meterList := []string{}
+	for scanner.Scan() {
+		meterList = append(meterList, strings.TrimSpace(scanner.Text()))
+	}
+	return meterList, nil
+}
+
+// DumpMeterStats parses the output of "ovs-ofctl dump-meter-stats", which looks like:
+//
+//	OFPST_METER reply (OF1.5) (xid=0x2):
+//	meter:256 flow_count:1 packet_in_count:120 byte_in_count:11760 duration:35.271s bands:
+//	0: packet_count:20 byte_count:1960
+func (c *ovsCtlClient) DumpMeterStats() ([]MeterStats, error) {
+	statsDump, err := c.ovsOfctlRunner.RunOfctlCmd("dump-meter-stats")
+	if err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(strings.NewReader(string(statsDump)))
+	scanner.Split(bufio.ScanLines)
+	// Skip the first line.
+	scanner.Scan()
+	statsList := []MeterStats{}
+	var current *MeterStats
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if strings.HasPrefix(fields[0], "meter:") {
+			current = nil
+			// Skip the special meters, e.g. "meter:slowpath".
+			meterID, err := strconv.ParseUint(strings.TrimPrefix(fields[0], "meter:"), 10, 32)
+			if err != nil {
+				continue
+			}
+			statsList = append(statsList, MeterStats{MeterID: uint32(meterID)})
+			current = &statsList[len(statsList)-1]
+			for _, field := range fields[1:] {
+				key, value, _ := strings.Cut(field, ":")
+				switch key {
+				case "packet_in_count":
+					current.PacketCount, _ = strconv.ParseUint(value, 10, 64)
+				case "byte_in_count":
+					current.ByteCount, _ = strconv.ParseUint(value, 10, 64)
+				}
+			}
+			continue
+		}
+		// The following lines are the statistics of the bands of the current meter.
+		if current == nil {
+			continue
+		}
+		for _, field := range fields[1:] {
+			key, value, _ := strings.Cut(field, ":")
+			count, _ := strconv.ParseUint(value, 10, 64)
+			switch key {
+			case "packet_count":
+				current.DroppedPacketCount += count
+			case "byte_count":
+				current.DroppedByteCount += count
+			}
+		}
+	}
+	return statsList, nil
+}
+
 func (c *ovsCtlClient) DumpPortsDesc() ([][]string, error) {
 	portsDescDump, err := c.ovsOfctlRunner.RunOfctlCmd("dump-ports-desc")
 	if err != nil {
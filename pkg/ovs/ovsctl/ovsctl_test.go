@@ -283,6 +283,27 @@ func TestDeleteDPInterface(t *testing.T) {
 	})
 }
 
+func TestDumpDatapathFlows(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockOVSAppctlRunner := NewMockOVSAppctlRunner(ctrl)
+	client := &ovsCtlClient{
+		bridge:          "br-int",
+		ovsAppctlRunner: mockOVSAppctlRunner,
+	}
+	offloadedFlows := `recirc_id(0),in_port(eth0),eth_type(0x0800),ipv4(src=192.168.1.17,frag=no), packets:10, bytes:1000, used:0.550s, actions:ct(zone=65520),recirc(0x18)
+ct_state(+est+trk),recirc_id(0x18),in_port(eth0),eth_type(0x0800),ipv4(dst=192.168.1.0/255.255.255.0,frag=no), packets:10, bytes:1000, used:0.550s, actions:eth1
+`
+	mockOVSAppctlRunner.EXPECT().RunAppctlCmd("dpctl/dump-flows", false, "--names", "type=offloaded").Return([]byte(offloadedFlows), nil)
+	flows, err := client.DumpDatapathFlows(true)
+	require.NoError(t, err)
+	assert.Len(t, flows, 2)
+
+	mockOVSAppctlRunner.EXPECT().RunAppctlCmd("dpctl/dump-flows", false, "--names", "type=ovs").Return([]byte(""), nil)
+	flows, err = client.DumpDatapathFlows(false)
+	require.NoError(t, err)
+	assert.Empty(t, flows)
+}
+
 func TestOfCtl(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -395,6 +416,52 @@ func TestOfCtl(t *testing.T) {
 		}
 		assert.Equal(expectedGroups, out)
 	})
+	t.Run("Dump Meters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOVSOfctlRunner := NewMockOVSOfctlRunner(ctrl)
+		client := &ovsCtlClient{
+			bridge:         "br-int",
+			ovsOfctlRunner: mockOVSOfctlRunner,
+		}
+		metersDump := []string{
+			"OFPST_METER_CONFIG reply (OF1.5) (xid=0x2):",
+			"meter=256 pktps burst stats bands=",
+			"type=drop rate=500 burst_size=1000",
+		}
+		mockOVSOfctlRunner.EXPECT().RunOfctlCmd("dump-meters").Return([]byte(strings.Join(metersDump, "\n")), nil)
+		out, err := client.DumpMeters()
+		require.NoError(err)
+		expectedMeters := []string{
+			"meter=256 pktps burst stats bands=",
+			"type=drop rate=500 burst_size=1000",
+		}
+		assert.Equal(expectedMeters, out)
+	})
+	t.Run("Dump Meter Stats", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockOVSOfctlRunner := NewMockOVSOfctlRunner(ctrl)
+		client := &ovsCtlClient{
+			bridge:         "br-int",
+			ovsOfctlRunner: mockOVSOfctlRunner,
+		}
+		statsDump := []string{
+			"OFPST_METER reply (OF1.5) (xid=0x2):",
+			"meter:1 flow_count:2 packet_in_count:0 byte_in_count:0 duration:12.301s bands:",
+			"0: packet_count:0 byte_count:0",
+			"meter:slowpath flow_count:0 packet_in_count:3 byte_in_count:180 duration:12.301s bands:",
+			"0: packet_count:1 byte_count:60",
+			"meter:1048577 flow_count:2 packet_in_count:120 byte_in_count:11760 duration:35.271s bands:",
+			"0: packet_count:20 byte_count:1960",
+		}
+		mockOVSOfctlRunner.EXPECT().RunOfctlCmd("dump-meter-stats").Return([]byte(strings.Join(statsDump, "\n")), nil)
+		out, err := client.DumpMeterStats()
+		require.NoError(err)
+		expectedStats := []MeterStats{
+			{MeterID: 1},
+			{MeterID: 1048577, PacketCount: 120, ByteCount: 11760, DroppedPacketCount: 20, DroppedByteCount: 1960},
+		}
+		assert.Equal(expectedStats, out)
+	})
 	t.Run("Dump Group", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		mockOVSOfctlRunner := NewMockOVSOfctlRunner(ctrl)
@@ -62,6 +62,21 @@ func (mr *MockOVSCtlClientMockRecorder) DeleteDPInterface(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDPInterface", reflect.TypeOf((*MockOVSCtlClient)(nil).DeleteDPInterface), arg0)
 }
 
+// DumpDatapathFlows mocks base method
+func (m *MockOVSCtlClient) DumpDatapathFlows(arg0 bool) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DumpDatapathFlows", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DumpDatapathFlows indicates an expected call of DumpDatapathFlows
+func (mr *MockOVSCtlClientMockRecorder) DumpDatapathFlows(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DumpDatapathFlows", reflect.TypeOf((*MockOVSCtlClient)(nil).DumpDatapathFlows), arg0)
+}
+
 // DumpFlows mocks base method
 func (m *MockOVSCtlClient) DumpFlows(arg0 ...string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -145,6 +160,36 @@ func (mr *MockOVSCtlClientMockRecorder) DumpMatchedFlow(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DumpMatchedFlow", reflect.TypeOf((*MockOVSCtlClient)(nil).DumpMatchedFlow), arg0)
 }
 
+// DumpMeterStats mocks base method
+func (m *MockOVSCtlClient) DumpMeterStats() ([]ovsctl.MeterStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DumpMeterStats")
+	ret0, _ := ret[0].([]ovsctl.MeterStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DumpMeterStats indicates an expected call of DumpMeterStats
+func (mr *MockOVSCtlClientMockRecorder) DumpMeterStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DumpMeterStats", reflect.TypeOf((*MockOVSCtlClient)(nil).DumpMeterStats))
+}
+
+// DumpMeters mocks base method
+func (m *MockOVSCtlClient) DumpMeters() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DumpMeters")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DumpMeters indicates an expected call of DumpMeters
+func (mr *MockOVSCtlClientMockRecorder) DumpMeters() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DumpMeters", reflect.TypeOf((*MockOVSCtlClient)(nil).DumpMeters))
+}
+
 // DumpPortsDesc mocks base method
 func (m *MockOVSCtlClient) DumpPortsDesc() ([][]string, error) {
 	m.ctrl.T.Helper()
@@ -15,9 +15,12 @@
 package querier
 
 import (
+	"net"
+
 	v1 "k8s.io/api/core/v1"
 	apitypes "k8s.io/apimachinery/pkg/types"
 
+	"antrea.io/antrea/pkg/agent/flowexporter"
 	"antrea.io/antrea/pkg/agent/interfacestore"
 	"antrea.io/antrea/pkg/agent/multicast"
 	"antrea.io/antrea/pkg/agent/types"
@@ -41,6 +44,33 @@ type AgentNetworkPolicyInfoQuerier interface {
 	GetAppliedNetworkPolicies(pod, namespace string, npFilter *NetworkPolicyQueryFilter) []cpv1beta.NetworkPolicy
 	GetNetworkPolicyByRuleFlowID(ruleFlowID uint32) *cpv1beta.NetworkPolicyReference
 	GetRuleByFlowID(ruleFlowID uint32) *types.PolicyRule
+	GetFQDNCache(fqdnFilter *FQDNCacheFilter) []types.DNSCacheEntry
+	FlushFQDNCache(fqdn string) error
+	// GetFQDNRuleMatches returns the match counts of the FQDNs of the FQDN policy rules realized
+	// on the Node. The counts are cumulative.
+	GetFQDNRuleMatches() []types.FQDNRuleMatches
+	// GetRuleSummaries returns the summaries of all the rules in the NetworkPolicy rule cache,
+	// sorted by rule ID.
+	GetRuleSummaries() []types.NetworkPolicyRuleSummary
+	// GetSupportedFeatures returns the NetworkPolicy features supported by the agent.
+	GetSupportedFeatures() []string
+	// GetUnsupportedFeatures returns the NetworkPolicy features required by the rules in the
+	// NetworkPolicy rule cache but not supported by the agent.
+	GetUnsupportedFeatures() []string
+	// PauseNetworkPolicy pauses the realization of a NetworkPolicy on the Node, keeping its
+	// current flows.
+	PauseNetworkPolicy(name string) error
+	// ResumeNetworkPolicy resumes the realization of a NetworkPolicy paused by PauseNetworkPolicy.
+	ResumeNetworkPolicy(name string) error
+	// GetPausedNetworkPolicies returns the names of the NetworkPolicies whose realization is
+	// paused, sorted by name.
+	GetPausedNetworkPolicies() []string
+	// SimulateFlow evaluates a flow against the rules in the NetworkPolicy rule cache and
+	// returns the action applied to it at each stage of the NetworkPolicy pipeline.
+	SimulateFlow(flow *SimulatedFlow) *types.PolicySimulationResult
+	// ExpireDeletedRules deletes the rules of deleted NetworkPolicies kept for async deletion
+	// immediately, and returns the number of deleted rules.
+	ExpireDeletedRules() int
 }
 
 type AgentMulticastInfoQuerier interface {
@@ -111,6 +141,64 @@ type NetworkPolicyQueryFilter struct {
 	SourceType cpv1beta.NetworkPolicyType
 }
 
+// FQDNCacheFilter is used to filter the result while retrieving the DNS cache of FQDN policies.
+type FQDNCacheFilter struct {
+	// The FQDN to look up, which can be an exact name or a wildcard pattern, e.g. "*.example.com".
+	// An empty DomainName matches all FQDNs.
+	DomainName string
+}
+
+// SimulatedFlow describes a flow to evaluate against the NetworkPolicy rules realized on the Node.
+type SimulatedFlow struct {
+	SourceIP      net.IP
+	DestinationIP net.IP
+	// The IP protocol number of the flow.
+	Protocol uint8
+	// The transport ports of the flow. They are ignored for protocols without ports.
+	SourcePort      uint16
+	DestinationPort uint16
+}
+
+// ConnectionFilter is used to filter the result while retrieving the conntrack connections of the Node. An empty
+// attribute, which won't be used as a condition, means match all.
+type ConnectionFilter struct {
+	// The Namespace of the Pod or the Service.
+	Namespace string
+	// The name of a local Pod, which matches the connections from or to the Pod.
+	PodName string
+	// The name of a Service, which matches the connections to the Service.
+	ServiceName string
+	// The source IP and port of the connections.
+	SourceIP   net.IP
+	SourcePort uint16
+	// The destination IP and port of the connections, which match either the original destination, e.g. the
+	// ClusterIP of a Service, or the translated destination, e.g. the Endpoint of a Service.
+	DestinationIP   net.IP
+	DestinationPort uint16
+	// The IP protocol number of the connections.
+	Protocol uint8
+}
+
+// AgentConnectionQuerier queries the connections tracked by conntrack in the Antrea zones of the Node for
+// debugging purposes.
+type AgentConnectionQuerier interface {
+	GetConnections(filter *ConnectionFilter) ([]*flowexporter.Connection, error)
+}
+
+// AgentOVSOffloadQuerier queries the hardware offload status of the OVS datapath flows for debugging purposes. This
+// should only be used when OVS hardware offload is enabled.
+type AgentOVSOffloadQuerier interface {
+	GetOVSOffloadStatus() []OVSOffloadStatus
+}
+
+// OVSOffloadStatus contains the numbers of offloaded and non-offloaded OVS datapath flows of a flow category, which
+// is determined by the type of the input port of the flows.
+type OVSOffloadStatus struct {
+	Category          string
+	OffloadedFlows    int
+	NonOffloadedFlows int
+}
+
 // ServiceExternalIPStatusQuerier queries the Service external IP status for debugging purposes.
 // Ideally, every Node should have consistent results eventually. This should only be used when
 // ServiceExternalIP feature is enabled.
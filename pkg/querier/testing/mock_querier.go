@@ -14,12 +14,13 @@
 //
 
 // Code generated by MockGen. DO NOT EDIT.
-// Source: antrea.io/antrea/pkg/querier (interfaces: AgentNetworkPolicyInfoQuerier,AgentMulticastInfoQuerier,EgressQuerier)
+// Source: antrea.io/antrea/pkg/querier (interfaces: AgentNetworkPolicyInfoQuerier,AgentMulticastInfoQuerier,EgressQuerier,AgentConnectionQuerier,AgentOVSOffloadQuerier)
 
 // Package testing is a generated GoMock package.
 package testing
 
 import (
+	flowexporter "antrea.io/antrea/pkg/agent/flowexporter"
 	interfacestore "antrea.io/antrea/pkg/agent/interfacestore"
 	multicast "antrea.io/antrea/pkg/agent/multicast"
 	types "antrea.io/antrea/pkg/agent/types"
@@ -53,6 +54,34 @@ func (m *MockAgentNetworkPolicyInfoQuerier) EXPECT() *MockAgentNetworkPolicyInfo
 	return m.recorder
 }
 
+// ExpireDeletedRules mocks base method
+func (m *MockAgentNetworkPolicyInfoQuerier) ExpireDeletedRules() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireDeletedRules")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// ExpireDeletedRules indicates an expected call of ExpireDeletedRules
+func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) ExpireDeletedRules() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireDeletedRules", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).ExpireDeletedRules))
+}
+
+// FlushFQDNCache mocks base method
+func (m *MockAgentNetworkPolicyInfoQuerier) FlushFQDNCache(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushFQDNCache", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlushFQDNCache indicates an expected call of FlushFQDNCache
+func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) FlushFQDNCache(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushFQDNCache", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).FlushFQDNCache), arg0)
+}
+
 // GetAddressGroupNum mocks base method
 func (m *MockAgentNetworkPolicyInfoQuerier) GetAddressGroupNum() int {
 	m.ctrl.T.Helper()
@@ -137,6 +166,34 @@ func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) GetControllerConnection
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetControllerConnectionStatus", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).GetControllerConnectionStatus))
 }
 
+// GetFQDNCache mocks base method
+func (m *MockAgentNetworkPolicyInfoQuerier) GetFQDNCache(arg0 *querier.FQDNCacheFilter) []types.DNSCacheEntry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFQDNCache", arg0)
+	ret0, _ := ret[0].([]types.DNSCacheEntry)
+	return ret0
+}
+
+// GetFQDNCache indicates an expected call of GetFQDNCache
+func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) GetFQDNCache(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFQDNCache", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).GetFQDNCache), arg0)
+}
+
+// GetFQDNRuleMatches mocks base method
+func (m *MockAgentNetworkPolicyInfoQuerier) GetFQDNRuleMatches() []types.FQDNRuleMatches {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFQDNRuleMatches")
+	ret0, _ := ret[0].([]types.FQDNRuleMatches)
+	return ret0
+}
+
+// GetFQDNRuleMatches indicates an expected call of GetFQDNRuleMatches
+func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) GetFQDNRuleMatches() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFQDNRuleMatches", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).GetFQDNRuleMatches))
+}
+
 // GetNetworkPolicies mocks base method
 func (m *MockAgentNetworkPolicyInfoQuerier) GetNetworkPolicies(arg0 *querier.NetworkPolicyQueryFilter) []v1beta2.NetworkPolicy {
 	m.ctrl.T.Helper()
@@ -179,6 +236,20 @@ func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) GetNetworkPolicyNum() *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkPolicyNum", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).GetNetworkPolicyNum))
 }
 
+// GetPausedNetworkPolicies mocks base method
+func (m *MockAgentNetworkPolicyInfoQuerier) GetPausedNetworkPolicies() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPausedNetworkPolicies")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetPausedNetworkPolicies indicates an expected call of GetPausedNetworkPolicies
+func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) GetPausedNetworkPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPausedNetworkPolicies", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).GetPausedNetworkPolicies))
+}
+
 // GetRuleByFlowID mocks base method
 func (m *MockAgentNetworkPolicyInfoQuerier) GetRuleByFlowID(arg0 uint32) *types.PolicyRule {
 	m.ctrl.T.Helper()
@@ -193,6 +264,90 @@ func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) GetRuleByFlowID(arg0 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleByFlowID", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).GetRuleByFlowID), arg0)
 }
 
+// GetRuleSummaries mocks base method
+func (m *MockAgentNetworkPolicyInfoQuerier) GetRuleSummaries() []types.NetworkPolicyRuleSummary {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuleSummaries")
+	ret0, _ := ret[0].([]types.NetworkPolicyRuleSummary)
+	return ret0
+}
+
+// GetRuleSummaries indicates an expected call of GetRuleSummaries
+func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) GetRuleSummaries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleSummaries", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).GetRuleSummaries))
+}
+
+// GetSupportedFeatures mocks base method
+func (m *MockAgentNetworkPolicyInfoQuerier) GetSupportedFeatures() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupportedFeatures")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetSupportedFeatures indicates an expected call of GetSupportedFeatures
+func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) GetSupportedFeatures() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupportedFeatures", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).GetSupportedFeatures))
+}
+
+// GetUnsupportedFeatures mocks base method
+func (m *MockAgentNetworkPolicyInfoQuerier) GetUnsupportedFeatures() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnsupportedFeatures")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetUnsupportedFeatures indicates an expected call of GetUnsupportedFeatures
+func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) GetUnsupportedFeatures() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnsupportedFeatures", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).GetUnsupportedFeatures))
+}
+
+// PauseNetworkPolicy mocks base method
+func (m *MockAgentNetworkPolicyInfoQuerier) PauseNetworkPolicy(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseNetworkPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseNetworkPolicy indicates an expected call of PauseNetworkPolicy
+func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) PauseNetworkPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseNetworkPolicy", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).PauseNetworkPolicy), arg0)
+}
+
+// ResumeNetworkPolicy mocks base method
+func (m *MockAgentNetworkPolicyInfoQuerier) ResumeNetworkPolicy(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeNetworkPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResumeNetworkPolicy indicates an expected call of ResumeNetworkPolicy
+func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) ResumeNetworkPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeNetworkPolicy", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).ResumeNetworkPolicy), arg0)
+}
+
+// SimulateFlow mocks base method
+func (m *MockAgentNetworkPolicyInfoQuerier) SimulateFlow(arg0 *querier.SimulatedFlow) *types.PolicySimulationResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimulateFlow", arg0)
+	ret0, _ := ret[0].(*types.PolicySimulationResult)
+	return ret0
+}
+
+// SimulateFlow indicates an expected call of SimulateFlow
+func (mr *MockAgentNetworkPolicyInfoQuerierMockRecorder) SimulateFlow(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimulateFlow", reflect.TypeOf((*MockAgentNetworkPolicyInfoQuerier)(nil).SimulateFlow), arg0)
+}
+
 // MockAgentMulticastInfoQuerier is a mock of AgentMulticastInfoQuerier interface
 type MockAgentMulticastInfoQuerier struct {
 	ctrl     *gomock.Controller
@@ -326,3 +481,78 @@ func (mr *MockEgressQuerierMockRecorder) GetEgressIPByMark(arg0 interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEgressIPByMark", reflect.TypeOf((*MockEgressQuerier)(nil).GetEgressIPByMark), arg0)
 }
+
+// MockAgentConnectionQuerier is a mock of AgentConnectionQuerier interface
+type MockAgentConnectionQuerier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgentConnectionQuerierMockRecorder
+}
+
+// MockAgentConnectionQuerierMockRecorder is the mock recorder for MockAgentConnectionQuerier
+type MockAgentConnectionQuerierMockRecorder struct {
+	mock *MockAgentConnectionQuerier
+}
+
+// NewMockAgentConnectionQuerier creates a new mock instance
+func NewMockAgentConnectionQuerier(ctrl *gomock.Controller) *MockAgentConnectionQuerier {
+	mock := &MockAgentConnectionQuerier{ctrl: ctrl}
+	mock.recorder = &MockAgentConnectionQuerierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAgentConnectionQuerier) EXPECT() *MockAgentConnectionQuerierMockRecorder {
+	return m.recorder
+}
+
+// GetConnections mocks base method
+func (m *MockAgentConnectionQuerier) GetConnections(arg0 *querier.ConnectionFilter) ([]*flowexporter.Connection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConnections", arg0)
+	ret0, _ := ret[0].([]*flowexporter.Connection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConnections indicates an expected call of GetConnections
+func (mr *MockAgentConnectionQuerierMockRecorder) GetConnections(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnections", reflect.TypeOf((*MockAgentConnectionQuerier)(nil).GetConnections), arg0)
+}
+
+// MockAgentOVSOffloadQuerier is a mock of AgentOVSOffloadQuerier interface
+type MockAgentOVSOffloadQuerier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgentOVSOffloadQuerierMockRecorder
+}
+
+// MockAgentOVSOffloadQuerierMockRecorder is the mock recorder for MockAgentOVSOffloadQuerier
+type MockAgentOVSOffloadQuerierMockRecorder struct {
+	mock *MockAgentOVSOffloadQuerier
+}
+
+// NewMockAgentOVSOffloadQuerier creates a new mock instance
+func NewMockAgentOVSOffloadQuerier(ctrl *gomock.Controller) *MockAgentOVSOffloadQuerier {
+	mock := &MockAgentOVSOffloadQuerier{ctrl: ctrl}
+	mock.recorder = &MockAgentOVSOffloadQuerierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAgentOVSOffloadQuerier) EXPECT() *MockAgentOVSOffloadQuerierMockRecorder {
+	return m.recorder
+}
+
+// GetOVSOffloadStatus mocks base method
+func (m *MockAgentOVSOffloadQuerier) GetOVSOffloadStatus() []querier.OVSOffloadStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOVSOffloadStatus")
+	ret0, _ := ret[0].([]querier.OVSOffloadStatus)
+	return ret0
+}
+
+// GetOVSOffloadStatus indicates an expected call of GetOVSOffloadStatus
+func (mr *MockAgentOVSOffloadQuerierMockRecorder) GetOVSOffloadStatus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOVSOffloadStatus", reflect.TypeOf((*MockAgentOVSOffloadQuerier)(nil).GetOVSOffloadStatus))
+}
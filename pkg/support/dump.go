@@ -16,6 +16,7 @@ package support
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -28,6 +29,7 @@ import (
 
 	"github.com/spf13/afero"
 	"gopkg.in/yaml.v2"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/exec"
 
 	agentquerier "antrea.io/antrea/pkg/agent/querier"
@@ -61,6 +63,20 @@ type AgentDumper interface {
 
 	// DumpOVSPorts should create file that contains OF port descriptions under the basedir.
 	DumpOVSPorts(basedir string) error
+	// DumpOVSGroups should create a file that contains OF groups in JSON format under the
+	// basedir.
+	DumpOVSGroups(basedir string) error
+	// DumpOVSMeters should create a file that contains OF meters in JSON format under the
+	// basedir.
+	DumpOVSMeters(basedir string) error
+	// DumpProxierState should create files that contain the Services, Endpoints and group
+	// IDs installed by AntreaProxy, and the references of its Service IP routes, in JSON
+	// format under the basedir.
+	DumpProxierState(basedir string) error
+	// DumpNetworkPolicyRealization should create files that contain the summaries of the
+	// NetworkPolicy rules cached by the agent and the FQDN cache in JSON format under the
+	// basedir.
+	DumpNetworkPolicyRealization(basedir string) error
 	// DumpMemberlist should create a file that contains state of Memberlist
 	// cluster of the agent Pod under the basedir.
 	DumpMemberlist(basedir string) error
@@ -244,6 +260,16 @@ func writeYAMLFile(fs afero.Fs, filePath string, resource string, data interface
 	return nil
 }
 
+// writeJSONFile writes the given data to the specified filePath in JSON format. Param "resource" is
+// used to identify the type of the given data in the error message.
+func writeJSONFile(fs afero.Fs, filePath string, resource string, data interface{}) error {
+	output, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error when encoding %s in JSON format: %w", resource, err)
+	}
+	return writeFile(fs, filePath, resource, output)
+}
+
 type controllerDumper struct {
 	fs       afero.Fs
 	executor exec.Interface
@@ -329,6 +355,43 @@ func (d *agentDumper) DumpOVSPorts(basedir string) error {
 	return writeFile(d.fs, filepath.Join(basedir, "ovsports"), "ports", []byte(strings.Join(portData, "\n")))
 }
 
+func (d *agentDumper) DumpOVSGroups(basedir string) error {
+	groups, err := d.ovsCtlClient.DumpGroups()
+	if err != nil {
+		return fmt.Errorf("error when dumping groups: %w", err)
+	}
+	return writeJSONFile(d.fs, filepath.Join(basedir, "groups.json"), "groups", groups)
+}
+
+func (d *agentDumper) DumpOVSMeters(basedir string) error {
+	meters, err := d.ovsCtlClient.DumpMeters()
+	if err != nil {
+		// OVS meters are not supported by all datapaths, in which case there is nothing to dump.
+		klog.ErrorS(err, "Error when dumping meters, skipping them")
+		return nil
+	}
+	return writeJSONFile(d.fs, filepath.Join(basedir, "meters.json"), "meters", meters)
+}
+
+func (d *agentDumper) DumpProxierState(basedir string) error {
+	proxier := d.aq.GetProxier()
+	if proxier == nil {
+		// AntreaProxy is disabled.
+		return nil
+	}
+	if err := writeJSONFile(d.fs, filepath.Join(basedir, "proxier.json"), "proxier state", proxier.GetServiceStates()); err != nil {
+		return err
+	}
+	return writeJSONFile(d.fs, filepath.Join(basedir, "proxier-routes.json"), "proxier route references", proxier.GetServiceIPRouteReferences())
+}
+
+func (d *agentDumper) DumpNetworkPolicyRealization(basedir string) error {
+	if err := writeJSONFile(d.fs, filepath.Join(basedir, "networkpolicy-rules.json"), "networkpolicy rules", d.npq.GetRuleSummaries()); err != nil {
+		return err
+	}
+	return writeJSONFile(d.fs, filepath.Join(basedir, "fqdn-cache.json"), "fqdn cache", d.npq.GetFQDNCache(&querier.FQDNCacheFilter{}))
+}
+
 func NewAgentDumper(fs afero.Fs, executor exec.Interface, ovsCtlClient ovsctl.OVSCtlClient, aq agentquerier.AgentQuerier, npq querier.AgentNetworkPolicyInfoQuerier, since string, v4Enabled, v6Enabled bool) AgentDumper {
 	return &agentDumper{
 		fs:           fs,
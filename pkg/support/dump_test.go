@@ -15,18 +15,22 @@
 package support
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 	"k8s.io/utils/exec"
 	exectesting "k8s.io/utils/exec/testing"
+
+	ovsctltest "antrea.io/antrea/pkg/ovs/ovsctl/testing"
 )
 
 var baseDir = filepath.Join("dir1", "dir2")
@@ -145,6 +149,28 @@ func TestWriteYAMLFile(t *testing.T) {
 	assert.Equal(t, data, fileData)
 }
 
+func TestWriteJSONFile(t *testing.T) {
+	type testData struct {
+		X string
+		Y int
+	}
+	data := testData{
+		X: "foo",
+		Y: 123,
+	}
+	resource := "test-data"
+	path := filepath.Join(baseDir, resource)
+	fs := afero.NewMemMapFs()
+	err := writeJSONFile(fs, path, resource, &data)
+	require.NoError(t, err)
+
+	fileContents, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	var fileData testData
+	require.NoError(t, json.Unmarshal(fileContents, &fileData))
+	assert.Equal(t, data, fileData)
+}
+
 func TestDumpAntctlGet(t *testing.T) {
 	name := "agentinfo"
 
@@ -195,3 +221,38 @@ func TestControllerDumpHeapPprof(t *testing.T) {
 	err := dumper.DumpHeapPprof(baseDir)
 	require.NoError(t, err)
 }
+
+func TestAgentDumpOVSGroupsAndMeters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ovsCtlClient := ovsctltest.NewMockOVSCtlClient(ctrl)
+	groups := []string{"group_id=1,type=select,bucket=bucket_id:0,actions=resubmit(,EndpointDNAT)"}
+	meters := []string{"meter=1 pktps burst stats bands=", "type=drop rate=100 burst_size=200"}
+	ovsCtlClient.EXPECT().DumpGroups().Return(groups, nil)
+	ovsCtlClient.EXPECT().DumpMeters().Return(meters, nil)
+
+	fs := afero.NewMemMapFs()
+	dumper := NewAgentDumper(fs, new(testExec), ovsCtlClient, nil, nil, "5s", true, false)
+	require.NoError(t, dumper.DumpOVSGroups(baseDir))
+	require.NoError(t, dumper.DumpOVSMeters(baseDir))
+
+	for file, expected := range map[string][]string{"groups.json": groups, "meters.json": meters} {
+		fileContents, err := afero.ReadFile(fs, filepath.Join(baseDir, file))
+		require.NoError(t, err)
+		var fileData []string
+		require.NoError(t, json.Unmarshal(fileContents, &fileData))
+		assert.Equal(t, expected, fileData, file)
+	}
+}
+
+func TestAgentDumpOVSMetersNotSupported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ovsCtlClient := ovsctltest.NewMockOVSCtlClient(ctrl)
+	ovsCtlClient.EXPECT().DumpMeters().Return(nil, fmt.Errorf("OpenFlow meters are not supported"))
+
+	fs := afero.NewMemMapFs()
+	dumper := NewAgentDumper(fs, new(testExec), ovsCtlClient, nil, nil, "5s", true, false)
+	require.NoError(t, dumper.DumpOVSMeters(baseDir))
+	exists, err := afero.Exists(fs, filepath.Join(baseDir, "meters.json"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -0,0 +1,201 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	runChaosTests       = flag.Bool("chaos", false, "Run the chaos tests, which disrupt the datapath of a Node while generating traffic")
+	maxChaosDisruption  = flag.Duration("chaos.maxDisruption", 30*time.Second, "Maximum allowed connectivity disruption when the datapath of a Node is disrupted")
+	maxChaosFailureRate = flag.Float64("chaos.maxFailureRate", 0.5, "Maximum allowed ratio of failed connectivity probes when the datapath of a Node is disrupted")
+)
+
+const (
+	ovsCtlScript = "/usr/share/openvswitch/scripts/ovs-ctl"
+	ovsDBFile    = "/var/run/openvswitch/conf.db"
+)
+
+func skipIfNotChaosTest(t *testing.T) {
+	if !*runChaosTests {
+		t.Skipf("Skipping test as chaos tests are not enabled, provide the -chaos flag to run them")
+	}
+}
+
+// trafficServers are the servers which the traffic generated by createTrafficProbers is sent to.
+type trafficServers struct {
+	clientName      string
+	localServerName string
+	peerServerName  string
+	service         *corev1.Service
+}
+
+func (s *trafficServers) cleanup(t *testing.T, data *TestData) {
+	if s.service != nil {
+		if err := data.deleteService(s.service.Namespace, s.service.Name); err != nil {
+			t.Errorf("Error when deleting Service '%s': %v", s.service.Name, err)
+		}
+	}
+	for _, name := range []string{s.clientName, s.localServerName, s.peerServerName} {
+		if name != "" {
+			deletePodWrapper(t, data, data.testNamespace, name)
+		}
+	}
+}
+
+// createTrafficProbers creates a client Pod on clientNode, a server Pod on clientNode and on
+// serverNode, and a ClusterIP Service selecting the server Pod on serverNode. It returns the
+// probers generating intra-Node Pod-to-Pod, inter-Node Pod-to-Pod and Service traffic from the
+// client Pod continuously when run with runWithConnectivityProbes.
+func (data *TestData) createTrafficProbers(t *testing.T, clientNode, serverNode string, budget time.Duration) ([]*connectivityProber, *trafficServers) {
+	servers := &trafficServers{}
+	clientName := randName("test-client-")
+	require.NoError(t, data.createBusyboxPodOnNode(clientName, data.testNamespace, clientNode, false))
+	servers.clientName = clientName
+	require.NoError(t, data.podWaitForRunning(defaultTimeout, clientName, data.testNamespace))
+
+	createServer := func(node string) *PodIPs {
+		name := randName("test-server-")
+		require.NoError(t, data.createNginxPodOnNode(name, data.testNamespace, node, false))
+		if node == clientNode {
+			servers.localServerName = name
+		} else {
+			servers.peerServerName = name
+		}
+		ips, err := data.podWaitForIPs(defaultTimeout, name, data.testNamespace)
+		require.NoError(t, err)
+		return ips
+	}
+	localServerIPs := createServer(clientNode)
+	peerServerIPs := createServer(serverNode)
+
+	svc, err := data.CreateService(servers.peerServerName, data.testNamespace, 80, 80, map[string]string{"antrea-e2e": servers.peerServerName}, false, false, corev1.ServiceTypeClusterIP, nil)
+	require.NoError(t, err)
+	servers.service = svc
+
+	httpProbe := func(host string) func() error {
+		url := fmt.Sprintf("http://%s", net.JoinHostPort(host, "80"))
+		return func() error {
+			_, stderr, err := data.runWgetCommandOnBusyboxWithRetry(clientName, data.testNamespace, url, 1)
+			if err != nil {
+				return fmt.Errorf("error when accessing %s: %v - stderr: %s", url, err, stderr)
+			}
+			return nil
+		}
+	}
+	probers := []*connectivityProber{
+		newConnectivityProber("Intra-Node Pod-to-Pod", budget, httpProbe(localServerIPs.ipStrings[0])),
+		newConnectivityProber("Inter-Node Pod-to-Pod", budget, httpProbe(peerServerIPs.ipStrings[0])),
+		newConnectivityProber("Service", budget, httpProbe(svc.Spec.ClusterIP)),
+	}
+	// Connectivity must be working before the datapath is disrupted, otherwise the measured
+	// disruptions are meaningless.
+	for _, p := range probers {
+		require.NoError(t, p.probe(), "%s connectivity is not working before disruption", p.name)
+	}
+	return probers, servers
+}
+
+// runOVSCommandOnNode runs a command in the antrea-ovs container of the antrea-agent Pod on the
+// Node.
+func (data *TestData) runOVSCommandOnNode(nodeName string, cmd []string) error {
+	antreaPodName, err := data.getAntreaPodOnNode(nodeName)
+	if err != nil {
+		return fmt.Errorf("error when retrieving the name of the Antrea Pod running on Node '%s': %v", nodeName, err)
+	}
+	stdout, stderr, err := data.RunCommandFromPod(antreaNamespace, antreaPodName, ovsContainerName, cmd)
+	if err != nil {
+		return fmt.Errorf("error when running %v in Antrea Pod '%s': %v - stdout: %s - stderr: %s", cmd, antreaPodName, err, stdout, stderr)
+	}
+	return nil
+}
+
+// restartOVSVSwitchdOnNode restarts ovs-vswitchd on the Node. ovs-ctl saves the OpenFlow flows
+// before stopping ovs-vswitchd and restores them after starting it, and antrea-agent replays its
+// flows once it has reconnected to OVS.
+func (data *TestData) restartOVSVSwitchdOnNode(nodeName string) error {
+	return data.runOVSCommandOnNode(nodeName, []string{ovsCtlScript, "--no-ovsdb-server", "--system-id=random", "restart", "--db-file=" + ovsDBFile})
+}
+
+// deleteOVSFlowsOnNode deletes all the OpenFlow flows and groups on the OVS bridge of the Node.
+// antrea-agent only replays its flows when it reconnects to OVS, so OVS is restarted in the same
+// command: ovs-ctl saves and restores the flows, which have been deleted, and antrea-agent must
+// then reinstall all of them.
+func (data *TestData) deleteOVSFlowsOnNode(nodeName string) error {
+	cmd := fmt.Sprintf("ovs-ofctl del-flows %[1]s ; ovs-ofctl del-groups %[1]s ; %[2]s --system-id=random restart --db-file=%[3]s", defaultBridgeName, ovsCtlScript, ovsDBFile)
+	return data.runOVSCommandOnNode(nodeName, []string{"bash", "-c", cmd})
+}
+
+// restartAntreaAgentOnNode deletes the antrea-agent Pod on the Node gracefully, e.g. as done by a
+// RollingUpdate, and waits for the new antrea-agent Pod to be running.
+func (data *TestData) restartAntreaAgentOnNode(nodeName string) error {
+	_, err := data.deleteAntreaAgentOnNode(nodeName, 30 /* grace period in seconds */, defaultTimeout)
+	return err
+}
+
+// TestDatapathContinuityUnderDisruptions generates intra-Node Pod-to-Pod, inter-Node Pod-to-Pod
+// and Service traffic continuously while the datapath of the client Node is disrupted, and checks
+// that connectivity is not disrupted for longer than -chaos.maxDisruption, and that the ratio of
+// failed probes doesn't exceed -chaos.maxFailureRate. The disruptions are:
+//   - restarting antrea-agent gracefully
+//   - restarting ovs-vswitchd
+//   - deleting all the OpenFlow flows and groups
+//
+// To run the test, provide the -chaos flag.
+func TestDatapathContinuityUnderDisruptions(t *testing.T) {
+	skipIfNotChaosTest(t)
+	skipIfNumNodesLessThan(t, 2)
+	skipIfHasWindowsNodes(t)
+
+	data, err := setupTest(t)
+	if err != nil {
+		t.Fatalf("Error when setting up test: %v", err)
+	}
+	defer teardownTest(t, data)
+
+	clientNode := workerNodeName(1)
+	serverNode := controlPlaneNodeName()
+	probers, servers := data.createTrafficProbers(t, clientNode, serverNode, *maxChaosDisruption)
+	defer servers.cleanup(t, data)
+
+	disruptions := []struct {
+		name    string
+		disrupt func(nodeName string) error
+	}{
+		{name: "AntreaAgentRestart", disrupt: data.restartAntreaAgentOnNode},
+		{name: "OVSVSwitchdRestart", disrupt: data.restartOVSVSwitchdOnNode},
+		{name: "FlowDeletion", disrupt: data.deleteOVSFlowsOnNode},
+	}
+	for _, d := range disruptions {
+		t.Run(d.name, func(t *testing.T) {
+			runWithConnectivityProbes(t, d.name, probers, func() error {
+				t.Logf("Disrupting the datapath of Node '%s': %s", clientNode, d.name)
+				return d.disrupt(clientNode)
+			})
+			for _, p := range probers {
+				assert.LessOrEqualf(t, p.failureRate(), *maxChaosFailureRate, "%s connectivity failure rate during %s exceeded the budget", p.name, d.name)
+			}
+		})
+	}
+}
@@ -343,6 +343,23 @@ func testEgressCRUD(t *testing.T, data *TestData) {
 			expectedNodes:    sets.New[string](nodeName(0), nodeName(1)),
 			expectedTotal:    2,
 		},
+		{
+			// The size of the range exceeds the maximum size of an IP range, only the first 65536 IPs are used.
+			name:    "two matching Nodes with large IPv6 range",
+			ipRange: v1alpha2.IPRange{Start: "2021:1:1::1", End: "2021:1:1:ffff:ffff:ffff:ffff:ffff"},
+			nodeSelector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      v1.LabelHostname,
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{nodeName(0), nodeName(1)},
+					},
+				},
+			},
+			expectedEgressIP: "2021:1:1::1",
+			expectedNodes:    sets.New[string](nodeName(0), nodeName(1)),
+			expectedTotal:    65536,
+		},
 		{
 			name:    "no matching Node",
 			ipRange: v1alpha2.IPRange{CIDR: "169.254.102.0/30"},
@@ -2202,6 +2202,18 @@ func (data *TestData) isProxyAll() (bool, error) {
 	return agentConf.AntreaProxy.ProxyAll, nil
 }
 
+func (data *TestData) isWindowsProxyAll() (bool, error) {
+	configMap, err := data.GetAntreaWindowsConfigMap(antreaNamespace)
+	if err != nil {
+		return false, err
+	}
+	var agentConf agentconfig.AgentConfig
+	if err := yaml.Unmarshal([]byte(configMap.Data[antreaAgentConfName]), &agentConf); err != nil {
+		return false, fmt.Errorf("failed to unmarshal Windows Agent config from ConfigMap: %v", err)
+	}
+	return agentConf.AntreaProxy.ProxyAll, nil
+}
+
 func GetAgentFeatures() (featuregate.FeatureGate, error) {
 	featureGate := features.DefaultMutableFeatureGate.DeepCopy()
 	var cfg agentconfig.AgentConfig
@@ -0,0 +1,186 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+	mcsclientset "sigs.k8s.io/mcs-api/pkg/client/clientset/versioned"
+
+	mcv1alpha1 "antrea.io/antrea/multicluster/apis/multicluster/v1alpha1"
+	"antrea.io/antrea/multicluster/controllers/multicluster/common"
+	mcclientset "antrea.io/antrea/multicluster/pkg/client/clientset/versioned"
+)
+
+var (
+	mcPeerKubeconfig = flag.String("mc.peerKubeconfig", "", "Path to the Kubeconfig file of another member cluster of the ClusterSet the test cluster is a member of; the multi-cluster tests are skipped if empty")
+	mcNamespace      = flag.String("mc.namespace", "kube-system", "Namespace of the ClusterSet in the member clusters")
+)
+
+const (
+	// mcSyncTimeout is the maximum time for the ClusterSet to be ready and for the exported
+	// resources to be imported into the member clusters.
+	mcSyncTimeout = 3 * time.Minute
+)
+
+func skipIfNotMulticlusterTest(t *testing.T) {
+	if *mcPeerKubeconfig == "" {
+		t.Skipf("Skipping test as no peer member cluster is provided with the -mc.peerKubeconfig flag")
+	}
+}
+
+// setupPeerCluster returns a TestData for the peer member cluster, with the same test Namespace as
+// the test cluster, as Services are exported and imported across clusters by Namespace sameness.
+func setupPeerCluster(t *testing.T, data *TestData) *TestData {
+	peerData := &TestData{
+		ClusterName:   "peer",
+		testNamespace: data.testNamespace,
+	}
+	require.NoError(t, peerData.CreateClient(*mcPeerKubeconfig), "Error when creating the clients of the peer cluster")
+	t.Logf("Creating '%s' K8s Namespace in the peer cluster", peerData.testNamespace)
+	require.NoError(t, peerData.CreateNamespace(peerData.testNamespace, nil))
+	return peerData
+}
+
+func teardownPeerCluster(t *testing.T, peerData *TestData) {
+	if err := peerData.DeleteNamespace(peerData.testNamespace, defaultTimeout); err != nil {
+		t.Errorf("Error when deleting Namespace '%s' in the peer cluster: %v", peerData.testNamespace, err)
+	}
+}
+
+// waitForClusterSetReady waits for the ClusterSet of the member cluster to be ready, i.e. for the
+// member cluster to be connected to the leader cluster.
+func (data *TestData) waitForClusterSetReady(timeout time.Duration) error {
+	mcClient, err := mcclientset.NewForConfig(data.kubeConfig)
+	if err != nil {
+		return fmt.Errorf("error when creating Multi-cluster client: %v", err)
+	}
+	var clusterSets *mcv1alpha1.ClusterSetList
+	err = wait.PollImmediate(defaultInterval, timeout, func() (bool, error) {
+		clusterSets, err = mcClient.MulticlusterV1alpha1().ClusterSets(*mcNamespace).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		for _, clusterSet := range clusterSets.Items {
+			for _, condition := range clusterSet.Status.Conditions {
+				if condition.Type == mcv1alpha1.ClusterSetReady && condition.Status == corev1.ConditionTrue {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("ClusterSet in Namespace '%s' is not ready: %+v", *mcNamespace, clusterSets)
+	}
+	return err
+}
+
+// exportService exports the Service to the ClusterSet by creating a ServiceExport.
+func (data *TestData) exportService(namespace, name string) error {
+	mcsClient, err := mcsclientset.NewForConfig(data.kubeConfig)
+	if err != nil {
+		return fmt.Errorf("error when creating MCS client: %v", err)
+	}
+	serviceExport := &mcsv1alpha1.ServiceExport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+	_, err = mcsClient.MulticlusterV1alpha1().ServiceExports(namespace).Create(context.TODO(), serviceExport, metav1.CreateOptions{})
+	return err
+}
+
+// waitForImportedService waits for the Service exported by another member cluster of the
+// ClusterSet to be imported, and returns the Service created by the Multi-cluster Controller for
+// the ServiceImport.
+func (data *TestData) waitForImportedService(namespace, name string, timeout time.Duration) (*corev1.Service, error) {
+	mcsClient, err := mcsclientset.NewForConfig(data.kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating MCS client: %v", err)
+	}
+	var svc *corev1.Service
+	err = wait.PollImmediate(defaultInterval, timeout, func() (bool, error) {
+		if _, err := mcsClient.MulticlusterV1alpha1().ServiceImports(namespace).Get(context.TODO(), name, metav1.GetOptions{}); err != nil {
+			return false, nil
+		}
+		svc, err = data.clientset.CoreV1().Services(namespace).Get(context.TODO(), common.AntreaMCSPrefix+name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		return svc.Spec.ClusterIP != "", nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return nil, fmt.Errorf("Service %s/%s has not been imported", namespace, name)
+	}
+	return svc, err
+}
+
+// TestMulticlusterServiceExport exports a Service from a peer member cluster, and checks that the
+// Service is imported into the test cluster and can be accessed from a Pod of the test cluster.
+// Both clusters must be members of the same ClusterSet, with the Multi-cluster Gateway enabled.
+//
+// To run the test, provide the -mc.peerKubeconfig flag. ci/kind/test-mc-e2e-kind.sh creates such
+// a ClusterSet with 2 Kind clusters and runs the test.
+func TestMulticlusterServiceExport(t *testing.T) {
+	skipIfNotMulticlusterTest(t)
+	skipIfHasWindowsNodes(t)
+
+	data, err := setupTest(t)
+	if err != nil {
+		t.Fatalf("Error when setting up test: %v", err)
+	}
+	defer teardownTest(t, data)
+
+	peerData := setupPeerCluster(t, data)
+	defer teardownPeerCluster(t, peerData)
+
+	require.NoError(t, data.waitForClusterSetReady(mcSyncTimeout), "ClusterSet of the test cluster is not ready")
+	require.NoError(t, peerData.waitForClusterSetReady(mcSyncTimeout), "ClusterSet of the peer cluster is not ready")
+
+	serverName := randName("test-server-")
+	require.NoError(t, peerData.createNginxPodOnNode(serverName, peerData.testNamespace, "", false))
+	_, err = peerData.podWaitForIPs(defaultTimeout, serverName, peerData.testNamespace)
+	require.NoError(t, err)
+	_, err = peerData.CreateService(serverName, peerData.testNamespace, 80, 80, map[string]string{"antrea-e2e": serverName}, false, false, corev1.ServiceTypeClusterIP, nil)
+	require.NoError(t, err)
+	t.Logf("Exporting Service '%s' from the peer cluster", serverName)
+	require.NoError(t, peerData.exportService(peerData.testNamespace, serverName))
+
+	importedSvc, err := data.waitForImportedService(data.testNamespace, serverName, mcSyncTimeout)
+	require.NoError(t, err)
+	t.Logf("Service '%s' has been imported as Service '%s'", serverName, importedSvc.Name)
+
+	clientName := randName("test-client-")
+	require.NoError(t, data.createBusyboxPodOnNode(clientName, data.testNamespace, "", false))
+	require.NoError(t, data.podWaitForRunning(defaultTimeout, clientName, data.testNamespace))
+
+	url := fmt.Sprintf("http://%s", net.JoinHostPort(importedSvc.Spec.ClusterIP, "80"))
+	// The cross-cluster tunnel between the Multi-cluster Gateways may take some time to be set up
+	// after the Service has been imported.
+	_, stderr, err := data.runWgetCommandOnBusyboxWithRetry(clientName, data.testNamespace, url, 10)
+	require.NoError(t, err, "Error when accessing imported Service %s: %s", url, stderr)
+}
@@ -38,6 +38,7 @@ var antreaAgentMetrics = []string{
 	"antrea_agent_egress_networkpolicy_rule_count",
 	"antrea_agent_ingress_networkpolicy_rule_count",
 	"antrea_agent_local_pod_count",
+	"antrea_agent_networkpolicy_async_delete_pending_rule_count",
 	"antrea_agent_networkpolicy_count",
 	"antrea_agent_ovs_flow_count",
 	"antrea_agent_ovs_flow_ops_count",
@@ -20,9 +20,14 @@ import (
 	"path"
 	"strings"
 
+	"k8s.io/client-go/tools/clientcmd"
+
 	"antrea.io/antrea/test/e2e/providers/exec"
 )
 
+// kindContextPrefix is the prefix of the names of the Kubeconfig contexts created by Kind.
+const kindContextPrefix = "kind-"
+
 type KindProvider struct {
 	controlPlaneNodeName string
 }
@@ -68,13 +73,35 @@ func (provider *KindProvider) enableKubectlOnControlPlane() error {
 	return nil
 }
 
+// getCurrentKindCluster returns the name of the Kind cluster selected by the current context of the
+// Kubeconfig file, or an empty string if the current context was not created by Kind.
+func (provider *KindProvider) getCurrentKindCluster() string {
+	kubeconfigPath, err := provider.GetKubeconfigPath()
+	if err != nil {
+		return ""
+	}
+	config, err := clientcmd.LoadFromFile(kubeconfigPath)
+	if err != nil {
+		return ""
+	}
+	if !strings.HasPrefix(config.CurrentContext, kindContextPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(config.CurrentContext, kindContextPrefix)
+}
+
 // NewKindProvider returns an implementation of ProviderInterface which is suitable for a
-// Kubernetes test cluster created with Kind.
+// Kubernetes test cluster created with Kind. When there are multiple Kind clusters, e.g. for
+// multi-cluster tests, the cluster selected by the current context of the Kubeconfig file is used.
 // configPath is unused for the kind provider
 func NewKindProvider(configPath string) (ProviderInterface, error) {
 	provider := &KindProvider{}
+	filter := "name=control-plane"
+	if cluster := provider.getCurrentKindCluster(); cluster != "" {
+		filter = fmt.Sprintf("name=%s-control-plane", cluster)
+	}
 	// Run docker ps to fetch control-plane Node name
-	rc, stdout, _, err := exec.RunDockerPsFilterCommand("name=control-plane")
+	rc, stdout, _, err := exec.RunDockerPsFilterCommand(filter)
 	if err != nil || rc != 0 {
 		return nil, fmt.Errorf("Error when running docker ps filter command: %s", stdout)
 	}
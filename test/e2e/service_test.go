@@ -17,6 +17,7 @@ package e2e
 import (
 	"fmt"
 	"net"
+	"strings"
 	"testing"
 	"time"
 
@@ -177,6 +178,55 @@ func (data *TestData) testNodePort(t *testing.T, isWindows bool, clientNamespace
 	}
 }
 
+// TestNodePortWindowsFromNode tests NodePort Services accessed from the Windows Node itself, through the Node IP and the
+// loopback address, when proxyAll is enabled on Windows.
+func TestNodePortWindowsFromNode(t *testing.T) {
+	skipIfNoWindowsNodes(t)
+	skipIfNotIPv4Cluster(t)
+
+	data, err := setupTest(t)
+	if err != nil {
+		t.Fatalf("Error when setting up test: %v", err)
+	}
+	defer teardownTest(t, data)
+
+	proxyAll, err := data.isWindowsProxyAll()
+	require.NoError(t, err)
+	if !proxyAll {
+		t.Skipf("Skipping test because option antreaProxy.proxyAll is not enabled on Windows Nodes")
+	}
+
+	nodeIdx := clusterInfo.windowsNodes[0]
+	svcNode := nodeName(nodeIdx)
+	svcName := "agnhost"
+	svc, cleanup := data.createAgnhostServiceAndBackendPods(t, svcName, data.testNamespace, svcNode, corev1.ServiceTypeNodePort)
+	defer cleanup()
+	ipProtocol := corev1.IPv4Protocol
+	localSvc, err := data.CreateService(svcName+"-local", data.testNamespace, 80, 80, map[string]string{"app": "agnhost", "antrea-e2e": svcName}, false, true, corev1.ServiceTypeNodePort, &ipProtocol)
+	require.NoError(t, err)
+	defer data.deleteService(localSvc.Namespace, localSvc.Name)
+
+	for _, tc := range []struct {
+		name string
+		svc  *corev1.Service
+	}{
+		{name: "ExternalTrafficPolicy:Cluster", svc: svc},
+		{name: "ExternalTrafficPolicy:Local", svc: localSvc},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nodePort := fmt.Sprint(tc.svc.Spec.Ports[0].NodePort)
+			for _, host := range []string{nodeIPv4(nodeIdx), "127.0.0.1"} {
+				url := fmt.Sprintf("http://%s/hostname", net.JoinHostPort(host, nodePort))
+				cmd := fmt.Sprintf("curl.exe --connect-timeout 5 --retry 5 --retry-connrefused -s %s", url)
+				rc, stdout, stderr, err := data.RunCommandOnNode(svcNode, cmd)
+				require.NoError(t, err)
+				require.Equal(t, 0, rc, "Accessing NodePort from Windows Node failed, url: %s, stdout: %s, stderr: %s", url, stdout, stderr)
+				require.Equal(t, svcName, strings.TrimSpace(stdout))
+			}
+		})
+	}
+}
+
 func (data *TestData) createAgnhostServiceAndBackendPods(t *testing.T, name, namespace string, node string, svcType corev1.ServiceType) (*corev1.Service, func()) {
 	ipProtocol := corev1.IPv4Protocol
 	args := []string{"netexec", "--http-port=80", "--udp-port=80"}
@@ -0,0 +1,252 @@
+// Copyright 2023 Antrea Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	maxPodDisruption     = flag.Duration("upgrade.maxPodDisruption", 30*time.Second, "Maximum allowed Pod-to-Pod connectivity disruption when upgrading or downgrading Antrea")
+	maxServiceDisruption = flag.Duration("upgrade.maxServiceDisruption", 30*time.Second, "Maximum allowed Service connectivity disruption when upgrading or downgrading Antrea")
+	maxEgressDisruption  = flag.Duration("upgrade.maxEgressDisruption", 30*time.Second, "Maximum allowed egress connectivity disruption when upgrading or downgrading Antrea")
+)
+
+const (
+	// continuityProbeInterval is the interval between 2 consecutive connectivity probes.
+	continuityProbeInterval = 500 * time.Millisecond
+	// continuitySettleTime is the time during which the probes keep running after the new
+	// version of Antrea has been rolled out, to catch disruptions happening during the
+	// initial reconciliation.
+	continuitySettleTime = 15 * time.Second
+)
+
+func skipIfNotUpgradeDowngradeTest(t *testing.T) {
+	skipIfNotUpgradeTest(t)
+	if *upgradeFromYML == "" {
+		t.Skipf("Skipping test as we are not testing for downgrade")
+	}
+}
+
+// connectivityProber probes connectivity continuously and records the longest period of time
+// during which connectivity was lost.
+type connectivityProber struct {
+	name     string
+	budget   time.Duration
+	probe    func() error
+	interval time.Duration
+
+	mutex sync.Mutex
+	// outageStart is the time of the first failed probe of the ongoing outage, if any.
+	outageStart *time.Time
+	maxOutage   time.Duration
+	numProbes   int
+	numFailures int
+	lastErr     error
+}
+
+func newConnectivityProber(name string, budget time.Duration, probe func() error) *connectivityProber {
+	return &connectivityProber{
+		name:     name,
+		budget:   budget,
+		probe:    probe,
+		interval: continuityProbeInterval,
+	}
+}
+
+func (p *connectivityProber) recordResult(start, end time.Time, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.numProbes++
+	if err != nil {
+		p.numFailures++
+		p.lastErr = err
+		if p.outageStart == nil {
+			p.outageStart = &start
+		}
+		return
+	}
+	if p.outageStart != nil {
+		if outage := end.Sub(*p.outageStart); outage > p.maxOutage {
+			p.maxOutage = outage
+		}
+		p.outageStart = nil
+	}
+}
+
+// run probes connectivity until stopCh is closed. An outage still ongoing when run returns is
+// accounted for until the time run returns.
+func (p *connectivityProber) run(stopCh <-chan struct{}) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		start := time.Now()
+		err := p.probe()
+		p.recordResult(start, time.Now(), err)
+		select {
+		case <-stopCh:
+			p.mutex.Lock()
+			defer p.mutex.Unlock()
+			if p.outageStart != nil {
+				if outage := time.Since(*p.outageStart); outage > p.maxOutage {
+					p.maxOutage = outage
+				}
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *connectivityProber) reset() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.outageStart = nil
+	p.maxOutage = 0
+	p.numProbes = 0
+	p.numFailures = 0
+	p.lastErr = nil
+}
+
+// failureRate returns the ratio of failed probes since the last reset.
+func (p *connectivityProber) failureRate() float64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.numProbes == 0 {
+		return 0
+	}
+	return float64(p.numFailures) / float64(p.numProbes)
+}
+
+func (p *connectivityProber) check(t *testing.T, phase string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	t.Logf("%s connectivity during %s: %d/%d probes failed, max disruption %v (budget %v)", p.name, phase, p.numFailures, p.numProbes, p.maxOutage, p.budget)
+	assert.LessOrEqualf(t, p.maxOutage, p.budget, "%s connectivity disruption during %s exceeded the budget, last error: %v", p.name, phase, p.lastErr)
+}
+
+// runWithConnectivityProbes runs all the probers concurrently while fn is executed, and for
+// continuitySettleTime after it returns. It then checks that no prober exceeded its disruption
+// budget.
+func runWithConnectivityProbes(t *testing.T, phase string, probers []*connectivityProber, fn func() error) {
+	stopCh := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, p := range probers {
+		p.reset()
+		wg.Add(1)
+		go func(p *connectivityProber) {
+			defer wg.Done()
+			p.run(stopCh)
+		}(p)
+	}
+	err := fn()
+	if err == nil {
+		time.Sleep(continuitySettleTime)
+	}
+	close(stopCh)
+	wg.Wait()
+	require.NoError(t, err, "Error during %s", phase)
+	for _, p := range probers {
+		p.check(t, phase)
+	}
+}
+
+// TestUpgradeDowngradeDatapathContinuity upgrades Antrea in place from the version currently
+// deployed to the version provided with -upgrade.toYML, and then downgrades it back to the version
+// provided with -upgrade.fromYML. During both operations, Pod-to-Pod, Service and egress
+// connectivity is probed continuously and the test fails if any of them is disrupted for longer
+// than the configured budget (see the -upgrade.max*Disruption flags).
+//
+// To run the test, provide the -upgrade.toYML and -upgrade.fromYML flags.
+func TestUpgradeDowngradeDatapathContinuity(t *testing.T) {
+	skipIfNotUpgradeDowngradeTest(t)
+	skipIfNumNodesLessThan(t, 2)
+	skipIfHasWindowsNodes(t)
+
+	data, err := setupTest(t)
+	if err != nil {
+		t.Fatalf("Error when setting up test: %v", err)
+	}
+	defer teardownTest(t, data)
+
+	clientNode := nodeName(0)
+	serverNode := nodeName(1)
+	clientName := randName("test-client-")
+	serverName := randName("test-server-")
+	hostServerName := randName("test-host-server-")
+
+	require.NoError(t, data.createBusyboxPodOnNode(clientName, data.testNamespace, clientNode, false))
+	defer deletePodWrapper(t, data, data.testNamespace, clientName)
+	require.NoError(t, data.podWaitForRunning(defaultTimeout, clientName, data.testNamespace))
+
+	require.NoError(t, data.createNginxPodOnNode(serverName, data.testNamespace, serverNode, false))
+	defer deletePodWrapper(t, data, data.testNamespace, serverName)
+	serverIPs, err := data.podWaitForIPs(defaultTimeout, serverName, data.testNamespace)
+	require.NoError(t, err)
+
+	// The hostNetwork server is used to validate egress traffic: it is reached through the Node
+	// IP, which is outside of the Pod network, so the traffic is SNATed by the client Node.
+	require.NoError(t, data.createNginxPodOnNode(hostServerName, data.testNamespace, serverNode, true))
+	defer deletePodWrapper(t, data, data.testNamespace, hostServerName)
+	require.NoError(t, data.podWaitForRunning(defaultTimeout, hostServerName, data.testNamespace))
+
+	svc, err := data.CreateService(serverName, data.testNamespace, 80, 80, map[string]string{"antrea-e2e": serverName}, false, false, corev1.ServiceTypeClusterIP, nil)
+	require.NoError(t, err)
+	defer data.deleteService(svc.Namespace, svc.Name)
+
+	httpProbe := func(host string) func() error {
+		url := fmt.Sprintf("http://%s", net.JoinHostPort(host, "80"))
+		return func() error {
+			_, stderr, err := data.runWgetCommandOnBusyboxWithRetry(clientName, data.testNamespace, url, 1)
+			if err != nil {
+				return fmt.Errorf("error when accessing %s: %v - stderr: %s", url, err, stderr)
+			}
+			return nil
+		}
+	}
+	probers := []*connectivityProber{
+		newConnectivityProber("Pod-to-Pod", *maxPodDisruption, httpProbe(serverIPs.ipStrings[0])),
+		newConnectivityProber("Service", *maxServiceDisruption, httpProbe(svc.Spec.ClusterIP)),
+		newConnectivityProber("Egress", *maxEgressDisruption, httpProbe(nodeIP(1))),
+	}
+
+	// Connectivity must be working before the upgrade, otherwise the measured disruptions are
+	// meaningless.
+	for _, p := range probers {
+		require.NoError(t, p.probe(), "%s connectivity is not working before upgrade", p.name)
+	}
+
+	runWithConnectivityProbes(t, "upgrade", probers, func() error {
+		t.Logf("Upgrading YAML to %s", *upgradeToYML)
+		return data.applyAntreaVersion(t, *upgradeToYML, *controllerOnly)
+	})
+	if t.Failed() {
+		t.FailNow()
+	}
+
+	runWithConnectivityProbes(t, "downgrade", probers, func() error {
+		t.Logf("Downgrading YAML to %s", *upgradeFromYML)
+		return data.applyAntreaVersion(t, *upgradeFromYML, *controllerOnly)
+	})
+}
@@ -16,11 +16,13 @@ package e2e
 
 import (
 	"flag"
+	"fmt"
 	"testing"
 )
 
 var (
 	upgradeToYML   = flag.String("upgrade.toYML", "", "Path to new Antrea manifest (on control-plane Node)")
+	upgradeFromYML = flag.String("upgrade.fromYML", "", "Path to the initially deployed Antrea manifest (on control-plane Node), used to downgrade Antrea after upgrading")
 	pruneAll       = flag.Bool("upgrade.pruneAll", false, "Prune all Antrea resources when upgrading")
 	controllerOnly = flag.Bool("upgrade.controllerOnly", false, "Update antrea-controller only when upgrading")
 )
@@ -84,20 +86,9 @@ func TestUpgrade(t *testing.T) {
 	}
 
 	t.Logf("Upgrading YAML to %s", *upgradeToYML)
-	var extraOptions string
-	if *pruneAll {
-		extraOptions = "--prune -l app=antrea --prune-whitelist=apiregistration.k8s.io/v1/APIService"
-	}
-	// Do not wait for agent rollout as its updateStrategy is set to OnDelete for upgrade test.
-	if err := data.deployAntreaCommon(*upgradeToYML, extraOptions, false); err != nil {
+	if err := data.applyAntreaVersion(t, *upgradeToYML, *controllerOnly); err != nil {
 		t.Fatalf("Error upgrading Antrea: %v", err)
 	}
-	if *controllerOnly == false {
-		t.Logf("Restarting all Antrea DaemonSet Pods")
-		if err := data.RestartAntreaAgentPods(defaultTimeout); err != nil {
-			t.Fatalf("Error when restarting Antrea: %v", err)
-		}
-	}
 
 	data.testPodConnectivitySameNode(t)
 	data.testPodConnectivityDifferentNodes(t)
@@ -116,3 +107,24 @@ func TestUpgrade(t *testing.T) {
 
 	data.testDeletePod(t, podName, nodeName, data.testNamespace, false)
 }
+
+// applyAntreaVersion applies the provided Antrea manifest and, unless controllerOnly is true,
+// restarts all the antrea-agent Pods so that they run the version from the manifest. It is used to
+// upgrade or downgrade Antrea in place.
+func (data *TestData) applyAntreaVersion(t *testing.T, yamlFile string, controllerOnly bool) error {
+	var extraOptions string
+	if *pruneAll {
+		extraOptions = "--prune -l app=antrea --prune-whitelist=apiregistration.k8s.io/v1/APIService"
+	}
+	// Do not wait for agent rollout as its updateStrategy is set to OnDelete for upgrade test.
+	if err := data.deployAntreaCommon(yamlFile, extraOptions, false); err != nil {
+		return err
+	}
+	if !controllerOnly {
+		t.Logf("Restarting all Antrea DaemonSet Pods")
+		if err := data.RestartAntreaAgentPods(defaultTimeout); err != nil {
+			return fmt.Errorf("error when restarting Antrea: %v", err)
+		}
+	}
+	return nil
+}
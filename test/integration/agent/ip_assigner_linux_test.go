@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"antrea.io/antrea/pkg/agent/ipassigner"
@@ -64,6 +65,16 @@ func TestIPAssigner(t *testing.T) {
 	require.NoError(t, err, "Failed to list IP addresses")
 	assert.Equal(t, desiredIPs, actualIPs, "Actual IPs don't match")
 
+	// Duplicate Address Detection should be disabled for the IPv6 address, so that it's usable once assigned.
+	addrList, err := netlink.AddrList(dummyDevice, netlink.FAMILY_V6)
+	require.NoError(t, err, "Failed to list IPv6 addresses")
+	for _, addr := range addrList {
+		if addr.IP.String() == ip3 {
+			assert.NotZero(t, addr.Flags&unix.IFA_F_NODAD, "DAD should be disabled for IPv6 address %s", ip3)
+			assert.Zero(t, addr.Flags&unix.IFA_F_TENTATIVE, "IPv6 address %s should not be tentative", ip3)
+		}
+	}
+
 	newIPAssigner, err := ipassigner.NewIPAssigner(nodeLinkName, dummyDeviceName)
 	require.NoError(t, err, "Initializing new IP assigner failed")
 	assert.Equal(t, sets.New[string](), newIPAssigner.AssignedIPs(), "Assigned IPs don't match")
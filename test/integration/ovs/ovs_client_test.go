@@ -236,6 +236,41 @@ func TestOVSOtherConfig(t *testing.T) {
 	require.Equal(t, map[string]string{"foo3": "bar2"}, gotOtherConfigs, "other_config mismatched")
 }
 
+// TestOVSPatchPort tests connecting two OVS bridges with a pair of patch ports.
+func TestOVSPatchPort(t *testing.T) {
+	data := &testData{}
+	data.setup(t)
+	defer data.teardown(t)
+
+	peerBridge := ovsconfig.NewOVSBridge(bridgeName+"-peer", "netdev", data.ovsdb)
+	require.Nil(t, peerBridge.Create(), "Failed to create peer bridge")
+	defer func() {
+		if err := peerBridge.Delete(); err != nil {
+			t.Errorf("Error when deleting peer bridge: %v", err)
+		}
+	}()
+
+	externalIDs := map[string]interface{}{"k1": "v1"}
+	_, err := data.br.CreatePatchPort("patch-peer", "patch-local", ofPortRequest, externalIDs)
+	require.Nil(t, err, "Failed to create patch port")
+	_, err = peerBridge.CreatePatchPort("patch-local", "patch-peer", 0, nil)
+	require.Nil(t, err, "Failed to create patch port on peer bridge")
+
+	ofPort, err := data.br.GetOFPort("patch-peer", false)
+	require.NoError(t, err, "Failed to get ofport for patch port")
+	assert.Equal(t, ofPortRequest, ofPort, "ofport does not match the requested value for patch port")
+	ofPortRequest++
+	_, err = peerBridge.GetOFPort("patch-local", false)
+	require.NoError(t, err, "Failed to get ofport for patch port on peer bridge")
+
+	options, err := data.br.GetInterfaceOptions("patch-peer")
+	require.Nil(t, err, "Error when getting interface options")
+	assert.Equal(t, "patch-local", options["peer"])
+
+	_, err = data.br.CreatePatchPort("patch-invalid", "patch-local", -1, nil)
+	assert.Error(t, err, "Creating patch port with an invalid ofPortRequest should fail")
+}
+
 func TestTunnelOptionCsum(t *testing.T) {
 	testCases := map[string]struct {
 		initialCsum bool
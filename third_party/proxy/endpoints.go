@@ -36,6 +36,7 @@ Modifies:
 - Remove functions: "newBaseEndpointInfo", "makeEndpointFunc",
   "NewEndpointChangeTracker", "detectStaleConnections"
 - Remove structs: "EndpointChangeTracker", "EndpointsMap"
+- Add Weight to BaseEndpointInfo
 */
 package proxy
 
@@ -81,6 +82,9 @@ type BaseEndpointInfo struct {
 	NodeName string
 	// Zone is the name of the zone this endpoint belongs to
 	Zone string
+	// Weight is the weight of the endpoint relative to the other endpoints of the service.
+	// 0 means the endpoint has no specific weight.
+	Weight uint16
 }
 
 var _ Endpoint = &BaseEndpointInfo{}
@@ -144,6 +148,11 @@ func (info *BaseEndpointInfo) GetZone() string {
 	return info.Zone
 }
 
+// GetWeight returns the Weight for this endpoint.
+func (info *BaseEndpointInfo) GetWeight() uint16 {
+	return info.Weight
+}
+
 func NewBaseEndpointInfo(IP, nodeName, zone string, port int, isLocal bool,
 	ready, serving, terminating bool, zoneHints sets.Set[string]) *BaseEndpointInfo {
 	return &BaseEndpointInfo{
@@ -319,8 +319,14 @@ type ServiceChangeTracker struct {
 	recorder                record.EventRecorder
 	serviceLabelSelector    labels.Selector
 	// skipServices indicates the service list for which we should skip proxying
-	// it will be initialized from antrea-agent.conf
+	// it will be initialized from antrea-agent.conf, and can be updated with UpdateSkipServices
 	skipServices sets.Set[string]
+	// skipServiceSelector selects the services for which we should skip proxying, in addition to skipServices.
+	// A nil selector selects no service.
+	skipServiceSelector labels.Selector
+	// skipServiceProtocols indicates the protocols of the service ports for which we should skip proxying,
+	// so that they can be handled by another proxy, it will be initialized from antrea-agent.conf
+	skipServiceProtocols sets.Set[v1.Protocol]
 }
 
 // NewServiceChangeTracker initializes a ServiceChangeTracker
@@ -329,7 +335,8 @@ func NewServiceChangeTracker(makeServiceInfo makeServicePortFunc,
 	recorder record.EventRecorder,
 	processServiceMapChange processServiceMapChangeFunc,
 	serviceLabelSelector labels.Selector,
-	skipServices []string) *ServiceChangeTracker {
+	skipServices []string,
+	skipServiceProtocols []v1.Protocol) *ServiceChangeTracker {
 	return &ServiceChangeTracker{
 		items:                   make(map[types.NamespacedName]*serviceChange),
 		makeServiceInfo:         makeServiceInfo,
@@ -338,6 +345,7 @@ func NewServiceChangeTracker(makeServiceInfo makeServicePortFunc,
 		processServiceMapChange: processServiceMapChange,
 		serviceLabelSelector:    serviceLabelSelector,
 		skipServices:            sets.New[string](skipServices...),
+		skipServiceProtocols:    sets.New[v1.Protocol](skipServiceProtocols...),
 	}
 }
 
@@ -381,6 +389,33 @@ func (sct *ServiceChangeTracker) Update(previous, current *v1.Service) bool {
 	return len(sct.items) > 0
 }
 
+// UpdateSkipServices replaces the services for which we should skip proxying, and records the changes caused by the
+// replacement for the given services, which should be all the services known to the proxier. It returns true if items
+// changed, otherwise return false.
+func (sct *ServiceChangeTracker) UpdateSkipServices(skipServices sets.Set[string], skipServiceSelector labels.Selector, services []*v1.Service) bool {
+	sct.lock.Lock()
+	defer sct.lock.Unlock()
+
+	// The previous state of the services must be computed with the old skipServices.
+	for _, svc := range services {
+		namespacedName := types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}
+		if _, exists := sct.items[namespacedName]; !exists {
+			sct.items[namespacedName] = &serviceChange{previous: sct.serviceToServiceMap(svc)}
+		}
+	}
+	sct.skipServices = skipServices
+	sct.skipServiceSelector = skipServiceSelector
+	for _, svc := range services {
+		namespacedName := types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}
+		change := sct.items[namespacedName]
+		change.current = sct.serviceToServiceMap(svc)
+		if reflect.DeepEqual(change.previous, change.current) {
+			delete(sct.items, namespacedName)
+		}
+	}
+	return len(sct.items) > 0
+}
+
 // UpdateServiceMapResult is the updated results after applying service changes.
 type UpdateServiceMapResult struct {
 	// HCServiceNodePorts is a map of Service names to node port numbers which indicate the health of that Service on this Node.
@@ -419,7 +454,7 @@ func (sct *ServiceChangeTracker) serviceToServiceMap(service *v1.Service) Servic
 		return nil
 	}
 
-	if utilproxy.ShouldSkipService(service, sct.skipServices, sct.serviceLabelSelector) {
+	if utilproxy.ShouldSkipService(service, sct.skipServices, sct.skipServiceSelector, sct.serviceLabelSelector) {
 		return nil
 	}
 
@@ -432,6 +467,9 @@ func (sct *ServiceChangeTracker) serviceToServiceMap(service *v1.Service) Servic
 	svcName := types.NamespacedName{Namespace: service.Namespace, Name: service.Name}
 	for i := range service.Spec.Ports {
 		servicePort := &service.Spec.Ports[i]
+		if sct.skipServiceProtocols.Has(servicePort.Protocol) {
+			continue
+		}
 		svcPortName := ServicePortName{NamespacedName: svcName, Port: servicePort.Name, Protocol: servicePort.Protocol}
 		baseSvcInfo := sct.newBaseServiceInfo(servicePort, service)
 		if sct.makeServiceInfo != nil {
@@ -33,6 +33,7 @@ Modifies:
 - Remove config.EndpointSliceHandler, config.NodeHandler from Provider interface type
 - Remove NodeHandler, EndpointSliceHandler, Sync() from Provider interface
 - Add Run() to Provider interface
+- Add GetWeight() to Endpoint interface
 */
 
 package proxy
@@ -156,6 +157,9 @@ type Endpoint interface {
 	GetNodeName() string
 	// GetZone returns the zone for the endpoint
 	GetZone() string
+	// GetWeight returns the weight of the endpoint relative to the other endpoints of
+	// the service when load balancing new connections, or 0 if it has no specific weight.
+	GetWeight() uint16
 }
 
 // ServiceEndpoint is used to identify a service and one of its endpoint pair.
@@ -67,7 +67,7 @@ type Resolver interface {
 }
 
 // ShouldSkipService checks if a given service should skip proxying
-func ShouldSkipService(service *v1.Service, skipServices sets.Set[string], serviceLabelSelector labels.Selector) bool {
+func ShouldSkipService(service *v1.Service, skipServices sets.Set[string], skipServiceSelector, serviceLabelSelector labels.Selector) bool {
 	// Skip proxying if the Service label doesn't match the serviceLabelSelector.
 	if !serviceLabelSelector.Matches(labels.Set(service.Labels)) {
 		return true
@@ -82,6 +82,10 @@ func ShouldSkipService(service *v1.Service, skipServices sets.Set[string], servi
 		klog.V(3).Infof("Skipping service %s in namespace %s due to Type=ExternalName", service.Name, service.Namespace)
 		return true
 	}
+	if skipServiceSelector != nil && !skipServiceSelector.Empty() && skipServiceSelector.Matches(labels.Set(service.Labels)) {
+		klog.InfoS("Skipping service because it matches skipServices selector", "service", klog.KObj(service))
+		return true
+	}
 	if skipServices.Len() == 0 {
 		return false
 	}